@@ -12,7 +12,10 @@ import (
 	"syscall"
 
 	"github.com/zarf-dev/zarf/src/cmd"
+	"github.com/zarf-dev/zarf/src/config/lang"
 	"github.com/zarf-dev/zarf/src/pkg/lint"
+	"github.com/zarf-dev/zarf/src/pkg/logger"
+	"github.com/zarf-dev/zarf/src/pkg/message"
 )
 
 //go:embed zarf.schema.json
@@ -29,6 +32,9 @@ func main() {
 			<-signalCh
 			if first {
 				first = false
+				// TODO(mkcp): Remove message on logger release
+				message.Warn(lang.RootInterrupted)
+				logger.Default().Warn(lang.RootInterrupted)
 				cancel()
 				continue
 			}
@@ -7,6 +7,7 @@ package v1alpha1
 import (
 	"fmt"
 	"regexp"
+	"slices"
 )
 
 // VariableType represents a type of a Zarf package variable
@@ -57,6 +58,8 @@ type ZarfPackage struct {
 	Build ZarfBuildData `json:"build,omitempty"`
 	// List of components to deploy in this package.
 	Components []ZarfComponent `json:"components" jsonschema:"minItems=1"`
+	// Groups of mutually exclusive components to choose from on deploy.
+	Groups []ZarfComponentGroup `json:"groups,omitempty"`
 	// Constant template values applied on deploy for K8s resources.
 	Constants []Constant `json:"constants,omitempty"`
 	// Variable template values applied on deploy for K8s resources.
@@ -68,6 +71,16 @@ func (pkg ZarfPackage) IsInitConfig() bool {
 	return pkg.Kind == ZarfInitConfig
 }
 
+// ComponentGroup returns the group that the named component is in.
+func (pkg ZarfPackage) ComponentGroup(componentName string) (ZarfComponentGroup, bool) {
+	for _, group := range pkg.Groups {
+		if slices.Contains(group.Components, componentName) {
+			return group, true
+		}
+	}
+	return ZarfComponentGroup{}, false
+}
+
 // HasImages returns true if one of the components contains an image.
 func (pkg ZarfPackage) HasImages() bool {
 	for _, component := range pkg.Components {
@@ -78,10 +91,10 @@ func (pkg ZarfPackage) HasImages() bool {
 	return false
 }
 
-// IsSBOMAble checks if a package has contents that an SBOM can be created on (i.e. images, files, or data injections).
+// IsSBOMAble checks if a package has contents that an SBOM can be created on (i.e. images, artifacts, files, host artifacts, or data injections).
 func (pkg ZarfPackage) IsSBOMAble() bool {
 	for _, c := range pkg.Components {
-		if len(c.Images) > 0 || len(c.Files) > 0 || len(c.DataInjections) > 0 {
+		if len(c.Images) > 0 || len(c.Artifacts) > 0 || len(c.Files) > 0 || len(c.HostArtifacts) > 0 || len(c.DataInjections) > 0 {
 			return true
 		}
 	}
@@ -164,6 +177,8 @@ type ZarfMetadata struct {
 	Authors string `json:"authors,omitempty" jsonschema:"example=Doug &#60;hello@defenseunicorns.com&#62;&#44; Pepr &#60;hello@defenseunicorns.com&#62;"`
 	// Link to package documentation when online.
 	Documentation string `json:"documentation,omitempty"`
+	// Path to a changelog or release notes file that is embedded in the package, shown by 'zarf package inspect notes' and optionally before deploy.
+	ReleaseNotes string `json:"releaseNotes,omitempty"`
 	// Link to package source code when online.
 	Source string `json:"source,omitempty"`
 	// Name of the distributing entity, organization or individual.
@@ -197,8 +212,34 @@ type ZarfBuildData struct {
 	DifferentialPackageVersion string `json:"differentialPackageVersion,omitempty"`
 	// List of components that were not included in this package due to differential packaging.
 	DifferentialMissing []string `json:"differentialMissing,omitempty"`
+	// Images that are used by more than one component, with the components that use them. Every image is only stored once.
+	SharedImages map[string][]string `json:"sharedImages,omitempty"`
 	// The minimum version of Zarf that does not have breaking package structure changes.
 	LastNonBreakingVersion string `json:"lastNonBreakingVersion,omitempty"`
 	// The flavor of Zarf used to build this package.
 	Flavor string `json:"flavor,omitempty"`
+	// Dependencies of the charts in the package that were vendored at create time, with the digest of their content.
+	ChartDependencies []ZarfChartDependency `json:"chartDependencies,omitempty"`
+	// Images that were pulled from another reference because of a registry override, with the reference they were pulled from.
+	ImageSources map[string]string `json:"imageSources,omitempty"`
+	// The digests of the images in the package, by image reference.
+	ImageDigests map[string]string `json:"imageDigests,omitempty"`
+	// The platforms of multi-platform images that were not included in the package, by image reference.
+	DiscardedPlatforms map[string][]string `json:"discardedPlatforms,omitempty"`
+}
+
+// ZarfChartDependency is a dependency of a chart that was vendored into the package.
+type ZarfChartDependency struct {
+	// The component of the chart.
+	Component string `json:"component"`
+	// The name of the chart.
+	Chart string `json:"chart"`
+	// The name of the dependency.
+	Name string `json:"name"`
+	// The version of the dependency.
+	Version string `json:"version"`
+	// The repository the dependency was resolved from.
+	Repository string `json:"repository,omitempty"`
+	// The sha256 digest of the files of the dependency.
+	Digest string `json:"digest"`
 }
@@ -45,13 +45,29 @@ func TestZarfPackageHasImages(t *testing.T) {
 	require.True(t, pkg.HasImages())
 }
 
+func TestZarfPackageComponentGroup(t *testing.T) {
+	t.Parallel()
+
+	ingress := ZarfComponentGroup{Name: "ingress", Components: []string{"nginx", "istio"}}
+	pkg := ZarfPackage{
+		Groups: []ZarfComponentGroup{ingress},
+	}
+	group, ok := pkg.ComponentGroup("istio")
+	require.True(t, ok)
+	require.Equal(t, ingress, group)
+	_, ok = pkg.ComponentGroup("podinfo")
+	require.False(t, ok)
+}
+
 func TestZarfPackageIsSBOMable(t *testing.T) {
 	t.Parallel()
 
 	tests := []struct {
 		name           string
 		images         []string
+		artifacts      []ZarfArtifact
 		files          []ZarfFile
+		hostArtifacts  []ZarfHostArtifact
 		dataInjections []ZarfDataInjection
 		expected       bool
 	}{
@@ -64,11 +80,21 @@ func TestZarfPackageIsSBOMable(t *testing.T) {
 			images:   []string{""},
 			expected: true,
 		},
+		{
+			name:      "only artifacts",
+			artifacts: []ZarfArtifact{{}},
+			expected:  true,
+		},
 		{
 			name:     "only files",
 			files:    []ZarfFile{{}},
 			expected: true,
 		},
+		{
+			name:          "only host artifacts",
+			hostArtifacts: []ZarfHostArtifact{{}},
+			expected:      true,
+		},
 		{
 			name:           "only data injections",
 			dataInjections: []ZarfDataInjection{{}},
@@ -91,7 +117,9 @@ func TestZarfPackageIsSBOMable(t *testing.T) {
 					{
 						Name:           "without images",
 						Images:         tt.images,
+						Artifacts:      tt.artifacts,
 						Files:          tt.files,
+						HostArtifacts:  tt.hostArtifacts,
 						DataInjections: tt.dataInjections,
 					},
 				},
@@ -5,6 +5,8 @@
 package v1beta1
 
 import (
+	"slices"
+
 	"github.com/invopop/jsonschema"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -29,6 +31,9 @@ type ZarfComponent struct {
 	// Import a component from another Zarf package.
 	Import ZarfComponentImport `json:"import,omitempty"`
 
+	// Namespaces used by the component and how they are created or adopted on package deploy.
+	Namespaces []ZarfNamespace `json:"namespaces,omitempty"`
+
 	// Kubernetes manifests to be included in a generated Helm chart on package deploy.
 	Manifests []ZarfManifest `json:"manifests,omitempty"`
 
@@ -44,6 +49,27 @@ type ZarfComponent struct {
 	// List of OCI images to include in the package.
 	Images []string `json:"images,omitempty"`
 
+	// Registries or image references mapped to the ones the images of this component are pulled from instead on package create. The most specific match wins and the --registry-override flag takes precedence.
+	RegistryOverrides map[string]string `json:"registryOverrides,omitempty"`
+
+	// The platform the images of this component are pulled for on package create, such as windows/amd64 for Windows nodes (defaults to linux and the architecture of the package).
+	ImagePlatform string `json:"imagePlatform,omitempty" jsonschema:"example=windows/amd64,pattern=^(linux|windows)/[a-z0-9]+$"`
+
+	// Images to build from a Dockerfile on package create and include in the package.
+	Builds []ZarfImageBuild `json:"builds,omitempty"`
+
+	// List of OCI artifacts to include in the package and push to the Zarf registry.
+	Artifacts []ZarfArtifact `json:"artifacts,omitempty"`
+
+	// Binaries or other assets to install onto the host the package is deployed from, deploying or removing them requires --allow-host-artifacts.
+	HostArtifacts []ZarfHostArtifact `json:"hostArtifacts,omitempty"`
+
+	// Systemd services to install onto the host the package is deployed from, deploying or removing them requires --allow-host-services.
+	HostServices []ZarfHostService `json:"hostServices,omitempty"`
+
+	// OpenTofu or Terraform modules to plan and apply during package deploy.
+	Tofu []ZarfTofuModule `json:"tofu,omitempty"`
+
 	// List of git repos to include in the package.
 	Repos []string `json:"repos,omitempty"`
 
@@ -52,6 +78,22 @@ type ZarfComponent struct {
 
 	// List of resources to health check after deployment
 	HealthChecks []NamespacedObjectKindReference `json:"healthChecks,omitempty"`
+
+	// Compute and storage resources the workloads of this component request once deployed, checked against the schedulable capacity of the cluster on package deploy.
+	Requirements ZarfComponentRequirements `json:"requirements,omitempty"`
+
+	// Timeout of the Helm installs, health checks and wait actions without a timeout of this component on package deploy (defaults to the deploy --timeout). An explicit --timeout on deploy takes precedence.
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+}
+
+// ZarfComponentRequirements are the resources the workloads of a component request in total, as Kubernetes quantities.
+type ZarfComponentRequirements struct {
+	// CPU requested by the workloads of the component.
+	CPU string `json:"cpu,omitempty" jsonschema:"example=500m,example=2"`
+	// Memory requested by the workloads of the component.
+	Memory string `json:"memory,omitempty" jsonschema:"example=512Mi,example=4Gi"`
+	// Ephemeral storage requested by the workloads of the component on the nodes.
+	Storage string `json:"storage,omitempty" jsonschema:"example=10Gi"`
 }
 
 // NamespacedObjectKindReference is a reference to a specific resource in a namespace using its kind and API version.
@@ -68,13 +110,16 @@ type NamespacedObjectKindReference struct {
 
 // RequiresCluster returns if the component requires a cluster connection to deploy.
 func (c ZarfComponent) RequiresCluster() bool {
-	hasImages := len(c.Images) > 0
+	hasImages := len(c.Images) > 0 || len(c.Builds) > 0
 	hasCharts := len(c.Charts) > 0
 	hasManifests := len(c.Manifests) > 0
 	hasRepos := len(c.Repos) > 0
 	hasDataInjections := len(c.DataInjections) > 0
+	hasArtifacts := len(c.Artifacts) > 0
+	hasKubernetesState := slices.ContainsFunc(c.Tofu, func(m ZarfTofuModule) bool { return m.Backend == TofuBackendKubernetes })
+	hasNamespaces := len(c.Namespaces) > 0
 
-	if hasImages || hasCharts || hasManifests || hasRepos || hasDataInjections {
+	if hasImages || hasCharts || hasManifests || hasRepos || hasDataInjections || hasArtifacts || hasKubernetesState || hasNamespaces {
 		return true
 	}
 
@@ -89,6 +134,28 @@ func (c ZarfComponent) IsOptional() bool {
 	return *c.Optional
 }
 
+// ZarfComponentGroupSelection is how many of the components in a group are deployed.
+type ZarfComponentGroupSelection string
+
+const (
+	// ExactlyOneSelection deploys exactly one of the components in a group.
+	ExactlyOneSelection ZarfComponentGroupSelection = "exactlyOne"
+	// AtMostOneSelection deploys one or none of the components in a group.
+	AtMostOneSelection ZarfComponentGroupSelection = "atMostOne"
+)
+
+// ZarfComponentGroup is a set of mutually exclusive components to choose from on deploy.
+type ZarfComponentGroup struct {
+	// The name of the group.
+	Name string `json:"name" jsonschema:"pattern=^[a-z0-9][a-z0-9\\-]*$"`
+	// Message to include when choosing a component from the group.
+	Description string `json:"description,omitempty"`
+	// The names of the components in the group. A component can only be in one group.
+	Components []string `json:"components" jsonschema:"minItems=2"`
+	// Whether exactly one or at most one of the components is deployed (default exactlyOne). When no component is chosen the component marked as default is deployed.
+	Selection ZarfComponentGroupSelection `json:"selection,omitempty" jsonschema:"enum=exactlyOne,enum=atMostOne"`
+}
+
 // ZarfComponentOnlyTarget filters a component to only show it for a given local OS and cluster.
 type ZarfComponentOnlyTarget struct {
 	// Only deploy component to specified OS.
@@ -97,14 +164,22 @@ type ZarfComponentOnlyTarget struct {
 	Cluster ZarfComponentOnlyCluster `json:"cluster,omitempty"`
 	// Only include this component when a matching '--flavor' is specified on 'zarf package create'.
 	Flavor string `json:"flavor,omitempty"`
+	// Only deploy component when all of the given feature flags are enabled with '--features' on 'zarf package deploy'.
+	Features []string `json:"features,omitempty"`
 }
 
-// ZarfComponentOnlyCluster represents the architecture and K8s cluster distribution to filter on.
+// ZarfComponentOnlyCluster represents the architecture and K8s cluster facts to filter on.
 type ZarfComponentOnlyCluster struct {
 	// Only create and deploy to clusters of the given architecture.
 	Architecture string `json:"architecture,omitempty" jsonschema:"enum=amd64,enum=arm64"`
-	// A list of kubernetes distros this package works with (Reserved for future use).
+	// Only deploy to clusters of one of the given Kubernetes distributions as detected from the cluster nodes.
 	Distros []string `json:"distros,omitempty" jsonschema:"example=k3s,example=eks"`
+	// Only deploy to clusters whose Kubernetes version satisfies the semver constraint.
+	Version string `json:"version,omitempty" jsonschema:"example=>= 1.28,example=~1.30"`
+	// Only deploy to clusters whose nodes run on one of the given cloud providers as reported by the node provider IDs.
+	Providers []string `json:"providers,omitempty" jsonschema:"example=aws,example=gce,example=azure"`
+	// Only deploy to clusters with at least one node that has all of the given labels.
+	NodeLabels map[string]string `json:"nodeLabels,omitempty"`
 }
 
 // ZarfFile defines a file to deploy.
@@ -269,10 +344,12 @@ type ZarfComponentAction struct {
 
 // ZarfComponentActionWait specifies a condition to wait for before continuing
 type ZarfComponentActionWait struct {
-	// Wait for a condition to be met in the cluster before continuing. Only one of cluster or network can be specified.
+	// Wait for a condition to be met in the cluster before continuing. Only one of cluster, network or exec can be specified.
 	Cluster *ZarfComponentActionWaitCluster `json:"cluster,omitempty"`
-	// Wait for a condition to be met on the network before continuing. Only one of cluster or network can be specified.
+	// Wait for a condition to be met on the network from the deploy host before continuing. Only one of cluster, network or exec can be specified.
 	Network *ZarfComponentActionWaitNetwork `json:"network,omitempty"`
+	// Wait for a command run on the deploy host to exit with code 0 before continuing. Only one of cluster, network or exec can be specified.
+	Exec *ZarfComponentActionWaitExec `json:"exec,omitempty"`
 }
 
 // ZarfComponentActionWaitCluster specifies a condition to wait for before continuing
@@ -295,6 +372,14 @@ type ZarfComponentActionWaitNetwork struct {
 	Address string `json:"address" jsonschema:"example=localhost:8080,example=1.1.1.1"`
 	// The HTTP status code to wait for if using http or https.
 	Code int `json:"code,omitempty" jsonschema:"example=200,example=404"`
+	// A regular expression the response body must match if using http or https.
+	Body string `json:"body,omitempty" jsonschema:"example=ready,example=^OK$"`
+}
+
+// ZarfComponentActionWaitExec specifies a command to run until it succeeds before continuing
+type ZarfComponentActionWaitExec struct {
+	// The command to run in the shell of the action, it is run every second until it exits with code 0.
+	Cmd string `json:"cmd" jsonschema:"example=pg_isready -h db.example.com"`
 }
 
 // ZarfContainerTarget defines the destination info for a ZarfData target
@@ -302,11 +387,141 @@ type ZarfContainerTarget struct {
 	// The namespace to target for data injection.
 	Namespace string `json:"namespace"`
 	// The K8s selector to target for data injection.
-	Selector string `json:"selector" jsonschema:"example=app=data-injection"`
+	Selector string `json:"selector,omitempty" jsonschema:"example=app=data-injection"`
 	// The container name to target for data injection.
-	Container string `json:"container"`
-	// The path within the container to copy the data into.
+	Container string `json:"container,omitempty"`
+	// The path within the container to copy the data into, or within the volume when targeting a PersistentVolumeClaim.
 	Path string `json:"path"`
+	// The PersistentVolumeClaim to inject the data into through a pod managed by Zarf, instead of a selector and container.
+	PersistentVolumeClaim string `json:"persistentVolumeClaim,omitempty"`
+	// The image of the pod that injects data into the PersistentVolumeClaim, which must provide sh and tar. Note: the image should be in the images of the component.
+	Image string `json:"image,omitempty"`
+}
+
+// JSONSchemaExtend extends the generated json schema during `zarf internal gen-config-schema`
+func (ZarfContainerTarget) JSONSchemaExtend(schema *jsonschema.Schema) {
+	schema.OneOf = []*jsonschema.Schema{
+		{Required: []string{"selector", "container"}},
+		{Required: []string{"persistentVolumeClaim", "image"}},
+	}
+}
+
+// ZarfArtifact is an OCI artifact, such as a WASM module or policy bundle, that is pulled when the package is created.
+type ZarfArtifact struct {
+	// The reference of the artifact, which is pinned to the digest of the artifact when the package is created.
+	Reference string `json:"reference" jsonschema:"example=ghcr.io/example/modules/filter:1.0.0"`
+	// The artifact type the artifact must have, or the media type of its config for artifacts without an artifact type. Selects the matching manifest when the reference is an index.
+	ArtifactType string `json:"artifactType,omitempty" jsonschema:"example=application/vnd.wasm.config.v0+json"`
+}
+
+// ZarfHostArtifact is a binary or other asset that is installed onto the deploy host and uninstalled when the package is removed.
+type ZarfHostArtifact struct {
+	// The file name the artifact is installed as.
+	Name string `json:"name" jsonschema:"example=k9s"`
+	// The directory the artifact is installed into, defaults to ~/.zarf/bin.
+	InstallPath string `json:"installPath,omitempty" jsonschema:"example=~/.local/bin"`
+	// Determines if the installed artifact should be made executable.
+	Executable bool `json:"executable,omitempty"`
+	// The sources of the artifact, the one matching the operating system and architecture of the deploy host is installed.
+	Sources []ZarfHostArtifactSource `json:"sources" jsonschema:"minItems=1"`
+}
+
+// ZarfHostArtifactSource is the source of a host artifact for a platform.
+type ZarfHostArtifactSource struct {
+	// The operating system the source is installed on, any operating system when empty.
+	OS string `json:"os,omitempty" jsonschema:"enum=linux,enum=darwin,enum=windows"`
+	// The architecture the source is installed on, any architecture when empty.
+	Architecture string `json:"architecture,omitempty" jsonschema:"enum=amd64,enum=arm64"`
+	// Local file path or remote URL to pull into the package.
+	Source string `json:"source"`
+	// Optional SHA256 checksum of the source, verified when the package is created and deployed.
+	Shasum string `json:"shasum,omitempty"`
+	// File to be extracted from a 'source' archive.
+	ExtractPath string `json:"extractPath,omitempty"`
+}
+
+// ZarfHostService is a systemd unit that is installed onto the deploy host and stopped and removed when the package is removed.
+type ZarfHostService struct {
+	// The name of the unit file including its unit type, such as .service or .timer.
+	Name string `json:"name" jsonschema:"example=edge-agent.service,pattern=^[a-zA-Z0-9:_.@-]+\\.(service|socket|timer|path|mount|target)$"`
+	// Local file path or remote URL of the unit file to pull into the package.
+	Source string `json:"source"`
+	// Optional SHA256 checksum of the unit file, verified when the package is created and deployed.
+	Shasum string `json:"shasum,omitempty"`
+	// Enable the unit so that it is started on boot.
+	Enable bool `json:"enable,omitempty"`
+	// Start the unit once it is installed, a running unit is restarted.
+	Start bool `json:"start,omitempty"`
+}
+
+// Tools images are built with.
+const (
+	ImageBuilderBuildkit = "buildkit"
+	ImageBuilderKaniko   = "kaniko"
+)
+
+// ZarfImageBuild is an image that is built from a Dockerfile on package create and included in the package.
+type ZarfImageBuild struct {
+	// The reference the built image is tagged with and deployed as.
+	Image string `json:"image"`
+	// The local path of the build context.
+	Context string `json:"context"`
+	// The path of the Dockerfile relative to the context, defaults to Dockerfile.
+	Dockerfile string `json:"dockerfile,omitempty"`
+	// Build arguments passed to the Dockerfile.
+	BuildArgs map[string]string `json:"buildArgs,omitempty"`
+	// The stage of the Dockerfile to build, defaults to the last stage.
+	Target string `json:"target,omitempty"`
+	// The platforms the image is built for, which must include the platform of the component. With more than one platform the image is included as a manifest list that is pushed intact on deploy.
+	Platforms []string `json:"platforms,omitempty" jsonschema:"example=linux/amd64,example=linux/arm64,pattern=^(linux|windows)/[a-z0-9]+(/[a-z0-9]+)?$"`
+	// The tool the image is built with, defaults to buildkit. buildkit runs buildctl against a buildkitd or daemonless and kaniko runs the kaniko executor in docker.
+	Builder string `json:"builder,omitempty" jsonschema:"enum=buildkit,enum=kaniko"`
+}
+
+// Backends the state of tofu modules can be stored in.
+const (
+	TofuBackendLocal      = "local"
+	TofuBackendKubernetes = "kubernetes"
+)
+
+// ZarfTofuModule is an OpenTofu or Terraform root module that is vendored into the package and applied during package deploy.
+type ZarfTofuModule struct {
+	// The name of the module, which names its state and must be unique within the package.
+	Name string `json:"name" jsonschema:"pattern=^[a-z0-9][a-z0-9\\-]*$"`
+	// The local path of the root module.
+	Source string `json:"source"`
+	// The platforms to vendor provider binaries for, defaults to linux and the architecture of the package.
+	Platforms []string `json:"platforms,omitempty" jsonschema:"example=linux_amd64,example=darwin_arm64,pattern=^[a-z0-9]+_[a-z0-9]+$"`
+	// Input variables of the module, values can use Zarf variables.
+	Variables map[string]string `json:"variables,omitempty"`
+	// Where the state of the module is stored, defaults to local.
+	Backend string `json:"backend,omitempty" jsonschema:"enum=local,enum=kubernetes"`
+	// (local backend only) The path of the state file, defaults to ~/.zarf/tofu/<package>/<name>.tfstate.
+	StatePath string `json:"statePath,omitempty"`
+	// (kubernetes backend only) The namespace of the secret the state is stored in, defaults to zarf.
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// Policies for managing the namespaces of a component.
+const (
+	// NamespacePolicyCreate creates the namespace when it is missing and fails when it exists but was not created by the package.
+	NamespacePolicyCreate = "create"
+	// NamespacePolicyAdopt creates the namespace when it is missing and adopts it when it exists.
+	NamespacePolicyAdopt = "adopt"
+	// NamespacePolicyRequire adopts the namespace and fails when it is missing.
+	NamespacePolicyRequire = "require"
+)
+
+// ZarfNamespace is a namespace used by a component and how it is managed on package deploy.
+type ZarfNamespace struct {
+	// The name of the namespace.
+	Name string `json:"name" jsonschema:"pattern=^[a-z0-9]([-a-z0-9]*[a-z0-9])?$"`
+	// How the namespace is managed, defaults to create. Only namespaces created by the package are deleted when it is removed.
+	Policy string `json:"policy,omitempty" jsonschema:"enum=create,enum=adopt,enum=require"`
+	// Labels to set on the namespace.
+	Labels map[string]string `json:"labels,omitempty"`
+	// Annotations to set on the namespace.
+	Annotations map[string]string `json:"annotations,omitempty"`
 }
 
 // ZarfDataInjection is a data-injection definition.
@@ -317,6 +532,12 @@ type ZarfDataInjection struct {
 	Target ZarfContainerTarget `json:"target"`
 	// Compress the data before transmitting using gzip. Note: this requires support for tar/gzip locally and in the target image.
 	Compress bool `json:"compress,omitempty"`
+	// Only transfer the files that are missing or differ from the files in the target path, resuming interrupted injections. Note: this requires sh, find and sha256sum in the target image.
+	Sync bool `json:"sync,omitempty"`
+	// Verify the checksums of the injected files in the target path after the transfer. Note: this requires sh, find and sha256sum in the target image.
+	Verify bool `json:"verify,omitempty"`
+	// The number of pods to inject the data into at the same time (default 1).
+	Concurrency int `json:"concurrency,omitempty" jsonschema:"minimum=1"`
 }
 
 // ZarfComponentImport structure for including imported Zarf components.
@@ -48,6 +48,8 @@ type ZarfPackage struct {
 	Build ZarfBuildData `json:"build,omitempty"`
 	// List of components to deploy in this package.
 	Components []ZarfComponent `json:"components" jsonschema:"minItems=1"`
+	// Groups of mutually exclusive components to choose from on deploy.
+	Groups []ZarfComponentGroup `json:"groups,omitempty"`
 	// Constant template values applied on deploy for K8s resources.
 	Constants []Constant `json:"constants,omitempty"`
 	// Variable template values applied on deploy for K8s resources.
@@ -69,10 +71,10 @@ func (pkg ZarfPackage) HasImages() bool {
 	return false
 }
 
-// IsSBOMAble checks if a package has contents that an SBOM can be created on (i.e. images, files, or data injections).
+// IsSBOMAble checks if a package has contents that an SBOM can be created on (i.e. images, artifacts, files, host artifacts, or data injections).
 func (pkg ZarfPackage) IsSBOMAble() bool {
 	for _, c := range pkg.Components {
-		if len(c.Images) > 0 || len(c.Files) > 0 || len(c.DataInjections) > 0 {
+		if len(c.Images) > 0 || len(c.Artifacts) > 0 || len(c.Files) > 0 || len(c.HostArtifacts) > 0 || len(c.DataInjections) > 0 {
 			return true
 		}
 	}
@@ -173,6 +175,8 @@ type ZarfBuildData struct {
 	DifferentialPackageVersion string `json:"differentialPackageVersion,omitempty"`
 	// List of components that were not included in this package due to differential packaging.
 	DifferentialMissing []string `json:"differentialMissing,omitempty"`
+	// Images that are used by more than one component, with the components that use them. Every image is only stored once.
+	SharedImages map[string][]string `json:"sharedImages,omitempty"`
 	// The minimum version of Zarf that does not have breaking package structure changes.
 	LastNonBreakingVersion string `json:"lastNonBreakingVersion,omitempty"`
 	// The flavor of Zarf used to build this package.
@@ -63,6 +63,9 @@ func TranslateAlphaPackage(alphaPkg v1alpha1.ZarfPackage) (ZarfPackage, error) {
 
 	for i := range betaPkg.Components {
 		betaPkg.Components[i].Optional = helpers.BoolPtr(!alphaPkg.Components[i].IsRequired())
+		if alphaPkg.Components[i].TimeoutSeconds != 0 {
+			betaPkg.Components[i].Timeout = &v1.Duration{Duration: time.Duration(alphaPkg.Components[i].TimeoutSeconds) * time.Second}
+		}
 		for j := range betaPkg.Components[i].Charts {
 			oldURL := alphaPkg.Components[i].Charts[j].URL
 			if helpers.IsOCIURL(oldURL) {
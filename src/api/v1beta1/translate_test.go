@@ -40,7 +40,8 @@ func TestTranslate(t *testing.T) {
 						Required: helpers.BoolPtr(true),
 					},
 					{
-						Name: "manifests",
+						Name:           "manifests",
+						TimeoutSeconds: 1800,
 						Manifests: []v1alpha1.ZarfManifest{
 							{
 								NoWait: true,
@@ -142,6 +143,7 @@ func TestTranslate(t *testing.T) {
 					{
 						Name:     "manifests",
 						Optional: helpers.BoolPtr(true),
+						Timeout:  &v1.Duration{Duration: 30 * time.Minute},
 						Manifests: []ZarfManifest{
 							{
 								Wait: helpers.BoolPtr(false),
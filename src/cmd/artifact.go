@@ -0,0 +1,212 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package cmd contains the CLI commands for Zarf.
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/defenseunicorns/pkg/helpers/v2"
+	"github.com/spf13/cobra"
+
+	"github.com/zarf-dev/zarf/src/config"
+	"github.com/zarf-dev/zarf/src/config/lang"
+	"github.com/zarf-dev/zarf/src/internal/artifact"
+	"github.com/zarf-dev/zarf/src/pkg/cluster"
+	"github.com/zarf-dev/zarf/src/pkg/logger"
+	"github.com/zarf-dev/zarf/src/pkg/message"
+)
+
+func newRegistryArtifactCommand(ro *registryOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "artifact",
+		Aliases: []string{"oras"},
+		Short:   lang.CmdToolsRegistryArtifactShort,
+		Long:    lang.CmdToolsRegistryArtifactLong,
+	}
+
+	cmd.AddCommand(newRegistryArtifactPushCommand(ro))
+	cmd.AddCommand(newRegistryArtifactPullCommand(ro))
+	cmd.AddCommand(newRegistryArtifactManifestCommand(ro))
+	cmd.AddCommand(newRegistryArtifactCopyCommand(ro))
+
+	return cmd
+}
+
+type registryArtifactPushOptions struct {
+	ro           *registryOptions
+	artifactType string
+	annotations  map[string]string
+}
+
+func newRegistryArtifactPushCommand(ro *registryOptions) *cobra.Command {
+	o := &registryArtifactPushOptions{ro: ro}
+
+	cmd := &cobra.Command{
+		Use:     "push REFERENCE FILE[:MEDIA_TYPE]...",
+		Args:    cobra.MinimumNArgs(2),
+		Short:   lang.CmdToolsRegistryArtifactPushShort,
+		Example: lang.CmdToolsRegistryArtifactPushExample,
+		RunE:    o.run,
+	}
+
+	cmd.Flags().StringVar(&o.artifactType, "artifact-type", artifact.DefaultArtifactType, lang.CmdToolsRegistryArtifactPushFlagType)
+	cmd.Flags().StringToStringVar(&o.annotations, "annotation", nil, lang.CmdToolsRegistryArtifactPushFlagAnnotation)
+
+	return cmd
+}
+
+func (o *registryArtifactPushOptions) run(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	files := []artifact.File{}
+	for _, arg := range args[1:] {
+		files = append(files, artifact.ParseFile(arg))
+	}
+	return withArtifactRegistry(ctx, o.ro, args[0], func(ref string, opts artifact.RegistryOptions) error {
+		pushOpts := artifact.PushOptions{
+			ArtifactType: o.artifactType,
+			Annotations:  o.annotations,
+			Registry:     opts,
+		}
+		_, err := artifact.Push(ctx, ref, files, pushOpts)
+		return err
+	})
+}
+
+type registryArtifactPullOptions struct {
+	ro     *registryOptions
+	output string
+}
+
+func newRegistryArtifactPullCommand(ro *registryOptions) *cobra.Command {
+	o := &registryArtifactPullOptions{ro: ro}
+
+	cmd := &cobra.Command{
+		Use:     "pull REFERENCE",
+		Args:    cobra.ExactArgs(1),
+		Short:   lang.CmdToolsRegistryArtifactPullShort,
+		Example: lang.CmdToolsRegistryArtifactPullExample,
+		RunE:    o.run,
+	}
+
+	cmd.Flags().StringVarP(&o.output, "output", "o", ".", lang.CmdToolsRegistryArtifactPullFlagOutput)
+
+	return cmd
+}
+
+func (o *registryArtifactPullOptions) run(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	return withArtifactRegistry(ctx, o.ro, args[0], func(ref string, opts artifact.RegistryOptions) error {
+		_, err := artifact.Pull(ctx, ref, o.output, opts)
+		return err
+	})
+}
+
+type registryArtifactManifestOptions struct {
+	ro *registryOptions
+}
+
+func newRegistryArtifactManifestCommand(ro *registryOptions) *cobra.Command {
+	o := &registryArtifactManifestOptions{ro: ro}
+
+	cmd := &cobra.Command{
+		Use:     "manifest REFERENCE",
+		Args:    cobra.ExactArgs(1),
+		Short:   lang.CmdToolsRegistryArtifactManifestShort,
+		Example: lang.CmdToolsRegistryArtifactManifestExample,
+		RunE:    o.run,
+	}
+
+	return cmd
+}
+
+func (o *registryArtifactManifestOptions) run(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	return withArtifactRegistry(ctx, o.ro, args[0], func(ref string, opts artifact.RegistryOptions) error {
+		_, b, err := artifact.FetchManifest(ctx, ref, opts)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(message.OutputWriter, string(b))
+		return nil
+	})
+}
+
+type registryArtifactCopyOptions struct {
+	ro *registryOptions
+}
+
+func newRegistryArtifactCopyCommand(ro *registryOptions) *cobra.Command {
+	o := &registryArtifactCopyOptions{ro: ro}
+
+	cmd := &cobra.Command{
+		Use:     "copy SOURCE DESTINATION",
+		Aliases: []string{"cp"},
+		Args:    cobra.ExactArgs(2),
+		Short:   lang.CmdToolsRegistryArtifactCopyShort,
+		Example: lang.CmdToolsRegistryArtifactCopyExample,
+		RunE:    o.run,
+	}
+
+	return cmd
+}
+
+func (o *registryArtifactCopyOptions) run(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	return withArtifactRegistry(ctx, o.ro, args[0], func(src string, srcOpts artifact.RegistryOptions) error {
+		return withArtifactRegistry(ctx, o.ro, args[1], func(dst string, dstOpts artifact.RegistryOptions) error {
+			_, err := artifact.Copy(ctx, src, dst, srcOpts, dstOpts)
+			return err
+		})
+	})
+}
+
+// withArtifactRegistry calls fn with the registry options for ref. References to the registry in the Zarf state are
+// authenticated with the push credentials and, when the registry is in cluster, rewritten to a tunnel to it.
+func withArtifactRegistry(ctx context.Context, ro *registryOptions, ref string, fn func(string, artifact.RegistryOptions) error) error {
+	l := logger.From(ctx)
+	opts := artifact.RegistryOptions{
+		PlainHTTP:             config.CommonOptions.PlainHTTP,
+		InsecureSkipTLSVerify: ro.insecure || config.CommonOptions.InsecureSkipTLSVerify,
+	}
+
+	// Try to connect to a Zarf initialized cluster otherwise use the reference as is.
+	c, err := cluster.NewCluster()
+	if err != nil {
+		return fn(ref, opts)
+	}
+	zarfState, err := c.LoadZarfState(ctx)
+	if err != nil {
+		l.Warn("could not get Zarf state from Kubernetes cluster, continuing without state information", "error", err.Error())
+		return fn(ref, opts)
+	}
+	if !strings.HasPrefix(strings.TrimPrefix(ref, helpers.OCIURLPrefix), zarfState.RegistryInfo.Address) {
+		return fn(ref, opts)
+	}
+
+	opts.Username = zarfState.RegistryInfo.PushUsername
+	opts.Password = zarfState.RegistryInfo.PushPassword
+	opts.CABundle = zarfState.RegistryInfo.TLS.CABundle()
+
+	_, tunnel, err := c.ConnectToZarfRegistryEndpoint(ctx, zarfState.RegistryInfo)
+	if err != nil {
+		return err
+	}
+	if tunnel == nil {
+		return fn(ref, opts)
+	}
+	defer tunnel.Close()
+
+	l.Info("opening a tunnel to the Zarf registry", "local-endpoint", tunnel.Endpoint(), "cluster-address", zarfState.RegistryInfo.Address)
+	givenAddress := fmt.Sprintf("%s/", zarfState.RegistryInfo.Address)
+	tunnelAddress := fmt.Sprintf("%s/", tunnel.Endpoint())
+	ref = strings.Replace(ref, givenAddress, tunnelAddress, 1)
+	// The in cluster registry is only served over HTTPS when it has a certificate.
+	if zarfState.RegistryInfo.TLS == nil {
+		opts.PlainHTTP = true
+	}
+	return tunnel.Wrap(func() error { return fn(ref, opts) })
+}
@@ -8,6 +8,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"strings"
 
@@ -20,7 +21,9 @@ import (
 	"github.com/zarf-dev/zarf/src/config"
 	"github.com/zarf-dev/zarf/src/config/lang"
 	"github.com/zarf-dev/zarf/src/internal/packager/images"
+	"github.com/zarf-dev/zarf/src/internal/registryauth"
 	"github.com/zarf-dev/zarf/src/pkg/cluster"
+	"github.com/zarf-dev/zarf/src/pkg/interactive"
 	"github.com/zarf-dev/zarf/src/pkg/logger"
 	"github.com/zarf-dev/zarf/src/pkg/transform"
 	"github.com/zarf-dev/zarf/src/types"
@@ -82,8 +85,10 @@ func newRegistryCommand() *cobra.Command {
 
 	cmd.AddCommand(newRegistryPruneCommand())
 	cmd.AddCommand(newRegistryLoginCommand())
+	cmd.AddCommand(newRegistryLogoutCommand())
 	cmd.AddCommand(newRegistryCopyCommand())
 	cmd.AddCommand(newRegistryCatalogCommand())
+	cmd.AddCommand(newRegistryArtifactCommand(o))
 
 	// TODO(soltysh): consider splitting craneOptions to be per command
 	cmd.AddCommand(zarfCraneInternalWrapper(craneCmd.NewCmdList, &craneOptions, lang.CmdToolsRegistryListExample, 0))
@@ -102,9 +107,75 @@ func newRegistryCommand() *cobra.Command {
 	return cmd
 }
 
+type registryLoginOptions struct {
+	username         string
+	password         string
+	passwordStdin    bool
+	credentialHelper string
+	skipVerify       bool
+}
+
 func newRegistryLoginCommand() *cobra.Command {
-	cmd := craneCmd.NewCmdAuthLogin()
-	cmd.Example = ""
+	o := &registryLoginOptions{}
+
+	cmd := &cobra.Command{
+		Use:     "login REGISTRY",
+		Args:    cobra.ExactArgs(1),
+		Short:   lang.CmdToolsRegistryLoginShort,
+		Long:    lang.CmdToolsRegistryLoginLong,
+		Example: lang.CmdToolsRegistryLoginExample,
+		RunE:    o.run,
+	}
+
+	cmd.Flags().StringVarP(&o.username, "username", "u", "", lang.CmdToolsRegistryLoginFlagUsername)
+	cmd.Flags().StringVarP(&o.password, "password", "p", "", lang.CmdToolsRegistryLoginFlagPassword)
+	cmd.Flags().BoolVar(&o.passwordStdin, "password-stdin", false, lang.CmdToolsRegistryLoginFlagPasswordStdin)
+	cmd.Flags().StringVar(&o.credentialHelper, "credential-helper", "", lang.CmdToolsRegistryLoginFlagCredentialHelper)
+	cmd.Flags().BoolVar(&o.skipVerify, "skip-verify", false, lang.CmdToolsRegistryLoginFlagSkipVerify)
+	cmd.MarkFlagsMutuallyExclusive("password", "password-stdin")
+	_ = cmd.MarkFlagRequired("username")
+
+	return cmd
+}
+
+func (o *registryLoginOptions) run(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	password := o.password
+	if o.passwordStdin {
+		b, err := io.ReadAll(cmd.InOrStdin())
+		if err != nil {
+			return err
+		}
+		password = strings.TrimRight(string(b), "\r\n")
+	}
+	if password == "" {
+		var err error
+		password, err = interactive.PromptRegistryPassword(args[0])
+		if err != nil {
+			return err
+		}
+	}
+	opts := registryauth.LoginOptions{
+		Username:              o.username,
+		Password:              password,
+		CredentialHelper:      o.credentialHelper,
+		SkipVerify:            o.skipVerify,
+		PlainHTTP:             config.CommonOptions.PlainHTTP,
+		InsecureSkipTLSVerify: config.CommonOptions.InsecureSkipTLSVerify,
+	}
+	return registryauth.Login(ctx, args[0], opts)
+}
+
+func newRegistryLogoutCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "logout REGISTRY",
+		Args:    cobra.ExactArgs(1),
+		Short:   lang.CmdToolsRegistryLogoutShort,
+		Example: lang.CmdToolsRegistryLogoutExample,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return registryauth.Logout(cmd.Context(), args[0], "")
+		},
+	}
 	return cmd
 }
 
@@ -162,7 +233,7 @@ func (o *registryCatalogOptions) run(cmd *cobra.Command, args []string) error {
 
 	// Add the correct authentication to the crane command options
 	authOption := images.WithPullAuth(zarfState.RegistryInfo)
-	o.craneOptions = append(o.craneOptions, authOption)
+	o.craneOptions = append(o.craneOptions, authOption, images.WithRegistryCA(zarfState.RegistryInfo))
 
 	if tunnel != nil {
 		defer tunnel.Close()
@@ -227,7 +298,7 @@ func (o *registryPruneOptions) run(cmd *cobra.Command, _ []string) error {
 
 func doPruneImagesForPackages(ctx context.Context, zarfState *types.ZarfState, zarfPackages []types.DeployedPackage, registryEndpoint string) error {
 	l := logger.From(ctx)
-	authOption := images.WithPushAuth(zarfState.RegistryInfo)
+	craneOptions := []crane.Option{images.WithPushAuth(zarfState.RegistryInfo), images.WithRegistryCA(zarfState.RegistryInfo)}
 
 	l.Info("finding images to prune")
 
@@ -243,12 +314,12 @@ func doPruneImagesForPackages(ctx context.Context, zarfState *types.ZarfState, z
 			if _, ok := deployedComponents[component.Name]; ok {
 				for _, image := range component.Images {
 					// We use the no checksum image since it will always exist and will share the same digest with other tags
-					transformedImageNoCheck, err := transform.ImageTransformHostWithoutChecksum(registryEndpoint, image)
+					transformedImageNoCheck, err := transform.ImageTransformHostWithoutChecksum(registryEndpoint, image, zarfState.RegistryInfo.ImageRules...)
 					if err != nil {
 						return err
 					}
 
-					digest, err := crane.Digest(transformedImageNoCheck, authOption)
+					digest, err := crane.Digest(transformedImageNoCheck, craneOptions...)
 					if err != nil {
 						return err
 					}
@@ -259,20 +330,20 @@ func doPruneImagesForPackages(ctx context.Context, zarfState *types.ZarfState, z
 	}
 
 	// Find which images and tags are in the registry currently
-	imageCatalog, err := crane.Catalog(registryEndpoint, authOption)
+	imageCatalog, err := crane.Catalog(registryEndpoint, craneOptions...)
 	if err != nil {
 		return err
 	}
 	referenceToDigest := map[string]string{}
 	for _, image := range imageCatalog {
 		imageRef := fmt.Sprintf("%s/%s", registryEndpoint, image)
-		tags, err := crane.ListTags(imageRef, authOption)
+		tags, err := crane.ListTags(imageRef, craneOptions...)
 		if err != nil {
 			return err
 		}
 		for _, tag := range tags {
 			taggedImageRef := fmt.Sprintf("%s:%s", imageRef, tag)
-			digest, err := crane.Digest(taggedImageRef, authOption)
+			digest, err := crane.Digest(taggedImageRef, craneOptions...)
 			if err != nil {
 				return err
 			}
@@ -305,6 +376,9 @@ func doPruneImagesForPackages(ctx context.Context, zarfState *types.ZarfState, z
 
 	confirm := config.CommonOptions.Confirm
 	if !confirm {
+		if err := interactive.CheckPrompt("confirmation to prune images, confirm it with --confirm"); err != nil {
+			return err
+		}
 		prompt := &survey.Confirm{
 			Message: "continue with image prune?",
 		}
@@ -317,7 +391,7 @@ func doPruneImagesForPackages(ctx context.Context, zarfState *types.ZarfState, z
 
 		// Delete the digest references that are to be pruned
 		for digestRef := range imageDigestsToPrune {
-			err = crane.Delete(digestRef, authOption)
+			err = crane.Delete(digestRef, craneOptions...)
 			if err != nil {
 				return err
 			}
@@ -369,7 +443,7 @@ func zarfCraneInternalWrapper(commandToWrap func(*[]crane.Option) *cobra.Command
 
 		// Add the correct authentication to the crane command options
 		authOption := images.WithPushAuth(zarfState.RegistryInfo)
-		*cranePlatformOptions = append(*cranePlatformOptions, authOption)
+		*cranePlatformOptions = append(*cranePlatformOptions, authOption, images.WithRegistryCA(zarfState.RegistryInfo))
 
 		if tunnel != nil {
 			l.Info("opening a tunnel to the Zarf registry", "local-endpoint", tunnel.Endpoint(), "cluster-address", zarfState.RegistryInfo.Address)
@@ -22,7 +22,12 @@ import (
 	"github.com/zarf-dev/zarf/src/api/v1alpha1"
 	"github.com/zarf-dev/zarf/src/config"
 	"github.com/zarf-dev/zarf/src/config/lang"
+	"github.com/zarf-dev/zarf/src/internal/packager/helm"
+	"github.com/zarf-dev/zarf/src/internal/packager2"
+	"github.com/zarf-dev/zarf/src/internal/packager2/filters"
 	layout2 "github.com/zarf-dev/zarf/src/internal/packager2/layout"
+	"github.com/zarf-dev/zarf/src/pkg/cluster"
+	"github.com/zarf-dev/zarf/src/pkg/interactive"
 	"github.com/zarf-dev/zarf/src/pkg/lint"
 	"github.com/zarf-dev/zarf/src/pkg/logger"
 	"github.com/zarf-dev/zarf/src/pkg/message"
@@ -30,6 +35,7 @@ import (
 	"github.com/zarf-dev/zarf/src/pkg/transform"
 	"github.com/zarf-dev/zarf/src/pkg/utils"
 	"github.com/zarf-dev/zarf/src/types"
+	"sigs.k8s.io/yaml"
 )
 
 var defaultRegistry = fmt.Sprintf("%s:%d", helpers.IPV4Localhost, types.ZarfInClusterContainerRegistryNodePort)
@@ -51,6 +57,7 @@ func newDevCommand() *cobra.Command {
 	cmd.AddCommand(newDevFindImagesCommand(v))
 	cmd.AddCommand(newDevGenerateConfigCommand())
 	cmd.AddCommand(newDevLintCommand(v))
+	cmd.AddCommand(newDevRBACCommand(v))
 
 	return cmd
 }
@@ -68,6 +75,7 @@ func newDevInspectCommand(v *viper.Viper) *cobra.Command {
 type devInspectDefinitionOptions struct {
 	flavor       string
 	setVariables map[string]string
+	overlays     []string
 }
 
 func newDevInspectDefinitionCommand(v *viper.Viper) *cobra.Command {
@@ -83,6 +91,7 @@ func newDevInspectDefinitionCommand(v *viper.Viper) *cobra.Command {
 
 	cmd.Flags().StringVarP(&o.flavor, "flavor", "f", "", lang.CmdPackageCreateFlagFlavor)
 	cmd.Flags().StringToStringVar(&o.setVariables, "set", v.GetStringMapString(VPkgCreateSet), lang.CmdPackageCreateFlagSet)
+	cmd.Flags().StringSliceVar(&o.overlays, "overlay", v.GetStringSlice(VPkgCreateOverlays), lang.CmdPackageCreateFlagOverlay)
 
 	return cmd
 }
@@ -92,7 +101,7 @@ func (o *devInspectDefinitionOptions) run(cmd *cobra.Command, args []string) err
 	v := getViper()
 	o.setVariables = helpers.TransformAndMergeMap(
 		v.GetStringMapString(VPkgCreateSet), o.setVariables, strings.ToUpper)
-	pkg, err := layout2.LoadPackage(ctx, setBaseDirectory(args), o.flavor, o.setVariables)
+	pkg, err := layout2.LoadPackage(ctx, setBaseDirectory(args), o.flavor, o.setVariables, o.overlays)
 	if err != nil {
 		return err
 	}
@@ -136,6 +145,14 @@ func newDevDeployCommand(v *viper.Viper) *cobra.Command {
 
 	cmd.Flags().IntVar(&pkgConfig.PkgOpts.Retries, "retries", v.GetInt(VPkgRetries), lang.CmdPackageFlagRetries)
 	cmd.Flags().StringVar(&pkgConfig.PkgOpts.OptionalComponents, "components", v.GetString(VPkgDeployComponents), lang.CmdPackageDeployFlagComponents)
+	cmd.Flags().StringVar(&pkgConfig.DeployOpts.ServiceAccount, "service-account", v.GetString(VPkgDeployServiceAccount), lang.CmdPackageDeployFlagServiceAccount)
+	cmd.Flags().StringToStringVar(&pkgConfig.DeployOpts.Labels, "labels", v.GetStringMapString(VPkgDeployLabels), lang.CmdPackageDeployFlagLabels)
+	cmd.Flags().StringToStringVar(&pkgConfig.DeployOpts.Annotations, "annotations", v.GetStringMapString(VPkgDeployAnnotations), lang.CmdPackageDeployFlagAnnotations)
+	cmd.Flags().StringToStringVar(&pkgConfig.DeployOpts.NodeSelector, "node-selector", v.GetStringMapString(VPkgDeployNodeSelector), lang.CmdPackageDeployFlagNodeSelector)
+	cmd.Flags().StringSliceVar(&pkgConfig.DeployOpts.Tolerations, "tolerations", v.GetStringSlice(VPkgDeployTolerations), lang.CmdPackageDeployFlagTolerations)
+	cmd.Flags().StringToStringVar(&pkgConfig.DeployOpts.HostAliases, "host-aliases", v.GetStringMapString(VPkgDeployHostAliases), lang.CmdPackageDeployFlagHostAliases)
+	cmd.Flags().BoolVar(&pkgConfig.DeployOpts.AllowHostServices, "allow-host-services", false, lang.CmdPackageDeployFlagAllowHostServices)
+	cmd.Flags().BoolVar(&pkgConfig.DeployOpts.AllowHostArtifacts, "allow-host-artifacts", false, lang.CmdPackageDeployFlagAllowHostArtifacts)
 
 	cmd.Flags().BoolVar(&pkgConfig.CreateOpts.NoYOLO, "no-yolo", v.GetBool(VDevDeployNoYolo), lang.CmdDevDeployFlagNoYolo)
 
@@ -144,6 +161,14 @@ func newDevDeployCommand(v *viper.Viper) *cobra.Command {
 
 func (o *devDeployOptions) run(cmd *cobra.Command, args []string) error {
 	ctx := cmd.Context()
+	if pkgConfig.DeployOpts.ServiceAccount != "" {
+		if _, _, err := cluster.ParseServiceAccount(pkgConfig.DeployOpts.ServiceAccount); err != nil {
+			return err
+		}
+	}
+	if _, err := helm.ParseTolerations(pkgConfig.DeployOpts.Tolerations); err != nil {
+		return err
+	}
 	pkgConfig.CreateOpts.BaseDir = setBaseDirectory(args)
 
 	v := getViper()
@@ -152,6 +177,7 @@ func (o *devDeployOptions) run(cmd *cobra.Command, args []string) error {
 
 	pkgConfig.PkgOpts.SetVariables = helpers.TransformAndMergeMap(
 		v.GetStringMapString(VPkgDeploySet), pkgConfig.PkgOpts.SetVariables, strings.ToUpper)
+	pkgConfig.DeployOpts.TimeoutOverride = isTimeoutOverridden(cmd)
 
 	pkgClient, err := packager.New(&pkgConfig, packager.WithContext(ctx))
 	if err != nil {
@@ -177,19 +203,19 @@ func newDevGenerateCommand() *cobra.Command {
 	o := &devGenerateOptions{}
 
 	cmd := &cobra.Command{
-		Use:     "generate NAME",
+		Use:     "generate NAME [ CHART | DIRECTORY | COMPOSE_FILE ]",
 		Aliases: []string{"g"},
-		Args:    cobra.ExactArgs(1),
+		Args:    cobra.RangeArgs(1, 2),
 		Short:   lang.CmdDevGenerateShort,
+		Long:    lang.CmdDevGenerateLong,
 		Example: lang.CmdDevGenerateExample,
 		RunE:    o.run,
 	}
 
 	cmd.Flags().StringVar(&pkgConfig.GenerateOpts.URL, "url", "", "URL to the source git repository")
-	cmd.MarkFlagRequired("url")
 	cmd.Flags().StringVar(&pkgConfig.GenerateOpts.Version, "version", "", "The Version of the chart to use")
-	cmd.MarkFlagRequired("version")
 	cmd.Flags().StringVar(&pkgConfig.GenerateOpts.GitPath, "gitPath", "", "Relative path to the chart in the git repository")
+	cmd.Flags().StringVar(&pkgConfig.GenerateOpts.Namespace, "from-namespace", "", lang.CmdDevGenerateFlagFromNamespace)
 	cmd.Flags().StringVar(&pkgConfig.GenerateOpts.Output, "output-directory", "", "Output directory for the generated zarf.yaml")
 	cmd.MarkFlagRequired("output-directory")
 	cmd.Flags().StringVar(&pkgConfig.FindImagesOpts.KubeVersionOverride, "kube-version", "", lang.CmdDevFlagKubeVersion)
@@ -199,6 +225,18 @@ func newDevGenerateCommand() *cobra.Command {
 
 func (o *devGenerateOptions) run(cmd *cobra.Command, args []string) error {
 	pkgConfig.GenerateOpts.Name = args[0]
+	if pkgConfig.GenerateOpts.Namespace != "" {
+		if len(args) > 1 || pkgConfig.GenerateOpts.URL != "" {
+			return errors.New(lang.CmdDevGenerateErrNamespaceAndSource)
+		}
+	} else if len(args) > 1 {
+		if pkgConfig.GenerateOpts.URL != "" {
+			return errors.New(lang.CmdDevGenerateErrSourceAndURL)
+		}
+		pkgConfig.GenerateOpts.Path = args[1]
+	} else if pkgConfig.GenerateOpts.URL == "" || pkgConfig.GenerateOpts.Version == "" {
+		return errors.New(lang.CmdDevGenerateErrURLAndVersion)
+	}
 
 	pkgConfig.CreateOpts.BaseDir = "."
 	pkgConfig.FindImagesOpts.RepoHelmChartPath = pkgConfig.GenerateOpts.GitPath
@@ -261,6 +299,9 @@ func (o *devPatchGitOptions) run(_ *cobra.Command, args []string) error {
 	pterm.Println(dmp.DiffPrettyText(diffs))
 
 	// Ask the user before this destructive action
+	if err := interactive.CheckPrompt(fmt.Sprintf("confirmation to overwrite %s", fileName)); err != nil {
+		return err
+	}
 	confirm := false
 	prompt := &survey.Confirm{
 		Message: fmt.Sprintf(lang.CmdDevPatchGitOverwritePrompt, fileName),
@@ -524,3 +565,75 @@ func (o *devLintOptions) run(cmd *cobra.Command, args []string) error {
 	}
 	return nil
 }
+
+type devRBACOptions struct {
+	publicKeyPath           string
+	skipSignatureValidation bool
+	setVariables            map[string]string
+	kubeVersion             string
+	registryURL             string
+	serviceAccount          string
+	outputWriter            io.Writer
+}
+
+func newDevRBACCommand(v *viper.Viper) *cobra.Command {
+	o := &devRBACOptions{
+		outputWriter: message.OutputWriter,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "rbac [ PACKAGE_SOURCE ]",
+		Args:  cobra.MaximumNArgs(1),
+		Short: lang.CmdDevRBACShort,
+		Long:  lang.CmdDevRBACLong,
+		RunE:  o.run,
+	}
+
+	cmd.Flags().StringVarP(&o.publicKeyPath, "key", "k", v.GetString(VPkgPublicKey), lang.CmdPackageFlagFlagPublicKey)
+	cmd.Flags().BoolVar(&o.skipSignatureValidation, "skip-signature-validation", false, lang.CmdPackageFlagSkipSignatureValidation)
+	cmd.Flags().StringToStringVar(&o.setVariables, "set", v.GetStringMapString(VPkgDeploySet), lang.CmdPackageDeployFlagSet)
+	cmd.Flags().StringVar(&o.kubeVersion, "kube-version", "", lang.CmdDevFlagKubeVersion)
+	cmd.Flags().StringVar(&o.registryURL, "registry-url", "", lang.CmdDevFlagRegistry)
+	cmd.Flags().StringVar(&o.serviceAccount, "service-account", v.GetString(VPkgDeployServiceAccount), lang.CmdDevRBACFlagServiceAccount)
+
+	return cmd
+}
+
+func (o *devRBACOptions) run(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	src, err := choosePackage(ctx, args)
+	if err != nil {
+		return err
+	}
+	loadOpt := packager2.LoadOptions{
+		Source:                  src,
+		SkipSignatureValidation: o.skipSignatureValidation,
+		Filter:                  filters.Empty(),
+		PublicKeyPath:           o.publicKeyPath,
+	}
+	pkgLayout, err := packager2.LoadPackage(ctx, loadOpt)
+	if err != nil {
+		return err
+	}
+	defer pkgLayout.Cleanup()
+
+	opt := packager2.RBACOptions{
+		SetVariables:   helpers.TransformAndMergeMap(getViper().GetStringMapString(VPkgDeploySet), o.setVariables, strings.ToUpper),
+		KubeVersion:    o.kubeVersion,
+		RegistryURL:    o.registryURL,
+		ServiceAccount: o.serviceAccount,
+	}
+	objs, err := packager2.GenerateRBAC(ctx, pkgLayout, opt)
+	if err != nil {
+		return err
+	}
+	for _, obj := range objs {
+		b, err := yaml.Marshal(obj)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(o.outputWriter, "---\n%s", b)
+	}
+	return nil
+}
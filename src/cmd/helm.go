@@ -21,6 +21,10 @@ var helmVersion string
 func newHelmCommand() *cobra.Command {
 	actionConfig := new(action.Configuration)
 
+	if IsVendorCmd(os.Args, []string{"helm", "h"}) && stripToolVersionArgs("helm", helmVersion) {
+		return newToolVersionCommand("helm", []string{"h"})
+	}
+
 	// Truncate Helm's arguments so that it thinks its all alone
 	helmArgs := []string{}
 	if len(os.Args) > 2 {
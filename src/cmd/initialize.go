@@ -10,6 +10,7 @@ import (
 	"fmt"
 	"path"
 	"path/filepath"
+	"slices"
 	"strings"
 
 	"github.com/AlecAivazis/survey/v2"
@@ -17,10 +18,15 @@ import (
 	"github.com/defenseunicorns/pkg/oci"
 	"github.com/zarf-dev/zarf/src/config"
 	"github.com/zarf-dev/zarf/src/config/lang"
+	"github.com/zarf-dev/zarf/src/internal/packager/helm"
+	"github.com/zarf-dev/zarf/src/internal/packager/images"
+	"github.com/zarf-dev/zarf/src/pkg/cluster"
+	"github.com/zarf-dev/zarf/src/pkg/interactive"
 	"github.com/zarf-dev/zarf/src/pkg/logger"
 	"github.com/zarf-dev/zarf/src/pkg/message"
 	"github.com/zarf-dev/zarf/src/pkg/packager"
 	"github.com/zarf-dev/zarf/src/pkg/packager/sources"
+	"github.com/zarf-dev/zarf/src/pkg/transform"
 	"github.com/zarf-dev/zarf/src/pkg/utils"
 	"github.com/zarf-dev/zarf/src/pkg/zoci"
 	"github.com/zarf-dev/zarf/src/types"
@@ -48,6 +54,9 @@ func newInitCommand() *cobra.Command {
 	// NOTE: these are not in setDefaults so that zarf tools update-creds does not erroneously update values back to the default
 	v.SetDefault(VInitGitPushUser, types.ZarfGitPushUser)
 	v.SetDefault(VInitRegistryPushUser, types.ZarfRegistryPushUser)
+	v.SetDefault(VInitSeedStrategy, cluster.SeedStrategyNodePort)
+	v.SetDefault(VInitRegistryStorage, types.RegistryStoragePVC)
+	v.SetDefault(VInitRegistryAuth, types.RegistryAuthHtpasswd)
 
 	// Init package set variable flags
 	cmd.Flags().StringToStringVar(&pkgConfig.PkgOpts.SetVariables, "set", v.GetStringMapString(VPkgDeploySet), lang.CmdInitFlagSet)
@@ -56,6 +65,7 @@ func newInitCommand() *cobra.Command {
 	cmd.Flags().BoolVar(&config.CommonOptions.Confirm, "confirm", false, lang.CmdInitFlagConfirm)
 	cmd.Flags().StringVar(&pkgConfig.PkgOpts.OptionalComponents, "components", v.GetString(VInitComponents), lang.CmdInitFlagComponents)
 	cmd.Flags().StringVar(&pkgConfig.InitOpts.StorageClass, "storage-class", v.GetString(VInitStorageClass), lang.CmdInitFlagStorageClass)
+	cmd.Flags().StringVar(&pkgConfig.InitOpts.PullSecretSelector, "pull-secret-selector", v.GetString(VInitPullSecretSelector), lang.CmdInitFlagPullSecretSelector)
 
 	// Flags for using an external Git server
 	cmd.Flags().StringVar(&pkgConfig.InitOpts.GitServer.Address, "git-url", v.GetString(VInitGitURL), lang.CmdInitFlagGitURL)
@@ -72,6 +82,65 @@ func newInitCommand() *cobra.Command {
 	cmd.Flags().StringVar(&pkgConfig.InitOpts.RegistryInfo.PullUsername, "registry-pull-username", v.GetString(VInitRegistryPullUser), lang.CmdInitFlagRegPullUser)
 	cmd.Flags().StringVar(&pkgConfig.InitOpts.RegistryInfo.PullPassword, "registry-pull-password", v.GetString(VInitRegistryPullPass), lang.CmdInitFlagRegPullPass)
 	cmd.Flags().StringVar(&pkgConfig.InitOpts.RegistryInfo.Secret, "registry-secret", v.GetString(VInitRegistrySecret), lang.CmdInitFlagRegSecret)
+	cmd.Flags().StringVar(&pkgConfig.InitOpts.RegistryInfo.ProjectAPI, "registry-project-api", v.GetString(VInitRegistryProjectAPI), lang.CmdInitFlagRegProjectAPI)
+	cmd.Flags().StringVar(&pkgConfig.InitOpts.RegistryInfo.ProjectUsername, "registry-project-username", v.GetString(VInitRegistryProjectUser), lang.CmdInitFlagRegProjectUser)
+	cmd.Flags().StringVar(&pkgConfig.InitOpts.RegistryInfo.ProjectPassword, "registry-project-password", v.GetString(VInitRegistryProjectPass), lang.CmdInitFlagRegProjectPass)
+	cmd.Flags().BoolVar(&pkgConfig.InitOpts.RegistryInfo.ScopedTokens, "registry-scoped-tokens", v.GetBool(VInitRegistryScopedTokens), lang.CmdInitFlagRegScopedToken)
+
+	// Flags for the authentication of the internal registry
+	cmd.Flags().StringVar(&pkgConfig.InitOpts.RegistryInfo.Auth, "registry-auth", v.GetString(VInitRegistryAuth), lang.CmdInitFlagRegAuth)
+	cmd.Flags().StringSliceVar(&pkgConfig.InitOpts.RegistryRobotNamespaces, "registry-robot-namespaces", v.GetStringSlice(VInitRegistryRobotNamespaces), lang.CmdInitFlagRegRobotNS)
+	cmd.Flags().StringVar(&pkgConfig.InitOpts.RegistryTokenAuth.Realm, "registry-token-realm", v.GetString(VInitRegistryTokenRealm), lang.CmdInitFlagRegTokenRealm)
+	cmd.Flags().StringVar(&pkgConfig.InitOpts.RegistryTokenAuth.Service, "registry-token-service", v.GetString(VInitRegistryTokenService), lang.CmdInitFlagRegTokenService)
+	cmd.Flags().StringVar(&pkgConfig.InitOpts.RegistryTokenAuth.Issuer, "registry-token-issuer", v.GetString(VInitRegistryTokenIssuer), lang.CmdInitFlagRegTokenIssuer)
+	cmd.Flags().StringVar(&pkgConfig.InitOpts.RegistryTokenAuth.CertFile, "registry-token-cert", v.GetString(VInitRegistryTokenCert), lang.CmdInitFlagRegTokenCert)
+
+	// Flags for serving the internal registry and git server with TLS
+	cmd.Flags().StringVar(&pkgConfig.InitOpts.RegistryTLS.CertFile, "registry-tls-cert", v.GetString(VInitRegistryTLSCert), lang.CmdInitFlagRegTLSCert)
+	cmd.Flags().StringVar(&pkgConfig.InitOpts.RegistryTLS.KeyFile, "registry-tls-key", v.GetString(VInitRegistryTLSKey), lang.CmdInitFlagRegTLSKey)
+	cmd.Flags().StringVar(&pkgConfig.InitOpts.RegistryTLS.CAFile, "registry-tls-ca", v.GetString(VInitRegistryTLSCA), lang.CmdInitFlagRegTLSCA)
+	cmd.Flags().StringVar(&pkgConfig.InitOpts.RegistryTLS.Issuer, "registry-tls-issuer", v.GetString(VInitRegistryTLSIssuer), lang.CmdInitFlagRegTLSIssuer)
+	cmd.Flags().StringVar(&pkgConfig.InitOpts.GitServerTLS.CertFile, "git-tls-cert", v.GetString(VInitGitTLSCert), lang.CmdInitFlagGitTLSCert)
+	cmd.Flags().StringVar(&pkgConfig.InitOpts.GitServerTLS.KeyFile, "git-tls-key", v.GetString(VInitGitTLSKey), lang.CmdInitFlagGitTLSKey)
+	cmd.Flags().StringVar(&pkgConfig.InitOpts.GitServerTLS.CAFile, "git-tls-ca", v.GetString(VInitGitTLSCA), lang.CmdInitFlagGitTLSCA)
+	cmd.Flags().StringVar(&pkgConfig.InitOpts.GitServerTLS.Issuer, "git-tls-issuer", v.GetString(VInitGitTLSIssuer), lang.CmdInitFlagGitTLSIssuer)
+
+	// Flags for sizing the internal registry, git server and agent
+	registryResources := &pkgConfig.InitOpts.RegistryResources
+	cmd.Flags().StringVar(&registryResources.CPURequest, "registry-cpu-request", v.GetString(VInitRegistryCPURequest), fmt.Sprintf(lang.CmdInitFlagCPURequest, "internal registry"))
+	cmd.Flags().StringVar(&registryResources.CPULimit, "registry-cpu-limit", v.GetString(VInitRegistryCPULimit), fmt.Sprintf(lang.CmdInitFlagCPULimit, "internal registry"))
+	cmd.Flags().StringVar(&registryResources.MemoryRequest, "registry-memory-request", v.GetString(VInitRegistryMemoryRequest), fmt.Sprintf(lang.CmdInitFlagMemoryRequest, "internal registry"))
+	cmd.Flags().StringVar(&registryResources.MemoryLimit, "registry-memory-limit", v.GetString(VInitRegistryMemoryLimit), fmt.Sprintf(lang.CmdInitFlagMemoryLimit, "internal registry"))
+	cmd.Flags().StringVar(&registryResources.PVCSize, "registry-pvc-size", v.GetString(VInitRegistryPVCSize), fmt.Sprintf(lang.CmdInitFlagPVCSize, "internal registry"))
+	cmd.Flags().StringVar(&registryResources.StorageClass, "registry-storage-class", v.GetString(VInitRegistryStorageClass), fmt.Sprintf(lang.CmdInitFlagStorageClassOf, "internal registry"))
+
+	// Flags for the storage of the internal registry
+	cmd.Flags().StringVar(&pkgConfig.InitOpts.RegistryInfo.Storage, "registry-storage", v.GetString(VInitRegistryStorage), lang.CmdInitFlagRegStorage)
+	cmd.Flags().StringVar(&pkgConfig.InitOpts.RegistryS3.Bucket, "registry-s3-bucket", v.GetString(VInitRegistryS3Bucket), lang.CmdInitFlagRegS3Bucket)
+	cmd.Flags().StringVar(&pkgConfig.InitOpts.RegistryS3.Region, "registry-s3-region", v.GetString(VInitRegistryS3Region), lang.CmdInitFlagRegS3Region)
+	cmd.Flags().StringVar(&pkgConfig.InitOpts.RegistryS3.Endpoint, "registry-s3-endpoint", v.GetString(VInitRegistryS3Endpoint), lang.CmdInitFlagRegS3Endpoint)
+	cmd.Flags().StringVar(&pkgConfig.InitOpts.RegistryS3.AccessKey, "registry-s3-access-key", v.GetString(VInitRegistryS3Access), lang.CmdInitFlagRegS3Access)
+	cmd.Flags().StringVar(&pkgConfig.InitOpts.RegistryS3.SecretKey, "registry-s3-secret-key", v.GetString(VInitRegistryS3Secret), lang.CmdInitFlagRegS3Secret)
+
+	gitServerResources := &pkgConfig.InitOpts.GitServerResources
+	cmd.Flags().StringVar(&gitServerResources.CPURequest, "git-cpu-request", v.GetString(VInitGitCPURequest), fmt.Sprintf(lang.CmdInitFlagCPURequest, "internal git server"))
+	cmd.Flags().StringVar(&gitServerResources.CPULimit, "git-cpu-limit", v.GetString(VInitGitCPULimit), fmt.Sprintf(lang.CmdInitFlagCPULimit, "internal git server"))
+	cmd.Flags().StringVar(&gitServerResources.MemoryRequest, "git-memory-request", v.GetString(VInitGitMemoryRequest), fmt.Sprintf(lang.CmdInitFlagMemoryRequest, "internal git server"))
+	cmd.Flags().StringVar(&gitServerResources.MemoryLimit, "git-memory-limit", v.GetString(VInitGitMemoryLimit), fmt.Sprintf(lang.CmdInitFlagMemoryLimit, "internal git server"))
+	cmd.Flags().StringVar(&gitServerResources.PVCSize, "git-pvc-size", v.GetString(VInitGitPVCSize), fmt.Sprintf(lang.CmdInitFlagPVCSize, "internal git server"))
+	cmd.Flags().StringVar(&gitServerResources.StorageClass, "git-storage-class", v.GetString(VInitGitStorageClass), fmt.Sprintf(lang.CmdInitFlagStorageClassOf, "internal git server"))
+	agentResources := &pkgConfig.InitOpts.AgentResources
+	cmd.Flags().StringVar(&agentResources.CPURequest, "agent-cpu-request", v.GetString(VInitAgentCPURequest), fmt.Sprintf(lang.CmdInitFlagCPURequest, "Zarf agent"))
+	cmd.Flags().StringVar(&agentResources.CPULimit, "agent-cpu-limit", v.GetString(VInitAgentCPULimit), fmt.Sprintf(lang.CmdInitFlagCPULimit, "Zarf agent"))
+	cmd.Flags().StringVar(&agentResources.MemoryRequest, "agent-memory-request", v.GetString(VInitAgentMemoryRequest), fmt.Sprintf(lang.CmdInitFlagMemoryRequest, "Zarf agent"))
+	cmd.Flags().StringVar(&agentResources.MemoryLimit, "agent-memory-limit", v.GetString(VInitAgentMemoryLimit), fmt.Sprintf(lang.CmdInitFlagMemoryLimit, "Zarf agent"))
+
+	// Flags that control how the registry image is bootstrapped
+	cmd.Flags().StringVar(&pkgConfig.InitOpts.SeedStrategy, "seed-strategy", v.GetString(VInitSeedStrategy), lang.CmdInitFlagSeedStrategy)
+	cmd.Flags().StringVar(&pkgConfig.InitOpts.SeedProxy, "seed-proxy", v.GetString(VInitSeedProxy), lang.CmdInitFlagSeedProxy)
+	cmd.Flags().StringVar(&pkgConfig.InitOpts.SeedHostPath, "seed-host-path", v.GetString(VInitSeedHostPath), lang.CmdInitFlagSeedHostPath)
+	cmd.Flags().IntVar(&pkgConfig.InitOpts.SeedHostPort, "seed-host-port", v.GetInt(VInitSeedHostPort), lang.CmdInitFlagSeedHostPort)
+	cmd.Flags().StringVar(&pkgConfig.InitOpts.SeedFrom, "seed-from", v.GetString(VInitSeedFrom), lang.CmdInitFlagSeedFrom)
 
 	// Flags for using an external artifact server
 	cmd.Flags().StringVar(&pkgConfig.InitOpts.ArtifactServer.Address, "artifact-url", v.GetString(VInitArtifactURL), lang.CmdInitFlagArtifactURL)
@@ -82,6 +151,11 @@ func newInitCommand() *cobra.Command {
 	// Always require adopt-existing-resources flag (no viper)
 	cmd.Flags().BoolVar(&pkgConfig.DeployOpts.AdoptExistingResources, "adopt-existing-resources", false, lang.CmdPackageDeployFlagAdoptExistingResources)
 	cmd.Flags().DurationVar(&pkgConfig.DeployOpts.Timeout, "timeout", v.GetDuration(VPkgDeployTimeout), lang.CmdPackageDeployFlagTimeout)
+	cmd.Flags().StringToStringVar(&pkgConfig.DeployOpts.Labels, "labels", v.GetStringMapString(VPkgDeployLabels), lang.CmdPackageDeployFlagLabels)
+	cmd.Flags().StringToStringVar(&pkgConfig.DeployOpts.Annotations, "annotations", v.GetStringMapString(VPkgDeployAnnotations), lang.CmdPackageDeployFlagAnnotations)
+	cmd.Flags().StringToStringVar(&pkgConfig.DeployOpts.NodeSelector, "node-selector", v.GetStringMapString(VPkgDeployNodeSelector), lang.CmdPackageDeployFlagNodeSelector)
+	cmd.Flags().StringSliceVar(&pkgConfig.DeployOpts.Tolerations, "tolerations", v.GetStringSlice(VPkgDeployTolerations), lang.CmdPackageDeployFlagTolerations)
+	cmd.Flags().StringToStringVar(&pkgConfig.DeployOpts.HostAliases, "host-aliases", v.GetStringMapString(VPkgDeployHostAliases), lang.CmdPackageDeployFlagHostAliases)
 
 	cmd.Flags().IntVar(&pkgConfig.PkgOpts.Retries, "retries", v.GetInt(VPkgRetries), lang.CmdPackageFlagRetries)
 	cmd.Flags().StringVarP(&pkgConfig.PkgOpts.PublicKeyPath, "key", "k", v.GetString(VPkgPublicKey), lang.CmdPackageFlagFlagPublicKey)
@@ -94,16 +168,23 @@ func newInitCommand() *cobra.Command {
 
 func (o *initOptions) run(cmd *cobra.Command, _ []string) error {
 	ctx := cmd.Context()
+	err := getViper().UnmarshalKey(VInitRegistryImageRules, &pkgConfig.InitOpts.RegistryInfo.ImageRules)
+	if err != nil {
+		return fmt.Errorf("invalid %s config: %w", VInitRegistryImageRules, err)
+	}
 	if err := validateInitFlags(); err != nil {
 		return fmt.Errorf("invalid command flags were provided: %w", err)
 	}
+	pkgConfig.DeployOpts.TimeoutOverride = isTimeoutOverridden(cmd)
+	if _, err := helm.ParseTolerations(pkgConfig.DeployOpts.Tolerations); err != nil {
+		return err
+	}
 
 	// Continue running package deploy for all components like any other package
 	initPackageName := sources.GetInitPackageName()
 	pkgConfig.PkgOpts.PackageSource = initPackageName
 
 	// Try to use an init-package in the executable directory if none exist in current working directory
-	var err error
 	if pkgConfig.PkgOpts.PackageSource, err = findInitPackage(cmd.Context(), initPackageName); err != nil {
 		return err
 	}
@@ -190,6 +271,9 @@ func downloadInitPackage(ctx context.Context, cacheDirectory string) (string, er
 	message.Note(lang.CmdInitPullNote)
 	l.Info("the init package was not found locally, but can be pulled in connected environments", "url", fmt.Sprintf("oci://%s", url))
 
+	if err := interactive.CheckPrompt("confirmation to download the init package, pull it with zarf tools download-init"); err != nil {
+		return "", err
+	}
 	var confirmDownload bool
 	prompt := &survey.Confirm{
 		Message: lang.CmdInitPullConfirm,
@@ -226,11 +310,92 @@ func validateInitFlags() error {
 		}
 	}
 
+	// Named instances bring their own registry
+	if config.CommonOptions.Instance != "" && pkgConfig.InitOpts.RegistryInfo.Address == "" {
+		return errors.New(lang.CmdInitErrValidateInstance)
+	}
+
 	// If 'artifact-url' is provided, make sure they provided values for the username and password of the push user
 	if pkgConfig.InitOpts.ArtifactServer.Address != "" {
 		if pkgConfig.InitOpts.ArtifactServer.PushUsername == "" || pkgConfig.InitOpts.ArtifactServer.PushToken == "" {
 			return fmt.Errorf(lang.CmdInitErrValidateArtifact)
 		}
 	}
+
+	if _, err := cluster.ParsePullSecretSelector(pkgConfig.InitOpts.PullSecretSelector); err != nil {
+		return err
+	}
+
+	if !slices.Contains(cluster.SeedStrategies, pkgConfig.InitOpts.SeedStrategy) {
+		return fmt.Errorf(lang.CmdInitErrValidateSeed, strings.Join(cluster.SeedStrategies, ", "))
+	}
+	if (pkgConfig.InitOpts.SeedStrategy == cluster.SeedStrategyPullThrough) != (pkgConfig.InitOpts.SeedProxy != "") {
+		return errors.New(lang.CmdInitErrValidateSeedProx)
+	}
+	if (pkgConfig.InitOpts.SeedStrategy == cluster.SeedStrategyHostPath) != (pkgConfig.InitOpts.SeedHostPath != "") {
+		return errors.New(lang.CmdInitErrValidateSeedPath)
+	}
+	if pkgConfig.InitOpts.SeedFrom != "" {
+		if !helpers.IsOCIURL(pkgConfig.InitOpts.SeedFrom) {
+			return errors.New(lang.CmdInitErrValidateSeedFrom)
+		}
+		if pkgConfig.InitOpts.SeedStrategy != cluster.SeedStrategyNodePort {
+			return fmt.Errorf(lang.CmdInitErrValidateSeedMirr, pkgConfig.InitOpts.SeedStrategy)
+		}
+	}
+
+	if pkgConfig.InitOpts.RegistryInfo.ProjectAPI != "" {
+		if !slices.Contains(images.ProjectAPIs, pkgConfig.InitOpts.RegistryInfo.ProjectAPI) || pkgConfig.InitOpts.RegistryInfo.Address == "" {
+			return fmt.Errorf(lang.CmdInitErrValidateProject, strings.Join(images.ProjectAPIs, ", "))
+		}
+	}
+
+	if err := transform.ValidateImageRules(pkgConfig.InitOpts.RegistryInfo.ImageRules); err != nil {
+		return fmt.Errorf("invalid registry image rules: %w", err)
+	}
+
+	if pkgConfig.InitOpts.RegistryTLS.Enabled() && pkgConfig.InitOpts.RegistryInfo.Address != "" {
+		return fmt.Errorf(lang.CmdInitErrValidateTLS, "registry", "registry")
+	}
+	if err := cluster.ValidateTLSOptions(pkgConfig.InitOpts.RegistryTLS); err != nil {
+		return fmt.Errorf("invalid registry TLS options: %w", err)
+	}
+	if pkgConfig.InitOpts.GitServerTLS.Enabled() && pkgConfig.InitOpts.GitServer.Address != "" {
+		return fmt.Errorf(lang.CmdInitErrValidateTLS, "git server", "git")
+	}
+	if err := cluster.ValidateTLSOptions(pkgConfig.InitOpts.GitServerTLS); err != nil {
+		return fmt.Errorf("invalid git server TLS options: %w", err)
+	}
+
+	if pkgConfig.InitOpts.RegistryResources != (types.ServiceResources{}) && pkgConfig.InitOpts.RegistryInfo.Address != "" {
+		return fmt.Errorf(lang.CmdInitErrValidateResource, "registry", "registry")
+	}
+	if err := cluster.ValidateServiceResources(pkgConfig.InitOpts.RegistryResources); err != nil {
+		return fmt.Errorf("invalid registry resources: %w", err)
+	}
+	if !slices.Contains(types.RegistryStorageModes, pkgConfig.InitOpts.RegistryInfo.Storage) {
+		return fmt.Errorf(lang.CmdInitErrValidateStorage, strings.Join(types.RegistryStorageModes, ", "))
+	}
+	if pkgConfig.InitOpts.RegistryInfo.Storage != types.RegistryStoragePVC && pkgConfig.InitOpts.RegistryInfo.Address != "" {
+		return errors.New(lang.CmdInitErrValidateStoreExt)
+	}
+	if (pkgConfig.InitOpts.RegistryInfo.Storage == types.RegistryStorageS3) != (pkgConfig.InitOpts.RegistryS3.Bucket != "") {
+		return errors.New(lang.CmdInitErrValidateS3)
+	}
+	if (pkgConfig.InitOpts.RegistryS3.AccessKey == "") != (pkgConfig.InitOpts.RegistryS3.SecretKey == "") {
+		return errors.New(lang.CmdInitErrValidateS3Keys)
+	}
+	if err := cluster.ValidateRegistryAuth(pkgConfig.InitOpts); err != nil {
+		return fmt.Errorf("invalid registry authentication: %w", err)
+	}
+	if pkgConfig.InitOpts.GitServerResources != (types.ServiceResources{}) && pkgConfig.InitOpts.GitServer.Address != "" {
+		return fmt.Errorf(lang.CmdInitErrValidateResource, "git server", "git")
+	}
+	if err := cluster.ValidateServiceResources(pkgConfig.InitOpts.GitServerResources); err != nil {
+		return fmt.Errorf("invalid git server resources: %w", err)
+	}
+	if err := cluster.ValidateServiceResources(pkgConfig.InitOpts.AgentResources); err != nil {
+		return fmt.Errorf("invalid agent resources: %w", err)
+	}
 	return nil
 }
@@ -0,0 +1,105 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package cmd contains the CLI commands for Zarf.
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/defenseunicorns/pkg/helpers/v2"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/zarf-dev/zarf/src/config"
+	"github.com/zarf-dev/zarf/src/config/lang"
+	"github.com/zarf-dev/zarf/src/internal/packager2"
+	"github.com/zarf-dev/zarf/src/pkg/lint"
+)
+
+func newInitPackageCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "init-package",
+		Short: lang.CmdInitPackageShort,
+	}
+
+	v := getViper()
+
+	cmd.AddCommand(newInitPackageBuildCommand(v))
+
+	return cmd
+}
+
+type initPackageBuildOptions struct {
+	output             string
+	setVariables       map[string]string
+	flavor             string
+	skipSBOM           bool
+	sbomOut            string
+	maxPackageSizeMB   int
+	registryOverrides  map[string]string
+	overlays           []string
+	signingKeyPath     string
+	signingKeyPassword string
+}
+
+func newInitPackageBuildCommand(v *viper.Viper) *cobra.Command {
+	o := &initPackageBuildOptions{}
+
+	cmd := &cobra.Command{
+		Use:     "build MANIFEST",
+		Args:    cobra.ExactArgs(1),
+		Short:   lang.CmdInitPackageBuildShort,
+		Long:    lang.CmdInitPackageBuildLong,
+		Example: lang.CmdInitPackageBuildExample,
+		RunE:    o.run,
+	}
+
+	// Always require confirm flag (no viper)
+	cmd.Flags().BoolVar(&config.CommonOptions.Confirm, "confirm", false, lang.CmdInitPackageBuildFlagConfirm)
+
+	cmd.Flags().StringVarP(&o.output, "output", "o", v.GetString(VPkgCreateOutput), lang.CmdInitPackageBuildFlagOutput)
+	cmd.Flags().StringToStringVar(&o.setVariables, "set", v.GetStringMapString(VPkgCreateSet), lang.CmdPackageCreateFlagSet)
+	cmd.Flags().StringVarP(&o.flavor, "flavor", "f", v.GetString(VPkgCreateFlavor), lang.CmdPackageCreateFlagFlavor)
+	cmd.Flags().BoolVar(&o.skipSBOM, "skip-sbom", v.GetBool(VPkgCreateSkipSbom), lang.CmdPackageCreateFlagSkipSbom)
+	cmd.Flags().StringVar(&o.sbomOut, "sbom-out", v.GetString(VPkgCreateSbomOutput), lang.CmdPackageCreateFlagSbomOut)
+	cmd.Flags().IntVarP(&o.maxPackageSizeMB, "max-package-size", "m", v.GetInt(VPkgCreateMaxPackageSize), lang.CmdPackageCreateFlagMaxPackageSize)
+	cmd.Flags().StringToStringVar(&o.registryOverrides, "registry-override", v.GetStringMapString(VPkgCreateRegistryOverride), lang.CmdPackageCreateFlagRegistryOverride)
+	cmd.Flags().StringSliceVar(&o.overlays, "overlay", v.GetStringSlice(VPkgCreateOverlays), lang.CmdPackageCreateFlagOverlay)
+	cmd.Flags().StringVar(&o.signingKeyPath, "signing-key", v.GetString(VPkgCreateSigningKey), lang.CmdPackageCreateFlagSigningKey)
+	cmd.Flags().StringVar(&o.signingKeyPassword, "signing-key-pass", v.GetString(VPkgCreateSigningKeyPassword), lang.CmdPackageCreateFlagSigningKeyPassword)
+
+	return cmd
+}
+
+func (o *initPackageBuildOptions) run(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	v := getViper()
+	o.setVariables = helpers.TransformAndMergeMap(
+		v.GetStringMapString(VPkgCreateSet), o.setVariables, strings.ToUpper)
+
+	opt := packager2.CreateOptions{
+		Flavor:             o.flavor,
+		RegistryOverrides:  o.registryOverrides,
+		SigningKeyPath:     o.signingKeyPath,
+		SigningKeyPassword: o.signingKeyPassword,
+		SetVariables:       o.setVariables,
+		MaxPackageSizeMB:   o.maxPackageSizeMB,
+		SBOMOut:            o.sbomOut,
+		SkipSBOM:           o.skipSBOM,
+		Output:             o.output,
+		OverlayPaths:       o.overlays,
+	}
+	err := packager2.BuildInitPackage(ctx, args[0], opt)
+	var lintErr *lint.LintError
+	if errors.As(err, &lintErr) {
+		PrintFindings(ctx, lintErr)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to build init package: %w", err)
+	}
+	return nil
+}
@@ -10,13 +10,16 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/defenseunicorns/pkg/helpers/v2"
 	"github.com/spf13/cobra"
 	"github.com/spf13/cobra/doc"
 	"github.com/spf13/pflag"
+	"github.com/zarf-dev/zarf/src/config"
 	"github.com/zarf-dev/zarf/src/config/lang"
 	"github.com/zarf-dev/zarf/src/internal/agent"
+	"github.com/zarf-dev/zarf/src/internal/controller"
 	"github.com/zarf-dev/zarf/src/internal/gitea"
 	"github.com/zarf-dev/zarf/src/pkg/cluster"
 	"github.com/zarf-dev/zarf/src/pkg/logger"
@@ -32,6 +35,7 @@ func newInternalCommand(rootCmd *cobra.Command) *cobra.Command {
 
 	cmd.AddCommand(newInternalAgentCommand())
 	cmd.AddCommand(newInternalHTTPProxyCommand())
+	cmd.AddCommand(newInternalControllerCommand())
 	cmd.AddCommand(newInternalGenCliDocsCommand(rootCmd))
 	cmd.AddCommand(newInternalCreateReadOnlyGiteaUserCommand())
 	cmd.AddCommand(newInternalCreateArtifactRegistryTokenCommand())
@@ -88,6 +92,50 @@ func (o *internalHTTPProxyOptions) run(cmd *cobra.Command, _ []string) error {
 	return agent.StartHTTPProxy(cmd.Context(), cluster)
 }
 
+type internalControllerOptions struct {
+	interval      time.Duration
+	retryInterval time.Duration
+	namespace     string
+	publicKeyPath string
+}
+
+func newInternalControllerCommand() *cobra.Command {
+	o := &internalControllerOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "controller",
+		Short: lang.CmdInternalControllerShort,
+		Long:  lang.CmdInternalControllerLong,
+		RunE:  o.run,
+	}
+
+	cmd.Flags().DurationVar(&o.interval, "interval", controller.DefaultInterval, lang.CmdInternalControllerFlagInterval)
+	cmd.Flags().DurationVar(&o.retryInterval, "retry-interval", controller.DefaultRetryInterval, lang.CmdInternalControllerFlagRetryInterval)
+	cmd.Flags().StringVarP(&o.namespace, "namespace", "n", cluster.ZarfNamespaceName, lang.CmdInternalControllerFlagNamespace)
+	cmd.Flags().StringVarP(&o.publicKeyPath, "key", "k", "", lang.CmdInternalControllerFlagKey)
+
+	return cmd
+}
+
+func (o *internalControllerOptions) run(cmd *cobra.Command, _ []string) error {
+	// Packages are deployed without prompting as there is no one to answer.
+	config.CommonOptions.Confirm = true
+	cluster, err := cluster.NewCluster()
+	if err != nil {
+		return err
+	}
+	ctrl, err := controller.New(cluster, controller.Options{
+		Interval:      o.interval,
+		RetryInterval: o.retryInterval,
+		Namespace:     o.namespace,
+		PublicKeyPath: o.publicKeyPath,
+	})
+	if err != nil {
+		return err
+	}
+	return ctrl.Start(cmd.Context())
+}
+
 type internalGenCliDocsOptions struct {
 	rootCmd *cobra.Command
 }
@@ -248,8 +296,8 @@ func (o *internalCreateReadOnlyGiteaUserOptions) run(cmd *cobra.Command, _ []str
 		return err
 	}
 	defer tunnel.Close()
-	tunnelURL := tunnel.HTTPEndpoint()
-	giteaClient, err := gitea.NewClient(tunnelURL, state.GitServer.PushUsername, state.GitServer.PushPassword)
+	tunnelURL := tunnel.ServiceEndpoint(state.GitServer.TLS)
+	giteaClient, err := gitea.NewClient(tunnelURL, state.GitServer.PushUsername, state.GitServer.PushPassword, state.GitServer.TLS.CABundle())
 	if err != nil {
 		return err
 	}
@@ -305,8 +353,8 @@ func (o *internalCreateArtifactRegistryTokenOptions) run(cmd *cobra.Command, _ [
 			return err
 		}
 		defer tunnel.Close()
-		tunnelURL := tunnel.HTTPEndpoint()
-		giteaClient, err := gitea.NewClient(tunnelURL, state.GitServer.PushUsername, state.GitServer.PushPassword)
+		tunnelURL := tunnel.ServiceEndpoint(state.GitServer.TLS)
+		giteaClient, err := gitea.NewClient(tunnelURL, state.GitServer.PushUsername, state.GitServer.PushPassword, state.GitServer.TLS.CABundle())
 		if err != nil {
 			return err
 		}
@@ -12,6 +12,7 @@ import (
 	"github.com/zarf-dev/zarf/src/pkg/logger"
 	"github.com/zarf-dev/zarf/src/pkg/message"
 	kubeCLI "k8s.io/component-base/cli"
+	"k8s.io/component-base/version"
 	kubeCmd "k8s.io/kubectl/pkg/cmd"
 
 	// Import to initialize client auth plugins.
@@ -27,6 +28,10 @@ func newKubectlCommand() *cobra.Command {
 
 	// Only load this command if it is being called directly.
 	if IsVendorCmd(os.Args, []string{"kubectl", "k"}) {
+		if stripToolVersionArgs("kubectl", version.Get().GitVersion) {
+			return newToolVersionCommand("kubectl", []string{"k"})
+		}
+
 		// Add the kubectl command to the tools command.
 		cmd = kubeCmd.NewDefaultKubectlCommand()
 
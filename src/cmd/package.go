@@ -14,26 +14,41 @@ import (
 	"path/filepath"
 	"regexp"
 	"runtime"
+	"runtime/debug"
+	"slices"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/AlecAivazis/survey/v2"
 	"github.com/defenseunicorns/pkg/helpers/v2"
+	"github.com/defenseunicorns/pkg/oci"
 	goyaml "github.com/goccy/go-yaml"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 	"github.com/spf13/viper"
+	"golang.org/x/term"
 	"oras.land/oras-go/v2/registry"
 
+	"github.com/zarf-dev/zarf/src/api/v1alpha1"
 	"github.com/zarf-dev/zarf/src/config"
 	"github.com/zarf-dev/zarf/src/config/lang"
 	"github.com/zarf-dev/zarf/src/internal/dns"
+	"github.com/zarf-dev/zarf/src/internal/packager/helm"
+	"github.com/zarf-dev/zarf/src/internal/packager/imagebuild"
 	"github.com/zarf-dev/zarf/src/internal/packager2"
+	"github.com/zarf-dev/zarf/src/internal/tui"
 	"github.com/zarf-dev/zarf/src/pkg/cluster"
+	"github.com/zarf-dev/zarf/src/pkg/interactive"
 	"github.com/zarf-dev/zarf/src/pkg/lint"
 	"github.com/zarf-dev/zarf/src/pkg/logger"
 	"github.com/zarf-dev/zarf/src/pkg/message"
 	"github.com/zarf-dev/zarf/src/pkg/packager"
 	"github.com/zarf-dev/zarf/src/pkg/packager/filters"
+	"github.com/zarf-dev/zarf/src/pkg/packager/sources"
+	"github.com/zarf-dev/zarf/src/pkg/transform"
 	"github.com/zarf-dev/zarf/src/pkg/utils"
+	"github.com/zarf-dev/zarf/src/pkg/zoci"
 	"github.com/zarf-dev/zarf/src/types"
 )
 
@@ -58,6 +73,11 @@ func newPackageCommand() *cobra.Command {
 	cmd.AddCommand(newPackageListCommand())
 	cmd.AddCommand(newPackagePublishCommand(v))
 	cmd.AddCommand(newPackagePullCommand(v))
+	cmd.AddCommand(newPackageCopyCommand(v))
+	cmd.AddCommand(newPackageVersionsCommand())
+	cmd.AddCommand(newPackageConvertCommand())
+	cmd.AddCommand(newPackageSignCommand(v))
+	cmd.AddCommand(newPackageResignCommand(v))
 
 	return cmd
 }
@@ -68,7 +88,7 @@ func newPackageCreateCommand(v *viper.Viper) *cobra.Command {
 	o := &packageCreateOptions{}
 
 	cmd := &cobra.Command{
-		Use:     "create [ DIRECTORY ]",
+		Use:     "create [ DIRECTORY | DEFINITION_URL ]",
 		Aliases: []string{"c"},
 		Args:    cobra.MaximumNArgs(1),
 		Short:   lang.CmdPackageCreateShort,
@@ -95,6 +115,25 @@ func newPackageCreateCommand(v *viper.Viper) *cobra.Command {
 	cmd.Flags().IntVarP(&pkgConfig.CreateOpts.MaxPackageSizeMB, "max-package-size", "m", v.GetInt(VPkgCreateMaxPackageSize), lang.CmdPackageCreateFlagMaxPackageSize)
 	cmd.Flags().StringToStringVar(&pkgConfig.CreateOpts.RegistryOverrides, "registry-override", v.GetStringMapString(VPkgCreateRegistryOverride), lang.CmdPackageCreateFlagRegistryOverride)
 	cmd.Flags().StringVarP(&pkgConfig.CreateOpts.Flavor, "flavor", "f", v.GetString(VPkgCreateFlavor), lang.CmdPackageCreateFlagFlavor)
+	cmd.Flags().StringSliceVar(&pkgConfig.CreateOpts.Overlays, "overlay", v.GetStringSlice(VPkgCreateOverlays), lang.CmdPackageCreateFlagOverlay)
+	cmd.Flags().BoolVar(&pkgConfig.CreateOpts.BuildCache, "build-cache", v.GetBool(VPkgCreateBuildCache), lang.CmdPackageCreateFlagBuildCache)
+	cmd.Flags().StringVar(&pkgConfig.CreateOpts.RemoteCache, "remote-cache", v.GetString(VPkgCreateRemoteCache), lang.CmdPackageCreateFlagRemoteCache)
+	cmd.Flags().BoolVar(&pkgConfig.CreateOpts.RemoteCacheReadOnly, "remote-cache-read-only", v.GetBool(VPkgCreateRemoteCacheRO), lang.CmdPackageCreateFlagRemoteCacheRO)
+	cmd.Flags().IntVar(&pkgConfig.CreateOpts.MaxMemoryMB, "max-memory", v.GetInt(VPkgCreateMaxMemory), lang.CmdPackageCreateFlagMaxMemory)
+	cmd.Flags().IntVar(&pkgConfig.CreateOpts.MaxTempSpaceMB, "max-temp-space", v.GetInt(VPkgCreateMaxTempSpace), lang.CmdPackageCreateFlagMaxTempSpace)
+	cmd.Flags().StringSliceVar(&pkgConfig.CreateOpts.IncludePlatforms, "include-platforms", v.GetStringSlice(VPkgCreateIncludePlatforms), lang.CmdPackageCreateFlagIncludePlatforms)
+	cmd.Flags().StringVar(&pkgConfig.CreateOpts.FailOnSeverity, "fail-on-severity", v.GetString(VPkgCreateFailOnSeverity), lang.CmdPackageCreateFlagFailOnSeverity)
+	cmd.Flags().StringVar(&pkgConfig.CreateOpts.VulnerabilityWaiversPath, "vulnerability-waivers", v.GetString(VPkgCreateVulnWaivers), lang.CmdPackageCreateFlagVulnWaivers)
+	cmd.Flags().BoolVar(&pkgConfig.CreateOpts.AllowUnpinned, "allow-unpinned", v.GetBool(VPkgCreateAllowUnpinned), lang.CmdPackageCreateFlagAllowUnpinned)
+	cmd.Flags().StringVar(&pkgConfig.CreateOpts.LintRulesPath, "lint-rules", v.GetString(VPkgCreateLintRules), lang.CmdPackageCreateFlagLintRules)
+	cmd.Flags().StringVar(&pkgConfig.CreateOpts.BuildkitAddr, "buildkit-addr", v.GetString(VPkgCreateBuildkitAddr), lang.CmdPackageCreateFlagBuildkitAddr)
+	cmd.Flags().StringVar(&pkgConfig.CreateOpts.BuildkitPool, "buildkit-pool", v.GetString(VPkgCreateBuildkitPool), lang.CmdPackageCreateFlagBuildkitPool)
+	cmd.Flags().StringVar(&pkgConfig.CreateOpts.BuildkitPoolNamespace, "buildkit-pool-namespace", v.GetString(VPkgCreateBuildkitPoolNS), lang.CmdPackageCreateFlagBuildkitPoolNamespace)
+	cmd.Flags().StringVar(&pkgConfig.CreateOpts.BuildkitTLSCACert, "buildkit-tls-ca-cert", v.GetString(VPkgCreateBuildkitCACert), lang.CmdPackageCreateFlagBuildkitTLSCACert)
+	cmd.Flags().StringVar(&pkgConfig.CreateOpts.BuildkitTLSCert, "buildkit-tls-cert", v.GetString(VPkgCreateBuildkitCert), lang.CmdPackageCreateFlagBuildkitTLSCert)
+	cmd.Flags().StringVar(&pkgConfig.CreateOpts.BuildkitTLSKey, "buildkit-tls-key", v.GetString(VPkgCreateBuildkitKey), lang.CmdPackageCreateFlagBuildkitTLSKey)
+	cmd.Flags().StringVar(&pkgConfig.CreateOpts.BuildkitTLSServerName, "buildkit-tls-server-name", v.GetString(VPkgCreateBuildkitServerName), lang.CmdPackageCreateFlagBuildkitTLSServerName)
+	cmd.MarkFlagsMutuallyExclusive("buildkit-addr", "buildkit-pool")
 
 	cmd.Flags().StringVar(&pkgConfig.CreateOpts.SigningKeyPath, "signing-key", v.GetString(VPkgCreateSigningKey), lang.CmdPackageCreateFlagSigningKey)
 	cmd.Flags().StringVar(&pkgConfig.CreateOpts.SigningKeyPassword, "signing-key-pass", v.GetString(VPkgCreateSigningKeyPassword), lang.CmdPackageCreateFlagSigningKeyPassword)
@@ -138,16 +177,42 @@ func (o *packageCreateOptions) run(cmd *cobra.Command, args []string) error {
 		v.GetStringMapString(VPkgCreateSet), pkgConfig.CreateOpts.SetVariables, strings.ToUpper)
 
 	opt := packager2.CreateOptions{
-		Flavor:                  pkgConfig.CreateOpts.Flavor,
-		RegistryOverrides:       pkgConfig.CreateOpts.RegistryOverrides,
-		SigningKeyPath:          pkgConfig.CreateOpts.SigningKeyPath,
-		SigningKeyPassword:      pkgConfig.CreateOpts.SigningKeyPassword,
-		SetVariables:            pkgConfig.CreateOpts.SetVariables,
-		MaxPackageSizeMB:        pkgConfig.CreateOpts.MaxPackageSizeMB,
-		SBOMOut:                 pkgConfig.CreateOpts.SBOMOutputDir,
-		SkipSBOM:                pkgConfig.CreateOpts.SkipSBOM,
-		Output:                  pkgConfig.CreateOpts.Output,
-		DifferentialPackagePath: pkgConfig.CreateOpts.DifferentialPackagePath,
+		Flavor:                   pkgConfig.CreateOpts.Flavor,
+		RegistryOverrides:        pkgConfig.CreateOpts.RegistryOverrides,
+		SigningKeyPath:           pkgConfig.CreateOpts.SigningKeyPath,
+		SigningKeyPassword:       pkgConfig.CreateOpts.SigningKeyPassword,
+		SetVariables:             pkgConfig.CreateOpts.SetVariables,
+		MaxPackageSizeMB:         pkgConfig.CreateOpts.MaxPackageSizeMB,
+		SBOMOut:                  pkgConfig.CreateOpts.SBOMOutputDir,
+		SkipSBOM:                 pkgConfig.CreateOpts.SkipSBOM,
+		Output:                   pkgConfig.CreateOpts.Output,
+		DifferentialPackagePath:  pkgConfig.CreateOpts.DifferentialPackagePath,
+		OverlayPaths:             pkgConfig.CreateOpts.Overlays,
+		BuildCache:               pkgConfig.CreateOpts.BuildCache,
+		RemoteCache:              pkgConfig.CreateOpts.RemoteCache,
+		RemoteCacheReadOnly:      pkgConfig.CreateOpts.RemoteCacheReadOnly,
+		MaxMemoryMB:              pkgConfig.CreateOpts.MaxMemoryMB,
+		MaxTempSpaceMB:           pkgConfig.CreateOpts.MaxTempSpaceMB,
+		IncludePlatforms:         pkgConfig.CreateOpts.IncludePlatforms,
+		FailOnSeverity:           pkgConfig.CreateOpts.FailOnSeverity,
+		VulnerabilityWaiversPath: pkgConfig.CreateOpts.VulnerabilityWaiversPath,
+		AllowUnpinned:            pkgConfig.CreateOpts.AllowUnpinned,
+		LintRulesPath:            pkgConfig.CreateOpts.LintRulesPath,
+		Flags:                    changedFlags(cmd),
+		ConfigPath:               v.ConfigFileUsed(),
+		Buildkit: imagebuild.Remote{
+			Addr:          pkgConfig.CreateOpts.BuildkitAddr,
+			Pool:          pkgConfig.CreateOpts.BuildkitPool,
+			PoolNamespace: pkgConfig.CreateOpts.BuildkitPoolNamespace,
+			TLSCACert:     pkgConfig.CreateOpts.BuildkitTLSCACert,
+			TLSCert:       pkgConfig.CreateOpts.BuildkitTLSCert,
+			TLSKey:        pkgConfig.CreateOpts.BuildkitTLSKey,
+			TLSServerName: pkgConfig.CreateOpts.BuildkitTLSServerName,
+		},
+	}
+	if opt.MaxMemoryMB > 0 {
+		// The soft limit makes the garbage collector work harder as the budget is approached.
+		debug.SetMemoryLimit(int64(opt.MaxMemoryMB) * 1000 * 1000)
 	}
 	err := packager2.Create(cmd.Context(), pkgConfig.CreateOpts.BaseDir, opt)
 	// NOTE(mkcp): LintErrors are rendered with a table
@@ -161,7 +226,52 @@ func (o *packageCreateOptions) run(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
-type packageDeployOptions struct{}
+// recordedFlags are the flags whose values are recorded in the build info of a package. The values of other flags can
+// hold credentials, such as URLs with userinfo and package variables, and are redacted.
+var recordedFlags = map[string]bool{
+	"allow-unpinned":           true,
+	"architecture":             true,
+	"build-cache":              true,
+	"buildkit-pool":            true,
+	"buildkit-pool-namespace":  true,
+	"buildkit-tls-server-name": true,
+	"confirm":                  true,
+	"differential":             true,
+	"fail-on-severity":         true,
+	"flavor":                   true,
+	"include-platforms":        true,
+	"lint-rules":               true,
+	"max-memory":               true,
+	"max-package-size":         true,
+	"max-temp-space":           true,
+	"output":                   true,
+	"output-directory":         true,
+	"overlay":                  true,
+	"remote-cache-read-only":   true,
+	"retries":                  true,
+	"sbom":                     true,
+	"sbom-out":                 true,
+	"skip-sbom":                true,
+	"vulnerability-waivers":    true,
+}
+
+// changedFlags returns the flags that were set on the command line. Only the values of recordedFlags are kept, the
+// values of all other flags are redacted.
+func changedFlags(cmd *cobra.Command) map[string]string {
+	flags := map[string]string{}
+	cmd.Flags().Visit(func(f *pflag.Flag) {
+		if !recordedFlags[f.Name] {
+			flags[f.Name] = logger.RedactedValue
+			return
+		}
+		flags[f.Name] = logger.Redact(f.Value.String())
+	})
+	return flags
+}
+
+type packageDeployOptions struct {
+	tui bool
+}
 
 func newPackageDeployCommand(v *viper.Viper) *cobra.Command {
 	o := &packageDeployOptions{}
@@ -186,9 +296,24 @@ func newPackageDeployCommand(v *viper.Viper) *cobra.Command {
 	cmd.Flags().IntVar(&pkgConfig.PkgOpts.Retries, "retries", v.GetInt(VPkgRetries), lang.CmdPackageFlagRetries)
 	cmd.Flags().StringToStringVar(&pkgConfig.PkgOpts.SetVariables, "set", v.GetStringMapString(VPkgDeploySet), lang.CmdPackageDeployFlagSet)
 	cmd.Flags().StringVar(&pkgConfig.PkgOpts.OptionalComponents, "components", v.GetString(VPkgDeployComponents), lang.CmdPackageDeployFlagComponents)
+	cmd.Flags().StringSliceVar(&pkgConfig.DeployOpts.Features, "features", v.GetStringSlice(VPkgDeployFeatures), lang.CmdPackageDeployFlagFeatures)
+	cmd.Flags().StringVar(&pkgConfig.DeployOpts.ServiceAccount, "service-account", v.GetString(VPkgDeployServiceAccount), lang.CmdPackageDeployFlagServiceAccount)
+	cmd.Flags().StringToStringVar(&pkgConfig.DeployOpts.Labels, "labels", v.GetStringMapString(VPkgDeployLabels), lang.CmdPackageDeployFlagLabels)
+	cmd.Flags().StringToStringVar(&pkgConfig.DeployOpts.Annotations, "annotations", v.GetStringMapString(VPkgDeployAnnotations), lang.CmdPackageDeployFlagAnnotations)
+	cmd.Flags().StringToStringVar(&pkgConfig.DeployOpts.NodeSelector, "node-selector", v.GetStringMapString(VPkgDeployNodeSelector), lang.CmdPackageDeployFlagNodeSelector)
+	cmd.Flags().StringSliceVar(&pkgConfig.DeployOpts.Tolerations, "tolerations", v.GetStringSlice(VPkgDeployTolerations), lang.CmdPackageDeployFlagTolerations)
+	cmd.Flags().StringToStringVar(&pkgConfig.DeployOpts.HostAliases, "host-aliases", v.GetStringMapString(VPkgDeployHostAliases), lang.CmdPackageDeployFlagHostAliases)
+	cmd.Flags().BoolVar(&pkgConfig.DeployOpts.ShowReleaseNotes, "notes", v.GetBool(VPkgDeployNotes), lang.CmdPackageDeployFlagNotes)
+	cmd.Flags().StringVar(&pkgConfig.DeployOpts.CapacityCheck, "capacity-check", v.GetString(VPkgDeployCapacityCheck), lang.CmdPackageDeployFlagCapacityCheck)
+	cmd.Flags().BoolVar(&pkgConfig.DeployOpts.AllowHostServices, "allow-host-services", false, lang.CmdPackageDeployFlagAllowHostServices)
+	cmd.Flags().BoolVar(&pkgConfig.DeployOpts.AllowHostArtifacts, "allow-host-artifacts", false, lang.CmdPackageDeployFlagAllowHostArtifacts)
+	cmd.Flags().BoolVar(&pkgConfig.DeployOpts.LazyLoad, "lazy-load", v.GetBool(VPkgDeployLazyLoad), lang.CmdPackageDeployFlagLazyLoad)
 	cmd.Flags().StringVar(&pkgConfig.PkgOpts.Shasum, "shasum", v.GetString(VPkgDeployShasum), lang.CmdPackageDeployFlagShasum)
+	cmd.Flags().StringVar(&pkgConfig.PkgOpts.ChecksumsPath, "checksums", v.GetString(VPkgDeployChecksums), lang.CmdPackageDeployFlagChecksums)
 	cmd.Flags().StringVar(&pkgConfig.PkgOpts.SGetKeyPath, "sget", v.GetString(VPkgDeploySget), lang.CmdPackageDeployFlagSget)
 	cmd.Flags().BoolVar(&pkgConfig.PkgOpts.SkipSignatureValidation, "skip-signature-validation", false, lang.CmdPackageFlagSkipSignatureValidation)
+	cmd.Flags().BoolVar(&o.tui, "tui", false, lang.CmdPackageDeployFlagTUI)
+	cmd.MarkFlagsMutuallyExclusive("tui", "confirm")
 
 	err := cmd.Flags().MarkHidden("sget")
 	if err != nil {
@@ -207,15 +332,49 @@ func (o *packageDeployOptions) preRun(_ *cobra.Command, _ []string) {
 
 func (o *packageDeployOptions) run(cmd *cobra.Command, args []string) error {
 	ctx := cmd.Context()
+	if pkgConfig.DeployOpts.ServiceAccount != "" {
+		if _, _, err := cluster.ParseServiceAccount(pkgConfig.DeployOpts.ServiceAccount); err != nil {
+			return err
+		}
+	}
+	if _, err := helm.ParseTolerations(pkgConfig.DeployOpts.Tolerations); err != nil {
+		return err
+	}
+	if !slices.Contains(types.CapacityChecks, pkgConfig.DeployOpts.CapacityCheck) {
+		return fmt.Errorf("invalid capacity check %q, must be one of %s", pkgConfig.DeployOpts.CapacityCheck, strings.Join(types.CapacityChecks, ", "))
+	}
 	packageSource, err := choosePackage(ctx, args)
 	if err != nil {
 		return err
 	}
+	// Prompts would read from stdin while it carries the package.
+	if packageSource == sources.StdinSource && (!config.CommonOptions.Confirm || o.tui) {
+		return errors.New("deploying a package from stdin requires --confirm")
+	}
+	if helpers.IsOCIURL(packageSource) {
+		resolved, err := zoci.ResolveVersionConstraint(ctx, packageSource, oci.PlatformForArch(config.GetArch()), oci.WithPlainHTTP(config.CommonOptions.PlainHTTP))
+		if err != nil {
+			return err
+		}
+		if resolved != packageSource {
+			logger.From(ctx).Info("resolved package version", "constraint", packageSource, "resolved", resolved)
+			packageSource = resolved
+		}
+	}
 	pkgConfig.PkgOpts.PackageSource = packageSource
 
 	v := getViper()
 	pkgConfig.PkgOpts.SetVariables = helpers.TransformAndMergeMap(
 		v.GetStringMapString(VPkgDeploySet), pkgConfig.PkgOpts.SetVariables, strings.ToUpper)
+	pkgConfig.DeployOpts.TimeoutOverride = isTimeoutOverridden(cmd)
+	// Packages from OCI registries only pull the layers of each component, so they are loaded lazily by default.
+	if !cmd.Flags().Changed("lazy-load") && !v.InConfig(VPkgDeployLazyLoad) {
+		pkgConfig.DeployOpts.LazyLoad = helpers.IsOCIURL(packageSource)
+	}
+
+	if o.tui {
+		return o.runTUI(ctx)
+	}
 
 	pkgClient, err := packager.New(&pkgConfig, packager.WithContext(cmd.Context()))
 	if err != nil {
@@ -229,6 +388,64 @@ func (o *packageDeployOptions) run(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// isTimeoutOverridden returns whether the deploy timeout was set with --timeout or in the config file, in which case it
+// takes precedence over the timeouts of the components.
+func isTimeoutOverridden(cmd *cobra.Command) bool {
+	return cmd.Flags().Changed("timeout") || getViper().InConfig(VPkgDeployTimeout)
+}
+
+// runTUI deploys the package from a full-screen terminal UI that selects components and prompts for variables up front.
+func (o *packageDeployOptions) runTUI(ctx context.Context) error {
+	if !term.IsTerminal(int(os.Stdout.Fd())) || !term.IsTerminal(int(os.Stdin.Fd())) {
+		return errors.New("--tui requires an interactive terminal")
+	}
+	pkg, err := loadPackageDefinition(ctx, pkgConfig.PkgOpts.PackageSource)
+	if err != nil {
+		return err
+	}
+	level, err := logger.ParseLevel(LogLevelCLI)
+	if err != nil {
+		level = logger.Info
+	}
+
+	// The UI owns the terminal, the deployment is confirmed in the UI and legacy output is discarded.
+	message.InitializePTerm(io.Discard)
+	message.NoProgress = true
+
+	return tui.RunDeploy(ctx, tui.DeployOptions{
+		Package:    pkg,
+		Components: pkgConfig.PkgOpts.OptionalComponents,
+		Variables:  pkgConfig.PkgOpts.SetVariables,
+		LogLevel:   level,
+		Deploy: func(ctx context.Context, components string, variables map[string]string) error {
+			cfg := pkgConfig
+			cfg.PkgOpts.OptionalComponents = components
+			cfg.PkgOpts.SetVariables = variables
+			pkgClient, err := packager.New(&cfg, packager.WithContext(ctx))
+			if err != nil {
+				return err
+			}
+			defer pkgClient.ClearTempPaths()
+			if err := pkgClient.Deploy(ctx); err != nil {
+				return fmt.Errorf("failed to deploy package: %w", err)
+			}
+			return nil
+		},
+	})
+}
+
+// loadPackageDefinition returns the definition of the package, only fetching the zarf.yaml of OCI packages.
+func loadPackageDefinition(ctx context.Context, source string) (v1alpha1.ZarfPackage, error) {
+	if !helpers.IsOCIURL(source) {
+		return packager2.GetPackageFromSourceOrCluster(ctx, nil, source, pkgConfig.PkgOpts.SkipSignatureValidation, pkgConfig.PkgOpts.PublicKeyPath)
+	}
+	remote, err := zoci.NewRemote(ctx, source, oci.PlatformForArch(config.GetArch()), oci.WithPlainHTTP(config.CommonOptions.PlainHTTP))
+	if err != nil {
+		return v1alpha1.ZarfPackage{}, err
+	}
+	return remote.FetchZarfYAML(ctx)
+}
+
 type packageMirrorResourcesOptions struct{}
 
 func newPackageMirrorResourcesCommand(v *viper.Viper) *cobra.Command {
@@ -269,6 +486,16 @@ func newPackageMirrorResourcesCommand(v *viper.Viper) *cobra.Command {
 	cmd.Flags().StringVar(&pkgConfig.InitOpts.RegistryInfo.Address, "registry-url", v.GetString(VInitRegistryURL), lang.CmdInitFlagRegURL)
 	cmd.Flags().StringVar(&pkgConfig.InitOpts.RegistryInfo.PushUsername, "registry-push-username", v.GetString(VInitRegistryPushUser), lang.CmdInitFlagRegPushUser)
 	cmd.Flags().StringVar(&pkgConfig.InitOpts.RegistryInfo.PushPassword, "registry-push-password", v.GetString(VInitRegistryPushPass), lang.CmdInitFlagRegPushPass)
+	cmd.Flags().StringVar(&pkgConfig.InitOpts.RegistryInfo.ProjectAPI, "registry-project-api", v.GetString(VInitRegistryProjectAPI), lang.CmdInitFlagRegProjectAPI)
+	cmd.Flags().StringVar(&pkgConfig.InitOpts.RegistryInfo.ProjectUsername, "registry-project-username", v.GetString(VInitRegistryProjectUser), lang.CmdInitFlagRegProjectUser)
+	cmd.Flags().StringVar(&pkgConfig.InitOpts.RegistryInfo.ProjectPassword, "registry-project-password", v.GetString(VInitRegistryProjectPass), lang.CmdInitFlagRegProjectPass)
+	cmd.Flags().BoolVar(&pkgConfig.InitOpts.RegistryInfo.ScopedTokens, "registry-scoped-tokens", v.GetBool(VInitRegistryScopedTokens), lang.CmdInitFlagRegScopedToken)
+
+	// Flags for staging resources on the filesystem to be pushed from another host
+	cmd.Flags().StringVar(&pkgConfig.MirrorOpts.OCILayoutDir, "oci-layout", "", lang.CmdPackageMirrorFlagOCILayout)
+	cmd.Flags().StringVar(&pkgConfig.MirrorOpts.GitDir, "git-dir", "", lang.CmdPackageMirrorFlagGitDir)
+	cmd.MarkFlagsMutuallyExclusive("oci-layout", "registry-url")
+	cmd.MarkFlagsMutuallyExclusive("git-dir", "git-url")
 
 	return cmd
 }
@@ -282,6 +509,13 @@ func (o *packageMirrorResourcesOptions) preRun(_ *cobra.Command, _ []string) {
 
 func (o *packageMirrorResourcesOptions) run(cmd *cobra.Command, args []string) (err error) {
 	ctx := cmd.Context()
+	err = getViper().UnmarshalKey(VInitRegistryImageRules, &pkgConfig.InitOpts.RegistryInfo.ImageRules)
+	if err != nil {
+		return fmt.Errorf("invalid %s config: %w", VInitRegistryImageRules, err)
+	}
+	if err := transform.ValidateImageRules(pkgConfig.InitOpts.RegistryInfo.ImageRules); err != nil {
+		return fmt.Errorf("invalid registry image rules: %w", err)
+	}
 	var c *cluster.Cluster
 	if dns.IsServiceURL(pkgConfig.InitOpts.RegistryInfo.Address) || dns.IsServiceURL(pkgConfig.InitOpts.GitServer.Address) {
 		var err error
@@ -323,6 +557,8 @@ func (o *packageMirrorResourcesOptions) run(cmd *cobra.Command, args []string) (
 		GitInfo:         pkgConfig.InitOpts.GitServer,
 		NoImageChecksum: pkgConfig.MirrorOpts.NoImgChecksum,
 		Retries:         pkgConfig.PkgOpts.Retries,
+		OCILayoutDir:    pkgConfig.MirrorOpts.OCILayoutDir,
+		GitDir:          pkgConfig.MirrorOpts.GitDir,
 	}
 	err = packager2.Mirror(ctx, mirrorOpt)
 	if err != nil {
@@ -348,9 +584,16 @@ func newPackageInspectCommand() *cobra.Command {
 	cmd.AddCommand(newPackageInspectSBOMCommand())
 	cmd.AddCommand(newPackageInspectImagesCommand())
 	cmd.AddCommand(newPackageInspectDefinitionCommand())
+	cmd.AddCommand(newPackageInspectManifestsCommand())
+	cmd.AddCommand(newPackageInspectComplianceCommand())
+	cmd.AddCommand(newPackageInspectLicensesCommand())
+	cmd.AddCommand(newPackageInspectNotesCommand())
+	cmd.AddCommand(newPackageInspectBuildInfoCommand())
+	cmd.AddCommand(newPackageInspectProvenanceCommand())
 
 	cmd.Flags().StringVar(&pkgConfig.InspectOpts.SBOMOutputDir, "sbom-out", "", lang.CmdPackageInspectFlagSbomOut)
 	cmd.Flags().BoolVar(&pkgConfig.InspectOpts.ListImages, "list-images", false, lang.CmdPackageInspectFlagListImages)
+	cmd.Flags().BoolVar(&pkgConfig.InspectOpts.ReleaseNotes, "notes", false, lang.CmdPackageInspectFlagNotes)
 	cmd.Flags().BoolVar(&pkgConfig.PkgOpts.SkipSignatureValidation, "skip-signature-validation", false, lang.CmdPackageFlagSkipSignatureValidation)
 
 	return cmd
@@ -371,6 +614,12 @@ func (o *packageInspectOptions) run(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("cannot use --sbom-out and --list-images at the same time")
 	}
 
+	if pkgConfig.InspectOpts.ReleaseNotes {
+		notesOpts := newPackageInspectNotesOptions()
+		notesOpts.skipSignatureValidation = pkgConfig.PkgOpts.SkipSignatureValidation
+		return notesOpts.run(cmd, args)
+	}
+
 	if pkgConfig.InspectOpts.SBOMOutputDir != "" {
 		sbomOpts := PackageInspectSBOMOptions{
 			skipSignatureValidation: pkgConfig.PkgOpts.SkipSignatureValidation,
@@ -453,11 +702,14 @@ func (o *PackageInspectSBOMOptions) Run(cmd *cobra.Command, args []string) error
 
 type packageInspectImagesOptions struct {
 	skipSignatureValidation bool
+	outputFormat            outputFormat
+	outputWriter            io.Writer
 }
 
 func newPackageInspectImagesOptions() *packageInspectImagesOptions {
 	return &packageInspectImagesOptions{
 		skipSignatureValidation: false,
+		outputWriter:            message.OutputWriter,
 	}
 }
 
@@ -471,6 +723,7 @@ func newPackageInspectImagesCommand() *cobra.Command {
 	}
 
 	cmd.Flags().BoolVar(&o.skipSignatureValidation, "skip-signature-validation", o.skipSignatureValidation, lang.CmdPackageFlagSkipSignatureValidation)
+	cmd.Flags().VarP(&o.outputFormat, "output", "o", lang.CmdPackageInspectImagesFlagOut)
 
 	return cmd
 }
@@ -483,6 +736,10 @@ func (o *packageInspectImagesOptions) run(cmd *cobra.Command, args []string) err
 		return err
 	}
 
+	if o.outputFormat != "" {
+		return o.printImageInfos(ctx, src)
+	}
+
 	// The user may be pulling the package from the cluster or using a built package
 	// since we don't know we don't check this error
 	cluster, _ := cluster.NewCluster() //nolint:errcheck
@@ -505,6 +762,48 @@ func (o *packageInspectImagesOptions) run(cmd *cobra.Command, args []string) err
 	return nil
 }
 
+func (o *packageInspectImagesOptions) printImageInfos(ctx context.Context, src string) error {
+	opt := packager2.InspectImagesOptions{
+		PublicKeyPath:           pkgConfig.PkgOpts.PublicKeyPath,
+		SkipSignatureValidation: o.skipSignatureValidation,
+	}
+	imageInfos, err := packager2.InspectImages(ctx, src, opt)
+	if err != nil {
+		return err
+	}
+
+	switch o.outputFormat {
+	case outputJSON:
+		output, err := json.MarshalIndent(imageInfos, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(o.outputWriter, string(output))
+	case outputYAML:
+		output, err := goyaml.Marshal(imageInfos)
+		if err != nil {
+			return err
+		}
+		fmt.Fprint(o.outputWriter, string(output))
+	case outputTable:
+		header := []string{"Image", "Digest", "Size", "Platforms", "Components"}
+		var imageData [][]string
+		for _, info := range imageInfos {
+			imageData = append(imageData, []string{
+				info.Reference,
+				info.Digest,
+				utils.ByteFormat(float64(info.Size), 2),
+				strings.Join(info.Platforms, ", "),
+				strings.Join(info.Components, ", "),
+			})
+		}
+		message.TableWithWriter(o.outputWriter, header, imageData)
+	default:
+		return fmt.Errorf("unsupported output format: %s", o.outputFormat)
+	}
+	return nil
+}
+
 type packageInspectDefinitionOptions struct {
 	skipSignatureValidation bool
 }
@@ -552,223 +851,706 @@ func (o *packageInspectDefinitionOptions) run(cmd *cobra.Command, args []string)
 	return nil
 }
 
-type packageListOptions struct {
-	outputFormat outputFormat
-	outputWriter io.Writer
-	cluster      *cluster.Cluster
+type packageInspectNotesOptions struct {
+	skipSignatureValidation bool
+	outputWriter            io.Writer
 }
 
-func newPackageListOptions() *packageListOptions {
-	return &packageListOptions{
-		outputFormat: outputTable,
-		// TODO accept output writer as a parameter to the root Zarf command and pass it through here
-		outputWriter: message.OutputWriter,
+func newPackageInspectNotesOptions() *packageInspectNotesOptions {
+	return &packageInspectNotesOptions{
+		skipSignatureValidation: false,
+		outputWriter:            message.OutputWriter,
 	}
 }
 
-func newPackageListCommand() *cobra.Command {
-	o := newPackageListOptions()
-
+func newPackageInspectNotesCommand() *cobra.Command {
+	o := newPackageInspectNotesOptions()
 	cmd := &cobra.Command{
-		Use:     "list",
-		Aliases: []string{"l", "ls"},
-		Short:   lang.CmdPackageListShort,
-		RunE: func(cmd *cobra.Command, _ []string) error {
-			ctx := cmd.Context()
-			err := o.complete(ctx)
-			if err != nil {
-				return err
-			}
-			return o.run(ctx)
-		},
+		Use:   "notes [ PACKAGE_SOURCE ]",
+		Short: "Displays the changelog or release notes embedded in the specified package",
+		Args:  cobra.MaximumNArgs(1),
+		RunE:  o.run,
 	}
 
-	cmd.Flags().VarP(&o.outputFormat, "output-format", "o", "Prints the output in the specified format. Valid options: table, json, yaml")
+	cmd.Flags().BoolVar(&o.skipSignatureValidation, "skip-signature-validation", o.skipSignatureValidation, lang.CmdPackageFlagSkipSignatureValidation)
 
 	return cmd
 }
 
-func (o *packageListOptions) complete(ctx context.Context) error {
-	timeoutCtx, cancel := context.WithTimeout(ctx, cluster.DefaultTimeout)
-	defer cancel()
-	c, err := cluster.NewClusterWithWait(timeoutCtx)
+func (o *packageInspectNotesOptions) run(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	src, err := choosePackage(ctx, args)
 	if err != nil {
 		return err
 	}
-	o.cluster = c
-	return nil
-}
-
-// packageListInfo represents the package information for output.
-type packageListInfo struct {
-	Package    string   `json:"package"`
-	Version    string   `json:"version"`
-	Components []string `json:"components"`
-}
-
-func (o *packageListOptions) run(ctx context.Context) error {
-	deployedZarfPackages, err := o.cluster.GetDeployedZarfPackages(ctx)
-	if err != nil && len(deployedZarfPackages) == 0 {
-		return fmt.Errorf("unable to get the packages deployed to the cluster: %w", err)
+	loadOpt := packager2.LoadOptions{
+		Source:                  src,
+		SkipSignatureValidation: o.skipSignatureValidation,
+		Filter:                  filters.Empty(),
+		PublicKeyPath:           pkgConfig.PkgOpts.PublicKeyPath,
 	}
-
-	var packageList []packageListInfo
-	for _, pkg := range deployedZarfPackages {
-		var components []string
-		for _, component := range pkg.DeployedComponents {
-			components = append(components, component.Name)
-		}
-		packageList = append(packageList, packageListInfo{
-			Package:    pkg.Name,
-			Version:    pkg.Data.Metadata.Version,
-			Components: components,
-		})
+	pkgLayout, err := packager2.LoadPackage(ctx, loadOpt)
+	if err != nil {
+		return err
 	}
-
-	switch o.outputFormat {
-	case outputJSON:
-		output, err := json.MarshalIndent(packageList, "", "  ")
-		if err != nil {
-			return err
-		}
-		fmt.Fprintln(o.outputWriter, string(output))
-	case outputYAML:
-		output, err := goyaml.Marshal(packageList)
-		if err != nil {
-			return err
-		}
-		fmt.Fprint(o.outputWriter, string(output))
-	case outputTable:
-		header := []string{"Package", "Version", "Components"}
-		var packageData [][]string
-		for _, info := range packageList {
-			packageData = append(packageData, []string{
-				info.Package, info.Version, fmt.Sprintf("%v", info.Components),
-			})
-		}
-		message.TableWithWriter(o.outputWriter, header, packageData)
-	default:
-		return fmt.Errorf("unsupported output format: %s", o.outputFormat)
+	defer func() {
+		err = errors.Join(err, pkgLayout.Cleanup())
+	}()
+	notes, err := pkgLayout.GetReleaseNotes()
+	if err != nil {
+		return err
 	}
+	fmt.Fprint(o.outputWriter, notes)
 	return nil
 }
 
-type packageRemoveOptions struct{}
+type packageInspectBuildInfoOptions struct {
+	skipSignatureValidation bool
+	outputWriter            io.Writer
+}
 
-func newPackageRemoveCommand(v *viper.Viper) *cobra.Command {
-	o := &packageRemoveOptions{}
+func newPackageInspectBuildInfoOptions() *packageInspectBuildInfoOptions {
+	return &packageInspectBuildInfoOptions{
+		skipSignatureValidation: false,
+		outputWriter:            message.OutputWriter,
+	}
+}
 
+func newPackageInspectBuildInfoCommand() *cobra.Command {
+	o := newPackageInspectBuildInfoOptions()
 	cmd := &cobra.Command{
-		Use:               "remove { PACKAGE_SOURCE | PACKAGE_NAME } --confirm",
-		Aliases:           []string{"u", "rm"},
-		Args:              cobra.MaximumNArgs(1),
-		Short:             lang.CmdPackageRemoveShort,
-		Long:              lang.CmdPackageRemoveLong,
-		PreRun:            o.preRun,
-		RunE:              o.run,
-		ValidArgsFunction: getPackageCompletionArgs,
+		Use:   "build-info [ PACKAGE_SOURCE ]",
+		Short: "Displays how the specified package was created: the Zarf version, host, flags, config file and the digests of its input files",
+		Args:  cobra.MaximumNArgs(1),
+		RunE:  o.run,
 	}
 
-	cmd.Flags().BoolVar(&config.CommonOptions.Confirm, "confirm", false, lang.CmdPackageRemoveFlagConfirm)
-	_ = cmd.MarkFlagRequired("confirm")
-	cmd.Flags().StringVar(&pkgConfig.PkgOpts.OptionalComponents, "components", v.GetString(VPkgDeployComponents), lang.CmdPackageRemoveFlagComponents)
-	cmd.Flags().BoolVar(&pkgConfig.PkgOpts.SkipSignatureValidation, "skip-signature-validation", false, lang.CmdPackageFlagSkipSignatureValidation)
+	cmd.Flags().BoolVar(&o.skipSignatureValidation, "skip-signature-validation", o.skipSignatureValidation, lang.CmdPackageFlagSkipSignatureValidation)
 
 	return cmd
 }
 
-func (o *packageRemoveOptions) preRun(_ *cobra.Command, _ []string) {
-	// If --insecure was provided, set --skip-signature-validation to match
-	if config.CommonOptions.Insecure {
-		pkgConfig.PkgOpts.SkipSignatureValidation = true
-	}
-}
-
-func (o *packageRemoveOptions) run(cmd *cobra.Command, args []string) error {
+func (o *packageInspectBuildInfoOptions) run(cmd *cobra.Command, args []string) (err error) {
 	ctx := cmd.Context()
-	packageSource, err := choosePackage(ctx, args)
+	src, err := choosePackage(ctx, args)
 	if err != nil {
 		return err
 	}
-	filter := filters.Combine(
-		filters.ByLocalOS(runtime.GOOS),
-		filters.BySelectState(pkgConfig.PkgOpts.OptionalComponents),
-	)
-	cluster, _ := cluster.NewCluster() //nolint:errcheck
-	removeOpt := packager2.RemoveOptions{
-		Source:                  packageSource,
-		Cluster:                 cluster,
-		Filter:                  filter,
-		SkipSignatureValidation: pkgConfig.PkgOpts.SkipSignatureValidation,
+	loadOpt := packager2.LoadOptions{
+		Source:                  src,
+		SkipSignatureValidation: o.skipSignatureValidation,
+		Filter:                  filters.Empty(),
 		PublicKeyPath:           pkgConfig.PkgOpts.PublicKeyPath,
 	}
-	err = packager2.Remove(ctx, removeOpt)
+	pkgLayout, err := packager2.LoadPackage(ctx, loadOpt)
 	if err != nil {
 		return err
 	}
+	defer func() {
+		err = errors.Join(err, pkgLayout.Cleanup())
+	}()
+	info, err := pkgLayout.GetBuildInfo()
+	if err != nil {
+		return err
+	}
+	b, err := json.MarshalIndent(info, "", "  ")
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(o.outputWriter, string(b))
 	return nil
 }
 
-type packagePublishOptions struct{}
+type packageInspectManifestsOptions struct {
+	skipSignatureValidation bool
+	setVariables            map[string]string
+	kubeVersion             string
+	registryURL             string
+	outputWriter            io.Writer
+}
 
-func newPackagePublishCommand(v *viper.Viper) *cobra.Command {
-	o := &packagePublishOptions{}
+func newPackageInspectManifestsOptions() *packageInspectManifestsOptions {
+	return &packageInspectManifestsOptions{
+		skipSignatureValidation: false,
+		outputWriter:            message.OutputWriter,
+	}
+}
 
+func newPackageInspectManifestsCommand() *cobra.Command {
+	o := newPackageInspectManifestsOptions()
 	cmd := &cobra.Command{
-		Use:     "publish { PACKAGE_SOURCE | SKELETON DIRECTORY } REPOSITORY",
-		Short:   lang.CmdPackagePublishShort,
-		Example: lang.CmdPackagePublishExample,
-		Args:    cobra.ExactArgs(2),
-		PreRun:  o.preRun,
-		RunE:    o.run,
+		Use:   "manifests [ PACKAGE_SOURCE ]",
+		Short: "Renders the charts and manifests of the package to the Kubernetes YAML that would be applied on deploy (runs offline)",
+		Args:  cobra.MaximumNArgs(1),
+		RunE:  o.run,
 	}
 
-	cmd.Flags().StringVar(&pkgConfig.PublishOpts.SigningKeyPath, "signing-key", v.GetString(VPkgPublishSigningKey), lang.CmdPackagePublishFlagSigningKey)
-	cmd.Flags().StringVar(&pkgConfig.PublishOpts.SigningKeyPassword, "signing-key-pass", v.GetString(VPkgPublishSigningKeyPassword), lang.CmdPackagePublishFlagSigningKeyPassword)
-	cmd.Flags().BoolVar(&pkgConfig.PkgOpts.SkipSignatureValidation, "skip-signature-validation", false, lang.CmdPackageFlagSkipSignatureValidation)
-	cmd.Flags().BoolVar(&config.CommonOptions.Confirm, "confirm", false, lang.CmdPackagePublishFlagConfirm)
+	cmd.Flags().BoolVar(&o.skipSignatureValidation, "skip-signature-validation", o.skipSignatureValidation, lang.CmdPackageFlagSkipSignatureValidation)
+	cmd.Flags().StringToStringVar(&o.setVariables, "set", o.setVariables, lang.CmdPackageDeployFlagSet)
+	cmd.Flags().StringVar(&o.kubeVersion, "kube-version", o.kubeVersion, lang.CmdDevFlagKubeVersion)
+	cmd.Flags().StringVar(&o.registryURL, "registry-url", o.registryURL, lang.CmdDevFlagRegistry)
 
 	return cmd
 }
 
-func (o *packagePublishOptions) preRun(_ *cobra.Command, _ []string) {
-	// If --insecure was provided, set --skip-signature-validation to match
-	if config.CommonOptions.Insecure {
-		pkgConfig.PkgOpts.SkipSignatureValidation = true
+func (o *packageInspectManifestsOptions) run(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	src, err := choosePackage(ctx, args)
+	if err != nil {
+		return err
+	}
+	loadOpt := packager2.LoadOptions{
+		Source:                  src,
+		SkipSignatureValidation: o.skipSignatureValidation,
+		Filter:                  filters.Empty(),
+		PublicKeyPath:           pkgConfig.PkgOpts.PublicKeyPath,
+	}
+	pkgLayout, err := packager2.LoadPackage(ctx, loadOpt)
+	if err != nil {
+		return err
+	}
+	defer pkgLayout.Cleanup()
+
+	opt := packager2.InspectManifestsOptions{
+		SetVariables: helpers.TransformAndMergeMap(getViper().GetStringMapString(VPkgDeploySet), o.setVariables, strings.ToUpper),
+		KubeVersion:  o.kubeVersion,
+		RegistryURL:  o.registryURL,
+	}
+	rendered, err := packager2.InspectManifests(ctx, pkgLayout, opt)
+	if err != nil {
+		return err
+	}
+	for _, r := range rendered {
+		fmt.Fprintf(o.outputWriter, "# Component: %s, %s: %s, namespace: %s\n%s", r.Component, r.Kind, r.Name, r.Namespace, r.Content)
+	}
+	return nil
+}
+
+type packageInspectComplianceOptions struct {
+	skipSignatureValidation bool
+	setVariables            map[string]string
+	kubeVersion             string
+	registryURL             string
+	level                   string
+	policyPaths             []string
+	outputFormat            outputFormat
+	outputWriter            io.Writer
+}
+
+func newPackageInspectComplianceOptions() *packageInspectComplianceOptions {
+	return &packageInspectComplianceOptions{
+		skipSignatureValidation: false,
+		level:                   "restricted",
+		outputFormat:            outputTable,
+		outputWriter:            message.OutputWriter,
+	}
+}
+
+func newPackageInspectComplianceCommand() *cobra.Command {
+	o := newPackageInspectComplianceOptions()
+	cmd := &cobra.Command{
+		Use:   "compliance [ PACKAGE_SOURCE ]",
+		Short: lang.CmdPackageInspectComplianceShort,
+		Long:  lang.CmdPackageInspectComplianceLong,
+		Args:  cobra.MaximumNArgs(1),
+		RunE:  o.run,
+	}
+
+	cmd.Flags().BoolVar(&o.skipSignatureValidation, "skip-signature-validation", o.skipSignatureValidation, lang.CmdPackageFlagSkipSignatureValidation)
+	cmd.Flags().StringToStringVar(&o.setVariables, "set", o.setVariables, lang.CmdPackageDeployFlagSet)
+	cmd.Flags().StringVar(&o.kubeVersion, "kube-version", o.kubeVersion, lang.CmdDevFlagKubeVersion)
+	cmd.Flags().StringVar(&o.registryURL, "registry-url", o.registryURL, lang.CmdDevFlagRegistry)
+	cmd.Flags().StringVar(&o.level, "level", o.level, lang.CmdPackageInspectComplianceFlagLevel)
+	cmd.Flags().StringArrayVar(&o.policyPaths, "policy", o.policyPaths, lang.CmdPackageInspectComplianceFlagPolicy)
+	cmd.Flags().VarP(&o.outputFormat, "output", "o", lang.CmdPackageInspectComplianceFlagOut)
+
+	return cmd
+}
+
+func (o *packageInspectComplianceOptions) run(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	src, err := choosePackage(ctx, args)
+	if err != nil {
+		return err
+	}
+	loadOpt := packager2.LoadOptions{
+		Source:                  src,
+		SkipSignatureValidation: o.skipSignatureValidation,
+		Filter:                  filters.Empty(),
+		PublicKeyPath:           pkgConfig.PkgOpts.PublicKeyPath,
+	}
+	pkgLayout, err := packager2.LoadPackage(ctx, loadOpt)
+	if err != nil {
+		return err
+	}
+	defer pkgLayout.Cleanup()
+
+	opt := packager2.InspectComplianceOptions{
+		SetVariables:     helpers.TransformAndMergeMap(getViper().GetStringMapString(VPkgDeploySet), o.setVariables, strings.ToUpper),
+		KubeVersion:      o.kubeVersion,
+		RegistryURL:      o.registryURL,
+		PodSecurityLevel: o.level,
+		PolicyPaths:      o.policyPaths,
+	}
+	violations, err := packager2.InspectCompliance(ctx, pkgLayout, opt)
+	if err != nil {
+		return err
+	}
+
+	switch o.outputFormat {
+	case outputJSON:
+		output, err := json.MarshalIndent(violations, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(o.outputWriter, string(output))
+	case outputYAML:
+		output, err := goyaml.Marshal(violations)
+		if err != nil {
+			return err
+		}
+		fmt.Fprint(o.outputWriter, string(output))
+	case outputTable:
+		if len(violations) == 0 {
+			break
+		}
+		header := []string{"Component", "Namespace", "Resource", "Policy", "Message"}
+		var violationData [][]string
+		for _, v := range violations {
+			violationData = append(violationData, []string{v.Component, v.Namespace, v.Resource, v.Policy, v.Message})
+		}
+		message.TableWithWriter(o.outputWriter, header, violationData)
+	default:
+		return fmt.Errorf("unsupported output format: %s", o.outputFormat)
+	}
+
+	if len(violations) > 0 {
+		return fmt.Errorf("found %d compliance violations in package %s", len(violations), pkgLayout.Pkg.Metadata.Name)
+	}
+	logger.From(ctx).Info("package complies with the policies", "name", pkgLayout.Pkg.Metadata.Name)
+	return nil
+}
+
+type packageInspectLicensesOptions struct {
+	skipSignatureValidation bool
+	policyPath              string
+	outputFormat            outputFormat
+	outputWriter            io.Writer
+}
+
+func newPackageInspectLicensesOptions() *packageInspectLicensesOptions {
+	return &packageInspectLicensesOptions{
+		skipSignatureValidation: false,
+		outputFormat:            outputTable,
+		outputWriter:            message.OutputWriter,
+	}
+}
+
+func newPackageInspectLicensesCommand() *cobra.Command {
+	o := newPackageInspectLicensesOptions()
+	cmd := &cobra.Command{
+		Use:   "licenses [ PACKAGE_SOURCE ]",
+		Short: lang.CmdPackageInspectLicensesShort,
+		Long:  lang.CmdPackageInspectLicensesLong,
+		Args:  cobra.MaximumNArgs(1),
+		RunE:  o.run,
+	}
+
+	cmd.Flags().BoolVar(&o.skipSignatureValidation, "skip-signature-validation", o.skipSignatureValidation, lang.CmdPackageFlagSkipSignatureValidation)
+	cmd.Flags().StringVar(&o.policyPath, "policy", o.policyPath, lang.CmdPackageInspectLicensesFlagPolicy)
+	cmd.Flags().VarP(&o.outputFormat, "output", "o", lang.CmdPackageInspectLicensesFlagOut)
+
+	return cmd
+}
+
+func (o *packageInspectLicensesOptions) run(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	opt := packager2.InspectLicensesOptions{}
+	if o.policyPath != "" {
+		if err := utils.ReadYaml(o.policyPath, &opt.Policy); err != nil {
+			return fmt.Errorf("unable to read the license policy: %w", err)
+		}
+	}
+	src, err := choosePackage(ctx, args)
+	if err != nil {
+		return err
+	}
+	loadOpt := packager2.LoadOptions{
+		Source:                  src,
+		SkipSignatureValidation: o.skipSignatureValidation,
+		Filter:                  filters.Empty(),
+		PublicKeyPath:           pkgConfig.PkgOpts.PublicKeyPath,
+	}
+	pkgLayout, err := packager2.LoadPackage(ctx, loadOpt)
+	if err != nil {
+		return err
+	}
+	defer pkgLayout.Cleanup()
+
+	report, err := packager2.InspectLicenses(ctx, pkgLayout, opt)
+	if err != nil {
+		return err
+	}
+
+	switch o.outputFormat {
+	case outputJSON:
+		output, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(o.outputWriter, string(output))
+	case outputYAML:
+		output, err := goyaml.Marshal(report)
+		if err != nil {
+			return err
+		}
+		fmt.Fprint(o.outputWriter, string(output))
+	case outputTable:
+		header := []string{"License", "Packages", "Sources", "Allowed"}
+		var licenseData [][]string
+		for _, l := range report.Licenses {
+			licenseData = append(licenseData, []string{l.License, strconv.Itoa(l.Packages), strings.Join(l.Sources, ", "), strconv.FormatBool(l.Allowed)})
+		}
+		message.TableWithWriter(o.outputWriter, header, licenseData)
+		if len(report.Images) > 0 {
+			header = []string{"Image", "Distro", "Base Images"}
+			var imageData [][]string
+			for _, image := range report.Images {
+				imageData = append(imageData, []string{image.Reference, image.Distro, strings.Join(image.Bases, " <- ")})
+			}
+			message.TableWithWriter(o.outputWriter, header, imageData)
+		}
+		if len(report.Violations) > 0 {
+			header = []string{"Source", "Package", "Version", "License"}
+			var violationData [][]string
+			for _, v := range report.Violations {
+				violationData = append(violationData, []string{v.Source, v.Package, v.Version, v.License})
+			}
+			message.TableWithWriter(o.outputWriter, header, violationData)
+		}
+	default:
+		return fmt.Errorf("unsupported output format: %s", o.outputFormat)
+	}
+
+	if len(report.Violations) > 0 {
+		return fmt.Errorf("found %d packages with disallowed licenses in package %s", len(report.Violations), pkgLayout.Pkg.Metadata.Name)
+	}
+	return nil
+}
+
+type packageInspectProvenanceOptions struct {
+	outputFormat outputFormat
+	outputWriter io.Writer
+}
+
+func newPackageInspectProvenanceOptions() *packageInspectProvenanceOptions {
+	return &packageInspectProvenanceOptions{
+		outputFormat: outputTable,
+		outputWriter: message.OutputWriter,
+	}
+}
+
+func newPackageInspectProvenanceCommand() *cobra.Command {
+	o := newPackageInspectProvenanceOptions()
+	cmd := &cobra.Command{
+		Use:   "provenance PACKAGE_SOURCE",
+		Short: lang.CmdPackageInspectProvenanceShort,
+		Long:  lang.CmdPackageInspectProvenanceLong,
+		Args:  cobra.ExactArgs(1),
+		RunE:  o.run,
+	}
+
+	cmd.Flags().VarP(&o.outputFormat, "output", "o", lang.CmdPackageInspectProvenanceFlagOut)
+
+	return cmd
+}
+
+func (o *packageInspectProvenanceOptions) run(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	opt := packager2.InspectProvenanceOptions{
+		WithPlainHTTP: config.CommonOptions.PlainHTTP,
+		Architecture:  config.GetArch(),
+	}
+	records, err := packager2.InspectProvenance(ctx, args[0], opt)
+	if err != nil {
+		return err
+	}
+
+	switch o.outputFormat {
+	case outputJSON:
+		output, err := json.MarshalIndent(records, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(o.outputWriter, string(output))
+	case outputYAML:
+		output, err := goyaml.Marshal(records)
+		if err != nil {
+			return err
+		}
+		fmt.Fprint(o.outputWriter, string(output))
+	case outputTable:
+		if len(records) == 0 {
+			logger.From(ctx).Info("package has no provenance records, it was published directly to this registry", "reference", args[0])
+			return nil
+		}
+		header := []string{"Source", "Destination", "Copied By", "Copied At", "Zarf Version"}
+		var recordData [][]string
+		for _, r := range records {
+			recordData = append(recordData, []string{r.Source, r.Destination, r.CopiedBy, r.CopiedAt.Format(time.RFC3339), r.ZarfVersion})
+		}
+		message.TableWithWriter(o.outputWriter, header, recordData)
+	default:
+		return fmt.Errorf("unsupported output format: %s", o.outputFormat)
+	}
+	return nil
+}
+
+type packageListOptions struct {
+	outputFormat outputFormat
+	outputWriter io.Writer
+	cluster      *cluster.Cluster
+}
+
+func newPackageListOptions() *packageListOptions {
+	return &packageListOptions{
+		outputFormat: outputTable,
+		// TODO accept output writer as a parameter to the root Zarf command and pass it through here
+		outputWriter: message.OutputWriter,
+	}
+}
+
+func newPackageListCommand() *cobra.Command {
+	o := newPackageListOptions()
+
+	cmd := &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"l", "ls"},
+		Short:   lang.CmdPackageListShort,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := cmd.Context()
+			err := o.complete(ctx)
+			if err != nil {
+				return err
+			}
+			return o.run(ctx)
+		},
+	}
+
+	cmd.Flags().VarP(&o.outputFormat, "output-format", "o", "Prints the output in the specified format. Valid options: table, json, yaml")
+
+	return cmd
+}
+
+func (o *packageListOptions) complete(ctx context.Context) error {
+	timeoutCtx, cancel := context.WithTimeout(ctx, cluster.DefaultTimeout)
+	defer cancel()
+	c, err := cluster.NewClusterWithWait(timeoutCtx)
+	if err != nil {
+		return err
+	}
+	o.cluster = c
+	return nil
+}
+
+// packageListInfo represents the package information for output.
+type packageListInfo struct {
+	Package    string   `json:"package"`
+	Version    string   `json:"version"`
+	Components []string `json:"components"`
+}
+
+func (o *packageListOptions) run(ctx context.Context) error {
+	deployedZarfPackages, err := o.cluster.GetDeployedZarfPackages(ctx)
+	if err != nil && len(deployedZarfPackages) == 0 {
+		return fmt.Errorf("unable to get the packages deployed to the cluster: %w", err)
+	}
+
+	var packageList []packageListInfo
+	for _, pkg := range deployedZarfPackages {
+		var components []string
+		for _, component := range pkg.DeployedComponents {
+			components = append(components, component.Name)
+		}
+		packageList = append(packageList, packageListInfo{
+			Package:    pkg.Name,
+			Version:    pkg.Data.Metadata.Version,
+			Components: components,
+		})
+	}
+
+	switch o.outputFormat {
+	case outputJSON:
+		output, err := json.MarshalIndent(packageList, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(o.outputWriter, string(output))
+	case outputYAML:
+		output, err := goyaml.Marshal(packageList)
+		if err != nil {
+			return err
+		}
+		fmt.Fprint(o.outputWriter, string(output))
+	case outputTable:
+		header := []string{"Package", "Version", "Components"}
+		var packageData [][]string
+		for _, info := range packageList {
+			packageData = append(packageData, []string{
+				info.Package, info.Version, fmt.Sprintf("%v", info.Components),
+			})
+		}
+		message.TableWithWriter(o.outputWriter, header, packageData)
+	default:
+		return fmt.Errorf("unsupported output format: %s", o.outputFormat)
+	}
+	return nil
+}
+
+type packageRemoveOptions struct {
+	timeout            time.Duration
+	force              bool
+	allowHostServices  bool
+	allowHostArtifacts bool
+}
+
+func newPackageRemoveCommand(v *viper.Viper) *cobra.Command {
+	o := &packageRemoveOptions{}
+
+	cmd := &cobra.Command{
+		Use:               "remove { PACKAGE_SOURCE | PACKAGE_NAME } --confirm",
+		Aliases:           []string{"u", "rm"},
+		Args:              cobra.MaximumNArgs(1),
+		Short:             lang.CmdPackageRemoveShort,
+		Long:              lang.CmdPackageRemoveLong,
+		PreRun:            o.preRun,
+		RunE:              o.run,
+		ValidArgsFunction: getPackageCompletionArgs,
+	}
+
+	cmd.Flags().BoolVar(&config.CommonOptions.Confirm, "confirm", false, lang.CmdPackageRemoveFlagConfirm)
+	_ = cmd.MarkFlagRequired("confirm")
+	cmd.Flags().StringVar(&pkgConfig.PkgOpts.OptionalComponents, "components", v.GetString(VPkgDeployComponents), lang.CmdPackageRemoveFlagComponents)
+	cmd.Flags().DurationVar(&o.timeout, "timeout", v.GetDuration(VPkgRemoveTimeout), lang.CmdPackageRemoveFlagTimeout)
+	cmd.Flags().BoolVar(&o.force, "force", v.GetBool(VPkgRemoveForce), lang.CmdPackageRemoveFlagForce)
+	cmd.Flags().BoolVar(&o.allowHostServices, "allow-host-services", false, lang.CmdPackageRemoveFlagAllowHostServices)
+	cmd.Flags().BoolVar(&o.allowHostArtifacts, "allow-host-artifacts", false, lang.CmdPackageRemoveFlagAllowHostArtifacts)
+	cmd.Flags().BoolVar(&pkgConfig.PkgOpts.SkipSignatureValidation, "skip-signature-validation", false, lang.CmdPackageFlagSkipSignatureValidation)
+
+	return cmd
+}
+
+func (o *packageRemoveOptions) preRun(_ *cobra.Command, _ []string) {
+	// If --insecure was provided, set --skip-signature-validation to match
+	if config.CommonOptions.Insecure {
+		pkgConfig.PkgOpts.SkipSignatureValidation = true
+	}
+}
+
+func (o *packageRemoveOptions) run(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	packageSource, err := choosePackage(ctx, args)
+	if err != nil {
+		return err
+	}
+	filter := filters.Combine(
+		filters.ByLocalOS(runtime.GOOS),
+		filters.BySelectState(pkgConfig.PkgOpts.OptionalComponents),
+	)
+	cluster, _ := cluster.NewCluster() //nolint:errcheck
+	removeOpt := packager2.RemoveOptions{
+		Source:                  packageSource,
+		Cluster:                 cluster,
+		Filter:                  filter,
+		SkipSignatureValidation: pkgConfig.PkgOpts.SkipSignatureValidation,
+		PublicKeyPath:           pkgConfig.PkgOpts.PublicKeyPath,
+		Timeout:                 o.timeout,
+		Force:                   o.force,
+		AllowHostServices:       o.allowHostServices,
+		AllowHostArtifacts:      o.allowHostArtifacts,
+	}
+	err = packager2.Remove(ctx, removeOpt)
+	if err != nil {
+		return err
 	}
+	return nil
 }
 
-func (o *packagePublishOptions) run(cmd *cobra.Command, args []string) error {
-	packageSource := args[0]
+type packagePublishOptions struct {
+	retag []string
+}
+
+func newPackagePublishCommand(v *viper.Viper) *cobra.Command {
+	o := &packagePublishOptions{}
 
-	if !helpers.IsOCIURL(args[1]) {
-		return errors.New("registry must be prefixed with 'oci://'")
+	cmd := &cobra.Command{
+		Use:     "publish { PACKAGE_SOURCE | SKELETON DIRECTORY } REPOSITORY [REPOSITORY...]",
+		Short:   lang.CmdPackagePublishShort,
+		Example: lang.CmdPackagePublishExample,
+		Args:    cobra.MinimumNArgs(2),
+		PreRun:  o.preRun,
+		RunE:    o.run,
 	}
 
-	// Destination Repository
-	parts := strings.Split(strings.TrimPrefix(args[1], helpers.OCIURLPrefix), "/")
-	ref := registry.Reference{
-		Registry:   parts[0],
-		Repository: strings.Join(parts[1:], "/"),
+	cmd.Flags().StringVar(&pkgConfig.PublishOpts.SigningKeyPath, "signing-key", v.GetString(VPkgPublishSigningKey), lang.CmdPackagePublishFlagSigningKey)
+	cmd.Flags().StringVar(&pkgConfig.PublishOpts.SigningKeyPassword, "signing-key-pass", v.GetString(VPkgPublishSigningKeyPassword), lang.CmdPackagePublishFlagSigningKeyPassword)
+	cmd.Flags().BoolVar(&pkgConfig.PkgOpts.SkipSignatureValidation, "skip-signature-validation", false, lang.CmdPackageFlagSkipSignatureValidation)
+	cmd.Flags().BoolVar(&config.CommonOptions.Confirm, "confirm", false, lang.CmdPackagePublishFlagConfirm)
+	cmd.Flags().StringSliceVar(&o.retag, "retag", v.GetStringSlice(VPkgPublishRetag), lang.CmdPackagePublishFlagRetag)
+
+	return cmd
+}
+
+func (o *packagePublishOptions) preRun(_ *cobra.Command, _ []string) {
+	// If --insecure was provided, set --skip-signature-validation to match
+	if config.CommonOptions.Insecure {
+		pkgConfig.PkgOpts.SkipSignatureValidation = true
 	}
-	err := ref.ValidateRegistry()
-	if err != nil {
-		return err
+}
+
+func (o *packagePublishOptions) run(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	packageSource := args[0]
+
+	// Destination Repositories
+	dsts := []registry.Reference{}
+	for _, dst := range args[1:] {
+		if !helpers.IsOCIURL(dst) {
+			return errors.New("registry must be prefixed with 'oci://'")
+		}
+		parts := strings.Split(strings.TrimPrefix(dst, helpers.OCIURLPrefix), "/")
+		ref := registry.Reference{
+			Registry:   parts[0],
+			Repository: strings.Join(parts[1:], "/"),
+		}
+		err := ref.ValidateRegistry()
+		if err != nil {
+			return err
+		}
+		dsts = append(dsts, ref)
 	}
 
+	var results []packager2.PublishResult
+	switch {
 	// Skeleton package - call PublishSkeleton
-	if helpers.IsDir(packageSource) {
+	case helpers.IsDir(packageSource):
 		skeletonOpts := packager2.PublishSkeletonOpts{
 			Concurrency:        config.CommonOptions.OCIConcurrency,
 			SigningKeyPath:     pkgConfig.PublishOpts.SigningKeyPath,
 			SigningKeyPassword: pkgConfig.PublishOpts.SigningKeyPassword,
 			WithPlainHTTP:      config.CommonOptions.PlainHTTP,
+			Retag:              o.retag,
 		}
-
-		return packager2.PublishSkeleton(cmd.Context(), packageSource, ref, skeletonOpts)
-	}
-
-	if helpers.IsOCIURL(packageSource) {
+		var err error
+		results, err = packager2.PublishSkeletonToDestinations(ctx, packageSource, dsts, skeletonOpts)
+		if err != nil {
+			return err
+		}
+	case helpers.IsOCIURL(packageSource):
 		ociOpts := packager2.PublishFromOCIOpts{
 			Concurrency:             config.CommonOptions.OCIConcurrency,
 			SigningKeyPath:          pkgConfig.PublishOpts.SigningKeyPath,
@@ -777,6 +1559,7 @@ func (o *packagePublishOptions) run(cmd *cobra.Command, args []string) error {
 			WithPlainHTTP:           config.CommonOptions.PlainHTTP,
 			PublicKeyPath:           pkgConfig.PkgOpts.PublicKeyPath,
 			Architecture:            config.GetArch(),
+			Retag:                   o.retag,
 		}
 
 		// source registry reference
@@ -791,26 +1574,55 @@ func (o *packagePublishOptions) run(cmd *cobra.Command, args []string) error {
 		srcParts := strings.Split(srcRegistry.Repository, "/")
 		srcPackageName := srcParts[len(srcParts)-1]
 
-		ref.Repository = fmt.Sprintf("%s/%s", ref.Repository, srcPackageName)
-		ref.Reference = srcRegistry.Reference
-
-		return packager2.PublishFromOCI(cmd.Context(), srcRegistry, ref, ociOpts)
+		results = packager2.PublishToDestinations(ctx, dsts, func(ctx context.Context, ref registry.Reference) error {
+			ref.Repository = fmt.Sprintf("%s/%s", ref.Repository, srcPackageName)
+			ref.Reference = srcRegistry.Reference
+			return packager2.PublishFromOCI(ctx, srcRegistry, ref, ociOpts)
+		})
+	default:
+		publishPackageOpts := packager2.PublishPackageOpts{
+			Concurrency:             config.CommonOptions.OCIConcurrency,
+			SigningKeyPath:          pkgConfig.PublishOpts.SigningKeyPath,
+			SigningKeyPassword:      pkgConfig.PublishOpts.SigningKeyPassword,
+			SkipSignatureValidation: pkgConfig.PkgOpts.SkipSignatureValidation,
+			WithPlainHTTP:           config.CommonOptions.PlainHTTP,
+			PublicKeyPath:           pkgConfig.PkgOpts.PublicKeyPath,
+			Architecture:            config.GetArch(),
+			Retag:                   o.retag,
+		}
+		var err error
+		results, err = packager2.PublishPackageToDestinations(ctx, packageSource, dsts, publishPackageOpts)
+		if err != nil {
+			return err
+		}
 	}
 
-	publishPackageOpts := packager2.PublishPackageOpts{
-		Concurrency:             config.CommonOptions.OCIConcurrency,
-		SigningKeyPath:          pkgConfig.PublishOpts.SigningKeyPath,
-		SigningKeyPassword:      pkgConfig.PublishOpts.SigningKeyPassword,
-		SkipSignatureValidation: pkgConfig.PkgOpts.SkipSignatureValidation,
-		WithPlainHTTP:           config.CommonOptions.PlainHTTP,
-		PublicKeyPath:           pkgConfig.PkgOpts.PublicKeyPath,
-		Architecture:            config.GetArch(),
+	if len(results) == 1 {
+		return results[0].Err
 	}
 
-	return packager2.PublishPackage(cmd.Context(), packageSource, ref, publishPackageOpts)
+	header := []string{"Destination", "Status"}
+	rows := [][]string{}
+	errs := []error{}
+	for _, result := range results {
+		status := "published"
+		if result.Err != nil {
+			status = fmt.Sprintf("failed: %s", result.Err)
+			errs = append(errs, fmt.Errorf("%s: %w", result.Destination, result.Err))
+		}
+		rows = append(rows, []string{helpers.OCIURLPrefix + result.Destination.String(), status})
+	}
+	message.TableWithWriter(message.OutputWriter, header, rows)
+	if len(errs) > 0 {
+		return fmt.Errorf("failed to publish to %d of %d destinations: %w", len(errs), len(dsts), errors.Join(errs...))
+	}
+	return nil
 }
 
-type packagePullOptions struct{}
+type packagePullOptions struct {
+	components string
+	layers     []string
+}
 
 func newPackagePullCommand(v *viper.Viper) *cobra.Command {
 	o := &packagePullOptions{}
@@ -826,6 +1638,9 @@ func newPackagePullCommand(v *viper.Viper) *cobra.Command {
 	cmd.Flags().StringVar(&pkgConfig.PkgOpts.Shasum, "shasum", "", lang.CmdPackagePullFlagShasum)
 	cmd.Flags().StringVarP(&pkgConfig.PullOpts.OutputDirectory, "output-directory", "o", v.GetString(VPkgPullOutputDir), lang.CmdPackagePullFlagOutputDirectory)
 	cmd.Flags().BoolVar(&pkgConfig.PkgOpts.SkipSignatureValidation, "skip-signature-validation", false, lang.CmdPackageFlagSkipSignatureValidation)
+	cmd.Flags().StringVar(&o.components, "components", "", lang.CmdPackagePullFlagComponents)
+	cmd.Flags().StringSliceVar(&o.layers, "layers", nil, lang.CmdPackagePullFlagLayers)
+	cmd.MarkFlagsMutuallyExclusive("components", "layers")
 
 	return cmd
 }
@@ -839,10 +1654,319 @@ func (o *packagePullOptions) run(cmd *cobra.Command, args []string) error {
 		}
 		outputDir = wd
 	}
-	err := packager2.Pull(cmd.Context(), args[0], outputDir, pkgConfig.PkgOpts.Shasum, config.GetArch(), filters.Empty(), pkgConfig.PkgOpts.PublicKeyPath, pkgConfig.PkgOpts.SkipSignatureValidation)
+	pullOpt := packager2.PullOptions{
+		Shasum:                  pkgConfig.PkgOpts.Shasum,
+		Architecture:            config.GetArch(),
+		PublicKeyPath:           pkgConfig.PkgOpts.PublicKeyPath,
+		SkipSignatureValidation: pkgConfig.PkgOpts.SkipSignatureValidation,
+		Filter:                  filters.BySelectState(o.components),
+		Layers:                  o.layers,
+	}
+	err := packager2.Pull(cmd.Context(), args[0], outputDir, pullOpt)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+type packageCopyOptions struct {
+	countersignKeyPath     string
+	countersignKeyPassword string
+}
+
+func newPackageCopyCommand(v *viper.Viper) *cobra.Command {
+	o := &packageCopyOptions{}
+
+	cmd := &cobra.Command{
+		Use:     "copy SOURCE DESTINATION",
+		Short:   lang.CmdPackageCopyShort,
+		Long:    lang.CmdPackageCopyLong,
+		Example: lang.CmdPackageCopyExample,
+		Args:    cobra.ExactArgs(2),
+		PreRun:  o.preRun,
+		RunE:    o.run,
+	}
+
+	cmd.Flags().StringVar(&o.countersignKeyPath, "signing-key", v.GetString(VPkgCopySigningKey), lang.CmdPackageCopyFlagSigningKey)
+	cmd.Flags().StringVar(&o.countersignKeyPassword, "signing-key-pass", v.GetString(VPkgCopySigningKeyPassword), lang.CmdPackageCopyFlagSigningKeyPassword)
+	cmd.Flags().BoolVar(&pkgConfig.PkgOpts.SkipSignatureValidation, "skip-signature-validation", false, lang.CmdPackageFlagSkipSignatureValidation)
+
+	return cmd
+}
+
+func (o *packageCopyOptions) preRun(_ *cobra.Command, _ []string) {
+	// If --insecure was provided, set --skip-signature-validation to match
+	if config.CommonOptions.Insecure {
+		pkgConfig.PkgOpts.SkipSignatureValidation = true
+	}
+}
+
+func (o *packageCopyOptions) run(cmd *cobra.Command, args []string) error {
+	for _, arg := range args {
+		if !helpers.IsOCIURL(arg) {
+			return errors.New("source and destination must be prefixed with 'oci://'")
+		}
+	}
+	copyOpts := packager2.CopyOptions{
+		Concurrency:             config.CommonOptions.OCIConcurrency,
+		PublicKeyPath:           pkgConfig.PkgOpts.PublicKeyPath,
+		SkipSignatureValidation: pkgConfig.PkgOpts.SkipSignatureValidation,
+		CountersignKeyPath:      o.countersignKeyPath,
+		CountersignKeyPassword:  o.countersignKeyPassword,
+		WithPlainHTTP:           config.CommonOptions.PlainHTTP,
+		Architecture:            config.GetArch(),
+	}
+	err := packager2.Copy(cmd.Context(), args[0], args[1], copyOpts)
+	if err != nil {
+		return fmt.Errorf("failed to copy package: %w", err)
+	}
+	return nil
+}
+
+type packageVersionsOptions struct {
+	outputFormat outputFormat
+	outputWriter io.Writer
+}
+
+func newPackageVersionsOptions() *packageVersionsOptions {
+	return &packageVersionsOptions{
+		outputFormat: outputTable,
+		outputWriter: message.OutputWriter,
+	}
+}
+
+func newPackageVersionsCommand() *cobra.Command {
+	o := newPackageVersionsOptions()
+
+	cmd := &cobra.Command{
+		Use:     "versions REPOSITORY",
+		Short:   lang.CmdPackageVersionsShort,
+		Long:    lang.CmdPackageVersionsLong,
+		Example: lang.CmdPackageVersionsExample,
+		Args:    cobra.ExactArgs(1),
+		RunE:    o.run,
+	}
+
+	cmd.Flags().VarP(&o.outputFormat, "output-format", "o", "Prints the output in the specified format. Valid options: table, json, yaml")
+
+	return cmd
+}
+
+func (o *packageVersionsOptions) run(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	if !helpers.IsOCIURL(args[0]) {
+		return errors.New("repository must be prefixed with 'oci://'")
+	}
+	remote, err := zoci.NewRemote(ctx, args[0], oci.PlatformForArch(config.GetArch()), oci.WithPlainHTTP(config.CommonOptions.PlainHTTP))
+	if err != nil {
+		return err
+	}
+	versions, err := remote.ListVersions(ctx)
+	if err != nil {
+		return err
+	}
+
+	switch o.outputFormat {
+	case outputJSON:
+		output, err := json.MarshalIndent(versions, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(o.outputWriter, string(output))
+	case outputYAML:
+		output, err := goyaml.Marshal(versions)
+		if err != nil {
+			return err
+		}
+		fmt.Fprint(o.outputWriter, string(output))
+	case outputTable:
+		header := []string{"Tag", "Platforms", "Digest"}
+		var versionData [][]string
+		for _, version := range versions {
+			versionData = append(versionData, []string{
+				version.Tag, strings.Join(version.Platforms, ", "), version.Digest,
+			})
+		}
+		message.TableWithWriter(o.outputWriter, header, versionData)
+	default:
+		return fmt.Errorf("unsupported output format: %s", o.outputFormat)
+	}
+	return nil
+}
+
+type packageConvertOptions struct {
+	to                      string
+	registryURL             string
+	noImgChecksum           bool
+	outputDirectory         string
+	setVariables            map[string]string
+	kubeVersion             string
+	skipSignatureValidation bool
+}
+
+func newPackageConvertOptions() *packageConvertOptions {
+	return &packageConvertOptions{
+		outputDirectory: ".",
+	}
+}
+
+func newPackageConvertCommand() *cobra.Command {
+	o := newPackageConvertOptions()
+
+	cmd := &cobra.Command{
+		Use:     "convert [ PACKAGE_SOURCE ]",
+		Short:   lang.CmdPackageConvertShort,
+		Long:    lang.CmdPackageConvertLong,
+		Example: lang.CmdPackageConvertExample,
+		Args:    cobra.MaximumNArgs(1),
+		PreRunE: o.preRun,
+		RunE:    o.run,
+	}
+
+	cmd.Flags().StringVar(&o.to, "to", o.to, lang.CmdPackageConvertFlagTo)
+	cmd.Flags().StringVar(&o.registryURL, "registry-url", o.registryURL, lang.CmdPackageConvertFlagRegistryURL)
+	cmd.Flags().BoolVar(&o.noImgChecksum, "no-img-checksum", o.noImgChecksum, lang.CmdPackageMirrorFlagNoChecksum)
+	cmd.Flags().StringVarP(&o.outputDirectory, "output-directory", "o", o.outputDirectory, lang.CmdPackageConvertFlagOutput)
+	cmd.Flags().StringToStringVar(&o.setVariables, "set", o.setVariables, lang.CmdPackageDeployFlagSet)
+	cmd.Flags().StringVar(&o.kubeVersion, "kube-version", o.kubeVersion, lang.CmdDevFlagKubeVersion)
+	cmd.Flags().BoolVar(&o.skipSignatureValidation, "skip-signature-validation", o.skipSignatureValidation, lang.CmdPackageFlagSkipSignatureValidation)
+	_ = cmd.MarkFlagRequired("to")
+	_ = cmd.MarkFlagRequired("registry-url")
+
+	return cmd
+}
+
+func (o *packageConvertOptions) preRun(_ *cobra.Command, _ []string) error {
+	if !slices.Contains(packager2.ConvertFormats, o.to) {
+		return fmt.Errorf("invalid format %q, must be one of %s", o.to, strings.Join(packager2.ConvertFormats, ", "))
+	}
+	return nil
+}
+
+func (o *packageConvertOptions) run(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	var imageRules []transform.ImageRule
+	err := getViper().UnmarshalKey(VInitRegistryImageRules, &imageRules)
+	if err != nil {
+		return fmt.Errorf("invalid %s config: %w", VInitRegistryImageRules, err)
+	}
+	if err := transform.ValidateImageRules(imageRules); err != nil {
+		return fmt.Errorf("invalid registry image rules: %w", err)
+	}
+	src, err := choosePackage(ctx, args)
+	if err != nil {
+		return err
+	}
+	loadOpt := packager2.LoadOptions{
+		Source:                  src,
+		SkipSignatureValidation: o.skipSignatureValidation,
+		Filter:                  filters.Empty(),
+		PublicKeyPath:           pkgConfig.PkgOpts.PublicKeyPath,
+	}
+	pkgLayout, err := packager2.LoadPackage(ctx, loadOpt)
+	if err != nil {
+		return err
+	}
+	defer pkgLayout.Cleanup()
+
+	opt := packager2.ConvertOptions{
+		To:            o.to,
+		SetVariables:  helpers.TransformAndMergeMap(getViper().GetStringMapString(VPkgDeploySet), o.setVariables, strings.ToUpper),
+		KubeVersion:   o.kubeVersion,
+		RegistryURL:   o.registryURL,
+		NoImgChecksum: o.noImgChecksum,
+		ImageRules:    imageRules,
+		OutputDir:     o.outputDirectory,
+	}
+	dir, err := packager2.Convert(ctx, pkgLayout, opt)
+	if err != nil {
+		return err
+	}
+	logger.From(ctx).Info("converted package", "name", pkgLayout.Pkg.Metadata.Name, "format", o.to, "path", dir)
+	return nil
+}
+
+type packageSignOptions struct {
+	signingKeyPath     string
+	signingKeyPassword string
+	outputDirectory    string
+	resign             bool
+}
+
+func newPackageSignCommand(v *viper.Viper) *cobra.Command {
+	o := &packageSignOptions{}
+
+	cmd := &cobra.Command{
+		Use:     "sign [ PACKAGE_SOURCE ]",
+		Short:   lang.CmdPackageSignShort,
+		Long:    lang.CmdPackageSignLong,
+		Example: lang.CmdPackageSignExample,
+		Args:    cobra.MaximumNArgs(1),
+		RunE:    o.run,
+	}
+
+	o.addFlags(cmd, v)
+
+	return cmd
+}
+
+func newPackageResignCommand(v *viper.Viper) *cobra.Command {
+	o := &packageSignOptions{resign: true}
+
+	cmd := &cobra.Command{
+		Use:     "resign [ PACKAGE_SOURCE ]",
+		Short:   lang.CmdPackageResignShort,
+		Long:    lang.CmdPackageResignLong,
+		Example: lang.CmdPackageResignExample,
+		Args:    cobra.MaximumNArgs(1),
+		PreRun:  o.preRun,
+		RunE:    o.run,
+	}
+
+	o.addFlags(cmd, v)
+	cmd.Flags().BoolVar(&pkgConfig.PkgOpts.SkipSignatureValidation, "skip-signature-validation", false, lang.CmdPackageResignFlagSkipSignatureValidation)
+
+	return cmd
+}
+
+func (o *packageSignOptions) addFlags(cmd *cobra.Command, v *viper.Viper) {
+	cmd.Flags().StringVar(&o.signingKeyPath, "signing-key", v.GetString(VPkgSignSigningKey), lang.CmdPackageSignFlagSigningKey)
+	cmd.Flags().StringVar(&o.signingKeyPassword, "signing-key-pass", v.GetString(VPkgSignSigningKeyPassword), lang.CmdPackageSignFlagSigningKeyPassword)
+	cmd.Flags().StringVarP(&o.outputDirectory, "output-directory", "o", "", lang.CmdPackageSignFlagOutput)
+}
+
+func (o *packageSignOptions) preRun(_ *cobra.Command, _ []string) {
+	// If --insecure was provided, set --skip-signature-validation to match
+	if config.CommonOptions.Insecure {
+		pkgConfig.PkgOpts.SkipSignatureValidation = true
+	}
+}
+
+func (o *packageSignOptions) run(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	src, err := choosePackage(ctx, args)
 	if err != nil {
 		return err
 	}
+	if o.signingKeyPath == "" {
+		return errors.New("a signing key is required, provide one with --signing-key")
+	}
+	opt := packager2.SignOptions{
+		SigningKeyPath:          o.signingKeyPath,
+		SigningKeyPassword:      o.signingKeyPassword,
+		Resign:                  o.resign,
+		PublicKeyPath:           pkgConfig.PkgOpts.PublicKeyPath,
+		SkipSignatureValidation: pkgConfig.PkgOpts.SkipSignatureValidation,
+		OutputDir:               o.outputDirectory,
+		WithPlainHTTP:           config.CommonOptions.PlainHTTP,
+		Architecture:            config.GetArch(),
+	}
+	err = packager2.Sign(ctx, src, opt)
+	if err != nil {
+		return fmt.Errorf("failed to sign package: %w", err)
+	}
 	return nil
 }
 
@@ -850,6 +1974,9 @@ func choosePackage(ctx context.Context, args []string) (string, error) {
 	if len(args) > 0 {
 		return args[0], nil
 	}
+	if err := interactive.CheckPrompt("package path, pass it as an argument"); err != nil {
+		return "", err
+	}
 	l := logger.From(ctx)
 	var path string
 	prompt := &survey.Input{
@@ -0,0 +1,90 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package cmd contains the CLI commands for Zarf.
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+
+	"github.com/spf13/cobra"
+
+	"github.com/zarf-dev/zarf/src/config/lang"
+	"github.com/zarf-dev/zarf/src/pkg/message"
+	"github.com/zarf-dev/zarf/src/pkg/plugin"
+)
+
+func newPluginCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "plugin",
+		Short: lang.CmdPluginShort,
+		Long:  lang.CmdPluginLong,
+	}
+
+	cmd.AddCommand(newPluginListCommand())
+
+	return cmd
+}
+
+type pluginListOptions struct{}
+
+func newPluginListCommand() *cobra.Command {
+	o := &pluginListOptions{}
+
+	cmd := &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   lang.CmdPluginListShort,
+		Args:    cobra.NoArgs,
+		RunE:    o.run,
+	}
+
+	return cmd
+}
+
+func (o *pluginListOptions) run(_ *cobra.Command, _ []string) error {
+	plugins := plugin.List()
+	if len(plugins) == 0 {
+		return errors.New(lang.CmdPluginListNoPlugins)
+	}
+	header := []string{"Name", "Type", "Path"}
+	rows := [][]string{}
+	for _, p := range plugins {
+		kind := "command"
+		if p.AssetProvider {
+			kind = "asset provider"
+		}
+		rows = append(rows, []string{p.Name, kind, p.Path})
+	}
+	message.TableWithWriter(message.OutputWriter, header, rows)
+	return nil
+}
+
+// findPlugin returns the plugin executable and its arguments when args do not match a Zarf command.
+func findPlugin(root *cobra.Command, args []string) (string, []string, bool) {
+	if len(args) == 0 {
+		return "", nil, false
+	}
+	if _, _, err := root.Find(args); err == nil {
+		return "", nil, false
+	}
+	return plugin.Lookup(args)
+}
+
+// runPlugin runs the plugin executable and returns the exit code Zarf should exit with.
+func runPlugin(ctx context.Context, path string, args []string) int {
+	err := plugin.Run(ctx, path, args)
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return exitErr.ExitCode()
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "unable to run plugin %s: %s\n", path, err)
+		return 1
+	}
+	return 0
+}
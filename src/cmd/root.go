@@ -17,13 +17,22 @@ import (
 
 	"github.com/zarf-dev/zarf/src/pkg/logger"
 
+	"github.com/defenseunicorns/pkg/helpers/v2"
 	"github.com/pterm/pterm"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
+	"go.opentelemetry.io/otel/trace"
 
 	"github.com/zarf-dev/zarf/src/config"
 	"github.com/zarf-dev/zarf/src/config/lang"
+	"github.com/zarf-dev/zarf/src/internal/fips"
+	"github.com/zarf-dev/zarf/src/internal/metrics"
+	"github.com/zarf-dev/zarf/src/internal/tracing"
+	"github.com/zarf-dev/zarf/src/pkg/cluster"
+	"github.com/zarf-dev/zarf/src/pkg/events"
+	"github.com/zarf-dev/zarf/src/pkg/exitcode"
 	"github.com/zarf-dev/zarf/src/pkg/message"
+	"github.com/zarf-dev/zarf/src/pkg/notify"
 	"github.com/zarf-dev/zarf/src/types"
 )
 
@@ -34,10 +43,28 @@ var (
 	LogLevelCLI string
 	// LogFormat holds the log format as input from a command
 	LogFormat string
+	// LogDestination holds where logs are sent as input from a command
+	LogDestination string
 	// SkipLogFile is a flag to skip logging to a file
 	SkipLogFile bool
+	// LogFile configures a managed log file with rotation and retention
+	LogFile logger.FileConfig
 	// NoColor is a flag to disable colors in output
 	NoColor bool
+	// RecordMetrics is a flag to record operation metrics to a local file
+	RecordMetrics bool
+	// OTLPEndpoint is the endpoint that OpenTelemetry traces are exported to
+	OTLPEndpoint string
+	// Events holds the format of the structured event stream as input from a command
+	Events string
+	// ProgressFD is the file descriptor the structured event stream is written to
+	ProgressFD int
+	// EventsFile is the path of the file the structured event stream is written to
+	EventsFile string
+	// shutdownTracing flushes exported traces before the CLI exits
+	shutdownTracing tracing.ShutdownFunc
+	// commandSpan is the span covering the execution of the command
+	commandSpan trace.Span
 	// OutputWriter provides a default writer to Stdout for user-facing command output
 	OutputWriter = os.Stdout
 )
@@ -80,6 +107,19 @@ func preRun(cmd *cobra.Command, _ []string) error {
 		config.CommonOptions.PlainHTTP = true
 	}
 
+	// Apply the FIPS crypto policy before any connections are made, including those of vendored tools
+	config.CommonOptions.FIPS = fips.Enabled(config.CommonOptions.FIPS)
+	if config.CommonOptions.FIPS {
+		err := fips.ConfigureDefaultTransport()
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := cluster.ValidateInstanceName(config.CommonOptions.Instance); err != nil {
+		return exitcode.Wrap(exitcode.Config, err)
+	}
+
 	// Skip for vendor only commands
 	if checkVendorOnlyFromPath(cmd) {
 		return nil
@@ -102,12 +142,44 @@ func preRun(cmd *cobra.Command, _ []string) error {
 		skipLogFile = true
 	}
 
+	// The logger and message share a single writer so only one of them rotates the log file.
+	var logFile *logger.RotatingFile
+	if LogFile.Path != "" {
+		var err error
+		logFile, err = logger.NewRotatingFile(LogFile)
+		if err != nil {
+			return exitcode.Wrap(exitcode.Config, err)
+		}
+	}
+
 	// Configure logger and add it to cmd context.
-	l, err := setupLogger(LogLevelCLI, LogFormat, !NoColor)
+	l, err := setupLogger(LogLevelCLI, LogFormat, LogDestination, !NoColor, logFile)
 	if err != nil {
-		return err
+		return exitcode.Wrap(exitcode.Config, err)
 	}
 	ctx := logger.WithContext(cmd.Context(), l)
+	if RecordMetrics {
+		metricsPath, err := metrics.DefaultPath()
+		if err != nil {
+			return err
+		}
+		ctx = metrics.WithContext(ctx, metrics.NewRecorder(metricsPath))
+	}
+	emitter, err := setupEvents(Events, ProgressFD, EventsFile)
+	if err != nil {
+		return exitcode.Wrap(exitcode.Config, err)
+	}
+	ctx = events.WithContext(ctx, emitter)
+	notifier, err := setupNotifier()
+	if err != nil {
+		return exitcode.Wrap(exitcode.Config, err)
+	}
+	ctx = notify.WithContext(ctx, notifier)
+	shutdownTracing, err = tracing.Setup(ctx, OTLPEndpoint)
+	if err != nil {
+		return err
+	}
+	ctx, commandSpan = tracing.Start(ctx, cmd.CommandPath())
 	cmd.SetContext(ctx)
 
 	// Configure the global message instance.
@@ -121,6 +193,7 @@ func preRun(cmd *cobra.Command, _ []string) error {
 	err = SetupMessage(MessageCfg{
 		Level:           LogLevelCLI,
 		SkipLogFile:     skipLogFile,
+		LogFile:         logFile,
 		NoColor:         NoColor,
 		FeatureDisabled: disableMessage,
 	})
@@ -128,10 +201,20 @@ func preRun(cmd *cobra.Command, _ []string) error {
 		return err
 	}
 
+	if config.CommonOptions.FIPS {
+		report, err := fips.NewReport(true)
+		if err != nil {
+			return err
+		}
+		if !report.Compliant {
+			logger.From(cmd.Context()).Warn("FIPS mode is enabled but the binary was not built with a FIPS validated crypto module")
+		}
+	}
+
 	// Print out config location
 	err = PrintViperConfigUsed(cmd.Context())
 	if err != nil {
-		return err
+		return exitcode.Wrap(exitcode.Config, err)
 	}
 	return nil
 }
@@ -156,6 +239,10 @@ func NewZarfCommand() *cobra.Command {
 		PersistentPreRunE: preRun,
 		Run:               run,
 	}
+	// Flag errors are inherited by all subcommands so invalid flags exit with the config error code
+	rootCmd.SetFlagErrorFunc(func(_ *cobra.Command, err error) error {
+		return exitcode.Wrap(exitcode.Config, err)
+	})
 
 	// Add the tools commands
 	// IMPORTANT: we need to make sure the tools command are added first
@@ -170,8 +257,11 @@ func NewZarfCommand() *cobra.Command {
 	rootCmd.AddCommand(newDestroyCommand())
 	rootCmd.AddCommand(newDevCommand())
 	rootCmd.AddCommand(newInitCommand())
+	rootCmd.AddCommand(newInitPackageCommand())
 	rootCmd.AddCommand(newInternalCommand(rootCmd))
 	rootCmd.AddCommand(newPackageCommand())
+	rootCmd.AddCommand(newPluginCommand())
+	rootCmd.AddCommand(newServeCommand())
 
 	rootCmd.AddCommand(newVersionCommand())
 
@@ -180,11 +270,27 @@ func NewZarfCommand() *cobra.Command {
 
 // Execute is the entrypoint for the CLI.
 func Execute(ctx context.Context) {
+	if path, args, ok := findPlugin(rootCmd, os.Args[1:]); ok {
+		os.Exit(runPlugin(ctx, path, args))
+	}
 	cmd, err := rootCmd.ExecuteContextC(ctx)
+	if commandSpan != nil {
+		tracing.End(commandSpan, err)
+	}
+	if shutdownTracing != nil {
+		if shutdownErr := shutdownTracing(ctx); shutdownErr != nil {
+			logger.Default().Warn("unable to export traces", "error", shutdownErr)
+		}
+	}
 	if err == nil {
 		return
 	}
 
+	// Alternate versions of vendored tools print their own errors
+	if errors.Is(err, errToolExited) {
+		os.Exit(int(exitcode.FromError(err)))
+	}
+
 	// Check if we need to use the default err printer
 	defaultPrintCmds := []string{"helm", "yq", "kubectl"}
 	comps := strings.Split(cmd.CommandPath(), " ")
@@ -200,7 +306,7 @@ func Execute(ctx context.Context) {
 	// NOTE(mkcp): The default logger is set with user flags downstream in rootCmd's preRun func, so we don't have
 	// access to it on Execute's ctx.
 	logger.Default().Error(err.Error())
-	os.Exit(1)
+	os.Exit(int(exitcode.FromError(err)))
 }
 
 func init() {
@@ -214,23 +320,79 @@ func init() {
 	// Logs
 	rootCmd.PersistentFlags().StringVarP(&LogLevelCLI, "log-level", "l", v.GetString(VLogLevel), lang.RootCmdFlagLogLevel)
 	rootCmd.PersistentFlags().StringVar(&LogFormat, "log-format", v.GetString(VLogFormat), "[beta] Select a logging format. Defaults to 'console'. Valid options are: 'console', 'json', 'dev', 'legacy'. The legacy option will be removed in a coming release")
+	rootCmd.PersistentFlags().StringVar(&LogDestination, "log-destination", v.GetString(VLogDestination), lang.RootCmdFlagLogDestination)
 	rootCmd.PersistentFlags().BoolVar(&SkipLogFile, "no-log-file", v.GetBool(VNoLogFile), lang.RootCmdFlagSkipLogFile)
+	rootCmd.PersistentFlags().StringVar(&LogFile.Path, "log-file", v.GetString(VLogFile), lang.RootCmdFlagLogFile)
+	rootCmd.PersistentFlags().IntVar(&LogFile.MaxSizeMB, "log-file-max-size", v.GetInt(VLogFileMaxSize), lang.RootCmdFlagLogFileMaxSize)
+	rootCmd.PersistentFlags().IntVar(&LogFile.MaxBackups, "log-file-max-backups", v.GetInt(VLogFileMaxBackups), lang.RootCmdFlagLogFileMaxBackups)
+	rootCmd.PersistentFlags().BoolVar(&LogFile.Compress, "log-file-compress", v.GetBool(VLogFileCompress), lang.RootCmdFlagLogFileCompress)
 	rootCmd.PersistentFlags().BoolVar(&message.NoProgress, "no-progress", v.GetBool(VNoProgress), lang.RootCmdFlagNoProgress)
 	rootCmd.PersistentFlags().BoolVar(&NoColor, "no-color", v.GetBool(VNoColor), lang.RootCmdFlagNoColor)
+	rootCmd.PersistentFlags().BoolVar(&RecordMetrics, "record-metrics", v.GetBool(VRecordMetrics), lang.RootCmdFlagRecordMetrics)
+	rootCmd.PersistentFlags().StringVar(&OTLPEndpoint, "otlp-endpoint", v.GetString(VOTLPEndpoint), lang.RootCmdFlagOTLPEndpoint)
+	rootCmd.PersistentFlags().StringVar(&Events, "events", v.GetString(VEvents), lang.RootCmdFlagEvents)
+	rootCmd.PersistentFlags().IntVar(&ProgressFD, "progress-fd", v.GetInt(VProgressFD), lang.RootCmdFlagProgressFD)
+	rootCmd.PersistentFlags().StringVar(&EventsFile, "events-file", v.GetString(VEventsFile), lang.RootCmdFlagEventsFile)
+	rootCmd.PersistentFlags().BoolVar(&config.CommonOptions.NonInteractive, "non-interactive", v.GetBool(VNonInteractive), lang.RootCmdFlagNonInteractive)
+	// The profile is applied when the config file is read, the flag is declared so it is documented and accepted
+	rootCmd.PersistentFlags().String("profile", v.GetString(VProfile), lang.RootCmdFlagProfile)
 
 	rootCmd.PersistentFlags().StringVarP(&config.CLIArch, "architecture", "a", v.GetString(VArchitecture), lang.RootCmdFlagArch)
 	rootCmd.PersistentFlags().StringVar(&config.CommonOptions.CachePath, "zarf-cache", v.GetString(VZarfCache), lang.RootCmdFlagCachePath)
 	rootCmd.PersistentFlags().StringVar(&config.CommonOptions.TempDirectory, "tmpdir", v.GetString(VTmpDir), lang.RootCmdFlagTempDir)
+	rootCmd.PersistentFlags().StringVar(&config.CommonOptions.Instance, "instance", v.GetString(VInstance), lang.RootCmdFlagInstance)
 
 	// Security
 	rootCmd.PersistentFlags().BoolVar(&config.CommonOptions.Insecure, "insecure", v.GetBool(VInsecure), lang.RootCmdFlagInsecure)
 	rootCmd.PersistentFlags().MarkDeprecated("insecure", "please use --plain-http, --insecure-skip-tls-verify, or --skip-signature-validation instead.")
 	rootCmd.PersistentFlags().BoolVar(&config.CommonOptions.PlainHTTP, "plain-http", v.GetBool(VPlainHTTP), lang.RootCmdFlagPlainHTTP)
 	rootCmd.PersistentFlags().BoolVar(&config.CommonOptions.InsecureSkipTLSVerify, "insecure-skip-tls-verify", v.GetBool(VInsecureSkipTLSVerify), lang.RootCmdFlagInsecureSkipTLSVerify)
+	rootCmd.PersistentFlags().BoolVar(&config.CommonOptions.FIPS, "fips", v.GetBool(VFIPS), lang.RootCmdFlagFIPS)
+}
+
+// setupEvents returns an emitter for the structured event stream or nil when the stream is disabled.
+// Setting a progress file descriptor or an events file enables the stream, otherwise it is written to stdout.
+func setupEvents(format string, fd int, path string) (*events.Emitter, error) {
+	f, err := events.ParseFormat(format)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case fd < 0:
+		return nil, fmt.Errorf("invalid progress file descriptor: %d", fd)
+	case fd > 0 && path != "":
+		return nil, errors.New("--progress-fd and --events-file can not be used together")
+	case path != "":
+		out, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, helpers.ReadWriteUser)
+		if err != nil {
+			return nil, fmt.Errorf("unable to open the events file: %w", err)
+		}
+		return events.NewEmitter(out), nil
+	case fd == 0 && f == events.FormatNone:
+		return nil, nil
+	case fd == 0:
+		// Logs go to stderr, so events go to stdout to be parsed without them.
+		return events.NewEmitter(os.Stdout), nil
+	}
+	out := os.NewFile(uintptr(fd), fmt.Sprintf("progress-fd-%d", fd))
+	if _, err := out.Stat(); err != nil {
+		return nil, fmt.Errorf("unable to write events to file descriptor %d: %w", fd, err)
+	}
+	return events.NewEmitter(out), nil
+}
+
+// setupNotifier returns a notifier for the webhooks configured in the Zarf config file.
+func setupNotifier() (*notify.Notifier, error) {
+	var webhooks []notify.Webhook
+	err := getViper().UnmarshalKey(VNotifyWebhooks, &webhooks)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s config: %w", VNotifyWebhooks, err)
+	}
+	return notify.NewNotifier(webhooks)
 }
 
 // setup Logger handles creating a logger and setting it as the global default.
-func setupLogger(level, format string, color bool) (*slog.Logger, error) {
+func setupLogger(level, format, destination string, color bool, file *logger.RotatingFile) (*slog.Logger, error) {
 	// If we didn't get a level from config, fallback to "info"
 	if level == "" {
 		level = "info"
@@ -239,11 +401,19 @@ func setupLogger(level, format string, color bool) (*slog.Logger, error) {
 	if err != nil {
 		return nil, err
 	}
+	output, err := logger.ParseOutput(destination)
+	if err != nil {
+		return nil, err
+	}
 	cfg := logger.Config{
 		Level:       sLevel,
 		Format:      logger.Format(format),
 		Destination: logger.DestinationDefault,
 		Color:       logger.Color(color),
+		Output:      output,
+	}
+	if file != nil {
+		cfg.File = file
 	}
 	l, err := logger.New(cfg)
 	if err != nil {
@@ -261,7 +431,9 @@ func setupLogger(level, format string, color bool) (*slog.Logger, error) {
 type MessageCfg struct {
 	Level       string
 	SkipLogFile bool
-	NoColor     bool
+	// LogFile replaces the temporary log file with a managed log file when set
+	LogFile *logger.RotatingFile
+	NoColor bool
 	// FeatureDisabled is a feature flag that disables it
 	FeatureDisabled bool
 }
@@ -306,7 +478,14 @@ func SetupMessage(cfg MessageCfg) error {
 		message.NoProgress = true
 	}
 
-	if !cfg.SkipLogFile {
+	if cfg.LogFile != nil {
+		logFile, err := message.UseLogFile(cfg.LogFile)
+		if err != nil {
+			return fmt.Errorf("could not use the log file: %w", err)
+		}
+		pterm.SetDefaultOutput(io.MultiWriter(os.Stderr, logFile))
+		message.Notef("Saving log file to %s", cfg.LogFile.Name())
+	} else if !cfg.SkipLogFile {
 		ts := time.Now().Format("2006-01-02-15-04-05")
 		f, err := os.CreateTemp("", fmt.Sprintf("zarf-%s-*.log", ts))
 		if err != nil {
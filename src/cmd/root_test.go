@@ -0,0 +1,38 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/zarf-dev/zarf/src/pkg/events"
+)
+
+func TestSetupEvents(t *testing.T) {
+	t.Parallel()
+
+	emitter, err := setupEvents("", 0, "")
+	require.NoError(t, err)
+	require.Nil(t, emitter)
+
+	emitter, err = setupEvents("json", 0, "")
+	require.NoError(t, err)
+	require.NotNil(t, emitter)
+
+	_, err = setupEvents("", 3, filepath.Join(t.TempDir(), "events.json"))
+	require.EqualError(t, err, "--progress-fd and --events-file can not be used together")
+
+	// An events file enables the stream without --events.
+	path := filepath.Join(t.TempDir(), "events.json")
+	emitter, err = setupEvents("", 0, path)
+	require.NoError(t, err)
+	emitter.Phase("deploy", events.StatusStarted, nil)
+	b, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.Contains(t, string(b), `"phase":"deploy"`)
+}
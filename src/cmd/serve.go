@@ -0,0 +1,96 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package cmd contains the CLI commands for Zarf.
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/zarf-dev/zarf/src/config"
+	"github.com/zarf-dev/zarf/src/config/lang"
+	"github.com/zarf-dev/zarf/src/internal/server"
+	"github.com/zarf-dev/zarf/src/pkg/logger"
+)
+
+type serveOptions struct {
+	listen      string
+	tokenFile   string
+	tlsCertPath string
+	tlsKeyPath  string
+}
+
+func newServeCommand() *cobra.Command {
+	o := &serveOptions{}
+
+	cmd := &cobra.Command{
+		Use:     "serve",
+		Short:   lang.CmdServeShort,
+		Long:    lang.CmdServeLong,
+		Example: lang.CmdServeExample,
+		Args:    cobra.NoArgs,
+		RunE:    o.run,
+	}
+
+	v := getViper()
+	cmd.Flags().StringVar(&o.listen, "listen", v.GetString(VServeListen), lang.CmdServeFlagListen)
+	cmd.Flags().StringVar(&o.tokenFile, "token-file", v.GetString(VServeTokenFile), lang.CmdServeFlagTokenFile)
+	cmd.Flags().StringVar(&o.tlsCertPath, "tls-cert", v.GetString(VServeTLSCert), lang.CmdServeFlagTLSCert)
+	cmd.Flags().StringVar(&o.tlsKeyPath, "tls-key", v.GetString(VServeTLSKey), lang.CmdServeFlagTLSKey)
+
+	return cmd
+}
+
+func (o *serveOptions) run(cmd *cobra.Command, _ []string) error {
+	ctx := cmd.Context()
+
+	token, err := o.token()
+	if err != nil {
+		return err
+	}
+	logger.AddSecret(token)
+	level, err := logger.ParseLevel(LogLevelCLI)
+	if err != nil {
+		return err
+	}
+
+	// The server can not prompt so every operation runs as if --confirm was passed.
+	config.CommonOptions.Confirm = true
+
+	srv, err := server.New(server.Options{
+		Listen:      o.listen,
+		Token:       token,
+		TLSCertPath: o.tlsCertPath,
+		TLSKeyPath:  o.tlsKeyPath,
+		LogLevel:    level,
+	})
+	if err != nil {
+		return err
+	}
+	return srv.Start(ctx)
+}
+
+// token reads the API token from the token file, falling back to the token in the config or environment.
+func (o *serveOptions) token() (string, error) {
+	if o.tokenFile == "" {
+		token := getViper().GetString(VServeToken)
+		if token == "" {
+			return "", errors.New("an API token is required, set --token-file or ZARF_SERVE_TOKEN")
+		}
+		return token, nil
+	}
+	b, err := os.ReadFile(o.tokenFile)
+	if err != nil {
+		return "", fmt.Errorf("unable to read the API token: %w", err)
+	}
+	token := strings.TrimSpace(string(b))
+	if token == "" {
+		return "", fmt.Errorf("the API token file %s is empty", o.tokenFile)
+	}
+	return token, nil
+}
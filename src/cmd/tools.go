@@ -30,7 +30,11 @@ func newToolsCommand() *cobra.Command {
 	cmd.AddCommand(newYQCommand())
 	cmd.AddCommand(newGetCredsCommand())
 	cmd.AddCommand(newUpdateCredsCommand(v))
+	cmd.AddCommand(newSyncPullSecretsCommand())
+	cmd.AddCommand(newStateCommand())
+	cmd.AddCommand(newInventoryCommand())
 	cmd.AddCommand(newClearCacheCommand())
+	cmd.AddCommand(newReportCommand())
 	cmd.AddCommand(newDownloadInitCommand())
 	cmd.AddCommand(newGenPKICommand())
 	cmd.AddCommand(newGenKeyCommand())
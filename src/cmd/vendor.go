@@ -5,13 +5,22 @@
 package cmd
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"os"
+	"strconv"
 	"strings"
 
 	"slices"
 
 	"github.com/spf13/cobra"
 	"github.com/zarf-dev/zarf/src/config"
+	"github.com/zarf-dev/zarf/src/config/lang"
+	"github.com/zarf-dev/zarf/src/internal/artifact"
+	"github.com/zarf-dev/zarf/src/internal/toolversion"
+	"github.com/zarf-dev/zarf/src/pkg/exitcode"
+	"github.com/zarf-dev/zarf/src/pkg/logger"
 )
 
 var vendorCmds = []string{
@@ -63,3 +72,139 @@ func IsVendorCmd(args []string, vendoredCmds []string) bool {
 
 	return false
 }
+
+// vendorToolArgsIndex returns the index of the first argument passed to a vendored tool in os.Args.
+func vendorToolArgsIndex() int {
+	if config.ActionsCommandZarfPrefix != "" {
+		return 4
+	}
+	return 3
+}
+
+// errToolExited is returned when an alternate version of a vendored tool exits with a non-zero exit code. The tool
+// prints its own errors so only its exit code is passed on.
+var errToolExited = errors.New("tool exited with a non-zero exit code")
+
+// stripToolVersionArgs removes the tool version flags from os.Args so they are not parsed by the vendored tool. It
+// returns true and leaves os.Args as is when another version than the embedded version of the tool is requested.
+func stripToolVersionArgs(tool, embeddedVersion string) bool {
+	idx := vendorToolArgsIndex()
+	if len(os.Args) < idx {
+		return false
+	}
+	o, rest := splitToolVersionArgs(tool, os.Args[idx:])
+	if o.requested(embeddedVersion) {
+		return true
+	}
+	os.Args = append(os.Args[:idx:idx], rest...)
+	return false
+}
+
+// toolVersionOptions select an alternate version of a vendored tool.
+type toolVersionOptions struct {
+	version    string
+	digest     string
+	repository string
+	cachePath  string
+}
+
+// requested returns true if another version than the embedded version of the tool is requested.
+func (o toolVersionOptions) requested(embeddedVersion string) bool {
+	return o.version != "" && toolversion.NormalizeVersion(o.version) != toolversion.NormalizeVersion(embeddedVersion)
+}
+
+// splitToolVersionArgs removes the --tool-version, --tool-digest, --tool-repository and --zarf-cache flags from the
+// arguments of a vendored tool. The flags fall back to the ZARF_TOOLS_<TOOL>_VERSION, ZARF_TOOLS_<TOOL>_DIGEST and
+// ZARF_TOOLS_REPOSITORY environment variables.
+func splitToolVersionArgs(tool string, args []string) (toolVersionOptions, []string) {
+	o := toolVersionOptions{
+		version:    os.Getenv(fmt.Sprintf("ZARF_TOOLS_%s_VERSION", strings.ToUpper(tool))),
+		digest:     os.Getenv(fmt.Sprintf("ZARF_TOOLS_%s_DIGEST", strings.ToUpper(tool))),
+		repository: os.Getenv("ZARF_TOOLS_REPOSITORY"),
+	}
+	flags := map[string]*string{
+		"--tool-version":    &o.version,
+		"--tool-digest":     &o.digest,
+		"--tool-repository": &o.repository,
+		"--zarf-cache":      &o.cachePath,
+	}
+	rest := []string{}
+	for i := 0; i < len(args); i++ {
+		// Everything after -- belongs to the tool.
+		if args[i] == "--" {
+			rest = append(rest, args[i:]...)
+			break
+		}
+		name, value, hasValue := strings.Cut(args[i], "=")
+		dst, ok := flags[name]
+		if !ok {
+			rest = append(rest, args[i])
+			continue
+		}
+		if !hasValue && i+1 < len(args) {
+			i++
+			value = args[i]
+		}
+		*dst = value
+	}
+	return o, rest
+}
+
+// newToolVersionCommand returns the command that runs the requested version of a vendored tool in place of the
+// embedded version. All arguments are passed through to the tool.
+func newToolVersionCommand(tool string, aliases []string) *cobra.Command {
+	return &cobra.Command{
+		Use:                tool,
+		Aliases:            aliases,
+		DisableFlagParsing: true,
+		SilenceUsage:       true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runToolVersion(cmd.Context(), tool, args)
+		},
+	}
+}
+
+// runToolVersion runs the requested version of a vendored tool from the Zarf cache, pulling it when it is not cached.
+func runToolVersion(ctx context.Context, tool string, args []string) error {
+	o, rest := splitToolVersionArgs(tool, args)
+	ctx = logger.WithContext(ctx, logger.Default())
+	l := logger.From(ctx)
+
+	// The Zarf config is not read for vendored tools so the cache falls back to its environment variable.
+	if o.cachePath == "" {
+		o.cachePath = os.Getenv("ZARF_ZARF_CACHE")
+	}
+	if o.cachePath == "" {
+		o.cachePath = config.ZarfDefaultCachePath
+	}
+	cachePath, err := config.GetAbsHomePath(o.cachePath)
+	if err != nil {
+		return err
+	}
+	plainHTTP, _ := strconv.ParseBool(os.Getenv("ZARF_PLAIN_HTTP"))
+	insecureSkipTLSVerify, _ := strconv.ParseBool(os.Getenv("ZARF_INSECURE_SKIP_TLS_VERIFY"))
+	opts := toolversion.Options{
+		Tool:       tool,
+		Version:    o.version,
+		Digest:     o.digest,
+		CachePath:  cachePath,
+		Repository: o.repository,
+		Registry: artifact.RegistryOptions{
+			PlainHTTP:             plainHTTP,
+			InsecureSkipTLSVerify: insecureSkipTLSVerify,
+		},
+	}
+	binPath, err := toolversion.Binary(ctx, opts)
+	if err != nil {
+		return fmt.Errorf(lang.CmdToolsToolVersionErr, tool, toolversion.NormalizeVersion(o.version), err.Error())
+	}
+	l.Debug("running alternate tool version", "tool", tool, "version", o.version, "path", binPath)
+	exitCode, err := toolversion.Run(ctx, binPath, rest)
+	if err != nil {
+		return err
+	}
+	if exitCode != 0 {
+		return exitcode.Wrap(exitcode.Code(exitCode), errToolExited)
+	}
+	return nil
+}
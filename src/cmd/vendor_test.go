@@ -0,0 +1,97 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitToolVersionArgs(t *testing.T) {
+	tests := []struct {
+		name         string
+		env          map[string]string
+		args         []string
+		expectedOpts toolVersionOptions
+		expectedArgs []string
+	}{
+		{
+			name:         "no flags",
+			args:         []string{"list", "-A"},
+			expectedOpts: toolVersionOptions{},
+			expectedArgs: []string{"list", "-A"},
+		},
+		{
+			name:         "flags",
+			args:         []string{"--tool-version", "3.14.4", "list", "--tool-repository=oci://registry.example.com/zarf-tools", "-A"},
+			expectedOpts: toolVersionOptions{version: "3.14.4", repository: "oci://registry.example.com/zarf-tools"},
+			expectedArgs: []string{"list", "-A"},
+		},
+		{
+			name:         "digest and cache",
+			args:         []string{"--tool-version", "3.14.4", "--tool-digest=sha256:abc", "--zarf-cache", "/tmp/cache", "list"},
+			expectedOpts: toolVersionOptions{version: "3.14.4", digest: "sha256:abc", cachePath: "/tmp/cache"},
+			expectedArgs: []string{"list"},
+		},
+		{
+			name:         "environment",
+			env:          map[string]string{"ZARF_TOOLS_HELM_VERSION": "3.13.0", "ZARF_TOOLS_HELM_DIGEST": "sha256:abc", "ZARF_TOOLS_REPOSITORY": "registry.example.com/zarf-tools"},
+			args:         []string{"--tool-version=3.14.4", "list"},
+			expectedOpts: toolVersionOptions{version: "3.14.4", digest: "sha256:abc", repository: "registry.example.com/zarf-tools"},
+			expectedArgs: []string{"list"},
+		},
+		{
+			name:         "after separator",
+			args:         []string{"plugin", "--", "--tool-version", "3.14.4"},
+			expectedOpts: toolVersionOptions{},
+			expectedArgs: []string{"plugin", "--", "--tool-version", "3.14.4"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("ZARF_TOOLS_HELM_VERSION", tt.env["ZARF_TOOLS_HELM_VERSION"])
+			t.Setenv("ZARF_TOOLS_HELM_DIGEST", tt.env["ZARF_TOOLS_HELM_DIGEST"])
+			t.Setenv("ZARF_TOOLS_REPOSITORY", tt.env["ZARF_TOOLS_REPOSITORY"])
+			opts, args := splitToolVersionArgs("helm", tt.args)
+			require.Equal(t, tt.expectedOpts, opts)
+			require.Equal(t, tt.expectedArgs, args)
+		})
+	}
+}
+
+func TestToolVersionRequested(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name            string
+		version         string
+		embeddedVersion string
+		expected        bool
+	}{
+		{
+			name:            "no version",
+			embeddedVersion: "v3.14.4",
+			expected:        false,
+		},
+		{
+			name:            "embedded version",
+			version:         "3.14.4",
+			embeddedVersion: "v3.14.4",
+			expected:        false,
+		},
+		{
+			name:            "other version",
+			version:         "3.13.0",
+			embeddedVersion: "v3.14.4",
+			expected:        true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			require.Equal(t, tt.expected, toolVersionOptions{version: tt.version}.requested(tt.embeddedVersion))
+		})
+	}
+}
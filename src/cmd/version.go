@@ -18,6 +18,7 @@ import (
 
 	"github.com/zarf-dev/zarf/src/config"
 	"github.com/zarf-dev/zarf/src/config/lang"
+	"github.com/zarf-dev/zarf/src/internal/fips"
 	"github.com/zarf-dev/zarf/src/pkg/message"
 )
 
@@ -90,6 +91,12 @@ func (o *versionOptions) run(_ *cobra.Command, _ []string) error {
 	}
 	output["build"] = buildMap
 
+	fipsReport, err := fips.NewReport(config.CommonOptions.FIPS)
+	if err != nil {
+		return err
+	}
+	output["fips"] = fipsReport
+
 	switch o.outputFormat {
 	case "yaml":
 		b, err := goyaml.Marshal(output)
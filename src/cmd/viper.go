@@ -10,12 +10,14 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
 	"strings"
 
 	"github.com/zarf-dev/zarf/src/pkg/logger"
 
 	"github.com/spf13/viper"
 	"github.com/zarf-dev/zarf/src/config"
+	"github.com/zarf-dev/zarf/src/types"
 )
 
 // Constants for use when loading configurations from viper config files
@@ -29,19 +31,48 @@ const (
 	VInsecure              = "insecure"
 	VPlainHTTP             = "plain_http"
 	VInsecureSkipTLSVerify = "insecure_skip_tls_verify"
+	VFIPS                  = "fips"
+	VRecordMetrics         = "record_metrics"
+	VOTLPEndpoint          = "otlp_endpoint"
+	VEvents                = "events"
+	VProgressFD            = "progress_fd"
+	VEventsFile            = "events_file"
+	VNonInteractive        = "non_interactive"
+	VInstance              = "instance"
+	VProfile               = "profile"
+
+	// Profile config keys
+
+	VProfiles       = "profiles"
+	VProfileExtends = "extends"
+
+	// Interpolation config keys, only read from the environment as ZARF_INTERPOLATION_ALLOW
+
+	VInterpolationAllow = "interpolation.allow"
+
+	// Notification config keys
+
+	VNotifyWebhooks = "notifications.webhooks"
 
 	// Root config, Logging
 
-	VLogLevel   = "log_level"
-	VLogFormat  = "log_format"
-	VNoLogFile  = "no_log_file"
-	VNoProgress = "no_progress"
-	VNoColor    = "no_color"
+	VLogLevel       = "log_level"
+	VLogFormat      = "log_format"
+	VLogDestination = "log_destination"
+	VNoLogFile      = "no_log_file"
+	VNoProgress     = "no_progress"
+	VNoColor        = "no_color"
+
+	VLogFile           = "log_file"
+	VLogFileMaxSize    = "log_file_max_size"
+	VLogFileMaxBackups = "log_file_max_backups"
+	VLogFileCompress   = "log_file_compress"
 
 	// Init config keys
 
-	VInitComponents   = "init.components"
-	VInitStorageClass = "init.storage_class"
+	VInitComponents         = "init.components"
+	VInitStorageClass       = "init.storage_class"
+	VInitPullSecretSelector = "init.pull_secret_selector"
 
 	// Init Git config keys
 
@@ -51,6 +82,18 @@ const (
 	VInitGitPullUser = "init.git.pull_username"
 	VInitGitPullPass = "init.git.pull_password"
 
+	VInitGitTLSCert   = "init.git.tls_cert"
+	VInitGitTLSKey    = "init.git.tls_key"
+	VInitGitTLSCA     = "init.git.tls_ca"
+	VInitGitTLSIssuer = "init.git.tls_issuer"
+
+	VInitGitCPURequest    = "init.git.cpu_request"
+	VInitGitCPULimit      = "init.git.cpu_limit"
+	VInitGitMemoryRequest = "init.git.memory_request"
+	VInitGitMemoryLimit   = "init.git.memory_limit"
+	VInitGitPVCSize       = "init.git.pvc_size"
+	VInitGitStorageClass  = "init.git.storage_class"
+
 	// Init Registry config keys
 
 	VInitRegistryURL      = "init.registry.url"
@@ -61,6 +104,55 @@ const (
 	VInitRegistryPullUser = "init.registry.pull_username"
 	VInitRegistryPullPass = "init.registry.pull_password"
 
+	VInitRegistryTLSCert   = "init.registry.tls_cert"
+	VInitRegistryTLSKey    = "init.registry.tls_key"
+	VInitRegistryTLSCA     = "init.registry.tls_ca"
+	VInitRegistryTLSIssuer = "init.registry.tls_issuer"
+
+	VInitRegistryImageRules = "init.registry.image_rules"
+
+	VInitRegistryProjectAPI  = "init.registry.project_api"
+	VInitRegistryProjectUser = "init.registry.project_username"
+	VInitRegistryProjectPass = "init.registry.project_password"
+
+	VInitRegistryScopedTokens = "init.registry.scoped_tokens"
+
+	VInitRegistryAuth            = "init.registry.auth"
+	VInitRegistryRobotNamespaces = "init.registry.robot_namespaces"
+	VInitRegistryTokenRealm      = "init.registry.token_realm"
+	VInitRegistryTokenService    = "init.registry.token_service"
+	VInitRegistryTokenIssuer     = "init.registry.token_issuer"
+	VInitRegistryTokenCert       = "init.registry.token_cert"
+
+	VInitRegistryCPURequest    = "init.registry.cpu_request"
+	VInitRegistryCPULimit      = "init.registry.cpu_limit"
+	VInitRegistryMemoryRequest = "init.registry.memory_request"
+	VInitRegistryMemoryLimit   = "init.registry.memory_limit"
+	VInitRegistryPVCSize       = "init.registry.pvc_size"
+	VInitRegistryStorageClass  = "init.registry.storage_class"
+
+	VInitRegistryStorage    = "init.registry.storage"
+	VInitRegistryS3Bucket   = "init.registry.s3_bucket"
+	VInitRegistryS3Region   = "init.registry.s3_region"
+	VInitRegistryS3Endpoint = "init.registry.s3_endpoint"
+	VInitRegistryS3Access   = "init.registry.s3_access_key"
+	VInitRegistryS3Secret   = "init.registry.s3_secret_key"
+
+	// Init Agent config keys
+
+	VInitAgentCPURequest    = "init.agent.cpu_request"
+	VInitAgentCPULimit      = "init.agent.cpu_limit"
+	VInitAgentMemoryRequest = "init.agent.memory_request"
+	VInitAgentMemoryLimit   = "init.agent.memory_limit"
+
+	// Init Seed config keys
+
+	VInitSeedStrategy = "init.seed.strategy"
+	VInitSeedProxy    = "init.seed.proxy"
+	VInitSeedHostPath = "init.seed.host_path"
+	VInitSeedHostPort = "init.seed.host_port"
+	VInitSeedFrom     = "init.seed.from"
+
 	// Init Package config keys
 
 	VInitArtifactURL       = "init.artifact.url"
@@ -85,25 +177,78 @@ const (
 	VPkgCreateDifferential       = "package.create.differential"
 	VPkgCreateRegistryOverride   = "package.create.registry_override"
 	VPkgCreateFlavor             = "package.create.flavor"
+	VPkgCreateOverlays           = "package.create.overlays"
+	VPkgCreateBuildCache         = "package.create.build_cache"
+	VPkgCreateRemoteCache        = "package.create.remote_cache"
+	VPkgCreateRemoteCacheRO      = "package.create.remote_cache_read_only"
+	VPkgCreateMaxMemory          = "package.create.max_memory"
+	VPkgCreateMaxTempSpace       = "package.create.max_temp_space"
+	VPkgCreateIncludePlatforms   = "package.create.include_platforms"
+	VPkgCreateFailOnSeverity     = "package.create.fail_on_severity"
+	VPkgCreateVulnWaivers        = "package.create.vulnerability_waivers"
+	VPkgCreateAllowUnpinned      = "package.create.allow_unpinned"
+	VPkgCreateLintRules          = "package.create.lint_rules"
+	VPkgCreateBuildkitAddr       = "package.create.buildkit_addr"
+	VPkgCreateBuildkitPool       = "package.create.buildkit_pool"
+	VPkgCreateBuildkitPoolNS     = "package.create.buildkit_pool_namespace"
+	VPkgCreateBuildkitCACert     = "package.create.buildkit_tls_ca_cert"
+	VPkgCreateBuildkitCert       = "package.create.buildkit_tls_cert"
+	VPkgCreateBuildkitKey        = "package.create.buildkit_tls_key"
+	VPkgCreateBuildkitServerName = "package.create.buildkit_tls_server_name"
 
 	// Package deploy config keys
 
-	VPkgDeploySet        = "package.deploy.set"
-	VPkgDeployComponents = "package.deploy.components"
-	VPkgDeployShasum     = "package.deploy.shasum"
-	VPkgDeploySget       = "package.deploy.sget"
-	VPkgDeployTimeout    = "package.deploy.timeout"
-	VPkgRetries          = "package.deploy.retries"
+	VPkgDeploySet            = "package.deploy.set"
+	VPkgDeployComponents     = "package.deploy.components"
+	VPkgDeployShasum         = "package.deploy.shasum"
+	VPkgDeployChecksums      = "package.deploy.checksums"
+	VPkgDeploySget           = "package.deploy.sget"
+	VPkgDeployTimeout        = "package.deploy.timeout"
+	VPkgDeployFeatures       = "package.deploy.features"
+	VPkgDeployServiceAccount = "package.deploy.service_account"
+	VPkgDeployLabels         = "package.deploy.labels"
+	VPkgDeployAnnotations    = "package.deploy.annotations"
+	VPkgDeployNodeSelector   = "package.deploy.node_selector"
+	VPkgDeployTolerations    = "package.deploy.tolerations"
+	VPkgDeployHostAliases    = "package.deploy.host_aliases"
+	VPkgDeployNotes          = "package.deploy.notes"
+	VPkgDeployCapacityCheck  = "package.deploy.capacity_check"
+	VPkgDeployLazyLoad       = "package.deploy.lazy_load"
+	VPkgRetries              = "package.deploy.retries"
+
+	// Package remove config keys
+
+	VPkgRemoveTimeout = "package.remove.timeout"
+	VPkgRemoveForce   = "package.remove.force"
 
 	// Package publish config keys
 
 	VPkgPublishSigningKey         = "package.publish.signing_key"
 	VPkgPublishSigningKeyPassword = "package.publish.signing_key_password"
+	VPkgPublishRetag              = "package.publish.retag"
+
+	// Package copy config keys
+
+	VPkgCopySigningKey         = "package.copy.signing_key"
+	VPkgCopySigningKeyPassword = "package.copy.signing_key_password"
+
+	// Package sign config keys
+
+	VPkgSignSigningKey         = "package.sign.signing_key"
+	VPkgSignSigningKeyPassword = "package.sign.signing_key_password"
 
 	// Package pull config keys
 
 	VPkgPullOutputDir = "package.pull.output_directory"
 
+	// Serve config keys
+
+	VServeListen    = "serve.listen"
+	VServeToken     = "serve.token"
+	VServeTokenFile = "serve.token_file"
+	VServeTLSCert   = "serve.tls_cert"
+	VServeTLSKey    = "serve.tls_key"
+
 	// Dev deploy config keys
 
 	VDevDeployNoYolo = "dev.deploy.no_yolo"
@@ -115,6 +260,9 @@ var (
 
 	// Viper configuration error
 	vConfigError error
+
+	// Name of the config profile applied to the viper instance
+	vProfile string
 )
 
 // initializes the viper singleton for the CLI
@@ -154,10 +302,193 @@ func initViper() *viper.Viper {
 	}
 
 	vConfigError = v.ReadInConfig()
+	if vConfigError == nil {
+		vConfigError = interpolateConfig(v)
+	}
+
+	// The profile has to be applied before any flag defaults are read, so the --profile flag is parsed here
+	vProfile = profileFromArgs(os.Args[1:])
+	if vProfile == "" {
+		vProfile = v.GetString(VProfile)
+	}
+	if vProfile != "" && vConfigError == nil {
+		vConfigError = applyProfile(v, vProfile)
+	} else if vProfile != "" {
+		var notFoundErr viper.ConfigFileNotFoundError
+		if errors.As(vConfigError, &notFoundErr) {
+			vConfigError = fmt.Errorf("profile %q was selected but no config file was found", vProfile)
+		}
+	}
 
 	return v
 }
 
+// interpolationPattern matches ${env:NAME} and ${file:/path} references, a reference prefixed with an extra $ is escaped.
+var interpolationPattern = regexp.MustCompile(`\$?\$\{(env|file):([^}]*)\}`)
+
+// interpolationAllowEnv is the environment variable the interpolation allowlist is read from.
+const interpolationAllowEnv = "ZARF_INTERPOLATION_ALLOW"
+
+// interpolateConfig replaces the ${env:NAME} and ${file:/path} references in the values of the config file. Only
+// references matching the patterns of the interpolation allowlist are resolved, as the values are often secrets they
+// are redacted from logs. The allowlist can not be set in the config file whose references it guards, as the file
+// could then allow itself to read any environment variable or file.
+func interpolateConfig(v *viper.Viper) error {
+	raw := viper.New()
+	raw.SetConfigFile(v.ConfigFileUsed())
+	err := raw.ReadInConfig()
+	if err != nil {
+		return err
+	}
+	if raw.IsSet(VInterpolationAllow) {
+		return fmt.Errorf("%s can not be set in the config file, set it with the %s environment variable", VInterpolationAllow, interpolationAllowEnv)
+	}
+	allow := strings.Fields(os.Getenv(interpolationAllowEnv))
+	interpolated, changed, err := interpolateValue(raw.AllSettings(), allow)
+	if err != nil {
+		return err
+	}
+	if !changed {
+		return nil
+	}
+	return v.MergeConfigMap(interpolated.(map[string]any))
+}
+
+func interpolateValue(value any, allow []string) (any, bool, error) {
+	switch val := value.(type) {
+	case string:
+		var errs []error
+		out := interpolationPattern.ReplaceAllStringFunc(val, func(match string) string {
+			if strings.HasPrefix(match, "$$") {
+				return match[1:]
+			}
+			groups := interpolationPattern.FindStringSubmatch(match)
+			resolved, err := resolveReference(groups[1], groups[2], allow)
+			if err != nil {
+				errs = append(errs, err)
+				return match
+			}
+			logger.AddSecret(resolved)
+			return resolved
+		})
+		return out, out != val, errors.Join(errs...)
+	case map[string]any:
+		out := map[string]any{}
+		changed := false
+		for k, item := range val {
+			resolved, itemChanged, err := interpolateValue(item, allow)
+			if err != nil {
+				return nil, false, err
+			}
+			out[k] = resolved
+			changed = changed || itemChanged
+		}
+		return out, changed, nil
+	case []any:
+		out := []any{}
+		changed := false
+		for _, item := range val {
+			resolved, itemChanged, err := interpolateValue(item, allow)
+			if err != nil {
+				return nil, false, err
+			}
+			out = append(out, resolved)
+			changed = changed || itemChanged
+		}
+		return out, changed, nil
+	default:
+		return value, false, nil
+	}
+}
+
+func resolveReference(kind, ref string, allow []string) (string, error) {
+	allowed := false
+	for _, pattern := range allow {
+		patternKind, glob, ok := strings.Cut(pattern, ":")
+		if !ok || patternKind != kind {
+			continue
+		}
+		if matched, err := filepath.Match(glob, ref); err == nil && matched {
+			allowed = true
+			break
+		}
+	}
+	if !allowed {
+		return "", fmt.Errorf("config reference ${%s:%s} is not allowed, add a matching %s:<pattern> to %s", kind, ref, kind, interpolationAllowEnv)
+	}
+	switch kind {
+	case "env":
+		value, ok := os.LookupEnv(ref)
+		if !ok {
+			return "", fmt.Errorf("config reference ${env:%s} is not set", ref)
+		}
+		return value, nil
+	default:
+		b, err := os.ReadFile(ref)
+		if err != nil {
+			return "", fmt.Errorf("unable to read config reference ${file:%s}: %w", ref, err)
+		}
+		return strings.TrimRight(string(b), "\r\n"), nil
+	}
+}
+
+// profileFromArgs returns the value of the --profile flag without waiting for cobra to parse the flags.
+func profileFromArgs(args []string) string {
+	for i, arg := range args {
+		if arg == "--" {
+			break
+		}
+		if value, ok := strings.CutPrefix(arg, "--profile="); ok {
+			return value
+		}
+		if arg == "--profile" && i+1 < len(args) {
+			return args[i+1]
+		}
+	}
+	return ""
+}
+
+// applyProfile merges the profile and the profiles it extends over the top level config values. Profiles closer to the
+// selected profile take precedence over the profiles they extend.
+func applyProfile(v *viper.Viper, name string) error {
+	profiles := v.GetStringMap(VProfiles)
+	chain := []map[string]any{}
+	seen := map[string]bool{}
+	for current := name; current != ""; {
+		if seen[current] {
+			return fmt.Errorf("config profile %q extends itself", current)
+		}
+		seen[current] = true
+		raw, ok := profiles[strings.ToLower(current)]
+		if !ok {
+			return fmt.Errorf("config profile %q does not exist", current)
+		}
+		profile, ok := raw.(map[string]any)
+		if !ok {
+			return fmt.Errorf("config profile %q must be a map of config values", current)
+		}
+		extends, ok := profile[VProfileExtends].(string)
+		if _, set := profile[VProfileExtends]; set && !ok {
+			return fmt.Errorf("config profile %q must extend a profile by name", current)
+		}
+		values := map[string]any{}
+		for k, val := range profile {
+			if k != VProfileExtends {
+				values[k] = val
+			}
+		}
+		chain = append(chain, values)
+		current = extends
+	}
+	for i := len(chain) - 1; i >= 0; i-- {
+		err := v.MergeConfigMap(chain[i])
+		if err != nil {
+			return fmt.Errorf("unable to apply config profile %q: %w", name, err)
+		}
+	}
+	return nil
+}
+
 // getViper returns the viper singleton
 func getViper() *viper.Viper {
 	if v == nil {
@@ -191,6 +522,9 @@ func PrintViperConfigUsed(ctx context.Context) error {
 	// Zarf skips loading the config file for version and tool commands, this avoids output in those cases
 	if cfgFile := v.ConfigFileUsed(); cfgFile != "" {
 		l.Info("using config file", "location", cfgFile)
+		if vProfile != "" {
+			l.Info("using config profile", "name", vProfile)
+		}
 		ext := filepath.Ext(cfgFile)
 		switch ext {
 		case ".yml", ".yaml":
@@ -211,11 +545,21 @@ func setDefaults() {
 	v.SetDefault(VLogLevel, "info")
 	v.SetDefault(VZarfCache, config.ZarfDefaultCachePath)
 	v.SetDefault(VLogFormat, string(logger.FormatConsole))
+	v.SetDefault(VLogDestination, string(logger.OutputStderr))
+	v.SetDefault(VLogFileMaxSize, logger.DefaultFileMaxSizeMB)
+	v.SetDefault(VLogFileMaxBackups, logger.DefaultFileMaxBackups)
 
 	// Package defaults that are non-zero values
 	v.SetDefault(VPkgOCIConcurrency, 3)
 	v.SetDefault(VPkgRetries, config.ZarfDefaultRetries)
 
+	// Serve opts that are non-zero values
+	v.SetDefault(VServeListen, ":8443")
+
 	// Deploy opts that are non-zero values
 	v.SetDefault(VPkgDeployTimeout, config.ZarfDefaultTimeout)
+	v.SetDefault(VPkgDeployCapacityCheck, types.CapacityCheckWarn)
+
+	// Remove opts that are non-zero values
+	v.SetDefault(VPkgRemoveTimeout, config.ZarfDefaultTimeout)
 }
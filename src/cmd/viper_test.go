@@ -0,0 +1,235 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zarf-dev/zarf/src/pkg/logger"
+)
+
+const profilesConfig = `
+log_level: info
+package:
+  deploy:
+    components: base
+    set:
+      domain: zarf.dev
+      replicas: "1"
+profiles:
+  base:
+    log_level: debug
+    package:
+      deploy:
+        set:
+          replicas: "3"
+  Prod:
+    extends: base
+    package:
+      deploy:
+        components: base,monitoring
+  edge:
+    extends: Prod
+    package:
+      deploy:
+        set:
+          domain: edge.zarf.dev
+  loop:
+    extends: loop
+  invalid:
+    extends: 1
+`
+
+func TestApplyProfile(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		profile     string
+		expected    map[string]any
+		expectedErr string
+	}{
+		{
+			name:    "single",
+			profile: "base",
+			expected: map[string]any{
+				VLogLevel:                   "debug",
+				VPkgDeployComponents:        "base",
+				VPkgDeploySet + ".domain":   "zarf.dev",
+				VPkgDeploySet + ".replicas": "3",
+			},
+		},
+		{
+			name:    "inherited",
+			profile: "edge",
+			expected: map[string]any{
+				VLogLevel:                   "debug",
+				VPkgDeployComponents:        "base,monitoring",
+				VPkgDeploySet + ".domain":   "edge.zarf.dev",
+				VPkgDeploySet + ".replicas": "3",
+			},
+		},
+		{
+			name:        "missing",
+			profile:     "staging",
+			expectedErr: `config profile "staging" does not exist`,
+		},
+		{
+			name:        "cycle",
+			profile:     "loop",
+			expectedErr: `config profile "loop" extends itself`,
+		},
+		{
+			name:        "invalid extends",
+			profile:     "invalid",
+			expectedErr: `config profile "invalid" must extend a profile by name`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			v := viper.New()
+			v.SetConfigType("yaml")
+			err := v.ReadConfig(strings.NewReader(profilesConfig))
+			require.NoError(t, err)
+
+			err = applyProfile(v, tt.profile)
+			if tt.expectedErr != "" {
+				require.EqualError(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			for key, value := range tt.expected {
+				require.Equal(t, value, v.Get(key), key)
+			}
+		})
+	}
+}
+
+func TestProfileFromArgs(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		args     []string
+		expected string
+	}{
+		{
+			name:     "separate value",
+			args:     []string{"package", "deploy", "--profile", "prod", "--confirm"},
+			expected: "prod",
+		},
+		{
+			name:     "inline value",
+			args:     []string{"init", "--profile=edge"},
+			expected: "edge",
+		},
+		{
+			name: "after terminator",
+			args: []string{"tools", "kubectl", "--", "--profile", "prod"},
+		},
+		{
+			name: "unset",
+			args: []string{"package", "list"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			require.Equal(t, tt.expected, profileFromArgs(tt.args))
+		})
+	}
+}
+
+func TestInterpolateConfig(t *testing.T) {
+	dir := t.TempDir()
+	passwordPath := filepath.Join(dir, "password")
+	err := os.WriteFile(passwordPath, []byte("s3cr3t-pa55word\n"), 0o600)
+	require.NoError(t, err)
+	t.Setenv("ZARF_TEST_REGISTRY", "registry.example.com")
+	t.Setenv("ZARF_TEST_UNLISTED", "hidden")
+
+	tests := []struct {
+		name        string
+		allow       string
+		config      string
+		expected    map[string]any
+		expectedErr string
+	}{
+		{
+			name:  "resolved",
+			allow: fmt.Sprintf("env:ZARF_TEST_* file:%s/*", dir),
+			config: fmt.Sprintf(`
+init:
+  registry:
+    url: https://${env:ZARF_TEST_REGISTRY}/v2
+    push_password: ${file:%s}
+package:
+  deploy:
+    set:
+      template: $${env:ZARF_TEST_REGISTRY}
+`, passwordPath),
+			expected: map[string]any{
+				VInitRegistryURL:            "https://registry.example.com/v2",
+				VInitRegistryPushPass:       "s3cr3t-pa55word",
+				VPkgDeploySet + ".template": "${env:ZARF_TEST_REGISTRY}",
+			},
+		},
+		{
+			name:  "not allowed",
+			allow: "env:ZARF_TEST_REGISTRY",
+			config: `
+log_level: ${env:ZARF_TEST_UNLISTED}
+`,
+			expectedErr: "config reference ${env:ZARF_TEST_UNLISTED} is not allowed, add a matching env:<pattern> to ZARF_INTERPOLATION_ALLOW",
+		},
+		{
+			name:  "unset",
+			allow: "env:*",
+			config: `
+log_level: ${env:ZARF_TEST_UNSET}
+`,
+			expectedErr: "config reference ${env:ZARF_TEST_UNSET} is not set",
+		},
+		{
+			name: "allowlist in the config file",
+			config: `
+interpolation:
+  allow: ["env:*"]
+log_level: ${env:ZARF_TEST_UNLISTED}
+`,
+			expectedErr: "interpolation.allow can not be set in the config file, set it with the ZARF_INTERPOLATION_ALLOW environment variable",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("ZARF_INTERPOLATION_ALLOW", tt.allow)
+			path := filepath.Join(t.TempDir(), "zarf-config.yaml")
+			err := os.WriteFile(path, []byte(tt.config), 0o600)
+			require.NoError(t, err)
+			v := viper.New()
+			v.SetConfigFile(path)
+			err = v.ReadInConfig()
+			require.NoError(t, err)
+
+			err = interpolateConfig(v)
+			if tt.expectedErr != "" {
+				require.EqualError(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			for key, value := range tt.expected {
+				require.Equal(t, value, v.Get(key), key)
+			}
+			require.Equal(t, "password is "+logger.RedactedValue, logger.Redact("password is s3cr3t-pa55word"))
+		})
+	}
+}
@@ -20,12 +20,13 @@ import (
 type waitForOptions struct {
 	waitTimeout   string
 	waitNamespace string
+	waitBody      string
 }
 
 func newWaitForCommand() *cobra.Command {
 	o := waitForOptions{}
 	cmd := &cobra.Command{
-		Use:     "wait-for { KIND | PROTOCOL } { NAME | SELECTOR | URI } { CONDITION | HTTP_CODE }",
+		Use:     "wait-for { KIND | PROTOCOL | exec } { NAME | SELECTOR | URI | COMMAND } { CONDITION | HTTP_CODE }",
 		Aliases: []string{"w", "wait"},
 		Short:   lang.CmdToolsWaitForShort,
 		Long:    lang.CmdToolsWaitForLong,
@@ -36,12 +37,13 @@ func newWaitForCommand() *cobra.Command {
 
 	cmd.Flags().StringVar(&o.waitTimeout, "timeout", "5m", lang.CmdToolsWaitForFlagTimeout)
 	cmd.Flags().StringVarP(&o.waitNamespace, "namespace", "n", "", lang.CmdToolsWaitForFlagNamespace)
+	cmd.Flags().StringVar(&o.waitBody, "body", "", lang.CmdToolsWaitForFlagBody)
 	cmd.Flags().BoolVar(&message.NoProgress, "no-progress", false, lang.RootCmdFlagNoProgress)
 
 	return cmd
 }
 
-func (o *waitForOptions) run(_ *cobra.Command, args []string) error {
+func (o *waitForOptions) run(cmd *cobra.Command, args []string) error {
 	// Parse the timeout string
 	timeout, err := time.ParseDuration(o.waitTimeout)
 	if err != nil {
@@ -63,5 +65,5 @@ func (o *waitForOptions) run(_ *cobra.Command, args []string) error {
 	}
 
 	// Execute the wait command.
-	return utils.ExecuteWait(o.waitTimeout, o.waitNamespace, condition, kind, identifier, timeout)
+	return utils.ExecuteWait(cmd.Context(), o.waitTimeout, o.waitNamespace, condition, kind, identifier, o.waitBody, timeout)
 }
@@ -12,7 +12,9 @@ import (
 	"io"
 	"os"
 	"slices"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/AlecAivazis/survey/v2"
 	"github.com/Masterminds/semver/v3"
@@ -25,13 +27,18 @@ import (
 	goyaml "github.com/goccy/go-yaml"
 	"github.com/zarf-dev/zarf/src/config"
 	"github.com/zarf-dev/zarf/src/config/lang"
+	"github.com/zarf-dev/zarf/src/internal/metrics"
 	"github.com/zarf-dev/zarf/src/internal/packager/helm"
 	"github.com/zarf-dev/zarf/src/internal/packager/template"
+	"github.com/zarf-dev/zarf/src/internal/packager2"
 	"github.com/zarf-dev/zarf/src/pkg/cluster"
+	"github.com/zarf-dev/zarf/src/pkg/interactive"
 	"github.com/zarf-dev/zarf/src/pkg/logger"
 	"github.com/zarf-dev/zarf/src/pkg/message"
 	"github.com/zarf-dev/zarf/src/pkg/packager/sources"
 	"github.com/zarf-dev/zarf/src/pkg/pki"
+	"github.com/zarf-dev/zarf/src/pkg/transform"
+	"github.com/zarf-dev/zarf/src/pkg/utils"
 	"github.com/zarf-dev/zarf/src/pkg/zoci"
 	"github.com/zarf-dev/zarf/src/types"
 )
@@ -51,6 +58,9 @@ const (
 	agentKey        = "agent"
 )
 
+// getCredsKeys are the service keys accepted by 'zarf tools get-creds'.
+var getCredsKeys = []string{registryKey, registryReadKey, gitKey, gitReadKey, artifactKey}
+
 type getCredsOptions struct {
 	outputFormat outputFormat
 	outputWriter io.Writer
@@ -85,7 +95,12 @@ func newGetCredsCommand() *cobra.Command {
 		},
 	}
 
-	cmd.Flags().VarP(&o.outputFormat, "output-format", "o", "Prints the output in the specified format. Valid options: table, json, yaml")
+	cmd.Flags().VarP(&o.outputFormat, "output", "o", lang.CmdToolsGetCredsFlagOutput)
+	cmd.Flags().Var(&o.outputFormat, "output-format", lang.CmdToolsGetCredsFlagOutput)
+	err := cmd.Flags().MarkDeprecated("output-format", "please use --output instead.")
+	if err != nil {
+		logger.Default().Debug("unable to mark flag output-format", "error", err)
+	}
 
 	return cmd
 }
@@ -112,11 +127,18 @@ func (o *getCredsOptions) run(ctx context.Context, args []string) error {
 	}
 
 	if len(args) > 0 {
-		// If a component name is provided, only show that component's credentials
-		// Printing both the pterm output and slogger for now
-		printComponentCredential(ctx, state, args[0], o.outputWriter)
-		message.PrintComponentCredential(state, args[0])
-		return nil
+		credential, ok := findCredential(state, args[0])
+		if !ok {
+			return fmt.Errorf("invalid service key %q, valid key choices are: %s", args[0], strings.Join(getCredsKeys, ", "))
+		}
+		// The password alone is printed so that it can be piped, the other output formats print the whole credential
+		if o.outputFormat == outputTable {
+			// Printing both the pterm output and slogger for now
+			printComponentCredential(ctx, state, args[0], o.outputWriter)
+			message.PrintComponentCredential(state, args[0])
+			return nil
+		}
+		return printCredential(credential, o.outputFormat, o.outputWriter)
 	}
 	return printCredentialTable(state, o.outputFormat, o.outputWriter)
 }
@@ -134,72 +156,108 @@ type credentialInfo struct {
 // information is empty and avoid printing that service if so
 func printCredentialTable(state *types.ZarfState, outputFormat outputFormat, out io.Writer) error {
 	var credentials []credentialInfo
+	for _, credential := range getCredentials(state) {
+		// Only the credentials of the internal registry are listed
+		isRegistry := credential.GetCredsKey == registryKey || credential.GetCredsKey == registryReadKey
+		if isRegistry && !state.RegistryInfo.IsInternal() {
+			continue
+		}
+		credentials = append(credentials, credential)
+	}
 
-	if state.RegistryInfo.IsInternal() {
-		credentials = append(credentials,
-			credentialInfo{
-				Application: "Registry",
-				Username:    state.RegistryInfo.PushUsername,
-				Password:    state.RegistryInfo.PushPassword,
-				Connect:     "zarf connect registry",
-				GetCredsKey: registryKey,
-			},
-			credentialInfo{
-				Application: "Registry (read-only)",
-				Username:    state.RegistryInfo.PullUsername,
-				Password:    state.RegistryInfo.PullPassword,
-				Connect:     "zarf connect registry",
-				GetCredsKey: registryReadKey,
-			},
-		)
-	}
-
-	credentials = append(credentials,
-		credentialInfo{
+	switch outputFormat {
+	case outputJSON:
+		output, err := json.MarshalIndent(credentials, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(out, string(output))
+	case outputYAML:
+		output, err := goyaml.Marshal(credentials)
+		if err != nil {
+			return err
+		}
+		fmt.Fprint(out, string(output))
+	case outputTable:
+		header := []string{"Application", "Username", "Password", "Connect", "Get-Creds Key"}
+		var tableData [][]string
+		for _, cred := range credentials {
+			tableData = append(tableData, []string{
+				cred.Application, cred.Username, cred.Password, cred.Connect, cred.GetCredsKey,
+			})
+		}
+		message.TableWithWriter(out, header, tableData)
+	default:
+		return fmt.Errorf("unsupported output format: %s", outputFormat)
+	}
+	return nil
+}
+
+// getCredentials returns the credentials of all Zarf services, in the order they are listed.
+func getCredentials(state *types.ZarfState) []credentialInfo {
+	return []credentialInfo{
+		{
+			Application: "Registry",
+			Username:    state.RegistryInfo.PushUsername,
+			Password:    state.RegistryInfo.PushPassword,
+			Connect:     "zarf connect registry",
+			GetCredsKey: registryKey,
+		},
+		{
+			Application: "Registry (read-only)",
+			Username:    state.RegistryInfo.PullUsername,
+			Password:    state.RegistryInfo.PullPassword,
+			Connect:     "zarf connect registry",
+			GetCredsKey: registryReadKey,
+		},
+		{
 			Application: "Git",
 			Username:    state.GitServer.PushUsername,
 			Password:    state.GitServer.PushPassword,
 			Connect:     "zarf connect git",
 			GetCredsKey: gitKey,
 		},
-		credentialInfo{
+		{
 			Application: "Git (read-only)",
 			Username:    state.GitServer.PullUsername,
 			Password:    state.GitServer.PullPassword,
 			Connect:     "zarf connect git",
 			GetCredsKey: gitReadKey,
 		},
-		credentialInfo{
+		{
 			Application: "Artifact Token",
 			Username:    state.ArtifactServer.PushUsername,
 			Password:    state.ArtifactServer.PushToken,
 			Connect:     "zarf connect git",
 			GetCredsKey: artifactKey,
 		},
-	)
+	}
+}
 
+// findCredential returns the credential of the service key.
+func findCredential(state *types.ZarfState, key string) (credentialInfo, bool) {
+	for _, credential := range getCredentials(state) {
+		if credential.GetCredsKey == strings.ToLower(key) {
+			return credential, true
+		}
+	}
+	return credentialInfo{}, false
+}
+
+func printCredential(credential credentialInfo, outputFormat outputFormat, out io.Writer) error {
 	switch outputFormat {
 	case outputJSON:
-		output, err := json.MarshalIndent(credentials, "", "  ")
+		output, err := json.MarshalIndent(credential, "", "  ")
 		if err != nil {
 			return err
 		}
 		fmt.Fprintln(out, string(output))
 	case outputYAML:
-		output, err := goyaml.Marshal(credentials)
+		output, err := goyaml.Marshal(credential)
 		if err != nil {
 			return err
 		}
 		fmt.Fprint(out, string(output))
-	case outputTable:
-		header := []string{"Application", "Username", "Password", "Connect", "Get-Creds Key"}
-		var tableData [][]string
-		for _, cred := range credentials {
-			tableData = append(tableData, []string{
-				cred.Application, cred.Username, cred.Password, cred.Connect, cred.GetCredsKey,
-			})
-		}
-		message.TableWithWriter(out, header, tableData)
 	default:
 		return fmt.Errorf("unsupported output format: %s", outputFormat)
 	}
@@ -229,7 +287,9 @@ func printComponentCredential(ctx context.Context, state *types.ZarfState, compo
 	}
 }
 
-type updateCredsOptions struct{}
+type updateCredsOptions struct {
+	tls bool
+}
 
 func newUpdateCredsCommand(v *viper.Viper) *cobra.Command {
 	o := updateCredsOptions{}
@@ -260,12 +320,26 @@ func newUpdateCredsCommand(v *viper.Viper) *cobra.Command {
 	cmd.Flags().StringVar(&updateCredsInitOpts.RegistryInfo.PushPassword, "registry-push-password", v.GetString(VInitRegistryPushPass), lang.CmdInitFlagRegPushPass)
 	cmd.Flags().StringVar(&updateCredsInitOpts.RegistryInfo.PullUsername, "registry-pull-username", v.GetString(VInitRegistryPullUser), lang.CmdInitFlagRegPullUser)
 	cmd.Flags().StringVar(&updateCredsInitOpts.RegistryInfo.PullPassword, "registry-pull-password", v.GetString(VInitRegistryPullPass), lang.CmdInitFlagRegPullPass)
+	cmd.Flags().StringVar(&updateCredsInitOpts.RegistryInfo.ProjectAPI, "registry-project-api", v.GetString(VInitRegistryProjectAPI), lang.CmdInitFlagRegProjectAPI)
+	cmd.Flags().StringVar(&updateCredsInitOpts.RegistryInfo.ProjectUsername, "registry-project-username", v.GetString(VInitRegistryProjectUser), lang.CmdInitFlagRegProjectUser)
+	cmd.Flags().StringVar(&updateCredsInitOpts.RegistryInfo.ProjectPassword, "registry-project-password", v.GetString(VInitRegistryProjectPass), lang.CmdInitFlagRegProjectPass)
 
 	// Flags for using an external artifact server
 	cmd.Flags().StringVar(&updateCredsInitOpts.ArtifactServer.Address, "artifact-url", v.GetString(VInitArtifactURL), lang.CmdInitFlagArtifactURL)
 	cmd.Flags().StringVar(&updateCredsInitOpts.ArtifactServer.PushUsername, "artifact-push-username", v.GetString(VInitArtifactPushUser), lang.CmdInitFlagArtifactPushUser)
 	cmd.Flags().StringVar(&updateCredsInitOpts.ArtifactServer.PushToken, "artifact-push-token", v.GetString(VInitArtifactPushToken), lang.CmdInitFlagArtifactPushToken)
 
+	// Flags for rotating the TLS certificates of the internal registry and git server
+	cmd.Flags().BoolVar(&o.tls, "tls", false, lang.CmdToolsUpdateCredsFlagTLS)
+	cmd.Flags().StringVar(&updateCredsInitOpts.RegistryTLS.CertFile, "registry-tls-cert", v.GetString(VInitRegistryTLSCert), lang.CmdInitFlagRegTLSCert)
+	cmd.Flags().StringVar(&updateCredsInitOpts.RegistryTLS.KeyFile, "registry-tls-key", v.GetString(VInitRegistryTLSKey), lang.CmdInitFlagRegTLSKey)
+	cmd.Flags().StringVar(&updateCredsInitOpts.RegistryTLS.CAFile, "registry-tls-ca", v.GetString(VInitRegistryTLSCA), lang.CmdInitFlagRegTLSCA)
+	cmd.Flags().StringVar(&updateCredsInitOpts.RegistryTLS.Issuer, "registry-tls-issuer", v.GetString(VInitRegistryTLSIssuer), lang.CmdInitFlagRegTLSIssuer)
+	cmd.Flags().StringVar(&updateCredsInitOpts.GitServerTLS.CertFile, "git-tls-cert", v.GetString(VInitGitTLSCert), lang.CmdInitFlagGitTLSCert)
+	cmd.Flags().StringVar(&updateCredsInitOpts.GitServerTLS.KeyFile, "git-tls-key", v.GetString(VInitGitTLSKey), lang.CmdInitFlagGitTLSKey)
+	cmd.Flags().StringVar(&updateCredsInitOpts.GitServerTLS.CAFile, "git-tls-ca", v.GetString(VInitGitTLSCA), lang.CmdInitFlagGitTLSCA)
+	cmd.Flags().StringVar(&updateCredsInitOpts.GitServerTLS.Issuer, "git-tls-issuer", v.GetString(VInitGitTLSIssuer), lang.CmdInitFlagGitTLSIssuer)
+
 	cmd.Flags().SortFlags = true
 
 	return cmd
@@ -300,6 +374,16 @@ func (o *updateCredsOptions) run(cmd *cobra.Command, args []string) error {
 	if oldState.Distro == "" {
 		return errors.New("zarf state secret did not load properly")
 	}
+	if o.tls {
+		return rotateTLS(ctx, c, oldState, args)
+	}
+	err = getViper().UnmarshalKey(VInitRegistryImageRules, &updateCredsInitOpts.RegistryInfo.ImageRules)
+	if err != nil {
+		return fmt.Errorf("invalid %s config: %w", VInitRegistryImageRules, err)
+	}
+	if err := transform.ValidateImageRules(updateCredsInitOpts.RegistryInfo.ImageRules); err != nil {
+		return fmt.Errorf("invalid registry image rules: %w", err)
+	}
 	newState, err := cluster.MergeZarfState(oldState, updateCredsInitOpts, args)
 	if err != nil {
 		return fmt.Errorf("unable to update Zarf credentials: %w", err)
@@ -309,24 +393,17 @@ func (o *updateCredsOptions) run(cmd *cobra.Command, args []string) error {
 	message.PrintCredentialUpdates(oldState, newState, args)
 	printCredentialUpdates(ctx, oldState, newState, args)
 
-	confirm := config.CommonOptions.Confirm
-
-	if !confirm {
-		prompt := &survey.Confirm{
-			Message: lang.CmdToolsUpdateCredsConfirmContinue,
-		}
-		if err := survey.AskOne(prompt, &confirm); err != nil {
-			return fmt.Errorf("confirm selection canceled: %w", err)
-		}
+	confirm, err := confirmCredentialUpdates()
+	if err != nil {
+		return err
 	}
-
 	if !confirm {
 		return nil
 	}
 
 	// Update registry and git pull secrets
 	if slices.Contains(args, message.RegistryKey) {
-		err := c.UpdateZarfManagedImageSecrets(ctx, newState)
+		err := c.SyncPullSecrets(ctx, newState)
 		if err != nil {
 			return err
 		}
@@ -387,6 +464,90 @@ func (o *updateCredsOptions) run(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+func confirmCredentialUpdates() (bool, error) {
+	confirm := config.CommonOptions.Confirm
+	if confirm {
+		return true, nil
+	}
+	if err := interactive.CheckPrompt("confirmation to update credentials, confirm it with --confirm"); err != nil {
+		return false, err
+	}
+	prompt := &survey.Confirm{
+		Message: lang.CmdToolsUpdateCredsConfirmContinue,
+	}
+	if err := survey.AskOne(prompt, &confirm); err != nil {
+		return false, fmt.Errorf("confirm selection canceled: %w", err)
+	}
+	return confirm, nil
+}
+
+// rotateTLS replaces the certificates of the internal registry and git server that TLS options were provided for.
+func rotateTLS(ctx context.Context, c *cluster.Cluster, state *types.ZarfState, services []string) error {
+	l := logger.From(ctx)
+	if len(services) == 1 && !slices.Contains([]string{message.RegistryKey, message.GitKey}, services[0]) {
+		return errors.New(lang.CmdToolsUpdateCredsErrTLSService)
+	}
+	rotateRegistry := slices.Contains(services, message.RegistryKey) && updateCredsInitOpts.RegistryTLS.Enabled()
+	rotateGit := slices.Contains(services, message.GitKey) && updateCredsInitOpts.GitServerTLS.Enabled()
+	if !rotateRegistry && !rotateGit {
+		return errors.New(lang.CmdToolsUpdateCredsErrTLSMissing)
+	}
+	if rotateRegistry {
+		if state.RegistryInfo.TLS == nil {
+			return fmt.Errorf(lang.CmdToolsUpdateCredsErrTLSNotEnabled, "registry")
+		}
+		if err := cluster.ValidateTLSOptions(updateCredsInitOpts.RegistryTLS); err != nil {
+			return fmt.Errorf("invalid registry TLS options: %w", err)
+		}
+		l.Info("registry TLS certificate will be rotated", "secret", state.RegistryInfo.TLS.SecretName)
+	}
+	if rotateGit {
+		if state.GitServer.TLS == nil {
+			return fmt.Errorf(lang.CmdToolsUpdateCredsErrTLSNotEnabled, "git server")
+		}
+		if err := cluster.ValidateTLSOptions(updateCredsInitOpts.GitServerTLS); err != nil {
+			return fmt.Errorf("invalid git server TLS options: %w", err)
+		}
+		l.Info("Git server TLS certificate will be rotated", "secret", state.GitServer.TLS.SecretName)
+	}
+
+	confirm, err := confirmCredentialUpdates()
+	if err != nil {
+		return err
+	}
+	if !confirm {
+		return nil
+	}
+
+	if rotateRegistry {
+		state.RegistryInfo.TLS, err = c.RotateServiceTLS(ctx, cluster.RegistryTLSService, updateCredsInitOpts.RegistryTLS)
+		if err != nil {
+			return fmt.Errorf("unable to rotate the registry TLS certificate: %w", err)
+		}
+		// The image pull secrets carry the CA bundle workloads verify the registry with
+		err = c.UpdateZarfManagedImageSecrets(ctx, state)
+		if err != nil {
+			return err
+		}
+	}
+	if rotateGit {
+		state.GitServer.TLS, err = c.RotateServiceTLS(ctx, cluster.GitServerTLSService, updateCredsInitOpts.GitServerTLS)
+		if err != nil {
+			return fmt.Errorf("unable to rotate the git server TLS certificate: %w", err)
+		}
+		// The git secrets carry the CA bundle GitOps controllers verify the git server with
+		err = c.UpdateZarfManagedGitSecrets(ctx, state)
+		if err != nil {
+			return err
+		}
+	}
+	err = c.SaveZarfState(ctx, state)
+	if err != nil {
+		return fmt.Errorf("failed to save the Zarf State to the cluster: %w", err)
+	}
+	return nil
+}
+
 func printCredentialUpdates(ctx context.Context, oldState *types.ZarfState, newState *types.ZarfState, services []string) {
 	// Pause the logfile's output to avoid credentials being printed to the log file
 	l := logger.From(ctx)
@@ -425,6 +586,430 @@ func printCredentialUpdates(ctx context.Context, oldState *types.ZarfState, newS
 	}
 }
 
+type syncPullSecretsOptions struct {
+	selector string
+}
+
+func newSyncPullSecretsCommand() *cobra.Command {
+	o := &syncPullSecretsOptions{}
+
+	cmd := &cobra.Command{
+		Use:     "sync-pull-secrets",
+		Short:   lang.CmdToolsSyncPullSecretsShort,
+		Long:    lang.CmdToolsSyncPullSecretsLong,
+		Example: lang.CmdToolsSyncPullSecretsExample,
+		Args:    cobra.NoArgs,
+		RunE:    o.run,
+	}
+
+	cmd.Flags().StringVar(&o.selector, "selector", "", lang.CmdToolsSyncPullSecretsFlagSelector)
+
+	return cmd
+}
+
+func (o *syncPullSecretsOptions) run(cmd *cobra.Command, _ []string) error {
+	ctx := cmd.Context()
+	l := logger.From(ctx)
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, cluster.DefaultTimeout)
+	defer cancel()
+	c, err := cluster.NewClusterWithWait(timeoutCtx)
+	if err != nil {
+		return err
+	}
+
+	state, err := c.LoadZarfState(ctx)
+	if err != nil {
+		return err
+	}
+	if state.Distro == "" {
+		return errors.New("zarf state secret did not load properly")
+	}
+
+	// Store the new selector before syncing so that the agent selects the same namespaces
+	if cmd.Flags().Changed("selector") {
+		if _, err := cluster.ParsePullSecretSelector(o.selector); err != nil {
+			return err
+		}
+		state.PullSecretSelector = o.selector
+		if err := c.SaveZarfState(ctx, state); err != nil {
+			return fmt.Errorf("failed to save the Zarf State to the cluster: %w", err)
+		}
+		l.Info("updated the pull secret selector", "selector", o.selector)
+	}
+
+	return c.SyncPullSecrets(ctx, state)
+}
+
+func newStateCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "state",
+		Short: lang.CmdToolsStateShort,
+	}
+
+	cmd.AddCommand(newStateInspectCommand())
+	cmd.AddCommand(newStateEditCommand())
+	cmd.AddCommand(newStateMigrateCommand())
+
+	return cmd
+}
+
+type stateInspectOptions struct {
+	outputFormat outputFormat
+	outputWriter io.Writer
+}
+
+func newStateInspectCommand() *cobra.Command {
+	o := &stateInspectOptions{
+		outputFormat: outputJSON,
+		outputWriter: message.OutputWriter,
+	}
+
+	cmd := &cobra.Command{
+		Use:     "inspect [FIELD]",
+		Short:   lang.CmdToolsStateInspectShort,
+		Long:    lang.CmdToolsStateInspectLong,
+		Example: lang.CmdToolsStateInspectExample,
+		Args:    cobra.MaximumNArgs(1),
+		ValidArgsFunction: func(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+			return cluster.ZarfStateFields(), cobra.ShellCompDirectiveNoFileComp
+		},
+		RunE: o.run,
+	}
+
+	cmd.Flags().VarP(&o.outputFormat, "output", "o", lang.CmdToolsStateInspectFlagOut)
+
+	return cmd
+}
+
+func (o *stateInspectOptions) run(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, cluster.DefaultTimeout)
+	defer cancel()
+	c, err := cluster.NewClusterWithWait(timeoutCtx)
+	if err != nil {
+		return err
+	}
+
+	state, err := c.LoadZarfState(ctx)
+	if err != nil {
+		return err
+	}
+	if len(args) == 1 {
+		value, err := cluster.GetZarfStateField(state, args[0])
+		if err != nil {
+			return err
+		}
+		if cluster.IsSensitiveZarfStateField(args[0]) {
+			value = "**sanitized**"
+		}
+		fmt.Fprintln(o.outputWriter, value)
+		return nil
+	}
+
+	output, err := json.MarshalIndent(cluster.SanitizeZarfState(state), "", "  ")
+	if err != nil {
+		return err
+	}
+	switch o.outputFormat {
+	case outputJSON:
+		fmt.Fprintln(o.outputWriter, string(output))
+	case outputYAML:
+		output, err := goyaml.JSONToYAML(output)
+		if err != nil {
+			return err
+		}
+		fmt.Fprint(o.outputWriter, string(output))
+	default:
+		return fmt.Errorf("unsupported output format %s, use json or yaml", o.outputFormat)
+	}
+	return nil
+}
+
+type stateEditOptions struct {
+	set []string
+}
+
+func newStateEditCommand() *cobra.Command {
+	o := &stateEditOptions{}
+
+	cmd := &cobra.Command{
+		Use:     "edit",
+		Short:   lang.CmdToolsStateEditShort,
+		Long:    fmt.Sprintf(lang.CmdToolsStateEditLong, strings.Join(cluster.ZarfStateFields(), ", "), cluster.ZarfStateBackupSecretName),
+		Example: lang.CmdToolsStateEditExample,
+		Args:    cobra.NoArgs,
+		RunE:    o.run,
+	}
+
+	// Always require confirm flag (no viper)
+	cmd.Flags().BoolVar(&config.CommonOptions.Confirm, "confirm", false, lang.CmdToolsStateEditFlagConfirm)
+	cmd.Flags().StringArrayVar(&o.set, "set", nil, lang.CmdToolsStateEditFlagSet)
+	_ = cmd.MarkFlagRequired("set")
+
+	return cmd
+}
+
+func (o *stateEditOptions) run(cmd *cobra.Command, _ []string) error {
+	ctx := cmd.Context()
+	l := logger.From(ctx)
+
+	fields := []string{}
+	values := map[string]string{}
+	for _, set := range o.set {
+		key, value, ok := strings.Cut(set, "=")
+		if !ok {
+			return fmt.Errorf("invalid --set %q, it must be FIELD=VALUE", set)
+		}
+		if _, ok := values[key]; !ok {
+			fields = append(fields, key)
+		}
+		values[key] = value
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, cluster.DefaultTimeout)
+	defer cancel()
+	c, err := cluster.NewClusterWithWait(timeoutCtx)
+	if err != nil {
+		return err
+	}
+
+	oldState, err := c.LoadZarfState(ctx)
+	if err != nil {
+		return err
+	}
+	// The edited fields are not pointers so a shallow copy keeps the loaded state as it was
+	newState := *oldState
+	for _, key := range fields {
+		if err := cluster.SetZarfStateField(&newState, key, values[key]); err != nil {
+			return err
+		}
+	}
+	if err := cluster.ValidateZarfState(&newState); err != nil {
+		return fmt.Errorf("invalid Zarf state: %w", err)
+	}
+
+	changed := []string{}
+	for _, key := range fields {
+		existing, err := cluster.GetZarfStateField(oldState, key)
+		if err != nil {
+			return err
+		}
+		replacement, err := cluster.GetZarfStateField(&newState, key)
+		if err != nil {
+			return err
+		}
+		if existing == replacement {
+			continue
+		}
+		changed = append(changed, key)
+		// Certificates and keys span several lines and keys must not be printed
+		if cluster.IsSensitiveZarfStateField(key) || strings.Contains(replacement, "\n") {
+			l.Info("Zarf state field", "field", key, "changed", true)
+			continue
+		}
+		l.Info("Zarf state field", "field", key, "existing", existing, "replacement", replacement)
+	}
+	if len(changed) == 0 {
+		l.Info("the Zarf state already has these values")
+		return nil
+	}
+
+	confirm, err := confirmStateEdit()
+	if err != nil {
+		return err
+	}
+	if !confirm {
+		return nil
+	}
+
+	if err := c.BackupZarfState(ctx); err != nil {
+		return err
+	}
+	l.Info("backed up the Zarf state", "secret", cluster.ZarfStateBackupSecretNameFor(c.Instance))
+	if err := c.SaveZarfState(ctx, &newState); err != nil {
+		return fmt.Errorf("failed to save the Zarf State to the cluster: %w", err)
+	}
+
+	// Update the secrets that are created from the edited fields
+	if slices.ContainsFunc(changed, func(key string) bool { return strings.HasPrefix(key, "registryInfo.") || key == "pullSecretSelector" }) {
+		if err := c.SyncPullSecrets(ctx, &newState); err != nil {
+			return err
+		}
+	}
+	if slices.Contains(changed, "gitServer.address") {
+		if err := c.UpdateZarfManagedGitSecrets(ctx, &newState); err != nil {
+			return err
+		}
+	}
+	if slices.ContainsFunc(changed, func(key string) bool { return strings.HasPrefix(key, "agentTLS.") }) {
+		h := helm.NewClusterOnly(&types.PackagerConfig{}, template.GetZarfVariableConfig(ctx), &newState, c)
+		if err := h.UpdateZarfAgentValues(ctx); err != nil {
+			// Warn if we couldn't actually update the agent (it might not be installed and we should try to continue)
+			message.Warnf(lang.CmdToolsUpdateCredsUnableUpdateAgent, err.Error())
+			l.Warn("unable to update Zarf Agent TLS secrets", "error", err.Error())
+		}
+	}
+	l.Info("updated the Zarf state", "fields", changed)
+	return nil
+}
+
+func confirmStateEdit() (bool, error) {
+	confirm := config.CommonOptions.Confirm
+	if confirm {
+		return true, nil
+	}
+	if err := interactive.CheckPrompt("confirmation to edit the Zarf state, confirm it with --confirm"); err != nil {
+		return false, err
+	}
+	prompt := &survey.Confirm{
+		Message: lang.CmdToolsStateEditConfirmContinue,
+	}
+	if err := survey.AskOne(prompt, &confirm); err != nil {
+		return false, fmt.Errorf("confirm selection canceled: %w", err)
+	}
+	return confirm, nil
+}
+
+type stateMigrateOptions struct {
+	dryRun bool
+}
+
+func newStateMigrateCommand() *cobra.Command {
+	o := &stateMigrateOptions{}
+
+	cmd := &cobra.Command{
+		Use:     "migrate",
+		Short:   lang.CmdToolsStateMigrateShort,
+		Long:    lang.CmdToolsStateMigrateLong,
+		Example: lang.CmdToolsStateMigrateExample,
+		Args:    cobra.NoArgs,
+		RunE:    o.run,
+	}
+
+	cmd.Flags().BoolVar(&o.dryRun, "dry-run", false, lang.CmdToolsStateMigrateFlagDryRun)
+
+	return cmd
+}
+
+func (o *stateMigrateOptions) run(cmd *cobra.Command, _ []string) error {
+	ctx := cmd.Context()
+	l := logger.From(ctx)
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, cluster.DefaultTimeout)
+	defer cancel()
+	c, err := cluster.NewClusterWithWait(timeoutCtx)
+	if err != nil {
+		return err
+	}
+
+	applied, err := c.MigrateZarfState(ctx, o.dryRun)
+	if err != nil {
+		return err
+	}
+	if len(applied) == 0 {
+		l.Info("the Zarf state is up to date", "schemaVersion", cluster.ZarfStateSchemaVersion)
+		return nil
+	}
+	for _, migration := range applied {
+		l.Info("state migration", "schemaVersion", migration.Version, "description", migration.Description)
+	}
+	if o.dryRun {
+		l.Info("dry run, the Zarf state was not changed", "migrations", len(applied))
+	}
+	return nil
+}
+
+type inventoryOptions struct {
+	packageName  string
+	outputFormat outputFormat
+	outputWriter io.Writer
+	cluster      *cluster.Cluster
+}
+
+func newInventoryOptions() *inventoryOptions {
+	return &inventoryOptions{
+		outputFormat: outputTable,
+		// TODO accept output writer as a parameter to the root Zarf command and pass it through here
+		outputWriter: message.OutputWriter,
+	}
+}
+
+func newInventoryCommand() *cobra.Command {
+	o := newInventoryOptions()
+
+	cmd := &cobra.Command{
+		Use:     "inventory",
+		Aliases: []string{"inv"},
+		Short:   lang.CmdToolsInventoryShort,
+		Long:    lang.CmdToolsInventoryLong,
+		Example: lang.CmdToolsInventoryExample,
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := cmd.Context()
+			err := o.complete(ctx)
+			if err != nil {
+				return err
+			}
+			return o.run(ctx)
+		},
+	}
+
+	cmd.Flags().StringVar(&o.packageName, "package", "", lang.CmdToolsInventoryFlagPackage)
+	cmd.Flags().VarP(&o.outputFormat, "output", "o", "Prints the output in the specified format. Valid options: table, json, yaml")
+
+	return cmd
+}
+
+func (o *inventoryOptions) complete(ctx context.Context) error {
+	timeoutCtx, cancel := context.WithTimeout(ctx, cluster.DefaultTimeout)
+	defer cancel()
+	c, err := cluster.NewClusterWithWait(timeoutCtx)
+	if err != nil {
+		return err
+	}
+	o.cluster = c
+	return nil
+}
+
+func (o *inventoryOptions) run(ctx context.Context) error {
+	resources, err := packager2.Inventory(ctx, packager2.InventoryOptions{
+		Cluster:     o.cluster,
+		PackageName: o.packageName,
+	})
+	if err != nil {
+		return err
+	}
+
+	switch o.outputFormat {
+	case outputJSON:
+		output, err := json.MarshalIndent(resources, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(o.outputWriter, string(output))
+	case outputYAML:
+		output, err := goyaml.Marshal(resources)
+		if err != nil {
+			return err
+		}
+		fmt.Fprint(o.outputWriter, string(output))
+	case outputTable:
+		header := []string{"Package", "Component", "Kind", "Namespace", "Name"}
+		var tableData [][]string
+		for _, resource := range resources {
+			tableData = append(tableData, []string{
+				resource.Package, resource.Component, resource.Kind, resource.Namespace, resource.Name,
+			})
+		}
+		message.TableWithWriter(o.outputWriter, header, tableData)
+	default:
+		return fmt.Errorf("unsupported output format: %s", o.outputFormat)
+	}
+	return nil
+}
+
 type clearCacheOptions struct{}
 
 func newClearCacheCommand() *cobra.Command {
@@ -458,6 +1043,96 @@ func (o *clearCacheOptions) run(cmd *cobra.Command, _ []string) error {
 	return nil
 }
 
+type reportOptions struct {
+	file         string
+	since        time.Duration
+	outputFormat outputFormat
+	outputWriter io.Writer
+}
+
+func newReportOptions() *reportOptions {
+	return &reportOptions{
+		outputFormat: outputTable,
+		outputWriter: message.OutputWriter,
+	}
+}
+
+func newReportCommand() *cobra.Command {
+	o := newReportOptions()
+
+	cmd := &cobra.Command{
+		Use:     "report",
+		Short:   lang.CmdToolsReportShort,
+		Long:    lang.CmdToolsReportLong,
+		Example: lang.CmdToolsReportExample,
+		Args:    cobra.NoArgs,
+		RunE:    o.run,
+	}
+
+	cmd.Flags().StringVar(&o.file, "file", "", lang.CmdToolsReportFlagFile)
+	cmd.Flags().DurationVar(&o.since, "since", 0, lang.CmdToolsReportFlagSince)
+	cmd.Flags().VarP(&o.outputFormat, "output-format", "o", "Prints the output in the specified format. Valid options: table, json, yaml")
+
+	return cmd
+}
+
+func (o *reportOptions) run(_ *cobra.Command, _ []string) error {
+	path := o.file
+	if path == "" {
+		defaultPath, err := metrics.DefaultPath()
+		if err != nil {
+			return err
+		}
+		path = defaultPath
+	}
+	recorded, err := metrics.Read(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return errors.New(lang.CmdToolsReportNoMetrics)
+	}
+	if err != nil {
+		return err
+	}
+	since := time.Time{}
+	if o.since > 0 {
+		since = time.Now().Add(-o.since)
+	}
+	summaries := metrics.Summarize(recorded, since)
+
+	switch o.outputFormat {
+	case outputJSON:
+		output, err := json.MarshalIndent(summaries, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(o.outputWriter, string(output))
+	case outputYAML:
+		output, err := goyaml.Marshal(summaries)
+		if err != nil {
+			return err
+		}
+		fmt.Fprint(o.outputWriter, string(output))
+	case outputTable:
+		header := []string{"Operation", "Count", "Failures", "P50", "P95", "Max", "Avg Size", "Throughput"}
+		var summaryData [][]string
+		for _, s := range summaries {
+			summaryData = append(summaryData, []string{
+				string(s.Operation),
+				strconv.Itoa(s.Count),
+				strconv.Itoa(s.Failures),
+				s.P50Duration.String(),
+				s.P95Duration.String(),
+				s.MaxDuration.String(),
+				utils.ByteFormat(float64(s.AvgSize), 2),
+				fmt.Sprintf("%s/s", utils.ByteFormat(float64(s.Throughput), 2)),
+			})
+		}
+		message.TableWithWriter(o.outputWriter, header, summaryData)
+	default:
+		return fmt.Errorf("unsupported output format: %s", o.outputFormat)
+	}
+	return nil
+}
+
 type downloadInitOptions struct {
 	version string
 }
@@ -558,6 +1233,10 @@ func newGenKeyCommand() *cobra.Command {
 func (o *genKeyOptions) run(cmd *cobra.Command, _ []string) error {
 	// Utility function to prompt the user for the password to the private key
 	passwordFunc := func(bool) ([]byte, error) {
+		if err := interactive.CheckPrompt("private key password"); err != nil {
+			return nil, err
+		}
+
 		// perform the first prompt
 		var password string
 		prompt := &survey.Password{
@@ -597,6 +1276,9 @@ func (o *genKeyOptions) run(cmd *cobra.Command, _ []string) error {
 	_, prvKeyExistsErr := os.Stat(prvKeyFileName)
 	_, pubKeyExistsErr := os.Stat(pubKeyFileName)
 	if prvKeyExistsErr == nil || pubKeyExistsErr == nil {
+		if err := interactive.CheckPrompt(fmt.Sprintf("confirmation to overwrite %s", prvKeyFileName)); err != nil {
+			return err
+		}
 		var confirm bool
 		confirmOverwritePrompt := &survey.Confirm{
 			Message: fmt.Sprintf(lang.CmdToolsGenKeyPromptExists, prvKeyFileName),
@@ -25,7 +25,9 @@ func TestGetCreds(t *testing.T) {
 	tests := []struct {
 		name         string
 		outputFormat outputFormat
+		args         []string
 		file         string
+		expectedErr  string
 	}{
 		{
 			name:         "json get creds",
@@ -37,6 +39,24 @@ func TestGetCreds(t *testing.T) {
 			outputFormat: outputYAML,
 			file:         "expected.yaml",
 		},
+		{
+			name:         "json get single cred",
+			outputFormat: outputJSON,
+			args:         []string{"registry-readonly"},
+			file:         "expected-registry-readonly.json",
+		},
+		{
+			name:         "yaml get single cred",
+			outputFormat: outputYAML,
+			args:         []string{"git"},
+			file:         "expected-git.yaml",
+		},
+		{
+			name:         "unknown key",
+			outputFormat: outputJSON,
+			args:         []string{"agent"},
+			expectedErr:  `invalid service key "agent"`,
+		},
 	}
 
 	for _, tt := range tests {
@@ -90,7 +110,11 @@ func TestGetCreds(t *testing.T) {
 				outputWriter: buf,
 				cluster:      c,
 			}
-			err = getCredsOpts.run(ctx, nil)
+			err = getCredsOpts.run(ctx, tt.args)
+			if tt.expectedErr != "" {
+				require.ErrorContains(t, err, tt.expectedErr)
+				return
+			}
 			require.NoError(t, err)
 			b, err = os.ReadFile(filepath.Join("testdata", "get-creds", tt.file))
 			require.NoError(t, err)
@@ -55,8 +55,11 @@ var (
 	// CLIArch is the computer architecture of the device executing the CLI commands
 	CLIArch string
 
-	// ZarfSeedPort is the NodePort Zarf uses for the 'seed registry'
-	ZarfSeedPort string
+	// ZarfSeedRegistry is the address the 'seed registry' image is pulled from during init
+	ZarfSeedRegistry string
+
+	// ZarfSeedNode is the hostname of the node the 'seed registry' has to run on, empty when it can run on any node
+	ZarfSeedNode string
 
 	CosignPublicKey string
 
@@ -44,6 +44,11 @@ const (
 	RootCmdFlagLogLevel              = "Log level when running Zarf. Valid options are: warn, info, debug, trace"
 	RootCmdFlagArch                  = "Architecture for OCI images and Zarf packages"
 	RootCmdFlagSkipLogFile           = "Disable log file creation"
+	RootCmdFlagLogDestination        = "Where to send logs. Valid options are: 'stderr', 'syslog', 'journald'. The syslog and journald destinations send logs to the host logging system and ignore the log format"
+	RootCmdFlagLogFile               = "Write logs to this file in addition to the console. The file is rotated and old files are removed based on the log-file-* flags"
+	RootCmdFlagLogFileMaxSize        = "Size in megabytes a log file can grow to before it is rotated"
+	RootCmdFlagLogFileMaxBackups     = "Number of rotated log files to retain"
+	RootCmdFlagLogFileCompress       = "Compress rotated log files with gzip"
 	RootCmdFlagNoProgress            = "Disable fancy UI progress bars, spinners, logos, etc"
 	RootCmdFlagNoColor               = "Disable colors in output"
 	RootCmdFlagCachePath             = "Specify the location of the Zarf cache directory"
@@ -51,10 +56,21 @@ const (
 	RootCmdFlagInsecure              = "Allow access to insecure registries and disable other recommended security enforcements such as package checksum and signature validation. This flag should only be used if you have a specific reason and accept the reduced security posture."
 	RootCmdFlagPlainHTTP             = "Force the connections over HTTP instead of HTTPS. This flag should only be used if you have a specific reason and accept the reduced security posture."
 	RootCmdFlagInsecureSkipTLSVerify = "Skip checking server's certificate for validity. This flag should only be used if you have a specific reason and accept the reduced security posture."
+	RootCmdFlagRecordMetrics         = "Record anonymized timing and size metrics of package create and deploy operations to a local file in the Zarf cache. Metrics are never sent over the network"
+	RootCmdFlagEvents                = "Emit a stream of structured progress events separate from logs. Valid options are: 'json'. Events are written to stdout unless --events-file or --progress-fd is set, use --events-file for commands that print their results to stdout"
+	RootCmdFlagProgressFD            = "File descriptor to write the structured progress event stream to. Enables the event stream in json format"
+	RootCmdFlagEventsFile            = "Path of the file to write the structured progress event stream to. Enables the event stream in json format"
+	RootCmdFlagOTLPEndpoint          = "OTLP HTTP endpoint to export OpenTelemetry traces of package operations to (e.g. http://localhost:4318). Tracing is disabled when empty"
+	RootCmdFlagNonInteractive        = "Fail with exit code 5 instead of prompting for confirmations, variables, component selections or passwords"
+	RootCmdFlagProfile               = "Name of the profile in the Zarf config file to apply. Profile values override top level values and can extend another profile"
+	RootCmdFlagInstance              = "Name of the Zarf instance of the cluster to use. Each instance has its own state, registry and git server and owns the namespaces its packages deploy to. Defaults to the instance created by 'zarf init' without --instance"
+	RootCmdFlagFIPS                  = "Enforce the FIPS crypto policy. Restricts TLS to approved cipher suites and curves, and requires image digests in packages to use SHA-256 or stronger. MD5 and SHA-1 are not disabled, SHA-1 still derives identifiers that are not security relevant such as git object IDs and the release names of manifests. The crypto of embedded tools is only checked to come from the crypto module of the binary"
 
 	RootCmdDeprecatedDeploy = "Deprecated: Please use \"zarf package deploy %s\" to deploy this package.  This warning will be removed in Zarf v1.0.0."
 	RootCmdDeprecatedCreate = "Deprecated: Please use \"zarf package create\" to create this package.  This warning will be removed in Zarf v1.0.0."
 
+	RootInterrupted = "Interrupted, stopping and cleaning up. Interrupt again to exit immediately without cleaning up."
+
 	// zarf connect
 	CmdConnectShort = "Accesses services or pods deployed in the cluster"
 	CmdConnectLong  = "Uses a k8s port-forward to connect to resources within the cluster referenced by your kube-context.\n" +
@@ -101,6 +117,30 @@ const (
 
 	CmdDestroyErrScriptPermissionDenied = "Received 'permission denied' when trying to execute the script (%s). Please double-check you have the correct kube-context."
 
+	// zarf serve
+	CmdServeShort = "Runs an API server that exposes package operations"
+	CmdServeLong  = "Runs an API server that exposes package create, deploy, inspect and remove operations over REST and gRPC on the same address.\n\n" +
+		"Create, deploy and remove requests are queued as jobs and run one at a time. The status of a job can be queried " +
+		"and its logs and progress events streamed as newline delimited JSON until it finishes. The 100 most recent finished jobs " +
+		"are kept, older jobs are dropped with their logs and events. Inspect requests wait for the running job to finish. " +
+		"Every request must present the API token as a bearer token, in the authorization metadata for gRPC calls. Paths in requests are resolved on the host running the server.\n\n" +
+		"The gRPC service is zarf.server.v1.PackageService. It supports server reflection and is defined in src/internal/server/zarf.proto."
+	CmdServeExample = `
+# Serve the API over TLS with a token read from a file
+$ zarf serve --listen :8443 --token-file /etc/zarf/token --tls-cert /etc/zarf/tls.crt --tls-key /etc/zarf/tls.key
+
+# Deploy a package and follow its logs
+$ curl -H "Authorization: Bearer $TOKEN" -d '{"source": "oci://ghcr.io/zarf-dev/packages/dos-games:1.1.0"}' https://localhost:8443/v1/packages/deploy
+$ curl -H "Authorization: Bearer $TOKEN" https://localhost:8443/v1/jobs/<id>/logs
+
+# Deploy a package over gRPC and follow its logs
+$ grpcurl -H "Authorization: Bearer $TOKEN" -d '{"source": "oci://ghcr.io/zarf-dev/packages/dos-games:1.1.0"}' localhost:8443 zarf.server.v1.PackageService/DeployPackage
+$ grpcurl -H "Authorization: Bearer $TOKEN" -d '{"id": "<id>"}' localhost:8443 zarf.server.v1.PackageService/StreamJobLogs`
+	CmdServeFlagListen    = "Address the API server listens on"
+	CmdServeFlagTokenFile = "Path to a file containing the API token clients must present. The token can also be set with the ZARF_SERVE_TOKEN environment variable"
+	CmdServeFlagTLSCert   = "Path to the TLS certificate used to serve the API"
+	CmdServeFlagTLSKey    = "Path to the TLS private key used to serve the API"
+
 	// zarf init
 	CmdInitShort = "Prepares a k8s cluster for the deployment of Zarf packages"
 	CmdInitLong  = "Injects an OCI registry as well as an optional git server " +
@@ -129,6 +169,9 @@ $ zarf init --registry-push-password={PASSWORD} --registry-push-username={USERNA
 # Initializing w/ an external git server:
 $ zarf init --git-push-password={PASSWORD} --git-push-username={USERNAME} --git-url={URL}
 
+# Initializing w/ the registry images pulled from a mirror registry:
+$ zarf init --seed-from=oci://mirror.internal/zarf
+
 # Initializing w/ an external artifact server:
 $ zarf init --artifact-push-password={PASSWORD} --artifact-push-username={USERNAME} --artifact-url={URL}
 
@@ -138,6 +181,19 @@ $ zarf init --artifact-push-password={PASSWORD} --artifact-push-username={USERNA
 	CmdInitErrValidateGit      = "the 'git-push-username' and 'git-push-password' flags must be provided if the 'git-url' flag is provided"
 	CmdInitErrValidateRegistry = "the 'registry-push-username' and 'registry-push-password' flags must be provided if the 'registry-url' flag is provided"
 	CmdInitErrValidateArtifact = "the 'artifact-push-username' and 'artifact-push-token' flags must be provided if the 'artifact-url' flag is provided"
+	CmdInitErrValidateInstance = "the 'registry-url' flag must be provided if the 'instance' flag is provided, named Zarf instances use an external registry"
+	CmdInitErrValidateSeed     = "the 'seed-strategy' flag must be one of %s"
+	CmdInitErrValidateSeedProx = "the 'seed-proxy' flag must be provided if and only if the 'seed-strategy' flag is pull-through"
+	CmdInitErrValidateSeedPath = "the 'seed-host-path' flag must be provided if and only if the 'seed-strategy' flag is hostpath"
+	CmdInitErrValidateSeedFrom = "the 'seed-from' flag must be an oci:// URL of a mirror registry, e.g. oci://mirror.internal/zarf"
+	CmdInitErrValidateSeedMirr = "the 'seed-from' flag can not be combined with the %s seed strategy"
+	CmdInitErrValidateProject  = "the 'registry-project-api' flag must be one of %s and can only be used with the 'registry-url' flag"
+	CmdInitErrValidateTLS      = "TLS certificates can only be provided for the internal %s, not with the '%s-url' flag"
+	CmdInitErrValidateResource = "resources can only be provided for the internal %s, not with the '%s-url' flag"
+	CmdInitErrValidateStorage  = "the 'registry-storage' flag must be one of %s"
+	CmdInitErrValidateS3       = "the 'registry-s3-bucket' flag must be provided if and only if the 'registry-storage' flag is s3"
+	CmdInitErrValidateS3Keys   = "the 'registry-s3-access-key' and 'registry-s3-secret-key' flags must be provided together"
+	CmdInitErrValidateStoreExt = "the 'registry-storage' flag can only be used with the internal registry, not with the 'registry-url' flag"
 
 	CmdInitPullAsk       = "It seems the init package could not be found locally, but can be pulled from oci://%s"
 	CmdInitPullNote      = "Note: This will require an internet connection."
@@ -146,9 +202,10 @@ $ zarf init --artifact-push-password={PASSWORD} --artifact-push-username={USERNA
 
 	CmdInitFlagSet = "Specify deployment variables to set on the command line (KEY=value)"
 
-	CmdInitFlagConfirm      = "Confirms package deployment without prompting. ONLY use with packages you trust. Skips prompts to review SBOM, configure variables, select optional components and review potential breaking changes."
-	CmdInitFlagComponents   = "Specify which optional components to install.  E.g. --components=git-server"
-	CmdInitFlagStorageClass = "Specify the storage class to use for the registry and git server.  E.g. --storage-class=standard"
+	CmdInitFlagConfirm            = "Confirms package deployment without prompting. ONLY use with packages you trust. Skips prompts to review SBOM, configure variables, select optional components and review potential breaking changes."
+	CmdInitFlagComponents         = "Specify which optional components to install.  E.g. --components=git-server"
+	CmdInitFlagStorageClass       = "Specify the storage class to use for the registry and git server.  E.g. --storage-class=standard"
+	CmdInitFlagPullSecretSelector = "Label selector of the namespaces that receive the Zarf managed image pull secret, by default the namespaces Zarf deploys to.  E.g. --pull-secret-selector=zarf.dev/pull-secret=enabled"
 
 	CmdInitFlagGitURL      = "External git server url to use for this Zarf cluster"
 	CmdInitFlagGitPushUser = "Username to access to the git server Zarf is configured to use. User must be able to create repositories via 'git push'"
@@ -164,10 +221,63 @@ $ zarf init --artifact-push-password={PASSWORD} --artifact-push-username={USERNA
 	CmdInitFlagRegPullPass = "Password for the pull-only user to access the registry"
 	CmdInitFlagRegSecret   = "Registry secret value"
 
+	CmdInitFlagRegProjectAPI  = "API of the external registry to create missing projects with before pushing images [harbor|quay]"
+	CmdInitFlagRegProjectUser = "Username of a user or robot account allowed to create projects in the registry, defaults to the push-user"
+	CmdInitFlagRegProjectPass = "Password of the project user, or the OAuth token for Quay"
+	CmdInitFlagRegScopedToken = "Push each image with a short-lived token from the registry token endpoint that is scoped to its repository instead of the push-user credentials"
+
+	CmdInitFlagRegAuth = "How consumers of the internal registry authenticate. Valid options are: 'htpasswd' (shared push and pull users), " +
+		"'robot' (an unscoped static token for each of the robot namespaces), 'oidc' (tokens from the token endpoint of an external OIDC provider)"
+	CmdInitFlagRegRobotNS      = "Namespaces that are given their own token instead of the shared pull user with the robot registry authentication. The tokens are not scoped, each of them can push and pull every repository of the registry"
+	CmdInitFlagRegTokenRealm   = "URL of the token endpoint of the OIDC provider the internal registry delegates authentication to, e.g. https://keycloak.example.com/realms/zarf/protocol/docker-v2/auth"
+	CmdInitFlagRegTokenService = "Name of the registry service the OIDC provider issues tokens for"
+	CmdInitFlagRegTokenIssuer  = "Issuer of the tokens of the OIDC provider"
+	CmdInitFlagRegTokenCert    = "Path to the PEM encoded certificates of the keys the OIDC provider signs tokens with"
+
+	CmdInitFlagRegTLSCert   = "Path to a PEM encoded certificate to serve the internal registry with. Must be valid for zarf-docker-registry.zarf.svc.cluster.local and should be valid for 127.0.0.1"
+	CmdInitFlagRegTLSKey    = "Path to the PEM encoded private key of the internal registry certificate"
+	CmdInitFlagRegTLSCA     = "Path to the PEM encoded CA bundle that issued the internal registry certificate"
+	CmdInitFlagRegTLSIssuer = "cert-manager issuer to request the internal registry certificate from in the form [Issuer|ClusterIssuer/]name"
+
+	CmdInitFlagGitTLSCert   = "Path to a PEM encoded certificate to serve the internal git server with. Must be valid for zarf-gitea-http.zarf.svc.cluster.local and should be valid for 127.0.0.1"
+	CmdInitFlagGitTLSKey    = "Path to the PEM encoded private key of the internal git server certificate"
+	CmdInitFlagGitTLSCA     = "Path to the PEM encoded CA bundle that issued the internal git server certificate"
+	CmdInitFlagGitTLSIssuer = "cert-manager issuer to request the internal git server certificate from in the form [Issuer|ClusterIssuer/]name"
+
+	CmdInitFlagCPURequest     = "CPU request of the %s, e.g. 500m. Defaults to the value in the init package"
+	CmdInitFlagCPULimit       = "CPU limit of the %s, e.g. 2. Defaults to the value in the init package"
+	CmdInitFlagMemoryRequest  = "Memory request of the %s, e.g. 512Mi. Defaults to the value in the init package"
+	CmdInitFlagMemoryLimit    = "Memory limit of the %s, e.g. 2Gi. Defaults to the value in the init package"
+	CmdInitFlagPVCSize        = "Size of the persistent volume claim of the %s, e.g. 100Gi. Defaults to the value in the init package"
+	CmdInitFlagStorageClassOf = "Storage class of the persistent volume claim of the %s. Defaults to the --storage-class flag or the storage class of the cluster"
+
+	CmdInitFlagRegStorage = "How the internal registry stores its images. Valid options are: 'pvc' (persistent volume claim), " +
+		"'ephemeral' (emptyDir volume for clusters without a storage class, images are lost whenever the registry pod restarts), 's3' (S3 bucket)"
+	CmdInitFlagRegS3Bucket   = "S3 bucket the internal registry stores its images in with the s3 registry storage"
+	CmdInitFlagRegS3Region   = "Region of the S3 bucket of the internal registry"
+	CmdInitFlagRegS3Endpoint = "Endpoint of S3 compatible storage for the internal registry, e.g. https://minio.example.com. Defaults to AWS S3"
+	CmdInitFlagRegS3Access   = "Access key of the S3 bucket of the internal registry. Defaults to the credentials of the environment of the registry, such as an IAM role"
+	CmdInitFlagRegS3Secret   = "Secret key of the S3 bucket of the internal registry"
+
+	CmdInitFlagSeedStrategy = "How the registry image is bootstrapped into the cluster. Valid options are: 'nodeport' (injector behind a NodePort service), " +
+		"'hostport' (injector behind an ephemeral host port), 'hostpath' (images pre-seeded in a directory on the nodes), 'pull-through' (pulled through an external registry proxy)"
+	CmdInitFlagSeedProxy    = "Registry proxy address to pull the registry image through with the pull-through seed strategy. E.g. --seed-proxy=harbor.example.com/ghcr"
+	CmdInitFlagSeedHostPath = "Directory on the nodes that holds the pre-seeded OCI image layout for the hostpath seed strategy"
+	CmdInitFlagSeedHostPort = "Host port of the injector for the hostport and hostpath seed strategies. Defaults to a random ephemeral port no pod on the injector node binds"
+	CmdInitFlagSeedFrom     = "Mirror registry to pull the registry images from instead of the init package, e.g. --seed-from=oci://mirror.internal/zarf. Images are looked up by their path without the registry host"
+
 	CmdInitFlagArtifactURL       = "[alpha] External artifact registry url to use for this Zarf cluster"
 	CmdInitFlagArtifactPushUser  = "[alpha] Username to access to the artifact registry Zarf is configured to use. User must be able to upload package artifacts."
 	CmdInitFlagArtifactPushToken = "[alpha] API Token for the push-user to access the artifact registry"
 
+	// zarf plugin
+	CmdPluginShort = "Lists the plugins that extend Zarf"
+	CmdPluginLong  = "Plugins are executables on your PATH named zarf-<name> that add the 'zarf <name>' command, " +
+		"or named zarf-asset-<scheme> that fetch component files, manifests and charts from <scheme>:// URLs.\n" +
+		"Plugins can call back into Zarf using the binary in the ZARF_BIN environment variable."
+	CmdPluginListShort     = "Lists the plugins found on your PATH"
+	CmdPluginListNoPlugins = "no plugins found on your PATH"
+
 	// zarf internal
 	CmdInternalShort = "Internal tools used by zarf"
 
@@ -181,6 +291,15 @@ $ zarf init --artifact-push-password={PASSWORD} --artifact-push-username={USERNA
 		"This command starts up a http proxy that can be used by running pods to transform queries " +
 		"that conform to Gitea / Gitlab repository and package URLs in the airgap."
 
+	CmdInternalControllerShort = "[alpha] Runs the zarf package deployment controller"
+	CmdInternalControllerLong  = "[alpha] NOTE: This command is a hidden command and generally shouldn't be run by a human.\n" +
+		"This command starts a controller that deploys, upgrades and removes the Zarf packages declared " +
+		"by ZarfPackageDeployment resources in the cluster."
+	CmdInternalControllerFlagInterval      = "Time between reconciliations of all package deployments"
+	CmdInternalControllerFlagRetryInterval = "Time to wait before retrying a failed package deploy"
+	CmdInternalControllerFlagNamespace     = "Namespace to watch for package deployments, only users that can write to it can deploy packages"
+	CmdInternalControllerFlagKey           = "Path to public key file for validating signed packages, an empty file disables signature validation"
+
 	CmdInternalGenerateCliDocsShort   = "Creates auto-generated markdown of all the commands for the CLI"
 	CmdInternalGenerateCliDocsSuccess = "Successfully created the CLI documentation"
 
@@ -207,6 +326,35 @@ $ zarf init --artifact-push-password={PASSWORD} --artifact-push-username={USERNA
 
 	CmdInternalCrc32Short = "Generates a decimal CRC32 for the given text"
 
+	// zarf init-package
+	CmdInitPackageShort = "Commands for building custom Zarf init packages"
+
+	CmdInitPackageBuildShort = "Builds a custom init package from an init package manifest"
+	CmdInitPackageBuildLong  = "Composes an init package from the components of a base init package as described by the given manifest.\n" +
+		"The manifest can exclude optional components such as the git server, add components imported from other packages " +
+		"and override the defaults of package variables to produce a smaller, organization-specific init package.\n"
+	CmdInitPackageBuildExample = `
+# Build an init package without the git server that adds a storage class before the registry
+$ cat zarf-init.yaml
+metadata:
+  description: Init package for edge clusters
+base: .
+exclude:
+  - git-server
+components:
+  - name: local-path-storage
+    required: true
+    before: zarf-seed-registry
+    import:
+      path: packages/local-path
+variables:
+  REGISTRY_PVC_SIZE: 5Gi
+  REGISTRY_HPA_ENABLE: "false"
+$ zarf init-package build zarf-init.yaml --confirm -o build
+`
+	CmdInitPackageBuildFlagConfirm = "Confirm init package creation without prompting"
+	CmdInitPackageBuildFlagOutput  = "Specify the output (either a directory or an oci:// URL) for the created init package"
+
 	// zarf package
 	CmdPackageShort                       = "Zarf package commands for creating, deploying, and inspecting packages"
 	CmdPackageFlagConcurrency             = "Number of concurrent layer operations to perform when interacting with a remote package."
@@ -216,11 +364,17 @@ $ zarf init --artifact-push-password={PASSWORD} --artifact-push-username={USERNA
 
 	CmdPackageCreateShort = "Creates a Zarf package from a given directory or the current directory"
 	CmdPackageCreateLong  = "Builds an archive of resources and dependencies defined by the 'zarf.yaml' in the specified directory.\n" +
+		"The definition can instead be fetched together with its local files from a git repository, such as " +
+		"'git::https://example.com/org/repo.git//path/to/package?ref=v1.2.0', or from a skeleton package published to an " +
+		"OCI registry, such as 'oci://ghcr.io/org/packages/name:1.2.0'. Refs that are not full refs are treated as tags, " +
+		"branches are given as 'refs/heads/<branch>'.\n" +
 		"Private registries and repositories are accessed via credentials in your local '~/.docker/config.json', " +
 		"'~/.git-credentials' and '~/.netrc'.\n"
 
 	CmdPackageDeployShort = "Deploys a Zarf package from a local file or URL (runs offline)"
 	CmdPackageDeployLong  = "Unpacks resources and dependencies from a Zarf package archive and deploys them onto the target system.\n" +
+		"Packages from http and https URLs are extracted while they are downloaded and only used once their shasum is verified, and a package archive can be streamed " +
+		"over stdin by passing '-' as the package source together with --confirm.\n" +
 		"Kubernetes clusters are accessed via credentials in your current kubecontext defined in '~/.kube/config'"
 
 	CmdPackageMirrorShort = "Mirrors a Zarf package's internal resources to specified image registries and git repositories"
@@ -244,6 +398,11 @@ $ zarf package mirror-resources <your-package.tar.zst> \
 	--git-url https://git.enterprise.corp \
 	--git-push-username <git-push-username> \
 	--git-push-password <git-push-password>
+
+# Stage resources on the filesystem to be pushed from another host
+$ zarf package mirror-resources <your-package.tar.zst> \
+	--oci-layout ./staged/images \
+	--git-dir ./staged/repos
 `
 
 	CmdPackageInspectShort = "Displays the definition of a Zarf package (runs offline)"
@@ -266,29 +425,91 @@ $ zarf package mirror-resources <your-package.tar.zst> \
 	CmdPackageCreateFlagDifferential          = "[beta] Build a package that only contains the differential changes from local resources and differing remote resources from the specified previously built package"
 	CmdPackageCreateFlagRegistryOverride      = "Specify a map of domains to override on package create when pulling images (e.g. --registry-override docker.io=dockerio-reg.enterprise.intranet)"
 	CmdPackageCreateFlagFlavor                = "The flavor of components to include in the resulting package (i.e. have a matching or empty \"only.flavor\" key)"
+	CmdPackageCreateFlagOverlay               = "Path to an overlay file that patches component fields, variable defaults, and image lists before validation. Can be specified multiple times and is applied in order"
+	CmdPackageCreateFlagBuildCache            = "Reuse components assembled by previous builds from the Zarf cache when their definition and local files did not change. Components with create actions, git repositories or remote files without a shasum are always rebuilt"
+	CmdPackageCreateFlagRemoteCache           = "An s3://bucket/prefix or oci://registry/repository URL that image layers, and components with --build-cache, are read through and stored in to share them between machines"
+	CmdPackageCreateFlagRemoteCacheRO         = "Only read from the remote cache without storing newly pulled layers and assembled components in it"
+	CmdPackageCreateFlagMaxMemory             = "Specify the memory budget of the create in megabytes. Images are saved fewer at a time, and one at a time once the budget is reached. Use 0 for no budget."
+	CmdPackageCreateFlagMaxTempSpace          = "Specify the temporary space budget of the create in megabytes. Creating a package that is estimated to need more fails before any work begins. Use 0 for no budget."
+	CmdPackageCreateFlagIncludePlatforms      = "Platforms of multi-platform images to keep in the package next to the package architecture (e.g. --include-platforms linux/arm64,linux/arm/v7). The images are deployed as image indexes with these platforms"
+	CmdPackageCreateFlagBuildkitAddr          = "Address of a remote buildkitd the images of components are built on instead of this machine (e.g. tcp://buildkitd.example.com:1234)"
+	CmdPackageCreateFlagBuildkitPool          = "Label selector of buildkitd pods in the cluster the images of components are built on. Builds are spread across the ready pods through tunnels to their port 1234"
+	CmdPackageCreateFlagBuildkitPoolNamespace = "Namespace of the buildkitd pods of the --buildkit-pool"
+	CmdPackageCreateFlagBuildkitTLSCACert     = "Path to the CA certificate the certificates of the remote buildkitd are verified with"
+	CmdPackageCreateFlagBuildkitTLSCert       = "Path to the client certificate presented to the remote buildkitd"
+	CmdPackageCreateFlagBuildkitTLSKey        = "Path to the key of the client certificate presented to the remote buildkitd"
+	CmdPackageCreateFlagBuildkitTLSServerName = "Name the certificates of the remote buildkitd are verified for, such as the name of the service in front of a --buildkit-pool"
 	CmdPackageCreateCleanPathErr              = "Invalid characters in Zarf cache path, defaulting to %s"
 
+	CmdPackageCreateFlagFailOnSeverity = "Scan the images of the package for vulnerabilities and fail when any are found at or above the severity (negligible, low, medium, high or critical) that are not waived"
+	CmdPackageCreateFlagVulnWaivers    = "Path to a YAML file of vulnerability waivers with an id, an expiry date (YYYY-MM-DD) and a justification. Expired waivers no longer apply"
+	CmdPackageCreateFlagAllowUnpinned  = "Allow kustomize remote bases that are not pinned to a tag or commit with ?ref=. Remote bases are always vendored into the package at create time"
+	CmdPackageCreateFlagLintRules      = "Path to a YAML file of lint rules, such as the OCI annotations and labels every image of the package is required to have"
+
+	CmdPackageDeployFlagTUI                            = "Deploy from a full-screen terminal UI to select components, answer variable prompts, confirm the package warnings and follow progress and logs"
 	CmdPackageDeployFlagConfirm                        = "Confirms package deployment without prompting. ONLY use with packages you trust. Skips prompts to review SBOM, configure variables, select optional components and review potential breaking changes."
 	CmdPackageDeployFlagAdoptExistingResources         = "Adopts any pre-existing K8s resources into the Helm charts managed by Zarf. ONLY use when you have existing deployments you want Zarf to takeover."
 	CmdPackageDeployFlagSet                            = "Specify deployment variables to set on the command line (KEY=value)"
 	CmdPackageDeployFlagComponents                     = "Comma-separated list of components to deploy.  Adding this flag will skip the prompts for selected components.  Globbing component names with '*' and deselecting 'default' components with a leading '-' are also supported."
-	CmdPackageDeployFlagShasum                         = "Shasum of the package to deploy. Required if deploying a remote https package."
+	CmdPackageDeployFlagFeatures                       = "Comma-separated list of feature flags to enable. Components with 'only.features' are only deployed when all of their features are enabled."
+	CmdPackageDeployFlagShasum                         = "Shasum of the package to deploy. Required if deploying a remote https package, and verified against packages streamed over stdin when set."
+	CmdPackageDeployFlagChecksums                      = "Path or URL of a checksums file in sha256sum format, signed with --key, that split, URL and stdin packages are verified against before they are loaded. The signature is read from the same location with a '.sig' suffix."
 	CmdPackageDeployFlagSget                           = "[Deprecated] Path to public sget key file for remote packages signed via cosign. This flag will be removed in v1.0.0 please use the --key flag instead."
-	CmdPackageDeployFlagTimeout                        = "Timeout for health checks and Helm operations such as installs and rollbacks"
+	CmdPackageDeployFlagTimeout                        = "Timeout for health checks, wait actions and Helm operations such as installs and rollbacks. When set, overrides the timeouts of the components"
+	CmdPackageDeployFlagLabels                         = "Labels to add to every resource Zarf deploys and to the pods of workloads (key=value)"
+	CmdPackageDeployFlagAnnotations                    = "Annotations to add to every resource Zarf deploys and to the pods of workloads (key=value)"
+	CmdPackageDeployFlagNodeSelector                   = "Node selectors to add to the pods of every workload Zarf deploys (key=value)"
+	CmdPackageDeployFlagTolerations                    = "Tolerations to add to the pods of every workload Zarf deploys in the form key[=value][:effect]. Without a value any value of the taint is tolerated and without an effect every effect is tolerated"
+	CmdPackageDeployFlagHostAliases                    = "Hostnames to map in the pods of every workload Zarf deploys (hostname=target). The target is an IP address, an in-cluster service such as name.namespace.svc.cluster.local, or registry or git-server for the Zarf registry and git server"
+	CmdPackageDeployFlagNotes                          = "Print the release notes embedded in the package before the deployment is confirmed"
+	CmdPackageDeployFlagCapacityCheck                  = "How a cluster without the schedulable capacity for the declared requirements of the components is handled before anything is pushed to it (warn, fail or skip)"
+	CmdPackageDeployFlagLazyLoad                       = "Extract the contents of each component just before it is deployed and remove them once it is deployed, instead of extracting the whole package up front. Lowers the peak disk usage at the cost of reading a package archive once per component. Defaults to true for oci:// packages, which only pull the layers of each component, and false for archives"
+	CmdPackageDeployFlagAllowHostArtifacts             = "Allow the package to install the host artifacts of its components onto this host. Required to deploy components with host artifacts, even with --confirm"
+	CmdPackageDeployFlagAllowHostServices              = "Allow the package to install, enable and start the systemd services of its components on this host. Required to deploy components with host services, even with --confirm"
+	CmdPackageDeployFlagServiceAccount                 = "Service account in the form namespace/name to impersonate when deploying, so the package is deployed with the permissions of the service account instead of the ones of the kubeconfig user"
 	CmdPackageDeployValidateArchitectureErr            = "this package architecture is %s, but the target cluster only has the %s architecture(s). These architectures must be compatible when \"images\" are present"
 	CmdPackageDeployValidateLastNonBreakingVersionWarn = "The version of this Zarf binary '%s' is less than the LastNonBreakingVersion of '%s'. You may need to upgrade your Zarf version to at least '%s' to deploy this package"
 	CmdPackageDeployInvalidCLIVersionWarn              = "CLIVersion is set to '%s' which can cause issues with package creation and deployment. To avoid such issues, please set the value to the valid semantic version for this version of Zarf."
+	CmdPackageDeployInterruptedWarn                    = "The deployment of %s was interrupted and is partially deployed. Run \"zarf package deploy %s\" again to finish the deployment or \"zarf package remove %s\" to remove the deployed components."
 
 	CmdPackageMirrorFlagComponents = "Comma-separated list of components to mirror.  This list will be respected regardless of a component's 'required' or 'default' status.  Globbing component names with '*' and deselecting components with a leading '-' are also supported."
 	CmdPackageMirrorFlagNoChecksum = "Turns off the addition of a checksum to image tags (as would be used by the Zarf Agent) while mirroring images."
+	CmdPackageMirrorFlagOCILayout  = "Write the images to an OCI image layout in this directory instead of pushing them to a registry, to be pushed from another host"
+	CmdPackageMirrorFlagGitDir     = "Write the git repositories as bare repositories into this directory instead of pushing them to a git server, to be pushed from another host"
 
 	CmdPackageInspectFlagSbomOut    = "Specify an output directory for the SBOMs from the inspected Zarf package"
 	CmdPackageInspectFlagListImages = "List images in the package (prints to stdout)"
-
-	CmdPackageRemoveShort          = "Removes a Zarf package that has been deployed already (runs offline)"
-	CmdPackageRemoveLong           = "Removes a Zarf package that has been deployed already (runs offline). Remove reverses the deployment order, the last component is removed first."
-	CmdPackageRemoveFlagConfirm    = "REQUIRED. Confirm the removal action to prevent accidental deletions"
-	CmdPackageRemoveFlagComponents = "Comma-separated list of components to remove.  This list will be respected regardless of a component's 'required' or 'default' status.  Globbing component names with '*' and deselecting components with a leading '-' are also supported."
+	CmdPackageInspectFlagNotes      = "Print the release notes embedded in the package (prints to stdout)"
+	CmdPackageInspectImagesFlagOut  = "Prints the digest, size, platforms and components of every image in the specified format. Valid options: table, json, yaml. Only supported for package tarballs and oci:// packages"
+
+	CmdPackageInspectComplianceShort = "Evaluates the rendered workloads of a package against the Pod Security Standards and Rego or Kyverno policies (runs offline)"
+	CmdPackageInspectComplianceLong  = "Renders the charts and manifests of the package to the resources that would be applied on deploy and reports the resources of each component " +
+		"that violate the Pod Security Standard level, the deny and violation rules of the Rego policies in the 'main' package or the validate rules of the Kyverno policies. " +
+		"Exits with an error when violations are found."
+	CmdPackageInspectComplianceFlagLevel  = "Pod Security Standard level to evaluate workloads against. Valid options: baseline, restricted. Set to an empty string to only evaluate policies"
+	CmdPackageInspectComplianceFlagPolicy = "Path to a Rego or Kyverno policy file or directory of policies to evaluate all resources against, can be repeated"
+	CmdPackageInspectComplianceFlagOut    = "Prints the violations in the specified format. Valid options: table, json, yaml"
+
+	CmdPackageInspectProvenanceShort = "Displays the chain of registries a published package was copied through"
+	CmdPackageInspectProvenanceLong  = "Displays the provenance records that 'zarf package copy' and 'zarf package publish' attach to a package when copying it between registries, " +
+		"oldest first, with the source and destination of every copy, who made it and when. Only supported for oci:// packages"
+	CmdPackageInspectProvenanceFlagOut = "Prints the provenance records in the specified format. Valid options: table, json, yaml"
+
+	CmdPackageInspectLicensesShort = "Reports the licenses in the SBOMs of a package and the base images its images are built from (runs offline)"
+	CmdPackageInspectLicensesLong  = "Aggregates the licenses of the packages found in the SBOMs of the images and files of a package and reports the base image chain of each image " +
+		"where it can be derived from the OCI base image annotations and labels. With a policy file, packages with disallowed licenses are listed and the command exits with an error."
+	CmdPackageInspectLicensesFlagPolicy = "Path to a license policy file with 'allow' and 'deny' lists of license identifiers (glob patterns supported) and 'denyUnknown'"
+	CmdPackageInspectLicensesFlagOut    = "Prints the report in the specified format. Valid options: table, json, yaml"
+
+	CmdPackageRemoveShort = "Removes a Zarf package that has been deployed already (runs offline)"
+	CmdPackageRemoveLong  = "Removes a Zarf package that has been deployed already (runs offline). Remove reverses the deployment order, the last component is removed first. " +
+		"The namespaces and custom resource definitions removed with a component are waited on before the next component is removed, so that controllers such as operators are still running while the finalizers of their resources are handled."
+	CmdPackageRemoveFlagConfirm            = "REQUIRED. Confirm the removal action to prevent accidental deletions"
+	CmdPackageRemoveFlagComponents         = "Comma-separated list of components to remove.  This list will be respected regardless of a component's 'required' or 'default' status.  Globbing component names with '*' and deselecting components with a leading '-' are also supported."
+	CmdPackageRemoveFlagTimeout            = "Timeout for Helm uninstalls and for the namespaces and custom resource definitions they delete to be removed"
+	CmdPackageRemoveFlagAllowHostArtifacts = "Allow the host artifacts the package installed on this host to be removed. Required to remove components with host artifacts"
+	CmdPackageRemoveFlagAllowHostServices  = "Allow the systemd services the package installed on this host to be stopped, disabled and removed. Required to remove components with host services"
+	CmdPackageRemoveFlagForce              = "Remove the finalizers of resources that keep namespaces and custom resource definitions terminating once the timeout is reached. Use only when the controller handling the finalizers is gone"
 
 	CmdPackagePublishShort   = "Publishes a Zarf package to a remote registry"
 	CmdPackagePublishExample = `
@@ -297,10 +518,14 @@ $ zarf package publish my-package.tar oci://my-registry.com/my-namespace
 
 # Publish a skeleton package to a remote registry
 $ zarf package publish ./path/to/dir oci://my-registry.com/my-namespace
+
+# Publish a package to multiple registries and add a channel tag
+$ zarf package publish my-package.tar oci://staging-registry.com/my-namespace oci://prod-registry.com/my-namespace --retag "{{ .Version }}-stable"
 `
 	CmdPackagePublishFlagSigningKey         = "Private key for signing or re-signing packages with a new key. Accepts either a local file path or a Cosign-supported key provider"
 	CmdPackagePublishFlagSigningKeyPassword = "Password to the private key used for publishing packages"
 	CmdPackagePublishFlagConfirm            = "Confirms package publish without prompting. Skips prompt for the signing key password"
+	CmdPackagePublishFlagRetag              = "Template for an additional tag to apply to the published package (e.g. \"{{ .Version }}-stable\"). Supports .Name, .Version, .Flavor, and .Architecture"
 
 	CmdPackagePullShort   = "Pulls a Zarf package from a remote registry and save to the local file system"
 	CmdPackagePullExample = `
@@ -311,9 +536,84 @@ $ zarf package pull oci://ghcr.io/zarf-dev/packages/dos-games:1.2.0
 $ zarf package pull oci://ghcr.io/zarf-dev/packages/dos-games:1.2.0 -a arm64
 
 # Pull a skeleton package
-$ zarf package pull oci://ghcr.io/zarf-dev/packages/dos-games:1.2.0 -a skeleton`
+$ zarf package pull oci://ghcr.io/zarf-dev/packages/dos-games:1.2.0 -a skeleton
+
+# Pull the highest published 1.x version of a package
+$ zarf package pull "oci://ghcr.io/zarf-dev/packages/dos-games:^1.0"
+
+# Pull a package with only some of its components
+$ zarf package pull oci://ghcr.io/zarf-dev/packages/dos-games:1.2.0 --components baseline
+
+# Pull only the metadata and SBOMs of a package for review
+$ zarf package pull oci://ghcr.io/zarf-dev/packages/dos-games:1.2.0 --layers metadata,sboms`
 	CmdPackagePullFlagOutputDirectory = "Specify the output directory for the pulled Zarf package"
 	CmdPackagePullFlagShasum          = "Shasum of the package to pull. Required if pulling a https package. A shasum can be retrieved using 'zarf dev sha256sum <url>'"
+	CmdPackagePullFlagComponents      = "Comma-separated list of components to pull. The pulled package only holds these components and their images and is not signed.  Globbing component names with '*' and deselecting components with a leading '-' are also supported."
+	CmdPackagePullFlagLayers          = "Comma-separated list of the layers to pull from an oci:// package instead of the whole package (metadata, sboms). The layers are written to a directory named after the package"
+
+	CmdPackageCopyShort = "Copies a published Zarf package between registries without unpacking it"
+	CmdPackageCopyLong  = "Copies a published Zarf package between OCI registries without unpacking it. " +
+		"The package signature is verified at the source before the copy and at the destination after the copy. " +
+		"If a signing key is provided a countersignature is attached to the package at the destination. " +
+		"A record of the copy is appended to the provenance chain of the package, which can be displayed with 'zarf package inspect provenance'."
+	CmdPackageCopyExample = `
+# Copy a package from a build registry to a DMZ registry
+$ zarf package copy oci://build-registry.com/packages/dos-games:1.2.0 oci://dmz-registry.com/packages/dos-games --key cosign.pub
+
+# Copy a package and countersign it at the destination
+$ zarf package copy oci://build-registry.com/packages/dos-games:1.2.0 oci://dmz-registry.com/packages/dos-games --key cosign.pub --signing-key dmz.key`
+	CmdPackageCopyFlagSigningKey         = "Private key used to countersign the package at the destination. Accepts either a local file path or a Cosign-supported key provider"
+	CmdPackageCopyFlagSigningKeyPassword = "Password to the private key used to countersign the package"
+
+	CmdPackageVersionsShort   = "Lists the published versions of a package in an OCI repository"
+	CmdPackageVersionsLong    = "Lists every tag of a package in an OCI repository with the platforms it is available for and the digest it references. Channel tags such as stable or latest share the digest of the version they point to."
+	CmdPackageVersionsExample = `
+# List the published versions of a package
+$ zarf package versions oci://ghcr.io/zarf-dev/packages/dos-games
+
+# Deploy the latest published 1.x version of a package
+$ zarf package deploy "oci://ghcr.io/zarf-dev/packages/dos-games:^1.0"`
+
+	CmdPackageConvertShort = "Converts a package to a Helm chart or plain Kubernetes manifests for clusters without Zarf (runs offline)"
+	CmdPackageConvertLong  = "Renders the charts and manifests of a package the same way as deploy and writes them as a Helm chart or a directory of numbered manifests " +
+		"to be applied in order, with the images rewritten to the registry they were mirrored to with 'zarf package mirror-resources'. " +
+		"Only container images are rewritten, git repositories, data injections, actions and host artifacts of the package are not converted."
+	CmdPackageConvertExample = `
+# Convert a package to a Helm chart after mirroring its images
+$ zarf package mirror-resources zarf-package-dos-games-amd64-1.0.0.tar.zst --registry-url registry.example.com --registry-push-username admin --registry-push-password secret
+$ zarf package convert zarf-package-dos-games-amd64-1.0.0.tar.zst --to helm --registry-url registry.example.com
+$ helm install dos-games ./dos-games
+
+# Convert a package to plain manifests and apply them in order
+$ zarf package convert zarf-package-dos-games-amd64-1.0.0.tar.zst --to manifests --registry-url registry.example.com --output-directory build
+$ kubectl apply -f build/dos-games`
+	CmdPackageConvertFlagTo          = "Format to convert the package to. Valid options: helm, manifests"
+	CmdPackageConvertFlagRegistryURL = "URL of the registry the images of the package were mirrored to, the images are rewritten to it"
+	CmdPackageConvertFlagOutput      = "Directory the chart or manifests are written into, in a directory named after the package"
+
+	CmdPackageSignShort = "Signs a package that was created or published without a signature"
+	CmdPackageSignLong  = "Signs a package tarball or a published package after it was created, so that building and signing packages can be done by different teams. " +
+		"The package is validated against its checksums before it is signed. A tarball is written to the output directory, a published package is signed in place."
+	CmdPackageSignExample = `
+# Sign a package tarball
+$ zarf package sign zarf-package-dos-games-amd64-1.2.0.tar.zst --signing-key cosign.key
+
+# Sign a published package
+$ zarf package sign oci://ghcr.io/my-org/packages/dos-games:1.2.0 --signing-key cosign.key`
+	CmdPackageSignFlagSigningKey         = "Private key for signing the package. Accepts either a local file path or a Cosign-supported key provider"
+	CmdPackageSignFlagSigningKeyPassword = "Password to the private key used for signing the package"
+	CmdPackageSignFlagOutput             = "Directory the signed package tarball is written to, defaults to the directory of the package"
+
+	CmdPackageResignShort = "Replaces the signature of a package, e.g. when signing keys are rotated"
+	CmdPackageResignLong  = "Replaces the signature of a package tarball or a published package with one made with a new key. " +
+		"The existing signature is verified with the public key given with --key and the package is validated against its checksums before it is signed again."
+	CmdPackageResignExample = `
+# Re-sign a package tarball with a rotated key
+$ zarf package resign zarf-package-dos-games-amd64-1.2.0.tar.zst --key old-cosign.pub --signing-key new-cosign.key
+
+# Re-sign a published package with a rotated key
+$ zarf package resign oci://ghcr.io/my-org/packages/dos-games:1.2.0 --key old-cosign.pub --signing-key new-cosign.key`
+	CmdPackageResignFlagSkipSignatureValidation = "Skip verifying the existing signature of the package before it is replaced"
 
 	CmdPackageChoose                = "Choose or type the package file"
 	CmdPackageClusterSourceFallback = "%q does not satisfy any current sources, assuming it is a package deployed to a cluster"
@@ -326,8 +626,35 @@ $ zarf package pull oci://ghcr.io/zarf-dev/packages/dos-games:1.2.0 -a skeleton`
 	CmdDevDeployLong       = "[beta] Creates and deploys a Zarf package from a given directory, setting options like YOLO mode for faster iteration."
 	CmdDevDeployFlagNoYolo = "Disable the YOLO mode default override and create / deploy the package as-defined"
 
-	CmdDevGenerateShort   = "[alpha] Creates a zarf.yaml automatically from a given remote (git) Helm chart"
-	CmdDevGenerateExample = "zarf dev generate podinfo --url https://github.com/stefanprodan/podinfo.git --version 6.4.0 --gitPath charts/podinfo"
+	CmdDevGenerateShort = "[alpha] Creates a zarf.yaml automatically from a remote (git) Helm chart, or a local Helm chart, kustomization, directory of manifests or compose file"
+	CmdDevGenerateLong  = "Creates a zarf.yaml with a component that deploys the given Helm chart, kustomization or manifests and the images found in them.\n\n" +
+		"Without a CHART or DIRECTORY the chart is read from the git repository given with --url and --version. " +
+		"A local directory that is not a chart or kustomization is searched for charts, chart archives, kustomizations and manifests. " +
+		"Local paths are written relative to the output directory.\n\n" +
+		"A compose file is translated into a Deployment per service, a Service for the ports it publishes or exposes and a persistent volume claim per named volume. " +
+		"The manifests are written to the output directory next to the zarf.yaml and the images are taken from the services. Bind mounts and env files are not translated.\n\n" +
+		"With --from-namespace the Helm releases, workloads, services, config maps, service accounts, persistent volume claims and ingresses " +
+		"of a namespace in the cluster are captured instead. Charts, values and manifests are written to the output directory next to the zarf.yaml " +
+		"and the images are taken from the workloads and pods. Secrets are not captured."
+	CmdDevGenerateExample = `
+# Generate a package from a Helm chart in a git repository
+$ zarf dev generate podinfo --url https://github.com/stefanprodan/podinfo.git --version 6.4.0 --gitPath charts/podinfo --output-directory podinfo
+
+# Generate a package from a local Helm chart, kustomization or directory of manifests
+$ zarf dev generate podinfo ./charts/podinfo --output-directory .
+$ zarf dev generate my-app ./deploy --output-directory .
+
+# Generate a package from a docker compose file
+$ zarf dev generate my-app ./compose.yaml --output-directory my-app
+
+# Generate a package that captures what is running in a namespace of the cluster
+$ zarf dev generate my-app --from-namespace my-app --output-directory my-app
+`
+	CmdDevGenerateErrURLAndVersion = "--url and --version are required without a CHART, DIRECTORY or --from-namespace"
+	CmdDevGenerateErrSourceAndURL  = "--url cannot be used with a local CHART or DIRECTORY"
+
+	CmdDevGenerateFlagFromNamespace     = "Capture the Helm releases, resources and images of a namespace in the cluster"
+	CmdDevGenerateErrNamespaceAndSource = "--from-namespace cannot be used with --url or a local CHART or DIRECTORY"
 
 	CmdDevPatchGitShort = "Converts all .git URLs to the specified Zarf HOST and with the Zarf URL pattern in a given FILE.  NOTE:\n" +
 		"This should only be used for manifests that are not mutated by the Zarf Agent Mutating Webhook."
@@ -340,6 +667,12 @@ $ zarf package pull oci://ghcr.io/zarf-dev/packages/dos-games:1.2.0 -a skeleton`
 	CmdDevFindImagesLong  = "Evaluates components in a Zarf file to identify images specified in their helm charts and manifests.\n\n" +
 		"Components that have repos that host helm charts can be processed by providing the --repo-chart-path."
 
+	CmdDevRBACShort = "Generates the Roles and ClusterRole a service account needs to deploy a package"
+	CmdDevRBACLong  = "Renders the charts and manifests of a package without a cluster and prints the ClusterRole and Roles with the minimal permissions to deploy it " +
+		"with 'zarf package deploy --service-account', including the permissions Zarf itself needs to read its state and record the deployment.\n\n" +
+		"NOTE: Permissions needed by actions, such as 'zarf tools kubectl' commands, are not detected and must be added to the generated roles."
+	CmdDevRBACFlagServiceAccount = "Service account in the form namespace/name to generate RoleBindings and a ClusterRoleBinding for"
+
 	CmdDevGenerateConfigShort = "Generates a config file for Zarf"
 	CmdDevGenerateConfigLong  = "Generates a Zarf config file for controlling how the Zarf CLI operates. Optionally accepts a filename to write the config to.\n\n" +
 		"The extension will determine the format of the config file, e.g. env-1.yaml, env-2.json, env-3.toml etc.\n" +
@@ -414,6 +747,67 @@ $ zarf tools registry digest 127.0.0.1:31999/stefanprodan/podinfo:6.4.0
 
 # Return an image digest from a repo hosted at reg.example.com
 $ zarf tools registry digest reg.example.com/stefanprodan/podinfo:6.4.0
+`
+
+	CmdToolsRegistryLoginShort = "Log in to a registry"
+	CmdToolsRegistryLoginLong  = "Verifies the credentials against the registry and stores them in the Docker credential store, " +
+		"using the credential helper configured in '~/.docker/config.json' when there is one. " +
+		"The stored credentials are used by every Zarf command that pulls from or pushes to the registry.\n"
+	CmdToolsRegistryLoginExample = `
+# Log in to reg.example.com, prompting for the password
+$ zarf tools registry login reg.example.com -u admin
+
+# Log in to reg.example.com with a password from stdin and store it with docker-credential-pass
+$ cat password.txt | zarf tools registry login reg.example.com -u admin --password-stdin --credential-helper pass
+`
+	CmdToolsRegistryLoginFlagUsername         = "Username of the registry"
+	CmdToolsRegistryLoginFlagPassword         = "Password of the registry"
+	CmdToolsRegistryLoginFlagPasswordStdin    = "Read the password of the registry from stdin"
+	CmdToolsRegistryLoginFlagCredentialHelper = "Store the credentials of this registry with docker-credential-<helper> instead of the default store"
+	CmdToolsRegistryLoginFlagSkipVerify       = "Store the credentials without checking them against the registry"
+
+	CmdToolsRegistryLogoutShort   = "Log out of a registry"
+	CmdToolsRegistryLogoutExample = `
+# Remove the stored credentials of reg.example.com
+$ zarf tools registry logout reg.example.com
+`
+
+	CmdToolsRegistryArtifactShort = "Push, pull, inspect and copy arbitrary OCI artifacts"
+	CmdToolsRegistryArtifactLong  = "Manages OCI artifacts such as configuration files, policies or binaries in a registry. " +
+		"References to the registry in the Zarf state use the Zarf credentials and are tunneled to the cluster when needed, " +
+		"other registries use the credentials in your local '~/.docker/config.json'.\n"
+
+	CmdToolsRegistryArtifactPushShort   = "Push files as the layers of an OCI artifact"
+	CmdToolsRegistryArtifactPushExample = `
+# Push files into an internal repo in Zarf
+$ zarf tools registry artifact push 127.0.0.1:31999/config/app:1.0.0 values.yaml policy.rego:application/vnd.cncf.openpolicyagent.policy.layer.v1+rego
+
+# Push a file with an artifact type and annotations into a repo hosted at reg.example.com
+$ zarf tools registry artifact push reg.example.com/config/app:1.0.0 values.yaml --artifact-type=application/vnd.example.config.v1 --annotation=org.opencontainers.image.source=https://example.com/app
+`
+	CmdToolsRegistryArtifactPushFlagType       = "Artifact type of the pushed manifest"
+	CmdToolsRegistryArtifactPushFlagAnnotation = "Annotations of the pushed manifest (KEY=value)"
+
+	CmdToolsRegistryArtifactPullShort   = "Pull the files of an OCI artifact"
+	CmdToolsRegistryArtifactPullExample = `
+# Pull the files of an artifact from an internal repo in Zarf into the current directory
+$ zarf tools registry artifact pull 127.0.0.1:31999/config/app:1.0.0
+
+# Pull the files of an artifact from a repo hosted at reg.example.com into a directory
+$ zarf tools registry artifact pull reg.example.com/config/app:1.0.0 -o config
+`
+	CmdToolsRegistryArtifactPullFlagOutput = "Directory to write the files of the artifact to"
+
+	CmdToolsRegistryArtifactManifestShort   = "Print the manifest of an OCI artifact"
+	CmdToolsRegistryArtifactManifestExample = `
+# Print the manifest of an artifact in an internal repo in Zarf
+$ zarf tools registry artifact manifest 127.0.0.1:31999/config/app:1.0.0
+`
+
+	CmdToolsRegistryArtifactCopyShort   = "Copy an OCI artifact, including everything it references, between registries"
+	CmdToolsRegistryArtifactCopyExample = `
+# Copy an artifact from a repo hosted at reg.example.com into an internal repo in Zarf
+$ zarf tools registry artifact copy reg.example.com/config/app:1.0.0 127.0.0.1:31999/config/app
 `
 
 	CmdToolsRegistryPruneShort       = "Prunes images from the registry that are not currently being used by any Zarf packages."
@@ -480,13 +874,26 @@ zarf tools yq e '.a.b = "cool"' -i file.yaml
 	CmdToolsMonitorShort = "Launches a terminal UI to monitor the connected cluster using K9s."
 
 	CmdToolsHelmShort = "Subset of the Helm CLI included with Zarf to help manage helm charts."
-	CmdToolsHelmLong  = "Subset of the Helm CLI that includes the repo and dependency commands for managing helm charts destined for the air gap."
+	CmdToolsHelmLong  = "Subset of the Helm CLI that includes the repo and dependency commands for managing helm charts destined for the air gap. " +
+		"Pass --tool-version to run the full CLI of another Helm version from the Zarf cache, pulled from --tool-repository and verified against --tool-digest when it is not cached."
 
 	CmdToolsClearCacheShort         = "Clears the configured git and image cache directory"
 	CmdToolsClearCacheDir           = "Cache directory set to: %s"
 	CmdToolsClearCacheSuccess       = "Successfully cleared the cache from %s"
 	CmdToolsClearCacheFlagCachePath = "Specify the location of the Zarf artifact cache (images and git repositories)"
 
+	CmdToolsReportShort   = "Summarizes the locally recorded metrics of package create and deploy operations"
+	CmdToolsReportLong    = "Summarizes the metrics recorded with --record-metrics to help with capacity planning. Metrics are read from the Zarf cache unless a file is specified."
+	CmdToolsReportExample = `
+# Summarize all recorded operations
+$ zarf tools report
+
+# Summarize operations from the last 30 days
+$ zarf tools report --since 720h -o json`
+	CmdToolsReportFlagFile  = "Path to a metrics file to summarize instead of the one in the Zarf cache"
+	CmdToolsReportFlagSince = "Only summarize operations recorded within this duration"
+	CmdToolsReportNoMetrics = "no metrics have been recorded, run zarf with --record-metrics to start recording"
+
 	CmdToolsDownloadInitShort               = "Downloads the init package for the current Zarf version into the specified directory"
 	CmdToolsDownloadInitFlagOutputDirectory = "Specify a directory to place the init package in."
 
@@ -507,7 +914,7 @@ zarf tools yq e '.a.b = "cool"' -i file.yaml
 	CmdToolsWaitForShort = "Waits for a given Kubernetes resource to be ready"
 	CmdToolsWaitForLong  = "By default Zarf will wait for all Kubernetes resources to be ready before completion of a component during a deployment.\n" +
 		"This command can be used to wait for a Kubernetes resources to exist and be ready that may be created by a Gitops tool or a Kubernetes operator.\n" +
-		"You can also wait for arbitrary network endpoints using REST or TCP checks.\n\n"
+		"You can also wait for arbitrary network endpoints using REST or TCP checks, or for a command to exit with code 0.\n\n"
 	CmdToolsWaitForExample = `
 # Wait for Kubernetes resources:
 $ zarf tools wait-for pod my-pod-name ready -n default                  #  wait for pod my-pod-name in namespace default to be ready
@@ -525,12 +932,19 @@ $ zarf tools wait-for tcp localhost:8080                                #  wait
 $ zarf tools wait-for https 1.1.1.1 200                                 #  wait for a 200 response from https://1.1.1.1
 $ zarf tools wait-for http google.com                                   #  wait for any 2xx response from http://google.com
 $ zarf tools wait-for http google.com success                           #  wait for any 2xx response from http://google.com
+$ zarf tools wait-for http localhost:8080/healthz --body '^ok$'         #  wait for a 2xx response with the body ok from http://localhost:8080/healthz
+
+# Wait for a command:
+$ zarf tools wait-for exec 'pg_isready -h db.example.com'               #  wait for the command to exit with code 0
 `
 	CmdToolsWaitForFlagTimeout   = "Specify the timeout duration for the wait command."
 	CmdToolsWaitForFlagNamespace = "Specify the namespace of the resources to wait for."
+	CmdToolsWaitForFlagBody      = "Specify a regular expression the response body of an http or https endpoint must match."
 
 	CmdToolsKubectlDocs = "Kubectl command. See https://kubernetes.io/docs/reference/kubectl/overview/ for more information."
 
+	CmdToolsToolVersionErr = "unable to run %s %s: %s"
+
 	CmdToolsGetCredsShort   = "Displays a table of credentials for deployed Zarf services. Pass a service key to get a single credential"
 	CmdToolsGetCredsLong    = "Display a table of credentials for deployed Zarf services. Pass a service key to get a single credential. i.e. 'zarf tools get-creds registry'"
 	CmdToolsGetCredsExample = `
@@ -543,7 +957,11 @@ $ zarf tools get-creds registry-readonly
 $ zarf tools get-creds git
 $ zarf tools get-creds git-readonly
 $ zarf tools get-creds artifact
+
+# Get a specific Zarf credential with its username as JSON for automation:
+$ zarf tools get-creds registry --output json
 `
+	CmdToolsGetCredsFlagOutput = "Prints the output in the specified format. Valid options: table, json, yaml. The table format of a single credential prints only its password"
 
 	CmdToolsUpdateCredsShort   = "Updates the credentials for deployed Zarf services. Pass a service key to update credentials for a single service"
 	CmdToolsUpdateCredsLong    = "Updates the credentials for deployed Zarf services. Pass a service key to update credentials for a single service. i.e. 'zarf tools update-creds registry'"
@@ -572,13 +990,95 @@ $ zarf tools update-creds git --git-push-username={USERNAME} --git-push-password
 $ zarf tools update-creds artifact --artifact-push-username={USERNAME} --artifact-push-token={PASSWORD}
 
 # NOTE: Not specifying a pull username/password will keep the previous pull username/password.
+
+# Rotate the TLS certificates of the internal registry and git server:
+$ zarf tools update-creds --tls \
+	--registry-tls-cert=registry.crt --registry-tls-key=registry.key --registry-tls-ca=ca.crt \
+	--git-tls-cert=git.crt --git-tls-key=git.key --git-tls-ca=ca.crt
+
+# Request the certificate of the internal registry from a cert-manager ClusterIssuer:
+$ zarf tools update-creds registry --tls --registry-tls-issuer=ClusterIssuer/{NAME}
+`
+	CmdToolsInventoryShort   = "Lists the resources Zarf put in the cluster by package and component"
+	CmdToolsInventoryLong    = "Lists the resources of the Helm releases of the deployed Zarf packages, and the namespaces and secrets labeled as managed by Zarf, by package and component. Resources that are not owned by a package, such as the image pull secrets, are listed without one."
+	CmdToolsInventoryExample = `
+# List the resources Zarf put in the cluster:
+$ zarf tools inventory
+
+# List the resources of a single package as JSON:
+$ zarf tools inventory --package podinfo --output json
 `
+	CmdToolsInventoryFlagPackage = "Only list the resources of the deployed package with this name"
+
+	CmdToolsSyncPullSecretsShort   = "Syncs the Zarf managed image pull secrets with the namespaces selected by the pull secret selector"
+	CmdToolsSyncPullSecretsLong    = "Syncs the Zarf managed image pull secrets with the namespaces selected by the pull secret selector of the Zarf state. Every selected namespace receives the secret and it is removed from every other namespace. Without a selector the existing secrets are updated."
+	CmdToolsSyncPullSecretsExample = `
+# Sync the image pull secrets with the current pull secret selector:
+$ zarf tools sync-pull-secrets
+
+# Only give the image pull secret to namespaces labelled zarf.dev/pull-secret=enabled:
+$ zarf tools sync-pull-secrets --selector zarf.dev/pull-secret=enabled
+
+# Go back to giving the image pull secret to the namespaces Zarf deploys to:
+$ zarf tools sync-pull-secrets --selector ""
+`
+	CmdToolsSyncPullSecretsFlagSelector = "Label selector of the namespaces that receive the Zarf managed image pull secret, stored in the Zarf state. An empty selector gives it to the namespaces Zarf deploys to"
+
+	CmdToolsStateShort          = "Manages the Zarf state of the cluster"
+	CmdToolsStateInspectShort   = "Prints the Zarf state of the cluster with its credentials redacted"
+	CmdToolsStateInspectLong    = "Prints the Zarf state of the cluster with its credentials redacted, or the value of a single editable field of the state. Use 'zarf tools get-creds' to print the credentials."
+	CmdToolsStateInspectExample = `
+# Print the Zarf state:
+$ zarf tools state inspect
+
+# Print the Zarf state as YAML:
+$ zarf tools state inspect -o yaml
+
+# Print the address of the registry:
+$ zarf tools state inspect registryInfo.address
+`
+	CmdToolsStateInspectFlagOut = "Output format (json|yaml)"
+
+	CmdToolsStateEditShort = "Edits fields of the Zarf state of the cluster"
+	CmdToolsStateEditLong  = "Edits fields of the Zarf state of the cluster. The edited state is validated before it is saved. " +
+		"The fields that can be edited are %s. " +
+		"The previous state is kept in the %s secret of the zarf namespace until the state is edited again. The image pull secrets and git server secrets are updated for the edited fields " +
+		"and the agent is restarted with an edited agent certificate."
+	CmdToolsStateEditExample = `
+# Point Zarf at a registry that moved:
+$ zarf tools state edit --set registryInfo.address=registry.example.com:5000
+
+# Change the git server and the storage class at once without prompting:
+$ zarf tools state edit --set gitServer.address=https://git.example.com --set storageClass=standard --confirm
+
+# Replace the certificate of the agent with one issued for agent-hook.zarf.svc:
+$ zarf tools state edit --set agentTLS.ca="$(cat ca.crt)" --set agentTLS.cert="$(cat tls.crt)" --set agentTLS.key="$(cat tls.key)"
+`
+	CmdToolsStateEditFlagSet         = "Set a field of the Zarf state (FIELD=VALUE), can be given multiple times"
+	CmdToolsStateEditFlagConfirm     = "Confirm editing the Zarf state without prompting"
+	CmdToolsStateEditConfirmContinue = "Continue with these changes?"
+
+	CmdToolsStateMigrateShort   = "Migrates the Zarf state of the cluster to the schema version of this version of Zarf"
+	CmdToolsStateMigrateLong    = "Migrates the Zarf state of the cluster to the schema version of this version of Zarf. The state is migrated in memory whenever Zarf loads it and saved the next time it changes, this command saves the migrated state right away. A state written by a newer version of Zarf is never changed."
+	CmdToolsStateMigrateExample = `
+# List the migrations the Zarf state needs without changing it:
+$ zarf tools state migrate --dry-run
+
+# Migrate the Zarf state:
+$ zarf tools state migrate
+`
+	CmdToolsStateMigrateFlagDryRun = "List the migrations of the Zarf state without saving the migrated state"
+
 	CmdToolsUpdateCredsConfirmFlag          = "Confirm updating credentials without prompting"
 	CmdToolsUpdateCredsConfirmProvided      = "Confirm flag specified, continuing without prompting."
 	CmdToolsUpdateCredsConfirmContinue      = "Continue with these changes?"
 	CmdToolsUpdateCredsUnableUpdateRegistry = "Unable to update Zarf Registry values: %s"
 	CmdToolsUpdateCredsUnableUpdateAgent    = "Unable to update Zarf Agent TLS secrets: %s"
 	CmdToolsUpdateCredsUnableUpdateCreds    = "Unable to update Zarf credentials"
+	CmdToolsUpdateCredsFlagTLS              = "Rotate the TLS certificates of the internal registry and git server instead of their credentials"
+	CmdToolsUpdateCredsErrTLSService        = "the 'tls' flag only applies to the registry and git services"
+	CmdToolsUpdateCredsErrTLSMissing        = "the 'tls' flag requires a certificate or cert-manager issuer for the registry or git server"
+	CmdToolsUpdateCredsErrTLSNotEnabled     = "TLS was not enabled for the %s during 'zarf init', re-initialize the cluster to enable it"
 
 	// zarf version
 	CmdVersionShort = "Shows the version of the running Zarf binary"
@@ -595,6 +1095,7 @@ const (
 	AgentErrCouldNotDeserializeReq = "could not deserialize request: %s"
 	AgentErrParsePod               = "failed to parse pod: %w"
 	AgentErrHostnameMatch          = "failed to complete hostname matching: %w"
+	AgentErrWindowsPlainHTTP       = "Windows nodes can not pull from the internal Zarf registry over plain HTTP, initialize the cluster with --registry-tls-cert or --registry-tls-issuer, or use an external registry"
 	AgentErrInvalidMethod          = "invalid method only POST requests are allowed"
 	AgentErrInvalidOp              = "invalid operation: %s"
 	AgentErrInvalidType            = "only content type 'application/json' is supported"
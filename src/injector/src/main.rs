@@ -263,7 +263,11 @@ async fn main() {
 
     unpack(payload_sha);
 
-    let listener = tokio::net::TcpListener::bind("0.0.0.0:5000").await.unwrap();
+    // Listen on both address families, falling back to IPv4 on nodes with IPv6 disabled.
+    let listener = match tokio::net::TcpListener::bind("[::]:5000").await {
+        Ok(listener) => listener,
+        Err(_) => tokio::net::TcpListener::bind("0.0.0.0:5000").await.unwrap(),
+    };
     println!("listening on {}", listener.local_addr().unwrap());
     axum::serve(listener, start_seed_registry()).await.unwrap();
     println!("Usage: {} <sha256sum>", args[1]);
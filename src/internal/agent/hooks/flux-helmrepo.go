@@ -49,7 +49,7 @@ func mutateHelmRepo(ctx context.Context, r *v1.AdmissionRequest, cluster *cluste
 		return &operations.Result{Allowed: true}, nil
 	}
 
-	zarfState, err := cluster.LoadZarfState(ctx)
+	zarfState, err := cluster.LoadNamespaceZarfState(ctx, r.Namespace)
 	if err != nil {
 		return nil, err
 	}
@@ -86,7 +86,7 @@ func mutateHelmRepo(ctx context.Context, r *v1.AdmissionRequest, cluster *cluste
 
 	// Mutate the helm repo URL if necessary
 	if isCreate || (isUpdate && !isPatched) {
-		patchedSrc, err := transform.ImageTransformHost(registryAddress, src.Spec.URL)
+		patchedSrc, err := transform.ImageTransformHost(registryAddress, src.Spec.URL, zarfState.RegistryInfo.ImageRules...)
 		if err != nil {
 			return nil, fmt.Errorf("unable to transform the HelmRepo URL: %w", err)
 		}
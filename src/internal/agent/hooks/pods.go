@@ -7,6 +7,7 @@ package hooks
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"strings"
 
@@ -16,6 +17,7 @@ import (
 	"github.com/zarf-dev/zarf/src/pkg/cluster"
 	"github.com/zarf-dev/zarf/src/pkg/logger"
 	"github.com/zarf-dev/zarf/src/pkg/transform"
+	"github.com/zarf-dev/zarf/src/types"
 	v1 "k8s.io/api/admission/v1"
 
 	corev1 "k8s.io/api/core/v1"
@@ -23,6 +25,10 @@ import (
 
 const annotationPrefix = "zarf.dev"
 
+// errWindowsPlainHTTP is returned for Windows pods that would pull from the internal registry over plain HTTP, which
+// the container runtime of Windows nodes rejects for addresses other than the loopback address.
+var errWindowsPlainHTTP = errors.New(lang.AgentErrWindowsPlainHTTP)
+
 // NewPodMutationHook creates a new instance of pods mutation hook.
 func NewPodMutationHook(ctx context.Context, cluster *cluster.Cluster) operations.Hook {
 	return operations.Hook{
@@ -78,11 +84,17 @@ func mutatePod(ctx context.Context, r *v1.AdmissionRequest, cluster *cluster.Clu
 		}, nil
 	}
 
-	state, err := cluster.LoadZarfState(ctx)
+	state, err := cluster.LoadNamespaceZarfState(ctx, r.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	registryURL, err := podRegistryURL(ctx, pod, state, cluster)
+	if errors.Is(err, errWindowsPlainHTTP) {
+		return &operations.Result{Allowed: false, Msg: err.Error()}, nil
+	}
 	if err != nil {
 		return nil, err
 	}
-	registryURL := state.RegistryInfo.Address
 
 	// Pods do not have a metadata.name at the time of admission if from a deployment so we don't log the name
 	l.Info("using the Zarf registry URL to mutate the Pod", "registry", registryURL)
@@ -101,7 +113,7 @@ func mutatePod(ctx context.Context, r *v1.AdmissionRequest, cluster *cluster.Clu
 	// update the image host for each init container
 	for idx, container := range pod.Spec.InitContainers {
 		path := fmt.Sprintf("/spec/initContainers/%d/image", idx)
-		replacement, err := transform.ImageTransformHost(registryURL, container.Image)
+		replacement, err := transform.ImageTransformHost(registryURL, container.Image, state.RegistryInfo.ImageRules...)
 		if err != nil {
 			return nil, err
 		}
@@ -112,7 +124,7 @@ func mutatePod(ctx context.Context, r *v1.AdmissionRequest, cluster *cluster.Clu
 	// update the image host for each normal container
 	for idx, container := range pod.Spec.Containers {
 		path := fmt.Sprintf("/spec/containers/%d/image", idx)
-		replacement, err := transform.ImageTransformHost(registryURL, container.Image)
+		replacement, err := transform.ImageTransformHost(registryURL, container.Image, state.RegistryInfo.ImageRules...)
 		if err != nil {
 			return nil, err
 		}
@@ -132,6 +144,67 @@ func mutatePod(ctx context.Context, r *v1.AdmissionRequest, cluster *cluster.Clu
 	}, nil
 }
 
+// podRegistryURL returns the address of the registry the images of the pod are pulled from. Windows nodes can not reach
+// node ports on the loopback address, so Windows pods pull from the cluster IP of the internal registry instead. This
+// requires the internal registry to be served with TLS, otherwise errWindowsPlainHTTP is returned.
+func podRegistryURL(ctx context.Context, pod *corev1.Pod, state *types.ZarfState, cluster *cluster.Cluster) (string, error) {
+	if !isWindowsPod(pod) {
+		return state.RegistryInfo.Address, nil
+	}
+	registryURL, err := cluster.GetServiceInfoFromRegistryAddress(ctx, state.RegistryInfo.Address)
+	if err != nil {
+		return "", err
+	}
+	// External registries are reached on their own address
+	if registryURL != state.RegistryInfo.Address && state.RegistryInfo.TLS == nil {
+		return "", errWindowsPlainHTTP
+	}
+	return registryURL, nil
+}
+
+// isWindowsPod returns if the pod runs on Windows nodes by its OS, node selector or required node affinity.
+func isWindowsPod(pod *corev1.Pod) bool {
+	if pod.Spec.OS != nil {
+		return pod.Spec.OS.Name == corev1.Windows
+	}
+	if pod.Spec.NodeSelector[corev1.LabelOSStable] == string(corev1.Windows) {
+		return true
+	}
+	if pod.Spec.Affinity == nil || pod.Spec.Affinity.NodeAffinity == nil {
+		return false
+	}
+	required := pod.Spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution
+	if required == nil || len(required.NodeSelectorTerms) == 0 {
+		return false
+	}
+	// Terms are ORed, so the pod only runs on Windows nodes when every term selects them
+	for _, term := range required.NodeSelectorTerms {
+		if !selectsWindows(term) {
+			return false
+		}
+	}
+	return true
+}
+
+// selectsWindows returns if the node selector term only matches nodes labeled with the Windows OS.
+func selectsWindows(term corev1.NodeSelectorTerm) bool {
+	for _, expr := range term.MatchExpressions {
+		if expr.Key != corev1.LabelOSStable || expr.Operator != corev1.NodeSelectorOpIn || len(expr.Values) == 0 {
+			continue
+		}
+		windows := true
+		for _, v := range expr.Values {
+			if v != string(corev1.Windows) {
+				windows = false
+			}
+		}
+		if windows {
+			return true
+		}
+	}
+	return false
+}
+
 // mutatePodSubresource handles pod subresource mutation
 func mutatePodSubresource(ctx context.Context, r *v1.AdmissionRequest, cluster *cluster.Cluster) (*operations.Result, error) {
 	switch res := r.SubResource; res {
@@ -150,11 +223,17 @@ func mutateEphemeralContainers(ctx context.Context, r *v1.AdmissionRequest, clus
 		return nil, fmt.Errorf(lang.AgentErrParsePod, err)
 	}
 
-	state, err := cluster.LoadZarfState(ctx)
+	state, err := cluster.LoadNamespaceZarfState(ctx, r.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	registryURL, err := podRegistryURL(ctx, pod, state, cluster)
+	if errors.Is(err, errWindowsPlainHTTP) {
+		return &operations.Result{Allowed: false, Msg: err.Error()}, nil
+	}
 	if err != nil {
 		return nil, err
 	}
-	registryURL := state.RegistryInfo.Address
 
 	// Pods do not have a metadata.name at the time of admission if from a deployment so we don't log the name
 	l.Info("using the Zarf registry URL to mutate the Pod", "registry", registryURL)
@@ -169,7 +248,7 @@ func mutateEphemeralContainers(ctx context.Context, r *v1.AdmissionRequest, clus
 	// update the image host for each ephemeral container
 	for idx, container := range pod.Spec.EphemeralContainers {
 		path := fmt.Sprintf("/spec/ephemeralContainers/%d/image", idx)
-		replacement, err := transform.ImageTransformHost(registryURL, container.Image)
+		replacement, err := transform.ImageTransformHost(registryURL, container.Image, state.RegistryInfo.ImageRules...)
 		if err != nil {
 			return nil, err
 		}
@@ -13,6 +13,7 @@ import (
 	"github.com/zarf-dev/zarf/src/config"
 	"github.com/zarf-dev/zarf/src/internal/agent/http/admission"
 	"github.com/zarf-dev/zarf/src/internal/agent/operations"
+	"github.com/zarf-dev/zarf/src/pkg/cluster"
 	"github.com/zarf-dev/zarf/src/types"
 	v1 "k8s.io/api/admission/v1"
 	corev1 "k8s.io/api/core/v1"
@@ -40,6 +41,15 @@ func TestPodMutationWebhook(t *testing.T) {
 
 	state := &types.ZarfState{RegistryInfo: types.RegistryInfo{Address: "127.0.0.1:31999"}}
 	c := createTestClientWithZarfState(ctx, t, state)
+	_, err := c.Clientset.CoreV1().Services(cluster.ZarfNamespaceName).Create(ctx, &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "zarf-docker-registry", Namespace: cluster.ZarfNamespaceName},
+		Spec: corev1.ServiceSpec{
+			Type:      corev1.ServiceTypeNodePort,
+			ClusterIP: "10.43.0.20",
+			Ports:     []corev1.ServicePort{{Port: 5000, NodePort: 31999}},
+		},
+	}, metav1.CreateOptions{})
+	require.NoError(t, err)
 	handler := admission.NewHandler().Serve(ctx, NewPodMutationHook(ctx, c))
 
 	tests := []admissionTest{
@@ -180,6 +190,162 @@ func TestPodMutationWebhook(t *testing.T) {
 		})
 	}
 }
+
+func TestWindowsPodMutationWebhook(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	registrySvc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "zarf-docker-registry", Namespace: cluster.ZarfNamespaceName},
+		Spec: corev1.ServiceSpec{
+			Type:      corev1.ServiceTypeNodePort,
+			ClusterIP: "10.43.0.20",
+			Ports:     []corev1.ServicePort{{Port: 5000, NodePort: 31999}},
+		},
+	}
+	newHandler := func(t *testing.T, state *types.ZarfState) http.HandlerFunc {
+		t.Helper()
+		c := createTestClientWithZarfState(ctx, t, state)
+		_, err := c.Clientset.CoreV1().Services(cluster.ZarfNamespaceName).Create(ctx, registrySvc, metav1.CreateOptions{})
+		require.NoError(t, err)
+		return admission.NewHandler().Serve(ctx, NewPodMutationHook(ctx, c))
+	}
+	tlsHandler := newHandler(t, &types.ZarfState{RegistryInfo: types.RegistryInfo{
+		Address: "127.0.0.1:31999",
+		TLS:     &types.TLSInfo{SecretName: cluster.ZarfRegistryTLSSecretName, CA: "ca-bundle"},
+	}})
+	plainHandler := newHandler(t, &types.ZarfState{RegistryInfo: types.RegistryInfo{Address: "127.0.0.1:31999"}})
+
+	clusterIPPatch := []operations.PatchOperation{
+		operations.ReplacePatchOperation(
+			"/spec/imagePullSecrets",
+			[]corev1.LocalObjectReference{{Name: config.ZarfImagePullSecretName}},
+		),
+		operations.ReplacePatchOperation(
+			"/spec/containers/0/image",
+			"10.43.0.20:5000/library/nginx:latest-zarf-3793515731",
+		),
+		operations.ReplacePatchOperation(
+			"/metadata/labels",
+			map[string]string{"zarf-agent": "patched"},
+		),
+		operations.ReplacePatchOperation(
+			"/metadata/annotations",
+			map[string]string{
+				"zarf.dev/original-image-nginx": "nginx",
+			},
+		),
+	}
+	windowsAffinity := func(values ...string) *corev1.Affinity {
+		return &corev1.Affinity{
+			NodeAffinity: &corev1.NodeAffinity{
+				RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+					NodeSelectorTerms: []corev1.NodeSelectorTerm{{
+						MatchExpressions: []corev1.NodeSelectorRequirement{{
+							Key:      corev1.LabelOSStable,
+							Operator: corev1.NodeSelectorOpIn,
+							Values:   values,
+						}},
+					}},
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		admissionTest
+		handler http.HandlerFunc
+	}{
+		{
+			admissionTest: admissionTest{
+				name: "windows pod should be mutated to the registry cluster IP",
+				admissionReq: createPodAdmissionRequest(t, v1.Create, &corev1.Pod{
+					Spec: corev1.PodSpec{
+						OS:         &corev1.PodOS{Name: corev1.Windows},
+						Containers: []corev1.Container{{Name: "nginx", Image: "nginx"}},
+					},
+				}, ""),
+				patch: clusterIPPatch,
+				code:  http.StatusOK,
+			},
+			handler: tlsHandler,
+		},
+		{
+			admissionTest: admissionTest{
+				name: "pod selecting windows nodes should be mutated to the registry cluster IP",
+				admissionReq: createPodAdmissionRequest(t, v1.Create, &corev1.Pod{
+					Spec: corev1.PodSpec{
+						NodeSelector: map[string]string{corev1.LabelOSStable: "windows"},
+						Containers:   []corev1.Container{{Name: "nginx", Image: "nginx"}},
+					},
+				}, ""),
+				patch: clusterIPPatch,
+				code:  http.StatusOK,
+			},
+			handler: tlsHandler,
+		},
+		{
+			admissionTest: admissionTest{
+				name: "pod requiring windows nodes by affinity should be mutated to the registry cluster IP",
+				admissionReq: createPodAdmissionRequest(t, v1.Create, &corev1.Pod{
+					Spec: corev1.PodSpec{
+						Affinity:   windowsAffinity("windows"),
+						Containers: []corev1.Container{{Name: "nginx", Image: "nginx"}},
+					},
+				}, ""),
+				patch: clusterIPPatch,
+				code:  http.StatusOK,
+			},
+			handler: tlsHandler,
+		},
+		{
+			admissionTest: admissionTest{
+				name: "pod allowing linux nodes by affinity should be mutated to the node port",
+				admissionReq: createPodAdmissionRequest(t, v1.Create, &corev1.Pod{
+					Spec: corev1.PodSpec{
+						Affinity:   windowsAffinity("windows", "linux"),
+						Containers: []corev1.Container{{Name: "nginx", Image: "nginx"}},
+					},
+				}, ""),
+				patch: []operations.PatchOperation{
+					clusterIPPatch[0],
+					operations.ReplacePatchOperation(
+						"/spec/containers/0/image",
+						"127.0.0.1:31999/library/nginx:latest-zarf-3793515731",
+					),
+					clusterIPPatch[2],
+					clusterIPPatch[3],
+				},
+				code: http.StatusOK,
+			},
+			handler: tlsHandler,
+		},
+		{
+			admissionTest: admissionTest{
+				name: "windows pod should be rejected when the registry is served over plain HTTP",
+				admissionReq: createPodAdmissionRequest(t, v1.Create, &corev1.Pod{
+					Spec: corev1.PodSpec{
+						OS:         &corev1.PodOS{Name: corev1.Windows},
+						Containers: []corev1.Container{{Name: "nginx", Image: "nginx"}},
+					},
+				}, ""),
+				code:        http.StatusOK,
+				errContains: "Windows nodes can not pull from the internal Zarf registry over plain HTTP",
+			},
+			handler: plainHandler,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			rr := sendAdmissionRequest(t, tt.admissionReq, tt.handler)
+			verifyAdmission(t, rr, tt.admissionTest)
+		})
+	}
+}
+
 func TestGetImageAnnotationKey(t *testing.T) {
 	t.Parallel()
 	tests := []struct {
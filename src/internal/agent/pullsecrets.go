@@ -0,0 +1,56 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package agent holds the mutating webhook server.
+package agent
+
+import (
+	"context"
+	"maps"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/zarf-dev/zarf/src/pkg/cluster"
+	"github.com/zarf-dev/zarf/src/pkg/logger"
+)
+
+// watchNamespaces gives namespaces selected by the pull secret selector of the state the Zarf managed image pull secret
+// as they are created or labelled, and removes it from namespaces that are no longer selected. A change of the selector
+// itself is applied to all namespaces by 'zarf tools sync-pull-secrets'.
+func watchNamespaces(ctx context.Context, c *cluster.Cluster) error {
+	l := logger.From(ctx)
+	sync := func(obj interface{}) {
+		namespace, ok := obj.(*corev1.Namespace)
+		if !ok {
+			return
+		}
+		state, err := c.LoadInstanceZarfState(ctx, namespace.Labels[cluster.InstanceLabel])
+		if err != nil {
+			l.Error("unable to load the Zarf state", "error", err)
+			return
+		}
+		if err := c.SyncPullSecret(ctx, *namespace, state); err != nil {
+			l.Error("unable to sync the registry secret", "namespace", namespace.Name, "error", err)
+		}
+	}
+
+	factory := informers.NewSharedInformerFactory(c.Clientset, 0)
+	_, err := factory.Core().V1().Namespaces().Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: sync,
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			oldNamespace, ok := oldObj.(*corev1.Namespace)
+			newNamespace, newOk := newObj.(*corev1.Namespace)
+			if ok && newOk && maps.Equal(oldNamespace.Labels, newNamespace.Labels) {
+				return
+			}
+			sync(newObj)
+		},
+	})
+	if err != nil {
+		return err
+	}
+	factory.Start(ctx.Done())
+	return nil
+}
@@ -51,6 +51,11 @@ func StartWebhook(ctx context.Context, cluster *cluster.Cluster) error {
 	mux.Handle("/mutate/argocd-application", admissionHandler.Serve(ctx, argocdApplicationMutation))
 	mux.Handle("/mutate/argocd-repository", admissionHandler.Serve(ctx, argocdRepositoryMutation))
 
+	// Give new namespaces the image pull secret when a pull secret selector is set
+	if err := watchNamespaces(ctx, cluster); err != nil {
+		return err
+	}
+
 	return startServer(ctx, httpPort, mux)
 }
 
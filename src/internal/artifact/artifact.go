@@ -0,0 +1,413 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package artifact pushes, pulls and copies arbitrary OCI artifacts.
+package artifact
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/defenseunicorns/pkg/helpers/v2"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/content/file"
+	"oras.land/oras-go/v2/content/oci"
+	"oras.land/oras-go/v2/registry"
+	"oras.land/oras-go/v2/registry/remote"
+	"oras.land/oras-go/v2/registry/remote/auth"
+	"oras.land/oras-go/v2/registry/remote/credentials"
+	"oras.land/oras-go/v2/registry/remote/retry"
+
+	"github.com/zarf-dev/zarf/src/config"
+	"github.com/zarf-dev/zarf/src/pkg/logger"
+	"github.com/zarf-dev/zarf/src/pkg/utils"
+)
+
+const (
+	// DefaultArtifactType is the artifact type of pushed artifacts when none is given.
+	DefaultArtifactType = "application/vnd.unknown.artifact.v1"
+	// DefaultFileMediaType is the media type of pushed files when none is given.
+	DefaultFileMediaType = "application/vnd.oci.image.layer.v1.tar"
+	// DefaultTag is the tag pushed to when the reference does not have one.
+	DefaultTag = "latest"
+
+	dockerManifestListMediaType = "application/vnd.docker.distribution.manifest.list.v2+json"
+)
+
+// RegistryOptions configure how a registry is accessed.
+type RegistryOptions struct {
+	// PlainHTTP accesses the registry over HTTP instead of HTTPS.
+	PlainHTTP bool
+	// InsecureSkipTLSVerify skips verifying the certificate of the registry.
+	InsecureSkipTLSVerify bool
+	// Username and Password authenticate with the registry, the Docker credential store is used when they are empty.
+	Username string
+	Password string
+	// CABundle is a PEM encoded bundle of certificates the registry certificate is verified against.
+	CABundle []byte
+}
+
+// PushOptions are the options for Push.
+type PushOptions struct {
+	// ArtifactType of the pushed manifest, defaults to DefaultArtifactType.
+	ArtifactType string
+	// Annotations of the pushed manifest.
+	Annotations map[string]string
+	Registry    RegistryOptions
+}
+
+// File is a file pushed as a layer of an artifact.
+type File struct {
+	Path      string
+	MediaType string
+}
+
+// ParseFile parses a file argument in the form path[:mediaType].
+func ParseFile(s string) File {
+	path, mediaType, ok := strings.Cut(s, ":")
+	// Media types always contain a slash, which also keeps Windows drive letters in the path.
+	if !ok || !strings.Contains(mediaType, "/") {
+		return File{Path: s, MediaType: DefaultFileMediaType}
+	}
+	return File{Path: path, MediaType: mediaType}
+}
+
+// Push pushes the files as the layers of an artifact to ref. Files are stored under their base name and are restored
+// with that name on pull.
+func Push(ctx context.Context, ref string, files []File, opts PushOptions) (ocispec.Descriptor, error) {
+	l := logger.From(ctx)
+
+	parsed, err := parseReference(ref)
+	if err != nil {
+		return ocispec.Descriptor{}, err
+	}
+	if parsed.Reference == "" {
+		parsed.Reference = DefaultTag
+	}
+	if err := parsed.ValidateReferenceAsTag(); err != nil {
+		return ocispec.Descriptor{}, fmt.Errorf("artifacts can only be pushed to a tag: %w", err)
+	}
+	if len(files) == 0 {
+		return ocispec.Descriptor{}, errors.New("at least one file has to be pushed")
+	}
+	repo, err := newRepository(parsed, opts.Registry)
+	if err != nil {
+		return ocispec.Descriptor{}, err
+	}
+
+	tmpDir, err := utils.MakeTempDir(config.CommonOptions.TempDirectory)
+	if err != nil {
+		return ocispec.Descriptor{}, err
+	}
+	defer os.RemoveAll(tmpDir)
+	store, err := file.New(tmpDir)
+	if err != nil {
+		return ocispec.Descriptor{}, err
+	}
+	defer store.Close()
+
+	layers := []ocispec.Descriptor{}
+	for _, f := range files {
+		// Relative paths would be resolved from the working directory of the store.
+		path, err := filepath.Abs(f.Path)
+		if err != nil {
+			return ocispec.Descriptor{}, err
+		}
+		desc, err := store.Add(ctx, filepath.Base(path), f.MediaType, path)
+		if err != nil {
+			return ocispec.Descriptor{}, fmt.Errorf("unable to add %s: %w", f.Path, err)
+		}
+		l.Debug("adding file to artifact", "path", f.Path, "mediaType", f.MediaType, "digest", desc.Digest)
+		layers = append(layers, desc)
+	}
+	artifactType := opts.ArtifactType
+	if artifactType == "" {
+		artifactType = DefaultArtifactType
+	}
+	packOpts := oras.PackManifestOptions{
+		Layers:              layers,
+		ManifestAnnotations: opts.Annotations,
+	}
+	manifestDesc, err := oras.PackManifest(ctx, store, oras.PackManifestVersion1_1, artifactType, packOpts)
+	if err != nil {
+		return ocispec.Descriptor{}, err
+	}
+	err = store.Tag(ctx, manifestDesc, parsed.Reference)
+	if err != nil {
+		return ocispec.Descriptor{}, err
+	}
+	desc, err := oras.Copy(ctx, store, parsed.Reference, repo, parsed.Reference, oras.DefaultCopyOptions)
+	if err != nil {
+		return ocispec.Descriptor{}, fmt.Errorf("unable to push %s: %w", parsed, err)
+	}
+	l.Info("pushed artifact", "reference", parsed.String(), "digest", desc.Digest, "files", len(files))
+	return desc, nil
+}
+
+// Pull pulls the artifact at ref and writes its files to dir.
+func Pull(ctx context.Context, ref, dir string, opts RegistryOptions) (ocispec.Descriptor, error) {
+	parsed, err := parseReference(ref)
+	if err != nil {
+		return ocispec.Descriptor{}, err
+	}
+	if parsed.Reference == "" {
+		parsed.Reference = DefaultTag
+	}
+	repo, err := newRepository(parsed, opts)
+	if err != nil {
+		return ocispec.Descriptor{}, err
+	}
+	err = helpers.CreateDirectory(dir, helpers.ReadWriteExecuteUser)
+	if err != nil {
+		return ocispec.Descriptor{}, err
+	}
+	store, err := file.New(dir)
+	if err != nil {
+		return ocispec.Descriptor{}, err
+	}
+	defer store.Close()
+	desc, err := oras.Copy(ctx, repo, parsed.Reference, store, parsed.Reference, oras.DefaultCopyOptions)
+	if err != nil {
+		return ocispec.Descriptor{}, fmt.Errorf("unable to pull %s: %w", parsed, err)
+	}
+	logger.From(ctx).Info("pulled artifact", "reference", parsed.String(), "digest", desc.Digest, "directory", dir)
+	return desc, nil
+}
+
+// FetchManifest returns the raw manifest or index at ref.
+func FetchManifest(ctx context.Context, ref string, opts RegistryOptions) (ocispec.Descriptor, []byte, error) {
+	parsed, err := parseReference(ref)
+	if err != nil {
+		return ocispec.Descriptor{}, nil, err
+	}
+	if parsed.Reference == "" {
+		parsed.Reference = DefaultTag
+	}
+	repo, err := newRepository(parsed, opts)
+	if err != nil {
+		return ocispec.Descriptor{}, nil, err
+	}
+	desc, b, err := oras.FetchBytes(ctx, repo, parsed.Reference, oras.DefaultFetchBytesOptions)
+	if err != nil {
+		return ocispec.Descriptor{}, nil, fmt.Errorf("unable to fetch the manifest of %s: %w", parsed, err)
+	}
+	return desc, b, nil
+}
+
+// Copy copies the artifact at src, including everything it references, to dst. The artifact is tagged with the
+// reference of src when dst does not have one.
+func Copy(ctx context.Context, src, dst string, srcOpts, dstOpts RegistryOptions) (ocispec.Descriptor, error) {
+	srcRef, err := parseReference(src)
+	if err != nil {
+		return ocispec.Descriptor{}, err
+	}
+	if srcRef.Reference == "" {
+		srcRef.Reference = DefaultTag
+	}
+	dstRef, err := parseReference(dst)
+	if err != nil {
+		return ocispec.Descriptor{}, err
+	}
+	if dstRef.Reference == "" {
+		dstRef.Reference = srcRef.Reference
+	}
+	srcRepo, err := newRepository(srcRef, srcOpts)
+	if err != nil {
+		return ocispec.Descriptor{}, err
+	}
+	dstRepo, err := newRepository(dstRef, dstOpts)
+	if err != nil {
+		return ocispec.Descriptor{}, err
+	}
+	desc, err := oras.Copy(ctx, srcRepo, srcRef.Reference, dstRepo, dstRef.Reference, oras.DefaultCopyOptions)
+	if err != nil {
+		return ocispec.Descriptor{}, fmt.Errorf("unable to copy %s to %s: %w", srcRef, dstRef, err)
+	}
+	logger.From(ctx).Info("copied artifact", "source", srcRef.String(), "destination", dstRef.String(), "digest", desc.Digest)
+	return desc, nil
+}
+
+// Resolve returns the descriptor of the manifest at ref. When artifactType is set the manifest must have that artifact
+// type, or config media type for artifacts that predate artifact types, and the matching manifest of an index is used.
+func Resolve(ctx context.Context, ref, artifactType string, opts RegistryOptions) (ocispec.Descriptor, error) {
+	parsed, err := parseReference(ref)
+	if err != nil {
+		return ocispec.Descriptor{}, err
+	}
+	if parsed.Reference == "" {
+		parsed.Reference = DefaultTag
+	}
+	repo, err := newRepository(parsed, opts)
+	if err != nil {
+		return ocispec.Descriptor{}, err
+	}
+	desc, err := resolve(ctx, repo, parsed.Reference, artifactType)
+	if err != nil {
+		return ocispec.Descriptor{}, fmt.Errorf("unable to resolve %s: %w", parsed, err)
+	}
+	return desc, nil
+}
+
+// Save copies the manifest at ref that matches artifactType, including everything it references, into the OCI layout
+// at dir and tags it with ref.
+func Save(ctx context.Context, ref, artifactType, dir string, opts RegistryOptions) (ocispec.Descriptor, error) {
+	parsed, err := parseReference(ref)
+	if err != nil {
+		return ocispec.Descriptor{}, err
+	}
+	if parsed.Reference == "" {
+		parsed.Reference = DefaultTag
+	}
+	repo, err := newRepository(parsed, opts)
+	if err != nil {
+		return ocispec.Descriptor{}, err
+	}
+	desc, err := resolve(ctx, repo, parsed.Reference, artifactType)
+	if err != nil {
+		return ocispec.Descriptor{}, fmt.Errorf("unable to resolve %s: %w", parsed, err)
+	}
+	store, err := oci.NewWithContext(ctx, dir)
+	if err != nil {
+		return ocispec.Descriptor{}, err
+	}
+	err = oras.CopyGraph(ctx, repo, store, desc, oras.DefaultCopyGraphOptions)
+	if err != nil {
+		return ocispec.Descriptor{}, fmt.Errorf("unable to save %s: %w", parsed, err)
+	}
+	err = store.Tag(ctx, desc, strings.TrimPrefix(ref, helpers.OCIURLPrefix))
+	if err != nil {
+		return ocispec.Descriptor{}, err
+	}
+	logger.From(ctx).Info("saved artifact", "reference", parsed.String(), "digest", desc.Digest, "directory", dir)
+	return desc, nil
+}
+
+// PushLayout pushes the artifact tagged with ref in the OCI layout at dir, including everything it references, to dst.
+func PushLayout(ctx context.Context, dir, ref, dst string, opts RegistryOptions) (ocispec.Descriptor, error) {
+	dstRef, err := parseReference(dst)
+	if err != nil {
+		return ocispec.Descriptor{}, err
+	}
+	if dstRef.Reference == "" {
+		dstRef.Reference = DefaultTag
+	}
+	store, err := oci.NewWithContext(ctx, dir)
+	if err != nil {
+		return ocispec.Descriptor{}, err
+	}
+	repo, err := newRepository(dstRef, opts)
+	if err != nil {
+		return ocispec.Descriptor{}, err
+	}
+	desc, err := oras.Copy(ctx, store, strings.TrimPrefix(ref, helpers.OCIURLPrefix), repo, dstRef.Reference, oras.DefaultCopyOptions)
+	if err != nil {
+		return ocispec.Descriptor{}, fmt.Errorf("unable to push %s to %s: %w", ref, dstRef, err)
+	}
+	logger.From(ctx).Info("pushed artifact", "reference", ref, "destination", dstRef.String(), "digest", desc.Digest)
+	return desc, nil
+}
+
+// resolve returns the descriptor of the manifest at ref in target that matches artifactType.
+func resolve(ctx context.Context, target oras.ReadOnlyTarget, ref, artifactType string) (ocispec.Descriptor, error) {
+	desc, b, err := oras.FetchBytes(ctx, target, ref, oras.DefaultFetchBytesOptions)
+	if err != nil {
+		return ocispec.Descriptor{}, err
+	}
+	if artifactType == "" {
+		return desc, nil
+	}
+	if desc.MediaType != ocispec.MediaTypeImageIndex && desc.MediaType != dockerManifestListMediaType {
+		if got := manifestArtifactType(b); got != artifactType {
+			return ocispec.Descriptor{}, fmt.Errorf("the artifact type is %q instead of %q", got, artifactType)
+		}
+		return desc, nil
+	}
+	var index ocispec.Index
+	if err := json.Unmarshal(b, &index); err != nil {
+		return ocispec.Descriptor{}, err
+	}
+	for _, m := range index.Manifests {
+		if m.ArtifactType == artifactType {
+			return m, nil
+		}
+		_, mb, err := oras.FetchBytes(ctx, target, m.Digest.String(), oras.DefaultFetchBytesOptions)
+		if err != nil {
+			return ocispec.Descriptor{}, err
+		}
+		if manifestArtifactType(mb) == artifactType {
+			return m, nil
+		}
+	}
+	return ocispec.Descriptor{}, fmt.Errorf("the index does not have a manifest with the artifact type %q", artifactType)
+}
+
+// manifestArtifactType returns the artifact type of a manifest, falling back to the media type of its config.
+func manifestArtifactType(b []byte) string {
+	var manifest ocispec.Manifest
+	if err := json.Unmarshal(b, &manifest); err != nil {
+		return ""
+	}
+	if manifest.ArtifactType != "" {
+		return manifest.ArtifactType
+	}
+	return manifest.Config.MediaType
+}
+
+func parseReference(ref string) (registry.Reference, error) {
+	parsed, err := registry.ParseReference(strings.TrimPrefix(ref, helpers.OCIURLPrefix))
+	if err != nil {
+		return registry.Reference{}, fmt.Errorf("invalid reference %s: %w", ref, err)
+	}
+	return parsed, nil
+}
+
+func newRepository(ref registry.Reference, opts RegistryOptions) (*remote.Repository, error) {
+	tlsConfig := &tls.Config{
+		// The registry may be reached with a self-signed certificate when users explicitly ask for it.
+		InsecureSkipVerify: opts.InsecureSkipTLSVerify, //nolint:gosec
+		MinVersion:         tls.VersionTLS12,
+	}
+	if len(opts.CABundle) > 0 {
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		pool.AppendCertsFromPEM(opts.CABundle)
+		tlsConfig.RootCAs = pool
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+
+	client := &auth.Client{
+		Client: &http.Client{Transport: retry.NewTransport(transport)},
+		Cache:  auth.NewCache(),
+	}
+	client.SetUserAgent("zarf")
+	if opts.Username != "" {
+		client.Credential = auth.StaticCredential(ref.Registry, auth.Credential{
+			Username: opts.Username,
+			Password: opts.Password,
+		})
+	} else {
+		store, err := credentials.NewStoreFromDocker(credentials.StoreOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("unable to load the Docker credential store: %w", err)
+		}
+		client.Credential = credentials.Credential(store)
+	}
+
+	return &remote.Repository{
+		Reference: ref,
+		Client:    client,
+		PlainHTTP: opts.PlainHTTP,
+	}, nil
+}
@@ -0,0 +1,152 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package artifact
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/defenseunicorns/pkg/helpers/v2"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zarf-dev/zarf/src/test/testutil"
+)
+
+func TestParseFile(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		arg      string
+		expected File
+	}{
+		{
+			name:     "path",
+			arg:      "values.yaml",
+			expected: File{Path: "values.yaml", MediaType: DefaultFileMediaType},
+		},
+		{
+			name:     "media type",
+			arg:      "policy.rego:application/vnd.cncf.openpolicyagent.policy.layer.v1+rego",
+			expected: File{Path: "policy.rego", MediaType: "application/vnd.cncf.openpolicyagent.policy.layer.v1+rego"},
+		},
+		{
+			name:     "windows path",
+			arg:      `C:\artifacts\values.yaml`,
+			expected: File{Path: `C:\artifacts\values.yaml`, MediaType: DefaultFileMediaType},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			require.Equal(t, tt.expected, ParseFile(tt.arg))
+		})
+	}
+}
+
+func TestPushPullCopy(t *testing.T) {
+	t.Parallel()
+	ctx := testutil.TestContext(t)
+
+	port, err := helpers.GetAvailablePort()
+	require.NoError(t, err)
+	registryURL := testutil.SetupInMemoryRegistry(ctx, t, port)
+	opts := RegistryOptions{PlainHTTP: true}
+
+	srcDir := t.TempDir()
+	valuesPath := filepath.Join(srcDir, "values.yaml")
+	require.NoError(t, os.WriteFile(valuesPath, []byte("replicas: 3\n"), 0o600))
+	policyPath := filepath.Join(srcDir, "policy.rego")
+	require.NoError(t, os.WriteFile(policyPath, []byte("package zarf\n"), 0o600))
+
+	ref := fmt.Sprintf("oci://%s/artifacts/config:1.0.0", registryURL)
+	files := []File{
+		{Path: valuesPath, MediaType: DefaultFileMediaType},
+		{Path: policyPath, MediaType: "application/vnd.cncf.openpolicyagent.policy.layer.v1+rego"},
+	}
+	pushOpts := PushOptions{
+		ArtifactType: "application/vnd.example.config.v1",
+		Annotations:  map[string]string{"org.opencontainers.image.source": "https://example.com/config"},
+		Registry:     opts,
+	}
+	pushed, err := Push(ctx, ref, files, pushOpts)
+	require.NoError(t, err)
+
+	desc, b, err := FetchManifest(ctx, ref, opts)
+	require.NoError(t, err)
+	require.Equal(t, pushed.Digest, desc.Digest)
+	var manifest ocispec.Manifest
+	require.NoError(t, json.Unmarshal(b, &manifest))
+	require.Equal(t, "application/vnd.example.config.v1", manifest.ArtifactType)
+	require.Equal(t, "https://example.com/config", manifest.Annotations["org.opencontainers.image.source"])
+	require.Len(t, manifest.Layers, 2)
+	require.Equal(t, "values.yaml", manifest.Layers[0].Annotations[ocispec.AnnotationTitle])
+
+	dst := fmt.Sprintf("%s/mirror/config", registryURL)
+	copied, err := Copy(ctx, ref, dst, opts, opts)
+	require.NoError(t, err)
+	require.Equal(t, pushed.Digest, copied.Digest)
+
+	pullDir := t.TempDir()
+	pulled, err := Pull(ctx, dst+":1.0.0", pullDir, opts)
+	require.NoError(t, err)
+	require.Equal(t, pushed.Digest, pulled.Digest)
+	b, err = os.ReadFile(filepath.Join(pullDir, "values.yaml"))
+	require.NoError(t, err)
+	require.Equal(t, "replicas: 3\n", string(b))
+	b, err = os.ReadFile(filepath.Join(pullDir, "policy.rego"))
+	require.NoError(t, err)
+	require.Equal(t, "package zarf\n", string(b))
+
+	_, err = Push(ctx, fmt.Sprintf("%s/artifacts/config@%s", registryURL, pushed.Digest), files, pushOpts)
+	require.ErrorContains(t, err, "artifacts can only be pushed to a tag")
+}
+
+func TestSavePushLayout(t *testing.T) {
+	t.Parallel()
+	ctx := testutil.TestContext(t)
+
+	port, err := helpers.GetAvailablePort()
+	require.NoError(t, err)
+	registryURL := testutil.SetupInMemoryRegistry(ctx, t, port)
+	opts := RegistryOptions{PlainHTTP: true}
+
+	modulePath := filepath.Join(t.TempDir(), "module.wasm")
+	require.NoError(t, os.WriteFile(modulePath, []byte("\x00asm"), 0o600))
+	ref := fmt.Sprintf("%s/modules/hello:1.0.0", registryURL)
+	pushOpts := PushOptions{
+		ArtifactType: "application/vnd.wasm.config.v0+json",
+		Registry:     opts,
+	}
+	pushed, err := Push(ctx, ref, []File{{Path: modulePath, MediaType: "application/wasm"}}, pushOpts)
+	require.NoError(t, err)
+
+	resolved, err := Resolve(ctx, ref, "application/vnd.wasm.config.v0+json", opts)
+	require.NoError(t, err)
+	require.Equal(t, pushed.Digest, resolved.Digest)
+	_, err = Resolve(ctx, ref, "application/vnd.cncf.helm.config.v1+json", opts)
+	require.ErrorContains(t, err, `the artifact type is "application/vnd.wasm.config.v0+json" instead of "application/vnd.cncf.helm.config.v1+json"`)
+
+	layoutDir := filepath.Join(t.TempDir(), "artifacts")
+	pinned := fmt.Sprintf("%s@%s", ref, pushed.Digest)
+	saved, err := Save(ctx, pinned, "", layoutDir, opts)
+	require.NoError(t, err)
+	require.Equal(t, pushed.Digest, saved.Digest)
+	require.FileExists(t, filepath.Join(layoutDir, "index.json"))
+
+	dst := fmt.Sprintf("%s/mirror/hello:1.0.0", registryURL)
+	copied, err := PushLayout(ctx, layoutDir, pinned, dst, opts)
+	require.NoError(t, err)
+	require.Equal(t, pushed.Digest, copied.Digest)
+	desc, _, err := FetchManifest(ctx, dst, opts)
+	require.NoError(t, err)
+	require.Equal(t, pushed.Digest, desc.Digest)
+
+	_, err = PushLayout(ctx, layoutDir, ref, dst, opts)
+	require.ErrorContains(t, err, "not found")
+}
@@ -0,0 +1,327 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package controller reconciles ZarfPackageDeployment resources to deploy, upgrade and remove Zarf packages in the cluster.
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/dynamic"
+
+	"github.com/zarf-dev/zarf/src/pkg/cluster"
+	"github.com/zarf-dev/zarf/src/pkg/logger"
+)
+
+const (
+	// DefaultInterval is the default time between reconciliations of all package deployments.
+	DefaultInterval = 30 * time.Second
+	// DefaultRetryInterval is the default time to wait before retrying a failed deploy of the same generation.
+	DefaultRetryInterval = 5 * time.Minute
+)
+
+// Options configures the controller.
+type Options struct {
+	// Interval is the time between reconciliations of all package deployments.
+	Interval time.Duration
+	// RetryInterval is the time to wait before retrying a failed deploy.
+	RetryInterval time.Duration
+	// Namespace is the only namespace package deployments are watched in.
+	// Deploying a package can change the whole cluster, so only the users that can write to this namespace may request it.
+	Namespace string
+	// PublicKeyPath is the path to the public key the signatures of the packages are verified with.
+	// A key file without content is treated as if no key was configured.
+	PublicKeyPath string
+}
+
+// Controller reconciles ZarfPackageDeployment resources.
+type Controller struct {
+	client dynamic.Interface
+	ops    operations
+	opts   Options
+}
+
+// New returns a controller that deploys packages to the cluster with the Zarf packager.
+func New(c *cluster.Cluster, opts Options) (*Controller, error) {
+	client, err := dynamic.NewForConfig(c.RestConfig)
+	if err != nil {
+		return nil, err
+	}
+	if opts.Interval <= 0 {
+		opts.Interval = DefaultInterval
+	}
+	if opts.RetryInterval <= 0 {
+		opts.RetryInterval = DefaultRetryInterval
+	}
+	if opts.Namespace == "" {
+		opts.Namespace = cluster.ZarfNamespaceName
+	}
+	if opts.PublicKeyPath != "" {
+		b, err := os.ReadFile(opts.PublicKeyPath)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read public key: %w", err)
+		}
+		if strings.TrimSpace(string(b)) == "" {
+			opts.PublicKeyPath = ""
+		}
+	}
+	return &Controller{
+		client: client,
+		ops:    packagerOperations{cluster: c, publicKeyPath: opts.PublicKeyPath},
+		opts:   opts,
+	}, nil
+}
+
+// Start reconciles all package deployments on every interval until the context is cancelled.
+func (c *Controller) Start(ctx context.Context) error {
+	l := logger.From(ctx)
+	l.Info("controller running", "interval", c.opts.Interval, "namespace", c.opts.Namespace, "verifySignatures", c.opts.PublicKeyPath != "")
+	ticker := time.NewTicker(c.opts.Interval)
+	defer ticker.Stop()
+	for {
+		if err := c.reconcileAll(ctx); err != nil {
+			l.Error("unable to reconcile package deployments", "error", err)
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// reconcileAll reconciles every package deployment in the watched namespace, one at a time.
+// Zarf operations share process wide configuration so they can not safely run concurrently.
+func (c *Controller) reconcileAll(ctx context.Context) error {
+	l := logger.From(ctx)
+	list, err := c.client.Resource(PackageDeploymentResource).Namespace(c.opts.Namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+	for _, item := range list.Items {
+		pd, err := fromUnstructured(&item)
+		if err != nil {
+			l.Error("invalid package deployment", "namespace", item.GetNamespace(), "name", item.GetName(), "error", err)
+			continue
+		}
+		if err := c.reconcile(ctx, pd); err != nil {
+			l.Error("unable to reconcile package deployment", "namespace", pd.Namespace, "name", pd.Name, "error", err)
+		}
+	}
+	return nil
+}
+
+// reconcile moves the cluster towards the state declared by the package deployment.
+func (c *Controller) reconcile(ctx context.Context, pd *PackageDeployment) error {
+	if pd.DeletionTimestamp != nil {
+		return c.finalize(ctx, pd)
+	}
+	if !slices.Contains(pd.Finalizers, Finalizer) {
+		pd.Finalizers = append(pd.Finalizers, Finalizer)
+		var err error
+		pd, err = c.update(ctx, pd)
+		if err != nil {
+			return fmt.Errorf("unable to add finalizer: %w", err)
+		}
+	}
+	if !c.needsDeploy(pd) {
+		return nil
+	}
+	return c.deploy(ctx, pd)
+}
+
+// needsDeploy returns true if the current generation has not been deployed and is not waiting to retry a failed deploy.
+func (c *Controller) needsDeploy(pd *PackageDeployment) bool {
+	if pd.Status.ObservedGeneration != pd.Generation {
+		return true
+	}
+	ready := meta.FindStatusCondition(pd.Status.Conditions, ConditionReady)
+	if ready == nil {
+		return true
+	}
+	if ready.Status == metav1.ConditionTrue {
+		return false
+	}
+	progressing := meta.FindStatusCondition(pd.Status.Conditions, ConditionProgressing)
+	if progressing == nil || progressing.Status == metav1.ConditionTrue {
+		// A deploy that was interrupted is restarted.
+		return true
+	}
+	return time.Since(progressing.LastTransitionTime.Time) >= c.opts.RetryInterval
+}
+
+func (c *Controller) deploy(ctx context.Context, pd *PackageDeployment) error {
+	l := logger.From(ctx)
+	l.Info("deploying package", "namespace", pd.Namespace, "name", pd.Name, "source", pd.Spec.Source)
+
+	setCondition(pd, ConditionProgressing, metav1.ConditionTrue, ReasonDeploying, fmt.Sprintf("Deploying %s", pd.Spec.Source))
+	setCondition(pd, ConditionReady, metav1.ConditionFalse, ReasonDeploying, fmt.Sprintf("Deploying %s", pd.Spec.Source))
+	pd, err := c.updateStatus(ctx, pd)
+	if err != nil {
+		return err
+	}
+
+	deployed, deployErr := c.ops.Deploy(ctx, pd.Spec)
+	if deployErr == nil && pd.Status.PackageName != "" && pd.Status.PackageName != deployed.Name {
+		// The spec now points at a different package so the previously deployed package is no longer wanted, unless
+		// another package deployment still points at it.
+		inUse, err := c.packageInUse(ctx, pd, pd.Status.PackageName)
+		switch {
+		case err != nil:
+			deployErr = err
+		case inUse:
+			l.Info("keeping replaced package referenced by another package deployment", "namespace", pd.Namespace, "name", pd.Name, "package", pd.Status.PackageName)
+		default:
+			l.Info("removing replaced package", "namespace", pd.Namespace, "name", pd.Name, "package", pd.Status.PackageName)
+			if err := c.ops.Remove(ctx, pd.Status.PackageName); err != nil {
+				deployErr = fmt.Errorf("unable to remove replaced package %s: %w", pd.Status.PackageName, err)
+			}
+		}
+	}
+
+	pd.Status.ObservedGeneration = pd.Generation
+	if deployErr != nil {
+		setCondition(pd, ConditionProgressing, metav1.ConditionFalse, ReasonDeployFailed, logger.Redact(deployErr.Error()))
+		setCondition(pd, ConditionReady, metav1.ConditionFalse, ReasonDeployFailed, logger.Redact(deployErr.Error()))
+		_, err := c.updateStatus(ctx, pd)
+		return errors.Join(deployErr, err)
+	}
+	pd.Status.PackageName = deployed.Name
+	pd.Status.PackageVersion = deployed.Version
+	pd.Status.Source = pd.Spec.Source
+	message := fmt.Sprintf("Deployed %s", pd.Spec.Source)
+	setCondition(pd, ConditionProgressing, metav1.ConditionFalse, ReasonDeployed, message)
+	setCondition(pd, ConditionReady, metav1.ConditionTrue, ReasonDeployed, message)
+	_, err = c.updateStatus(ctx, pd)
+	if err != nil {
+		return err
+	}
+	l.Info("deployed package", "namespace", pd.Namespace, "name", pd.Name, "package", deployed.Name, "version", deployed.Version)
+	return nil
+}
+
+// finalize removes the deployed package and then the finalizer so that the resource can be deleted. The package is
+// kept while another package deployment points at it, the last one to be deleted removes it.
+func (c *Controller) finalize(ctx context.Context, pd *PackageDeployment) error {
+	if !slices.Contains(pd.Finalizers, Finalizer) {
+		return nil
+	}
+	inUse := false
+	if pd.Status.PackageName != "" {
+		var err error
+		inUse, err = c.packageInUse(ctx, pd, pd.Status.PackageName)
+		if err != nil {
+			return err
+		}
+	}
+	if inUse {
+		logger.From(ctx).Info("keeping package referenced by another package deployment", "namespace", pd.Namespace, "name", pd.Name, "package", pd.Status.PackageName)
+	}
+	if pd.Status.PackageName != "" && !inUse {
+		logger.From(ctx).Info("removing package", "namespace", pd.Namespace, "name", pd.Name, "package", pd.Status.PackageName)
+		setCondition(pd, ConditionProgressing, metav1.ConditionTrue, ReasonRemoving, fmt.Sprintf("Removing %s", pd.Status.PackageName))
+		var err error
+		pd, err = c.updateStatus(ctx, pd)
+		if err != nil {
+			return err
+		}
+		removeErr := c.ops.Remove(ctx, pd.Status.PackageName)
+		if removeErr != nil {
+			setCondition(pd, ConditionProgressing, metav1.ConditionFalse, ReasonRemoveFailed, logger.Redact(removeErr.Error()))
+			setCondition(pd, ConditionReady, metav1.ConditionFalse, ReasonRemoveFailed, logger.Redact(removeErr.Error()))
+			_, err := c.updateStatus(ctx, pd)
+			return errors.Join(removeErr, err)
+		}
+	}
+	pd.Finalizers = slices.DeleteFunc(pd.Finalizers, func(f string) bool {
+		return f == Finalizer
+	})
+	_, err := c.update(ctx, pd)
+	if err != nil {
+		return fmt.Errorf("unable to remove finalizer: %w", err)
+	}
+	return nil
+}
+
+// packageInUse returns true if a package deployment other than pd points at the deployed package. Package deployments
+// that are being deleted still count, so that the last one of them to be finalized removes the package.
+func (c *Controller) packageInUse(ctx context.Context, pd *PackageDeployment, packageName string) (bool, error) {
+	list, err := c.client.Resource(PackageDeploymentResource).Namespace(c.opts.Namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return false, fmt.Errorf("unable to list package deployments: %w", err)
+	}
+	for _, item := range list.Items {
+		if item.GetNamespace() == pd.Namespace && item.GetName() == pd.Name {
+			continue
+		}
+		other, err := fromUnstructured(&item)
+		if err != nil {
+			continue
+		}
+		if other.Status.PackageName == packageName {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (c *Controller) update(ctx context.Context, pd *PackageDeployment) (*PackageDeployment, error) {
+	u, err := toUnstructured(pd)
+	if err != nil {
+		return nil, err
+	}
+	u, err = c.client.Resource(PackageDeploymentResource).Namespace(pd.Namespace).Update(ctx, u, metav1.UpdateOptions{FieldManager: cluster.FieldManagerName})
+	if err != nil {
+		return nil, err
+	}
+	return fromUnstructured(u)
+}
+
+func (c *Controller) updateStatus(ctx context.Context, pd *PackageDeployment) (*PackageDeployment, error) {
+	u, err := toUnstructured(pd)
+	if err != nil {
+		return nil, err
+	}
+	u, err = c.client.Resource(PackageDeploymentResource).Namespace(pd.Namespace).UpdateStatus(ctx, u, metav1.UpdateOptions{FieldManager: cluster.FieldManagerName})
+	if err != nil {
+		return nil, fmt.Errorf("unable to update status: %w", err)
+	}
+	return fromUnstructured(u)
+}
+
+func setCondition(pd *PackageDeployment, conditionType string, status metav1.ConditionStatus, reason, message string) {
+	meta.SetStatusCondition(&pd.Status.Conditions, metav1.Condition{
+		Type:               conditionType,
+		Status:             status,
+		ObservedGeneration: pd.Generation,
+		Reason:             reason,
+		Message:            message,
+	})
+}
+
+func fromUnstructured(u *unstructured.Unstructured) (*PackageDeployment, error) {
+	pd := &PackageDeployment{}
+	err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, pd)
+	if err != nil {
+		return nil, err
+	}
+	return pd, nil
+}
+
+func toUnstructured(pd *PackageDeployment) (*unstructured.Unstructured, error) {
+	obj, err := runtime.DefaultUnstructuredConverter.ToUnstructured(pd)
+	if err != nil {
+		return nil, err
+	}
+	return &unstructured.Unstructured{Object: obj}, nil
+}
@@ -0,0 +1,197 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+)
+
+type fakeOperations struct {
+	deployed  []string
+	removed   []string
+	deployErr error
+}
+
+func (f *fakeOperations) Deploy(_ context.Context, spec PackageDeploymentSpec) (deployedPackage, error) {
+	if f.deployErr != nil {
+		return deployedPackage{}, f.deployErr
+	}
+	f.deployed = append(f.deployed, spec.Source)
+	name := "dos-games"
+	if spec.Source == "oci://ghcr.io/zarf-dev/packages/podinfo:6.4.0" {
+		name = "podinfo"
+	}
+	return deployedPackage{Name: name, Version: "1.0.0"}, nil
+}
+
+func (f *fakeOperations) Remove(_ context.Context, name string) error {
+	f.removed = append(f.removed, name)
+	return nil
+}
+
+func newTestController(t *testing.T, pds ...*PackageDeployment) (*Controller, *fakeOperations) {
+	t.Helper()
+
+	objs := []runtime.Object{}
+	for _, pd := range pds {
+		pd.APIVersion = PackageDeploymentResource.GroupVersion().String()
+		pd.Kind = "ZarfPackageDeployment"
+		u, err := toUnstructured(pd)
+		require.NoError(t, err)
+		objs = append(objs, u)
+	}
+	client := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+		PackageDeploymentResource: "ZarfPackageDeploymentList",
+	}, objs...)
+	ops := &fakeOperations{}
+	return &Controller{client: client, ops: ops, opts: Options{Interval: time.Second, RetryInterval: time.Hour, Namespace: "zarf"}}, ops
+}
+
+func getPackageDeployment(t *testing.T, c *Controller) *PackageDeployment {
+	t.Helper()
+
+	u, err := c.client.Resource(PackageDeploymentResource).Namespace("zarf").Get(context.Background(), "dos-games", metav1.GetOptions{})
+	require.NoError(t, err)
+	pd, err := fromUnstructured(u)
+	require.NoError(t, err)
+	return pd
+}
+
+func TestReconcile(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	c, ops := newTestController(t, &PackageDeployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "dos-games", Namespace: "zarf", Generation: 1},
+		Spec:       PackageDeploymentSpec{Source: "oci://ghcr.io/zarf-dev/packages/dos-games:1.1.0"},
+	})
+
+	require.NoError(t, c.reconcileAll(ctx))
+	pd := getPackageDeployment(t, c)
+	require.Equal(t, []string{Finalizer}, pd.Finalizers)
+	require.Equal(t, int64(1), pd.Status.ObservedGeneration)
+	require.Equal(t, "dos-games", pd.Status.PackageName)
+	require.Equal(t, "oci://ghcr.io/zarf-dev/packages/dos-games:1.1.0", pd.Status.Source)
+	require.True(t, meta.IsStatusConditionTrue(pd.Status.Conditions, ConditionReady))
+	require.True(t, meta.IsStatusConditionFalse(pd.Status.Conditions, ConditionProgressing))
+
+	// An up to date package deployment is not deployed again.
+	require.NoError(t, c.reconcileAll(ctx))
+	require.Equal(t, []string{"oci://ghcr.io/zarf-dev/packages/dos-games:1.1.0"}, ops.deployed)
+
+	// A new generation that points at another package replaces the deployed package.
+	pd.Generation = 2
+	pd.Spec.Source = "oci://ghcr.io/zarf-dev/packages/podinfo:6.4.0"
+	_, err := c.update(ctx, pd)
+	require.NoError(t, err)
+	require.NoError(t, c.reconcileAll(ctx))
+	pd = getPackageDeployment(t, c)
+	require.Equal(t, "podinfo", pd.Status.PackageName)
+	require.Equal(t, []string{"dos-games"}, ops.removed)
+
+	// Deleting the package deployment removes the package and the finalizer.
+	now := metav1.Now()
+	pd.DeletionTimestamp = &now
+	require.NoError(t, c.reconcile(ctx, pd))
+	pd = getPackageDeployment(t, c)
+	require.Empty(t, pd.Finalizers)
+	require.Equal(t, []string{"dos-games", "podinfo"}, ops.removed)
+}
+
+func TestReconcileFailure(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	c, ops := newTestController(t, &PackageDeployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "dos-games", Namespace: "zarf", Generation: 1},
+		Spec:       PackageDeploymentSpec{Source: "oci://ghcr.io/zarf-dev/packages/dos-games:1.1.0"},
+	})
+	ops.deployErr = errors.New("unable to pull package")
+
+	err := c.reconcile(ctx, getPackageDeployment(t, c))
+	require.EqualError(t, err, "unable to pull package")
+	pd := getPackageDeployment(t, c)
+	ready := meta.FindStatusCondition(pd.Status.Conditions, ConditionReady)
+	require.NotNil(t, ready)
+	require.Equal(t, metav1.ConditionFalse, ready.Status)
+	require.Equal(t, ReasonDeployFailed, ready.Reason)
+	require.Equal(t, "unable to pull package", ready.Message)
+
+	// The failed deploy is not retried until the retry interval has passed.
+	require.False(t, c.needsDeploy(pd))
+	c.opts.RetryInterval = 0
+	require.True(t, c.needsDeploy(pd))
+}
+
+func TestReconcileOtherNamespace(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	c, ops := newTestController(t,
+		&PackageDeployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "dos-games", Namespace: "zarf", Generation: 1},
+			Spec:       PackageDeploymentSpec{Source: "oci://ghcr.io/zarf-dev/packages/dos-games:1.1.0"},
+		},
+		&PackageDeployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "podinfo", Namespace: "default", Generation: 1},
+			Spec:       PackageDeploymentSpec{Source: "oci://ghcr.io/zarf-dev/packages/podinfo:6.4.0"},
+		},
+	)
+
+	// Package deployments outside of the watched namespace are ignored.
+	require.NoError(t, c.reconcileAll(ctx))
+	require.Equal(t, []string{"oci://ghcr.io/zarf-dev/packages/dos-games:1.1.0"}, ops.deployed)
+	u, err := c.client.Resource(PackageDeploymentResource).Namespace("default").Get(ctx, "podinfo", metav1.GetOptions{})
+	require.NoError(t, err)
+	pd, err := fromUnstructured(u)
+	require.NoError(t, err)
+	require.Empty(t, pd.Finalizers)
+	require.Empty(t, pd.Status.Conditions)
+}
+
+func TestFinalizeSharedPackage(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	c, ops := newTestController(t,
+		&PackageDeployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "dos-games", Namespace: "zarf", Generation: 1},
+			Spec:       PackageDeploymentSpec{Source: "oci://ghcr.io/zarf-dev/packages/dos-games:1.1.0"},
+		},
+		&PackageDeployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "dos-games-copy", Namespace: "zarf", Generation: 1},
+			Spec:       PackageDeploymentSpec{Source: "oci://ghcr.io/zarf-dev/packages/dos-games:1.1.0"},
+		},
+	)
+	require.NoError(t, c.reconcileAll(ctx))
+
+	// The package is kept while another package deployment points at it.
+	pd := getPackageDeployment(t, c)
+	now := metav1.Now()
+	pd.DeletionTimestamp = &now
+	require.NoError(t, c.reconcile(ctx, pd))
+	require.Empty(t, getPackageDeployment(t, c).Finalizers)
+	require.Empty(t, ops.removed)
+
+	// The last package deployment pointing at the package removes it.
+	err := c.client.Resource(PackageDeploymentResource).Namespace("zarf").Delete(ctx, "dos-games", metav1.DeleteOptions{})
+	require.NoError(t, err)
+	u, err := c.client.Resource(PackageDeploymentResource).Namespace("zarf").Get(ctx, "dos-games-copy", metav1.GetOptions{})
+	require.NoError(t, err)
+	other, err := fromUnstructured(u)
+	require.NoError(t, err)
+	other.DeletionTimestamp = &now
+	require.NoError(t, c.reconcile(ctx, other))
+	require.Equal(t, []string{"dos-games"}, ops.removed)
+}
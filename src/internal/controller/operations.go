@@ -0,0 +1,98 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/defenseunicorns/pkg/helpers/v2"
+
+	"github.com/zarf-dev/zarf/src/config"
+	"github.com/zarf-dev/zarf/src/internal/packager2"
+	"github.com/zarf-dev/zarf/src/pkg/cluster"
+	"github.com/zarf-dev/zarf/src/pkg/packager"
+	"github.com/zarf-dev/zarf/src/pkg/packager/filters"
+	"github.com/zarf-dev/zarf/src/types"
+)
+
+// deployedPackage identifies a package deployed by the controller.
+type deployedPackage struct {
+	Name    string
+	Version string
+}
+
+// operations deploys and removes packages for the controller.
+type operations interface {
+	Deploy(ctx context.Context, spec PackageDeploymentSpec) (deployedPackage, error)
+	Remove(ctx context.Context, name string) error
+}
+
+// packagerOperations runs operations with the Zarf packager.
+type packagerOperations struct {
+	cluster       *cluster.Cluster
+	publicKeyPath string
+}
+
+func (o packagerOperations) Deploy(ctx context.Context, spec PackageDeploymentSpec) (deployedPackage, error) {
+	if !strings.HasPrefix(spec.Source, helpers.OCIURLPrefix) {
+		return deployedPackage{}, fmt.Errorf("source %s must be an OCI reference starting with %s", spec.Source, helpers.OCIURLPrefix)
+	}
+	timeout := config.ZarfDefaultTimeout
+	if spec.Timeout != "" {
+		var err error
+		timeout, err = time.ParseDuration(spec.Timeout)
+		if err != nil {
+			return deployedPackage{}, fmt.Errorf("invalid timeout: %w", err)
+		}
+	}
+	retries := config.ZarfDefaultRetries
+	if spec.Retries > 0 {
+		retries = spec.Retries
+	}
+	cfg := types.PackagerConfig{
+		PkgOpts: types.ZarfPackageOptions{
+			PackageSource:      spec.Source,
+			OptionalComponents: spec.Components,
+			SetVariables:       helpers.TransformMapKeys(spec.Variables, strings.ToUpper),
+			Shasum:             spec.Shasum,
+			PublicKeyPath:      o.publicKeyPath,
+			Retries:            retries,
+		},
+		DeployOpts: types.ZarfDeployOptions{
+			AdoptExistingResources: spec.AdoptExistingResources,
+			Timeout:                timeout,
+		},
+	}
+	pkgClient, err := packager.New(&cfg, packager.WithContext(ctx))
+	if err != nil {
+		return deployedPackage{}, err
+	}
+	defer pkgClient.ClearTempPaths()
+	if err := pkgClient.Deploy(ctx); err != nil {
+		return deployedPackage{}, fmt.Errorf("failed to deploy package: %w", err)
+	}
+	return deployedPackage{Name: cfg.Pkg.Metadata.Name, Version: cfg.Pkg.Metadata.Version}, nil
+}
+
+func (o packagerOperations) Remove(ctx context.Context, name string) error {
+	if name == "" {
+		return errors.New("package name is required")
+	}
+	removeOpt := packager2.RemoveOptions{
+		Source:  name,
+		Cluster: o.cluster,
+		Filter: filters.Combine(
+			filters.ByLocalOS(runtime.GOOS),
+			filters.BySelectState(""),
+		),
+		// The package is removed using the definition stored in the cluster.
+		SkipSignatureValidation: true,
+	}
+	return packager2.Remove(ctx, removeOpt)
+}
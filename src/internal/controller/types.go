@@ -0,0 +1,78 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package controller
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const (
+	// Finalizer is added to package deployments so that the package is removed before the resource is deleted.
+	Finalizer = "zarf.dev/package-deployment"
+
+	// ConditionReady is true when the package referenced by the spec is deployed.
+	ConditionReady = "Ready"
+	// ConditionProgressing is true while the package is being deployed or removed.
+	ConditionProgressing = "Progressing"
+
+	// ReasonDeploying is set while the package is being deployed.
+	ReasonDeploying = "Deploying"
+	// ReasonDeployed is set once the package has been deployed.
+	ReasonDeployed = "Deployed"
+	// ReasonDeployFailed is set when the package could not be deployed.
+	ReasonDeployFailed = "DeployFailed"
+	// ReasonRemoving is set while the package is being removed.
+	ReasonRemoving = "Removing"
+	// ReasonRemoveFailed is set when the package could not be removed.
+	ReasonRemoveFailed = "RemoveFailed"
+)
+
+// PackageDeploymentResource is the resource of the ZarfPackageDeployment custom resource definition.
+var PackageDeploymentResource = schema.GroupVersionResource{
+	Group:    "zarf.dev",
+	Version:  "v1alpha1",
+	Resource: "zarfpackagedeployments",
+}
+
+// PackageDeployment declares a Zarf package that should be deployed to the cluster.
+type PackageDeployment struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   PackageDeploymentSpec   `json:"spec"`
+	Status PackageDeploymentStatus `json:"status,omitempty"`
+}
+
+// PackageDeploymentSpec is the desired state of a package deployment.
+type PackageDeploymentSpec struct {
+	// Source is the OCI reference of the package, e.g. oci://ghcr.io/zarf-dev/packages/dos-games:1.1.0.
+	Source string `json:"source"`
+	// Components is a comma separated list of optional components to deploy.
+	Components string `json:"components,omitempty"`
+	// Variables are the package variables to set during deploy.
+	Variables map[string]string `json:"variables,omitempty"`
+	// Shasum is the expected shasum of the package.
+	Shasum string `json:"shasum,omitempty"`
+	// AdoptExistingResources adopts resources that already exist in the cluster.
+	AdoptExistingResources bool `json:"adoptExistingResources,omitempty"`
+	// Timeout is the timeout for Helm operations, e.g. 15m.
+	Timeout string `json:"timeout,omitempty"`
+	// Retries is the number of times to retry a failed deploy.
+	Retries int `json:"retries,omitempty"`
+}
+
+// PackageDeploymentStatus is the observed state of a package deployment.
+type PackageDeploymentStatus struct {
+	// ObservedGeneration is the generation of the spec that was last reconciled.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// PackageName is the name of the deployed package, used to remove it.
+	PackageName string `json:"packageName,omitempty"`
+	// PackageVersion is the version of the deployed package.
+	PackageVersion string `json:"packageVersion,omitempty"`
+	// Source is the source the deployed package was pulled from.
+	Source string `json:"source,omitempty"`
+	// Conditions describe the state of the package deployment.
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
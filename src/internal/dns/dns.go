@@ -40,9 +40,19 @@ func ParseServiceURL(serviceURL string) (string, string, int, error) {
 	if err != nil {
 		return "", "", 0, err
 	}
-	matches := localClusterServiceRegex.FindStringSubmatch(parsedURL.Hostname())
-	if len(matches) != 3 {
+	namespace, name, err := ParseServiceHostname(parsedURL.Hostname())
+	if err != nil {
 		return "", "", 0, fmt.Errorf("invalid service url %s", serviceURL)
 	}
-	return matches[2], matches[1], remotePort, nil
+	return namespace, name, remotePort, nil
+}
+
+// ParseServiceHostname returns the namespace and name of a service from its hostname in the form
+// {SERVICE_NAME}.{NAMESPACE}.svc.cluster.local.
+func ParseServiceHostname(hostname string) (string, string, error) {
+	matches := localClusterServiceRegex.FindStringSubmatch(hostname)
+	if len(matches) != 3 {
+		return "", "", fmt.Errorf("invalid service hostname %s", hostname)
+	}
+	return matches[2], matches[1], nil
 }
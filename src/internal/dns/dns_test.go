@@ -61,3 +61,15 @@ func TestServiceURL(t *testing.T) {
 		})
 	}
 }
+
+func TestParseServiceHostname(t *testing.T) {
+	t.Parallel()
+
+	namespace, name, err := ParseServiceHostname("zarf-gitea-http.zarf.svc.cluster.local")
+	require.NoError(t, err)
+	require.Equal(t, "zarf", namespace)
+	require.Equal(t, "zarf-gitea-http", name)
+
+	_, _, err = ParseServiceHostname("github.com")
+	require.EqualError(t, err, "invalid service hostname github.com")
+}
@@ -0,0 +1,203 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package fips contains the crypto policy that is enforced when Zarf runs in FIPS mode.
+package fips
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net/http"
+	"runtime/debug"
+	"slices"
+	"strings"
+
+	"github.com/zarf-dev/zarf/src/api/v1alpha1"
+)
+
+// tools are the tools embedded in the Zarf binary whose crypto is checked against the policy.
+var tools = map[string]string{
+	"helm":    "helm.sh/helm/v3",
+	"kubectl": "k8s.io/kubectl",
+}
+
+// approvedDigestAlgorithms are the digest algorithms allowed in FIPS mode.
+var approvedDigestAlgorithms = []string{"sha256", "sha384", "sha512"}
+
+// approvedCipherSuites are the TLS 1.2 cipher suites allowed in FIPS mode. TLS 1.3 suites are all approved.
+var approvedCipherSuites = []uint16{
+	tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256,
+	tls.TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384,
+	tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256,
+	tls.TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384,
+}
+
+// approvedCurves are the key exchange curves allowed in FIPS mode.
+var approvedCurves = []tls.CurveID{tls.CurveP256, tls.CurveP384, tls.CurveP521}
+
+// Enabled returns true if FIPS mode is requested at runtime or the binary was built with the fips build tag.
+func Enabled(requested bool) bool {
+	return requested || buildTag
+}
+
+// ConfigureTLS restricts a TLS config to the FIPS approved protocol versions, cipher suites, and curves.
+func ConfigureTLS(cfg *tls.Config) {
+	cfg.MinVersion = tls.VersionTLS12
+	cfg.CipherSuites = slices.Clone(approvedCipherSuites)
+	cfg.CurvePreferences = slices.Clone(approvedCurves)
+}
+
+// ConfigureDefaultTransport applies the TLS policy to the default HTTP transport.
+// Clients that clone the default transport inherit the policy.
+func ConfigureDefaultTransport() error {
+	transport, ok := http.DefaultTransport.(*http.Transport)
+	if !ok {
+		return errors.New("default transport is not an *http.Transport")
+	}
+	if transport.TLSClientConfig == nil {
+		transport.TLSClientConfig = &tls.Config{}
+	}
+	ConfigureTLS(transport.TLSClientConfig)
+	return nil
+}
+
+// ValidateDigest returns an error if the digest does not use an approved algorithm.
+func ValidateDigest(digest string) error {
+	algorithm, _, ok := strings.Cut(digest, ":")
+	if !ok {
+		return fmt.Errorf("digest %q is missing an algorithm", digest)
+	}
+	if !slices.Contains(approvedDigestAlgorithms, algorithm) {
+		return fmt.Errorf("digest algorithm %s is not allowed in FIPS mode, use one of %s", algorithm, strings.Join(approvedDigestAlgorithms, ", "))
+	}
+	return nil
+}
+
+// ValidatePackage returns an error if an image of the package is pinned to a digest that does not use an approved
+// algorithm.
+func ValidatePackage(pkg v1alpha1.ZarfPackage) error {
+	for _, component := range pkg.Components {
+		for _, image := range component.Images {
+			_, digest, ok := strings.Cut(image, "@")
+			if !ok {
+				continue
+			}
+			if err := ValidateDigest(digest); err != nil {
+				return fmt.Errorf("image %s: %w", image, err)
+			}
+		}
+	}
+	return nil
+}
+
+// TLSPolicy describes the enforced TLS policy.
+type TLSPolicy struct {
+	MinVersion   string   `json:"minVersion"`
+	CipherSuites []string `json:"cipherSuites"`
+	Curves       []string `json:"curves"`
+}
+
+// ToolReport describes whether an embedded tool uses the crypto module of the binary. It does not attest the
+// algorithms the tool chooses.
+type ToolReport struct {
+	Name             string `json:"name"`
+	Version          string `json:"version"`
+	UsesCryptoModule bool   `json:"usesCryptoModule"`
+	Reason           string `json:"reason,omitempty"`
+}
+
+// Report describes the FIPS compliance of the running binary. Compliant means FIPS mode is enabled and the binary,
+// including its embedded tools, is built with a validated crypto module.
+type Report struct {
+	Enabled          bool         `json:"enabled"`
+	Compliant        bool         `json:"compliant"`
+	CryptoModule     string       `json:"cryptoModule"`
+	DigestAlgorithms []string     `json:"digestAlgorithms"`
+	TLS              TLSPolicy    `json:"tls"`
+	Tools            []ToolReport `json:"tools"`
+}
+
+// NewReport returns the FIPS compliance report of the running binary.
+func NewReport(requested bool) (Report, error) {
+	buildInfo, ok := debug.ReadBuildInfo()
+	if !ok {
+		return Report{}, errors.New("failed to get build info")
+	}
+	return newReport(Enabled(requested), buildInfo), nil
+}
+
+func newReport(enabled bool, buildInfo *debug.BuildInfo) Report {
+	module := cryptoModule(buildInfo)
+	report := Report{
+		Enabled:          enabled,
+		CryptoModule:     module,
+		DigestAlgorithms: approvedDigestAlgorithms,
+		TLS: TLSPolicy{
+			MinVersion: tls.VersionName(tls.VersionTLS12),
+		},
+		Tools: []ToolReport{},
+	}
+	for _, suite := range approvedCipherSuites {
+		report.TLS.CipherSuites = append(report.TLS.CipherSuites, tls.CipherSuiteName(suite))
+	}
+	for _, curve := range approvedCurves {
+		report.TLS.Curves = append(report.TLS.Curves, curve.String())
+	}
+
+	names := []string{}
+	for name := range tools {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	report.Compliant = enabled && module != ""
+	for _, name := range names {
+		tool := checkTool(name, tools[name], module, buildInfo.Deps)
+		if !tool.UsesCryptoModule {
+			report.Compliant = false
+		}
+		report.Tools = append(report.Tools, tool)
+	}
+	return report
+}
+
+// checkTool verifies that an embedded tool is linked into the binary and therefore uses its crypto module. Whether the
+// tool only picks approved algorithms is not checked, only the digests and TLS connections of Zarf are enforced.
+func checkTool(name, path, module string, deps []*debug.Module) ToolReport {
+	tool := ToolReport{Name: name}
+	idx := slices.IndexFunc(deps, func(dep *debug.Module) bool {
+		return dep.Path == path
+	})
+	if idx == -1 {
+		tool.Reason = fmt.Sprintf("%s is not embedded in the binary", path)
+		return tool
+	}
+	dep := deps[idx]
+	tool.Version = dep.Version
+	if dep.Replace != nil {
+		tool.Version = fmt.Sprintf("%s -> %s %s", dep.Version, dep.Replace.Path, dep.Replace.Version)
+	}
+	if module == "" {
+		tool.Reason = "binary was not built with a FIPS validated crypto module"
+		return tool
+	}
+	tool.UsesCryptoModule = true
+	return tool
+}
+
+// cryptoModule returns the FIPS validated crypto module the binary was built with or an empty string if there is none.
+func cryptoModule(buildInfo *debug.BuildInfo) string {
+	for _, setting := range buildInfo.Settings {
+		switch setting.Key {
+		case "GOEXPERIMENT":
+			if slices.Contains(strings.Split(setting.Value, ","), "boringcrypto") {
+				return "boringcrypto"
+			}
+		case "GOFIPS140":
+			if setting.Value != "" && setting.Value != "off" {
+				return fmt.Sprintf("go-fips140 %s", setting.Value)
+			}
+		}
+	}
+	return ""
+}
@@ -0,0 +1,163 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package fips
+
+import (
+	"crypto/tls"
+	"runtime/debug"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/zarf-dev/zarf/src/api/v1alpha1"
+)
+
+func TestValidateDigest(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		digest      string
+		expectedErr string
+	}{
+		{
+			name:   "sha256",
+			digest: "sha256:3b0e8a9c1f4b6d2e7a5c8f9b0d1e2f3a4b5c6d7e8f9a0b1c2d3e4f5a6b7c8d9e",
+		},
+		{
+			name:   "sha512",
+			digest: "sha512:abc",
+		},
+		{
+			name:        "sha1",
+			digest:      "sha1:abc",
+			expectedErr: "digest algorithm sha1 is not allowed in FIPS mode, use one of sha256, sha384, sha512",
+		},
+		{
+			name:        "md5",
+			digest:      "md5:abc",
+			expectedErr: "digest algorithm md5 is not allowed in FIPS mode, use one of sha256, sha384, sha512",
+		},
+		{
+			name:        "missing algorithm",
+			digest:      "abc",
+			expectedErr: "digest \"abc\" is missing an algorithm",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := ValidateDigest(tt.digest)
+			if tt.expectedErr != "" {
+				require.EqualError(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestValidatePackage(t *testing.T) {
+	t.Parallel()
+
+	pkg := v1alpha1.ZarfPackage{
+		Components: []v1alpha1.ZarfComponent{
+			{
+				Name:   "tagged",
+				Images: []string{"ghcr.io/zarf-dev/doom-game:0.0.1"},
+			},
+			{
+				Name:   "pinned",
+				Images: []string{"ghcr.io/zarf-dev/doom-game:0.0.1@sha256:3b0e8a9c1f4b6d2e7a5c8f9b0d1e2f3a4b5c6d7e8f9a0b1c2d3e4f5a6b7c8d9e"},
+			},
+		},
+	}
+	require.NoError(t, ValidatePackage(pkg))
+
+	pkg.Components = append(pkg.Components, v1alpha1.ZarfComponent{
+		Name:   "sha1",
+		Images: []string{"registry.example.com/app@sha1:abc"},
+	})
+	require.EqualError(t, ValidatePackage(pkg), "image registry.example.com/app@sha1:abc: digest algorithm sha1 is not allowed in FIPS mode, use one of sha256, sha384, sha512")
+}
+
+func TestConfigureTLS(t *testing.T) {
+	t.Parallel()
+
+	cfg := &tls.Config{MinVersion: tls.VersionTLS10}
+	ConfigureTLS(cfg)
+	require.Equal(t, uint16(tls.VersionTLS12), cfg.MinVersion)
+	require.Equal(t, approvedCipherSuites, cfg.CipherSuites)
+	require.Equal(t, approvedCurves, cfg.CurvePreferences)
+}
+
+func TestNewReport(t *testing.T) {
+	t.Parallel()
+
+	deps := []*debug.Module{
+		{Path: "helm.sh/helm/v3", Version: "v3.17.2"},
+		{Path: "k8s.io/kubectl", Version: "v0.32.3"},
+	}
+
+	tests := []struct {
+		name              string
+		enabled           bool
+		settings          []debug.BuildSetting
+		deps              []*debug.Module
+		expectedModule    string
+		expectedCompliant bool
+	}{
+		{
+			name:              "boringcrypto",
+			enabled:           true,
+			settings:          []debug.BuildSetting{{Key: "GOEXPERIMENT", Value: "boringcrypto"}},
+			deps:              deps,
+			expectedModule:    "boringcrypto",
+			expectedCompliant: true,
+		},
+		{
+			name:              "go fips140 module",
+			enabled:           true,
+			settings:          []debug.BuildSetting{{Key: "GOFIPS140", Value: "v1.0.0"}},
+			deps:              deps,
+			expectedModule:    "go-fips140 v1.0.0",
+			expectedCompliant: true,
+		},
+		{
+			name:              "no validated module",
+			enabled:           true,
+			settings:          []debug.BuildSetting{{Key: "GOFIPS140", Value: "off"}},
+			deps:              deps,
+			expectedCompliant: false,
+		},
+		{
+			name:              "not enabled",
+			enabled:           false,
+			settings:          []debug.BuildSetting{{Key: "GOEXPERIMENT", Value: "boringcrypto"}},
+			deps:              deps,
+			expectedModule:    "boringcrypto",
+			expectedCompliant: false,
+		},
+		{
+			name:              "missing tool",
+			enabled:           true,
+			settings:          []debug.BuildSetting{{Key: "GOEXPERIMENT", Value: "boringcrypto"}},
+			deps:              deps[:1],
+			expectedModule:    "boringcrypto",
+			expectedCompliant: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			report := newReport(tt.enabled, &debug.BuildInfo{Settings: tt.settings, Deps: tt.deps})
+			require.Equal(t, tt.enabled, report.Enabled)
+			require.Equal(t, tt.expectedModule, report.CryptoModule)
+			require.Equal(t, tt.expectedCompliant, report.Compliant)
+			require.Len(t, report.Tools, 2)
+		})
+	}
+}
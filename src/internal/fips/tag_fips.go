@@ -0,0 +1,9 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+//go:build fips
+
+package fips
+
+// buildTag is true when the binary is built with the fips build tag which enables FIPS mode by default.
+const buildTag = true
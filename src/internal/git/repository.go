@@ -147,8 +147,8 @@ func (r *Repository) Path() string {
 	return r.path
 }
 
-// Push pushes the repository to the remote git server.
-func (r *Repository) Push(ctx context.Context, address, username, password string) error {
+// Push pushes the repository to the remote git server. The CA bundle is trusted in addition to the system roots when set.
+func (r *Repository) Push(ctx context.Context, address, username, password string, caBundle []byte) error {
 	l := logger.From(ctx)
 	repo, err := git.PlainOpen(r.path)
 	if err != nil {
@@ -191,6 +191,7 @@ func (r *Repository) Push(ctx context.Context, address, username, password strin
 	fetchOptions := &git.FetchOptions{
 		RemoteName: offlineRemoteName,
 		Auth:       &gitCred,
+		CABundle:   caBundle,
 		RefSpecs: []config.RefSpec{
 			"refs/heads/*:refs/heads/*",
 			"refs/tags/*:refs/tags/*",
@@ -214,6 +215,7 @@ func (r *Repository) Push(ctx context.Context, address, username, password strin
 	err = repo.PushContext(ctx, &git.PushOptions{
 		RemoteName: offlineRemoteName,
 		Auth:       &gitCred,
+		CABundle:   caBundle,
 		// TODO: (@JEFFMCCOY) add the parsing for the `+` force prefix (see https://github.com/zarf-dev/zarf/issues/1410)
 		//Force: isForce,
 		// If a provided refspec doesn't push anything, it is just ignored
@@ -233,6 +235,64 @@ func (r *Repository) Push(ctx context.Context, address, username, password strin
 
 	return nil
 }
+
+// PushToDirectory copies the branches and tags of the repository into a bare repository in the directory, named the
+// same as the repository on the git server, so that it can be pushed to the server from another host with git. It
+// returns the path of the bare repository.
+func (r *Repository) PushToDirectory(ctx context.Context, dir, address string) (string, error) {
+	l := logger.From(ctx)
+	repoName, err := transform.GitURLtoRepoName(address)
+	if err != nil {
+		return "", err
+	}
+	dst := filepath.Join(dir, repoName+".git")
+	src, err := git.PlainOpen(r.path)
+	if err != nil {
+		return "", fmt.Errorf("not a valid git repo or unable to open: %w", err)
+	}
+	bare, err := git.PlainInit(dst, true)
+	if errors.Is(err, git.ErrRepositoryAlreadyExists) {
+		bare, err = git.PlainOpen(dst)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	// Objects are copied through the storage as pushing to a local path requires the git binaries.
+	objects, err := src.Storer.IterEncodedObjects(plumbing.AnyObject)
+	if err != nil {
+		return "", err
+	}
+	err = objects.ForEach(func(obj plumbing.EncodedObject) error {
+		_, err := bare.Storer.SetEncodedObject(obj)
+		return err
+	})
+	if err != nil {
+		return "", fmt.Errorf("unable to copy the objects of the repository: %w", err)
+	}
+	refs, err := src.References()
+	if err != nil {
+		return "", err
+	}
+	err = refs.ForEach(func(ref *plumbing.Reference) error {
+		if ref.Type() != plumbing.HashReference || (!ref.Name().IsBranch() && !ref.Name().IsTag()) {
+			return nil
+		}
+		return bare.Storer.SetReference(ref)
+	})
+	if err != nil {
+		return "", fmt.Errorf("unable to copy the references of the repository: %w", err)
+	}
+	head, err := src.Storer.Reference(plumbing.HEAD)
+	if err == nil && head.Type() == plumbing.SymbolicReference {
+		if err := bare.Storer.SetReference(head); err != nil {
+			return "", err
+		}
+	}
+	l.Debug("copied repository to directory", "repo", address, "path", dst)
+	return dst, nil
+}
+
 func (r *Repository) checkoutRefAsBranch(ref string, branch plumbing.ReferenceName) error {
 	repo, err := git.PlainOpen(r.path)
 	if err != nil {
@@ -95,3 +95,58 @@ func TestRepository(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, filepath.Join(rootPath, expectedPath), repo.Path())
 }
+
+func TestPushToDirectory(t *testing.T) {
+	t.Parallel()
+	ctx := testutil.TestContext(t)
+
+	rootPath := t.TempDir()
+	repoAddress := "https://github.com/zarf-dev/zarf.git"
+	repo, err := Open(rootPath, repoAddress)
+	require.NoError(t, err)
+
+	src, err := git.PlainInitWithOptions(repo.Path(), &git.PlainInitOptions{
+		InitOptions: git.InitOptions{DefaultBranch: plumbing.Main},
+	})
+	require.NoError(t, err)
+	err = os.WriteFile(filepath.Join(repo.Path(), "test.txt"), []byte("Hello World"), 0o600)
+	require.NoError(t, err)
+	w, err := src.Worktree()
+	require.NoError(t, err)
+	_, err = w.Add("test.txt")
+	require.NoError(t, err)
+	hash, err := w.Commit("Initial commit", &git.CommitOptions{
+		Author: &object.Signature{Email: "example@example.com"},
+	})
+	require.NoError(t, err)
+	_, err = src.CreateTag("v1.0.0", hash, nil)
+	require.NoError(t, err)
+	err = src.Storer.SetReference(plumbing.NewHashReference("refs/remotes/origin/main", hash))
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	expectedPath := filepath.Join(dir, fmt.Sprintf("zarf-%d.git", helpers.GetCRCHash("github.com/zarf-dev/zarf")))
+	// Pushing twice updates the existing bare repository.
+	for range 2 {
+		path, err := repo.PushToDirectory(ctx, dir, repoAddress)
+		require.NoError(t, err)
+		require.Equal(t, expectedPath, path)
+	}
+
+	bare, err := git.PlainOpen(expectedPath)
+	require.NoError(t, err)
+	ref, err := bare.Reference(plumbing.NewBranchReferenceName("main"), true)
+	require.NoError(t, err)
+	require.Equal(t, hash, ref.Hash())
+	ref, err = bare.Tag("v1.0.0")
+	require.NoError(t, err)
+	require.Equal(t, hash, ref.Hash())
+	head, err := bare.Head()
+	require.NoError(t, err)
+	require.Equal(t, plumbing.NewBranchReferenceName("main"), head.Name())
+	commit, err := bare.CommitObject(hash)
+	require.NoError(t, err)
+	require.Equal(t, "Initial commit", commit.Message)
+	_, err = bare.Reference("refs/remotes/origin/main", false)
+	require.ErrorIs(t, err, plumbing.ErrReferenceNotFound)
+}
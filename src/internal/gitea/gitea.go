@@ -7,6 +7,8 @@ package gitea
 import (
 	"bytes"
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -26,8 +28,8 @@ type Client struct {
 	password   string
 }
 
-// NewClient creates and returns a new Gitea client.
-func NewClient(endpoint, username, password string) (*Client, error) {
+// NewClient creates and returns a new Gitea client. The CA bundle is trusted in addition to the system roots when set.
+func NewClient(endpoint, username, password string, caBundle []byte) (*Client, error) {
 	u, err := url.Parse(endpoint)
 	if err != nil {
 		return nil, err
@@ -38,6 +40,16 @@ func NewClient(endpoint, username, password string) (*Client, error) {
 	}
 	transport = transport.Clone()
 	transport.MaxIdleConnsPerHost = transport.MaxIdleConns
+	if len(caBundle) > 0 {
+		rootCAs, err := x509.SystemCertPool()
+		if err != nil {
+			rootCAs = x509.NewCertPool()
+		}
+		if !rootCAs.AppendCertsFromPEM(caBundle) {
+			return nil, errors.New("CA bundle does not contain any PEM encoded certificates")
+		}
+		transport.TLSClientConfig = &tls.Config{RootCAs: rootCAs, MinVersion: tls.VersionTLS12}
+	}
 	httpClient := &http.Client{
 		Timeout:   10 * time.Second,
 		Transport: transport,
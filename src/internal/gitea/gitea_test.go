@@ -4,17 +4,35 @@
 package gitea
 
 import (
+	"net/http"
 	"testing"
 
 	"github.com/stretchr/testify/require"
+
+	"github.com/zarf-dev/zarf/src/pkg/pki"
 )
 
 func TestNewClient(t *testing.T) {
 	t.Parallel()
 
-	c, err := NewClient("https://example.com", "foo", "bar")
+	c, err := NewClient("https://example.com", "foo", "bar", nil)
 	require.NoError(t, err)
 	require.Equal(t, "https", c.endpoint.Scheme)
 	require.Equal(t, "foo", c.username)
 	require.Equal(t, "bar", c.password)
 }
+
+func TestNewClientCABundle(t *testing.T) {
+	t.Parallel()
+
+	generated, err := pki.GeneratePKI("zarf-gitea-http.zarf.svc.cluster.local")
+	require.NoError(t, err)
+	c, err := NewClient("https://127.0.0.1:3000", "foo", "bar", generated.CA)
+	require.NoError(t, err)
+	transport, ok := c.httpClient.Transport.(*http.Transport)
+	require.True(t, ok)
+	require.NotNil(t, transport.TLSClientConfig.RootCAs)
+
+	_, err = NewClient("https://127.0.0.1:3000", "foo", "bar", []byte("not a certificate"))
+	require.EqualError(t, err, "CA bundle does not contain any PEM encoded certificates")
+}
@@ -0,0 +1,188 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package metrics records anonymized operation metrics to a local file.
+package metrics
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/defenseunicorns/pkg/helpers/v2"
+
+	"github.com/zarf-dev/zarf/src/api/v1alpha1"
+	"github.com/zarf-dev/zarf/src/config"
+	"github.com/zarf-dev/zarf/src/pkg/logger"
+)
+
+// FileName is the name of the metrics file in the Zarf cache.
+const FileName = "metrics.jsonl"
+
+// Operation is a recorded Zarf operation.
+type Operation string
+
+const (
+	// OperationCreate is a package create.
+	OperationCreate Operation = "create"
+	// OperationDeploy is a package deploy.
+	OperationDeploy Operation = "deploy"
+)
+
+// Metric is a single recorded operation. It does not contain names, paths, or hosts so that it can be shared without review.
+type Metric struct {
+	Timestamp    time.Time `json:"timestamp"`
+	Operation    Operation `json:"operation"`
+	PackageID    string    `json:"packageID"`
+	Architecture string    `json:"architecture"`
+	ZarfVersion  string    `json:"zarfVersion"`
+	Components   int       `json:"components"`
+	Images       int       `json:"images"`
+	SizeBytes    int64     `json:"sizeBytes"`
+	DurationMS   int64     `json:"durationMs"`
+	Success      bool      `json:"success"`
+}
+
+// NewMetric returns a metric for an operation on pkg that started at start and finished with err.
+func NewMetric(op Operation, pkg v1alpha1.ZarfPackage, sizeBytes int64, start time.Time, err error) Metric {
+	images := 0
+	for _, component := range pkg.Components {
+		images += len(component.Images)
+	}
+	return Metric{
+		Timestamp:    start.UTC(),
+		Operation:    op,
+		PackageID:    packageID(pkg),
+		Architecture: pkg.Metadata.Architecture,
+		ZarfVersion:  config.CLIVersion,
+		Components:   len(pkg.Components),
+		Images:       images,
+		SizeBytes:    sizeBytes,
+		DurationMS:   time.Since(start).Milliseconds(),
+		Success:      err == nil,
+	}
+}
+
+// packageID returns an anonymized identifier that is stable across versions of the same package.
+func packageID(pkg v1alpha1.ZarfPackage) string {
+	if pkg.Metadata.Name == "" {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(pkg.Metadata.Name))
+	return hex.EncodeToString(sum[:])[:12]
+}
+
+// Recorder appends metrics to a local JSONL file.
+type Recorder struct {
+	path string
+	mu   sync.Mutex
+}
+
+// NewRecorder returns a recorder that appends to the file at path.
+func NewRecorder(path string) *Recorder {
+	return &Recorder{path: path}
+}
+
+// DefaultPath returns the path of the metrics file in the Zarf cache.
+func DefaultPath() (string, error) {
+	cachePath, err := config.GetAbsCachePath()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(cachePath, FileName), nil
+}
+
+// Record appends the metric to the metrics file. Failures are logged and never fail the operation being recorded.
+// Recording with a nil recorder is a no-op.
+func (r *Recorder) Record(ctx context.Context, m Metric) {
+	if r == nil {
+		return
+	}
+	err := r.write(m)
+	if err != nil {
+		logger.From(ctx).Warn("unable to record metrics", "path", r.path, "error", err)
+	}
+}
+
+func (r *Recorder) write(m Metric) (err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	b, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+	err = helpers.CreateParentDirectory(r.path)
+	if err != nil {
+		return err
+	}
+	f, err := os.OpenFile(r.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, helpers.ReadWriteUser)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = errors.Join(err, f.Close())
+	}()
+	_, err = f.Write(append(b, '\n'))
+	return err
+}
+
+// Read returns all metrics in the file at path.
+func Read(path string) (_ []Metric, err error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		err = errors.Join(err, f.Close())
+	}()
+	metrics := []Metric{}
+	scanner := bufio.NewScanner(f)
+	line := 0
+	for scanner.Scan() {
+		line++
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var m Metric
+		err := json.Unmarshal(scanner.Bytes(), &m)
+		if err != nil {
+			return nil, fmt.Errorf("invalid metric on line %d of %s: %w", line, path, err)
+		}
+		metrics = append(metrics, m)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return metrics, nil
+}
+
+// ctxKey provides a location to store a recorder in a context.
+type ctxKey struct{}
+
+var defaultCtxKey = ctxKey{}
+
+// WithContext takes a context.Context and a *Recorder, storing it on the key.
+func WithContext(ctx context.Context, r *Recorder) context.Context {
+	return context.WithValue(ctx, defaultCtxKey, r)
+}
+
+// From returns the recorder in the context or nil if metrics are not enabled.
+func From(ctx context.Context) *Recorder {
+	if ctx == nil {
+		return nil
+	}
+	r, ok := ctx.Value(defaultCtxKey).(*Recorder)
+	if !ok {
+		return nil
+	}
+	return r
+}
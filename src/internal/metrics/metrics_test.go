@@ -0,0 +1,92 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package metrics
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/zarf-dev/zarf/src/api/v1alpha1"
+)
+
+func TestRecorder(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	require.Nil(t, From(ctx))
+	// Recording without a recorder is a no-op.
+	From(ctx).Record(ctx, Metric{})
+
+	path := filepath.Join(t.TempDir(), "nested", FileName)
+	ctx = WithContext(ctx, NewRecorder(path))
+	pkg := v1alpha1.ZarfPackage{
+		Metadata: v1alpha1.ZarfMetadata{
+			Name:         "secret-project",
+			Architecture: "amd64",
+		},
+		Components: []v1alpha1.ZarfComponent{
+			{Name: "a", Images: []string{"nginx:1.0", "redis:1.0"}},
+			{Name: "b", Images: []string{"busybox:1.0"}},
+		},
+	}
+	From(ctx).Record(ctx, NewMetric(OperationCreate, pkg, 1024, time.Now(), nil))
+	From(ctx).Record(ctx, NewMetric(OperationDeploy, pkg, 2048, time.Now(), errors.New("failed")))
+
+	recorded, err := Read(path)
+	require.NoError(t, err)
+	require.Len(t, recorded, 2)
+	require.Equal(t, OperationCreate, recorded[0].Operation)
+	require.Equal(t, 2, recorded[0].Components)
+	require.Equal(t, 3, recorded[0].Images)
+	require.Equal(t, int64(1024), recorded[0].SizeBytes)
+	require.True(t, recorded[0].Success)
+	require.NotContains(t, recorded[0].PackageID, "secret-project")
+	require.Equal(t, recorded[0].PackageID, recorded[1].PackageID)
+	require.False(t, recorded[1].Success)
+}
+
+func TestSummarize(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	recorded := []Metric{
+		{Timestamp: now, Operation: OperationDeploy, SizeBytes: 1000, DurationMS: 1000, Success: true},
+		{Timestamp: now, Operation: OperationDeploy, SizeBytes: 3000, DurationMS: 3000, Success: true},
+		{Timestamp: now, Operation: OperationDeploy, SizeBytes: 2000, DurationMS: 500, Success: false},
+		{Timestamp: now, Operation: OperationCreate, SizeBytes: 4000, DurationMS: 2000, Success: true},
+		{Timestamp: now.Add(-48 * time.Hour), Operation: OperationCreate, SizeBytes: 100, DurationMS: 100000, Success: true},
+	}
+
+	summaries := Summarize(recorded, now.Add(-24*time.Hour))
+	expected := []Summary{
+		{
+			Operation:   OperationCreate,
+			Count:       1,
+			P50Duration: 2 * time.Second,
+			P95Duration: 2 * time.Second,
+			MaxDuration: 2 * time.Second,
+			AvgSize:     4000,
+			Throughput:  2000,
+		},
+		{
+			Operation:   OperationDeploy,
+			Count:       3,
+			Failures:    1,
+			P50Duration: time.Second,
+			P95Duration: 3 * time.Second,
+			MaxDuration: 3 * time.Second,
+			AvgSize:     2000,
+			Throughput:  1000,
+		},
+	}
+	require.Equal(t, expected, summaries)
+
+	summaries = Summarize(recorded, time.Time{})
+	require.Equal(t, 2, summaries[0].Count)
+}
@@ -0,0 +1,78 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package metrics
+
+import (
+	"cmp"
+	"slices"
+	"time"
+)
+
+// Summary aggregates the recorded metrics of a single operation.
+type Summary struct {
+	Operation   Operation     `json:"operation"`
+	Count       int           `json:"count"`
+	Failures    int           `json:"failures"`
+	P50Duration time.Duration `json:"p50Duration"`
+	P95Duration time.Duration `json:"p95Duration"`
+	MaxDuration time.Duration `json:"maxDuration"`
+	AvgSize     int64         `json:"avgSizeBytes"`
+	// Throughput is the average number of bytes processed per second by successful operations.
+	Throughput int64 `json:"throughputBytesPerSecond"`
+}
+
+// Summarize aggregates metrics per operation, optionally limited to metrics recorded after since.
+func Summarize(metrics []Metric, since time.Time) []Summary {
+	grouped := map[Operation][]Metric{}
+	for _, m := range metrics {
+		if m.Timestamp.Before(since) {
+			continue
+		}
+		grouped[m.Operation] = append(grouped[m.Operation], m)
+	}
+
+	summaries := []Summary{}
+	for op, ms := range grouped {
+		summary := Summary{
+			Operation: op,
+			Count:     len(ms),
+		}
+		durations := []time.Duration{}
+		var totalSize, successSize, successMS int64
+		for _, m := range ms {
+			totalSize += m.SizeBytes
+			if !m.Success {
+				summary.Failures++
+				continue
+			}
+			durations = append(durations, time.Duration(m.DurationMS)*time.Millisecond)
+			successSize += m.SizeBytes
+			successMS += m.DurationMS
+		}
+		summary.AvgSize = totalSize / int64(len(ms))
+		if successMS > 0 {
+			summary.Throughput = successSize * 1000 / successMS
+		}
+		if len(durations) > 0 {
+			slices.Sort(durations)
+			summary.P50Duration = percentile(durations, 50)
+			summary.P95Duration = percentile(durations, 95)
+			summary.MaxDuration = durations[len(durations)-1]
+		}
+		summaries = append(summaries, summary)
+	}
+	slices.SortFunc(summaries, func(a, b Summary) int {
+		return cmp.Compare(a.Operation, b.Operation)
+	})
+	return summaries
+}
+
+// percentile returns the nearest-rank percentile of sorted durations.
+func percentile(sorted []time.Duration, p int) time.Duration {
+	rank := (p*len(sorted) + 99) / 100
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
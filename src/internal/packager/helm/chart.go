@@ -18,6 +18,7 @@ import (
 	plutoversionsfile "github.com/fairwindsops/pluto/v5"
 	plutoapi "github.com/fairwindsops/pluto/v5/pkg/api"
 	goyaml "github.com/goccy/go-yaml"
+	"go.opentelemetry.io/otel/attribute"
 	"helm.sh/helm/v3/pkg/action"
 	"helm.sh/helm/v3/pkg/chart"
 	"helm.sh/helm/v3/pkg/chartutil"
@@ -30,6 +31,7 @@ import (
 
 	"github.com/zarf-dev/zarf/src/config"
 	"github.com/zarf-dev/zarf/src/internal/healthchecks"
+	"github.com/zarf-dev/zarf/src/internal/tracing"
 	"github.com/zarf-dev/zarf/src/pkg/message"
 	"github.com/zarf-dev/zarf/src/types"
 )
@@ -38,7 +40,14 @@ import (
 const maxHelmHistory = 10
 
 // InstallOrUpgradeChart performs a helm install of the given chart.
-func (h *Helm) InstallOrUpgradeChart(ctx context.Context) (types.ConnectStrings, string, error) {
+func (h *Helm) InstallOrUpgradeChart(ctx context.Context) (_ types.ConnectStrings, _ string, err error) {
+	ctx, span := tracing.Start(ctx, "install chart",
+		attribute.String("chart", h.chart.Name),
+		attribute.String("version", h.chart.Version),
+		attribute.String("namespace", h.chart.Namespace))
+	defer func() {
+		tracing.End(span, err)
+	}()
 	l := logger.From(ctx)
 	start := time.Now()
 	source := h.chart.URL
@@ -58,7 +67,7 @@ func (h *Helm) InstallOrUpgradeChart(ctx context.Context) (types.ConnectStrings,
 	}
 
 	// Setup K8s connection.
-	err := h.createActionConfig(ctx, h.chart.Namespace, spinner)
+	err = h.createActionConfig(ctx, h.chart.Namespace, spinner)
 	if err != nil {
 		return nil, "", fmt.Errorf("unable to initialize the K8s client: %w", err)
 	}
@@ -106,7 +115,9 @@ func (h *Helm) InstallOrUpgradeChart(ctx context.Context) (types.ConnectStrings,
 
 		spinner.Success()
 		return nil
-	}, retry.Context(ctx), retry.Attempts(uint(h.retries)), retry.Delay(500*time.Millisecond))
+	}, retry.Context(ctx), retry.Attempts(uint(h.retries)), retry.Delay(500*time.Millisecond),
+		// Every further attempt fails straight away once the helm timeout is reached.
+		retry.RetryIf(func(error) bool { return helmCtx.Err() == nil }))
 	if err != nil {
 		removeMsg := "if you need to remove the failed chart, use `zarf package remove`"
 		installErr := fmt.Errorf("unable to install chart after %d attempts: %w: %s", h.retries, err, removeMsg)
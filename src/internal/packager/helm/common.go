@@ -89,10 +89,12 @@ func NewFromZarfManifest(manifest v1alpha1.ZarfManifest, manifestPath, packageNa
 
 	// Generate a hashed chart name.
 	rawChartName := fmt.Sprintf("raw-%s-%s-%s", packageName, componentName, manifest.Name)
+	// The hash only derives a stable release name and is not used for security, it stays SHA-1 in FIPS mode so that the
+	// same package always maps to the same releases.
 	hasher := sha1.New()
 	hasher.Write([]byte(rawChartName))
 	tmpChart.Metadata.Name = rawChartName
-	sha1ReleaseName := hex.EncodeToString(hasher.Sum(nil))
+	hashedReleaseName := hex.EncodeToString(hasher.Sum(nil))
 
 	// This is fun, increment forward in a semver-way using epoch so helm doesn't cry.
 	tmpChart.Metadata.Version = fmt.Sprintf("0.1.%d", config.GetStartTime())
@@ -119,7 +121,7 @@ func NewFromZarfManifest(manifest v1alpha1.ZarfManifest, manifestPath, packageNa
 		chart: v1alpha1.ZarfChart{
 			Name: tmpChart.Metadata.Name,
 			// Preserve the zarf prefix for chart names to match v0.22.x and earlier behavior.
-			ReleaseName: fmt.Sprintf("zarf-%s", sha1ReleaseName),
+			ReleaseName: fmt.Sprintf("zarf-%s", hashedReleaseName),
 			Version:     tmpChart.Metadata.Version,
 			Namespace:   manifest.Namespace,
 			NoWait:      manifest.NoWait,
@@ -164,6 +166,13 @@ func WithVariableConfig(variableConfig *variables.VariableConfig) Modifier {
 	}
 }
 
+// WithValuesOverrides sets the values that are merged over the values files of the chart
+func WithValuesOverrides(valuesOverrides map[string]any) Modifier {
+	return func(h *Helm) {
+		h.valuesOverrides = valuesOverrides
+	}
+}
+
 // StandardName generates a predictable full path for a helm chart for Zarf.
 func StandardName(destination string, chart v1alpha1.ZarfChart) string {
 	return filepath.Join(destination, chart.Name+"-"+chart.Version)
@@ -0,0 +1,232 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package helm contains operations for working with helm charts.
+package helm
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/yaml"
+
+	"github.com/zarf-dev/zarf/src/types"
+)
+
+// podSpecPaths are the paths of the pod specs of the workloads that are customized on deploy by kind.
+var podSpecPaths = map[string][]string{
+	"Pod":                   {"spec"},
+	"Deployment":            {"spec", "template", "spec"},
+	"StatefulSet":           {"spec", "template", "spec"},
+	"DaemonSet":             {"spec", "template", "spec"},
+	"ReplicaSet":            {"spec", "template", "spec"},
+	"ReplicationController": {"spec", "template", "spec"},
+	"Job":                   {"spec", "template", "spec"},
+	"CronJob":               {"spec", "jobTemplate", "spec", "template", "spec"},
+}
+
+// customizations are the labels, annotations, scheduling constraints and host aliases added to every resource Zarf
+// applies.
+type customizations struct {
+	labels       map[string]string
+	annotations  map[string]string
+	nodeSelector map[string]string
+	tolerations  []corev1.Toleration
+	hostAliases  []corev1.HostAlias
+}
+
+// newCustomizations returns the customizations of the deploy options.
+func newCustomizations(opts types.ZarfDeployOptions) (customizations, error) {
+	tolerations, err := ParseTolerations(opts.Tolerations)
+	if err != nil {
+		return customizations{}, err
+	}
+	return customizations{
+		labels:       opts.Labels,
+		annotations:  opts.Annotations,
+		nodeSelector: opts.NodeSelector,
+		tolerations:  tolerations,
+	}, nil
+}
+
+func (c customizations) empty() bool {
+	return len(c.labels) == 0 && len(c.annotations) == 0 && len(c.nodeSelector) == 0 && len(c.tolerations) == 0 && len(c.hostAliases) == 0
+}
+
+// ParseTolerations parses tolerations in the form key[=value][:effect]. A toleration without a value tolerates any
+// value of the taint and a toleration without an effect tolerates every effect.
+func ParseTolerations(tolerations []string) ([]corev1.Toleration, error) {
+	parsed := []corev1.Toleration{}
+	for _, s := range tolerations {
+		rest, effect, _ := strings.Cut(s, ":")
+		key, value, hasValue := strings.Cut(rest, "=")
+		if key == "" {
+			return nil, fmt.Errorf("invalid toleration %q: the key is required", s)
+		}
+		toleration := corev1.Toleration{
+			Key:      key,
+			Operator: corev1.TolerationOpExists,
+			Effect:   corev1.TaintEffect(effect),
+		}
+		if hasValue {
+			toleration.Operator = corev1.TolerationOpEqual
+			toleration.Value = value
+		}
+		switch toleration.Effect {
+		case "", corev1.TaintEffectNoSchedule, corev1.TaintEffectPreferNoSchedule, corev1.TaintEffectNoExecute:
+		default:
+			return nil, fmt.Errorf("invalid toleration %q: the effect must be NoSchedule, PreferNoSchedule or NoExecute", s)
+		}
+		parsed = append(parsed, toleration)
+	}
+	return parsed, nil
+}
+
+// apply adds the labels and annotations to the resource and the pod template of workloads, and the node selectors,
+// tolerations and host aliases to the pod spec of workloads. Values set by the deploy options take precedence over the
+// chart, except for hostnames the chart already aliases.
+func (c customizations) apply(content string) (string, error) {
+	if c.empty() {
+		return content, nil
+	}
+	obj := &unstructured.Unstructured{}
+	if err := yaml.Unmarshal([]byte(content), obj); err != nil {
+		return "", fmt.Errorf("failed to unmarshal manifest: %w", err)
+	}
+	if obj.GetKind() == "" {
+		return content, nil
+	}
+
+	obj.SetLabels(mergeStringMaps(obj.GetLabels(), c.labels))
+	obj.SetAnnotations(mergeStringMaps(obj.GetAnnotations(), c.annotations))
+
+	if specPath, ok := podSpecPaths[obj.GetKind()]; ok {
+		if len(specPath) > 1 {
+			templatePath := append(slices.Clone(specPath[:len(specPath)-1]), "metadata")
+			if err := mergeNestedStringMap(obj.Object, c.labels, append(slices.Clone(templatePath), "labels")...); err != nil {
+				return "", err
+			}
+			if err := mergeNestedStringMap(obj.Object, c.annotations, append(slices.Clone(templatePath), "annotations")...); err != nil {
+				return "", err
+			}
+		}
+		if err := mergeNestedStringMap(obj.Object, c.nodeSelector, append(slices.Clone(specPath), "nodeSelector")...); err != nil {
+			return "", err
+		}
+		if err := addTolerations(obj.Object, c.tolerations, append(slices.Clone(specPath), "tolerations")...); err != nil {
+			return "", err
+		}
+		if err := addHostAliases(obj.Object, c.hostAliases, append(slices.Clone(specPath), "hostAliases")...); err != nil {
+			return "", err
+		}
+	}
+
+	b, err := yaml.Marshal(obj.Object)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+func mergeStringMaps(existing, values map[string]string) map[string]string {
+	if len(values) == 0 {
+		return existing
+	}
+	if existing == nil {
+		existing = map[string]string{}
+	}
+	maps.Copy(existing, values)
+	return existing
+}
+
+func mergeNestedStringMap(obj map[string]interface{}, values map[string]string, fields ...string) error {
+	if len(values) == 0 {
+		return nil
+	}
+	existing, _, err := unstructured.NestedStringMap(obj, fields...)
+	if err != nil {
+		return fmt.Errorf("invalid %s: %w", strings.Join(fields, "."), err)
+	}
+	return unstructured.SetNestedStringMap(obj, mergeStringMaps(existing, values), fields...)
+}
+
+// addTolerations adds the tolerations to the pod spec that it does not already have.
+func addTolerations(obj map[string]interface{}, tolerations []corev1.Toleration, fields ...string) error {
+	if len(tolerations) == 0 {
+		return nil
+	}
+	existing, _, err := unstructured.NestedSlice(obj, fields...)
+	if err != nil {
+		return fmt.Errorf("invalid %s: %w", strings.Join(fields, "."), err)
+	}
+	current := []corev1.Toleration{}
+	for _, item := range existing {
+		m, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		var toleration corev1.Toleration
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(m, &toleration); err != nil {
+			return fmt.Errorf("invalid toleration: %w", err)
+		}
+		current = append(current, toleration)
+	}
+	for _, toleration := range tolerations {
+		if slices.ContainsFunc(current, func(t corev1.Toleration) bool { return t.MatchToleration(&toleration) }) {
+			continue
+		}
+		m, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&toleration)
+		if err != nil {
+			return err
+		}
+		existing = append(existing, m)
+	}
+	return unstructured.SetNestedSlice(obj, existing, fields...)
+}
+
+// addHostAliases adds the hostnames of the host aliases to the pod spec that it does not already alias.
+func addHostAliases(obj map[string]interface{}, hostAliases []corev1.HostAlias, fields ...string) error {
+	if len(hostAliases) == 0 {
+		return nil
+	}
+	existing, _, err := unstructured.NestedSlice(obj, fields...)
+	if err != nil {
+		return fmt.Errorf("invalid %s: %w", strings.Join(fields, "."), err)
+	}
+	aliased := map[string]bool{}
+	for _, item := range existing {
+		m, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		var hostAlias corev1.HostAlias
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(m, &hostAlias); err != nil {
+			return fmt.Errorf("invalid host alias: %w", err)
+		}
+		for _, hostname := range hostAlias.Hostnames {
+			aliased[hostname] = true
+		}
+	}
+	for _, hostAlias := range hostAliases {
+		hostnames := []string{}
+		for _, hostname := range hostAlias.Hostnames {
+			if !aliased[hostname] {
+				hostnames = append(hostnames, hostname)
+			}
+		}
+		if len(hostnames) == 0 {
+			continue
+		}
+		m, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&corev1.HostAlias{IP: hostAlias.IP, Hostnames: hostnames})
+		if err != nil {
+			return err
+		}
+		existing = append(existing, m)
+	}
+	return unstructured.SetNestedSlice(obj, existing, fields...)
+}
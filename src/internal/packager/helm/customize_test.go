@@ -0,0 +1,247 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package helm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/zarf-dev/zarf/src/types"
+)
+
+func TestParseTolerations(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		tolerations []string
+		expected    []corev1.Toleration
+		expectedErr string
+	}{
+		{
+			name:        "key value and effect",
+			tolerations: []string{"edge=true:NoSchedule"},
+			expected:    []corev1.Toleration{{Key: "edge", Operator: corev1.TolerationOpEqual, Value: "true", Effect: corev1.TaintEffectNoSchedule}},
+		},
+		{
+			name:        "key and effect",
+			tolerations: []string{"edge:NoExecute"},
+			expected:    []corev1.Toleration{{Key: "edge", Operator: corev1.TolerationOpExists, Effect: corev1.TaintEffectNoExecute}},
+		},
+		{
+			name:        "key only",
+			tolerations: []string{"edge"},
+			expected:    []corev1.Toleration{{Key: "edge", Operator: corev1.TolerationOpExists}},
+		},
+		{
+			name:        "missing key",
+			tolerations: []string{"=true:NoSchedule"},
+			expectedErr: "the key is required",
+		},
+		{
+			name:        "invalid effect",
+			tolerations: []string{"edge=true:Never"},
+			expectedErr: "the effect must be NoSchedule, PreferNoSchedule or NoExecute",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			tolerations, err := ParseTolerations(tt.tolerations)
+			if tt.expectedErr != "" {
+				require.ErrorContains(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, tolerations)
+		})
+	}
+}
+
+func TestCustomizationsApply(t *testing.T) {
+	t.Parallel()
+
+	c, err := newCustomizations(types.ZarfDeployOptions{
+		Labels:       map[string]string{"cost-center": "edge"},
+		Annotations:  map[string]string{"owner": "ops"},
+		NodeSelector: map[string]string{"node-role": "edge"},
+		Tolerations:  []string{"edge=true:NoSchedule"},
+	})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		content  string
+		expected string
+	}{
+		{
+			name: "deployment",
+			content: `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app
+  labels:
+    app: app
+spec:
+  template:
+    metadata:
+      labels:
+        app: app
+    spec:
+      tolerations:
+        - key: edge
+          operator: Equal
+          value: "true"
+          effect: NoSchedule
+      containers:
+        - name: app
+          image: app:1.0.0
+`,
+			expected: `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  annotations:
+    owner: ops
+  labels:
+    app: app
+    cost-center: edge
+  name: app
+spec:
+  template:
+    metadata:
+      annotations:
+        owner: ops
+      labels:
+        app: app
+        cost-center: edge
+    spec:
+      containers:
+      - image: app:1.0.0
+        name: app
+      nodeSelector:
+        node-role: edge
+      tolerations:
+      - effect: NoSchedule
+        key: edge
+        operator: Equal
+        value: "true"
+`,
+		},
+		{
+			name: "cronjob",
+			content: `apiVersion: batch/v1
+kind: CronJob
+metadata:
+  name: job
+spec:
+  jobTemplate:
+    spec:
+      template:
+        spec:
+          containers: []
+`,
+			expected: `apiVersion: batch/v1
+kind: CronJob
+metadata:
+  annotations:
+    owner: ops
+  labels:
+    cost-center: edge
+  name: job
+spec:
+  jobTemplate:
+    spec:
+      template:
+        metadata:
+          annotations:
+            owner: ops
+          labels:
+            cost-center: edge
+        spec:
+          containers: []
+          nodeSelector:
+            node-role: edge
+          tolerations:
+          - effect: NoSchedule
+            key: edge
+            operator: Equal
+            value: "true"
+`,
+		},
+		{
+			name: "config map",
+			content: `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: config
+  labels:
+    cost-center: chart
+`,
+			expected: `apiVersion: v1
+kind: ConfigMap
+metadata:
+  annotations:
+    owner: ops
+  labels:
+    cost-center: edge
+  name: config
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			content, err := c.apply(tt.content)
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, content)
+		})
+	}
+
+	// Resources are left unchanged without customizations.
+	content, err := customizations{}.apply("kind: ConfigMap\n")
+	require.NoError(t, err)
+	require.Equal(t, "kind: ConfigMap\n", content)
+}
+
+func TestAddHostAliases(t *testing.T) {
+	t.Parallel()
+
+	c := customizations{
+		hostAliases: []corev1.HostAlias{
+			{IP: "10.43.0.10", Hostnames: []string{"github.com"}},
+			{IP: "10.43.0.20", Hostnames: []string{"index.docker.io", "registry-1.docker.io"}},
+		},
+	}
+	content := `apiVersion: v1
+kind: Pod
+metadata:
+  name: app
+spec:
+  hostAliases:
+  - hostnames:
+    - github.com
+    ip: 192.168.1.10
+`
+	expected := `apiVersion: v1
+kind: Pod
+metadata:
+  name: app
+spec:
+  hostAliases:
+  - hostnames:
+    - github.com
+    ip: 192.168.1.10
+  - hostnames:
+    - index.docker.io
+    - registry-1.docker.io
+    ip: 10.43.0.20
+`
+	content, err := c.apply(content)
+	require.NoError(t, err)
+	require.Equal(t, expected, content)
+}
@@ -0,0 +1,129 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package helm
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/defenseunicorns/pkg/helpers/v2"
+	"helm.sh/helm/v3/pkg/action"
+	"helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/chart/loader"
+	"helm.sh/helm/v3/pkg/chartutil"
+
+	"github.com/zarf-dev/zarf/src/api/v1alpha1"
+	"github.com/zarf-dev/zarf/src/config"
+	"github.com/zarf-dev/zarf/src/pkg/logger"
+	"github.com/zarf-dev/zarf/src/pkg/utils"
+)
+
+// ChartDependencies returns the dependencies of the chart with the digest of their content. It fails when a
+// dependency in the Chart.yaml is not vendored in the charts directory of the chart.
+func ChartDependencies(c *chart.Chart) ([]v1alpha1.ZarfChartDependency, error) {
+	if err := action.CheckDependencies(c, c.Metadata.Dependencies); err != nil {
+		return nil, fmt.Errorf("chart %s: %w", c.Name(), err)
+	}
+	deps := []v1alpha1.ZarfChartDependency{}
+	for _, dep := range c.Metadata.Dependencies {
+		idx := slices.IndexFunc(c.Dependencies(), func(sub *chart.Chart) bool {
+			return sub.Name() == dep.Name
+		})
+		sub := c.Dependencies()[idx]
+		deps = append(deps, v1alpha1.ZarfChartDependency{
+			Name:       dep.Name,
+			Version:    sub.Metadata.Version,
+			Repository: dep.Repository,
+			Digest:     chartDigest(sub),
+		})
+	}
+	return deps, nil
+}
+
+// VerifyChartDependencies checks that the dependencies of the chart archive are vendored and that their content
+// matches the digests recorded when the package was created, so that a deploy never downloads dependencies.
+func VerifyChartDependencies(chartArchive string, recorded []v1alpha1.ZarfChartDependency) error {
+	c, err := loader.Load(chartArchive)
+	if err != nil {
+		return fmt.Errorf("unable to load helm chart archive: %w", err)
+	}
+	deps, err := ChartDependencies(c)
+	if err != nil {
+		return err
+	}
+	for _, r := range recorded {
+		idx := slices.IndexFunc(deps, func(dep v1alpha1.ZarfChartDependency) bool {
+			return dep.Name == r.Name
+		})
+		if idx == -1 {
+			return fmt.Errorf("chart %s is missing the dependency %s recorded in the package", c.Name(), r.Name)
+		}
+		if deps[idx].Digest != r.Digest {
+			return fmt.Errorf("dependency %s of chart %s has digest %s, expected %s", r.Name, c.Name(), deps[idx].Digest, r.Digest)
+		}
+	}
+	return nil
+}
+
+// vendorChartDependencies downloads the dependencies of a chart archive that are not vendored in it and repackages
+// the archive with them.
+func (h *Helm) vendorChartDependencies(ctx context.Context, chartArchive string) error {
+	c, err := loader.Load(chartArchive)
+	if err != nil {
+		return fmt.Errorf("unable to load helm chart archive: %w", err)
+	}
+	if action.CheckDependencies(c, c.Metadata.Dependencies) == nil {
+		return nil
+	}
+	logger.From(ctx).Info("vendoring helm chart dependencies", "name", c.Name(), "version", c.Metadata.Version)
+
+	tmpDir, err := utils.MakeTempDir(config.CommonOptions.TempDirectory)
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmpDir)
+	if err := chartutil.ExpandFile(tmpDir, chartArchive); err != nil {
+		return fmt.Errorf("unable to expand the chart %s: %w", c.Name(), err)
+	}
+	// Build the dependencies in the expanded chart the same way as a local chart.
+	expanded := *h
+	expanded.chart.LocalPath = filepath.Join(tmpDir, c.Name())
+	if err := expanded.buildChartDependencies(); err != nil {
+		return fmt.Errorf("unable to vendor the dependencies of the chart %s: %w", c.Name(), err)
+	}
+	client := action.NewPackage()
+	client.Destination = tmpDir
+	saved, err := client.Run(expanded.chart.LocalPath, nil)
+	if err != nil {
+		return err
+	}
+	vendored, err := loader.Load(saved)
+	if err != nil {
+		return err
+	}
+	if _, err := ChartDependencies(vendored); err != nil {
+		return err
+	}
+	return helpers.CreatePathAndCopy(saved, chartArchive)
+}
+
+// chartDigest returns the digest of the content of a chart, independent of how it was archived.
+func chartDigest(c *chart.Chart) string {
+	files := slices.Clone(c.Raw)
+	slices.SortFunc(files, func(a, b *chart.File) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	hash := sha256.New()
+	for _, f := range files {
+		fmt.Fprintf(hash, "%s\x00%d\x00", f.Name, len(f.Data))
+		hash.Write(f.Data)
+	}
+	return "sha256:" + hex.EncodeToString(hash.Sum(nil))
+}
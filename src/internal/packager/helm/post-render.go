@@ -35,6 +35,7 @@ type renderer struct {
 	*Helm
 	connectStrings types.ConnectStrings
 	namespaces     map[string]*corev1.Namespace
+	customizations customizations
 }
 
 func (h *Helm) newRenderer(ctx context.Context) (*renderer, error) {
@@ -43,9 +44,23 @@ func (h *Helm) newRenderer(ctx context.Context) (*renderer, error) {
 		connectStrings: types.ConnectStrings{},
 		namespaces:     map[string]*corev1.Namespace{},
 	}
+	if h.cfg != nil {
+		c, err := newCustomizations(h.cfg.DeployOpts)
+		if err != nil {
+			return nil, err
+		}
+		rend.customizations = c
+	}
 	if h.cluster == nil {
 		return rend, nil
 	}
+	if len(h.cfg.DeployOpts.HostAliases) > 0 {
+		hostAliases, err := h.cluster.ResolveHostAliases(ctx, h.cfg.DeployOpts.HostAliases, h.state)
+		if err != nil {
+			return nil, err
+		}
+		rend.customizations.hostAliases = hostAliases
+	}
 
 	namespace, err := h.cluster.Clientset.CoreV1().Namespaces().Get(ctx, h.chart.Namespace, metav1.GetOptions{})
 	if err != nil && !kerrors.IsNotFound(err) {
@@ -94,6 +109,14 @@ func (r *renderer) Run(renderedManifests *bytes.Buffer) (*bytes.Buffer, error) {
 		return nil, fmt.Errorf("error re-rendering helm output: %w", err)
 	}
 
+	// Add the labels, annotations and scheduling constraints of the deploy options
+	for i := range resources {
+		resources[i].Content, err = r.customizations.apply(resources[i].Content)
+		if err != nil {
+			return nil, fmt.Errorf("unable to customize %s: %w", resources[i].Name, err)
+		}
+	}
+
 	finalManifestsOutput := bytes.NewBuffer(nil)
 
 	if r.cluster != nil {
@@ -123,12 +146,38 @@ func (r *renderer) adoptAndUpdateNamespaces(ctx context.Context) error {
 	if err != nil {
 		return err
 	}
+	pullSecretSelector, err := cluster.ParsePullSecretSelector(r.state.PullSecretSelector)
+	if err != nil {
+		return err
+	}
 	for name, namespace := range r.namespaces {
 		// Check to see if this namespace already exists
 		var existingNamespace bool
+		// The namespace as it will be in the cluster, used to match it against the pull secret selector
+		if r.state.Instance != "" {
+			if namespace.Labels == nil {
+				namespace.Labels = map[string]string{}
+			}
+			namespace.Labels[cluster.InstanceLabel] = r.state.Instance
+		}
+		clusterNamespace := *namespace
 		for _, serverNamespace := range namespaceList.Items {
 			if serverNamespace.Name == name {
 				existingNamespace = true
+				claim, err := cluster.ClaimNamespace(serverNamespace, r.state.Instance)
+				if err != nil {
+					return err
+				}
+				keepCreatedByAnnotations(namespace, serverNamespace)
+				if !r.cfg.DeployOpts.AdoptExistingResources {
+					clusterNamespace = serverNamespace
+					if claim {
+						// Label the namespace so the agent mutates its resources with the state of the instance
+						if err := c.ClaimNamespaceForInstance(ctx, &clusterNamespace, r.state.Instance); err != nil {
+							return err
+						}
+					}
+				}
 			}
 		}
 
@@ -158,14 +207,16 @@ func (r *renderer) adoptAndUpdateNamespaces(ctx context.Context) error {
 			continue
 		}
 
-		// Create the secret
-		validRegistrySecret, err := c.GenerateRegistryPullCreds(ctx, name, config.ZarfImagePullSecretName, r.state.RegistryInfo)
-		if err != nil {
-			return err
-		}
-		_, err = c.Clientset.CoreV1().Secrets(*validRegistrySecret.Namespace).Apply(ctx, validRegistrySecret, metav1.ApplyOptions{Force: true, FieldManager: cluster.FieldManagerName})
-		if err != nil {
-			return fmt.Errorf("problem applying registry secret for the %s namespace: %w", name, err)
+		// Create the secret, only in the namespaces selected by the pull secret selector if one is set
+		if r.state.PullSecretSelector == "" || cluster.WantsPullSecret(clusterNamespace, pullSecretSelector) {
+			validRegistrySecret, err := c.GenerateRegistryPullCreds(ctx, name, config.ZarfImagePullSecretName, r.state.RegistryInfo)
+			if err != nil {
+				return err
+			}
+			_, err = c.Clientset.CoreV1().Secrets(*validRegistrySecret.Namespace).Apply(ctx, validRegistrySecret, metav1.ApplyOptions{Force: true, FieldManager: cluster.FieldManagerName})
+			if err != nil {
+				return fmt.Errorf("problem applying registry secret for the %s namespace: %w", name, err)
+			}
 		}
 		gitServerSecret := c.GenerateGitPullCreds(name, config.ZarfGitServerSecretName, r.state.GitServer)
 		_, err = c.Clientset.CoreV1().Secrets(*gitServerSecret.Namespace).Apply(ctx, gitServerSecret, metav1.ApplyOptions{Force: true, FieldManager: cluster.FieldManagerName})
@@ -176,6 +227,21 @@ func (r *renderer) adoptAndUpdateNamespaces(ctx context.Context) error {
 	return nil
 }
 
+// keepCreatedByAnnotations keeps the record of the package that created a namespace when it is adopted, so that the
+// namespace is still deleted when the package is removed.
+func keepCreatedByAnnotations(namespace *corev1.Namespace, serverNamespace corev1.Namespace) {
+	for _, key := range []string{cluster.ZarfCreatedByPackageAnnotation, cluster.ZarfCreatedByComponentAnnotation} {
+		value, ok := serverNamespace.Annotations[key]
+		if !ok {
+			continue
+		}
+		if namespace.Annotations == nil {
+			namespace.Annotations = map[string]string{}
+		}
+		namespace.Annotations[key] = value
+	}
+}
+
 func (r *renderer) editHelmResources(ctx context.Context, resources []releaseutil.Manifest, finalManifestsOutput *bytes.Buffer) error {
 	l := logger.From(ctx)
 	dc, err := dynamic.NewForConfig(r.cluster.RestConfig)
@@ -91,7 +91,10 @@ func (h *Helm) PackageChartFromLocalFiles(ctx context.Context, cosignKeyPath str
 
 	// Handle the chart directory or tarball
 	var saved string
-	temp := filepath.Join(h.chartPath, "temp")
+	temp, err := h.makeTempDir()
+	if err != nil {
+		return err
+	}
 	if _, ok := cl.(loader.DirLoader); ok {
 		err = h.buildChartDependencies()
 		if err != nil {
@@ -116,6 +119,10 @@ func (h *Helm) PackageChartFromLocalFiles(ctx context.Context, cosignKeyPath str
 	if err != nil {
 		return fmt.Errorf("unable to save the archive and create the package %s: %w", saved, err)
 	}
+	err = h.vendorChartDependencies(ctx, saved)
+	if err != nil {
+		return err
+	}
 
 	// Finalize the chart
 	err = h.finalizeChartPackage(ctx, saved, cosignKeyPath)
@@ -251,9 +258,9 @@ func (h *Helm) DownloadPublishedChart(ctx context.Context, cosignKeyPath string)
 	}
 
 	// Download the file into a temp directory since we don't control what name helm creates here
-	temp := filepath.Join(h.chartPath, "temp")
-	if err = helpers.CreateDirectory(temp, helpers.ReadWriteExecuteUser); err != nil {
-		return fmt.Errorf("unable to create helm chart temp directory: %w", err)
+	temp, err := h.makeTempDir()
+	if err != nil {
+		return err
 	}
 	defer func(l *slog.Logger) {
 		err := os.RemoveAll(temp)
@@ -272,6 +279,10 @@ func (h *Helm) DownloadPublishedChart(ctx context.Context, cosignKeyPath string)
 	if err != nil {
 		return err
 	}
+	err = h.vendorChartDependencies(ctx, saved)
+	if err != nil {
+		return err
+	}
 
 	// Finalize the chart
 	err = h.finalizeChartPackage(ctx, saved, cosignKeyPath)
@@ -289,6 +300,19 @@ func (h *Helm) DownloadPublishedChart(ctx context.Context, cosignKeyPath string)
 	return nil
 }
 
+// makeTempDir creates a temp directory within the chart path that is unique to this chart, as several charts of a
+// component can be packaged into the same chart path at the same time.
+func (h *Helm) makeTempDir() (string, error) {
+	if err := helpers.CreateDirectory(h.chartPath, helpers.ReadWriteExecuteUser); err != nil {
+		return "", fmt.Errorf("unable to create helm chart directory: %w", err)
+	}
+	temp, err := os.MkdirTemp(h.chartPath, "temp-")
+	if err != nil {
+		return "", fmt.Errorf("unable to create helm chart temp directory: %w", err)
+	}
+	return temp, nil
+}
+
 // DownloadChartFromGitToTemp downloads a chart from git into a temp directory
 func DownloadChartFromGitToTemp(ctx context.Context, url string) (string, error) {
 	path, err := utils.MakeTempDir(config.CommonOptions.TempDirectory)
@@ -72,6 +72,11 @@ func (h *Helm) createActionConfig(ctx context.Context, namespace string, spinner
 	// Set the namespace for helm
 	h.settings.SetNamespace(namespace)
 
+	// Act as the same user as the cluster clients when they impersonate a service account
+	if h.cluster != nil {
+		h.settings.KubeAsUser = h.cluster.ImpersonatedUser()
+	}
+
 	// Setup K8s connection
 	helmLogger := spinner.Updatef
 	if logger.Enabled(ctx) {
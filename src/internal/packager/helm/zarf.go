@@ -7,6 +7,7 @@ package helm
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"helm.sh/helm/v3/pkg/action"
@@ -27,17 +28,13 @@ import (
 
 // UpdateZarfRegistryValues updates the Zarf registry deployment with the new state values
 func (h *Helm) UpdateZarfRegistryValues(ctx context.Context) error {
-	pushUser, err := utils.GetHtpasswdString(h.state.RegistryInfo.PushUsername, h.state.RegistryInfo.PushPassword)
-	if err != nil {
-		return fmt.Errorf("error generating htpasswd string: %w", err)
-	}
-	pullUser, err := utils.GetHtpasswdString(h.state.RegistryInfo.PullUsername, h.state.RegistryInfo.PullPassword)
+	users, err := utils.GetHtpasswdStrings(h.state.RegistryInfo.HtpasswdUsers())
 	if err != nil {
 		return fmt.Errorf("error generating htpasswd string: %w", err)
 	}
 	registryValues := map[string]interface{}{
 		"secrets": map[string]interface{}{
-			"htpasswd": fmt.Sprintf("%s\n%s", pushUser, pullUser),
+			"htpasswd": strings.Join(users, "\n"),
 		},
 	}
 	h.chart = v1alpha1.ZarfChart{
@@ -0,0 +1,341 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package hostartifacts contains functions for installing binaries and other assets onto the deploy host.
+package hostartifacts
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/defenseunicorns/pkg/helpers/v2"
+	"github.com/mholt/archiver/v3"
+
+	"github.com/zarf-dev/zarf/src/api/v1alpha1"
+	"github.com/zarf-dev/zarf/src/config"
+	"github.com/zarf-dev/zarf/src/config/lang"
+	"github.com/zarf-dev/zarf/src/pkg/utils"
+)
+
+// DefaultInstallPath is the directory host artifacts are installed into when they do not set an install path.
+const DefaultInstallPath = "~/.zarf/bin"
+
+// Names returns the names of the host artifacts of the components.
+func Names(components []v1alpha1.ZarfComponent) []string {
+	names := []string{}
+	for _, component := range components {
+		for _, hostArtifact := range component.HostArtifacts {
+			names = append(names, hostArtifact.Name)
+		}
+	}
+	return names
+}
+
+// Validate returns an error if the name of the host artifact is not a file name or its install path is neither
+// absolute nor relative to the home directory.
+func Validate(hostArtifact v1alpha1.ZarfHostArtifact) error {
+	name := hostArtifact.Name
+	if name == "" || name == "." || name == ".." || strings.ContainsAny(name, `/\`) {
+		return fmt.Errorf("host artifact name %q must be a file name", name)
+	}
+	installPath := hostArtifact.InstallPath
+	if installPath != "" && !filepath.IsAbs(installPath) && installPath != "~" && !strings.HasPrefix(installPath, "~/") {
+		return fmt.Errorf("install path %q of host artifact %s must be absolute or relative to the home directory", installPath, name)
+	}
+	return nil
+}
+
+// SourcePath returns the path a source of a host artifact is stored at, relative to the host artifacts directory of its component.
+func SourcePath(artifactIdx, sourceIdx int, name string) string {
+	return filepath.Join(strconv.Itoa(artifactIdx), strconv.Itoa(sourceIdx), name)
+}
+
+// Pull copies or downloads the source to dst, extracting it from its archive when an extract path is set, and verifies its checksum.
+func Pull(ctx context.Context, basePath string, src v1alpha1.ZarfHostArtifactSource, dst, cosignKeyPath string) error {
+	archivePath := src.Source
+	if helpers.IsURL(src.Source) {
+		downloadPath := dst
+		if src.ExtractPath != "" {
+			name, err := helpers.ExtractBasePathFromURL(src.Source)
+			if err != nil {
+				return fmt.Errorf(lang.ErrFileNameExtract, src.Source, err.Error())
+			}
+			tmpDir, err := utils.MakeTempDir(config.CommonOptions.TempDirectory)
+			if err != nil {
+				return err
+			}
+			defer os.RemoveAll(tmpDir)
+			downloadPath = filepath.Join(tmpDir, name)
+		}
+		if err := utils.DownloadToFile(ctx, src.Source, downloadPath, cosignKeyPath); err != nil {
+			return fmt.Errorf(lang.ErrDownloading, src.Source, err.Error())
+		}
+		archivePath = downloadPath
+	} else {
+		if !filepath.IsAbs(archivePath) {
+			archivePath = filepath.Join(basePath, archivePath)
+		}
+		if src.ExtractPath == "" {
+			if err := helpers.CreatePathAndCopy(archivePath, dst); err != nil {
+				return fmt.Errorf("unable to copy host artifact %s: %w", src.Source, err)
+			}
+		}
+	}
+
+	if src.ExtractPath != "" {
+		tmpDir, err := utils.MakeTempDir(config.CommonOptions.TempDirectory)
+		if err != nil {
+			return err
+		}
+		defer os.RemoveAll(tmpDir)
+		if err := archiver.Extract(archivePath, src.ExtractPath, tmpDir); err != nil {
+			return fmt.Errorf(lang.ErrFileExtract, src.ExtractPath, src.Source, err.Error())
+		}
+		if err := helpers.CreatePathAndCopy(filepath.Join(tmpDir, src.ExtractPath), dst); err != nil {
+			return fmt.Errorf(lang.ErrWritingFile, dst, err)
+		}
+	}
+
+	if helpers.IsDir(dst) {
+		return fmt.Errorf("host artifact %s must be a file", src.Source)
+	}
+	if src.Shasum != "" {
+		if err := helpers.SHAsMatch(dst, src.Shasum); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Select returns the index of the source that is installed on the given operating system and architecture.
+// Sources for a specific platform are preferred over sources that leave the operating system or architecture empty.
+func Select(hostArtifact v1alpha1.ZarfHostArtifact, goos, goarch string) (int, bool) {
+	best, bestScore := -1, -1
+	for i, src := range hostArtifact.Sources {
+		if (src.OS != "" && src.OS != goos) || (src.Architecture != "" && src.Architecture != goarch) {
+			continue
+		}
+		score := 0
+		if src.OS != "" {
+			score++
+		}
+		if src.Architecture != "" {
+			score++
+		}
+		if score > bestScore {
+			best, bestScore = i, score
+		}
+	}
+	return best, best != -1
+}
+
+// InstallDir returns the absolute directory the host artifact is installed into.
+func InstallDir(hostArtifact v1alpha1.ZarfHostArtifact) (string, error) {
+	installPath := hostArtifact.InstallPath
+	if installPath == "" {
+		installPath = DefaultInstallPath
+	}
+	dir, err := config.GetAbsHomePath(installPath)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Abs(dir)
+}
+
+// record is stored next to a file Zarf installs onto the host so that Zarf only replaces and removes the files it
+// installed.
+type record struct {
+	// Package is the name of the package that installed the file.
+	Package string `json:"package"`
+	// Shasum is the SHA256 checksum of the installed file.
+	Shasum string `json:"shasum"`
+}
+
+// recordPath returns the path of the record of the file installed at dst.
+func recordPath(dst string) string {
+	return filepath.Join(filepath.Dir(dst), fmt.Sprintf(".%s.zarf.json", filepath.Base(dst)))
+}
+
+// readRecord returns the record of the file installed at dst, or nil if Zarf did not install it.
+func readRecord(dst string) (*record, error) {
+	b, err := os.ReadFile(recordPath(dst))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	r := &record{}
+	if err := json.Unmarshal(b, r); err != nil {
+		return nil, fmt.Errorf("unable to read the install record of %s: %w", dst, err)
+	}
+	return r, nil
+}
+
+// CheckReplace returns an error if a file exists at dst that was not installed by the package.
+func CheckReplace(dst, packageName string) error {
+	if helpers.InvalidPath(dst) {
+		return nil
+	}
+	r, err := readRecord(dst)
+	if err != nil {
+		return err
+	}
+	if r == nil {
+		return fmt.Errorf("%s already exists and was not installed by Zarf", dst)
+	}
+	if r.Package != packageName {
+		return fmt.Errorf("%s was installed by the package %s", dst, r.Package)
+	}
+	return nil
+}
+
+// WriteRecord records that the package installed the file with the SHA256 checksum at dst.
+func WriteRecord(dst, packageName, shasum string) error {
+	b, err := json.Marshal(record{Package: packageName, Shasum: shasum})
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(recordPath(dst), b, helpers.ReadWriteUser)
+}
+
+// KeptReason returns why the file at dst must be left in place instead of being removed by the package, or an empty
+// string if the package installed it and it was not changed since.
+func KeptReason(dst, packageName string) (string, error) {
+	r, err := readRecord(dst)
+	if err != nil {
+		return "", err
+	}
+	if r == nil {
+		return "it was not installed by Zarf", nil
+	}
+	if r.Package != packageName {
+		return fmt.Sprintf("it was installed by the package %s", r.Package), nil
+	}
+	shasum, err := helpers.GetSHA256OfFile(dst)
+	if err != nil {
+		return "", err
+	}
+	if shasum != r.Shasum {
+		return "it was changed after it was installed", nil
+	}
+	return "", nil
+}
+
+// RemoveRecord removes the record of the file at dst if it was installed by the package.
+func RemoveRecord(dst, packageName string) error {
+	r, err := readRecord(dst)
+	if err != nil || r == nil || r.Package != packageName {
+		return err
+	}
+	if err := os.Remove(recordPath(dst)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// Install installs the source of the host artifact that matches the platform of the host from dir, the host artifacts
+// directory of its component, and returns the path it was installed to. It refuses to replace files that were not
+// installed by the package.
+func Install(hostArtifact v1alpha1.ZarfHostArtifact, artifactIdx int, dir, packageName string) (string, error) {
+	if err := Validate(hostArtifact); err != nil {
+		return "", err
+	}
+	sourceIdx, ok := Select(hostArtifact, runtime.GOOS, runtime.GOARCH)
+	if !ok {
+		return "", fmt.Errorf("host artifact %s has no source for %s/%s", hostArtifact.Name, runtime.GOOS, runtime.GOARCH)
+	}
+	src := filepath.Join(dir, SourcePath(artifactIdx, sourceIdx, hostArtifact.Name))
+	shasum, err := helpers.GetSHA256OfFile(src)
+	if err != nil {
+		return "", err
+	}
+	if expected := hostArtifact.Sources[sourceIdx].Shasum; expected != "" && expected != shasum {
+		return "", fmt.Errorf("expected sha256 of %s to be %s, found %s", src, expected, shasum)
+	}
+
+	installDir, err := InstallDir(hostArtifact)
+	if err != nil {
+		return "", err
+	}
+	dst := filepath.Join(installDir, hostArtifact.Name)
+	if err := CheckReplace(dst, packageName); err != nil {
+		return "", err
+	}
+	if err := helpers.CreateDirectory(installDir, helpers.ReadWriteExecuteUser); err != nil {
+		return "", err
+	}
+	if err := WriteRecord(dst, packageName, shasum); err != nil {
+		return "", err
+	}
+	// Copy next to the destination and rename so that a binary that is currently running can be replaced.
+	tmp := fmt.Sprintf("%s.%d.tmp", dst, os.Getpid())
+	if err := helpers.CreatePathAndCopy(src, tmp); err != nil {
+		return "", err
+	}
+	mode := helpers.ReadWriteUser
+	if hostArtifact.Executable {
+		mode = helpers.ReadWriteExecuteUser
+	}
+	if err := os.Chmod(tmp, os.FileMode(mode)); err != nil {
+		return "", errors.Join(err, os.Remove(tmp))
+	}
+	if err := os.Rename(tmp, dst); err != nil {
+		return "", errors.Join(err, os.Remove(tmp))
+	}
+	return dst, nil
+}
+
+// Uninstall removes the host artifact if it was installed by the package and was not changed since. Otherwise it
+// leaves the file in place and returns the reason it was kept. It does nothing if the host artifact is not installed.
+func Uninstall(hostArtifact v1alpha1.ZarfHostArtifact, packageName string) (dst, kept string, err error) {
+	if err := Validate(hostArtifact); err != nil {
+		return "", "", err
+	}
+	installDir, err := InstallDir(hostArtifact)
+	if err != nil {
+		return "", "", err
+	}
+	dst = filepath.Join(installDir, hostArtifact.Name)
+	if helpers.InvalidPath(dst) {
+		return dst, "", RemoveRecord(dst, packageName)
+	}
+	kept, err = KeptReason(dst, packageName)
+	if err != nil {
+		return "", "", err
+	}
+	if kept != "" {
+		return dst, kept, nil
+	}
+	if err := os.Remove(dst); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return "", "", err
+	}
+	if err := RemoveRecord(dst, packageName); err != nil {
+		return "", "", err
+	}
+	return dst, "", nil
+}
+
+// AddToPath prepends dir to the PATH of the running process, so that actions can use the installed host artifacts,
+// and returns true when dir was not already in the PATH.
+func AddToPath(dir string) (bool, error) {
+	paths := filepath.SplitList(os.Getenv("PATH"))
+	for _, p := range paths {
+		if filepath.Clean(p) == filepath.Clean(dir) {
+			return false, nil
+		}
+	}
+	if err := os.Setenv("PATH", strings.Join(slices.Concat([]string{dir}, paths), string(os.PathListSeparator))); err != nil {
+		return false, err
+	}
+	return true, nil
+}
@@ -0,0 +1,236 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package hostartifacts
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/zarf-dev/zarf/src/api/v1alpha1"
+	"github.com/zarf-dev/zarf/src/test/testutil"
+)
+
+const helloChecksum = "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824"
+
+func TestSelect(t *testing.T) {
+	t.Parallel()
+
+	hostArtifact := v1alpha1.ZarfHostArtifact{
+		Name: "tool",
+		Sources: []v1alpha1.ZarfHostArtifactSource{
+			{Source: "tool-any"},
+			{OS: "linux", Source: "tool-linux"},
+			{OS: "linux", Architecture: "arm64", Source: "tool-linux-arm64"},
+			{OS: "darwin", Architecture: "arm64", Source: "tool-darwin-arm64"},
+		},
+	}
+	tests := []struct {
+		name     string
+		goos     string
+		goarch   string
+		expected int
+	}{
+		{
+			name:     "exact match",
+			goos:     "linux",
+			goarch:   "arm64",
+			expected: 2,
+		},
+		{
+			name:     "operating system match",
+			goos:     "linux",
+			goarch:   "amd64",
+			expected: 1,
+		},
+		{
+			name:     "any platform",
+			goos:     "windows",
+			goarch:   "amd64",
+			expected: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			idx, ok := Select(hostArtifact, tt.goos, tt.goarch)
+			require.True(t, ok)
+			require.Equal(t, tt.expected, idx)
+		})
+	}
+
+	_, ok := Select(v1alpha1.ZarfHostArtifact{Sources: hostArtifact.Sources[3:]}, "linux", "amd64")
+	require.False(t, ok)
+}
+
+func TestPull(t *testing.T) {
+	t.Parallel()
+	ctx := testutil.TestContext(t)
+
+	basePath := t.TempDir()
+	err := os.WriteFile(filepath.Join(basePath, "tool"), []byte("hello"), 0o600)
+	require.NoError(t, err)
+
+	dst := filepath.Join(t.TempDir(), "0", "0", "tool")
+	err = Pull(ctx, basePath, v1alpha1.ZarfHostArtifactSource{Source: "tool", Shasum: helloChecksum}, dst, "")
+	require.NoError(t, err)
+	b, err := os.ReadFile(dst)
+	require.NoError(t, err)
+	require.Equal(t, "hello", string(b))
+
+	err = Pull(ctx, basePath, v1alpha1.ZarfHostArtifactSource{Source: "tool", Shasum: "abc"}, filepath.Join(t.TempDir(), "tool"), "")
+	require.ErrorContains(t, err, "to be abc, found "+helloChecksum)
+
+	err = Pull(ctx, basePath, v1alpha1.ZarfHostArtifactSource{Source: "."}, filepath.Join(t.TempDir(), "tool"), "")
+	require.EqualError(t, err, "host artifact . must be a file")
+}
+
+func TestInstallUninstall(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	hostArtifact := v1alpha1.ZarfHostArtifact{
+		Name:        "tool",
+		InstallPath: filepath.Join(t.TempDir(), "bin"),
+		Executable:  true,
+		Sources: []v1alpha1.ZarfHostArtifactSource{
+			{OS: "plan9", Source: "tool-plan9"},
+			{OS: runtime.GOOS, Architecture: runtime.GOARCH, Source: "tool", Shasum: helloChecksum},
+		},
+	}
+	err := os.MkdirAll(filepath.Join(dir, "2", "1"), 0o700)
+	require.NoError(t, err)
+	err = os.WriteFile(filepath.Join(dir, SourcePath(2, 1, "tool")), []byte("hello"), 0o600)
+	require.NoError(t, err)
+
+	installed, err := Install(hostArtifact, 2, dir, "test")
+	require.NoError(t, err)
+	require.Equal(t, filepath.Join(hostArtifact.InstallPath, "tool"), installed)
+	fi, err := os.Stat(installed)
+	require.NoError(t, err)
+	require.Equal(t, os.FileMode(0o700), fi.Mode().Perm())
+
+	// Installing again replaces the installed artifact.
+	_, err = Install(hostArtifact, 2, dir, "test")
+	require.NoError(t, err)
+	_, err = Install(hostArtifact, 2, dir, "other")
+	require.EqualError(t, err, installed+" was installed by the package test")
+
+	removed, kept, err := Uninstall(hostArtifact, "other")
+	require.NoError(t, err)
+	require.Equal(t, "it was installed by the package test", kept)
+	require.FileExists(t, removed)
+
+	removed, kept, err = Uninstall(hostArtifact, "test")
+	require.NoError(t, err)
+	require.Empty(t, kept)
+	require.Equal(t, installed, removed)
+	require.NoFileExists(t, installed)
+	require.NoFileExists(t, recordPath(installed))
+	_, kept, err = Uninstall(hostArtifact, "test")
+	require.NoError(t, err)
+	require.Empty(t, kept)
+
+	// Files that were changed after they were installed are kept.
+	_, err = Install(hostArtifact, 2, dir, "test")
+	require.NoError(t, err)
+	err = os.WriteFile(installed, []byte("changed"), 0o700)
+	require.NoError(t, err)
+	_, kept, err = Uninstall(hostArtifact, "test")
+	require.NoError(t, err)
+	require.Equal(t, "it was changed after it was installed", kept)
+	require.FileExists(t, installed)
+
+	// Files that were not installed by Zarf are neither replaced nor removed.
+	err = os.Remove(recordPath(installed))
+	require.NoError(t, err)
+	_, err = Install(hostArtifact, 2, dir, "test")
+	require.EqualError(t, err, installed+" already exists and was not installed by Zarf")
+	_, kept, err = Uninstall(hostArtifact, "test")
+	require.NoError(t, err)
+	require.Equal(t, "it was not installed by Zarf", kept)
+	b, err := os.ReadFile(installed)
+	require.NoError(t, err)
+	require.Equal(t, "changed", string(b))
+
+	hostArtifact.Sources = hostArtifact.Sources[:1]
+	_, err = Install(hostArtifact, 2, dir, "test")
+	require.EqualError(t, err, "host artifact tool has no source for "+runtime.GOOS+"/"+runtime.GOARCH)
+}
+
+func TestValidate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		hostArtifact v1alpha1.ZarfHostArtifact
+		expectedErr  string
+	}{
+		{
+			name:         "default install path",
+			hostArtifact: v1alpha1.ZarfHostArtifact{Name: "tool"},
+		},
+		{
+			name:         "home install path",
+			hostArtifact: v1alpha1.ZarfHostArtifact{Name: "tool", InstallPath: "~/bin"},
+		},
+		{
+			name:         "absolute install path",
+			hostArtifact: v1alpha1.ZarfHostArtifact{Name: "tool", InstallPath: "/usr/local/bin"},
+		},
+		{
+			name:         "name with a path",
+			hostArtifact: v1alpha1.ZarfHostArtifact{Name: "../tool"},
+			expectedErr:  `host artifact name "../tool" must be a file name`,
+		},
+		{
+			name:         "parent name",
+			hostArtifact: v1alpha1.ZarfHostArtifact{Name: ".."},
+			expectedErr:  `host artifact name ".." must be a file name`,
+		},
+		{
+			name:         "relative install path",
+			hostArtifact: v1alpha1.ZarfHostArtifact{Name: "tool", InstallPath: "bin"},
+			expectedErr:  `install path "bin" of host artifact tool must be absolute or relative to the home directory`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := Validate(tt.hostArtifact)
+			if tt.expectedErr != "" {
+				require.EqualError(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestInstallDir(t *testing.T) {
+	t.Parallel()
+
+	home, err := os.UserHomeDir()
+	require.NoError(t, err)
+	dir, err := InstallDir(v1alpha1.ZarfHostArtifact{})
+	require.NoError(t, err)
+	require.Equal(t, filepath.Join(home, ".zarf", "bin"), dir)
+}
+
+func TestAddToPath(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("PATH", "/usr/bin")
+
+	added, err := AddToPath(dir)
+	require.NoError(t, err)
+	require.True(t, added)
+	require.Equal(t, dir+string(os.PathListSeparator)+"/usr/bin", os.Getenv("PATH"))
+
+	added, err = AddToPath(dir + "/")
+	require.NoError(t, err)
+	require.False(t, added)
+}
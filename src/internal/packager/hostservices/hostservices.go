@@ -0,0 +1,162 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package hostservices contains functions for installing and managing systemd services on the deploy host.
+package hostservices
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+
+	"github.com/defenseunicorns/pkg/helpers/v2"
+
+	"github.com/zarf-dev/zarf/src/api/v1alpha1"
+	"github.com/zarf-dev/zarf/src/internal/packager/hostartifacts"
+	zexec "github.com/zarf-dev/zarf/src/pkg/utils/exec"
+)
+
+// DefaultUnitDir is the directory the unit files of host services are installed into.
+const DefaultUnitDir = "/etc/systemd/system"
+
+// SourcePath returns the path the unit file of a host service is stored at, relative to the host services directory of its component.
+func SourcePath(serviceIdx int, name string) string {
+	return filepath.Join(strconv.Itoa(serviceIdx), name)
+}
+
+// Pull copies or downloads the unit file of the host service to dst and verifies its checksum.
+func Pull(ctx context.Context, basePath string, hostService v1alpha1.ZarfHostService, dst, cosignKeyPath string) error {
+	src := v1alpha1.ZarfHostArtifactSource{Source: hostService.Source, Shasum: hostService.Shasum}
+	return hostartifacts.Pull(ctx, basePath, src, dst, cosignKeyPath)
+}
+
+// Names returns the names of the host services of the components.
+func Names(components []v1alpha1.ZarfComponent) []string {
+	names := []string{}
+	for _, component := range components {
+		for _, hostService := range component.HostServices {
+			names = append(names, hostService.Name)
+		}
+	}
+	return names
+}
+
+// Manager installs the unit files of host services and manages their units with systemctl.
+type Manager struct {
+	// UnitDir is the directory unit files are installed into.
+	UnitDir string
+	// Systemctl runs systemctl with the arguments.
+	Systemctl func(ctx context.Context, args ...string) error
+}
+
+// NewManager returns a manager for the systemd instance of the host, it returns an error if the host does not run systemd.
+func NewManager() (*Manager, error) {
+	if runtime.GOOS != "linux" {
+		return nil, fmt.Errorf("host services require systemd, which is not available on %s", runtime.GOOS)
+	}
+	if _, err := exec.LookPath("systemctl"); err != nil {
+		return nil, fmt.Errorf("host services require systemd, systemctl was not found: %w", err)
+	}
+	return &Manager{
+		UnitDir:   DefaultUnitDir,
+		Systemctl: systemctl,
+	}, nil
+}
+
+// systemctl runs systemctl and returns its output as part of the error when it fails.
+func systemctl(ctx context.Context, args ...string) error {
+	stdout, stderr, err := zexec.CmdWithContext(ctx, zexec.Config{}, "systemctl", args...)
+	if err != nil {
+		return fmt.Errorf("systemctl %s failed: %w: %s", strings.Join(args, " "), err, strings.TrimSpace(stdout+stderr))
+	}
+	return nil
+}
+
+// Install installs the unit file of the host service from dir, the host services directory of its component, reloads
+// systemd and enables and starts the unit as configured. A running unit is restarted to pick up the new unit file.
+// It refuses to replace unit files that were not installed by the package, and returns the path the unit file was
+// installed to.
+func (m *Manager) Install(ctx context.Context, hostService v1alpha1.ZarfHostService, serviceIdx int, dir, packageName string) (string, error) {
+	src := filepath.Join(dir, SourcePath(serviceIdx, hostService.Name))
+	shasum, err := helpers.GetSHA256OfFile(src)
+	if err != nil {
+		return "", err
+	}
+	if hostService.Shasum != "" && hostService.Shasum != shasum {
+		return "", fmt.Errorf("expected sha256 of %s to be %s, found %s", src, hostService.Shasum, shasum)
+	}
+
+	dst := filepath.Join(m.UnitDir, hostService.Name)
+	if err := hostartifacts.CheckReplace(dst, packageName); err != nil {
+		return "", err
+	}
+	if err := helpers.CreateDirectory(m.UnitDir, helpers.ReadWriteExecuteUser); err != nil {
+		return "", err
+	}
+	if err := hostartifacts.WriteRecord(dst, packageName, shasum); err != nil {
+		return "", err
+	}
+	tmp := fmt.Sprintf("%s.%d.tmp", dst, os.Getpid())
+	if err := helpers.CreatePathAndCopy(src, tmp); err != nil {
+		return "", err
+	}
+	// Unit files are read by systemd and must not be writable by other users.
+	if err := os.Chmod(tmp, 0o644); err != nil {
+		return "", errors.Join(err, os.Remove(tmp))
+	}
+	if err := os.Rename(tmp, dst); err != nil {
+		return "", errors.Join(err, os.Remove(tmp))
+	}
+
+	if err := m.Systemctl(ctx, "daemon-reload"); err != nil {
+		return "", err
+	}
+	if hostService.Enable {
+		if err := m.Systemctl(ctx, "enable", hostService.Name); err != nil {
+			return "", err
+		}
+	}
+	if hostService.Start {
+		if err := m.Systemctl(ctx, "restart", hostService.Name); err != nil {
+			return "", err
+		}
+	}
+	return dst, nil
+}
+
+// Uninstall stops and disables the unit of the host service, removes its unit file and reloads systemd. Units whose
+// unit file was not installed by the package, or was changed since, are left alone and the reason they were kept is
+// returned. It does nothing if the unit file is not installed.
+func (m *Manager) Uninstall(ctx context.Context, hostService v1alpha1.ZarfHostService, packageName string) (dst, kept string, err error) {
+	dst = filepath.Join(m.UnitDir, hostService.Name)
+	if _, err := os.Stat(dst); errors.Is(err, fs.ErrNotExist) {
+		return dst, "", hostartifacts.RemoveRecord(dst, packageName)
+	}
+	kept, err = hostartifacts.KeptReason(dst, packageName)
+	if err != nil {
+		return "", "", err
+	}
+	if kept != "" {
+		return dst, kept, nil
+	}
+	if err := m.Systemctl(ctx, "disable", "--now", hostService.Name); err != nil {
+		return "", "", err
+	}
+	if err := os.Remove(dst); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return "", "", err
+	}
+	if err := hostartifacts.RemoveRecord(dst, packageName); err != nil {
+		return "", "", err
+	}
+	if err := m.Systemctl(ctx, "daemon-reload"); err != nil {
+		return "", "", err
+	}
+	return dst, "", nil
+}
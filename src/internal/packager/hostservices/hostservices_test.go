@@ -0,0 +1,178 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package hostservices
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/zarf-dev/zarf/src/api/v1alpha1"
+	"github.com/zarf-dev/zarf/src/test/testutil"
+)
+
+const unitChecksum = "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824"
+
+// fakeManager returns a manager that installs into a temporary directory and records the systemctl calls.
+func fakeManager(t *testing.T, calls *[]string) *Manager {
+	t.Helper()
+	return &Manager{
+		UnitDir: filepath.Join(t.TempDir(), "system"),
+		Systemctl: func(_ context.Context, args ...string) error {
+			*calls = append(*calls, strings.Join(args, " "))
+			return nil
+		},
+	}
+}
+
+func TestInstallAndUninstall(t *testing.T) {
+	t.Parallel()
+	ctx := testutil.TestContext(t)
+
+	dir := t.TempDir()
+	err := os.MkdirAll(filepath.Join(dir, "0"), 0o700)
+	require.NoError(t, err)
+	err = os.WriteFile(filepath.Join(dir, SourcePath(0, "edge-agent.service")), []byte("hello"), 0o600)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name          string
+		hostService   v1alpha1.ZarfHostService
+		expectedCalls []string
+		expectedErr   string
+	}{
+		{
+			name:          "install only",
+			hostService:   v1alpha1.ZarfHostService{Name: "edge-agent.service", Shasum: unitChecksum},
+			expectedCalls: []string{"daemon-reload"},
+		},
+		{
+			name:          "enable and start",
+			hostService:   v1alpha1.ZarfHostService{Name: "edge-agent.service", Enable: true, Start: true},
+			expectedCalls: []string{"daemon-reload", "enable edge-agent.service", "restart edge-agent.service"},
+		},
+		{
+			name:        "checksum mismatch",
+			hostService: v1alpha1.ZarfHostService{Name: "edge-agent.service", Shasum: "abc"},
+			expectedErr: "expected sha256 of",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			calls := []string{}
+			m := fakeManager(t, &calls)
+			installed, err := m.Install(ctx, tt.hostService, 0, dir, "edge")
+			if tt.expectedErr != "" {
+				require.ErrorContains(t, err, tt.expectedErr)
+				require.NoFileExists(t, filepath.Join(m.UnitDir, tt.hostService.Name))
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expectedCalls, calls)
+			b, err := os.ReadFile(installed)
+			require.NoError(t, err)
+			require.Equal(t, "hello", string(b))
+			fi, err := os.Stat(installed)
+			require.NoError(t, err)
+			require.Equal(t, os.FileMode(0o644), fi.Mode().Perm())
+
+			calls = []string{}
+			removed, kept, err := m.Uninstall(ctx, tt.hostService, "edge")
+			require.NoError(t, err)
+			require.Empty(t, kept)
+			require.Equal(t, installed, removed)
+			require.NoFileExists(t, removed)
+			require.Equal(t, []string{"disable --now edge-agent.service", "daemon-reload"}, calls)
+
+			// Uninstalling a unit that is not installed does nothing.
+			calls = []string{}
+			_, _, err = m.Uninstall(ctx, tt.hostService, "edge")
+			require.NoError(t, err)
+			require.Empty(t, calls)
+		})
+	}
+}
+
+func TestInstallSystemctlError(t *testing.T) {
+	t.Parallel()
+	ctx := testutil.TestContext(t)
+
+	dir := t.TempDir()
+	err := os.MkdirAll(filepath.Join(dir, "0"), 0o700)
+	require.NoError(t, err)
+	err = os.WriteFile(filepath.Join(dir, SourcePath(0, "edge-agent.service")), []byte("hello"), 0o600)
+	require.NoError(t, err)
+
+	m := &Manager{
+		UnitDir: t.TempDir(),
+		Systemctl: func(_ context.Context, args ...string) error {
+			if args[0] == "restart" {
+				return errors.New("unit failed to start")
+			}
+			return nil
+		},
+	}
+	_, err = m.Install(ctx, v1alpha1.ZarfHostService{Name: "edge-agent.service", Start: true}, 0, dir, "edge")
+	require.EqualError(t, err, "unit failed to start")
+}
+
+func TestUnitOwnership(t *testing.T) {
+	t.Parallel()
+	ctx := testutil.TestContext(t)
+
+	dir := t.TempDir()
+	err := os.MkdirAll(filepath.Join(dir, "0"), 0o700)
+	require.NoError(t, err)
+	err = os.WriteFile(filepath.Join(dir, SourcePath(0, "sshd.service")), []byte("hello"), 0o600)
+	require.NoError(t, err)
+	hostService := v1alpha1.ZarfHostService{Name: "sshd.service", Start: true}
+
+	calls := []string{}
+	m := fakeManager(t, &calls)
+	err = os.MkdirAll(m.UnitDir, 0o700)
+	require.NoError(t, err)
+	unit := filepath.Join(m.UnitDir, "sshd.service")
+	err = os.WriteFile(unit, []byte("host"), 0o644)
+	require.NoError(t, err)
+
+	// Units that were not installed by Zarf are neither replaced nor stopped and removed.
+	_, err = m.Install(ctx, hostService, 0, dir, "edge")
+	require.EqualError(t, err, unit+" already exists and was not installed by Zarf")
+	_, kept, err := m.Uninstall(ctx, hostService, "edge")
+	require.NoError(t, err)
+	require.Equal(t, "it was not installed by Zarf", kept)
+	require.FileExists(t, unit)
+	require.Empty(t, calls)
+
+	// Units installed by another package are left to that package.
+	err = os.Remove(unit)
+	require.NoError(t, err)
+	_, err = m.Install(ctx, hostService, 0, dir, "edge")
+	require.NoError(t, err)
+	_, err = m.Install(ctx, hostService, 0, dir, "other")
+	require.EqualError(t, err, unit+" was installed by the package edge")
+	calls = []string{}
+	_, kept, err = m.Uninstall(ctx, hostService, "other")
+	require.NoError(t, err)
+	require.Equal(t, "it was installed by the package edge", kept)
+	require.Empty(t, calls)
+}
+
+func TestNames(t *testing.T) {
+	t.Parallel()
+
+	components := []v1alpha1.ZarfComponent{
+		{Name: "agent", HostServices: []v1alpha1.ZarfHostService{{Name: "edge-agent.service"}, {Name: "edge-agent.timer"}}},
+		{Name: "app"},
+	}
+	require.Equal(t, []string{"edge-agent.service", "edge-agent.timer"}, Names(components))
+	require.Empty(t, Names(nil))
+}
@@ -0,0 +1,273 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package imagebuild contains functions for building the images of components from Dockerfiles.
+package imagebuild
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/defenseunicorns/pkg/helpers/v2"
+
+	"github.com/zarf-dev/zarf/src/api/v1alpha1"
+	"github.com/zarf-dev/zarf/src/pkg/logger"
+	"github.com/zarf-dev/zarf/src/pkg/transform"
+	zexec "github.com/zarf-dev/zarf/src/pkg/utils/exec"
+	"golang.org/x/sync/errgroup"
+)
+
+// KanikoImage is the image of the kaniko executor that images are built with by the kaniko builder.
+const KanikoImage = "gcr.io/kaniko-project/executor:v1.23.2"
+
+// Paths the build context, Dockerfile and output are mounted at in the kaniko container.
+const (
+	kanikoContextDir    = "/workspace"
+	kanikoDockerfileDir = "/zarf/dockerfile"
+	kanikoOutputDir     = "/zarf/output"
+)
+
+// Binary returns the path of the binary the builder runs. Local buildkit builds prefer the daemonless wrapper of
+// buildctl, which starts a buildkitd for the build, over buildctl, which connects to the buildkitd at BUILDKIT_HOST.
+// Remote buildkit builds always run buildctl.
+func Binary(builder string, remote bool) (string, error) {
+	switch cmp.Or(builder, v1alpha1.ImageBuilderBuildkit) {
+	case v1alpha1.ImageBuilderBuildkit:
+		names := []string{"buildctl-daemonless.sh", "buildctl"}
+		if remote {
+			names = []string{"buildctl"}
+		}
+		for _, name := range names {
+			path, err := exec.LookPath(name)
+			if err == nil {
+				return path, nil
+			}
+		}
+		return "", fmt.Errorf("unable to find %s in the PATH, which are part of the buildkit release", strings.Join(names, " or "))
+	case v1alpha1.ImageBuilderKaniko:
+		if remote {
+			return "", errors.New("the kaniko builder can not run on a remote buildkit, use the buildkit builder")
+		}
+		path, err := exec.LookPath("docker")
+		if err != nil {
+			return "", errors.New("unable to find docker in the PATH, the kaniko builder runs the kaniko executor in docker")
+		}
+		return path, nil
+	default:
+		return "", fmt.Errorf("unknown image builder %s, must be one of buildkit or kaniko", builder)
+	}
+}
+
+// Platform returns the platform the images of the component are built for, which defaults to linux and the
+// architecture of the package.
+func Platform(component v1alpha1.ZarfComponent, arch string) string {
+	return cmp.Or(component.ImagePlatform, fmt.Sprintf("linux/%s", arch))
+}
+
+// WithImages returns the component with the images of its builds added to its images, so that they are included in
+// the package and pushed on deploy like any other image.
+func WithImages(component v1alpha1.ZarfComponent) v1alpha1.ZarfComponent {
+	for _, build := range component.Builds {
+		if !slices.Contains(component.Images, build.Image) {
+			component.Images = append(component.Images, build.Image)
+		}
+	}
+	return component
+}
+
+// BuildComponents builds the images of the components into image tarballs in dir and returns the paths of the
+// tarballs of the platform of the component by image reference. Images that are built for more than one platform also
+// have their tarballs by platform returned by image reference. Relative build contexts are relative to basePath. Builds
+// run on the remote when it is enabled and are spread across the pods of a buildkit pool, one build per pod at a time.
+func BuildComponents(ctx context.Context, components []v1alpha1.ZarfComponent, basePath, arch, dir string, remote Remote) (map[string]string, map[string]map[string]string, error) {
+	l := logger.From(ctx)
+	if err := remote.Validate(); err != nil {
+		return nil, nil, err
+	}
+
+	type job struct {
+		component  string
+		build      v1alpha1.ZarfImageBuild
+		reference  string
+		contextDir string
+		platform   string
+		primary    bool
+		multi      bool
+		dst        string
+	}
+	jobs := []job{}
+	for _, component := range components {
+		platform := Platform(component, arch)
+		for _, build := range component.Builds {
+			refInfo, err := transform.ParseImageRef(build.Image)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to create ref for image %s: %w", build.Image, err)
+			}
+			platforms := build.Platforms
+			if len(platforms) == 0 {
+				platforms = []string{platform}
+			}
+			if !slices.Contains(platforms, platform) {
+				return nil, nil, fmt.Errorf("image %s of component %s is built for the platforms %s, which must include the platform %s of the component",
+					build.Image, component.Name, strings.Join(platforms, ", "), platform)
+			}
+			contextDir := build.Context
+			if !filepath.IsAbs(contextDir) {
+				contextDir = filepath.Join(basePath, contextDir)
+			}
+			// The context is mounted into the kaniko container, which requires an absolute path.
+			contextDir, err = filepath.Abs(contextDir)
+			if err != nil {
+				return nil, nil, err
+			}
+			for _, p := range platforms {
+				jobs = append(jobs, job{
+					component:  component.Name,
+					build:      build,
+					reference:  refInfo.Reference,
+					contextDir: contextDir,
+					platform:   p,
+					primary:    p == platform,
+					multi:      len(platforms) > 1,
+					dst:        filepath.Join(dir, fmt.Sprintf("%d.tar", len(jobs))),
+				})
+			}
+		}
+	}
+	if len(jobs) == 0 {
+		return map[string]string{}, map[string]map[string]string{}, nil
+	}
+
+	addrs, closeRemote, err := remote.connect(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer closeRemote()
+	free := make(chan string, len(addrs))
+	for _, addr := range addrs {
+		free <- addr
+	}
+	eg, ectx := errgroup.WithContext(ctx)
+	eg.SetLimit(len(addrs))
+	for _, j := range jobs {
+		eg.Go(func() error {
+			addr := <-free
+			defer func() { free <- addr }()
+			jobRemote := remote
+			jobRemote.Addr = addr
+			jobRemote.Pool = ""
+			l.Info("building image", "component", j.component, "image", j.reference, "platform", j.platform, "builder", cmp.Or(j.build.Builder, v1alpha1.ImageBuilderBuildkit), "endpoint", cmp.Or(addr, "local"))
+			if err := Build(ectx, j.build, j.contextDir, j.platform, j.dst, jobRemote); err != nil {
+				return fmt.Errorf("component %s: %w", j.component, err)
+			}
+			return nil
+		})
+	}
+	if err := eg.Wait(); err != nil {
+		return nil, nil, err
+	}
+	tarballs := map[string]string{}
+	indexTarballs := map[string]map[string]string{}
+	for _, j := range jobs {
+		if j.primary {
+			tarballs[j.reference] = j.dst
+		}
+		if !j.multi {
+			continue
+		}
+		if indexTarballs[j.reference] == nil {
+			indexTarballs[j.reference] = map[string]string{}
+		}
+		indexTarballs[j.reference][j.platform] = j.dst
+	}
+	return tarballs, indexTarballs, nil
+}
+
+// Build builds the image from the Dockerfile in contextDir for the platform and writes it as an image tarball to dst.
+// Buildkit builds run on the address of the remote when it has one.
+func Build(ctx context.Context, build v1alpha1.ZarfImageBuild, contextDir, platform, dst string, remote Remote) error {
+	bin, err := Binary(build.Builder, remote.Addr != "")
+	if err != nil {
+		return err
+	}
+	if err := helpers.CreateDirectory(filepath.Dir(dst), helpers.ReadWriteExecuteUser); err != nil {
+		return err
+	}
+	var args []string
+	switch cmp.Or(build.Builder, v1alpha1.ImageBuilderBuildkit) {
+	case v1alpha1.ImageBuilderBuildkit:
+		args = append(remote.args(), buildkitArgs(build, contextDir, platform, dst)...)
+	case v1alpha1.ImageBuilderKaniko:
+		args = kanikoArgs(build, contextDir, platform, dst)
+	}
+	_, stderr, err := zexec.CmdWithContext(ctx, zexec.Config{Dir: contextDir}, bin, args...)
+	if err != nil {
+		return fmt.Errorf("unable to build image %s: %w: %s", build.Image, err, strings.TrimSpace(stderr))
+	}
+	return nil
+}
+
+// dockerfilePath returns the path of the Dockerfile of the build.
+func dockerfilePath(build v1alpha1.ZarfImageBuild, contextDir string) string {
+	return filepath.Join(contextDir, cmp.Or(build.Dockerfile, "Dockerfile"))
+}
+
+// buildArgs returns the build arguments of the build as KEY=VALUE sorted by key.
+func buildArgs(build v1alpha1.ZarfImageBuild) []string {
+	args := []string{}
+	for k, v := range build.BuildArgs {
+		args = append(args, fmt.Sprintf("%s=%s", k, v))
+	}
+	slices.Sort(args)
+	return args
+}
+
+func buildkitArgs(build v1alpha1.ZarfImageBuild, contextDir, platform, dst string) []string {
+	dockerfile := dockerfilePath(build, contextDir)
+	args := []string{
+		"build",
+		"--frontend", "dockerfile.v0",
+		"--local", fmt.Sprintf("context=%s", contextDir),
+		"--local", fmt.Sprintf("dockerfile=%s", filepath.Dir(dockerfile)),
+		"--opt", fmt.Sprintf("filename=%s", filepath.Base(dockerfile)),
+		"--opt", fmt.Sprintf("platform=%s", platform),
+	}
+	if build.Target != "" {
+		args = append(args, "--opt", fmt.Sprintf("target=%s", build.Target))
+	}
+	for _, arg := range buildArgs(build) {
+		args = append(args, "--opt", fmt.Sprintf("build-arg:%s", arg))
+	}
+	return append(args, "--output", fmt.Sprintf("type=docker,name=%s,dest=%s", build.Image, dst))
+}
+
+func kanikoArgs(build v1alpha1.ZarfImageBuild, contextDir, platform, dst string) []string {
+	dockerfile := dockerfilePath(build, contextDir)
+	args := []string{
+		"run", "--rm",
+		"--volume", fmt.Sprintf("%s:%s:ro", contextDir, kanikoContextDir),
+		"--volume", fmt.Sprintf("%s:%s:ro", filepath.Dir(dockerfile), kanikoDockerfileDir),
+		"--volume", fmt.Sprintf("%s:%s", filepath.Dir(dst), kanikoOutputDir),
+		KanikoImage,
+		"--context", fmt.Sprintf("dir://%s", kanikoContextDir),
+		"--dockerfile", fmt.Sprintf("%s/%s", kanikoDockerfileDir, filepath.Base(dockerfile)),
+		"--custom-platform", platform,
+		"--destination", build.Image,
+		"--tar-path", fmt.Sprintf("%s/%s", kanikoOutputDir, filepath.Base(dst)),
+		"--no-push",
+		"--reproducible",
+	}
+	if build.Target != "" {
+		args = append(args, "--target", build.Target)
+	}
+	for _, arg := range buildArgs(build) {
+		args = append(args, "--build-arg", arg)
+	}
+	return args
+}
@@ -0,0 +1,322 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package imagebuild
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/zarf-dev/zarf/src/api/v1alpha1"
+	"github.com/zarf-dev/zarf/src/test/testutil"
+)
+
+// fakeBuildctl puts a buildctl binary in the PATH that records its arguments to the returned log.
+func fakeBuildctl(t *testing.T) string {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("the fake buildctl binary is a shell script")
+	}
+	binDir := t.TempDir()
+	log := filepath.Join(t.TempDir(), "buildctl.log")
+	script := "#!/bin/sh\necho \"$*\" >> " + log + "\n"
+	err := os.WriteFile(filepath.Join(binDir, "buildctl"), []byte(script), 0o700)
+	require.NoError(t, err)
+	t.Setenv("PATH", binDir)
+	return log
+}
+
+func TestWithImages(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		component v1alpha1.ZarfComponent
+		expected  []string
+	}{
+		{
+			name:      "no builds",
+			component: v1alpha1.ZarfComponent{Images: []string{"ghcr.io/example/api:1.0.0"}},
+			expected:  []string{"ghcr.io/example/api:1.0.0"},
+		},
+		{
+			name: "builds",
+			component: v1alpha1.ZarfComponent{
+				Images: []string{"ghcr.io/example/api:1.0.0"},
+				Builds: []v1alpha1.ZarfImageBuild{
+					{Image: "ghcr.io/example/api:1.0.0", Context: "api"},
+					{Image: "ghcr.io/example/worker:1.0.0", Context: "worker"},
+				},
+			},
+			expected: []string{"ghcr.io/example/api:1.0.0", "ghcr.io/example/worker:1.0.0"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			component := WithImages(tt.component)
+			require.Equal(t, tt.expected, component.Images)
+		})
+	}
+}
+
+func TestBuildComponents(t *testing.T) {
+	log := fakeBuildctl(t)
+	ctx := testutil.TestContext(t)
+
+	basePath := t.TempDir()
+	err := os.MkdirAll(filepath.Join(basePath, "api"), 0o700)
+	require.NoError(t, err)
+	dir := t.TempDir()
+	components := []v1alpha1.ZarfComponent{
+		{
+			Name: "api",
+			Builds: []v1alpha1.ZarfImageBuild{
+				{
+					Image:      "example/api:1.0.0",
+					Context:    "api",
+					Dockerfile: "build/Dockerfile.api",
+					BuildArgs:  map[string]string{"VERSION": "1.0.0", "GO_VERSION": "1.24"},
+					Target:     "release",
+				},
+			},
+		},
+		{
+			Name:          "windows",
+			ImagePlatform: "windows/amd64",
+			Builds: []v1alpha1.ZarfImageBuild{
+				{Image: "ghcr.io/example/agent:1.0.0", Context: "api"},
+			},
+		},
+		{
+			Name: "multi-arch",
+			Builds: []v1alpha1.ZarfImageBuild{
+				{Image: "ghcr.io/example/worker:1.0.0", Context: "api", Platforms: []string{"linux/amd64", "linux/arm64"}},
+			},
+		},
+	}
+	tarballs, indexTarballs, err := BuildComponents(ctx, components, basePath, "arm64", dir, Remote{})
+	require.NoError(t, err)
+	expectedTarballs := map[string]string{
+		"docker.io/example/api:1.0.0":  filepath.Join(dir, "0.tar"),
+		"ghcr.io/example/agent:1.0.0":  filepath.Join(dir, "1.tar"),
+		"ghcr.io/example/worker:1.0.0": filepath.Join(dir, "3.tar"),
+	}
+	require.Equal(t, expectedTarballs, tarballs)
+	expectedIndexTarballs := map[string]map[string]string{
+		"ghcr.io/example/worker:1.0.0": {
+			"linux/amd64": filepath.Join(dir, "2.tar"),
+			"linux/arm64": filepath.Join(dir, "3.tar"),
+		},
+	}
+	require.Equal(t, expectedIndexTarballs, indexTarballs)
+
+	b, err := os.ReadFile(log)
+	require.NoError(t, err)
+	contextDir := filepath.Join(basePath, "api")
+	expected := []string{
+		strings.Join([]string{
+			"build --frontend dockerfile.v0",
+			"--local context=" + contextDir,
+			"--local dockerfile=" + filepath.Join(contextDir, "build"),
+			"--opt filename=Dockerfile.api --opt platform=linux/arm64 --opt target=release",
+			"--opt build-arg:GO_VERSION=1.24 --opt build-arg:VERSION=1.0.0",
+			"--output type=docker,name=example/api:1.0.0,dest=" + filepath.Join(dir, "0.tar"),
+		}, " "),
+		strings.Join([]string{
+			"build --frontend dockerfile.v0",
+			"--local context=" + contextDir,
+			"--local dockerfile=" + contextDir,
+			"--opt filename=Dockerfile --opt platform=windows/amd64",
+			"--output type=docker,name=ghcr.io/example/agent:1.0.0,dest=" + filepath.Join(dir, "1.tar"),
+		}, " "),
+		strings.Join([]string{
+			"build --frontend dockerfile.v0",
+			"--local context=" + contextDir,
+			"--local dockerfile=" + contextDir,
+			"--opt filename=Dockerfile --opt platform=linux/amd64",
+			"--output type=docker,name=ghcr.io/example/worker:1.0.0,dest=" + filepath.Join(dir, "2.tar"),
+		}, " "),
+		strings.Join([]string{
+			"build --frontend dockerfile.v0",
+			"--local context=" + contextDir,
+			"--local dockerfile=" + contextDir,
+			"--opt filename=Dockerfile --opt platform=linux/arm64",
+			"--output type=docker,name=ghcr.io/example/worker:1.0.0,dest=" + filepath.Join(dir, "3.tar"),
+		}, " "),
+	}
+	require.Equal(t, expected, strings.Split(strings.TrimSpace(string(b)), "\n"))
+
+	components = []v1alpha1.ZarfComponent{
+		{
+			Name: "amd64-only",
+			Builds: []v1alpha1.ZarfImageBuild{
+				{Image: "ghcr.io/example/api:1.0.0", Context: "api", Platforms: []string{"linux/amd64"}},
+			},
+		},
+	}
+	_, _, err = BuildComponents(ctx, components, basePath, "arm64", dir, Remote{})
+	require.EqualError(t, err, "image ghcr.io/example/api:1.0.0 of component amd64-only is built for the platforms linux/amd64, which must include the platform linux/arm64 of the component")
+}
+
+func TestKanikoArgs(t *testing.T) {
+	t.Parallel()
+
+	build := v1alpha1.ZarfImageBuild{
+		Image:     "ghcr.io/example/api:1.0.0",
+		Context:   "api",
+		BuildArgs: map[string]string{"VERSION": "1.0.0"},
+		Builder:   v1alpha1.ImageBuilderKaniko,
+	}
+	args := kanikoArgs(build, "/src/api", "linux/amd64", "/tmp/images/0.tar")
+	expected := []string{
+		"run", "--rm",
+		"--volume", "/src/api:/workspace:ro",
+		"--volume", "/src/api:/zarf/dockerfile:ro",
+		"--volume", "/tmp/images:/zarf/output",
+		KanikoImage,
+		"--context", "dir:///workspace",
+		"--dockerfile", "/zarf/dockerfile/Dockerfile",
+		"--custom-platform", "linux/amd64",
+		"--destination", "ghcr.io/example/api:1.0.0",
+		"--tar-path", "/zarf/output/0.tar",
+		"--no-push",
+		"--reproducible",
+		"--build-arg", "VERSION=1.0.0",
+	}
+	require.Equal(t, expected, args)
+}
+
+func TestBuildRemote(t *testing.T) {
+	log := fakeBuildctl(t)
+	ctx := testutil.TestContext(t)
+
+	contextDir := t.TempDir()
+	dst := filepath.Join(t.TempDir(), "0.tar")
+	remote := Remote{
+		Addr:          "tcp://buildkitd.example.com:1234",
+		TLSCACert:     "/certs/ca.pem",
+		TLSCert:       "/certs/cert.pem",
+		TLSKey:        "/certs/key.pem",
+		TLSServerName: "buildkitd",
+	}
+	build := v1alpha1.ZarfImageBuild{Image: "ghcr.io/example/api:1.0.0", Context: "api"}
+	err := Build(ctx, build, contextDir, "linux/amd64", dst, remote)
+	require.NoError(t, err)
+
+	b, err := os.ReadFile(log)
+	require.NoError(t, err)
+	expected := strings.Join([]string{
+		"--addr tcp://buildkitd.example.com:1234",
+		"--tlscacert /certs/ca.pem --tlscert /certs/cert.pem --tlskey /certs/key.pem --tlsservername buildkitd",
+		"build --frontend dockerfile.v0",
+		"--local context=" + contextDir,
+		"--local dockerfile=" + contextDir,
+		"--opt filename=Dockerfile --opt platform=linux/amd64",
+		"--output type=docker,name=ghcr.io/example/api:1.0.0,dest=" + dst,
+	}, " ")
+	require.Equal(t, expected, strings.TrimSpace(string(b)))
+
+	build.Builder = v1alpha1.ImageBuilderKaniko
+	err = Build(ctx, build, contextDir, "linux/amd64", dst, remote)
+	require.EqualError(t, err, "the kaniko builder can not run on a remote buildkit, use the buildkit builder")
+}
+
+func TestRemoteValidate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		remote      Remote
+		expectedErr string
+	}{
+		{
+			name:   "local",
+			remote: Remote{},
+		},
+		{
+			name:   "address with TLS",
+			remote: Remote{Addr: "tcp://buildkitd:1234", TLSCACert: "ca.pem", TLSCert: "cert.pem", TLSKey: "key.pem"},
+		},
+		{
+			name:   "pool",
+			remote: Remote{Pool: "app=buildkitd", TLSServerName: "buildkitd"},
+		},
+		{
+			name:        "address and pool",
+			remote:      Remote{Addr: "tcp://buildkitd:1234", Pool: "app=buildkitd"},
+			expectedErr: "a buildkit address and a buildkit pool can not be used together",
+		},
+		{
+			name:        "address without scheme",
+			remote:      Remote{Addr: "buildkitd:1234"},
+			expectedErr: "buildkit address buildkitd:1234 must include a scheme such as tcp://",
+		},
+		{
+			name:        "TLS without remote",
+			remote:      Remote{TLSCACert: "ca.pem"},
+			expectedErr: "buildkit TLS options require a buildkit address or a buildkit pool",
+		},
+		{
+			name:        "certificate without key",
+			remote:      Remote{Addr: "tcp://buildkitd:1234", TLSCert: "cert.pem"},
+			expectedErr: "a buildkit TLS certificate and key must be used together",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tt.remote.Validate()
+			if tt.expectedErr != "" {
+				require.EqualError(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestReadyPods(t *testing.T) {
+	t.Parallel()
+	ctx := testutil.TestContext(t)
+
+	newPod := func(name string, phase corev1.PodPhase, ready corev1.ConditionStatus) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "buildkit", Labels: map[string]string{"app": "buildkitd"}},
+			Status: corev1.PodStatus{
+				Phase:      phase,
+				Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: ready}},
+			},
+		}
+	}
+	cs := fake.NewClientset(
+		newPod("buildkitd-b", corev1.PodRunning, corev1.ConditionTrue),
+		newPod("buildkitd-a", corev1.PodRunning, corev1.ConditionTrue),
+		newPod("buildkitd-c", corev1.PodRunning, corev1.ConditionFalse),
+		newPod("buildkitd-d", corev1.PodPending, corev1.ConditionFalse),
+	)
+
+	pods, err := readyPods(ctx, cs, "buildkit", "app=buildkitd")
+	require.NoError(t, err)
+	require.Equal(t, []string{"buildkitd-a", "buildkitd-b"}, pods)
+
+	_, err = readyPods(ctx, cs, "buildkit", "app=other")
+	require.EqualError(t, err, "the buildkit pool has no ready pods in namespace buildkit matching app=other")
+}
+
+func TestBinary(t *testing.T) {
+	t.Parallel()
+
+	_, err := Binary("docker-build", false)
+	require.EqualError(t, err, "unknown image builder docker-build, must be one of buildkit or kaniko")
+}
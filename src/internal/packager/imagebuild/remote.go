@@ -0,0 +1,154 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package imagebuild
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/zarf-dev/zarf/src/pkg/cluster"
+	"github.com/zarf-dev/zarf/src/pkg/logger"
+)
+
+const (
+	// DefaultPoolNamespace is the namespace of the buildkitd pods of a pool when none is given.
+	DefaultPoolNamespace = "buildkit"
+	// PoolPort is the port the buildkitd pods of a pool listen on.
+	PoolPort = 1234
+)
+
+// Remote are the buildkitd endpoints buildkit builds run on instead of a buildkitd that is started on this machine.
+type Remote struct {
+	// Addr is the address of a buildkitd, such as tcp://buildkitd.example.com:1234.
+	Addr string
+	// Pool is the label selector of buildkitd pods in the cluster that builds are spread across.
+	Pool string
+	// PoolNamespace is the namespace of the pods of the pool, defaults to buildkit.
+	PoolNamespace string
+	// TLSCACert is the CA certificate the buildkitd certificates are verified with.
+	TLSCACert string
+	// TLSCert is the client certificate presented to buildkitd.
+	TLSCert string
+	// TLSKey is the key of the client certificate.
+	TLSKey string
+	// TLSServerName is the name the buildkitd certificates are verified for, such as the name of the service in front
+	// of a pool.
+	TLSServerName string
+}
+
+// Enabled returns whether builds run on a remote buildkitd.
+func (r Remote) Enabled() bool {
+	return r.Addr != "" || r.Pool != ""
+}
+
+// Validate checks that the remote selects one kind of endpoint and only sets TLS options for one.
+func (r Remote) Validate() error {
+	if r.Addr != "" && r.Pool != "" {
+		return errors.New("a buildkit address and a buildkit pool can not be used together")
+	}
+	if r.Addr != "" && !strings.Contains(r.Addr, "://") {
+		return fmt.Errorf("buildkit address %s must include a scheme such as tcp://", r.Addr)
+	}
+	hasTLS := r.TLSCACert != "" || r.TLSCert != "" || r.TLSKey != "" || r.TLSServerName != ""
+	if hasTLS && !r.Enabled() {
+		return errors.New("buildkit TLS options require a buildkit address or a buildkit pool")
+	}
+	if (r.TLSCert == "") != (r.TLSKey == "") {
+		return errors.New("a buildkit TLS certificate and key must be used together")
+	}
+	return nil
+}
+
+// args returns the global buildctl flags that connect to the address of the remote with its TLS options.
+func (r Remote) args() []string {
+	if r.Addr == "" {
+		return nil
+	}
+	args := []string{"--addr", r.Addr}
+	if r.TLSCACert != "" {
+		args = append(args, "--tlscacert", r.TLSCACert)
+	}
+	if r.TLSCert != "" {
+		args = append(args, "--tlscert", r.TLSCert, "--tlskey", r.TLSKey)
+	}
+	if r.TLSServerName != "" {
+		args = append(args, "--tlsservername", r.TLSServerName)
+	}
+	return args
+}
+
+// connect returns the addresses builds run on and a function that closes the connections to them. Without a remote
+// builds run on the local machine, which is returned as an empty address.
+func (r Remote) connect(ctx context.Context) ([]string, func(), error) {
+	if r.Pool == "" {
+		return []string{r.Addr}, func() {}, nil
+	}
+	l := logger.From(ctx)
+	c, err := cluster.NewCluster()
+	if err != nil {
+		return nil, nil, err
+	}
+	namespace := r.PoolNamespace
+	if namespace == "" {
+		namespace = DefaultPoolNamespace
+	}
+	pods, err := readyPods(ctx, c.Clientset, namespace, r.Pool)
+	if err != nil {
+		return nil, nil, err
+	}
+	tunnels := []*cluster.Tunnel{}
+	closeTunnels := func() {
+		for _, tunnel := range tunnels {
+			tunnel.Close()
+		}
+	}
+	addrs := []string{}
+	for _, pod := range pods {
+		tunnel, err := c.NewTunnel(namespace, cluster.PodResource, pod, "", 0, PoolPort)
+		if err != nil {
+			closeTunnels()
+			return nil, nil, err
+		}
+		if _, err := tunnel.Connect(ctx); err != nil {
+			closeTunnels()
+			return nil, nil, fmt.Errorf("unable to connect to buildkitd pod %s: %w", pod, err)
+		}
+		tunnels = append(tunnels, tunnel)
+		addrs = append(addrs, fmt.Sprintf("tcp://%s", tunnel.Endpoint()))
+	}
+	l.Info("connected to the buildkit pool", "namespace", namespace, "selector", r.Pool, "pods", len(pods))
+	return addrs, closeTunnels, nil
+}
+
+// readyPods returns the names of the running and ready pods in the namespace that match the label selector.
+func readyPods(ctx context.Context, clientset kubernetes.Interface, namespace, selector string) ([]string, error) {
+	podList, err := clientset.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		return nil, fmt.Errorf("unable to list the pods of the buildkit pool: %w", err)
+	}
+	pods := []string{}
+	for _, pod := range podList.Items {
+		if pod.Status.Phase != corev1.PodRunning || pod.DeletionTimestamp != nil {
+			continue
+		}
+		ready := slices.ContainsFunc(pod.Status.Conditions, func(cond corev1.PodCondition) bool {
+			return cond.Type == corev1.PodReady && cond.Status == corev1.ConditionTrue
+		})
+		if ready {
+			pods = append(pods, pod.Name)
+		}
+	}
+	if len(pods) == 0 {
+		return nil, fmt.Errorf("the buildkit pool has no ready pods in namespace %s matching %s", namespace, selector)
+	}
+	slices.Sort(pods)
+	return pods, nil
+}
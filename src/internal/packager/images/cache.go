@@ -0,0 +1,271 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package images provides functions for building and pushing images.
+package images
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/defenseunicorns/pkg/helpers/v2"
+	"github.com/gofrs/flock"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/cache"
+	"github.com/google/go-containerregistry/pkg/v1/tarball"
+
+	"github.com/zarf-dev/zarf/src/internal/remotecache"
+	"github.com/zarf-dev/zarf/src/pkg/logger"
+)
+
+// layerCache is a filesystem layer cache that can be shared by parallel Zarf invocations. It stores layers at the same
+// paths as cache.NewFilesystemCache, but a layer is only downloaded by one process at a time while the others wait
+// for it, and it is written to a temporary file that is renamed into place once its digest is verified so that a
+// cached layer is never partial. Layers that are not cached locally are read through the remote cache when one is
+// configured.
+type layerCache struct {
+	ctx    context.Context
+	path   string
+	remote remotecache.Store
+}
+
+// newLayerCache returns a layer cache in the given directory backed by the optional remote cache.
+func newLayerCache(ctx context.Context, path string, remote remotecache.Store) cache.Cache {
+	return &layerCache{ctx: ctx, path: path, remote: remote}
+}
+
+// remoteLayerKey returns the key of the layer with the hash in the remote cache.
+func remoteLayerKey(h v1.Hash) string {
+	return fmt.Sprintf("layer-%s-%s", h.Algorithm, h.Hex)
+}
+
+// Put implements cache.Cache.
+func (c *layerCache) Put(l v1.Layer) (v1.Layer, error) {
+	digest, err := l.Digest()
+	if err != nil {
+		return nil, err
+	}
+	diffID, err := l.DiffID()
+	if err != nil {
+		return nil, err
+	}
+	return &cachingLayer{
+		Layer:  l,
+		cache:  c,
+		digest: digest,
+		diffID: diffID,
+	}, nil
+}
+
+// Get implements cache.Cache.
+func (c *layerCache) Get(h v1.Hash) (v1.Layer, error) {
+	l, err := tarball.LayerFromFile(layerCachePath(c.path, h))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, cache.ErrNotFound
+	}
+	// Layers written by older versions of Zarf can be incomplete.
+	if errors.Is(err, io.ErrUnexpectedEOF) {
+		if err := c.Delete(h); err != nil && !errors.Is(err, cache.ErrNotFound) {
+			return nil, err
+		}
+		return nil, cache.ErrNotFound
+	}
+	return l, err
+}
+
+// Delete implements cache.Cache.
+func (c *layerCache) Delete(h v1.Hash) error {
+	lock, err := lockLayer(c.path, h)
+	if err != nil {
+		return err
+	}
+	defer lock.Unlock() //nolint:errcheck
+	err = os.Remove(layerCachePath(c.path, h))
+	if errors.Is(err, fs.ErrNotExist) {
+		return cache.ErrNotFound
+	}
+	return err
+}
+
+// lockLayer takes the lock of the cache entry, waiting for any other process that holds it.
+func lockLayer(path string, h v1.Hash) (*flock.Flock, error) {
+	err := helpers.CreateDirectory(path, helpers.ReadWriteExecuteUser)
+	if err != nil {
+		return nil, err
+	}
+	lock := flock.New(layerCachePath(path, h)+".lock", flock.SetPermissions(helpers.ReadWriteUser))
+	err = lock.Lock()
+	if err != nil {
+		return nil, fmt.Errorf("unable to lock the cache entry of layer %s: %w", h, err)
+	}
+	return lock, nil
+}
+
+// cachingLayer writes the layer to the cache as it is read.
+type cachingLayer struct {
+	v1.Layer
+	cache          *layerCache
+	digest, diffID v1.Hash
+}
+
+// Compressed implements v1.Layer.
+func (l *cachingLayer) Compressed() (io.ReadCloser, error) {
+	return l.open(l.digest, l.Layer.Compressed)
+}
+
+// Uncompressed implements v1.Layer.
+func (l *cachingLayer) Uncompressed() (io.ReadCloser, error) {
+	return l.open(l.diffID, l.Layer.Uncompressed)
+}
+
+func (l *cachingLayer) open(h v1.Hash, read func() (io.ReadCloser, error)) (io.ReadCloser, error) {
+	path := layerCachePath(l.cache.path, h)
+	lock, err := lockLayer(l.cache.path, h)
+	if err != nil {
+		return nil, err
+	}
+	// Another process may have cached the layer while this one waited for the lock.
+	if !helpers.InvalidPath(path) || l.cache.getRemote(h) {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, errors.Join(err, lock.Unlock())
+		}
+		if err := lock.Unlock(); err != nil {
+			return nil, errors.Join(err, f.Close())
+		}
+		return f, nil
+	}
+
+	hasher, err := v1.Hasher(h.Algorithm)
+	if err != nil {
+		return nil, errors.Join(err, lock.Unlock())
+	}
+	tmp, err := os.CreateTemp(l.cache.path, filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return nil, errors.Join(err, lock.Unlock())
+	}
+	rc, err := read()
+	if err != nil {
+		return nil, errors.Join(err, tmp.Close(), os.Remove(tmp.Name()), lock.Unlock())
+	}
+	return &cacheWriter{
+		rc:     rc,
+		r:      io.TeeReader(rc, io.MultiWriter(tmp, hasher)),
+		tmp:    tmp,
+		hasher: hasher,
+		hash:   h,
+		path:   path,
+		lock:   lock,
+		cache:  l.cache,
+	}, nil
+}
+
+// getRemote reads the layer with the hash through the remote cache into the local cache and reports whether it was
+// found. The caller must hold the lock of the layer.
+func (c *layerCache) getRemote(h v1.Hash) bool {
+	if c.remote == nil {
+		return false
+	}
+	l := logger.From(c.ctx)
+	tmp, err := os.CreateTemp(c.path, filepath.Base(layerCachePath(c.path, h))+".*.tmp")
+	if err != nil {
+		l.Warn("unable to read layer from the remote cache", "digest", h.String(), "error", err)
+		return false
+	}
+	// The temporary file is renamed into place once verified.
+	defer os.Remove(tmp.Name())
+	err = tmp.Close()
+	if err == nil {
+		err = c.remote.Get(c.ctx, remoteLayerKey(h), tmp.Name())
+	}
+	if errors.Is(err, remotecache.ErrNotFound) {
+		return false
+	}
+	if err == nil {
+		err = verifyFile(tmp.Name(), h)
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), layerCachePath(c.path, h))
+	}
+	if err != nil {
+		l.Warn("unable to read layer from the remote cache", "digest", h.String(), "error", err)
+		return false
+	}
+	l.Debug("read layer from the remote cache", "digest", h.String())
+	return true
+}
+
+// putRemote writes the cached layer with the hash to the remote cache.
+func (c *layerCache) putRemote(h v1.Hash) {
+	if c.remote == nil {
+		return
+	}
+	err := c.remote.Put(c.ctx, remoteLayerKey(h), layerCachePath(c.path, h))
+	if err != nil {
+		// A failure to share the layer does not affect the current pull.
+		logger.From(c.ctx).Warn("unable to write layer to the remote cache", "digest", h.String(), "error", err)
+	}
+}
+
+// verifyFile checks that the file matches the hash.
+func verifyFile(path string, h v1.Hash) error {
+	hasher, err := v1.Hasher(h.Algorithm)
+	if err != nil {
+		return err
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	_, err = io.Copy(hasher, f)
+	if err != nil {
+		return err
+	}
+	if fmt.Sprintf("%x", hasher.Sum(nil)) != h.Hex {
+		return fmt.Errorf("content does not match %s", h)
+	}
+	return nil
+}
+
+// cacheWriter copies a layer into a temporary file while it is read and moves it into the cache when the whole layer
+// was read and matches its hash.
+type cacheWriter struct {
+	rc     io.ReadCloser
+	r      io.Reader
+	tmp    *os.File
+	hasher hash.Hash
+	hash   v1.Hash
+	path   string
+	lock   *flock.Flock
+	cache  *layerCache
+	done   bool
+}
+
+func (w *cacheWriter) Read(b []byte) (int, error) {
+	n, err := w.r.Read(b)
+	if errors.Is(err, io.EOF) {
+		w.done = true
+	}
+	return n, err
+}
+
+func (w *cacheWriter) Close() error {
+	defer w.lock.Unlock() //nolint:errcheck
+	err := errors.Join(w.rc.Close(), w.tmp.Close())
+	if err != nil || !w.done || fmt.Sprintf("%x", w.hasher.Sum(nil)) != w.hash.Hex {
+		return errors.Join(err, os.Remove(w.tmp.Name()))
+	}
+	err = os.Rename(w.tmp.Name(), w.path)
+	if err != nil {
+		return err
+	}
+	w.cache.putRemote(w.hash)
+	return nil
+}
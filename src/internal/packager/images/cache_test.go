@@ -0,0 +1,133 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package images
+
+import (
+	"context"
+	"io"
+	"os"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/cache"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// countingLayer counts how often the compressed contents of the layer are read.
+type countingLayer struct {
+	v1.Layer
+	reads atomic.Int32
+}
+
+func (l *countingLayer) Compressed() (io.ReadCloser, error) {
+	l.reads.Add(1)
+	return l.Layer.Compressed()
+}
+
+func TestLayerCache(t *testing.T) {
+	t.Parallel()
+
+	t.Run("cache fully read layers", func(t *testing.T) {
+		t.Parallel()
+		dir := t.TempDir()
+		layer, err := random.Layer(1024, types.DockerLayer)
+		require.NoError(t, err)
+		digest, err := layer.Digest()
+		require.NoError(t, err)
+
+		c := newLayerCache(context.Background(), dir, nil)
+		_, err = c.Get(digest)
+		require.ErrorIs(t, err, cache.ErrNotFound)
+
+		cl, err := c.Put(layer)
+		require.NoError(t, err)
+		rc, err := cl.Compressed()
+		require.NoError(t, err)
+		_, err = io.Copy(io.Discard, rc)
+		require.NoError(t, err)
+		require.NoError(t, rc.Close())
+
+		cached, err := c.Get(digest)
+		require.NoError(t, err)
+		cachedDigest, err := cached.Digest()
+		require.NoError(t, err)
+		require.Equal(t, digest, cachedDigest)
+
+		require.NoError(t, c.Delete(digest))
+		_, err = c.Get(digest)
+		require.ErrorIs(t, err, cache.ErrNotFound)
+	})
+
+	t.Run("discard partially read layers", func(t *testing.T) {
+		t.Parallel()
+		dir := t.TempDir()
+		layer, err := random.Layer(1024, types.DockerLayer)
+		require.NoError(t, err)
+		digest, err := layer.Digest()
+		require.NoError(t, err)
+
+		c := newLayerCache(context.Background(), dir, nil)
+		cl, err := c.Put(layer)
+		require.NoError(t, err)
+		rc, err := cl.Compressed()
+		require.NoError(t, err)
+		_, err = rc.Read(make([]byte, 10))
+		require.NoError(t, err)
+		require.NoError(t, rc.Close())
+
+		_, err = c.Get(digest)
+		require.ErrorIs(t, err, cache.ErrNotFound)
+		entries, err := os.ReadDir(dir)
+		require.NoError(t, err)
+		for _, entry := range entries {
+			require.NotContains(t, entry.Name(), ".tmp")
+		}
+	})
+
+	t.Run("download shared layers once", func(t *testing.T) {
+		t.Parallel()
+		dir := t.TempDir()
+		rl, err := random.Layer(1024*1024, types.DockerLayer)
+		require.NoError(t, err)
+		layer := &countingLayer{Layer: rl}
+
+		// Every cache stands in for a separate Zarf process sharing the cache directory.
+		var wg sync.WaitGroup
+		for range 5 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				img := cache.Image(&singleLayerImage{layer: layer}, newLayerCache(context.Background(), dir, nil))
+				layers, err := img.Layers()
+				if !assert.NoError(t, err) {
+					return
+				}
+				rc, err := layers[0].Compressed()
+				if !assert.NoError(t, err) {
+					return
+				}
+				defer rc.Close()
+				_, err = io.Copy(io.Discard, rc)
+				assert.NoError(t, err)
+			}()
+		}
+		wg.Wait()
+		require.Equal(t, int32(1), layer.reads.Load())
+	})
+}
+
+// singleLayerImage is the minimal image cache.Image needs to wrap a layer.
+type singleLayerImage struct {
+	v1.Image
+	layer v1.Layer
+}
+
+func (i *singleLayerImage) Layers() ([]v1.Layer, error) {
+	return []v1.Layer{i.layer}, nil
+}
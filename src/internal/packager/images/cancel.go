@@ -0,0 +1,99 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package images provides functions for building and pushing images.
+package images
+
+import (
+	"context"
+	"io"
+
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+)
+
+// cancelableImage stops reading the layers of the image once the context is cancelled, so that writing the image to a
+// layout aborts instead of finishing the layer in progress.
+type cancelableImage struct {
+	v1.Image
+	ctx context.Context
+}
+
+// withContext returns the image with layers that stop reading once the context is cancelled.
+func withContext(ctx context.Context, img v1.Image) v1.Image {
+	return &cancelableImage{Image: img, ctx: ctx}
+}
+
+// Layers implements v1.Image.
+func (i *cancelableImage) Layers() ([]v1.Layer, error) {
+	layers, err := i.Image.Layers()
+	if err != nil {
+		return nil, err
+	}
+	wrapped := make([]v1.Layer, 0, len(layers))
+	for _, layer := range layers {
+		wrapped = append(wrapped, &cancelableLayer{Layer: layer, ctx: i.ctx})
+	}
+	return wrapped, nil
+}
+
+// LayerByDigest implements v1.Image.
+func (i *cancelableImage) LayerByDigest(h v1.Hash) (v1.Layer, error) {
+	layer, err := i.Image.LayerByDigest(h)
+	if err != nil {
+		return nil, err
+	}
+	return &cancelableLayer{Layer: layer, ctx: i.ctx}, nil
+}
+
+// LayerByDiffID implements v1.Image.
+func (i *cancelableImage) LayerByDiffID(h v1.Hash) (v1.Layer, error) {
+	layer, err := i.Image.LayerByDiffID(h)
+	if err != nil {
+		return nil, err
+	}
+	return &cancelableLayer{Layer: layer, ctx: i.ctx}, nil
+}
+
+// cancelableLayer stops reading the layer once the context is cancelled.
+type cancelableLayer struct {
+	v1.Layer
+	ctx context.Context
+}
+
+// Compressed implements v1.Layer.
+func (l *cancelableLayer) Compressed() (io.ReadCloser, error) {
+	if err := l.ctx.Err(); err != nil {
+		return nil, err
+	}
+	rc, err := l.Layer.Compressed()
+	if err != nil {
+		return nil, err
+	}
+	return &cancelableReader{ReadCloser: rc, ctx: l.ctx}, nil
+}
+
+// Uncompressed implements v1.Layer.
+func (l *cancelableLayer) Uncompressed() (io.ReadCloser, error) {
+	if err := l.ctx.Err(); err != nil {
+		return nil, err
+	}
+	rc, err := l.Layer.Uncompressed()
+	if err != nil {
+		return nil, err
+	}
+	return &cancelableReader{ReadCloser: rc, ctx: l.ctx}, nil
+}
+
+// cancelableReader returns the error of the context from Read once it is cancelled.
+type cancelableReader struct {
+	io.ReadCloser
+	ctx context.Context
+}
+
+// Read implements io.Reader.
+func (r *cancelableReader) Read(p []byte) (int, error) {
+	if err := r.ctx.Err(); err != nil {
+		return 0, err
+	}
+	return r.ReadCloser.Read(p)
+}
@@ -5,6 +5,8 @@
 package images
 
 import (
+	"crypto/tls"
+	"crypto/x509"
 	"net/http"
 	"time"
 
@@ -13,6 +15,7 @@ import (
 	"github.com/google/go-containerregistry/pkg/crane"
 	v1 "github.com/google/go-containerregistry/pkg/v1"
 	"github.com/zarf-dev/zarf/src/config"
+	"github.com/zarf-dev/zarf/src/internal/remotecache"
 	"github.com/zarf-dev/zarf/src/pkg/transform"
 	"github.com/zarf-dev/zarf/src/types"
 )
@@ -27,7 +30,31 @@ type PullConfig struct {
 
 	RegistryOverrides map[string]string
 
+	// ImageRegistryOverrides are the registry overrides of images by reference that are used instead of RegistryOverrides.
+	ImageRegistryOverrides map[string]map[string]string
+
+	// ImagePlatforms are the platforms of images by reference that are pulled for another platform than linux/Arch.
+	ImagePlatforms map[string]string
+
+	// ImageTarballs are the image tarballs by reference that images built on create are loaded from instead of being
+	// pulled.
+	ImageTarballs map[string]string
+
+	// ImageIndexTarballs are the image tarballs by platform of images built on create for more than one platform, by
+	// reference. They are assembled into a manifest list that is saved next to the image loaded from ImageTarballs.
+	ImageIndexTarballs map[string]map[string]string
+
+	// IncludePlatforms are the platforms of multi-platform images that are kept in an image index next to the
+	// platform the image is pulled for.
+	IncludePlatforms []string
+
 	CacheDirectory string
+
+	// RemoteCache is read through when layers are not in the cache directory and stores the layers that are pulled.
+	RemoteCache remotecache.Store
+
+	// MemoryBudget is the number of bytes of heap image saving should stay under, or zero for no budget.
+	MemoryBudget int64
 }
 
 // PushConfig is the configuration for pushing images.
@@ -43,6 +70,9 @@ type PushConfig struct {
 	Arch string
 
 	Retries int
+
+	// Mirror is the registry images that are not in the source directory are pulled from, e.g. oci://mirror.internal/zarf
+	Mirror string
 }
 
 // NoopOpt is a no-op option for crane.
@@ -97,18 +127,49 @@ func WithPushAuth(ri types.RegistryInfo) crane.Option {
 	return WithBasicAuth(ri.PushUsername, ri.PushPassword)
 }
 
+// WithRegistryCA returns an option for crane that trusts the CA bundle of a given registry info in addition to the
+// system roots.
+func WithRegistryCA(ri types.RegistryInfo) crane.Option {
+	ca := ri.TLS.CABundle()
+	if ca == nil {
+		return NoopOpt
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = &tls.Config{
+		RootCAs:            rootCAs(ca),
+		InsecureSkipVerify: config.CommonOptions.InsecureSkipTLSVerify,
+		MinVersion:         tls.VersionTLS12,
+	}
+	return crane.WithTransport(transport)
+}
+
+// rootCAs returns the system roots extended with the CA bundle.
+func rootCAs(caBundle []byte) *x509.CertPool {
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		pool = x509.NewCertPool()
+	}
+	pool.AppendCertsFromPEM(caBundle)
+	return pool
+}
+
 func createPushOpts(cfg PushConfig) []crane.Option {
 	opts := CommonOpts(cfg.Arch)
 	opts = append(opts, WithPushAuth(cfg.RegInfo))
+	opts = append(opts, crane.WithTransport(PushTransport(cfg.RegInfo)))
+	return opts
+}
 
+// PushTransport returns the transport images are pushed to the registry of a given registry info with, which trusts
+// the CA bundle of the registry in addition to the system roots.
+func PushTransport(ri types.RegistryInfo) http.RoundTripper {
 	defaultTransport := http.DefaultTransport.(*http.Transport).Clone()
 	defaultTransport.TLSClientConfig.InsecureSkipVerify = config.CommonOptions.InsecureSkipTLSVerify
+	if ca := ri.TLS.CABundle(); ca != nil {
+		defaultTransport.TLSClientConfig.RootCAs = rootCAs(ca)
+	}
 	// TODO (@WSTARR) This is set to match the TLSHandshakeTimeout to potentially mitigate effects of https://github.com/zarf-dev/zarf/issues/1444
 	defaultTransport.ResponseHeaderTimeout = 10 * time.Second
 
-	transport := helpers.NewTransport(defaultTransport, nil)
-
-	opts = append(opts, crane.WithTransport(transport))
-
-	return opts
+	return helpers.NewTransport(defaultTransport, nil)
 }
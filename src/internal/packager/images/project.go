@@ -0,0 +1,195 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package images provides functions for building and pushing images.
+package images
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/zarf-dev/zarf/src/config"
+	"github.com/zarf-dev/zarf/src/pkg/logger"
+	"github.com/zarf-dev/zarf/src/pkg/transform"
+	"github.com/zarf-dev/zarf/src/types"
+)
+
+// Registry APIs that projects are created with before pushing images.
+const (
+	// ProjectAPIHarbor creates Harbor projects
+	ProjectAPIHarbor = "harbor"
+	// ProjectAPIQuay creates Quay organizations
+	ProjectAPIQuay = "quay"
+)
+
+// ProjectAPIs are the supported registry project APIs.
+var ProjectAPIs = []string{ProjectAPIHarbor, ProjectAPIQuay}
+
+// CreateProjects creates the projects the images are pushed to in the registry when it has a project API configured.
+// The project of an image is the first component of its path in the registry.
+func CreateProjects(ctx context.Context, regInfo types.RegistryInfo, imageList []transform.Image) error {
+	if regInfo.ProjectAPI == "" {
+		return nil
+	}
+	projects, err := registryProjects(regInfo, imageList)
+	if err != nil {
+		return err
+	}
+	pc, err := newProjectClient(regInfo, config.CommonOptions.PlainHTTP, config.CommonOptions.InsecureSkipTLSVerify)
+	if err != nil {
+		return err
+	}
+	for _, project := range projects {
+		err := pc.ensure(ctx, project)
+		if err != nil {
+			return fmt.Errorf("unable to create the %s project %s: %w", regInfo.ProjectAPI, project, err)
+		}
+	}
+	return nil
+}
+
+// registryProjects returns the projects the images are pushed to in the registry.
+func registryProjects(regInfo types.RegistryInfo, imageList []transform.Image) ([]string, error) {
+	// The address may include a path, which is then the project of every image.
+	host, _, _ := strings.Cut(regInfo.Address, "/")
+	projects := []string{}
+	for _, refInfo := range imageList {
+		name, err := transform.ImageTransformHostWithoutChecksum(regInfo.Address, refInfo.Reference, regInfo.ImageRules...)
+		if err != nil {
+			return nil, err
+		}
+		project, _, ok := strings.Cut(strings.TrimPrefix(name, host+"/"), "/")
+		if !ok || slices.Contains(projects, project) {
+			continue
+		}
+		projects = append(projects, project)
+	}
+	return projects, nil
+}
+
+type projectClient struct {
+	api      string
+	baseURL  *url.URL
+	username string
+	password string
+	client   *http.Client
+}
+
+func newProjectClient(regInfo types.RegistryInfo, plainHTTP, insecureSkipTLSVerify bool) (*projectClient, error) {
+	if !slices.Contains(ProjectAPIs, regInfo.ProjectAPI) {
+		return nil, fmt.Errorf("unsupported registry project API %s, must be one of %s", regInfo.ProjectAPI, strings.Join(ProjectAPIs, ", "))
+	}
+	scheme := "https"
+	if plainHTTP {
+		scheme = "http"
+	}
+	// Projects are created on the registry host, the path of the address is the parent of the images.
+	host, _, _ := strings.Cut(regInfo.Address, "/")
+	baseURL, err := url.Parse(fmt.Sprintf("%s://%s", scheme, host))
+	if err != nil {
+		return nil, err
+	}
+	username, password := regInfo.ProjectUsername, regInfo.ProjectPassword
+	if username == "" && password == "" {
+		username, password = regInfo.PushUsername, regInfo.PushPassword
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = &tls.Config{
+		RootCAs:            rootCAs(regInfo.TLS.CABundle()),
+		InsecureSkipVerify: insecureSkipTLSVerify,
+		MinVersion:         tls.VersionTLS12,
+	}
+	return &projectClient{
+		api:      regInfo.ProjectAPI,
+		baseURL:  baseURL,
+		username: username,
+		password: password,
+		client:   &http.Client{Transport: transport},
+	}, nil
+}
+
+// ensure creates the project when it does not exist.
+func (pc *projectClient) ensure(ctx context.Context, project string) error {
+	var existsMethod, existsPath, createPath string
+	var body any
+	switch pc.api {
+	case ProjectAPIHarbor:
+		existsMethod = http.MethodHead
+		existsPath = "/api/v2.0/projects?project_name=" + url.QueryEscape(project)
+		createPath = "/api/v2.0/projects"
+		body = map[string]any{"project_name": project, "public": false}
+	case ProjectAPIQuay:
+		existsMethod = http.MethodGet
+		existsPath = "/api/v1/organization/" + url.PathEscape(project)
+		createPath = "/api/v1/organization/"
+		body = map[string]any{"name": project}
+	}
+
+	statusCode, err := pc.do(ctx, existsMethod, existsPath, nil)
+	if err != nil {
+		return err
+	}
+	if statusCode == http.StatusOK {
+		return nil
+	}
+	statusCode, err = pc.do(ctx, http.MethodPost, createPath, body)
+	if err != nil {
+		return err
+	}
+	switch statusCode {
+	case http.StatusCreated, http.StatusOK:
+		logger.From(ctx).Info("created registry project", "api", pc.api, "project", project)
+		return nil
+	// The project was created by someone else in the meantime.
+	case http.StatusConflict:
+		return nil
+	default:
+		return fmt.Errorf("unexpected status code %d", statusCode)
+	}
+}
+
+func (pc *projectClient) do(ctx context.Context, method, path string, body any) (int, error) {
+	var r io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return 0, err
+		}
+		r = bytes.NewReader(b)
+	}
+	u, err := pc.baseURL.Parse(path)
+	if err != nil {
+		return 0, err
+	}
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), r)
+	if err != nil {
+		return 0, err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	// Quay authenticates API calls with OAuth tokens while Harbor uses the credentials of a user or robot account.
+	if pc.api == ProjectAPIQuay {
+		req.Header.Set("Authorization", "Bearer "+pc.password)
+	} else {
+		req.SetBasicAuth(pc.username, pc.password)
+	}
+	resp, err := pc.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	_, err = io.Copy(io.Discard, resp.Body)
+	if err != nil {
+		return 0, err
+	}
+	return resp.StatusCode, nil
+}
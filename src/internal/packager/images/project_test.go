@@ -0,0 +1,158 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package images
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/zarf-dev/zarf/src/pkg/transform"
+	"github.com/zarf-dev/zarf/src/test/testutil"
+	"github.com/zarf-dev/zarf/src/types"
+)
+
+func TestRegistryProjects(t *testing.T) {
+	t.Parallel()
+
+	imageList := []transform.Image{}
+	for _, ref := range []string{"nginx:1.23.3", "busybox:1.36", "ghcr.io/stefanprodan/podinfo:6.3.3"} {
+		refInfo, err := transform.ParseImageRef(ref)
+		require.NoError(t, err)
+		imageList = append(imageList, refInfo)
+	}
+
+	tests := []struct {
+		name     string
+		regInfo  types.RegistryInfo
+		expected []string
+	}{
+		{
+			name:     "image paths",
+			regInfo:  types.RegistryInfo{Address: "harbor.example.com"},
+			expected: []string{"library", "stefanprodan"},
+		},
+		{
+			name:     "address with a path",
+			regInfo:  types.RegistryInfo{Address: "harbor.example.com/zarf"},
+			expected: []string{"zarf"},
+		},
+		{
+			name: "image rules",
+			regInfo: types.RegistryInfo{
+				Address:    "harbor.example.com",
+				ImageRules: []transform.ImageRule{{Prefix: "docker.io/", Replace: "dockerhub/"}},
+			},
+			expected: []string{"dockerhub", "stefanprodan"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			projects, err := registryProjects(tt.regInfo, imageList)
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, projects)
+		})
+	}
+}
+
+// projectServer fakes the project APIs of Harbor and Quay.
+type projectServer struct {
+	mu       sync.Mutex
+	projects []string
+	auth     []string
+}
+
+func (ps *projectServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+	ps.auth = append(ps.auth, r.Header.Get("Authorization"))
+
+	exists := func(name string) {
+		for _, p := range ps.projects {
+			if p == name {
+				w.WriteHeader(http.StatusOK)
+				return
+			}
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}
+	create := func(key string) {
+		body := map[string]any{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		ps.projects = append(ps.projects, body[key].(string))
+		w.WriteHeader(http.StatusCreated)
+	}
+	switch {
+	case r.Method == http.MethodHead && r.URL.Path == "/api/v2.0/projects":
+		exists(r.URL.Query().Get("project_name"))
+	case r.Method == http.MethodPost && r.URL.Path == "/api/v2.0/projects":
+		create("project_name")
+	case r.Method == http.MethodGet && strings.HasPrefix(r.URL.Path, "/api/v1/organization/"):
+		exists(strings.TrimPrefix(r.URL.Path, "/api/v1/organization/"))
+	case r.Method == http.MethodPost && r.URL.Path == "/api/v1/organization/":
+		create("name")
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func TestProjectClientEnsure(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		regInfo      types.RegistryInfo
+		expectedAuth string
+	}{
+		{
+			name:         "harbor robot account",
+			regInfo:      types.RegistryInfo{ProjectAPI: ProjectAPIHarbor, PushUsername: "push", PushPassword: "push", ProjectUsername: "robot$zarf", ProjectPassword: "secret"},
+			expectedAuth: "Basic cm9ib3QkemFyZjpzZWNyZXQ=",
+		},
+		{
+			name:         "harbor push user",
+			regInfo:      types.RegistryInfo{ProjectAPI: ProjectAPIHarbor, PushUsername: "push", PushPassword: "push"},
+			expectedAuth: "Basic cHVzaDpwdXNo",
+		},
+		{
+			name:         "quay token",
+			regInfo:      types.RegistryInfo{ProjectAPI: ProjectAPIQuay, ProjectPassword: "token"},
+			expectedAuth: "Bearer token",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := testutil.TestContext(t)
+
+			ps := &projectServer{projects: []string{"library"}}
+			srv := httptest.NewServer(ps)
+			t.Cleanup(srv.Close)
+			tt.regInfo.Address = strings.TrimPrefix(srv.URL, "http://")
+
+			pc, err := newProjectClient(tt.regInfo, true, false)
+			require.NoError(t, err)
+			require.NoError(t, pc.ensure(ctx, "library"))
+			require.NoError(t, pc.ensure(ctx, "stefanprodan"))
+			require.NoError(t, pc.ensure(ctx, "stefanprodan"))
+
+			require.Equal(t, []string{"library", "stefanprodan"}, ps.projects)
+			for _, auth := range ps.auth {
+				require.Equal(t, tt.expectedAuth, auth)
+			}
+		})
+	}
+
+	_, err := newProjectClient(types.RegistryInfo{ProjectAPI: "gitlab"}, true, false)
+	require.EqualError(t, err, "unsupported registry project API gitlab, must be one of harbor, quay")
+}
@@ -14,6 +14,8 @@ import (
 	"os"
 	"path/filepath"
 	"runtime"
+	"runtime/metrics"
+	"slices"
 	"strings"
 	"sync"
 	"sync/atomic"
@@ -22,6 +24,7 @@ import (
 	"github.com/docker/cli/cli/command"
 	"github.com/docker/cli/cli/context/docker"
 	"github.com/docker/cli/cli/flags"
+	"github.com/zarf-dev/zarf/src/pkg/events"
 	"github.com/zarf-dev/zarf/src/pkg/logger"
 
 	"github.com/avast/retry-go/v4"
@@ -34,14 +37,18 @@ import (
 	"github.com/google/go-containerregistry/pkg/v1/daemon"
 	"github.com/google/go-containerregistry/pkg/v1/empty"
 	clayout "github.com/google/go-containerregistry/pkg/v1/layout"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
 	"github.com/google/go-containerregistry/pkg/v1/partial"
 	"github.com/google/go-containerregistry/pkg/v1/remote"
 	"github.com/google/go-containerregistry/pkg/v1/types"
 	"github.com/moby/moby/client"
 	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/zarf-dev/zarf/src/api/v1alpha1"
+	"github.com/zarf-dev/zarf/src/internal/tracing"
 	"github.com/zarf-dev/zarf/src/pkg/message"
 	"github.com/zarf-dev/zarf/src/pkg/transform"
 	"github.com/zarf-dev/zarf/src/pkg/utils"
+	"go.opentelemetry.io/otel/attribute"
 	"golang.org/x/sync/errgroup"
 )
 
@@ -87,8 +94,13 @@ func getDockerEndpointHost() (string, error) {
 	return endpoint.Host, nil
 }
 
-// Pull pulls all images from the given config.
-func Pull(ctx context.Context, cfg PullConfig) (map[transform.Image]v1.Image, error) {
+// Pull pulls the images to the destination directory. It returns the images by reference, along with the platforms
+// of multi-platform images that were not kept in the package by reference.
+func Pull(ctx context.Context, cfg PullConfig) (_ map[transform.Image]v1.Image, _ map[string][]string, err error) {
+	ctx, span := tracing.Start(ctx, "pull images", attribute.Int("images", len(cfg.ImageList)))
+	defer func() {
+		tracing.End(span, err)
+	}()
 	l := logger.From(ctx)
 	var longer string
 	pullStart := time.Now()
@@ -101,13 +113,19 @@ func Pull(ctx context.Context, cfg PullConfig) (map[transform.Image]v1.Image, er
 		longer = "This step may take several seconds to complete."
 	}
 
+	for _, platform := range cfg.IncludePlatforms {
+		if _, err := parseImagePlatform(platform); err != nil {
+			return nil, nil, err
+		}
+	}
+
 	if err := helpers.CreateDirectory(cfg.DestinationDirectory, helpers.ReadExecuteAllWriteUser); err != nil {
-		return nil, fmt.Errorf("failed to create image path %s: %w", cfg.DestinationDirectory, err)
+		return nil, nil, fmt.Errorf("failed to create image path %s: %w", cfg.DestinationDirectory, err)
 	}
 
 	cranePath, err := clayout.Write(cfg.DestinationDirectory, empty.Index)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	// Give some additional user feedback on larger image sets
@@ -128,6 +146,8 @@ func Pull(ctx context.Context, cfg PullConfig) (map[transform.Image]v1.Image, er
 	opts := CommonOpts(cfg.Arch)
 
 	fetched := map[transform.Image]v1.Image{}
+	indexes := map[transform.Image]v1.ImageIndex{}
+	discarded := map[string][]string{}
 
 	var counter, totalBytes atomic.Int64
 	var dockerEndPointHost string
@@ -141,22 +161,30 @@ func Pull(ctx context.Context, cfg PullConfig) (map[transform.Image]v1.Image, er
 			spinner.Updatef("Fetching image info (%d of %d)", idx, imageCount)
 			l.Debug("fetching image info", "name", refInfo.Name)
 
-			ref := refInfo.Reference
-			for k, v := range cfg.RegistryOverrides {
-				if strings.HasPrefix(refInfo.Reference, k) {
-					ref = strings.Replace(refInfo.Reference, k, v, 1)
-				}
+			ref := cfg.PullReference(refInfo.Reference)
+			opts, err := cfg.pullOpts(refInfo.Reference, opts)
+			if err != nil {
+				return err
 			}
 
 			var img v1.Image
 			var desc *remote.Descriptor
 
 			// load from local fs if it's a tarball
-			if strings.HasSuffix(ref, ".tar") || strings.HasSuffix(ref, ".tar.gz") || strings.HasSuffix(ref, ".tgz") {
+			if isTarball(ref) {
 				img, err = crane.Load(ref, opts...)
 				if err != nil {
 					return fmt.Errorf("unable to load %s: %w", refInfo.Reference, err)
 				}
+				if platforms, ok := cfg.ImageIndexTarballs[refInfo.Reference]; ok {
+					idx, err := tarballIndex(platforms)
+					if err != nil {
+						return fmt.Errorf("unable to assemble the manifest list of %s: %w", refInfo.Reference, err)
+					}
+					shaLock.Lock()
+					indexes[refInfo] = idx
+					shaLock.Unlock()
+				}
 			} else {
 				reference, err := name.ParseReference(ref)
 				if err != nil {
@@ -209,6 +237,20 @@ func Pull(ctx context.Context, cfg PullConfig) (map[transform.Image]v1.Image, er
 						return fmt.Errorf("failed to load from docker daemon: %w", err)
 					}
 				} else {
+					if refInfo.Digest == "" {
+						idx, discardedPlatforms, err := cfg.selectPlatforms(ectx, refInfo.Reference, desc)
+						if err != nil {
+							return err
+						}
+						shaLock.Lock()
+						if idx != nil {
+							indexes[refInfo] = idx
+						}
+						if len(discardedPlatforms) > 0 {
+							discarded[refInfo.Reference] = discardedPlatforms
+						}
+						shaLock.Unlock()
+					}
 					img, err = crane.Pull(ref, opts...)
 					if err != nil {
 						return fmt.Errorf("unable to pull image %s: %w", refInfo.Reference, err)
@@ -225,7 +267,7 @@ func Pull(ctx context.Context, cfg PullConfig) (map[transform.Image]v1.Image, er
 				return err
 			}
 			if cacheImg && cfg.CacheDirectory != "" {
-				img = cache.Image(img, cache.NewFilesystemCache(cfg.CacheDirectory))
+				img = cache.Image(img, newLayerCache(ctx, cfg.CacheDirectory, cfg.RemoteCache))
 			}
 
 			size, err := getSizeOfImage(img)
@@ -263,7 +305,7 @@ func Pull(ctx context.Context, cfg PullConfig) (map[transform.Image]v1.Image, er
 
 	// Wait until we're done fetching images
 	if err := eg.Wait(); err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	// TODO(mkcp): Remove message on logger release
@@ -278,21 +320,44 @@ func Pull(ctx context.Context, cfg PullConfig) (map[transform.Image]v1.Image, er
 
 	toPull := maps.Clone(fetched)
 
-	err = retry.Do(func() error {
-		saved, err := SaveConcurrent(ctx, cranePath, toPull, cfg.CacheDirectory)
-		// Done save, remove from download list.
-		for k := range saved {
-			delete(toPull, k)
-		}
-		return err
-	},
-		retry.Context(ctx),
-		retry.Attempts(2),
-	)
+	// Images are saved one at a time, streaming their layers to disk, when the memory budget does not allow
+	// for concurrent saves or is reached while saving concurrently.
+	limit := saveConcurrency(cfg.MemoryBudget)
+	err = errMemoryBudget
+	if limit > 1 {
+		err = retry.Do(func() error {
+			saved, err := saveConcurrent(ctx, cranePath, toPull, cfg.CacheDirectory, limit, cfg.MemoryBudget)
+			// Done save, remove from download list.
+			for k := range saved {
+				delete(toPull, k)
+			}
+			return err
+		},
+			retry.Context(ctx),
+			retry.Attempts(2),
+			retry.RetryIf(func(err error) bool {
+				return !errors.Is(err, errMemoryBudget)
+			}),
+		)
+	}
+	// Falling back to a sequential save does not help once the pull is cancelled.
+	if err != nil && ctx.Err() != nil {
+		err = ctx.Err()
+		doneSaving <- err
+		<-doneSaving
+		return nil, nil, err
+	}
 	if err != nil {
-		// TODO(mkcp): Remove message on logger release
-		message.Warnf("Failed to save images in parallel, falling back to sequential save: %s", err.Error())
-		l.Warn("failed to save images in parallel, falling back to sequential save", "error", err.Error())
+		if errors.Is(err, errMemoryBudget) {
+			l.Info("saving images sequentially to stay within the memory budget",
+				"budget", utils.ByteFormat(float64(cfg.MemoryBudget), 2),
+				"remaining", len(toPull),
+			)
+		} else {
+			// TODO(mkcp): Remove message on logger release
+			message.Warnf("Failed to save images in parallel, falling back to sequential save: %s", err.Error())
+			l.Warn("failed to save images in parallel, falling back to sequential save", "error", err.Error())
+		}
 		err = retry.Do(func() error {
 			saved, err := SaveSequential(ctx, cranePath, toPull, cfg.CacheDirectory)
 			for k := range saved {
@@ -304,7 +369,9 @@ func Pull(ctx context.Context, cfg PullConfig) (map[transform.Image]v1.Image, er
 			retry.Attempts(2),
 		)
 		if err != nil {
-			return nil, err
+			doneSaving <- err
+			<-doneSaving
+			return nil, nil, err
 		}
 	}
 
@@ -313,6 +380,27 @@ func Pull(ctx context.Context, cfg PullConfig) (map[transform.Image]v1.Image, er
 	doneSaving <- nil
 	<-doneSaving
 
+	// The platforms that are kept next to the pulled image are saved as an image index that shares its blobs.
+	for refInfo, idx := range indexes {
+		if err := ctx.Err(); err != nil {
+			return nil, nil, err
+		}
+		l.Info("saving image platforms", "ref", refInfo.Reference)
+		if err := cranePath.WriteIndex(idx); err != nil {
+			return nil, nil, fmt.Errorf("unable to save the platforms of %s: %w", refInfo.Reference, err)
+		}
+		desc, err := partial.Descriptor(idx)
+		if err != nil {
+			return nil, nil, err
+		}
+		desc.Annotations = map[string]string{
+			utils.ImageIndexAnnotation: refInfo.Reference,
+		}
+		if err := cranePath.AppendDescriptor(*desc); err != nil {
+			return nil, nil, err
+		}
+	}
+
 	// Needed because when pulling from the local docker daemon, while using the docker containerd runtime
 	// Crane incorrectly names the blob of the docker image config to a sha that does not match the contents
 	// https://github.com/zarf-dev/zarf/issues/2584
@@ -322,6 +410,9 @@ func Pull(ctx context.Context, cfg PullConfig) (map[transform.Image]v1.Image, er
 		if err != nil {
 			return err
 		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
 
 		if fi.IsDir() {
 			return nil
@@ -335,12 +426,283 @@ func Pull(ctx context.Context, cfg PullConfig) (map[transform.Image]v1.Image, er
 		return os.Rename(path, newFile)
 	})
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	l.Debug("done pulling images", "count", len(cfg.ImageList), "duration", time.Since(pullStart))
 
-	return fetched, nil
+	return fetched, discarded, nil
+}
+
+// overrideReference returns the reference with the first matching registry override applied.
+func overrideReference(ref string, overrides map[string]string) string {
+	// The most specific override, such as one for an image over one for its registry, wins.
+	match := ""
+	for k := range overrides {
+		if strings.HasPrefix(ref, k) && len(k) > len(match) {
+			match = k
+		}
+	}
+	if match == "" {
+		return ref
+	}
+	return overrides[match] + strings.TrimPrefix(ref, match)
+}
+
+// PullReference returns the reference the image is pulled from after the registry overrides are applied, or the
+// tarball it is loaded from when it is built.
+func (cfg PullConfig) PullReference(ref string) string {
+	if tarball, ok := cfg.ImageTarballs[ref]; ok {
+		return tarball
+	}
+	overrides := cfg.RegistryOverrides
+	if imageOverrides, ok := cfg.ImageRegistryOverrides[ref]; ok {
+		overrides = imageOverrides
+	}
+	return overrideReference(ref, overrides)
+}
+
+// ComponentRegistryOverrides returns the registry overrides of the images of components that declare registry
+// overrides, merged with the overrides of the package which take precedence. It fails when an image that is used by
+// more than one component would be pulled from different references.
+func ComponentRegistryOverrides(components []v1alpha1.ZarfComponent, overrides map[string]string) (map[string]map[string]string, error) {
+	byImage := map[string]map[string]string{}
+	pulledFrom := map[string]string{}
+	usedBy := map[string]string{}
+	for _, component := range components {
+		merged := map[string]string{}
+		maps.Copy(merged, component.RegistryOverrides)
+		maps.Copy(merged, overrides)
+		for _, src := range component.Images {
+			refInfo, err := transform.ParseImageRef(src)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create ref for image %s: %w", src, err)
+			}
+			ref := overrideReference(refInfo.Reference, merged)
+			if prev, ok := pulledFrom[refInfo.Reference]; ok {
+				if prev != ref {
+					return nil, fmt.Errorf("image %s is pulled from %s by component %s and from %s by component %s", refInfo.Reference, prev, usedBy[refInfo.Reference], ref, component.Name)
+				}
+				continue
+			}
+			pulledFrom[refInfo.Reference] = ref
+			usedBy[refInfo.Reference] = component.Name
+			if len(component.RegistryOverrides) > 0 {
+				byImage[refInfo.Reference] = merged
+			}
+		}
+	}
+	return byImage, nil
+}
+
+// pullOpts returns the crane options the image is pulled with, which select its platform when it has one.
+func (cfg PullConfig) pullOpts(ref string, opts []crane.Option) ([]crane.Option, error) {
+	platform, ok := cfg.ImagePlatforms[ref]
+	if !ok {
+		return opts, nil
+	}
+	p, err := parseImagePlatform(platform)
+	if err != nil {
+		return nil, err
+	}
+	return append(slices.Clone(opts), crane.WithPlatform(p)), nil
+}
+
+// parseImagePlatform parses an image platform in the form os/architecture for Linux or Windows nodes.
+func parseImagePlatform(platform string) (*v1.Platform, error) {
+	p, err := v1.ParsePlatform(platform)
+	if err != nil {
+		return nil, fmt.Errorf("invalid image platform %s: %w", platform, err)
+	}
+	if p.OS != "linux" && p.OS != "windows" {
+		return nil, fmt.Errorf("invalid image platform %s: the OS must be linux or windows", platform)
+	}
+	if p.Architecture == "" {
+		return nil, fmt.Errorf("invalid image platform %s: the architecture is required", platform)
+	}
+	return p, nil
+}
+
+// ComponentImagePlatforms returns the platforms of the images of components that declare an image platform. It fails
+// when an image that is used by more than one component would be pulled for different platforms.
+func ComponentImagePlatforms(components []v1alpha1.ZarfComponent) (map[string]string, error) {
+	byImage := map[string]string{}
+	usedBy := map[string]string{}
+	for _, component := range components {
+		if component.ImagePlatform != "" {
+			if _, err := parseImagePlatform(component.ImagePlatform); err != nil {
+				return nil, fmt.Errorf("component %s: %w", component.Name, err)
+			}
+		}
+		for _, src := range component.Images {
+			refInfo, err := transform.ParseImageRef(src)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create ref for image %s: %w", src, err)
+			}
+			if prev, ok := usedBy[refInfo.Reference]; ok {
+				if byImage[refInfo.Reference] != component.ImagePlatform {
+					return nil, fmt.Errorf("image %s is pulled for different platforms by components %s and %s", refInfo.Reference, prev, component.Name)
+				}
+				continue
+			}
+			usedBy[refInfo.Reference] = component.Name
+			byImage[refInfo.Reference] = component.ImagePlatform
+		}
+	}
+	maps.DeleteFunc(byImage, func(_, platform string) bool {
+		return platform == ""
+	})
+	return byImage, nil
+}
+
+// selectPlatforms returns the image index of the platforms that are kept for a multi-platform image and the platforms
+// of the image that are discarded. The index is nil unless other platforms are kept next to the one the image is
+// pulled for. Attestation manifests of the image are neither kept nor reported.
+func (cfg PullConfig) selectPlatforms(ctx context.Context, ref string, desc *remote.Descriptor) (v1.ImageIndex, []string, error) {
+	if desc == nil || !desc.MediaType.IsIndex() {
+		return nil, nil, nil
+	}
+	idx, err := desc.ImageIndex()
+	if err != nil {
+		return nil, nil, err
+	}
+	idxManifest, err := idx.IndexManifest()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	pulled := v1.Platform{OS: "linux", Architecture: cfg.Arch}
+	if platform, ok := cfg.ImagePlatforms[ref]; ok {
+		p, err := parseImagePlatform(platform)
+		if err != nil {
+			return nil, nil, err
+		}
+		pulled = *p
+	}
+	kept := []v1.Platform{pulled}
+	for _, platform := range cfg.IncludePlatforms {
+		p, err := parseImagePlatform(platform)
+		if err != nil {
+			return nil, nil, err
+		}
+		kept = append(kept, *p)
+	}
+	isKept := func(platform *v1.Platform) bool {
+		return platform != nil && slices.ContainsFunc(kept, platform.Satisfies)
+	}
+
+	available := []string{}
+	discarded := []string{}
+	for _, manifest := range idxManifest.Manifests {
+		if manifest.Platform == nil || manifest.Platform.OS == "unknown" {
+			continue
+		}
+		available = append(available, manifest.Platform.String())
+		if !isKept(manifest.Platform) {
+			discarded = append(discarded, manifest.Platform.String())
+		}
+	}
+	hasPlatform := func(spec v1.Platform) bool {
+		return slices.ContainsFunc(idxManifest.Manifests, func(manifest v1.Descriptor) bool {
+			return manifest.Platform != nil && manifest.Platform.Satisfies(spec)
+		})
+	}
+	if !hasPlatform(pulled) {
+		return nil, nil, fmt.Errorf("image %s is not available for the platform %s, the image is available for the platforms %s. "+
+			"Create the package for one of these architectures or set the imagePlatform of the component",
+			ref, pulled.String(), strings.Join(available, ", "))
+	}
+	for _, platform := range kept[1:] {
+		if !hasPlatform(platform) {
+			message.Warnf("Image %s is not available for the platform %s, the image is available for the platforms %s", ref, platform.String(), strings.Join(available, ", "))
+			logger.From(ctx).Warn("image is not available for an included platform", "ref", ref, "platform", platform.String(), "available", available)
+		}
+	}
+	slices.Sort(discarded)
+	if len(discarded) == 0 {
+		discarded = nil
+	}
+	if len(available)-len(discarded) <= 1 {
+		return nil, discarded, nil
+	}
+	keptIdx := mutate.RemoveManifests(idx, func(desc v1.Descriptor) bool {
+		return !isKept(desc.Platform)
+	})
+	return keptIdx, discarded, nil
+}
+
+// tarballIndex assembles a manifest list of the image tarballs by platform.
+func tarballIndex(platforms map[string]string) (v1.ImageIndex, error) {
+	addenda := []mutate.IndexAddendum{}
+	for _, platform := range slices.Sorted(maps.Keys(platforms)) {
+		p, err := v1.ParsePlatform(platform)
+		if err != nil {
+			return nil, err
+		}
+		img, err := crane.Load(platforms[platform])
+		if err != nil {
+			return nil, fmt.Errorf("unable to load the image for %s: %w", platform, err)
+		}
+		addenda = append(addenda, mutate.IndexAddendum{
+			Add:        img,
+			Descriptor: v1.Descriptor{Platform: p},
+		})
+	}
+	return mutate.AppendManifests(mutate.IndexMediaType(empty.Index, types.DockerManifestList), addenda...), nil
+}
+
+// isTarball returns true if the reference is an image tarball on the local filesystem.
+func isTarball(ref string) bool {
+	return strings.HasSuffix(ref, ".tar") || strings.HasSuffix(ref, ".tar.gz") || strings.HasSuffix(ref, ".tgz")
+}
+
+// Size returns the combined size of the images in the config before they are pulled. Image tarballs are measured on
+// disk and remote images by the sizes in their manifests. Images that can not be resolved, such as images that are
+// only in the local docker daemon, are left out as Pull reports why they can not be pulled.
+func Size(ctx context.Context, cfg PullConfig) (int64, error) {
+	l := logger.From(ctx)
+	opts := CommonOpts(cfg.Arch)
+
+	var total atomic.Int64
+	eg, _ := errgroup.WithContext(ctx)
+	eg.SetLimit(10)
+	for _, refInfo := range cfg.ImageList {
+		eg.Go(func() error {
+			ref := cfg.PullReference(refInfo.Reference)
+			opts, err := cfg.pullOpts(refInfo.Reference, opts)
+			if err != nil {
+				return err
+			}
+			if isTarball(ref) {
+				fi, err := os.Stat(ref)
+				if err != nil {
+					return err
+				}
+				total.Add(fi.Size())
+				return nil
+			}
+			desc, err := crane.Get(ref, opts...)
+			if err != nil {
+				l.Debug("unable to get the size of image", "name", refInfo.Reference, "error", err)
+				return nil
+			}
+			img, err := desc.Image()
+			if err != nil {
+				l.Debug("unable to get the size of image", "name", refInfo.Reference, "error", err)
+				return nil
+			}
+			size, err := getSizeOfImage(img)
+			if err != nil {
+				return fmt.Errorf("failed to get size of image %s: %w", refInfo.Reference, err)
+			}
+			total.Add(size)
+			return nil
+		})
+	}
+	if err := eg.Wait(); err != nil {
+		return 0, err
+	}
+	return total.Load(), nil
 }
 
 // from https://github.com/google/go-containerregistry/blob/6bce25ecf0297c1aa9072bc665b5cf58d53e1c54/pkg/v1/cache/fs.go#L143
@@ -422,6 +784,9 @@ func SaveSequential(ctx context.Context, cl clayout.Path, m map[transform.Image]
 	l := logger.From(ctx)
 	saved := map[transform.Image]v1.Image{}
 	for info, img := range m {
+		if err := ctx.Err(); err != nil {
+			return saved, err
+		}
 		annotations := map[string]string{
 			ocispec.AnnotationBaseImageName: info.Reference,
 		}
@@ -432,7 +797,9 @@ func SaveSequential(ctx context.Context, cl clayout.Path, m map[transform.Image]
 		}
 		byteSize := utils.ByteFormat(float64(size), 2)
 		l.Info("saving image", "ref", info.Reference, "size", byteSize, "method", "sequential")
-		if err := cl.AppendImage(img, clayout.WithAnnotations(annotations)); err != nil {
+		events.From(ctx).Image(info.Reference, events.StatusStarted, 0, size, nil)
+		if err := cl.AppendImage(withContext(ctx, img), clayout.WithAnnotations(annotations)); err != nil {
+			events.From(ctx).Image(info.Reference, events.StatusFailed, 0, size, err)
 			if err := CleanupInProgressLayers(ctx, img, cacheDirectory); err != nil {
 				message.WarnErr(err, "failed to clean up in-progress layers, please run `zarf tools clear-cache`")
 				l.Error("failed to clean up in-progress layers. please run `zarf tools clear-cache`")
@@ -440,6 +807,7 @@ func SaveSequential(ctx context.Context, cl clayout.Path, m map[transform.Image]
 			return saved, err
 		}
 		saved[info] = img
+		events.From(ctx).Image(info.Reference, events.StatusSucceeded, size, size, nil)
 		l.Debug("done saving image",
 			"ref", info.Reference,
 			"bytes", size,
@@ -450,15 +818,49 @@ func SaveSequential(ctx context.Context, cl clayout.Path, m map[transform.Image]
 	return saved, nil
 }
 
+const (
+	// maxSaveConcurrency is the number of images that are saved at the same time.
+	maxSaveConcurrency = 10
+	// saveMemory is the heap budgeted for every image that is saved concurrently.
+	saveMemory = 256 * 1000 * 1000
+)
+
+// errMemoryBudget is returned by saveConcurrent when the heap reaches the memory budget.
+var errMemoryBudget = errors.New("reached the memory budget")
+
+// saveConcurrency returns the number of images that can be saved at the same time within the memory budget.
+func saveConcurrency(budget int64) int {
+	if budget <= 0 {
+		return maxSaveConcurrency
+	}
+	return int(min(max(budget/saveMemory, 1), maxSaveConcurrency))
+}
+
+// heapBytes returns the number of bytes of heap in use.
+func heapBytes() int64 {
+	sample := []metrics.Sample{{Name: "/memory/classes/heap/objects:bytes"}}
+	metrics.Read(sample)
+	if sample[0].Value.Kind() != metrics.KindUint64 {
+		return 0
+	}
+	return int64(sample[0].Value.Uint64())
+}
+
 // SaveConcurrent saves images in a concurrent, bounded manner.
 func SaveConcurrent(ctx context.Context, cl clayout.Path, m map[transform.Image]v1.Image, cacheDirectory string) (map[transform.Image]v1.Image, error) {
+	return saveConcurrent(ctx, cl, m, cacheDirectory, maxSaveConcurrency, 0)
+}
+
+// saveConcurrent saves up to limit images at the same time. No further images are started once the heap reaches the
+// memory budget, in which case errMemoryBudget is returned with the images that were saved.
+func saveConcurrent(ctx context.Context, cl clayout.Path, m map[transform.Image]v1.Image, cacheDirectory string, limit int, budget int64) (map[transform.Image]v1.Image, error) {
 	l := logger.From(ctx)
 	saved := map[transform.Image]v1.Image{}
 
 	var mu sync.Mutex
 
 	eg, ectx := errgroup.WithContext(ctx)
-	eg.SetLimit(10)
+	eg.SetLimit(limit)
 
 	for info, img := range m {
 		info, img := info, img
@@ -467,6 +869,9 @@ func SaveConcurrent(ctx context.Context, cl clayout.Path, m map[transform.Image]
 			case <-ectx.Done():
 				return ectx.Err()
 			default:
+				if budget > 0 && heapBytes() >= budget {
+					return errMemoryBudget
+				}
 				desc, err := partial.Descriptor(img)
 				if err != nil {
 					return err
@@ -478,7 +883,9 @@ func SaveConcurrent(ctx context.Context, cl clayout.Path, m map[transform.Image]
 				byteSize := utils.ByteFormat(float64(size), 2)
 				wStart := time.Now()
 				l.Info("saving image", "ref", info.Reference, "size", byteSize, "method", "concurrent")
-				if err := cl.WriteImage(img); err != nil {
+				events.From(ectx).Image(info.Reference, events.StatusStarted, 0, size, nil)
+				if err := cl.WriteImage(withContext(ectx, img)); err != nil {
+					events.From(ectx).Image(info.Reference, events.StatusFailed, 0, size, err)
 					if err := CleanupInProgressLayers(ectx, img, cacheDirectory); err != nil {
 						message.WarnErr(err, "failed to clean up in-progress layers, please run `zarf tools clear-cache`")
 						l.Error("failed to clean up in-progress layers. please run `zarf tools clear-cache`")
@@ -503,6 +910,7 @@ func SaveConcurrent(ctx context.Context, cl clayout.Path, m map[transform.Image]
 				}
 
 				saved[info] = img
+				events.From(ectx).Image(info.Reference, events.StatusSucceeded, size, size, nil)
 				return nil
 			}
 		})
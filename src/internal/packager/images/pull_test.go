@@ -9,15 +9,30 @@ import (
 	"crypto/sha256"
 	"encoding/json"
 	"fmt"
+	"io"
+	"log"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
 	"strings"
 	"testing"
 
+	"github.com/defenseunicorns/pkg/helpers/v2"
+	"github.com/google/go-containerregistry/pkg/crane"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
 	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	clayout "github.com/google/go-containerregistry/pkg/v1/layout"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/random"
 	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/types"
 	"github.com/stretchr/testify/require"
+	"github.com/zarf-dev/zarf/src/api/v1alpha1"
 	"github.com/zarf-dev/zarf/src/pkg/transform"
+	"github.com/zarf-dev/zarf/src/pkg/utils"
+	"github.com/zarf-dev/zarf/src/test/testutil"
 )
 
 func TestCheckForIndex(t *testing.T) {
@@ -116,7 +131,7 @@ func TestPull(t *testing.T) {
 				},
 			}
 
-			pulled, err := Pull(context.Background(), pullConfig)
+			pulled, _, err := Pull(context.Background(), pullConfig)
 			if tc.expectErr {
 				require.Error(t, err, tc.expectErr)
 				return
@@ -148,7 +163,7 @@ func TestPull(t *testing.T) {
 			},
 		}
 
-		_, err = Pull(context.Background(), pullConfig)
+		_, _, err = Pull(context.Background(), pullConfig)
 		require.NoError(t, err)
 		require.FileExists(t, filepath.Join(destDir, "blobs/sha256/3e84ea487b4c52a3299cf2996f70e7e1721236a0998da33a0e30107108486b3e"))
 
@@ -180,7 +195,7 @@ func TestPull(t *testing.T) {
 				ref,
 			},
 		}
-		_, err = Pull(context.Background(), pullConfig)
+		_, _, err = Pull(context.Background(), pullConfig)
 		require.NoError(t, err)
 
 		// Verify the cache layer has the correct sha
@@ -196,3 +211,455 @@ func TestPull(t *testing.T) {
 		require.Equal(t, correctLayerSha, fmt.Sprintf("%x", pulledLayerSha))
 	})
 }
+
+func TestSaveConcurrency(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		budget   int64
+		expected int
+	}{
+		{name: "no budget", budget: 0, expected: maxSaveConcurrency},
+		{name: "budget below a single save", budget: 1, expected: 1},
+		{name: "budget for some saves", budget: 3 * saveMemory, expected: 3},
+		{name: "budget above the maximum", budget: 100 * saveMemory, expected: maxSaveConcurrency},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			require.Equal(t, tt.expected, saveConcurrency(tt.budget))
+		})
+	}
+}
+
+func TestSaveConcurrentMemoryBudget(t *testing.T) {
+	t.Parallel()
+	ctx := testutil.TestContext(t)
+
+	m := map[transform.Image]v1.Image{}
+	for i := range 3 {
+		img, err := random.Image(1024, 1)
+		require.NoError(t, err)
+		m[transform.Image{Reference: fmt.Sprintf("example.com/image:%d", i)}] = img
+	}
+
+	cl, err := clayout.Write(t.TempDir(), empty.Index)
+	require.NoError(t, err)
+	saved, err := saveConcurrent(ctx, cl, m, t.TempDir(), 2, 1)
+	require.ErrorIs(t, err, errMemoryBudget)
+	require.Empty(t, saved)
+
+	saved, err = saveConcurrent(ctx, cl, m, t.TempDir(), 2, 0)
+	require.NoError(t, err)
+	require.Len(t, saved, 3)
+}
+
+func TestSaveCancelled(t *testing.T) {
+	t.Parallel()
+
+	m := map[transform.Image]v1.Image{}
+	for i := range 3 {
+		img, err := random.Image(1024, 1)
+		require.NoError(t, err)
+		m[transform.Image{Reference: fmt.Sprintf("example.com/image:%d", i)}] = img
+	}
+
+	ctx, cancel := context.WithCancel(testutil.TestContext(t))
+	cancel()
+
+	cl, err := clayout.Write(t.TempDir(), empty.Index)
+	require.NoError(t, err)
+	saved, err := SaveSequential(ctx, cl, m, t.TempDir())
+	require.ErrorIs(t, err, context.Canceled)
+	require.Empty(t, saved)
+	saved, err = saveConcurrent(ctx, cl, m, t.TempDir(), 2, 0)
+	require.ErrorIs(t, err, context.Canceled)
+	require.Empty(t, saved)
+
+	// Layers stop reading once the context is cancelled mid write.
+	img, err := random.Image(1024, 2)
+	require.NoError(t, err)
+	ctx, cancel = context.WithCancel(testutil.TestContext(t))
+	layers, err := withContext(ctx, img).Layers()
+	require.NoError(t, err)
+	rc, err := layers[0].Compressed()
+	require.NoError(t, err)
+	_, err = rc.Read(make([]byte, 1))
+	require.NoError(t, err)
+	cancel()
+	_, err = rc.Read(make([]byte, 1))
+	require.ErrorIs(t, err, context.Canceled)
+	require.NoError(t, rc.Close())
+}
+
+func TestSize(t *testing.T) {
+	t.Parallel()
+	ctx := testutil.TestContext(t)
+
+	port, err := helpers.GetAvailablePort()
+	require.NoError(t, err)
+	registry := testutil.SetupInMemoryRegistry(ctx, t, port)
+	img, err := random.Image(1024, 2)
+	require.NoError(t, err)
+	ref := fmt.Sprintf("%s/image:1.0.0", registry)
+	err = crane.Push(img, ref)
+	require.NoError(t, err)
+	imgSize, err := getSizeOfImage(img)
+	require.NoError(t, err)
+
+	tarPath := filepath.Join(t.TempDir(), "image.tar")
+	err = crane.Save(img, "image:1.0.0", tarPath)
+	require.NoError(t, err)
+	fi, err := os.Stat(tarPath)
+	require.NoError(t, err)
+
+	imageList := []transform.Image{
+		{Reference: ref},
+		{Reference: tarPath},
+		{Reference: fmt.Sprintf("%s/missing:1.0.0", registry)},
+	}
+	size, err := Size(ctx, PullConfig{ImageList: imageList, Arch: "amd64"})
+	require.NoError(t, err)
+	require.Equal(t, imgSize+fi.Size(), size)
+}
+
+func TestPullReference(t *testing.T) {
+	t.Parallel()
+
+	cfg := PullConfig{
+		RegistryOverrides: map[string]string{
+			"docker.io":               "mirror.example.com/docker.io",
+			"docker.io/library/nginx": "registry1.dso.mil/ironbank/opensource/nginx/nginx",
+		},
+		ImageRegistryOverrides: map[string]map[string]string{
+			"ghcr.io/stefanprodan/podinfo:6.4.0": {"ghcr.io": "mirror.example.com/ghcr.io"},
+		},
+		ImageTarballs: map[string]string{
+			"docker.io/example/api:1.0.0": "/tmp/builds/0.tar",
+		},
+	}
+	tests := []struct {
+		ref      string
+		expected string
+	}{
+		{ref: "docker.io/library/nginx:1.27", expected: "registry1.dso.mil/ironbank/opensource/nginx/nginx:1.27"},
+		{ref: "docker.io/library/busybox:1.36", expected: "mirror.example.com/docker.io/library/busybox:1.36"},
+		{ref: "ghcr.io/stefanprodan/podinfo:6.4.0", expected: "mirror.example.com/ghcr.io/stefanprodan/podinfo:6.4.0"},
+		{ref: "ghcr.io/zarf-dev/zarf/agent:v0.32.6", expected: "ghcr.io/zarf-dev/zarf/agent:v0.32.6"},
+		{ref: "docker.io/example/api:1.0.0", expected: "/tmp/builds/0.tar"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.ref, func(t *testing.T) {
+			t.Parallel()
+			require.Equal(t, tt.expected, cfg.PullReference(tt.ref))
+		})
+	}
+}
+
+func TestComponentRegistryOverrides(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		components  []v1alpha1.ZarfComponent
+		overrides   map[string]string
+		expected    map[string]map[string]string
+		expectedErr string
+	}{
+		{
+			name: "component overrides",
+			components: []v1alpha1.ZarfComponent{
+				{
+					Name:              "ironbank",
+					Images:            []string{"nginx:1.27"},
+					RegistryOverrides: map[string]string{"docker.io/library/nginx": "registry1.dso.mil/ironbank/opensource/nginx/nginx"},
+				},
+				{
+					Name:   "ghcr",
+					Images: []string{"ghcr.io/stefanprodan/podinfo:6.4.0"},
+				},
+			},
+			overrides: map[string]string{"ghcr.io": "mirror.example.com/ghcr.io"},
+			expected: map[string]map[string]string{
+				"docker.io/library/nginx:1.27": {
+					"docker.io/library/nginx": "registry1.dso.mil/ironbank/opensource/nginx/nginx",
+					"ghcr.io":                 "mirror.example.com/ghcr.io",
+				},
+			},
+		},
+		{
+			name: "package overrides take precedence",
+			components: []v1alpha1.ZarfComponent{
+				{
+					Name:              "app",
+					Images:            []string{"nginx:1.27"},
+					RegistryOverrides: map[string]string{"docker.io": "registry1.dso.mil/ironbank"},
+				},
+			},
+			overrides: map[string]string{"docker.io": "mirror.example.com/docker.io"},
+			expected: map[string]map[string]string{
+				"docker.io/library/nginx:1.27": {"docker.io": "mirror.example.com/docker.io"},
+			},
+		},
+		{
+			name: "shared image pulled from the same reference",
+			components: []v1alpha1.ZarfComponent{
+				{
+					Name:              "a",
+					Images:            []string{"nginx:1.27"},
+					RegistryOverrides: map[string]string{"docker.io": "registry1.dso.mil/ironbank"},
+				},
+				{
+					Name:              "b",
+					Images:            []string{"docker.io/library/nginx:1.27"},
+					RegistryOverrides: map[string]string{"docker.io/library": "registry1.dso.mil/ironbank/library"},
+				},
+			},
+			expected: map[string]map[string]string{
+				"docker.io/library/nginx:1.27": {"docker.io": "registry1.dso.mil/ironbank"},
+			},
+		},
+		{
+			name: "shared image pulled from different references",
+			components: []v1alpha1.ZarfComponent{
+				{
+					Name:              "a",
+					Images:            []string{"nginx:1.27"},
+					RegistryOverrides: map[string]string{"docker.io": "registry1.dso.mil/ironbank"},
+				},
+				{
+					Name:   "b",
+					Images: []string{"nginx:1.27"},
+				},
+			},
+			expectedErr: "image docker.io/library/nginx:1.27 is pulled from registry1.dso.mil/ironbank/library/nginx:1.27 by component a and from docker.io/library/nginx:1.27 by component b",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			byImage, err := ComponentRegistryOverrides(tt.components, tt.overrides)
+			if tt.expectedErr != "" {
+				require.EqualError(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, byImage)
+		})
+	}
+}
+
+func TestComponentImagePlatforms(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		components  []v1alpha1.ZarfComponent
+		expected    map[string]string
+		expectedErr string
+	}{
+		{
+			name: "windows images",
+			components: []v1alpha1.ZarfComponent{
+				{
+					Name:          "windows",
+					Images:        []string{"mcr.microsoft.com/windows/nanoserver:ltsc2022"},
+					ImagePlatform: "windows/amd64",
+				},
+				{
+					Name:   "linux",
+					Images: []string{"nginx:1.27"},
+				},
+			},
+			expected: map[string]string{"mcr.microsoft.com/windows/nanoserver:ltsc2022": "windows/amd64"},
+		},
+		{
+			name: "shared image pulled for different platforms",
+			components: []v1alpha1.ZarfComponent{
+				{
+					Name:   "linux",
+					Images: []string{"nginx:1.27"},
+				},
+				{
+					Name:          "windows",
+					Images:        []string{"docker.io/library/nginx:1.27"},
+					ImagePlatform: "windows/amd64",
+				},
+			},
+			expectedErr: "image docker.io/library/nginx:1.27 is pulled for different platforms by components linux and windows",
+		},
+		{
+			name: "invalid os",
+			components: []v1alpha1.ZarfComponent{
+				{
+					Name:          "darwin",
+					ImagePlatform: "darwin/arm64",
+				},
+			},
+			expectedErr: "component darwin: invalid image platform darwin/arm64: the OS must be linux or windows",
+		},
+		{
+			name: "missing architecture",
+			components: []v1alpha1.ZarfComponent{
+				{
+					Name:          "windows",
+					ImagePlatform: "windows",
+				},
+			},
+			expectedErr: "component windows: invalid image platform windows: the architecture is required",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			byImage, err := ComponentImagePlatforms(tt.components)
+			if tt.expectedErr != "" {
+				require.EqualError(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, byImage)
+		})
+	}
+}
+
+func TestPullIncludePlatforms(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(registry.New(registry.Logger(log.New(io.Discard, "", 0))))
+	t.Cleanup(srv.Close)
+	host := strings.TrimPrefix(srv.URL, "http://")
+
+	idx := mutate.IndexMediaType(empty.Index, types.OCIImageIndex)
+	for _, platform := range []v1.Platform{
+		{OS: "linux", Architecture: "amd64"},
+		{OS: "linux", Architecture: "arm64"},
+		{OS: "linux", Architecture: "arm", Variant: "v7"},
+		{OS: "unknown", Architecture: "unknown"},
+	} {
+		img, err := random.Image(64, 1)
+		require.NoError(t, err)
+		cf, err := img.ConfigFile()
+		require.NoError(t, err)
+		cf = cf.DeepCopy()
+		cf.OS, cf.Architecture, cf.Variant = platform.OS, platform.Architecture, platform.Variant
+		img, err = mutate.ConfigFile(img, cf)
+		require.NoError(t, err)
+		idx = mutate.AppendManifests(idx, mutate.IndexAddendum{
+			Add:        img,
+			Descriptor: v1.Descriptor{Platform: &platform},
+		})
+	}
+	src, err := name.ParseReference(host + "/multi:1.0.0")
+	require.NoError(t, err)
+	err = remote.WriteIndex(src, idx)
+	require.NoError(t, err)
+	ref, err := transform.ParseImageRef(src.String())
+	require.NoError(t, err)
+
+	destDir := t.TempDir()
+	cfg := PullConfig{
+		DestinationDirectory: destDir,
+		ImageList:            []transform.Image{ref},
+		Arch:                 "amd64",
+		IncludePlatforms:     []string{"linux/arm64"},
+	}
+	_, discarded, err := Pull(testutil.TestContext(t), cfg)
+	require.NoError(t, err)
+	require.Equal(t, map[string][]string{ref.Reference: {"linux/arm/v7"}}, discarded)
+
+	img, err := utils.LoadOCIImage(destDir, ref)
+	require.NoError(t, err)
+	cf, err := img.ConfigFile()
+	require.NoError(t, err)
+	require.Equal(t, "amd64", cf.Architecture)
+	keptIdx, err := utils.LoadOCIImageIndex(destDir, ref)
+	require.NoError(t, err)
+	require.NotNil(t, keptIdx)
+	keptManifest, err := keptIdx.IndexManifest()
+	require.NoError(t, err)
+	platforms := []string{}
+	for _, desc := range keptManifest.Manifests {
+		platforms = append(platforms, desc.Platform.String())
+	}
+	require.ElementsMatch(t, []string{"linux/amd64", "linux/arm64"}, platforms)
+
+	dst := host + "/mirror/multi:1.0.0"
+	err = PushIndex(keptIdx, dst)
+	require.NoError(t, err)
+	pushed, err := crane.Digest(dst)
+	require.NoError(t, err)
+	keptDigest, err := keptIdx.Digest()
+	require.NoError(t, err)
+	require.Equal(t, keptDigest.String(), pushed)
+
+	cfg.DestinationDirectory = t.TempDir()
+	cfg.IncludePlatforms = nil
+	_, discarded, err = Pull(testutil.TestContext(t), cfg)
+	require.NoError(t, err)
+	require.Equal(t, map[string][]string{ref.Reference: {"linux/arm/v7", "linux/arm64"}}, discarded)
+	keptIdx, err = utils.LoadOCIImageIndex(cfg.DestinationDirectory, ref)
+	require.NoError(t, err)
+	require.Nil(t, keptIdx)
+
+	cfg.DestinationDirectory = t.TempDir()
+	cfg.Arch = "s390x"
+	_, _, err = Pull(testutil.TestContext(t), cfg)
+	require.ErrorContains(t, err, fmt.Sprintf("image %s is not available for the platform linux/s390x, the image is available for the platforms linux/amd64, linux/arm64, linux/arm/v7", ref.Reference))
+}
+
+func TestPullImageIndexTarballs(t *testing.T) {
+	t.Parallel()
+
+	tarballs := map[string]string{}
+	for _, platform := range []string{"linux/amd64", "linux/arm64"} {
+		img, err := random.Image(64, 1)
+		require.NoError(t, err)
+		cf, err := img.ConfigFile()
+		require.NoError(t, err)
+		cf = cf.DeepCopy()
+		cf.OS, cf.Architecture, _ = strings.Cut(platform, "/")
+		img, err = mutate.ConfigFile(img, cf)
+		require.NoError(t, err)
+		path := filepath.Join(t.TempDir(), "image.tar")
+		err = crane.Save(img, "example/api:1.0.0", path)
+		require.NoError(t, err)
+		tarballs[platform] = path
+	}
+	ref, err := transform.ParseImageRef("example/api:1.0.0")
+	require.NoError(t, err)
+
+	destDir := t.TempDir()
+	cfg := PullConfig{
+		DestinationDirectory: destDir,
+		ImageList:            []transform.Image{ref},
+		Arch:                 "amd64",
+		ImageTarballs:        map[string]string{ref.Reference: tarballs["linux/amd64"]},
+		ImageIndexTarballs:   map[string]map[string]string{ref.Reference: tarballs},
+	}
+	_, _, err = Pull(testutil.TestContext(t), cfg)
+	require.NoError(t, err)
+
+	img, err := utils.LoadOCIImage(destDir, ref)
+	require.NoError(t, err)
+	cf, err := img.ConfigFile()
+	require.NoError(t, err)
+	require.Equal(t, "amd64", cf.Architecture)
+	idx, err := utils.LoadOCIImageIndex(destDir, ref)
+	require.NoError(t, err)
+	require.NotNil(t, idx)
+	idxManifest, err := idx.IndexManifest()
+	require.NoError(t, err)
+	require.Equal(t, types.DockerManifestList, idxManifest.MediaType)
+	platforms := []string{}
+	for _, desc := range idxManifest.Manifests {
+		platforms = append(platforms, desc.Platform.String())
+	}
+	require.Equal(t, []string{"linux/amd64", "linux/arm64"}, platforms)
+	imgDigest, err := img.Digest()
+	require.NoError(t, err)
+	require.Equal(t, imgDigest, idxManifest.Manifests[0].Digest)
+}
@@ -6,35 +6,66 @@ package images
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/avast/retry-go/v4"
+	"github.com/defenseunicorns/pkg/helpers/v2"
 	"github.com/google/go-containerregistry/pkg/crane"
+	"github.com/google/go-containerregistry/pkg/name"
 	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/remote/transport"
 
+	"github.com/zarf-dev/zarf/src/config"
 	"github.com/zarf-dev/zarf/src/pkg/cluster"
+	"github.com/zarf-dev/zarf/src/pkg/events"
 	"github.com/zarf-dev/zarf/src/pkg/logger"
 	"github.com/zarf-dev/zarf/src/pkg/message"
 	"github.com/zarf-dev/zarf/src/pkg/transform"
 	"github.com/zarf-dev/zarf/src/pkg/utils"
 )
 
+// pushProgressInterval is the minimum time between progress events for a single image push.
+const pushProgressInterval = 500 * time.Millisecond
+
 // Push pushes images to a registry.
 func Push(ctx context.Context, cfg PushConfig) error {
 	l := logger.From(ctx)
 
 	toPush := map[transform.Image]v1.Image{}
+	indexes := map[transform.Image]v1.ImageIndex{}
 	// Build an image list from the references
 	for _, refInfo := range cfg.ImageList {
 		img, err := utils.LoadOCIImage(cfg.SourceDirectory, refInfo)
+		if err != nil && cfg.Mirror != "" {
+			l.Debug("image is not in the package, pulling it from the mirror", "name", refInfo.Reference, "error", err)
+			img, err = pullFromMirror(ctx, cfg, refInfo)
+		}
 		if err != nil {
 			return err
 		}
 		toPush[refInfo] = img
+		// Images with more than one platform in the package are pushed as an index of those platforms.
+		idx, err := utils.LoadOCIImageIndex(cfg.SourceDirectory, refInfo)
+		if err != nil {
+			return err
+		}
+		if idx != nil {
+			indexes[refInfo] = idx
+		}
+	}
+
+	err := CreateProjects(ctx, cfg.RegInfo, cfg.ImageList)
+	if err != nil {
+		return err
 	}
 
 	var (
-		err         error
 		tunnel      *cluster.Tunnel
 		registryURL = cfg.RegInfo.Address
 	)
@@ -50,45 +81,85 @@ func Push(ctx context.Context, cfg PushConfig) error {
 			}
 		}
 		pushOptions := createPushOpts(cfg)
+		var scopedTokens *ScopedTokens
+		if cfg.RegInfo.ScopedTokens {
+			scopedTokens = NewScopedTokens(cfg.RegInfo.PushUsername, cfg.RegInfo.PushPassword, PushTransport(cfg.RegInfo))
+		}
 
-		pushImage := func(img v1.Image, name string) error {
+		pushImage := func(img v1.Image, idx v1.ImageIndex, ref, name string) error {
+			opts := slices.Clone(pushOptions)
+			if scopedTokens != nil {
+				tokenOpt, err := scopedTokens.Option(name)
+				if err != nil {
+					return err
+				}
+				opts = append(opts, tokenOpt)
+			}
+			finish := func(error) {}
+			if events.From(ctx) != nil {
+				var progressOpt crane.Option
+				progressOpt, finish = pushProgress(ctx, ref)
+				opts = append(opts, progressOpt)
+			}
+			push := func() error {
+				if idx != nil {
+					return PushIndex(idx, name, opts...)
+				}
+				return crane.Push(img, name, opts...)
+			}
+			var err error
 			if tunnel != nil {
-				return tunnel.Wrap(func() error { return crane.Push(img, name, pushOptions...) })
+				err = tunnel.Wrap(push)
+			} else {
+				err = push()
 			}
-			return crane.Push(img, name, pushOptions...)
+			finish(err)
+			return err
 		}
 
-		pushed := []transform.Image{}
-		defer func() {
-			for _, refInfo := range pushed {
-				delete(toPush, refInfo)
-			}
-		}()
-		for refInfo, img := range toPush {
-			message.Infof("Pushing %s", refInfo.Reference)
-			l.Info("pushing image", "name", refInfo.Reference)
+		pushRefInfo := func(refInfo transform.Image, img v1.Image) error {
 			// If this is not a no checksum image push it for use with the Zarf agent
 			if !cfg.NoChecksum {
-				offlineNameCRC, err := transform.ImageTransformHost(registryURL, refInfo.Reference)
+				offlineNameCRC, err := transform.ImageTransformHost(registryURL, refInfo.Reference, cfg.RegInfo.ImageRules...)
 				if err != nil {
 					return err
 				}
 
-				if err = pushImage(img, offlineNameCRC); err != nil {
+				if err = pushImage(img, indexes[refInfo], refInfo.Reference, offlineNameCRC); err != nil {
 					return err
 				}
 			}
 
 			// To allow for other non-zarf workloads to easily see the images upload a non-checksum version
 			// (this may result in collisions but this is acceptable for this use case)
-			offlineName, err := transform.ImageTransformHostWithoutChecksum(registryURL, refInfo.Reference)
+			offlineName, err := transform.ImageTransformHostWithoutChecksum(registryURL, refInfo.Reference, cfg.RegInfo.ImageRules...)
 			if err != nil {
 				return err
 			}
 
-			if err = pushImage(img, offlineName); err != nil {
+			return pushImage(img, indexes[refInfo], refInfo.Reference, offlineName)
+		}
+
+		pushed := []transform.Image{}
+		defer func() {
+			for _, refInfo := range pushed {
+				delete(toPush, refInfo)
+			}
+		}()
+		for refInfo, img := range toPush {
+			message.Infof("Pushing %s", refInfo.Reference)
+			l.Info("pushing image", "name", refInfo.Reference)
+			size, err := getSizeOfImage(img)
+			if err != nil {
 				return err
 			}
+			events.From(ctx).Image(refInfo.Reference, events.StatusStarted, 0, size, nil)
+			err = pushRefInfo(refInfo, img)
+			if err != nil {
+				events.From(ctx).Image(refInfo.Reference, events.StatusFailed, 0, size, err)
+				return err
+			}
+			events.From(ctx).Image(refInfo.Reference, events.StatusSucceeded, size, size, nil)
 
 			pushed = append(pushed, refInfo)
 		}
@@ -100,3 +171,116 @@ func Push(ctx context.Context, cfg PushConfig) error {
 
 	return nil
 }
+
+// Missing returns the images of the image list that are not in the registry. Images are looked up by the reference
+// without a checksum, which Push pushes every image to.
+func Missing(ctx context.Context, cfg PushConfig) ([]transform.Image, error) {
+	registryURL := cfg.RegInfo.Address
+	c, _ := cluster.NewCluster()
+	if c != nil {
+		var tunnel *cluster.Tunnel
+		var err error
+		registryURL, tunnel, err = c.ConnectToZarfRegistryEndpoint(ctx, cfg.RegInfo)
+		if err != nil {
+			return nil, err
+		}
+		if tunnel != nil {
+			defer tunnel.Close()
+		}
+	}
+	opts := append(createPushOpts(cfg), crane.WithContext(ctx))
+	missing := []transform.Image{}
+	for _, refInfo := range cfg.ImageList {
+		offlineName, err := transform.ImageTransformHostWithoutChecksum(registryURL, refInfo.Reference, cfg.RegInfo.ImageRules...)
+		if err != nil {
+			return nil, err
+		}
+		_, err = crane.Head(offlineName, opts...)
+		var terr *transport.Error
+		if errors.As(err, &terr) && terr.StatusCode == http.StatusNotFound {
+			missing = append(missing, refInfo)
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("unable to look up %s in the registry: %w", refInfo.Reference, err)
+		}
+	}
+	return missing, nil
+}
+
+// PushIndex pushes an image index with the images of its platforms to dst, the same way crane.Push pushes an image.
+func PushIndex(idx v1.ImageIndex, dst string, opts ...crane.Option) error {
+	o := crane.GetOptions(opts...)
+	ref, err := name.ParseReference(dst, o.Name...)
+	if err != nil {
+		return fmt.Errorf("parsing reference %q: %w", dst, err)
+	}
+	return remote.WriteIndex(ref, idx, o.Remote...)
+}
+
+// MirrorReference returns the reference of the image in the mirror registry. Images are mirrored by their path without
+// the registry host, e.g. ghcr.io/zarf-dev/zarf/registry:3.0.0 is mirror.internal/zarf/zarf-dev/zarf/registry:3.0.0 in
+// the oci://mirror.internal/zarf mirror.
+func MirrorReference(mirror string, refInfo transform.Image) string {
+	mirror = strings.TrimSuffix(strings.TrimPrefix(mirror, helpers.OCIURLPrefix), "/")
+	return fmt.Sprintf("%s/%s%s", mirror, refInfo.Path, refInfo.TagOrDigest)
+}
+
+// pullFromMirror returns the image from the mirror registry. Layers are only fetched once the image is pushed.
+func pullFromMirror(ctx context.Context, cfg PushConfig, refInfo transform.Image) (v1.Image, error) {
+	ref := MirrorReference(cfg.Mirror, refInfo)
+	opts := CommonOpts(cfg.Arch)
+	if config.CommonOptions.PlainHTTP {
+		opts = append(opts, crane.Insecure)
+	}
+	opts = append(opts, crane.WithContext(ctx))
+	img, err := crane.Pull(ref, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("unable to pull %s from the mirror %s: %w", refInfo.Reference, ref, err)
+	}
+	return img, nil
+}
+
+// pushProgress returns a crane option that reports the progress of pushing the image ref to the event stream and a
+// function that is called with the result of the push. It waits for the final progress event of a successful push and
+// stops reporting progress of a failed one. Progress is reported at most once per pushProgressInterval.
+func pushProgress(ctx context.Context, ref string) (crane.Option, func(error)) {
+	updates := make(chan v1.Update, 100)
+	stop := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		var last time.Time
+		for {
+			var u v1.Update
+			var ok bool
+			select {
+			case u, ok = <-updates:
+			case <-stop:
+				return
+			}
+			if !ok {
+				return
+			}
+			// Errors are returned by the push itself.
+			if u.Error != nil {
+				continue
+			}
+			if u.Complete != u.Total && time.Since(last) < pushProgressInterval {
+				continue
+			}
+			last = time.Now()
+			events.From(ctx).Image(ref, events.StatusProgress, u.Complete, u.Total, nil)
+		}
+	}()
+	opt := func(o *crane.Options) {
+		o.Remote = append(o.Remote, remote.WithProgress(updates))
+	}
+	return opt, func(err error) {
+		// The progress channel is only closed once the write has started, which a successful push guarantees.
+		if err != nil {
+			close(stop)
+		}
+		<-done
+	}
+}
@@ -0,0 +1,50 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package images
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/zarf-dev/zarf/src/pkg/transform"
+)
+
+func TestMirrorReference(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		mirror   string
+		image    string
+		expected string
+	}{
+		{
+			name:     "tag",
+			mirror:   "oci://mirror.internal/zarf",
+			image:    "ghcr.io/zarf-dev/zarf/registry:3.0.0",
+			expected: "mirror.internal/zarf/zarf-dev/zarf/registry:3.0.0",
+		},
+		{
+			name:     "digest",
+			mirror:   "oci://mirror.internal:5000/",
+			image:    "docker.io/library/registry@sha256:3f6e5d6f7d7a0c5ff2fca8e2b4ce03d3a1d1f8f2b5f5c57a1f2c5d6a0b2f2e1c",
+			expected: "mirror.internal:5000/library/registry@sha256:3f6e5d6f7d7a0c5ff2fca8e2b4ce03d3a1d1f8f2b5f5c57a1f2c5d6a0b2f2e1c",
+		},
+		{
+			name:     "docker hub",
+			mirror:   "mirror.internal",
+			image:    "nginx",
+			expected: "mirror.internal/library/nginx:latest",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			refInfo, err := transform.ParseImageRef(tt.image)
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, MirrorReference(tt.mirror, refInfo))
+		})
+	}
+}
@@ -0,0 +1,143 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package images provides functions for building and pushing images.
+package images
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/crane"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/remote/transport"
+
+	"github.com/zarf-dev/zarf/src/config"
+	"github.com/zarf-dev/zarf/src/pkg/logger"
+)
+
+const (
+	// defaultTokenExpiry is the lifetime of tokens whose response does not include one, as defined by the registry
+	// token spec.
+	defaultTokenExpiry = 60 * time.Second
+	// tokenExpiryMargin renews tokens before they expire so that they do not run out in the middle of a request.
+	tokenExpiryMargin = 10 * time.Second
+)
+
+// ScopedTokens authenticates pushes with short-lived tokens from the token endpoint of the registry that are limited
+// to pushing to a single repository, so the push credentials are only ever sent to the token endpoint.
+type ScopedTokens struct {
+	auth      authn.Authenticator
+	transport http.RoundTripper
+
+	mu    sync.Mutex
+	repos map[string]*scopedToken
+}
+
+// NewScopedTokens returns ScopedTokens that exchange the given credentials for tokens using the transport.
+func NewScopedTokens(username, password string, t http.RoundTripper) *ScopedTokens {
+	return &ScopedTokens{
+		auth: authn.FromConfig(authn.AuthConfig{
+			Username: username,
+			Password: password,
+		}),
+		transport: t,
+		repos:     map[string]*scopedToken{},
+	}
+}
+
+// Option returns a crane option that authenticates with a token scoped to the repository of the image name.
+// Tokens are shared by all images in the same repository and renewed when they expire.
+func (st *ScopedTokens) Option(imageName string) (crane.Option, error) {
+	tok, err := st.authenticator(imageName)
+	if err != nil {
+		return nil, err
+	}
+	return crane.WithAuth(tok), nil
+}
+
+func (st *ScopedTokens) authenticator(imageName string) (*scopedToken, error) {
+	opts := []name.Option{}
+	if config.CommonOptions.InsecureSkipTLSVerify {
+		opts = append(opts, name.Insecure)
+	}
+	ref, err := name.ParseReference(imageName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	repo := ref.Context()
+
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	tok, ok := st.repos[repo.Name()]
+	if !ok {
+		tok = &scopedToken{
+			repo:      repo,
+			auth:      st.auth,
+			transport: st.transport,
+		}
+		st.repos[repo.Name()] = tok
+	}
+	return tok, nil
+}
+
+// scopedToken is an authenticator that hands out a registry token for pushing to a single repository.
+type scopedToken struct {
+	repo      name.Repository
+	auth      authn.Authenticator
+	transport http.RoundTripper
+
+	mu        sync.Mutex
+	challenge *transport.Challenge
+	token     string
+	expiry    time.Time
+}
+
+// Authorization implements authn.Authenticator.
+func (t *scopedToken) Authorization() (*authn.AuthConfig, error) {
+	return t.AuthorizationContext(context.Background())
+}
+
+// AuthorizationContext implements authn.ContextAuthenticator.
+func (t *scopedToken) AuthorizationContext(ctx context.Context) (*authn.AuthConfig, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.challenge == nil {
+		challenge, err := transport.Ping(ctx, t.repo.Registry, t.transport)
+		if err != nil {
+			return nil, err
+		}
+		t.challenge = challenge
+	}
+	// Registries without a token endpoint can only be authenticated with the credentials themselves.
+	if !strings.EqualFold(t.challenge.Scheme, "bearer") {
+		return authn.Authorization(ctx, t.auth)
+	}
+	if t.token != "" && time.Now().Before(t.expiry) {
+		return &authn.AuthConfig{RegistryToken: t.token}, nil
+	}
+
+	scope := t.repo.Scope(transport.PushScope)
+	tok, err := transport.Exchange(ctx, t.repo.Registry, t.auth, t.transport, []string{scope}, t.challenge)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get a token for %s: %w", scope, err)
+	}
+	// Token endpoints following the OAuth spec only return an access token.
+	if tok.Token == "" {
+		tok.Token = tok.AccessToken
+	}
+	expiresIn := defaultTokenExpiry
+	if tok.ExpiresIn > 0 {
+		expiresIn = time.Duration(tok.ExpiresIn) * time.Second
+	}
+	t.token = tok.Token
+	t.expiry = time.Now().Add(expiresIn - tokenExpiryMargin)
+	logger.From(ctx).Debug("requested scoped registry token", "scope", scope, "expiresIn", expiresIn)
+	return &authn.AuthConfig{RegistryToken: t.token}, nil
+}
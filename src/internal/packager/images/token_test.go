@@ -0,0 +1,122 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package images
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zarf-dev/zarf/src/test/testutil"
+)
+
+// tokenServer fakes a registry that either hands out tokens from its token endpoint or only accepts basic auth.
+type tokenServer struct {
+	bearer    bool
+	expiresIn int
+
+	mu     sync.Mutex
+	scopes []string
+}
+
+func (ts *tokenServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.URL.Path {
+	case "/v2/":
+		if ts.bearer {
+			w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer realm="http://%s/token",service="test"`, r.Host))
+		} else {
+			w.Header().Set("WWW-Authenticate", `Basic realm="test"`)
+		}
+		w.WriteHeader(http.StatusUnauthorized)
+	case "/token":
+		username, password, ok := r.BasicAuth()
+		if !ok || username != "push" || password != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		ts.mu.Lock()
+		ts.scopes = append(ts.scopes, r.URL.Query().Get("scope"))
+		n := len(ts.scopes)
+		ts.mu.Unlock()
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"access_token": fmt.Sprintf("token-%d", n),
+			"expires_in":   ts.expiresIn,
+		})
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func TestScopedTokens(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		server         *tokenServer
+		expectedAuth   []authn.AuthConfig
+		expectedScopes []string
+	}{
+		{
+			name:   "token per repository",
+			server: &tokenServer{bearer: true, expiresIn: 300},
+			expectedAuth: []authn.AuthConfig{
+				{RegistryToken: "token-1"},
+				{RegistryToken: "token-1"},
+				{RegistryToken: "token-2"},
+			},
+			expectedScopes: []string{"repository:library/nginx:push,pull", "repository:stefanprodan/podinfo:push,pull"},
+		},
+		{
+			name:   "renew expired tokens",
+			server: &tokenServer{bearer: true, expiresIn: 1},
+			expectedAuth: []authn.AuthConfig{
+				{RegistryToken: "token-1"},
+				{RegistryToken: "token-2"},
+				{RegistryToken: "token-3"},
+			},
+			expectedScopes: []string{"repository:library/nginx:push,pull", "repository:library/nginx:push,pull", "repository:stefanprodan/podinfo:push,pull"},
+		},
+		{
+			name:   "registry without token endpoint",
+			server: &tokenServer{},
+			expectedAuth: []authn.AuthConfig{
+				{Username: "push", Password: "secret"},
+				{Username: "push", Password: "secret"},
+				{Username: "push", Password: "secret"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := testutil.TestContext(t)
+
+			srv := httptest.NewServer(tt.server)
+			t.Cleanup(srv.Close)
+			host := strings.TrimPrefix(srv.URL, "http://")
+
+			st := NewScopedTokens("push", "secret", http.DefaultTransport)
+			names := []string{
+				host + "/library/nginx:1.23.3-zarf-3793515731",
+				host + "/library/nginx:1.23.3",
+				host + "/stefanprodan/podinfo:6.3.3",
+			}
+			for i, name := range names {
+				tok, err := st.authenticator(name)
+				require.NoError(t, err)
+				auth, err := tok.AuthorizationContext(ctx)
+				require.NoError(t, err)
+				require.Equal(t, tt.expectedAuth[i], *auth)
+			}
+			require.Equal(t, tt.expectedScopes, tt.server.scopes)
+		})
+	}
+}
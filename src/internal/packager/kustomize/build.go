@@ -17,8 +17,10 @@ import (
 // Build reads a kustomization and builds it into a single yaml file.
 func Build(path string, destination string, kustomizeAllowAnyDirectory bool) error {
 	// Kustomize has to write to the filesystem on-disk
-	fSys := filesys.MakeFsOnDisk()
+	return build(filesys.MakeFsOnDisk(), path, destination, kustomizeAllowAnyDirectory)
+}
 
+func build(fSys filesys.FileSystem, path string, destination string, kustomizeAllowAnyDirectory bool) error {
 	// flux2 build options for consistency, load restrictions none applies only to local files
 	buildOptions := krusty.MakeDefaultOptions()
 
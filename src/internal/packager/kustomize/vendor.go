@@ -0,0 +1,318 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package kustomize
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/defenseunicorns/pkg/helpers/v2"
+	gogit "github.com/go-git/go-git/v5"
+	gitconfig "github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/storage/memory"
+	"sigs.k8s.io/kustomize/api/konfig"
+	"sigs.k8s.io/kustomize/kyaml/filesys"
+	"sigs.k8s.io/yaml"
+
+	"github.com/zarf-dev/zarf/src/config"
+	"github.com/zarf-dev/zarf/src/internal/git"
+	"github.com/zarf-dev/zarf/src/pkg/logger"
+	"github.com/zarf-dev/zarf/src/pkg/utils"
+)
+
+// BuildVendored clones the remote bases of a kustomization, points the kustomization at the clones and builds it into
+// a single yaml file. Remote bases must be pinned to a tag or commit with ?ref= unless allowUnpinned is set.
+func BuildVendored(ctx context.Context, path, destination string, kustomizeAllowAnyDirectory, allowUnpinned bool) error {
+	vendorDir, err := utils.MakeTempDir(config.CommonOptions.TempDirectory)
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(vendorDir)
+
+	v := &vendorer{
+		dir:           vendorDir,
+		allowUnpinned: allowUnpinned,
+		clones:        map[string]string{},
+		visited:       map[string]bool{},
+		rewritten:     map[string][]byte{},
+	}
+	root := path
+	if base, ok := parseRemoteBase(path); ok && helpers.InvalidPath(path) {
+		root, err = v.fetch(ctx, path, base)
+		if err != nil {
+			return err
+		}
+	}
+	root, err = filepath.Abs(root)
+	if err != nil {
+		return err
+	}
+	if err := v.vendor(ctx, root); err != nil {
+		return err
+	}
+	return build(vendoredFs{FileSystem: filesys.MakeFsOnDisk(), rewritten: v.rewritten}, root, destination, kustomizeAllowAnyDirectory)
+}
+
+// remoteBase is a directory of a git repository that is referenced by a kustomization.
+type remoteBase struct {
+	repo string
+	path string
+	ref  string
+}
+
+// parseRemoteBase parses a kustomize remote base such as github.com/org/repo/path?ref=v1.0.0 or
+// https://example.com/org/repo.git//path?ref=v1.0.0. Remote files and other references are not remote bases.
+func parseRemoteBase(s string) (remoteBase, bool) {
+	rest, query, _ := strings.Cut(s, "?")
+	values, err := url.ParseQuery(query)
+	if err != nil {
+		return remoteBase{}, false
+	}
+	ref := values.Get("ref")
+	if ref == "" {
+		ref = values.Get("version")
+	}
+	rest = strings.TrimPrefix(rest, "git::")
+
+	scheme := ""
+	switch {
+	case strings.HasPrefix(rest, "git@"):
+		// scp-like addresses are cloned over ssh.
+		host, p, ok := strings.Cut(strings.TrimPrefix(rest, "git@"), ":")
+		if !ok {
+			return remoteBase{}, false
+		}
+		scheme, rest = "ssh://git@", host+"/"+p
+	case strings.HasPrefix(rest, "github.com/"):
+		scheme = "https://"
+	default:
+		idx := strings.Index(rest, "://")
+		if idx == -1 {
+			return remoteBase{}, false
+		}
+		scheme, rest = rest[:idx+3], rest[idx+3:]
+	}
+
+	repo, path := "", ""
+	if idx := strings.Index(rest, "//"); idx != -1 {
+		repo, path = rest[:idx], rest[idx+2:]
+	} else if idx := strings.Index(rest+"/", ".git/"); idx != -1 {
+		repo, path = rest[:idx+len(".git")], strings.TrimPrefix(rest[idx+len(".git"):], "/")
+	} else if strings.HasPrefix(rest, "github.com/") {
+		// GitHub repositories are always github.com/<org>/<repo>.
+		parts := strings.SplitN(rest, "/", 4)
+		if len(parts) < 3 {
+			return remoteBase{}, false
+		}
+		repo = strings.Join(parts[:3], "/")
+		if len(parts) == 4 {
+			path = parts[3]
+		}
+	} else {
+		return remoteBase{}, false
+	}
+	if repo == "" || strings.HasSuffix(path, ".yaml") || strings.HasSuffix(path, ".yml") {
+		return remoteBase{}, false
+	}
+	return remoteBase{repo: scheme + repo, path: path, ref: ref}, true
+}
+
+// vendorer clones the remote bases of kustomizations and rewrites the kustomizations to point at the clones.
+type vendorer struct {
+	dir           string
+	allowUnpinned bool
+	// clones are the local paths of the cloned repositories by address.
+	clones  map[string]string
+	visited map[string]bool
+	// rewritten are the kustomization files that reference clones by path.
+	rewritten map[string][]byte
+}
+
+// vendor vendors the remote bases of the kustomization in the directory and of every local base it references.
+func (v *vendorer) vendor(ctx context.Context, dir string) error {
+	if v.visited[dir] {
+		return nil
+	}
+	v.visited[dir] = true
+
+	kustomizationPath := ""
+	for _, name := range konfig.RecognizedKustomizationFileNames() {
+		if !helpers.InvalidPath(filepath.Join(dir, name)) {
+			kustomizationPath = filepath.Join(dir, name)
+			break
+		}
+	}
+	if kustomizationPath == "" {
+		return nil
+	}
+	b, err := os.ReadFile(kustomizationPath)
+	if err != nil {
+		return err
+	}
+	kustomization := map[string]interface{}{}
+	if err := yaml.Unmarshal(b, &kustomization); err != nil {
+		return fmt.Errorf("unable to parse %s: %w", kustomizationPath, err)
+	}
+
+	rewritten := false
+	for _, field := range []string{"resources", "bases", "components"} {
+		entries, ok := kustomization[field].([]interface{})
+		if !ok {
+			continue
+		}
+		for i, entry := range entries {
+			entry, ok := entry.(string)
+			if !ok {
+				continue
+			}
+			if local := filepath.Join(dir, entry); !helpers.InvalidPath(local) {
+				if helpers.IsDir(local) {
+					if err := v.vendor(ctx, local); err != nil {
+						return err
+					}
+				}
+				continue
+			}
+			base, ok := parseRemoteBase(entry)
+			if !ok {
+				continue
+			}
+			clone, err := v.fetch(ctx, entry, base)
+			if err != nil {
+				return err
+			}
+			// Kustomize only accepts bases relative to the kustomization.
+			rel, err := filepath.Rel(dir, clone)
+			if err != nil {
+				return err
+			}
+			entries[i] = filepath.ToSlash(rel)
+			rewritten = true
+			if err := v.vendor(ctx, clone); err != nil {
+				return err
+			}
+		}
+	}
+	if !rewritten {
+		return nil
+	}
+	b, err = yaml.Marshal(kustomization)
+	if err != nil {
+		return err
+	}
+	resolved, err := filepath.EvalSymlinks(kustomizationPath)
+	if err != nil {
+		return err
+	}
+	v.rewritten[resolved] = b
+	return nil
+}
+
+// fetch clones the repository of a remote base and returns the local path of the base.
+func (v *vendorer) fetch(ctx context.Context, reference string, base remoteBase) (string, error) {
+	address := base.repo
+	pinned := false
+	switch {
+	case base.ref == "":
+	case plumbing.IsHash(base.ref):
+		pinned = true
+		address = fmt.Sprintf("%s@%s", base.repo, base.ref)
+	default:
+		refs, err := listRemoteRefs(ctx, base.repo)
+		if err != nil {
+			return "", fmt.Errorf("unable to list the refs of %s: %w", base.repo, err)
+		}
+		switch {
+		case slices.Contains(refs, plumbing.NewTagReferenceName(base.ref)):
+			pinned = true
+			address = fmt.Sprintf("%s@%s", base.repo, base.ref)
+		case slices.Contains(refs, plumbing.NewBranchReferenceName(base.ref)):
+			address = fmt.Sprintf("%s@%s", base.repo, plumbing.NewBranchReferenceName(base.ref))
+		default:
+			return "", fmt.Errorf("ref %s of the remote base %s is not a tag, branch or full commit SHA", base.ref, reference)
+		}
+	}
+	if !pinned && !v.allowUnpinned {
+		return "", fmt.Errorf("remote base %s is not pinned to a tag or commit, set ?ref= to a tag or full commit SHA or allow unpinned remote bases", reference)
+	}
+
+	repoPath, ok := v.clones[address]
+	if !ok {
+		// Commits can only be checked out from a full clone.
+		shallow := base.ref != "" && !plumbing.IsHash(base.ref)
+		repo, err := git.Clone(ctx, v.dir, address, shallow)
+		if err != nil {
+			return "", fmt.Errorf("unable to clone the remote base %s: %w", reference, err)
+		}
+		repoPath = repo.Path()
+		v.clones[address] = repoPath
+		head, err := repoHead(repoPath)
+		if err != nil {
+			return "", err
+		}
+		logger.From(ctx).Info("vendored kustomize remote base", "base", reference, "commit", head, "pinned", pinned)
+	}
+	basePath := filepath.Join(repoPath, filepath.FromSlash(base.path))
+	if !helpers.IsDir(basePath) {
+		return "", fmt.Errorf("remote base %s does not exist in %s", base.path, base.repo)
+	}
+	return basePath, nil
+}
+
+// listRemoteRefs returns the names of the refs of a remote repository.
+func listRemoteRefs(ctx context.Context, repoURL string) ([]plumbing.ReferenceName, error) {
+	remote := gogit.NewRemote(memory.NewStorage(), &gitconfig.RemoteConfig{Name: "origin", URLs: []string{repoURL}})
+	listOpts := &gogit.ListOptions{}
+	gitCred, err := utils.FindAuthForHost(repoURL)
+	if err != nil {
+		return nil, err
+	}
+	if gitCred != nil {
+		listOpts.Auth = &gitCred.Auth
+	}
+	refs, err := remote.ListContext(ctx, listOpts)
+	if err != nil {
+		return nil, err
+	}
+	names := []plumbing.ReferenceName{}
+	for _, ref := range refs {
+		names = append(names, ref.Name())
+	}
+	return names, nil
+}
+
+// repoHead returns the commit checked out in a repository.
+func repoHead(repoPath string) (string, error) {
+	repo, err := gogit.PlainOpen(repoPath)
+	if err != nil {
+		return "", err
+	}
+	head, err := repo.Head()
+	if err != nil {
+		return "", err
+	}
+	return head.Hash().String(), nil
+}
+
+// vendoredFs is a file system in which kustomization files that reference vendored remote bases are rewritten.
+type vendoredFs struct {
+	filesys.FileSystem
+	rewritten map[string][]byte
+}
+
+// ReadFile returns the rewritten content of kustomization files and the content on disk of other files.
+func (fSys vendoredFs) ReadFile(path string) ([]byte, error) {
+	if resolved, err := filepath.EvalSymlinks(path); err == nil {
+		if b, ok := fSys.rewritten[resolved]; ok {
+			return b, nil
+		}
+	}
+	return fSys.FileSystem.ReadFile(path)
+}
@@ -0,0 +1,167 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package kustomize
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zarf-dev/zarf/src/test/testutil"
+)
+
+func TestParseRemoteBase(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		reference string
+		expected  remoteBase
+		ok        bool
+	}{
+		{
+			name:      "github shorthand",
+			reference: "github.com/stefanprodan/podinfo/kustomize?ref=6.4.0",
+			expected:  remoteBase{repo: "https://github.com/stefanprodan/podinfo", path: "kustomize", ref: "6.4.0"},
+			ok:        true,
+		},
+		{
+			name:      "github https",
+			reference: "https://github.com/stefanprodan/podinfo/kustomize?ref=6.4.0",
+			expected:  remoteBase{repo: "https://github.com/stefanprodan/podinfo", path: "kustomize", ref: "6.4.0"},
+			ok:        true,
+		},
+		{
+			name:      "double slash",
+			reference: "git::https://example.com/org/repo//deploy/base?ref=v1.0.0&timeout=120",
+			expected:  remoteBase{repo: "https://example.com/org/repo", path: "deploy/base", ref: "v1.0.0"},
+			ok:        true,
+		},
+		{
+			name:      "git suffix",
+			reference: "https://example.com/org/repo.git/deploy?version=v1.0.0",
+			expected:  remoteBase{repo: "https://example.com/org/repo.git", path: "deploy", ref: "v1.0.0"},
+			ok:        true,
+		},
+		{
+			name:      "scp-like",
+			reference: "git@github.com:org/repo/deploy",
+			expected:  remoteBase{repo: "ssh://git@github.com/org/repo", path: "deploy"},
+			ok:        true,
+		},
+		{
+			name:      "remote file",
+			reference: "https://raw.githubusercontent.com/org/repo/main/deploy.yaml",
+		},
+		{
+			name:      "local path",
+			reference: "../base",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			base, ok := parseRemoteBase(tt.reference)
+			require.Equal(t, tt.ok, ok)
+			require.Equal(t, tt.expected, base)
+		})
+	}
+}
+
+func TestBuildVendored(t *testing.T) {
+	t.Parallel()
+	ctx := testutil.TestContext(t)
+
+	// A repository with a base that is tagged and on a branch.
+	repoDir := t.TempDir()
+	repo, err := gogit.PlainInit(repoDir, false)
+	require.NoError(t, err)
+	err = os.MkdirAll(filepath.Join(repoDir, "base"), 0o755)
+	require.NoError(t, err)
+	err = os.WriteFile(filepath.Join(repoDir, "base", "kustomization.yaml"), []byte("resources:\n  - configmap.yaml\n"), 0o644)
+	require.NoError(t, err)
+	configMap := "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: remote\ndata:\n  key: value\n"
+	err = os.WriteFile(filepath.Join(repoDir, "base", "configmap.yaml"), []byte(configMap), 0o644)
+	require.NoError(t, err)
+	tree, err := repo.Worktree()
+	require.NoError(t, err)
+	_, err = tree.Add("base")
+	require.NoError(t, err)
+	commit, err := tree.Commit("add base", &gogit.CommitOptions{
+		Author: &object.Signature{Name: "zarf", Email: "zarf@example.com", When: time.Now()},
+	})
+	require.NoError(t, err)
+	_, err = repo.CreateTag("v1.0.0", commit, nil)
+	require.NoError(t, err)
+	head, err := repo.Head()
+	require.NoError(t, err)
+	branch := head.Name().Short()
+
+	tests := []struct {
+		name          string
+		ref           string
+		allowUnpinned bool
+		expectedErr   string
+	}{
+		{
+			name: "tag",
+			ref:  "?ref=v1.0.0",
+		},
+		{
+			name: "commit",
+			ref:  "?ref=" + commit.String(),
+		},
+		{
+			name:        "branch",
+			ref:         "?ref=" + branch,
+			expectedErr: "is not pinned to a tag or commit",
+		},
+		{
+			name:          "allowed branch",
+			ref:           "?ref=" + branch,
+			allowUnpinned: true,
+		},
+		{
+			name:        "no ref",
+			expectedErr: "is not pinned to a tag or commit",
+		},
+		{
+			name:        "unknown ref",
+			ref:         "?ref=v2.0.0",
+			expectedErr: "ref v2.0.0 of the remote base",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			overlayDir := t.TempDir()
+			kustomization := fmt.Sprintf("resources:\n  - file://%s//base%s\nnamePrefix: app-\n", filepath.ToSlash(repoDir), tt.ref)
+			err := os.WriteFile(filepath.Join(overlayDir, "kustomization.yaml"), []byte(kustomization), 0o644)
+			require.NoError(t, err)
+			dst := filepath.Join(t.TempDir(), "out.yaml")
+			err = BuildVendored(ctx, overlayDir, dst, false, tt.allowUnpinned)
+			if tt.expectedErr != "" {
+				require.ErrorContains(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			b, err := os.ReadFile(dst)
+			require.NoError(t, err)
+			require.Equal(t, "apiVersion: v1\ndata:\n  key: value\nkind: ConfigMap\nmetadata:\n  name: app-remote\n", string(b))
+
+			// The kustomization on disk is left unchanged.
+			b, err = os.ReadFile(filepath.Join(overlayDir, "kustomization.yaml"))
+			require.NoError(t, err)
+			require.Equal(t, kustomization, string(b))
+		})
+	}
+}
@@ -5,8 +5,10 @@
 package sbom
 
 import (
+	"bufio"
 	"context"
 	"embed"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -20,7 +22,6 @@ import (
 	"github.com/anchore/syft/syft"
 	"github.com/anchore/syft/syft/artifact"
 	syftFile "github.com/anchore/syft/syft/file"
-	"github.com/anchore/syft/syft/format"
 	"github.com/anchore/syft/syft/format/syftjson"
 	"github.com/anchore/syft/syft/linux"
 	"github.com/anchore/syft/syft/pkg"
@@ -98,14 +99,14 @@ func Catalog(ctx context.Context, componentSBOMs map[string]*layout.ComponentSBO
 			return fmt.Errorf("unable to load the image to generate an SBOM: %w", err)
 		}
 
-		jsonData, err := builder.createImageSBOM(ctx, img, refInfo.Reference)
+		jsonPath, err := builder.createImageSBOM(ctx, img, refInfo.Reference)
 		if err != nil {
 			// TODO(mkcp): Remove message on logger release
 			builder.spinner.Errorf(err, "Unable to create SBOM for image %s", refInfo.Reference)
 			return fmt.Errorf("unable to create SBOM for image=%s: %w", refInfo.Reference, err)
 		}
 
-		if err = builder.createSBOMViewerAsset(refInfo.Reference, jsonData); err != nil {
+		if err = builder.createSBOMViewerAsset(refInfo.Reference, jsonPath); err != nil {
 			// TODO(mkcp): Remove message on logger release
 			builder.spinner.Errorf(err, "Unable to create SBOM viewer for image %s", refInfo.Reference)
 			return fmt.Errorf("unable to create SBOM viewer for image=%s: %w", refInfo.Reference, err)
@@ -130,14 +131,14 @@ func Catalog(ctx context.Context, componentSBOMs map[string]*layout.ComponentSBO
 			continue
 		}
 
-		jsonData, err := builder.createFileSBOM(ctx, *componentSBOMs[component], component)
+		jsonPath, err := builder.createFileSBOM(ctx, *componentSBOMs[component], component)
 		if err != nil {
 			// TODO(mkcp): Remove message on logger release
 			builder.spinner.Errorf(err, "Unable to create SBOM for component %s", component)
 			return fmt.Errorf("unable to create SBOM for component=%s: %w", component, err)
 		}
 
-		if err = builder.createSBOMViewerAsset(fmt.Sprintf("%s%s", componentPrefix, component), jsonData); err != nil {
+		if err = builder.createSBOMViewerAsset(fmt.Sprintf("%s%s", componentPrefix, component), jsonPath); err != nil {
 			// TODO(mkcp): Remove message on logger release
 			builder.spinner.Errorf(err, "Unable to create SBOM viewer for component %s", component)
 			return fmt.Errorf("unable to create SBOM for component=%s: %w", component, err)
@@ -168,13 +169,13 @@ func Catalog(ctx context.Context, componentSBOMs map[string]*layout.ComponentSBO
 	return nil
 }
 
-// createImageSBOM uses syft to generate SBOM for an image,
+// createImageSBOM uses syft to generate SBOM for an image and returns the path it was written to,
 // some code/structure migrated from https://github.com/testifysec/go-witness/blob/v0.1.12/attestation/syft/syft.go.
-func (b *Builder) createImageSBOM(ctx context.Context, img v1.Image, src string) ([]byte, error) {
+func (b *Builder) createImageSBOM(ctx context.Context, img v1.Image, src string) (_ string, err error) {
 	// Get the image reference.
 	refInfo, err := transform.ParseImageRef(src)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create ref for image %s: %w", src, err)
+		return "", fmt.Errorf("failed to create ref for image %s: %w", src, err)
 	}
 
 	// Create the sbom.
@@ -182,12 +183,16 @@ func (b *Builder) createImageSBOM(ctx context.Context, img v1.Image, src string)
 
 	// Ensure the image cache directory exists.
 	if err := helpers.CreateDirectory(imageCachePath, helpers.ReadWriteExecuteUser); err != nil {
-		return nil, err
+		return "", err
 	}
 
 	syftImage := image.NewImage(img, file.NewTempDirGenerator("zarf"), imageCachePath, image.WithTags(refInfo.Reference))
+	// Remove the unpacked layers once the image is cataloged so they do not pile up across images.
+	defer func() {
+		err = errors.Join(err, syftImage.Cleanup())
+	}()
 	if err := syftImage.Read(); err != nil {
-		return nil, err
+		return "", err
 	}
 
 	syftSrc := stereoscopesource.New(syftImage, stereoscopesource.ImageConfig{
@@ -197,50 +202,34 @@ func (b *Builder) createImageSBOM(ctx context.Context, img v1.Image, src string)
 	cfg := getDefaultSyftConfig()
 	sbom, err := syft.CreateSBOM(ctx, syftSrc, cfg)
 	if err != nil {
-		return nil, err
-	}
-
-	jsonData, err := format.Encode(*sbom, syftjson.NewFormatEncoder())
-	if err != nil {
-		return nil, err
+		return "", err
 	}
 
 	// Write the sbom to disk using the image ref as the filename
 	filename := fmt.Sprintf("%s.json", refInfo.Reference)
-	sbomFile, err := b.createSBOMFile(filename)
-	if err != nil {
-		return nil, err
-	}
-	defer sbomFile.Close()
-
-	if _, err = sbomFile.Write(jsonData); err != nil {
-		return nil, err
-	}
-
-	// Return the json data
-	return jsonData, nil
+	return b.writeSBOM(filename, *sbom)
 }
 
-// createPathSBOM uses syft to generate SBOM for a filepath.
-func (b *Builder) createFileSBOM(ctx context.Context, componentSBOM layout.ComponentSBOM, component string) ([]byte, error) {
+// createPathSBOM uses syft to generate SBOM for a filepath and returns the path it was written to.
+func (b *Builder) createFileSBOM(ctx context.Context, componentSBOM layout.ComponentSBOM, component string) (string, error) {
 	catalog := pkg.NewCollection()
 	relationships := []artifact.Relationship{}
 	parentSource, err := directorysource.NewFromPath(componentSBOM.Component.Base)
 	if err != nil {
-		return nil, err
+		return "", err
 	}
 
 	for _, sbomFile := range componentSBOM.Files {
 		// Create the sbom source
 		fileSrc, err := filesource.NewFromPath(sbomFile)
 		if err != nil {
-			return nil, err
+			return "", err
 		}
 
 		cfg := getDefaultSyftConfig()
 		sbom, err := syft.CreateSBOM(ctx, fileSrc, cfg)
 		if err != nil {
-			return nil, err
+			return "", err
 		}
 
 		for pkg := range sbom.Artifacts.Packages.Enumerate() {
@@ -285,25 +274,26 @@ func (b *Builder) createFileSBOM(ctx context.Context, componentSBOM layout.Compo
 		Relationships: relationships,
 	}
 
-	jsonData, err := format.Encode(artifact, syftjson.NewFormatEncoder())
-	if err != nil {
-		return nil, err
-	}
-
 	// Write the sbom to disk using the component prefix and name as the filename
 	filename := fmt.Sprintf("%s%s.json", componentPrefix, component)
+	return b.writeSBOM(filename, artifact)
+}
+
+// writeSBOM encodes the sbom as syft JSON directly to a file in the output directory and returns its path.
+func (b *Builder) writeSBOM(filename string, s sbom.SBOM) (string, error) {
 	sbomFile, err := b.createSBOMFile(filename)
 	if err != nil {
-		return nil, err
+		return "", err
 	}
-	defer sbomFile.Close()
-
-	if _, err = sbomFile.Write(jsonData); err != nil {
-		return nil, err
+	w := bufio.NewWriter(sbomFile)
+	err = syftjson.NewFormatEncoder().Encode(w, s)
+	if err == nil {
+		err = w.Flush()
 	}
-
-	// Return the json data
-	return jsonData, nil
+	if err := errors.Join(err, sbomFile.Close()); err != nil {
+		return "", err
+	}
+	return sbomFile.Name(), nil
 }
 
 func (b *Builder) getNormalizedFileName(identifier string) string {
@@ -12,6 +12,7 @@ import (
 	"github.com/zarf-dev/zarf/src/pkg/logger"
 
 	"github.com/AlecAivazis/survey/v2"
+	"github.com/zarf-dev/zarf/src/pkg/interactive"
 	"github.com/zarf-dev/zarf/src/pkg/message"
 	"github.com/zarf-dev/zarf/src/pkg/utils/exec"
 )
@@ -31,6 +32,10 @@ func ViewSBOMFiles(ctx context.Context, directory string) error {
 	}
 
 	link := sbomViewFiles[0]
+	if err := interactive.CheckPrompt("confirmation to finish viewing the SBOM files, write them with --sbom-out instead of viewing them with --sbom"); err != nil {
+		return err
+	}
+
 	msg := fmt.Sprintf("This package has %d images with software bill-of-materials (SBOM) included. If your browser did not open automatically you can copy and paste this file location into your browser address bar to view them: %s\n\n", len(sbomViewFiles), link)
 	message.Note(msg)
 	l.Info("this package has images with software bill-of-materials (SBOM) included. If your browser did not open automatically you can copy and paste this file location into your browser address bar to view them", "SBOMCount", len(sbomViewFiles), "link", link)
@@ -5,32 +5,36 @@
 package sbom
 
 import (
+	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"html/template"
+	"io"
+	"os"
 
 	"github.com/zarf-dev/zarf/src/pkg/layout"
 	"github.com/zarf-dev/zarf/src/pkg/transform"
 )
 
-func (b *Builder) createSBOMViewerAsset(identifier string, jsonData []byte) error {
+// sbomDataPlaceholder is rendered in place of the sbom data so the sbom can be copied into the viewer from disk.
+const sbomDataPlaceholder = "__ZARF_SBOM_DATA_PLACEHOLDER__"
+
+func (b *Builder) createSBOMViewerAsset(identifier string, jsonPath string) error {
 	filename := fmt.Sprintf("sbom-viewer-%s.html", b.getNormalizedFileName(identifier))
-	return b.createSBOMHTML(filename, "viewer/template.gohtml", jsonData)
+	return b.createSBOMHTML(filename, "viewer/template.gohtml", jsonPath)
 }
 
 func (b *Builder) createSBOMCompareAsset() error {
-	return b.createSBOMHTML("compare.html", "viewer/compare.gohtml", []byte{})
+	return b.createSBOMHTML("compare.html", "viewer/compare.gohtml", "")
 }
 
-func (b *Builder) createSBOMHTML(filename string, goTemplate string, jsonData []byte) error {
-	// Create the sbom viewer file for the image
-	sbomViewerFile, err := b.createSBOMFile(filename)
-	if err != nil {
-		return err
+func (b *Builder) createSBOMHTML(filename string, goTemplate string, jsonPath string) (err error) {
+	var data template.JS
+	if jsonPath != "" {
+		data = sbomDataPlaceholder
 	}
 
-	defer sbomViewerFile.Close()
-
 	// Create the sbomviewer template data
 	tplData := struct {
 		ThemeCSS  template.CSS
@@ -45,7 +49,7 @@ func (b *Builder) createSBOMHTML(filename string, goTemplate string, jsonData []
 		ThemeCSS:  b.loadFileCSS("theme.css"),
 		ViewerCSS: b.loadFileCSS("styles.css"),
 		List:      template.JS(b.jsonList),
-		Data:      template.JS(jsonData),
+		Data:      data,
 		LibraryJS: b.loadFileJS("library.js"),
 		CommonJS:  b.loadFileJS("common.js"),
 		ViewerJS:  b.loadFileJS("viewer.js"),
@@ -58,8 +62,43 @@ func (b *Builder) createSBOMHTML(filename string, goTemplate string, jsonData []
 		return err
 	}
 
-	// Write the sbomviewer template to disk
-	return tpl.Execute(sbomViewerFile, tplData)
+	var rendered bytes.Buffer
+	if err := tpl.Execute(&rendered, tplData); err != nil {
+		return err
+	}
+
+	// Create the sbom viewer file for the image
+	sbomViewerFile, err := b.createSBOMFile(filename)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = errors.Join(err, sbomViewerFile.Close())
+	}()
+
+	if jsonPath == "" {
+		_, err = rendered.WriteTo(sbomViewerFile)
+		return err
+	}
+
+	// Write the sbomviewer template to disk, copying the sbom in from its file in place of the placeholder
+	before, after, ok := bytes.Cut(rendered.Bytes(), []byte(sbomDataPlaceholder))
+	if !ok {
+		return fmt.Errorf("template %s does not render the sbom data", goTemplate)
+	}
+	jsonFile, err := os.Open(jsonPath)
+	if err != nil {
+		return err
+	}
+	defer jsonFile.Close()
+	if _, err := sbomViewerFile.Write(before); err != nil {
+		return err
+	}
+	if _, err := io.Copy(sbomViewerFile, jsonFile); err != nil {
+		return err
+	}
+	_, err = sbomViewerFile.Write(after)
+	return err
 }
 
 func (b *Builder) loadFileCSS(name string) template.CSS {
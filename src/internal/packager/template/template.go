@@ -15,7 +15,6 @@ import (
 	"github.com/zarf-dev/zarf/src/api/v1alpha1"
 	"github.com/zarf-dev/zarf/src/types"
 
-	"github.com/defenseunicorns/pkg/helpers/v2"
 	"github.com/zarf-dev/zarf/src/config"
 	"github.com/zarf-dev/zarf/src/pkg/interactive"
 	"github.com/zarf-dev/zarf/src/pkg/logger"
@@ -65,6 +64,11 @@ func GetZarfTemplates(ctx context.Context, componentName string, state *types.Za
 			"GIT_AUTH_PUSH": gitInfo.PushPassword,
 			"GIT_PULL":      gitInfo.PullUsername,
 			"GIT_AUTH_PULL": gitInfo.PullPassword,
+
+			"GIT_SERVER_PROTOCOL": "http",
+		}
+		if gitInfo.TLS != nil {
+			builtinMap["GIT_SERVER_PROTOCOL"] = "https"
 		}
 
 		builtinMap[depMarker] = config.GetDataInjectionMarker()
@@ -78,13 +82,31 @@ func GetZarfTemplates(ctx context.Context, componentName string, state *types.Za
 			builtinMap["AGENT_CA"] = base64.StdEncoding.EncodeToString(agentTLS.CA)
 
 		case "zarf-seed-registry", "zarf-registry":
-			builtinMap["SEED_REGISTRY"] = fmt.Sprintf("%s:%s", helpers.IPV4Localhost, config.ZarfSeedPort)
+			builtinMap["SEED_REGISTRY"] = config.ZarfSeedRegistry
+			builtinMap["SEED_NODE_SELECTOR"] = ""
+			if config.ZarfSeedNode != "" {
+				builtinMap["SEED_NODE_SELECTOR"] = fmt.Sprintf("kubernetes.io/hostname: %s", config.ZarfSeedNode)
+			}
 			htpasswd, err := generateHtpasswd(&regInfo)
 			if err != nil {
 				return templateMap, err
 			}
 			builtinMap["HTPASSWD"] = htpasswd
 			builtinMap["REGISTRY_SECRET"] = regInfo.Secret
+			builtinMap["REGISTRY_TLS_SECRET"] = ""
+			if regInfo.TLS != nil {
+				builtinMap["REGISTRY_TLS_SECRET"] = regInfo.TLS.SecretName
+			}
+			builtinMap["REGISTRY_TOKEN_REALM"] = ""
+			builtinMap["REGISTRY_TOKEN_SERVICE"] = ""
+			builtinMap["REGISTRY_TOKEN_ISSUER"] = ""
+			builtinMap["REGISTRY_TOKEN_CERT"] = ""
+			if regInfo.TokenAuth != nil {
+				builtinMap["REGISTRY_TOKEN_REALM"] = regInfo.TokenAuth.Realm
+				builtinMap["REGISTRY_TOKEN_SERVICE"] = regInfo.TokenAuth.Service
+				builtinMap["REGISTRY_TOKEN_ISSUER"] = regInfo.TokenAuth.Issuer
+				builtinMap["REGISTRY_TOKEN_CERT"] = base64.StdEncoding.EncodeToString(regInfo.TokenAuth.RootCertBundle)
+			}
 		}
 
 		// Iterate over any custom variables and add them to the mappings for templating
@@ -99,6 +121,7 @@ func GetZarfTemplates(ctx context.Context, componentName string, state *types.Za
 				key == "GIT_AUTH_PUSH" || key == "REGISTRY_AUTH_PULL" || key == "REGISTRY_AUTH_PUSH" {
 				// Sanitize any builtin templates that are sensitive
 				templateMap[strings.ToUpper(fmt.Sprintf("###ZARF_%s###", key))].Sensitive = true
+				logger.AddSecret(value)
 			}
 		}
 	}
@@ -115,17 +138,12 @@ func GetZarfTemplates(ctx context.Context, componentName string, state *types.Za
 func generateHtpasswd(regInfo *types.RegistryInfo) (string, error) {
 	// Only calculate this for internal registries to allow longer external passwords
 	if regInfo.IsInternal() {
-		pushUser, err := utils.GetHtpasswdString(regInfo.PushUsername, regInfo.PushPassword)
-		if err != nil {
-			return "", fmt.Errorf("error generating htpasswd string: %w", err)
-		}
-
-		pullUser, err := utils.GetHtpasswdString(regInfo.PullUsername, regInfo.PullPassword)
+		users, err := utils.GetHtpasswdStrings(regInfo.HtpasswdUsers())
 		if err != nil {
 			return "", fmt.Errorf("error generating htpasswd string: %w", err)
 		}
 
-		return fmt.Sprintf("%s\\n%s", pushUser, pullUser), nil
+		return strings.Join(users, "\\n"), nil
 	}
 
 	return "", nil
@@ -0,0 +1,200 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package tofu contains functions for vendoring and applying OpenTofu and Terraform modules.
+package tofu
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/defenseunicorns/pkg/helpers/v2"
+
+	"github.com/zarf-dev/zarf/src/api/v1alpha1"
+	"github.com/zarf-dev/zarf/src/config"
+	zexec "github.com/zarf-dev/zarf/src/pkg/utils/exec"
+)
+
+const (
+	// ModuleDir is the directory the vendored module is stored in.
+	ModuleDir = "module"
+	// ProvidersDir is the directory the provider mirror of the module is stored in.
+	ProvidersDir = "providers"
+	// VariablesFile is the file the variables of the module are written to before they are templated.
+	VariablesFile = "zarf.auto.tfvars.json"
+
+	backendOverrideFile = "zarf_override.tf"
+	cliConfigFile       = "zarf.tfrc"
+	planFile            = "zarf.tfplan"
+)
+
+// Binary returns the path of the tofu binary, falling back to terraform.
+func Binary() (string, error) {
+	for _, name := range []string{"tofu", "terraform"} {
+		path, err := exec.LookPath(name)
+		if err == nil {
+			return path, nil
+		}
+	}
+	return "", errors.New("unable to find tofu or terraform in the PATH, they can be installed with a host artifact")
+}
+
+// Platforms returns the platforms provider binaries are vendored for, which defaults to linux and the architecture of the package.
+func Platforms(module v1alpha1.ZarfTofuModule, arch string) []string {
+	if len(module.Platforms) > 0 {
+		return module.Platforms
+	}
+	return []string{fmt.Sprintf("linux_%s", arch)}
+}
+
+// Vendor copies the module from src into dst, downloads its remote modules and mirrors its providers for the given platforms.
+func Vendor(ctx context.Context, src, dst string, platforms []string) error {
+	bin, err := Binary()
+	if err != nil {
+		return err
+	}
+	moduleDir := filepath.Join(dst, ModuleDir)
+	if err := helpers.CreatePathAndCopy(src, moduleDir); err != nil {
+		return fmt.Errorf("unable to copy tofu module %s: %w", src, err)
+	}
+	// Local state and working directories must not end up in the package.
+	for _, name := range []string{".terraform", "terraform.tfstate", "terraform.tfstate.backup"} {
+		if err := os.RemoveAll(filepath.Join(moduleDir, name)); err != nil {
+			return err
+		}
+	}
+
+	cfg := zexec.Config{Dir: moduleDir, Env: []string{"TF_IN_AUTOMATION=1"}}
+	if err := run(ctx, cfg, bin, "init", "-backend=false", "-input=false"); err != nil {
+		return err
+	}
+	// Providers are installed from the mirror on deploy.
+	if err := os.RemoveAll(filepath.Join(moduleDir, ".terraform", "providers")); err != nil {
+		return err
+	}
+	args := []string{"providers", "mirror"}
+	for _, platform := range platforms {
+		args = append(args, fmt.Sprintf("-platform=%s", platform))
+	}
+	args = append(args, filepath.Join(dst, ProvidersDir))
+	return run(ctx, cfg, bin, args...)
+}
+
+// WriteVariables writes the variables of the module to the variables file of the vendored module in dir.
+func WriteVariables(module v1alpha1.ZarfTofuModule, dir string) (string, error) {
+	b, err := json.MarshalIndent(module.Variables, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	path := filepath.Join(dir, ModuleDir, VariablesFile)
+	if err := os.WriteFile(path, b, helpers.ReadWriteUser); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// ApplyOptions are the options for Apply.
+type ApplyOptions struct {
+	// PackageName is the name of the package the module is deployed with, used to name its state.
+	PackageName string
+	// KubeConfigPath is the kubeconfig the kubernetes backend connects with, it uses the in cluster config when empty.
+	KubeConfigPath string
+}
+
+// Apply plans and applies the vendored module in dir, installing its providers from the vendored mirror.
+func Apply(ctx context.Context, module v1alpha1.ZarfTofuModule, dir string, opts ApplyOptions) error {
+	bin, err := Binary()
+	if err != nil {
+		return err
+	}
+	moduleDir := filepath.Join(dir, ModuleDir)
+	backend, err := backendConfig(module, opts)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(moduleDir, backendOverrideFile), []byte(backend), helpers.ReadWriteUser); err != nil {
+		return err
+	}
+	cliConfigPath := filepath.Join(dir, cliConfigFile)
+	if err := os.WriteFile(cliConfigPath, []byte(cliConfig(filepath.Join(dir, ProvidersDir))), helpers.ReadWriteUser); err != nil {
+		return err
+	}
+
+	cfg := zexec.Config{
+		Print: true,
+		Dir:   moduleDir,
+		Env:   []string{"TF_IN_AUTOMATION=1", fmt.Sprintf("TF_CLI_CONFIG_FILE=%s", cliConfigPath)},
+	}
+	if err := run(ctx, cfg, bin, "init", "-input=false", "-reconfigure"); err != nil {
+		return err
+	}
+	if err := run(ctx, cfg, bin, "plan", "-input=false", fmt.Sprintf("-out=%s", planFile)); err != nil {
+		return err
+	}
+	return run(ctx, cfg, bin, "apply", "-input=false", planFile)
+}
+
+// StatePath returns the path of the local state file of the module.
+func StatePath(module v1alpha1.ZarfTofuModule, packageName string) (string, error) {
+	statePath := module.StatePath
+	if statePath == "" {
+		statePath = filepath.Join("~", ".zarf", "tofu", packageName, fmt.Sprintf("%s.tfstate", module.Name))
+	}
+	path, err := config.GetAbsHomePath(statePath)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Abs(path)
+}
+
+// backendConfig returns the override file that configures the backend the state of the module is stored in.
+func backendConfig(module v1alpha1.ZarfTofuModule, opts ApplyOptions) (string, error) {
+	switch module.Backend {
+	case "", v1alpha1.TofuBackendLocal:
+		statePath, err := StatePath(module, opts.PackageName)
+		if err != nil {
+			return "", err
+		}
+		if err := helpers.CreateParentDirectory(statePath); err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("terraform {\n  backend \"local\" {\n    path = %s\n  }\n}\n", strconv.Quote(statePath)), nil
+	case v1alpha1.TofuBackendKubernetes:
+		namespace := module.Namespace
+		if namespace == "" {
+			namespace = "zarf"
+		}
+		lines := []string{
+			fmt.Sprintf("    secret_suffix = %s", strconv.Quote(fmt.Sprintf("%s-%s", opts.PackageName, module.Name))),
+			fmt.Sprintf("    namespace     = %s", strconv.Quote(namespace)),
+		}
+		if opts.KubeConfigPath == "" {
+			lines = append(lines, "    in_cluster_config = true")
+		} else {
+			lines = append(lines, fmt.Sprintf("    config_path   = %s", strconv.Quote(opts.KubeConfigPath)))
+		}
+		return fmt.Sprintf("terraform {\n  backend \"kubernetes\" {\n%s\n  }\n}\n", strings.Join(lines, "\n")), nil
+	default:
+		return "", fmt.Errorf("tofu module %s has an unsupported backend %s", module.Name, module.Backend)
+	}
+}
+
+// cliConfig returns the CLI configuration that installs providers only from the mirror at providersPath.
+func cliConfig(providersPath string) string {
+	return fmt.Sprintf("provider_installation {\n  filesystem_mirror {\n    path = %s\n  }\n}\n", strconv.Quote(providersPath))
+}
+
+func run(ctx context.Context, cfg zexec.Config, bin string, args ...string) error {
+	_, stderr, err := zexec.CmdWithContext(ctx, cfg, bin, args...)
+	if err != nil {
+		return fmt.Errorf("unable to run %s %s: %w: %s", filepath.Base(bin), args[0], err, strings.TrimSpace(stderr))
+	}
+	return nil
+}
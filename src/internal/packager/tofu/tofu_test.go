@@ -0,0 +1,166 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package tofu
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/zarf-dev/zarf/src/api/v1alpha1"
+	"github.com/zarf-dev/zarf/src/test/testutil"
+)
+
+// fakeTofu puts a tofu binary in the PATH that records its working directory and arguments to the returned log.
+func fakeTofu(t *testing.T) string {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("the fake tofu binary is a shell script")
+	}
+	binDir := t.TempDir()
+	log := filepath.Join(t.TempDir(), "tofu.log")
+	script := "#!/bin/sh\necho \"${PWD##*/} $*\" >> " + log + "\n"
+	err := os.WriteFile(filepath.Join(binDir, "tofu"), []byte(script), 0o700)
+	require.NoError(t, err)
+	t.Setenv("PATH", binDir)
+	return log
+}
+
+func readLog(t *testing.T, log string) []string {
+	t.Helper()
+	b, err := os.ReadFile(log)
+	require.NoError(t, err)
+	return strings.Split(strings.TrimSpace(string(b)), "\n")
+}
+
+func TestVendor(t *testing.T) {
+	log := fakeTofu(t)
+	ctx := testutil.TestContext(t)
+
+	src := t.TempDir()
+	err := os.WriteFile(filepath.Join(src, "main.tf"), []byte("resource \"null_resource\" \"a\" {}"), 0o600)
+	require.NoError(t, err)
+	err = os.WriteFile(filepath.Join(src, "terraform.tfstate"), []byte("{}"), 0o600)
+	require.NoError(t, err)
+	err = os.MkdirAll(filepath.Join(src, ".terraform", "providers"), 0o700)
+	require.NoError(t, err)
+
+	dst := filepath.Join(t.TempDir(), "0")
+	err = Vendor(ctx, src, dst, []string{"linux_amd64", "darwin_arm64"})
+	require.NoError(t, err)
+	require.FileExists(t, filepath.Join(dst, ModuleDir, "main.tf"))
+	require.NoFileExists(t, filepath.Join(dst, ModuleDir, "terraform.tfstate"))
+	require.NoDirExists(t, filepath.Join(dst, ModuleDir, ".terraform"))
+
+	expected := []string{
+		"module init -backend=false -input=false",
+		"module providers mirror -platform=linux_amd64 -platform=darwin_arm64 " + filepath.Join(dst, ProvidersDir),
+	}
+	require.Equal(t, expected, readLog(t, log))
+}
+
+func TestApply(t *testing.T) {
+	log := fakeTofu(t)
+	ctx := testutil.TestContext(t)
+
+	dir := t.TempDir()
+	err := os.MkdirAll(filepath.Join(dir, ModuleDir), 0o700)
+	require.NoError(t, err)
+	module := v1alpha1.ZarfTofuModule{
+		Name:      "network",
+		StatePath: filepath.Join(t.TempDir(), "state", "network.tfstate"),
+		Variables: map[string]string{"cidr": "10.0.0.0/16"},
+	}
+	path, err := WriteVariables(module, dir)
+	require.NoError(t, err)
+	b, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"cidr": "10.0.0.0/16"}`, string(b))
+
+	err = Apply(ctx, module, dir, ApplyOptions{PackageName: "infra"})
+	require.NoError(t, err)
+	expected := []string{
+		"module init -input=false -reconfigure",
+		"module plan -input=false -out=zarf.tfplan",
+		"module apply -input=false zarf.tfplan",
+	}
+	require.Equal(t, expected, readLog(t, log))
+	b, err = os.ReadFile(filepath.Join(dir, cliConfigFile))
+	require.NoError(t, err)
+	require.Contains(t, string(b), filepath.Join(dir, ProvidersDir))
+	require.FileExists(t, filepath.Join(dir, ModuleDir, backendOverrideFile))
+	require.DirExists(t, filepath.Dir(module.StatePath))
+
+	module.Backend = "s3"
+	err = Apply(ctx, module, dir, ApplyOptions{PackageName: "infra"})
+	require.EqualError(t, err, "tofu module network has an unsupported backend s3")
+}
+
+func TestBinary(t *testing.T) {
+	t.Setenv("PATH", t.TempDir())
+	_, err := Binary()
+	require.EqualError(t, err, "unable to find tofu or terraform in the PATH, they can be installed with a host artifact")
+}
+
+func TestBackendConfig(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		module   v1alpha1.ZarfTofuModule
+		opts     ApplyOptions
+		expected string
+	}{
+		{
+			name:     "local",
+			module:   v1alpha1.ZarfTofuModule{Name: "network", StatePath: "/var/lib/zarf/network.tfstate"},
+			expected: "terraform {\n  backend \"local\" {\n    path = \"/var/lib/zarf/network.tfstate\"\n  }\n}\n",
+		},
+		{
+			name:     "kubernetes",
+			module:   v1alpha1.ZarfTofuModule{Name: "network", Backend: v1alpha1.TofuBackendKubernetes},
+			opts:     ApplyOptions{PackageName: "infra", KubeConfigPath: "/root/.kube/config"},
+			expected: "terraform {\n  backend \"kubernetes\" {\n    secret_suffix = \"infra-network\"\n    namespace     = \"zarf\"\n    config_path   = \"/root/.kube/config\"\n  }\n}\n",
+		},
+		{
+			name:     "kubernetes in cluster",
+			module:   v1alpha1.ZarfTofuModule{Name: "network", Backend: v1alpha1.TofuBackendKubernetes, Namespace: "infra"},
+			opts:     ApplyOptions{PackageName: "infra"},
+			expected: "terraform {\n  backend \"kubernetes\" {\n    secret_suffix = \"infra-network\"\n    namespace     = \"infra\"\n    in_cluster_config = true\n  }\n}\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if tt.module.StatePath != "" {
+				tt.module.StatePath = filepath.Join(t.TempDir(), filepath.Base(tt.module.StatePath))
+				tt.expected = strings.Replace(tt.expected, "/var/lib/zarf/network.tfstate", tt.module.StatePath, 1)
+			}
+			backend, err := backendConfig(tt.module, tt.opts)
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, backend)
+		})
+	}
+}
+
+func TestStatePath(t *testing.T) {
+	t.Parallel()
+
+	home, err := os.UserHomeDir()
+	require.NoError(t, err)
+	path, err := StatePath(v1alpha1.ZarfTofuModule{Name: "network"}, "infra")
+	require.NoError(t, err)
+	require.Equal(t, filepath.Join(home, ".zarf", "tofu", "infra", "network.tfstate"), path)
+}
+
+func TestPlatforms(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, []string{"linux_arm64"}, Platforms(v1alpha1.ZarfTofuModule{}, "arm64"))
+	require.Equal(t, []string{"darwin_arm64"}, Platforms(v1alpha1.ZarfTofuModule{Platforms: []string{"darwin_arm64"}}, "amd64"))
+}
@@ -10,17 +10,20 @@ import (
 	"path/filepath"
 	"regexp"
 	"runtime"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/defenseunicorns/pkg/helpers/v2"
 	"github.com/zarf-dev/zarf/src/api/v1alpha1"
 	"github.com/zarf-dev/zarf/src/internal/packager/template"
+	"github.com/zarf-dev/zarf/src/internal/tracing"
 	"github.com/zarf-dev/zarf/src/pkg/logger"
 	"github.com/zarf-dev/zarf/src/pkg/message"
 	"github.com/zarf-dev/zarf/src/pkg/utils"
 	"github.com/zarf-dev/zarf/src/pkg/utils/exec"
 	"github.com/zarf-dev/zarf/src/pkg/variables"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 // Run runs all provided actions.
@@ -38,13 +41,22 @@ func Run(ctx context.Context, basePath string, defaultCfg v1alpha1.ZarfComponent
 }
 
 // Run commands that a component has provided.
-func runAction(ctx context.Context, basePath string, defaultCfg v1alpha1.ZarfComponentActionDefaults, action v1alpha1.ZarfComponentAction, variableConfig *variables.VariableConfig) error {
+func runAction(ctx context.Context, basePath string, defaultCfg v1alpha1.ZarfComponentActionDefaults, action v1alpha1.ZarfComponentAction, variableConfig *variables.VariableConfig) (err error) {
+	// The command is not recorded as it may contain sensitive values.
+	ctx, span := tracing.Start(ctx, "action", attribute.String("description", action.Description))
+	defer func() {
+		tracing.End(span, err)
+	}()
 	var cmdEscaped string
-	var err error
 	cmd := action.Cmd
 	l := logger.From(ctx)
 	start := time.Now()
 
+	// Network and exec waits are checked from the deploy host itself.
+	if action.Wait != nil && action.Wait.Cluster == nil {
+		return runHostWait(ctx, basePath, defaultCfg, action, variableConfig)
+	}
+
 	// If the action is a wait, convert it to a command.
 	if action.Wait != nil {
 		// If the wait has no timeout, set a default of 5 minutes.
@@ -143,6 +155,10 @@ retryCmd:
 			spinner.Updatef("Waiting for \"%s\" (no timeout)", cmdEscaped)
 			l.Info("waiting for action (no timeout)", "cmd", cmdEscaped)
 			if err := tryCmd(ctx); err != nil {
+				// Stop retrying once the deploy is cancelled.
+				if ctx.Err() != nil {
+					return fmt.Errorf("command %q cancelled: %w", cmdEscaped, context.Cause(ctx))
+				}
 				continue retryCmd
 			}
 
@@ -159,9 +175,13 @@ retryCmd:
 
 		// Otherwise, try running the command.
 		default:
-			ctx, cancel := context.WithTimeout(ctx, duration)
-			defer cancel()
-			if err := tryCmd(ctx); err != nil {
+			tryCtx, cancel := context.WithTimeout(ctx, duration)
+			err := tryCmd(tryCtx)
+			cancel()
+			if err != nil {
+				if ctx.Err() != nil {
+					return fmt.Errorf("command %q cancelled: %w", cmdEscaped, context.Cause(ctx))
+				}
 				continue retryCmd
 			}
 
@@ -201,22 +221,64 @@ func convertWaitToCmd(_ context.Context, wait v1alpha1.ZarfComponentActionWait,
 			cluster.Kind, cluster.Name, cluster.Condition, ns, timeoutString), nil
 	}
 
-	network := wait.Network
-	if network != nil {
-		// Make sure the protocol is lower case.
-		network.Protocol = strings.ToLower(network.Protocol)
+	return "", fmt.Errorf("wait action is missing a cluster")
+}
 
-		// If the protocol is http and no code is set, default to 200.
-		if strings.HasPrefix(network.Protocol, "http") && network.Code == 0 {
-			network.Code = 200
-		}
+// runHostWait waits for the network or exec condition of a wait action from the deploy host.
+func runHostWait(ctx context.Context, basePath string, defaultCfg v1alpha1.ZarfComponentActionDefaults, action v1alpha1.ZarfComponentAction, variableConfig *variables.VariableConfig) error {
+	l := logger.From(ctx)
+	start := time.Now()
 
-		// Build a call to the zarf tools wait-for command.
-		return fmt.Sprintf("./zarf tools wait-for %s %s %d %s",
-			network.Protocol, network.Address, network.Code, timeoutString), nil
+	// If the wait has no timeout, set a default of 5 minutes.
+	timeout := 300
+	if action.MaxTotalSeconds != nil {
+		timeout = *action.MaxTotalSeconds
 	}
+	duration := time.Duration(timeout) * time.Second
 
-	return "", fmt.Errorf("wait action is missing a cluster or network")
+	// Waits are checked until the timeout unless the action or its defaults limit the retries.
+	actionDefaults := actionGetCfg(ctx, defaultCfg, action, variableConfig.GetAllTemplates())
+	maxRetries := -1
+	if action.MaxRetries != nil || defaultCfg.MaxRetries > 0 {
+		maxRetries = actionDefaults.MaxRetries
+	}
+
+	description := action.Description
+	var err error
+	switch {
+	case action.Wait.Network != nil:
+		network := action.Wait.Network
+		protocol := strings.ToLower(network.Protocol)
+		// If the protocol is http and no code is set, default to 200.
+		code := network.Code
+		if strings.HasPrefix(protocol, "http") && code == 0 {
+			code = 200
+		}
+		if description == "" {
+			description = fmt.Sprintf("%s://%s", protocol, network.Address)
+		}
+		l.Info("waiting for network endpoint", "endpoint", description, "timeout", timeout)
+		err = utils.WaitForNetworkEndpoint(ctx, protocol, network.Address, strconv.Itoa(code), network.Body, duration, maxRetries)
+	case action.Wait.Exec != nil:
+		// Exec waits run in the shell, directory and environment of the action.
+		actionDefaults.Dir = filepath.Join(basePath, actionDefaults.Dir)
+		cmd, mutateErr := actionCmdMutation(ctx, action.Wait.Exec.Cmd, actionDefaults.Shell)
+		if mutateErr != nil {
+			return fmt.Errorf("unable to mutate the wait command: %w", mutateErr)
+		}
+		if description == "" {
+			description = helpers.Truncate(action.Wait.Exec.Cmd, 60, false)
+		}
+		l.Info("waiting for command", "cmd", description, "timeout", timeout)
+		err = utils.WaitForCommand(ctx, actionDefaults.Shell, exec.Config{Env: actionDefaults.Env, Dir: actionDefaults.Dir}, cmd, duration, maxRetries)
+	default:
+		return fmt.Errorf("wait action is missing a cluster, network or exec")
+	}
+	if err != nil {
+		return fmt.Errorf("wait for %q failed: %w", description, err)
+	}
+	l.Debug("wait for action succeeded", "cmd", description, "duration", time.Since(start))
+	return nil
 }
 
 // Perform some basic string mutations to make commands more useful.
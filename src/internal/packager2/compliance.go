@@ -0,0 +1,291 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package packager2
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/open-policy-agent/opa/rego"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	psaapi "k8s.io/pod-security-admission/api"
+	psapolicy "k8s.io/pod-security-admission/policy"
+
+	layout2 "github.com/zarf-dev/zarf/src/internal/packager2/layout"
+	"github.com/zarf-dev/zarf/src/pkg/utils"
+)
+
+// regoQuery is the query that policy bundles are evaluated with, it follows the conftest convention of deny and
+// violation rules in the main package.
+const regoQuery = "deny := object.get(data.main, \"deny\", []); violation := object.get(data.main, \"violation\", [])"
+
+// podTemplatePaths are the paths to the pod template of the workload kinds.
+var podTemplatePaths = map[schema.GroupKind][]string{
+	{Kind: "ReplicationController"}:      {"spec", "template"},
+	{Group: "apps", Kind: "Deployment"}:  {"spec", "template"},
+	{Group: "apps", Kind: "StatefulSet"}: {"spec", "template"},
+	{Group: "apps", Kind: "DaemonSet"}:   {"spec", "template"},
+	{Group: "apps", Kind: "ReplicaSet"}:  {"spec", "template"},
+	{Group: "batch", Kind: "Job"}:        {"spec", "template"},
+	{Group: "batch", Kind: "CronJob"}:    {"spec", "jobTemplate", "spec", "template"},
+	{Kind: "PodTemplate"}:                {"template"},
+}
+
+// InspectComplianceOptions are the options for InspectCompliance.
+type InspectComplianceOptions struct {
+	SetVariables map[string]string
+	KubeVersion  string
+	// RegistryURL is the registry address used for the ###ZARF_REGISTRY### template, it defaults to the in cluster registry.
+	RegistryURL string
+	// PodSecurityLevel is the Pod Security Standard that workloads are evaluated against, either baseline or restricted.
+	// Workloads are not evaluated against a Pod Security Standard when it is empty.
+	PodSecurityLevel string
+	// PolicyPaths are the files and directories of Rego and Kyverno policies that all resources are evaluated against.
+	PolicyPaths []string
+}
+
+// ComplianceViolation is a resource of a package that does not comply with a policy.
+type ComplianceViolation struct {
+	Component string `json:"component"`
+	Resource  string `json:"resource"`
+	Namespace string `json:"namespace,omitempty"`
+	Policy    string `json:"policy"`
+	Message   string `json:"message"`
+}
+
+// InspectCompliance renders the charts and manifests of the package without a cluster and evaluates the resources
+// against the Pod Security Standards and the Rego and Kyverno policies, returning the violations in the order the resources are
+// rendered.
+func InspectCompliance(ctx context.Context, pkgLayout *layout2.PackageLayout, opt InspectComplianceOptions) ([]ComplianceViolation, error) {
+	evaluator, err := newComplianceEvaluator(ctx, opt.PodSecurityLevel, opt.PolicyPaths)
+	if err != nil {
+		return nil, err
+	}
+	rendered, err := InspectManifests(ctx, pkgLayout, InspectManifestsOptions{
+		SetVariables: opt.SetVariables,
+		KubeVersion:  opt.KubeVersion,
+		RegistryURL:  opt.RegistryURL,
+	})
+	if err != nil {
+		return nil, err
+	}
+	violations := []ComplianceViolation{}
+	for _, r := range rendered {
+		objs, err := utils.SplitYAML([]byte(r.Content))
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse the %s %s of component %s: %w", r.Kind, r.Name, r.Component, err)
+		}
+		for _, obj := range objs {
+			namespace := obj.GetNamespace()
+			if namespace == "" {
+				namespace = r.Namespace
+			}
+			vs, err := evaluator.evaluate(ctx, obj, namespace)
+			if err != nil {
+				return nil, err
+			}
+			for _, v := range vs {
+				v.Component = r.Component
+				v.Namespace = namespace
+				violations = append(violations, v)
+			}
+		}
+	}
+	return violations, nil
+}
+
+// complianceEvaluator evaluates resources against a Pod Security Standard and Rego and Kyverno policies.
+type complianceEvaluator struct {
+	level     psaapi.Level
+	podChecks psapolicy.Evaluator
+	query     *rego.PreparedEvalQuery
+	kyverno   []kyvernoPolicy
+}
+
+func newComplianceEvaluator(ctx context.Context, podSecurityLevel string, policyPaths []string) (*complianceEvaluator, error) {
+	e := &complianceEvaluator{}
+	if podSecurityLevel != "" {
+		level, err := psaapi.ParseLevel(podSecurityLevel)
+		if err != nil || level == psaapi.LevelPrivileged {
+			return nil, fmt.Errorf("invalid pod security level %q: must be baseline or restricted", podSecurityLevel)
+		}
+		podChecks, err := psapolicy.NewEvaluator(psapolicy.DefaultChecks())
+		if err != nil {
+			return nil, err
+		}
+		e.level = level
+		e.podChecks = podChecks
+	}
+	kyverno, regoPaths, err := loadPolicies(ctx, policyPaths)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load the policies: %w", err)
+	}
+	e.kyverno = kyverno
+	if len(regoPaths) > 0 {
+		query, err := rego.New(rego.Query(regoQuery), rego.Load(regoPaths, nil)).PrepareForEval(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("unable to load the policies: %w", err)
+		}
+		e.query = &query
+	}
+	return e, nil
+}
+
+// loadPolicies reads the Kyverno policies from the YAML files of the policy paths and returns them with the remaining
+// files, which are loaded as Rego policies and data.
+func loadPolicies(ctx context.Context, policyPaths []string) ([]kyvernoPolicy, []string, error) {
+	kyverno := []kyvernoPolicy{}
+	regoPaths := []string{}
+	for _, root := range policyPaths {
+		err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if d.IsDir() {
+				return nil
+			}
+			switch filepath.Ext(path) {
+			case ".yaml", ".yml":
+				b, err := os.ReadFile(path)
+				if err != nil {
+					return err
+				}
+				docs, err := utils.SplitYAMLToString(b)
+				if err != nil {
+					return fmt.Errorf("unable to parse %s: %w", path, err)
+				}
+				policies, ok, err := parseKyvernoPolicies(ctx, docs)
+				if err != nil {
+					return fmt.Errorf("unable to parse %s: %w", path, err)
+				}
+				if ok {
+					kyverno = append(kyverno, policies...)
+					return nil
+				}
+			case ".rego", ".json":
+			default:
+				// Files in directories are only loaded when they have a policy or data extension.
+				if path != root {
+					return nil
+				}
+			}
+			regoPaths = append(regoPaths, path)
+			return nil
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	return kyverno, regoPaths, nil
+}
+
+func (e *complianceEvaluator) evaluate(ctx context.Context, obj *unstructured.Unstructured, namespace string) ([]ComplianceViolation, error) {
+	resource := fmt.Sprintf("%s/%s", obj.GetKind(), obj.GetName())
+	violations := []ComplianceViolation{}
+	if e.podChecks != nil {
+		template, ok, err := podTemplate(obj)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read the pod template of %s: %w", resource, err)
+		}
+		if ok {
+			lv := psaapi.LevelVersion{Level: e.level, Version: psaapi.LatestVersion()}
+			for _, result := range e.podChecks.EvaluatePod(lv, &template.ObjectMeta, &template.Spec) {
+				if result.Allowed {
+					continue
+				}
+				message := result.ForbiddenReason
+				if result.ForbiddenDetail != "" {
+					message = fmt.Sprintf("%s (%s)", result.ForbiddenReason, result.ForbiddenDetail)
+				}
+				violations = append(violations, ComplianceViolation{
+					Resource: resource,
+					Policy:   fmt.Sprintf("pod-security-%s", e.level),
+					Message:  message,
+				})
+			}
+		}
+	}
+	if e.query != nil {
+		results, err := e.query.Eval(ctx, rego.EvalInput(obj.Object))
+		if err != nil {
+			return nil, fmt.Errorf("unable to evaluate the policies against %s: %w", resource, err)
+		}
+		for _, result := range results {
+			for _, rule := range []string{"deny", "violation"} {
+				for _, message := range regoMessages(result.Bindings[rule]) {
+					violations = append(violations, ComplianceViolation{
+						Resource: resource,
+						Policy:   fmt.Sprintf("rego-%s", rule),
+						Message:  message,
+					})
+				}
+			}
+		}
+	}
+	for _, p := range e.kyverno {
+		vs, err := p.evaluate(obj, namespace)
+		if err != nil {
+			return nil, err
+		}
+		violations = append(violations, vs...)
+	}
+	return violations, nil
+}
+
+// podTemplate returns the pod metadata and spec of a pod or workload, it returns false for other resources.
+func podTemplate(obj *unstructured.Unstructured) (corev1.PodTemplateSpec, bool, error) {
+	gk := obj.GroupVersionKind().GroupKind()
+	if gk == (schema.GroupKind{Kind: "Pod"}) {
+		pod := corev1.Pod{}
+		err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, &pod)
+		if err != nil {
+			return corev1.PodTemplateSpec{}, false, err
+		}
+		return corev1.PodTemplateSpec{ObjectMeta: pod.ObjectMeta, Spec: pod.Spec}, true, nil
+	}
+	path, ok := podTemplatePaths[gk]
+	if !ok {
+		return corev1.PodTemplateSpec{}, false, nil
+	}
+	raw, ok, err := unstructured.NestedMap(obj.Object, path...)
+	if err != nil || !ok {
+		return corev1.PodTemplateSpec{}, false, err
+	}
+	template := corev1.PodTemplateSpec{}
+	err = runtime.DefaultUnstructuredConverter.FromUnstructured(raw, &template)
+	if err != nil {
+		return corev1.PodTemplateSpec{}, false, err
+	}
+	return template, true, nil
+}
+
+// regoMessages returns the messages of a deny or violation rule, which are either strings or objects with a msg.
+func regoMessages(value any) []string {
+	values, ok := value.([]any)
+	if !ok {
+		return nil
+	}
+	messages := []string{}
+	for _, v := range values {
+		switch v := v.(type) {
+		case string:
+			messages = append(messages, v)
+		case map[string]any:
+			if msg, ok := v["msg"].(string); ok {
+				messages = append(messages, msg)
+				continue
+			}
+			messages = append(messages, fmt.Sprint(v))
+		default:
+			messages = append(messages, fmt.Sprint(v))
+		}
+	}
+	return messages
+}
@@ -0,0 +1,422 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package packager2
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/zarf-dev/zarf/src/pkg/utils"
+	"github.com/zarf-dev/zarf/src/test/testutil"
+)
+
+func TestComplianceEvaluator(t *testing.T) {
+	t.Parallel()
+
+	policyDir := t.TempDir()
+	policy := `package main
+
+deny[msg] {
+	input.kind == "Deployment"
+	not input.metadata.labels.team
+	msg := sprintf("%s must have a team label", [input.metadata.name])
+}
+
+violation[{"msg": msg}] {
+	input.kind == "Service"
+	input.spec.type == "NodePort"
+	msg := "node ports are not allowed"
+}
+`
+	err := os.WriteFile(filepath.Join(policyDir, "policy.rego"), []byte(policy), 0o600)
+	require.NoError(t, err)
+
+	manifests := `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+spec:
+  template:
+    spec:
+      hostNetwork: true
+      containers:
+      - name: web
+        image: nginx
+---
+apiVersion: batch/v1
+kind: CronJob
+metadata:
+  name: backup
+  labels:
+    team: platform
+spec:
+  jobTemplate:
+    spec:
+      template:
+        spec:
+          securityContext:
+            runAsNonRoot: true
+            seccompProfile:
+              type: RuntimeDefault
+          containers:
+          - name: backup
+            image: busybox
+            securityContext:
+              allowPrivilegeEscalation: false
+              capabilities:
+                drop: ["ALL"]
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: web
+spec:
+  type: NodePort
+`
+	objs, err := utils.SplitYAML([]byte(manifests))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name               string
+		level              string
+		policyPaths        []string
+		expectedViolations [][]ComplianceViolation
+		expectedErr        string
+	}{
+		{
+			name:  "baseline",
+			level: "baseline",
+			expectedViolations: [][]ComplianceViolation{
+				{{Resource: "Deployment/web", Policy: "pod-security-baseline", Message: "host namespaces (hostNetwork=true)"}},
+				{},
+				{},
+			},
+		},
+		{
+			name:  "restricted",
+			level: "restricted",
+			expectedViolations: [][]ComplianceViolation{
+				{
+					{Resource: "Deployment/web", Policy: "pod-security-restricted", Message: "host namespaces (hostNetwork=true)"},
+					{Resource: "Deployment/web", Policy: "pod-security-restricted", Message: `allowPrivilegeEscalation != false (container "web" must set securityContext.allowPrivilegeEscalation=false)`},
+					{Resource: "Deployment/web", Policy: "pod-security-restricted", Message: `unrestricted capabilities (container "web" must set securityContext.capabilities.drop=["ALL"])`},
+					{Resource: "Deployment/web", Policy: "pod-security-restricted", Message: `runAsNonRoot != true (pod or container "web" must set securityContext.runAsNonRoot=true)`},
+					{Resource: "Deployment/web", Policy: "pod-security-restricted", Message: `seccompProfile (pod or container "web" must set securityContext.seccompProfile.type to "RuntimeDefault" or "Localhost")`},
+				},
+				{},
+				{},
+			},
+		},
+		{
+			name:        "rego",
+			policyPaths: []string{policyDir},
+			expectedViolations: [][]ComplianceViolation{
+				{{Resource: "Deployment/web", Policy: "rego-deny", Message: "web must have a team label"}},
+				{},
+				{{Resource: "Service/web", Policy: "rego-violation", Message: "node ports are not allowed"}},
+			},
+		},
+		{
+			name:        "privileged",
+			level:       "privileged",
+			expectedErr: `invalid pod security level "privileged": must be baseline or restricted`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := testutil.TestContext(t)
+			evaluator, err := newComplianceEvaluator(ctx, tt.level, tt.policyPaths)
+			if tt.expectedErr != "" {
+				require.EqualError(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			for i, obj := range objs {
+				violations, err := evaluator.evaluate(ctx, obj, "")
+				require.NoError(t, err)
+				require.Equal(t, tt.expectedViolations[i], violations)
+			}
+		})
+	}
+}
+
+func TestComplianceEvaluatorKyverno(t *testing.T) {
+	t.Parallel()
+
+	policyDir := t.TempDir()
+	policies := `apiVersion: kyverno.io/v1
+kind: ClusterPolicy
+metadata:
+  name: require-run-as-non-root
+spec:
+  rules:
+  - name: run-as-non-root
+    match:
+      any:
+      - resources:
+          kinds:
+          - Pod
+    exclude:
+      any:
+      - resources:
+          namespaces:
+          - kube-system
+    validate:
+      message: "Containers of {{ request.object.metadata.name }} must run as non-root."
+      pattern:
+        spec:
+          containers:
+          - securityContext:
+              runAsNonRoot: true
+---
+apiVersion: kyverno.io/v1
+kind: ClusterPolicy
+metadata:
+  name: disallow-latest-tag
+  annotations:
+    pod-policies.kyverno.io/autogen-controllers: none
+spec:
+  rules:
+  - name: require-image-tag
+    match:
+      any:
+      - resources:
+          kinds:
+          - Pod
+    validate:
+      message: "An image tag is required."
+      anyPattern:
+      - spec:
+          containers:
+          - image: "*:*"
+      - spec:
+          containers:
+          - image: "*@sha256:*"
+---
+apiVersion: kyverno.io/v1
+kind: ClusterPolicy
+metadata:
+  name: restrict-node-port
+spec:
+  rules:
+  - name: validate-node-port
+    match:
+      any:
+      - resources:
+          kinds:
+          - Service
+    validate:
+      message: "Services of type NodePort are not allowed."
+      pattern:
+        spec:
+          =(type): "!NodePort"
+  - name: check-owner
+    match:
+      any:
+      - resources:
+          kinds:
+          - Service
+    preconditions:
+      all:
+      - key: "{{ request.operation }}"
+        operator: Equals
+        value: CREATE
+    validate:
+      deny: {}
+---
+apiVersion: kyverno.io/v1
+kind: Policy
+metadata:
+  name: baseline
+  namespace: apps
+spec:
+  rules:
+  - name: baseline
+    match:
+      any:
+      - resources:
+          kinds:
+          - Pod
+    validate:
+      podSecurity:
+        level: baseline
+        version: latest
+        exclude:
+        - controlName: Host Ports
+`
+	err := os.WriteFile(filepath.Join(policyDir, "policies.yaml"), []byte(policies), 0o600)
+	require.NoError(t, err)
+
+	manifests := `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+spec:
+  template:
+    spec:
+      containers:
+      - name: web
+        image: nginx
+        ports:
+        - containerPort: 80
+          hostPort: 80
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: proxy
+spec:
+  template:
+    spec:
+      hostNetwork: true
+      containers:
+      - name: proxy
+        image: nginx:1.27
+        securityContext:
+          runAsNonRoot: true
+---
+apiVersion: v1
+kind: Pod
+metadata:
+  name: debug
+spec:
+  containers:
+  - name: debug
+    image: busybox
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: web
+spec:
+  type: NodePort
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: internal
+spec:
+  ports:
+  - port: 80
+`
+	objs, err := utils.SplitYAML([]byte(manifests))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name               string
+		namespace          string
+		expectedViolations [][]ComplianceViolation
+	}{
+		{
+			name:      "apps",
+			namespace: "apps",
+			expectedViolations: [][]ComplianceViolation{
+				{{Resource: "Deployment/web", Policy: "kyverno-require-run-as-non-root/run-as-non-root", Message: "validation error: Containers of web must run as non-root. rule run-as-non-root failed at path /spec/template/spec/containers/0/securityContext"}},
+				{{Resource: "Deployment/proxy", Policy: "kyverno-baseline/baseline", Message: "validation error: rule baseline failed: host namespaces (hostNetwork=true)"}},
+				{
+					{Resource: "Pod/debug", Policy: "kyverno-require-run-as-non-root/run-as-non-root", Message: "validation error: Containers of debug must run as non-root. rule run-as-non-root failed at path /spec/containers/0/securityContext"},
+					{Resource: "Pod/debug", Policy: "kyverno-disallow-latest-tag/require-image-tag", Message: "validation error: An image tag is required. rule require-image-tag failed"},
+				},
+				{{Resource: "Service/web", Policy: "kyverno-restrict-node-port/validate-node-port", Message: "validation error: Services of type NodePort are not allowed. rule validate-node-port failed at path /spec/type"}},
+				{},
+			},
+		},
+		{
+			name:      "kube-system",
+			namespace: "kube-system",
+			expectedViolations: [][]ComplianceViolation{
+				{},
+				{},
+				{{Resource: "Pod/debug", Policy: "kyverno-disallow-latest-tag/require-image-tag", Message: "validation error: An image tag is required. rule require-image-tag failed"}},
+				{{Resource: "Service/web", Policy: "kyverno-restrict-node-port/validate-node-port", Message: "validation error: Services of type NodePort are not allowed. rule validate-node-port failed at path /spec/type"}},
+				{},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := testutil.TestContext(t)
+			evaluator, err := newComplianceEvaluator(ctx, "", []string{policyDir})
+			require.NoError(t, err)
+			require.Nil(t, evaluator.query)
+			for i, obj := range objs {
+				violations, err := evaluator.evaluate(ctx, obj, tt.namespace)
+				require.NoError(t, err)
+				require.Equal(t, tt.expectedViolations[i], violations)
+			}
+		})
+	}
+}
+
+func TestMatchPattern(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name            string
+		value           string
+		pattern         string
+		expectedMatch   bool
+		expectedFailure string
+	}{
+		{
+			name:          "conditional anchor skips",
+			value:         `{"spec": {"containers": [{"image": "nginx", "imagePullPolicy": "IfNotPresent"}]}}`,
+			pattern:       `{"spec": {"containers": [{"(image)": "*:latest", "imagePullPolicy": "Always"}]}}`,
+			expectedMatch: true,
+		},
+		{
+			name:            "conditional anchor applies",
+			value:           `{"spec": {"containers": [{"image": "nginx:latest", "imagePullPolicy": "IfNotPresent"}]}}`,
+			pattern:         `{"spec": {"containers": [{"(image)": "*:latest", "imagePullPolicy": "Always"}]}}`,
+			expectedFailure: "/spec/containers/0/imagePullPolicy",
+		},
+		{
+			name:            "negation anchor",
+			value:           `{"spec": {"hostPath": {"path": "/var"}}}`,
+			pattern:         `{"spec": {"X(hostPath)": null}}`,
+			expectedFailure: "/spec/hostPath",
+		},
+		{
+			name:          "existence anchor",
+			value:         `{"spec": {"containers": [{"name": "a", "image": "nginx"}, {"name": "b", "image": "registry.local/app"}]}}`,
+			pattern:       `{"spec": {"^(containers)": [{"image": "registry.local/*"}]}}`,
+			expectedMatch: true,
+		},
+		{
+			name:          "quantity operator",
+			value:         `{"resources": {"limits": {"memory": "512Mi"}}}`,
+			pattern:       `{"resources": {"limits": {"memory": "<=1Gi"}}}`,
+			expectedMatch: true,
+		},
+		{
+			name:            "alternatives",
+			value:           `{"spec": {"type": "LoadBalancer"}}`,
+			pattern:         `{"spec": {"type": "ClusterIP | NodePort"}}`,
+			expectedFailure: "/spec/type",
+		},
+		{
+			name:          "numbers",
+			value:         `{"spec": {"replicas": 3}}`,
+			pattern:       `{"spec": {"replicas": ">1"}}`,
+			expectedMatch: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var value, pattern any
+			require.NoError(t, json.Unmarshal([]byte(tt.value), &value))
+			require.NoError(t, json.Unmarshal([]byte(tt.pattern), &pattern))
+			ok, failure := matchPattern(value, pattern, "")
+			require.Equal(t, tt.expectedMatch, ok)
+			require.Equal(t, tt.expectedFailure, failure)
+		})
+	}
+}
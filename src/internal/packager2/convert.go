@@ -0,0 +1,331 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package packager2
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/defenseunicorns/pkg/helpers/v2"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/yaml"
+
+	"github.com/zarf-dev/zarf/src/api/v1alpha1"
+	layout2 "github.com/zarf-dev/zarf/src/internal/packager2/layout"
+	"github.com/zarf-dev/zarf/src/pkg/transform"
+	"github.com/zarf-dev/zarf/src/pkg/utils"
+)
+
+// Formats a package can be converted to.
+const (
+	// ConvertToHelm converts the package to a Helm chart.
+	ConvertToHelm = "helm"
+	// ConvertToManifests converts the package to a directory of plain Kubernetes manifests.
+	ConvertToManifests = "manifests"
+)
+
+// ConvertFormats are the formats a package can be converted to.
+var ConvertFormats = []string{ConvertToHelm, ConvertToManifests}
+
+// ConvertOptions are the options for Convert.
+type ConvertOptions struct {
+	// To is the format the package is converted to, one of ConvertFormats.
+	To           string
+	SetVariables map[string]string
+	KubeVersion  string
+	// RegistryURL is the registry the images of the package were mirrored to, the images are rewritten to it.
+	RegistryURL string
+	// NoImgChecksum rewrites the images without the checksum that is added to them when they are mirrored by default.
+	NoImgChecksum bool
+	// ImageRules rewrite the paths of the images the same way as when they are mirrored and deployed.
+	ImageRules []transform.ImageRule
+	// OutputDir is the directory the chart or manifests are written into, in a directory named after the package.
+	OutputDir string
+}
+
+// convertedFile is a file of a converted package.
+type convertedFile struct {
+	Name    string
+	Objects []*unstructured.Unstructured
+}
+
+// Convert renders the charts and manifests of the package the same way as InspectManifests, rewrites their images to
+// the registry and writes them as a Helm chart or plain manifests for clusters that do not run Zarf. It returns the
+// directory the converted package was written to.
+func Convert(ctx context.Context, pkgLayout *layout2.PackageLayout, opt ConvertOptions) (string, error) {
+	if opt.To != ConvertToHelm && opt.To != ConvertToManifests {
+		return "", fmt.Errorf("invalid format %q, must be one of %s", opt.To, strings.Join(ConvertFormats, ", "))
+	}
+	if opt.RegistryURL == "" {
+		return "", errors.New("a registry URL is required to rewrite the images of the package")
+	}
+	dir := filepath.Join(opt.OutputDir, pkgLayout.Pkg.Metadata.Name)
+	if _, err := os.Stat(dir); err == nil {
+		return "", fmt.Errorf("%s already exists, remove it or choose another output directory", dir)
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return "", err
+	}
+
+	rendered, err := InspectManifests(ctx, pkgLayout, InspectManifestsOptions{
+		SetVariables: opt.SetVariables,
+		KubeVersion:  opt.KubeVersion,
+		RegistryURL:  opt.RegistryURL,
+	})
+	if err != nil {
+		return "", err
+	}
+	crds, files, err := convertRendered(rendered, opt.RegistryURL, opt.NoImgChecksum, opt.ImageRules)
+	if err != nil {
+		return "", err
+	}
+
+	switch opt.To {
+	case ConvertToHelm:
+		err = writeConvertedChart(pkgLayout.Pkg, dir, crds, files)
+	case ConvertToManifests:
+		if len(crds.Objects) > 0 {
+			files = append([]convertedFile{crds}, files...)
+		}
+		err = writeConvertedManifests(dir, files)
+	}
+	if err != nil {
+		return "", errors.Join(err, os.RemoveAll(dir))
+	}
+	return dir, nil
+}
+
+// convertRendered returns the custom resource definitions and a file per chart and manifest of the rendered package.
+// Namespaced objects get the namespace of their chart or manifest, which is otherwise only set by Helm on install,
+// the namespaces that Zarf creates on deploy are added, and the images of the workloads are rewritten to the registry
+// with the image rules.
+func convertRendered(rendered []RenderedManifest, registryURL string, noImgChecksum bool, rules []transform.ImageRule) (convertedFile, []convertedFile, error) {
+	rewrite := func(image string) (string, error) {
+		if noImgChecksum {
+			return transform.ImageTransformHostWithoutChecksum(registryURL, image, rules...)
+		}
+		return transform.ImageTransformHost(registryURL, image, rules...)
+	}
+
+	objects := map[int][]*unstructured.Unstructured{}
+	all := []*unstructured.Unstructured{}
+	for i, r := range rendered {
+		objs, err := utils.SplitYAML([]byte(r.Content))
+		if err != nil {
+			return convertedFile{}, nil, fmt.Errorf("unable to parse the %s %s of component %s: %w", r.Kind, r.Name, r.Component, err)
+		}
+		objects[i] = objs
+		all = append(all, objs...)
+	}
+	clusterScoped := clusterScopedKindsOf(all)
+
+	crds := convertedFile{Name: "crds.yaml"}
+	namespaces := convertedFile{Name: "namespaces.yaml"}
+	existingNamespaces := map[string]bool{"default": true, "kube-system": true, "kube-public": true}
+	for _, obj := range all {
+		if obj.GroupVersionKind().GroupKind() == (schema.GroupKind{Kind: "Namespace"}) {
+			existingNamespaces[obj.GetName()] = true
+		}
+	}
+	addNamespace := func(name string) {
+		if existingNamespaces[name] {
+			return
+		}
+		existingNamespaces[name] = true
+		ns := &unstructured.Unstructured{}
+		ns.SetAPIVersion("v1")
+		ns.SetKind("Namespace")
+		ns.SetName(name)
+		namespaces.Objects = append(namespaces.Objects, ns)
+	}
+
+	files := []convertedFile{}
+	for i, r := range rendered {
+		file := convertedFile{Name: fmt.Sprintf("%s-%s-%s.yaml", r.Component, r.Kind, r.Name)}
+		for _, obj := range objects[i] {
+			gvk := obj.GroupVersionKind()
+			if gvk.Kind == "" {
+				continue
+			}
+			// Zarf does not run the tests of charts on deploy.
+			if strings.Contains(obj.GetAnnotations()["helm.sh/hook"], "test") {
+				continue
+			}
+			if gvk.GroupKind() == (schema.GroupKind{Group: "apiextensions.k8s.io", Kind: "CustomResourceDefinition"}) {
+				crds.Objects = append(crds.Objects, obj)
+				continue
+			}
+			if !clusterScoped[gvk.GroupKind()] {
+				if obj.GetNamespace() == "" {
+					obj.SetNamespace(namespaceOrDefault(r.Namespace))
+				}
+				addNamespace(obj.GetNamespace())
+			}
+			if err := rewriteImages(obj.Object, rewrite); err != nil {
+				return convertedFile{}, nil, fmt.Errorf("unable to rewrite the images of %s %s in component %s: %w", gvk.Kind, obj.GetName(), r.Component, err)
+			}
+			file.Objects = append(file.Objects, obj)
+		}
+		if len(file.Objects) > 0 {
+			files = append(files, file)
+		}
+	}
+	if len(namespaces.Objects) > 0 {
+		files = append([]convertedFile{namespaces}, files...)
+	}
+	return crds, files, nil
+}
+
+// clusterScopedKindsOf returns the cluster scoped kinds, including those of the custom resource definitions in the objects.
+func clusterScopedKindsOf(objects []*unstructured.Unstructured) map[schema.GroupKind]bool {
+	clusterScoped := map[schema.GroupKind]bool{}
+	for gk := range clusterScopedKinds {
+		clusterScoped[gk] = true
+	}
+	for _, obj := range objects {
+		if obj.GroupVersionKind().GroupKind() != (schema.GroupKind{Group: "apiextensions.k8s.io", Kind: "CustomResourceDefinition"}) {
+			continue
+		}
+		group, _, _ := unstructured.NestedString(obj.Object, "spec", "group")
+		kind, _, _ := unstructured.NestedString(obj.Object, "spec", "names", "kind")
+		scope, _, _ := unstructured.NestedString(obj.Object, "spec", "scope")
+		clusterScoped[schema.GroupKind{Group: group, Kind: kind}] = scope == "Cluster"
+	}
+	return clusterScoped
+}
+
+// containerFields are the fields of pod specs that hold containers with images.
+var containerFields = []string{"containers", "initContainers", "ephemeralContainers"}
+
+// rewriteImages rewrites the images of the containers anywhere in the object, which covers the pod templates of
+// workloads and custom resources alike.
+func rewriteImages(obj map[string]any, rewrite func(string) (string, error)) error {
+	for key, value := range obj {
+		switch v := value.(type) {
+		case map[string]any:
+			if err := rewriteImages(v, rewrite); err != nil {
+				return err
+			}
+		case []any:
+			isContainers := false
+			for _, field := range containerFields {
+				if key == field {
+					isContainers = true
+				}
+			}
+			for _, item := range v {
+				m, ok := item.(map[string]any)
+				if !ok {
+					continue
+				}
+				if image, ok := m["image"].(string); ok && isContainers && image != "" {
+					rewritten, err := rewrite(image)
+					if err != nil {
+						return err
+					}
+					m["image"] = rewritten
+				}
+				if err := rewriteImages(m, rewrite); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// marshalObjects returns the objects as a multi document YAML.
+func marshalObjects(objects []*unstructured.Unstructured) ([]byte, error) {
+	docs := []string{}
+	for _, obj := range objects {
+		b, err := yaml.Marshal(obj.Object)
+		if err != nil {
+			return nil, err
+		}
+		docs = append(docs, string(b))
+	}
+	return []byte("---\n" + strings.Join(docs, "---\n")), nil
+}
+
+// writeConvertedManifests writes the files numbered in the order they have to be applied in.
+func writeConvertedManifests(dir string, files []convertedFile) error {
+	if err := helpers.CreateDirectory(dir, helpers.ReadWriteExecuteUser); err != nil {
+		return err
+	}
+	for i, file := range files {
+		b, err := marshalObjects(file.Objects)
+		if err != nil {
+			return err
+		}
+		path := filepath.Join(dir, fmt.Sprintf("%02d-%s", i, file.Name))
+		if err := os.WriteFile(path, b, helpers.ReadWriteUser); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// invalidChartName matches the characters that Helm does not allow in chart names.
+var invalidChartName = regexp.MustCompile(`[^a-z0-9-]`)
+
+// writeConvertedChart writes a chart that installs the files. The rendered objects are stored as chart files and
+// included by the templates as they are, so that template syntax in their content is not evaluated a second time.
+func writeConvertedChart(pkg v1alpha1.ZarfPackage, dir string, crds convertedFile, files []convertedFile) error {
+	version := "0.0.0"
+	if v, err := semver.NewVersion(pkg.Metadata.Version); err == nil {
+		version = v.String()
+	}
+	chart := map[string]any{
+		"apiVersion":  "v2",
+		"name":        invalidChartName.ReplaceAllString(pkg.Metadata.Name, "-"),
+		"description": pkg.Metadata.Description,
+		"type":        "application",
+		"version":     version,
+		"appVersion":  pkg.Metadata.Version,
+	}
+	b, err := yaml.Marshal(chart)
+	if err != nil {
+		return err
+	}
+	for _, sub := range []string{"crds", "files", "templates"} {
+		if err := helpers.CreateDirectory(filepath.Join(dir, sub), helpers.ReadWriteExecuteUser); err != nil {
+			return err
+		}
+	}
+	if err := os.WriteFile(filepath.Join(dir, "Chart.yaml"), b, helpers.ReadWriteUser); err != nil {
+		return err
+	}
+
+	// Helm installs custom resource definitions from the crds directory before the templates, without templating them.
+	if len(crds.Objects) > 0 {
+		b, err := marshalObjects(crds.Objects)
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(filepath.Join(dir, "crds", crds.Name), b, helpers.ReadWriteUser); err != nil {
+			return err
+		}
+	}
+	for _, file := range files {
+		b, err := marshalObjects(file.Objects)
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(filepath.Join(dir, "files", file.Name), b, helpers.ReadWriteUser); err != nil {
+			return err
+		}
+		tpl := fmt.Sprintf("{{ .Files.Get %q }}\n", filepath.ToSlash(filepath.Join("files", file.Name)))
+		if err := os.WriteFile(filepath.Join(dir, "templates", file.Name), []byte(tpl), helpers.ReadWriteUser); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,228 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package packager2
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/zarf-dev/zarf/src/api/v1alpha1"
+	"github.com/zarf-dev/zarf/src/pkg/transform"
+)
+
+var convertRenderedManifests = []RenderedManifest{
+	{
+		Component: "web",
+		Kind:      "chart",
+		Name:      "web",
+		Namespace: "web",
+		Content: `---
+apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: widgets.example.com
+spec:
+  group: example.com
+  scope: Cluster
+  names:
+    kind: Widget
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+spec:
+  template:
+    spec:
+      initContainers:
+      - name: init
+        image: busybox:1.36
+      containers:
+      - name: web
+        image: ghcr.io/stefanprodan/podinfo:6.4.0
+        env:
+        - name: image
+          value: not-an-image
+---
+apiVersion: example.com/v1
+kind: Widget
+metadata:
+  name: default
+---
+apiVersion: v1
+kind: Pod
+metadata:
+  name: web-test
+  annotations:
+    helm.sh/hook: test
+spec:
+  containers:
+  - name: test
+    image: busybox:1.36
+`,
+	},
+	{
+		Component: "web",
+		Kind:      "manifest",
+		Name:      "extra",
+		Content: `---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: extra
+---
+apiVersion: v1
+kind: Namespace
+metadata:
+  name: web
+`,
+	},
+}
+
+func TestConvertRendered(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		noImgChecksum bool
+		rules         []transform.ImageRule
+		expectedImage string
+		expectedInit  string
+	}{
+		{
+			name:          "with checksum",
+			expectedImage: "registry.example.com/stefanprodan/podinfo:6.4.0-zarf-2985051089",
+			expectedInit:  "registry.example.com/library/busybox:1.36-zarf-2140033595",
+		},
+		{
+			name:          "without checksum",
+			noImgChecksum: true,
+			expectedImage: "registry.example.com/stefanprodan/podinfo:6.4.0",
+			expectedInit:  "registry.example.com/library/busybox:1.36",
+		},
+		{
+			name:          "with image rules",
+			noImgChecksum: true,
+			rules:         []transform.ImageRule{{Prefix: "docker.io/library/", Replace: "mirror/"}},
+			expectedImage: "registry.example.com/stefanprodan/podinfo:6.4.0",
+			expectedInit:  "registry.example.com/mirror/busybox:1.36",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			crds, files, err := convertRendered(convertRenderedManifests, "registry.example.com", tt.noImgChecksum, tt.rules)
+			require.NoError(t, err)
+
+			require.Len(t, crds.Objects, 1)
+			require.Equal(t, "widgets.example.com", crds.Objects[0].GetName())
+
+			// The web namespace is defined by the package, only the default namespace of the manifest is used otherwise.
+			require.Len(t, files, 2)
+			require.Equal(t, "web-chart-web.yaml", files[0].Name)
+			require.Equal(t, "web-manifest-extra.yaml", files[1].Name)
+
+			chart := files[0].Objects
+			require.Len(t, chart, 2)
+			require.Equal(t, "web", chart[0].GetNamespace())
+			containers, _, err := unstructured.NestedSlice(chart[0].Object, "spec", "template", "spec", "containers")
+			require.NoError(t, err)
+			require.Equal(t, tt.expectedImage, containers[0].(map[string]any)["image"])
+			initContainers, _, err := unstructured.NestedSlice(chart[0].Object, "spec", "template", "spec", "initContainers")
+			require.NoError(t, err)
+			require.Equal(t, tt.expectedInit, initContainers[0].(map[string]any)["image"])
+			env := containers[0].(map[string]any)["env"].([]any)
+			require.Equal(t, "not-an-image", env[0].(map[string]any)["value"])
+			// The custom resource is cluster scoped by its definition.
+			require.Equal(t, "Widget", chart[1].GetKind())
+			require.Empty(t, chart[1].GetNamespace())
+
+			require.Equal(t, "default", files[1].Objects[0].GetNamespace())
+			require.Empty(t, files[1].Objects[1].GetNamespace())
+		})
+	}
+}
+
+func TestConvertRenderedNamespaces(t *testing.T) {
+	t.Parallel()
+
+	rendered := []RenderedManifest{
+		{
+			Component: "app",
+			Kind:      "manifest",
+			Name:      "app",
+			Namespace: "app",
+			Content: `---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: app
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: other
+  namespace: kube-system
+`,
+		},
+	}
+	_, files, err := convertRendered(rendered, "registry.example.com", false, nil)
+	require.NoError(t, err)
+	require.Len(t, files, 2)
+	require.Equal(t, "namespaces.yaml", files[0].Name)
+	require.Len(t, files[0].Objects, 1)
+	require.Equal(t, "Namespace", files[0].Objects[0].GetKind())
+	require.Equal(t, "app", files[0].Objects[0].GetName())
+}
+
+func TestWriteConverted(t *testing.T) {
+	t.Parallel()
+
+	crds, files, err := convertRendered(convertRenderedManifests, "registry.example.com", true, nil)
+	require.NoError(t, err)
+
+	t.Run("helm", func(t *testing.T) {
+		t.Parallel()
+
+		dir := filepath.Join(t.TempDir(), "web")
+		pkg := v1alpha1.ZarfPackage{Metadata: v1alpha1.ZarfMetadata{Name: "web_app", Version: "v1.2.3"}}
+		err := writeConvertedChart(pkg, dir, crds, files)
+		require.NoError(t, err)
+
+		b, err := os.ReadFile(filepath.Join(dir, "Chart.yaml"))
+		require.NoError(t, err)
+		require.Contains(t, string(b), "name: web-app\n")
+		require.Contains(t, string(b), "version: 1.2.3\n")
+		require.Contains(t, string(b), "appVersion: v1.2.3\n")
+		require.FileExists(t, filepath.Join(dir, "crds", "crds.yaml"))
+		require.FileExists(t, filepath.Join(dir, "files", "web-chart-web.yaml"))
+		b, err = os.ReadFile(filepath.Join(dir, "templates", "web-chart-web.yaml"))
+		require.NoError(t, err)
+		require.Equal(t, "{{ .Files.Get \"files/web-chart-web.yaml\" }}\n", string(b))
+	})
+
+	t.Run("manifests", func(t *testing.T) {
+		t.Parallel()
+
+		dir := filepath.Join(t.TempDir(), "web")
+		err := writeConvertedManifests(dir, append([]convertedFile{crds}, files...))
+		require.NoError(t, err)
+
+		entries, err := os.ReadDir(dir)
+		require.NoError(t, err)
+		names := []string{}
+		for _, e := range entries {
+			names = append(names, e.Name())
+		}
+		require.Equal(t, []string{"00-crds.yaml", "01-web-chart-web.yaml", "02-web-manifest-extra.yaml"}, names)
+		b, err := os.ReadFile(filepath.Join(dir, "01-web-chart-web.yaml"))
+		require.NoError(t, err)
+		require.Contains(t, string(b), "image: registry.example.com/stefanprodan/podinfo:6.4.0\n")
+	})
+}
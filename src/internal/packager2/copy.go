@@ -0,0 +1,221 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package packager2
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/defenseunicorns/pkg/helpers/v2"
+	"github.com/defenseunicorns/pkg/oci"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/registry"
+
+	"github.com/zarf-dev/zarf/src/config"
+	layout2 "github.com/zarf-dev/zarf/src/internal/packager2/layout"
+	"github.com/zarf-dev/zarf/src/pkg/logger"
+	"github.com/zarf-dev/zarf/src/pkg/utils"
+	"github.com/zarf-dev/zarf/src/pkg/zoci"
+)
+
+const (
+	// CountersignatureArtifactType is the artifact type of countersignatures attached to published packages.
+	CountersignatureArtifactType = "application/vnd.zarf.countersignature.v1"
+	// CountersignatureMediaType is the media type of the countersignature layer.
+	CountersignatureMediaType = "application/vnd.zarf.countersignature.v1.sig"
+)
+
+// CopyOptions are the options for Copy.
+type CopyOptions struct {
+	// Concurrency configures the zoci copy concurrency if empty defaults to 3.
+	Concurrency int
+	// PublicKeyPath verifies the package signature before and after the copy.
+	PublicKeyPath string
+	// SkipSignatureValidation flags whether Copy should skip validating the signature.
+	SkipSignatureValidation bool
+	// CountersignKeyPath points to a key used to countersign the package at the destination.
+	CountersignKeyPath string
+	// CountersignKeyPassword holds a password to use the key at CountersignKeyPath.
+	CountersignKeyPassword string
+	// WithPlainHTTP falls back to plain HTTP for the registry calls instead of TLS.
+	WithPlainHTTP bool
+	// Architecture is the architecture of the package to copy.
+	Architecture string
+}
+
+// Copy copies a published package from the src repository to the dst repository without unpacking it.
+// The package signature is verified at the source before the copy and at the destination after the copy.
+// If a countersign key is provided a countersignature of the package definition is attached to the destination package.
+// A record of the copy is appended to the provenance chain of the source package and attached to the destination package.
+func Copy(ctx context.Context, src, dst string, opts CopyOptions) error {
+	l := logger.From(ctx)
+	start := time.Now()
+
+	srcRef, err := registry.ParseReference(strings.TrimPrefix(src, helpers.OCIURLPrefix))
+	if err != nil {
+		return err
+	}
+	if err := srcRef.ValidateReferenceAsTag(); err != nil {
+		return fmt.Errorf("source must reference a tag: %w", err)
+	}
+	dstRef, err := registry.ParseReference(strings.TrimPrefix(dst, helpers.OCIURLPrefix))
+	if err != nil {
+		return err
+	}
+	if dstRef.Reference != "" && dstRef.Reference != srcRef.Reference {
+		return fmt.Errorf("destination tag %s must match source tag %s", dstRef.Reference, srcRef.Reference)
+	}
+	dstRef.Reference = srcRef.Reference
+
+	p := oci.PlatformForArch(config.GetArch(opts.Architecture))
+	srcRemote, err := zoci.NewRemote(ctx, srcRef.String(), p, oci.WithPlainHTTP(opts.WithPlainHTTP))
+	if err != nil {
+		return fmt.Errorf("could not instantiate remote: %w", err)
+	}
+	l.Info("verifying source package", "source", srcRef.String())
+	err = verifyRemotePackage(ctx, srcRemote, opts.PublicKeyPath, opts.SkipSignatureValidation)
+	if err != nil {
+		return fmt.Errorf("source package failed verification: %w", err)
+	}
+
+	dstRemote, err := zoci.NewRemote(ctx, dstRef.String(), p, oci.WithPlainHTTP(opts.WithPlainHTTP))
+	if err != nil {
+		return fmt.Errorf("could not instantiate remote: %w", err)
+	}
+	err = zoci.CopyPackage(ctx, srcRemote, dstRemote, opts.Concurrency)
+	if err != nil {
+		return fmt.Errorf("could not copy package: %w", err)
+	}
+
+	// CopyPackage mutates the destination remote when updating the index so a fresh remote is used for verification.
+	dstRemote, err = zoci.NewRemote(ctx, dstRef.String(), p, oci.WithPlainHTTP(opts.WithPlainHTTP))
+	if err != nil {
+		return fmt.Errorf("could not instantiate remote: %w", err)
+	}
+	l.Info("verifying destination package", "destination", dstRef.String())
+	err = verifyRemotePackage(ctx, dstRemote, opts.PublicKeyPath, opts.SkipSignatureValidation)
+	if err != nil {
+		return fmt.Errorf("destination package failed verification: %w", err)
+	}
+
+	if opts.CountersignKeyPath != "" {
+		err = countersignRemotePackage(ctx, dstRemote, opts.CountersignKeyPath, opts.CountersignKeyPassword)
+		if err != nil {
+			return fmt.Errorf("unable to countersign package: %w", err)
+		}
+	}
+
+	// The package has already been copied, a missing provenance record should not fail the copy.
+	err = recordProvenance(ctx, srcRemote, dstRemote)
+	if err != nil {
+		l.Warn("unable to record the provenance of the package", "destination", dstRemote.Repo().Reference.String(), "error", err)
+	}
+
+	l.Debug("packager2.Copy done", "duration", time.Since(start))
+	return nil
+}
+
+// fetchRemoteMetadata writes the package definition, checksums, and signature of a remote package to dir.
+func fetchRemoteMetadata(ctx context.Context, remote *zoci.Remote, dir string) error {
+	manifest, err := remote.FetchRoot(ctx)
+	if err != nil {
+		return err
+	}
+	for _, path := range zoci.PackageAlwaysPull {
+		desc := manifest.Locate(path)
+		if oci.IsEmptyDescriptor(desc) {
+			continue
+		}
+		b, err := remote.FetchLayer(ctx, desc)
+		if err != nil {
+			return err
+		}
+		err = os.WriteFile(filepath.Join(dir, path), b, helpers.ReadWriteUser)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// verifyRemotePackage verifies the signature and checksums of a remote package without pulling its contents.
+func verifyRemotePackage(ctx context.Context, remote *zoci.Remote, publicKeyPath string, skipSignatureValidation bool) (err error) {
+	tmpDir, err := utils.MakeTempDir(config.CommonOptions.TempDirectory)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = errors.Join(err, os.RemoveAll(tmpDir))
+	}()
+	err = fetchRemoteMetadata(ctx, remote, tmpDir)
+	if err != nil {
+		return err
+	}
+	layoutOpt := layout2.PackageLayoutOptions{
+		PublicKeyPath:           publicKeyPath,
+		SkipSignatureValidation: skipSignatureValidation,
+		IsPartial:               true,
+	}
+	_, err = layout2.LoadFromDir(ctx, tmpDir, layoutOpt)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// countersignRemotePackage signs the package definition of a remote package and attaches the signature as a referrer of the package manifest.
+func countersignRemotePackage(ctx context.Context, remote *zoci.Remote, keyPath, keyPassword string) (err error) {
+	tmpDir, err := utils.MakeTempDir(config.CommonOptions.TempDirectory)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = errors.Join(err, os.RemoveAll(tmpDir))
+	}()
+	err = fetchRemoteMetadata(ctx, remote, tmpDir)
+	if err != nil {
+		return err
+	}
+	passFunc := func(_ bool) ([]byte, error) {
+		return []byte(keyPassword), nil
+	}
+	sig, err := utils.CosignSignBlob(filepath.Join(tmpDir, layout2.ZarfYAML), filepath.Join(tmpDir, "countersignature.sig"), keyPath, passFunc)
+	if err != nil {
+		return err
+	}
+
+	subject, err := remote.ResolveRoot(ctx)
+	if err != nil {
+		return err
+	}
+	sigDesc := content.NewDescriptorFromBytes(CountersignatureMediaType, sig)
+	sigDesc.Annotations = map[string]string{
+		ocispec.AnnotationTitle: layout2.Signature,
+	}
+	err = remote.Repo().Push(ctx, sigDesc, bytes.NewReader(sig))
+	if err != nil {
+		return err
+	}
+	packOpts := oras.PackManifestOptions{
+		Subject: &subject,
+		Layers:  []ocispec.Descriptor{sigDesc},
+		ManifestAnnotations: map[string]string{
+			ocispec.AnnotationCreated: time.Now().UTC().Format(time.RFC3339),
+		},
+	}
+	desc, err := oras.PackManifest(ctx, remote.Repo(), oras.PackManifestVersion1_1, CountersignatureArtifactType, packOpts)
+	if err != nil {
+		return err
+	}
+	logger.From(ctx).Info("countersigned package", "reference", remote.Repo().Reference.String(), "digest", desc.Digest.String())
+	return nil
+}
@@ -0,0 +1,67 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package packager2
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/defenseunicorns/pkg/oci"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zarf-dev/zarf/src/pkg/zoci"
+	"github.com/zarf-dev/zarf/src/test/testutil"
+)
+
+func TestCopy(t *testing.T) {
+	ctx := testutil.TestContext(t)
+	srcRegistryRef := createRegistry(t, ctx)
+	dstRegistryRef := createRegistry(t, ctx)
+
+	publishOpts := PublishPackageOpts{
+		WithPlainHTTP: true,
+		Architecture:  "amd64",
+	}
+	err := PublishPackage(ctx, "testdata/zarf-package-test-amd64-0.0.1.tar.zst", srcRegistryRef, publishOpts)
+	require.NoError(t, err)
+	// Publish creates a local oci manifest file using the package name, delete this to clean up test name
+	defer os.Remove("test")
+
+	src := fmt.Sprintf("oci://%s/test:0.0.1", srcRegistryRef.String())
+	dst := fmt.Sprintf("oci://%s/promoted/test", dstRegistryRef.String())
+
+	copyOpts := CopyOptions{
+		WithPlainHTTP: true,
+		Architecture:  "amd64",
+		PublicKeyPath: "layout/testdata/cosign.pub",
+	}
+	err = Copy(ctx, src, dst, copyOpts)
+	require.EqualError(t, err, "source package failed verification: a key was provided but the package is not signed")
+
+	copyOpts.PublicKeyPath = ""
+	copyOpts.CountersignKeyPath = "layout/testdata/cosign.key"
+	copyOpts.CountersignKeyPassword = "test"
+	err = Copy(ctx, src, dst+":0.0.2", copyOpts)
+	require.EqualError(t, err, "destination tag 0.0.2 must match source tag 0.0.1")
+	err = Copy(ctx, src, dst, copyOpts)
+	require.NoError(t, err)
+
+	rmt, err := zoci.NewRemote(ctx, fmt.Sprintf("%s/promoted/test:0.0.1", dstRegistryRef.String()), oci.PlatformForArch("amd64"), oci.WithPlainHTTP(true))
+	require.NoError(t, err)
+	pkg, err := rmt.FetchZarfYAML(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "test", pkg.Metadata.Name)
+
+	subject, err := rmt.ResolveRoot(ctx)
+	require.NoError(t, err)
+	referrers := []ocispec.Descriptor{}
+	err = rmt.Repo().Referrers(ctx, subject, CountersignatureArtifactType, func(descs []ocispec.Descriptor) error {
+		referrers = append(referrers, descs...)
+		return nil
+	})
+	require.NoError(t, err)
+	require.Len(t, referrers, 1)
+}
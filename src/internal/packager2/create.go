@@ -7,32 +7,95 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"os"
+	"time"
 
 	"github.com/defenseunicorns/pkg/helpers/v2"
 	"github.com/defenseunicorns/pkg/oci"
 
+	"github.com/zarf-dev/zarf/src/api/v1alpha1"
 	"github.com/zarf-dev/zarf/src/config"
+	"github.com/zarf-dev/zarf/src/internal/metrics"
+	"github.com/zarf-dev/zarf/src/internal/packager/imagebuild"
 	layout2 "github.com/zarf-dev/zarf/src/internal/packager2/layout"
+	"github.com/zarf-dev/zarf/src/internal/tracing"
+	"github.com/zarf-dev/zarf/src/pkg/events"
+	"github.com/zarf-dev/zarf/src/pkg/lint"
 	"github.com/zarf-dev/zarf/src/pkg/logger"
+	"github.com/zarf-dev/zarf/src/pkg/utils"
 )
 
 type CreateOptions struct {
-	Flavor                  string
-	RegistryOverrides       map[string]string
-	SigningKeyPath          string
-	SigningKeyPassword      string
-	SetVariables            map[string]string
-	MaxPackageSizeMB        int
-	SBOMOut                 string
-	SkipSBOM                bool
-	Output                  string
-	DifferentialPackagePath string
+	Flavor                   string
+	RegistryOverrides        map[string]string
+	SigningKeyPath           string
+	SigningKeyPassword       string
+	SetVariables             map[string]string
+	MaxPackageSizeMB         int
+	SBOMOut                  string
+	SkipSBOM                 bool
+	Output                   string
+	DifferentialPackagePath  string
+	OverlayPaths             []string
+	ExcludeImagesFrom        []string
+	BuildCache               bool
+	RemoteCache              string
+	RemoteCacheReadOnly      bool
+	MaxMemoryMB              int
+	MaxTempSpaceMB           int
+	IncludePlatforms         []string
+	Buildkit                 imagebuild.Remote
+	FailOnSeverity           string
+	VulnerabilityWaiversPath string
+	AllowUnpinned            bool
+	LintRulesPath            string
+	// Flags are the command line flags the package is created with, recorded in the build info of the package.
+	Flags map[string]string
+	// ConfigPath is the Zarf config file the package is created with.
+	ConfigPath string
 }
 
-func Create(ctx context.Context, packagePath string, opt CreateOptions) error {
+func Create(ctx context.Context, packagePath string, opt CreateOptions) (err error) {
+	ctx, span := tracing.Start(ctx, "create")
+	events.From(ctx).Phase("create", events.StatusStarted, nil)
+	start := time.Now()
+	var pkg v1alpha1.ZarfPackage
+	var size int64
+	defer func() {
+		metrics.From(ctx).Record(ctx, metrics.NewMetric(metrics.OperationCreate, pkg, size, start, err))
+		events.From(ctx).Phase("create", events.Done(err), err)
+		tracing.End(span, err)
+	}()
+
 	if opt.SkipSBOM && opt.SBOMOut != "" {
 		return fmt.Errorf("cannot skip SBOM creation and specify an SBOM output directory")
 	}
+	if opt.SkipSBOM && opt.FailOnSeverity != "" {
+		return fmt.Errorf("cannot skip SBOM creation and scan the package for vulnerabilities")
+	}
+	if opt.VulnerabilityWaiversPath != "" && opt.FailOnSeverity == "" {
+		return fmt.Errorf("vulnerability waivers can only be used with a severity to fail on")
+	}
+	var waivers []VulnerabilityWaiver
+	if opt.FailOnSeverity != "" {
+		if _, err := parseFailOnSeverity(opt.FailOnSeverity); err != nil {
+			return err
+		}
+		if opt.VulnerabilityWaiversPath != "" {
+			waivers, err = ReadVulnerabilityWaivers(opt.VulnerabilityWaiversPath)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	var lintRules lint.Rules
+	if opt.LintRulesPath != "" {
+		lintRules, err = lint.ReadRules(opt.LintRulesPath)
+		if err != nil {
+			return err
+		}
+	}
 
 	createOpt := layout2.CreateOptions{
 		Flavor:                  opt.Flavor,
@@ -42,12 +105,53 @@ func Create(ctx context.Context, packagePath string, opt CreateOptions) error {
 		SetVariables:            opt.SetVariables,
 		SkipSBOM:                opt.SkipSBOM,
 		DifferentialPackagePath: opt.DifferentialPackagePath,
+		OverlayPaths:            opt.OverlayPaths,
+		ExcludeImagesFrom:       opt.ExcludeImagesFrom,
+		BuildCache:              opt.BuildCache,
+		RemoteCache:             opt.RemoteCache,
+		RemoteCacheReadOnly:     opt.RemoteCacheReadOnly,
+		MaxMemoryMB:             opt.MaxMemoryMB,
+		MaxTempSpaceMB:          opt.MaxTempSpaceMB,
+		IncludePlatforms:        opt.IncludePlatforms,
+		Buildkit:                opt.Buildkit,
+		AllowUnpinned:           opt.AllowUnpinned,
+		LintRules:               lintRules,
+		Flags:                   opt.Flags,
+		ConfigPath:              opt.ConfigPath,
+	}
+	if layout2.IsRemoteDefinition(packagePath) {
+		createOpt.Source = packagePath
+		definitionPath, err := utils.MakeTempDir(config.CommonOptions.TempDirectory)
+		if err != nil {
+			return err
+		}
+		defer os.RemoveAll(definitionPath)
+		packagePath, err = layout2.FetchRemoteDefinition(ctx, packagePath, definitionPath)
+		if err != nil {
+			return err
+		}
 	}
 	pkgLayout, err := layout2.CreatePackage(ctx, packagePath, createOpt)
 	if err != nil {
 		return err
 	}
 	defer pkgLayout.Cleanup()
+	pkg = pkgLayout.Pkg
+	// The size is only needed for the metrics, so failing to get it does not fail the create.
+	if metrics.From(ctx) != nil {
+		var sizeErr error
+		size, sizeErr = pkgLayout.Size()
+		if sizeErr != nil {
+			logger.From(ctx).Warn("unable to get the package size for the metrics", "error", sizeErr)
+		}
+	}
+
+	if opt.FailOnSeverity != "" {
+		err = scanVulnerabilities(ctx, pkgLayout, opt.FailOnSeverity, waivers)
+		if err != nil {
+			return err
+		}
+	}
 
 	if helpers.IsOCIURL(opt.Output) {
 		ref, err := layout2.ReferenceFromMetadata(opt.Output, pkgLayout.Pkg)
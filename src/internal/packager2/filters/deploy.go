@@ -44,12 +44,18 @@ func (f *deploymentFilter) Apply(pkg v1alpha1.ZarfPackage) ([]v1alpha1.ZarfCompo
 	var selectedComponents []v1alpha1.ZarfComponent
 	groupedComponents := map[string][]v1alpha1.ZarfComponent{}
 	orderedComponentGroups := []string{}
+	groups := map[string]v1alpha1.ZarfComponentGroup{}
 
 	// Group the components by Name and Group while maintaining order
 	for _, component := range pkg.Components {
 		groupKey := component.Name
 		if component.DeprecatedGroup != "" {
 			groupKey = component.DeprecatedGroup
+			groups[groupKey] = v1alpha1.ZarfComponentGroup{Name: groupKey, Selection: v1alpha1.ExactlyOneSelection}
+		}
+		if group, ok := pkg.ComponentGroup(component.Name); ok {
+			groupKey = group.Name
+			groups[groupKey] = group
 		}
 
 		if !slices.Contains(orderedComponentGroups, groupKey) {
@@ -95,7 +101,7 @@ func (f *deploymentFilter) Apply(pkg v1alpha1.ZarfPackage) ([]v1alpha1.ZarfCompo
 
 					// Then check for already selected groups
 					if groupSelected != nil {
-						return nil, fmt.Errorf("%w: group: %s selected: %s, %s", ErrMultipleSameGroup, component.DeprecatedGroup, groupSelected.Name, component.Name)
+						return nil, fmt.Errorf("%w: group: %s selected: %s, %s", ErrMultipleSameGroup, groupKey, groupSelected.Name, component.Name)
 					}
 
 					// Then append to the final list
@@ -107,7 +113,7 @@ func (f *deploymentFilter) Apply(pkg v1alpha1.ZarfPackage) ([]v1alpha1.ZarfCompo
 			// If nothing was selected from a group, handle the default
 			if groupSelected == nil && groupDefault != nil {
 				selectedComponents = append(selectedComponents, *groupDefault)
-			} else if len(groupedComponents[groupKey]) > 1 && groupSelected == nil && groupDefault == nil {
+			} else if len(groupedComponents[groupKey]) > 1 && groupSelected == nil && groupDefault == nil && groups[groupKey].Selection != v1alpha1.AtMostOneSelection {
 				// If no default component was found, give up
 				componentNames := []string{}
 				for _, component := range groupedComponents[groupKey] {
@@ -135,11 +141,13 @@ func (f *deploymentFilter) Apply(pkg v1alpha1.ZarfPackage) ([]v1alpha1.ZarfCompo
 			group := groupedComponents[groupKey]
 			if len(group) > 1 {
 				if f.isInteractive {
-					component, err := interactive.SelectChoiceGroup(group)
+					component, selected, err := interactive.SelectChoiceGroup(groups[groupKey], group)
 					if err != nil {
 						return nil, fmt.Errorf("%w: %w", ErrSelectionCanceled, err)
 					}
-					selectedComponents = append(selectedComponents, component)
+					if selected {
+						selectedComponents = append(selectedComponents, component)
+					}
 				} else {
 					foundDefault := false
 					componentNames := []string{}
@@ -153,7 +161,7 @@ func (f *deploymentFilter) Apply(pkg v1alpha1.ZarfPackage) ([]v1alpha1.ZarfCompo
 						// Add each component name to the list
 						componentNames = append(componentNames, component.Name)
 					}
-					if !foundDefault {
+					if !foundDefault && groups[groupKey].Selection != v1alpha1.AtMostOneSelection {
 						// If no default component was found, give up
 						return nil, fmt.Errorf("%w: choose from %s", ErrNoDefaultOrSelection, strings.Join(componentNames, ", "))
 					}
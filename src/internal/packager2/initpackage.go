@@ -0,0 +1,238 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package packager2
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/defenseunicorns/pkg/helpers/v2"
+	goyaml "github.com/goccy/go-yaml"
+
+	"github.com/zarf-dev/zarf/src/api/v1alpha1"
+	"github.com/zarf-dev/zarf/src/config"
+	layout2 "github.com/zarf-dev/zarf/src/internal/packager2/layout"
+	"github.com/zarf-dev/zarf/src/pkg/layout"
+	"github.com/zarf-dev/zarf/src/pkg/logger"
+	"github.com/zarf-dev/zarf/src/pkg/utils"
+)
+
+// InitManifest describes a custom init package composed from the components of a base init package.
+type InitManifest struct {
+	// Metadata overrides for the name and description of the base init package
+	Metadata InitManifestMetadata `json:"metadata,omitempty"`
+	// Directory containing the zarf.yaml of the base init package, relative paths are resolved from the manifest
+	Base string `json:"base,omitempty"`
+	// Names of the optional base components to leave out of the init package
+	Exclude []string `json:"exclude,omitempty"`
+	// Components to add to the init package, each has to import a package by path or URL. Import paths are resolved from the manifest
+	Components []InitManifestComponent `json:"components,omitempty"`
+	// Overrides for the defaults of package variables
+	Variables map[string]string `json:"variables,omitempty"`
+	// Leave the registry images out of the package, they are pulled from the mirror given to zarf init --seed-from
+	SeedFromMirror bool `json:"seedFromMirror,omitempty"`
+}
+
+// seedImageComponents are the components of the init package whose images seed the internal registry.
+var seedImageComponents = []string{"zarf-seed-registry", "zarf-registry"}
+
+// InitManifestMetadata overrides the metadata of the base init package.
+type InitManifestMetadata struct {
+	Name        string `json:"name,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+// InitManifestComponent is a component added to the init package.
+type InitManifestComponent struct {
+	v1alpha1.ZarfComponent `yaml:",inline"`
+	// Name of the base component to place this component before, components are added after the base components by default
+	Before string `json:"before,omitempty"`
+}
+
+// BuildInitPackage creates an init package from the manifest at manifestPath.
+func BuildInitPackage(ctx context.Context, manifestPath string, opt CreateOptions) error {
+	tmpDir, err := utils.MakeTempDir(config.CommonOptions.TempDirectory)
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmpDir)
+
+	manifest, overlayPaths, err := writeInitPackage(manifestPath, tmpDir)
+	if err != nil {
+		return err
+	}
+	opt.OverlayPaths = append(overlayPaths, opt.OverlayPaths...)
+	if manifest.SeedFromMirror {
+		opt.ExcludeImagesFrom = append(opt.ExcludeImagesFrom, seedImageComponents...)
+	}
+
+	logger.From(ctx).Info("building init package", "manifest", manifestPath)
+	return Create(ctx, tmpDir, opt)
+}
+
+// writeInitPackage writes the init package composed from the manifest to dstDir. It returns the parsed manifest and
+// the overlays that have to be applied to the package to set the variable defaults of the manifest.
+func writeInitPackage(manifestPath, dstDir string) (InitManifest, []string, error) {
+	b, err := os.ReadFile(manifestPath)
+	if err != nil {
+		return InitManifest{}, nil, fmt.Errorf("unable to read init package manifest: %w", err)
+	}
+	manifest := InitManifest{}
+	err = goyaml.UnmarshalWithOptions(b, &manifest, goyaml.Strict())
+	if err != nil {
+		return InitManifest{}, nil, fmt.Errorf("unable to parse init package manifest %s: %w", manifestPath, err)
+	}
+	manifestDir, err := filepath.Abs(filepath.Dir(manifestPath))
+	if err != nil {
+		return InitManifest{}, nil, err
+	}
+	basePath := manifest.Base
+	if !filepath.IsAbs(basePath) {
+		basePath = filepath.Join(manifestDir, basePath)
+	}
+	b, err = os.ReadFile(filepath.Join(basePath, layout.ZarfYAML))
+	if err != nil {
+		return InitManifest{}, nil, fmt.Errorf("unable to read the base init package: %w", err)
+	}
+	base, err := layout2.ParseZarfPackage(b)
+	if err != nil {
+		return InitManifest{}, nil, err
+	}
+
+	pkg, err := composeInitPackage(manifest, base, basePath, manifestDir, dstDir)
+	if err != nil {
+		return InitManifest{}, nil, err
+	}
+	b, err = goyaml.Marshal(pkg)
+	if err != nil {
+		return InitManifest{}, nil, err
+	}
+	err = os.WriteFile(filepath.Join(dstDir, layout.ZarfYAML), b, helpers.ReadWriteUser)
+	if err != nil {
+		return InitManifest{}, nil, err
+	}
+
+	if len(manifest.Variables) == 0 {
+		return manifest, nil, nil
+	}
+	overlayPath := filepath.Join(dstDir, "variables.yaml")
+	b, err = goyaml.Marshal(variablesOverlay(manifest.Variables))
+	if err != nil {
+		return InitManifest{}, nil, err
+	}
+	err = os.WriteFile(overlayPath, b, helpers.ReadWriteUser)
+	if err != nil {
+		return InitManifest{}, nil, err
+	}
+	return manifest, []string{overlayPath}, nil
+}
+
+// composeInitPackage returns an init package that imports the base components that are not excluded along with the
+// components added by the manifest. Import paths are made relative to dstDir, where the package is written to.
+func composeInitPackage(manifest InitManifest, base v1alpha1.ZarfPackage, basePath, manifestDir, dstDir string) (v1alpha1.ZarfPackage, error) {
+	if !base.IsInitConfig() {
+		return v1alpha1.ZarfPackage{}, fmt.Errorf("base package %s is a %s, not a %s", base.Metadata.Name, base.Kind, v1alpha1.ZarfInitConfig)
+	}
+	basePathRel, err := filepath.Rel(dstDir, basePath)
+	if err != nil {
+		return v1alpha1.ZarfPackage{}, err
+	}
+
+	errs := []error{}
+	for _, name := range manifest.Exclude {
+		idx := slices.IndexFunc(base.Components, func(c v1alpha1.ZarfComponent) bool { return c.Name == name })
+		if idx == -1 {
+			errs = append(errs, fmt.Errorf("excluded component %s does not exist in the base init package", name))
+			continue
+		}
+		if base.Components[idx].IsRequired() {
+			errs = append(errs, fmt.Errorf("component %s is required by the base init package and can not be excluded", name))
+		}
+	}
+	for _, comp := range manifest.Components {
+		if comp.Import.Path == "" && comp.Import.URL == "" {
+			errs = append(errs, fmt.Errorf("added component %s has to import a package by path or URL", comp.Name))
+		}
+		if comp.Before != "" && !slices.ContainsFunc(base.Components, func(c v1alpha1.ZarfComponent) bool { return c.Name == comp.Before }) {
+			errs = append(errs, fmt.Errorf("added component %s is placed before %s which does not exist in the base init package", comp.Name, comp.Before))
+		}
+	}
+	if err := errors.Join(errs...); err != nil {
+		return v1alpha1.ZarfPackage{}, err
+	}
+
+	added := []v1alpha1.ZarfComponent{}
+	before := map[string][]v1alpha1.ZarfComponent{}
+	for _, comp := range manifest.Components {
+		if comp.Import.Path != "" {
+			importPath := comp.Import.Path
+			if !filepath.IsAbs(importPath) {
+				importPath = filepath.Join(manifestDir, importPath)
+			}
+			comp.Import.Path, err = filepath.Rel(dstDir, importPath)
+			if err != nil {
+				return v1alpha1.ZarfPackage{}, err
+			}
+		}
+		if comp.Before != "" {
+			before[comp.Before] = append(before[comp.Before], comp.ZarfComponent)
+			continue
+		}
+		added = append(added, comp.ZarfComponent)
+	}
+
+	pkg := v1alpha1.ZarfPackage{
+		Kind:     v1alpha1.ZarfInitConfig,
+		Metadata: base.Metadata,
+	}
+	if manifest.Metadata.Name != "" {
+		pkg.Metadata.Name = manifest.Metadata.Name
+	}
+	if manifest.Metadata.Description != "" {
+		pkg.Metadata.Description = manifest.Metadata.Description
+	}
+	for _, comp := range base.Components {
+		pkg.Components = append(pkg.Components, before[comp.Name]...)
+		delete(before, comp.Name)
+		if slices.Contains(manifest.Exclude, comp.Name) {
+			continue
+		}
+		// Only the architecture and flavor filters are copied as the local OS can not be redefined during compose.
+		pkg.Components = append(pkg.Components, v1alpha1.ZarfComponent{
+			Name:        comp.Name,
+			Description: comp.Description,
+			Default:     comp.Default,
+			Required:    comp.Required,
+			Only: v1alpha1.ZarfComponentOnlyTarget{
+				Cluster: v1alpha1.ZarfComponentOnlyCluster{
+					Architecture: comp.Only.Cluster.Architecture,
+				},
+				Flavor: comp.Only.Flavor,
+			},
+			Import: v1alpha1.ZarfComponentImport{
+				Path: basePathRel,
+			},
+		})
+	}
+	pkg.Components = append(pkg.Components, added...)
+	return pkg, nil
+}
+
+// variablesOverlay returns an overlay that replaces the defaults of the given package variables.
+func variablesOverlay(variables map[string]string) map[string]any {
+	names := []string{}
+	for name := range variables {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	overlay := []map[string]any{}
+	for _, name := range names {
+		overlay = append(overlay, map[string]any{"name": name, "default": variables[name]})
+	}
+	return map[string]any{"variables": overlay}
+}
@@ -0,0 +1,156 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package packager2
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/defenseunicorns/pkg/helpers/v2"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zarf-dev/zarf/src/api/v1alpha1"
+	layout2 "github.com/zarf-dev/zarf/src/internal/packager2/layout"
+	"github.com/zarf-dev/zarf/src/pkg/lint"
+	"github.com/zarf-dev/zarf/src/test/testutil"
+)
+
+func TestBuildInitPackageLoad(t *testing.T) {
+	t.Parallel()
+	ctx := testutil.TestContext(t)
+	lint.ZarfSchema = testutil.LoadSchema(t, "../../../zarf.schema.json")
+
+	dstDir := t.TempDir()
+	manifest, overlayPaths, err := writeInitPackage("testdata/init-package/zarf-init.yaml", dstDir)
+	require.NoError(t, err)
+	require.True(t, manifest.SeedFromMirror)
+	pkg, err := layout2.LoadPackage(ctx, dstDir, "", nil, overlayPaths)
+	require.NoError(t, err)
+
+	require.Equal(t, v1alpha1.ZarfInitConfig, pkg.Kind)
+	require.Equal(t, "init-edge", pkg.Metadata.Name)
+	require.Equal(t, "Init package for edge clusters", pkg.Metadata.Description)
+	names := []string{}
+	for _, comp := range pkg.Components {
+		names = append(names, comp.Name)
+		require.True(t, comp.IsRequired(), comp.Name)
+	}
+	require.Equal(t, []string{"storage", "zarf-registry"}, names)
+	require.Len(t, pkg.Variables, 1)
+	require.Equal(t, "REGISTRY_PVC_SIZE", pkg.Variables[0].Name)
+	require.Equal(t, "5Gi", pkg.Variables[0].Default)
+	require.Equal(t, "The size of the persistent volume claim for the registry", pkg.Variables[0].Description)
+}
+
+func TestWriteInitPackageWithoutVariables(t *testing.T) {
+	t.Parallel()
+
+	basePath, err := filepath.Abs("testdata/init-package/base")
+	require.NoError(t, err)
+	manifestPath := filepath.Join(t.TempDir(), "zarf-init.yaml")
+	err = os.WriteFile(manifestPath, []byte("base: "+basePath+"\nseedFromMirror: true\n"), 0o600)
+	require.NoError(t, err)
+
+	manifest, overlayPaths, err := writeInitPackage(manifestPath, t.TempDir())
+	require.NoError(t, err)
+	require.True(t, manifest.SeedFromMirror)
+	require.Empty(t, overlayPaths)
+}
+
+func TestComposeInitPackage(t *testing.T) {
+	t.Parallel()
+
+	base := v1alpha1.ZarfPackage{
+		Kind: v1alpha1.ZarfInitConfig,
+		Metadata: v1alpha1.ZarfMetadata{
+			Name: "init",
+		},
+		Components: []v1alpha1.ZarfComponent{
+			{
+				Name:     "zarf-registry",
+				Required: helpers.BoolPtr(true),
+			},
+			{
+				Name: "k3s",
+				Only: v1alpha1.ZarfComponentOnlyTarget{
+					LocalOS: "linux",
+					Cluster: v1alpha1.ZarfComponentOnlyCluster{
+						Architecture: "amd64",
+					},
+				},
+			},
+			{
+				Name: "git-server",
+			},
+		},
+	}
+
+	tests := []struct {
+		name        string
+		base        v1alpha1.ZarfPackage
+		manifest    InitManifest
+		expected    []v1alpha1.ZarfComponent
+		expectedErr string
+	}{
+		{
+			name: "base",
+			base: base,
+			expected: []v1alpha1.ZarfComponent{
+				{Name: "zarf-registry", Required: helpers.BoolPtr(true), Import: v1alpha1.ZarfComponentImport{Path: "../base"}},
+				{Name: "k3s", Only: v1alpha1.ZarfComponentOnlyTarget{Cluster: v1alpha1.ZarfComponentOnlyCluster{Architecture: "amd64"}}, Import: v1alpha1.ZarfComponentImport{Path: "../base"}},
+				{Name: "git-server", Import: v1alpha1.ZarfComponentImport{Path: "../base"}},
+			},
+		},
+		{
+			name: "exclude and add",
+			base: base,
+			manifest: InitManifest{
+				Exclude: []string{"k3s", "git-server"},
+				Components: []InitManifestComponent{
+					{ZarfComponent: v1alpha1.ZarfComponent{Name: "storage", Import: v1alpha1.ZarfComponentImport{Path: "storage"}}, Before: "zarf-registry"},
+					{ZarfComponent: v1alpha1.ZarfComponent{Name: "monitoring", Import: v1alpha1.ZarfComponentImport{URL: "oci://ghcr.io/example/monitoring:1.0.0"}}},
+				},
+			},
+			expected: []v1alpha1.ZarfComponent{
+				{Name: "storage", Import: v1alpha1.ZarfComponentImport{Path: "../manifest/storage"}},
+				{Name: "zarf-registry", Required: helpers.BoolPtr(true), Import: v1alpha1.ZarfComponentImport{Path: "../base"}},
+				{Name: "monitoring", Import: v1alpha1.ZarfComponentImport{URL: "oci://ghcr.io/example/monitoring:1.0.0"}},
+			},
+		},
+		{
+			name:        "not an init package",
+			base:        v1alpha1.ZarfPackage{Kind: v1alpha1.ZarfPackageConfig, Metadata: v1alpha1.ZarfMetadata{Name: "app"}},
+			expectedErr: "base package app is a ZarfPackageConfig, not a ZarfInitConfig",
+		},
+		{
+			name: "invalid manifest",
+			base: base,
+			manifest: InitManifest{
+				Exclude: []string{"zarf-registry", "flux"},
+				Components: []InitManifestComponent{
+					{ZarfComponent: v1alpha1.ZarfComponent{Name: "storage"}, Before: "k8s"},
+				},
+			},
+			expectedErr: "component zarf-registry is required by the base init package and can not be excluded\n" +
+				"excluded component flux does not exist in the base init package\n" +
+				"added component storage has to import a package by path or URL\n" +
+				"added component storage is placed before k8s which does not exist in the base init package",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			pkg, err := composeInitPackage(tt.manifest, tt.base, "/tmp/base", "/tmp/manifest", "/tmp/build")
+			if tt.expectedErr != "" {
+				require.EqualError(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, v1alpha1.ZarfInitConfig, pkg.Kind)
+			require.Equal(t, tt.base.Metadata, pkg.Metadata)
+			require.Equal(t, tt.expected, pkg.Components)
+		})
+	}
+}
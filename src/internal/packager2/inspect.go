@@ -0,0 +1,586 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package packager2
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/defenseunicorns/pkg/helpers/v2"
+	"github.com/defenseunicorns/pkg/oci"
+	"github.com/mholt/archiver/v3"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+
+	"github.com/zarf-dev/zarf/src/api/v1alpha1"
+	"github.com/zarf-dev/zarf/src/config"
+	"github.com/zarf-dev/zarf/src/internal/packager/helm"
+	"github.com/zarf-dev/zarf/src/internal/packager/template"
+	layout2 "github.com/zarf-dev/zarf/src/internal/packager2/layout"
+	"github.com/zarf-dev/zarf/src/pkg/layout"
+	"github.com/zarf-dev/zarf/src/pkg/transform"
+	"github.com/zarf-dev/zarf/src/pkg/utils"
+	"github.com/zarf-dev/zarf/src/pkg/variables"
+	"github.com/zarf-dev/zarf/src/pkg/zoci"
+	"github.com/zarf-dev/zarf/src/types"
+)
+
+// maxImageMetadataSize is the largest image manifest or config that is read from a package.
+const maxImageMetadataSize = 4 * 1024 * 1024
+
+// ImageInfo describes an image in a package.
+type ImageInfo struct {
+	Reference  string   `json:"reference"`
+	Digest     string   `json:"digest"`
+	Size       int64    `json:"size"`
+	Platforms  []string `json:"platforms"`
+	Components []string `json:"components"`
+}
+
+// InspectImagesOptions are the options for InspectImages.
+type InspectImagesOptions struct {
+	Architecture            string
+	PublicKeyPath           string
+	SkipSignatureValidation bool
+	WithPlainHTTP           bool
+}
+
+// InspectImages lists the images in a package tarball or OCI package. Only the package metadata, image index and image
+// manifests and configs are read, which are validated against the package checksums.
+func InspectImages(ctx context.Context, src string, opt InspectImagesOptions) (_ []ImageInfo, err error) {
+	srcType, err := identifySource(src)
+	if err != nil {
+		return nil, err
+	}
+	tmpDir, err := utils.MakeTempDir(config.CommonOptions.TempDirectory)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		err = errors.Join(err, os.RemoveAll(tmpDir))
+	}()
+
+	switch srcType {
+	case "oci":
+		mods := []oci.Modifier{}
+		if opt.WithPlainHTTP {
+			mods = append(mods, oci.WithPlainHTTP(true))
+		}
+		remote, err := zoci.NewRemote(ctx, src, oci.PlatformForArch(config.GetArch(opt.Architecture)), mods...)
+		if err != nil {
+			return nil, err
+		}
+		err = fetchRemoteImageMetadata(ctx, remote, tmpDir)
+		if err != nil {
+			return nil, err
+		}
+	case "split":
+		tarPath := filepath.Join(tmpDir, "data.tar.zst")
+		err = assembleSplitTar(src, tarPath)
+		if err != nil {
+			return nil, err
+		}
+		err = extractImageMetadata(tarPath, tmpDir)
+		if err != nil {
+			return nil, err
+		}
+		err = os.Remove(tarPath)
+		if err != nil {
+			return nil, err
+		}
+	case "tarball":
+		err = extractImageMetadata(src, tmpDir)
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("images can only be inspected in package tarballs and oci:// packages, not %s", src)
+	}
+
+	layoutOpt := layout2.PackageLayoutOptions{
+		PublicKeyPath:           opt.PublicKeyPath,
+		SkipSignatureValidation: opt.SkipSignatureValidation,
+		IsPartial:               true,
+	}
+	pkgLayout, err := layout2.LoadFromDir(ctx, tmpDir, layoutOpt)
+	if err != nil {
+		return nil, err
+	}
+	return imageInfos(pkgLayout.Pkg, tmpDir)
+}
+
+// extractImageMetadata extracts the package metadata and the image index, manifests and configs from the package
+// tarball without extracting the image layers or components.
+func extractImageMetadata(tarPath, dir string) error {
+	blobsDir := filepath.ToSlash(layout.ImagesBlobsDir) + "/"
+	return archiver.Walk(tarPath, func(f archiver.File) error {
+		if f.IsDir() {
+			return nil
+		}
+		header, ok := f.Header.(*tar.Header)
+		if !ok {
+			return fmt.Errorf("expected header to be *tar.Header but was %T", f.Header)
+		}
+		name := header.Name
+		var r io.Reader = f
+		switch {
+		case slices.Contains(zoci.PackageAlwaysPull, name), name == filepath.ToSlash(layout.IndexPath):
+		case strings.HasPrefix(name, blobsDir) && f.Size() <= maxImageMetadataSize:
+			// Image manifests and configs are JSON while layers are archives.
+			br := bufio.NewReader(f)
+			b, err := br.Peek(1)
+			if err != nil || b[0] != '{' {
+				return nil
+			}
+			r = br
+		default:
+			return nil
+		}
+		return writeImageMetadata(dir, name, r)
+	})
+}
+
+// fetchRemoteImageMetadata fetches the package metadata and the image index, manifests and configs of a remote
+// package without pulling the image layers or components.
+func fetchRemoteImageMetadata(ctx context.Context, remote *zoci.Remote, dir string) error {
+	err := fetchRemoteMetadata(ctx, remote, dir)
+	if err != nil {
+		return err
+	}
+	manifest, err := remote.FetchRoot(ctx)
+	if err != nil {
+		return err
+	}
+	fetch := func(path string) ([]byte, error) {
+		desc := manifest.Locate(path)
+		if oci.IsEmptyDescriptor(desc) {
+			return nil, fmt.Errorf("%s is missing from the package", path)
+		}
+		b, err := remote.FetchLayer(ctx, desc)
+		if err != nil {
+			return nil, err
+		}
+		err = writeImageMetadata(dir, path, bytes.NewReader(b))
+		if err != nil {
+			return nil, err
+		}
+		return b, nil
+	}
+
+	b, err := fetch(filepath.ToSlash(layout.IndexPath))
+	if err != nil {
+		return err
+	}
+	var index ocispec.Index
+	err = json.Unmarshal(b, &index)
+	if err != nil {
+		return err
+	}
+	descs := index.Manifests
+	for len(descs) > 0 {
+		desc := descs[0]
+		descs = descs[1:]
+		b, err := fetch(imageBlobPath(desc.Digest.Encoded()))
+		if err != nil {
+			return err
+		}
+		switch desc.MediaType {
+		case ocispec.MediaTypeImageIndex, "application/vnd.docker.distribution.manifest.list.v2+json":
+			var childIndex ocispec.Index
+			err = json.Unmarshal(b, &childIndex)
+			if err != nil {
+				return err
+			}
+			descs = append(descs, childIndex.Manifests...)
+		default:
+			var imageManifest ocispec.Manifest
+			err = json.Unmarshal(b, &imageManifest)
+			if err != nil {
+				return err
+			}
+			_, err = fetch(imageBlobPath(imageManifest.Config.Digest.Encoded()))
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// imageInfos returns the images in the image index of the package in dir.
+func imageInfos(pkg v1alpha1.ZarfPackage, dir string) ([]ImageInfo, error) {
+	b, err := os.ReadFile(filepath.Join(dir, layout.IndexPath))
+	if errors.Is(err, os.ErrNotExist) {
+		return []ImageInfo{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var index ocispec.Index
+	err = json.Unmarshal(b, &index)
+	if err != nil {
+		return nil, err
+	}
+
+	owners := map[string][]string{}
+	for _, component := range pkg.Components {
+		for _, src := range component.Images {
+			ref := src
+			if refInfo, err := transform.ParseImageRef(src); err == nil {
+				ref = refInfo.Reference
+			}
+			if !slices.Contains(owners[ref], component.Name) {
+				owners[ref] = append(owners[ref], component.Name)
+			}
+		}
+	}
+
+	// Images with more than one platform in the package are listed by the index of those platforms.
+	withPlatforms := map[string]bool{}
+	for _, desc := range index.Manifests {
+		if ref := desc.Annotations[utils.ImageIndexAnnotation]; ref != "" {
+			withPlatforms[ref] = true
+		}
+	}
+
+	infos := []ImageInfo{}
+	for _, desc := range index.Manifests {
+		ref := desc.Annotations[ocispec.AnnotationBaseImageName]
+		if withPlatforms[ref] {
+			continue
+		}
+		if indexRef := desc.Annotations[utils.ImageIndexAnnotation]; indexRef != "" {
+			ref = indexRef
+		}
+		size, platforms, err := imageSizeAndPlatforms(dir, desc)
+		if err != nil {
+			return nil, err
+		}
+		infos = append(infos, ImageInfo{
+			Reference:  ref,
+			Digest:     desc.Digest.String(),
+			Size:       size,
+			Platforms:  platforms,
+			Components: owners[ref],
+		})
+	}
+	return infos, nil
+}
+
+// imageSizeAndPlatforms returns the compressed size and the platforms of the image or image index.
+func imageSizeAndPlatforms(dir string, desc ocispec.Descriptor) (int64, []string, error) {
+	b, err := os.ReadFile(filepath.Join(dir, imageBlobPath(desc.Digest.Encoded())))
+	if err != nil {
+		return 0, nil, fmt.Errorf("unable to read manifest %s: %w", desc.Digest, err)
+	}
+	switch desc.MediaType {
+	case ocispec.MediaTypeImageIndex, "application/vnd.docker.distribution.manifest.list.v2+json":
+		var index ocispec.Index
+		err = json.Unmarshal(b, &index)
+		if err != nil {
+			return 0, nil, err
+		}
+		size := desc.Size
+		platforms := []string{}
+		for _, child := range index.Manifests {
+			childSize, childPlatforms, err := imageSizeAndPlatforms(dir, child)
+			if err != nil {
+				return 0, nil, err
+			}
+			size += childSize
+			platforms = append(platforms, childPlatforms...)
+		}
+		return size, platforms, nil
+	default:
+		var manifest ocispec.Manifest
+		err = json.Unmarshal(b, &manifest)
+		if err != nil {
+			return 0, nil, err
+		}
+		size := desc.Size + manifest.Config.Size
+		for _, layer := range manifest.Layers {
+			size += layer.Size
+		}
+		platform := desc.Platform
+		if platform == nil {
+			b, err := os.ReadFile(filepath.Join(dir, imageBlobPath(manifest.Config.Digest.Encoded())))
+			if err != nil {
+				return 0, nil, fmt.Errorf("unable to read config %s: %w", manifest.Config.Digest, err)
+			}
+			platform = &ocispec.Platform{}
+			err = json.Unmarshal(b, platform)
+			if err != nil {
+				return 0, nil, err
+			}
+		}
+		return size, []string{formatPlatform(*platform)}, nil
+	}
+}
+
+// formatPlatform returns the platform as os/architecture[/variant].
+func formatPlatform(platform ocispec.Platform) string {
+	parts := []string{platform.OS, platform.Architecture}
+	if platform.Variant != "" {
+		parts = append(parts, platform.Variant)
+	}
+	return strings.Join(parts, "/")
+}
+
+// imageBlobPath returns the path of the image blob in the package.
+func imageBlobPath(encoded string) string {
+	return filepath.ToSlash(filepath.Join(layout.ImagesBlobsDir, encoded))
+}
+
+// writeImageMetadata writes r to the path of the named package file in dir.
+func writeImageMetadata(dir, name string, r io.Reader) error {
+	path := filepath.Join(dir, filepath.FromSlash(name))
+	err := helpers.CreateDirectory(filepath.Dir(path), helpers.ReadExecuteAllWriteUser)
+	if err != nil {
+		return err
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	_, err = io.Copy(f, r)
+	return err
+}
+
+// RenderedManifest is the YAML that a chart or manifest of a package renders to.
+type RenderedManifest struct {
+	Component string
+	// Kind is either chart or manifest.
+	Kind      string
+	Name      string
+	Namespace string
+	Content   string
+}
+
+// InspectManifestsOptions are the options for InspectManifests.
+type InspectManifestsOptions struct {
+	SetVariables map[string]string
+	KubeVersion  string
+	// RegistryURL is the registry address used for the ###ZARF_REGISTRY### template, it defaults to the in cluster registry.
+	RegistryURL string
+}
+
+// InspectManifests renders the charts and manifests of the package without a cluster, the same way they are templated
+// on deploy. Values that are only known once Zarf is initialized, such as credentials, are filled in with placeholders.
+func InspectManifests(ctx context.Context, pkgLayout *layout2.PackageLayout, opt InspectManifestsOptions) (_ []RenderedManifest, err error) {
+	variableConfig := template.GetZarfVariableConfig(ctx)
+	variableConfig.SetConstants(pkgLayout.Pkg.Constants)
+	err = variableConfig.PopulateVariables(pkgLayout.Pkg.Variables, opt.SetVariables)
+	if err != nil {
+		return nil, fmt.Errorf("unable to set the active variables: %w", err)
+	}
+	state, err := placeholderState(opt.RegistryURL)
+	if err != nil {
+		return nil, err
+	}
+
+	tmpDir, err := utils.MakeTempDir(config.CommonOptions.TempDirectory)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		err = errors.Join(err, os.RemoveAll(tmpDir))
+	}()
+
+	rendered := []RenderedManifest{}
+	for _, component := range pkgLayout.Pkg.Components {
+		if len(component.Charts)+len(component.Manifests) == 0 {
+			continue
+		}
+		applicationTemplates, err := template.GetZarfTemplates(ctx, component.Name, state)
+		if err != nil {
+			return nil, err
+		}
+		variableConfig.SetApplicationTemplates(applicationTemplates)
+
+		componentDir := filepath.Join(tmpDir, component.Name)
+		err = helpers.CreateDirectory(componentDir, helpers.ReadWriteExecuteUser)
+		if err != nil {
+			return nil, err
+		}
+		charts, err := renderCharts(ctx, pkgLayout, component, componentDir, variableConfig, opt.KubeVersion)
+		if err != nil {
+			return nil, err
+		}
+		rendered = append(rendered, charts...)
+		manifests, err := renderManifests(ctx, pkgLayout, component, componentDir, variableConfig, opt.KubeVersion)
+		if err != nil {
+			return nil, err
+		}
+		rendered = append(rendered, manifests...)
+	}
+	return rendered, nil
+}
+
+// placeholderCredential replaces the credentials that Zarf generates on init so that renders of a package are stable.
+const placeholderCredential = "placeholder"
+
+// placeholderState returns a Zarf state with the defaults of an initialized cluster and placeholder credentials.
+func placeholderState(registryURL string) (*types.ZarfState, error) {
+	registryInfo := types.RegistryInfo{
+		Address:      registryURL,
+		PushPassword: placeholderCredential,
+		PullPassword: placeholderCredential,
+		Secret:       placeholderCredential,
+	}
+	err := registryInfo.FillInEmptyValues()
+	if err != nil {
+		return nil, err
+	}
+	gitServer := types.GitServerInfo{
+		PushPassword: placeholderCredential,
+		PullPassword: placeholderCredential,
+	}
+	err = gitServer.FillInEmptyValues()
+	if err != nil {
+		return nil, err
+	}
+	artifactServer := types.ArtifactServerInfo{}
+	artifactServer.FillInEmptyValues()
+	return &types.ZarfState{
+		RegistryInfo:   registryInfo,
+		GitServer:      gitServer,
+		ArtifactServer: artifactServer,
+	}, nil
+}
+
+func renderCharts(ctx context.Context, pkgLayout *layout2.PackageLayout, component v1alpha1.ZarfComponent, componentDir string, variableConfig *variables.VariableConfig, kubeVersion string) ([]RenderedManifest, error) {
+	if len(component.Charts) == 0 {
+		return nil, nil
+	}
+	chartsDir, err := pkgLayout.GetComponentDir(componentDir, component.Name, layout2.ChartsComponentDir)
+	if err != nil {
+		return nil, err
+	}
+	valuesDir, err := pkgLayout.GetComponentDir(componentDir, component.Name, layout2.ValuesComponentDir)
+	// Components without values files do not have a values directory.
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+
+	rendered := []RenderedManifest{}
+	for _, chart := range component.Charts {
+		for idx := range chart.ValuesFiles {
+			err := variableConfig.ReplaceTextTemplate(helm.StandardValuesName(valuesDir, chart, idx))
+			if err != nil {
+				return nil, err
+			}
+		}
+		valuesOverrides := map[string]any{}
+		for _, variable := range chart.Variables {
+			if setVar, ok := variableConfig.GetSetVariable(variable.Name); ok && setVar != nil {
+				err := helpers.MergePathAndValueIntoMap(valuesOverrides, variable.Path, setVar.Value)
+				if err != nil {
+					return nil, fmt.Errorf("unable to merge path and value into map: %w", err)
+				}
+			}
+		}
+
+		helmCfg := helm.New(
+			chart,
+			chartsDir,
+			valuesDir,
+			helm.WithKubeVersion(kubeVersion),
+			helm.WithVariableConfig(variableConfig),
+			helm.WithValuesOverrides(valuesOverrides),
+		)
+		content, _, err := helmCfg.TemplateChart(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("could not render the Helm template for chart %s: %w", chart.Name, err)
+		}
+		content = stripSourceComments(content)
+		rendered = append(rendered, RenderedManifest{
+			Component: component.Name,
+			Kind:      "chart",
+			Name:      chart.Name,
+			Namespace: chart.Namespace,
+			Content:   content,
+		})
+	}
+	return rendered, nil
+}
+
+func renderManifests(ctx context.Context, pkgLayout *layout2.PackageLayout, component v1alpha1.ZarfComponent, componentDir string, variableConfig *variables.VariableConfig, kubeVersion string) ([]RenderedManifest, error) {
+	if len(component.Manifests) == 0 {
+		return nil, nil
+	}
+	manifestsDir, err := pkgLayout.GetComponentDir(componentDir, component.Name, layout2.ManifestsComponentDir)
+	if err != nil {
+		return nil, err
+	}
+
+	rendered := []RenderedManifest{}
+	for _, manifest := range component.Manifests {
+		// Manifests are stored in the package by name and index, the same way they are looked up on deploy.
+		files := []string{}
+		for idx, file := range manifest.Files {
+			if helpers.InvalidPath(filepath.Join(manifestsDir, file)) {
+				file = fmt.Sprintf("%s-%d.yaml", manifest.Name, idx)
+				if helpers.InvalidPath(filepath.Join(manifestsDir, file)) {
+					return nil, fmt.Errorf("unable to find manifest file %s", manifest.Files[idx])
+				}
+			}
+			files = append(files, file)
+		}
+		for idx := range manifest.Kustomizations {
+			files = append(files, fmt.Sprintf("kustomization-%s-%d.yaml", manifest.Name, idx))
+		}
+		manifest.Files = files
+		if manifest.Namespace == "" {
+			manifest.Namespace = "default"
+		}
+
+		helmCfg, err := helm.NewFromZarfManifest(
+			manifest,
+			manifestsDir,
+			pkgLayout.Pkg.Metadata.Name,
+			component.Name,
+			helm.WithKubeVersion(kubeVersion),
+			helm.WithVariableConfig(variableConfig),
+		)
+		if err != nil {
+			return nil, err
+		}
+		content, _, err := helmCfg.TemplateChart(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("could not render manifest %s: %w", manifest.Name, err)
+		}
+		content = stripSourceComments(content)
+		rendered = append(rendered, RenderedManifest{
+			Component: component.Name,
+			Kind:      "manifest",
+			Name:      manifest.Name,
+			Namespace: manifest.Namespace,
+			Content:   content,
+		})
+	}
+	return rendered, nil
+}
+
+// stripSourceComments removes the source comments that Helm adds to rendered resources. These point to temporary
+// directories and would otherwise differ between every render of the same package.
+func stripSourceComments(content string) string {
+	lines := []string{}
+	for _, line := range strings.Split(content, "\n") {
+		if strings.HasPrefix(line, "# Source: ") {
+			continue
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,147 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package packager2
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/zarf-dev/zarf/src/internal/packager2/layout"
+	"github.com/zarf-dev/zarf/src/pkg/lint"
+	"github.com/zarf-dev/zarf/src/test/testutil"
+)
+
+func TestInspectImages(t *testing.T) {
+	ctx := testutil.TestContext(t)
+
+	registryRef := createRegistry(t, ctx)
+	publishOpts := PublishPackageOpts{
+		WithPlainHTTP: true,
+		Architecture:  "amd64",
+	}
+	err := PublishPackage(ctx, "testdata/zarf-package-test-amd64-0.0.1.tar.zst", registryRef, publishOpts)
+	require.NoError(t, err)
+	// Publish creates a local oci manifest file using the package name, delete this to clean up test name
+	defer os.Remove("test")
+
+	alpine := ImageInfo{
+		Reference:  "docker.io/library/alpine:3.20",
+		Digest:     "sha256:43180c492a5e6cedd8232e8f77a454f666f247586853eecb90258b26688ad1d3",
+		Size:       1022 + 581 + 3626897,
+		Platforms:  []string{"linux/amd64"},
+		Components: []string{"test"},
+	}
+	// The split package was created separately and contains a different build of the image.
+	splitAlpine := alpine
+	splitAlpine.Digest = "sha256:33735bd63cf84d7e388d9f6d297d348c523c044410f553bd878c6d7829612735"
+	splitAlpine.Size = 3625806
+
+	tests := []struct {
+		name     string
+		source   string
+		expected []ImageInfo
+	}{
+		{
+			name:     "tarball",
+			source:   "testdata/zarf-package-test-amd64-0.0.1.tar.zst",
+			expected: []ImageInfo{alpine},
+		},
+		{
+			name:     "split",
+			source:   "testdata/zarf-package-test-amd64-0.0.1.tar.zst.part000",
+			expected: []ImageInfo{splitAlpine},
+		},
+		{
+			name:     "oci",
+			source:   fmt.Sprintf("oci://%s/test:0.0.1", registryRef.String()),
+			expected: []ImageInfo{alpine},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opt := InspectImagesOptions{
+				Architecture:  "amd64",
+				WithPlainHTTP: true,
+			}
+			images, err := InspectImages(ctx, tt.source, opt)
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, images)
+
+			opt.PublicKeyPath = "layout/testdata/cosign.pub"
+			_, err = InspectImages(ctx, tt.source, opt)
+			require.EqualError(t, err, "a key was provided but the package is not signed")
+		})
+	}
+
+	_, err = InspectImages(ctx, "https://example.com/zarf-package-test-amd64-0.0.1.tar.zst", InspectImagesOptions{})
+	require.EqualError(t, err, "images can only be inspected in package tarballs and oci:// packages, not https://example.com/zarf-package-test-amd64-0.0.1.tar.zst")
+}
+
+func TestInspectManifests(t *testing.T) {
+	ctx := testutil.TestContext(t)
+	lint.ZarfSchema = testutil.LoadSchema(t, "../../../zarf.schema.json")
+
+	pkgLayout, err := layout.CreatePackage(ctx, "testdata/inspect-manifests", layout.CreateOptions{SkipSBOM: true})
+	require.NoError(t, err)
+	defer pkgLayout.Cleanup()
+
+	tests := []struct {
+		name     string
+		opt      InspectManifestsOptions
+		expected []RenderedManifest
+	}{
+		{
+			name: "defaults",
+			expected: []RenderedManifest{
+				{
+					Component: "chart",
+					Kind:      "chart",
+					Name:      "greeting",
+					Namespace: "greeting",
+					Content:   "---\napiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: greeting\ndata:\n  greeting: \"hello\"\n  replicas: \"1\"\n",
+				},
+				{
+					Component: "manifest",
+					Kind:      "manifest",
+					Name:      "config",
+					Namespace: "config",
+					Content:   "---\napiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: config\ndata:\n  greeting: \"hello\"\n  registry: \"127.0.0.1:31999\"\n  auth: \"placeholder\"\n",
+				},
+			},
+		},
+		{
+			name: "variables and registry",
+			opt: InspectManifestsOptions{
+				SetVariables: map[string]string{"GREETING": "hi", "REPLICAS": "2"},
+				RegistryURL:  "registry.example.com",
+			},
+			expected: []RenderedManifest{
+				{
+					Component: "chart",
+					Kind:      "chart",
+					Name:      "greeting",
+					Namespace: "greeting",
+					Content:   "---\napiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: greeting\ndata:\n  greeting: \"hi\"\n  replicas: \"2\"\n",
+				},
+				{
+					Component: "manifest",
+					Kind:      "manifest",
+					Name:      "config",
+					Namespace: "config",
+					Content:   "---\napiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: config\ndata:\n  greeting: \"hi\"\n  registry: \"registry.example.com\"\n  auth: \"placeholder\"\n",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rendered, err := InspectManifests(ctx, pkgLayout, tt.opt)
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, rendered)
+		})
+	}
+}
@@ -0,0 +1,196 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package packager2
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"helm.sh/helm/v3/pkg/action"
+	"helm.sh/helm/v3/pkg/cli"
+	"helm.sh/helm/v3/pkg/releaseutil"
+	"helm.sh/helm/v3/pkg/storage/driver"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/restmapper"
+	"sigs.k8s.io/yaml"
+
+	"github.com/zarf-dev/zarf/src/pkg/cluster"
+	"github.com/zarf-dev/zarf/src/pkg/logger"
+	"github.com/zarf-dev/zarf/src/types"
+)
+
+// InventoryOptions are the options for Inventory.
+type InventoryOptions struct {
+	Cluster *cluster.Cluster
+	// PackageName limits the inventory to the resources of a deployed package.
+	PackageName string
+}
+
+// InventoryResource is a resource Zarf put in the cluster.
+type InventoryResource struct {
+	// Package and Component are empty for resources that are not owned by a package, such as the image pull secrets.
+	Package    string `json:"package,omitempty"`
+	Component  string `json:"component,omitempty"`
+	Release    string `json:"release,omitempty"`
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Namespace  string `json:"namespace,omitempty"`
+	Name       string `json:"name"`
+}
+
+// Inventory lists the resources of the Helm releases of the deployed packages, and the namespaces and secrets labeled
+// as managed by Zarf, by package and component.
+func Inventory(ctx context.Context, opt InventoryOptions) ([]InventoryResource, error) {
+	l := logger.From(ctx)
+
+	deployedPackages, err := opt.Cluster.GetDeployedZarfPackages(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if opt.PackageName != "" {
+		deployedPackages = slices.DeleteFunc(deployedPackages, func(depPkg types.DeployedPackage) bool {
+			return depPkg.Name != opt.PackageName
+		})
+		if len(deployedPackages) == 0 {
+			return nil, fmt.Errorf("the package %s is not deployed", opt.PackageName)
+		}
+	}
+
+	groupResources, err := restmapper.GetAPIGroupResources(opt.Cluster.Clientset.Discovery())
+	if err != nil {
+		return nil, err
+	}
+	mapper := restmapper.NewDiscoveryRESTMapper(groupResources)
+
+	resources := []InventoryResource{}
+	for _, depPkg := range deployedPackages {
+		for _, depComp := range depPkg.DeployedComponents {
+			for _, chart := range depComp.InstalledCharts {
+				settings := cli.New()
+				settings.SetNamespace(chart.Namespace)
+				actionConfig := &action.Configuration{}
+				err := actionConfig.Init(settings.RESTClientGetter(), chart.Namespace, "", func(string, ...interface{}) {})
+				if err != nil {
+					return nil, err
+				}
+				rel, err := action.NewGet(actionConfig).Run(chart.ChartName)
+				if errors.Is(err, driver.ErrReleaseNotFound) {
+					l.Warn("helm release was not found", "name", chart.ChartName, "namespace", chart.Namespace)
+					continue
+				}
+				if err != nil {
+					return nil, fmt.Errorf("unable to get the helm release %s in the namespace %s: %w", chart.ChartName, chart.Namespace, err)
+				}
+				releaseResources, err := manifestResources(rel.Manifest, chart.Namespace, mapper)
+				if err != nil {
+					return nil, fmt.Errorf("unable to read the manifest of the helm release %s: %w", chart.ChartName, err)
+				}
+				for _, resource := range releaseResources {
+					resource.Package = depPkg.Name
+					resource.Component = depComp.Name
+					resource.Release = chart.ChartName
+					resources = append(resources, resource)
+				}
+			}
+		}
+	}
+
+	managed, err := managedResources(ctx, opt.Cluster)
+	if err != nil {
+		return nil, err
+	}
+	for _, resource := range managed {
+		if opt.PackageName != "" && resource.Package != opt.PackageName {
+			continue
+		}
+		resources = append(resources, resource)
+	}
+
+	slices.SortStableFunc(resources, compareInventoryResources)
+	return resources, nil
+}
+
+// manifestResources returns the sorted resources in the manifest of a release, namespaced resources without a namespace
+// are in the namespace of the release.
+func manifestResources(manifest, namespace string, mapper meta.RESTMapper) ([]InventoryResource, error) {
+	resources := []InventoryResource{}
+	for _, doc := range releaseutil.SplitManifests(manifest) {
+		obj := metav1.PartialObjectMetadata{}
+		if err := yaml.Unmarshal([]byte(doc), &obj); err != nil {
+			return nil, err
+		}
+		if obj.Kind == "" {
+			continue
+		}
+		resource := InventoryResource{
+			APIVersion: obj.APIVersion,
+			Kind:       obj.Kind,
+			Namespace:  obj.Namespace,
+			Name:       obj.Name,
+		}
+		if resource.Namespace == "" {
+			gvk := obj.GroupVersionKind()
+			mapping, err := mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+			// Resources of removed custom resource definitions can not be mapped and are listed without a namespace
+			if err == nil && mapping.Scope.Name() == meta.RESTScopeNameNamespace {
+				resource.Namespace = namespace
+			}
+		}
+		resources = append(resources, resource)
+	}
+	// The manifests are split into a map, sort the resources so that they are returned in the same order every time.
+	slices.SortFunc(resources, compareInventoryResources)
+	return resources, nil
+}
+
+// managedResources returns the namespaces and secrets labeled as managed by Zarf. Namespaces created by a package and
+// the secrets of deployed packages are attributed to their package.
+func managedResources(ctx context.Context, c *cluster.Cluster) ([]InventoryResource, error) {
+	listOpts := metav1.ListOptions{LabelSelector: fmt.Sprintf("%s=zarf", cluster.ZarfManagedByLabel)}
+	resources := []InventoryResource{}
+
+	namespaceList, err := c.Clientset.CoreV1().Namespaces().List(ctx, listOpts)
+	if err != nil {
+		return nil, err
+	}
+	for _, namespace := range namespaceList.Items {
+		resources = append(resources, InventoryResource{
+			Package:    namespace.Annotations[cluster.ZarfCreatedByPackageAnnotation],
+			Component:  namespace.Annotations[cluster.ZarfCreatedByComponentAnnotation],
+			APIVersion: "v1",
+			Kind:       "Namespace",
+			Name:       namespace.Name,
+		})
+	}
+
+	secretList, err := c.Clientset.CoreV1().Secrets(metav1.NamespaceAll).List(ctx, listOpts)
+	if err != nil {
+		return nil, err
+	}
+	for _, secret := range secretList.Items {
+		resources = append(resources, InventoryResource{
+			Package:    secret.Labels[cluster.ZarfPackageInfoLabel],
+			APIVersion: "v1",
+			Kind:       "Secret",
+			Namespace:  secret.Namespace,
+			Name:       secret.Name,
+		})
+	}
+	return resources, nil
+}
+
+func compareInventoryResources(a, b InventoryResource) int {
+	return cmp.Or(
+		strings.Compare(a.Package, b.Package),
+		strings.Compare(a.Component, b.Component),
+		strings.Compare(a.Kind, b.Kind),
+		strings.Compare(a.Namespace, b.Namespace),
+		strings.Compare(a.Name, b.Name),
+	)
+}
@@ -0,0 +1,98 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package packager2
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/zarf-dev/zarf/src/pkg/cluster"
+	"github.com/zarf-dev/zarf/src/test/testutil"
+)
+
+func TestManifestResources(t *testing.T) {
+	t.Parallel()
+
+	manifest := `---
+# Source: podinfo/templates/service.yaml
+apiVersion: v1
+kind: Service
+metadata:
+  name: podinfo
+---
+# Source: podinfo/templates/deployment.yaml
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: podinfo
+  namespace: podinfo-system
+---
+# Source: podinfo/templates/clusterrole.yaml
+apiVersion: rbac.authorization.k8s.io/v1
+kind: ClusterRole
+metadata:
+  name: podinfo
+`
+	mapper := meta.NewDefaultRESTMapper(nil)
+	mapper.Add(schema.GroupVersionKind{Version: "v1", Kind: "Service"}, meta.RESTScopeNamespace)
+	mapper.Add(schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"}, meta.RESTScopeNamespace)
+	mapper.Add(schema.GroupVersionKind{Group: "rbac.authorization.k8s.io", Version: "v1", Kind: "ClusterRole"}, meta.RESTScopeRoot)
+
+	resources, err := manifestResources(manifest, "podinfo", mapper)
+	require.NoError(t, err)
+	expected := []InventoryResource{
+		{APIVersion: "rbac.authorization.k8s.io/v1", Kind: "ClusterRole", Name: "podinfo"},
+		{APIVersion: "apps/v1", Kind: "Deployment", Namespace: "podinfo-system", Name: "podinfo"},
+		{APIVersion: "v1", Kind: "Service", Namespace: "podinfo", Name: "podinfo"},
+	}
+	require.Equal(t, expected, resources)
+}
+
+func TestManagedResources(t *testing.T) {
+	t.Parallel()
+	ctx := testutil.TestContext(t)
+
+	managed := map[string]string{cluster.ZarfManagedByLabel: "zarf"}
+	c := &cluster.Cluster{
+		Clientset: fake.NewClientset(
+			&corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:   "podinfo",
+					Labels: managed,
+					Annotations: map[string]string{
+						cluster.ZarfCreatedByPackageAnnotation:   "podinfo",
+						cluster.ZarfCreatedByComponentAnnotation: "podinfo-helm",
+					},
+				},
+			},
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "unmanaged"}},
+			&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "private-registry", Namespace: "podinfo", Labels: managed}},
+			&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "zarf-package-podinfo",
+					Namespace: cluster.ZarfNamespaceName,
+					Labels:    map[string]string{cluster.ZarfManagedByLabel: "zarf", cluster.ZarfPackageInfoLabel: "podinfo"},
+				},
+			},
+			&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "unmanaged", Namespace: "podinfo"}},
+		),
+	}
+
+	resources, err := managedResources(ctx, c)
+	require.NoError(t, err)
+	slices.SortFunc(resources, compareInventoryResources)
+	expected := []InventoryResource{
+		{APIVersion: "v1", Kind: "Secret", Namespace: "podinfo", Name: "private-registry"},
+		{Package: "podinfo", APIVersion: "v1", Kind: "Secret", Namespace: cluster.ZarfNamespaceName, Name: "zarf-package-podinfo"},
+		{Package: "podinfo", Component: "podinfo-helm", APIVersion: "v1", Kind: "Namespace", Name: "podinfo"},
+	}
+	require.Equal(t, expected, resources)
+}
@@ -0,0 +1,608 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package packager2
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	psaapi "k8s.io/pod-security-admission/api"
+	psapolicy "k8s.io/pod-security-admission/policy"
+	"sigs.k8s.io/yaml"
+
+	"github.com/zarf-dev/zarf/src/pkg/logger"
+)
+
+// kyvernoAutogenAnnotation limits the workload kinds the pod rules of a policy are applied to.
+const kyvernoAutogenAnnotation = "pod-policies.kyverno.io/autogen-controllers"
+
+// kyvernoPodSecurityControls are the checks of the Pod Security Standards controls that podSecurity rules can exclude.
+var kyvernoPodSecurityControls = map[string][]psapolicy.CheckID{
+	"HostProcess":              {"windowsHostProcess"},
+	"Host Namespaces":          {"hostNamespaces"},
+	"Privileged Containers":    {"privileged"},
+	"Capabilities":             {"capabilities_baseline", "capabilities_restricted"},
+	"HostPath Volumes":         {"hostPathVolumes"},
+	"Host Ports":               {"hostPorts"},
+	"AppArmor":                 {"appArmorProfile"},
+	"SELinux":                  {"seLinuxOptions"},
+	"/proc Mount Type":         {"procMount"},
+	"Seccomp":                  {"seccompProfile_baseline", "seccompProfile_restricted"},
+	"Sysctls":                  {"sysctls"},
+	"Volume Types":             {"restrictedVolumes"},
+	"Privilege Escalation":     {"allowPrivilegeEscalation"},
+	"Running as Non-root":      {"runAsNonRoot"},
+	"Running as Non-root user": {"runAsUser"},
+}
+
+// kyvernoMessageVariable matches the variables of validation messages that refer to the resource.
+var kyvernoMessageVariable = regexp.MustCompile(`\{\{\s*request\.object\.([\w.]+)\s*\}\}`)
+
+// kyvernoPolicy is a Kyverno ClusterPolicy or Policy. Only the validate rules that can be evaluated without a cluster
+// are read, which are the rules with a pattern, anyPattern or podSecurity.
+type kyvernoPolicy struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Metadata   struct {
+		Name        string            `json:"name"`
+		Namespace   string            `json:"namespace"`
+		Annotations map[string]string `json:"annotations"`
+	} `json:"metadata"`
+	Spec struct {
+		Rules []kyvernoRule `json:"rules"`
+	} `json:"spec"`
+}
+
+type kyvernoRule struct {
+	Name          string           `json:"name"`
+	Match         kyvernoMatch     `json:"match"`
+	Exclude       kyvernoMatch     `json:"exclude"`
+	Preconditions any              `json:"preconditions"`
+	Validate      *kyvernoValidate `json:"validate"`
+
+	// podChecks evaluates podSecurity rules, without the excluded controls.
+	podChecks psapolicy.Evaluator
+	level     psaapi.LevelVersion
+}
+
+type kyvernoMatch struct {
+	Any       []kyvernoFilter   `json:"any"`
+	All       []kyvernoFilter   `json:"all"`
+	Resources *kyvernoResources `json:"resources"`
+}
+
+type kyvernoFilter struct {
+	Resources kyvernoResources `json:"resources"`
+}
+
+type kyvernoResources struct {
+	Kinds      []string              `json:"kinds"`
+	Name       string                `json:"name"`
+	Names      []string              `json:"names"`
+	Namespaces []string              `json:"namespaces"`
+	Selector   *metav1.LabelSelector `json:"selector"`
+}
+
+type kyvernoValidate struct {
+	Message     string              `json:"message"`
+	Pattern     any                 `json:"pattern"`
+	AnyPattern  []any               `json:"anyPattern"`
+	PodSecurity *kyvernoPodSecurity `json:"podSecurity"`
+}
+
+type kyvernoPodSecurity struct {
+	Level   string `json:"level"`
+	Version string `json:"version"`
+	Exclude []struct {
+		ControlName string `json:"controlName"`
+	} `json:"exclude"`
+}
+
+// kyvernoResource is the resource a rule is matched against.
+type kyvernoResource struct {
+	gvk       schema.GroupVersionKind
+	name      string
+	namespace string
+	labels    map[string]string
+}
+
+// parseKyvernoPolicies returns the Kyverno policies of a YAML document stream and whether it contained any.
+func parseKyvernoPolicies(ctx context.Context, docs []string) ([]kyvernoPolicy, bool, error) {
+	policies := []kyvernoPolicy{}
+	for _, doc := range docs {
+		p := kyvernoPolicy{}
+		if err := yaml.Unmarshal([]byte(doc), &p); err != nil {
+			continue
+		}
+		if !strings.HasPrefix(p.APIVersion, "kyverno.io/") || (p.Kind != "ClusterPolicy" && p.Kind != "Policy") {
+			continue
+		}
+		rules := []kyvernoRule{}
+		for _, rule := range p.Spec.Rules {
+			if rule.Validate == nil {
+				continue
+			}
+			if rule.Preconditions != nil || (rule.Validate.Pattern == nil && rule.Validate.AnyPattern == nil && rule.Validate.PodSecurity == nil) {
+				logger.From(ctx).Warn("skipping Kyverno rule that can not be evaluated without a cluster", "policy", p.Metadata.Name, "rule", rule.Name)
+				continue
+			}
+			if rule.Validate.PodSecurity != nil {
+				if err := rule.preparePodSecurity(); err != nil {
+					return nil, true, fmt.Errorf("invalid podSecurity rule %s of Kyverno policy %s: %w", rule.Name, p.Metadata.Name, err)
+				}
+			}
+			rules = append(rules, rule)
+		}
+		p.Spec.Rules = rules
+		policies = append(policies, p)
+	}
+	return policies, len(policies) > 0, nil
+}
+
+func (r *kyvernoRule) preparePodSecurity() error {
+	ps := r.Validate.PodSecurity
+	level, err := psaapi.ParseLevel(ps.Level)
+	if err != nil {
+		return err
+	}
+	version := psaapi.LatestVersion()
+	if ps.Version != "" {
+		version, err = psaapi.ParseVersion(ps.Version)
+		if err != nil {
+			return err
+		}
+	}
+	excluded := []psapolicy.CheckID{}
+	for _, e := range ps.Exclude {
+		ids, ok := kyvernoPodSecurityControls[e.ControlName]
+		if !ok {
+			return fmt.Errorf("unknown control %q", e.ControlName)
+		}
+		excluded = append(excluded, ids...)
+	}
+	checks := []psapolicy.Check{}
+	for _, check := range psapolicy.DefaultChecks() {
+		if !slices.Contains(excluded, check.ID) {
+			checks = append(checks, check)
+		}
+	}
+	r.podChecks, err = psapolicy.NewEvaluator(checks)
+	if err != nil {
+		return err
+	}
+	r.level = psaapi.LevelVersion{Level: level, Version: version}
+	return nil
+}
+
+// evaluate returns the violations of the validate rules of the policy that match the resource. Rules that match pods
+// are also applied to the pod templates of workloads, as Kyverno does with the rules it generates for them.
+func (p kyvernoPolicy) evaluate(obj *unstructured.Unstructured, namespace string) ([]ComplianceViolation, error) {
+	if p.Kind == "Policy" && namespace != p.Metadata.Namespace {
+		return nil, nil
+	}
+	res := kyvernoResource{gvk: obj.GroupVersionKind(), name: obj.GetName(), namespace: namespace, labels: obj.GetLabels()}
+	violations := []ComplianceViolation{}
+	for _, rule := range p.Spec.Rules {
+		target, prefix, ok := p.target(rule, obj, res)
+		if !ok {
+			continue
+		}
+		detail, ok, err := rule.validate(obj, target, prefix)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			continue
+		}
+		violations = append(violations, ComplianceViolation{
+			Resource: fmt.Sprintf("%s/%s", obj.GetKind(), obj.GetName()),
+			Policy:   fmt.Sprintf("kyverno-%s/%s", p.Metadata.Name, rule.Name),
+			Message:  fmt.Sprintf("validation error: %srule %s failed%s", kyvernoMessage(rule.Validate.Message, obj), rule.Name, detail),
+		})
+	}
+	return violations, nil
+}
+
+// target returns the object a rule validates and the path it is found at in the resource.
+func (p kyvernoPolicy) target(rule kyvernoRule, obj *unstructured.Unstructured, res kyvernoResource) (map[string]any, string, bool) {
+	if rule.Match.matches(res) {
+		return obj.Object, "", !rule.Exclude.matches(res)
+	}
+	path, ok := podTemplatePaths[res.gvk.GroupKind()]
+	if !ok || !p.autogen(res.gvk.Kind) {
+		return nil, "", false
+	}
+	pod := res
+	pod.gvk = schema.GroupVersionKind{Version: "v1", Kind: "Pod"}
+	if !rule.Match.matches(pod) || rule.Exclude.matches(pod) {
+		return nil, "", false
+	}
+	template, ok, err := unstructured.NestedMap(obj.Object, path...)
+	if err != nil || !ok {
+		return nil, "", false
+	}
+	target := map[string]any{"apiVersion": "v1", "kind": "Pod", "metadata": template["metadata"], "spec": template["spec"]}
+	return target, "/" + strings.Join(path, "/"), true
+}
+
+// autogen returns whether the pod rules of the policy apply to the workload kind.
+func (p kyvernoPolicy) autogen(kind string) bool {
+	controllers, ok := p.Metadata.Annotations[kyvernoAutogenAnnotation]
+	if !ok {
+		return true
+	}
+	for _, c := range strings.Split(controllers, ",") {
+		if strings.TrimSpace(c) == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// validate returns whether the target passes the rule, and otherwise the detail of the failure that follows the
+// rule in the message.
+func (r kyvernoRule) validate(obj *unstructured.Unstructured, target map[string]any, prefix string) (string, bool, error) {
+	v := r.Validate
+	switch {
+	case v.PodSecurity != nil:
+		template, ok, err := podTemplate(obj)
+		if err != nil {
+			return "", false, fmt.Errorf("unable to read the pod template of %s/%s: %w", obj.GetKind(), obj.GetName(), err)
+		}
+		if !ok {
+			return "", true, nil
+		}
+		reasons := []string{}
+		for _, result := range r.podChecks.EvaluatePod(r.level, &template.ObjectMeta, &template.Spec) {
+			if result.Allowed {
+				continue
+			}
+			reason := result.ForbiddenReason
+			if result.ForbiddenDetail != "" {
+				reason = fmt.Sprintf("%s (%s)", result.ForbiddenReason, result.ForbiddenDetail)
+			}
+			reasons = append(reasons, reason)
+		}
+		if len(reasons) > 0 {
+			return ": " + strings.Join(reasons, ", "), false, nil
+		}
+		return "", true, nil
+	case v.Pattern != nil:
+		if ok, path := matchPattern(target, v.Pattern, ""); !ok {
+			return fmt.Sprintf(" at path %s%s", prefix, path), false, nil
+		}
+		return "", true, nil
+	default:
+		for _, pattern := range v.AnyPattern {
+			if ok, _ := matchPattern(target, pattern, ""); ok {
+				return "", true, nil
+			}
+		}
+		return "", false, nil
+	}
+}
+
+func (m kyvernoMatch) matches(res kyvernoResource) bool {
+	switch {
+	case len(m.Any) > 0:
+		return slices.ContainsFunc(m.Any, func(f kyvernoFilter) bool { return f.Resources.matches(res) })
+	case len(m.All) > 0:
+		return !slices.ContainsFunc(m.All, func(f kyvernoFilter) bool { return !f.Resources.matches(res) })
+	case m.Resources != nil:
+		return m.Resources.matches(res)
+	}
+	return false
+}
+
+func (r kyvernoResources) matches(res kyvernoResource) bool {
+	if len(r.Kinds) > 0 && !slices.ContainsFunc(r.Kinds, func(k string) bool { return matchKind(k, res.gvk) }) {
+		return false
+	}
+	if r.Name != "" && !wildcardMatch(r.Name, res.name) {
+		return false
+	}
+	if len(r.Names) > 0 && !slices.ContainsFunc(r.Names, func(n string) bool { return wildcardMatch(n, res.name) }) {
+		return false
+	}
+	if len(r.Namespaces) > 0 && !slices.ContainsFunc(r.Namespaces, func(n string) bool { return wildcardMatch(n, res.namespace) }) {
+		return false
+	}
+	if r.Selector != nil {
+		selector, err := metav1.LabelSelectorAsSelector(r.Selector)
+		if err != nil || !selector.Matches(labels.Set(res.labels)) {
+			return false
+		}
+	}
+	return true
+}
+
+// matchKind matches a kind of a Kyverno match, which is either Kind, version/Kind or group/version/Kind.
+func matchKind(pattern string, gvk schema.GroupVersionKind) bool {
+	parts := strings.Split(pattern, "/")
+	if !wildcardMatch(parts[len(parts)-1], gvk.Kind) {
+		return false
+	}
+	switch len(parts) {
+	case 2:
+		return wildcardMatch(parts[0], gvk.Version)
+	case 3:
+		return wildcardMatch(parts[0], gvk.Group) && wildcardMatch(parts[1], gvk.Version)
+	}
+	return true
+}
+
+// matchPattern checks a value against a Kyverno validation pattern. It returns the path of the first mismatch.
+func matchPattern(value, pattern any, path string) (bool, string) {
+	ok, failure, _ := matchPatternValue(value, pattern, path)
+	return ok, failure
+}
+
+// matchPatternValue returns whether the value matches and the path of the mismatch. A conditional anchor that does
+// not match skips the pattern, which matches and is reported up to the closest list element.
+func matchPatternValue(value, pattern any, path string) (bool, string, bool) {
+	switch p := pattern.(type) {
+	case map[string]any:
+		return matchPatternMap(value, p, path)
+	case []any:
+		return matchPatternList(value, p, path)
+	default:
+		return matchScalar(value, p), path, false
+	}
+}
+
+func matchPatternMap(value any, pattern map[string]any, path string) (bool, string, bool) {
+	m, ok := value.(map[string]any)
+	if !ok {
+		return false, path, false
+	}
+	keys := make([]string, 0, len(pattern))
+	for k := range pattern {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	// Conditional anchors are checked first, the pattern only applies when all of them match.
+	for _, k := range keys {
+		anchor, key := parseAnchor(k)
+		if anchor != "(" {
+			continue
+		}
+		v, exists := m[key]
+		if !exists {
+			return true, "", true
+		}
+		if ok, _, skipped := matchPatternValue(v, pattern[k], path+"/"+key); !ok || skipped {
+			return true, "", true
+		}
+	}
+	for _, k := range keys {
+		anchor, key := parseAnchor(k)
+		v, exists := m[key]
+		keyPath := path + "/" + key
+		switch anchor {
+		case "(":
+			continue
+		case "X(":
+			if exists {
+				return false, keyPath, false
+			}
+			continue
+		case "=(", "+(":
+			if !exists {
+				continue
+			}
+		case "^(":
+			list, ok := v.([]any)
+			patterns, isList := pattern[k].([]any)
+			if !ok || !isList || len(patterns) == 0 {
+				return false, keyPath, false
+			}
+			found := slices.ContainsFunc(list, func(el any) bool {
+				ok, _, skipped := matchPatternValue(el, patterns[0], keyPath)
+				return ok && !skipped
+			})
+			if !found {
+				return false, keyPath, false
+			}
+			continue
+		default:
+			if !exists {
+				return false, keyPath, false
+			}
+		}
+		ok, failure, skipped := matchPatternValue(v, pattern[k], keyPath)
+		if !ok {
+			return false, failure, false
+		}
+		if skipped {
+			return true, "", true
+		}
+	}
+	return true, "", false
+}
+
+func matchPatternList(value any, pattern []any, path string) (bool, string, bool) {
+	list, ok := value.([]any)
+	if !ok {
+		return false, path, false
+	}
+	if len(pattern) == 0 {
+		return true, "", false
+	}
+	for i, el := range list {
+		elPath := fmt.Sprintf("%s/%d", path, i)
+		switch pattern[0].(type) {
+		case map[string]any, []any:
+			// A skipped element matches, the other elements are still checked.
+			if ok, failure, _ := matchPatternValue(el, pattern[0], elPath); !ok {
+				return false, failure, false
+			}
+		default:
+			if !slices.ContainsFunc(pattern, func(p any) bool { return matchScalar(el, p) }) {
+				return false, elPath, false
+			}
+		}
+	}
+	return true, "", false
+}
+
+// parseAnchor splits a pattern key into its anchor and the key of the resource.
+func parseAnchor(k string) (string, string) {
+	for _, anchor := range []string{"X(", "=(", "^(", "+(", "("} {
+		if strings.HasPrefix(k, anchor) && strings.HasSuffix(k, ")") {
+			return anchor, k[len(anchor) : len(k)-1]
+		}
+	}
+	return "", k
+}
+
+// matchScalar matches a value against a scalar pattern. String patterns support wildcards, the !, >, >=, < and <=
+// operators and alternatives separated by |.
+func matchScalar(value, pattern any) bool {
+	switch p := pattern.(type) {
+	case nil:
+		return value == nil
+	case bool:
+		v, ok := value.(bool)
+		return ok && v == p
+	case string:
+		for _, alt := range strings.Split(p, "|") {
+			if matchString(value, strings.TrimSpace(alt)) {
+				return true
+			}
+		}
+		return false
+	default:
+		pf, ok := toFloat(p)
+		if !ok {
+			return false
+		}
+		vf, ok := toFloat(value)
+		return ok && vf == pf
+	}
+}
+
+func matchString(value any, pattern string) bool {
+	if value == nil {
+		return false
+	}
+	if rest, ok := strings.CutPrefix(pattern, "!"); ok {
+		return !matchString(value, rest)
+	}
+	for _, op := range []string{">=", "<=", ">", "<"} {
+		operand, ok := strings.CutPrefix(pattern, op)
+		if !ok {
+			continue
+		}
+		vf, ok := parseNumeric(value)
+		if !ok {
+			return false
+		}
+		of, ok := parseNumeric(operand)
+		if !ok {
+			return false
+		}
+		switch op {
+		case ">=":
+			return vf >= of
+		case "<=":
+			return vf <= of
+		case ">":
+			return vf > of
+		default:
+			return vf < of
+		}
+	}
+	switch v := value.(type) {
+	case map[string]any, []any:
+		return pattern == "*" || pattern == "?*"
+	case string:
+		return wildcardMatch(pattern, v)
+	case bool:
+		return wildcardMatch(pattern, strconv.FormatBool(v))
+	default:
+		f, ok := toFloat(v)
+		if !ok {
+			return false
+		}
+		if pf, err := strconv.ParseFloat(pattern, 64); err == nil {
+			return pf == f
+		}
+		return wildcardMatch(pattern, strconv.FormatFloat(f, 'f', -1, 64))
+	}
+}
+
+// parseNumeric reads a number, a quantity such as 512Mi or a duration such as 10s.
+func parseNumeric(value any) (float64, bool) {
+	if f, ok := toFloat(value); ok {
+		return f, true
+	}
+	s, ok := value.(string)
+	if !ok {
+		return 0, false
+	}
+	if f, err := strconv.ParseFloat(s, 64); err == nil {
+		return f, true
+	}
+	if q, err := resource.ParseQuantity(s); err == nil {
+		return q.AsApproximateFloat64(), true
+	}
+	if d, err := time.ParseDuration(s); err == nil {
+		return d.Seconds(), true
+	}
+	return 0, false
+}
+
+func toFloat(value any) (float64, bool) {
+	switch v := value.(type) {
+	case int:
+		return float64(v), true
+	case int32:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case float64:
+		return v, !math.IsNaN(v)
+	}
+	return 0, false
+}
+
+// wildcardMatch matches a string against a pattern where * matches any characters and ? a single character.
+func wildcardMatch(pattern, s string) bool {
+	if !strings.ContainsAny(pattern, "*?") {
+		return pattern == s
+	}
+	expr := regexp.QuoteMeta(pattern)
+	expr = strings.ReplaceAll(expr, `\*`, ".*")
+	expr = strings.ReplaceAll(expr, `\?`, ".")
+	return regexp.MustCompile("^" + expr + "$").MatchString(s)
+}
+
+// kyvernoMessage replaces the variables of a validation message that refer to fields of the resource and ends it
+// with a period, to be followed by the rule that failed.
+func kyvernoMessage(message string, obj *unstructured.Unstructured) string {
+	if message == "" {
+		return ""
+	}
+	message = kyvernoMessageVariable.ReplaceAllStringFunc(message, func(v string) string {
+		path := kyvernoMessageVariable.FindStringSubmatch(v)[1]
+		value, ok, err := unstructured.NestedFieldNoCopy(obj.Object, strings.Split(path, ".")...)
+		if err != nil || !ok {
+			return v
+		}
+		return fmt.Sprint(value)
+	})
+	return strings.TrimSuffix(message, ".") + ". "
+}
@@ -0,0 +1,59 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package layout
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/zarf-dev/zarf/src/api/v1alpha1"
+	"github.com/zarf-dev/zarf/src/config"
+	"github.com/zarf-dev/zarf/src/internal/artifact"
+	"github.com/zarf-dev/zarf/src/pkg/logger"
+	"github.com/zarf-dev/zarf/src/pkg/transform"
+)
+
+// artifactRegistryOptions returns the options artifacts are pulled with when a package is created.
+func artifactRegistryOptions() artifact.RegistryOptions {
+	return artifact.RegistryOptions{
+		PlainHTTP:             config.CommonOptions.PlainHTTP,
+		InsecureSkipTLSVerify: config.CommonOptions.InsecureSkipTLSVerify,
+	}
+}
+
+// pinArtifacts resolves the artifacts of the components and pins their references to the digest of the manifest that
+// is pulled, so that the package records exactly which artifact it contains.
+func pinArtifacts(ctx context.Context, pkg v1alpha1.ZarfPackage) (v1alpha1.ZarfPackage, error) {
+	l := logger.From(ctx)
+	for i, component := range pkg.Components {
+		for j, a := range component.Artifacts {
+			desc, err := artifact.Resolve(ctx, a.Reference, a.ArtifactType, artifactRegistryOptions())
+			if err != nil {
+				return v1alpha1.ZarfPackage{}, fmt.Errorf("component %s: %w", component.Name, err)
+			}
+			pinned, err := pinnedReference(a.Reference, desc.Digest.String())
+			if err != nil {
+				return v1alpha1.ZarfPackage{}, err
+			}
+			l.Debug("pinned artifact", "component", component.Name, "reference", a.Reference, "pinned", pinned)
+			pkg.Components[i].Artifacts[j].Reference = pinned
+		}
+	}
+	return pkg, nil
+}
+
+// pinnedReference returns the reference with its digest set to digest, keeping its tag.
+func pinnedReference(ref, digest string) (string, error) {
+	refInfo, err := transform.ParseImageRef(ref)
+	if err != nil {
+		return "", fmt.Errorf("invalid artifact reference %s: %w", ref, err)
+	}
+	if refInfo.Digest == digest {
+		return ref, nil
+	}
+	if refInfo.Tag == "" {
+		return fmt.Sprintf("%s@%s", refInfo.Name, digest), nil
+	}
+	return fmt.Sprintf("%s:%s@%s", refInfo.Name, refInfo.Tag, digest), nil
+}
@@ -0,0 +1,73 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package layout
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+
+	"github.com/defenseunicorns/pkg/helpers/v2"
+
+	"github.com/zarf-dev/zarf/src/api/v1alpha1"
+	"github.com/zarf-dev/zarf/src/internal/packager/images"
+	"github.com/zarf-dev/zarf/src/pkg/logger"
+	"github.com/zarf-dev/zarf/src/pkg/utils"
+)
+
+// estimateBuildSize returns the number of bytes the package is expected to take up in the build directory. The
+// estimate covers the local files the components are assembled from and the images as reported by their registries.
+// Content that is only known once it is downloaded, such as git repositories and remote files, is not included.
+func estimateBuildSize(ctx context.Context, pkg v1alpha1.ZarfPackage, packagePath string, pullCfg images.PullConfig) (int64, error) {
+	var size int64
+	for _, component := range pkg.Components {
+		localPaths, _ := componentInputs(component)
+		for _, path := range localPaths {
+			if !filepath.IsAbs(path) {
+				path = filepath.Join(packagePath, path)
+			}
+			pathSize, err := helpers.GetDirSize(path)
+			// Missing files are reported when the component is assembled.
+			if errors.Is(err, fs.ErrNotExist) {
+				continue
+			}
+			if err != nil {
+				return 0, err
+			}
+			size += pathSize
+		}
+	}
+	if len(pullCfg.ImageList) > 0 {
+		imagesSize, err := images.Size(ctx, pullCfg)
+		if err != nil {
+			return 0, err
+		}
+		size += imagesSize
+	}
+	return size, nil
+}
+
+// checkBuildSpace fails when the estimated size of the package exceeds the temporary space budget or the space that is
+// available in the build directory.
+func checkBuildSpace(ctx context.Context, buildPath string, estimate int64, maxTempSpaceMB int) error {
+	l := logger.From(ctx)
+	l.Debug("estimated the temporary space needed to create the package", "size", utils.ByteFormat(float64(estimate), 2))
+
+	if maxTempSpaceMB > 0 && estimate > int64(maxTempSpaceMB)*1000*1000 {
+		return fmt.Errorf("the package is estimated to need %s of temporary space which exceeds the budget of %dMB",
+			utils.ByteFormat(float64(estimate), 2), maxTempSpaceMB)
+	}
+	free, err := utils.FreeDiskSpace(buildPath)
+	if err != nil {
+		l.Warn("unable to check the space available for creating the package", "path", buildPath, "error", err)
+		return nil
+	}
+	if uint64(estimate) > free {
+		return fmt.Errorf("the package is estimated to need %s of temporary space but only %s is available in %s, free up space or use --tmpdir to create the package on a larger filesystem",
+			utils.ByteFormat(float64(estimate), 2), utils.ByteFormat(float64(free), 2), filepath.Dir(buildPath))
+	}
+	return nil
+}
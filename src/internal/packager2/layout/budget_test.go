@@ -0,0 +1,93 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package layout
+
+import (
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/zarf-dev/zarf/src/api/v1alpha1"
+	"github.com/zarf-dev/zarf/src/internal/packager/images"
+	"github.com/zarf-dev/zarf/src/test/testutil"
+)
+
+func TestEstimateBuildSize(t *testing.T) {
+	t.Parallel()
+	ctx := testutil.TestContext(t)
+
+	packagePath := t.TempDir()
+	err := os.WriteFile(filepath.Join(packagePath, "file.txt"), make([]byte, 100), 0o600)
+	require.NoError(t, err)
+	err = os.MkdirAll(filepath.Join(packagePath, "chart", "templates"), 0o700)
+	require.NoError(t, err)
+	err = os.WriteFile(filepath.Join(packagePath, "chart", "Chart.yaml"), make([]byte, 20), 0o600)
+	require.NoError(t, err)
+	err = os.WriteFile(filepath.Join(packagePath, "chart", "templates", "pod.yaml"), make([]byte, 30), 0o600)
+	require.NoError(t, err)
+
+	pkg := v1alpha1.ZarfPackage{
+		Components: []v1alpha1.ZarfComponent{
+			{
+				Name:  "files",
+				Files: []v1alpha1.ZarfFile{{Source: "file.txt"}, {Source: "https://example.com/file.txt"}},
+			},
+			{
+				Name:      "charts",
+				Charts:    []v1alpha1.ZarfChart{{Name: "chart", LocalPath: "chart"}},
+				Manifests: []v1alpha1.ZarfManifest{{Name: "missing", Files: []string{"missing.yaml"}}},
+			},
+		},
+	}
+	size, err := estimateBuildSize(ctx, pkg, packagePath, images.PullConfig{})
+	require.NoError(t, err)
+	require.Equal(t, int64(150), size)
+}
+
+func TestCheckBuildSpace(t *testing.T) {
+	t.Parallel()
+	ctx := testutil.TestContext(t)
+
+	tests := []struct {
+		name           string
+		estimate       int64
+		maxTempSpaceMB int
+		expectedErr    string
+	}{
+		{
+			name:     "fits",
+			estimate: 1000,
+		},
+		{
+			name:           "within budget",
+			estimate:       1000 * 1000,
+			maxTempSpaceMB: 1,
+		},
+		{
+			name:           "exceeds budget",
+			estimate:       2 * 1000 * 1000,
+			maxTempSpaceMB: 1,
+			expectedErr:    "the package is estimated to need 2.00 MBs of temporary space which exceeds the budget of 1MB",
+		},
+		{
+			name:        "exceeds free space",
+			estimate:    math.MaxInt64,
+			expectedErr: "of temporary space but only",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := checkBuildSpace(ctx, t.TempDir(), tt.estimate, tt.maxTempSpaceMB)
+			if tt.expectedErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorContains(t, err, tt.expectedErr)
+		})
+	}
+}
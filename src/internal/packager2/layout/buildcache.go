@@ -0,0 +1,358 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package layout
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/defenseunicorns/pkg/helpers/v2"
+	"sigs.k8s.io/yaml"
+
+	"github.com/zarf-dev/zarf/src/api/v1alpha1"
+	"github.com/zarf-dev/zarf/src/config"
+	"github.com/zarf-dev/zarf/src/internal/remotecache"
+	"github.com/zarf-dev/zarf/src/pkg/logger"
+)
+
+// BuildCacheDir is the directory of the Zarf cache assembled components are stored in.
+const BuildCacheDir = "components"
+
+// componentCacheKey returns the key of the assembled component in the build cache. The key covers the component
+// definition and the content of every local file it is assembled from. Components that run create actions or pull
+// content that is not pinned, such as git repositories, remote files without a shasum, remote values files or remote
+// kustomize bases, can not be cached.
+func componentCacheKey(component v1alpha1.ZarfComponent, packagePath, arch string) (string, bool, error) {
+	if len(component.Actions.OnCreate.Before) > 0 || len(component.Actions.OnCreate.After) > 0 || len(component.Repos) > 0 {
+		return "", false, nil
+	}
+	localPaths, pinned := componentInputs(component)
+	if !pinned {
+		return "", false, nil
+	}
+	for _, manifest := range component.Manifests {
+		for _, kustomization := range manifest.Kustomizations {
+			if helpers.IsURL(kustomization) {
+				continue
+			}
+			path := kustomization
+			if !filepath.IsAbs(path) {
+				path = filepath.Join(packagePath, path)
+			}
+			remote, err := hasRemoteKustomizeBase(path, map[string]bool{})
+			if err != nil {
+				return "", false, err
+			}
+			if remote {
+				return "", false, nil
+			}
+		}
+	}
+
+	// Inputs are keyed by their path in the definition so that the key does not depend on where the package is.
+	inputs := map[string]string{}
+	for _, path := range localPaths {
+		absPath := path
+		if !filepath.IsAbs(path) {
+			absPath = filepath.Join(packagePath, path)
+		}
+		sum, err := hashPath(absPath)
+		if err != nil {
+			return "", false, err
+		}
+		inputs[path] = sum
+	}
+
+	b, err := json.Marshal(struct {
+		Version      string                 `json:"version"`
+		Architecture string                 `json:"architecture"`
+		Component    v1alpha1.ZarfComponent `json:"component"`
+		Inputs       map[string]string      `json:"inputs"`
+	}{
+		Version:      config.CLIVersion,
+		Architecture: arch,
+		Component:    component,
+		Inputs:       inputs,
+	})
+	if err != nil {
+		return "", false, err
+	}
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:]), true, nil
+}
+
+// componentInputs returns the local paths the component is assembled from and whether all of its remote content is
+// pinned to a version or shasum.
+func componentInputs(component v1alpha1.ZarfComponent) ([]string, bool) {
+	localPaths := []string{}
+	pinned := true
+	for _, chart := range component.Charts {
+		if chart.LocalPath == "" && chart.Version == "" {
+			pinned = false
+		}
+		if chart.LocalPath != "" {
+			localPaths = append(localPaths, chart.LocalPath)
+		}
+		for _, valuesFile := range chart.ValuesFiles {
+			// Values files can be downloaded when the chart is packaged, their content is not known up front.
+			if helpers.IsURL(valuesFile) {
+				pinned = false
+				continue
+			}
+			localPaths = append(localPaths, valuesFile)
+		}
+	}
+	for _, file := range component.Files {
+		if helpers.IsURL(file.Source) {
+			if file.Shasum == "" {
+				pinned = false
+			}
+			continue
+		}
+		localPaths = append(localPaths, file.Source)
+	}
+	for _, hostArtifact := range component.HostArtifacts {
+		for _, src := range hostArtifact.Sources {
+			if helpers.IsURL(src.Source) {
+				if src.Shasum == "" {
+					pinned = false
+				}
+				continue
+			}
+			localPaths = append(localPaths, src.Source)
+		}
+	}
+	for _, hostService := range component.HostServices {
+		if helpers.IsURL(hostService.Source) {
+			if hostService.Shasum == "" {
+				pinned = false
+			}
+			continue
+		}
+		localPaths = append(localPaths, hostService.Source)
+	}
+	for _, module := range component.Tofu {
+		// Remote modules and providers are resolved when the module is initialized.
+		pinned = false
+		localPaths = append(localPaths, module.Source)
+	}
+	for _, data := range component.DataInjections {
+		if helpers.IsURL(data.Source) {
+			pinned = false
+			continue
+		}
+		localPaths = append(localPaths, data.Source)
+	}
+	for _, a := range component.Artifacts {
+		// Tags can be moved to other artifacts, digests can not.
+		if !strings.Contains(a.Reference, "@") {
+			pinned = false
+		}
+	}
+	for _, manifest := range component.Manifests {
+		// Kustomizations that may reach outside of their directory depend on files that are not known up front.
+		if manifest.KustomizeAllowAnyDirectory {
+			pinned = false
+		}
+		for _, path := range slices.Concat(manifest.Files, manifest.Kustomizations) {
+			if helpers.IsURL(path) {
+				pinned = false
+				continue
+			}
+			localPaths = append(localPaths, path)
+		}
+	}
+	return localPaths, pinned
+}
+
+// kustomizationFileNames are the names kustomize looks for in a kustomization directory.
+var kustomizationFileNames = []string{"kustomization.yaml", "kustomization.yml", "Kustomization"}
+
+// hasRemoteKustomizeBase returns true if the kustomization in dir, or any local kustomization it includes, refers to
+// resources that kustomize fetches remotely. Like kustomize, entries that are not local files or directories are
+// treated as remote.
+func hasRemoteKustomizeBase(dir string, seen map[string]bool) (bool, error) {
+	if seen[dir] {
+		return false, nil
+	}
+	seen[dir] = true
+	var b []byte
+	for _, name := range kustomizationFileNames {
+		var err error
+		b, err = os.ReadFile(filepath.Join(dir, name))
+		if err == nil {
+			break
+		}
+		if !errors.Is(err, fs.ErrNotExist) {
+			return false, err
+		}
+	}
+	// Missing kustomizations are reported when the component is assembled.
+	if b == nil {
+		return false, nil
+	}
+	kustomization := struct {
+		Resources  []string `json:"resources"`
+		Bases      []string `json:"bases"`
+		Components []string `json:"components"`
+	}{}
+	if err := yaml.Unmarshal(b, &kustomization); err != nil {
+		return false, fmt.Errorf("unable to parse the kustomization in %s: %w", dir, err)
+	}
+	for _, entry := range slices.Concat(kustomization.Resources, kustomization.Bases, kustomization.Components) {
+		if helpers.IsURL(entry) {
+			return true, nil
+		}
+		path := entry
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(dir, path)
+		}
+		info, err := os.Stat(path)
+		if errors.Is(err, fs.ErrNotExist) {
+			return true, nil
+		}
+		if err != nil {
+			return false, err
+		}
+		if !info.IsDir() {
+			continue
+		}
+		remote, err := hasRemoteKustomizeBase(path, seen)
+		if err != nil {
+			return false, err
+		}
+		if remote {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// hashPath returns the SHA256 of the file, or of the paths, modes and contents of every file in the directory.
+func hashPath(path string) (string, error) {
+	h := sha256.New()
+	err := filepath.WalkDir(path, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(path, p)
+		if err != nil {
+			return err
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(h, "%s %s\n", filepath.ToSlash(rel), info.Mode())
+		switch {
+		case d.Type()&fs.ModeSymlink != 0:
+			target, err := os.Readlink(p)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintln(h, target)
+		case d.Type().IsRegular():
+			f, err := os.Open(p)
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+			_, err = io.Copy(h, f)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// buildCache stores assembled components in the Zarf cache and, when configured, a remote cache.
+type buildCache struct {
+	dir    string
+	remote remotecache.Store
+}
+
+// remoteComponentKey returns the key of the component in the remote cache.
+func remoteComponentKey(key string) string {
+	return "component-" + key
+}
+
+// restore copies the assembled component with the given key from the build cache to tarPath and reports whether it
+// was cached.
+func (bc *buildCache) restore(ctx context.Context, key, tarPath string) (bool, error) {
+	cachedPath := filepath.Join(bc.dir, key+".tar")
+	if helpers.InvalidPath(cachedPath) {
+		if bc.remote == nil {
+			return false, nil
+		}
+		err := bc.remote.Get(ctx, remoteComponentKey(key), cachedPath)
+		if errors.Is(err, remotecache.ErrNotFound) {
+			return false, nil
+		}
+		if err != nil {
+			logger.From(ctx).Warn("unable to read component from the remote cache", "key", key, "error", err)
+			return false, nil
+		}
+	}
+	err := helpers.CreatePathAndCopy(cachedPath, tarPath)
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// store stores the assembled component at tarPath in the build cache. The component is copied to a temporary file
+// that is renamed into place so that parallel builds never read a partially written component.
+func (bc *buildCache) store(ctx context.Context, key, tarPath string) (err error) {
+	err = helpers.CreateDirectory(bc.dir, helpers.ReadWriteExecuteUser)
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(bc.dir, key+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			err = errors.Join(err, os.Remove(tmp.Name()))
+		}
+	}()
+	src, err := os.Open(tarPath)
+	if err != nil {
+		return errors.Join(err, tmp.Close())
+	}
+	defer src.Close()
+	_, err = io.Copy(tmp, src)
+	if err != nil {
+		return errors.Join(err, tmp.Close())
+	}
+	err = tmp.Close()
+	if err != nil {
+		return err
+	}
+	cachedPath := filepath.Join(bc.dir, key+".tar")
+	err = os.Rename(tmp.Name(), cachedPath)
+	if err != nil {
+		return err
+	}
+	logger.From(ctx).Debug("stored component in the build cache", "key", key)
+	if bc.remote != nil {
+		return bc.remote.Put(ctx, remoteComponentKey(key), cachedPath)
+	}
+	return nil
+}
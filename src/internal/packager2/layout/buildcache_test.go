@@ -0,0 +1,198 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package layout
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/zarf-dev/zarf/src/api/v1alpha1"
+	"github.com/zarf-dev/zarf/src/internal/remotecache"
+	"github.com/zarf-dev/zarf/src/test/testutil"
+)
+
+func TestComponentCacheKey(t *testing.T) {
+	t.Parallel()
+
+	writeFiles := func(t *testing.T, files map[string]string) string {
+		t.Helper()
+		dir := t.TempDir()
+		for name, content := range files {
+			err := os.MkdirAll(filepath.Join(dir, filepath.Dir(name)), 0o700)
+			require.NoError(t, err)
+			err = os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600)
+			require.NoError(t, err)
+		}
+		return dir
+	}
+	component := v1alpha1.ZarfComponent{
+		Name:          "test",
+		Files:         []v1alpha1.ZarfFile{{Source: "file.txt", Target: "/tmp/file.txt"}},
+		Charts:        []v1alpha1.ZarfChart{{Name: "chart", LocalPath: "chart"}},
+		HostArtifacts: []v1alpha1.ZarfHostArtifact{{Name: "tool", Sources: []v1alpha1.ZarfHostArtifactSource{{Source: "tool"}}}},
+	}
+	files := map[string]string{"file.txt": "hello", "chart/Chart.yaml": "name: chart", "tool": "tool"}
+
+	key, ok, err := componentCacheKey(component, writeFiles(t, files), "amd64")
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	// Moving the package does not change the key.
+	movedKey, _, err := componentCacheKey(component, writeFiles(t, files), "amd64")
+	require.NoError(t, err)
+	require.Equal(t, key, movedKey)
+
+	changed := map[string]map[string]string{
+		"file content":          {"file.txt": "world", "chart/Chart.yaml": "name: chart", "tool": "tool"},
+		"chart content":         {"file.txt": "hello", "chart/Chart.yaml": "name: chart", "chart/values.yaml": "a: b", "tool": "tool"},
+		"host artifact content": {"file.txt": "hello", "chart/Chart.yaml": "name: chart", "tool": "tool v2"},
+	}
+	for name, files := range changed {
+		changedKey, _, err := componentCacheKey(component, writeFiles(t, files), "amd64")
+		require.NoError(t, err)
+		require.NotEqual(t, key, changedKey, name)
+	}
+	archKey, _, err := componentCacheKey(component, writeFiles(t, files), "arm64")
+	require.NoError(t, err)
+	require.NotEqual(t, key, archKey)
+
+	uncacheable := []v1alpha1.ZarfComponent{
+		{Name: "repo", Repos: []string{"https://github.com/zarf-dev/zarf.git"}},
+		{Name: "action", Actions: v1alpha1.ZarfComponentActions{OnCreate: v1alpha1.ZarfComponentActionSet{Before: []v1alpha1.ZarfComponentAction{{Cmd: "date"}}}}},
+		{Name: "remote-file", Files: []v1alpha1.ZarfFile{{Source: "https://example.com/file.txt", Target: "/tmp/file.txt"}}},
+		{Name: "unpinned-chart", Charts: []v1alpha1.ZarfChart{{Name: "chart", URL: "oci://ghcr.io/stefanprodan/charts/podinfo"}}},
+		{Name: "remote-manifest", Manifests: []v1alpha1.ZarfManifest{{Name: "manifest", Files: []string{"https://example.com/manifest.yaml"}}}},
+		{Name: "tagged-artifact", Artifacts: []v1alpha1.ZarfArtifact{{Reference: "ghcr.io/example/modules/filter:1.0.0"}}},
+		{Name: "tofu", Tofu: []v1alpha1.ZarfTofuModule{{Name: "network", Source: "network"}}},
+		{Name: "remote-host-artifact", HostArtifacts: []v1alpha1.ZarfHostArtifact{{Name: "tool", Sources: []v1alpha1.ZarfHostArtifactSource{{Source: "https://example.com/tool"}}}}},
+		{Name: "remote-values-file", Charts: []v1alpha1.ZarfChart{{Name: "chart", URL: "oci://ghcr.io/stefanprodan/charts/podinfo", Version: "6.4.0", ValuesFiles: []string{"https://example.com/values.yaml"}}}},
+	}
+	for _, component := range uncacheable {
+		_, ok, err := componentCacheKey(component, t.TempDir(), "amd64")
+		require.NoError(t, err)
+		require.False(t, ok, component.Name)
+	}
+
+	pinned := v1alpha1.ZarfComponent{
+		Name:   "pinned",
+		Files:  []v1alpha1.ZarfFile{{Source: "https://example.com/file.txt", Shasum: "abc", Target: "/tmp/file.txt"}},
+		Charts: []v1alpha1.ZarfChart{{Name: "chart", URL: "oci://ghcr.io/stefanprodan/charts/podinfo", Version: "6.4.0"}},
+		Artifacts: []v1alpha1.ZarfArtifact{
+			{Reference: "ghcr.io/example/modules/filter:1.0.0@sha256:3e1d9e9ae4df4a1ec3bb8bd9de7d3fdc05bcbfb7b1b6e04e20e41a4a5b0b8c9c"},
+		},
+	}
+	_, ok, err = componentCacheKey(pinned, t.TempDir(), "amd64")
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	kustomize := v1alpha1.ZarfComponent{
+		Name:      "kustomize",
+		Manifests: []v1alpha1.ZarfManifest{{Name: "manifest", Kustomizations: []string{"overlay"}}},
+	}
+	localBase := map[string]string{
+		"overlay/kustomization.yaml": "resources:\n- ../base\n- configmap.yaml\n",
+		"overlay/configmap.yaml":     "kind: ConfigMap",
+		"base/kustomization.yaml":    "resources:\n- deployment.yaml\n",
+		"base/deployment.yaml":       "kind: Deployment",
+	}
+	_, ok, err = componentCacheKey(kustomize, writeFiles(t, localBase), "amd64")
+	require.NoError(t, err)
+	require.True(t, ok)
+	remoteBases := map[string]map[string]string{
+		"remote overlay": {
+			"overlay/kustomization.yaml": "resources:\n- github.com/stefanprodan/podinfo//kustomize?ref=6.4.0\n",
+		},
+		"remote nested base": {
+			"overlay/kustomization.yaml": "resources:\n- ../base\n",
+			"base/kustomization.yaml":    "resources:\n- https://raw.githubusercontent.com/stefanprodan/podinfo/6.4.0/kustomize/deployment.yaml\n",
+		},
+	}
+	for name, files := range remoteBases {
+		_, ok, err := componentCacheKey(kustomize, writeFiles(t, files), "amd64")
+		require.NoError(t, err)
+		require.False(t, ok, name)
+	}
+}
+
+func TestAssemblePackageComponentBuildCache(t *testing.T) {
+	t.Parallel()
+	ctx := testutil.TestContext(t)
+
+	packagePath := t.TempDir()
+	cacheDir := t.TempDir()
+	err := os.WriteFile(filepath.Join(packagePath, "file.txt"), []byte("hello"), 0o600)
+	require.NoError(t, err)
+	component := v1alpha1.ZarfComponent{
+		Name:  "test",
+		Files: []v1alpha1.ZarfFile{{Source: "file.txt", Target: "/tmp/file.txt"}},
+	}
+
+	assemble := func() []byte {
+		buildPath := t.TempDir()
+		err := assemblePackageComponent(ctx, component, packagePath, buildPath, "amd64", false, &buildCache{dir: cacheDir})
+		require.NoError(t, err)
+		b, err := os.ReadFile(filepath.Join(buildPath, "components", "test.tar"))
+		require.NoError(t, err)
+		return b
+	}
+	cacheEntries := func() int {
+		entries, err := os.ReadDir(cacheDir)
+		require.NoError(t, err)
+		return len(entries)
+	}
+
+	built := assemble()
+	require.Equal(t, 1, cacheEntries())
+	require.Equal(t, built, assemble())
+	require.Equal(t, 1, cacheEntries())
+
+	err = os.WriteFile(filepath.Join(packagePath, "file.txt"), []byte("world"), 0o600)
+	require.NoError(t, err)
+	require.NotEqual(t, built, assemble())
+	require.Equal(t, 2, cacheEntries())
+
+	// A fresh cache directory restores the component from the remote cache.
+	remote := &memoryStore{entries: map[string][]byte{}}
+	bc := &buildCache{dir: t.TempDir(), remote: remote}
+	buildPath := t.TempDir()
+	err = assemblePackageComponent(ctx, component, packagePath, buildPath, "amd64", false, bc)
+	require.NoError(t, err)
+	require.Len(t, remote.entries, 1)
+	for key := range remote.entries {
+		remote.entries[key] = []byte("from the remote cache")
+	}
+	bc.dir = t.TempDir()
+	buildPath = t.TempDir()
+	err = assemblePackageComponent(ctx, component, packagePath, buildPath, "amd64", false, bc)
+	require.NoError(t, err)
+	b, err := os.ReadFile(filepath.Join(buildPath, "components", "test.tar"))
+	require.NoError(t, err)
+	require.Equal(t, "from the remote cache", string(b))
+}
+
+// memoryStore is a remote cache that keeps entries in memory.
+type memoryStore struct {
+	entries map[string][]byte
+}
+
+func (s *memoryStore) Get(_ context.Context, key, path string) error {
+	b, ok := s.entries[key]
+	if !ok {
+		return remotecache.ErrNotFound
+	}
+	return os.WriteFile(path, b, 0o600)
+}
+
+func (s *memoryStore) Put(_ context.Context, key, path string) error {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	s.entries[key] = b
+	return nil
+}
@@ -0,0 +1,211 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package layout
+
+import (
+	"cmp"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+
+	"github.com/defenseunicorns/pkg/helpers/v2"
+
+	"github.com/zarf-dev/zarf/src/api/v1alpha1"
+	"github.com/zarf-dev/zarf/src/config"
+)
+
+// BuildInfo describes how a package was created: the Zarf binary and host that created it, the options it was created
+// with and the digests of the local files it was created from.
+type BuildInfo struct {
+	// ZarfVersion is the version of Zarf that created the package.
+	ZarfVersion string `json:"zarfVersion"`
+	// GoVersion is the version of Go the Zarf binary was built with.
+	GoVersion string `json:"goVersion"`
+	// OS is the operating system of the host that created the package, left out of reproducible builds.
+	OS string `json:"os,omitempty"`
+	// Arch is the architecture of the host that created the package, left out of reproducible builds.
+	Arch string `json:"arch,omitempty"`
+	// Source is the git repository or OCI registry the package definition was fetched from.
+	Source string `json:"source,omitempty"`
+	// Flags are the flags that were set on the command line, with the values of secrets redacted.
+	Flags map[string]string `json:"flags,omitempty"`
+	// ConfigDigest is the SHA256 of the Zarf config file that was used.
+	ConfigDigest string `json:"configDigest,omitempty"`
+	// Inputs are the SHA256 of the package definitions, overlays and local files the package was created from by
+	// their path relative to the package directory. Directories are digested over the paths, modes and contents of
+	// their files.
+	Inputs map[string]string `json:"inputs"`
+}
+
+// newBuildInfo returns the build info of the package created from packagePath.
+func newBuildInfo(pkg v1alpha1.ZarfPackage, packagePath string, opt CreateOptions) (BuildInfo, error) {
+	_, reproducible, err := sourceDateEpoch()
+	if err != nil {
+		return BuildInfo{}, err
+	}
+	info := BuildInfo{
+		ZarfVersion: config.CLIVersion,
+		GoVersion:   runtime.Version(),
+		Source:      redactSource(opt.Source),
+		Flags:       opt.Flags,
+	}
+	// The host that builds a reproducible package is left out as it differs between builders.
+	if !reproducible {
+		info.OS = runtime.GOOS
+		info.Arch = runtime.GOARCH
+	}
+	if opt.ConfigPath != "" {
+		info.ConfigDigest, err = helpers.GetSHA256OfFile(opt.ConfigPath)
+		if err != nil {
+			return BuildInfo{}, fmt.Errorf("unable to digest the config file %s: %w", opt.ConfigPath, err)
+		}
+	}
+	info.Inputs, err = buildInputs(pkg, packagePath, opt.OverlayPaths)
+	if err != nil {
+		return BuildInfo{}, err
+	}
+	return info, nil
+}
+
+// credentialParams are substrings of the names of query parameters that carry credentials, such as access tokens and
+// presigned URL signatures.
+var credentialParams = []string{"token", "pass", "secret", "key", "sig", "auth", "credential"}
+
+// redactSource removes the userinfo and the query parameters that carry credentials from the URL of a remote package
+// definition, keeping the git:: prefix and parameters such as the ref.
+func redactSource(source string) string {
+	rest, isGit := strings.CutPrefix(source, gitSourcePrefix)
+	u, err := url.Parse(rest)
+	if err != nil || u.Host == "" {
+		return source
+	}
+	u.User = nil
+	query := u.Query()
+	for name := range query {
+		lower := strings.ToLower(name)
+		if slices.ContainsFunc(credentialParams, func(param string) bool { return strings.Contains(lower, param) }) {
+			query.Del(name)
+		}
+	}
+	if len(query) == 0 {
+		u.RawQuery = ""
+	} else {
+		u.RawQuery = query.Encode()
+	}
+	if isGit {
+		return gitSourcePrefix + u.String()
+	}
+	return u.String()
+}
+
+// buildInputs returns the digests of the package definitions, overlays and local files the package is created from.
+func buildInputs(pkg v1alpha1.ZarfPackage, packagePath string, overlayPaths []string) (map[string]string, error) {
+	absPackagePath, err := filepath.Abs(packagePath)
+	if err != nil {
+		return nil, err
+	}
+	paths, err := definitionPaths(packagePath, nil)
+	if err != nil {
+		return nil, err
+	}
+	for _, overlayPath := range overlayPaths {
+		// Overlays are relative to the working directory instead of the package directory.
+		abs, err := filepath.Abs(overlayPath)
+		if err != nil {
+			return nil, err
+		}
+		rel, err := filepath.Rel(absPackagePath, abs)
+		if err != nil {
+			return nil, err
+		}
+		paths = append(paths, rel)
+	}
+	if pkg.Metadata.ReleaseNotes != "" {
+		paths = append(paths, pkg.Metadata.ReleaseNotes)
+	}
+	for _, component := range pkg.Components {
+		localPaths, _ := componentInputs(component)
+		paths = append(paths, localPaths...)
+		if component.DeprecatedCosignKeyPath != "" {
+			paths = append(paths, component.DeprecatedCosignKeyPath)
+		}
+		for _, build := range component.Builds {
+			// The Dockerfile can be outside of the build context.
+			paths = append(paths, build.Context, filepath.Join(build.Context, cmp.Or(build.Dockerfile, "Dockerfile")))
+		}
+	}
+
+	inputs := map[string]string{}
+	for _, path := range paths {
+		key := filepath.ToSlash(filepath.Clean(path))
+		if _, ok := inputs[key]; ok {
+			continue
+		}
+		absPath := path
+		if !filepath.IsAbs(path) {
+			absPath = filepath.Join(packagePath, path)
+		}
+		sum, err := hashPath(absPath)
+		// Kustomizations that are not local paths are remote bases.
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		inputs[key] = sum
+	}
+	return inputs, nil
+}
+
+// definitionPaths returns the paths of the zarf.yaml of the package and of every package it imports by path, relative
+// to the package directory.
+func definitionPaths(packagePath string, visited []string) ([]string, error) {
+	abs, err := filepath.Abs(packagePath)
+	if err != nil {
+		return nil, err
+	}
+	if slices.Contains(visited, abs) {
+		return nil, nil
+	}
+	visited = append(visited, abs)
+	b, err := os.ReadFile(filepath.Join(packagePath, ZarfYAML))
+	if err != nil {
+		return nil, err
+	}
+	pkg, err := ParseZarfPackage(b)
+	if err != nil {
+		return nil, err
+	}
+	paths := []string{ZarfYAML}
+	for _, component := range pkg.Components {
+		if component.Import.Path == "" {
+			continue
+		}
+		imported, err := definitionPaths(filepath.Join(packagePath, component.Import.Path), visited)
+		if err != nil {
+			return nil, err
+		}
+		for _, path := range imported {
+			paths = append(paths, filepath.Join(component.Import.Path, path))
+		}
+	}
+	return paths, nil
+}
+
+// writeBuildInfo writes the build info to the package at buildPath.
+func writeBuildInfo(buildPath string, info BuildInfo) error {
+	b, err := json.MarshalIndent(info, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(buildPath, BuildInfoFile), b, helpers.ReadWriteUser)
+}
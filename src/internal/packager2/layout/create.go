@@ -14,28 +14,43 @@ import (
 	"io"
 	"io/fs"
 	"os"
+	"path"
 	"path/filepath"
 	"runtime"
 	"slices"
 	"strconv"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/defenseunicorns/pkg/helpers/v2"
 	goyaml "github.com/goccy/go-yaml"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
 	"github.com/mholt/archiver/v3"
 	"github.com/sigstore/cosign/v2/cmd/cosign/cli/options"
 	"github.com/sigstore/cosign/v2/cmd/cosign/cli/sign"
+	"go.opentelemetry.io/otel/attribute"
+	"golang.org/x/sync/errgroup"
+	"helm.sh/helm/v3/pkg/chart/loader"
 
 	"github.com/zarf-dev/zarf/src/api/v1alpha1"
 	"github.com/zarf-dev/zarf/src/config"
 	"github.com/zarf-dev/zarf/src/config/lang"
+	"github.com/zarf-dev/zarf/src/internal/artifact"
+	"github.com/zarf-dev/zarf/src/internal/fips"
 	"github.com/zarf-dev/zarf/src/internal/git"
 	"github.com/zarf-dev/zarf/src/internal/packager/helm"
+	"github.com/zarf-dev/zarf/src/internal/packager/hostartifacts"
+	"github.com/zarf-dev/zarf/src/internal/packager/hostservices"
+	"github.com/zarf-dev/zarf/src/internal/packager/imagebuild"
 	"github.com/zarf-dev/zarf/src/internal/packager/images"
 	"github.com/zarf-dev/zarf/src/internal/packager/kustomize"
+	"github.com/zarf-dev/zarf/src/internal/packager/tofu"
 	actions2 "github.com/zarf-dev/zarf/src/internal/packager2/actions"
 	"github.com/zarf-dev/zarf/src/internal/packager2/filters"
+	"github.com/zarf-dev/zarf/src/internal/remotecache"
+	"github.com/zarf-dev/zarf/src/internal/tracing"
+	"github.com/zarf-dev/zarf/src/pkg/events"
 	"github.com/zarf-dev/zarf/src/pkg/interactive"
 	"github.com/zarf-dev/zarf/src/pkg/lint"
 	"github.com/zarf-dev/zarf/src/pkg/logger"
@@ -55,6 +70,40 @@ type CreateOptions struct {
 	SetVariables            map[string]string
 	SkipSBOM                bool
 	DifferentialPackagePath string
+	OverlayPaths            []string
+	// ExcludeImagesFrom are the names of components whose images are left out of the package, e.g. because they are
+	// pulled from a mirror registry on deploy.
+	ExcludeImagesFrom []string
+	// BuildCache reuses components assembled by previous builds whose definition and local files did not change.
+	BuildCache bool
+	// RemoteCache is an s3:// or oci:// URL that image layers and, with BuildCache, components are read through and
+	// stored in so that they can be shared between machines.
+	RemoteCache string
+	// RemoteCacheReadOnly only reads from the remote cache.
+	RemoteCacheReadOnly bool
+	// MaxMemoryMB is the memory in MB image assembly stays under by saving fewer images at a time, or zero for no
+	// budget.
+	MaxMemoryMB int
+	// MaxTempSpaceMB fails the create before any work begins when the package is estimated to need more temporary
+	// space, or zero for no budget.
+	MaxTempSpaceMB int
+	// IncludePlatforms are the platforms of multi-platform images that are kept in the package next to the package
+	// architecture.
+	IncludePlatforms []string
+	// Buildkit are the remote buildkitd endpoints the images of the components are built on.
+	Buildkit imagebuild.Remote
+	// AllowUnpinned allows kustomize remote bases that are not pinned to a tag or commit.
+	AllowUnpinned bool
+	// LintRules are checked against the pulled images of the package.
+	LintRules lint.Rules
+	// Source is the git repository or OCI registry the package definition was fetched from, recorded in the build
+	// info of the package.
+	Source string
+	// Flags are the command line flags the package is created with, recorded in the build info of the package.
+	Flags map[string]string
+	// ConfigPath is the Zarf config file the package is created with, whose digest is recorded in the build info of
+	// the package.
+	ConfigPath string
 }
 
 func CreatePackage(ctx context.Context, packagePath string, opt CreateOptions) (*PackageLayout, error) {
@@ -66,7 +115,7 @@ func CreatePackage(ctx context.Context, packagePath string, opt CreateOptions) (
 		return nil, err
 	}
 
-	pkg, err := LoadPackage(ctx, packagePath, opt.Flavor, opt.SetVariables)
+	pkg, err := LoadPackage(ctx, packagePath, opt.Flavor, opt.SetVariables, opt.OverlayPaths)
 	if err != nil {
 		return nil, err
 	}
@@ -109,52 +158,173 @@ func CreatePackage(ctx context.Context, packagePath string, opt CreateOptions) (
 		}
 	}
 
+	pkg, err = pinArtifacts(ctx, pkg)
+	if err != nil {
+		return nil, err
+	}
+	for i, component := range pkg.Components {
+		pkg.Components[i] = imagebuild.WithImages(component)
+	}
+
+	var remoteCache remotecache.Store
+	if opt.RemoteCache != "" {
+		remoteOpts := remotecache.Options{
+			ReadOnly:              opt.RemoteCacheReadOnly,
+			PlainHTTP:             config.CommonOptions.PlainHTTP,
+			InsecureSkipTLSVerify: config.CommonOptions.InsecureSkipTLSVerify,
+		}
+		remoteCache, err = remotecache.New(ctx, opt.RemoteCache, remoteOpts)
+		if err != nil {
+			return nil, err
+		}
+	}
 	for _, component := range pkg.Components {
-		err := assemblePackageComponent(ctx, component, packagePath, buildPath)
+		if slices.Contains(opt.ExcludeImagesFrom, component.Name) {
+			l.Info("leaving component images out of the package", "component", component.Name, "images", len(component.Images))
+		}
+	}
+	componentImages, sharedImages, err := packageImages(pkg.Components, opt.ExcludeImagesFrom)
+	if err != nil {
+		return nil, err
+	}
+	if config.CommonOptions.FIPS {
+		if err := fips.ValidatePackage(pkg); err != nil {
+			return nil, err
+		}
+	}
+	for ref, components := range sharedImages {
+		l.Debug("image is shared between components and only pulled once", "image", ref, "components", components)
+	}
+	pkg.Build.SharedImages = sharedImages
+	pulledComponents := slices.DeleteFunc(slices.Clone(pkg.Components), func(component v1alpha1.ZarfComponent) bool {
+		return slices.Contains(opt.ExcludeImagesFrom, component.Name)
+	})
+	imageRegistryOverrides, err := images.ComponentRegistryOverrides(pulledComponents, opt.RegistryOverrides)
+	if err != nil {
+		return nil, err
+	}
+	imagePlatforms, err := images.ComponentImagePlatforms(pulledComponents)
+	if err != nil {
+		return nil, err
+	}
+	var imageTarballs map[string]string
+	var imageIndexTarballs map[string]map[string]string
+	if slices.ContainsFunc(pulledComponents, func(component v1alpha1.ZarfComponent) bool { return len(component.Builds) > 0 }) {
+		buildDir, err := utils.MakeTempDir(config.CommonOptions.TempDirectory)
 		if err != nil {
 			return nil, err
 		}
+		defer os.RemoveAll(buildDir)
+		imageTarballs, imageIndexTarballs, err = imagebuild.BuildComponents(ctx, pulledComponents, packagePath, pkg.Metadata.Architecture, buildDir, opt.Buildkit)
+		if err != nil {
+			return nil, err
+		}
+	}
+	cachePath, err := config.GetAbsCachePath()
+	if err != nil {
+		return nil, err
+	}
+	pullCfg := images.PullConfig{
+		DestinationDirectory:   filepath.Join(buildPath, ImagesDir),
+		ImageList:              componentImages,
+		Arch:                   pkg.Metadata.Architecture,
+		RegistryOverrides:      opt.RegistryOverrides,
+		ImageRegistryOverrides: imageRegistryOverrides,
+		ImagePlatforms:         imagePlatforms,
+		ImageTarballs:          imageTarballs,
+		ImageIndexTarballs:     imageIndexTarballs,
+		CacheDirectory:         filepath.Join(cachePath, ImagesDir),
+		RemoteCache:            remoteCache,
+		MemoryBudget:           int64(opt.MaxMemoryMB) * 1000 * 1000,
+		IncludePlatforms:       opt.IncludePlatforms,
+	}
+	for _, refInfo := range componentImages {
+		if _, ok := imageTarballs[refInfo.Reference]; ok {
+			continue
+		}
+		src := pullCfg.PullReference(refInfo.Reference)
+		if src == refInfo.Reference {
+			continue
+		}
+		if pkg.Build.ImageSources == nil {
+			pkg.Build.ImageSources = map[string]string{}
+		}
+		pkg.Build.ImageSources[refInfo.Reference] = src
 	}
 
-	componentImages := []transform.Image{}
-	for _, component := range pkg.Components {
-		for _, src := range component.Images {
-			refInfo, err := transform.ParseImageRef(src)
-			if err != nil {
-				return nil, fmt.Errorf("failed to create ref for image %s: %w", src, err)
-			}
-			if slices.Contains(componentImages, refInfo) {
-				continue
-			}
-			componentImages = append(componentImages, refInfo)
+	// Check that the package fits before any components are assembled or images are pulled. The estimate queries the
+	// registry for every image, so it is only made when a budget is requested.
+	if opt.MaxTempSpaceMB > 0 {
+		estimate, err := estimateBuildSize(ctx, pkg, packagePath, pullCfg)
+		if err != nil {
+			return nil, err
+		}
+		err = checkBuildSpace(ctx, buildPath, estimate, opt.MaxTempSpaceMB)
+		if err != nil {
+			return nil, err
 		}
 	}
-	sbomImageList := []transform.Image{}
-	if len(componentImages) > 0 {
-		cachePath, err := config.GetAbsCachePath()
+
+	var bc *buildCache
+	if opt.BuildCache {
+		bc = &buildCache{dir: filepath.Join(cachePath, BuildCacheDir), remote: remoteCache}
+	}
+	for _, component := range pkg.Components {
+		err := assemblePackageComponent(ctx, component, packagePath, buildPath, pkg.Metadata.Architecture, opt.AllowUnpinned, bc)
 		if err != nil {
 			return nil, err
 		}
-		pullCfg := images.PullConfig{
-			DestinationDirectory: filepath.Join(buildPath, ImagesDir),
-			ImageList:            componentImages,
-			Arch:                 pkg.Metadata.Architecture,
-			RegistryOverrides:    opt.RegistryOverrides,
-			CacheDirectory:       filepath.Join(cachePath, ImagesDir),
+		if len(component.Charts) == 0 {
+			continue
+		}
+		deps, err := chartDependencies(filepath.Join(buildPath, "components", fmt.Sprintf("%s.tar", component.Name)), component)
+		if err != nil {
+			return nil, err
 		}
-		pulled, err := images.Pull(ctx, pullCfg)
+		pkg.Build.ChartDependencies = append(pkg.Build.ChartDependencies, deps...)
+	}
+
+	sbomImageList := []transform.Image{}
+	if len(componentImages) > 0 {
+		pulled, discarded, err := images.Pull(ctx, pullCfg)
 		if err != nil {
 			return nil, err
 		}
+		if len(discarded) > 0 {
+			pkg.Build.DiscardedPlatforms = discarded
+		}
+		pkg.Build.ImageDigests = map[string]string{}
+		pulledImages := map[transform.Image]v1.Image{}
 		for info, img := range pulled {
+			dgst, err := img.Digest()
+			if err != nil {
+				return nil, fmt.Errorf("unable to get the digest of %s: %w", info.Reference, err)
+			}
+			pkg.Build.ImageDigests[info.Reference] = dgst.String()
 			ok, err := utils.OnlyHasImageLayers(img)
 			if err != nil {
 				return nil, fmt.Errorf("failed to validate %s is an image and not an artifact: %w", info, err)
 			}
 			if ok {
 				sbomImageList = append(sbomImageList, info)
+				pulledImages[info] = img
 			}
 		}
+		findings, err := lint.CheckImageMetadata(pkg, pulledImages, opt.LintRules.Images)
+		if err != nil {
+			return nil, err
+		}
+		lintErr := &lint.LintError{
+			BaseDir:     packagePath,
+			PackageName: pkg.Metadata.Name,
+			Findings:    findings,
+		}
+		if !lintErr.OnlyWarnings() {
+			return nil, lintErr
+		}
+		for _, finding := range findings {
+			l.Warn("image does not follow the lint rules", "image", finding.Item, "path", finding.YqPath, "finding", finding.Description)
+		}
 
 		// Sort images index to make build reproducible.
 		err = utils.SortImagesIndex(filepath.Join(buildPath, ImagesDir))
@@ -173,6 +343,22 @@ func CreatePackage(ctx context.Context, packagePath string, opt CreateOptions) (
 		}
 	}
 
+	if pkg.Metadata.ReleaseNotes != "" {
+		err = copyReleaseNotes(packagePath, buildPath, pkg.Metadata.ReleaseNotes)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	buildInfo, err := newBuildInfo(pkg, packagePath, opt)
+	if err != nil {
+		return nil, err
+	}
+	err = writeBuildInfo(buildPath, buildInfo)
+	if err != nil {
+		return nil, err
+	}
+
 	checksumContent, checksumSha, err := getChecksum(buildPath)
 	if err != nil {
 		return nil, err
@@ -184,7 +370,10 @@ func CreatePackage(ctx context.Context, packagePath string, opt CreateOptions) (
 	}
 	pkg.Metadata.AggregateChecksum = checksumSha
 
-	pkg = recordPackageMetadata(pkg, opt.Flavor, opt.RegistryOverrides)
+	pkg, err = recordPackageMetadata(pkg, opt.Flavor, opt.RegistryOverrides)
+	if err != nil {
+		return nil, err
+	}
 
 	b, err := goyaml.Marshal(pkg)
 	if err != nil {
@@ -210,9 +399,26 @@ func CreatePackage(ctx context.Context, packagePath string, opt CreateOptions) (
 	return pkgLayout, nil
 }
 
+// copyReleaseNotes embeds the release notes file of the package, the checksums of the package cover it like any other
+// file.
+func copyReleaseNotes(packagePath, buildPath, releaseNotes string) error {
+	src := releaseNotes
+	if !filepath.IsAbs(src) {
+		src = filepath.Join(packagePath, src)
+	}
+	fi, err := os.Stat(src)
+	if err != nil {
+		return fmt.Errorf("unable to read the release notes %s: %w", releaseNotes, err)
+	}
+	if fi.IsDir() {
+		return fmt.Errorf("the release notes %s must be a file", releaseNotes)
+	}
+	return helpers.CreatePathAndCopy(src, filepath.Join(buildPath, ReleaseNotes))
+}
+
 // CreateSkeleton creates a skeleton package and returns the path to the created package.
 func CreateSkeleton(ctx context.Context, packagePath string, opt CreateOptions) (string, error) {
-	pkg, err := LoadPackage(ctx, packagePath, opt.Flavor, nil)
+	pkg, err := LoadPackage(ctx, packagePath, opt.Flavor, nil, opt.OverlayPaths)
 	if err != nil {
 		return "", err
 	}
@@ -224,7 +430,14 @@ func CreateSkeleton(ctx context.Context, packagePath string, opt CreateOptions)
 	}
 
 	for _, component := range pkg.Components {
-		err := assembleSkeletonComponent(component, packagePath, buildPath)
+		err := assembleSkeletonComponent(ctx, component, packagePath, buildPath)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	if pkg.Metadata.ReleaseNotes != "" {
+		err = copyReleaseNotes(packagePath, buildPath, pkg.Metadata.ReleaseNotes)
 		if err != nil {
 			return "", err
 		}
@@ -241,7 +454,10 @@ func CreateSkeleton(ctx context.Context, packagePath string, opt CreateOptions)
 	}
 	pkg.Metadata.AggregateChecksum = checksumSha
 
-	pkg = recordPackageMetadata(pkg, opt.Flavor, opt.RegistryOverrides)
+	pkg, err = recordPackageMetadata(pkg, opt.Flavor, opt.RegistryOverrides)
+	if err != nil {
+		return "", err
+	}
 
 	b, err := goyaml.Marshal(pkg)
 	if err != nil {
@@ -260,8 +476,8 @@ func CreateSkeleton(ctx context.Context, packagePath string, opt CreateOptions)
 	return buildPath, nil
 }
 
-// LoadPackage returns a validated package definition after flavors, imports, and variables are applied.
-func LoadPackage(ctx context.Context, packagePath, flavor string, setVariables map[string]string) (v1alpha1.ZarfPackage, error) {
+// LoadPackage returns a validated package definition after flavors, imports, overlays, and variables are applied.
+func LoadPackage(ctx context.Context, packagePath, flavor string, setVariables map[string]string, overlayPaths []string) (v1alpha1.ZarfPackage, error) {
 	b, err := os.ReadFile(filepath.Join(packagePath, ZarfYAML))
 	if err != nil {
 		return v1alpha1.ZarfPackage{}, err
@@ -275,6 +491,10 @@ func LoadPackage(ctx context.Context, packagePath, flavor string, setVariables m
 	if err != nil {
 		return v1alpha1.ZarfPackage{}, err
 	}
+	pkg, err = applyOverlays(pkg, overlayPaths)
+	if err != nil {
+		return v1alpha1.ZarfPackage{}, err
+	}
 	if setVariables != nil {
 		pkg, _, err = fillActiveTemplate(ctx, pkg, setVariables)
 		if err != nil {
@@ -321,7 +541,34 @@ func validateFlavorExists(pkg v1alpha1.ZarfPackage, flavor string) error {
 	return fmt.Errorf("could not find flavor %s in package definition", flavor)
 }
 
-func assemblePackageComponent(ctx context.Context, component v1alpha1.ZarfComponent, packagePath, buildPath string) error {
+func assemblePackageComponent(ctx context.Context, component v1alpha1.ZarfComponent, packagePath, buildPath, arch string, allowUnpinned bool, bc *buildCache) (err error) {
+	ctx, span := tracing.Start(ctx, "assemble component", attribute.String("component", component.Name))
+	events.From(ctx).Component(component.Name, events.StatusStarted, nil)
+	defer func() {
+		events.From(ctx).Component(component.Name, events.Done(err), err)
+		tracing.End(span, err)
+	}()
+	l := logger.From(ctx)
+
+	tarPath := filepath.Join(buildPath, "components", fmt.Sprintf("%s.tar", component.Name))
+	cacheKey, cacheable := "", false
+	if bc != nil {
+		cacheKey, cacheable, err = componentCacheKey(component, packagePath, arch)
+		if err != nil {
+			return err
+		}
+		if cacheable {
+			cached, err := bc.restore(ctx, cacheKey, tarPath)
+			if err != nil {
+				return err
+			}
+			if cached {
+				l.Info("using component from the build cache", "component", component.Name)
+				return nil
+			}
+		}
+	}
+
 	tmpBuildPath, err := utils.MakeTempDir(config.CommonOptions.TempDirectory)
 	if err != nil {
 		return err
@@ -338,102 +585,30 @@ func assemblePackageComponent(ctx context.Context, component v1alpha1.ZarfCompon
 		return fmt.Errorf("unable to run component before action: %w", err)
 	}
 
-	// If any helm charts are defined, process them.
-	for _, chart := range component.Charts {
-		// TODO: Refactor helm builder
-		if chart.LocalPath != "" {
-			chart.LocalPath = filepath.Join(packagePath, chart.LocalPath)
-		}
-		oldValuesFiles := chart.ValuesFiles
-		valuesFiles := []string{}
-		for _, v := range chart.ValuesFiles {
-			valuesFiles = append(valuesFiles, filepath.Join(packagePath, v))
-		}
-		chart.ValuesFiles = valuesFiles
-		helmCfg := helm.New(chart, filepath.Join(compBuildPath, string(ChartsComponentDir)), filepath.Join(compBuildPath, string(ValuesComponentDir)))
-		if err := helmCfg.PackageChart(ctx, filepath.Join(compBuildPath, string(ChartsComponentDir))); err != nil {
-			return err
-		}
-		chart.ValuesFiles = oldValuesFiles
+	if err := fetchComponentSources(ctx, component, packagePath, compBuildPath); err != nil {
+		return err
 	}
 
-	for filesIdx, file := range component.Files {
-		rel := filepath.Join(string(FilesComponentDir), strconv.Itoa(filesIdx), filepath.Base(file.Target))
-		dst := filepath.Join(compBuildPath, rel)
-		destinationDir := filepath.Dir(dst)
-
-		if helpers.IsURL(file.Source) {
-			if file.ExtractPath != "" {
-				// get the compressedFileName from the source
-				compressedFileName, err := helpers.ExtractBasePathFromURL(file.Source)
-				if err != nil {
-					return fmt.Errorf(lang.ErrFileNameExtract, file.Source, err.Error())
-				}
-				tmpDir, err := utils.MakeTempDir(config.CommonOptions.TempDirectory)
-				if err != nil {
-					return err
-				}
-				defer os.RemoveAll(tmpDir)
-				compressedFile := filepath.Join(tmpDir, compressedFileName)
-
-				// If the file is an archive, download it to the componentPath.Temp
-				if err := utils.DownloadToFile(ctx, file.Source, compressedFile, component.DeprecatedCosignKeyPath); err != nil {
-					return fmt.Errorf(lang.ErrDownloading, file.Source, err.Error())
-				}
-				err = archiver.Extract(compressedFile, file.ExtractPath, destinationDir)
-				if err != nil {
-					return fmt.Errorf(lang.ErrFileExtract, file.ExtractPath, compressedFileName, err.Error())
-				}
-			} else {
-				if err := utils.DownloadToFile(ctx, file.Source, dst, component.DeprecatedCosignKeyPath); err != nil {
-					return fmt.Errorf(lang.ErrDownloading, file.Source, err.Error())
-				}
-			}
-		} else {
-			if file.ExtractPath != "" {
-				if err := archiver.Extract(filepath.Join(packagePath, file.Source), file.ExtractPath, destinationDir); err != nil {
-					return fmt.Errorf(lang.ErrFileExtract, file.ExtractPath, file.Source, err.Error())
-				}
-			} else {
-				if filepath.IsAbs(file.Source) {
-					if err := helpers.CreatePathAndCopy(file.Source, dst); err != nil {
-						return fmt.Errorf("unable to copy file %s: %w", file.Source, err)
-					}
-				} else {
-					if err := helpers.CreatePathAndCopy(filepath.Join(packagePath, file.Source), dst); err != nil {
-						return fmt.Errorf("unable to copy file %s: %w", file.Source, err)
-					}
-				}
-			}
-		}
-
-		if file.ExtractPath != "" {
-			// Make sure dst reflects the actual file or directory.
-			updatedExtractedFileOrDir := filepath.Join(destinationDir, file.ExtractPath)
-			if updatedExtractedFileOrDir != dst {
-				if err := os.Rename(updatedExtractedFileOrDir, dst); err != nil {
-					return fmt.Errorf(lang.ErrWritingFile, dst, err)
-				}
+	for hostArtifactIdx, hostArtifact := range component.HostArtifacts {
+		for sourceIdx, src := range hostArtifact.Sources {
+			dst := filepath.Join(compBuildPath, string(HostArtifactsComponentDir), hostartifacts.SourcePath(hostArtifactIdx, sourceIdx, hostArtifact.Name))
+			if err := hostartifacts.Pull(ctx, packagePath, src, dst, component.DeprecatedCosignKeyPath); err != nil {
+				return err
 			}
 		}
+	}
 
-		// Abort packaging on invalid shasum (if one is specified).
-		if file.Shasum != "" {
-			if err := helpers.SHAsMatch(dst, file.Shasum); err != nil {
-				return err
-			}
+	for hostServiceIdx, hostService := range component.HostServices {
+		dst := filepath.Join(compBuildPath, string(HostServicesComponentDir), hostservices.SourcePath(hostServiceIdx, hostService.Name))
+		if err := hostservices.Pull(ctx, packagePath, hostService, dst, component.DeprecatedCosignKeyPath); err != nil {
+			return err
 		}
+	}
 
-		if file.Executable || helpers.IsDir(dst) {
-			err := os.Chmod(dst, helpers.ReadWriteExecuteUser)
-			if err != nil {
-				return err
-			}
-		} else {
-			err := os.Chmod(dst, helpers.ReadWriteUser)
-			if err != nil {
-				return err
-			}
+	for moduleIdx, module := range component.Tofu {
+		dst := filepath.Join(compBuildPath, string(TofuComponentDir), strconv.Itoa(moduleIdx))
+		if err := tofu.Vendor(ctx, filepath.Join(packagePath, module.Source), dst, tofu.Platforms(module, arch)); err != nil {
+			return fmt.Errorf("unable to vendor tofu module %s: %w", module.Name, err)
 		}
 	}
 
@@ -485,18 +660,16 @@ func assemblePackageComponent(ctx context.Context, component v1alpha1.ZarfCompon
 			if !helpers.IsURL(path) {
 				path = filepath.Join(packagePath, path)
 			}
-			if err := kustomize.Build(path, dst, manifest.KustomizeAllowAnyDirectory); err != nil {
+			if err := kustomize.BuildVendored(ctx, path, dst, manifest.KustomizeAllowAnyDirectory, allowUnpinned); err != nil {
 				return fmt.Errorf("unable to build kustomization %s: %w", path, err)
 			}
 		}
 	}
 
-	// Load all specified git repos.
-	for _, url := range component.Repos {
-		// Pull all the references if there is no `@` in the string.
-		_, err := git.Clone(ctx, filepath.Join(compBuildPath, string(RepoComponentDir)), url, false)
+	for _, a := range component.Artifacts {
+		_, err := artifact.Save(ctx, a.Reference, a.ArtifactType, filepath.Join(compBuildPath, string(ArtifactsComponentDir)), artifactRegistryOptions())
 		if err != nil {
-			return fmt.Errorf("unable to pull git repo %s: %w", url, err)
+			return err
 		}
 	}
 
@@ -512,7 +685,6 @@ func assemblePackageComponent(ctx context.Context, component v1alpha1.ZarfCompon
 	if len(entries) == 0 {
 		return nil
 	}
-	tarPath := filepath.Join(buildPath, "components", fmt.Sprintf("%s.tar", component.Name))
 	err = os.MkdirAll(filepath.Join(buildPath, "components"), 0o700)
 	if err != nil {
 		return err
@@ -521,10 +693,170 @@ func assemblePackageComponent(ctx context.Context, component v1alpha1.ZarfCompon
 	if err != nil {
 		return err
 	}
+	if cacheable {
+		// A failure to cache the component does not affect the package being built.
+		if err := bc.store(ctx, cacheKey, tarPath); err != nil {
+			l.Warn("unable to store component in the build cache", "component", component.Name, "error", err)
+		}
+	}
 	return nil
 }
 
-func assembleSkeletonComponent(component v1alpha1.ZarfComponent, packagePath, buildPath string) error {
+// maxFetchConcurrency is the number of charts, files and repos of a component that are fetched at the same time.
+const maxFetchConcurrency = 10
+
+// fetchComponentSources pulls the charts, fetches the files and clones the repos of a component into compBuildPath.
+// They are fetched concurrently as most of the time is spent waiting on the network.
+func fetchComponentSources(ctx context.Context, component v1alpha1.ZarfComponent, packagePath, compBuildPath string) error {
+	total := len(component.Charts) + len(component.Files) + len(component.Repos)
+	if total == 0 {
+		return nil
+	}
+	l := logger.From(ctx)
+	l.Info("fetching component sources",
+		"component", component.Name,
+		"charts", len(component.Charts),
+		"files", len(component.Files),
+		"repos", len(component.Repos),
+	)
+	start := time.Now()
+
+	var completed atomic.Int64
+	eg, ectx := errgroup.WithContext(ctx)
+	eg.SetLimit(maxFetchConcurrency)
+	fetch := func(kind, source string, fn func() error) {
+		eg.Go(func() error {
+			if err := fn(); err != nil {
+				return err
+			}
+			l.Debug("fetched component source", "component", component.Name, kind, source, "completed", completed.Add(1), "total", total)
+			return nil
+		})
+	}
+	for _, chart := range component.Charts {
+		fetch("chart", chart.Name, func() error {
+			return fetchComponentChart(ectx, chart, packagePath, compBuildPath)
+		})
+	}
+	for filesIdx, file := range component.Files {
+		fetch("file", file.Source, func() error {
+			return fetchComponentFile(ectx, file, filesIdx, packagePath, compBuildPath, component.DeprecatedCosignKeyPath)
+		})
+	}
+	for _, url := range component.Repos {
+		fetch("repo", url, func() error {
+			// Pull all the references if there is no `@` in the string.
+			if _, err := git.Clone(ectx, filepath.Join(compBuildPath, string(RepoComponentDir)), url, false); err != nil {
+				return fmt.Errorf("unable to pull git repo %s: %w", url, err)
+			}
+			return nil
+		})
+	}
+	if err := eg.Wait(); err != nil {
+		return err
+	}
+	l.Info("done fetching component sources", "component", component.Name, "count", total, "duration", time.Since(start))
+	return nil
+}
+
+// fetchComponentChart pulls or packages the chart of a component into compBuildPath.
+func fetchComponentChart(ctx context.Context, chart v1alpha1.ZarfChart, packagePath, compBuildPath string) error {
+	// TODO: Refactor helm builder
+	if chart.LocalPath != "" {
+		chart.LocalPath = filepath.Join(packagePath, chart.LocalPath)
+	}
+	valuesFiles := []string{}
+	for _, v := range chart.ValuesFiles {
+		valuesFiles = append(valuesFiles, filepath.Join(packagePath, v))
+	}
+	chart.ValuesFiles = valuesFiles
+	helmCfg := helm.New(chart, filepath.Join(compBuildPath, string(ChartsComponentDir)), filepath.Join(compBuildPath, string(ValuesComponentDir)))
+	return helmCfg.PackageChart(ctx, filepath.Join(compBuildPath, string(ChartsComponentDir)))
+}
+
+// fetchComponentFile fetches or copies the file of a component into compBuildPath.
+func fetchComponentFile(ctx context.Context, file v1alpha1.ZarfFile, filesIdx int, packagePath, compBuildPath, cosignKeyPath string) error {
+	rel := filepath.Join(string(FilesComponentDir), strconv.Itoa(filesIdx), filepath.Base(file.Target))
+	dst := filepath.Join(compBuildPath, rel)
+	destinationDir := filepath.Dir(dst)
+
+	if helpers.IsURL(file.Source) {
+		if file.ExtractPath != "" {
+			// get the compressedFileName from the source
+			compressedFileName, err := helpers.ExtractBasePathFromURL(file.Source)
+			if err != nil {
+				return fmt.Errorf(lang.ErrFileNameExtract, file.Source, err.Error())
+			}
+			tmpDir, err := utils.MakeTempDir(config.CommonOptions.TempDirectory)
+			if err != nil {
+				return err
+			}
+			defer os.RemoveAll(tmpDir)
+			compressedFile := filepath.Join(tmpDir, compressedFileName)
+
+			// If the file is an archive, download it to the componentPath.Temp
+			if err := utils.DownloadToFile(ctx, file.Source, compressedFile, cosignKeyPath); err != nil {
+				return fmt.Errorf(lang.ErrDownloading, file.Source, err.Error())
+			}
+			err = archiver.Extract(compressedFile, file.ExtractPath, destinationDir)
+			if err != nil {
+				return fmt.Errorf(lang.ErrFileExtract, file.ExtractPath, compressedFileName, err.Error())
+			}
+		} else {
+			if err := utils.DownloadToFile(ctx, file.Source, dst, cosignKeyPath); err != nil {
+				return fmt.Errorf(lang.ErrDownloading, file.Source, err.Error())
+			}
+		}
+	} else {
+		if file.ExtractPath != "" {
+			if err := archiver.Extract(filepath.Join(packagePath, file.Source), file.ExtractPath, destinationDir); err != nil {
+				return fmt.Errorf(lang.ErrFileExtract, file.ExtractPath, file.Source, err.Error())
+			}
+		} else {
+			if filepath.IsAbs(file.Source) {
+				if err := helpers.CreatePathAndCopy(file.Source, dst); err != nil {
+					return fmt.Errorf("unable to copy file %s: %w", file.Source, err)
+				}
+			} else {
+				if err := helpers.CreatePathAndCopy(filepath.Join(packagePath, file.Source), dst); err != nil {
+					return fmt.Errorf("unable to copy file %s: %w", file.Source, err)
+				}
+			}
+		}
+	}
+
+	if file.ExtractPath != "" {
+		// Make sure dst reflects the actual file or directory.
+		updatedExtractedFileOrDir := filepath.Join(destinationDir, file.ExtractPath)
+		if updatedExtractedFileOrDir != dst {
+			if err := os.Rename(updatedExtractedFileOrDir, dst); err != nil {
+				return fmt.Errorf(lang.ErrWritingFile, dst, err)
+			}
+		}
+	}
+
+	// Abort packaging on invalid shasum (if one is specified).
+	if file.Shasum != "" {
+		if err := helpers.SHAsMatch(dst, file.Shasum); err != nil {
+			return err
+		}
+	}
+
+	if file.Executable || helpers.IsDir(dst) {
+		err := os.Chmod(dst, helpers.ReadWriteExecuteUser)
+		if err != nil {
+			return err
+		}
+	} else {
+		err := os.Chmod(dst, helpers.ReadWriteUser)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func assembleSkeletonComponent(ctx context.Context, component v1alpha1.ZarfComponent, packagePath, buildPath string) error {
 	tmpBuildPath, err := utils.MakeTempDir(config.CommonOptions.TempDirectory)
 	if err != nil {
 		return err
@@ -626,6 +958,51 @@ func assembleSkeletonComponent(component v1alpha1.ZarfComponent, packagePath, bu
 		}
 	}
 
+	for hostArtifactIdx, hostArtifact := range component.HostArtifacts {
+		for sourceIdx, src := range hostArtifact.Sources {
+			if helpers.IsURL(src.Source) {
+				continue
+			}
+
+			rel := filepath.Join(string(HostArtifactsComponentDir), hostartifacts.SourcePath(hostArtifactIdx, sourceIdx, hostArtifact.Name))
+			if err := hostartifacts.Pull(ctx, packagePath, src, filepath.Join(compBuildPath, rel), ""); err != nil {
+				return err
+			}
+
+			// Local sources are already extracted into the skeleton.
+			component.HostArtifacts[hostArtifactIdx].Sources[sourceIdx].Source = rel
+			component.HostArtifacts[hostArtifactIdx].Sources[sourceIdx].ExtractPath = ""
+		}
+	}
+
+	for hostServiceIdx, hostService := range component.HostServices {
+		if helpers.IsURL(hostService.Source) {
+			continue
+		}
+
+		rel := filepath.Join(string(HostServicesComponentDir), hostservices.SourcePath(hostServiceIdx, hostService.Name))
+		if err := hostservices.Pull(ctx, packagePath, hostService, filepath.Join(compBuildPath, rel), ""); err != nil {
+			return err
+		}
+		component.HostServices[hostServiceIdx].Source = rel
+	}
+
+	for moduleIdx, module := range component.Tofu {
+		rel := filepath.Join(string(TofuComponentDir), strconv.Itoa(moduleIdx))
+		if err := helpers.CreatePathAndCopy(filepath.Join(packagePath, module.Source), filepath.Join(compBuildPath, rel)); err != nil {
+			return fmt.Errorf("unable to copy tofu module %s: %w", module.Name, err)
+		}
+		component.Tofu[moduleIdx].Source = rel
+	}
+
+	for buildIdx, build := range component.Builds {
+		rel := filepath.Join(string(BuildsComponentDir), strconv.Itoa(buildIdx))
+		if err := helpers.CreatePathAndCopy(filepath.Join(packagePath, build.Context), filepath.Join(compBuildPath, rel)); err != nil {
+			return fmt.Errorf("unable to copy the build context of image %s: %w", build.Image, err)
+		}
+		component.Builds[buildIdx].Context = rel
+	}
+
 	for dataIdx, data := range component.DataInjections {
 		rel := filepath.Join(string(DataComponentDir), strconv.Itoa(dataIdx), filepath.Base(data.Target.Path))
 		dst := filepath.Join(compBuildPath, rel)
@@ -690,21 +1067,81 @@ func assembleSkeletonComponent(component v1alpha1.ZarfComponent, packagePath, bu
 	return nil
 }
 
-func recordPackageMetadata(pkg v1alpha1.ZarfPackage, flavor string, registryOverrides map[string]string) v1alpha1.ZarfPackage {
-	now := time.Now()
-	// Just use $USER env variable to avoid CGO issue.
-	// https://groups.google.com/g/golang-dev/c/ZFDDX3ZiJ84.
-	// Record the name of the user creating the package.
-	if runtime.GOOS == "windows" {
-		pkg.Build.User = os.Getenv("USERNAME")
-	} else {
-		pkg.Build.User = os.Getenv("USER")
+// packageImages returns the unique images of the components whose images are included in the package, in the order
+// they are first used. References that normalize to the same image, e.g. nginx:1.25 and docker.io/library/nginx:1.25,
+// are the same image. Images that are used by more than one component are returned with the names of those components.
+func packageImages(components []v1alpha1.ZarfComponent, excludeImagesFrom []string) ([]transform.Image, map[string][]string, error) {
+	refs := []transform.Image{}
+	usedBy := map[string][]string{}
+	for _, component := range components {
+		if slices.Contains(excludeImagesFrom, component.Name) {
+			continue
+		}
+		for _, src := range component.Images {
+			refInfo, err := transform.ParseImageRef(src)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to create ref for image %s: %w", src, err)
+			}
+			if !slices.Contains(refs, refInfo) {
+				refs = append(refs, refInfo)
+			}
+			if !slices.Contains(usedBy[refInfo.Reference], component.Name) {
+				usedBy[refInfo.Reference] = append(usedBy[refInfo.Reference], component.Name)
+			}
+		}
 	}
+	var shared map[string][]string
+	for ref, names := range usedBy {
+		if len(names) < 2 {
+			continue
+		}
+		if shared == nil {
+			shared = map[string][]string{}
+		}
+		shared[ref] = names
+	}
+	return refs, shared, nil
+}
+
+// SourceDateEpochEnv is the environment variable that holds the time in seconds since the Unix epoch that packages are
+// recorded as built at, see https://reproducible-builds.org/specs/source-date-epoch/.
+const SourceDateEpochEnv = "SOURCE_DATE_EPOCH"
+
+// sourceDateEpoch returns the time set by SOURCE_DATE_EPOCH and whether it is set.
+func sourceDateEpoch() (time.Time, bool, error) {
+	v, ok := os.LookupEnv(SourceDateEpochEnv)
+	if !ok || v == "" {
+		return time.Time{}, false, nil
+	}
+	sec, err := strconv.ParseInt(v, 10, 64)
+	if err != nil || sec < 0 {
+		return time.Time{}, false, fmt.Errorf("%s must be a non-negative number of seconds since the Unix epoch, got %q", SourceDateEpochEnv, v)
+	}
+	return time.Unix(sec, 0).UTC(), true, nil
+}
 
-	// Record the hostname of the package creation terminal.
-	// The error here is ignored because the hostname is not critical to the package creation.
-	hostname, _ := os.Hostname()
-	pkg.Build.Terminal = hostname
+func recordPackageMetadata(pkg v1alpha1.ZarfPackage, flavor string, registryOverrides map[string]string) (v1alpha1.ZarfPackage, error) {
+	now, reproducible, err := sourceDateEpoch()
+	if err != nil {
+		return v1alpha1.ZarfPackage{}, err
+	}
+	// The user and host that build a reproducible package are left out as they differ between builders.
+	if !reproducible {
+		now = time.Now()
+		// Just use $USER env variable to avoid CGO issue.
+		// https://groups.google.com/g/golang-dev/c/ZFDDX3ZiJ84.
+		// Record the name of the user creating the package.
+		if runtime.GOOS == "windows" {
+			pkg.Build.User = os.Getenv("USERNAME")
+		} else {
+			pkg.Build.User = os.Getenv("USER")
+		}
+
+		// Record the hostname of the package creation terminal.
+		// The error here is ignored because the hostname is not critical to the package creation.
+		hostname, _ := os.Hostname()
+		pkg.Build.Terminal = hostname
+	}
 
 	if pkg.IsInitConfig() && pkg.Metadata.Version == "" {
 		pkg.Metadata.Version = config.CLIVersion
@@ -723,7 +1160,7 @@ func recordPackageMetadata(pkg v1alpha1.ZarfPackage, flavor string, registryOver
 
 	pkg.Build.RegistryOverrides = registryOverrides
 
-	return pkg
+	return pkg, nil
 }
 
 func getChecksum(dirPath string) (string, string, error) {
@@ -788,6 +1225,50 @@ func signPackage(dirPath, signingKeyPath, signingKeyPassword string) error {
 	return nil
 }
 
+// chartDependencies returns the vendored dependencies of the charts in a component tarball.
+func chartDependencies(tarPath string, component v1alpha1.ZarfComponent) ([]v1alpha1.ZarfChartDependency, error) {
+	f, err := os.Open(tarPath)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	chartsDir := path.Join(component.Name, string(ChartsComponentDir))
+	chartNames := map[string]string{}
+	for _, chart := range component.Charts {
+		chartNames[helm.StandardName("", chart)+".tgz"] = chart.Name
+	}
+	deps := []v1alpha1.ZarfChartDependency{}
+	tr := tar.NewReader(f)
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		name := filepath.ToSlash(header.Name)
+		chartName, ok := chartNames[path.Base(name)]
+		if path.Dir(name) != chartsDir || !ok {
+			continue
+		}
+		c, err := loader.LoadArchive(tr)
+		if err != nil {
+			return nil, fmt.Errorf("unable to load the chart %s: %w", name, err)
+		}
+		chartDeps, err := helm.ChartDependencies(c)
+		if err != nil {
+			return nil, err
+		}
+		for _, dep := range chartDeps {
+			dep.Component = component.Name
+			dep.Chart = chartName
+			deps = append(deps, dep)
+		}
+	}
+	return deps, nil
+}
+
 func createReproducibleTarballFromDir(dirPath, dirPrefix, tarballPath string, overrideMode bool) error {
 	tb, err := os.Create(tarballPath)
 	if err != nil {
@@ -7,6 +7,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io/fs"
 	"os"
 	"path/filepath"
 	"testing"
@@ -14,8 +15,11 @@ import (
 	"github.com/defenseunicorns/pkg/helpers/v2"
 	goyaml "github.com/goccy/go-yaml"
 	"github.com/stretchr/testify/require"
+	"helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/chartutil"
 
 	"github.com/zarf-dev/zarf/src/api/v1alpha1"
+	"github.com/zarf-dev/zarf/src/internal/packager/helm"
 	"github.com/zarf-dev/zarf/src/pkg/layout"
 	"github.com/zarf-dev/zarf/src/pkg/lint"
 	"github.com/zarf-dev/zarf/src/test/testutil"
@@ -182,6 +186,36 @@ fcde2b2edba56bf408601fb721fe9b5c338d10ee429ea04fae5511b68fbf8fb9 foo
 	require.Equal(t, "7c554cf67e1c2b50a1b728299c368cd56d53588300c37479623f29a52812ca3f", checksumHash)
 }
 
+func TestCopyReleaseNotes(t *testing.T) {
+	t.Parallel()
+
+	packagePath := t.TempDir()
+	err := os.WriteFile(filepath.Join(packagePath, "CHANGELOG.md"), []byte("# v0.0.2\n\n- Fixed things\n"), helpers.ReadWriteUser)
+	require.NoError(t, err)
+	err = os.Mkdir(filepath.Join(packagePath, "docs"), helpers.ReadWriteExecuteUser)
+	require.NoError(t, err)
+
+	buildPath := t.TempDir()
+	err = copyReleaseNotes(packagePath, buildPath, "CHANGELOG.md")
+	require.NoError(t, err)
+	pkgLayout := &PackageLayout{
+		dirPath: buildPath,
+		Pkg:     v1alpha1.ZarfPackage{Metadata: v1alpha1.ZarfMetadata{Name: "test", ReleaseNotes: "CHANGELOG.md"}},
+	}
+	notes, err := pkgLayout.GetReleaseNotes()
+	require.NoError(t, err)
+	require.Equal(t, "# v0.0.2\n\n- Fixed things\n", notes)
+
+	err = copyReleaseNotes(packagePath, buildPath, "docs")
+	require.EqualError(t, err, "the release notes docs must be a file")
+	err = copyReleaseNotes(packagePath, buildPath, "NOTES.md")
+	require.ErrorContains(t, err, "unable to read the release notes NOTES.md")
+
+	pkgLayout.Pkg.Metadata.ReleaseNotes = ""
+	_, err = pkgLayout.GetReleaseNotes()
+	require.EqualError(t, err, "zarf package test does not have release notes")
+}
+
 func TestSignPackage(t *testing.T) {
 	t.Parallel()
 
@@ -248,7 +282,7 @@ func TestLoadPackageWithFlavors(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
-			_, err := LoadPackage(context.Background(), filepath.Join("testdata", "package-with-flavors"), tt.flavor, map[string]string{})
+			_, err := LoadPackage(context.Background(), filepath.Join("testdata", "package-with-flavors"), tt.flavor, map[string]string{}, nil)
 			if tt.expectedErr != "" {
 				require.EqualError(t, err, tt.expectedErr)
 				return
@@ -394,3 +428,175 @@ func TestCreateAbsolutePathFileSource(t *testing.T) {
 		require.FileExists(t, filepath.Join(importedFileComponent, "0", "file.txt"))
 	})
 }
+
+func TestApplyOverlays(t *testing.T) {
+	t.Parallel()
+
+	b, err := os.ReadFile(filepath.Join("./testdata/overlay", ZarfYAML))
+	require.NoError(t, err)
+	pkg, err := ParseZarfPackage(b)
+	require.NoError(t, err)
+
+	overlaid, err := applyOverlays(pkg, []string{"./testdata/overlay/prod.yaml"})
+	require.NoError(t, err)
+
+	b, err = os.ReadFile("./testdata/overlay/expected.yaml")
+	require.NoError(t, err)
+	expected, err := ParseZarfPackage(b)
+	require.NoError(t, err)
+	require.Equal(t, expected, overlaid)
+
+	_, err = applyOverlays(pkg, []string{"./testdata/overlay/prod.yaml", "./testdata/overlay/prod.yaml"})
+	require.EqualError(t, err, "unable to apply overlay ./testdata/overlay/prod.yaml: components: cannot delete debug-tools as it does not exist")
+}
+
+func TestPackageImages(t *testing.T) {
+	t.Parallel()
+
+	components := []v1alpha1.ZarfComponent{
+		{Name: "first", Images: []string{"nginx:1.25", "ghcr.io/zarf-dev/zarf/agent:v0.50.0"}},
+		{Name: "second", Images: []string{"docker.io/library/nginx:1.25", "nginx:1.25", "busybox:1.36"}},
+		{Name: "mirrored", Images: []string{"nginx:1.25", "registry:2"}},
+	}
+
+	refs, shared, err := packageImages(components, []string{"mirrored"})
+	require.NoError(t, err)
+	names := []string{}
+	for _, ref := range refs {
+		names = append(names, ref.Reference)
+	}
+	expected := []string{
+		"docker.io/library/nginx:1.25",
+		"ghcr.io/zarf-dev/zarf/agent:v0.50.0",
+		"docker.io/library/busybox:1.36",
+	}
+	require.Equal(t, expected, names)
+	require.Equal(t, map[string][]string{"docker.io/library/nginx:1.25": {"first", "second"}}, shared)
+
+	_, shared, err = packageImages(components[:1], nil)
+	require.NoError(t, err)
+	require.Nil(t, shared)
+
+	_, _, err = packageImages([]v1alpha1.ZarfComponent{{Name: "invalid", Images: []string{"INVALID"}}}, nil)
+	require.ErrorContains(t, err, "failed to create ref for image INVALID")
+}
+
+func TestChartDependencies(t *testing.T) {
+	t.Parallel()
+
+	newChart := func(name string, deps ...*chart.Chart) *chart.Chart {
+		c := &chart.Chart{
+			Metadata: &chart.Metadata{APIVersion: chart.APIVersionV2, Name: name, Version: "1.0.0"},
+			Templates: []*chart.File{
+				{Name: "templates/configmap.yaml", Data: []byte("apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: " + name + "\n")},
+			},
+		}
+		for _, dep := range deps {
+			c.Metadata.Dependencies = append(c.Metadata.Dependencies, &chart.Dependency{
+				Name:       dep.Name(),
+				Version:    dep.Metadata.Version,
+				Repository: "https://charts.example.com",
+			})
+			c.AddDependency(dep)
+		}
+		return c
+	}
+	component := v1alpha1.ZarfComponent{
+		Name:   "app",
+		Charts: []v1alpha1.ZarfChart{{Name: "app", Version: "1.0.0"}},
+	}
+	createComponentTar := func(t *testing.T, c *chart.Chart) string {
+		t.Helper()
+		compDir := filepath.Join(t.TempDir(), component.Name)
+		chartsDir := filepath.Join(compDir, string(ChartsComponentDir))
+		require.NoError(t, os.MkdirAll(chartsDir, 0o700))
+		_, err := chartutil.Save(c, chartsDir)
+		require.NoError(t, err)
+		tarPath := filepath.Join(t.TempDir(), "app.tar")
+		require.NoError(t, createReproducibleTarballFromDir(compDir, component.Name, tarPath, false))
+		return tarPath
+	}
+
+	tarPath := createComponentTar(t, newChart("app", newChart("redis")))
+	deps, err := chartDependencies(tarPath, component)
+	require.NoError(t, err)
+	require.Len(t, deps, 1)
+	require.Equal(t, "app", deps[0].Component)
+	require.Equal(t, "app", deps[0].Chart)
+	require.Equal(t, "redis", deps[0].Name)
+	require.Equal(t, "1.0.0", deps[0].Version)
+	require.Equal(t, "https://charts.example.com", deps[0].Repository)
+	require.Regexp(t, "^sha256:[0-9a-f]{64}$", deps[0].Digest)
+
+	// The digest does not depend on how the chart was archived.
+	again, err := chartDependencies(createComponentTar(t, newChart("app", newChart("redis"))), component)
+	require.NoError(t, err)
+	require.Equal(t, deps, again)
+
+	chartArchive := filepath.Join(t.TempDir(), "app-1.0.0.tgz")
+	_, err = chartutil.Save(newChart("app", newChart("redis")), filepath.Dir(chartArchive))
+	require.NoError(t, err)
+	require.NoError(t, helm.VerifyChartDependencies(chartArchive, deps))
+	tampered := deps[0]
+	tampered.Digest = "sha256:0000"
+	require.ErrorContains(t, helm.VerifyChartDependencies(chartArchive, []v1alpha1.ZarfChartDependency{tampered}), "dependency redis of chart app has digest")
+
+	missing := newChart("app", newChart("redis"))
+	missing.SetDependencies()
+	_, err = chartDependencies(createComponentTar(t, missing), component)
+	require.EqualError(t, err, "chart app: found in Chart.yaml, but missing in charts/ directory: redis")
+}
+
+func TestRecordPackageMetadataSourceDateEpoch(t *testing.T) {
+	t.Setenv(SourceDateEpochEnv, "1700000000")
+	pkg, err := recordPackageMetadata(v1alpha1.ZarfPackage{Metadata: v1alpha1.ZarfMetadata{Architecture: "amd64"}}, "", nil)
+	require.NoError(t, err)
+	require.Equal(t, "Tue, 14 Nov 2023 22:13:20 +0000", pkg.Build.Timestamp)
+	require.Empty(t, pkg.Build.User)
+	require.Empty(t, pkg.Build.Terminal)
+	require.Equal(t, "amd64", pkg.Build.Architecture)
+
+	t.Setenv(SourceDateEpochEnv, "yesterday")
+	_, err = recordPackageMetadata(v1alpha1.ZarfPackage{}, "", nil)
+	require.EqualError(t, err, `SOURCE_DATE_EPOCH must be a non-negative number of seconds since the Unix epoch, got "yesterday"`)
+}
+
+func TestFetchComponentSources(t *testing.T) {
+	t.Parallel()
+
+	ctx := testutil.TestContext(t)
+	packagePath := filepath.Join("testdata", "zarf-package")
+	component := v1alpha1.ZarfComponent{
+		Name: "sources",
+		Charts: []v1alpha1.ZarfChart{
+			{Name: "podinfo-a", Version: "6.4.0", LocalPath: "chart", ValuesFiles: []string{"values.yaml"}},
+			{Name: "podinfo-b", Version: "6.4.0", LocalPath: "chart"},
+		},
+		Files: []v1alpha1.ZarfFile{
+			{Source: "data.txt", Target: "data.txt"},
+			{Source: "archive.tar", ExtractPath: "archive-data.txt", Target: "archive-data.txt"},
+			{Source: "deployment.yaml", Target: "deployment.yaml", Executable: true},
+		},
+	}
+	compBuildPath := t.TempDir()
+	err := fetchComponentSources(ctx, component, packagePath, compBuildPath)
+	require.NoError(t, err)
+
+	chartsPath := filepath.Join(compBuildPath, string(ChartsComponentDir))
+	require.FileExists(t, filepath.Join(chartsPath, "podinfo-a-6.4.0.tgz"))
+	require.FileExists(t, filepath.Join(chartsPath, "podinfo-b-6.4.0.tgz"))
+	entries, err := os.ReadDir(chartsPath)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	require.FileExists(t, filepath.Join(compBuildPath, string(ValuesComponentDir), "podinfo-a-6.4.0-0"))
+	filesPath := filepath.Join(compBuildPath, string(FilesComponentDir))
+	require.FileExists(t, filepath.Join(filesPath, "0", "data.txt"))
+	require.FileExists(t, filepath.Join(filesPath, "1", "archive-data.txt"))
+	fi, err := os.Stat(filepath.Join(filesPath, "2", "deployment.yaml"))
+	require.NoError(t, err)
+	require.Equal(t, fs.FileMode(helpers.ReadWriteExecuteUser), fi.Mode().Perm())
+
+	component.Files = append(component.Files, v1alpha1.ZarfFile{Source: "missing.txt", Target: "missing.txt"})
+	err = fetchComponentSources(ctx, component, packagePath, t.TempDir())
+	require.ErrorContains(t, err, "unable to copy file missing.txt")
+}
@@ -8,6 +8,7 @@ import (
 	"crypto/sha256"
 	"errors"
 	"fmt"
+	"maps"
 	"os"
 	"path/filepath"
 	"slices"
@@ -21,6 +22,7 @@ import (
 	"github.com/zarf-dev/zarf/src/api/v1alpha1"
 	"github.com/zarf-dev/zarf/src/config"
 	"github.com/zarf-dev/zarf/src/pkg/layout"
+	"github.com/zarf-dev/zarf/src/pkg/logger"
 	"github.com/zarf-dev/zarf/src/pkg/zoci"
 )
 
@@ -86,6 +88,17 @@ func resolveImports(ctx context.Context, pkg v1alpha1.ZarfPackage, packagePath,
 				return v1alpha1.ZarfPackage{}, err
 			}
 		} else if component.Import.URL != "" {
+			resolvedURL, err := zoci.ResolveVersionConstraint(ctx, component.Import.URL, zoci.PlatformForSkeleton())
+			if err != nil {
+				return v1alpha1.ZarfPackage{}, fmt.Errorf("unable to resolve import for component %s: %w", component.Name, err)
+			}
+			if resolvedURL != component.Import.URL {
+				logger.From(ctx).Info("resolved versioned component import",
+					"component", component.Name,
+					"constraint", component.Import.URL,
+					"resolved", resolvedURL)
+				component.Import.URL = resolvedURL
+			}
 			remote, err := zoci.NewRemote(ctx, component.Import.URL, zoci.PlatformForSkeleton())
 			if err != nil {
 				return v1alpha1.ZarfPackage{}, err
@@ -280,6 +293,24 @@ func overrideMetadata(comp v1alpha1.ZarfComponent, override v1alpha1.ZarfCompone
 		}
 		comp.Only.LocalOS = override.Only.LocalOS
 	}
+
+	if override.ImagePlatform != "" {
+		if comp.ImagePlatform != "" && comp.ImagePlatform != override.ImagePlatform {
+			return v1alpha1.ZarfComponent{}, fmt.Errorf("component %q: \"imagePlatform\" %q cannot be redefined as %q during compose", comp.Name, comp.ImagePlatform, override.ImagePlatform)
+		}
+		comp.ImagePlatform = override.ImagePlatform
+	}
+
+	// The importing component knows the footprint of its workloads best
+	if override.Requirements.CPU != "" {
+		comp.Requirements.CPU = override.Requirements.CPU
+	}
+	if override.Requirements.Memory != "" {
+		comp.Requirements.Memory = override.Requirements.Memory
+	}
+	if override.Requirements.Storage != "" {
+		comp.Requirements.Storage = override.Requirements.Storage
+	}
 	return comp, nil
 }
 
@@ -332,6 +363,20 @@ func overrideResources(comp v1alpha1.ZarfComponent, override v1alpha1.ZarfCompon
 	comp.DataInjections = append(comp.DataInjections, override.DataInjections...)
 	comp.Files = append(comp.Files, override.Files...)
 	comp.Images = append(comp.Images, override.Images...)
+	if len(override.RegistryOverrides) > 0 {
+		overrides := maps.Clone(comp.RegistryOverrides)
+		if overrides == nil {
+			overrides = map[string]string{}
+		}
+		maps.Copy(overrides, override.RegistryOverrides)
+		comp.RegistryOverrides = overrides
+	}
+	comp.Artifacts = append(comp.Artifacts, override.Artifacts...)
+	comp.HostArtifacts = append(comp.HostArtifacts, override.HostArtifacts...)
+	comp.HostServices = append(comp.HostServices, override.HostServices...)
+	comp.Tofu = append(comp.Tofu, override.Tofu...)
+	comp.Builds = append(comp.Builds, override.Builds...)
+	comp.Namespaces = append(comp.Namespaces, override.Namespaces...)
 	comp.Repos = append(comp.Repos, override.Repos...)
 
 	// Merge charts with the same name to keep them unique
@@ -397,6 +442,28 @@ func fixPaths(child v1alpha1.ZarfComponent, relativeToHead, packagePath string)
 		child.Files[fileIdx].Source = composed
 	}
 
+	for hostArtifactIdx, hostArtifact := range child.HostArtifacts {
+		for sourceIdx, src := range hostArtifact.Sources {
+			composed := makePathRelativeTo(src.Source, relativeToHead)
+			child.HostArtifacts[hostArtifactIdx].Sources[sourceIdx].Source = composed
+		}
+	}
+
+	for hostServiceIdx, hostService := range child.HostServices {
+		composed := makePathRelativeTo(hostService.Source, relativeToHead)
+		child.HostServices[hostServiceIdx].Source = composed
+	}
+
+	for moduleIdx, module := range child.Tofu {
+		composed := makePathRelativeTo(module.Source, relativeToHead)
+		child.Tofu[moduleIdx].Source = composed
+	}
+
+	for buildIdx, build := range child.Builds {
+		composed := makePathRelativeTo(build.Context, relativeToHead)
+		child.Builds[buildIdx].Context = composed
+	}
+
 	for chartIdx, chart := range child.Charts {
 		for valuesIdx, valuesFile := range chart.ValuesFiles {
 			composed := makePathRelativeTo(valuesFile, relativeToHead)
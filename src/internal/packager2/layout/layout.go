@@ -16,6 +16,9 @@ const (
 	Signature = "zarf.yaml.sig"
 	Checksums = "checksums.txt"
 
+	ReleaseNotes  = "release-notes.md"
+	BuildInfoFile = "build-info.json"
+
 	ImagesDir     = "images"
 	ComponentsDir = "components"
 
@@ -31,12 +34,17 @@ type ComponentDir string
 
 // Different component directory types.
 const (
-	RepoComponentDir      ComponentDir = "repos"
-	FilesComponentDir     ComponentDir = "files"
-	ChartsComponentDir    ComponentDir = "charts"
-	ManifestsComponentDir ComponentDir = "manifests"
-	DataComponentDir      ComponentDir = "data"
-	ValuesComponentDir    ComponentDir = "values"
+	RepoComponentDir          ComponentDir = "repos"
+	FilesComponentDir         ComponentDir = "files"
+	ChartsComponentDir        ComponentDir = "charts"
+	ManifestsComponentDir     ComponentDir = "manifests"
+	DataComponentDir          ComponentDir = "data"
+	ValuesComponentDir        ComponentDir = "values"
+	ArtifactsComponentDir     ComponentDir = "artifacts"
+	HostArtifactsComponentDir ComponentDir = "hostartifacts"
+	HostServicesComponentDir  ComponentDir = "hostservices"
+	TofuComponentDir          ComponentDir = "tofu"
+	BuildsComponentDir        ComponentDir = "builds"
 )
 
 // ParseZarfPackage parses the yaml passed as a byte slice and applies potential schema migrations.
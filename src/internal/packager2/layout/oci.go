@@ -9,19 +9,27 @@ import (
 	"fmt"
 	"log/slog"
 	"maps"
+	"slices"
 	"strings"
+	"sync/atomic"
+	"time"
 
+	"github.com/avast/retry-go/v4"
 	"github.com/defenseunicorns/pkg/helpers/v2"
 	"github.com/defenseunicorns/pkg/oci"
 	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"go.opentelemetry.io/otel/attribute"
 	"oras.land/oras-go/v2"
 	"oras.land/oras-go/v2/content/file"
 	"oras.land/oras-go/v2/registry"
 
 	"github.com/zarf-dev/zarf/src/api/v1alpha1"
 	"github.com/zarf-dev/zarf/src/config"
+	"github.com/zarf-dev/zarf/src/internal/tracing"
+	"github.com/zarf-dev/zarf/src/pkg/events"
 	"github.com/zarf-dev/zarf/src/pkg/logger"
 	"github.com/zarf-dev/zarf/src/pkg/message"
+	"github.com/zarf-dev/zarf/src/pkg/utils"
 )
 
 const (
@@ -29,11 +37,15 @@ const (
 	ZarfConfigMediaType = "application/vnd.zarf.config.v1+json"
 	// ZarfLayerMediaTypeBlob is the media type for all Zarf layers due to the range of possible content
 	ZarfLayerMediaTypeBlob = "application/vnd.zarf.layer.v1.blob"
+	// pushAttempts is the number of times copying a package to a registry is attempted before giving up
+	pushAttempts = 3
 )
 
 // Remote is a wrapper around the Oras remote repository with zarf specific functions
 type Remote struct {
 	orasRemote *oci.OrasRemote
+	// chunkSize is the size of the chunks blobs larger than it are uploaded in.
+	chunkSize int64
 }
 
 // NewRemote returns an oras remote repository client and context for the given url with zarf opination embedded.
@@ -52,11 +64,17 @@ func NewRemote(ctx context.Context, url string, platform ocispec.Platform, mods
 	if err != nil {
 		return nil, err
 	}
-	return &Remote{orasRemote: remote}, nil
+	return &Remote{orasRemote: remote, chunkSize: defaultBlobChunkSize}, nil
 }
 
-// Push pushes the given package layout to the remote registry.
-func (r *Remote) Push(ctx context.Context, pkgLayout *PackageLayout, concurrency int) (err error) {
+// Push pushes the given package layout to the remote registry and tags it with any additional tags.
+func (r *Remote) Push(ctx context.Context, pkgLayout *PackageLayout, concurrency int, additionalTags ...string) (err error) {
+	ctx, span := tracing.Start(ctx, "push", attribute.String("destination", r.orasRemote.Repo().Reference.String()))
+	events.From(ctx).Phase("push", events.StatusStarted, nil)
+	defer func() {
+		events.From(ctx).Phase("push", events.Done(err), err)
+		tracing.End(span, err)
+	}()
 	logger.From(ctx).Info("pushing package to registry",
 		"destination", r.orasRemote.Repo().Reference.String(),
 		"architecture", pkgLayout.Pkg.Metadata.Architecture)
@@ -83,7 +101,18 @@ func (r *Remote) Push(ctx context.Context, pkgLayout *PackageLayout, concurrency
 		descs = append(descs, desc)
 	}
 
+	// Order the layers by name so the manifest of the package does not depend on the order the files are listed in.
+	slices.SortFunc(descs, func(a, b ocispec.Descriptor) int {
+		return strings.Compare(a.Annotations[ocispec.AnnotationTitle], b.Annotations[ocispec.AnnotationTitle])
+	})
+
 	annotations := annotationsFromMetadata(pkgLayout.Pkg.Metadata)
+	// The manifest is otherwise annotated with the time it is pushed at.
+	if _, ok := annotations[ocispec.AnnotationCreated]; !ok {
+		if created, err := time.Parse(time.RFC1123Z, pkgLayout.Pkg.Build.Timestamp); err == nil {
+			annotations[ocispec.AnnotationCreated] = created.UTC().Format(time.RFC3339)
+		}
+	}
 	manifestConfigDesc, err := r.orasRemote.CreateAndPushManifestConfig(ctx, annotations, ZarfConfigMediaType)
 	if err != nil {
 		return err
@@ -93,9 +122,7 @@ func (r *Remote) Push(ctx context.Context, pkgLayout *PackageLayout, concurrency
 		return err
 	}
 
-	copyOpts := r.orasRemote.GetDefaultCopyOpts()
-	copyOpts.Concurrency = concurrency
-	publishedDesc, err := oras.Copy(ctx, src, root.Digest.String(), r.orasRemote.Repo(), "", copyOpts)
+	publishedDesc, err := r.copyToRemote(ctx, src, root.Digest.String(), concurrency)
 	if err != nil {
 		return err
 	}
@@ -104,10 +131,74 @@ func (r *Remote) Push(ctx context.Context, pkgLayout *PackageLayout, concurrency
 	if err != nil {
 		return err
 	}
+	for _, tag := range additionalTags {
+		logger.From(ctx).Info("tagging package", "tag", tag)
+		err = r.orasRemote.UpdateIndex(ctx, tag, publishedDesc)
+		if err != nil {
+			return err
+		}
+	}
 
 	return nil
 }
 
+// copyToRemote copies the graph of the root manifest from src to the remote repository. The copy checks which blobs
+// already exist in the repository with HEAD requests and skips them, so failed copies are retried without pushing
+// completed blobs twice and an interrupted publish can be resumed by re-running it. Blobs larger than the chunk size
+// are uploaded in chunks, and a failed chunk resumes the upload from the bytes the registry received.
+func (r *Remote) copyToRemote(ctx context.Context, src oras.ReadOnlyTarget, root string, concurrency int) (ocispec.Descriptor, error) {
+	copyOpts := r.orasRemote.GetDefaultCopyOpts()
+	copyOpts.Concurrency = concurrency
+	uploader := &chunkedUploader{repo: r.orasRemote.Repo(), src: src, chunkSize: r.chunkSize}
+	postCopy := copyOpts.PostCopy
+	copyOpts.PreCopy = func(ctx context.Context, desc ocispec.Descriptor) error {
+		if desc.Size <= uploader.chunkSize || desc.MediaType == ocispec.MediaTypeImageManifest || desc.MediaType == ocispec.MediaTypeImageIndex {
+			return nil
+		}
+		err := uploader.Upload(ctx, desc)
+		if errors.Is(err, errChunkedUploadUnsupported) {
+			// Fall back to pushing the blob in a single request.
+			logger.From(ctx).Debug("pushing blob in a single request", "digest", desc.Digest, "reason", err)
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if postCopy != nil {
+			if err := postCopy(ctx, desc); err != nil {
+				return err
+			}
+		}
+		return oras.SkipNode
+	}
+	var skipped, skippedSize atomic.Int64
+	onCopySkipped := copyOpts.OnCopySkipped
+	copyOpts.OnCopySkipped = func(ctx context.Context, desc ocispec.Descriptor) error {
+		skipped.Add(1)
+		skippedSize.Add(desc.Size)
+		if onCopySkipped == nil {
+			return nil
+		}
+		return onCopySkipped(ctx, desc)
+	}
+
+	var published ocispec.Descriptor
+	err := retry.Do(func() error {
+		skipped.Store(0)
+		skippedSize.Store(0)
+		var err error
+		published, err = oras.Copy(ctx, src, root, r.orasRemote.Repo(), "", copyOpts)
+		return err
+	}, retry.Context(ctx), retry.Attempts(pushAttempts), retry.Delay(500*time.Millisecond), retry.LastErrorOnly(true))
+	if err != nil {
+		return ocispec.Descriptor{}, fmt.Errorf("unable to push the package, re-run publish to resume: %w", err)
+	}
+	logger.From(ctx).Info("skipped blobs that already exist in the registry",
+		"count", skipped.Load(),
+		"size", utils.ByteFormat(float64(skippedSize.Load()), 2))
+	return published, nil
+}
+
 func ReferenceFromMetadata(registryLocation string, pkg v1alpha1.ZarfPackage) (string, error) {
 	if len(pkg.Metadata.Version) == 0 {
 		return "", errors.New("version is required for publishing")
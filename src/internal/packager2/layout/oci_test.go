@@ -4,12 +4,47 @@
 package layout
 
 import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
 	"testing"
 
+	"github.com/defenseunicorns/pkg/helpers/v2"
+	"github.com/defenseunicorns/pkg/oci"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 	"github.com/stretchr/testify/require"
+	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/content/memory"
+	"oras.land/oras-go/v2/registry/remote"
+
 	"github.com/zarf-dev/zarf/src/api/v1alpha1"
+	"github.com/zarf-dev/zarf/src/test/testutil"
 )
 
+// flakyTarget fails the first fetch of every blob and counts the number of fetches.
+type flakyTarget struct {
+	*memory.Store
+	mu      sync.Mutex
+	fetches map[string]int
+}
+
+func (f *flakyTarget) Fetch(ctx context.Context, desc ocispec.Descriptor) (io.ReadCloser, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if desc.MediaType == ZarfLayerMediaTypeBlob {
+		f.fetches[desc.Digest.String()]++
+		if f.fetches[desc.Digest.String()] == 1 {
+			return nil, errors.New("connection reset")
+		}
+	}
+	return f.Store.Fetch(ctx, desc)
+}
+
 func TestAnnotationsFromMetadata(t *testing.T) {
 	t.Parallel()
 
@@ -39,3 +74,103 @@ func TestAnnotationsFromMetadata(t *testing.T) {
 	}
 	require.Equal(t, expectedAnnotations, annotations)
 }
+
+func TestCopyToRemote(t *testing.T) {
+	t.Parallel()
+
+	ctx := testutil.TestContext(t)
+	port, err := helpers.GetAvailablePort()
+	require.NoError(t, err)
+	registryURL := testutil.SetupInMemoryRegistry(ctx, t, port)
+	remote, err := NewRemote(ctx, fmt.Sprintf("%s/test:0.0.1", registryURL), oci.PlatformForArch("amd64"), oci.WithPlainHTTP(true))
+	require.NoError(t, err)
+
+	src := &flakyTarget{
+		Store:   memory.New(),
+		fetches: map[string]int{},
+	}
+	descs := []ocispec.Descriptor{}
+	for _, b := range [][]byte{[]byte("existing"), []byte("missing")} {
+		desc := content.NewDescriptorFromBytes(ZarfLayerMediaTypeBlob, b)
+		require.NoError(t, src.Push(ctx, desc, bytes.NewReader(b)))
+		descs = append(descs, desc)
+	}
+	root, err := oras.PackManifest(ctx, src, oras.PackManifestVersion1_1, ZarfConfigMediaType, oras.PackManifestOptions{Layers: descs})
+	require.NoError(t, err)
+	require.NoError(t, src.Tag(ctx, root, root.Digest.String()))
+	err = remote.orasRemote.Repo().Push(ctx, descs[0], bytes.NewReader([]byte("existing")))
+	require.NoError(t, err)
+
+	// Existing blobs are skipped and the copy is retried when pushing a missing blob fails.
+	published, err := remote.copyToRemote(ctx, src, root.Digest.String(), 2)
+	require.NoError(t, err)
+	require.Equal(t, root.Digest, published.Digest)
+	require.Equal(t, map[string]int{descs[1].Digest.String(): 2}, src.fetches)
+
+	// Re-running only checks for existence.
+	_, err = remote.copyToRemote(ctx, src, root.Digest.String(), 2)
+	require.NoError(t, err)
+	require.Equal(t, map[string]int{descs[1].Digest.String(): 2}, src.fetches)
+}
+
+// droppingClient forwards requests to the registry and drops the response of the second chunk, as if the connection
+// was reset after the registry received it.
+type droppingClient struct {
+	remote.Client
+	mu          sync.Mutex
+	patches     int
+	patchedSize int64
+}
+
+func (c *droppingClient) Do(req *http.Request) (*http.Response, error) {
+	resp, err := c.Client.Do(req)
+	if err != nil || req.Method != http.MethodPatch {
+		return resp, err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.patches++
+	c.patchedSize += req.ContentLength
+	if c.patches == 2 {
+		resp.Body.Close()
+		return nil, errors.New("connection reset")
+	}
+	return resp, nil
+}
+
+func TestCopyToRemoteChunked(t *testing.T) {
+	t.Parallel()
+
+	ctx := testutil.TestContext(t)
+	port, err := helpers.GetAvailablePort()
+	require.NoError(t, err)
+	registryURL := testutil.SetupInMemoryRegistry(ctx, t, port)
+	r, err := NewRemote(ctx, fmt.Sprintf("%s/test:0.0.1", registryURL), oci.PlatformForArch("amd64"), oci.WithPlainHTTP(true))
+	require.NoError(t, err)
+	r.chunkSize = 4
+	repo := r.orasRemote.Repo()
+	client := &droppingClient{Client: repo.Client}
+	repo.Client = client
+
+	src := memory.New()
+	b := []byte("0123456789abcdef")
+	desc := content.NewDescriptorFromBytes(ZarfLayerMediaTypeBlob, b)
+	require.NoError(t, src.Push(ctx, desc, bytes.NewReader(b)))
+	root, err := oras.PackManifest(ctx, src, oras.PackManifestVersion1_1, ZarfConfigMediaType, oras.PackManifestOptions{Layers: []ocispec.Descriptor{desc}})
+	require.NoError(t, err)
+	require.NoError(t, src.Tag(ctx, root, root.Digest.String()))
+
+	// The upload resumes after the dropped chunk without sending it again.
+	_, err = r.copyToRemote(ctx, src, root.Digest.String(), 1)
+	require.NoError(t, err)
+	require.Equal(t, 4, client.patches)
+	require.Equal(t, desc.Size, client.patchedSize)
+	pushed, err := content.FetchAll(ctx, repo, desc)
+	require.NoError(t, err)
+	require.Equal(t, b, pushed)
+
+	// Re-running skips the existing blob.
+	_, err = r.copyToRemote(ctx, src, root.Digest.String(), 1)
+	require.NoError(t, err)
+	require.Equal(t, 4, client.patches)
+}
@@ -0,0 +1,147 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package layout
+
+import (
+	"fmt"
+	"os"
+
+	goyaml "github.com/goccy/go-yaml"
+
+	"github.com/zarf-dev/zarf/src/api/v1alpha1"
+)
+
+const (
+	// overlayMergeKey is the key used to match elements of object lists between a package and an overlay.
+	overlayMergeKey = "name"
+	// overlayPatchKey is the directive key that controls how an element of an object list is merged.
+	overlayPatchKey = "$patch"
+	// overlayPatchDelete removes the matching element from the package.
+	overlayPatchDelete = "delete"
+)
+
+// applyOverlays patches the package definition with each overlay file in order.
+//
+// Overlays are partial package definitions merged using strategic-merge semantics:
+// maps are merged recursively, lists of objects are merged by their name key,
+// and all other values (including lists of strings such as images) replace the existing value.
+// An element of an object list can be removed by setting "$patch: delete" on it.
+func applyOverlays(pkg v1alpha1.ZarfPackage, overlayPaths []string) (v1alpha1.ZarfPackage, error) {
+	if len(overlayPaths) == 0 {
+		return pkg, nil
+	}
+	b, err := goyaml.Marshal(pkg)
+	if err != nil {
+		return v1alpha1.ZarfPackage{}, err
+	}
+	base := map[string]any{}
+	err = goyaml.Unmarshal(b, &base)
+	if err != nil {
+		return v1alpha1.ZarfPackage{}, err
+	}
+	for _, overlayPath := range overlayPaths {
+		b, err := os.ReadFile(overlayPath)
+		if err != nil {
+			return v1alpha1.ZarfPackage{}, fmt.Errorf("unable to read overlay: %w", err)
+		}
+		overlay := map[string]any{}
+		err = goyaml.Unmarshal(b, &overlay)
+		if err != nil {
+			return v1alpha1.ZarfPackage{}, fmt.Errorf("unable to parse overlay %s: %w", overlayPath, err)
+		}
+		base, err = mergeOverlayMap(base, overlay)
+		if err != nil {
+			return v1alpha1.ZarfPackage{}, fmt.Errorf("unable to apply overlay %s: %w", overlayPath, err)
+		}
+	}
+	b, err = goyaml.Marshal(base)
+	if err != nil {
+		return v1alpha1.ZarfPackage{}, err
+	}
+	return ParseZarfPackage(b)
+}
+
+func mergeOverlayMap(base, overlay map[string]any) (map[string]any, error) {
+	for k, overlayValue := range overlay {
+		baseValue, ok := base[k]
+		if !ok {
+			base[k] = overlayValue
+			continue
+		}
+		merged, err := mergeOverlayValue(baseValue, overlayValue)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", k, err)
+		}
+		base[k] = merged
+	}
+	return base, nil
+}
+
+func mergeOverlayValue(base, overlay any) (any, error) {
+	switch overlayValue := overlay.(type) {
+	case map[string]any:
+		baseValue, ok := base.(map[string]any)
+		if !ok {
+			return overlayValue, nil
+		}
+		return mergeOverlayMap(baseValue, overlayValue)
+	case []any:
+		baseValue, ok := base.([]any)
+		if !ok || !isNamedList(baseValue) || !isNamedList(overlayValue) {
+			return overlayValue, nil
+		}
+		return mergeOverlayList(baseValue, overlayValue)
+	default:
+		return overlay, nil
+	}
+}
+
+func mergeOverlayList(base, overlay []any) ([]any, error) {
+	for _, item := range overlay {
+		overlayItem := item.(map[string]any)
+		name := overlayItem[overlayMergeKey]
+		patch, hasPatch := overlayItem[overlayPatchKey]
+		if hasPatch && patch != overlayPatchDelete {
+			return nil, fmt.Errorf("unsupported %s directive %q for %v", overlayPatchKey, patch, name)
+		}
+		delete(overlayItem, overlayPatchKey)
+
+		idx := -1
+		for i, baseItem := range base {
+			if baseItem.(map[string]any)[overlayMergeKey] == name {
+				idx = i
+				break
+			}
+		}
+		switch {
+		case hasPatch && idx == -1:
+			return nil, fmt.Errorf("cannot delete %v as it does not exist", name)
+		case hasPatch:
+			base = append(base[:idx], base[idx+1:]...)
+		case idx == -1:
+			base = append(base, overlayItem)
+		default:
+			merged, err := mergeOverlayMap(base[idx].(map[string]any), overlayItem)
+			if err != nil {
+				return nil, fmt.Errorf("%v: %w", name, err)
+			}
+			base[idx] = merged
+		}
+	}
+	return base, nil
+}
+
+// isNamedList returns true if every element of the list is an object with a name key.
+func isNamedList(list []any) bool {
+	for _, item := range list {
+		m, ok := item.(map[string]any)
+		if !ok {
+			return false
+		}
+		if _, ok := m[overlayMergeKey]; !ok {
+			return false
+		}
+	}
+	return true
+}
@@ -6,6 +6,7 @@ package layout
 import (
 	"archive/tar"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
@@ -13,6 +14,7 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/defenseunicorns/pkg/helpers/v2"
 	registryv1 "github.com/google/go-containerregistry/pkg/v1"
@@ -24,6 +26,9 @@ import (
 
 	"github.com/zarf-dev/zarf/src/api/v1alpha1"
 	"github.com/zarf-dev/zarf/src/config"
+	"github.com/zarf-dev/zarf/src/internal/fips"
+	"github.com/zarf-dev/zarf/src/internal/tracing"
+	"github.com/zarf-dev/zarf/src/pkg/exitcode"
 	"github.com/zarf-dev/zarf/src/pkg/logger"
 	"github.com/zarf-dev/zarf/src/pkg/message"
 	"github.com/zarf-dev/zarf/src/pkg/packager/sources"
@@ -109,6 +114,12 @@ func LoadFromDir(ctx context.Context, dirPath string, opt PackageLayoutOptions)
 	if err != nil {
 		return nil, err
 	}
+	if config.CommonOptions.FIPS {
+		err = fips.ValidatePackage(pkg)
+		if err != nil {
+			return nil, err
+		}
+	}
 	return pkgLayout, nil
 }
 
@@ -121,6 +132,31 @@ func (p *PackageLayout) Cleanup() error {
 	return nil
 }
 
+// IsSigned returns true if the package has a signature.
+func (p *PackageLayout) IsSigned() (bool, error) {
+	_, err := os.Stat(filepath.Join(p.dirPath, Signature))
+	if errors.Is(err, os.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// Sign signs the package definition with the key at signingKeyPath, replacing any existing signature. The checksums
+// of the package are not changed as the signature is not part of them.
+func (p *PackageLayout) Sign(signingKeyPath, signingKeyPassword string) error {
+	if signingKeyPath == "" {
+		return errors.New("a signing key is required to sign the package")
+	}
+	err := os.Remove(filepath.Join(p.dirPath, Signature))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return signPackage(p.dirPath, signingKeyPath, signingKeyPassword)
+}
+
 // NoSBOMAvailableError is returned when a user tries to access a package SBOM, but it is not available
 type NoSBOMAvailableError struct {
 	pkgName string
@@ -143,6 +179,34 @@ func (p *PackageLayout) GetSBOM(destPath string) (string, error) {
 	return path, nil
 }
 
+// GetReleaseNotes returns the release notes embedded in the package.
+func (p *PackageLayout) GetReleaseNotes() (string, error) {
+	if p.Pkg.Metadata.ReleaseNotes == "" {
+		return "", fmt.Errorf("zarf package %s does not have release notes", p.Pkg.Metadata.Name)
+	}
+	b, err := os.ReadFile(filepath.Join(p.dirPath, ReleaseNotes))
+	if err != nil {
+		return "", fmt.Errorf("unable to read the release notes: %w", err)
+	}
+	return string(b), nil
+}
+
+// GetBuildInfo returns the build info embedded in the package.
+func (p *PackageLayout) GetBuildInfo() (BuildInfo, error) {
+	b, err := os.ReadFile(filepath.Join(p.dirPath, BuildInfoFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return BuildInfo{}, fmt.Errorf("zarf package %s does not have build info, it was created with an older version of Zarf", p.Pkg.Metadata.Name)
+	}
+	if err != nil {
+		return BuildInfo{}, fmt.Errorf("unable to read the build info: %w", err)
+	}
+	var info BuildInfo
+	if err := json.Unmarshal(b, &info); err != nil {
+		return BuildInfo{}, fmt.Errorf("unable to parse the build info: %w", err)
+	}
+	return info, nil
+}
+
 // GetComponentDir returns a path to the directory in the given component.
 func (p *PackageLayout) GetComponentDir(destPath, componentName string, ct ComponentDir) (string, error) {
 	sourcePath := filepath.Join(p.dirPath, ComponentsDir, fmt.Sprintf("%s.tar", componentName))
@@ -204,24 +268,33 @@ func (p *PackageLayout) GetImage(ref transform.Image) (registryv1.Image, error)
 	return nil, fmt.Errorf("unable to find the image %s", ref.Reference)
 }
 
-func (p *PackageLayout) Archive(ctx context.Context, dirPath string, maxPackageSize int) error {
+// GetImageIndex returns the image index of the platforms kept for the image with the given reference, or nil if only
+// the platform of the package is in the package layout.
+func (p *PackageLayout) GetImageIndex(ref transform.Image) (registryv1.ImageIndex, error) {
+	return utils.LoadOCIImageIndex(filepath.Join(p.dirPath, ImagesDir), ref)
+}
+
+func (p *PackageLayout) Archive(ctx context.Context, dirPath string, maxPackageSize int) (err error) {
+	_, span := tracing.Start(ctx, "save")
+	defer func() {
+		tracing.End(span, err)
+	}()
 	packageName := fmt.Sprintf("%s%s", sources.NameFromMetadata(&p.Pkg, false), sources.PkgSuffix(p.Pkg.Metadata.Uncompressed))
 	tarballPath := filepath.Join(dirPath, packageName)
-	err := os.Remove(tarballPath)
+	err = os.Remove(tarballPath)
 	if err != nil && !errors.Is(err, os.ErrNotExist) {
 		return err
 	}
 	message.Notef("Saving package to path %s", tarballPath)
 	logger.From(ctx).Info("writing package to disk", "path", tarballPath)
-	files, err := os.ReadDir(p.dirPath)
+	modTime, ok, err := sourceDateEpoch()
 	if err != nil {
 		return err
 	}
-	var filePaths []string
-	for _, file := range files {
-		filePaths = append(filePaths, filepath.Join(p.dirPath, file.Name()))
+	if !ok {
+		modTime = time.Unix(0, 0).UTC()
 	}
-	err = archiver.Archive(filePaths, tarballPath)
+	err = archiveReproducible(p.dirPath, tarballPath, modTime)
 	if err != nil {
 		return fmt.Errorf("unable to create package: %w", err)
 	}
@@ -244,6 +317,88 @@ func (p *PackageLayout) Archive(ctx context.Context, dirPath string, maxPackageS
 	return nil
 }
 
+// archiveReproducible writes the files in dirPath to the archive at tarballPath in the format of its extension. Files
+// are written in lexical order with their modification time set to modTime and without an owner, so that identical
+// package layouts produce byte identical archives.
+func archiveReproducible(dirPath, tarballPath string, modTime time.Time) (err error) {
+	format, err := archiver.ByExtension(tarballPath)
+	if err != nil {
+		return err
+	}
+	w, ok := format.(archiver.Writer)
+	if !ok {
+		return fmt.Errorf("format specified by destination filename is not an archive format: %s (%T)", tarballPath, format)
+	}
+	f, err := os.Create(tarballPath)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = errors.Join(err, f.Close())
+	}()
+	if err := w.Create(f); err != nil {
+		return err
+	}
+	defer func() {
+		err = errors.Join(err, w.Close())
+	}()
+	// WalkDir visits the entries of each directory in lexical order.
+	return filepath.WalkDir(dirPath, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if path == dirPath {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(dirPath, path)
+		if err != nil {
+			return err
+		}
+		var rc io.ReadCloser
+		if info.Mode().IsRegular() {
+			file, err := os.Open(path)
+			if err != nil {
+				return err
+			}
+			defer file.Close()
+			rc = file
+		}
+		return w.Write(archiver.File{
+			FileInfo: archiver.FileInfo{
+				FileInfo:   reproducibleFileInfo{FileInfo: info, modTime: modTime},
+				CustomName: filepath.ToSlash(rel),
+				SourcePath: path,
+			},
+			ReadCloser: rc,
+		})
+	})
+}
+
+// reproducibleFileInfo is the file info tar headers are made from with the modification time replaced and the owner
+// of the file left out.
+type reproducibleFileInfo struct {
+	fs.FileInfo
+	modTime time.Time
+}
+
+func (fi reproducibleFileInfo) ModTime() time.Time {
+	return fi.modTime
+}
+
+// Sys returns an empty tar header, which tar headers take the owner and access times of the file from.
+func (fi reproducibleFileInfo) Sys() any {
+	return &tar.Header{}
+}
+
+// Size returns the total size in bytes of the files in the package.
+func (p *PackageLayout) Size() (int64, error) {
+	return helpers.GetDirSize(p.dirPath)
+}
+
 // Files returns a map off all the files in the package.
 func (p *PackageLayout) Files() (map[string]string, error) {
 	files := map[string]string{}
@@ -352,9 +507,9 @@ func validatePackageSignature(ctx context.Context, pkgLayout *PackageLayout, pub
 		// Nobody was expecting a signature, so we can just return
 		return nil
 	} else if sigExist && publicKeyPath == "" {
-		return errors.New("package is signed but no key was provided")
+		return exitcode.Wrap(exitcode.Signature, errors.New("package is signed but no key was provided"))
 	} else if !sigExist && publicKeyPath != "" {
-		return errors.New("a key was provided but the package is not signed")
+		return exitcode.Wrap(exitcode.Signature, errors.New("a key was provided but the package is not signed"))
 	}
 
 	keyOptions := options.KeyOpts{KeyRef: publicKeyPath}
@@ -367,7 +522,7 @@ func validatePackageSignature(ctx context.Context, pkgLayout *PackageLayout, pub
 	}
 	err = cmd.Exec(ctx, filepath.Join(pkgLayout.dirPath, ZarfYAML))
 	if err != nil {
-		return fmt.Errorf("package signature did not match the provided key: %w", err)
+		return exitcode.Wrap(exitcode.Signature, fmt.Errorf("package signature did not match the provided key: %w", err))
 	}
 	return nil
 }
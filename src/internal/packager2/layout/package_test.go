@@ -4,10 +4,14 @@
 package layout
 
 import (
+	"archive/tar"
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
+	"github.com/defenseunicorns/pkg/helpers/v2"
+	"github.com/mholt/archiver/v3"
 	"github.com/stretchr/testify/require"
 
 	"github.com/zarf-dev/zarf/src/pkg/transform"
@@ -75,3 +79,51 @@ func TestPackageLayout(t *testing.T) {
 		require.Equal(t, expectedName, name)
 	}
 }
+
+func TestArchiveReproducible(t *testing.T) {
+	t.Parallel()
+
+	modTime := time.Unix(1700000000, 0).UTC()
+	archive := func(fileTime time.Time) string {
+		dir := t.TempDir()
+		for _, name := range []string{"zarf.yaml", "components/b.tar", "components/a.tar", "images/index.json"} {
+			path := filepath.Join(dir, filepath.FromSlash(name))
+			require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o700))
+			require.NoError(t, os.WriteFile(path, []byte(name), 0o600))
+			require.NoError(t, os.Chtimes(path, fileTime, fileTime))
+		}
+		tarballPath := filepath.Join(t.TempDir(), "package.tar.zst")
+		require.NoError(t, archiveReproducible(dir, tarballPath, modTime))
+		return tarballPath
+	}
+
+	first := archive(time.Now())
+	second := archive(time.Now().Add(-time.Hour))
+	firstSHA, err := helpers.GetSHA256OfFile(first)
+	require.NoError(t, err)
+	secondSHA, err := helpers.GetSHA256OfFile(second)
+	require.NoError(t, err)
+	require.Equal(t, firstSHA, secondSHA)
+
+	names := []string{}
+	err = archiver.Walk(first, func(f archiver.File) error {
+		header, ok := f.Header.(*tar.Header)
+		require.True(t, ok)
+		require.True(t, header.ModTime.Equal(modTime))
+		require.Zero(t, header.Uid)
+		require.Zero(t, header.Gid)
+		require.Empty(t, header.Uname)
+		names = append(names, header.Name)
+		return nil
+	})
+	require.NoError(t, err)
+	expectedNames := []string{
+		"components/",
+		"components/a.tar",
+		"components/b.tar",
+		"images/",
+		"images/index.json",
+		"zarf.yaml",
+	}
+	require.Equal(t, expectedNames, names)
+}
@@ -0,0 +1,200 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package layout
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/defenseunicorns/pkg/helpers/v2"
+	goyaml "github.com/goccy/go-yaml"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+
+	"github.com/zarf-dev/zarf/src/api/v1alpha1"
+	"github.com/zarf-dev/zarf/src/pkg/logger"
+	"github.com/zarf-dev/zarf/src/pkg/transform"
+	"github.com/zarf-dev/zarf/src/pkg/utils"
+)
+
+// Reduce turns the layout of a package into a valid package that holds just the given components. The tarballs of
+// other components and the image blobs only they use are removed and the checksums of the package are recomputed. The
+// signature of the package is removed as it does not cover the reduced package.
+func (p *PackageLayout) Reduce(ctx context.Context, components []v1alpha1.ZarfComponent) error {
+	p.Pkg.Components = components
+
+	if err := reduceComponents(filepath.Join(p.dirPath, ComponentsDir), components); err != nil {
+		return err
+	}
+	if err := reduceImagesIndex(filepath.Join(p.dirPath, ImagesDir), components); err != nil {
+		return err
+	}
+	if err := removeUnusedBlobs(filepath.Join(p.dirPath, ImagesDir)); err != nil {
+		return err
+	}
+
+	err := os.Remove(filepath.Join(p.dirPath, Signature))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	if err == nil {
+		logger.From(ctx).Warn("removed the signature of the package as it does not cover the reduced package")
+	}
+
+	checksumContent, checksumSha, err := getChecksum(p.dirPath)
+	if err != nil {
+		return err
+	}
+	err = os.WriteFile(filepath.Join(p.dirPath, Checksums), []byte(checksumContent), helpers.ReadWriteUser)
+	if err != nil {
+		return err
+	}
+	p.Pkg.Metadata.AggregateChecksum = checksumSha
+	b, err := goyaml.Marshal(p.Pkg)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(p.dirPath, ZarfYAML), b, helpers.ReadWriteUser)
+}
+
+// reduceComponents removes the tarballs of the components that are not kept from dir.
+func reduceComponents(dir string, components []v1alpha1.ZarfComponent) error {
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		kept := slices.ContainsFunc(components, func(component v1alpha1.ZarfComponent) bool {
+			return entry.Name() == fmt.Sprintf("%s.tar", component.Name)
+		})
+		if kept {
+			continue
+		}
+		if err := os.RemoveAll(filepath.Join(dir, entry.Name())); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// manifestMediaTypes are the media types of the blobs that reference other blobs.
+var manifestMediaTypes = []string{
+	ocispec.MediaTypeImageManifest,
+	ocispec.MediaTypeImageIndex,
+	"application/vnd.docker.distribution.manifest.v2+json",
+	"application/vnd.docker.distribution.manifest.list.v2+json",
+}
+
+// removeUnusedBlobs removes the blobs of the OCI layout at ociPath that are not referenced from its index.json.
+func removeUnusedBlobs(ociPath string) error {
+	b, err := os.ReadFile(filepath.Join(ociPath, "index.json"))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	var index ocispec.Index
+	if err := json.Unmarshal(b, &index); err != nil {
+		return err
+	}
+	used := map[string]bool{}
+	var walk func(descs []ocispec.Descriptor) error
+	walk = func(descs []ocispec.Descriptor) error {
+		for _, desc := range descs {
+			blobPath := filepath.Join(ociPath, "blobs", desc.Digest.Algorithm().String(), desc.Digest.Encoded())
+			if used[blobPath] {
+				continue
+			}
+			used[blobPath] = true
+			if !slices.Contains(manifestMediaTypes, desc.MediaType) {
+				continue
+			}
+			b, err := os.ReadFile(blobPath)
+			if err != nil {
+				return err
+			}
+			var manifest struct {
+				Config    *ocispec.Descriptor  `json:"config"`
+				Layers    []ocispec.Descriptor `json:"layers"`
+				Manifests []ocispec.Descriptor `json:"manifests"`
+			}
+			if err := json.Unmarshal(b, &manifest); err != nil {
+				return fmt.Errorf("unable to parse the manifest %s: %w", desc.Digest, err)
+			}
+			children := append(manifest.Layers, manifest.Manifests...)
+			if manifest.Config != nil {
+				children = append(children, *manifest.Config)
+			}
+			if err := walk(children); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if err := walk(index.Manifests); err != nil {
+		return err
+	}
+	return filepath.WalkDir(filepath.Join(ociPath, "blobs"), func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+		if d.IsDir() || used[path] {
+			return nil
+		}
+		return os.Remove(path)
+	})
+}
+
+// reduceImagesIndex removes the images that are not used by the components from the index.json at ociPath.
+func reduceImagesIndex(ociPath string, components []v1alpha1.ZarfComponent) error {
+	indexPath := filepath.Join(ociPath, "index.json")
+	b, err := os.ReadFile(indexPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	var index ocispec.Index
+	if err := json.Unmarshal(b, &index); err != nil {
+		return err
+	}
+
+	refs := []transform.Image{}
+	for _, component := range components {
+		for _, image := range component.Images {
+			refInfo, err := transform.ParseImageRef(image)
+			if err != nil {
+				return fmt.Errorf("failed to parse image ref %q: %w", image, err)
+			}
+			refs = append(refs, refInfo)
+		}
+	}
+	index.Manifests = slices.DeleteFunc(index.Manifests, func(desc ocispec.Descriptor) bool {
+		return !slices.ContainsFunc(refs, func(refInfo transform.Image) bool {
+			name := desc.Annotations[ocispec.AnnotationBaseImageName]
+			return name == refInfo.Reference ||
+				// A backwards compatibility shim for older Zarf versions that would leave docker.io off of image annotations
+				(name == refInfo.Path+refInfo.TagOrDigest && refInfo.Host == "docker.io") ||
+				desc.Annotations[utils.ImageIndexAnnotation] == refInfo.Reference
+		})
+	})
+	b, err = json.Marshal(index)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(indexPath, b, helpers.ReadWriteUser)
+}
@@ -0,0 +1,150 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package layout
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/stretchr/testify/require"
+	"oras.land/oras-go/v2/content"
+
+	"github.com/zarf-dev/zarf/src/api/v1alpha1"
+	"github.com/zarf-dev/zarf/src/pkg/lint"
+	"github.com/zarf-dev/zarf/src/pkg/utils"
+	"github.com/zarf-dev/zarf/src/test/testutil"
+)
+
+func TestReduce(t *testing.T) {
+	t.Parallel()
+
+	ctx := testutil.TestContext(t)
+	lint.ZarfSchema = testutil.LoadSchema(t, "../../../../zarf.schema.json")
+	dir := t.TempDir()
+	pkg := v1alpha1.ZarfPackage{
+		Kind:     v1alpha1.ZarfPackageConfig,
+		Metadata: v1alpha1.ZarfMetadata{Name: "reduce"},
+	}
+	for _, name := range []string{"core", "monitoring", "extra"} {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name+".txt"), []byte(name), 0o600))
+		pkg.Components = append(pkg.Components, v1alpha1.ZarfComponent{
+			Name:  name,
+			Files: []v1alpha1.ZarfFile{{Source: name + ".txt", Target: name + ".txt"}},
+		})
+	}
+	writePackageToDisk(t, pkg, dir)
+	opt := CreateOptions{
+		SkipSBOM:           true,
+		SigningKeyPath:     "./testdata/cosign.key",
+		SigningKeyPassword: "test",
+	}
+	pkgLayout, err := CreatePackage(ctx, dir, opt)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, pkgLayout.Cleanup())
+	})
+	require.FileExists(t, filepath.Join(pkgLayout.dirPath, Signature))
+
+	err = pkgLayout.Reduce(ctx, pkgLayout.Pkg.Components[:2])
+	require.NoError(t, err)
+	require.NoFileExists(t, filepath.Join(pkgLayout.dirPath, Signature))
+	require.NoFileExists(t, filepath.Join(pkgLayout.dirPath, ComponentsDir, "extra.tar"))
+	require.FileExists(t, filepath.Join(pkgLayout.dirPath, ComponentsDir, "core.tar"))
+
+	reduced, err := LoadFromDir(ctx, pkgLayout.dirPath, PackageLayoutOptions{})
+	require.NoError(t, err)
+	names := []string{}
+	for _, component := range reduced.Pkg.Components {
+		names = append(names, component.Name)
+	}
+	require.Equal(t, []string{"core", "monitoring"}, names)
+}
+
+func TestReduceImagesIndex(t *testing.T) {
+	t.Parallel()
+
+	ociPath := t.TempDir()
+	index := ocispec.Index{
+		Manifests: []ocispec.Descriptor{
+			{Digest: "sha256:1", Annotations: map[string]string{ocispec.AnnotationBaseImageName: "docker.io/library/nginx:1.27.0"}},
+			{Digest: "sha256:2", Annotations: map[string]string{ocispec.AnnotationBaseImageName: "library/redis:7.4.0"}},
+			{Digest: "sha256:3", Annotations: map[string]string{utils.ImageIndexAnnotation: "ghcr.io/example/app:1.0.0"}},
+			{Digest: "sha256:4", Annotations: map[string]string{ocispec.AnnotationBaseImageName: "ghcr.io/example/other:1.0.0"}},
+		},
+	}
+	b, err := json.Marshal(index)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(ociPath, "index.json"), b, 0o600))
+
+	components := []v1alpha1.ZarfComponent{
+		{Name: "web", Images: []string{"nginx:1.27.0", "redis:7.4.0"}},
+		{Name: "app", Images: []string{"ghcr.io/example/app:1.0.0"}},
+	}
+	err = reduceImagesIndex(ociPath, components)
+	require.NoError(t, err)
+
+	b, err = os.ReadFile(filepath.Join(ociPath, "index.json"))
+	require.NoError(t, err)
+	var reduced ocispec.Index
+	require.NoError(t, json.Unmarshal(b, &reduced))
+	digests := []string{}
+	for _, desc := range reduced.Manifests {
+		digests = append(digests, desc.Digest.String())
+	}
+	require.Equal(t, []string{"sha256:1", "sha256:2", "sha256:3"}, digests)
+
+	// Packages without images have no index to reduce.
+	err = reduceImagesIndex(t.TempDir(), components)
+	require.NoError(t, err)
+}
+
+func TestRemoveUnusedBlobs(t *testing.T) {
+	t.Parallel()
+
+	ociPath := t.TempDir()
+	blobsDir := filepath.Join(ociPath, "blobs", "sha256")
+	require.NoError(t, os.MkdirAll(blobsDir, 0o700))
+	writeBlob := func(b []byte) ocispec.Descriptor {
+		t.Helper()
+		desc := content.NewDescriptorFromBytes("", b)
+		require.NoError(t, os.WriteFile(filepath.Join(blobsDir, desc.Digest.Encoded()), b, 0o600))
+		return desc
+	}
+	writeManifest := func(v any, mediaType string) ocispec.Descriptor {
+		t.Helper()
+		b, err := json.Marshal(v)
+		require.NoError(t, err)
+		desc := writeBlob(b)
+		desc.MediaType = mediaType
+		return desc
+	}
+
+	config := writeBlob([]byte("config"))
+	shared := writeBlob([]byte("shared"))
+	kept := writeBlob([]byte("kept"))
+	removed := writeBlob([]byte("removed"))
+	keptManifest := writeManifest(ocispec.Manifest{Config: config, Layers: []ocispec.Descriptor{shared, kept}}, ocispec.MediaTypeImageManifest)
+	keptIndex := writeManifest(ocispec.Index{Manifests: []ocispec.Descriptor{keptManifest}}, ocispec.MediaTypeImageIndex)
+	removedManifest := writeManifest(ocispec.Manifest{Config: config, Layers: []ocispec.Descriptor{shared, removed}}, ocispec.MediaTypeImageManifest)
+
+	b, err := json.Marshal(ocispec.Index{Manifests: []ocispec.Descriptor{keptIndex}})
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(ociPath, "index.json"), b, 0o600))
+
+	err = removeUnusedBlobs(ociPath)
+	require.NoError(t, err)
+	for _, desc := range []ocispec.Descriptor{config, shared, kept, keptManifest, keptIndex} {
+		require.FileExists(t, filepath.Join(blobsDir, desc.Digest.Encoded()))
+	}
+	for _, desc := range []ocispec.Descriptor{removed, removedManifest} {
+		require.NoFileExists(t, filepath.Join(blobsDir, desc.Digest.Encoded()))
+	}
+
+	// Packages without images have no blobs to remove.
+	err = removeUnusedBlobs(t.TempDir())
+	require.NoError(t, err)
+}
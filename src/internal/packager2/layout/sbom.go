@@ -1,11 +1,15 @@
 package layout
 
 import (
+	"bufio"
+	"bytes"
 	"context"
 	"embed"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"html/template"
+	"io"
 	"os"
 	"path/filepath"
 	"regexp"
@@ -16,7 +20,6 @@ import (
 	"github.com/anchore/syft/syft"
 	"github.com/anchore/syft/syft/artifact"
 	syftFile "github.com/anchore/syft/syft/file"
-	"github.com/anchore/syft/syft/format"
 	"github.com/anchore/syft/syft/format/syftjson"
 	"github.com/anchore/syft/syft/linux"
 	"github.com/anchore/syft/syft/pkg"
@@ -42,6 +45,10 @@ const componentPrefix = "zarf-component-"
 var viewerAssets embed.FS
 var transformRegex = regexp.MustCompile(`(?m)[^a-zA-Z0-9\.\-]`)
 
+// sbomDataPlaceholder is rendered in place of the SBOM data of a viewer so that the SBOM can be copied from disk
+// into the viewer instead of being held in memory while the template is executed.
+const sbomDataPlaceholder = "__ZARF_SBOM_DATA_PLACEHOLDER__"
+
 func generateSBOM(ctx context.Context, pkg v1alpha1.ZarfPackage, buildPath string, images []transform.Image) error {
 	l := logger.From(ctx)
 	outputPath, err := utils.MakeTempDir(config.CommonOptions.TempDirectory)
@@ -57,7 +64,7 @@ func generateSBOM(ctx context.Context, pkg v1alpha1.ZarfPackage, buildPath strin
 
 	componentSBOMs := []string{}
 	for _, comp := range pkg.Components {
-		if len(comp.Files) > 0 || len(comp.DataInjections) > 0 {
+		if len(comp.Files) > 0 || len(comp.HostArtifacts) > 0 || len(comp.DataInjections) > 0 || len(comp.Artifacts) > 0 {
 			componentSBOMs = append(componentSBOMs, comp.Name)
 		}
 	}
@@ -71,12 +78,12 @@ func generateSBOM(ctx context.Context, pkg v1alpha1.ZarfPackage, buildPath strin
 		if err != nil {
 			return err
 		}
-		b, err := createImageSBOM(ctx, cachePath, outputPath, img, refInfo.Reference)
+		l.Info("creating image SBOMs", "reference", refInfo.Reference)
+		jsonPath, err := createImageSBOM(ctx, cachePath, outputPath, img, refInfo.Reference)
 		if err != nil {
 			return err
 		}
-		l.Info("creating image SBOMs", "reference", refInfo.Reference)
-		err = createSBOMViewerAsset(outputPath, refInfo.Reference, b, jsonList)
+		err = createSBOMViewerAsset(outputPath, refInfo.Reference, jsonPath, jsonList)
 		if err != nil {
 			return err
 		}
@@ -84,14 +91,14 @@ func generateSBOM(ctx context.Context, pkg v1alpha1.ZarfPackage, buildPath strin
 
 	// Generate SBOM for each component
 	for _, comp := range pkg.Components {
-		if len(comp.DataInjections) == 0 && len(comp.Files) == 0 {
+		if len(comp.DataInjections) == 0 && len(comp.Files) == 0 && len(comp.HostArtifacts) == 0 && len(comp.Artifacts) == 0 {
 			continue
 		}
-		jsonData, err := createFileSBOM(ctx, comp, outputPath, buildPath)
+		jsonPath, err := createFileSBOM(ctx, comp, outputPath, buildPath)
 		if err != nil {
 			return err
 		}
-		err = createSBOMViewerAsset(outputPath, fmt.Sprintf("%s%s", componentPrefix, comp.Name), jsonData, jsonList)
+		err = createSBOMViewerAsset(outputPath, fmt.Sprintf("%s%s", componentPrefix, comp.Name), jsonPath, jsonList)
 		if err != nil {
 			return err
 		}
@@ -111,21 +118,26 @@ func generateSBOM(ctx context.Context, pkg v1alpha1.ZarfPackage, buildPath strin
 	return nil
 }
 
-func createImageSBOM(ctx context.Context, cachePath, outputPath string, img v1.Image, src string) ([]byte, error) {
+// createImageSBOM catalogs the image and writes its SBOM to the output path, returning the path of the SBOM. The
+// layers the image is unpacked to are removed once it is cataloged, so only one image is held at a time.
+func createImageSBOM(ctx context.Context, cachePath, outputPath string, img v1.Image, src string) (_ string, err error) {
 	imageCachePath := filepath.Join(cachePath, ImagesDir)
-	err := os.MkdirAll(imageCachePath, helpers.ReadWriteExecuteUser)
+	err = os.MkdirAll(imageCachePath, helpers.ReadWriteExecuteUser)
 	if err != nil {
-		return nil, err
+		return "", err
 	}
 
 	refInfo, err := transform.ParseImageRef(src)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create ref for image %s: %w", src, err)
+		return "", fmt.Errorf("failed to create ref for image %s: %w", src, err)
 	}
 	syftImage := image.NewImage(img, file.NewTempDirGenerator("zarf"), imageCachePath, image.WithTags(refInfo.Reference))
+	defer func() {
+		err = errors.Join(err, syftImage.Cleanup())
+	}()
 	err = syftImage.Read()
 	if err != nil {
-		return nil, err
+		return "", err
 	}
 	cfg := getDefaultSyftConfig()
 	syftSrc := stereoscopesource.New(syftImage, stereoscopesource.ImageConfig{
@@ -133,33 +145,31 @@ func createImageSBOM(ctx context.Context, cachePath, outputPath string, img v1.I
 	})
 	sbom, err := syft.CreateSBOM(ctx, syftSrc, cfg)
 	if err != nil {
-		return nil, err
-	}
-	jsonData, err := format.Encode(*sbom, syftjson.NewFormatEncoder())
-	if err != nil {
-		return nil, err
+		return "", err
 	}
 
 	normalizedName := getNormalizedFileName(fmt.Sprintf("%s.json", refInfo.Reference))
 	path := filepath.Join(outputPath, normalizedName)
-	err = os.WriteFile(path, jsonData, 0o666)
+	err = writeSBOM(path, *sbom)
 	if err != nil {
-		return nil, err
+		return "", err
 	}
-	return jsonData, nil
+	return path, nil
 }
 
-func createFileSBOM(ctx context.Context, component v1alpha1.ZarfComponent, outputPath, buildPath string) ([]byte, error) {
+// createFileSBOM catalogs the files of the component and writes their SBOM to the output path, returning the path of
+// the SBOM. The packages of each file are merged into the component catalog as the file is cataloged.
+func createFileSBOM(ctx context.Context, component v1alpha1.ZarfComponent, outputPath, buildPath string) (string, error) {
 	l := logger.From(ctx)
 	tmpDir, err := utils.MakeTempDir(config.CommonOptions.TempDirectory)
 	if err != nil {
-		return nil, err
+		return "", err
 	}
 	defer os.RemoveAll(tmpDir)
 	tarPath := filepath.Join(buildPath, ComponentsDir, component.Name) + ".tar"
 	err = archiver.Unarchive(tarPath, tmpDir)
 	if err != nil {
-		return nil, err
+		return "", err
 	}
 	sbomFiles := []string{}
 	appendSBOMFiles := func(path string) error {
@@ -178,20 +188,34 @@ func createFileSBOM(ctx context.Context, component v1alpha1.ZarfComponent, outpu
 		path := filepath.Join(tmpDir, component.Name, string(FilesComponentDir), strconv.Itoa(i), filepath.Base(file.Target))
 		err := appendSBOMFiles(path)
 		if err != nil {
-			return nil, err
+			return "", err
+		}
+	}
+	if len(component.HostArtifacts) > 0 {
+		err := appendSBOMFiles(filepath.Join(tmpDir, component.Name, string(HostArtifactsComponentDir)))
+		if err != nil {
+			return "", err
 		}
 	}
 	for i, data := range component.DataInjections {
 		path := filepath.Join(tmpDir, component.Name, string(DataComponentDir), strconv.Itoa(i), filepath.Base(data.Target.Path))
 		err := appendSBOMFiles(path)
 		if err != nil {
-			return nil, err
+			return "", err
+		}
+	}
+
+	if len(component.Artifacts) > 0 {
+		// The content of artifacts is stored as blobs named by their digest.
+		err := appendSBOMFiles(filepath.Join(tmpDir, component.Name, string(ArtifactsComponentDir), "blobs"))
+		if err != nil {
+			return "", err
 		}
 	}
 
 	parentSource, err := directorysource.NewFromPath(tmpDir)
 	if err != nil {
-		return nil, err
+		return "", err
 	}
 	catalog := pkg.NewCollection()
 	relationships := []artifact.Relationship{}
@@ -199,13 +223,13 @@ func createFileSBOM(ctx context.Context, component v1alpha1.ZarfComponent, outpu
 		l.Info("creating file SBOMs", "file", sbomFile)
 		fileSrc, err := filesource.NewFromPath(sbomFile)
 		if err != nil {
-			return nil, err
+			return "", err
 		}
 
 		cfg := getDefaultSyftConfig()
 		sbom, err := syft.CreateSBOM(ctx, fileSrc, cfg)
 		if err != nil {
-			return nil, err
+			return "", err
 		}
 
 		for pkg := range sbom.Artifacts.Packages.Enumerate() {
@@ -248,36 +272,45 @@ func createFileSBOM(ctx context.Context, component v1alpha1.ZarfComponent, outpu
 		},
 		Relationships: relationships,
 	}
-	jsonData, err := format.Encode(artifact, syftjson.NewFormatEncoder())
+	filename := fmt.Sprintf("%s%s.json", componentPrefix, component.Name)
+	path := filepath.Join(outputPath, getNormalizedFileName(filename))
+	err = writeSBOM(path, artifact)
 	if err != nil {
-		return nil, err
+		return "", err
 	}
+	return path, nil
+}
 
-	filename := fmt.Sprintf("%s%s.json", componentPrefix, component.Name)
-	path := filepath.Join(outputPath, getNormalizedFileName(filename))
-	err = os.WriteFile(path, jsonData, 0o666)
+// writeSBOM encodes the SBOM as syft JSON directly to the file at path.
+func writeSBOM(path string, s sbom.SBOM) error {
+	f, err := os.Create(path)
 	if err != nil {
-		return nil, err
+		return err
+	}
+	w := bufio.NewWriter(f)
+	err = syftjson.NewFormatEncoder().Encode(w, s)
+	if err == nil {
+		err = w.Flush()
 	}
-	return jsonData, nil
+	return errors.Join(err, f.Close())
 }
 
-func createSBOMViewerAsset(outputDir, identifier string, jsonData, jsonList []byte) error {
+func createSBOMViewerAsset(outputDir, identifier, jsonPath string, jsonList []byte) error {
 	filename := fmt.Sprintf("sbom-viewer-%s.html", getNormalizedFileName(identifier))
-	return createSBOMHTML(outputDir, filename, "viewer/template.gohtml", jsonData, jsonList)
+	return createSBOMHTML(outputDir, filename, "viewer/template.gohtml", jsonPath, jsonList)
 }
 
 func createSBOMCompareAsset(outputDir string) error {
-	return createSBOMHTML(outputDir, "compare.html", "viewer/compare.gohtml", nil, nil)
+	return createSBOMHTML(outputDir, "compare.html", "viewer/compare.gohtml", "", nil)
 }
 
-func createSBOMHTML(outputDir, filename, goTemplate string, jsonData, jsonList []byte) error {
-	path := filepath.Join(outputDir, getNormalizedFileName(filename))
-	file, err := os.Create(path)
-	if err != nil {
-		return err
+// createSBOMHTML renders the template to the output directory. When a JSON path is given, the SBOM it holds is
+// copied into the rendered template from disk as the viewer data.
+func createSBOMHTML(outputDir, filename, goTemplate, jsonPath string, jsonList []byte) (err error) {
+	var data template.JS
+	if jsonPath != "" {
+		data = sbomDataPlaceholder
 	}
-	defer file.Close()
 	tplData := struct {
 		ThemeCSS  template.CSS
 		ViewerCSS template.CSS
@@ -291,7 +324,7 @@ func createSBOMHTML(outputDir, filename, goTemplate string, jsonData, jsonList [
 		ThemeCSS:  loadFileCSS("theme.css"),
 		ViewerCSS: loadFileCSS("styles.css"),
 		List:      template.JS(jsonList),
-		Data:      template.JS(jsonData),
+		Data:      data,
 		LibraryJS: loadFileJS("library.js"),
 		CommonJS:  loadFileJS("common.js"),
 		ViewerJS:  loadFileJS("viewer.js"),
@@ -301,7 +334,40 @@ func createSBOMHTML(outputDir, filename, goTemplate string, jsonData, jsonList [
 	if err != nil {
 		return err
 	}
-	return tpl.Execute(file, tplData)
+	var rendered bytes.Buffer
+	err = tpl.Execute(&rendered, tplData)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.Create(filepath.Join(outputDir, getNormalizedFileName(filename)))
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = errors.Join(err, file.Close())
+	}()
+	if jsonPath == "" {
+		_, err = rendered.WriteTo(file)
+		return err
+	}
+	before, after, ok := bytes.Cut(rendered.Bytes(), []byte(sbomDataPlaceholder))
+	if !ok {
+		return fmt.Errorf("template %s does not render the SBOM data", goTemplate)
+	}
+	jsonFile, err := os.Open(jsonPath)
+	if err != nil {
+		return err
+	}
+	defer jsonFile.Close()
+	if _, err := file.Write(before); err != nil {
+		return err
+	}
+	if _, err := io.Copy(file, jsonFile); err != nil {
+		return err
+	}
+	_, err = file.Write(after)
+	return err
 }
 
 func loadFileCSS(name string) template.CSS {
@@ -3,6 +3,7 @@ package layout
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/google/go-containerregistry/pkg/v1/empty"
@@ -17,11 +18,35 @@ func TestCreateImageSBOM(t *testing.T) {
 
 	outputPath := t.TempDir()
 	img := empty.Image
-	b, err := createImageSBOM(ctx, t.TempDir(), outputPath, img, "docker.io/foo/bar:latest")
+	path, err := createImageSBOM(ctx, t.TempDir(), outputPath, img, "docker.io/foo/bar:latest")
 	require.NoError(t, err)
-	require.NotEmpty(t, b)
+	require.Equal(t, filepath.Join(outputPath, "docker.io_foo_bar_latest.json"), path)
 
-	fileContent, err := os.ReadFile(filepath.Join(outputPath, "docker.io_foo_bar_latest.json"))
+	fileContent, err := os.ReadFile(path)
 	require.NoError(t, err)
-	require.Equal(t, fileContent, b)
+	require.Contains(t, string(fileContent), `"userInput":"docker.io/foo/bar:latest"`)
+}
+
+func TestCreateSBOMViewerAsset(t *testing.T) {
+	t.Parallel()
+
+	outputPath := t.TempDir()
+	jsonPath := filepath.Join(outputPath, "docker.io_foo_bar_latest.json")
+	jsonData := `{"artifacts":[{"name":"` + strings.Repeat("a", 64*1024) + `"}]}` + "\n"
+	err := os.WriteFile(jsonPath, []byte(jsonData), 0o600)
+	require.NoError(t, err)
+
+	err = createSBOMViewerAsset(outputPath, "docker.io/foo/bar:latest", jsonPath, []byte(`["docker.io_foo_bar_latest"]`))
+	require.NoError(t, err)
+	b, err := os.ReadFile(filepath.Join(outputPath, "sbom-viewer-docker.io_foo_bar_latest.html"))
+	require.NoError(t, err)
+	require.Contains(t, string(b), `ZARF_SBOM_LIST = ["docker.io_foo_bar_latest"];`)
+	require.Contains(t, string(b), "ZARF_SBOM_DATA = "+jsonData+";")
+	require.NotContains(t, string(b), sbomDataPlaceholder)
+
+	err = createSBOMCompareAsset(outputPath)
+	require.NoError(t, err)
+	b, err = os.ReadFile(filepath.Join(outputPath, "compare.html"))
+	require.NoError(t, err)
+	require.NotContains(t, string(b), sbomDataPlaceholder)
 }
@@ -0,0 +1,153 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package layout
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/defenseunicorns/pkg/helpers/v2"
+	"github.com/go-git/go-git/v5/plumbing"
+	goyaml "github.com/goccy/go-yaml"
+
+	"github.com/zarf-dev/zarf/src/api/v1alpha1"
+	"github.com/zarf-dev/zarf/src/internal/git"
+	"github.com/zarf-dev/zarf/src/pkg/logger"
+	"github.com/zarf-dev/zarf/src/pkg/zoci"
+)
+
+// gitSourcePrefix is the prefix of package definitions that are fetched from a git repository.
+const gitSourcePrefix = "git::"
+
+// IsRemoteDefinition returns whether the package definition is fetched from a skeleton package in an OCI registry or
+// from a git repository instead of being read from a local directory.
+func IsRemoteDefinition(src string) bool {
+	return helpers.IsOCIURL(src) || strings.HasPrefix(src, gitSourcePrefix)
+}
+
+// FetchRemoteDefinition fetches the package definition at src together with the local files it references into dir
+// and returns the directory the package is created from.
+//
+// Definitions in git are given as git::https://example.com/org/repo.git//path/to/package?ref=v1.2.0, where the path
+// within the repository and the ref are optional. Definitions in OCI registries are skeleton packages published with
+// zarf package publish, given as oci://ghcr.io/org/packages/name:1.2.0.
+func FetchRemoteDefinition(ctx context.Context, src, dir string) (string, error) {
+	if helpers.IsOCIURL(src) {
+		return fetchOCIDefinition(ctx, src, dir)
+	}
+	return fetchGitDefinition(ctx, src, dir)
+}
+
+// gitSource is a package definition in a directory of a git repository.
+type gitSource struct {
+	repo string
+	path string
+	ref  string
+}
+
+// parseGitSource parses a git package definition such as git::https://example.com/org/repo.git//path?ref=v1.2.0.
+func parseGitSource(src string) (gitSource, error) {
+	rest, query, _ := strings.Cut(strings.TrimPrefix(src, gitSourcePrefix), "?")
+	values, err := url.ParseQuery(query)
+	if err != nil {
+		return gitSource{}, fmt.Errorf("invalid query of git package definition %s: %w", src, err)
+	}
+	scheme, rest, ok := strings.Cut(rest, "://")
+	if !ok || scheme == "" {
+		return gitSource{}, fmt.Errorf("git package definition %s must include a scheme such as https://", src)
+	}
+	rest, path, _ := strings.Cut(rest, "//")
+	path = strings.Trim(path, "/")
+	if path != "" && !filepath.IsLocal(path) {
+		return gitSource{}, fmt.Errorf("path %s of git package definition %s must be within the repository", path, src)
+	}
+	return gitSource{
+		repo: fmt.Sprintf("%s://%s", scheme, rest),
+		path: path,
+		ref:  values.Get("ref"),
+	}, nil
+}
+
+func fetchGitDefinition(ctx context.Context, src, dir string) (string, error) {
+	source, err := parseGitSource(src)
+	if err != nil {
+		return "", err
+	}
+	address := source.repo
+	if source.ref != "" {
+		address = fmt.Sprintf("%s@%s", source.repo, source.ref)
+	}
+	logger.From(ctx).Info("fetching package definition", "repository", source.repo, "path", source.path, "ref", source.ref)
+	// Commits can not be checked out of a shallow clone of the default branch.
+	repo, err := git.Clone(ctx, dir, address, !plumbing.IsHash(source.ref))
+	if err != nil {
+		return "", fmt.Errorf("unable to clone the package definition from %s: %w", source.repo, err)
+	}
+	packagePath := filepath.Join(repo.Path(), filepath.FromSlash(source.path))
+	if _, err := os.Stat(filepath.Join(packagePath, ZarfYAML)); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return "", fmt.Errorf("no %s found in the package definition %s", ZarfYAML, src)
+		}
+		return "", err
+	}
+	return packagePath, nil
+}
+
+func fetchOCIDefinition(ctx context.Context, src, dir string) (string, error) {
+	resolved, err := zoci.ResolveVersionConstraint(ctx, src, zoci.PlatformForSkeleton())
+	if err != nil {
+		return "", err
+	}
+	logger.From(ctx).Info("fetching package definition", "reference", resolved)
+	remote, err := zoci.NewRemote(ctx, resolved, zoci.PlatformForSkeleton())
+	if err != nil {
+		return "", err
+	}
+	if _, err := remote.ResolveRoot(ctx); err != nil {
+		return "", fmt.Errorf("published skeleton package for %s does not exist: %w", resolved, err)
+	}
+	pkg, err := remote.FetchZarfYAML(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	// The local files of each component are extracted from the skeleton and the paths of the component are made
+	// relative to the directory the package is created from, the same way importing the component would.
+	for i, component := range pkg.Components {
+		skeletonComponent := v1alpha1.ZarfComponent{
+			Name:   component.Name,
+			Import: v1alpha1.ZarfComponentImport{URL: resolved},
+		}
+		importPath, err := fetchOCISkeleton(ctx, skeletonComponent, dir)
+		if err != nil {
+			return "", err
+		}
+		pkg.Components[i] = fixPaths(component, importPath, dir)
+	}
+	if pkg.Metadata.ReleaseNotes != "" {
+		if _, err := remote.PullPaths(ctx, dir, []string{ReleaseNotes}); err != nil {
+			return "", fmt.Errorf("unable to pull the release notes of %s: %w", resolved, err)
+		}
+		pkg.Metadata.ReleaseNotes = ReleaseNotes
+	}
+
+	// Drop what publishing the skeleton recorded so the package is built like it was created from its definition.
+	pkg.Metadata.Architecture = ""
+	pkg.Metadata.AggregateChecksum = ""
+	pkg.Build = v1alpha1.ZarfBuildData{}
+
+	b, err := goyaml.Marshal(pkg)
+	if err != nil {
+		return "", err
+	}
+	if err := os.WriteFile(filepath.Join(dir, ZarfYAML), b, helpers.ReadWriteUser); err != nil {
+		return "", err
+	}
+	return dir, nil
+}
@@ -0,0 +1,141 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package layout
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/fluxcd/gitkit"
+	"github.com/go-git/go-billy/v5/memfs"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/storage/memory"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zarf-dev/zarf/src/test/testutil"
+)
+
+func TestIsRemoteDefinition(t *testing.T) {
+	t.Parallel()
+
+	require.True(t, IsRemoteDefinition("oci://ghcr.io/example/packages/app:1.0.0"))
+	require.True(t, IsRemoteDefinition("git::https://example.com/org/repo.git"))
+	require.False(t, IsRemoteDefinition("."))
+	require.False(t, IsRemoteDefinition("https://example.com/org/repo.git"))
+}
+
+func TestParseGitSource(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		src         string
+		expected    gitSource
+		expectedErr string
+	}{
+		{
+			name:     "repository",
+			src:      "git::https://example.com/org/repo.git",
+			expected: gitSource{repo: "https://example.com/org/repo.git"},
+		},
+		{
+			name:     "path and ref",
+			src:      "git::https://example.com/org/repo.git//packages/app/?ref=v1.2.0",
+			expected: gitSource{repo: "https://example.com/org/repo.git", path: "packages/app", ref: "v1.2.0"},
+		},
+		{
+			name:     "branch",
+			src:      "git::ssh://git@example.com/org/repo.git?ref=refs/heads/main",
+			expected: gitSource{repo: "ssh://git@example.com/org/repo.git", ref: "refs/heads/main"},
+		},
+		{
+			name:        "no scheme",
+			src:         "git::example.com/org/repo.git",
+			expectedErr: "git package definition git::example.com/org/repo.git must include a scheme such as https://",
+		},
+		{
+			name:        "path outside of the repository",
+			src:         "git::https://example.com/org/repo.git//../other",
+			expectedErr: "path ../other of git package definition git::https://example.com/org/repo.git//../other must be within the repository",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			source, err := parseGitSource(tt.src)
+			if tt.expectedErr != "" {
+				require.EqualError(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, source)
+		})
+	}
+}
+
+func TestFetchGitDefinition(t *testing.T) {
+	t.Parallel()
+	ctx := testutil.TestContext(t)
+
+	cfg := gitkit.Config{
+		Dir:        t.TempDir(),
+		AutoCreate: true,
+	}
+	gitSrv := gitkit.New(cfg)
+	require.NoError(t, gitSrv.Setup())
+	srv := httptest.NewServer(http.HandlerFunc(gitSrv.ServeHTTP))
+	t.Cleanup(func() {
+		srv.Close()
+	})
+	repoAddress := fmt.Sprintf("%s/definitions.git", srv.URL)
+
+	fs := memfs.New()
+	repo, err := git.InitWithOptions(memory.NewStorage(), fs, git.InitOptions{DefaultBranch: plumbing.Main})
+	require.NoError(t, err)
+	w, err := repo.Worktree()
+	require.NoError(t, err)
+	for _, name := range []string{"packages/app/zarf.yaml", "packages/app/manifests/deployment.yaml"} {
+		f, err := fs.Create(name)
+		require.NoError(t, err)
+		_, err = f.Write([]byte("kind: ZarfPackageConfig\n"))
+		require.NoError(t, err)
+		require.NoError(t, f.Close())
+		_, err = w.Add(name)
+		require.NoError(t, err)
+	}
+	hash, err := w.Commit("Add app package", &git.CommitOptions{Author: &object.Signature{Email: "example@example.com"}})
+	require.NoError(t, err)
+	_, err = repo.CreateTag("v1.0.0", hash, nil)
+	require.NoError(t, err)
+	_, err = repo.CreateRemote(&config.RemoteConfig{Name: "origin", URLs: []string{repoAddress}})
+	require.NoError(t, err)
+	err = repo.Push(&git.PushOptions{
+		RemoteName: "origin",
+		RefSpecs:   []config.RefSpec{"refs/heads/*:refs/heads/*", "refs/tags/*:refs/tags/*"},
+	})
+	require.NoError(t, err)
+	// Decouple the default branch of the served repository from the gitconfig of the host.
+	err = os.WriteFile(filepath.Join(cfg.Dir, "definitions.git", "HEAD"), []byte("ref: refs/heads/main\n"), 0o644)
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	packagePath, err := FetchRemoteDefinition(ctx, fmt.Sprintf("git::%s//packages/app?ref=v1.0.0", repoAddress), dir)
+	require.NoError(t, err)
+	require.FileExists(t, filepath.Join(packagePath, ZarfYAML))
+	require.FileExists(t, filepath.Join(packagePath, "manifests", "deployment.yaml"))
+	rel, err := filepath.Rel(dir, packagePath)
+	require.NoError(t, err)
+	require.True(t, filepath.IsLocal(rel))
+
+	_, err = FetchRemoteDefinition(ctx, fmt.Sprintf("git::%s//packages/other?ref=v1.0.0", repoAddress), t.TempDir())
+	require.EqualError(t, err, fmt.Sprintf("no zarf.yaml found in the package definition git::%s//packages/other?ref=v1.0.0", repoAddress))
+}
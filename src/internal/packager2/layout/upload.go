@@ -0,0 +1,224 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package layout
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/avast/retry-go/v4"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/registry/remote"
+	"oras.land/oras-go/v2/registry/remote/auth"
+
+	"github.com/zarf-dev/zarf/src/pkg/logger"
+)
+
+const (
+	// defaultBlobChunkSize is the size of the chunks blobs larger than it are uploaded in.
+	defaultBlobChunkSize = 16 * 1024 * 1024
+	// chunkAttempts is the number of times a chunked blob upload is resumed before giving up
+	chunkAttempts = 5
+)
+
+// errChunkedUploadUnsupported is returned when the registry rejects chunked uploads.
+var errChunkedUploadUnsupported = errors.New("registry does not support chunked uploads")
+
+// chunkedUploader uploads blobs in chunks following the chunked push flow of the OCI distribution spec. When a chunk
+// fails the upload session is queried for the bytes the registry received, and the upload resumes from there instead
+// of sending the blob again.
+type chunkedUploader struct {
+	repo      *remote.Repository
+	src       content.Fetcher
+	chunkSize int64
+}
+
+// Upload pushes desc in chunks, resuming the upload session when a chunk fails.
+func (u *chunkedUploader) Upload(ctx context.Context, desc ocispec.Descriptor) error {
+	// Pushing requires both the pull and push actions.
+	ctx = auth.AppendRepositoryScope(ctx, u.repo.Reference, auth.ActionPull, auth.ActionPush)
+	location := ""
+	var offset int64
+	return retry.Do(func() error {
+		if location == "" {
+			var err error
+			location, err = u.start(ctx)
+			if err != nil {
+				return err
+			}
+			offset = 0
+		}
+		err := u.send(ctx, desc, &location, &offset)
+		if err == nil {
+			return u.complete(ctx, location, desc)
+		}
+		if errors.Is(err, errChunkedUploadUnsupported) {
+			return retry.Unrecoverable(err)
+		}
+		received, next, statusErr := u.status(ctx, location)
+		if statusErr != nil {
+			// The session is gone, the next attempt starts a new one.
+			logger.From(ctx).Debug("unable to resume blob upload", "digest", desc.Digest, "error", statusErr)
+			location = ""
+			return err
+		}
+		logger.From(ctx).Debug("resuming blob upload", "digest", desc.Digest, "offset", received, "error", err)
+		location, offset = next, received
+		return err
+	}, retry.Context(ctx), retry.Attempts(chunkAttempts), retry.Delay(500*time.Millisecond), retry.LastErrorOnly(true))
+}
+
+// start opens an upload session and returns its location.
+func (u *chunkedUploader) start(ctx context.Context) (string, error) {
+	uploadURL := fmt.Sprintf("%s://%s/v2/%s/blobs/uploads/", u.scheme(), u.repo.Reference.Host(), u.repo.Reference.Repository)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, uploadURL, nil)
+	if err != nil {
+		return "", err
+	}
+	resp, err := u.do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusAccepted {
+		return "", fmt.Errorf("unable to start blob upload: %s", resp.Status)
+	}
+	return u.location(resp)
+}
+
+// send uploads the chunks of desc from offset, advancing location and offset after every accepted chunk.
+func (u *chunkedUploader) send(ctx context.Context, desc ocispec.Descriptor, location *string, offset *int64) error {
+	rc, err := u.src.Fetch(ctx, desc)
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+	if seeker, ok := rc.(io.Seeker); ok {
+		_, err = seeker.Seek(*offset, io.SeekStart)
+	} else {
+		_, err = io.CopyN(io.Discard, rc, *offset)
+	}
+	if err != nil {
+		return err
+	}
+
+	for *offset < desc.Size {
+		n := min(u.chunkSize, desc.Size-*offset)
+		req, err := http.NewRequestWithContext(ctx, http.MethodPatch, *location, io.NopCloser(io.LimitReader(rc, n)))
+		if err != nil {
+			return err
+		}
+		req.ContentLength = n
+		req.Header.Set("Content-Type", "application/octet-stream")
+		req.Header.Set("Content-Range", fmt.Sprintf("%d-%d", *offset, *offset+n-1))
+		resp, err := u.do(req)
+		if err != nil {
+			return err
+		}
+		resp.Body.Close()
+		switch {
+		case resp.StatusCode == http.StatusAccepted:
+		case *offset == 0 && resp.StatusCode >= 400 && resp.StatusCode < 500 && resp.StatusCode != http.StatusRequestTimeout &&
+			resp.StatusCode != http.StatusTooManyRequests && resp.StatusCode != http.StatusRequestedRangeNotSatisfiable:
+			return fmt.Errorf("%w: %s", errChunkedUploadUnsupported, resp.Status)
+		default:
+			return fmt.Errorf("unable to upload blob chunk: %s", resp.Status)
+		}
+		next, err := u.location(resp)
+		if err != nil {
+			return err
+		}
+		*location = next
+		*offset += n
+	}
+	return nil
+}
+
+// status returns the number of bytes the registry received for the upload session and its location.
+func (u *chunkedUploader) status(ctx context.Context, location string) (int64, string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, location, nil)
+	if err != nil {
+		return 0, "", err
+	}
+	resp, err := u.do(req)
+	if err != nil {
+		return 0, "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusNoContent {
+		return 0, "", fmt.Errorf("unable to get blob upload status: %s", resp.Status)
+	}
+	next, err := u.location(resp)
+	if err != nil {
+		return 0, "", err
+	}
+	// The range is inclusive, registries report 0-0 for an empty session.
+	_, end, ok := strings.Cut(resp.Header.Get("Range"), "-")
+	if !ok {
+		return 0, next, nil
+	}
+	last, err := strconv.ParseInt(end, 10, 64)
+	if err != nil {
+		return 0, "", fmt.Errorf("invalid blob upload range %q: %w", resp.Header.Get("Range"), err)
+	}
+	if last == 0 {
+		return 0, next, nil
+	}
+	return last + 1, next, nil
+}
+
+// complete closes the upload session, the registry verifies the blob against its digest.
+func (u *chunkedUploader) complete(ctx context.Context, location string, desc ocispec.Descriptor) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, location, nil)
+	if err != nil {
+		return err
+	}
+	q := req.URL.Query()
+	q.Set("digest", desc.Digest.String())
+	req.URL.RawQuery = q.Encode()
+	resp, err := u.do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated {
+		return fmt.Errorf("unable to complete blob upload of %s: %s", desc.Digest, resp.Status)
+	}
+	return nil
+}
+
+// location resolves the upload location of a response against the request URL.
+func (u *chunkedUploader) location(resp *http.Response) (string, error) {
+	loc, err := resp.Location()
+	if err != nil {
+		return "", err
+	}
+	// Registries may drop an explicit 443 port from the location, which breaks matching credentials to the host.
+	if resp.Request.URL.Port() == "443" && loc.Hostname() == resp.Request.URL.Hostname() && loc.Port() == "" {
+		loc.Host = loc.Hostname() + ":443"
+	}
+	return loc.String(), nil
+}
+
+func (u *chunkedUploader) do(req *http.Request) (*http.Response, error) {
+	client := u.repo.Client
+	if client == nil {
+		client = auth.DefaultClient
+	}
+	return client.Do(req)
+}
+
+func (u *chunkedUploader) scheme() string {
+	if u.repo.PlainHTTP {
+		return "http"
+	}
+	return "https"
+}
@@ -0,0 +1,329 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package packager2
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/anchore/syft/syft/format/syftjson/model"
+	"github.com/anchore/syft/syft/source"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+
+	"github.com/zarf-dev/zarf/src/config"
+	layout2 "github.com/zarf-dev/zarf/src/internal/packager2/layout"
+	"github.com/zarf-dev/zarf/src/pkg/transform"
+	"github.com/zarf-dev/zarf/src/pkg/utils"
+)
+
+// unknownLicense is reported for packages in an SBOM without license data.
+const unknownLicense = "UNKNOWN"
+
+// componentSBOMPrefix is the file name prefix of the SBOMs of the files of a component.
+const componentSBOMPrefix = "zarf-component-"
+
+// LicensePolicy defines the licenses that are allowed in a package. License identifiers may contain glob patterns.
+type LicensePolicy struct {
+	// Allow is the list of allowed licenses, all licenses are allowed when it is empty.
+	Allow []string `json:"allow,omitempty"`
+	// Deny is the list of licenses that are never allowed, it takes precedence over Allow.
+	Deny []string `json:"deny,omitempty"`
+	// DenyUnknown disallows packages without license data.
+	DenyUnknown bool `json:"denyUnknown,omitempty"`
+}
+
+// InspectLicensesOptions are the options for InspectLicenses.
+type InspectLicensesOptions struct {
+	// Policy is the license policy packages are evaluated against, all licenses are allowed when it is empty.
+	Policy LicensePolicy
+}
+
+// LicenseSummary is a license found in the SBOMs of a package.
+type LicenseSummary struct {
+	License  string   `json:"license"`
+	Packages int      `json:"packages"`
+	Sources  []string `json:"sources"`
+	Allowed  bool     `json:"allowed"`
+}
+
+// LicenseViolation is a package in an SBOM with a license that is not allowed by the policy.
+type LicenseViolation struct {
+	Source  string `json:"source"`
+	Package string `json:"package"`
+	Version string `json:"version"`
+	License string `json:"license"`
+}
+
+// BaseImageInfo describes where an image in a package is derived from.
+type BaseImageInfo struct {
+	Reference string `json:"reference"`
+	Distro    string `json:"distro,omitempty"`
+	// Bases is the chain of base images, the closest base image first. It is derived from the OCI base image
+	// annotations and labels and followed through the images of the package.
+	Bases []string `json:"bases"`
+}
+
+// LicenseReport is the license and base image report of a package.
+type LicenseReport struct {
+	Licenses   []LicenseSummary   `json:"licenses"`
+	Violations []LicenseViolation `json:"violations"`
+	Images     []BaseImageInfo    `json:"images"`
+}
+
+// InspectLicenses aggregates the licenses of the packages in the SBOMs of the package, evaluates them against the
+// license policy and reports the base images that the images of the package are built from.
+func InspectLicenses(_ context.Context, pkgLayout *layout2.PackageLayout, opt InspectLicensesOptions) (_ LicenseReport, err error) {
+	tmpDir, err := utils.MakeTempDir(config.CommonOptions.TempDirectory)
+	if err != nil {
+		return LicenseReport{}, err
+	}
+	defer func() {
+		err = errors.Join(err, os.RemoveAll(tmpDir))
+	}()
+	sbomDir, err := pkgLayout.GetSBOM(tmpDir)
+	var noSBOMErr *layout2.NoSBOMAvailableError
+	if errors.As(err, &noSBOMErr) {
+		return licenseReport(nil, opt.Policy), nil
+	}
+	if err != nil {
+		return LicenseReport{}, fmt.Errorf("unable to read the SBOMs of the package: %w", err)
+	}
+	entries, err := os.ReadDir(sbomDir)
+	if err != nil {
+		return LicenseReport{}, err
+	}
+	docs := []model.Document{}
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".json" {
+			continue
+		}
+		b, err := os.ReadFile(filepath.Join(sbomDir, entry.Name()))
+		if err != nil {
+			return LicenseReport{}, err
+		}
+		var doc model.Document
+		err = json.Unmarshal(b, &doc)
+		if err != nil {
+			return LicenseReport{}, fmt.Errorf("unable to parse the SBOM %s: %w", entry.Name(), err)
+		}
+		if strings.HasPrefix(entry.Name(), componentSBOMPrefix) {
+			doc.Source.Name = strings.TrimSuffix(entry.Name(), ".json")
+		}
+		docs = append(docs, doc)
+	}
+	return licenseReport(docs, opt.Policy), nil
+}
+
+func licenseReport(docs []model.Document, policy LicensePolicy) LicenseReport {
+	report := LicenseReport{
+		Licenses:   []LicenseSummary{},
+		Violations: []LicenseViolation{},
+		Images:     []BaseImageInfo{},
+	}
+	summaries := map[string]*LicenseSummary{}
+	bases := map[string]string{}
+	for _, doc := range docs {
+		src := doc.Source.Name
+		imageMetadata, isImage := doc.Source.Metadata.(source.ImageMetadata)
+		if isImage {
+			src = normalizeImageRef(imageMetadata.UserInput)
+			bases[src] = baseImage(imageMetadata)
+			report.Images = append(report.Images, BaseImageInfo{Reference: src, Distro: doc.Distro.PrettyName})
+		}
+		for _, artifact := range doc.Artifacts {
+			expressions := []string{}
+			for _, l := range artifact.Licenses {
+				expression := l.SPDXExpression
+				if expression == "" {
+					expression = l.Value
+				}
+				if expression != "" && !slices.Contains(expressions, expression) {
+					expressions = append(expressions, expression)
+				}
+			}
+			if len(expressions) == 0 {
+				expressions = append(expressions, unknownLicense)
+			}
+			for _, expression := range expressions {
+				allowed := policy.allows(expression)
+				summary, ok := summaries[expression]
+				if !ok {
+					summary = &LicenseSummary{License: expression, Sources: []string{}, Allowed: allowed}
+					summaries[expression] = summary
+				}
+				summary.Packages++
+				if !slices.Contains(summary.Sources, src) {
+					summary.Sources = append(summary.Sources, src)
+				}
+				if !allowed {
+					report.Violations = append(report.Violations, LicenseViolation{
+						Source:  src,
+						Package: artifact.Name,
+						Version: artifact.Version,
+						License: expression,
+					})
+				}
+			}
+		}
+	}
+	for _, summary := range summaries {
+		slices.Sort(summary.Sources)
+		report.Licenses = append(report.Licenses, *summary)
+	}
+	slices.SortFunc(report.Licenses, func(a, b LicenseSummary) int {
+		return strings.Compare(a.License, b.License)
+	})
+	slices.SortFunc(report.Violations, func(a, b LicenseViolation) int {
+		return strings.Compare(a.Source+"\x00"+a.Package+"\x00"+a.Version, b.Source+"\x00"+b.Package+"\x00"+b.Version)
+	})
+
+	// Follow the base images through the images of the package to build the chain as far as it can be derived.
+	for i, image := range report.Images {
+		chain := []string{}
+		base := bases[image.Reference]
+		for base != "" && !slices.Contains(chain, base) {
+			chain = append(chain, base)
+			base = bases[strings.Split(base, "@sha256:")[0]]
+		}
+		report.Images[i].Bases = chain
+	}
+	slices.SortFunc(report.Images, func(a, b BaseImageInfo) int {
+		return strings.Compare(a.Reference, b.Reference)
+	})
+	return report
+}
+
+// baseImage returns the base image of the image from the OCI base image annotations of its manifest, or the labels of
+// its config when the manifest does not have them.
+func baseImage(metadata source.ImageMetadata) string {
+	var manifest ocispec.Manifest
+	if err := json.Unmarshal(metadata.RawManifest, &manifest); err != nil {
+		// Images without a readable manifest fall back to the labels.
+		manifest = ocispec.Manifest{}
+	}
+	name := manifest.Annotations[ocispec.AnnotationBaseImageName]
+	digest := manifest.Annotations[ocispec.AnnotationBaseImageDigest]
+	if name == "" {
+		name = metadata.Labels[ocispec.AnnotationBaseImageName]
+		digest = metadata.Labels[ocispec.AnnotationBaseImageDigest]
+	}
+	if name == "" {
+		return ""
+	}
+	name = normalizeImageRef(name)
+	if digest != "" && !strings.Contains(name, "@") {
+		name = fmt.Sprintf("%s@%s", name, digest)
+	}
+	return name
+}
+
+// normalizeImageRef returns the fully qualified reference of the image, or the reference as is when it can not be
+// parsed.
+func normalizeImageRef(ref string) string {
+	refInfo, err := transform.ParseImageRef(ref)
+	if err != nil {
+		return ref
+	}
+	return refInfo.Reference
+}
+
+// allows returns whether the license expression is allowed by the policy. Either side of an OR must be allowed and
+// both sides of an AND.
+func (p LicensePolicy) allows(expression string) bool {
+	if expression == unknownLicense {
+		return !p.DenyUnknown
+	}
+	tokens := tokenizeLicenseExpression(expression)
+	allowed, rest, ok := p.allowsOr(tokens)
+	if !ok || len(rest) > 0 {
+		// Values that are not SPDX expressions are matched as a whole.
+		return p.allowsLicense(expression)
+	}
+	return allowed
+}
+
+func (p LicensePolicy) allowsOr(tokens []string) (bool, []string, bool) {
+	allowed, tokens, ok := p.allowsAnd(tokens)
+	if !ok {
+		return false, nil, false
+	}
+	for len(tokens) > 0 && strings.EqualFold(tokens[0], "OR") {
+		var right bool
+		right, tokens, ok = p.allowsAnd(tokens[1:])
+		if !ok {
+			return false, nil, false
+		}
+		allowed = allowed || right
+	}
+	return allowed, tokens, true
+}
+
+func (p LicensePolicy) allowsAnd(tokens []string) (bool, []string, bool) {
+	allowed, tokens, ok := p.allowsTerm(tokens)
+	if !ok {
+		return false, nil, false
+	}
+	for len(tokens) > 0 && strings.EqualFold(tokens[0], "AND") {
+		var right bool
+		right, tokens, ok = p.allowsTerm(tokens[1:])
+		if !ok {
+			return false, nil, false
+		}
+		allowed = allowed && right
+	}
+	return allowed, tokens, true
+}
+
+func (p LicensePolicy) allowsTerm(tokens []string) (bool, []string, bool) {
+	if len(tokens) == 0 {
+		return false, nil, false
+	}
+	if tokens[0] == "(" {
+		allowed, rest, ok := p.allowsOr(tokens[1:])
+		if !ok || len(rest) == 0 || rest[0] != ")" {
+			return false, nil, false
+		}
+		return allowed, rest[1:], true
+	}
+	if tokens[0] == ")" || strings.EqualFold(tokens[0], "AND") || strings.EqualFold(tokens[0], "OR") {
+		return false, nil, false
+	}
+	allowed := p.allowsLicense(tokens[0])
+	// License exceptions only relax a license, the license itself is evaluated.
+	if len(tokens) > 2 && strings.EqualFold(tokens[1], "WITH") {
+		return allowed, tokens[3:], true
+	}
+	return allowed, tokens[1:], true
+}
+
+// allowsLicense returns whether the single license is allowed by the policy.
+func (p LicensePolicy) allowsLicense(license string) bool {
+	if matchesLicense(p.Deny, license) {
+		return false
+	}
+	return len(p.Allow) == 0 || matchesLicense(p.Allow, license)
+}
+
+// matchesLicense returns whether the license matches any of the patterns, ignoring case.
+func matchesLicense(patterns []string, license string) bool {
+	for _, pattern := range patterns {
+		if ok, err := path.Match(strings.ToLower(pattern), strings.ToLower(license)); err == nil && ok {
+			return true
+		}
+	}
+	return false
+}
+
+// tokenizeLicenseExpression splits an SPDX license expression into identifiers, operators and parentheses.
+func tokenizeLicenseExpression(expression string) []string {
+	expression = strings.NewReplacer("(", " ( ", ")", " ) ").Replace(expression)
+	return strings.Fields(expression)
+}
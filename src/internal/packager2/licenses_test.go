@@ -0,0 +1,130 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package packager2
+
+import (
+	"testing"
+
+	"github.com/anchore/syft/syft/format/syftjson/model"
+	"github.com/anchore/syft/syft/source"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLicensePolicyAllows(t *testing.T) {
+	t.Parallel()
+
+	policy := LicensePolicy{
+		Allow:       []string{"MIT", "Apache-2.0", "BSD-*", "GPL-2.0-only"},
+		Deny:        []string{"BSD-4-Clause"},
+		DenyUnknown: true,
+	}
+	tests := []struct {
+		expression string
+		expected   bool
+	}{
+		{expression: "MIT", expected: true},
+		{expression: "mit", expected: true},
+		{expression: "BSD-3-Clause", expected: true},
+		{expression: "BSD-4-Clause", expected: false},
+		{expression: "GPL-3.0-only", expected: false},
+		{expression: "MIT OR GPL-3.0-only", expected: true},
+		{expression: "MIT AND GPL-3.0-only", expected: false},
+		{expression: "(MIT OR GPL-3.0-only) AND Apache-2.0", expected: true},
+		{expression: "(MIT AND GPL-3.0-only) OR BSD-4-Clause", expected: false},
+		{expression: "GPL-2.0-only WITH Classpath-exception-2.0", expected: true},
+		{expression: "MIT License", expected: false},
+		{expression: "MIT AND (Apache-2.0", expected: false},
+		{expression: unknownLicense, expected: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expression, func(t *testing.T) {
+			t.Parallel()
+			require.Equal(t, tt.expected, policy.allows(tt.expression))
+		})
+	}
+
+	require.True(t, LicensePolicy{}.allows("GPL-3.0-only"))
+	require.True(t, LicensePolicy{}.allows(unknownLicense))
+}
+
+func TestLicenseReport(t *testing.T) {
+	t.Parallel()
+
+	newPackage := func(name, version string, licenses ...string) model.Package {
+		p := model.Package{}
+		p.Name = name
+		p.Version = version
+		for _, l := range licenses {
+			p.Licenses = append(p.Licenses, model.License{SPDXExpression: l})
+		}
+		return p
+	}
+	docs := []model.Document{
+		{
+			Source: model.Source{
+				Name: "ghcr.io/example/app",
+				Metadata: source.ImageMetadata{
+					UserInput:   "ghcr.io/example/app:1.0.0",
+					RawManifest: []byte(`{"annotations":{"org.opencontainers.image.base.name":"ghcr.io/example/base:1.0.0","org.opencontainers.image.base.digest":"sha256:abc"}}`),
+				},
+			},
+			Distro: model.LinuxRelease{PrettyName: "Alpine Linux v3.19"},
+			Artifacts: []model.Package{
+				newPackage("musl", "1.2.4", "MIT"),
+				newPackage("app", "1.0.0", "AGPL-3.0-only"),
+			},
+		},
+		{
+			Source: model.Source{
+				Name: "ghcr.io/example/base",
+				Metadata: source.ImageMetadata{
+					UserInput: "ghcr.io/example/base:1.0.0",
+					Labels:    map[string]string{"org.opencontainers.image.base.name": "alpine:3.19"},
+				},
+			},
+			Distro: model.LinuxRelease{PrettyName: "Alpine Linux v3.19"},
+			Artifacts: []model.Package{
+				newPackage("musl", "1.2.4", "MIT"),
+				newPackage("busybox", "1.36.1", "GPL-2.0-only"),
+			},
+		},
+		{
+			Source: model.Source{Name: "zarf-component-files"},
+			Artifacts: []model.Package{
+				newPackage("helper", "0.1.0"),
+			},
+		},
+	}
+	policy := LicensePolicy{Deny: []string{"AGPL-*"}, DenyUnknown: true}
+
+	report := licenseReport(docs, policy)
+	expected := LicenseReport{
+		Licenses: []LicenseSummary{
+			{License: "AGPL-3.0-only", Packages: 1, Sources: []string{"ghcr.io/example/app:1.0.0"}, Allowed: false},
+			{License: "GPL-2.0-only", Packages: 1, Sources: []string{"ghcr.io/example/base:1.0.0"}, Allowed: true},
+			{License: "MIT", Packages: 2, Sources: []string{"ghcr.io/example/app:1.0.0", "ghcr.io/example/base:1.0.0"}, Allowed: true},
+			{License: unknownLicense, Packages: 1, Sources: []string{"zarf-component-files"}, Allowed: false},
+		},
+		Violations: []LicenseViolation{
+			{Source: "ghcr.io/example/app:1.0.0", Package: "app", Version: "1.0.0", License: "AGPL-3.0-only"},
+			{Source: "zarf-component-files", Package: "helper", Version: "0.1.0", License: unknownLicense},
+		},
+		Images: []BaseImageInfo{
+			{
+				Reference: "ghcr.io/example/app:1.0.0",
+				Distro:    "Alpine Linux v3.19",
+				Bases:     []string{"ghcr.io/example/base:1.0.0@sha256:abc", "docker.io/library/alpine:3.19"},
+			},
+			{
+				Reference: "ghcr.io/example/base:1.0.0",
+				Distro:    "Alpine Linux v3.19",
+				Bases:     []string{"docker.io/library/alpine:3.19"},
+			},
+		},
+	}
+	require.Equal(t, expected, report)
+
+	report = licenseReport(nil, policy)
+	require.Equal(t, LicenseReport{Licenses: []LicenseSummary{}, Violations: []LicenseViolation{}, Images: []BaseImageInfo{}}, report)
+}
@@ -7,8 +7,10 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"net/http"
+	"maps"
 	"os"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/avast/retry-go/v4"
@@ -16,11 +18,16 @@ import (
 	"github.com/google/go-containerregistry/pkg/authn"
 	"github.com/google/go-containerregistry/pkg/crane"
 	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	clayout "github.com/google/go-containerregistry/pkg/v1/layout"
+	"github.com/google/go-containerregistry/pkg/v1/match"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 
 	"github.com/zarf-dev/zarf/src/config"
 	"github.com/zarf-dev/zarf/src/internal/dns"
 	"github.com/zarf-dev/zarf/src/internal/git"
 	"github.com/zarf-dev/zarf/src/internal/gitea"
+	"github.com/zarf-dev/zarf/src/internal/packager/images"
 	"github.com/zarf-dev/zarf/src/internal/packager2/layout"
 	"github.com/zarf-dev/zarf/src/pkg/cluster"
 	"github.com/zarf-dev/zarf/src/pkg/logger"
@@ -40,55 +47,188 @@ type MirrorOptions struct {
 	GitInfo         types.GitServerInfo
 	NoImageChecksum bool
 	Retries         int
+	// OCILayoutDir writes the images to an OCI image layout in the directory instead of pushing them to the registry.
+	OCILayoutDir string
+	// GitDir writes the repositories as bare repositories into the directory instead of pushing them to the git server.
+	GitDir string
 }
 
-// Mirror mirrors the package contents to the given registry and git server.
+// Mirror mirrors the package contents to the given registry and git server, or to the OCI layout and git directory
+// to be pushed from another host.
 func Mirror(ctx context.Context, opt MirrorOptions) error {
-	err := pushImagesToRegistry(ctx, opt.Cluster, opt.PkgLayout, opt.Filter, opt.RegistryInfo, opt.NoImageChecksum, opt.Retries)
+	var err error
+	if opt.OCILayoutDir != "" {
+		err = writeImagesToOCILayout(ctx, opt.PkgLayout, opt.Filter, opt.OCILayoutDir, opt.RegistryInfo.ImageRules, opt.NoImageChecksum)
+	} else {
+		err = pushImagesToRegistry(ctx, opt.Cluster, opt.PkgLayout, opt.Filter, opt.RegistryInfo, opt.NoImageChecksum, opt.Retries)
+	}
 	if err != nil {
 		return err
 	}
-	err = pushReposToRepository(ctx, opt.Cluster, opt.PkgLayout, opt.Filter, opt.GitInfo, opt.Retries)
+	if opt.GitDir != "" {
+		err = writeReposToDirectory(ctx, opt.PkgLayout, opt.Filter, opt.GitDir)
+	} else {
+		err = pushReposToRepository(ctx, opt.Cluster, opt.PkgLayout, opt.Filter, opt.GitInfo, opt.Retries)
+	}
 	if err != nil {
 		return err
 	}
 	return nil
 }
 
-func pushImagesToRegistry(ctx context.Context, c *cluster.Cluster, pkgLayout *layout.PackageLayout, filter filters.ComponentFilterStrategy, regInfo types.RegistryInfo, noImgChecksum bool, retries int) error {
-	l := logger.From(ctx)
-
+// mirrorImages returns the images of the components and the indexes of the images that were pulled with more than
+// one platform.
+func mirrorImages(pkgLayout *layout.PackageLayout, filter filters.ComponentFilterStrategy) (map[transform.Image]v1.Image, map[transform.Image]v1.ImageIndex, error) {
 	components, err := filter.Apply(pkgLayout.Pkg)
 	if err != nil {
-		return err
+		return nil, nil, err
 	}
-
-	images := map[transform.Image]v1.Image{}
+	toPush := map[transform.Image]v1.Image{}
+	indexes := map[transform.Image]v1.ImageIndex{}
 	for _, component := range components {
 		for _, img := range component.Images {
 			ref, err := transform.ParseImageRef(img)
 			if err != nil {
-				return fmt.Errorf("failed to create ref for image %s: %w", img, err)
+				return nil, nil, fmt.Errorf("failed to create ref for image %s: %w", img, err)
 			}
-			if _, ok := images[ref]; ok {
+			if _, ok := toPush[ref]; ok {
 				continue
 			}
 			img, err := pkgLayout.GetImage(ref)
+			if err != nil {
+				return nil, nil, err
+			}
+			toPush[ref] = img
+			idx, err := pkgLayout.GetImageIndex(ref)
+			if err != nil {
+				return nil, nil, err
+			}
+			if idx != nil {
+				indexes[ref] = idx
+			}
+		}
+	}
+	return toPush, indexes, nil
+}
+
+// mirrorImageNames returns the names an image is pushed to on the registry, the image with and without the checksum
+// unless the checksum is turned off.
+func mirrorImageNames(registryURL string, ref transform.Image, rules []transform.ImageRule, noImgChecksum bool) ([]string, error) {
+	names := []string{}
+	if !noImgChecksum {
+		offlineNameCRC, err := transform.ImageTransformHost(registryURL, ref.Reference, rules...)
+		if err != nil {
+			return nil, err
+		}
+		names = append(names, offlineNameCRC)
+	}
+	offlineName, err := transform.ImageTransformHostWithoutChecksum(registryURL, ref.Reference, rules...)
+	if err != nil {
+		return nil, err
+	}
+	return append(names, offlineName), nil
+}
+
+// writeImagesToOCILayout writes the images to an OCI image layout. Every name the image would be pushed to is a
+// descriptor in the index annotated with the name relative to the registry, so that the images can be pushed to the
+// registry from another host. Images that are already in the layout under the same name are replaced.
+func writeImagesToOCILayout(ctx context.Context, pkgLayout *layout.PackageLayout, filter filters.ComponentFilterStrategy, dir string, rules []transform.ImageRule, noImgChecksum bool) error {
+	l := logger.From(ctx)
+	toWrite, indexes, err := mirrorImages(pkgLayout, filter)
+	if err != nil {
+		return err
+	}
+	if len(toWrite) == 0 {
+		return nil
+	}
+	cl, err := clayout.FromPath(dir)
+	if err != nil {
+		if err := helpers.CreateDirectory(dir, helpers.ReadExecuteAllWriteUser); err != nil {
+			return err
+		}
+		cl, err = clayout.Write(dir, empty.Index)
+		if err != nil {
+			return err
+		}
+	}
+	for ref, img := range toWrite {
+		// The names are resolved against an empty host, which leaves the path the image has on any registry.
+		names, err := mirrorImageNames("", ref, rules, noImgChecksum)
+		if err != nil {
+			return err
+		}
+		for _, name := range names {
+			name = strings.TrimPrefix(name, "/")
+			l.Info("writing image to OCI layout", "name", name, "path", dir)
+			annotations := map[string]string{
+				ocispec.AnnotationBaseImageName: ref.Reference,
+				ocispec.AnnotationRefName:       name,
+			}
+			matcher := match.Annotation(ocispec.AnnotationRefName, name)
+			if idx, ok := indexes[ref]; ok {
+				err = cl.ReplaceIndex(idx, matcher, clayout.WithAnnotations(annotations))
+			} else {
+				err = cl.ReplaceImage(img, matcher, clayout.WithAnnotations(annotations))
+			}
+			if err != nil {
+				return fmt.Errorf("unable to write image %s to the OCI layout: %w", ref.Reference, err)
+			}
+		}
+	}
+	return nil
+}
+
+// writeReposToDirectory writes the repositories of the components as bare repositories into the directory.
+func writeReposToDirectory(ctx context.Context, pkgLayout *layout.PackageLayout, filter filters.ComponentFilterStrategy, dir string) error {
+	l := logger.From(ctx)
+	components, err := filter.Apply(pkgLayout.Pkg)
+	if err != nil {
+		return err
+	}
+	for _, component := range components {
+		if len(component.Repos) == 0 {
+			continue
+		}
+		tmpDir, err := utils.MakeTempDir(config.CommonOptions.TempDirectory)
+		if err != nil {
+			return err
+		}
+		defer os.RemoveAll(tmpDir)
+		reposPath, err := pkgLayout.GetComponentDir(tmpDir, component.Name, layout.RepoComponentDir)
+		if err != nil {
+			return err
+		}
+		for _, repoURL := range component.Repos {
+			repository, err := git.Open(reposPath, repoURL)
 			if err != nil {
 				return err
 			}
-			images[ref] = img
+			path, err := repository.PushToDirectory(ctx, dir, repoURL)
+			if err != nil {
+				return fmt.Errorf("unable to write repo %s to %s: %w", repoURL, dir, err)
+			}
+			l.Info("wrote repository to directory", "repo", repoURL, "path", path)
 		}
 	}
-	if len(images) == 0 {
+	return nil
+}
+
+func pushImagesToRegistry(ctx context.Context, c *cluster.Cluster, pkgLayout *layout.PackageLayout, filter filters.ComponentFilterStrategy, regInfo types.RegistryInfo, noImgChecksum bool, retries int) error {
+	l := logger.From(ctx)
+
+	toPush, indexes, err := mirrorImages(pkgLayout, filter)
+	if err != nil {
+		return err
+	}
+	if len(toPush) == 0 {
 		return nil
 	}
+	err = images.CreateProjects(ctx, regInfo, slices.Collect(maps.Keys(toPush)))
+	if err != nil {
+		return err
+	}
 
-	defaultTransport := http.DefaultTransport.(*http.Transport).Clone()
-	defaultTransport.TLSClientConfig.InsecureSkipVerify = config.CommonOptions.InsecureSkipTLSVerify
-	// TODO (@WSTARR) This is set to match the TLSHandshakeTimeout to potentially mitigate effects of https://github.com/zarf-dev/zarf/issues/1444
-	defaultTransport.ResponseHeaderTimeout = 10 * time.Second
-	transport := helpers.NewTransport(defaultTransport, nil)
+	transport := images.PushTransport(regInfo)
 
 	pushOptions := []crane.Option{
 		crane.WithPlatform(&v1.Platform{OS: "linux", Architecture: pkgLayout.Pkg.Build.Architecture}),
@@ -104,27 +244,34 @@ func pushImagesToRegistry(ctx context.Context, c *cluster.Cluster, pkgLayout *la
 	if config.CommonOptions.InsecureSkipTLSVerify {
 		pushOptions = append(pushOptions, crane.Insecure)
 	}
+	var scopedTokens *images.ScopedTokens
+	if regInfo.ScopedTokens {
+		scopedTokens = images.NewScopedTokens(regInfo.PushUsername, regInfo.PushPassword, transport)
+	}
 
-	for refInfo, img := range images {
+	for refInfo, img := range toPush {
 		err = retry.Do(func() error {
 			pushImage := func(registryUrl string) error {
-				names := []string{}
-				if !noImgChecksum {
-					offlineNameCRC, err := transform.ImageTransformHost(registryUrl, refInfo.Reference)
-					if err != nil {
-						return retry.Unrecoverable(err)
-					}
-					names = append(names, offlineNameCRC)
-				}
-				offlineName, err := transform.ImageTransformHostWithoutChecksum(registryUrl, refInfo.Reference)
+				names, err := mirrorImageNames(registryUrl, refInfo, regInfo.ImageRules, noImgChecksum)
 				if err != nil {
 					return retry.Unrecoverable(err)
 				}
-				names = append(names, offlineName)
 				for _, name := range names {
 					message.Infof("Pushing image %s", name)
 					l.Info("pushing image", "name", name)
-					err = crane.Push(img, name, pushOptions...)
+					opts := pushOptions
+					if scopedTokens != nil {
+						tokenOpt, err := scopedTokens.Option(name)
+						if err != nil {
+							return err
+						}
+						opts = append(slices.Clone(pushOptions), tokenOpt)
+					}
+					if idx, ok := indexes[refInfo]; ok {
+						err = images.PushIndex(idx, name, opts...)
+					} else {
+						err = crane.Push(img, name, opts...)
+					}
 					if err != nil {
 						return err
 					}
@@ -192,7 +339,7 @@ func pushReposToRepository(ctx context.Context, c *cluster.Cluster, pkgLayout *l
 				if !dns.IsServiceURL(gitInfo.Address) {
 					message.Infof("Pushing repository %s to server %s", repoURL, gitInfo.Address)
 					l.Info("pushing repository to server", "repo", repoURL, "server", gitInfo.Address)
-					err = repository.Push(ctx, gitInfo.Address, gitInfo.PushUsername, gitInfo.PushPassword)
+					err = repository.Push(ctx, gitInfo.Address, gitInfo.PushUsername, gitInfo.PushPassword, gitInfo.TLS.CABundle())
 					if err != nil {
 						return err
 					}
@@ -215,14 +362,15 @@ func pushReposToRepository(ctx context.Context, c *cluster.Cluster, pkgLayout *l
 					return err
 				}
 				defer tunnel.Close()
-				giteaClient, err := gitea.NewClient(tunnel.HTTPEndpoint(), gitInfo.PushUsername, gitInfo.PushPassword)
+				tunnelURL := tunnel.ServiceEndpoint(gitInfo.TLS)
+				giteaClient, err := gitea.NewClient(tunnelURL, gitInfo.PushUsername, gitInfo.PushPassword, gitInfo.TLS.CABundle())
 				if err != nil {
 					return err
 				}
 				return tunnel.Wrap(func() error {
-					message.Infof("Pushing repository %s to server %s", repoURL, tunnel.HTTPEndpoint())
-					l.Info("pushing repository to server", "repo", repoURL, "server", tunnel.HTTPEndpoint())
-					err = repository.Push(ctx, tunnel.HTTPEndpoint(), gitInfo.PushUsername, gitInfo.PushPassword)
+					message.Infof("Pushing repository %s to server %s", repoURL, tunnelURL)
+					l.Info("pushing repository to server", "repo", repoURL, "server", tunnelURL)
+					err = repository.Push(ctx, tunnelURL, gitInfo.PushUsername, gitInfo.PushPassword, gitInfo.TLS.CABundle())
 					if err != nil {
 						return err
 					}
@@ -0,0 +1,83 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package packager2
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/v1/layout"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/stretchr/testify/require"
+
+	layout2 "github.com/zarf-dev/zarf/src/internal/packager2/layout"
+	"github.com/zarf-dev/zarf/src/pkg/packager/filters"
+	"github.com/zarf-dev/zarf/src/pkg/transform"
+	"github.com/zarf-dev/zarf/src/test/testutil"
+)
+
+func TestMirrorToOCILayout(t *testing.T) {
+	t.Parallel()
+	ctx := testutil.TestContext(t)
+
+	pkgLayout, err := layout2.LoadFromTar(ctx, "testdata/zarf-package-test-amd64-0.0.1.tar.zst", layout2.PackageLayoutOptions{})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, pkgLayout.Cleanup())
+	})
+
+	tests := []struct {
+		name          string
+		noImgChecksum bool
+		rules         []transform.ImageRule
+		expected      []string
+	}{
+		{
+			name:     "with checksum",
+			expected: []string{"library/alpine:3.20-zarf-1117969859", "library/alpine:3.20"},
+		},
+		{
+			name:          "without checksum",
+			noImgChecksum: true,
+			expected:      []string{"library/alpine:3.20"},
+		},
+		{
+			name:          "image rules",
+			noImgChecksum: true,
+			rules:         []transform.ImageRule{{Prefix: "docker.io/library/", Replace: "mirror/"}},
+			expected:      []string{"mirror/alpine:3.20"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			dir := filepath.Join(t.TempDir(), "images")
+			opt := MirrorOptions{
+				PkgLayout:       pkgLayout,
+				Filter:          filters.Empty(),
+				NoImageChecksum: tt.noImgChecksum,
+				OCILayoutDir:    dir,
+				GitDir:          t.TempDir(),
+			}
+			opt.RegistryInfo.ImageRules = tt.rules
+			// Mirroring twice replaces the images instead of adding them again.
+			for range 2 {
+				err := Mirror(ctx, opt)
+				require.NoError(t, err)
+			}
+
+			idx, err := layout.ImageIndexFromPath(dir)
+			require.NoError(t, err)
+			manifest, err := idx.IndexManifest()
+			require.NoError(t, err)
+			names := []string{}
+			for _, desc := range manifest.Manifests {
+				require.Equal(t, "docker.io/library/alpine:3.20", desc.Annotations[ocispec.AnnotationBaseImageName])
+				names = append(names, desc.Annotations[ocispec.AnnotationRefName])
+			}
+			require.ElementsMatch(t, tt.expected, names)
+		})
+	}
+}
@@ -0,0 +1,195 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package packager2
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/user"
+	"strings"
+	"time"
+
+	"github.com/defenseunicorns/pkg/helpers/v2"
+	"github.com/defenseunicorns/pkg/oci"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/content"
+
+	"github.com/zarf-dev/zarf/src/config"
+	"github.com/zarf-dev/zarf/src/pkg/logger"
+	"github.com/zarf-dev/zarf/src/pkg/zoci"
+)
+
+const (
+	// ProvenanceArtifactType is the artifact type of the provenance records attached to copied packages.
+	ProvenanceArtifactType = "application/vnd.zarf.provenance.v1"
+	// ProvenanceMediaType is the media type of the provenance layer.
+	ProvenanceMediaType = "application/vnd.zarf.provenance.v1+json"
+	// provenanceTitle is the title of the provenance layer.
+	provenanceTitle = "provenance.json"
+)
+
+// ProvenanceRecord records a copy of a published package from one registry to another.
+type ProvenanceRecord struct {
+	// Source is the reference the package was copied from.
+	Source string `json:"source"`
+	// Destination is the reference the package was copied to.
+	Destination string `json:"destination"`
+	// CopiedBy is the user and host that copied the package.
+	CopiedBy string `json:"copiedBy"`
+	// CopiedAt is the time the package was copied.
+	CopiedAt time.Time `json:"copiedAt"`
+	// ZarfVersion is the version of Zarf that copied the package.
+	ZarfVersion string `json:"zarfVersion"`
+}
+
+// InspectProvenanceOptions are the options for InspectProvenance.
+type InspectProvenanceOptions struct {
+	// WithPlainHTTP falls back to plain HTTP for the registry calls instead of TLS.
+	WithPlainHTTP bool
+	// Architecture is the architecture of the package to inspect.
+	Architecture string
+}
+
+// InspectProvenance returns the chain of registries a published package was copied through, oldest first.
+func InspectProvenance(ctx context.Context, src string, opt InspectProvenanceOptions) ([]ProvenanceRecord, error) {
+	if !helpers.IsOCIURL(src) {
+		return nil, fmt.Errorf("provenance is only recorded for published packages, %s must be prefixed with 'oci://'", src)
+	}
+	p := oci.PlatformForArch(config.GetArch(opt.Architecture))
+	remote, err := zoci.NewRemote(ctx, src, p, oci.WithPlainHTTP(opt.WithPlainHTTP))
+	if err != nil {
+		return nil, fmt.Errorf("could not instantiate remote: %w", err)
+	}
+	return fetchProvenance(ctx, remote)
+}
+
+// fetchProvenance returns the provenance chain attached to a remote package. Every copy attaches the whole chain, so
+// only the most recent provenance artifact is read.
+func fetchProvenance(ctx context.Context, remote *zoci.Remote) ([]ProvenanceRecord, error) {
+	subject, err := remote.ResolveRoot(ctx)
+	if err != nil {
+		return nil, err
+	}
+	referrers := []ocispec.Descriptor{}
+	err = remote.Repo().Referrers(ctx, subject, ProvenanceArtifactType, func(descs []ocispec.Descriptor) error {
+		referrers = append(referrers, descs...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	records := []ProvenanceRecord{}
+	for _, referrer := range referrers {
+		manifest, err := remote.FetchManifest(ctx, referrer)
+		if err != nil {
+			return nil, err
+		}
+		for _, layer := range manifest.Layers {
+			if layer.MediaType != ProvenanceMediaType {
+				continue
+			}
+			b, err := remote.FetchLayer(ctx, layer)
+			if err != nil {
+				return nil, err
+			}
+			chain := []ProvenanceRecord{}
+			if err := json.Unmarshal(b, &chain); err != nil {
+				return nil, fmt.Errorf("unable to parse the provenance of the package: %w", err)
+			}
+			if newerProvenance(chain, records) {
+				records = chain
+			}
+		}
+	}
+	return records, nil
+}
+
+// newerProvenance returns true if chain ends with a more recent copy than current, or with the same copy after
+// more hops.
+func newerProvenance(chain, current []ProvenanceRecord) bool {
+	if len(chain) == 0 {
+		return false
+	}
+	if len(current) == 0 {
+		return true
+	}
+	last, currentLast := chain[len(chain)-1].CopiedAt, current[len(current)-1].CopiedAt
+	if last.Equal(currentLast) {
+		return len(chain) > len(current)
+	}
+	return last.After(currentLast)
+}
+
+// attachProvenance attaches the provenance chain as a referrer of the package manifest of the remote package.
+func attachProvenance(ctx context.Context, remote *zoci.Remote, chain []ProvenanceRecord) error {
+	b, err := json.MarshalIndent(chain, "", "  ")
+	if err != nil {
+		return err
+	}
+	subject, err := remote.ResolveRoot(ctx)
+	if err != nil {
+		return err
+	}
+	desc := content.NewDescriptorFromBytes(ProvenanceMediaType, b)
+	desc.Annotations = map[string]string{
+		ocispec.AnnotationTitle: provenanceTitle,
+	}
+	err = remote.Repo().Push(ctx, desc, bytes.NewReader(b))
+	if err != nil {
+		return err
+	}
+	// Registries without the referrers API keep the referrers in an index that is replaced on every push. Many of them
+	// do not allow deleting manifests, and earlier provenance artifacts are kept as the record of earlier copies anyway.
+	remote.Repo().SkipReferrersGC = true
+	packOpts := oras.PackManifestOptions{
+		Subject: &subject,
+		Layers:  []ocispec.Descriptor{desc},
+		ManifestAnnotations: map[string]string{
+			ocispec.AnnotationCreated: chain[len(chain)-1].CopiedAt.Format(time.RFC3339),
+		},
+	}
+	_, err = oras.PackManifest(ctx, remote.Repo(), oras.PackManifestVersion1_1, ProvenanceArtifactType, packOpts)
+	if err != nil {
+		return err
+	}
+	logger.From(ctx).Info("recorded package provenance", "reference", remote.Repo().Reference.String(), "hops", len(chain))
+	return nil
+}
+
+// recordProvenance appends a record of the copy from src to dst to the provenance chain of the package at src and
+// attaches it to the package at dst.
+func recordProvenance(ctx context.Context, srcRemote, dstRemote *zoci.Remote) error {
+	chain, err := fetchProvenance(ctx, srcRemote)
+	if err != nil {
+		return fmt.Errorf("unable to read the provenance of the source package: %w", err)
+	}
+	chain = append(chain, ProvenanceRecord{
+		Source:      srcRemote.Repo().Reference.String(),
+		Destination: dstRemote.Repo().Reference.String(),
+		CopiedBy:    provenanceAuthor(),
+		CopiedAt:    time.Now().UTC().Truncate(time.Second),
+		ZarfVersion: config.CLIVersion,
+	})
+	return attachProvenance(ctx, dstRemote, chain)
+}
+
+// provenanceAuthor returns the user and host that Zarf is running as, as far as they are known.
+func provenanceAuthor() string {
+	parts := []string{}
+	if u, err := user.Current(); err == nil && u.Username != "" {
+		parts = append(parts, u.Username)
+	}
+	if host, err := os.Hostname(); err == nil && host != "" {
+		parts = append(parts, host)
+	}
+	if len(parts) == 0 {
+		return "unknown"
+	}
+	return strings.Join(parts, "@")
+}
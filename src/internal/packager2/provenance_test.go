@@ -0,0 +1,122 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package packager2
+
+import (
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/zarf-dev/zarf/src/test/testutil"
+)
+
+func TestCopyProvenance(t *testing.T) {
+	ctx := testutil.TestContext(t)
+	buildRegistryRef := createRegistry(t, ctx)
+	stagingRegistryRef := createRegistry(t, ctx)
+	prodRegistryRef := createRegistry(t, ctx)
+
+	publishOpts := PublishPackageOpts{
+		WithPlainHTTP: true,
+		Architecture:  "amd64",
+	}
+	err := PublishPackage(ctx, "testdata/zarf-package-test-amd64-0.0.1.tar.zst", buildRegistryRef, publishOpts)
+	require.NoError(t, err)
+	// Publish creates a local oci manifest file using the package name, delete this to clean up test name
+	defer os.Remove("test")
+
+	build := fmt.Sprintf("oci://%s/test:0.0.1", buildRegistryRef.String())
+	staging := fmt.Sprintf("oci://%s/staging/test:0.0.1", stagingRegistryRef.String())
+	prod := fmt.Sprintf("oci://%s/prod/test:0.0.1", prodRegistryRef.String())
+
+	inspectOpts := InspectProvenanceOptions{
+		WithPlainHTTP: true,
+		Architecture:  "amd64",
+	}
+	records, err := InspectProvenance(ctx, build, inspectOpts)
+	require.NoError(t, err)
+	require.Empty(t, records)
+
+	copyOpts := CopyOptions{
+		WithPlainHTTP: true,
+		Architecture:  "amd64",
+	}
+	err = Copy(ctx, build, staging, copyOpts)
+	require.NoError(t, err)
+	err = Copy(ctx, staging, prod, copyOpts)
+	require.NoError(t, err)
+
+	records, err = InspectProvenance(ctx, staging, inspectOpts)
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+
+	records, err = InspectProvenance(ctx, prod, inspectOpts)
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+	require.Equal(t, fmt.Sprintf("%s/test:0.0.1", buildRegistryRef.String()), records[0].Source)
+	require.Equal(t, fmt.Sprintf("%s/staging/test:0.0.1", stagingRegistryRef.String()), records[0].Destination)
+	require.Equal(t, fmt.Sprintf("%s/staging/test:0.0.1", stagingRegistryRef.String()), records[1].Source)
+	require.Equal(t, fmt.Sprintf("%s/prod/test:0.0.1", prodRegistryRef.String()), records[1].Destination)
+	require.NotEmpty(t, records[1].CopiedBy)
+	require.False(t, records[1].CopiedAt.Before(records[0].CopiedAt))
+
+	_, err = InspectProvenance(ctx, "testdata/zarf-package-test-amd64-0.0.1.tar.zst", inspectOpts)
+	require.EqualError(t, err, "provenance is only recorded for published packages, testdata/zarf-package-test-amd64-0.0.1.tar.zst must be prefixed with 'oci://'")
+}
+
+func TestNewerProvenance(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	first := ProvenanceRecord{Source: "a", Destination: "b", CopiedAt: now}
+	second := ProvenanceRecord{Source: "b", Destination: "c", CopiedAt: now.Add(time.Minute)}
+
+	tests := []struct {
+		name     string
+		chain    []ProvenanceRecord
+		current  []ProvenanceRecord
+		expected bool
+	}{
+		{
+			name:     "empty chain",
+			chain:    []ProvenanceRecord{},
+			current:  []ProvenanceRecord{first},
+			expected: false,
+		},
+		{
+			name:     "no current chain",
+			chain:    []ProvenanceRecord{first},
+			current:  []ProvenanceRecord{},
+			expected: true,
+		},
+		{
+			name:     "more recent copy",
+			chain:    []ProvenanceRecord{first, second},
+			current:  []ProvenanceRecord{first},
+			expected: true,
+		},
+		{
+			name:     "older copy",
+			chain:    []ProvenanceRecord{first},
+			current:  []ProvenanceRecord{first, second},
+			expected: false,
+		},
+		{
+			name:     "same copy with more hops",
+			chain:    []ProvenanceRecord{first, first},
+			current:  []ProvenanceRecord{first},
+			expected: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			require.Equal(t, tt.expected, newerProvenance(tt.chain, tt.current))
+		})
+	}
+}
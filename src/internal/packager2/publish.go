@@ -4,13 +4,21 @@
 package packager2
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
+	"os"
 	"strings"
+	"sync"
+	"text/template"
 	"time"
 
+	"github.com/zarf-dev/zarf/src/api/v1alpha1"
 	"github.com/zarf-dev/zarf/src/config"
+	"github.com/zarf-dev/zarf/src/internal/fips"
 	"github.com/zarf-dev/zarf/src/pkg/logger"
+	"github.com/zarf-dev/zarf/src/pkg/notify"
 	"github.com/zarf-dev/zarf/src/pkg/zoci"
 
 	"github.com/defenseunicorns/pkg/oci"
@@ -35,6 +43,8 @@ type PublishFromOCIOpts struct {
 	PublicKeyPath string
 	// Architecture is the architecture we are publishing to
 	Architecture string
+	// Retag are templates for additional tags applied to the published package (e.g. "{{ .Version }}-stable").
+	Retag []string
 }
 
 // PublishFromOCI takes a source and destination registry reference and a PublishFromOCIOpts and copies the package from the source to the destination.
@@ -73,12 +83,55 @@ func PublishFromOCI(ctx context.Context, src registry.Reference, dst registry.Re
 		return fmt.Errorf("could not instantiate remote: %w", err)
 	}
 
+	if config.CommonOptions.FIPS {
+		pkg, err := srcRemote.FetchZarfYAML(ctx)
+		if err != nil {
+			return err
+		}
+		err = fips.ValidatePackage(pkg)
+		if err != nil {
+			return err
+		}
+	}
+
 	// Execute copy
 	err = zoci.CopyPackage(ctx, srcRemote, dstRemote, opts.Concurrency)
 	if err != nil {
 		return fmt.Errorf("could not copy package: %w", err)
 	}
 
+	// The package has already been copied, a missing provenance record should not fail the copy.
+	err = recordProvenance(ctx, srcRemote, dstRemote)
+	if err != nil {
+		l.Warn("unable to record the provenance of the package", "destination", dstRemote.Repo().Reference.String(), "error", err)
+	}
+
+	pkg, err := dstRemote.FetchZarfYAML(ctx)
+	if err != nil {
+		return err
+	}
+	if len(opts.Retag) > 0 {
+		tags, err := renderRetags(opts.Retag, pkg)
+		if err != nil {
+			return err
+		}
+		desc, err := dstRemote.ResolveRoot(ctx)
+		if err != nil {
+			return err
+		}
+		for _, tag := range tags {
+			l.Info("tagging package", "tag", tag)
+			err = dstRemote.UpdateIndex(ctx, tag, desc)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	n := notify.NewNotification(notify.EventPackagePublished, pkg, nil)
+	n.Reference = dst.String()
+	notify.From(ctx).Notify(ctx, n)
+
 	l.Debug("publisher2.PublishOCI done", "duration", time.Since(start))
 	return nil
 }
@@ -99,19 +152,33 @@ type PublishPackageOpts struct {
 	PublicKeyPath string
 	// Architecture is the architecture we are publishing to
 	Architecture string
+	// Retag are templates for additional tags applied to the published package (e.g. "{{ .Version }}-stable").
+	Retag []string
 }
 
 // PublishPackage takes a Path to the location of the built package, a ref to a registry, and a PublishOpts and uploads to the target OCI registry.
 func PublishPackage(ctx context.Context, path string, dst registry.Reference, opts PublishPackageOpts) error {
+	results, err := PublishPackageToDestinations(ctx, path, []registry.Reference{dst}, opts)
+	if err != nil {
+		return err
+	}
+	return results[0].Err
+}
+
+// PublishPackageToDestinations loads the built package at path once and uploads it to every destination concurrently.
+// The returned error is only set if the package could not be loaded, failed uploads are reported in the results.
+func PublishPackageToDestinations(ctx context.Context, path string, dsts []registry.Reference, opts PublishPackageOpts) (_ []PublishResult, err error) {
 	l := logger.From(ctx)
 
 	// Validate inputs
 	l.Debug("validating PublishOpts")
-	if err := dst.ValidateRegistry(); err != nil {
-		return fmt.Errorf("invalid registry: %w", err)
+	for _, dst := range dsts {
+		if err := dst.ValidateRegistry(); err != nil {
+			return nil, fmt.Errorf("invalid registry: %w", err)
+		}
 	}
 	if path == "" {
-		return fmt.Errorf("path must be specified")
+		return nil, fmt.Errorf("path must be specified")
 	}
 
 	// Load package layout
@@ -122,10 +189,15 @@ func PublishPackage(ctx context.Context, path string, dst registry.Reference, op
 	}
 	pkgLayout, err := layout2.LoadFromTar(ctx, path, layoutOpts)
 	if err != nil {
-		return fmt.Errorf("unable to load package: %w", err)
+		return nil, fmt.Errorf("unable to load package: %w", err)
 	}
+	defer func() {
+		err = errors.Join(err, pkgLayout.Cleanup())
+	}()
 
-	return pushToRemote(ctx, pkgLayout, dst, opts.Concurrency, opts.WithPlainHTTP)
+	return PublishToDestinations(ctx, dsts, func(ctx context.Context, dst registry.Reference) error {
+		return pushToRemote(ctx, pkgLayout, dst, opts.Concurrency, opts.WithPlainHTTP, opts.Retag)
+	}), nil
 }
 
 // PublishSkeletonOpts declares the parameters to publish a skeleton package.
@@ -138,19 +210,33 @@ type PublishSkeletonOpts struct {
 	SigningKeyPassword string
 	// WithPlainHTTP falls back to plain HTTP for the registry calls instead of TLS.
 	WithPlainHTTP bool
+	// Retag are templates for additional tags applied to the published package (e.g. "{{ .Version }}-stable").
+	Retag []string
 }
 
 // PublishSkeleton takes a Path to the location of the build package, a ref to a registry, and a PublishOpts and uploads the skeleton package to the target OCI registry.
 func PublishSkeleton(ctx context.Context, path string, ref registry.Reference, opts PublishSkeletonOpts) error {
+	results, err := PublishSkeletonToDestinations(ctx, path, []registry.Reference{ref}, opts)
+	if err != nil {
+		return err
+	}
+	return results[0].Err
+}
+
+// PublishSkeletonToDestinations creates the skeleton package at path once and uploads it to every destination concurrently.
+// The returned error is only set if the skeleton could not be created, failed uploads are reported in the results.
+func PublishSkeletonToDestinations(ctx context.Context, path string, dsts []registry.Reference, opts PublishSkeletonOpts) (_ []PublishResult, err error) {
 	l := logger.From(ctx)
 
 	// Validate inputs
 	l.Debug("validating PublishOpts")
-	if err := ref.ValidateRegistry(); err != nil {
-		return fmt.Errorf("invalid registry: %w", err)
+	for _, dst := range dsts {
+		if err := dst.ValidateRegistry(); err != nil {
+			return nil, fmt.Errorf("invalid registry: %w", err)
+		}
 	}
 	if path == "" {
-		return fmt.Errorf("path must be specified")
+		return nil, fmt.Errorf("path must be specified")
 	}
 
 	// Load package layout
@@ -163,8 +249,11 @@ func PublishSkeleton(ctx context.Context, path string, ref registry.Reference, o
 	}
 	buildPath, err := layout2.CreateSkeleton(ctx, path, createOpts)
 	if err != nil {
-		return fmt.Errorf("unable to create skeleton: %w", err)
+		return nil, fmt.Errorf("unable to create skeleton: %w", err)
 	}
+	defer func() {
+		err = errors.Join(err, os.RemoveAll(buildPath))
+	}()
 
 	layoutOpts := layout2.PackageLayoutOptions{
 		SkipSignatureValidation: true,
@@ -172,14 +261,16 @@ func PublishSkeleton(ctx context.Context, path string, ref registry.Reference, o
 	}
 	pkgLayout, err := layout2.LoadFromDir(ctx, buildPath, layoutOpts)
 	if err != nil {
-		return fmt.Errorf("unable to load skeleton: %w", err)
+		return nil, fmt.Errorf("unable to load skeleton: %w", err)
 	}
 
-	return pushToRemote(ctx, pkgLayout, ref, opts.Concurrency, opts.WithPlainHTTP)
+	return PublishToDestinations(ctx, dsts, func(ctx context.Context, dst registry.Reference) error {
+		return pushToRemote(ctx, pkgLayout, dst, opts.Concurrency, opts.WithPlainHTTP, opts.Retag)
+	}), nil
 }
 
 // pushToRemote pushes a package to a remote at ref.
-func pushToRemote(ctx context.Context, layout *layout2.PackageLayout, ref registry.Reference, concurrency int, plainHTTP bool) error {
+func pushToRemote(ctx context.Context, layout *layout2.PackageLayout, ref registry.Reference, concurrency int, plainHTTP bool, retag []string) error {
 	// Build Reference for remote from registry location and pkg
 	r, err := layout2.ReferenceFromMetadata(ref.String(), layout.Pkg)
 	if err != nil {
@@ -196,5 +287,78 @@ func pushToRemote(ctx context.Context, layout *layout2.PackageLayout, ref regist
 		return fmt.Errorf("could not instantiate remote: %w", err)
 	}
 
-	return rem.Push(ctx, layout, concurrency)
+	tags, err := renderRetags(retag, layout.Pkg)
+	if err != nil {
+		return err
+	}
+
+	err = rem.Push(ctx, layout, concurrency, tags...)
+	if err != nil {
+		return err
+	}
+	n := notify.NewNotification(notify.EventPackagePublished, layout.Pkg, nil)
+	n.Reference = r
+	notify.From(ctx).Notify(ctx, n)
+	return nil
+}
+
+// retagData is the data available to retag templates.
+type retagData struct {
+	Name         string
+	Version      string
+	Flavor       string
+	Architecture string
+}
+
+// renderRetags renders the retag templates with the package metadata and validates the resulting tags.
+func renderRetags(templates []string, pkg v1alpha1.ZarfPackage) ([]string, error) {
+	data := retagData{
+		Name:         pkg.Metadata.Name,
+		Version:      pkg.Metadata.Version,
+		Flavor:       pkg.Build.Flavor,
+		Architecture: pkg.Metadata.Architecture,
+	}
+	tags := []string{}
+	for _, tmpl := range templates {
+		t, err := template.New("retag").Option("missingkey=error").Parse(tmpl)
+		if err != nil {
+			return nil, fmt.Errorf("invalid retag template %q: %w", tmpl, err)
+		}
+		var buf bytes.Buffer
+		err = t.Execute(&buf, data)
+		if err != nil {
+			return nil, fmt.Errorf("unable to render retag template %q: %w", tmpl, err)
+		}
+		tag := buf.String()
+		ref := registry.Reference{Reference: tag}
+		if err := ref.ValidateReferenceAsTag(); err != nil {
+			return nil, fmt.Errorf("retag template %q rendered invalid tag %q: %w", tmpl, tag, err)
+		}
+		tags = append(tags, tag)
+	}
+	return tags, nil
+}
+
+// PublishResult is the outcome of publishing a package to a single destination.
+type PublishResult struct {
+	Destination registry.Reference
+	Err         error
+}
+
+// PublishToDestinations runs publish for each destination concurrently and returns the result for every destination.
+func PublishToDestinations(ctx context.Context, dsts []registry.Reference, publish func(context.Context, registry.Reference) error) []PublishResult {
+	results := make([]PublishResult, len(dsts))
+	var wg sync.WaitGroup
+	for i, dst := range dsts {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = PublishResult{
+				Destination: dst,
+				Err:         publish(ctx, dst),
+			}
+		}()
+	}
+	wg.Wait()
+	return results
 }
@@ -2,8 +2,11 @@ package packager2
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
 	"testing"
@@ -11,10 +14,12 @@ import (
 	"github.com/defenseunicorns/pkg/helpers/v2"
 	"github.com/defenseunicorns/pkg/oci"
 	goyaml "github.com/goccy/go-yaml"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/zarf-dev/zarf/src/api/v1alpha1"
 	"github.com/zarf-dev/zarf/src/internal/packager2/layout"
 	"github.com/zarf-dev/zarf/src/pkg/lint"
+	"github.com/zarf-dev/zarf/src/pkg/notify"
 	"github.com/zarf-dev/zarf/src/pkg/packager/filters"
 	"github.com/zarf-dev/zarf/src/pkg/zoci"
 	"github.com/zarf-dev/zarf/src/test/testutil"
@@ -245,6 +250,32 @@ func TestPublishPackage(t *testing.T) {
 	}
 }
 
+func TestPublishPackageToDestinations(t *testing.T) {
+	ctx := testutil.TestContext(t)
+	path := "testdata/zarf-package-test-amd64-0.0.1.tar.zst"
+	dsts := []registry.Reference{createRegistry(t, ctx), createRegistry(t, ctx)}
+
+	results, err := PublishPackageToDestinations(ctx, path, dsts, PublishPackageOpts{WithPlainHTTP: true})
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+
+	layoutExpected, err := layout.LoadFromTar(ctx, path, layout.PackageLayoutOptions{})
+	require.NoError(t, err)
+	// Publish creates a local oci manifest file using the package name, delete this to clean up test name
+	defer os.Remove(layoutExpected.Pkg.Metadata.Name)
+	for i, result := range results {
+		require.Equal(t, dsts[i], result.Destination)
+		require.NoError(t, result.Err)
+		packageRef, err := zoci.ReferenceFromMetadata(result.Destination.String(), &layoutExpected.Pkg.Metadata, &layoutExpected.Pkg.Build)
+		require.NoError(t, err)
+		layoutActual := pullFromRemote(t, ctx, packageRef, "amd64")
+		require.Equal(t, layoutExpected.Pkg, layoutActual.Pkg, "Uploaded package is not identical to downloaded package")
+	}
+
+	_, err = PublishPackageToDestinations(ctx, "testdata/missing.tar.zst", dsts, PublishPackageOpts{WithPlainHTTP: true})
+	require.ErrorContains(t, err, "unable to load package")
+}
+
 func TestPublishCopySHA(t *testing.T) {
 	tt := []struct {
 		name             string
@@ -357,13 +388,26 @@ func TestPublishCopyTag(t *testing.T) {
 				Concurrency:   tc.opts.Concurrency,
 			}
 
+			var notification notify.Notification
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.NoError(t, json.NewDecoder(r.Body).Decode(&notification))
+				w.WriteHeader(http.StatusOK)
+			}))
+			t.Cleanup(srv.Close)
+			notifier, err := notify.NewNotifier([]notify.Webhook{{URL: srv.URL, Events: []notify.Event{notify.EventPackagePublished}}})
+			require.NoError(t, err)
+
 			// Publish test package
-			err = PublishFromOCI(ctx, srcRegistry, dstRegistry, opts)
+			err = PublishFromOCI(notify.WithContext(ctx, notifier), srcRegistry, dstRegistry, opts)
 			require.NoError(t, err)
 
 			// We want to pull the package and sure the content is the same as the local package
 			layoutExpected, err := layout.LoadFromTar(ctx, tc.packageToPublish, layout.PackageLayoutOptions{})
 			require.NoError(t, err)
+			require.Equal(t, layoutExpected.Pkg.Metadata.Name, notification.Package)
+			require.Equal(t, layoutExpected.Pkg.Metadata.Version, notification.Version)
+			require.Equal(t, layoutExpected.Pkg.Metadata.Architecture, notification.Architecture)
+			require.Equal(t, dst, notification.Reference)
 			// Publish creates a local oci manifest file using the package name, delete this to clean up test name
 			defer os.Remove(layoutExpected.Pkg.Metadata.Name)
 			// Format url and instantiate remote
@@ -376,3 +420,74 @@ func TestPublishCopyTag(t *testing.T) {
 		})
 	}
 }
+
+func TestRenderRetags(t *testing.T) {
+	t.Parallel()
+
+	pkg := v1alpha1.ZarfPackage{
+		Metadata: v1alpha1.ZarfMetadata{
+			Name:         "test",
+			Version:      "1.0.0",
+			Architecture: "amd64",
+		},
+		Build: v1alpha1.ZarfBuildData{
+			Flavor: "upstream",
+		},
+	}
+
+	tests := []struct {
+		name        string
+		templates   []string
+		expected    []string
+		expectedErr string
+	}{
+		{
+			name:      "literal and templated tags",
+			templates: []string{"stable", "{{ .Version }}-{{ .Flavor }}-stable", "{{ .Name }}-{{ .Architecture }}"},
+			expected:  []string{"stable", "1.0.0-upstream-stable", "test-amd64"},
+		},
+		{
+			name:        "unknown field",
+			templates:   []string{"{{ .Channel }}"},
+			expectedErr: "unable to render retag template \"{{ .Channel }}\"",
+		},
+		{
+			name:        "invalid tag",
+			templates:   []string{"{{ .Version }}:stable"},
+			expectedErr: "retag template \"{{ .Version }}:stable\" rendered invalid tag \"1.0.0:stable\"",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			tags, err := renderRetags(tt.templates, pkg)
+			if tt.expectedErr != "" {
+				require.ErrorContains(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, tags)
+		})
+	}
+}
+
+func TestPublishToDestinations(t *testing.T) {
+	t.Parallel()
+
+	dsts := []registry.Reference{
+		{Registry: "localhost:5000", Repository: "staging"},
+		{Registry: "localhost:5001", Repository: "production"},
+	}
+	results := PublishToDestinations(context.Background(), dsts, func(_ context.Context, ref registry.Reference) error {
+		if ref.Repository == "production" {
+			return errors.New("unauthorized")
+		}
+		return nil
+	})
+	require.Len(t, results, 2)
+	require.Equal(t, dsts[0], results[0].Destination)
+	require.NoError(t, results[0].Err)
+	require.Equal(t, dsts[1], results[1].Destination)
+	require.EqualError(t, results[1].Err, "unauthorized")
+}
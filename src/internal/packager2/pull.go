@@ -12,6 +12,7 @@ import (
 	"net/url"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"time"
 
@@ -23,20 +24,59 @@ import (
 	goyaml "github.com/goccy/go-yaml"
 	"github.com/mholt/archiver/v3"
 	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"go.opentelemetry.io/otel/attribute"
 
 	"github.com/zarf-dev/zarf/src/api/v1alpha1"
 	"github.com/zarf-dev/zarf/src/config"
 	"github.com/zarf-dev/zarf/src/internal/packager2/layout"
+	"github.com/zarf-dev/zarf/src/internal/tracing"
+	"github.com/zarf-dev/zarf/src/pkg/events"
 	"github.com/zarf-dev/zarf/src/pkg/packager/filters"
 	"github.com/zarf-dev/zarf/src/pkg/utils"
 	"github.com/zarf-dev/zarf/src/pkg/zoci"
 )
 
-// TODO: Add options struct
+const (
+	// PullLayerMetadata selects the zarf.yaml, checksums, signature, release notes and build info of a package.
+	PullLayerMetadata = "metadata"
+	// PullLayerSBOMs selects the SBOMs of a package.
+	PullLayerSBOMs = "sboms"
+)
+
+// PullOptions are the options for Pull.
+type PullOptions struct {
+	Shasum                  string
+	Architecture            string
+	PublicKeyPath           string
+	SkipSignatureValidation bool
+	Filter                  filters.ComponentFilterStrategy
+	// Layers selects the layers pulled from oci:// sources, such as metadata and sboms. The layers are written to a
+	// directory named after the package instead of a package tarball. The metadata of the package is always pulled.
+	Layers []string
+}
+
 // Pull fetches the Zarf package from the given sources.
-func Pull(ctx context.Context, src, dir, shasum, architecture string, filter filters.ComponentFilterStrategy, publicKeyPath string, skipSignatureValidation bool) error {
+func Pull(ctx context.Context, src, dir string, opt PullOptions) (err error) {
+	ctx, span := tracing.Start(ctx, "pull", attribute.String("source", src))
+	events.From(ctx).Phase("pull", events.StatusStarted, nil)
+	defer func() {
+		events.From(ctx).Phase("pull", events.Done(err), err)
+		tracing.End(span, err)
+	}()
 	l := logger.From(ctx)
 	start := time.Now()
+	// ensure architecture is set
+	architecture := config.GetArch(opt.Architecture)
+	if helpers.IsOCIURL(src) {
+		resolved, err := zoci.ResolveVersionConstraint(ctx, src, oci.PlatformForArch(architecture))
+		if err != nil {
+			return err
+		}
+		if resolved != src {
+			l.Info("resolved package version", "constraint", src, "resolved", resolved)
+			src = resolved
+		}
+	}
 	u, err := url.Parse(src)
 	if err != nil {
 		return err
@@ -47,8 +87,13 @@ func Pull(ctx context.Context, src, dir, shasum, architecture string, filter fil
 	if u.Host == "" {
 		return errors.New("host cannot be empty")
 	}
-	// ensure architecture is set
-	architecture = config.GetArch(architecture)
+	if len(opt.Layers) > 0 {
+		if u.Scheme != "oci" {
+			return errors.New("layers can only be selected when pulling from an oci:// source")
+		}
+		opt.Architecture = architecture
+		return pullOCILayers(ctx, src, dir, opt)
+	}
 
 	tmpDir, err := utils.MakeTempDir(config.CommonOptions.TempDirectory)
 	if err != nil {
@@ -60,14 +105,14 @@ func Pull(ctx context.Context, src, dir, shasum, architecture string, filter fil
 	isPartial := false
 	switch u.Scheme {
 	case "oci":
-		l.Info("starting pull from oci source", "src", src, "digest", shasum)
-		isPartial, tmpPath, err = pullOCI(ctx, src, tmpDir, shasum, architecture, filter)
+		l.Info("starting pull from oci source", "src", src, "digest", opt.Shasum)
+		isPartial, tmpPath, err = pullOCI(ctx, src, tmpDir, opt.Shasum, architecture, opt.Filter)
 		if err != nil {
 			return err
 		}
 	case "http", "https":
-		l.Info("starting pull from http(s) source", "src", src, "digest", shasum)
-		tmpPath, err = pullHTTP(ctx, src, tmpDir, shasum)
+		l.Info("starting pull from http(s) source", "src", src, "digest", opt.Shasum)
+		tmpPath, err = pullHTTP(ctx, src, tmpDir, opt.Shasum)
 		if err != nil {
 			return err
 		}
@@ -77,14 +122,35 @@ func Pull(ctx context.Context, src, dir, shasum, architecture string, filter fil
 
 	// This loadFromTar is done so that validatePackageIntegrtiy and validatePackageSignature are called
 	layoutOpt := layout.PackageLayoutOptions{
-		PublicKeyPath:           publicKeyPath,
-		SkipSignatureValidation: skipSignatureValidation,
+		PublicKeyPath:           opt.PublicKeyPath,
+		SkipSignatureValidation: opt.SkipSignatureValidation,
 		IsPartial:               isPartial,
 	}
-	_, err = layout.LoadFromTar(ctx, tmpPath, layoutOpt)
+	pkgLayout, err := layout.LoadFromTar(ctx, tmpPath, layoutOpt)
 	if err != nil {
 		return err
 	}
+	defer pkgLayout.Cleanup()
+
+	// A package pulled with some of its components is rewritten into a valid package that only holds them. Sources
+	// that can not be pulled by component are pulled whole and reduced the same way.
+	components, err := opt.Filter.Apply(pkgLayout.Pkg)
+	if err != nil {
+		return err
+	}
+	if isPartial || len(components) != len(pkgLayout.Pkg.Components) {
+		if len(components) == 0 {
+			return fmt.Errorf("none of the components of %s were selected", src)
+		}
+		if err := pkgLayout.Reduce(ctx, components); err != nil {
+			return fmt.Errorf("unable to reduce the package to the selected components: %w", err)
+		}
+		if err := pkgLayout.Archive(ctx, dir, 0); err != nil {
+			return err
+		}
+		l.Debug("done packager2.Pull", "src", src, "dir", dir, "duration", time.Since(start))
+		return nil
+	}
 
 	name, err := nameFromMetadata(tmpPath)
 	if err != nil {
@@ -132,6 +198,7 @@ func pullOCI(ctx context.Context, src, tarDir, shasum string, architecture strin
 	if err != nil {
 		return false, "", fmt.Errorf("could not find package %s with architecture %s: %w", src, platform.Architecture, err)
 	}
+	logger.From(ctx).Debug("resolved package reference", "src", src, "digest", desc.Digest.String())
 	layersToPull := []ocispec.Descriptor{}
 	isPartial := false
 	tarPath := filepath.Join(tarDir, "data.tar")
@@ -143,20 +210,17 @@ func pullOCI(ctx context.Context, src, tarDir, shasum string, architecture strin
 		tarPath = fmt.Sprintf("%s.zst", tarPath)
 	}
 	if supportsFiltering(desc.Platform) {
-		root, err := remote.FetchRoot(ctx)
+		requested, err := filter.Apply(pkg)
 		if err != nil {
 			return false, "", err
 		}
-		if len(root.Layers) != len(layersToPull) {
+		// The whole package is pulled when every component is requested.
+		if len(requested) != len(pkg.Components) {
 			isPartial = true
-		}
-		pkg.Components, err = filter.Apply(pkg)
-		if err != nil {
-			return false, "", err
-		}
-		layersToPull, err = remote.LayersFromRequestedComponents(ctx, pkg.Components)
-		if err != nil {
-			return false, "", err
+			layersToPull, err = remote.LayersFromRequestedComponents(ctx, requested)
+			if err != nil {
+				return false, "", err
+			}
 		}
 	}
 	_, err = remote.PullPackage(ctx, tmpDir, config.CommonOptions.OCIConcurrency, layersToPull...)
@@ -174,6 +238,69 @@ func pullOCI(ctx context.Context, src, tarDir, shasum string, architecture strin
 	return isPartial, tarPath, nil
 }
 
+// pullLayerPaths returns the paths of the package that make up the given layers.
+func pullLayerPaths(layers []string) ([]string, error) {
+	paths := slices.Clone(zoci.PackageAlwaysPull)
+	for _, l := range layers {
+		switch l {
+		case PullLayerMetadata:
+		case PullLayerSBOMs:
+			paths = append(paths, layout.SBOMTar)
+		default:
+			return nil, fmt.Errorf("invalid layer %s, valid layers are %s and %s", l, PullLayerMetadata, PullLayerSBOMs)
+		}
+	}
+	return paths, nil
+}
+
+// pullOCILayers pulls the selected layers of the package at src into a directory within dir named after the package.
+// The layers are verified against the checksums and signature of the package before they are written.
+func pullOCILayers(ctx context.Context, src, dir string, opt PullOptions, mods ...oci.Modifier) error {
+	paths, err := pullLayerPaths(opt.Layers)
+	if err != nil {
+		return err
+	}
+	if opt.Shasum != "" {
+		src = fmt.Sprintf("%s@sha256:%s", src, opt.Shasum)
+	}
+	platform := oci.PlatformForArch(opt.Architecture)
+	remote, err := zoci.NewRemote(ctx, src, platform, mods...)
+	if err != nil {
+		return err
+	}
+	if _, err := remote.ResolveRoot(ctx); err != nil {
+		return fmt.Errorf("could not find package %s with architecture %s: %w", src, platform.Architecture, err)
+	}
+	tmpDir, err := utils.MakeTempDir(config.CommonOptions.TempDirectory)
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmpDir)
+	logger.From(ctx).Info("pulling selected package layers", "src", src, "layers", opt.Layers)
+	if _, err := remote.PullPaths(ctx, tmpDir, paths); err != nil {
+		return err
+	}
+	layoutOpt := layout.PackageLayoutOptions{
+		PublicKeyPath:           opt.PublicKeyPath,
+		SkipSignatureValidation: opt.SkipSignatureValidation,
+		IsPartial:               true,
+	}
+	pkgLayout, err := layout.LoadFromDir(ctx, tmpDir, layoutOpt)
+	if err != nil {
+		return err
+	}
+
+	dst := filepath.Join(dir, packageName(pkgLayout.Pkg))
+	if err := os.RemoveAll(dst); err != nil {
+		return err
+	}
+	if err := helpers.CreatePathAndCopy(tmpDir, dst); err != nil {
+		return err
+	}
+	logger.From(ctx).Info("pulled package layers", "path", dst)
+	return nil
+}
+
 func pullHTTP(ctx context.Context, src, tarDir, shasum string) (string, error) {
 	if shasum == "" {
 		return "", errors.New("shasum cannot be empty")
@@ -266,7 +393,15 @@ func nameFromMetadata(path string) (string, error) {
 	if pkg.Metadata.Name == "" {
 		return "", fmt.Errorf("%s does not contain a zarf.yaml", path)
 	}
+	name := packageName(pkg)
+	if pkg.Metadata.Uncompressed {
+		return fmt.Sprintf("%s.tar", name), nil
+	}
+	return fmt.Sprintf("%s.tar.zst", name), nil
+}
 
+// packageName returns the name of the package without the extension of its tarball.
+func packageName(pkg v1alpha1.ZarfPackage) string {
 	arch := config.GetArch(pkg.Metadata.Architecture, pkg.Build.Architecture)
 	if pkg.Build.Architecture == zoci.SkeletonArch {
 		arch = zoci.SkeletonArch
@@ -286,10 +421,7 @@ func nameFromMetadata(path string) (string, error) {
 	} else if pkg.Metadata.Version != "" {
 		name = fmt.Sprintf("%s-%s", name, pkg.Metadata.Version)
 	}
-	if pkg.Metadata.Uncompressed {
-		return fmt.Sprintf("%s.tar", name), nil
-	}
-	return fmt.Sprintf("%s.tar.zst", name), nil
+	return name
 }
 
 func supportsFiltering(platform *ocispec.Platform) bool {
@@ -14,6 +14,7 @@ import (
 	"github.com/defenseunicorns/pkg/oci"
 	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 	"github.com/stretchr/testify/require"
+	"github.com/zarf-dev/zarf/src/internal/packager2/layout"
 	"github.com/zarf-dev/zarf/src/pkg/packager/filters"
 	"github.com/zarf-dev/zarf/src/pkg/zoci"
 	"github.com/zarf-dev/zarf/src/test/testutil"
@@ -39,7 +40,7 @@ func TestPull(t *testing.T) {
 
 	dir := t.TempDir()
 	shasum := "f9b15b1bc0f760a87bad68196b339a8ce8330e3a0241191a826a8962a88061f1"
-	err := Pull(ctx, srv.URL, dir, shasum, "amd64", filters.Empty(), "", false)
+	err := Pull(ctx, srv.URL, dir, PullOptions{Shasum: shasum, Architecture: "amd64", Filter: filters.Empty()})
 	require.NoError(t, err)
 
 	packageData, err := os.ReadFile(packagePath)
@@ -70,7 +71,7 @@ func TestPullUncompressed(t *testing.T) {
 
 	dir := t.TempDir()
 	shasum := "a118a4d306acc5dd4eab2c161e78fa3dfd1e08ae1e1794a4393be98c79257f5c"
-	err := Pull(ctx, srv.URL, dir, shasum, "amd64", filters.Empty(), "", false)
+	err := Pull(ctx, srv.URL, dir, PullOptions{Shasum: shasum, Architecture: "amd64", Filter: filters.Empty()})
 	require.NoError(t, err)
 
 	packageData, err := os.ReadFile(packagePath)
@@ -101,7 +102,7 @@ func TestPullUnsupported(t *testing.T) {
 
 	dir := t.TempDir()
 	shasum := "6e9dccce07ba9d3c45b7c872fae863c5415d296fd5e2fb72a2583530aa750ccd"
-	err := Pull(ctx, srv.URL, dir, shasum, "amd64", filters.Empty(), "", false)
+	err := Pull(ctx, srv.URL, dir, PullOptions{Shasum: shasum, Architecture: "amd64", Filter: filters.Empty()})
 	require.EqualError(t, err, "unsupported file type: .txt", "unsupported file type: .txt")
 }
 
@@ -138,3 +139,70 @@ func TestSupportsFiltering(t *testing.T) {
 		})
 	}
 }
+
+func TestPullLayers(t *testing.T) {
+	ctx := testutil.TestContext(t)
+	packagePath := "testdata/zarf-package-test-amd64-0.0.1.tar.zst"
+	registryRef := createRegistry(t, ctx)
+	err := PublishPackage(ctx, packagePath, registryRef, PublishPackageOpts{WithPlainHTTP: true})
+	require.NoError(t, err)
+	pkgLayout, err := layout.LoadFromTar(ctx, packagePath, layout.PackageLayoutOptions{})
+	require.NoError(t, err)
+	// Publish creates a local oci manifest file using the package name.
+	t.Cleanup(func() {
+		os.Remove(pkgLayout.Pkg.Metadata.Name)
+	})
+	packageRef, err := zoci.ReferenceFromMetadata(registryRef.String(), &pkgLayout.Pkg.Metadata, &pkgLayout.Pkg.Build)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name          string
+		layers        []string
+		expectedFiles []string
+		expectedErr   string
+	}{
+		{
+			name:          "metadata",
+			layers:        []string{PullLayerMetadata},
+			expectedFiles: []string{layout.Checksums, layout.ZarfYAML},
+		},
+		{
+			name:          "metadata and sboms",
+			layers:        []string{PullLayerMetadata, PullLayerSBOMs},
+			expectedFiles: []string{layout.Checksums, layout.SBOMTar, layout.ZarfYAML},
+		},
+		{
+			name:          "sboms",
+			layers:        []string{PullLayerSBOMs},
+			expectedFiles: []string{layout.Checksums, layout.SBOMTar, layout.ZarfYAML},
+		},
+		{
+			name:        "invalid layer",
+			layers:      []string{"images"},
+			expectedErr: "invalid layer images, valid layers are metadata and sboms",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			opt := PullOptions{Architecture: "amd64", Layers: tt.layers}
+			err := pullOCILayers(ctx, packageRef, dir, opt, oci.WithPlainHTTP(true))
+			if tt.expectedErr != "" {
+				require.EqualError(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+
+			entries, err := os.ReadDir(filepath.Join(dir, "zarf-package-test-amd64-0.0.1"))
+			require.NoError(t, err)
+			files := []string{}
+			for _, entry := range entries {
+				files = append(files, entry.Name())
+			}
+			require.Equal(t, tt.expectedFiles, files)
+		})
+	}
+
+	err = Pull(ctx, "https://example.com/package.tar.zst", t.TempDir(), PullOptions{Shasum: "abc", Layers: []string{PullLayerSBOMs}})
+	require.EqualError(t, err, "layers can only be selected when pulling from an oci:// source")
+}
@@ -0,0 +1,288 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package packager2
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/zarf-dev/zarf/src/api/v1alpha1"
+	layout2 "github.com/zarf-dev/zarf/src/internal/packager2/layout"
+	"github.com/zarf-dev/zarf/src/pkg/cluster"
+	"github.com/zarf-dev/zarf/src/pkg/utils"
+)
+
+var (
+	// deployVerbs are the verbs Helm needs to install, upgrade, roll back and wait for a resource.
+	deployVerbs = []string{"get", "list", "watch", "create", "update", "patch", "delete"}
+	// readVerbs are the verbs needed to wait for a resource.
+	readVerbs = []string{"get", "list", "watch"}
+)
+
+// clusterScopedKinds are the built-in kinds that are not namespaced.
+var clusterScopedKinds = map[schema.GroupKind]bool{
+	{Kind: "Namespace"}:        true,
+	{Kind: "Node"}:             true,
+	{Kind: "PersistentVolume"}: true,
+	{Group: "admissionregistration.k8s.io", Kind: "MutatingWebhookConfiguration"}:     true,
+	{Group: "admissionregistration.k8s.io", Kind: "ValidatingWebhookConfiguration"}:   true,
+	{Group: "admissionregistration.k8s.io", Kind: "ValidatingAdmissionPolicy"}:        true,
+	{Group: "admissionregistration.k8s.io", Kind: "ValidatingAdmissionPolicyBinding"}: true,
+	{Group: "apiextensions.k8s.io", Kind: "CustomResourceDefinition"}:                 true,
+	{Group: "apiregistration.k8s.io", Kind: "APIService"}:                             true,
+	{Group: "networking.k8s.io", Kind: "IngressClass"}:                                true,
+	{Group: "node.k8s.io", Kind: "RuntimeClass"}:                                      true,
+	{Group: "policy", Kind: "PodSecurityPolicy"}:                                      true,
+	{Group: "rbac.authorization.k8s.io", Kind: "ClusterRole"}:                         true,
+	{Group: "rbac.authorization.k8s.io", Kind: "ClusterRoleBinding"}:                  true,
+	{Group: "scheduling.k8s.io", Kind: "PriorityClass"}:                               true,
+	{Group: "storage.k8s.io", Kind: "CSIDriver"}:                                      true,
+	{Group: "storage.k8s.io", Kind: "CSINode"}:                                        true,
+	{Group: "storage.k8s.io", Kind: "StorageClass"}:                                   true,
+	{Group: "storage.k8s.io", Kind: "VolumeAttachment"}:                               true,
+}
+
+// RBACOptions are the options for GenerateRBAC.
+type RBACOptions struct {
+	SetVariables map[string]string
+	KubeVersion  string
+	// RegistryURL is the registry address used for the ###ZARF_REGISTRY### template, it defaults to the in cluster registry.
+	RegistryURL string
+	// ServiceAccount in the form namespace/name that the roles are bound to, no bindings are generated when it is empty.
+	ServiceAccount string
+}
+
+// GenerateRBAC renders the charts and manifests of the package and returns the ClusterRole, Roles and optionally the
+// bindings with the permissions that a service account needs to deploy it with `zarf package deploy --service-account`.
+func GenerateRBAC(ctx context.Context, pkgLayout *layout2.PackageLayout, opt RBACOptions) ([]runtime.Object, error) {
+	rendered, err := InspectManifests(ctx, pkgLayout, InspectManifestsOptions{
+		SetVariables: opt.SetVariables,
+		KubeVersion:  opt.KubeVersion,
+		RegistryURL:  opt.RegistryURL,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return rbacForPackage(pkgLayout.Pkg, rendered, opt.ServiceAccount)
+}
+
+// rbacRules collects the resources and verbs of the rules of a role.
+type rbacRules map[schema.GroupResource]map[string]bool
+
+func (r rbacRules) add(gr schema.GroupResource, verbs ...string) {
+	if r[gr] == nil {
+		r[gr] = map[string]bool{}
+	}
+	for _, verb := range verbs {
+		r[gr][verb] = true
+	}
+}
+
+// policyRules returns the rules sorted by group and resource, resources of the same group with the same verbs share a
+// rule.
+func (r rbacRules) policyRules() []rbacv1.PolicyRule {
+	grs := slices.SortedFunc(maps.Keys(r), func(a, b schema.GroupResource) int {
+		return cmp.Or(cmp.Compare(a.Group, b.Group), cmp.Compare(a.Resource, b.Resource))
+	})
+	rules := []rbacv1.PolicyRule{}
+	for _, gr := range grs {
+		verbs := sortedVerbs(r[gr])
+		if len(rules) > 0 {
+			last := &rules[len(rules)-1]
+			if last.APIGroups[0] == gr.Group && slices.Equal(last.Verbs, verbs) {
+				last.Resources = append(last.Resources, gr.Resource)
+				continue
+			}
+		}
+		rules = append(rules, rbacv1.PolicyRule{
+			APIGroups: []string{gr.Group},
+			Resources: []string{gr.Resource},
+			Verbs:     verbs,
+		})
+	}
+	return rules
+}
+
+// sortedVerbs returns the verbs in the order of deployVerbs followed by any other verbs.
+func sortedVerbs(verbs map[string]bool) []string {
+	sorted := []string{}
+	for _, verb := range deployVerbs {
+		if verbs[verb] {
+			sorted = append(sorted, verb)
+		}
+	}
+	for _, verb := range slices.Sorted(maps.Keys(verbs)) {
+		if !slices.Contains(deployVerbs, verb) {
+			sorted = append(sorted, verb)
+		}
+	}
+	return sorted
+}
+
+func rbacForPackage(pkg v1alpha1.ZarfPackage, rendered []RenderedManifest, serviceAccount string) ([]runtime.Object, error) {
+	objects := []*unstructured.Unstructured{}
+	namespaces := map[*unstructured.Unstructured]string{}
+	for _, r := range rendered {
+		objs, err := utils.SplitYAML([]byte(r.Content))
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse the %s %s of component %s: %w", r.Kind, r.Name, r.Component, err)
+		}
+		for _, obj := range objs {
+			objects = append(objects, obj)
+			namespaces[obj] = r.Namespace
+		}
+	}
+
+	// Resources of custom resource definitions in the package are resolved with the definition instead of a guess.
+	crdResources := map[schema.GroupKind]schema.GroupResource{}
+	crdScopes := map[schema.GroupKind]bool{}
+	for _, obj := range objects {
+		if obj.GroupVersionKind().GroupKind() != (schema.GroupKind{Group: "apiextensions.k8s.io", Kind: "CustomResourceDefinition"}) {
+			continue
+		}
+		group, _, _ := unstructured.NestedString(obj.Object, "spec", "group")
+		kind, _, _ := unstructured.NestedString(obj.Object, "spec", "names", "kind")
+		plural, _, _ := unstructured.NestedString(obj.Object, "spec", "names", "plural")
+		scope, _, _ := unstructured.NestedString(obj.Object, "spec", "scope")
+		gk := schema.GroupKind{Group: group, Kind: kind}
+		crdResources[gk] = schema.GroupResource{Group: group, Resource: plural}
+		crdScopes[gk] = scope == "Cluster"
+	}
+
+	clusterRules := rbacRules{}
+	namespaceRules := map[string]rbacRules{}
+	namespaceRulesFor := func(namespace string) rbacRules {
+		if namespaceRules[namespace] == nil {
+			namespaceRules[namespace] = rbacRules{}
+		}
+		return namespaceRules[namespace]
+	}
+	groupResource := func(gvk schema.GroupVersionKind) schema.GroupResource {
+		if gr, ok := crdResources[gvk.GroupKind()]; ok {
+			return gr
+		}
+		plural, _ := meta.UnsafeGuessKindToResource(gvk)
+		return plural.GroupResource()
+	}
+
+	// Zarf reads the state, records the deployed package and finds the registry and git server in the Zarf namespace,
+	// and labels the namespaces that resources are deployed to.
+	zarfRules := namespaceRulesFor(cluster.ZarfNamespaceName)
+	zarfRules.add(schema.GroupResource{Resource: "secrets"}, "get", "list", "create", "update", "patch")
+	zarfRules.add(schema.GroupResource{Resource: "services"}, "get", "list")
+	zarfRules.add(schema.GroupResource{Resource: "pods"}, "get", "list")
+	zarfRules.add(schema.GroupResource{Resource: "pods/portforward"}, "create")
+	clusterRules.add(schema.GroupResource{Resource: "namespaces"}, "get", "list", "watch", "create", "update", "patch")
+
+	for _, obj := range objects {
+		gvk := obj.GroupVersionKind()
+		if gvk.Kind == "" {
+			continue
+		}
+		gr := groupResource(gvk)
+		namespace := obj.GetNamespace()
+		if namespace == "" {
+			namespace = namespaces[obj]
+		}
+		clusterScoped, isCRD := crdScopes[gvk.GroupKind()]
+		if !isCRD {
+			clusterScoped = clusterScopedKinds[gvk.GroupKind()]
+		}
+		if clusterScoped || namespace == "" {
+			clusterRules.add(gr, deployVerbs...)
+			continue
+		}
+		namespaceRulesFor(namespace).add(gr, deployVerbs...)
+	}
+
+	for _, component := range pkg.Components {
+		// Charts and manifests are installed as Helm releases, which are stored as secrets in the release namespace.
+		// The same namespaces get the pull secrets of the registry and git server.
+		for _, chart := range component.Charts {
+			namespaceRulesFor(namespaceOrDefault(chart.Namespace)).add(schema.GroupResource{Resource: "secrets"}, deployVerbs...)
+		}
+		for _, manifest := range component.Manifests {
+			namespaceRulesFor(namespaceOrDefault(manifest.Namespace)).add(schema.GroupResource{Resource: "secrets"}, deployVerbs...)
+		}
+		for _, data := range component.DataInjections {
+			rules := namespaceRulesFor(data.Target.Namespace)
+			rules.add(schema.GroupResource{Resource: "pods"}, readVerbs...)
+			rules.add(schema.GroupResource{Resource: "pods/exec"}, "create")
+			if data.Target.PersistentVolumeClaim != "" {
+				rules.add(schema.GroupResource{Resource: "pods"}, "create", "delete")
+			}
+		}
+		for _, check := range component.HealthChecks {
+			gv, err := schema.ParseGroupVersion(check.APIVersion)
+			if err != nil {
+				return nil, fmt.Errorf("invalid health check api version %q in component %s: %w", check.APIVersion, component.Name, err)
+			}
+			gr := groupResource(gv.WithKind(check.Kind))
+			if check.Namespace == "" {
+				clusterRules.add(gr, readVerbs...)
+				continue
+			}
+			namespaceRulesFor(check.Namespace).add(gr, readVerbs...)
+		}
+	}
+
+	name := fmt.Sprintf("zarf-deploy-%s", pkg.Metadata.Name)
+	labels := map[string]string{cluster.ZarfManagedByLabel: "zarf"}
+	result := []runtime.Object{
+		&rbacv1.ClusterRole{
+			TypeMeta:   metav1.TypeMeta{APIVersion: rbacv1.SchemeGroupVersion.String(), Kind: "ClusterRole"},
+			ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels},
+			Rules:      clusterRules.policyRules(),
+		},
+	}
+	for _, namespace := range slices.Sorted(maps.Keys(namespaceRules)) {
+		result = append(result, &rbacv1.Role{
+			TypeMeta:   metav1.TypeMeta{APIVersion: rbacv1.SchemeGroupVersion.String(), Kind: "Role"},
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace, Labels: labels},
+			Rules:      namespaceRules[namespace].policyRules(),
+		})
+	}
+	if serviceAccount == "" {
+		return result, nil
+	}
+
+	saNamespace, saName, err := cluster.ParseServiceAccount(serviceAccount)
+	if err != nil {
+		return nil, err
+	}
+	subjects := []rbacv1.Subject{{Kind: rbacv1.ServiceAccountKind, Name: saName, Namespace: saNamespace}}
+	result = append(result, &rbacv1.ClusterRoleBinding{
+		TypeMeta:   metav1.TypeMeta{APIVersion: rbacv1.SchemeGroupVersion.String(), Kind: "ClusterRoleBinding"},
+		ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels},
+		Subjects:   subjects,
+		RoleRef:    rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole", Name: name},
+	})
+	for _, namespace := range slices.Sorted(maps.Keys(namespaceRules)) {
+		result = append(result, &rbacv1.RoleBinding{
+			TypeMeta:   metav1.TypeMeta{APIVersion: rbacv1.SchemeGroupVersion.String(), Kind: "RoleBinding"},
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace, Labels: labels},
+			Subjects:   subjects,
+			RoleRef:    rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "Role", Name: name},
+		})
+	}
+	return result, nil
+}
+
+// namespaceOrDefault returns the namespace, or the default namespace when it is empty.
+func namespaceOrDefault(namespace string) string {
+	if namespace == "" {
+		return "default"
+	}
+	return namespace
+}
@@ -0,0 +1,138 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package packager2
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	rbacv1 "k8s.io/api/rbac/v1"
+
+	"github.com/zarf-dev/zarf/src/api/v1alpha1"
+)
+
+func TestRBACForPackage(t *testing.T) {
+	t.Parallel()
+
+	pkg := v1alpha1.ZarfPackage{
+		Metadata: v1alpha1.ZarfMetadata{Name: "app"},
+		Components: []v1alpha1.ZarfComponent{
+			{
+				Name:   "web",
+				Charts: []v1alpha1.ZarfChart{{Name: "web", Namespace: "web"}},
+				DataInjections: []v1alpha1.ZarfDataInjection{
+					{Target: v1alpha1.ZarfContainerTarget{Namespace: "web", Selector: "app=web"}},
+				},
+				HealthChecks: []v1alpha1.NamespacedObjectKindReference{
+					{APIVersion: "example.com/v1", Kind: "Widget", Name: "default"},
+				},
+			},
+		},
+	}
+	rendered := []RenderedManifest{
+		{
+			Component: "web",
+			Kind:      "chart",
+			Name:      "web",
+			Namespace: "web",
+			Content: `---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: web
+---
+apiVersion: networking.k8s.io/v1
+kind: Ingress
+metadata:
+  name: web
+  namespace: ingress
+---
+apiVersion: rbac.authorization.k8s.io/v1
+kind: ClusterRole
+metadata:
+  name: web
+---
+apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: widgets.example.com
+spec:
+  group: example.com
+  names:
+    kind: Widget
+    plural: widgets
+  scope: Cluster
+---
+apiVersion: example.com/v1
+kind: Widget
+metadata:
+  name: default
+  namespace: web
+`,
+		},
+	}
+
+	objs, err := rbacForPackage(pkg, rendered, "ci/deployer")
+	require.NoError(t, err)
+	require.Len(t, objs, 8)
+
+	clusterRole, ok := objs[0].(*rbacv1.ClusterRole)
+	require.True(t, ok)
+	require.Equal(t, "zarf-deploy-app", clusterRole.Name)
+	expectedClusterRules := []rbacv1.PolicyRule{
+		{APIGroups: []string{""}, Resources: []string{"namespaces"}, Verbs: []string{"get", "list", "watch", "create", "update", "patch"}},
+		{APIGroups: []string{"apiextensions.k8s.io"}, Resources: []string{"customresourcedefinitions"}, Verbs: deployVerbs},
+		{APIGroups: []string{"example.com"}, Resources: []string{"widgets"}, Verbs: deployVerbs},
+		{APIGroups: []string{"rbac.authorization.k8s.io"}, Resources: []string{"clusterroles"}, Verbs: deployVerbs},
+	}
+	require.Equal(t, expectedClusterRules, clusterRole.Rules)
+
+	roles := map[string][]rbacv1.PolicyRule{}
+	for _, obj := range objs[1:4] {
+		role, ok := obj.(*rbacv1.Role)
+		require.True(t, ok)
+		require.Equal(t, "zarf-deploy-app", role.Name)
+		roles[role.Namespace] = role.Rules
+	}
+	expectedRoles := map[string][]rbacv1.PolicyRule{
+		"ingress": {
+			{APIGroups: []string{"networking.k8s.io"}, Resources: []string{"ingresses"}, Verbs: deployVerbs},
+		},
+		"web": {
+			{APIGroups: []string{""}, Resources: []string{"pods"}, Verbs: []string{"get", "list", "watch"}},
+			{APIGroups: []string{""}, Resources: []string{"pods/exec"}, Verbs: []string{"create"}},
+			{APIGroups: []string{""}, Resources: []string{"secrets", "services"}, Verbs: deployVerbs},
+			{APIGroups: []string{"apps"}, Resources: []string{"deployments"}, Verbs: deployVerbs},
+		},
+		"zarf": {
+			{APIGroups: []string{""}, Resources: []string{"pods"}, Verbs: []string{"get", "list"}},
+			{APIGroups: []string{""}, Resources: []string{"pods/portforward"}, Verbs: []string{"create"}},
+			{APIGroups: []string{""}, Resources: []string{"secrets"}, Verbs: []string{"get", "list", "create", "update", "patch"}},
+			{APIGroups: []string{""}, Resources: []string{"services"}, Verbs: []string{"get", "list"}},
+		},
+	}
+	require.Equal(t, expectedRoles, roles)
+
+	clusterRoleBinding, ok := objs[4].(*rbacv1.ClusterRoleBinding)
+	require.True(t, ok)
+	require.Equal(t, []rbacv1.Subject{{Kind: "ServiceAccount", Name: "deployer", Namespace: "ci"}}, clusterRoleBinding.Subjects)
+	require.Equal(t, rbacv1.RoleRef{APIGroup: "rbac.authorization.k8s.io", Kind: "ClusterRole", Name: "zarf-deploy-app"}, clusterRoleBinding.RoleRef)
+	for _, obj := range objs[5:] {
+		roleBinding, ok := obj.(*rbacv1.RoleBinding)
+		require.True(t, ok)
+		require.Equal(t, rbacv1.RoleRef{APIGroup: "rbac.authorization.k8s.io", Kind: "Role", Name: "zarf-deploy-app"}, roleBinding.RoleRef)
+	}
+
+	objs, err = rbacForPackage(pkg, rendered, "")
+	require.NoError(t, err)
+	require.Len(t, objs, 4)
+
+	_, err = rbacForPackage(pkg, rendered, "deployer")
+	require.EqualError(t, err, `invalid service account "deployer": must be in the form namespace/name`)
+}
@@ -8,15 +8,22 @@ import (
 	"errors"
 	"fmt"
 	"slices"
+	"strings"
+	"time"
 
 	"github.com/zarf-dev/zarf/src/pkg/logger"
 
 	"helm.sh/helm/v3/pkg/action"
 	"helm.sh/helm/v3/pkg/cli"
+	"helm.sh/helm/v3/pkg/releaseutil"
 	"helm.sh/helm/v3/pkg/storage/driver"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
 
 	"github.com/zarf-dev/zarf/src/api/v1alpha1"
 	"github.com/zarf-dev/zarf/src/config"
+	"github.com/zarf-dev/zarf/src/internal/packager/hostartifacts"
+	"github.com/zarf-dev/zarf/src/internal/packager/hostservices"
 	"github.com/zarf-dev/zarf/src/pkg/cluster"
 	"github.com/zarf-dev/zarf/src/pkg/message"
 	"github.com/zarf-dev/zarf/src/pkg/packager/actions"
@@ -31,11 +38,22 @@ type RemoveOptions struct {
 	Filter                  filters.ComponentFilterStrategy
 	SkipSignatureValidation bool
 	PublicKeyPath           string
+	// Timeout of the Helm uninstalls and of the wait for the namespaces and CRDs they delete to be removed.
+	Timeout time.Duration
+	// Force removes the finalizers blocking the removal of namespaces and CRDs once the timeout is reached.
+	Force bool
+	// AllowHostServices allows the systemd services of the components to be stopped and removed from the host.
+	AllowHostServices bool
+	// AllowHostArtifacts allows the host artifacts of the components to be removed from the host.
+	AllowHostArtifacts bool
 }
 
 // Remove removes a package that was already deployed onto a cluster, uninstalling all installed helm charts.
 func Remove(ctx context.Context, opt RemoveOptions) error {
 	l := logger.From(ctx)
+	if opt.Timeout <= 0 {
+		opt.Timeout = config.ZarfDefaultTimeout
+	}
 	pkg, err := GetPackageFromSourceOrCluster(ctx, opt.Cluster, opt.Source, opt.SkipSignatureValidation, opt.PublicKeyPath)
 	if err != nil {
 		return err
@@ -46,6 +64,13 @@ func Remove(ctx context.Context, opt RemoveOptions) error {
 	if err != nil {
 		return err
 	}
+	// Host services change the host itself and have to be allowed explicitly, even when the removal is confirmed.
+	if names := hostservices.Names(components); len(names) > 0 && !opt.AllowHostServices {
+		return fmt.Errorf("the components to remove installed the systemd services %s onto this host, remove with --allow-host-services to allow removing them", strings.Join(names, ", "))
+	}
+	if names := hostartifacts.Names(components); len(names) > 0 && !opt.AllowHostArtifacts {
+		return fmt.Errorf("the components to remove installed %s onto this host, remove with --allow-host-artifacts to allow removing them", strings.Join(names, ", "))
+	}
 	// Check that cluster is configured if required.
 	requiresCluster := false
 	componentIdx := map[string]v1alpha1.ZarfComponent{}
@@ -85,6 +110,17 @@ func Remove(ctx context.Context, opt RemoveOptions) error {
 		}
 
 		err := func() error {
+			if opt.Cluster != nil {
+				updateDeployedComponent(depPkg, depComp.Name, func(c *types.DeployedComponent) {
+					c.Status = types.ComponentStatusRemoving
+				})
+				if err := opt.Cluster.UpdateDeployedPackage(ctx, *depPkg); err != nil {
+					// We warn and ignore errors because we may have removed the cluster that this package was inside of
+					message.Warnf("Unable to update the secret for package %s, this may be normal if the cluster was removed: %s", depPkg.Name, err.Error())
+					l.Warn("unable to update secret for package, this may be normal if the cluster was removed", "pkgName", depPkg.Name, "error", err.Error())
+				}
+			}
+
 			err := actions.Run(ctx, comp.Actions.OnRemove.Defaults, comp.Actions.OnRemove.Before, nil)
 			if err != nil {
 				return fmt.Errorf("unable to run the before action: %w", err)
@@ -93,6 +129,7 @@ func Remove(ctx context.Context, opt RemoveOptions) error {
 			reverseInstalledCharts := slices.Clone(depComp.InstalledCharts)
 			slices.Reverse(reverseInstalledCharts)
 			if opt.Cluster != nil {
+				waitOpts := cluster.RemovalWaitOptions{Timeout: opt.Timeout, Force: opt.Force}
 				for _, chart := range reverseInstalledCharts {
 					settings := cli.New()
 					settings.SetNamespace(chart.Namespace)
@@ -102,10 +139,23 @@ func Remove(ctx context.Context, opt RemoveOptions) error {
 					if err != nil {
 						return err
 					}
+					// The namespaces and CRDs of the release are only removed once the finalizers of the resources in them are.
+					rel, err := action.NewGet(actionConfig).Run(chart.ChartName)
+					if err != nil && !errors.Is(err, driver.ErrReleaseNotFound) {
+						return fmt.Errorf("unable to get the helm release %s in the namespace %s: %w", chart.ChartName, chart.Namespace, err)
+					}
+					if rel != nil {
+						namespaces, crds, err := removalTargets(rel.Manifest)
+						if err != nil {
+							return fmt.Errorf("unable to read the manifest of the helm release %s: %w", chart.ChartName, err)
+						}
+						waitOpts.Namespaces = append(waitOpts.Namespaces, namespaces...)
+						waitOpts.CRDs = append(waitOpts.CRDs, crds...)
+					}
 					client := action.NewUninstall(actionConfig)
 					client.KeepHistory = false
 					client.Wait = true
-					client.Timeout = config.ZarfDefaultTimeout
+					client.Timeout = opt.Timeout
 					_, err = client.Run(chart.ChartName)
 					if err != nil && !errors.Is(err, driver.ErrReleaseNotFound) {
 						return fmt.Errorf("unable to uninstall the helm chart %s in the namespace %s: %w", chart.ChartName, chart.Namespace, err)
@@ -116,9 +166,9 @@ func Remove(ctx context.Context, opt RemoveOptions) error {
 					}
 
 					// Pop the removed helm chart from the installed charts slice.
-					installedCharts := depPkg.DeployedComponents[len(depPkg.DeployedComponents)-1].InstalledCharts
-					installedCharts = installedCharts[:len(installedCharts)-1]
-					depPkg.DeployedComponents[len(depPkg.DeployedComponents)-1].InstalledCharts = installedCharts
+					updateDeployedComponent(depPkg, depComp.Name, func(c *types.DeployedComponent) {
+						c.InstalledCharts = c.InstalledCharts[:len(c.InstalledCharts)-1]
+					})
 					err = opt.Cluster.UpdateDeployedPackage(ctx, *depPkg)
 					if err != nil {
 						// We warn and ignore errors because we may have removed the cluster that this package was inside of
@@ -126,6 +176,51 @@ func Remove(ctx context.Context, opt RemoveOptions) error {
 						l.Warn("unable to update secret for package, this may be normal if the cluster was removed", "pkgName", depPkg.Name, "error", err.Error())
 					}
 				}
+
+				// Only the namespaces created by the component are deleted, adopted namespaces may be shared.
+				namespaces, err := opt.Cluster.DeleteCreatedNamespaces(ctx, depPkg.Name, depComp.Name)
+				if err != nil {
+					return err
+				}
+				waitOpts.Namespaces = append(waitOpts.Namespaces, namespaces...)
+
+				// Wait for the component to be gone before the components it depends on, such as the operator
+				// handling its custom resources, are removed.
+				if err := opt.Cluster.WaitForRemoval(ctx, waitOpts); err != nil {
+					return err
+				}
+			}
+
+			for _, hostArtifact := range comp.HostArtifacts {
+				removed, kept, err := hostartifacts.Uninstall(hostArtifact, pkg.Metadata.Name)
+				if err != nil {
+					return fmt.Errorf("unable to uninstall the host artifact %s: %w", hostArtifact.Name, err)
+				}
+				if kept != "" {
+					message.Warnf("Leaving %s in place, %s", removed, kept)
+					l.Warn("leaving host artifact in place", "name", hostArtifact.Name, "path", removed, "reason", kept)
+					continue
+				}
+				l.Info("uninstalled host artifact", "name", hostArtifact.Name, "path", removed)
+			}
+
+			if len(comp.HostServices) > 0 {
+				m, err := hostservices.NewManager()
+				if err != nil {
+					return err
+				}
+				for _, hostService := range comp.HostServices {
+					removed, kept, err := m.Uninstall(ctx, hostService, pkg.Metadata.Name)
+					if err != nil {
+						return fmt.Errorf("unable to uninstall the host service %s: %w", hostService.Name, err)
+					}
+					if kept != "" {
+						message.Warnf("Leaving the host service %s in place, %s", removed, kept)
+						l.Warn("leaving host service in place", "name", hostService.Name, "path", removed, "reason", kept)
+						continue
+					}
+					l.Info("uninstalled host service", "name", hostService.Name, "path", removed)
+				}
 			}
 
 			err = actions.Run(ctx, comp.Actions.OnRemove.Defaults, comp.Actions.OnRemove.After, nil)
@@ -139,7 +234,9 @@ func Remove(ctx context.Context, opt RemoveOptions) error {
 
 			// Pop the removed component from deploy components slice.
 			if opt.Cluster != nil {
-				depPkg.DeployedComponents = depPkg.DeployedComponents[:len(depPkg.DeployedComponents)-1]
+				depPkg.DeployedComponents = slices.DeleteFunc(depPkg.DeployedComponents, func(c types.DeployedComponent) bool {
+					return c.Name == depComp.Name
+				})
 				err = opt.Cluster.UpdateDeployedPackage(ctx, *depPkg)
 				if err != nil {
 					// We warn and ignore errors because we may have removed the cluster that this package was inside of
@@ -170,3 +267,34 @@ func Remove(ctx context.Context, opt RemoveOptions) error {
 	l.Info("package successfully removed", "name", pkg.Metadata.Name)
 	return nil
 }
+
+// updateDeployedComponent updates the deployed component with the name.
+func updateDeployedComponent(depPkg *types.DeployedPackage, name string, update func(*types.DeployedComponent)) {
+	for i := range depPkg.DeployedComponents {
+		if depPkg.DeployedComponents[i].Name == name {
+			update(&depPkg.DeployedComponents[i])
+			return
+		}
+	}
+}
+
+// removalTargets returns the namespaces and custom resource definitions in the manifest of a release.
+func removalTargets(manifest string) ([]string, []string, error) {
+	namespaces := []string{}
+	crds := []string{}
+	for _, doc := range releaseutil.SplitManifests(manifest) {
+		obj := metav1.PartialObjectMetadata{}
+		if err := yaml.Unmarshal([]byte(doc), &obj); err != nil {
+			return nil, nil, err
+		}
+		switch {
+		case obj.APIVersion == "v1" && obj.Kind == "Namespace":
+			namespaces = append(namespaces, obj.Name)
+		case obj.APIVersion == "apiextensions.k8s.io/v1" && obj.Kind == "CustomResourceDefinition":
+			crds = append(crds, obj.Name)
+		}
+	}
+	slices.Sort(namespaces)
+	slices.Sort(crds)
+	return namespaces, crds, nil
+}
@@ -0,0 +1,105 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package packager2
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/zarf-dev/zarf/src/api/v1alpha1"
+	"github.com/zarf-dev/zarf/src/pkg/cluster"
+	"github.com/zarf-dev/zarf/src/pkg/packager/filters"
+	"github.com/zarf-dev/zarf/src/test/testutil"
+	"github.com/zarf-dev/zarf/src/types"
+)
+
+func TestRemovalTargets(t *testing.T) {
+	t.Parallel()
+
+	manifest := `---
+# Source: operator/templates/namespace.yaml
+apiVersion: v1
+kind: Namespace
+metadata:
+  name: operator-system
+---
+# Source: operator/templates/crd.yaml
+apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: widgets.example.com
+---
+# Source: operator/templates/deployment.yaml
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: operator
+  namespace: operator-system
+---
+# Source: operator/templates/widget.yaml
+apiVersion: example.com/v1
+kind: Namespace
+metadata:
+  name: not-a-namespace
+`
+	namespaces, crds, err := removalTargets(manifest)
+	require.NoError(t, err)
+	require.Equal(t, []string{"operator-system"}, namespaces)
+	require.Equal(t, []string{"widgets.example.com"}, crds)
+
+	namespaces, crds, err = removalTargets("")
+	require.NoError(t, err)
+	require.Empty(t, namespaces)
+	require.Empty(t, crds)
+}
+
+func TestRemoveHostServicesNotAllowed(t *testing.T) {
+	t.Parallel()
+	ctx := testutil.TestContext(t)
+
+	c := &cluster.Cluster{Clientset: fake.NewClientset()}
+	pkg := v1alpha1.ZarfPackage{
+		Metadata: v1alpha1.ZarfMetadata{Name: "edge"},
+		Components: []v1alpha1.ZarfComponent{
+			{Name: "agent", HostServices: []v1alpha1.ZarfHostService{{Name: "edge-agent.service"}}},
+		},
+	}
+	err := c.UpdateDeployedPackage(ctx, types.DeployedPackage{
+		Name:               "edge",
+		Data:               pkg,
+		DeployedComponents: []types.DeployedComponent{{Name: "agent"}},
+	})
+	require.NoError(t, err)
+
+	err = Remove(ctx, RemoveOptions{Source: "edge", Cluster: c, Filter: filters.Empty()})
+	require.EqualError(t, err, "the components to remove installed the systemd services edge-agent.service onto this host, remove with --allow-host-services to allow removing them")
+	_, err = c.GetDeployedPackage(ctx, "edge")
+	require.NoError(t, err)
+}
+
+func TestRemoveHostArtifactsNotAllowed(t *testing.T) {
+	t.Parallel()
+	ctx := testutil.TestContext(t)
+
+	c := &cluster.Cluster{Clientset: fake.NewClientset()}
+	pkg := v1alpha1.ZarfPackage{
+		Metadata: v1alpha1.ZarfMetadata{Name: "edge"},
+		Components: []v1alpha1.ZarfComponent{
+			{Name: "cli", HostArtifacts: []v1alpha1.ZarfHostArtifact{{Name: "edgectl"}}},
+		},
+	}
+	err := c.UpdateDeployedPackage(ctx, types.DeployedPackage{
+		Name:               "edge",
+		Data:               pkg,
+		DeployedComponents: []types.DeployedComponent{{Name: "cli"}},
+	})
+	require.NoError(t, err)
+
+	err = Remove(ctx, RemoveOptions{Source: "edge", Cluster: c, Filter: filters.Empty()})
+	require.EqualError(t, err, "the components to remove installed edgectl onto this host, remove with --allow-host-artifacts to allow removing them")
+	_, err = c.GetDeployedPackage(ctx, "edge")
+	require.NoError(t, err)
+}
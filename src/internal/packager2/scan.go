@@ -0,0 +1,250 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package packager2
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/anchore/clio"
+	"github.com/anchore/grype/grype"
+	"github.com/anchore/grype/grype/db/legacy/distribution"
+	grypepkg "github.com/anchore/grype/grype/pkg"
+	"github.com/anchore/grype/grype/vulnerability"
+	"github.com/anchore/syft/syft/source"
+
+	"github.com/zarf-dev/zarf/src/config"
+	layout2 "github.com/zarf-dev/zarf/src/internal/packager2/layout"
+	"github.com/zarf-dev/zarf/src/pkg/logger"
+	"github.com/zarf-dev/zarf/src/pkg/message"
+	"github.com/zarf-dev/zarf/src/pkg/utils"
+)
+
+// vulnerabilityDBListingURL is the listing of the Grype vulnerability databases.
+const vulnerabilityDBListingURL = "https://toolbox-data.anchore.io/grype/databases/listing.json"
+
+// waiverDateLayout is the layout of the expiry date of a vulnerability waiver.
+const waiverDateLayout = "2006-01-02"
+
+// VulnerabilityWaiver accepts a known vulnerability until it expires.
+type VulnerabilityWaiver struct {
+	// ID is the ID of the vulnerability, i.e. CVE-2024-1234 or GHSA-xxxx-xxxx-xxxx.
+	ID string `json:"id"`
+	// Expires is the last day (YYYY-MM-DD) the waiver applies, the vulnerability fails the scan again afterwards.
+	Expires string `json:"expires"`
+	// Justification is the reason the vulnerability is accepted.
+	Justification string `json:"justification"`
+	// Images are the images the waiver applies to, it applies to all images when it is empty.
+	Images []string `json:"images,omitempty"`
+}
+
+// VulnerabilityWaivers is the file format of the vulnerability waivers.
+type VulnerabilityWaivers struct {
+	Waivers []VulnerabilityWaiver `json:"waivers"`
+}
+
+// VulnerabilityFinding is a vulnerability found in a package of an image.
+type VulnerabilityFinding struct {
+	Image      string
+	ID         string
+	Severity   vulnerability.Severity
+	Package    string
+	Version    string
+	FixVersion string
+}
+
+// vulnerabilityScanResult is the outcome of applying waivers to the findings of a scan.
+type vulnerabilityScanResult struct {
+	Failing []VulnerabilityFinding
+	Waived  []VulnerabilityFinding
+	Expired []VulnerabilityWaiver
+}
+
+// parseFailOnSeverity returns the severity for the --fail-on-severity flag.
+func parseFailOnSeverity(severity string) (vulnerability.Severity, error) {
+	s := vulnerability.ParseSeverity(severity)
+	if s == vulnerability.UnknownSeverity {
+		return s, fmt.Errorf("invalid severity %q: must be one of negligible, low, medium, high or critical", severity)
+	}
+	return s, nil
+}
+
+// ReadVulnerabilityWaivers reads and validates a vulnerability waivers file.
+func ReadVulnerabilityWaivers(path string) ([]VulnerabilityWaiver, error) {
+	var file VulnerabilityWaivers
+	if err := utils.ReadYaml(path, &file); err != nil {
+		return nil, fmt.Errorf("unable to read the vulnerability waivers %s: %w", path, err)
+	}
+	for i, w := range file.Waivers {
+		if w.ID == "" {
+			return nil, fmt.Errorf("waiver %d in %s is missing an id", i, path)
+		}
+		if w.Justification == "" {
+			return nil, fmt.Errorf("waiver %s in %s is missing a justification", w.ID, path)
+		}
+		if _, err := time.Parse(waiverDateLayout, w.Expires); err != nil {
+			return nil, fmt.Errorf("waiver %s in %s has an invalid expiry date %q, must be YYYY-MM-DD", w.ID, path, w.Expires)
+		}
+	}
+	return file.Waivers, nil
+}
+
+// scanVulnerabilities scans the SBOMs of the package for vulnerabilities and fails when vulnerabilities at or above
+// the severity are found that are not waived.
+func scanVulnerabilities(ctx context.Context, pkgLayout *layout2.PackageLayout, failOnSeverity string, waivers []VulnerabilityWaiver) (err error) {
+	l := logger.From(ctx)
+	threshold, err := parseFailOnSeverity(failOnSeverity)
+	if err != nil {
+		return err
+	}
+	tmpDir, err := utils.MakeTempDir(config.CommonOptions.TempDirectory)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = errors.Join(err, os.RemoveAll(tmpDir))
+	}()
+	sbomDir, err := pkgLayout.GetSBOM(tmpDir)
+	var noSBOMErr *layout2.NoSBOMAvailableError
+	if errors.As(err, &noSBOMErr) {
+		l.Info("package has no SBOMs, skipping the vulnerability scan")
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("unable to read the SBOMs of the package: %w", err)
+	}
+
+	cachePath, err := config.GetAbsCachePath()
+	if err != nil {
+		return err
+	}
+	dbCfg := distribution.Config{
+		ID:                      clio.Identification{Name: "zarf", Version: config.CLIVersion},
+		DBRootDir:               filepath.Join(cachePath, "grype"),
+		ListingURL:              vulnerabilityDBListingURL,
+		ValidateByHashOnGet:     true,
+		ValidateAge:             true,
+		MaxAllowedBuiltAge:      5 * 24 * time.Hour,
+		ListingFileTimeout:      time.Minute,
+		UpdateTimeout:           30 * time.Minute,
+		UpdateCheckMaxFrequency: 2 * time.Hour,
+	}
+	l.Info("loading the vulnerability database", "path", dbCfg.DBRootDir)
+	store, _, err := grype.LoadVulnerabilityDB(dbCfg, true)
+	if err != nil {
+		return fmt.Errorf("unable to load the vulnerability database: %w", err)
+	}
+	defer func() {
+		err = errors.Join(err, store.Close())
+	}()
+	matcher := grype.DefaultVulnerabilityMatcher(*store)
+
+	entries, err := os.ReadDir(sbomDir)
+	if err != nil {
+		return err
+	}
+	findings := []VulnerabilityFinding{}
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".json" {
+			continue
+		}
+		pkgs, pkgCtx, _, err := grypepkg.Provide("sbom:"+filepath.Join(sbomDir, entry.Name()), grypepkg.ProviderConfig{
+			SynthesisConfig: grypepkg.SynthesisConfig{GenerateMissingCPEs: true},
+		})
+		if err != nil {
+			return fmt.Errorf("unable to read the SBOM %s: %w", entry.Name(), err)
+		}
+		image := strings.TrimSuffix(entry.Name(), ".json")
+		if pkgCtx.Source != nil {
+			if imageMetadata, ok := pkgCtx.Source.Metadata.(source.ImageMetadata); ok {
+				image = normalizeImageRef(imageMetadata.UserInput)
+			}
+		}
+		matches, _, err := matcher.FindMatches(pkgs, pkgCtx)
+		if err != nil {
+			return fmt.Errorf("unable to scan %s for vulnerabilities: %w", image, err)
+		}
+		for _, m := range matches.Sorted() {
+			severity := vulnerability.UnknownSeverity
+			metadata, err := store.GetMetadata(m.Vulnerability.ID, m.Vulnerability.Namespace)
+			if err == nil && metadata != nil {
+				severity = vulnerability.ParseSeverity(metadata.Severity)
+			}
+			findings = append(findings, VulnerabilityFinding{
+				Image:      image,
+				ID:         m.Vulnerability.ID,
+				Severity:   severity,
+				Package:    m.Package.Name,
+				Version:    m.Package.Version,
+				FixVersion: strings.Join(m.Vulnerability.Fix.Versions, ", "),
+			})
+		}
+	}
+
+	result := applyVulnerabilityWaivers(findings, waivers, threshold, time.Now())
+	for _, w := range result.Expired {
+		message.Warnf("The waiver for %s expired on %s and no longer applies", w.ID, w.Expires)
+		l.Warn("vulnerability waiver expired and no longer applies", "id", w.ID, "expires", w.Expires, "justification", w.Justification)
+	}
+	for _, f := range result.Waived {
+		l.Info("vulnerability waived", "id", f.ID, "severity", f.Severity.String(), "image", f.Image, "package", f.Package, "version", f.Version)
+	}
+	if len(result.Failing) == 0 {
+		l.Info("vulnerability scan passed", "failOnSeverity", threshold.String(), "waived", len(result.Waived))
+		return nil
+	}
+	header := []string{"Image", "Vulnerability", "Severity", "Package", "Version", "Fixed In"}
+	rows := [][]string{}
+	for _, f := range result.Failing {
+		rows = append(rows, []string{f.Image, f.ID, f.Severity.String(), f.Package, f.Version, f.FixVersion})
+	}
+	message.TableWithWriter(message.OutputWriter, header, rows)
+	return fmt.Errorf("found %d vulnerabilities with a severity of %s or higher that are not waived", len(result.Failing), threshold)
+}
+
+// applyVulnerabilityWaivers returns the findings at or above the severity that fail the scan and the ones that are
+// waived. Waivers that expired before now are returned separately and do not apply, so their vulnerabilities fail
+// the scan again.
+func applyVulnerabilityWaivers(findings []VulnerabilityFinding, waivers []VulnerabilityWaiver, threshold vulnerability.Severity, now time.Time) vulnerabilityScanResult {
+	result := vulnerabilityScanResult{
+		Failing: []VulnerabilityFinding{},
+		Waived:  []VulnerabilityFinding{},
+		Expired: []VulnerabilityWaiver{},
+	}
+	active := []VulnerabilityWaiver{}
+	for _, w := range waivers {
+		expires, err := time.Parse(waiverDateLayout, w.Expires)
+		// Waivers apply through the end of the day they expire.
+		if err != nil || !now.Before(expires.AddDate(0, 0, 1)) {
+			result.Expired = append(result.Expired, w)
+			continue
+		}
+		active = append(active, w)
+	}
+	for _, f := range findings {
+		if f.Severity < threshold {
+			continue
+		}
+		waived := slices.ContainsFunc(active, func(w VulnerabilityWaiver) bool {
+			if !strings.EqualFold(w.ID, f.ID) {
+				return false
+			}
+			return len(w.Images) == 0 || slices.ContainsFunc(w.Images, func(image string) bool {
+				return normalizeImageRef(image) == f.Image
+			})
+		})
+		if waived {
+			result.Waived = append(result.Waived, f)
+			continue
+		}
+		result.Failing = append(result.Failing, f)
+	}
+	return result
+}
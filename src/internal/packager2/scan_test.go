@@ -0,0 +1,161 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package packager2
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/anchore/grype/grype/vulnerability"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseFailOnSeverity(t *testing.T) {
+	t.Parallel()
+
+	severity, err := parseFailOnSeverity("High")
+	require.NoError(t, err)
+	require.Equal(t, vulnerability.HighSeverity, severity)
+
+	_, err = parseFailOnSeverity("severe")
+	require.EqualError(t, err, "invalid severity \"severe\": must be one of negligible, low, medium, high or critical")
+}
+
+func TestReadVulnerabilityWaivers(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		content     string
+		expected    []VulnerabilityWaiver
+		expectedErr string
+	}{
+		{
+			name: "valid waivers",
+			content: `waivers:
+  - id: CVE-2024-0001
+    expires: "2026-12-31"
+    justification: not reachable
+    images:
+      - nginx:1.27
+`,
+			expected: []VulnerabilityWaiver{
+				{ID: "CVE-2024-0001", Expires: "2026-12-31", Justification: "not reachable", Images: []string{"nginx:1.27"}},
+			},
+		},
+		{
+			name: "missing justification",
+			content: `waivers:
+  - id: CVE-2024-0001
+    expires: "2026-12-31"
+`,
+			expectedErr: "waiver CVE-2024-0001 in %s is missing a justification",
+		},
+		{
+			name: "invalid expiry",
+			content: `waivers:
+  - id: CVE-2024-0001
+    expires: "31/12/2026"
+    justification: not reachable
+`,
+			expectedErr: "waiver CVE-2024-0001 in %s has an invalid expiry date \"31/12/2026\", must be YYYY-MM-DD",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			path := filepath.Join(t.TempDir(), "waivers.yaml")
+			err := os.WriteFile(path, []byte(tt.content), 0o644)
+			require.NoError(t, err)
+			waivers, err := ReadVulnerabilityWaivers(path)
+			if tt.expectedErr != "" {
+				require.EqualError(t, err, fmt.Sprintf(tt.expectedErr, path))
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, waivers)
+		})
+	}
+}
+
+func TestApplyVulnerabilityWaivers(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 6, 15, 12, 0, 0, 0, time.UTC)
+	nginxCritical := VulnerabilityFinding{Image: "docker.io/library/nginx:1.27", ID: "CVE-2024-0001", Severity: vulnerability.CriticalSeverity}
+	nginxHigh := VulnerabilityFinding{Image: "docker.io/library/nginx:1.27", ID: "CVE-2024-0002", Severity: vulnerability.HighSeverity}
+	nginxLow := VulnerabilityFinding{Image: "docker.io/library/nginx:1.27", ID: "CVE-2024-0003", Severity: vulnerability.LowSeverity}
+	podinfoHigh := VulnerabilityFinding{Image: "ghcr.io/stefanprodan/podinfo:6.4.0", ID: "CVE-2024-0002", Severity: vulnerability.HighSeverity}
+	findings := []VulnerabilityFinding{nginxCritical, nginxHigh, nginxLow, podinfoHigh}
+
+	tests := []struct {
+		name     string
+		waivers  []VulnerabilityWaiver
+		expected vulnerabilityScanResult
+	}{
+		{
+			name: "no waivers",
+			expected: vulnerabilityScanResult{
+				Failing: []VulnerabilityFinding{nginxCritical, nginxHigh, podinfoHigh},
+				Waived:  []VulnerabilityFinding{},
+				Expired: []VulnerabilityWaiver{},
+			},
+		},
+		{
+			name: "waiver for all images",
+			waivers: []VulnerabilityWaiver{
+				{ID: "cve-2024-0002", Expires: "2026-07-01", Justification: "not reachable"},
+			},
+			expected: vulnerabilityScanResult{
+				Failing: []VulnerabilityFinding{nginxCritical},
+				Waived:  []VulnerabilityFinding{nginxHigh, podinfoHigh},
+				Expired: []VulnerabilityWaiver{},
+			},
+		},
+		{
+			name: "waiver for one image",
+			waivers: []VulnerabilityWaiver{
+				{ID: "CVE-2024-0002", Expires: "2026-07-01", Justification: "not reachable", Images: []string{"nginx:1.27"}},
+			},
+			expected: vulnerabilityScanResult{
+				Failing: []VulnerabilityFinding{nginxCritical, podinfoHigh},
+				Waived:  []VulnerabilityFinding{nginxHigh},
+				Expired: []VulnerabilityWaiver{},
+			},
+		},
+		{
+			name: "waiver applies through its expiry date",
+			waivers: []VulnerabilityWaiver{
+				{ID: "CVE-2024-0001", Expires: "2026-06-15", Justification: "fix pending"},
+			},
+			expected: vulnerabilityScanResult{
+				Failing: []VulnerabilityFinding{nginxHigh, podinfoHigh},
+				Waived:  []VulnerabilityFinding{nginxCritical},
+				Expired: []VulnerabilityWaiver{},
+			},
+		},
+		{
+			name: "expired waiver resurfaces the vulnerability",
+			waivers: []VulnerabilityWaiver{
+				{ID: "CVE-2024-0001", Expires: "2026-06-14", Justification: "fix pending"},
+			},
+			expected: vulnerabilityScanResult{
+				Failing: []VulnerabilityFinding{nginxCritical, nginxHigh, podinfoHigh},
+				Waived:  []VulnerabilityFinding{},
+				Expired: []VulnerabilityWaiver{{ID: "CVE-2024-0001", Expires: "2026-06-14", Justification: "fix pending"}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			result := applyVulnerabilityWaivers(findings, tt.waivers, vulnerability.HighSeverity, now)
+			require.Equal(t, tt.expected, result)
+		})
+	}
+}
@@ -0,0 +1,230 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package packager2
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/defenseunicorns/pkg/helpers/v2"
+	"github.com/defenseunicorns/pkg/oci"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/registry"
+
+	"github.com/zarf-dev/zarf/src/config"
+	layout2 "github.com/zarf-dev/zarf/src/internal/packager2/layout"
+	"github.com/zarf-dev/zarf/src/pkg/logger"
+	"github.com/zarf-dev/zarf/src/pkg/utils"
+	"github.com/zarf-dev/zarf/src/pkg/zoci"
+)
+
+// SignOptions are the options for Sign.
+type SignOptions struct {
+	// SigningKeyPath points to the key the package is signed with.
+	SigningKeyPath string
+	// SigningKeyPassword holds a password to use the key at SigningKeyPath.
+	SigningKeyPassword string
+	// Resign replaces the existing signature of the package, which has to be signed already.
+	Resign bool
+	// PublicKeyPath verifies the existing signature of the package before it is replaced.
+	PublicKeyPath string
+	// SkipSignatureValidation skips verifying the existing signature of the package before it is replaced.
+	SkipSignatureValidation bool
+	// OutputDir is the directory a signed package tarball is written to, defaults to the directory of the source.
+	OutputDir string
+	// WithPlainHTTP falls back to plain HTTP for the registry calls instead of TLS.
+	WithPlainHTTP bool
+	// Architecture is the architecture of the published package to sign.
+	Architecture string
+}
+
+// Sign signs a package tarball or a published package after it was created, so that building and signing can be done
+// by different parties. The integrity of the package is validated against its checksums before it is signed. Signing
+// a package that is already signed requires Resign, which verifies the existing signature unless told otherwise.
+func Sign(ctx context.Context, src string, opt SignOptions) error {
+	l := logger.From(ctx)
+	start := time.Now()
+
+	if opt.SigningKeyPath == "" {
+		return errors.New("a signing key is required to sign the package")
+	}
+	if opt.Resign && opt.PublicKeyPath == "" && !opt.SkipSignatureValidation {
+		return errors.New("a public key is required to verify the existing signature of the package, or signature validation has to be skipped")
+	}
+
+	var err error
+	if helpers.IsOCIURL(src) {
+		err = signRemotePackage(ctx, src, opt)
+	} else {
+		err = signPackageTarball(ctx, src, opt)
+	}
+	if err != nil {
+		return err
+	}
+	l.Debug("packager2.Sign done", "duration", time.Since(start))
+	return nil
+}
+
+// signPackageTarball signs a package tarball and writes the signed package to the output directory.
+func signPackageTarball(ctx context.Context, src string, opt SignOptions) (err error) {
+	layoutOpt := layout2.PackageLayoutOptions{
+		PublicKeyPath:           opt.PublicKeyPath,
+		SkipSignatureValidation: !opt.Resign || opt.SkipSignatureValidation,
+	}
+	pkgLayout, err := layout2.LoadFromTar(ctx, src, layoutOpt)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = errors.Join(err, pkgLayout.Cleanup())
+	}()
+	if err := checkSignatureState(pkgLayout, opt.Resign); err != nil {
+		return err
+	}
+	if err := pkgLayout.Sign(opt.SigningKeyPath, opt.SigningKeyPassword); err != nil {
+		return err
+	}
+
+	outputDir := opt.OutputDir
+	if outputDir == "" {
+		outputDir = filepath.Dir(src)
+	}
+	if err := pkgLayout.Archive(ctx, outputDir, 0); err != nil {
+		return err
+	}
+	logger.From(ctx).Info("signed package", "name", pkgLayout.Pkg.Metadata.Name, "path", outputDir)
+	return nil
+}
+
+// signRemotePackage signs a published package in place. The signature layer is pushed and the package manifest is
+// replaced with one that references it, the other layers of the package are left untouched.
+func signRemotePackage(ctx context.Context, src string, opt SignOptions) (err error) {
+	ref, err := registry.ParseReference(strings.TrimPrefix(src, helpers.OCIURLPrefix))
+	if err != nil {
+		return err
+	}
+	if err := ref.ValidateReferenceAsTag(); err != nil {
+		return fmt.Errorf("package must be referenced by a tag: %w", err)
+	}
+	p := oci.PlatformForArch(config.GetArch(opt.Architecture))
+	remote, err := zoci.NewRemote(ctx, ref.String(), p, oci.WithPlainHTTP(opt.WithPlainHTTP))
+	if err != nil {
+		return fmt.Errorf("could not instantiate remote: %w", err)
+	}
+
+	tmpDir, err := utils.MakeTempDir(config.CommonOptions.TempDirectory)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = errors.Join(err, os.RemoveAll(tmpDir))
+	}()
+	if err := fetchRemoteMetadata(ctx, remote, tmpDir); err != nil {
+		return err
+	}
+	layoutOpt := layout2.PackageLayoutOptions{
+		PublicKeyPath:           opt.PublicKeyPath,
+		SkipSignatureValidation: !opt.Resign || opt.SkipSignatureValidation,
+		IsPartial:               true,
+	}
+	pkgLayout, err := layout2.LoadFromDir(ctx, tmpDir, layoutOpt)
+	if err != nil {
+		return err
+	}
+	if err := checkSignatureState(pkgLayout, opt.Resign); err != nil {
+		return err
+	}
+	manifest, err := remote.FetchRoot(ctx)
+	if err != nil {
+		return err
+	}
+	checksums, err := os.ReadFile(filepath.Join(tmpDir, layout2.Checksums))
+	if err != nil {
+		return err
+	}
+	if err := validateRemoteChecksums(manifest, string(checksums)); err != nil {
+		return err
+	}
+
+	if err := pkgLayout.Sign(opt.SigningKeyPath, opt.SigningKeyPassword); err != nil {
+		return err
+	}
+	sig, err := os.ReadFile(filepath.Join(tmpDir, layout2.Signature))
+	if err != nil {
+		return err
+	}
+	sigDesc, err := remote.PushLayer(ctx, sig, zoci.ZarfLayerMediaTypeBlob)
+	if err != nil {
+		return err
+	}
+	sigDesc.Annotations = map[string]string{
+		ocispec.AnnotationTitle: layout2.Signature,
+	}
+	layers := []ocispec.Descriptor{}
+	for _, layer := range manifest.Layers {
+		if layer.Annotations[ocispec.AnnotationTitle] == layout2.Signature {
+			continue
+		}
+		layers = append(layers, layer)
+	}
+	layers = append(layers, *sigDesc)
+
+	packOpts := oras.PackManifestOptions{
+		Layers:              layers,
+		ConfigDescriptor:    &manifest.Config,
+		ManifestAnnotations: manifest.Annotations,
+	}
+	desc, err := oras.PackManifest(ctx, remote.Repo(), oras.PackManifestVersion1_1_RC4, "", packOpts)
+	if err != nil {
+		return err
+	}
+	if err := remote.UpdateIndex(ctx, ref.Reference, desc); err != nil {
+		return fmt.Errorf("failed to update index: %w", err)
+	}
+	logger.From(ctx).Info("signed package", "reference", src, "digest", desc.Digest.String())
+	return nil
+}
+
+// checkSignatureState returns an error if the package is signed but should not be, or the other way around.
+func checkSignatureState(pkgLayout *layout2.PackageLayout, resign bool) error {
+	signed, err := pkgLayout.IsSigned()
+	if err != nil {
+		return err
+	}
+	if signed && !resign {
+		return errors.New("package is already signed, use resign to replace the signature")
+	}
+	if !signed && resign {
+		return errors.New("package is not signed, use sign to sign it")
+	}
+	return nil
+}
+
+// validateRemoteChecksums verifies that every file in the checksums of a published package is a layer of its
+// manifest with the same digest, so that a signature is never added to a package whose content does not match.
+func validateRemoteChecksums(manifest *oci.Manifest, checksums string) error {
+	for _, line := range strings.Split(checksums, "\n") {
+		if line == "" {
+			continue
+		}
+		sha, rel, ok := strings.Cut(line, " ")
+		if !ok || sha == "" || rel == "" {
+			return fmt.Errorf("invalid checksum line: %s", line)
+		}
+		desc := manifest.Locate(rel)
+		if oci.IsEmptyDescriptor(desc) {
+			return fmt.Errorf("file %s from checksum missing in package manifest", rel)
+		}
+		if desc.Digest.Encoded() != sha {
+			return fmt.Errorf("digest of %s does not match its checksum, expected %s but got %s", rel, sha, desc.Digest.Encoded())
+		}
+	}
+	return nil
+}
@@ -0,0 +1,166 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package packager2
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/defenseunicorns/pkg/helpers/v2"
+	"github.com/defenseunicorns/pkg/oci"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/stretchr/testify/require"
+
+	layout2 "github.com/zarf-dev/zarf/src/internal/packager2/layout"
+	"github.com/zarf-dev/zarf/src/pkg/zoci"
+	"github.com/zarf-dev/zarf/src/test/testutil"
+)
+
+func TestSignPackageTarball(t *testing.T) {
+	t.Parallel()
+
+	ctx := testutil.TestContext(t)
+	tmpDir := t.TempDir()
+	src := filepath.Join(tmpDir, "zarf-package-test-amd64-0.0.1.tar.zst")
+	err := helpers.CreatePathAndCopy("testdata/zarf-package-test-amd64-0.0.1.tar.zst", src)
+	require.NoError(t, err)
+
+	opt := SignOptions{
+		SigningKeyPath:     "layout/testdata/cosign.key",
+		SigningKeyPassword: "test",
+		Resign:             true,
+		PublicKeyPath:      "layout/testdata/cosign.pub",
+	}
+	err = Sign(ctx, src, opt)
+	require.EqualError(t, err, "a key was provided but the package is not signed")
+
+	opt.Resign = false
+	opt.PublicKeyPath = ""
+	err = Sign(ctx, src, opt)
+	require.NoError(t, err)
+	pkgLayout, err := layout2.LoadFromTar(ctx, src, layout2.PackageLayoutOptions{PublicKeyPath: "layout/testdata/cosign.pub"})
+	require.NoError(t, err)
+	require.NoError(t, pkgLayout.Cleanup())
+
+	err = Sign(ctx, src, opt)
+	require.EqualError(t, err, "package is already signed, use resign to replace the signature")
+
+	opt.Resign = true
+	err = Sign(ctx, src, opt)
+	require.EqualError(t, err, "a public key is required to verify the existing signature of the package, or signature validation has to be skipped")
+	opt.PublicKeyPath = "layout/testdata/cosign.pub"
+	opt.OutputDir = filepath.Join(tmpDir, "resigned")
+	require.NoError(t, os.Mkdir(opt.OutputDir, helpers.ReadWriteExecuteUser))
+	err = Sign(ctx, src, opt)
+	require.NoError(t, err)
+	pkgLayout, err = layout2.LoadFromTar(ctx, filepath.Join(opt.OutputDir, "zarf-package-test-amd64-0.0.1.tar.zst"), layout2.PackageLayoutOptions{PublicKeyPath: "layout/testdata/cosign.pub"})
+	require.NoError(t, err)
+	require.NoError(t, pkgLayout.Cleanup())
+}
+
+func TestSignRemotePackage(t *testing.T) {
+	ctx := testutil.TestContext(t)
+	registryRef := createRegistry(t, ctx)
+
+	publishOpts := PublishPackageOpts{
+		WithPlainHTTP: true,
+		Architecture:  "amd64",
+	}
+	err := PublishPackage(ctx, "testdata/zarf-package-test-amd64-0.0.1.tar.zst", registryRef, publishOpts)
+	require.NoError(t, err)
+	// Publish creates a local oci manifest file using the package name, delete this to clean up test name
+	defer os.Remove("test")
+
+	src := fmt.Sprintf("oci://%s/test:0.0.1", registryRef.String())
+	opt := SignOptions{
+		SigningKeyPath:     "layout/testdata/cosign.key",
+		SigningKeyPassword: "test",
+		WithPlainHTTP:      true,
+		Architecture:       "amd64",
+	}
+	err = Sign(ctx, src, opt)
+	require.NoError(t, err)
+
+	rmt, err := zoci.NewRemote(ctx, fmt.Sprintf("%s/test:0.0.1", registryRef.String()), oci.PlatformForArch("amd64"), oci.WithPlainHTTP(true))
+	require.NoError(t, err)
+	err = verifyRemotePackage(ctx, rmt, "layout/testdata/cosign.pub", false)
+	require.NoError(t, err)
+
+	err = Sign(ctx, src, opt)
+	require.EqualError(t, err, "package is already signed, use resign to replace the signature")
+
+	opt.Resign = true
+	opt.PublicKeyPath = "layout/testdata/cosign.pub"
+	err = Sign(ctx, src, opt)
+	require.NoError(t, err)
+
+	rmt, err = zoci.NewRemote(ctx, fmt.Sprintf("%s/test:0.0.1", registryRef.String()), oci.PlatformForArch("amd64"), oci.WithPlainHTTP(true))
+	require.NoError(t, err)
+	manifest, err := rmt.FetchRoot(ctx)
+	require.NoError(t, err)
+	signatures := 0
+	for _, layer := range manifest.Layers {
+		if layer.Annotations[ocispec.AnnotationTitle] == layout2.Signature {
+			signatures++
+		}
+	}
+	require.Equal(t, 1, signatures)
+	err = verifyRemotePackage(ctx, rmt, "layout/testdata/cosign.pub", false)
+	require.NoError(t, err)
+}
+
+func TestValidateRemoteChecksums(t *testing.T) {
+	t.Parallel()
+
+	manifest := &oci.Manifest{
+		Manifest: ocispec.Manifest{
+			Layers: []ocispec.Descriptor{
+				{
+					Digest:      "sha256:9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08",
+					Annotations: map[string]string{ocispec.AnnotationTitle: "components/test.tar"},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name        string
+		checksums   string
+		expectedErr string
+	}{
+		{
+			name:      "matching checksums",
+			checksums: "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08 components/test.tar\n",
+		},
+		{
+			name:        "missing layer",
+			checksums:   "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08 components/other.tar\n",
+			expectedErr: "file components/other.tar from checksum missing in package manifest",
+		},
+		{
+			name:        "mismatched digest",
+			checksums:   "60303ae22b998861bce3b28f33eec1be758a213c86c93c076dbe9f558c11c752 components/test.tar\n",
+			expectedErr: "digest of components/test.tar does not match its checksum, expected 60303ae22b998861bce3b28f33eec1be758a213c86c93c076dbe9f558c11c752 but got 9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08",
+		},
+		{
+			name:        "invalid line",
+			checksums:   "components/test.tar\n",
+			expectedErr: "invalid checksum line: components/test.tar",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := validateRemoteChecksums(manifest, tt.checksums)
+			if tt.expectedErr != "" {
+				require.EqualError(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
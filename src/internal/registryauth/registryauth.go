@@ -0,0 +1,171 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package registryauth stores registry credentials in the Docker credential store, which is read by every Zarf
+// command that pulls from or pushes to a registry.
+package registryauth
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+
+	"github.com/docker/cli/cli/config"
+	"github.com/docker/cli/cli/config/configfile"
+	"github.com/docker/cli/cli/config/types"
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"oras.land/oras-go/v2/registry/remote"
+	"oras.land/oras-go/v2/registry/remote/auth"
+	"oras.land/oras-go/v2/registry/remote/retry"
+
+	"github.com/zarf-dev/zarf/src/pkg/logger"
+)
+
+// LoginOptions are the options for Login.
+type LoginOptions struct {
+	Username string
+	Password string
+	// CredentialHelper stores the credentials of this registry with docker-credential-<CredentialHelper> instead of
+	// the default store of the Docker config.
+	CredentialHelper string
+	// SkipVerify stores the credentials without checking them against the registry.
+	SkipVerify            bool
+	PlainHTTP             bool
+	InsecureSkipTLSVerify bool
+	// ConfigDir is the directory of the Docker config, defaults to $DOCKER_CONFIG or ~/.docker.
+	ConfigDir string
+}
+
+// ServerAddress returns the key the credentials of a registry are stored under in the Docker config.
+func ServerAddress(registry string) (string, error) {
+	reg, err := name.NewRegistry(registry)
+	if err != nil {
+		return "", fmt.Errorf("invalid registry %s: %w", registry, err)
+	}
+	return serverAddress(reg), nil
+}
+
+func serverAddress(reg name.Registry) string {
+	// Docker stores the credentials of Docker Hub under its legacy index address.
+	if reg.Name() == name.DefaultRegistry {
+		return authn.DefaultAuthKey
+	}
+	return reg.Name()
+}
+
+// Login verifies the credentials against the registry and stores them in the Docker credential store.
+func Login(ctx context.Context, registry string, opts LoginOptions) error {
+	if opts.Username == "" || opts.Password == "" {
+		return errors.New("a username and password are required")
+	}
+	reg, err := name.NewRegistry(registry)
+	if err != nil {
+		return fmt.Errorf("invalid registry %s: %w", registry, err)
+	}
+	address := serverAddress(reg)
+	if !opts.SkipVerify {
+		err := verify(ctx, reg.RegistryStr(), opts)
+		if err != nil {
+			return fmt.Errorf("unable to log in to %s: %w", registry, err)
+		}
+	}
+
+	cf, err := loadConfig(opts.ConfigDir)
+	if err != nil {
+		return err
+	}
+	if opts.CredentialHelper != "" {
+		cf.CredentialHelpers[address] = opts.CredentialHelper
+	}
+	err = cf.GetCredentialsStore(address).Store(types.AuthConfig{
+		ServerAddress: address,
+		Username:      opts.Username,
+		Password:      opts.Password,
+	})
+	if err != nil {
+		return fmt.Errorf("unable to store the credentials of %s: %w", registry, err)
+	}
+	err = cf.Save()
+	if err != nil {
+		return err
+	}
+	logger.From(ctx).Info("logged in", "registry", address, "config", cf.Filename, "store", storeName(cf, address))
+	return nil
+}
+
+// Logout removes the credentials of the registry from the Docker credential store.
+func Logout(ctx context.Context, registry, configDir string) error {
+	serverAddress, err := ServerAddress(registry)
+	if err != nil {
+		return err
+	}
+	cf, err := loadConfig(configDir)
+	if err != nil {
+		return err
+	}
+	err = cf.GetCredentialsStore(serverAddress).Erase(serverAddress)
+	if err != nil {
+		return fmt.Errorf("unable to remove the credentials of %s: %w", registry, err)
+	}
+	err = cf.Save()
+	if err != nil {
+		return err
+	}
+	logger.From(ctx).Info("logged out", "registry", serverAddress, "config", cf.Filename)
+	return nil
+}
+
+func loadConfig(dir string) (*configfile.ConfigFile, error) {
+	if dir == "" {
+		dir = os.Getenv("DOCKER_CONFIG")
+	}
+	cf, err := config.Load(dir)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load the Docker config: %w", err)
+	}
+	if cf.CredentialHelpers == nil {
+		cf.CredentialHelpers = map[string]string{}
+	}
+	return cf, nil
+}
+
+// storeName returns the name of the store the credentials of the registry are kept in.
+func storeName(cf *configfile.ConfigFile, serverAddress string) string {
+	if helper, ok := cf.CredentialHelpers[serverAddress]; ok {
+		return "docker-credential-" + helper
+	}
+	if cf.CredentialsStore != "" {
+		return "docker-credential-" + cf.CredentialsStore
+	}
+	return "file"
+}
+
+// verify checks the credentials by pinging the registry API with them.
+func verify(ctx context.Context, host string, opts LoginOptions) error {
+	reg, err := remote.NewRegistry(host)
+	if err != nil {
+		return err
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = &tls.Config{
+		// The registry may be reached with a self-signed certificate when users explicitly ask for it.
+		InsecureSkipVerify: opts.InsecureSkipTLSVerify, //nolint:gosec
+		MinVersion:         tls.VersionTLS12,
+	}
+	client := &auth.Client{
+		Client: &http.Client{Transport: retry.NewTransport(transport)},
+		Cache:  auth.NewCache(),
+		Credential: auth.StaticCredential(reg.Reference.Registry, auth.Credential{
+			Username: opts.Username,
+			Password: opts.Password,
+		}),
+	}
+	client.SetUserAgent("zarf")
+	reg.Client = client
+	reg.PlainHTTP = opts.PlainHTTP
+	return reg.Ping(ctx)
+}
@@ -0,0 +1,84 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package registryauth
+
+import (
+	"testing"
+
+	"github.com/defenseunicorns/pkg/helpers/v2"
+	"github.com/docker/cli/cli/config"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zarf-dev/zarf/src/test/testutil"
+)
+
+func TestServerAddress(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		registry string
+		expected string
+	}{
+		{
+			name:     "registry",
+			registry: "reg.example.com",
+			expected: "reg.example.com",
+		},
+		{
+			name:     "registry with port",
+			registry: "127.0.0.1:31999",
+			expected: "127.0.0.1:31999",
+		},
+		{
+			name:     "docker hub",
+			registry: "docker.io",
+			expected: "https://index.docker.io/v1/",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			address, err := ServerAddress(tt.registry)
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, address)
+		})
+	}
+}
+
+func TestLoginLogout(t *testing.T) {
+	t.Parallel()
+	ctx := testutil.TestContext(t)
+
+	port, err := helpers.GetAvailablePort()
+	require.NoError(t, err)
+	registryURL := testutil.SetupInMemoryRegistry(ctx, t, port)
+	configDir := t.TempDir()
+
+	err = Login(ctx, registryURL, LoginOptions{Username: "admin", ConfigDir: configDir})
+	require.EqualError(t, err, "a username and password are required")
+
+	opts := LoginOptions{
+		Username:  "admin",
+		Password:  "hunter2",
+		PlainHTTP: true,
+		ConfigDir: configDir,
+	}
+	err = Login(ctx, registryURL, opts)
+	require.NoError(t, err)
+	cf, err := config.Load(configDir)
+	require.NoError(t, err)
+	authConfig, err := cf.GetAuthConfig(registryURL)
+	require.NoError(t, err)
+	require.Equal(t, "admin", authConfig.Username)
+	require.Equal(t, "hunter2", authConfig.Password)
+
+	err = Logout(ctx, registryURL, configDir)
+	require.NoError(t, err)
+	cf, err = config.Load(configDir)
+	require.NoError(t, err)
+	authConfig, err = cf.GetAuthConfig(registryURL)
+	require.NoError(t, err)
+	require.Empty(t, authConfig.Username)
+}
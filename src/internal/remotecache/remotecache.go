@@ -0,0 +1,260 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package remotecache shares the entries of the Zarf cache between machines through an S3 bucket or OCI repository.
+package remotecache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/defenseunicorns/pkg/helpers/v2"
+	"oras.land/oras-go/v2/errdef"
+
+	"github.com/zarf-dev/zarf/src/config"
+	"github.com/zarf-dev/zarf/src/internal/artifact"
+	"github.com/zarf-dev/zarf/src/pkg/utils"
+)
+
+// S3URLPrefix is the prefix of S3 remote caches.
+const S3URLPrefix = "s3://"
+
+// ErrNotFound is returned by Get when the store does not have the entry.
+var ErrNotFound = errors.New("entry not found in the remote cache")
+
+var keyPattern = regexp.MustCompile(`^[a-zA-Z0-9_][a-zA-Z0-9._-]{0,127}$`)
+
+// Store is a remote store of cache entries. Entries are files identified by a key that is valid as an OCI tag.
+type Store interface {
+	// Get downloads the entry to path and returns ErrNotFound when the store does not have it.
+	Get(ctx context.Context, key, path string) error
+	// Put uploads the file at path as the entry.
+	Put(ctx context.Context, key, path string) error
+}
+
+// Options configure a remote cache.
+type Options struct {
+	// ReadOnly never uploads entries, e.g. for runners that may not write to the cache.
+	ReadOnly              bool
+	PlainHTTP             bool
+	InsecureSkipTLSVerify bool
+}
+
+// New returns the store for the URL of a remote cache, either s3://bucket/prefix or oci://registry/repository.
+func New(ctx context.Context, rawURL string, opts Options) (Store, error) {
+	var store Store
+	switch {
+	case strings.HasPrefix(rawURL, S3URLPrefix):
+		u, err := url.Parse(rawURL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid remote cache %s: %w", rawURL, err)
+		}
+		if u.Host == "" {
+			return nil, fmt.Errorf("remote cache %s does not have a bucket", rawURL)
+		}
+		store, err = newS3Store(ctx, u.Host, strings.Trim(u.Path, "/"))
+		if err != nil {
+			return nil, err
+		}
+	case helpers.IsOCIURL(rawURL):
+		store = &ociStore{
+			repository: strings.TrimSuffix(strings.TrimPrefix(rawURL, helpers.OCIURLPrefix), "/"),
+			registry: artifact.RegistryOptions{
+				PlainHTTP:             opts.PlainHTTP,
+				InsecureSkipTLSVerify: opts.InsecureSkipTLSVerify,
+			},
+		}
+	default:
+		return nil, fmt.Errorf("remote cache %s must be an %s or %s URL", rawURL, S3URLPrefix, helpers.OCIURLPrefix)
+	}
+	if opts.ReadOnly {
+		return readOnlyStore{store}, nil
+	}
+	return store, nil
+}
+
+// validateKey checks that the key can be used as an OCI tag and S3 object name.
+func validateKey(key string) error {
+	if !keyPattern.MatchString(key) {
+		return fmt.Errorf("invalid remote cache key %s", key)
+	}
+	return nil
+}
+
+type readOnlyStore struct {
+	Store
+}
+
+func (readOnlyStore) Put(context.Context, string, string) error {
+	return nil
+}
+
+// ociStore stores every entry as a single file artifact tagged with its key.
+type ociStore struct {
+	repository string
+	registry   artifact.RegistryOptions
+}
+
+func (s *ociStore) Get(ctx context.Context, key, dst string) error {
+	if err := validateKey(key); err != nil {
+		return err
+	}
+	tmpDir, err := utils.MakeTempDir(config.CommonOptions.TempDirectory)
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmpDir)
+	_, err = artifact.Pull(ctx, fmt.Sprintf("%s:%s", s.repository, key), tmpDir, s.registry)
+	if errors.Is(err, errdef.ErrNotFound) {
+		return ErrNotFound
+	}
+	if err != nil {
+		return err
+	}
+	entries, err := os.ReadDir(tmpDir)
+	if err != nil {
+		return err
+	}
+	if len(entries) != 1 {
+		return fmt.Errorf("remote cache entry %s must contain exactly one file, found %d", key, len(entries))
+	}
+	return moveFile(filepath.Join(tmpDir, entries[0].Name()), dst)
+}
+
+func (s *ociStore) Put(ctx context.Context, key, src string) error {
+	if err := validateKey(key); err != nil {
+		return err
+	}
+	opts := artifact.PushOptions{
+		ArtifactType: "application/vnd.zarf.cache.v1",
+		Registry:     s.registry,
+	}
+	_, err := artifact.Push(ctx, fmt.Sprintf("%s:%s", s.repository, key), []artifact.File{{Path: src, MediaType: artifact.DefaultFileMediaType}}, opts)
+	return err
+}
+
+// s3Store stores every entry as an object named after its key under the prefix.
+type s3Store struct {
+	client *s3.Client
+	bucket string
+	prefix string
+}
+
+func newS3Store(ctx context.Context, bucket, prefix string) (*s3Store, error) {
+	// Credentials, region and endpoint are configured the same way as for the AWS CLI.
+	cfg, err := awsconfig.LoadDefaultConfig(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load the AWS config: %w", err)
+	}
+	client := s3.NewFromConfig(cfg, func(o *s3.Options) {
+		// S3 compatible stores such as MinIO are usually only reachable with path style requests.
+		o.UsePathStyle = o.BaseEndpoint != nil
+	})
+	return &s3Store{client: client, bucket: bucket, prefix: prefix}, nil
+}
+
+func (s *s3Store) object(key string) string {
+	return path.Join(s.prefix, key)
+}
+
+func (s *s3Store) Get(ctx context.Context, key, dst string) error {
+	if err := validateKey(key); err != nil {
+		return err
+	}
+	out, err := s.client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(s.object(key)),
+	})
+	if isNotFound(err) {
+		return ErrNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("unable to get %s from the remote cache: %w", key, err)
+	}
+	defer out.Body.Close()
+	return writeFile(out.Body, dst)
+}
+
+func (s *s3Store) Put(ctx context.Context, key, src string) error {
+	if err := validateKey(key); err != nil {
+		return err
+	}
+	f, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	fi, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	_, err = s.client.PutObject(ctx, &s3.PutObjectInput{
+		Bucket:        aws.String(s.bucket),
+		Key:           aws.String(s.object(key)),
+		Body:          f,
+		ContentLength: aws.Int64(fi.Size()),
+	})
+	if err != nil {
+		return fmt.Errorf("unable to put %s into the remote cache: %w", key, err)
+	}
+	return nil
+}
+
+// writeFile writes r to a temporary file next to dst that is renamed to dst once it is complete.
+func writeFile(r io.Reader, dst string) (err error) {
+	err = helpers.CreateDirectory(filepath.Dir(dst), helpers.ReadWriteExecuteUser)
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(dst), filepath.Base(dst)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			err = errors.Join(err, os.Remove(tmp.Name()))
+		}
+	}()
+	_, err = io.Copy(tmp, r)
+	if err != nil {
+		return errors.Join(err, tmp.Close())
+	}
+	err = tmp.Close()
+	if err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), dst)
+}
+
+// moveFile moves src to dst, copying it when they are on different filesystems.
+func moveFile(src, dst string) error {
+	if err := os.Rename(src, dst); err == nil {
+		return nil
+	}
+	f, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return writeFile(f, dst)
+}
+
+// isNotFound reports whether the S3 error is a missing object.
+func isNotFound(err error) bool {
+	var noSuchKey *types.NoSuchKey
+	var respErr interface{ HTTPStatusCode() int }
+	return errors.As(err, &noSuchKey) || (errors.As(err, &respErr) && respErr.HTTPStatusCode() == http.StatusNotFound)
+}
@@ -0,0 +1,137 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package remotecache
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/defenseunicorns/pkg/helpers/v2"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zarf-dev/zarf/src/test/testutil"
+)
+
+// s3Server fakes the object API of S3 with path style requests.
+type s3Server struct {
+	mu      sync.Mutex
+	objects map[string][]byte
+}
+
+func (s *s3Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	switch r.Method {
+	case http.MethodPut:
+		b, err := io.ReadAll(r.Body)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		s.objects[r.URL.Path] = b
+	case http.MethodGet:
+		b, ok := s.objects[r.URL.Path]
+		if !ok {
+			w.Header().Set("Content-Type", "application/xml")
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `<Error><Code>NoSuchKey</Code><Message>The specified key does not exist.</Message></Error>`)
+			return
+		}
+		_, _ = w.Write(b)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func TestStores(t *testing.T) {
+	t.Parallel()
+	ctx := testutil.TestContext(t)
+
+	port, err := helpers.GetAvailablePort()
+	require.NoError(t, err)
+	registry := testutil.SetupInMemoryRegistry(ctx, t, port)
+	ociStore, err := New(ctx, fmt.Sprintf("oci://%s/zarf/cache", registry), Options{PlainHTTP: true})
+	require.NoError(t, err)
+
+	fake := &s3Server{objects: map[string][]byte{}}
+	srv := httptest.NewServer(fake)
+	t.Cleanup(srv.Close)
+	client := s3.New(s3.Options{
+		BaseEndpoint: aws.String(srv.URL),
+		UsePathStyle: true,
+		Region:       "us-east-1",
+		Credentials:  credentials.NewStaticCredentialsProvider("key", "secret", ""),
+	})
+	s3Store := &s3Store{client: client, bucket: "bucket", prefix: "zarf/cache"}
+
+	tests := []struct {
+		name  string
+		store Store
+	}{
+		{name: "oci", store: ociStore},
+		{name: "s3", store: s3Store},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			src := filepath.Join(dir, "sha256:abc")
+			err := os.WriteFile(src, []byte("layer"), 0o600)
+			require.NoError(t, err)
+
+			dst := filepath.Join(dir, "pulled", "entry")
+			err = tt.store.Get(ctx, "layer-sha256-abc", dst)
+			require.ErrorIs(t, err, ErrNotFound)
+
+			err = tt.store.Put(ctx, "layer-sha256-abc", src)
+			require.NoError(t, err)
+			err = tt.store.Get(ctx, "layer-sha256-abc", dst)
+			require.NoError(t, err)
+			b, err := os.ReadFile(dst)
+			require.NoError(t, err)
+			require.Equal(t, "layer", string(b))
+
+			err = tt.store.Put(ctx, "not/a/key", src)
+			require.EqualError(t, err, "invalid remote cache key not/a/key")
+		})
+	}
+	require.Contains(t, fake.objects, "/bucket/zarf/cache/layer-sha256-abc")
+}
+
+func TestReadOnly(t *testing.T) {
+	t.Parallel()
+	ctx := testutil.TestContext(t)
+
+	port, err := helpers.GetAvailablePort()
+	require.NoError(t, err)
+	registry := testutil.SetupInMemoryRegistry(ctx, t, port)
+	store, err := New(ctx, fmt.Sprintf("oci://%s/zarf/cache", registry), Options{PlainHTTP: true, ReadOnly: true})
+	require.NoError(t, err)
+
+	src := filepath.Join(t.TempDir(), "entry")
+	err = os.WriteFile(src, []byte("component"), 0o600)
+	require.NoError(t, err)
+	err = store.Put(ctx, "component-abc", src)
+	require.NoError(t, err)
+	err = store.Get(ctx, "component-abc", filepath.Join(t.TempDir(), "entry"))
+	require.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestNew(t *testing.T) {
+	t.Parallel()
+	ctx := testutil.TestContext(t)
+
+	_, err := New(ctx, "https://example.com/cache", Options{})
+	require.EqualError(t, err, "remote cache https://example.com/cache must be an s3:// or oci:// URL")
+	_, err = New(ctx, "s3:///cache", Options{})
+	require.EqualError(t, err, "remote cache s3:///cache does not have a bucket")
+}
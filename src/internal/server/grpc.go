@@ -0,0 +1,440 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+	_ "google.golang.org/protobuf/types/known/structpb" // registers google/protobuf/struct.proto
+
+	"github.com/zarf-dev/zarf/src/api/v1alpha1"
+	"github.com/zarf-dev/zarf/src/pkg/logger"
+)
+
+// grpcServiceName is the full name of the gRPC service defined in zarf.proto.
+const grpcServiceName = "zarf.server.v1.PackageService"
+
+// grpcFile is the descriptor of zarf.proto. The repository does not generate protobuf code, so the descriptor is built
+// here and messages are converted to and from the JSON types of the REST API.
+var grpcFile = mustBuildGRPCFile()
+
+// jobRequest is the request of the calls that look up a job.
+type jobRequest struct {
+	ID string `json:"id"`
+}
+
+// jobOutput is a single line of a job stream.
+type jobOutput struct {
+	Line string `json:"line"`
+}
+
+func mustBuildGRPCFile() protoreflect.FileDescriptor {
+	str := descriptorpb.FieldDescriptorProto_TYPE_STRING
+	boolean := descriptorpb.FieldDescriptorProto_TYPE_BOOL
+	messages := []*descriptorpb.DescriptorProto{
+		message("CreateRequest",
+			field("path", 1, str),
+			field("output", 2, str),
+			field("flavor", 3, str),
+			mapField("CreateRequest", "set", 4),
+			mapField("CreateRequest", "registry_overrides", 5),
+			field("signing_key_path", 6, str),
+			withJSONName(field("skip_sbom", 7, boolean), "skipSBOM"),
+		),
+		message("DeployRequest",
+			field("source", 1, str),
+			field("components", 2, str),
+			mapField("DeployRequest", "set", 3),
+			field("shasum", 4, str),
+			field("public_key_path", 5, str),
+			field("skip_signature_validation", 6, boolean),
+			field("adopt_existing_resources", 7, boolean),
+			field("timeout", 8, str),
+			field("retries", 9, descriptorpb.FieldDescriptorProto_TYPE_INT32),
+		),
+		message("InspectRequest",
+			field("source", 1, str),
+			field("public_key_path", 2, str),
+			field("skip_signature_validation", 3, boolean),
+		),
+		message("InspectResponse",
+			messageField("package", 1, ".google.protobuf.Struct", false),
+		),
+		message("RemoveRequest",
+			field("source", 1, str),
+			field("components", 2, str),
+			field("public_key_path", 3, str),
+			field("skip_signature_validation", 4, boolean),
+		),
+		message("ListJobsRequest"),
+		message("ListJobsResponse",
+			messageField("jobs", 1, ".zarf.server.v1.Job", true),
+		),
+		message("JobRequest",
+			field("id", 1, str),
+		),
+		message("Job",
+			field("id", 1, str),
+			field("operation", 2, str),
+			field("status", 3, str),
+			field("error", 4, str),
+			field("created_at", 5, str),
+			field("started_at", 6, str),
+			field("finished_at", 7, str),
+		),
+		message("JobOutput",
+			field("line", 1, str),
+		),
+	}
+	for _, m := range messages {
+		for _, f := range m.Field {
+			if f.TypeName != nil && strings.HasSuffix(f.GetTypeName(), "Entry") {
+				m.NestedType = append(m.NestedType, mapEntry(f))
+			}
+		}
+	}
+	fdp := &descriptorpb.FileDescriptorProto{
+		Name:        proto.String("zarf/server/v1/zarf.proto"),
+		Package:     proto.String("zarf.server.v1"),
+		Dependency:  []string{"google/protobuf/struct.proto"},
+		Syntax:      proto.String("proto3"),
+		MessageType: messages,
+		Service: []*descriptorpb.ServiceDescriptorProto{{
+			Name: proto.String("PackageService"),
+			Method: []*descriptorpb.MethodDescriptorProto{
+				method("CreatePackage", "CreateRequest", "Job", false),
+				method("DeployPackage", "DeployRequest", "Job", false),
+				method("RemovePackage", "RemoveRequest", "Job", false),
+				method("InspectPackage", "InspectRequest", "InspectResponse", false),
+				method("ListJobs", "ListJobsRequest", "ListJobsResponse", false),
+				method("GetJob", "JobRequest", "Job", false),
+				method("StreamJobLogs", "JobRequest", "JobOutput", true),
+				method("StreamJobEvents", "JobRequest", "JobOutput", true),
+			},
+		}},
+		Options: &descriptorpb.FileOptions{GoPackage: proto.String("github.com/zarf-dev/zarf/src/internal/server")},
+	}
+	fd, err := protodesc.NewFile(fdp, protoregistry.GlobalFiles)
+	if err != nil {
+		panic(fmt.Sprintf("invalid gRPC descriptor: %v", err))
+	}
+	// Registering the file lets clients discover the service through server reflection.
+	if err := protoregistry.GlobalFiles.RegisterFile(fd); err != nil {
+		panic(fmt.Sprintf("unable to register gRPC descriptor: %v", err))
+	}
+	return fd
+}
+
+func message(name string, fields ...*descriptorpb.FieldDescriptorProto) *descriptorpb.DescriptorProto {
+	return &descriptorpb.DescriptorProto{Name: proto.String(name), Field: fields}
+}
+
+func field(name string, number int32, typ descriptorpb.FieldDescriptorProto_Type) *descriptorpb.FieldDescriptorProto {
+	return &descriptorpb.FieldDescriptorProto{
+		Name:     proto.String(name),
+		JsonName: proto.String(jsonName(name)),
+		Number:   proto.Int32(number),
+		Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+		Type:     typ.Enum(),
+	}
+}
+
+func withJSONName(f *descriptorpb.FieldDescriptorProto, name string) *descriptorpb.FieldDescriptorProto {
+	f.JsonName = proto.String(name)
+	return f
+}
+
+func messageField(name string, number int32, typeName string, repeated bool) *descriptorpb.FieldDescriptorProto {
+	f := field(name, number, descriptorpb.FieldDescriptorProto_TYPE_MESSAGE)
+	f.TypeName = proto.String(typeName)
+	if repeated {
+		f.Label = descriptorpb.FieldDescriptorProto_LABEL_REPEATED.Enum()
+	}
+	return f
+}
+
+// mapField returns a map<string, string> field, its entry message is added to the message by mustBuildGRPCFile.
+func mapField(msg, name string, number int32) *descriptorpb.FieldDescriptorProto {
+	entry := strings.ToUpper(jsonName(name)[:1]) + jsonName(name)[1:] + "Entry"
+	return messageField(name, number, ".zarf.server.v1."+msg+"."+entry, true)
+}
+
+func mapEntry(f *descriptorpb.FieldDescriptorProto) *descriptorpb.DescriptorProto {
+	typeName := f.GetTypeName()
+	entry := message(typeName[strings.LastIndex(typeName, ".")+1:],
+		field("key", 1, descriptorpb.FieldDescriptorProto_TYPE_STRING),
+		field("value", 2, descriptorpb.FieldDescriptorProto_TYPE_STRING),
+	)
+	entry.Options = &descriptorpb.MessageOptions{MapEntry: proto.Bool(true)}
+	return entry
+}
+
+func method(name, input, output string, serverStreaming bool) *descriptorpb.MethodDescriptorProto {
+	return &descriptorpb.MethodDescriptorProto{
+		Name:            proto.String(name),
+		InputType:       proto.String(".zarf.server.v1." + input),
+		OutputType:      proto.String(".zarf.server.v1." + output),
+		ServerStreaming: proto.Bool(serverStreaming),
+	}
+}
+
+// jsonName returns the lower camel case JSON name protoc derives from a field name.
+func jsonName(name string) string {
+	var sb strings.Builder
+	upper := false
+	for _, r := range name {
+		if r == '_' {
+			upper = true
+			continue
+		}
+		if upper {
+			r = []rune(strings.ToUpper(string(r)))[0]
+			upper = false
+		}
+		sb.WriteRune(r)
+	}
+	return sb.String()
+}
+
+func grpcMessage(name string) protoreflect.MessageDescriptor {
+	return grpcFile.Messages().ByName(protoreflect.Name(name))
+}
+
+// fromProto converts a message to the REST type with the same JSON representation.
+func fromProto(m proto.Message, v any) error {
+	b, err := protojson.Marshal(m)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, v)
+}
+
+// toProto converts a REST type to the message with the same JSON representation.
+func toProto(v any, name string) (proto.Message, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	m := dynamicpb.NewMessage(grpcMessage(name))
+	err = protojson.UnmarshalOptions{DiscardUnknown: true}.Unmarshal(b, m)
+	if err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// grpcServer returns the gRPC server of the API.
+func (s *Server) grpcServer() *grpc.Server {
+	srv := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+			if err := s.authenticateGRPC(ctx); err != nil {
+				return nil, err
+			}
+			return handler(ctx, req)
+		}),
+		grpc.ChainStreamInterceptor(func(srv any, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+			if err := s.authenticateGRPC(ss.Context()); err != nil {
+				return err
+			}
+			return handler(srv, ss)
+		}),
+	)
+	srv.RegisterService(&grpc.ServiceDesc{
+		ServiceName: grpcServiceName,
+		HandlerType: (*any)(nil),
+		Methods: []grpc.MethodDesc{
+			unaryMethod("CreatePackage", "CreateRequest", func(ctx context.Context, req CreateRequest) (any, string, error) {
+				return s.submitGRPC("create", func(ctx context.Context) error {
+					return s.ops.Create(ctx, req)
+				})
+			}),
+			unaryMethod("DeployPackage", "DeployRequest", func(ctx context.Context, req DeployRequest) (any, string, error) {
+				return s.submitGRPC("deploy", func(ctx context.Context) error {
+					return s.ops.Deploy(ctx, req)
+				})
+			}),
+			unaryMethod("RemovePackage", "RemoveRequest", func(ctx context.Context, req RemoveRequest) (any, string, error) {
+				return s.submitGRPC("remove", func(ctx context.Context) error {
+					return s.ops.Remove(ctx, req)
+				})
+			}),
+			unaryMethod("InspectPackage", "InspectRequest", func(ctx context.Context, req InspectRequest) (any, string, error) {
+				var pkg v1alpha1.ZarfPackage
+				err := s.jobs.Exclusive(ctx, func(ctx context.Context) error {
+					var err error
+					pkg, err = s.ops.Inspect(ctx, req)
+					return err
+				})
+				if err != nil {
+					return nil, "", grpcError(codes.InvalidArgument, err)
+				}
+				return map[string]any{"package": pkg}, "InspectResponse", nil
+			}),
+			unaryMethod("ListJobs", "ListJobsRequest", func(_ context.Context, _ struct{}) (any, string, error) {
+				return map[string]any{"jobs": s.jobs.List()}, "ListJobsResponse", nil
+			}),
+			unaryMethod("GetJob", "JobRequest", func(_ context.Context, req jobRequest) (any, string, error) {
+				j, ok := s.jobs.Get(req.ID)
+				if !ok {
+					return nil, "", status.Errorf(codes.NotFound, "job %s not found", req.ID)
+				}
+				return j.Job(), "Job", nil
+			}),
+		},
+		Streams: []grpc.StreamDesc{
+			s.jobStreamMethod("StreamJobLogs", func(j *job) *stream { return j.logs }),
+			s.jobStreamMethod("StreamJobEvents", func(j *job) *stream { return j.events }),
+		},
+		Metadata: "zarf.proto",
+	}, s)
+	reflection.Register(srv)
+	return srv
+}
+
+// unaryMethod returns a method that decodes its request into the REST type T and encodes the result of call as the
+// named message.
+func unaryMethod[T any](name, input string, call func(ctx context.Context, req T) (any, string, error)) grpc.MethodDesc {
+	return grpc.MethodDesc{
+		MethodName: name,
+		Handler: func(_ any, ctx context.Context, dec func(any) error, interceptor grpc.UnaryServerInterceptor) (any, error) {
+			in := dynamicpb.NewMessage(grpcMessage(input))
+			if err := dec(in); err != nil {
+				return nil, err
+			}
+			handler := func(ctx context.Context, in any) (any, error) {
+				var req T
+				if err := fromProto(in.(proto.Message), &req); err != nil {
+					return nil, status.Errorf(codes.InvalidArgument, "invalid request: %v", err)
+				}
+				out, output, err := call(ctx, req)
+				if err != nil {
+					return nil, err
+				}
+				m, err := toProto(out, output)
+				if err != nil {
+					return nil, grpcError(codes.Internal, err)
+				}
+				return m, nil
+			}
+			if interceptor == nil {
+				return handler(ctx, in)
+			}
+			info := &grpc.UnaryServerInfo{FullMethod: "/" + grpcServiceName + "/" + name}
+			return interceptor(ctx, in, info, handler)
+		},
+	}
+}
+
+// jobStreamMethod returns a method that streams the lines of a job stream until the job finishes.
+func (s *Server) jobStreamMethod(name string, streamOf func(j *job) *stream) grpc.StreamDesc {
+	return grpc.StreamDesc{
+		StreamName:    name,
+		ServerStreams: true,
+		Handler: func(_ any, ss grpc.ServerStream) error {
+			in := dynamicpb.NewMessage(grpcMessage("JobRequest"))
+			if err := ss.RecvMsg(in); err != nil {
+				return err
+			}
+			var req jobRequest
+			if err := fromProto(in, &req); err != nil {
+				return status.Errorf(codes.InvalidArgument, "invalid request: %v", err)
+			}
+			j, ok := s.jobs.Get(req.ID)
+			if !ok {
+				return status.Errorf(codes.NotFound, "job %s not found", req.ID)
+			}
+			w := &lineWriter{send: func(line string) error {
+				m, err := toProto(jobOutput{Line: line}, "JobOutput")
+				if err != nil {
+					return err
+				}
+				return ss.SendMsg(m)
+			}}
+			err := streamOf(j).Follow(ss.Context(), w)
+			if err == nil {
+				err = w.Flush()
+			}
+			if err != nil && !errors.Is(err, context.Canceled) {
+				logger.From(ss.Context()).Debug("job stream ended", "id", req.ID, "error", err)
+				return status.FromContextError(err).Err()
+			}
+			return nil
+		},
+	}
+}
+
+func (s *Server) submitGRPC(operation string, run func(ctx context.Context) error) (any, string, error) {
+	j, err := s.jobs.Submit(operation, run)
+	if errors.Is(err, errQueueFull) {
+		return nil, "", grpcError(codes.ResourceExhausted, err)
+	}
+	if err != nil {
+		return nil, "", grpcError(codes.Internal, err)
+	}
+	return j, "Job", nil
+}
+
+// authenticateGRPC rejects calls that do not present the API token as a bearer token in the authorization metadata.
+func (s *Server) authenticateGRPC(ctx context.Context) error {
+	md, _ := metadata.FromIncomingContext(ctx)
+	for _, v := range md.Get("authorization") {
+		token, ok := strings.CutPrefix(v, "Bearer ")
+		if ok && s.validToken(token) {
+			return nil
+		}
+	}
+	return status.Error(codes.Unauthenticated, "invalid or missing API token")
+}
+
+func grpcError(code codes.Code, err error) error {
+	return status.Error(code, logger.Redact(err.Error()))
+}
+
+// lineWriter sends every complete line written to it.
+type lineWriter struct {
+	buf  []byte
+	send func(line string) error
+}
+
+func (w *lineWriter) Write(p []byte) (int, error) {
+	w.buf = append(w.buf, p...)
+	for {
+		i := bytes.IndexByte(w.buf, '\n')
+		if i < 0 {
+			return len(p), nil
+		}
+		line := string(w.buf[:i])
+		w.buf = w.buf[i+1:]
+		if err := w.send(line); err != nil {
+			return 0, err
+		}
+	}
+}
+
+// Flush sends the remaining partial line, if any.
+func (w *lineWriter) Flush() error {
+	if len(w.buf) == 0 {
+		return nil
+	}
+	line := string(w.buf)
+	w.buf = nil
+	return w.send(line)
+}
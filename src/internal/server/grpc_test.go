@@ -0,0 +1,197 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package server
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http/httptest"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/dynamicpb"
+
+	"github.com/zarf-dev/zarf/src/api/v1alpha1"
+)
+
+func newGRPCClient(t *testing.T, ts *httptest.Server) *grpc.ClientConn {
+	t.Helper()
+
+	conn, err := grpc.NewClient(strings.TrimPrefix(ts.URL, "http://"), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, conn.Close())
+	})
+	return conn
+}
+
+func authContext(t *testing.T, token string) context.Context {
+	t.Helper()
+
+	return metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+token)
+}
+
+// invokeGRPC calls a unary method with req encoded as the input message and decodes the output message into resp.
+func invokeGRPC(ctx context.Context, conn *grpc.ClientConn, name string, req any, resp any) error {
+	md := grpcFile.Services().ByName(protoreflect.Name("PackageService")).Methods().ByName(protoreflect.Name(name))
+	in, err := toProto(req, string(md.Input().Name()))
+	if err != nil {
+		return err
+	}
+	out := dynamicpb.NewMessage(md.Output())
+	err = conn.Invoke(ctx, "/"+grpcServiceName+"/"+name, in, out)
+	if err != nil {
+		return err
+	}
+	return fromProto(out, resp)
+}
+
+// followGRPC returns the lines of a job stream until the job finishes.
+func followGRPC(t *testing.T, conn *grpc.ClientConn, name, id string) []string {
+	t.Helper()
+
+	desc := &grpc.StreamDesc{StreamName: name, ServerStreams: true}
+	cs, err := conn.NewStream(authContext(t, testToken), desc, "/"+grpcServiceName+"/"+name)
+	require.NoError(t, err)
+	in, err := toProto(jobRequest{ID: id}, "JobRequest")
+	require.NoError(t, err)
+	require.NoError(t, cs.SendMsg(in))
+	require.NoError(t, cs.CloseSend())
+	lines := []string{}
+	for {
+		out := dynamicpb.NewMessage(grpcMessage("JobOutput"))
+		err := cs.RecvMsg(out)
+		if errors.Is(err, io.EOF) {
+			return lines
+		}
+		require.NoError(t, err)
+		var o jobOutput
+		require.NoError(t, fromProto(out, &o))
+		lines = append(lines, o.Line)
+	}
+}
+
+func TestGRPCAuthentication(t *testing.T) {
+	t.Parallel()
+	conn := newGRPCClient(t, newTestServer(t))
+
+	for _, ctx := range []context.Context{context.Background(), authContext(t, "wrong-token")} {
+		var resp struct{}
+		err := invokeGRPC(ctx, conn, "ListJobs", struct{}{}, &resp)
+		require.Equal(t, codes.Unauthenticated, status.Code(err))
+	}
+}
+
+func TestGRPCJobs(t *testing.T) {
+	t.Parallel()
+	conn := newGRPCClient(t, newTestServer(t))
+	ctx := authContext(t, testToken)
+
+	var created Job
+	err := invokeGRPC(ctx, conn, "CreatePackage", CreateRequest{Path: "examples/dos-games", Output: "build", SkipSBOM: true}, &created)
+	require.NoError(t, err)
+	require.Equal(t, "create", created.Operation)
+	logs := followGRPC(t, conn, "StreamJobLogs", created.ID)
+	require.NotEmpty(t, logs)
+	require.Contains(t, strings.Join(logs, "\n"), `"msg":"creating package"`)
+	evs := followGRPC(t, conn, "StreamJobEvents", created.ID)
+	require.Len(t, evs, 1)
+	require.Contains(t, evs[0], `"phase":"create"`)
+
+	var j Job
+	require.NoError(t, invokeGRPC(ctx, conn, "GetJob", jobRequest{ID: created.ID}, &j))
+	require.Equal(t, JobSucceeded, j.Status)
+	require.NotNil(t, j.StartedAt)
+	require.NotNil(t, j.FinishedAt)
+
+	var deployed Job
+	err = invokeGRPC(ctx, conn, "DeployPackage", DeployRequest{Source: "dos-games", SetVariables: map[string]string{"A": "b"}, Retries: 2}, &deployed)
+	require.NoError(t, err)
+	followGRPC(t, conn, "StreamJobLogs", deployed.ID)
+	require.NoError(t, invokeGRPC(ctx, conn, "GetJob", jobRequest{ID: deployed.ID}, &j))
+	require.Equal(t, JobFailed, j.Status)
+	require.Equal(t, "unable to deploy dos-games", j.Error)
+
+	var jobs struct {
+		Jobs []Job `json:"jobs"`
+	}
+	require.NoError(t, invokeGRPC(ctx, conn, "ListJobs", struct{}{}, &jobs))
+	require.Len(t, jobs.Jobs, 2)
+
+	var inspected struct {
+		Package v1alpha1.ZarfPackage `json:"package"`
+	}
+	require.NoError(t, invokeGRPC(ctx, conn, "InspectPackage", InspectRequest{Source: "dos-games"}, &inspected))
+	require.Equal(t, "dos-games", inspected.Package.Metadata.Name)
+
+	err = invokeGRPC(ctx, conn, "GetJob", jobRequest{ID: "missing"}, &j)
+	require.Equal(t, codes.NotFound, status.Code(err))
+	require.Equal(t, "job missing not found", status.Convert(err).Message())
+}
+
+// TestGRPCDescriptor checks that the descriptor built by the server matches zarf.proto.
+func TestGRPCDescriptor(t *testing.T) {
+	t.Parallel()
+
+	b, err := os.ReadFile("zarf.proto")
+	require.NoError(t, err)
+
+	rpcRe := regexp.MustCompile(`^\s*rpc (\w+)\((\w+)\) returns \((stream )?(\w+)\);`)
+	messageRe := regexp.MustCompile(`^message (\w+) \{`)
+	fieldRe := regexp.MustCompile(`^\s*(repeated |map<string, string> )?(?:([\w.]+) )?(\w+) = (\d+)`)
+	svc := grpcFile.Services().ByName("PackageService")
+	methods := 0
+	var msg protoreflect.MessageDescriptor
+	fields := 0
+	for _, line := range strings.Split(string(b), "\n") {
+		if m := rpcRe.FindStringSubmatch(line); m != nil {
+			methods++
+			md := svc.Methods().ByName(protoreflect.Name(m[1]))
+			require.NotNil(t, md, m[1])
+			require.Equal(t, m[2], string(md.Input().Name()))
+			require.Equal(t, m[3] != "", md.IsStreamingServer())
+			require.Equal(t, m[4], string(md.Output().Name()))
+			continue
+		}
+		if m := messageRe.FindStringSubmatch(line); m != nil {
+			if msg != nil {
+				require.Equal(t, msg.Fields().Len(), fields, msg.Name())
+			}
+			msg = grpcMessage(m[1])
+			require.NotNil(t, msg, m[1])
+			fields = 0
+			continue
+		}
+		if m := fieldRe.FindStringSubmatch(line); m != nil && msg != nil {
+			fields++
+			fd := msg.Fields().ByName(protoreflect.Name(m[3]))
+			require.NotNil(t, fd, m[3])
+			require.Equal(t, m[4], strconv.Itoa(int(fd.Number())), m[3])
+			switch {
+			case fd.IsMap():
+				require.Equal(t, protoreflect.StringKind, fd.MapValue().Kind(), m[3])
+			case fd.Kind() == protoreflect.MessageKind:
+				require.True(t, strings.HasSuffix(string(fd.Message().FullName()), m[2]), m[3])
+			default:
+				require.Equal(t, m[2], fd.Kind().String(), m[3])
+			}
+			require.Equal(t, m[1] == "map<string, string> ", fd.IsMap(), m[3])
+			require.Equal(t, m[1] == "repeated ", fd.IsList(), m[3])
+		}
+	}
+	require.Equal(t, msg.Fields().Len(), fields, msg.Name())
+	require.Equal(t, svc.Methods().Len(), methods)
+	require.Equal(t, 10, grpcFile.Messages().Len())
+}
@@ -0,0 +1,317 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/defenseunicorns/pkg/helpers/v2"
+
+	"github.com/zarf-dev/zarf/src/pkg/events"
+	"github.com/zarf-dev/zarf/src/pkg/logger"
+)
+
+// JobStatus is the state of a job.
+type JobStatus string
+
+const (
+	// JobQueued is a job waiting for earlier jobs to finish.
+	JobQueued JobStatus = "queued"
+	// JobRunning is a job being run.
+	JobRunning JobStatus = "running"
+	// JobSucceeded is a job that finished successfully.
+	JobSucceeded JobStatus = "succeeded"
+	// JobFailed is a job that finished with an error.
+	JobFailed JobStatus = "failed"
+)
+
+// Job is the state of a submitted operation.
+type Job struct {
+	ID         string     `json:"id"`
+	Operation  string     `json:"operation"`
+	Status     JobStatus  `json:"status"`
+	Error      string     `json:"error,omitempty"`
+	CreatedAt  time.Time  `json:"createdAt"`
+	StartedAt  *time.Time `json:"startedAt,omitempty"`
+	FinishedAt *time.Time `json:"finishedAt,omitempty"`
+}
+
+// jobIDLength is the length of the random job IDs.
+const jobIDLength = 16
+
+// job is a submitted operation with the streams it writes its logs and events to.
+type job struct {
+	mu     sync.Mutex
+	state  Job
+	run    func(ctx context.Context) error
+	logs   *stream
+	events *stream
+}
+
+// Job returns a copy of the job state.
+func (j *job) Job() Job {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.state
+}
+
+func (j *job) setRunning() {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	now := time.Now().UTC()
+	j.state.Status = JobRunning
+	j.state.StartedAt = &now
+}
+
+func (j *job) setDone(err error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	now := time.Now().UTC()
+	j.state.FinishedAt = &now
+	j.state.Status = JobSucceeded
+	if err != nil {
+		j.state.Status = JobFailed
+		j.state.Error = logger.Redact(err.Error())
+	}
+}
+
+// jobManager queues submitted jobs and runs them one at a time.
+// Zarf operations share process wide configuration so they can not safely run concurrently.
+type jobManager struct {
+	mu    sync.RWMutex
+	jobs  map[string]*job
+	order []string
+	queue chan *job
+	level logger.Level
+	// retain is the number of finished jobs that are kept with their logs and events, older ones are dropped.
+	retain int
+	// running is held while an operation runs, by queued jobs and by operations run with Exclusive.
+	running chan struct{}
+}
+
+func newJobManager(queueSize, retain int, level logger.Level) *jobManager {
+	return &jobManager{
+		jobs:    map[string]*job{},
+		queue:   make(chan *job, queueSize),
+		level:   level,
+		retain:  retain,
+		running: make(chan struct{}, 1),
+	}
+}
+
+// Exclusive runs the operation outside of the queue once no other operation is running.
+func (m *jobManager) Exclusive(ctx context.Context, run func(ctx context.Context) error) error {
+	select {
+	case m.running <- struct{}{}:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	defer func() { <-m.running }()
+	return runRecovered(ctx, run)
+}
+
+// evict drops the oldest finished jobs, with their logs and events, beyond the number of finished jobs retained.
+func (m *jobManager) evict() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	finished := 0
+	for _, id := range m.order {
+		if m.jobs[id].Job().FinishedAt != nil {
+			finished++
+		}
+	}
+	order := make([]string, 0, len(m.order))
+	for _, id := range m.order {
+		if finished > m.retain && m.jobs[id].Job().FinishedAt != nil {
+			delete(m.jobs, id)
+			finished--
+			continue
+		}
+		order = append(order, id)
+	}
+	m.order = order
+}
+
+// errQueueFull is returned when a job is submitted while the queue is full.
+var errQueueFull = errors.New("job queue is full, try again later")
+
+// Submit queues the operation and returns the queued job.
+func (m *jobManager) Submit(operation string, run func(ctx context.Context) error) (Job, error) {
+	id, err := helpers.RandomString(jobIDLength)
+	if err != nil {
+		return Job{}, err
+	}
+	j := &job{
+		state: Job{
+			ID:        id,
+			Operation: operation,
+			Status:    JobQueued,
+			CreatedAt: time.Now().UTC(),
+		},
+		run:    run,
+		logs:   newStream(),
+		events: newStream(),
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	select {
+	case m.queue <- j:
+	default:
+		return Job{}, errQueueFull
+	}
+	m.jobs[id] = j
+	m.order = append(m.order, id)
+	return j.Job(), nil
+}
+
+// Get returns the job with the ID.
+func (m *jobManager) Get(id string) (*job, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	j, ok := m.jobs[id]
+	return j, ok
+}
+
+// List returns all jobs in the order they were submitted.
+func (m *jobManager) List() []Job {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	jobs := make([]Job, 0, len(m.order))
+	for _, id := range m.order {
+		jobs = append(jobs, m.jobs[id].Job())
+	}
+	return jobs
+}
+
+// Run runs queued jobs until the context is cancelled.
+func (m *jobManager) Run(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case j := <-m.queue:
+			select {
+			case m.running <- struct{}{}:
+			case <-ctx.Done():
+				return
+			}
+			m.runJob(ctx, j)
+			<-m.running
+			m.evict()
+		}
+	}
+}
+
+func (m *jobManager) runJob(ctx context.Context, j *job) {
+	l := logger.From(ctx)
+	defer j.logs.Close()
+	defer j.events.Close()
+
+	jobLogger, err := logger.New(logger.Config{
+		Level:       m.level,
+		Format:      logger.FormatJSON,
+		Destination: j.logs,
+	})
+	if err != nil {
+		j.setDone(err)
+		return
+	}
+	jobCtx := logger.WithContext(ctx, jobLogger.With("job", j.state.ID))
+	jobCtx = logger.WithLoggingEnabled(jobCtx, true)
+	jobCtx = events.WithContext(jobCtx, events.NewEmitter(j.events))
+
+	l.Info("starting job", "id", j.state.ID, "operation", j.state.Operation)
+	j.setRunning()
+	err = runRecovered(jobCtx, j.run)
+	j.setDone(err)
+	if err != nil {
+		l.Error("job failed", "id", j.state.ID, "operation", j.state.Operation, "error", err)
+		return
+	}
+	l.Info("job succeeded", "id", j.state.ID, "operation", j.state.Operation)
+}
+
+// runRecovered runs the operation and returns a panic as an error so that a failing job does not stop the server.
+func runRecovered(ctx context.Context, run func(ctx context.Context) error) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("job panicked: %v", r)
+		}
+	}()
+	return run(ctx)
+}
+
+// stream is an append only buffer that can be followed by multiple readers until it is closed.
+type stream struct {
+	mu     sync.Mutex
+	data   []byte
+	closed bool
+	notify chan struct{}
+}
+
+func newStream() *stream {
+	return &stream{notify: make(chan struct{})}
+}
+
+// Write appends p to the stream and wakes up any followers.
+func (s *stream) Write(p []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return 0, errors.New("write to closed stream")
+	}
+	s.data = append(s.data, p...)
+	close(s.notify)
+	s.notify = make(chan struct{})
+	return len(p), nil
+}
+
+// Close marks the stream as complete.
+func (s *stream) Close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return
+	}
+	s.closed = true
+	close(s.notify)
+}
+
+// Follow writes the stream to w as it grows, flushing HTTP responses after every write, until it is closed or ctx is cancelled.
+func (s *stream) Follow(ctx context.Context, w io.Writer) error {
+	flusher, canFlush := w.(http.Flusher)
+	offset := 0
+	for {
+		s.mu.Lock()
+		chunk := s.data[offset:]
+		closed := s.closed
+		notify := s.notify
+		s.mu.Unlock()
+
+		if len(chunk) > 0 {
+			if _, err := w.Write(chunk); err != nil {
+				return err
+			}
+			if canFlush {
+				flusher.Flush()
+			}
+			offset += len(chunk)
+			continue
+		}
+		if closed {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-notify:
+		}
+	}
+}
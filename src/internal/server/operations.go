@@ -0,0 +1,158 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/defenseunicorns/pkg/helpers/v2"
+
+	"github.com/zarf-dev/zarf/src/api/v1alpha1"
+	"github.com/zarf-dev/zarf/src/config"
+	"github.com/zarf-dev/zarf/src/internal/packager2"
+	"github.com/zarf-dev/zarf/src/pkg/cluster"
+	"github.com/zarf-dev/zarf/src/pkg/packager"
+	"github.com/zarf-dev/zarf/src/pkg/packager/filters"
+	"github.com/zarf-dev/zarf/src/types"
+)
+
+// CreateRequest is the body of a package create request. Paths are resolved on the host running the server.
+type CreateRequest struct {
+	Path              string            `json:"path"`
+	Output            string            `json:"output"`
+	Flavor            string            `json:"flavor,omitempty"`
+	SetVariables      map[string]string `json:"set,omitempty"`
+	RegistryOverrides map[string]string `json:"registryOverrides,omitempty"`
+	SigningKeyPath    string            `json:"signingKeyPath,omitempty"`
+	SkipSBOM          bool              `json:"skipSBOM,omitempty"`
+}
+
+// DeployRequest is the body of a package deploy request.
+type DeployRequest struct {
+	Source                  string            `json:"source"`
+	Components              string            `json:"components,omitempty"`
+	SetVariables            map[string]string `json:"set,omitempty"`
+	Shasum                  string            `json:"shasum,omitempty"`
+	PublicKeyPath           string            `json:"publicKeyPath,omitempty"`
+	SkipSignatureValidation bool              `json:"skipSignatureValidation,omitempty"`
+	AdoptExistingResources  bool              `json:"adoptExistingResources,omitempty"`
+	Timeout                 string            `json:"timeout,omitempty"`
+	Retries                 int               `json:"retries,omitempty"`
+}
+
+// InspectRequest is the body of a package inspect request. The source may be the name of a deployed package.
+type InspectRequest struct {
+	Source                  string `json:"source"`
+	PublicKeyPath           string `json:"publicKeyPath,omitempty"`
+	SkipSignatureValidation bool   `json:"skipSignatureValidation,omitempty"`
+}
+
+// RemoveRequest is the body of a package remove request. The source may be the name of a deployed package.
+type RemoveRequest struct {
+	Source                  string `json:"source"`
+	Components              string `json:"components,omitempty"`
+	PublicKeyPath           string `json:"publicKeyPath,omitempty"`
+	SkipSignatureValidation bool   `json:"skipSignatureValidation,omitempty"`
+}
+
+// operations runs the package operations exposed by the server.
+type operations interface {
+	Create(ctx context.Context, req CreateRequest) error
+	Deploy(ctx context.Context, req DeployRequest) error
+	Inspect(ctx context.Context, req InspectRequest) (v1alpha1.ZarfPackage, error)
+	Remove(ctx context.Context, req RemoveRequest) error
+}
+
+// packagerOperations runs operations with the Zarf packager.
+type packagerOperations struct{}
+
+func (packagerOperations) Create(ctx context.Context, req CreateRequest) error {
+	if req.Path == "" || req.Output == "" {
+		return errors.New("path and output are required")
+	}
+	opt := packager2.CreateOptions{
+		Flavor:            req.Flavor,
+		RegistryOverrides: req.RegistryOverrides,
+		SigningKeyPath:    req.SigningKeyPath,
+		SetVariables:      helpers.TransformMapKeys(req.SetVariables, strings.ToUpper),
+		SkipSBOM:          req.SkipSBOM,
+		Output:            req.Output,
+	}
+	return packager2.Create(ctx, req.Path, opt)
+}
+
+func (packagerOperations) Deploy(ctx context.Context, req DeployRequest) error {
+	if req.Source == "" {
+		return errors.New("source is required")
+	}
+	timeout := config.ZarfDefaultTimeout
+	if req.Timeout != "" {
+		var err error
+		timeout, err = time.ParseDuration(req.Timeout)
+		if err != nil {
+			return fmt.Errorf("invalid timeout: %w", err)
+		}
+	}
+	retries := config.ZarfDefaultRetries
+	if req.Retries > 0 {
+		retries = req.Retries
+	}
+	cfg := types.PackagerConfig{
+		PkgOpts: types.ZarfPackageOptions{
+			PackageSource:           req.Source,
+			OptionalComponents:      req.Components,
+			SetVariables:            helpers.TransformMapKeys(req.SetVariables, strings.ToUpper),
+			Shasum:                  req.Shasum,
+			PublicKeyPath:           req.PublicKeyPath,
+			SkipSignatureValidation: req.SkipSignatureValidation,
+			Retries:                 retries,
+		},
+		DeployOpts: types.ZarfDeployOptions{
+			AdoptExistingResources: req.AdoptExistingResources,
+			Timeout:                timeout,
+			TimeoutOverride:        req.Timeout != "",
+		},
+	}
+	pkgClient, err := packager.New(&cfg, packager.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	defer pkgClient.ClearTempPaths()
+	if err := pkgClient.Deploy(ctx); err != nil {
+		return fmt.Errorf("failed to deploy package: %w", err)
+	}
+	return nil
+}
+
+func (packagerOperations) Inspect(ctx context.Context, req InspectRequest) (v1alpha1.ZarfPackage, error) {
+	if req.Source == "" {
+		return v1alpha1.ZarfPackage{}, errors.New("source is required")
+	}
+	// The package may come from the cluster or a built package, since we don't know we don't check this error
+	c, _ := cluster.NewCluster() //nolint:errcheck
+	return packager2.GetPackageFromSourceOrCluster(ctx, c, req.Source, req.SkipSignatureValidation, req.PublicKeyPath)
+}
+
+func (packagerOperations) Remove(ctx context.Context, req RemoveRequest) error {
+	if req.Source == "" {
+		return errors.New("source is required")
+	}
+	c, _ := cluster.NewCluster() //nolint:errcheck
+	removeOpt := packager2.RemoveOptions{
+		Source:  req.Source,
+		Cluster: c,
+		Filter: filters.Combine(
+			filters.ByLocalOS(runtime.GOOS),
+			filters.BySelectState(req.Components),
+		),
+		SkipSignatureValidation: req.SkipSignatureValidation,
+		PublicKeyPath:           req.PublicKeyPath,
+	}
+	return packager2.Remove(ctx, removeOpt)
+}
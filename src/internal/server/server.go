@@ -0,0 +1,284 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package server exposes Zarf package operations over an authenticated REST and gRPC API.
+package server
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/zarf-dev/zarf/src/api/v1alpha1"
+	"github.com/zarf-dev/zarf/src/pkg/logger"
+)
+
+const (
+	// defaultQueueSize is the number of jobs that can wait for earlier jobs to finish.
+	defaultQueueSize = 100
+	// defaultRetainedJobs is the number of finished jobs that can still be queried and streamed.
+	defaultRetainedJobs = 100
+	// maxRequestBytes limits the size of request bodies.
+	maxRequestBytes = 1 << 20
+)
+
+// Options configures the API server.
+type Options struct {
+	// Listen is the address the server listens on, e.g. :8443.
+	Listen string
+	// Token is the bearer token clients must present.
+	Token string
+	// TLSCertPath and TLSKeyPath serve the API over TLS when both are set.
+	TLSCertPath string
+	TLSKeyPath  string
+	// LogLevel is the level of the logs streamed for each job.
+	LogLevel logger.Level
+}
+
+// Server is the Zarf API server.
+type Server struct {
+	opts Options
+	jobs *jobManager
+	ops  operations
+}
+
+// New returns a server that runs operations with the Zarf packager.
+func New(opts Options) (*Server, error) {
+	if opts.Listen == "" {
+		return nil, errors.New("listen address is required")
+	}
+	if opts.Token == "" {
+		return nil, errors.New("an API token is required")
+	}
+	if (opts.TLSCertPath == "") != (opts.TLSKeyPath == "") {
+		return nil, errors.New("both a TLS certificate and key are required to serve over TLS")
+	}
+	return &Server{
+		opts: opts,
+		jobs: newJobManager(defaultQueueSize, defaultRetainedJobs, opts.LogLevel),
+		ops:  packagerOperations{},
+	}, nil
+}
+
+// Handler returns the handler of the REST and gRPC APIs.
+func (s *Server) Handler() http.Handler {
+	api := http.NewServeMux()
+	api.HandleFunc("POST /v1/packages/create", s.handleCreate)
+	api.HandleFunc("POST /v1/packages/deploy", s.handleDeploy)
+	api.HandleFunc("POST /v1/packages/inspect", s.handleInspect)
+	api.HandleFunc("POST /v1/packages/remove", s.handleRemove)
+	api.HandleFunc("GET /v1/jobs", s.handleListJobs)
+	api.HandleFunc("GET /v1/jobs/{id}", s.handleGetJob)
+	api.HandleFunc("GET /v1/jobs/{id}/logs", s.handleJobStream(func(j *job) *stream { return j.logs }))
+	api.HandleFunc("GET /v1/jobs/{id}/events", s.handleJobStream(func(j *job) *stream { return j.events }))
+
+	mux := http.NewServeMux()
+	mux.Handle("/healthz", http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		//nolint: errcheck // ignore
+		w.Write([]byte("ok"))
+	}))
+	mux.Handle("/v1/", s.authenticate(api))
+
+	// gRPC calls are served on the same address, over TLS or cleartext HTTP/2.
+	grpcSrv := s.grpcServer()
+	return h2c.NewHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.ProtoMajor == 2 && strings.HasPrefix(r.Header.Get("Content-Type"), "application/grpc") {
+			grpcSrv.ServeHTTP(w, r)
+			return
+		}
+		mux.ServeHTTP(w, r)
+	}), &http2.Server{})
+}
+
+// Start runs the server and its job runner until the context is cancelled.
+func (s *Server) Start(ctx context.Context) error {
+	l := logger.From(ctx)
+	srv := &http.Server{
+		Addr:              s.opts.Listen,
+		Handler:           s.Handler(),
+		ReadHeaderTimeout: 5 * time.Second, // Set ReadHeaderTimeout to avoid Slowloris attacks
+		BaseContext: func(_ net.Listener) context.Context {
+			return ctx
+		},
+	}
+
+	g, gCtx := errgroup.WithContext(ctx)
+	g.Go(func() error {
+		s.jobs.Run(gCtx)
+		return nil
+	})
+	g.Go(func() error {
+		var err error
+		if s.opts.TLSCertPath != "" {
+			err = srv.ListenAndServeTLS(s.opts.TLSCertPath, s.opts.TLSKeyPath)
+		} else {
+			err = srv.ListenAndServe()
+		}
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
+			return err
+		}
+		return nil
+	})
+	g.Go(func() error {
+		<-gCtx.Done()
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		return srv.Shutdown(ctx)
+	})
+	if s.opts.TLSCertPath == "" {
+		l.Warn("serving the API without TLS, the API token is sent in plain text")
+	}
+	l.Info("server running", "listen", s.opts.Listen)
+	return g.Wait()
+}
+
+// authenticate rejects requests that do not present the API token as a bearer token.
+func (s *Server) authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || !s.validToken(token) {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			writeError(w, http.StatusUnauthorized, errors.New("invalid or missing API token"))
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// validToken reports whether token is the API token.
+func (s *Server) validToken(token string) bool {
+	return subtle.ConstantTimeCompare([]byte(token), []byte(s.opts.Token)) == 1
+}
+
+func (s *Server) handleCreate(w http.ResponseWriter, r *http.Request) {
+	var req CreateRequest
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+	s.submit(w, "create", func(ctx context.Context) error {
+		return s.ops.Create(ctx, req)
+	})
+}
+
+func (s *Server) handleDeploy(w http.ResponseWriter, r *http.Request) {
+	var req DeployRequest
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+	s.submit(w, "deploy", func(ctx context.Context) error {
+		return s.ops.Deploy(ctx, req)
+	})
+}
+
+func (s *Server) handleRemove(w http.ResponseWriter, r *http.Request) {
+	var req RemoveRequest
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+	s.submit(w, "remove", func(ctx context.Context) error {
+		return s.ops.Remove(ctx, req)
+	})
+}
+
+// handleInspect runs synchronously as inspecting a package does not change any state. It still waits for the running
+// job, as operations share process wide configuration.
+func (s *Server) handleInspect(w http.ResponseWriter, r *http.Request) {
+	var req InspectRequest
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+	var pkg v1alpha1.ZarfPackage
+	err := s.jobs.Exclusive(r.Context(), func(ctx context.Context) error {
+		var err error
+		pkg, err = s.ops.Inspect(ctx, req)
+		return err
+	})
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, pkg)
+}
+
+func (s *Server) handleListJobs(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, s.jobs.List())
+}
+
+func (s *Server) handleGetJob(w http.ResponseWriter, r *http.Request) {
+	j, ok := s.jobs.Get(r.PathValue("id"))
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Errorf("job %s not found", r.PathValue("id")))
+		return
+	}
+	writeJSON(w, http.StatusOK, j.Job())
+}
+
+// handleJobStream follows a newline delimited JSON stream of the job until the job finishes.
+func (s *Server) handleJobStream(streamOf func(j *job) *stream) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		j, ok := s.jobs.Get(r.PathValue("id"))
+		if !ok {
+			writeError(w, http.StatusNotFound, fmt.Errorf("job %s not found", r.PathValue("id")))
+			return
+		}
+		w.Header().Set("Content-Type", "application/x-ndjson")
+		w.WriteHeader(http.StatusOK)
+		err := streamOf(j).Follow(r.Context(), w)
+		if err != nil && !errors.Is(err, context.Canceled) {
+			logger.From(r.Context()).Debug("job stream ended", "id", r.PathValue("id"), "error", err)
+		}
+	}
+}
+
+func (s *Server) submit(w http.ResponseWriter, operation string, run func(ctx context.Context) error) {
+	j, err := s.jobs.Submit(operation, run)
+	if errors.Is(err, errQueueFull) {
+		writeError(w, http.StatusServiceUnavailable, err)
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	w.Header().Set("Location", "/v1/jobs/"+j.ID)
+	writeJSON(w, http.StatusAccepted, j)
+}
+
+// decodeRequest decodes the JSON request body into v and writes an error response if it is invalid.
+func decodeRequest(w http.ResponseWriter, r *http.Request, v any) bool {
+	dec := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRequestBytes))
+	dec.DisallowUnknownFields()
+	err := dec.Decode(v)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid request body: %w", err))
+		return false
+	}
+	return true
+}
+
+// errorResponse is the body of a failed request.
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, errorResponse{Error: logger.Redact(err.Error())})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	//nolint: errcheck // the response has already started
+	json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,270 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/zarf-dev/zarf/src/api/v1alpha1"
+	"github.com/zarf-dev/zarf/src/pkg/events"
+	"github.com/zarf-dev/zarf/src/pkg/logger"
+)
+
+const testToken = "test-token"
+
+type fakeOperations struct{}
+
+func (fakeOperations) Create(ctx context.Context, req CreateRequest) error {
+	logger.From(ctx).Info("creating package", "path", req.Path)
+	events.From(ctx).Phase("create", events.StatusSucceeded, nil)
+	return nil
+}
+
+func (fakeOperations) Deploy(_ context.Context, req DeployRequest) error {
+	return errors.New("unable to deploy " + req.Source)
+}
+
+func (fakeOperations) Inspect(_ context.Context, req InspectRequest) (v1alpha1.ZarfPackage, error) {
+	return v1alpha1.ZarfPackage{Metadata: v1alpha1.ZarfMetadata{Name: req.Source}}, nil
+}
+
+func (fakeOperations) Remove(_ context.Context, _ RemoveRequest) error {
+	panic("remove failed")
+}
+
+func newTestServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	s, err := New(Options{Listen: ":0", Token: testToken, LogLevel: logger.Info})
+	require.NoError(t, err)
+	s.ops = fakeOperations{}
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	go s.jobs.Run(ctx)
+	ts := httptest.NewServer(s.Handler())
+	t.Cleanup(ts.Close)
+	return ts
+}
+
+func doRequest(t *testing.T, method, url, body string) *http.Response {
+	t.Helper()
+
+	req, err := http.NewRequest(method, url, strings.NewReader(body))
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer "+testToken)
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, resp.Body.Close())
+	})
+	return resp
+}
+
+func submitJob(t *testing.T, ts *httptest.Server, path, body string) Job {
+	t.Helper()
+
+	resp := doRequest(t, http.MethodPost, ts.URL+path, body)
+	require.Equal(t, http.StatusAccepted, resp.StatusCode)
+	var j Job
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&j))
+	require.Equal(t, "/v1/jobs/"+j.ID, resp.Header.Get("Location"))
+	return j
+}
+
+// waitForJob follows the job logs until the job finishes and returns the logs and final job state.
+func waitForJob(t *testing.T, ts *httptest.Server, id string) (string, Job) {
+	t.Helper()
+
+	resp := doRequest(t, http.MethodGet, ts.URL+"/v1/jobs/"+id+"/logs", "")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	logs, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	resp = doRequest(t, http.MethodGet, ts.URL+"/v1/jobs/"+id, "")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var j Job
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&j))
+	return string(logs), j
+}
+
+func TestNew(t *testing.T) {
+	t.Parallel()
+
+	_, err := New(Options{Listen: ":8443"})
+	require.EqualError(t, err, "an API token is required")
+	_, err = New(Options{Listen: ":8443", Token: testToken, TLSCertPath: "tls.crt"})
+	require.EqualError(t, err, "both a TLS certificate and key are required to serve over TLS")
+}
+
+func TestAuthentication(t *testing.T) {
+	t.Parallel()
+	ts := newTestServer(t)
+
+	resp, err := http.Get(ts.URL + "/healthz")
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	for _, header := range []string{"", "Bearer wrong-token", testToken} {
+		req, err := http.NewRequest(http.MethodGet, ts.URL+"/v1/jobs", nil)
+		require.NoError(t, err)
+		req.Header.Set("Authorization", header)
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+		require.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	}
+}
+
+func TestJobs(t *testing.T) {
+	t.Parallel()
+	ts := newTestServer(t)
+
+	created := submitJob(t, ts, "/v1/packages/create", `{"path": "examples/dos-games", "output": "build"}`)
+	require.Equal(t, "create", created.Operation)
+	logs, j := waitForJob(t, ts, created.ID)
+	require.Equal(t, JobSucceeded, j.Status)
+	require.NotNil(t, j.StartedAt)
+	require.NotNil(t, j.FinishedAt)
+	require.Contains(t, logs, `"msg":"creating package"`)
+	require.Contains(t, logs, `"path":"examples/dos-games"`)
+
+	resp := doRequest(t, http.MethodGet, ts.URL+"/v1/jobs/"+created.ID+"/events", "")
+	var ev events.Event
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&ev))
+	require.Equal(t, events.TypePhase, ev.Type)
+	require.Equal(t, "create", ev.Phase)
+
+	deployed := submitJob(t, ts, "/v1/packages/deploy", `{"source": "oci://ghcr.io/zarf-dev/packages/dos-games:1.1.0"}`)
+	_, j = waitForJob(t, ts, deployed.ID)
+	require.Equal(t, JobFailed, j.Status)
+	require.Equal(t, "unable to deploy oci://ghcr.io/zarf-dev/packages/dos-games:1.1.0", j.Error)
+
+	removed := submitJob(t, ts, "/v1/packages/remove", `{"source": "dos-games"}`)
+	_, j = waitForJob(t, ts, removed.ID)
+	require.Equal(t, JobFailed, j.Status)
+	require.Equal(t, "job panicked: remove failed", j.Error)
+
+	resp = doRequest(t, http.MethodGet, ts.URL+"/v1/jobs", "")
+	var jobs []Job
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&jobs))
+	require.Len(t, jobs, 3)
+	require.Equal(t, []string{created.ID, deployed.ID, removed.ID}, []string{jobs[0].ID, jobs[1].ID, jobs[2].ID})
+}
+
+func TestRequests(t *testing.T) {
+	t.Parallel()
+	ts := newTestServer(t)
+
+	tests := []struct {
+		name           string
+		method         string
+		path           string
+		body           string
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:           "inspect",
+			method:         http.MethodPost,
+			path:           "/v1/packages/inspect",
+			body:           `{"source": "dos-games"}`,
+			expectedStatus: http.StatusOK,
+			expectedBody:   `"name":"dos-games"`,
+		},
+		{
+			name:           "unknown field",
+			method:         http.MethodPost,
+			path:           "/v1/packages/deploy",
+			body:           `{"package": "dos-games"}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `unknown field \"package\"`,
+		},
+		{
+			name:           "unknown job",
+			method:         http.MethodGet,
+			path:           "/v1/jobs/missing",
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   `{"error":"job missing not found"}`,
+		},
+		{
+			name:           "wrong method",
+			method:         http.MethodGet,
+			path:           "/v1/packages/deploy",
+			expectedStatus: http.StatusMethodNotAllowed,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			resp := doRequest(t, tt.method, ts.URL+tt.path, tt.body)
+			require.Equal(t, tt.expectedStatus, resp.StatusCode)
+			b, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+			require.Contains(t, string(b), tt.expectedBody)
+		})
+	}
+}
+
+func TestJobRetention(t *testing.T) {
+	t.Parallel()
+
+	m := newJobManager(10, 1, logger.Info)
+	ids := []string{}
+	for range 3 {
+		j, err := m.Submit("create", func(_ context.Context) error { return nil })
+		require.NoError(t, err)
+		ids = append(ids, j.ID)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	go m.Run(ctx)
+
+	require.Eventually(t, func() bool {
+		jobs := m.List()
+		return len(jobs) == 1 && jobs[0].Status == JobSucceeded
+	}, 5*time.Second, 10*time.Millisecond)
+	_, ok := m.Get(ids[0])
+	require.False(t, ok)
+	_, ok = m.Get(ids[2])
+	require.True(t, ok)
+}
+
+func TestExclusive(t *testing.T) {
+	t.Parallel()
+
+	m := newJobManager(10, 10, logger.Info)
+	started := make(chan struct{})
+	release := make(chan struct{})
+	_, err := m.Submit("deploy", func(_ context.Context) error {
+		close(started)
+		<-release
+		return nil
+	})
+	require.NoError(t, err)
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	go m.Run(ctx)
+	<-started
+
+	// Operations outside of the queue wait for the running job.
+	waitCtx, waitCancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer waitCancel()
+	err = m.Exclusive(waitCtx, func(_ context.Context) error { return nil })
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	close(release)
+	err = m.Exclusive(context.Background(), func(_ context.Context) error { return nil })
+	require.NoError(t, err)
+}
@@ -0,0 +1,142 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package toolversion runs alternate versions of the tools embedded in Zarf from binaries in the Zarf cache.
+package toolversion
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/defenseunicorns/pkg/helpers/v2"
+
+	"github.com/zarf-dev/zarf/src/internal/artifact"
+	"github.com/zarf-dev/zarf/src/pkg/logger"
+)
+
+// CacheDir is the directory of the Zarf cache tool binaries are stored in.
+const CacheDir = "tools"
+
+// Options select the binary of a tool.
+type Options struct {
+	// Tool is the name of the tool, e.g. helm or kubectl.
+	Tool string
+	// Version of the tool, with or without a leading v.
+	Version string
+	// Digest is the SHA256 of the binary, with or without a leading sha256:. It is required to pull a binary and the
+	// cached binary is verified against it when it is set.
+	Digest string
+	// CachePath is the absolute path of the Zarf cache.
+	CachePath string
+	// Repository is the OCI repository binaries that are not cached are pulled from, e.g. oci://registry.example.com/zarf-tools.
+	Repository string
+	Registry   artifact.RegistryOptions
+}
+
+// NormalizeVersion returns the version without a leading v.
+func NormalizeVersion(version string) string {
+	return strings.TrimPrefix(version, "v")
+}
+
+// Reference returns the reference of the artifact containing the binary of the tool for the given platform.
+// Binaries are stored in a repository named after the tool and tagged with their version and platform,
+// e.g. registry.example.com/zarf-tools/helm:3.14.4-linux-amd64.
+func Reference(repository, tool, version, goos, goarch string) string {
+	repository = strings.TrimSuffix(repository, "/")
+	return fmt.Sprintf("%s/%s:%s-%s-%s", repository, tool, NormalizeVersion(version), goos, goarch)
+}
+
+// BinaryName returns the file name of the binary of the tool for the given OS.
+func BinaryName(tool, goos string) string {
+	if goos == "windows" {
+		return tool + ".exe"
+	}
+	return tool
+}
+
+// CachedPath returns the path the binary of the tool is cached at for the current platform.
+func CachedPath(opts Options) string {
+	return filepath.Join(opts.CachePath, CacheDir, opts.Tool, NormalizeVersion(opts.Version),
+		fmt.Sprintf("%s-%s", runtime.GOOS, runtime.GOARCH), BinaryName(opts.Tool, runtime.GOOS))
+}
+
+// Binary returns the path of the cached binary of the tool, pulling it from the repository when it is not cached.
+func Binary(ctx context.Context, opts Options) (string, error) {
+	if opts.Tool == "" || NormalizeVersion(opts.Version) == "" {
+		return "", errors.New("a tool and version are required")
+	}
+	digest := strings.TrimPrefix(opts.Digest, "sha256:")
+	binPath := CachedPath(opts)
+	if !helpers.InvalidPath(binPath) {
+		if digest != "" {
+			if err := helpers.SHAsMatch(binPath, digest); err != nil {
+				return "", err
+			}
+		}
+		return binPath, nil
+	}
+	if opts.Repository == "" {
+		return "", fmt.Errorf("%s %s is not in the Zarf cache at %s and no repository to pull it from was given", opts.Tool, NormalizeVersion(opts.Version), binPath)
+	}
+	if digest == "" {
+		return "", fmt.Errorf("%s %s is not in the Zarf cache at %s and can only be pulled when its digest is given", opts.Tool, NormalizeVersion(opts.Version), binPath)
+	}
+
+	ref := Reference(opts.Repository, opts.Tool, opts.Version, runtime.GOOS, runtime.GOARCH)
+	logger.From(ctx).Info("pulling tool binary into the Zarf cache", "tool", opts.Tool, "version", NormalizeVersion(opts.Version), "reference", ref)
+	// The binary is pulled next to its cached path and only moved there once it is verified, so that an interrupted or
+	// tampered pull is never run.
+	err := helpers.CreateDirectory(filepath.Dir(binPath), helpers.ReadWriteExecuteUser)
+	if err != nil {
+		return "", err
+	}
+	tmpDir, err := os.MkdirTemp(filepath.Dir(binPath), ".pull-")
+	if err != nil {
+		return "", err
+	}
+	defer os.RemoveAll(tmpDir)
+	_, err = artifact.Pull(ctx, ref, tmpDir, opts.Registry)
+	if err != nil {
+		return "", err
+	}
+	tmpPath := filepath.Join(tmpDir, filepath.Base(binPath))
+	if helpers.InvalidPath(tmpPath) {
+		return "", fmt.Errorf("artifact %s does not contain a %s binary", ref, filepath.Base(binPath))
+	}
+	err = helpers.SHAsMatch(tmpPath, digest)
+	if err != nil {
+		return "", err
+	}
+	err = os.Chmod(tmpPath, helpers.ReadWriteExecuteUser)
+	if err != nil {
+		return "", err
+	}
+	err = os.Rename(tmpPath, binPath)
+	if err != nil {
+		return "", err
+	}
+	return binPath, nil
+}
+
+// Run runs the binary with the given arguments attached to the standard streams of Zarf and returns its exit code.
+func Run(ctx context.Context, binPath string, args []string) (int, error) {
+	cmd := exec.CommandContext(ctx, binPath, args...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	err := cmd.Run()
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return exitErr.ExitCode(), nil
+	}
+	if err != nil {
+		return 1, err
+	}
+	return 0, nil
+}
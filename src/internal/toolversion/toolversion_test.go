@@ -0,0 +1,122 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package toolversion
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/defenseunicorns/pkg/helpers/v2"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zarf-dev/zarf/src/internal/artifact"
+	"github.com/zarf-dev/zarf/src/test/testutil"
+)
+
+func TestReference(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		repository string
+		version    string
+		expected   string
+	}{
+		{
+			name:       "version",
+			repository: "oci://registry.example.com/zarf-tools",
+			version:    "3.14.4",
+			expected:   "oci://registry.example.com/zarf-tools/helm:3.14.4-linux-arm64",
+		},
+		{
+			name:       "prefixed version and trailing slash",
+			repository: "registry.example.com/zarf-tools/",
+			version:    "v3.14.4",
+			expected:   "registry.example.com/zarf-tools/helm:3.14.4-linux-arm64",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			require.Equal(t, tt.expected, Reference(tt.repository, "helm", tt.version, "linux", "arm64"))
+		})
+	}
+}
+
+func TestBinary(t *testing.T) {
+	t.Parallel()
+	ctx := testutil.TestContext(t)
+
+	port, err := helpers.GetAvailablePort()
+	require.NoError(t, err)
+	registryURL := testutil.SetupInMemoryRegistry(ctx, t, port)
+	registryOpts := artifact.RegistryOptions{PlainHTTP: true}
+	repository := fmt.Sprintf("oci://%s/zarf-tools", registryURL)
+
+	srcPath := filepath.Join(t.TempDir(), BinaryName("helm", runtime.GOOS))
+	require.NoError(t, os.WriteFile(srcPath, []byte("#!/bin/sh\n"), 0o600))
+	digest, err := helpers.GetSHA256OfFile(srcPath)
+	require.NoError(t, err)
+	ref := Reference(repository, "helm", "3.14.4", runtime.GOOS, runtime.GOARCH)
+	_, err = artifact.Push(ctx, ref, []artifact.File{{Path: srcPath, MediaType: artifact.DefaultFileMediaType}}, artifact.PushOptions{Registry: registryOpts})
+	require.NoError(t, err)
+
+	t.Run("not cached without repository", func(t *testing.T) {
+		t.Parallel()
+		opts := Options{Tool: "helm", Version: "3.14.4", CachePath: t.TempDir()}
+		_, err := Binary(ctx, opts)
+		require.ErrorContains(t, err, "helm 3.14.4 is not in the Zarf cache")
+	})
+
+	t.Run("not cached without digest", func(t *testing.T) {
+		t.Parallel()
+		opts := Options{Tool: "helm", Version: "3.14.4", CachePath: t.TempDir(), Repository: repository, Registry: registryOpts}
+		_, err := Binary(ctx, opts)
+		require.ErrorContains(t, err, "can only be pulled when its digest is given")
+	})
+
+	t.Run("mismatched digest", func(t *testing.T) {
+		t.Parallel()
+		opts := Options{Tool: "helm", Version: "3.14.4", Digest: "sha256:60303ae22b998861bce3b28f33eec1be758a213c86c93c076dbe9f558c11c752", CachePath: t.TempDir(), Repository: repository, Registry: registryOpts}
+		_, err := Binary(ctx, opts)
+		require.ErrorContains(t, err, "expected sha256")
+		// The binary that failed verification is not cached.
+		require.NoFileExists(t, CachedPath(opts))
+		entries, err := os.ReadDir(filepath.Dir(CachedPath(opts)))
+		require.NoError(t, err)
+		require.Empty(t, entries)
+	})
+
+	t.Run("pulled and cached", func(t *testing.T) {
+		t.Parallel()
+		opts := Options{Tool: "helm", Version: "v3.14.4", Digest: "sha256:" + digest, CachePath: t.TempDir(), Repository: repository, Registry: registryOpts}
+		binPath, err := Binary(ctx, opts)
+		require.NoError(t, err)
+		require.Equal(t, CachedPath(opts), binPath)
+		fi, err := os.Stat(binPath)
+		require.NoError(t, err)
+		require.Equal(t, os.FileMode(helpers.ReadWriteExecuteUser), fi.Mode().Perm())
+
+		// The cached binary is used without the repository.
+		opts.Repository = ""
+		cachedPath, err := Binary(ctx, opts)
+		require.NoError(t, err)
+		require.Equal(t, binPath, cachedPath)
+
+		// The cached binary is verified against the digest.
+		opts.Digest = "60303ae22b998861bce3b28f33eec1be758a213c86c93c076dbe9f558c11c752"
+		_, err = Binary(ctx, opts)
+		require.ErrorContains(t, err, "expected sha256")
+	})
+
+	t.Run("missing version", func(t *testing.T) {
+		t.Parallel()
+		opts := Options{Tool: "helm", Version: "3.13.0", Digest: digest, CachePath: t.TempDir(), Repository: repository, Registry: registryOpts}
+		_, err := Binary(ctx, opts)
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,75 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package tracing
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// The tests in this file modify the global tracer provider and can not run in parallel.
+
+func TestStartEnd(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(provider)
+	t.Cleanup(func() {
+		otel.SetTracerProvider(previous)
+	})
+
+	ctx, parent := Start(context.Background(), "parent")
+	_, child := Start(ctx, "child", attribute.String("component", "podinfo"))
+	End(child, errors.New("install failed"))
+	End(parent, nil)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+	require.Equal(t, "child", spans[0].Name())
+	require.Equal(t, spans[1].SpanContext().SpanID(), spans[0].Parent().SpanID())
+	require.Equal(t, codes.Error, spans[0].Status().Code)
+	require.Equal(t, "install failed", spans[0].Status().Description)
+	require.Contains(t, spans[0].Attributes(), attribute.String("component", "podinfo"))
+	require.Equal(t, codes.Unset, spans[1].Status().Code)
+}
+
+func TestSetup(t *testing.T) {
+	ctx := context.Background()
+	previous := otel.GetTracerProvider()
+	t.Cleanup(func() {
+		otel.SetTracerProvider(previous)
+	})
+
+	shutdown, err := Setup(ctx, "")
+	require.NoError(t, err)
+	require.NoError(t, shutdown(ctx))
+	require.Equal(t, previous, otel.GetTracerProvider())
+
+	var requests atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v1/traces" {
+			requests.Add(1)
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(srv.Close)
+
+	shutdown, err = Setup(ctx, srv.URL+"/v1/traces")
+	require.NoError(t, err)
+	_, span := Start(ctx, "create")
+	End(span, nil)
+	require.NoError(t, shutdown(ctx))
+	require.Equal(t, int32(1), requests.Load())
+}
@@ -0,0 +1,565 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package tui contains the full-screen terminal UIs of Zarf.
+package tui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/progress"
+	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/zarf-dev/zarf/src/api/v1alpha1"
+	"github.com/zarf-dev/zarf/src/pkg/events"
+	"github.com/zarf-dev/zarf/src/pkg/interactive"
+	"github.com/zarf-dev/zarf/src/pkg/logger"
+)
+
+// ErrCancelled is returned when the user quits before the deploy starts.
+var ErrCancelled = errors.New("deployment cancelled")
+
+// maxLogLines is the number of log lines kept for the logs pane.
+const maxLogLines = 500
+
+// DeployFunc deploys the package with the selected components and variables.
+// The context carries the logger and event emitter that feed the UI.
+type DeployFunc func(ctx context.Context, components string, variables map[string]string) error
+
+// DeployOptions configures the deploy UI.
+type DeployOptions struct {
+	// Package is the definition of the package being deployed.
+	Package v1alpha1.ZarfPackage
+	// Components are the optional components requested with --components, if empty the defaults are selected.
+	Components string
+	// Variables are the variables already set, they are not prompted for.
+	Variables map[string]string
+	// LogLevel is the level of the logs shown in the logs pane.
+	LogLevel logger.Level
+	// Deploy runs the deployment.
+	Deploy DeployFunc
+}
+
+type deployState int
+
+const (
+	stateSelect deployState = iota
+	stateVariables
+	stateDeploying
+	stateConfirm
+	stateDone
+)
+
+type componentItem struct {
+	name        string
+	description string
+	// group is the name of the group of mutually exclusive components the component is in.
+	group    string
+	required bool
+	selected bool
+	status   events.Status
+}
+
+type imageProgress struct {
+	name      string
+	completed int64
+	total     int64
+}
+
+type logMsg string
+
+type eventMsg events.Event
+
+type deployDoneMsg struct {
+	err error
+}
+
+// confirmMsg asks the user to confirm the deployment once the package is loaded, the answer is sent on reply.
+type confirmMsg struct {
+	request interactive.ConfirmRequest
+	reply   chan bool
+}
+
+type deployModel struct {
+	ctx   context.Context
+	opts  DeployOptions
+	state deployState
+
+	components []componentItem
+	cursor     int
+
+	prompts   []v1alpha1.InteractiveVariable
+	prompt    int
+	input     textinput.Model
+	variables map[string]string
+
+	confirm *confirmMsg
+
+	updates chan tea.Msg
+	done    chan struct{}
+	result  chan error
+	logs    []string
+	image   *imageProgress
+	phase   string
+	spinner spinner.Model
+	bar     progress.Model
+	err     error
+
+	width  int
+	height int
+}
+
+var (
+	titleStyle    = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("12"))
+	helpStyle     = lipgloss.NewStyle().Foreground(lipgloss.Color("8"))
+	paneStyle     = lipgloss.NewStyle().Border(lipgloss.RoundedBorder()).BorderForeground(lipgloss.Color("8")).Padding(0, 1)
+	successStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("10"))
+	failureStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("9"))
+	selectedStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("14"))
+)
+
+func newDeployModel(ctx context.Context, opts DeployOptions) *deployModel {
+	requested := []string{}
+	if opts.Components != "" {
+		requested = strings.Split(opts.Components, ",")
+	}
+	components := []componentItem{}
+	for _, c := range opts.Package.Components {
+		// Components for other operating systems are filtered out of the deploy.
+		if c.Only.LocalOS != "" && c.Only.LocalOS != runtime.GOOS {
+			continue
+		}
+		selected := c.IsRequired()
+		if !selected && len(requested) > 0 {
+			selected = slices.Contains(requested, c.Name)
+		} else if !selected {
+			selected = c.Default
+		}
+		group := c.DeprecatedGroup
+		if g, ok := opts.Package.ComponentGroup(c.Name); ok {
+			group = g.Name
+		}
+		components = append(components, componentItem{
+			name:        c.Name,
+			description: c.Description,
+			group:       group,
+			required:    c.IsRequired(),
+			selected:    selected,
+		})
+	}
+
+	variables := map[string]string{}
+	for k, v := range opts.Variables {
+		variables[strings.ToUpper(k)] = v
+	}
+	prompts := []v1alpha1.InteractiveVariable{}
+	for _, v := range opts.Package.Variables {
+		if _, ok := variables[v.Name]; v.Prompt && !ok {
+			prompts = append(prompts, v)
+		}
+	}
+
+	return &deployModel{
+		ctx:        ctx,
+		opts:       opts,
+		components: components,
+		prompts:    prompts,
+		input:      textinput.New(),
+		variables:  variables,
+		updates:    make(chan tea.Msg, 256),
+		done:       make(chan struct{}),
+		result:     make(chan error, 1),
+		spinner:    spinner.New(spinner.WithSpinner(spinner.Dot)),
+		bar:        progress.New(progress.WithDefaultGradient(), progress.WithWidth(40)),
+	}
+}
+
+// RunDeploy runs the full-screen deploy UI until the deploy finishes and the user exits.
+func RunDeploy(ctx context.Context, opts DeployOptions) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	m := newDeployModel(ctx, opts)
+	// The package warnings are confirmed in the UI instead of the terminal prompt.
+	interactive.SetConfirmer(m)
+	defer interactive.SetConfirmer(nil)
+	_, err := tea.NewProgram(m, tea.WithAltScreen(), tea.WithContext(ctx)).Run()
+	// The UI has exited, stop forwarding updates so that the deployment does not block once the buffer is full.
+	close(m.done)
+	if err != nil && !errors.Is(err, tea.ErrProgramKilled) {
+		return err
+	}
+	if m.state < stateDeploying {
+		return ErrCancelled
+	}
+	// The user may quit while the deployment is running, stop it and wait for it to return.
+	cancel()
+	return <-m.result
+}
+
+// selectedComponents returns the component request that deploys exactly the selected components.
+func (m *deployModel) selectedComponents() string {
+	requested := []string{}
+	for _, c := range m.components {
+		if c.required {
+			continue
+		}
+		if c.selected {
+			requested = append(requested, c.name)
+			continue
+		}
+		// Explicitly exclude deselected components so that defaults are not deployed.
+		requested = append(requested, "-"+c.name)
+	}
+	return strings.Join(requested, ",")
+}
+
+// toggleComponent toggles the selection of a component, deselecting the other components in its group.
+func (m *deployModel) toggleComponent(idx int) {
+	c := &m.components[idx]
+	c.selected = !c.selected
+	if !c.selected || c.group == "" {
+		return
+	}
+	for i := range m.components {
+		if i != idx && m.components[i].group == c.group {
+			m.components[i].selected = false
+		}
+	}
+}
+
+func (m *deployModel) Init() tea.Cmd {
+	return tea.Batch(m.spinner.Tick, m.waitForUpdate())
+}
+
+// waitForUpdate returns the next log line, event or result from the running deployment.
+func (m *deployModel) waitForUpdate() tea.Cmd {
+	return func() tea.Msg {
+		select {
+		case msg := <-m.updates:
+			return msg
+		case <-m.done:
+			return nil
+		}
+	}
+}
+
+// send forwards a message from the deployment to the UI, dropping it if the UI has exited.
+func (m *deployModel) send(msg tea.Msg) {
+	select {
+	case m.updates <- msg:
+	case <-m.done:
+	}
+}
+
+// Confirm asks the user to confirm the deployment in the UI, it implements interactive.Confirmer.
+func (m *deployModel) Confirm(ctx context.Context, request interactive.ConfirmRequest) (bool, error) {
+	reply := make(chan bool, 1)
+	m.send(&confirmMsg{request: request, reply: reply})
+	select {
+	case confirmed := <-reply:
+		return confirmed, nil
+	case <-m.done:
+		return false, ErrCancelled
+	case <-ctx.Done():
+		return false, ctx.Err()
+	}
+}
+
+func (m *deployModel) startDeploy() tea.Cmd {
+	m.state = stateDeploying
+	components := m.selectedComponents()
+	return func() tea.Msg {
+		ctx := m.ctx
+		l, err := logger.New(logger.Config{
+			Level:       m.opts.LogLevel,
+			Format:      logger.FormatConsole,
+			Destination: &lineWriter{send: func(line string) { m.send(logMsg(line)) }},
+			Color:       false,
+		})
+		if err != nil {
+			m.result <- err
+			return deployDoneMsg{err: err}
+		}
+		ctx = logger.WithLoggingEnabled(logger.WithContext(ctx, l), true)
+		ctx = events.WithContext(ctx, events.NewEmitter(&eventWriter{send: func(ev events.Event) { m.send(eventMsg(ev)) }}))
+		err = m.opts.Deploy(ctx, components, m.variables)
+		m.result <- err
+		m.send(deployDoneMsg{err: err})
+		return nil
+	}
+}
+
+func (m *deployModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		return m, nil
+	case spinner.TickMsg:
+		var cmd tea.Cmd
+		m.spinner, cmd = m.spinner.Update(msg)
+		return m, cmd
+	case logMsg:
+		m.logs = append(m.logs, string(msg))
+		if len(m.logs) > maxLogLines {
+			m.logs = m.logs[len(m.logs)-maxLogLines:]
+		}
+		return m, m.waitForUpdate()
+	case eventMsg:
+		m.handleEvent(events.Event(msg))
+		return m, m.waitForUpdate()
+	case *confirmMsg:
+		m.state = stateConfirm
+		m.confirm = msg
+		return m, m.waitForUpdate()
+	case deployDoneMsg:
+		m.state = stateDone
+		m.err = msg.err
+		m.image = nil
+		return m, nil
+	case tea.KeyMsg:
+		return m.handleKey(msg)
+	}
+	return m, nil
+}
+
+func (m *deployModel) handleEvent(ev events.Event) {
+	switch ev.Type {
+	case events.TypePhase:
+		m.phase = ev.Phase
+	case events.TypeComponent:
+		for i := range m.components {
+			if m.components[i].name == ev.Component {
+				m.components[i].status = ev.Status
+			}
+		}
+	case events.TypeImage:
+		if ev.Status == events.StatusSucceeded || ev.Status == events.StatusFailed {
+			m.image = nil
+			return
+		}
+		m.image = &imageProgress{name: ev.Image, completed: ev.Completed, total: ev.Total}
+	}
+}
+
+func (m *deployModel) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if msg.Type == tea.KeyCtrlC {
+		return m, tea.Quit
+	}
+	switch m.state {
+	case stateSelect:
+		switch msg.String() {
+		case "q", "esc":
+			return m, tea.Quit
+		case "up", "k":
+			if m.cursor > 0 {
+				m.cursor--
+			}
+		case "down", "j":
+			if m.cursor < len(m.components)-1 {
+				m.cursor++
+			}
+		case " ":
+			if len(m.components) > 0 && !m.components[m.cursor].required {
+				m.toggleComponent(m.cursor)
+			}
+		case "enter":
+			if len(m.prompts) > 0 {
+				m.state = stateVariables
+				m.setPrompt(0)
+				return m, textinput.Blink
+			}
+			return m, m.startDeploy()
+		}
+		return m, nil
+	case stateVariables:
+		switch msg.Type {
+		case tea.KeyEsc:
+			return m, tea.Quit
+		case tea.KeyEnter:
+			m.variables[m.prompts[m.prompt].Name] = m.input.Value()
+			if m.prompt < len(m.prompts)-1 {
+				m.setPrompt(m.prompt + 1)
+				return m, nil
+			}
+			return m, m.startDeploy()
+		}
+		var cmd tea.Cmd
+		m.input, cmd = m.input.Update(msg)
+		return m, cmd
+	case stateConfirm:
+		switch msg.String() {
+		case "y", "enter":
+			m.answerConfirm(true)
+		case "n", "q", "esc":
+			m.answerConfirm(false)
+		}
+		return m, nil
+	case stateDone:
+		if msg.String() == "q" || msg.Type == tea.KeyEnter || msg.Type == tea.KeyEsc {
+			return m, tea.Quit
+		}
+	}
+	return m, nil
+}
+
+// answerConfirm answers the pending confirmation and returns to the deployment view.
+func (m *deployModel) answerConfirm(confirmed bool) {
+	m.confirm.reply <- confirmed
+	m.confirm = nil
+	m.state = stateDeploying
+}
+
+func (m *deployModel) setPrompt(i int) {
+	m.prompt = i
+	v := m.prompts[i]
+	m.input.Reset()
+	m.input.SetValue(v.Default)
+	m.input.Placeholder = v.Default
+	m.input.EchoMode = textinput.EchoNormal
+	if v.Sensitive {
+		m.input.EchoMode = textinput.EchoPassword
+	}
+	m.input.Focus()
+}
+
+func (m *deployModel) View() string {
+	header := titleStyle.Render(fmt.Sprintf("📦 %s %s", m.opts.Package.Metadata.Name, m.opts.Package.Metadata.Version))
+	switch m.state {
+	case stateSelect:
+		return lipgloss.JoinVertical(lipgloss.Left, header, "", m.selectView(), "",
+			helpStyle.Render("↑/↓ move • space toggle • enter deploy • q quit"))
+	case stateVariables:
+		v := m.prompts[m.prompt]
+		lines := []string{header, "", fmt.Sprintf("Variable %d of %d: %s", m.prompt+1, len(m.prompts), selectedStyle.Render(v.Name))}
+		if v.Description != "" {
+			lines = append(lines, helpStyle.Render(v.Description))
+		}
+		lines = append(lines, "", m.input.View(), "", helpStyle.Render("enter next • esc quit"))
+		return lipgloss.JoinVertical(lipgloss.Left, lines...)
+	case stateConfirm:
+		return lipgloss.JoinVertical(lipgloss.Left, header, "", m.confirmView(), "",
+			helpStyle.Render("y deploy • n cancel"))
+	}
+	return m.deployView(header)
+}
+
+func (m *deployModel) selectView() string {
+	lines := []string{}
+	for i, c := range m.components {
+		cursor := "  "
+		if i == m.cursor {
+			cursor = selectedStyle.Render("> ")
+		}
+		check := "[ ]"
+		if c.selected {
+			check = "[x]"
+		}
+		name := c.name
+		if c.required {
+			name += helpStyle.Render(" (required)")
+		}
+		if c.group != "" {
+			name += helpStyle.Render(fmt.Sprintf(" (one of %s)", c.group))
+		}
+		line := fmt.Sprintf("%s%s %s", cursor, check, name)
+		if c.description != "" {
+			line += helpStyle.Render(" - " + strings.TrimSpace(c.description))
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (m *deployModel) confirmView() string {
+	request := m.confirm.request
+	lines := []string{}
+	if diff := request.Diff; diff != nil && !diff.IsEmpty() {
+		lines = append(lines, titleStyle.Render("Deployed Package Changes"))
+		if diff.FromVersion != diff.ToVersion {
+			lines = append(lines, fmt.Sprintf("Version: %s -> %s", diff.FromVersion, diff.ToVersion))
+		}
+		if len(diff.AddedComponents) > 0 {
+			lines = append(lines, "Added components: "+strings.Join(diff.AddedComponents, ", "))
+		}
+		if len(diff.RemovedComponents) > 0 {
+			lines = append(lines, "Removed components: "+strings.Join(diff.RemovedComponents, ", "))
+		}
+		lines = append(lines, "")
+	}
+	if len(request.SBOMFiles) > 0 {
+		lines = append(lines, helpStyle.Render(fmt.Sprintf("%d SBOM artifacts are available for review with zarf package inspect", len(request.SBOMFiles))), "")
+	}
+	if request.ReleaseNotes != "" {
+		lines = append(lines, titleStyle.Render("Release Notes"), strings.TrimSpace(request.ReleaseNotes), "")
+	}
+	if len(request.Warnings) > 0 {
+		lines = append(lines, titleStyle.Render("Package Warnings"))
+		for _, warning := range request.Warnings {
+			lines = append(lines, failureStyle.Render("! ")+warning)
+		}
+		lines = append(lines, "")
+	}
+	lines = append(lines, fmt.Sprintf("%s this Zarf package?", request.Stage))
+	return strings.Join(lines, "\n")
+}
+
+func (m *deployModel) deployView(header string) string {
+	tree := []string{titleStyle.Render("Components")}
+	for _, c := range m.components {
+		if !c.selected {
+			continue
+		}
+		icon := helpStyle.Render("○")
+		switch c.status {
+		case events.StatusStarted, events.StatusProgress:
+			icon = m.spinner.View()
+		case events.StatusSucceeded:
+			icon = successStyle.Render("✔")
+		case events.StatusFailed:
+			icon = failureStyle.Render("✖")
+		}
+		tree = append(tree, fmt.Sprintf("%s %s", icon, c.name))
+	}
+
+	status := m.spinner.View() + " deploying"
+	if m.phase != "" {
+		status = fmt.Sprintf("%s %s", m.spinner.View(), m.phase)
+	}
+	if m.state == stateDone {
+		status = successStyle.Render("✔ deployment succeeded") + helpStyle.Render(" • press q to exit")
+		if m.err != nil {
+			status = failureStyle.Render("✖ "+logger.Redact(m.err.Error())) + helpStyle.Render(" • press q to exit")
+		}
+	}
+	if m.image != nil && m.image.total > 0 {
+		percent := float64(m.image.completed) / float64(m.image.total)
+		status = lipgloss.JoinVertical(lipgloss.Left, status, m.image.name, m.bar.ViewAs(percent))
+	}
+
+	logHeight := m.height - len(tree) - lipgloss.Height(status) - 8
+	logHeight = max(logHeight, 5)
+	logs := m.logs
+	if len(logs) > logHeight {
+		logs = logs[len(logs)-logHeight:]
+	}
+	logWidth := max(m.width-4, 20)
+	truncated := make([]string, 0, len(logs))
+	for _, line := range logs {
+		if len(line) > logWidth {
+			line = line[:logWidth]
+		}
+		truncated = append(truncated, line)
+	}
+	logPane := paneStyle.Width(logWidth).Height(logHeight).Render(strings.Join(truncated, "\n"))
+
+	return lipgloss.JoinVertical(lipgloss.Left, header, "", strings.Join(tree, "\n"), "", status, logPane)
+}
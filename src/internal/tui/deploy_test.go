@@ -0,0 +1,205 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package tui
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/defenseunicorns/pkg/helpers/v2"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zarf-dev/zarf/src/api/v1alpha1"
+	"github.com/zarf-dev/zarf/src/pkg/events"
+	"github.com/zarf-dev/zarf/src/pkg/interactive"
+	"github.com/zarf-dev/zarf/src/pkg/logger"
+)
+
+func testPackage() v1alpha1.ZarfPackage {
+	return v1alpha1.ZarfPackage{
+		Metadata: v1alpha1.ZarfMetadata{Name: "dos-games", Version: "1.1.0"},
+		Components: []v1alpha1.ZarfComponent{
+			{Name: "baseline", Required: helpers.BoolPtr(true)},
+			{Name: "games", Default: true},
+			{Name: "extras"},
+			{Name: "plan9-only", Only: v1alpha1.ZarfComponentOnlyTarget{LocalOS: "plan9"}},
+		},
+		Variables: []v1alpha1.InteractiveVariable{
+			{Variable: v1alpha1.Variable{Name: "DOMAIN"}, Prompt: true, Default: "zarf.dev"},
+			{Variable: v1alpha1.Variable{Name: "PASSWORD", Sensitive: true}, Prompt: true},
+			{Variable: v1alpha1.Variable{Name: "REPLICAS"}, Prompt: true},
+			{Variable: v1alpha1.Variable{Name: "NOT_PROMPTED"}},
+		},
+	}
+}
+
+func key(s string) tea.KeyMsg {
+	switch s {
+	case "enter":
+		return tea.KeyMsg{Type: tea.KeyEnter}
+	case " ":
+		return tea.KeyMsg{Type: tea.KeySpace, Runes: []rune{' '}}
+	case "down":
+		return tea.KeyMsg{Type: tea.KeyDown}
+	}
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)}
+}
+
+func TestSelectedComponents(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		components string
+		expected   string
+	}{
+		{
+			name:     "defaults",
+			expected: "games,-extras",
+		},
+		{
+			name:       "requested",
+			components: "extras",
+			expected:   "-games,extras",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			m := newDeployModel(context.Background(), DeployOptions{Package: testPackage(), Components: tt.components})
+			require.Len(t, m.components, 3)
+			require.Equal(t, tt.expected, m.selectedComponents())
+		})
+	}
+}
+
+func TestToggleGroupedComponent(t *testing.T) {
+	t.Parallel()
+
+	pkg := v1alpha1.ZarfPackage{
+		Components: []v1alpha1.ZarfComponent{
+			{Name: "nginx", Default: true},
+			{Name: "istio"},
+			{Name: "extras"},
+		},
+		Groups: []v1alpha1.ZarfComponentGroup{
+			{Name: "ingress", Components: []string{"nginx", "istio"}},
+		},
+	}
+	m := newDeployModel(context.Background(), DeployOptions{Package: pkg})
+	require.Equal(t, "ingress", m.components[0].group)
+	require.Equal(t, "nginx,-istio,-extras", m.selectedComponents())
+
+	m.toggleComponent(1)
+	require.Equal(t, "-nginx,istio,-extras", m.selectedComponents())
+	m.toggleComponent(2)
+	require.Equal(t, "-nginx,istio,extras", m.selectedComponents())
+	m.toggleComponent(1)
+	require.Equal(t, "-nginx,-istio,extras", m.selectedComponents())
+}
+
+func TestDeployModel(t *testing.T) {
+	t.Parallel()
+
+	var deployedComponents string
+	var deployedVariables map[string]string
+	m := newDeployModel(context.Background(), DeployOptions{
+		Package:   testPackage(),
+		Variables: map[string]string{"replicas": "3"},
+		LogLevel:  logger.Info,
+		Deploy: func(ctx context.Context, components string, variables map[string]string) error {
+			deployedComponents = components
+			deployedVariables = variables
+			logger.From(ctx).Info("deploying component", "name", "games")
+			events.From(ctx).Component("games", events.StatusStarted, nil)
+			return errors.New("games failed")
+		},
+	})
+	require.Len(t, m.prompts, 2)
+
+	// Toggle the extras component and continue to the variable prompts.
+	m.Update(key("down"))
+	m.Update(key("down"))
+	m.Update(key(" "))
+	// Required components can not be deselected.
+	m.cursor = 0
+	m.Update(key(" "))
+	require.True(t, m.components[0].selected)
+	m.Update(key("enter"))
+	require.Equal(t, stateVariables, m.state)
+	require.Equal(t, "zarf.dev", m.input.Value())
+	m.Update(key("enter"))
+	m.Update(key("s"))
+	m.Update(key("3"))
+	_, cmd := m.Update(key("enter"))
+	require.Equal(t, stateDeploying, m.state)
+	require.NotNil(t, cmd)
+
+	// Run the deploy and feed the updates it sends back into the model.
+	cmd()
+	for {
+		msg := <-m.updates
+		m.Update(msg)
+		if _, ok := msg.(deployDoneMsg); ok {
+			break
+		}
+	}
+	require.EqualError(t, <-m.result, "games failed")
+	require.Equal(t, "games,extras", deployedComponents)
+	require.Equal(t, map[string]string{"DOMAIN": "zarf.dev", "PASSWORD": "s3", "REPLICAS": "3"}, deployedVariables)
+	require.Equal(t, stateDone, m.state)
+	require.Equal(t, events.StatusStarted, m.components[1].status)
+	require.Len(t, m.logs, 1)
+	require.Contains(t, m.logs[0], "deploying component")
+	require.Contains(t, m.View(), "games failed")
+}
+
+func TestConfirm(t *testing.T) {
+	t.Parallel()
+
+	m := newDeployModel(context.Background(), DeployOptions{Package: testPackage(), LogLevel: logger.Info})
+	m.opts.Deploy = func(ctx context.Context, _ string, _ map[string]string) error {
+		confirmed, err := m.Confirm(ctx, interactive.ConfirmRequest{
+			Stage:    "Deploy",
+			Warnings: []string{"this package installs the systemd services edge.service onto this host"},
+		})
+		if err != nil {
+			return err
+		}
+		if !confirmed {
+			return errors.New("deployment cancelled")
+		}
+		return nil
+	}
+	cmd := m.startDeploy()
+	go cmd()
+
+	m.Update(<-m.updates)
+	require.Equal(t, stateConfirm, m.state)
+	require.Contains(t, m.View(), "this package installs the systemd services edge.service onto this host")
+	m.Update(key("n"))
+	require.Equal(t, stateDeploying, m.state)
+	require.EqualError(t, <-m.result, "deployment cancelled")
+
+	// Confirmations are cancelled once the UI has exited.
+	close(m.done)
+	_, err := m.Confirm(context.Background(), interactive.ConfirmRequest{Stage: "Deploy"})
+	require.ErrorIs(t, err, ErrCancelled)
+}
+
+func TestHandleEvent(t *testing.T) {
+	t.Parallel()
+
+	m := newDeployModel(context.Background(), DeployOptions{Package: testPackage()})
+	m.handleEvent(events.Event{Type: events.TypePhase, Phase: "deploy"})
+	m.handleEvent(events.Event{Type: events.TypeComponent, Component: "games", Status: events.StatusSucceeded})
+	m.handleEvent(events.Event{Type: events.TypeImage, Image: "ghcr.io/zarf-dev/doom-game:0.0.1", Status: events.StatusProgress, Completed: 5, Total: 10})
+	require.Equal(t, "deploy", m.phase)
+	require.Equal(t, events.StatusSucceeded, m.components[1].status)
+	require.Equal(t, &imageProgress{name: "ghcr.io/zarf-dev/doom-game:0.0.1", completed: 5, total: 10}, m.image)
+	m.handleEvent(events.Event{Type: events.TypeImage, Image: "ghcr.io/zarf-dev/doom-game:0.0.1", Status: events.StatusSucceeded})
+	require.Nil(t, m.image)
+}
@@ -0,0 +1,54 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package tui
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"sync"
+
+	"github.com/zarf-dev/zarf/src/pkg/events"
+)
+
+// lineWriter splits written data into lines and sends each complete line.
+type lineWriter struct {
+	mu   sync.Mutex
+	buf  []byte
+	send func(line string)
+}
+
+func (w *lineWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.buf = append(w.buf, p...)
+	for {
+		i := bytes.IndexByte(w.buf, '\n')
+		if i < 0 {
+			break
+		}
+		w.send(strings.TrimRight(string(w.buf[:i]), "\r"))
+		w.buf = w.buf[i+1:]
+	}
+	return len(p), nil
+}
+
+// eventWriter decodes the newline delimited JSON written by an event emitter and sends each event.
+type eventWriter struct {
+	lines lineWriter
+	send  func(ev events.Event)
+}
+
+func (w *eventWriter) Write(p []byte) (int, error) {
+	if w.lines.send == nil {
+		w.lines.send = func(line string) {
+			var ev events.Event
+			if err := json.Unmarshal([]byte(line), &ev); err != nil {
+				return
+			}
+			w.send(ev)
+		}
+	}
+	return w.lines.Write(p)
+}
@@ -0,0 +1,74 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package cluster contains Zarf-specific cluster management functions.
+package cluster
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	resourcehelper "k8s.io/component-helpers/resource"
+)
+
+// CapacityResources are the resources the schedulable capacity of the cluster is measured in.
+var CapacityResources = []corev1.ResourceName{corev1.ResourceCPU, corev1.ResourceMemory, corev1.ResourceEphemeralStorage}
+
+// GetSchedulableCapacity returns the resources that are left to request on the nodes new pods can be scheduled on,
+// which is their allocatable resources less the requests of the pods running on them.
+func (c *Cluster) GetSchedulableCapacity(ctx context.Context) (corev1.ResourceList, error) {
+	nodeList, err := c.Clientset.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("unable to list the nodes: %w", err)
+	}
+	podList, err := c.Clientset.CoreV1().Pods(corev1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("unable to list the pods: %w", err)
+	}
+
+	capacity := corev1.ResourceList{}
+	schedulable := map[string]bool{}
+	for _, node := range nodeList.Items {
+		if !isNodeSchedulable(node) {
+			continue
+		}
+		schedulable[node.Name] = true
+		for _, name := range CapacityResources {
+			quantity := capacity[name]
+			quantity.Add(node.Status.Allocatable[name])
+			capacity[name] = quantity
+		}
+	}
+	for _, pod := range podList.Items {
+		if !schedulable[pod.Spec.NodeName] || pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+		requests := resourcehelper.PodRequests(&pod, resourcehelper.PodResourcesOptions{})
+		for _, name := range CapacityResources {
+			quantity := capacity[name]
+			quantity.Sub(requests[name])
+			capacity[name] = quantity
+		}
+	}
+	return capacity, nil
+}
+
+// isNodeSchedulable returns true if new pods can be scheduled on the node.
+func isNodeSchedulable(node corev1.Node) bool {
+	if node.Spec.Unschedulable {
+		return false
+	}
+	for _, taint := range node.Spec.Taints {
+		if taint.Effect == corev1.TaintEffectNoSchedule || taint.Effect == corev1.TaintEffectNoExecute {
+			return false
+		}
+	}
+	for _, condition := range node.Status.Conditions {
+		if condition.Type == corev1.NodeReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
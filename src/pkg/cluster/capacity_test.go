@@ -0,0 +1,74 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package cluster
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/zarf-dev/zarf/src/test/testutil"
+)
+
+func TestGetSchedulableCapacity(t *testing.T) {
+	t.Parallel()
+	ctx := testutil.TestContext(t)
+
+	newNode := func(name string, mutate func(node *corev1.Node)) *corev1.Node {
+		node := &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Status: corev1.NodeStatus{
+				Allocatable: corev1.ResourceList{
+					corev1.ResourceCPU:              resource.MustParse("4"),
+					corev1.ResourceMemory:           resource.MustParse("8Gi"),
+					corev1.ResourceEphemeralStorage: resource.MustParse("100Gi"),
+				},
+				Conditions: []corev1.NodeCondition{{Type: corev1.NodeReady, Status: corev1.ConditionTrue}},
+			},
+		}
+		mutate(node)
+		return node
+	}
+	newPod := func(name, nodeName string, phase corev1.PodPhase) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec: corev1.PodSpec{
+				NodeName: nodeName,
+				Containers: []corev1.Container{{
+					Name: "app",
+					Resources: corev1.ResourceRequirements{Requests: corev1.ResourceList{
+						corev1.ResourceCPU:    resource.MustParse("500m"),
+						corev1.ResourceMemory: resource.MustParse("1Gi"),
+					}},
+				}},
+			},
+			Status: corev1.PodStatus{Phase: phase},
+		}
+	}
+	c := &Cluster{
+		Clientset: fake.NewClientset(
+			newNode("ready", func(_ *corev1.Node) {}),
+			newNode("cordoned", func(node *corev1.Node) { node.Spec.Unschedulable = true }),
+			newNode("control-plane", func(node *corev1.Node) {
+				node.Spec.Taints = []corev1.Taint{{Key: "node-role.kubernetes.io/control-plane", Effect: corev1.TaintEffectNoSchedule}}
+			}),
+			newNode("not-ready", func(node *corev1.Node) { node.Status.Conditions[0].Status = corev1.ConditionFalse }),
+			newPod("running", "ready", corev1.PodRunning),
+			newPod("pending", "ready", corev1.PodPending),
+			newPod("succeeded", "ready", corev1.PodSucceeded),
+			newPod("cordoned", "cordoned", corev1.PodRunning),
+			newPod("unscheduled", "", corev1.PodPending),
+		),
+	}
+
+	capacity, err := c.GetSchedulableCapacity(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "3", capacity.Cpu().String())
+	require.Equal(t, "6Gi", capacity.Memory().String())
+	require.Equal(t, "100Gi", capacity.StorageEphemeral().String())
+}
@@ -18,6 +18,8 @@ import (
 
 	"github.com/avast/retry-go/v4"
 
+	"github.com/zarf-dev/zarf/src/config"
+	"github.com/zarf-dev/zarf/src/pkg/exitcode"
 	"github.com/zarf-dev/zarf/src/pkg/logger"
 	"github.com/zarf-dev/zarf/src/pkg/message"
 	"k8s.io/client-go/dynamic"
@@ -39,6 +41,8 @@ type Cluster struct {
 	Clientset  kubernetes.Interface
 	RestConfig *rest.Config
 	Watcher    watcher.StatusWatcher
+	// Name of the Zarf instance whose state is loaded and saved, empty for the default instance
+	Instance string
 }
 
 // NewClusterWithWait creates a new Cluster instance and waits for the given timeout for the cluster to be ready.
@@ -73,7 +77,7 @@ func NewClusterWithWait(ctx context.Context) (*Cluster, error) {
 		return fmt.Errorf("no pods are in succeeded or running state")
 	}, retry.Context(ctx), retry.Attempts(0), retry.DelayType(retry.FixedDelay), retry.Delay(time.Second))
 	if err != nil {
-		return nil, err
+		return nil, exitcode.Wrap(exitcode.Cluster, err)
 	}
 
 	spinner.Success()
@@ -85,23 +89,24 @@ func NewClusterWithWait(ctx context.Context) (*Cluster, error) {
 // NewCluster creates a new Cluster instance and validates connection to the cluster by fetching the Kubernetes version.
 func NewCluster() (*Cluster, error) {
 	clusterErr := errors.New("unable to connect to the cluster")
-	clientset, config, err := ClientAndConfig()
+	clientset, restConfig, err := ClientAndConfig()
 	if err != nil {
-		return nil, errors.Join(clusterErr, err)
+		return nil, exitcode.Wrap(exitcode.Cluster, errors.Join(clusterErr, err))
 	}
-	watcher, err := WatcherForConfig(config)
+	watcher, err := WatcherForConfig(restConfig)
 	if err != nil {
-		return nil, errors.Join(clusterErr, err)
+		return nil, exitcode.Wrap(exitcode.Cluster, errors.Join(clusterErr, err))
 	}
 	c := &Cluster{
 		Clientset:  clientset,
-		RestConfig: config,
+		RestConfig: restConfig,
 		Watcher:    watcher,
+		Instance:   config.CommonOptions.Instance,
 	}
 	// Dogsled the version output. We just want to ensure no errors were returned to validate cluster connection.
 	_, err = c.Clientset.Discovery().ServerVersion()
 	if err != nil {
-		return nil, errors.Join(clusterErr, err)
+		return nil, exitcode.Wrap(exitcode.Cluster, errors.Join(clusterErr, err))
 	}
 	return c, nil
 }
@@ -5,22 +5,34 @@
 package cluster
 
 import (
+	"archive/tar"
+	"compress/gzip"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"io/fs"
 	"os"
+	"path"
 	"path/filepath"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	v1ac "k8s.io/client-go/applyconfigurations/core/v1"
 	"k8s.io/client-go/kubernetes"
 
 	"github.com/avast/retry-go/v4"
 	"github.com/defenseunicorns/pkg/helpers/v2"
+	"golang.org/x/sync/errgroup"
 
 	"github.com/zarf-dev/zarf/src/api/v1alpha1"
 	"github.com/zarf-dev/zarf/src/config"
@@ -31,7 +43,8 @@ import (
 	"github.com/zarf-dev/zarf/src/pkg/utils/exec"
 )
 
-// HandleDataInjection waits for the target pod(s) to come up and inject the data into them
+// HandleDataInjection waits for the target pod(s) to come up and inject the data into them, or injects the data into
+// the target PersistentVolumeClaim through a short-lived pod.
 // todo:  this currently requires kubectl but we should have enough k8s work to make this native now.
 func (c *Cluster) HandleDataInjection(ctx context.Context, data v1alpha1.ZarfDataInjection, componentPath *layout.ComponentPaths, dataIdx int) error {
 	l := logger.From(ctx)
@@ -40,11 +53,6 @@ func (c *Cluster) HandleDataInjection(ctx context.Context, data v1alpha1.ZarfDat
 		return fmt.Errorf("unable to create the data injection completion marker: %w", err)
 	}
 
-	tarCompressFlag := ""
-	if data.Compress {
-		tarCompressFlag = "-z"
-	}
-
 	// Pod filter to ensure we only use the current deployment's pods
 	podFilterByInitContainer := func(pod corev1.Pod) bool {
 		b, err := json.Marshal(pod)
@@ -58,8 +66,11 @@ func (c *Cluster) HandleDataInjection(ctx context.Context, data v1alpha1.ZarfDat
 	// Get the OS shell to execute commands in
 	shell, shellArgs := exec.GetOSShell(v1alpha1.Shell{Windows: "cmd"})
 
-	if _, _, err := exec.Cmd(shell, append(shellArgs, "tar --version")...); err != nil {
-		return fmt.Errorf("unable to execute tar, ensure it is installed in the $PATH: %w", err)
+	// Synced data is archived by Zarf rather than the local tar.
+	if !data.Sync {
+		if _, _, err := exec.Cmd(shell, append(shellArgs, "tar --version")...); err != nil {
+			return fmt.Errorf("unable to execute tar, ensure it is installed in the $PATH: %w", err)
+		}
 	}
 
 	message.Debugf("Attempting to inject data into %s", data.Target)
@@ -74,6 +85,14 @@ func (c *Cluster) HandleDataInjection(ctx context.Context, data v1alpha1.ZarfDat
 		}
 	}
 
+	if data.Target.PersistentVolumeClaim != "" {
+		if err := c.injectIntoPersistentVolumeClaim(ctx, data, source, injectionCompletionMarker, shell, shellArgs); err != nil {
+			return err
+		}
+		// Cleanup now to reduce disk pressure
+		return os.RemoveAll(source)
+	}
+
 	// Wait until the pod we are injecting data into becomes available
 	target := podLookup{
 		Namespace: data.Target.Namespace,
@@ -87,53 +106,8 @@ func (c *Cluster) HandleDataInjection(ctx context.Context, data v1alpha1.ZarfDat
 		return err
 	}
 
-	// Inject into all the pods
-	for _, pod := range pods {
-		// Try to use the embedded kubectl if we can
-		zarfCommand, err := utils.GetFinalExecutableCommand()
-		kubectlBinPath := "kubectl"
-		if err != nil {
-			message.Warnf("Unable to get the zarf executable path, falling back to host kubectl: %s", err)
-			l.Warn("unable to get the zarf executable path, falling back to host kubectl", "error", err)
-		} else {
-			kubectlBinPath = fmt.Sprintf("%s tools kubectl", zarfCommand)
-		}
-		kubectlCmd := fmt.Sprintf("%s exec -i -n %s %s -c %s ", kubectlBinPath, data.Target.Namespace, pod.Name, data.Target.Container)
-
-		// Note that each command flag is separated to provide the widest cross-platform tar support
-		tarCmd := fmt.Sprintf("tar -c %s -f -", tarCompressFlag)
-		untarCmd := fmt.Sprintf("tar -x %s -v -f - -C %s", tarCompressFlag, data.Target.Path)
-
-		// Must create the target directory before trying to change to it for untar
-		mkdirCmd := fmt.Sprintf("%s -- mkdir -p %s", kubectlCmd, data.Target.Path)
-		if err := exec.CmdWithPrint(shell, append(shellArgs, mkdirCmd)...); err != nil {
-			return fmt.Errorf("unable to create the data injection target directory %s in pod %s: %w", data.Target.Path, pod.Name, err)
-		}
-
-		cpPodCmd := fmt.Sprintf("%s -C %s . | %s -- %s",
-			tarCmd,
-			source,
-			kubectlCmd,
-			untarCmd,
-		)
-
-		// Do the actual data injection
-		if err := exec.CmdWithPrint(shell, append(shellArgs, cpPodCmd)...); err != nil {
-			return fmt.Errorf("could not copy data into the pod %s: %w", pod.Name, err)
-		}
-
-		// Leave a marker in the target container for pods to track the sync action
-		cpPodCmd = fmt.Sprintf("%s -C %s %s | %s -- %s",
-			tarCmd,
-			componentPath.DataInjections,
-			config.GetDataInjectionMarker(),
-			kubectlCmd,
-			untarCmd,
-		)
-
-		if err := exec.CmdWithPrint(shell, append(shellArgs, cpPodCmd)...); err != nil {
-			return fmt.Errorf("could not save the Zarf sync completion file after injection into pod %s: %w", pod.Name, err)
-		}
+	if err := injectIntoPods(ctx, data, pods, source, injectionCompletionMarker, shell, shellArgs); err != nil {
+		return err
 	}
 
 	// Do not look for a specific container after injection in case they are running an init container
@@ -160,6 +134,401 @@ func (c *Cluster) HandleDataInjection(ctx context.Context, data v1alpha1.ZarfDat
 	return nil
 }
 
+// injectIntoPods injects the data into the target container of the pods.
+func injectIntoPods(ctx context.Context, data v1alpha1.ZarfDataInjection, pods []corev1.Pod, source, marker, shell string, shellArgs []string) error {
+	l := logger.From(ctx)
+
+	var content injectionContent
+	if data.Sync || data.Verify {
+		var err error
+		content, err = readInjectionContent(source)
+		if err != nil {
+			return err
+		}
+	}
+
+	g, gCtx := errgroup.WithContext(ctx)
+	g.SetLimit(max(data.Concurrency, 1))
+	for _, pod := range pods {
+		g.Go(func() error {
+			if data.Sync {
+				err := retry.Do(func() error {
+					return syncIntoPod(gCtx, data, pod.Name, source, marker, content)
+				}, retry.Context(gCtx), retry.Attempts(uint(config.ZarfDefaultRetries)), retry.Delay(time.Second))
+				if err != nil {
+					return err
+				}
+			} else if err := copyIntoPod(data, pod.Name, source, filepath.Dir(marker), shell, shellArgs); err != nil {
+				return err
+			}
+			if !data.Verify {
+				return nil
+			}
+			remote, err := remoteChecksums(gCtx, data, pod.Name)
+			if err != nil {
+				return err
+			}
+			if err := content.verify(remote); err != nil {
+				return fmt.Errorf("data injected into pod %s is not valid: %w", pod.Name, err)
+			}
+			l.Debug("verified the injected data", "pod", pod.Name, "files", len(content.files))
+			return nil
+		})
+	}
+	return g.Wait()
+}
+
+// injectIntoPersistentVolumeClaim injects the data into a PersistentVolumeClaim through a helper pod that mounts it,
+// removing the pod once the data is injected.
+func (c *Cluster) injectIntoPersistentVolumeClaim(ctx context.Context, data v1alpha1.ZarfDataInjection, source, marker, shell string, shellArgs []string) (err error) {
+	l := logger.From(ctx)
+
+	pod := buildDataInjectionPod(data.Target)
+	l.Info("starting a pod to inject data into a persistent volume claim", "pod", *pod.Name, "namespace", data.Target.Namespace, "pvc", data.Target.PersistentVolumeClaim)
+	defer func() {
+		// Remove the pod even if the deploy was canceled so the volume is released for the workloads that consume it.
+		deleteErr := c.Clientset.CoreV1().Pods(data.Target.Namespace).Delete(context.WithoutCancel(ctx), *pod.Name, metav1.DeleteOptions{})
+		if deleteErr != nil && !kerrors.IsNotFound(deleteErr) {
+			err = errors.Join(err, fmt.Errorf("unable to remove the data injection pod %s: %w", *pod.Name, deleteErr))
+		}
+	}()
+
+	// The namespace and claim may be created by the charts and manifests that are deployed alongside the injection,
+	// and provisioning the volume and pulling the image can take a while, so allow longer than for workload pods.
+	waitCtx, waitCancel := context.WithTimeout(ctx, 5*time.Minute)
+	defer waitCancel()
+	err = retry.Do(func() error {
+		// Replace a pod left behind by an earlier deploy as its spec can not be changed.
+		err := c.Clientset.CoreV1().Pods(data.Target.Namespace).Delete(waitCtx, *pod.Name, metav1.DeleteOptions{})
+		if err != nil && !kerrors.IsNotFound(err) {
+			return err
+		}
+		_, err = c.Clientset.CoreV1().Pods(data.Target.Namespace).Apply(waitCtx, pod, metav1.ApplyOptions{Force: true, FieldManager: FieldManagerName})
+		return err
+	}, retry.Context(waitCtx), retry.Attempts(0), retry.DelayType(retry.FixedDelay), retry.Delay(time.Second))
+	if err != nil {
+		return fmt.Errorf("unable to create the data injection pod %s: %w", *pod.Name, err)
+	}
+	target := podLookup{
+		Namespace: data.Target.Namespace,
+		Selector:  fmt.Sprintf("app=%s", *pod.Name),
+		Container: dataInjectionContainerName,
+	}
+	pods, err := waitForPodsAndContainers(waitCtx, c.Clientset, target, nil)
+	if err != nil {
+		return fmt.Errorf("the data injection pod %s did not start: %w", *pod.Name, err)
+	}
+
+	data.Target.Container = dataInjectionContainerName
+	data.Target.Path = path.Join(dataInjectionMountPath, data.Target.Path)
+	return injectIntoPods(ctx, data, pods, source, marker, shell, shellArgs)
+}
+
+const (
+	dataInjectionContainerName = "data-injection"
+	dataInjectionMountPath     = "/zarf-data"
+)
+
+// buildDataInjectionPod returns a pod that mounts the target PersistentVolumeClaim and waits for data to be injected.
+// The name of the pod is derived from the claim and path so that retried deploys replace the pod of earlier attempts.
+func buildDataInjectionPod(target v1alpha1.ZarfContainerTarget) *v1ac.PodApplyConfiguration {
+	hash := sha256.Sum256([]byte(target.PersistentVolumeClaim + ":" + target.Path))
+	name := fmt.Sprintf("zarf-data-injection-%s", hex.EncodeToString(hash[:])[:8])
+	return v1ac.Pod(name, target.Namespace).
+		WithLabels(map[string]string{
+			"app": name,
+		}).
+		WithSpec(
+			v1ac.PodSpec().
+				WithRestartPolicy(corev1.RestartPolicyNever).
+				WithContainers(
+					v1ac.Container().
+						WithName(dataInjectionContainerName).
+						WithImage(target.Image).
+						WithImagePullPolicy(corev1.PullIfNotPresent).
+						WithCommand("sh", "-c", "while true; do sleep 10; done").
+						WithVolumeMounts(
+							v1ac.VolumeMount().
+								WithName("data").
+								WithMountPath(dataInjectionMountPath),
+						),
+				).
+				WithVolumes(
+					v1ac.Volume().
+						WithName("data").
+						WithPersistentVolumeClaim(
+							v1ac.PersistentVolumeClaimVolumeSource().
+								WithClaimName(target.PersistentVolumeClaim),
+						),
+				),
+		)
+}
+
+// kubectlCommand returns the command and arguments to run kubectl, preferring the kubectl embedded in Zarf.
+func kubectlCommand() (string, []string) {
+	zarfCommand, err := utils.GetFinalExecutableCommand()
+	if err != nil {
+		message.Warnf("Unable to get the zarf executable path, falling back to host kubectl: %s", err)
+		logger.Default().Warn("unable to get the zarf executable path, falling back to host kubectl", "error", err)
+		return "kubectl", nil
+	}
+	return zarfCommand, []string{"tools", "kubectl"}
+}
+
+// copyIntoPod copies all of the data of an injection into the target path of a pod using tar.
+func copyIntoPod(data v1alpha1.ZarfDataInjection, podName, source, markerDir, shell string, shellArgs []string) error {
+	tarCompressFlag := ""
+	if data.Compress {
+		tarCompressFlag = "-z"
+	}
+
+	kubectlBin, kubectlArgs := kubectlCommand()
+	kubectlCmd := fmt.Sprintf("%s exec -i -n %s %s -c %s ", strings.Join(append([]string{kubectlBin}, kubectlArgs...), " "), data.Target.Namespace, podName, data.Target.Container)
+
+	// Note that each command flag is separated to provide the widest cross-platform tar support
+	tarCmd := fmt.Sprintf("tar -c %s -f -", tarCompressFlag)
+	untarCmd := fmt.Sprintf("tar -x %s -v -f - -C %s", tarCompressFlag, data.Target.Path)
+
+	// Must create the target directory before trying to change to it for untar
+	mkdirCmd := fmt.Sprintf("%s -- mkdir -p %s", kubectlCmd, data.Target.Path)
+	if err := exec.CmdWithPrint(shell, append(shellArgs, mkdirCmd)...); err != nil {
+		return fmt.Errorf("unable to create the data injection target directory %s in pod %s: %w", data.Target.Path, podName, err)
+	}
+
+	cpPodCmd := fmt.Sprintf("%s -C %s . | %s -- %s",
+		tarCmd,
+		source,
+		kubectlCmd,
+		untarCmd,
+	)
+
+	// Do the actual data injection
+	if err := exec.CmdWithPrint(shell, append(shellArgs, cpPodCmd)...); err != nil {
+		return fmt.Errorf("could not copy data into the pod %s: %w", podName, err)
+	}
+
+	// Leave a marker in the target container for pods to track the sync action
+	cpPodCmd = fmt.Sprintf("%s -C %s %s | %s -- %s",
+		tarCmd,
+		markerDir,
+		config.GetDataInjectionMarker(),
+		kubectlCmd,
+		untarCmd,
+	)
+
+	if err := exec.CmdWithPrint(shell, append(shellArgs, cpPodCmd)...); err != nil {
+		return fmt.Errorf("could not save the Zarf sync completion file after injection into pod %s: %w", podName, err)
+	}
+	return nil
+}
+
+// syncIntoPod transfers the files of an injection that are missing or differ in the target path of a pod along with
+// the completion marker. Files that were fully transferred by an earlier attempt are skipped.
+func syncIntoPod(ctx context.Context, data v1alpha1.ZarfDataInjection, podName, source, marker string, content injectionContent) error {
+	l := logger.From(ctx)
+
+	remote, err := remoteChecksums(ctx, data, podName)
+	if err != nil {
+		return err
+	}
+	changed := content.changed(remote)
+	var size int64
+	for _, name := range changed {
+		size += content.files[name].size
+	}
+	l.Info("syncing data into pod", "pod", podName, "path", data.Target.Path, "changed", len(changed), "unchanged", len(content.files)-len(changed), "size", utils.ByteFormat(float64(size), 2))
+
+	tarArgs := []string{"-x", "-f", "-", "-C", data.Target.Path}
+	if data.Compress {
+		tarArgs = []string{"-x", "-z", "-f", "-", "-C", data.Target.Path}
+	}
+	kubectlBin, kubectlArgs := kubectlCommand()
+	args := append(kubectlArgs, "exec", "-i", "-n", data.Target.Namespace, podName, "-c", data.Target.Container, "--", "sh", "-c",
+		fmt.Sprintf("mkdir -p '%[1]s' && tar %[2]s", data.Target.Path, strings.Join(tarArgs, " ")))
+
+	progressBar := message.NewProgressBar(size, fmt.Sprintf("Syncing data into %s", podName))
+	pr, pw := io.Pipe()
+	go func() {
+		pw.CloseWithError(writeInjectionTar(pw, source, content.dirs, changed, marker, data.Compress, progressBar))
+	}()
+	_, stderr, err := exec.CmdWithContext(ctx, exec.Config{Stdin: pr}, kubectlBin, args...)
+	// Unblock the writer if the command exited before reading all of the data.
+	pr.Close()
+	if err != nil {
+		progressBar.Failf("Unable to sync data into %s", podName)
+		return fmt.Errorf("could not sync data into the pod %s: %w: %s", podName, err, strings.TrimSpace(stderr))
+	}
+	progressBar.Successf("Synced data into %s", podName)
+	return nil
+}
+
+// remoteChecksums returns the sha256 checksums of the files in the target path of a pod keyed by their path relative
+// to the target path. A target path that does not exist yet has no files.
+func remoteChecksums(ctx context.Context, data v1alpha1.ZarfDataInjection, podName string) (map[string]string, error) {
+	kubectlBin, kubectlArgs := kubectlCommand()
+	args := append(kubectlArgs, "exec", "-n", data.Target.Namespace, podName, "-c", data.Target.Container, "--", "sh", "-c",
+		fmt.Sprintf("cd '%s' 2>/dev/null || exit 0; find . -type f -exec sha256sum {} +", data.Target.Path))
+	stdout, stderr, err := exec.CmdWithContext(ctx, exec.Config{}, kubectlBin, args...)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get the checksums of the files in %s in pod %s: %w: %s", data.Target.Path, podName, err, strings.TrimSpace(stderr))
+	}
+	return parseChecksums(stdout), nil
+}
+
+// injectionFile is a file in the source of a data injection.
+type injectionFile struct {
+	checksum string
+	size     int64
+}
+
+// injectionContent is the content of the source of a data injection keyed by slash separated paths relative to the source.
+type injectionContent struct {
+	dirs  []string
+	files map[string]injectionFile
+}
+
+func readInjectionContent(source string) (injectionContent, error) {
+	content := injectionContent{files: map[string]injectionFile{}}
+	err := filepath.WalkDir(source, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(source, path)
+		if err != nil {
+			return err
+		}
+		if rel == "." {
+			return nil
+		}
+		rel = filepath.ToSlash(rel)
+		if d.IsDir() {
+			content.dirs = append(content.dirs, rel)
+			return nil
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		fi, err := d.Info()
+		if err != nil {
+			return err
+		}
+		checksum, err := helpers.GetSHA256OfFile(path)
+		if err != nil {
+			return err
+		}
+		content.files[rel] = injectionFile{checksum: checksum, size: fi.Size()}
+		return nil
+	})
+	if err != nil {
+		return injectionContent{}, fmt.Errorf("unable to read the data injection source %s: %w", source, err)
+	}
+	return content, nil
+}
+
+// changed returns the sorted paths of the files that are missing or differ in the remote checksums.
+func (ic injectionContent) changed(remote map[string]string) []string {
+	changed := []string{}
+	for name, file := range ic.files {
+		if remote[name] != file.checksum {
+			changed = append(changed, name)
+		}
+	}
+	slices.Sort(changed)
+	return changed
+}
+
+// verify returns an error listing the files that are missing or differ in the remote checksums.
+func (ic injectionContent) verify(remote map[string]string) error {
+	changed := ic.changed(remote)
+	if len(changed) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%d files are missing or have a different checksum: %s", len(changed), strings.Join(changed, ", "))
+}
+
+// parseChecksums parses the output of sha256sum into checksums keyed by slash separated paths relative to the directory
+// it was run in. Escaped file names are skipped.
+func parseChecksums(out string) map[string]string {
+	checksums := map[string]string{}
+	for _, line := range strings.Split(out, "\n") {
+		checksum, name, ok := strings.Cut(line, "  ")
+		if !ok || strings.HasPrefix(checksum, "\\") {
+			continue
+		}
+		checksums[strings.TrimPrefix(name, "./")] = checksum
+	}
+	return checksums
+}
+
+// writeInjectionTar writes a tar archive of the directories and files of a data injection followed by the completion
+// marker, writing the content of the files to progress as they are archived.
+func writeInjectionTar(w io.Writer, source string, dirs, files []string, marker string, compress bool, progress io.Writer) (err error) {
+	if compress {
+		gw := gzip.NewWriter(w)
+		defer func() {
+			err = errors.Join(err, gw.Close())
+		}()
+		w = gw
+	}
+	tw := tar.NewWriter(w)
+	defer func() {
+		err = errors.Join(err, tw.Close())
+	}()
+
+	for _, dir := range dirs {
+		fi, err := os.Stat(filepath.Join(source, filepath.FromSlash(dir)))
+		if err != nil {
+			return err
+		}
+		if err := writeTarEntry(tw, filepath.Join(source, filepath.FromSlash(dir)), dir, fi, nil); err != nil {
+			return err
+		}
+	}
+	for _, name := range files {
+		path := filepath.Join(source, filepath.FromSlash(name))
+		fi, err := os.Stat(path)
+		if err != nil {
+			return err
+		}
+		if err := writeTarEntry(tw, path, name, fi, progress); err != nil {
+			return err
+		}
+	}
+	fi, err := os.Stat(marker)
+	if err != nil {
+		return err
+	}
+	return writeTarEntry(tw, marker, filepath.Base(marker), fi, nil)
+}
+
+func writeTarEntry(tw *tar.Writer, path, name string, fi fs.FileInfo, progress io.Writer) error {
+	hdr, err := tar.FileInfoHeader(fi, "")
+	if err != nil {
+		return err
+	}
+	hdr.Name = name
+	if fi.IsDir() {
+		hdr.Name += "/"
+	}
+	if err := tw.WriteHeader(hdr); err != nil {
+		return err
+	}
+	if fi.IsDir() {
+		return nil
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	var r io.Reader = f
+	if progress != nil {
+		r = io.TeeReader(f, progress)
+	}
+	_, err = io.Copy(tw, r)
+	return err
+}
+
 // podLookup is a struct for specifying a pod to target for data injection or lookups.
 type podLookup struct {
 	Namespace string
@@ -0,0 +1,187 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package cluster
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/zarf-dev/zarf/src/api/v1alpha1"
+)
+
+const (
+	helloChecksum = "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824"
+	worldChecksum = "486ea46224d1bb4fb680f34f7c9ad96a8f24ec88be73ea8e5a6c65260e9cb8a7"
+)
+
+func writeInjectionSource(t *testing.T) string {
+	t.Helper()
+
+	source := t.TempDir()
+	err := os.MkdirAll(filepath.Join(source, "models", "empty"), 0o700)
+	require.NoError(t, err)
+	err = os.WriteFile(filepath.Join(source, "hello.txt"), []byte("hello"), 0o600)
+	require.NoError(t, err)
+	err = os.WriteFile(filepath.Join(source, "models", "world.txt"), []byte("world"), 0o600)
+	require.NoError(t, err)
+	return source
+}
+
+func TestReadInjectionContent(t *testing.T) {
+	t.Parallel()
+
+	content, err := readInjectionContent(writeInjectionSource(t))
+	require.NoError(t, err)
+	require.Equal(t, []string{"models", "models/empty"}, content.dirs)
+	expected := map[string]injectionFile{
+		"hello.txt":        {checksum: helloChecksum, size: 5},
+		"models/world.txt": {checksum: worldChecksum, size: 5},
+	}
+	require.Equal(t, expected, content.files)
+
+	_, err = readInjectionContent(filepath.Join(t.TempDir(), "missing"))
+	require.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestParseChecksums(t *testing.T) {
+	t.Parallel()
+
+	out := helloChecksum + "  ./hello.txt\n" +
+		worldChecksum + "  ./models/world.txt\n" +
+		"\\" + helloChecksum + "  ./new\\nline.txt\n"
+	expected := map[string]string{
+		"hello.txt":        helloChecksum,
+		"models/world.txt": worldChecksum,
+	}
+	require.Equal(t, expected, parseChecksums(out))
+	require.Empty(t, parseChecksums(""))
+}
+
+func TestInjectionContentChanged(t *testing.T) {
+	t.Parallel()
+
+	content := injectionContent{
+		files: map[string]injectionFile{
+			"hello.txt":        {checksum: helloChecksum},
+			"models/world.txt": {checksum: worldChecksum},
+		},
+	}
+
+	tests := []struct {
+		name        string
+		remote      map[string]string
+		expected    []string
+		expectedErr string
+	}{
+		{
+			name:        "empty target",
+			remote:      map[string]string{},
+			expected:    []string{"hello.txt", "models/world.txt"},
+			expectedErr: "2 files are missing or have a different checksum: hello.txt, models/world.txt",
+		},
+		{
+			name: "partially transferred",
+			remote: map[string]string{
+				"hello.txt":        helloChecksum,
+				"models/world.txt": helloChecksum,
+			},
+			expected:    []string{"models/world.txt"},
+			expectedErr: "1 files are missing or have a different checksum: models/world.txt",
+		},
+		{
+			name: "in sync",
+			remote: map[string]string{
+				"hello.txt":        helloChecksum,
+				"models/world.txt": worldChecksum,
+				"other.txt":        helloChecksum,
+			},
+			expected: []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			require.Equal(t, tt.expected, content.changed(tt.remote))
+			err := content.verify(tt.remote)
+			if tt.expectedErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.EqualError(t, err, tt.expectedErr)
+		})
+	}
+}
+
+func TestWriteInjectionTar(t *testing.T) {
+	t.Parallel()
+
+	source := writeInjectionSource(t)
+	marker := filepath.Join(t.TempDir(), ".zarf-injection-marker")
+	err := os.WriteFile(marker, []byte("done"), 0o600)
+	require.NoError(t, err)
+
+	for _, compress := range []bool{false, true} {
+		buf := &bytes.Buffer{}
+		progress := &bytes.Buffer{}
+		err := writeInjectionTar(buf, source, []string{"models"}, []string{"models/world.txt"}, marker, compress, progress)
+		require.NoError(t, err)
+		require.Equal(t, "world", progress.String())
+
+		var r io.Reader = buf
+		if compress {
+			r, err = gzip.NewReader(buf)
+			require.NoError(t, err)
+		}
+		tr := tar.NewReader(r)
+		entries := map[string]string{}
+		for {
+			hdr, err := tr.Next()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			require.NoError(t, err)
+			b, err := io.ReadAll(tr)
+			require.NoError(t, err)
+			entries[hdr.Name] = string(b)
+		}
+		expected := map[string]string{
+			"models/":                "",
+			"models/world.txt":       "world",
+			".zarf-injection-marker": "done",
+		}
+		require.Equal(t, expected, entries)
+	}
+}
+
+func TestBuildDataInjectionPod(t *testing.T) {
+	t.Parallel()
+
+	target := v1alpha1.ZarfContainerTarget{
+		Namespace:             "models",
+		Path:                  "/llm",
+		PersistentVolumeClaim: "model-cache",
+		Image:                 "docker.io/library/busybox:1.37",
+	}
+	pod := buildDataInjectionPod(target)
+	require.Equal(t, "models", *pod.Namespace)
+	require.Equal(t, map[string]string{"app": *pod.Name}, pod.Labels)
+	require.Equal(t, *pod.Name, *buildDataInjectionPod(target).Name)
+	target.Path = "/other"
+	require.NotEqual(t, *pod.Name, *buildDataInjectionPod(target).Name)
+
+	require.Len(t, pod.Spec.Containers, 1)
+	container := pod.Spec.Containers[0]
+	require.Equal(t, dataInjectionContainerName, *container.Name)
+	require.Equal(t, "docker.io/library/busybox:1.37", *container.Image)
+	require.Equal(t, dataInjectionMountPath, *container.VolumeMounts[0].MountPath)
+	require.Equal(t, "model-cache", *pod.Spec.Volumes[0].PersistentVolumeClaim.ClaimName)
+}
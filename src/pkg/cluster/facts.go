@@ -0,0 +1,51 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package cluster contains Zarf-specific cluster management functions.
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/zarf-dev/zarf/src/types"
+)
+
+// GetClusterFacts returns the facts about the cluster that components can be conditionally deployed on.
+func (c *Cluster) GetClusterFacts(ctx context.Context) (types.ClusterFacts, error) {
+	serverVersion, err := c.Clientset.Discovery().ServerVersion()
+	if err != nil {
+		return types.ClusterFacts{}, fmt.Errorf("unable to get the Kubernetes version: %w", err)
+	}
+	nodeList, err := c.Clientset.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return types.ClusterFacts{}, err
+	}
+	if len(nodeList.Items) == 0 {
+		return types.ClusterFacts{}, fmt.Errorf("cannot get the facts of an empty cluster")
+	}
+	namespaceList, err := c.Clientset.CoreV1().Namespaces().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return types.ClusterFacts{}, err
+	}
+
+	facts := types.ClusterFacts{
+		Distro:     detectDistro(nodeList.Items[0], namespaceList.Items),
+		Version:    serverVersion.GitVersion,
+		Providers:  []string{},
+		NodeLabels: []map[string]string{},
+	}
+	for _, node := range nodeList.Items {
+		// Provider IDs are in the format <provider>://<provider specific node id>.
+		provider, _, ok := strings.Cut(node.Spec.ProviderID, "://")
+		if ok && provider != "" && !slices.Contains(facts.Providers, provider) {
+			facts.Providers = append(facts.Providers, provider)
+		}
+		facts.NodeLabels = append(facts.NodeLabels, node.GetLabels())
+	}
+	return facts, nil
+}
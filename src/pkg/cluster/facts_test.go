@@ -0,0 +1,58 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package cluster
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/version"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/zarf-dev/zarf/src/test/testutil"
+	"github.com/zarf-dev/zarf/src/types"
+)
+
+func TestGetClusterFacts(t *testing.T) {
+	t.Parallel()
+	ctx := testutil.TestContext(t)
+
+	cs := fake.NewClientset(
+		&corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   "first",
+				Labels: map[string]string{"topology.kubernetes.io/zone": "us-east-1a"},
+			},
+			Spec: corev1.NodeSpec{ProviderID: "aws:///us-east-1a/i-0123"},
+		},
+		&corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   "second",
+				Labels: map[string]string{"gpu": "true"},
+			},
+			Spec: corev1.NodeSpec{ProviderID: "aws:///us-east-1b/i-4567"},
+		},
+	)
+	cs.Discovery().(*fakediscovery.FakeDiscovery).FakedServerVersion = &version.Info{GitVersion: "v1.30.2-eks-1552ad0"}
+	c := &Cluster{Clientset: cs}
+
+	facts, err := c.GetClusterFacts(ctx)
+	require.NoError(t, err)
+	expected := types.ClusterFacts{
+		Distro:    DistroIsEKS,
+		Version:   "v1.30.2-eks-1552ad0",
+		Providers: []string{"aws"},
+		NodeLabels: []map[string]string{
+			{"topology.kubernetes.io/zone": "us-east-1a"},
+			{"gpu": "true"},
+		},
+	}
+	require.Equal(t, expected, facts)
+
+	_, err = (&Cluster{Clientset: fake.NewClientset()}).GetClusterFacts(ctx)
+	require.EqualError(t, err, "cannot get the facts of an empty cluster")
+}
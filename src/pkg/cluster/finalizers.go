@@ -0,0 +1,257 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package cluster contains Zarf-specific cluster management functions.
+package cluster
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/zarf-dev/zarf/src/pkg/logger"
+	"github.com/zarf-dev/zarf/src/pkg/message"
+)
+
+var crdResource = schema.GroupVersionResource{Group: "apiextensions.k8s.io", Version: "v1", Resource: "customresourcedefinitions"}
+
+// removeFinalizersPatch clears the finalizers of a resource.
+var removeFinalizersPatch = []byte(`{"metadata":{"finalizers":null}}`)
+
+// RemovalWaitOptions are the options for WaitForRemoval.
+type RemovalWaitOptions struct {
+	// Namespaces are the namespaces waited on while they are terminating.
+	Namespaces []string
+	// CRDs are the names of the custom resource definitions waited on while they are terminating.
+	CRDs []string
+	// Timeout is how long to wait for the namespaces and CRDs to be removed.
+	Timeout time.Duration
+	// Force removes the finalizers of the resources blocking the removal once the timeout is reached, and waits for
+	// the timeout again.
+	Force bool
+}
+
+// WaitForRemoval waits for the terminating namespaces and custom resource definitions to be removed. Namespaces wait
+// for the finalizers of the resources in them and custom resource definitions for those of their custom resources,
+// which are never removed once the controller handling them is gone.
+func (c *Cluster) WaitForRemoval(ctx context.Context, opts RemovalWaitOptions) error {
+	if len(opts.Namespaces) == 0 && len(opts.CRDs) == 0 {
+		return nil
+	}
+	dynamicClient, err := dynamic.NewForConfig(c.RestConfig)
+	if err != nil {
+		return err
+	}
+	return waitForRemoval(ctx, c.Clientset, dynamicClient, opts)
+}
+
+func waitForRemoval(ctx context.Context, clientset kubernetes.Interface, dynamicClient dynamic.Interface, opts RemovalWaitOptions) error {
+	l := logger.From(ctx)
+	spinner := message.NewProgressSpinner("Waiting for namespaces and custom resource definitions to be removed")
+	defer spinner.Stop()
+
+	remaining, err := pollRemoval(ctx, clientset, dynamicClient, opts)
+	if err != nil {
+		return err
+	}
+	if len(remaining) == 0 {
+		spinner.Success()
+		return nil
+	}
+	if !opts.Force {
+		return fmt.Errorf("%s are still terminating after %s, they are likely blocked by finalizers whose controller was removed. Remove again with force to remove the finalizers", strings.Join(remaining, ", "), opts.Timeout)
+	}
+
+	spinner.Updatef("Removing the finalizers blocking the removal of %s", strings.Join(remaining, ", "))
+	l.Warn("removing the finalizers blocking the removal", "resources", remaining)
+	for _, name := range opts.Namespaces {
+		if err := removeNamespaceFinalizers(ctx, clientset, dynamicClient, name); err != nil {
+			return err
+		}
+	}
+	for _, name := range opts.CRDs {
+		if err := removeCustomResourceFinalizers(ctx, dynamicClient, name); err != nil {
+			return err
+		}
+	}
+
+	remaining, err = pollRemoval(ctx, clientset, dynamicClient, opts)
+	if err != nil {
+		return err
+	}
+	if len(remaining) > 0 {
+		return fmt.Errorf("%s are still terminating after removing the finalizers blocking them", strings.Join(remaining, ", "))
+	}
+	spinner.Success()
+	return nil
+}
+
+// pollRemoval polls until none of the namespaces or CRDs are terminating or the timeout is reached, it returns the
+// ones that are still terminating.
+func pollRemoval(ctx context.Context, clientset kubernetes.Interface, dynamicClient dynamic.Interface, opts RemovalWaitOptions) ([]string, error) {
+	timer := time.NewTimer(opts.Timeout)
+	defer timer.Stop()
+	for {
+		remaining, err := terminating(ctx, clientset, dynamicClient, opts)
+		if err != nil || len(remaining) == 0 {
+			return remaining, err
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-timer.C:
+			return remaining, nil
+		case <-time.After(time.Second):
+		}
+	}
+}
+
+// terminating returns the namespaces and CRDs that are terminating. Ones that exist without being deleted are left
+// to the caller.
+func terminating(ctx context.Context, clientset kubernetes.Interface, dynamicClient dynamic.Interface, opts RemovalWaitOptions) ([]string, error) {
+	remaining := []string{}
+	for _, name := range opts.Namespaces {
+		ns, err := clientset.CoreV1().Namespaces().Get(ctx, name, metav1.GetOptions{})
+		if kerrors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if ns.DeletionTimestamp != nil {
+			remaining = append(remaining, fmt.Sprintf("namespace/%s", name))
+		}
+	}
+	for _, name := range opts.CRDs {
+		crd, err := dynamicClient.Resource(crdResource).Get(ctx, name, metav1.GetOptions{})
+		if kerrors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if crd.GetDeletionTimestamp() != nil {
+			remaining = append(remaining, fmt.Sprintf("customresourcedefinition/%s", name))
+		}
+	}
+	return remaining, nil
+}
+
+// removeNamespaceFinalizers removes the finalizers of the resources in the terminating namespace.
+func removeNamespaceFinalizers(ctx context.Context, clientset kubernetes.Interface, dynamicClient dynamic.Interface, name string) error {
+	ns, err := clientset.CoreV1().Namespaces().Get(ctx, name, metav1.GetOptions{})
+	if kerrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if ns.DeletionTimestamp == nil {
+		return nil
+	}
+	// Discovery fails for API groups whose server is gone, the resources of the groups that were discovered are
+	// still cleaned up.
+	resourceLists, err := discovery.ServerPreferredNamespacedResources(clientset.Discovery())
+	if err != nil && !discovery.IsGroupDiscoveryFailedError(err) {
+		return fmt.Errorf("unable to discover the resources in namespace %s: %w", name, err)
+	}
+	for _, resourceList := range resourceLists {
+		gv, err := schema.ParseGroupVersion(resourceList.GroupVersion)
+		if err != nil {
+			return err
+		}
+		for _, resource := range resourceList.APIResources {
+			if !slices.Contains(resource.Verbs, "list") || !slices.Contains(resource.Verbs, "patch") {
+				continue
+			}
+			if err := removeFinalizers(ctx, dynamicClient.Resource(gv.WithResource(resource.Name)), name); err != nil {
+				return fmt.Errorf("unable to remove the finalizers of %s in namespace %s: %w", resource.Name, name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// removeCustomResourceFinalizers removes the finalizers of the custom resources of the terminating CRD.
+func removeCustomResourceFinalizers(ctx context.Context, dynamicClient dynamic.Interface, name string) error {
+	crd, err := dynamicClient.Resource(crdResource).Get(ctx, name, metav1.GetOptions{})
+	if kerrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if crd.GetDeletionTimestamp() != nil {
+		gvr, err := storageResource(crd)
+		if err != nil {
+			return err
+		}
+		if err := removeFinalizers(ctx, dynamicClient.Resource(gvr), metav1.NamespaceAll); err != nil {
+			return fmt.Errorf("unable to remove the finalizers of %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// storageResource returns the resource of the stored version of the CRD.
+func storageResource(crd *unstructured.Unstructured) (schema.GroupVersionResource, error) {
+	group, _, err := unstructured.NestedString(crd.Object, "spec", "group")
+	if err != nil {
+		return schema.GroupVersionResource{}, err
+	}
+	plural, _, err := unstructured.NestedString(crd.Object, "spec", "names", "plural")
+	if err != nil {
+		return schema.GroupVersionResource{}, err
+	}
+	versions, _, err := unstructured.NestedSlice(crd.Object, "spec", "versions")
+	if err != nil {
+		return schema.GroupVersionResource{}, err
+	}
+	for _, v := range versions {
+		version, ok := v.(map[string]any)
+		if !ok {
+			continue
+		}
+		if storage, _ := version["storage"].(bool); storage {
+			name, _ := version["name"].(string)
+			return schema.GroupVersionResource{Group: group, Version: name, Resource: plural}, nil
+		}
+	}
+	return schema.GroupVersionResource{}, fmt.Errorf("custom resource definition %s has no storage version", crd.GetName())
+}
+
+// removeFinalizers removes the finalizers of the resources in the namespace that are being deleted.
+func removeFinalizers(ctx context.Context, client dynamic.NamespaceableResourceInterface, namespace string) error {
+	list, err := client.Namespace(namespace).List(ctx, metav1.ListOptions{})
+	// Resources that cannot be listed do not block the removal.
+	if kerrors.IsNotFound(err) || kerrors.IsMethodNotSupported(err) || kerrors.IsForbidden(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	var errs []error
+	for _, item := range list.Items {
+		if item.GetDeletionTimestamp() == nil || len(item.GetFinalizers()) == 0 {
+			continue
+		}
+		logger.From(ctx).Debug("removing finalizers", "kind", item.GetKind(), "name", item.GetName(), "namespace", item.GetNamespace(), "finalizers", item.GetFinalizers())
+		_, err := client.Namespace(item.GetNamespace()).Patch(ctx, item.GetName(), types.MergePatchType, removeFinalizersPatch, metav1.PatchOptions{})
+		if err != nil && !kerrors.IsNotFound(err) {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,149 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package cluster
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/zarf-dev/zarf/src/test/testutil"
+)
+
+var widgetResource = schema.GroupVersionResource{Group: "example.com", Version: "v1", Resource: "widgets"}
+
+func newWidget(namespace, name string, deleting bool) *unstructured.Unstructured {
+	widget := &unstructured.Unstructured{}
+	widget.SetAPIVersion("example.com/v1")
+	widget.SetKind("Widget")
+	widget.SetNamespace(namespace)
+	widget.SetName(name)
+	widget.SetFinalizers([]string{"example.com/cleanup"})
+	if deleting {
+		widget.SetDeletionTimestamp(&metav1.Time{Time: time.Now()})
+	}
+	return widget
+}
+
+func newWidgetCRD(deleting bool) *unstructured.Unstructured {
+	crd := &unstructured.Unstructured{Object: map[string]any{
+		"spec": map[string]any{
+			"group": "example.com",
+			"names": map[string]any{"plural": "widgets"},
+			"versions": []any{
+				map[string]any{"name": "v1beta1", "storage": false},
+				map[string]any{"name": "v1", "storage": true},
+			},
+		},
+	}}
+	crd.SetAPIVersion("apiextensions.k8s.io/v1")
+	crd.SetKind("CustomResourceDefinition")
+	crd.SetName("widgets.example.com")
+	if deleting {
+		crd.SetDeletionTimestamp(&metav1.Time{Time: time.Now()})
+	}
+	return crd
+}
+
+func newRemovalClients(t *testing.T, objects ...runtime.Object) (*fake.Clientset, *dynamicfake.FakeDynamicClient) {
+	t.Helper()
+	clientset := fake.NewClientset(
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "active"}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "terminating", DeletionTimestamp: &metav1.Time{Time: time.Now()}, Finalizers: []string{"kubernetes"}}},
+	)
+	fakeDiscovery, ok := clientset.Discovery().(*fakediscovery.FakeDiscovery)
+	require.True(t, ok)
+	fakeDiscovery.Resources = []*metav1.APIResourceList{
+		{
+			GroupVersion: "example.com/v1",
+			APIResources: []metav1.APIResource{{Name: "widgets", Namespaced: true, Kind: "Widget", Verbs: metav1.Verbs{"list", "patch"}}},
+		},
+	}
+	dynamicClient := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+		widgetResource: "WidgetList",
+		crdResource:    "CustomResourceDefinitionList",
+	}, objects...)
+	return clientset, dynamicClient
+}
+
+func TestWaitForRemoval(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		opts        RemovalWaitOptions
+		objects     []runtime.Object
+		expectedErr string
+	}{
+		{
+			name: "nothing terminating",
+			opts: RemovalWaitOptions{Namespaces: []string{"active", "missing"}, CRDs: []string{"widgets.example.com"}, Timeout: time.Minute},
+		},
+		{
+			name:        "terminating namespace",
+			opts:        RemovalWaitOptions{Namespaces: []string{"active", "terminating"}, Timeout: 10 * time.Millisecond},
+			expectedErr: "namespace/terminating are still terminating after 10ms",
+		},
+		{
+			name:        "terminating crd",
+			opts:        RemovalWaitOptions{CRDs: []string{"widgets.example.com"}, Timeout: 10 * time.Millisecond},
+			objects:     []runtime.Object{newWidgetCRD(true)},
+			expectedErr: "customresourcedefinition/widgets.example.com are still terminating after 10ms",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := testutil.TestContext(t)
+
+			clientset, dynamicClient := newRemovalClients(t, tt.objects...)
+			err := waitForRemoval(ctx, clientset, dynamicClient, tt.opts)
+			if tt.expectedErr != "" {
+				require.ErrorContains(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestRemoveFinalizers(t *testing.T) {
+	t.Parallel()
+	ctx := testutil.TestContext(t)
+
+	clientset, dynamicClient := newRemovalClients(t,
+		newWidgetCRD(true),
+		newWidget("terminating", "deleting", true),
+		newWidget("terminating", "running", false),
+		newWidget("active", "deleting", true),
+	)
+
+	err := removeNamespaceFinalizers(ctx, clientset, dynamicClient, "terminating")
+	require.NoError(t, err)
+	err = removeNamespaceFinalizers(ctx, clientset, dynamicClient, "active")
+	require.NoError(t, err)
+	finalizers := func(namespace, name string) []string {
+		t.Helper()
+		widget, err := dynamicClient.Resource(widgetResource).Namespace(namespace).Get(ctx, name, metav1.GetOptions{})
+		require.NoError(t, err)
+		return widget.GetFinalizers()
+	}
+	require.Empty(t, finalizers("terminating", "deleting"))
+	require.Equal(t, []string{"example.com/cleanup"}, finalizers("terminating", "running"))
+	require.Equal(t, []string{"example.com/cleanup"}, finalizers("active", "deleting"))
+
+	err = removeCustomResourceFinalizers(ctx, dynamicClient, "widgets.example.com")
+	require.NoError(t, err)
+	require.Empty(t, finalizers("active", "deleting"))
+	require.Equal(t, []string{"example.com/cleanup"}, finalizers("terminating", "running"))
+}
@@ -0,0 +1,95 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package cluster contains Zarf-specific cluster management functions.
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/zarf-dev/zarf/src/internal/dns"
+	"github.com/zarf-dev/zarf/src/types"
+)
+
+const (
+	// HostAliasRegistry is the host alias target of the Zarf registry.
+	HostAliasRegistry = "registry"
+	// HostAliasGitServer is the host alias target of the Zarf git server.
+	HostAliasGitServer = "git-server"
+)
+
+// ResolveHostAliases resolves host aliases from hostnames to targets into the IP addresses the hostnames are mapped to
+// in pods. A target is an IP address, an in-cluster service in the form {SERVICE_NAME}.{NAMESPACE}.svc.cluster.local,
+// or registry and git-server for the services of the Zarf registry and git server.
+func (c *Cluster) ResolveHostAliases(ctx context.Context, aliases map[string]string, state *types.ZarfState) ([]corev1.HostAlias, error) {
+	hostnamesByIP := map[string][]string{}
+	for hostname, target := range aliases {
+		ip, err := c.resolveHostAliasTarget(ctx, target, state)
+		if err != nil {
+			return nil, fmt.Errorf("unable to resolve the host alias %s=%s: %w", hostname, target, err)
+		}
+		hostnamesByIP[ip] = append(hostnamesByIP[ip], hostname)
+	}
+	hostAliases := []corev1.HostAlias{}
+	for ip, hostnames := range hostnamesByIP {
+		slices.Sort(hostnames)
+		hostAliases = append(hostAliases, corev1.HostAlias{IP: ip, Hostnames: hostnames})
+	}
+	slices.SortFunc(hostAliases, func(a, b corev1.HostAlias) int {
+		return strings.Compare(a.IP, b.IP)
+	})
+	return hostAliases, nil
+}
+
+func (c *Cluster) resolveHostAliasTarget(ctx context.Context, target string, state *types.ZarfState) (string, error) {
+	switch target {
+	case HostAliasRegistry:
+		if state == nil {
+			return "", fmt.Errorf("the Zarf state is required to resolve the registry")
+		}
+		address, err := c.GetServiceInfoFromRegistryAddress(ctx, state.RegistryInfo.Address)
+		if err != nil {
+			return "", err
+		}
+		host, _, err := net.SplitHostPort(address)
+		if err != nil {
+			host = address
+		}
+		if net.ParseIP(host) == nil || net.ParseIP(host).IsLoopback() {
+			return "", fmt.Errorf("the registry %s is not an in-cluster service or IP address", state.RegistryInfo.Address)
+		}
+		return host, nil
+	case HostAliasGitServer:
+		if state == nil {
+			return "", fmt.Errorf("the Zarf state is required to resolve the git server")
+		}
+		u, err := url.Parse(state.GitServer.Address)
+		if err != nil {
+			return "", err
+		}
+		return c.resolveHostAliasTarget(ctx, u.Hostname(), nil)
+	}
+	if net.ParseIP(target) != nil {
+		return target, nil
+	}
+	namespace, name, err := dns.ParseServiceHostname(target)
+	if err != nil {
+		return "", fmt.Errorf("%s is not an IP address or in-cluster service", target)
+	}
+	svc, err := c.Clientset.CoreV1().Services(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return "", err
+	}
+	if net.ParseIP(svc.Spec.ClusterIP) == nil {
+		return "", fmt.Errorf("the service %s/%s does not have a cluster IP", namespace, name)
+	}
+	return svc.Spec.ClusterIP, nil
+}
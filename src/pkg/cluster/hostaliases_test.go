@@ -0,0 +1,94 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package cluster
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/zarf-dev/zarf/src/test/testutil"
+	"github.com/zarf-dev/zarf/src/types"
+)
+
+func TestResolveHostAliases(t *testing.T) {
+	t.Parallel()
+	ctx := testutil.TestContext(t)
+
+	c := &Cluster{
+		Clientset: fake.NewClientset(
+			&corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{Name: "zarf-docker-registry", Namespace: "zarf"},
+				Spec: corev1.ServiceSpec{
+					Type:      corev1.ServiceTypeNodePort,
+					ClusterIP: "10.43.0.20",
+					Ports:     []corev1.ServicePort{{Port: 5000, NodePort: 31999}},
+				},
+			},
+			&corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{Name: "zarf-gitea-http", Namespace: "zarf"},
+				Spec:       corev1.ServiceSpec{ClusterIP: "10.43.0.10"},
+			},
+			&corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{Name: "headless", Namespace: "zarf"},
+				Spec:       corev1.ServiceSpec{ClusterIP: corev1.ClusterIPNone},
+			},
+		),
+	}
+	state := &types.ZarfState{
+		RegistryInfo: types.RegistryInfo{Address: "127.0.0.1:31999", NodePort: 31999},
+		GitServer:    types.GitServerInfo{Address: types.ZarfInClusterGitServiceURL},
+	}
+
+	tests := []struct {
+		name        string
+		aliases     map[string]string
+		expected    []corev1.HostAlias
+		expectedErr string
+	}{
+		{
+			name: "zarf services",
+			aliases: map[string]string{
+				"registry-1.docker.io": HostAliasRegistry,
+				"index.docker.io":      HostAliasRegistry,
+				"github.com":           HostAliasGitServer,
+			},
+			expected: []corev1.HostAlias{
+				{IP: "10.43.0.10", Hostnames: []string{"github.com"}},
+				{IP: "10.43.0.20", Hostnames: []string{"index.docker.io", "registry-1.docker.io"}},
+			},
+		},
+		{
+			name:     "service and ip",
+			aliases:  map[string]string{"gitlab.com": "zarf-gitea-http.zarf.svc.cluster.local", "quay.io": "192.168.1.10"},
+			expected: []corev1.HostAlias{{IP: "10.43.0.10", Hostnames: []string{"gitlab.com"}}, {IP: "192.168.1.10", Hostnames: []string{"quay.io"}}},
+		},
+		{
+			name:        "external hostname",
+			aliases:     map[string]string{"quay.io": "mirror.example.com"},
+			expectedErr: "unable to resolve the host alias quay.io=mirror.example.com: mirror.example.com is not an IP address or in-cluster service",
+		},
+		{
+			name:        "headless service",
+			aliases:     map[string]string{"quay.io": "headless.zarf.svc.cluster.local"},
+			expectedErr: "the service zarf/headless does not have a cluster IP",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			hostAliases, err := c.ResolveHostAliases(ctx, tt.aliases, state)
+			if tt.expectedErr != "" {
+				require.ErrorContains(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, hostAliases)
+		})
+	}
+}
@@ -0,0 +1,68 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package cluster contains Zarf-specific cluster management functions.
+package cluster
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+// ParseServiceAccount parses a service account reference in the form namespace/name.
+func ParseServiceAccount(ref string) (string, string, error) {
+	namespace, name, ok := strings.Cut(ref, "/")
+	if !ok || namespace == "" || name == "" {
+		return "", "", fmt.Errorf("invalid service account %q: must be in the form namespace/name", ref)
+	}
+	if errs := validation.IsDNS1123Label(namespace); len(errs) > 0 {
+		return "", "", fmt.Errorf("invalid service account namespace %q: %s", namespace, strings.Join(errs, "; "))
+	}
+	if errs := validation.IsDNS1123Subdomain(name); len(errs) > 0 {
+		return "", "", fmt.Errorf("invalid service account name %q: %s", name, strings.Join(errs, "; "))
+	}
+	return namespace, name, nil
+}
+
+// ImpersonateServiceAccount returns a copy of the cluster whose clients act as the service account, so that requests
+// are authorized with the permissions of the service account instead of the ones of the kubeconfig user. The kubeconfig
+// user needs permission to impersonate the service account.
+func (c *Cluster) ImpersonateServiceAccount(namespace, name string) (*Cluster, error) {
+	cfg := impersonatedConfig(c.RestConfig, namespace, name)
+	clientset, err := kubernetes.NewForConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+	watcher, err := WatcherForConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return &Cluster{
+		Clientset:  clientset,
+		RestConfig: cfg,
+		Watcher:    watcher,
+		Instance:   c.Instance,
+	}, nil
+}
+
+// impersonatedConfig returns a copy of the rest config that impersonates the service account.
+func impersonatedConfig(cfg *rest.Config, namespace, name string) *rest.Config {
+	impersonated := rest.CopyConfig(cfg)
+	impersonated.Impersonate = rest.ImpersonationConfig{
+		UserName: fmt.Sprintf("system:serviceaccount:%s:%s", namespace, name),
+	}
+	return impersonated
+}
+
+// ImpersonatedUser returns the user that the cluster clients impersonate, it is empty when they act as the kubeconfig
+// user.
+func (c *Cluster) ImpersonatedUser() string {
+	if c.RestConfig == nil {
+		return ""
+	}
+	return c.RestConfig.Impersonate.UserName
+}
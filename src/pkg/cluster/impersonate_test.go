@@ -0,0 +1,73 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package cluster
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/rest"
+)
+
+func TestParseServiceAccount(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name              string
+		ref               string
+		expectedNamespace string
+		expectedName      string
+		expectedErr       string
+	}{
+		{
+			name:              "valid",
+			ref:               "podinfo/deployer",
+			expectedNamespace: "podinfo",
+			expectedName:      "deployer",
+		},
+		{
+			name:        "missing namespace",
+			ref:         "deployer",
+			expectedErr: `invalid service account "deployer": must be in the form namespace/name`,
+		},
+		{
+			name:        "empty name",
+			ref:         "podinfo/",
+			expectedErr: `invalid service account "podinfo/": must be in the form namespace/name`,
+		},
+		{
+			name:        "invalid namespace",
+			ref:         "Podinfo/deployer",
+			expectedErr: `invalid service account namespace "Podinfo": a lowercase RFC 1123 label must consist of lower case alphanumeric characters or '-', and must start and end with an alphanumeric character (e.g. 'my-name',  or '123-abc', regex used for validation is '[a-z0-9]([-a-z0-9]*[a-z0-9])?')`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			namespace, name, err := ParseServiceAccount(tt.ref)
+			if tt.expectedErr != "" {
+				require.EqualError(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expectedNamespace, namespace)
+			require.Equal(t, tt.expectedName, name)
+		})
+	}
+}
+
+func TestImpersonatedConfig(t *testing.T) {
+	t.Parallel()
+
+	cfg := &rest.Config{
+		Host:        "https://127.0.0.1:6443",
+		BearerToken: "token",
+		Impersonate: rest.ImpersonationConfig{UserName: "admin", Groups: []string{"system:masters"}},
+	}
+	impersonated := impersonatedConfig(cfg, "podinfo", "deployer")
+	require.Equal(t, rest.ImpersonationConfig{UserName: "system:serviceaccount:podinfo:deployer"}, impersonated.Impersonate)
+	require.Equal(t, cfg.Host, impersonated.Host)
+	require.Equal(t, cfg.BearerToken, impersonated.BearerToken)
+	require.Equal(t, "admin", cfg.Impersonate.UserName)
+}
@@ -5,11 +5,15 @@
 package cluster
 
 import (
+	"archive/tar"
+	"compress/gzip"
 	"context"
 	"fmt"
+	"math/rand/v2"
 	"os"
 	"path/filepath"
 	"regexp"
+	"slices"
 	"strings"
 	"time"
 
@@ -34,10 +38,54 @@ import (
 	v1ac "k8s.io/client-go/applyconfigurations/core/v1"
 )
 
+// Seed strategies select how the seed registry image is made available to the cluster during init.
+const (
+	// SeedStrategyNodePort serves the seed image from the injector through a NodePort service.
+	SeedStrategyNodePort = "nodeport"
+	// SeedStrategyHostPort serves the seed image from the injector through an ephemeral host port on its node.
+	SeedStrategyHostPort = "hostport"
+	// SeedStrategyHostPath serves seed images pre-seeded in a directory on the injector node through a host port.
+	SeedStrategyHostPath = "hostpath"
+	// SeedStrategyPullThrough pulls the seed image through an external registry proxy instead of injecting it.
+	SeedStrategyPullThrough = "pull-through"
+)
+
+// SeedStrategies are the supported seed strategies.
+var SeedStrategies = []string{SeedStrategyNodePort, SeedStrategyHostPort, SeedStrategyHostPath, SeedStrategyPullThrough}
+
+// injectorPort is the port the injector serves the seed images on.
+const injectorPort = 5000
+
+// InjectionOptions configure how the injector serves the seed images.
+type InjectionOptions struct {
+	// Strategy is one of the injecting seed strategies, an empty strategy is SeedStrategyNodePort.
+	Strategy string
+	// HostPath is the directory on the nodes that holds the pre-seeded OCI layout for SeedStrategyHostPath.
+	HostPath string
+	// HostPort is the host port of the injector for SeedStrategyHostPort and SeedStrategyHostPath, zero picks a free
+	// ephemeral port.
+	HostPort int32
+}
+
+func (o InjectionOptions) usesHostPort() bool {
+	return o.Strategy == SeedStrategyHostPort || o.Strategy == SeedStrategyHostPath
+}
+
 // StartInjection initializes a Zarf injection into the cluster.
-func (c *Cluster) StartInjection(ctx context.Context, tmpDir, imagesDir string, injectorSeedSrcs []string) error {
+func (c *Cluster) StartInjection(ctx context.Context, tmpDir, imagesDir string, injectorSeedSrcs []string, opts InjectionOptions) error {
 	l := logger.From(ctx)
 	start := time.Now()
+	switch opts.Strategy {
+	case "":
+		opts.Strategy = SeedStrategyNodePort
+	case SeedStrategyNodePort, SeedStrategyHostPort:
+	case SeedStrategyHostPath:
+		if opts.HostPath == "" {
+			return fmt.Errorf("the %s seed strategy requires a host path", SeedStrategyHostPath)
+		}
+	default:
+		return fmt.Errorf("the %s seed strategy does not use the injector", opts.Strategy)
+	}
 	// Stop any previous running injection before starting.
 	err := c.StopInjection(ctx)
 	if err != nil {
@@ -61,8 +109,14 @@ func (c *Cluster) StartInjection(ctx context.Context, tmpDir, imagesDir string,
 	if err != nil {
 		return err
 	}
+	if opts.usesHostPort() {
+		opts.HostPort, err = c.pickHostPort(ctx, injectorNodeName, opts.HostPort)
+		if err != nil {
+			return err
+		}
+	}
 
-	payloadCmNames, shasum, err := c.createPayloadConfigMaps(ctx, spinner, tmpDir, imagesDir, injectorSeedSrcs)
+	payloadCmNames, shasum, err := c.createPayloadConfigMaps(ctx, spinner, tmpDir, imagesDir, injectorSeedSrcs, opts)
 	if err != nil {
 		return fmt.Errorf("unable to generate the injector payload configmaps: %w", err)
 	}
@@ -80,22 +134,40 @@ func (c *Cluster) StartInjection(ctx context.Context, tmpDir, imagesDir string,
 		return err
 	}
 
-	svcAc := v1ac.Service("zarf-injector", ZarfNamespaceName).
-		WithSpec(v1ac.ServiceSpec().
-			WithType(corev1.ServiceTypeNodePort).
-			WithPorts(
-				v1ac.ServicePort().WithPort(int32(5000)),
-			).WithSelector(map[string]string{
-			"app": "zarf-injector",
-		}))
-	svc, err := c.Clientset.CoreV1().Services(*svcAc.Namespace).Apply(ctx, svcAc, metav1.ApplyOptions{Force: true, FieldManager: FieldManagerName})
-	if err != nil {
-		return err
-	}
 	// TODO: Remove use of passing data through global variables.
-	config.ZarfSeedPort = fmt.Sprintf("%d", svc.Spec.Ports[0].NodePort)
+	config.ZarfSeedNode = ""
+	if opts.usesHostPort() {
+		// The host port is only reachable on the injector node so the seed registry has to run there
+		node, err := c.Clientset.CoreV1().Nodes().Get(ctx, injectorNodeName, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		config.ZarfSeedNode = injectorNodeName
+		if hostname, ok := node.Labels[corev1.LabelHostname]; ok {
+			config.ZarfSeedNode = hostname
+		}
+		config.ZarfSeedRegistry = fmt.Sprintf("%s:%d", helpers.IPV4Localhost, opts.HostPort)
+	} else {
+		svcAc := v1ac.Service("zarf-injector", ZarfNamespaceName).
+			WithSpec(v1ac.ServiceSpec().
+				WithType(corev1.ServiceTypeNodePort).
+				// The node port is reached on the IPv4 loopback address, which requires an IPv4 cluster IP on dual-stack
+				// clusters with IPv6 as the primary family.
+				WithIPFamilyPolicy(corev1.IPFamilyPolicyPreferDualStack).
+				WithPorts(
+					v1ac.ServicePort().WithPort(int32(injectorPort)),
+				).WithSelector(map[string]string{
+				"app": "zarf-injector",
+			}))
+		svc, err := c.Clientset.CoreV1().Services(*svcAc.Namespace).Apply(ctx, svcAc, metav1.ApplyOptions{Force: true, FieldManager: FieldManagerName})
+		if err != nil {
+			return err
+		}
+		config.ZarfSeedRegistry = fmt.Sprintf("%s:%d", helpers.IPV4Localhost, svc.Spec.Ports[0].NodePort)
+	}
+	l.Debug("serving seed images", "strategy", opts.Strategy, "registry", config.ZarfSeedRegistry)
 
-	pod := buildInjectionPod(injectorNodeName, injectorImage, payloadCmNames, shasum, resReq)
+	pod := buildInjectionPod(injectorNodeName, injectorImage, payloadCmNames, shasum, resReq, opts)
 	_, err = c.Clientset.CoreV1().Pods(*pod.Namespace).Apply(ctx, pod, metav1.ApplyOptions{Force: true, FieldManager: FieldManagerName})
 	if err != nil {
 		return fmt.Errorf("error creating pod in cluster: %w", err)
@@ -183,45 +255,19 @@ func (c *Cluster) StopInjection(ctx context.Context) error {
 	return nil
 }
 
-func (c *Cluster) createPayloadConfigMaps(ctx context.Context, spinner *message.Spinner, tmpDir, imagesDir string, injectorSeedSrcs []string) ([]string, string, error) {
+func (c *Cluster) createPayloadConfigMaps(ctx context.Context, spinner *message.Spinner, tmpDir, imagesDir string, injectorSeedSrcs []string, opts InjectionOptions) ([]string, string, error) {
 	l := logger.From(ctx)
 	tarPath := filepath.Join(tmpDir, "payload.tar.gz")
-	seedImagesDir := filepath.Join(tmpDir, "seed-images")
-	if err := helpers.CreateDirectory(seedImagesDir, helpers.ReadWriteExecuteUser); err != nil {
-		return nil, "", fmt.Errorf("unable to create the seed images directory: %w", err)
-	}
-
-	localReferenceToDigest := map[string]string{}
-	for _, src := range injectorSeedSrcs {
-		ref, err := transform.ParseImageRef(src)
-		if err != nil {
-			return nil, "", fmt.Errorf("failed to create ref for image %s: %w", src, err)
-		}
-		img, err := utils.LoadOCIImage(imagesDir, ref)
-		if err != nil {
-			return nil, "", err
-		}
-		if err := crane.SaveOCI(img, seedImagesDir); err != nil {
+	if opts.Strategy == SeedStrategyHostPath {
+		// The pre-seeded images are mounted from the host path so the payload is an empty archive
+		if err := writeEmptyArchive(tarPath); err != nil {
 			return nil, "", err
 		}
-		imgDigest, err := img.Digest()
-		if err != nil {
-			return nil, "", err
-		}
-		localReferenceToDigest[ref.Path+ref.TagOrDigest] = imgDigest.String()
-	}
-	if err := utils.AddImageNameAnnotation(seedImagesDir, localReferenceToDigest); err != nil {
-		return nil, "", fmt.Errorf("unable to format OCI layout: %w", err)
+	} else if err := archiveSeedImages(tarPath, tmpDir, imagesDir, injectorSeedSrcs); err != nil {
+		return nil, "", err
 	}
 
 	// Chunk size has to accommodate base64 encoding & etcd 1MB limit
-	tarFileList, err := filepath.Glob(filepath.Join(seedImagesDir, "*"))
-	if err != nil {
-		return nil, "", err
-	}
-	if err := archiver.Archive(tarFileList, tarPath); err != nil {
-		return nil, "", err
-	}
 	payloadChunkSize := 1024 * 768
 	chunks, shasum, err := helpers.ReadFileByChunks(tarPath, payloadChunkSize)
 	if err != nil {
@@ -249,12 +295,111 @@ func (c *Cluster) createPayloadConfigMaps(ctx context.Context, spinner *message.
 		cmNames = append(cmNames, fileName)
 
 		// Give the control plane a 250ms buffer between each configmap
-		time.Sleep(250 * time.Millisecond)
+		select {
+		case <-ctx.Done():
+			return nil, "", ctx.Err()
+		case <-time.After(250 * time.Millisecond):
+		}
 	}
 	return cmNames, shasum, nil
 }
 
-// getImagesAndNodesForInjection checks for images on schedulable nodes within a cluster.
+// archiveSeedImages writes the seed images as an OCI layout to a gzipped tarball.
+func archiveSeedImages(tarPath, tmpDir, imagesDir string, injectorSeedSrcs []string) error {
+	seedImagesDir := filepath.Join(tmpDir, "seed-images")
+	if err := helpers.CreateDirectory(seedImagesDir, helpers.ReadWriteExecuteUser); err != nil {
+		return fmt.Errorf("unable to create the seed images directory: %w", err)
+	}
+
+	localReferenceToDigest := map[string]string{}
+	for _, src := range injectorSeedSrcs {
+		ref, err := transform.ParseImageRef(src)
+		if err != nil {
+			return fmt.Errorf("failed to create ref for image %s: %w", src, err)
+		}
+		img, err := utils.LoadOCIImage(imagesDir, ref)
+		if err != nil {
+			return err
+		}
+		if err := crane.SaveOCI(img, seedImagesDir); err != nil {
+			return err
+		}
+		imgDigest, err := img.Digest()
+		if err != nil {
+			return err
+		}
+		localReferenceToDigest[ref.Path+ref.TagOrDigest] = imgDigest.String()
+	}
+	if err := utils.AddImageNameAnnotation(seedImagesDir, localReferenceToDigest); err != nil {
+		return fmt.Errorf("unable to format OCI layout: %w", err)
+	}
+
+	tarFileList, err := filepath.Glob(filepath.Join(seedImagesDir, "*"))
+	if err != nil {
+		return err
+	}
+	return archiver.Archive(tarFileList, tarPath)
+}
+
+// writeEmptyArchive writes a gzipped tarball without any files.
+func writeEmptyArchive(tarPath string) error {
+	f, err := os.Create(tarPath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	gw := gzip.NewWriter(f)
+	tw := tar.NewWriter(gw)
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	if err := gw.Close(); err != nil {
+		return err
+	}
+	return f.Close()
+}
+
+// maxHostPortAttempts is how many random host ports are tried before giving up on finding a free one.
+const maxHostPortAttempts = 32
+
+// pickHostPort returns the port if it is set and a random port from the IANA ephemeral range, which is outside of the
+// default NodePort range, otherwise. The port must not be bound by any pod on the node. Ports bound by processes
+// outside of pods are not visible through the API, so a known free port has to be set on such nodes.
+func (c *Cluster) pickHostPort(ctx context.Context, nodeName string, port int32) (int32, error) {
+	podList, err := c.Clientset.CoreV1().Pods(corev1.NamespaceAll).List(ctx, metav1.ListOptions{
+		FieldSelector: fmt.Sprintf("spec.nodeName=%s", nodeName),
+	})
+	if err != nil {
+		return 0, err
+	}
+	used := map[int32]bool{}
+	for _, pod := range podList.Items {
+		if pod.Spec.NodeName != nodeName {
+			continue
+		}
+		for _, container := range slices.Concat(pod.Spec.InitContainers, pod.Spec.Containers) {
+			for _, p := range container.Ports {
+				if p.HostPort != 0 {
+					used[p.HostPort] = true
+				}
+			}
+		}
+	}
+	if port != 0 {
+		if used[port] {
+			return 0, fmt.Errorf("host port %d is already used by a pod on node %s", port, nodeName)
+		}
+		return port, nil
+	}
+	for range maxHostPortAttempts {
+		port = int32(49152 + rand.IntN(65535-49152))
+		if !used[port] {
+			return port, nil
+		}
+	}
+	return 0, fmt.Errorf("unable to find a free host port on node %s, set one with --seed-host-port", nodeName)
+}
+
 func (c *Cluster) getInjectorImageAndNode(ctx context.Context, resReq *v1ac.ResourceRequirementsApplyConfiguration) (string, string, error) {
 	// Regex for Zarf seed image
 	zarfImageRegex, err := regexp.Compile(`(?m)^127\.0\.0\.1:`)
@@ -280,6 +425,10 @@ func (c *Cluster) getInjectorImageAndNode(ctx context.Context, resReq *v1ac.Reso
 		if hasBlockingTaints(nodeDetails.Spec.Taints) {
 			continue
 		}
+		// The injector binary and the images it borrows have to run on Linux
+		if !isLinuxNode(nodeDetails) {
+			continue
+		}
 		for _, container := range pod.Spec.Containers {
 			if zarfImageRegex.MatchString(container.Image) {
 				continue
@@ -311,7 +460,18 @@ func hasBlockingTaints(taints []corev1.Taint) bool {
 	return false
 }
 
-func buildInjectionPod(nodeName, image string, payloadCmNames []string, shasum string, resReq *v1ac.ResourceRequirementsApplyConfiguration) *v1ac.PodApplyConfiguration {
+// isLinuxNode returns if the node runs Linux, nodes that do not report their operating system are assumed to.
+func isLinuxNode(node *corev1.Node) bool {
+	if os, ok := node.Labels[corev1.LabelOSStable]; ok {
+		return os == string(corev1.Linux)
+	}
+	if node.Status.NodeInfo.OperatingSystem != "" {
+		return node.Status.NodeInfo.OperatingSystem == string(corev1.Linux)
+	}
+	return true
+}
+
+func buildInjectionPod(nodeName, image string, payloadCmNames []string, shasum string, resReq *v1ac.ResourceRequirementsApplyConfiguration, opts InjectionOptions) *v1ac.PodApplyConfiguration {
 	executeMode := int32(0777)
 	userID := int64(1000)
 	groupID := int64(2000)
@@ -327,15 +487,24 @@ func buildInjectionPod(nodeName, image string, payloadCmNames []string, shasum s
 		v1ac.Volume().
 			WithName("seed").
 			WithEmptyDir(&v1ac.EmptyDirVolumeSourceApplyConfiguration{})}
+	seedMount := v1ac.VolumeMount().
+		WithName("seed").
+		WithMountPath("/zarf-seed")
+	if opts.Strategy == SeedStrategyHostPath {
+		volumes[1] = v1ac.Volume().
+			WithName("seed").
+			WithHostPath(v1ac.HostPathVolumeSource().
+				WithPath(opts.HostPath).
+				WithType(corev1.HostPathDirectory))
+		seedMount = seedMount.WithReadOnly(true)
+	}
 
 	volumeMounts := []*v1ac.VolumeMountApplyConfiguration{
 		v1ac.VolumeMount().
 			WithName("init").
 			WithMountPath("/zarf-init/zarf-injector").
 			WithSubPath("zarf-injector"),
-		v1ac.VolumeMount().
-			WithName("seed").
-			WithMountPath("/zarf-seed"),
+		seedMount,
 	}
 
 	for _, filename := range payloadCmNames {
@@ -351,6 +520,39 @@ func buildInjectionPod(nodeName, image string, payloadCmNames []string, shasum s
 			WithSubPath(filename))
 	}
 
+	container := v1ac.Container().
+		WithName("injector").
+		WithImage(image).
+		WithImagePullPolicy(corev1.PullIfNotPresent).
+		WithWorkingDir("/zarf-init").
+		WithCommand("/zarf-init/zarf-injector", shasum).
+		WithVolumeMounts(volumeMounts...).
+		WithSecurityContext(
+			v1ac.SecurityContext().
+				WithReadOnlyRootFilesystem(true).
+				WithAllowPrivilegeEscalation(false).
+				WithRunAsNonRoot(true).
+				WithCapabilities(v1ac.Capabilities().WithDrop(corev1.Capability("ALL"))),
+		).
+		WithReadinessProbe(
+			v1ac.Probe().
+				WithPeriodSeconds(2).
+				WithSuccessThreshold(1).
+				WithFailureThreshold(10).
+				WithHTTPGet(
+					v1ac.HTTPGetAction().
+						WithPath("/v2/").
+						WithPort(intstr.FromInt(injectorPort)),
+				),
+		).
+		WithResources(resReq)
+	if opts.usesHostPort() {
+		container = container.WithPorts(v1ac.ContainerPort().
+			WithContainerPort(injectorPort).
+			WithHostPort(opts.HostPort).
+			WithProtocol(corev1.ProtocolTCP))
+	}
+
 	pod := v1ac.Pod("injector", ZarfNamespaceName).
 		WithLabels(map[string]string{
 			"app":      "zarf-injector",
@@ -359,6 +561,7 @@ func buildInjectionPod(nodeName, image string, payloadCmNames []string, shasum s
 		WithSpec(
 			v1ac.PodSpec().
 				WithNodeName(nodeName).
+				WithNodeSelector(map[string]string{corev1.LabelOSStable: string(corev1.Linux)}).
 				WithRestartPolicy(corev1.RestartPolicyNever).
 				WithSecurityContext(
 					v1ac.PodSecurityContext().
@@ -370,34 +573,7 @@ func buildInjectionPod(nodeName, image string, payloadCmNames []string, shasum s
 								WithType(corev1.SeccompProfileTypeRuntimeDefault),
 						),
 				).
-				WithContainers(
-					v1ac.Container().
-						WithName("injector").
-						WithImage(image).
-						WithImagePullPolicy(corev1.PullIfNotPresent).
-						WithWorkingDir("/zarf-init").
-						WithCommand("/zarf-init/zarf-injector", shasum).
-						WithVolumeMounts(volumeMounts...).
-						WithSecurityContext(
-							v1ac.SecurityContext().
-								WithReadOnlyRootFilesystem(true).
-								WithAllowPrivilegeEscalation(false).
-								WithRunAsNonRoot(true).
-								WithCapabilities(v1ac.Capabilities().WithDrop(corev1.Capability("ALL"))),
-						).
-						WithReadinessProbe(
-							v1ac.Probe().
-								WithPeriodSeconds(2).
-								WithSuccessThreshold(1).
-								WithFailureThreshold(10).
-								WithHTTPGet(
-									v1ac.HTTPGetAction().
-										WithPath("/v2/").
-										WithPort(intstr.FromInt(5000)),
-								),
-						).
-						WithResources(resReq),
-				).
+				WithContainers(container).
 				WithVolumes(volumes...),
 		)
 
@@ -15,6 +15,7 @@ import (
 	"github.com/google/go-containerregistry/pkg/v1/layout"
 	"github.com/google/go-containerregistry/pkg/v1/random"
 	"github.com/stretchr/testify/require"
+	"github.com/zarf-dev/zarf/src/config"
 	"github.com/zarf-dev/zarf/src/internal/healthchecks"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
@@ -106,8 +107,10 @@ func TestInjector(t *testing.T) {
 		_, err = layout.Write(filepath.Join(tmpDir, "seed-images"), idx)
 		require.NoError(t, err)
 
-		err = c.StartInjection(ctx, tmpDir, t.TempDir(), nil)
+		err = c.StartInjection(ctx, tmpDir, t.TempDir(), nil, InjectionOptions{})
 		require.NoError(t, err)
+		require.Regexp(t, `^127\.0\.0\.1:\d*$`, config.ZarfSeedRegistry)
+		require.Empty(t, config.ZarfSeedNode)
 
 		podList, err := cs.CoreV1().Pods(ZarfNamespaceName).List(ctx, metav1.ListOptions{})
 		require.NoError(t, err)
@@ -162,7 +165,7 @@ func TestBuildInjectionPod(t *testing.T) {
 				corev1.ResourceCPU:    resource.MustParse("1"),
 				corev1.ResourceMemory: resource.MustParse("256Mi"),
 			})
-	pod := buildInjectionPod("injection-node", "docker.io/library/ubuntu:latest", []string{"foo", "bar"}, "shasum", resReq)
+	pod := buildInjectionPod("injection-node", "docker.io/library/ubuntu:latest", []string{"foo", "bar"}, "shasum", resReq, InjectionOptions{})
 	require.Equal(t, "injector", *pod.Name)
 	b, err := json.MarshalIndent(pod, "", "  ")
 	require.NoError(t, err)
@@ -172,6 +175,81 @@ func TestBuildInjectionPod(t *testing.T) {
 	require.Equal(t, strings.TrimSpace(string(expected)), string(b))
 }
 
+func TestBuildInjectionPodHostPath(t *testing.T) {
+	t.Parallel()
+
+	resReq := v1ac.ResourceRequirements()
+	opts := InjectionOptions{Strategy: SeedStrategyHostPath, HostPath: "/var/lib/zarf-seed", HostPort: 50000}
+	pod := buildInjectionPod("injection-node", "docker.io/library/ubuntu:latest", []string{"foo"}, "shasum", resReq, opts)
+
+	container := pod.Spec.Containers[0]
+	require.Len(t, container.Ports, 1)
+	require.Equal(t, int32(5000), *container.Ports[0].ContainerPort)
+	require.Equal(t, int32(50000), *container.Ports[0].HostPort)
+	require.Equal(t, "seed", *pod.Spec.Volumes[1].Name)
+	require.Nil(t, pod.Spec.Volumes[1].EmptyDir)
+	require.Equal(t, "/var/lib/zarf-seed", *pod.Spec.Volumes[1].HostPath.Path)
+	require.Equal(t, "/zarf-seed", *container.VolumeMounts[1].MountPath)
+	require.True(t, *container.VolumeMounts[1].ReadOnly)
+}
+
+func TestStartInjectionHostPort(t *testing.T) {
+	ctx := context.Background()
+	cs := fake.NewClientset()
+	c := &Cluster{
+		Clientset: cs,
+		Watcher:   healthchecks.NewImmediateWatcher(status.CurrentStatus),
+	}
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "node1",
+			Labels: map[string]string{corev1.LabelHostname: "node1.example.com"},
+		},
+		Status: corev1.NodeStatus{
+			Allocatable: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("10"),
+				corev1.ResourceMemory: resource.MustParse("100Gi"),
+			},
+		},
+	}
+	_, err := cs.CoreV1().Nodes().Create(ctx, node, metav1.CreateOptions{})
+	require.NoError(t, err)
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "good", Namespace: "default"},
+		Spec: corev1.PodSpec{
+			NodeName:   "node1",
+			Containers: []corev1.Container{{Image: "ubuntu:latest"}},
+		},
+	}
+	_, err = cs.CoreV1().Pods(pod.Namespace).Create(ctx, pod, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	tmpDir := t.TempDir()
+	err = os.WriteFile(filepath.Join(tmpDir, "zarf-injector"), []byte("foobar"), 0o644)
+	require.NoError(t, err)
+
+	err = c.StartInjection(ctx, tmpDir, t.TempDir(), []string{"ghcr.io/zarf-dev/zarf/registry:v1"}, InjectionOptions{Strategy: SeedStrategyHostPath, HostPath: "/var/lib/zarf-seed"})
+	require.NoError(t, err)
+	require.Equal(t, "node1.example.com", config.ZarfSeedNode)
+	require.Regexp(t, `^127\.0\.0\.1:\d+$`, config.ZarfSeedRegistry)
+
+	svcList, err := cs.CoreV1().Services(ZarfNamespaceName).List(ctx, metav1.ListOptions{})
+	require.NoError(t, err)
+	require.Empty(t, svcList.Items)
+	injector, err := cs.CoreV1().Pods(ZarfNamespaceName).Get(ctx, "injector", metav1.GetOptions{})
+	require.NoError(t, err)
+	hostPort := injector.Spec.Containers[0].Ports[0].HostPort
+	require.GreaterOrEqual(t, hostPort, int32(49152))
+	require.Equal(t, fmt.Sprintf("127.0.0.1:%d", hostPort), config.ZarfSeedRegistry)
+	// The pre-seeded images are not part of the payload
+	cm, err := cs.CoreV1().ConfigMaps(ZarfNamespaceName).Get(ctx, "zarf-payload-000", metav1.GetOptions{})
+	require.NoError(t, err)
+	require.Less(t, len(cm.BinaryData["zarf-payload-000"]), 100)
+
+	err = c.StartInjection(ctx, tmpDir, t.TempDir(), nil, InjectionOptions{Strategy: SeedStrategyPullThrough})
+	require.EqualError(t, err, "the pull-through seed strategy does not use the injector")
+}
+
 func TestGetInjectorImageAndNode(t *testing.T) {
 	t.Parallel()
 
@@ -212,6 +290,20 @@ func TestGetInjectorImageAndNode(t *testing.T) {
 				},
 			},
 		},
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "windows",
+				Labels: map[string]string{
+					corev1.LabelOSStable: "windows",
+				},
+			},
+			Status: corev1.NodeStatus{
+				Allocatable: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("1000m"),
+					corev1.ResourceMemory: resource.MustParse("10Gi"),
+				},
+			},
+		},
 		{
 			ObjectMeta: metav1.ObjectMeta{
 				Name: "good",
@@ -288,6 +380,35 @@ func TestGetInjectorImageAndNode(t *testing.T) {
 			})
 	image, node, err := c.getInjectorImageAndNode(ctx, resReq)
 	require.NoError(t, err)
-	require.Equal(t, "pod-2-container", image)
+	require.Equal(t, "pod-3-container", image)
 	require.Equal(t, "good", node)
 }
+
+func TestPickHostPort(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	cs := fake.NewClientset()
+	c := &Cluster{Clientset: cs}
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "proxy", Namespace: "default"},
+		Spec: corev1.PodSpec{
+			NodeName:   "node1",
+			Containers: []corev1.Container{{Ports: []corev1.ContainerPort{{HostPort: 50000}}}},
+		},
+	}
+	_, err := cs.CoreV1().Pods(pod.Namespace).Create(ctx, pod, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	port, err := c.pickHostPort(ctx, "node1", 0)
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, port, int32(49152))
+	require.NotEqual(t, int32(50000), port)
+
+	port, err = c.pickHostPort(ctx, "node2", 50000)
+	require.NoError(t, err)
+	require.Equal(t, int32(50000), port)
+
+	_, err = c.pickHostPort(ctx, "node1", 50000)
+	require.EqualError(t, err, "host port 50000 is already used by a pod on node node1")
+}
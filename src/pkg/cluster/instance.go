@@ -0,0 +1,159 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package cluster contains Zarf-specific cluster management functions.
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	"github.com/zarf-dev/zarf/src/pkg/logger"
+	"github.com/zarf-dev/zarf/src/types"
+)
+
+// InstanceLabel marks the namespaces owned by a named Zarf instance, namespaces without it belong to the default instance.
+const InstanceLabel = "zarf.dev/instance"
+
+// ValidateInstanceName returns an error if the name can not be used for a Zarf instance. The empty name of the
+// default instance is valid.
+func ValidateInstanceName(instance string) error {
+	if instance == "" {
+		return nil
+	}
+	// The state secret of an instance named backup would be the backup of the default state
+	if instance == "backup" {
+		return fmt.Errorf("invalid Zarf instance name %q: the name is reserved", instance)
+	}
+	if errs := validation.IsDNS1123Label(instance); len(errs) > 0 {
+		return fmt.Errorf("invalid Zarf instance name %q: %s", instance, strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// ZarfStateSecretNameFor returns the name of the secret that holds the state of the Zarf instance.
+func ZarfStateSecretNameFor(instance string) string {
+	if instance == "" {
+		return ZarfStateSecretName
+	}
+	return fmt.Sprintf("%s-%s", ZarfStateSecretName, instance)
+}
+
+// ZarfStateBackupSecretNameFor returns the name of the secret that holds the backup of the state of the Zarf instance.
+func ZarfStateBackupSecretNameFor(instance string) string {
+	if instance == "" {
+		return ZarfStateBackupSecretName
+	}
+	return fmt.Sprintf("%s-%s", ZarfStateBackupSecretName, instance)
+}
+
+// BelongsToInstance returns true if the namespace is owned by the Zarf instance. The Zarf namespace always belongs to
+// the default instance, as it runs the Zarf components every instance shares.
+func BelongsToInstance(namespace corev1.Namespace, instance string) bool {
+	if namespace.Name == ZarfNamespaceName {
+		return instance == ""
+	}
+	return namespace.Labels[InstanceLabel] == instance
+}
+
+// ClaimNamespace returns whether the namespace has to be labeled before the Zarf instance deploys to it and an error
+// if the namespace is owned by another instance. Namespaces that are not managed by Zarf and belong to no instance can
+// be claimed by any instance.
+func ClaimNamespace(namespace corev1.Namespace, instance string) (bool, error) {
+	if BelongsToInstance(namespace, instance) {
+		return false, nil
+	}
+	owner := namespace.Labels[InstanceLabel]
+	if namespace.Name != ZarfNamespaceName && owner == "" && namespace.Labels[ZarfManagedByLabel] != "zarf" {
+		return true, nil
+	}
+	return false, fmt.Errorf("namespace %s belongs to the Zarf instance %s, not %s", namespace.Name, instanceDisplayName(owner), instanceDisplayName(instance))
+}
+
+// ClaimNamespaceForInstance labels the namespace in the cluster as owned by the Zarf instance.
+func (c *Cluster) ClaimNamespaceForInstance(ctx context.Context, namespace *corev1.Namespace, instance string) error {
+	namespace.Labels = maps.Clone(namespace.Labels)
+	if namespace.Labels == nil {
+		namespace.Labels = map[string]string{}
+	}
+	namespace.Labels[InstanceLabel] = instance
+	if _, err := c.Clientset.CoreV1().Namespaces().Update(ctx, namespace, metav1.UpdateOptions{}); err != nil {
+		return fmt.Errorf("unable to label the namespace %s for the Zarf instance %s: %w", namespace.Name, instance, err)
+	}
+	logger.From(ctx).Debug("claimed namespace for the Zarf instance", "name", namespace.Name, "instance", instance)
+	return nil
+}
+
+// instanceDisplayName returns the name of the instance as it is shown to users.
+func instanceDisplayName(instance string) string {
+	if instance == "" {
+		return "default"
+	}
+	return fmt.Sprintf("%q", instance)
+}
+
+// LoadNamespaceZarfState returns the state of the Zarf instance that owns the namespace. A namespace that can not be
+// found is treated as a namespace of the default instance.
+func (c *Cluster) LoadNamespaceZarfState(ctx context.Context, namespace string) (*types.ZarfState, error) {
+	ns, err := c.Clientset.CoreV1().Namespaces().Get(ctx, namespace, metav1.GetOptions{})
+	if err != nil && !kerrors.IsNotFound(err) {
+		return nil, fmt.Errorf("unable to get the namespace %s: %w", namespace, err)
+	}
+	if err != nil {
+		return c.LoadInstanceZarfState(ctx, "")
+	}
+	return c.LoadInstanceZarfState(ctx, ns.Labels[InstanceLabel])
+}
+
+// LoadInstanceZarfState returns the state of the Zarf instance.
+func (c *Cluster) LoadInstanceZarfState(ctx context.Context, instance string) (*types.ZarfState, error) {
+	if instance == c.Instance {
+		return c.LoadZarfState(ctx)
+	}
+	instanceCluster := *c
+	instanceCluster.Instance = instance
+	return instanceCluster.LoadZarfState(ctx)
+}
+
+// newInstanceZarfState returns the state of a new named instance. Named instances share the Zarf namespace and agent
+// of the default instance and bring their own registry, git server and artifact server rather than deploying them.
+func (c *Cluster) newInstanceZarfState(ctx context.Context, initOptions types.ZarfInitOptions) (*types.ZarfState, error) {
+	defaultCluster := *c
+	defaultCluster.Instance = ""
+	defaultState, err := defaultCluster.LoadZarfState(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("the default Zarf instance must be initialized before the instance %s, run zarf init without --instance first: %w", c.Instance, err)
+	}
+	if initOptions.RegistryInfo.Address == "" {
+		return nil, fmt.Errorf("the Zarf instance %s requires an external registry, set it with --registry-url", c.Instance)
+	}
+
+	state := &types.ZarfState{
+		Distro:       defaultState.Distro,
+		StorageClass: defaultState.StorageClass,
+	}
+	state.RegistryInfo = initOptions.RegistryInfo
+	if err := state.RegistryInfo.FillInEmptyValues(); err != nil {
+		return nil, err
+	}
+	// The git server and artifact server are optional, packages with repositories can not be deployed without them
+	if initOptions.GitServer.Address != "" {
+		state.GitServer = initOptions.GitServer
+		if err := state.GitServer.FillInEmptyValues(); err != nil {
+			return nil, err
+		}
+	}
+	if initOptions.ArtifactServer.Address != "" {
+		state.ArtifactServer = initOptions.ArtifactServer
+		state.ArtifactServer.FillInEmptyValues()
+	}
+	logger.From(ctx).Debug("new Zarf instance", "name", c.Instance, "registry", state.RegistryInfo.Address)
+	return state, nil
+}
@@ -0,0 +1,152 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package cluster
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/zarf-dev/zarf/src/types"
+)
+
+func TestValidateInstanceName(t *testing.T) {
+	t.Parallel()
+
+	require.NoError(t, ValidateInstanceName(""))
+	require.NoError(t, ValidateInstanceName("team-a"))
+	require.ErrorContains(t, ValidateInstanceName("Team_A"), `invalid Zarf instance name "Team_A"`)
+	require.ErrorContains(t, ValidateInstanceName("backup"), "the name is reserved")
+
+	require.Equal(t, "zarf-state", ZarfStateSecretNameFor(""))
+	require.Equal(t, "zarf-state-team-a", ZarfStateSecretNameFor("team-a"))
+	require.Equal(t, "zarf-state-backup", ZarfStateBackupSecretNameFor(""))
+	require.Equal(t, "zarf-state-backup-team-a", ZarfStateBackupSecretNameFor("team-a"))
+}
+
+func TestClaimNamespace(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		namespace     corev1.Namespace
+		instance      string
+		expectedClaim bool
+		expectedErr   string
+	}{
+		{
+			name:      "default instance in an unlabeled namespace",
+			namespace: corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "app"}},
+		},
+		{
+			name:          "named instance in an unmanaged namespace",
+			namespace:     corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "app"}},
+			instance:      "team-a",
+			expectedClaim: true,
+		},
+		{
+			name: "named instance in its own namespace",
+			namespace: corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+				Name:   "app",
+				Labels: map[string]string{ZarfManagedByLabel: "zarf", InstanceLabel: "team-a"},
+			}},
+			instance: "team-a",
+		},
+		{
+			name: "named instance in a namespace of the default instance",
+			namespace: corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+				Name:   "app",
+				Labels: map[string]string{ZarfManagedByLabel: "zarf"},
+			}},
+			instance:    "team-a",
+			expectedErr: `namespace app belongs to the Zarf instance default, not "team-a"`,
+		},
+		{
+			name: "default instance in a namespace of a named instance",
+			namespace: corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+				Name:   "app",
+				Labels: map[string]string{InstanceLabel: "team-a"},
+			}},
+			expectedErr: `namespace app belongs to the Zarf instance "team-a", not default`,
+		},
+		{
+			name:        "named instance in the Zarf namespace",
+			namespace:   corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: ZarfNamespaceName}},
+			instance:    "team-a",
+			expectedErr: "namespace zarf belongs to the Zarf instance default",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			claim, err := ClaimNamespace(tt.namespace, tt.instance)
+			if tt.expectedErr != "" {
+				require.ErrorContains(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expectedClaim, claim)
+		})
+	}
+}
+
+func TestInstanceZarfState(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	c := &Cluster{
+		Clientset: fake.NewClientset(
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "default-app"}},
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-a-app", Labels: map[string]string{InstanceLabel: "team-a"}}},
+		),
+		Instance: "team-a",
+	}
+	initOptions := types.ZarfInitOptions{
+		RegistryInfo: types.RegistryInfo{Address: "registry.team-a.example.com", PushUsername: "push", PushPassword: "password"},
+	}
+
+	err := c.InitZarfState(ctx, initOptions)
+	require.ErrorContains(t, err, "the default Zarf instance must be initialized before the instance team-a")
+
+	defaultCluster := &Cluster{Clientset: c.Clientset}
+	defaultState := validZarfState()
+	err = defaultCluster.SaveZarfState(ctx, &defaultState)
+	require.NoError(t, err)
+
+	err = c.InitZarfState(ctx, types.ZarfInitOptions{})
+	require.ErrorContains(t, err, "requires an external registry")
+	err = c.InitZarfState(ctx, initOptions)
+	require.NoError(t, err)
+
+	state, err := c.LoadZarfState(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "team-a", state.Instance)
+	require.Equal(t, DistroIsK3d, state.Distro)
+	require.Equal(t, "registry.team-a.example.com", state.RegistryInfo.Address)
+	require.Equal(t, "push", state.RegistryInfo.PullUsername)
+	require.Empty(t, state.GitServer.Address)
+	require.Empty(t, state.AgentTLS.Key)
+
+	// The namespaces select the state of the instance that owns them
+	state, err = defaultCluster.LoadNamespaceZarfState(ctx, "team-a-app")
+	require.NoError(t, err)
+	require.Equal(t, "team-a", state.Instance)
+	state, err = c.LoadNamespaceZarfState(ctx, "default-app")
+	require.NoError(t, err)
+	require.Empty(t, state.Instance)
+	require.Equal(t, defaultState.RegistryInfo.Address, state.RegistryInfo.Address)
+	state, err = c.LoadNamespaceZarfState(ctx, "missing")
+	require.NoError(t, err)
+	require.Empty(t, state.Instance)
+
+	// Existing namespaces are left alone by named instances
+	ns, err := c.Clientset.CoreV1().Namespaces().Get(ctx, "default-app", metav1.GetOptions{})
+	require.NoError(t, err)
+	require.Empty(t, ns.Labels[AgentLabel])
+}
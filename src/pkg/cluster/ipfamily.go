@@ -0,0 +1,132 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package cluster contains Zarf-specific cluster management functions.
+package cluster
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/zarf-dev/zarf/src/pkg/logger"
+	"github.com/zarf-dev/zarf/src/types"
+)
+
+// IPFamilies returns the IP families of the cluster, detected from the cluster IPs of the Kubernetes API service and
+// the pod CIDRs of the nodes. The internal IP addresses of the nodes are used when neither is available.
+func (c *Cluster) IPFamilies(ctx context.Context) ([]corev1.IPFamily, error) {
+	families := []corev1.IPFamily{}
+	addFamily := func(address string) {
+		family := addressIPFamily(address)
+		if family != "" && !slices.Contains(families, family) {
+			families = append(families, family)
+		}
+	}
+
+	svc, err := c.Clientset.CoreV1().Services(corev1.NamespaceDefault).Get(ctx, "kubernetes", metav1.GetOptions{})
+	if err != nil && !kerrors.IsNotFound(err) {
+		return nil, err
+	}
+	if err == nil {
+		for _, clusterIP := range svc.Spec.ClusterIPs {
+			addFamily(clusterIP)
+		}
+	}
+	nodeList, err := c.Clientset.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for _, node := range nodeList.Items {
+		for _, podCIDR := range node.Spec.PodCIDRs {
+			addFamily(podCIDR)
+		}
+	}
+	if len(families) == 0 {
+		for _, node := range nodeList.Items {
+			for _, address := range node.Status.Addresses {
+				if address.Type == corev1.NodeInternalIP {
+					addFamily(address.Address)
+				}
+			}
+		}
+	}
+	slices.Sort(families)
+	return families, nil
+}
+
+// CheckIPFamilies checks that the registry, git server, artifact server and seed addresses given to init can be used
+// with the IP families of the cluster. Every issue is reported at once so that init fails before the seed registry is
+// deployed rather than while the images are pushed.
+func (c *Cluster) CheckIPFamilies(ctx context.Context, initOptions types.ZarfInitOptions) error {
+	l := logger.From(ctx)
+	families, err := c.IPFamilies(ctx)
+	if err != nil {
+		return fmt.Errorf("unable to detect the IP families of the cluster: %w", err)
+	}
+	if len(families) == 0 {
+		l.Warn("unable to detect the IP families of the cluster, skipping the address checks")
+		return nil
+	}
+	l.Debug("detected the IP families of the cluster", "families", families)
+	return checkIPFamilies(families, initOptions)
+}
+
+func checkIPFamilies(families []corev1.IPFamily, initOptions types.ZarfInitOptions) error {
+	var errs []error
+	// Container runtimes pull from the internal registry through a node port on 127.0.0.1, Kubernetes only serves node
+	// ports on the loopback address for IPv4.
+	if !slices.Contains(families, corev1.IPv4Protocol) && initOptions.RegistryInfo.Address == "" {
+		errs = append(errs, errors.New("the internal registry is served on a node port of 127.0.0.1 which IPv6 only clusters do not route, use an external registry reachable over IPv6 with --registry-url"))
+	}
+	addresses := []struct {
+		flag    string
+		address string
+	}{
+		{"--registry-url", initOptions.RegistryInfo.Address},
+		{"--git-url", initOptions.GitServer.Address},
+		{"--artifact-url", initOptions.ArtifactServer.Address},
+		{"--seed-from", initOptions.SeedFrom},
+		{"--seed-proxy", initOptions.SeedProxy},
+	}
+	for _, a := range addresses {
+		family := addressIPFamily(a.address)
+		if family == "" || slices.Contains(families, family) {
+			continue
+		}
+		errs = append(errs, fmt.Errorf("%s %s is an %s address but the cluster only supports %s", a.flag, a.address, family, families[0]))
+	}
+	return errors.Join(errs...)
+}
+
+// addressIPFamily returns the IP family of the host of an address, CIDR or URL. An empty family is returned for
+// hostnames.
+func addressIPFamily(address string) corev1.IPFamily {
+	host := address
+	if u, err := url.Parse(address); err == nil && u.Host != "" {
+		host = u.Hostname()
+	} else {
+		host, _, _ = strings.Cut(address, "/")
+		if h, _, err := net.SplitHostPort(host); err == nil {
+			host = h
+		}
+		host = strings.Trim(host, "[]")
+	}
+	ip := net.ParseIP(host)
+	switch {
+	case ip == nil:
+		return ""
+	case ip.To4() != nil:
+		return corev1.IPv4Protocol
+	default:
+		return corev1.IPv6Protocol
+	}
+}
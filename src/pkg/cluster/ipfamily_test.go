@@ -0,0 +1,154 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package cluster
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/zarf-dev/zarf/src/test/testutil"
+	"github.com/zarf-dev/zarf/src/types"
+)
+
+func TestIPFamilies(t *testing.T) {
+	t.Parallel()
+	ctx := testutil.TestContext(t)
+
+	tests := []struct {
+		name     string
+		objects  []runtime.Object
+		expected []corev1.IPFamily
+	}{
+		{
+			name: "api service",
+			objects: []runtime.Object{
+				&corev1.Service{
+					ObjectMeta: metav1.ObjectMeta{Name: "kubernetes", Namespace: corev1.NamespaceDefault},
+					Spec:       corev1.ServiceSpec{ClusterIPs: []string{"fd00:10:96::1", "10.96.0.1"}},
+				},
+			},
+			expected: []corev1.IPFamily{corev1.IPv4Protocol, corev1.IPv6Protocol},
+		},
+		{
+			name: "pod cidrs",
+			objects: []runtime.Object{
+				&corev1.Node{
+					ObjectMeta: metav1.ObjectMeta{Name: "node"},
+					Spec:       corev1.NodeSpec{PodCIDRs: []string{"fd00:10:244::/64"}},
+					Status:     corev1.NodeStatus{Addresses: []corev1.NodeAddress{{Type: corev1.NodeInternalIP, Address: "192.168.1.10"}}},
+				},
+			},
+			expected: []corev1.IPFamily{corev1.IPv6Protocol},
+		},
+		{
+			name: "internal ips",
+			objects: []runtime.Object{
+				&corev1.Node{
+					ObjectMeta: metav1.ObjectMeta{Name: "node"},
+					Status: corev1.NodeStatus{Addresses: []corev1.NodeAddress{
+						{Type: corev1.NodeHostName, Address: "node"},
+						{Type: corev1.NodeInternalIP, Address: "192.168.1.10"},
+					}},
+				},
+			},
+			expected: []corev1.IPFamily{corev1.IPv4Protocol},
+		},
+		{
+			name:     "unknown",
+			expected: []corev1.IPFamily{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			c := &Cluster{Clientset: fake.NewClientset(tt.objects...)}
+			families, err := c.IPFamilies(ctx)
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, families)
+		})
+	}
+}
+
+func TestCheckIPFamilies(t *testing.T) {
+	t.Parallel()
+
+	ipv4 := []corev1.IPFamily{corev1.IPv4Protocol}
+	ipv6 := []corev1.IPFamily{corev1.IPv6Protocol}
+	dualStack := []corev1.IPFamily{corev1.IPv4Protocol, corev1.IPv6Protocol}
+
+	tests := []struct {
+		name         string
+		families     []corev1.IPFamily
+		initOptions  types.ZarfInitOptions
+		expectedErrs []string
+	}{
+		{
+			name:     "ipv4 internal registry",
+			families: ipv4,
+		},
+		{
+			name:     "dual-stack addresses",
+			families: dualStack,
+			initOptions: types.ZarfInitOptions{
+				RegistryInfo: types.RegistryInfo{Address: "[fd00::1]:5000"},
+				GitServer:    types.GitServerInfo{Address: "http://10.0.0.5:3000"},
+			},
+		},
+		{
+			name:     "ipv6 external services",
+			families: ipv6,
+			initOptions: types.ZarfInitOptions{
+				RegistryInfo:   types.RegistryInfo{Address: "[fd00::1]:5000"},
+				GitServer:      types.GitServerInfo{Address: "https://git.example.com"},
+				ArtifactServer: types.ArtifactServerInfo{Address: "http://[fd00::2]:3000/api/packages/zarf-git-user"},
+				SeedFrom:       "oci://[fd00::1]:5000/zarf",
+			},
+		},
+		{
+			name:         "ipv6 internal registry",
+			families:     ipv6,
+			expectedErrs: []string{"the internal registry is served on a node port of 127.0.0.1 which IPv6 only clusters do not route"},
+		},
+		{
+			name:     "mismatched addresses",
+			families: ipv6,
+			initOptions: types.ZarfInitOptions{
+				RegistryInfo: types.RegistryInfo{Address: "10.0.0.5:5000"},
+				SeedProxy:    "http://10.0.0.6:8080",
+			},
+			expectedErrs: []string{
+				"--registry-url 10.0.0.5:5000 is an IPv4 address but the cluster only supports IPv6",
+				"--seed-proxy http://10.0.0.6:8080 is an IPv4 address but the cluster only supports IPv6",
+			},
+		},
+		{
+			name:     "ipv6 address on ipv4 cluster",
+			families: ipv4,
+			initOptions: types.ZarfInitOptions{
+				GitServer: types.GitServerInfo{Address: "http://[fd00::1]:3000"},
+			},
+			expectedErrs: []string{"--git-url http://[fd00::1]:3000 is an IPv6 address but the cluster only supports IPv4"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := checkIPFamilies(tt.families, tt.initOptions)
+			if len(tt.expectedErrs) == 0 {
+				require.NoError(t, err)
+				return
+			}
+			for _, expectedErr := range tt.expectedErrs {
+				require.ErrorContains(t, err, expectedErr)
+			}
+		})
+	}
+}
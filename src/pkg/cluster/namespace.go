@@ -5,20 +5,36 @@
 package cluster
 
 import (
+	"cmp"
 	"context"
 	"fmt"
+	"maps"
+	"slices"
 	"time"
 
 	"github.com/avast/retry-go/v4"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
+	"github.com/zarf-dev/zarf/src/api/v1alpha1"
 	"github.com/zarf-dev/zarf/src/pkg/logger"
 	"github.com/zarf-dev/zarf/src/pkg/message"
 	corev1 "k8s.io/api/core/v1"
 	v1ac "k8s.io/client-go/applyconfigurations/core/v1"
 )
 
+const (
+	// ZarfCreatedByPackageAnnotation records the package that created a namespace. Only namespaces created by a
+	// package are deleted when it is removed.
+	ZarfCreatedByPackageAnnotation = "zarf.dev/created-by-package"
+	// ZarfCreatedByComponentAnnotation records the component that created a namespace.
+	ZarfCreatedByComponentAnnotation = "zarf.dev/created-by-component"
+)
+
+// initialNamespaces are the namespaces Kubernetes starts with, which are never labeled by Zarf.
+// https://kubernetes.io/docs/concepts/overview/working-with-objects/namespaces/#initial-namespaces
+var initialNamespaces = []string{"default", "kube-node-lease", "kube-public", "kube-system"}
+
 // DeleteZarfNamespace deletes the Zarf namespace from the connected cluster.
 func (c *Cluster) DeleteZarfNamespace(ctx context.Context) error {
 	start := time.Now()
@@ -80,3 +96,89 @@ func AdoptZarfManagedLabels(labels map[string]string) map[string]string {
 	labels[ZarfManagedByLabel] = "zarf"
 	return labels
 }
+
+// ApplyNamespacePolicies creates or adopts the namespaces of a component according to their policies. Namespaces that
+// are created are annotated with the package and component, so that they are deleted when the component is removed.
+func (c *Cluster) ApplyNamespacePolicies(ctx context.Context, packageName, componentName string, namespaces []v1alpha1.ZarfNamespace) error {
+	l := logger.From(ctx)
+	for _, ns := range namespaces {
+		policy := cmp.Or(ns.Policy, v1alpha1.NamespacePolicyCreate)
+		existing, err := c.Clientset.CoreV1().Namespaces().Get(ctx, ns.Name, metav1.GetOptions{})
+		if err != nil && !kerrors.IsNotFound(err) {
+			return fmt.Errorf("unable to get the namespace %s: %w", ns.Name, err)
+		}
+		if kerrors.IsNotFound(err) {
+			if policy == v1alpha1.NamespacePolicyRequire {
+				return fmt.Errorf("namespace %s is required by component %s but does not exist", ns.Name, componentName)
+			}
+			namespace := NewZarfManagedNamespace(ns.Name)
+			maps.Copy(namespace.Labels, ns.Labels)
+			if c.Instance != "" {
+				namespace.Labels[InstanceLabel] = c.Instance
+			}
+			namespace.Annotations = maps.Clone(ns.Annotations)
+			if namespace.Annotations == nil {
+				namespace.Annotations = map[string]string{}
+			}
+			namespace.Annotations[ZarfCreatedByPackageAnnotation] = packageName
+			namespace.Annotations[ZarfCreatedByComponentAnnotation] = componentName
+			if _, err := c.Clientset.CoreV1().Namespaces().Create(ctx, namespace, metav1.CreateOptions{}); err != nil {
+				return fmt.Errorf("unable to create the namespace %s: %w", ns.Name, err)
+			}
+			l.Info("created namespace", "name", ns.Name, "policy", policy)
+			continue
+		}
+
+		claim, err := ClaimNamespace(*existing, c.Instance)
+		if err != nil {
+			return err
+		}
+		createdByPackage := existing.Annotations[ZarfCreatedByPackageAnnotation] == packageName
+		if policy == v1alpha1.NamespacePolicyCreate && !createdByPackage {
+			return fmt.Errorf("namespace %s already exists and was not created by package %s, use the adopt or require policy to deploy into it", ns.Name, packageName)
+		}
+		if slices.Contains(initialNamespaces, ns.Name) {
+			message.Warnf("Refusing to adopt the initial namespace: %s", ns.Name)
+			l.Warn("refusing to adopt initial namespace", "name", ns.Name)
+			continue
+		}
+		existing.Labels = AdoptZarfManagedLabels(existing.Labels)
+		maps.Copy(existing.Labels, ns.Labels)
+		if claim {
+			existing.Labels[InstanceLabel] = c.Instance
+		}
+		if len(ns.Annotations) > 0 && existing.Annotations == nil {
+			existing.Annotations = map[string]string{}
+		}
+		maps.Copy(existing.Annotations, ns.Annotations)
+		if _, err := c.Clientset.CoreV1().Namespaces().Update(ctx, existing, metav1.UpdateOptions{}); err != nil {
+			return fmt.Errorf("unable to adopt the namespace %s: %w", ns.Name, err)
+		}
+		l.Debug("adopted namespace", "name", ns.Name, "policy", policy)
+	}
+	return nil
+}
+
+// DeleteCreatedNamespaces deletes the namespaces created by the component of the package and returns their names.
+// Namespaces that were adopted are left in place.
+func (c *Cluster) DeleteCreatedNamespaces(ctx context.Context, packageName, componentName string) ([]string, error) {
+	namespaceList, err := c.Clientset.CoreV1().Namespaces().List(ctx, metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("%s=zarf", ZarfManagedByLabel),
+	})
+	if err != nil {
+		return nil, err
+	}
+	deleted := []string{}
+	for _, namespace := range namespaceList.Items {
+		if namespace.Annotations[ZarfCreatedByPackageAnnotation] != packageName || namespace.Annotations[ZarfCreatedByComponentAnnotation] != componentName {
+			continue
+		}
+		logger.From(ctx).Info("deleting namespace created by the package", "name", namespace.Name, "package", packageName, "component", componentName)
+		err := c.Clientset.CoreV1().Namespaces().Delete(ctx, namespace.Name, metav1.DeleteOptions{})
+		if err != nil && !kerrors.IsNotFound(err) {
+			return nil, fmt.Errorf("unable to delete the namespace %s: %w", namespace.Name, err)
+		}
+		deleted = append(deleted, namespace.Name)
+	}
+	return deleted, nil
+}
@@ -0,0 +1,164 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package cluster
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/zarf-dev/zarf/src/api/v1alpha1"
+	"github.com/zarf-dev/zarf/src/test/testutil"
+)
+
+func TestApplyNamespacePolicies(t *testing.T) {
+	t.Parallel()
+
+	shared := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "shared",
+			Labels: map[string]string{"team": "platform"},
+		},
+	}
+	owned := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "owned",
+			Labels:      map[string]string{ZarfManagedByLabel: "zarf"},
+			Annotations: map[string]string{ZarfCreatedByPackageAnnotation: "app", ZarfCreatedByComponentAnnotation: "first"},
+		},
+	}
+
+	tests := []struct {
+		name                string
+		namespace           v1alpha1.ZarfNamespace
+		expectedErr         string
+		expectedLabels      map[string]string
+		expectedAnnotations map[string]string
+	}{
+		{
+			name: "create missing",
+			namespace: v1alpha1.ZarfNamespace{
+				Name:        "app",
+				Labels:      map[string]string{"istio-injection": "enabled"},
+				Annotations: map[string]string{"owner": "app-team"},
+			},
+			expectedLabels: map[string]string{ZarfManagedByLabel: "zarf", "istio-injection": "enabled"},
+			expectedAnnotations: map[string]string{
+				"owner":                          "app-team",
+				ZarfCreatedByPackageAnnotation:   "app",
+				ZarfCreatedByComponentAnnotation: "web",
+			},
+		},
+		{
+			name:        "create existing",
+			namespace:   v1alpha1.ZarfNamespace{Name: "shared", Policy: v1alpha1.NamespacePolicyCreate},
+			expectedErr: "namespace shared already exists and was not created by package app, use the adopt or require policy to deploy into it",
+		},
+		{
+			name:           "create existing created by package",
+			namespace:      v1alpha1.ZarfNamespace{Name: "owned", Labels: map[string]string{"tier": "web"}},
+			expectedLabels: map[string]string{ZarfManagedByLabel: "zarf", "tier": "web"},
+			expectedAnnotations: map[string]string{
+				ZarfCreatedByPackageAnnotation:   "app",
+				ZarfCreatedByComponentAnnotation: "first",
+			},
+		},
+		{
+			name:           "adopt existing",
+			namespace:      v1alpha1.ZarfNamespace{Name: "shared", Policy: v1alpha1.NamespacePolicyAdopt, Annotations: map[string]string{"owner": "app-team"}},
+			expectedLabels: map[string]string{ZarfManagedByLabel: "zarf", "team": "platform"},
+			expectedAnnotations: map[string]string{
+				"owner": "app-team",
+			},
+		},
+		{
+			name:           "adopt missing",
+			namespace:      v1alpha1.ZarfNamespace{Name: "app", Policy: v1alpha1.NamespacePolicyAdopt},
+			expectedLabels: map[string]string{ZarfManagedByLabel: "zarf"},
+			expectedAnnotations: map[string]string{
+				ZarfCreatedByPackageAnnotation:   "app",
+				ZarfCreatedByComponentAnnotation: "web",
+			},
+		},
+		{
+			name:           "require existing",
+			namespace:      v1alpha1.ZarfNamespace{Name: "shared", Policy: v1alpha1.NamespacePolicyRequire},
+			expectedLabels: map[string]string{ZarfManagedByLabel: "zarf", "team": "platform"},
+		},
+		{
+			name:        "require missing",
+			namespace:   v1alpha1.ZarfNamespace{Name: "app", Policy: v1alpha1.NamespacePolicyRequire},
+			expectedErr: "namespace app is required by component web but does not exist",
+		},
+		{
+			name:      "adopt initial namespace",
+			namespace: v1alpha1.ZarfNamespace{Name: "kube-system", Policy: v1alpha1.NamespacePolicyAdopt, Labels: map[string]string{"tier": "web"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := testutil.TestContext(t)
+			kubeSystem := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "kube-system"}}
+			c := &Cluster{Clientset: fake.NewClientset(shared.DeepCopy(), owned.DeepCopy(), kubeSystem)}
+
+			err := c.ApplyNamespacePolicies(ctx, "app", "web", []v1alpha1.ZarfNamespace{tt.namespace})
+			if tt.expectedErr != "" {
+				require.EqualError(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+
+			namespace, err := c.Clientset.CoreV1().Namespaces().Get(ctx, tt.namespace.Name, metav1.GetOptions{})
+			require.NoError(t, err)
+			require.Equal(t, tt.expectedLabels, namespace.Labels)
+			require.Equal(t, tt.expectedAnnotations, namespace.Annotations)
+		})
+	}
+}
+
+func TestDeleteCreatedNamespaces(t *testing.T) {
+	t.Parallel()
+	ctx := testutil.TestContext(t)
+
+	newNamespace := func(name, packageName, componentName string) *corev1.Namespace {
+		return &corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   name,
+				Labels: map[string]string{ZarfManagedByLabel: "zarf"},
+				Annotations: map[string]string{
+					ZarfCreatedByPackageAnnotation:   packageName,
+					ZarfCreatedByComponentAnnotation: componentName,
+				},
+			},
+		}
+	}
+	adopted := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "shared",
+			Labels: map[string]string{ZarfManagedByLabel: "zarf"},
+		},
+	}
+	c := &Cluster{Clientset: fake.NewClientset(
+		newNamespace("web", "app", "web"),
+		newNamespace("db", "app", "db"),
+		newNamespace("other", "other", "web"),
+		adopted,
+	)}
+
+	deleted, err := c.DeleteCreatedNamespaces(ctx, "app", "web")
+	require.NoError(t, err)
+	require.Equal(t, []string{"web"}, deleted)
+
+	namespaceList, err := c.Clientset.CoreV1().Namespaces().List(ctx, metav1.ListOptions{})
+	require.NoError(t, err)
+	names := []string{}
+	for _, namespace := range namespaceList.Items {
+		names = append(names, namespace.Name)
+	}
+	require.ElementsMatch(t, []string{"db", "other", "shared"}, names)
+}
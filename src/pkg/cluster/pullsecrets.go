@@ -0,0 +1,122 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package cluster contains Zarf-specific cluster management functions.
+package cluster
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/zarf-dev/zarf/src/config"
+	"github.com/zarf-dev/zarf/src/pkg/logger"
+	"github.com/zarf-dev/zarf/src/pkg/message"
+	"github.com/zarf-dev/zarf/src/types"
+)
+
+// ParsePullSecretSelector parses the label selector of the namespaces that receive the Zarf managed image pull secret.
+// An empty selector selects every namespace.
+func ParsePullSecretSelector(selector string) (labels.Selector, error) {
+	parsed, err := labels.Parse(selector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid pull secret selector %q: %w", selector, err)
+	}
+	return parsed, nil
+}
+
+// WantsPullSecret returns true if the namespace receives the Zarf managed image pull secret. The Zarf namespace always
+// does, so that the Zarf components can pull their images, and namespaces ignored by the Zarf agent never do.
+func WantsPullSecret(namespace corev1.Namespace, selector labels.Selector) bool {
+	if namespace.Name == ZarfNamespaceName {
+		return true
+	}
+	if namespace.Labels[AgentLabel] == "skip" || namespace.Labels[AgentLabel] == "ignore" {
+		return false
+	}
+	return selector.Matches(labels.Set(namespace.Labels))
+}
+
+// SyncPullSecret creates or updates the Zarf managed image pull secret of a namespace selected by the pull secret
+// selector of the state, and removes it from a namespace that is not. It does nothing without a pull secret selector.
+func (c *Cluster) SyncPullSecret(ctx context.Context, namespace corev1.Namespace, state *types.ZarfState) error {
+	if state.PullSecretSelector == "" {
+		return nil
+	}
+	selector, err := ParsePullSecretSelector(state.PullSecretSelector)
+	if err != nil {
+		return err
+	}
+	return c.syncPullSecret(ctx, namespace, selector, state)
+}
+
+func (c *Cluster) syncPullSecret(ctx context.Context, namespace corev1.Namespace, selector labels.Selector, state *types.ZarfState) error {
+	l := logger.From(ctx)
+	// The namespaces of other Zarf instances are synced with the state of their own instance
+	if namespace.Status.Phase == corev1.NamespaceTerminating || !BelongsToInstance(namespace, state.Instance) {
+		return nil
+	}
+	if !WantsPullSecret(namespace, selector) {
+		secret, err := c.Clientset.CoreV1().Secrets(namespace.Name).Get(ctx, config.ZarfImagePullSecretName, metav1.GetOptions{})
+		if kerrors.IsNotFound(err) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		// Only remove secrets Zarf created
+		if secret.Labels[ZarfManagedByLabel] != "zarf" {
+			return nil
+		}
+		l.Info("removing Zarf managed registry secret from namespace", "name", namespace.Name)
+		err = c.Clientset.CoreV1().Secrets(namespace.Name).Delete(ctx, config.ZarfImagePullSecretName, metav1.DeleteOptions{})
+		if err != nil && !kerrors.IsNotFound(err) {
+			return fmt.Errorf("unable to remove the registry secret from the %s namespace: %w", namespace.Name, err)
+		}
+		return nil
+	}
+	secret, err := c.GenerateRegistryPullCreds(ctx, namespace.Name, config.ZarfImagePullSecretName, state.RegistryInfo)
+	if err != nil {
+		return err
+	}
+	l.Info("applying Zarf managed registry secret for namespace", "name", namespace.Name)
+	_, err = c.Clientset.CoreV1().Secrets(namespace.Name).Apply(ctx, secret, metav1.ApplyOptions{Force: true, FieldManager: FieldManagerName})
+	if err != nil {
+		return fmt.Errorf("unable to apply the registry secret to the %s namespace: %w", namespace.Name, err)
+	}
+	return nil
+}
+
+// SyncPullSecrets brings the Zarf managed image pull secrets of all namespaces in line with the state. With a pull
+// secret selector every selected namespace receives the secret and it is removed from every other namespace, without
+// one the existing secrets are updated.
+func (c *Cluster) SyncPullSecrets(ctx context.Context, state *types.ZarfState) error {
+	if state.PullSecretSelector == "" {
+		return c.UpdateZarfManagedImageSecrets(ctx, state)
+	}
+	selector, err := ParsePullSecretSelector(state.PullSecretSelector)
+	if err != nil {
+		return err
+	}
+
+	spinner := message.NewProgressSpinner("Syncing Zarf-managed image secrets")
+	defer spinner.Stop()
+
+	namespaceList, err := c.Clientset.CoreV1().Namespaces().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+	for _, namespace := range namespaceList.Items {
+		spinner.Updatef("Syncing Zarf-managed image secret for namespace: '%s'", namespace.Name)
+		if err := c.syncPullSecret(ctx, namespace, selector, state); err != nil {
+			return err
+		}
+	}
+
+	spinner.Success()
+	return nil
+}
@@ -0,0 +1,116 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package cluster
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/zarf-dev/zarf/src/config"
+	"github.com/zarf-dev/zarf/src/test/testutil"
+	"github.com/zarf-dev/zarf/src/types"
+)
+
+func TestWantsPullSecret(t *testing.T) {
+	t.Parallel()
+
+	selector, err := ParsePullSecretSelector("team in (podinfo, game)")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		ns       string
+		labels   map[string]string
+		expected bool
+	}{
+		{
+			name:     "selected",
+			ns:       "podinfo",
+			labels:   map[string]string{"team": "podinfo"},
+			expected: true,
+		},
+		{
+			name:     "not selected",
+			ns:       "tenant",
+			labels:   map[string]string{"team": "tenant"},
+			expected: false,
+		},
+		{
+			name:     "zarf namespace",
+			ns:       ZarfNamespaceName,
+			expected: true,
+		},
+		{
+			name:     "ignored by the agent",
+			ns:       "game",
+			labels:   map[string]string{"team": "game", AgentLabel: "ignore"},
+			expected: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			namespace := corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: tt.ns, Labels: tt.labels}}
+			require.Equal(t, tt.expected, WantsPullSecret(namespace, selector))
+		})
+	}
+
+	_, err = ParsePullSecretSelector("team in podinfo")
+	require.ErrorContains(t, err, `invalid pull secret selector "team in podinfo"`)
+}
+
+func TestSyncPullSecrets(t *testing.T) {
+	t.Parallel()
+	ctx := testutil.TestContext(t)
+
+	newNamespace := func(name string, labels map[string]string) *corev1.Namespace {
+		return &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels}}
+	}
+	newSecret := func(namespace string, labels map[string]string) *corev1.Secret {
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: config.ZarfImagePullSecretName, Namespace: namespace, Labels: labels},
+		}
+	}
+	managed := map[string]string{ZarfManagedByLabel: "zarf"}
+	c := &Cluster{
+		Clientset: fake.NewClientset(
+			newNamespace(ZarfNamespaceName, nil),
+			newNamespace("selected", map[string]string{"zarf.dev/pull-secret": "enabled"}),
+			newNamespace("unselected", nil),
+			newNamespace("unmanaged", nil),
+			newNamespace("tenant", map[string]string{"zarf.dev/pull-secret": "enabled", InstanceLabel: "team-a"}),
+			newSecret("unselected", managed),
+			newSecret("tenant", managed),
+			newSecret("unmanaged", nil),
+		),
+	}
+	state := &types.ZarfState{
+		RegistryInfo: types.RegistryInfo{
+			Address:      "registry.example.com",
+			PullUsername: "pull",
+			PullPassword: "pull",
+		},
+		PullSecretSelector: "zarf.dev/pull-secret=enabled",
+	}
+
+	err := c.SyncPullSecrets(ctx, state)
+	require.NoError(t, err)
+
+	for _, namespace := range []string{ZarfNamespaceName, "selected", "unmanaged"} {
+		_, err := c.Clientset.CoreV1().Secrets(namespace).Get(ctx, config.ZarfImagePullSecretName, metav1.GetOptions{})
+		require.NoError(t, err)
+	}
+	_, err = c.Clientset.CoreV1().Secrets("unselected").Get(ctx, config.ZarfImagePullSecretName, metav1.GetOptions{})
+	require.True(t, kerrors.IsNotFound(err))
+	// The namespaces of other Zarf instances keep the secret of their own registry
+	secret, err := c.Clientset.CoreV1().Secrets("tenant").Get(ctx, config.ZarfImagePullSecretName, metav1.GetOptions{})
+	require.NoError(t, err)
+	require.Empty(t, secret.Data)
+}
@@ -0,0 +1,117 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package cluster contains Zarf-specific cluster management functions.
+package cluster
+
+import (
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/defenseunicorns/pkg/helpers/v2"
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	"github.com/zarf-dev/zarf/src/config/lang"
+	"github.com/zarf-dev/zarf/src/types"
+)
+
+// ValidateRegistryAuth checks that the init options provide what the authentication mode of the internal registry
+// requires, robot namespaces for robot and a token endpoint with the credentials of the OIDC provider for oidc.
+func ValidateRegistryAuth(opts types.ZarfInitOptions) error {
+	ri := opts.RegistryInfo
+	if !slices.Contains(types.RegistryAuthModes, ri.Auth) {
+		return fmt.Errorf("the registry authentication must be one of %s", strings.Join(types.RegistryAuthModes, ", "))
+	}
+	if ri.Auth != types.RegistryAuthHtpasswd && ri.Address != "" {
+		return errors.New("the registry authentication can only be set for the internal registry, not with the 'registry-url' flag")
+	}
+	if (ri.Auth == types.RegistryAuthRobot) != (len(opts.RegistryRobotNamespaces) > 0) {
+		return errors.New("robot namespaces must be provided if and only if the registry authentication is robot")
+	}
+	for _, namespace := range opts.RegistryRobotNamespaces {
+		if errs := validation.IsDNS1123Label(namespace); len(errs) > 0 {
+			return fmt.Errorf("invalid robot namespace %q: %s", namespace, strings.Join(errs, ", "))
+		}
+	}
+	if (ri.Auth == types.RegistryAuthOIDC) != opts.RegistryTokenAuth.Enabled() {
+		return errors.New("a token endpoint must be provided if and only if the registry authentication is oidc")
+	}
+	if ri.Auth != types.RegistryAuthOIDC {
+		return nil
+	}
+	tokenAuth := opts.RegistryTokenAuth
+	if tokenAuth.Realm == "" || tokenAuth.Service == "" || tokenAuth.Issuer == "" || tokenAuth.CertFile == "" {
+		return errors.New("the realm, service, issuer and certificate of the token endpoint must all be provided")
+	}
+	if ri.PushPassword == "" || ri.PullPassword == "" {
+		return errors.New("the passwords of the push and pull users of the OIDC provider must be provided with the registry push and pull password flags")
+	}
+	return nil
+}
+
+// setRegistryAuth sets the robot tokens and token endpoint of the authentication mode of the internal registry. Robot
+// namespaces keep their existing tokens, so that only the tokens of new namespaces are generated.
+func setRegistryAuth(ri *types.RegistryInfo, robotNamespaces []string, tokenAuth types.TokenAuthOptions) error {
+	if !ri.IsInternal() {
+		return nil
+	}
+
+	robotTokens := map[string]string{}
+	if ri.Auth == types.RegistryAuthRobot {
+		for _, namespace := range robotNamespaces {
+			if token, ok := ri.RobotTokens[namespace]; ok {
+				robotTokens[namespace] = token
+				continue
+			}
+			token, err := helpers.RandomString(types.ZarfGeneratedPasswordLen)
+			if err != nil {
+				return fmt.Errorf("%s: %w", lang.ErrUnableToGenerateRandomSecret, err)
+			}
+			robotTokens[namespace] = token
+		}
+	}
+	ri.RobotTokens = nil
+	if len(robotTokens) > 0 {
+		ri.RobotTokens = robotTokens
+	}
+
+	ri.TokenAuth = nil
+	if ri.Auth != types.RegistryAuthOIDC {
+		return nil
+	}
+	bundle, err := os.ReadFile(tokenAuth.CertFile)
+	if err != nil {
+		return fmt.Errorf("unable to read the token certificate: %w", err)
+	}
+	if !x509.NewCertPool().AppendCertsFromPEM(bundle) {
+		return errors.New("the token certificate does not contain any PEM encoded certificates")
+	}
+	ri.TokenAuth = &types.RegistryTokenAuth{
+		Realm:          tokenAuth.Realm,
+		Service:        tokenAuth.Service,
+		Issuer:         tokenAuth.Issuer,
+		RootCertBundle: bundle,
+	}
+	return nil
+}
+
+// rotateRobotTokens generates new tokens for all of the robot namespaces.
+func rotateRobotTokens(ri *types.RegistryInfo) error {
+	if len(ri.RobotTokens) == 0 {
+		return nil
+	}
+	robotTokens := map[string]string{}
+	for namespace := range ri.RobotTokens {
+		token, err := helpers.RandomString(types.ZarfGeneratedPasswordLen)
+		if err != nil {
+			return fmt.Errorf("%s: %w", lang.ErrUnableToGenerateRandomSecret, err)
+		}
+		robotTokens[namespace] = token
+	}
+	ri.RobotTokens = robotTokens
+	return nil
+}
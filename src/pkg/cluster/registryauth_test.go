@@ -0,0 +1,213 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package cluster
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/defenseunicorns/pkg/helpers/v2"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zarf-dev/zarf/src/pkg/pki"
+	"github.com/zarf-dev/zarf/src/types"
+)
+
+func TestValidateRegistryAuth(t *testing.T) {
+	t.Parallel()
+
+	tokenAuth := types.TokenAuthOptions{
+		Realm:    "https://keycloak.example.com/realms/zarf/protocol/docker-v2/auth",
+		Service:  "zarf-registry",
+		Issuer:   "https://keycloak.example.com/realms/zarf",
+		CertFile: "token.crt",
+	}
+	oidcRegistry := types.RegistryInfo{
+		Auth:         types.RegistryAuthOIDC,
+		PushUsername: "zarf-push",
+		PushPassword: "push",
+		PullUsername: "zarf-pull",
+		PullPassword: "pull",
+	}
+
+	tests := []struct {
+		name        string
+		opts        types.ZarfInitOptions
+		expectedErr string
+	}{
+		{
+			name: "htpasswd",
+			opts: types.ZarfInitOptions{RegistryInfo: types.RegistryInfo{Auth: types.RegistryAuthHtpasswd}},
+		},
+		{
+			name: "robot",
+			opts: types.ZarfInitOptions{
+				RegistryInfo:            types.RegistryInfo{Auth: types.RegistryAuthRobot},
+				RegistryRobotNamespaces: []string{"podinfo", "game"},
+			},
+		},
+		{
+			name: "oidc",
+			opts: types.ZarfInitOptions{RegistryInfo: oidcRegistry, RegistryTokenAuth: tokenAuth},
+		},
+		{
+			name:        "unknown mode",
+			opts:        types.ZarfInitOptions{RegistryInfo: types.RegistryInfo{Auth: "ldap"}},
+			expectedErr: "the registry authentication must be one of htpasswd, robot, oidc",
+		},
+		{
+			name: "external registry",
+			opts: types.ZarfInitOptions{
+				RegistryInfo:            types.RegistryInfo{Auth: types.RegistryAuthRobot, Address: "registry.example.com"},
+				RegistryRobotNamespaces: []string{"podinfo"},
+			},
+			expectedErr: "not with the 'registry-url' flag",
+		},
+		{
+			name:        "robot without namespaces",
+			opts:        types.ZarfInitOptions{RegistryInfo: types.RegistryInfo{Auth: types.RegistryAuthRobot}},
+			expectedErr: "robot namespaces must be provided if and only if the registry authentication is robot",
+		},
+		{
+			name: "namespaces without robot",
+			opts: types.ZarfInitOptions{
+				RegistryInfo:            types.RegistryInfo{Auth: types.RegistryAuthHtpasswd},
+				RegistryRobotNamespaces: []string{"podinfo"},
+			},
+			expectedErr: "robot namespaces must be provided if and only if the registry authentication is robot",
+		},
+		{
+			name: "invalid namespace",
+			opts: types.ZarfInitOptions{
+				RegistryInfo:            types.RegistryInfo{Auth: types.RegistryAuthRobot},
+				RegistryRobotNamespaces: []string{"Pod_Info"},
+			},
+			expectedErr: `invalid robot namespace "Pod_Info"`,
+		},
+		{
+			name:        "token endpoint without oidc",
+			opts:        types.ZarfInitOptions{RegistryInfo: types.RegistryInfo{Auth: types.RegistryAuthHtpasswd}, RegistryTokenAuth: tokenAuth},
+			expectedErr: "a token endpoint must be provided if and only if the registry authentication is oidc",
+		},
+		{
+			name: "incomplete token endpoint",
+			opts: types.ZarfInitOptions{
+				RegistryInfo:      oidcRegistry,
+				RegistryTokenAuth: types.TokenAuthOptions{Realm: tokenAuth.Realm},
+			},
+			expectedErr: "the realm, service, issuer and certificate of the token endpoint must all be provided",
+		},
+		{
+			name: "oidc without passwords",
+			opts: types.ZarfInitOptions{
+				RegistryInfo:      types.RegistryInfo{Auth: types.RegistryAuthOIDC, PushUsername: "zarf-push"},
+				RegistryTokenAuth: tokenAuth,
+			},
+			expectedErr: "the passwords of the push and pull users of the OIDC provider must be provided",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := ValidateRegistryAuth(tt.opts)
+			if tt.expectedErr != "" {
+				require.ErrorContains(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestSetRegistryAuth(t *testing.T) {
+	t.Parallel()
+
+	internal := func(auth string) types.RegistryInfo {
+		return types.RegistryInfo{
+			Address:  fmt.Sprintf("%s:%d", helpers.IPV4Localhost, types.ZarfInClusterContainerRegistryNodePort),
+			NodePort: types.ZarfInClusterContainerRegistryNodePort,
+			Auth:     auth,
+		}
+	}
+
+	t.Run("robot", func(t *testing.T) {
+		t.Parallel()
+
+		ri := internal(types.RegistryAuthRobot)
+		ri.RobotTokens = map[string]string{"podinfo": "existing", "removed": "removed"}
+		err := setRegistryAuth(&ri, []string{"podinfo", "game"}, types.TokenAuthOptions{})
+		require.NoError(t, err)
+		require.Len(t, ri.RobotTokens, 2)
+		require.Equal(t, "existing", ri.RobotTokens["podinfo"])
+		require.Len(t, ri.RobotTokens["game"], types.ZarfGeneratedPasswordLen)
+		require.Nil(t, ri.TokenAuth)
+
+		username, password := ri.PullCredentials("game")
+		require.Equal(t, "zarf-robot-game", username)
+		require.Equal(t, ri.RobotTokens["game"], password)
+
+		err = rotateRobotTokens(&ri)
+		require.NoError(t, err)
+		require.Len(t, ri.RobotTokens, 2)
+		require.NotEqual(t, "existing", ri.RobotTokens["podinfo"])
+	})
+
+	t.Run("htpasswd", func(t *testing.T) {
+		t.Parallel()
+
+		ri := internal(types.RegistryAuthHtpasswd)
+		ri.RobotTokens = map[string]string{"podinfo": "existing"}
+		err := setRegistryAuth(&ri, nil, types.TokenAuthOptions{})
+		require.NoError(t, err)
+		require.Nil(t, ri.RobotTokens)
+		require.Nil(t, ri.TokenAuth)
+	})
+
+	t.Run("oidc", func(t *testing.T) {
+		t.Parallel()
+
+		tokenPKI, err := pki.GeneratePKI("keycloak.example.com")
+		require.NoError(t, err)
+		certFile := filepath.Join(t.TempDir(), "token.crt")
+		require.NoError(t, os.WriteFile(certFile, tokenPKI.Cert, 0o600))
+		opts := types.TokenAuthOptions{
+			Realm:    "https://keycloak.example.com/realms/zarf/protocol/docker-v2/auth",
+			Service:  "zarf-registry",
+			Issuer:   "https://keycloak.example.com/realms/zarf",
+			CertFile: certFile,
+		}
+
+		ri := internal(types.RegistryAuthOIDC)
+		err = setRegistryAuth(&ri, nil, opts)
+		require.NoError(t, err)
+		expected := &types.RegistryTokenAuth{
+			Realm:          opts.Realm,
+			Service:        opts.Service,
+			Issuer:         opts.Issuer,
+			RootCertBundle: tokenPKI.Cert,
+		}
+		require.Equal(t, expected, ri.TokenAuth)
+
+		invalidFile := filepath.Join(t.TempDir(), "invalid.crt")
+		require.NoError(t, os.WriteFile(invalidFile, []byte("not a certificate"), 0o600))
+		opts.CertFile = invalidFile
+		err = setRegistryAuth(&ri, nil, opts)
+		require.EqualError(t, err, "the token certificate does not contain any PEM encoded certificates")
+	})
+
+	t.Run("external registry", func(t *testing.T) {
+		t.Parallel()
+
+		ri := types.RegistryInfo{Address: "registry.example.com", PullUsername: "pull", PullPassword: "pull"}
+		err := setRegistryAuth(&ri, []string{"podinfo"}, types.TokenAuthOptions{})
+		require.NoError(t, err)
+		require.Nil(t, ri.RobotTokens)
+		username, password := ri.PullCredentials("podinfo")
+		require.Equal(t, "pull", username)
+		require.Equal(t, "pull", password)
+	})
+}
@@ -0,0 +1,60 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package cluster contains Zarf-specific cluster management functions.
+package cluster
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	"github.com/zarf-dev/zarf/src/types"
+)
+
+// ValidateServiceResources checks that the resources are valid quantities and that the requests do not exceed the
+// limits.
+func ValidateServiceResources(opts types.ServiceResources) error {
+	var err error
+	cpuRequest, cpuErr := parseQuantity("cpu request", opts.CPURequest)
+	cpuLimit, limitErr := parseQuantity("cpu limit", opts.CPULimit)
+	err = errors.Join(err, cpuErr, limitErr)
+	if cpuRequest != nil && cpuLimit != nil && cpuRequest.Cmp(*cpuLimit) > 0 {
+		err = errors.Join(err, fmt.Errorf("cpu request %s is greater than the cpu limit %s", opts.CPURequest, opts.CPULimit))
+	}
+	memoryRequest, memoryErr := parseQuantity("memory request", opts.MemoryRequest)
+	memoryLimit, limitErr := parseQuantity("memory limit", opts.MemoryLimit)
+	err = errors.Join(err, memoryErr, limitErr)
+	if memoryRequest != nil && memoryLimit != nil && memoryRequest.Cmp(*memoryLimit) > 0 {
+		err = errors.Join(err, fmt.Errorf("memory request %s is greater than the memory limit %s", opts.MemoryRequest, opts.MemoryLimit))
+	}
+	pvcSize, sizeErr := parseQuantity("pvc size", opts.PVCSize)
+	err = errors.Join(err, sizeErr)
+	if pvcSize != nil && pvcSize.Sign() <= 0 {
+		err = errors.Join(err, fmt.Errorf("pvc size %s must be greater than zero", opts.PVCSize))
+	}
+	if opts.StorageClass != "" {
+		if errs := validation.IsDNS1123Subdomain(opts.StorageClass); len(errs) > 0 {
+			err = errors.Join(err, fmt.Errorf("invalid storage class %q: %s", opts.StorageClass, strings.Join(errs, "; ")))
+		}
+	}
+	return err
+}
+
+// parseQuantity parses the quantity, it returns nil when it is empty.
+func parseQuantity(name, value string) (*resource.Quantity, error) {
+	if value == "" {
+		return nil, nil
+	}
+	quantity, err := resource.ParseQuantity(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s %q: %w", name, value, err)
+	}
+	if quantity.Sign() < 0 {
+		return nil, fmt.Errorf("invalid %s %q: must not be negative", name, value)
+	}
+	return &quantity, nil
+}
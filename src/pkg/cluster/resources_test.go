@@ -0,0 +1,74 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package cluster
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/zarf-dev/zarf/src/types"
+)
+
+func TestValidateServiceResources(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		opts        types.ServiceResources
+		expectedErr string
+	}{
+		{
+			name: "empty",
+			opts: types.ServiceResources{},
+		},
+		{
+			name: "valid",
+			opts: types.ServiceResources{
+				CPURequest:    "500m",
+				CPULimit:      "2",
+				MemoryRequest: "512Mi",
+				MemoryLimit:   "4Gi",
+				PVCSize:       "100Gi",
+				StorageClass:  "fast-ssd",
+			},
+		},
+		{
+			name:        "invalid quantity",
+			opts:        types.ServiceResources{CPURequest: "lots"},
+			expectedErr: `invalid cpu request "lots": quantities must match the regular expression '^([+-]?[0-9.]+)([eEinumkKMGTP]*[-+]?[0-9]*)$'`,
+		},
+		{
+			name:        "negative quantity",
+			opts:        types.ServiceResources{MemoryLimit: "-1Gi"},
+			expectedErr: `invalid memory limit "-1Gi": must not be negative`,
+		},
+		{
+			name:        "request greater than limit",
+			opts:        types.ServiceResources{CPURequest: "2", CPULimit: "500m", MemoryRequest: "2Gi", MemoryLimit: "1Gi"},
+			expectedErr: "cpu request 2 is greater than the cpu limit 500m\nmemory request 2Gi is greater than the memory limit 1Gi",
+		},
+		{
+			name:        "zero pvc size",
+			opts:        types.ServiceResources{PVCSize: "0"},
+			expectedErr: "pvc size 0 must be greater than zero",
+		},
+		{
+			name:        "invalid storage class",
+			opts:        types.ServiceResources{StorageClass: "Fast_SSD"},
+			expectedErr: `invalid storage class "Fast_SSD": a lowercase RFC 1123 subdomain must consist of lower case alphanumeric characters, '-' or '.', and must start and end with an alphanumeric character (e.g. 'example.com', regex used for validation is '[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*')`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := ValidateServiceResources(tt.opts)
+			if tt.expectedErr != "" {
+				require.EqualError(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
@@ -9,10 +9,13 @@ import (
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"net"
+	"strconv"
 
 	corev1 "k8s.io/api/core/v1"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	v1ac "k8s.io/client-go/applyconfigurations/core/v1"
 
 	"github.com/zarf-dev/zarf/src/config"
@@ -37,7 +40,8 @@ type DockerConfigEntryWithAuth struct {
 // GenerateRegistryPullCreds generates a secret containing the registry credentials.
 func (c *Cluster) GenerateRegistryPullCreds(ctx context.Context, namespace, name string, registryInfo types.RegistryInfo) (*v1ac.SecretApplyConfiguration, error) {
 	// Auth field must be username:password and base64 encoded
-	fieldValue := registryInfo.PullUsername + ":" + registryInfo.PullPassword
+	username, password := registryInfo.PullCredentials(namespace)
+	fieldValue := username + ":" + password
 	authEncodedValue := base64.StdEncoding.EncodeToString([]byte(fieldValue))
 
 	dockerConfigJSON := DockerConfig{
@@ -56,7 +60,7 @@ func (c *Cluster) GenerateRegistryPullCreds(ctx context.Context, namespace, name
 	// Build zarf-docker-registry service address string
 	svc, port, err := serviceInfoFromNodePortURL(serviceList.Items, registryInfo.Address)
 	if err == nil {
-		kubeDNSRegistryURL := fmt.Sprintf("%s:%d", svc.Spec.ClusterIP, port)
+		kubeDNSRegistryURL := net.JoinHostPort(svc.Spec.ClusterIP, strconv.Itoa(port))
 		dockerConfigJSON.Auths[kubeDNSRegistryURL] = DockerConfigEntryWithAuth{
 			Auth: authEncodedValue,
 		}
@@ -68,28 +72,38 @@ func (c *Cluster) GenerateRegistryPullCreds(ctx context.Context, namespace, name
 		return nil, fmt.Errorf("unable to marshal the .dockerconfigjson secret data for the image pull secret: %w", err)
 	}
 
+	data := map[string][]byte{
+		".dockerconfigjson": dockerConfigData,
+	}
+	// Propagate the CA of the registry so workloads in the namespace can trust its certificate
+	if ca := registryInfo.TLS.CABundle(); ca != nil {
+		data[caBundleKey] = ca
+	}
 	secretDockerConfig := v1ac.Secret(name, namespace).
 		WithLabels(map[string]string{
 			ZarfManagedByLabel: "zarf",
 		}).
 		WithType(corev1.SecretTypeDockerConfigJson).
-		WithData(map[string][]byte{
-			".dockerconfigjson": dockerConfigData,
-		})
+		WithData(data)
 
 	return secretDockerConfig, nil
 }
 
 // GenerateGitPullCreds generates a secret containing the git credentials.
 func (c *Cluster) GenerateGitPullCreds(namespace, name string, gitServerInfo types.GitServerInfo) *v1ac.SecretApplyConfiguration {
+	data := map[string]string{
+		"username": gitServerInfo.PullUsername,
+		"password": gitServerInfo.PullPassword,
+	}
+	// Propagate the CA of the git server so GitOps controllers trust its certificate
+	if ca := gitServerInfo.TLS.CABundle(); ca != nil {
+		data[caBundleKey] = string(ca)
+	}
 	return v1ac.Secret(name, namespace).
 		WithLabels(map[string]string{
 			ZarfManagedByLabel: "zarf",
 		}).WithType(corev1.SecretTypeOpaque).
-		WithStringData(map[string]string{
-			"username": gitServerInfo.PullUsername,
-			"password": gitServerInfo.PullPassword,
-		})
+		WithStringData(data)
 }
 
 // UpdateZarfManagedImageSecrets updates all Zarf-managed image secrets in all namespaces based on state
@@ -98,12 +112,23 @@ func (c *Cluster) UpdateZarfManagedImageSecrets(ctx context.Context, state *type
 	spinner := message.NewProgressSpinner("Updating existing Zarf-managed image secrets")
 	defer spinner.Stop()
 
+	selector, err := ParsePullSecretSelector(state.PullSecretSelector)
+	if err != nil {
+		return err
+	}
 	namespaceList, err := c.Clientset.CoreV1().Namespaces().List(ctx, metav1.ListOptions{})
 	if err != nil {
 		return err
 	}
-	// Update all image pull secrets
+	// Update all image pull secrets of the namespaces selected by the pull secret selector
 	for _, namespace := range namespaceList.Items {
+		// The namespaces of other Zarf instances have the secrets of their own registry
+		if !BelongsToInstance(namespace, state.Instance) {
+			continue
+		}
+		if namespace.Name != ZarfNamespaceName && !selector.Matches(labels.Set(namespace.Labels)) {
+			continue
+		}
 		currentRegistrySecret, err := c.Clientset.CoreV1().Secrets(namespace.Name).Get(ctx, config.ZarfImagePullSecretName, metav1.GetOptions{})
 		if kerrors.IsNotFound(err) {
 			continue
@@ -142,6 +167,9 @@ func (c *Cluster) UpdateZarfManagedGitSecrets(ctx context.Context, state *types.
 		return err
 	}
 	for _, namespace := range namespaceList.Items {
+		if !BelongsToInstance(namespace, state.Instance) {
+			continue
+		}
 		currentGitSecret, err := c.Clientset.CoreV1().Secrets(namespace.Name).Get(ctx, config.ZarfGitServerSecretName, metav1.GetOptions{})
 		if kerrors.IsNotFound(err) {
 			continue
@@ -181,5 +209,5 @@ func (c *Cluster) GetServiceInfoFromRegistryAddress(ctx context.Context, stateRe
 		return stateRegistryAddress, nil
 	}
 
-	return fmt.Sprintf("%s:%d", svc.Spec.ClusterIP, port), nil
+	return net.JoinHostPort(svc.Spec.ClusterIP, strconv.Itoa(port)), nil
 }
@@ -204,3 +204,93 @@ func TestUpdateZarfManagedSecrets(t *testing.T) {
 		})
 	}
 }
+
+func TestGenerateGitPullCredsCABundle(t *testing.T) {
+	t.Parallel()
+
+	c := &Cluster{}
+	gitServer := types.GitServerInfo{
+		PullUsername: "pull-user",
+		PullPassword: "pull-password",
+	}
+	secret := c.GenerateGitPullCreds("test", config.ZarfGitServerSecretName, gitServer)
+	require.NotContains(t, secret.StringData, "ca.crt")
+
+	gitServer.TLS = &types.TLSInfo{SecretName: ZarfGitServerTLSSecretName, CA: "ca-bundle"}
+	secret = c.GenerateGitPullCreds("test", config.ZarfGitServerSecretName, gitServer)
+	require.Equal(t, map[string]string{"username": "pull-user", "password": "pull-password", "ca.crt": "ca-bundle"}, secret.StringData)
+}
+
+func TestGenerateRegistryPullCredsCABundle(t *testing.T) {
+	t.Parallel()
+	ctx := testutil.TestContext(t)
+
+	c := &Cluster{Clientset: fake.NewClientset()}
+	registryInfo := types.RegistryInfo{
+		PullUsername: "pull-user",
+		PullPassword: "pull-password",
+		Address:      "127.0.0.1:30001",
+	}
+	secret, err := c.GenerateRegistryPullCreds(ctx, "test", config.ZarfImagePullSecretName, registryInfo)
+	require.NoError(t, err)
+	require.NotContains(t, secret.Data, "ca.crt")
+
+	registryInfo.TLS = &types.TLSInfo{SecretName: ZarfRegistryTLSSecretName, CA: "ca-bundle"}
+	secret, err = c.GenerateRegistryPullCreds(ctx, "test", config.ZarfImagePullSecretName, registryInfo)
+	require.NoError(t, err)
+	require.Equal(t, []byte("ca-bundle"), secret.Data["ca.crt"])
+	require.Contains(t, secret.Data, ".dockerconfigjson")
+}
+
+func TestGetServiceInfoFromRegistryAddress(t *testing.T) {
+	t.Parallel()
+	ctx := testutil.TestContext(t)
+
+	newService := func(name, clusterIP string, nodePort int32) *corev1.Service {
+		return &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: ZarfNamespaceName},
+			Spec: corev1.ServiceSpec{
+				Type:      corev1.ServiceTypeNodePort,
+				ClusterIP: clusterIP,
+				Ports:     []corev1.ServicePort{{Port: 5000, NodePort: nodePort}},
+			},
+		}
+	}
+	c := &Cluster{
+		Clientset: fake.NewClientset(
+			newService("ipv4", "10.43.0.20", 31999),
+			newService("ipv6", "fd00:10:96::20", 31998),
+		),
+	}
+
+	tests := []struct {
+		name     string
+		address  string
+		expected string
+	}{
+		{
+			name:     "ipv4 service",
+			address:  "127.0.0.1:31999",
+			expected: "10.43.0.20:5000",
+		},
+		{
+			name:     "ipv6 service",
+			address:  "127.0.0.1:31998",
+			expected: "[fd00:10:96::20]:5000",
+		},
+		{
+			name:     "external registry",
+			address:  "[fd00::1]:5000",
+			expected: "[fd00::1]:5000",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			address, err := c.GetServiceInfoFromRegistryAddress(ctx, tt.address)
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, address)
+		})
+	}
+}
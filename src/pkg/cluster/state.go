@@ -51,8 +51,14 @@ func (c *Cluster) InitZarfState(ctx context.Context, initOptions types.ZarfInitO
 		return fmt.Errorf("failed to check for existing state: %w", err)
 	}
 
-	// If state is nil, this is a new cluster.
-	if state == nil {
+	// If state is nil, this is a new cluster or a new instance of it.
+	if state == nil && c.Instance != "" {
+		spinner.Updatef("New Zarf instance %s", c.Instance)
+		state, err = c.newInstanceZarfState(ctx, initOptions)
+		if err != nil {
+			return err
+		}
+	} else if state == nil {
 		state = &types.ZarfState{}
 		spinner.Updatef("New cluster, no prior Zarf deployments found")
 		l.Debug("new cluster, no prior Zarf deployments found")
@@ -81,6 +87,12 @@ func (c *Cluster) InitZarfState(ctx context.Context, initOptions types.ZarfInitO
 			l.Debug("Detected K8s distro", "name", state.Distro)
 		}
 
+		// Check the addresses against the IP families of the cluster before anything is deployed.
+		err = c.CheckIPFamilies(ctx, initOptions)
+		if err != nil {
+			return err
+		}
+
 		// Setup zarf agent PKI
 		agentTLS, err := pki.GeneratePKI(config.ZarfAgentHost)
 		if err != nil {
@@ -137,6 +149,13 @@ func (c *Cluster) InitZarfState(ctx context.Context, initOptions types.ZarfInitO
 			return fmt.Errorf("unable get default Zarf service account: %w", err)
 		}
 
+		// The internal git server and artifact registry are served over HTTPS when a certificate is provided.
+		if initOptions.GitServerTLS.Enabled() && initOptions.GitServer.Address == "" {
+			initOptions.GitServer.Address = types.ZarfInClusterGitServiceTLSURL
+			if initOptions.ArtifactServer.Address == "" {
+				initOptions.ArtifactServer.Address = types.ZarfInClusterArtifactServiceTLSURL
+			}
+		}
 		err = initOptions.GitServer.FillInEmptyValues()
 		if err != nil {
 			return err
@@ -147,8 +166,22 @@ func (c *Cluster) InitZarfState(ctx context.Context, initOptions types.ZarfInitO
 			return err
 		}
 		state.RegistryInfo = initOptions.RegistryInfo
+		err = setRegistryAuth(&state.RegistryInfo, initOptions.RegistryRobotNamespaces, initOptions.RegistryTokenAuth)
+		if err != nil {
+			return err
+		}
 		initOptions.ArtifactServer.FillInEmptyValues()
 		state.ArtifactServer = initOptions.ArtifactServer
+
+		spinner.Updatef("Applying the registry and git server TLS certificates")
+		state.RegistryInfo.TLS, err = c.ApplyServiceTLS(ctx, RegistryTLSService, initOptions.RegistryTLS)
+		if err != nil {
+			return fmt.Errorf("unable to apply the registry TLS certificate: %w", err)
+		}
+		state.GitServer.TLS, err = c.ApplyServiceTLS(ctx, GitServerTLSService, initOptions.GitServerTLS)
+		if err != nil {
+			return fmt.Errorf("unable to apply the git server TLS certificate: %w", err)
+		}
 	} else {
 		// TODO (@austinabro321) validate immediately in `zarf init` if these are set and not equal and error out if so
 		if helpers.IsNotZeroAndNotEqual(initOptions.GitServer, state.GitServer) {
@@ -156,6 +189,21 @@ func (c *Cluster) InitZarfState(ctx context.Context, initOptions types.ZarfInitO
 			message.ZarfCommand("tools update-creds git")
 			l.Warn("ignoring change in git sever init options on re-init, to update run `zarf tools update-creds git`")
 		}
+		// The registry is deployed again with the storage and authentication modes of the re-init
+		if state.RegistryInfo.IsInternal() && initOptions.RegistryInfo.Storage != "" {
+			state.RegistryInfo.Storage = initOptions.RegistryInfo.Storage
+		}
+		if state.RegistryInfo.IsInternal() && initOptions.RegistryInfo.Auth != "" {
+			// The credentials of the push and pull users are generated by Zarf or come from the OIDC provider
+			if (state.RegistryInfo.Auth == types.RegistryAuthOIDC) != (initOptions.RegistryInfo.Auth == types.RegistryAuthOIDC) {
+				return errors.New("the registry authentication can not be changed to or from oidc on a re-init")
+			}
+			state.RegistryInfo.Auth = initOptions.RegistryInfo.Auth
+			err = setRegistryAuth(&state.RegistryInfo, initOptions.RegistryRobotNamespaces, initOptions.RegistryTokenAuth)
+			if err != nil {
+				return err
+			}
+		}
 		if helpers.IsNotZeroAndNotEqual(initOptions.RegistryInfo, state.RegistryInfo) {
 			message.Warn("Detected a change in Image Registry init options on a re-init. Ignoring... To update run:")
 			message.ZarfCommand("tools update-creds registry")
@@ -168,6 +216,11 @@ func (c *Cluster) InitZarfState(ctx context.Context, initOptions types.ZarfInitO
 		}
 	}
 
+	if len(state.RegistryInfo.RobotTokens) > 0 {
+		message.Warn("The robot tokens of the registry are not scoped to their namespaces, each of them can push and pull every repository of the registry")
+		l.Warn("robot tokens of the registry are not scoped to their namespaces, each of them can push and pull every repository of the registry")
+	}
+
 	switch state.Distro {
 	case DistroIsK3s, DistroIsK3d:
 		state.StorageClass = "local-path"
@@ -183,6 +236,11 @@ func (c *Cluster) InitZarfState(ctx context.Context, initOptions types.ZarfInitO
 		state.StorageClass = initOptions.StorageClass
 	}
 
+	if initOptions.PullSecretSelector != "" {
+		state.PullSecretSelector = initOptions.PullSecretSelector
+	}
+	state.Instance = c.Instance
+
 	spinner.Success()
 
 	// Save the state back to K8s
@@ -196,21 +254,55 @@ func (c *Cluster) InitZarfState(ctx context.Context, initOptions types.ZarfInitO
 // LoadZarfState returns the current zarf/zarf-state secret data or an empty ZarfState.
 func (c *Cluster) LoadZarfState(ctx context.Context) (*types.ZarfState, error) {
 	stateErr := errors.New("failed to load the Zarf State from the cluster, has Zarf been initiated?")
-	secret, err := c.Clientset.CoreV1().Secrets(ZarfNamespaceName).Get(ctx, ZarfStateSecretName, metav1.GetOptions{})
+	secret, err := c.Clientset.CoreV1().Secrets(ZarfNamespaceName).Get(ctx, ZarfStateSecretNameFor(c.Instance), metav1.GetOptions{})
 	if err != nil {
 		return nil, fmt.Errorf("%w: %w", stateErr, err)
 	}
 
+	data, applied, err := MigrateZarfStateData(secret.Data[ZarfStateDataKey])
+	if errors.Is(err, ErrZarfStateNewer) {
+		// The state can still be read, saving it is refused as the fields this version does not know would be lost
+		logger.From(ctx).Warn("the Zarf state was written by a newer version of Zarf and can not be changed by this version", "error", err)
+		data = secret.Data[ZarfStateDataKey]
+	} else if err != nil {
+		return nil, fmt.Errorf("%w: %w", stateErr, err)
+	}
+	for _, migration := range applied {
+		logger.From(ctx).Debug("migrated the Zarf state", "schemaVersion", migration.Version, "description", migration.Description)
+	}
+
 	state := &types.ZarfState{}
-	err = json.Unmarshal(secret.Data[ZarfStateDataKey], &state)
+	err = json.Unmarshal(data, &state)
 	if err != nil {
 		return nil, fmt.Errorf("%w: %w", stateErr, err)
 	}
+	addStateSecrets(state)
 	c.debugPrintZarfState(ctx, state)
 	return state, nil
 }
 
-func (c *Cluster) sanitizeZarfState(state *types.ZarfState) *types.ZarfState {
+// addStateSecrets registers the credentials held in the state so they are redacted from log output.
+func addStateSecrets(state *types.ZarfState) {
+	if state == nil {
+		return
+	}
+	logger.AddSecret(
+		state.GitServer.PushPassword,
+		state.GitServer.PullPassword,
+		state.RegistryInfo.PushPassword,
+		state.RegistryInfo.PullPassword,
+		state.RegistryInfo.Secret,
+		state.ArtifactServer.PushToken,
+		string(state.AgentTLS.Key),
+	)
+	for _, token := range state.RegistryInfo.RobotTokens {
+		logger.AddSecret(token)
+	}
+}
+
+// SanitizeZarfState redacts the credentials of the state, nested pointers are left in place so a shallow copy of a
+// state can be sanitized.
+func SanitizeZarfState(state *types.ZarfState) *types.ZarfState {
 	// Overwrite the AgentTLS information
 	state.AgentTLS.CA = []byte("**sanitized**")
 	state.AgentTLS.Cert = []byte("**sanitized**")
@@ -224,6 +316,16 @@ func (c *Cluster) sanitizeZarfState(state *types.ZarfState) *types.ZarfState {
 	state.RegistryInfo.PushPassword = "**sanitized**"
 	state.RegistryInfo.PullPassword = "**sanitized**"
 	state.RegistryInfo.Secret = "**sanitized**"
+	if state.RegistryInfo.ProjectPassword != "" {
+		state.RegistryInfo.ProjectPassword = "**sanitized**"
+	}
+	if state.RegistryInfo.RobotTokens != nil {
+		robotTokens := map[string]string{}
+		for namespace := range state.RegistryInfo.RobotTokens {
+			robotTokens[namespace] = "**sanitized**"
+		}
+		state.RegistryInfo.RobotTokens = robotTokens
+	}
 
 	// Overwrite the ArtifactServer secret
 	state.ArtifactServer.PushToken = "**sanitized**"
@@ -237,7 +339,7 @@ func (c *Cluster) debugPrintZarfState(ctx context.Context, state *types.ZarfStat
 	}
 	// this is a shallow copy, nested pointers WILL NOT be copied
 	oldState := *state
-	sanitized := c.sanitizeZarfState(&oldState)
+	sanitized := SanitizeZarfState(&oldState)
 	b, err := json.MarshalIndent(sanitized, "", "  ")
 	if err != nil {
 		return
@@ -248,13 +350,18 @@ func (c *Cluster) debugPrintZarfState(ctx context.Context, state *types.ZarfStat
 
 // SaveZarfState takes a given state and persists it to the Zarf/zarf-state secret.
 func (c *Cluster) SaveZarfState(ctx context.Context, state *types.ZarfState) error {
+	if state.SchemaVersion > ZarfStateSchemaVersion {
+		return fmt.Errorf("%w, saving it with schema version %d would lose the fields of schema version %d", ErrZarfStateNewer, ZarfStateSchemaVersion, state.SchemaVersion)
+	}
+	state.SchemaVersion = ZarfStateSchemaVersion
+	addStateSecrets(state)
 	c.debugPrintZarfState(ctx, state)
 
 	data, err := json.Marshal(&state)
 	if err != nil {
 		return err
 	}
-	secret := v1ac.Secret(ZarfStateSecretName, ZarfNamespaceName).
+	secret := v1ac.Secret(ZarfStateSecretNameFor(c.Instance), ZarfNamespaceName).
 		WithLabels(map[string]string{
 			ZarfManagedByLabel: "zarf",
 		}).
@@ -278,17 +385,22 @@ func MergeZarfState(oldState *types.ZarfState, initOptions types.ZarfInitOptions
 		// TODO: Replace use of reflections with explicit setting
 		newState.RegistryInfo = helpers.MergeNonZero(newState.RegistryInfo, initOptions.RegistryInfo)
 
-		// Set the new passwords if they should be autogenerated
-		if newState.RegistryInfo.PushPassword == oldState.RegistryInfo.PushPassword && oldState.RegistryInfo.IsInternal() {
+		// Set the new passwords if they should be autogenerated, the passwords of the users of an OIDC provider can
+		// only be changed by the provider
+		generated := oldState.RegistryInfo.IsInternal() && oldState.RegistryInfo.Auth != types.RegistryAuthOIDC
+		if newState.RegistryInfo.PushPassword == oldState.RegistryInfo.PushPassword && generated {
 			if newState.RegistryInfo.PushPassword, err = helpers.RandomString(types.ZarfGeneratedPasswordLen); err != nil {
 				return nil, fmt.Errorf("%s: %w", lang.ErrUnableToGenerateRandomSecret, err)
 			}
 		}
-		if newState.RegistryInfo.PullPassword == oldState.RegistryInfo.PullPassword && oldState.RegistryInfo.IsInternal() {
+		if newState.RegistryInfo.PullPassword == oldState.RegistryInfo.PullPassword && generated {
 			if newState.RegistryInfo.PullPassword, err = helpers.RandomString(types.ZarfGeneratedPasswordLen); err != nil {
 				return nil, fmt.Errorf("%s: %w", lang.ErrUnableToGenerateRandomSecret, err)
 			}
 		}
+		if err := rotateRobotTokens(&newState.RegistryInfo); err != nil {
+			return nil, err
+		}
 	}
 	if slices.Contains(services, message.GitKey) {
 		// TODO: Replace use of reflections with explicit setting
@@ -0,0 +1,233 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package cluster contains Zarf-specific cluster management functions.
+package cluster
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"maps"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	v1ac "k8s.io/client-go/applyconfigurations/core/v1"
+
+	"github.com/zarf-dev/zarf/src/config"
+	"github.com/zarf-dev/zarf/src/types"
+)
+
+// ZarfStateBackupSecretName is the name of the secret that holds the Zarf state as it was before it was last edited.
+const ZarfStateBackupSecretName = "zarf-state-backup"
+
+// stateField is a field of the Zarf state that can be inspected and edited on its own.
+type stateField struct {
+	get func(state *types.ZarfState) string
+	set func(state *types.ZarfState, value string) error
+	// sensitive fields are redacted when they are printed.
+	sensitive bool
+}
+
+// stateFields are the fields of the Zarf state that can be edited, keyed by their JSON path in the state.
+var stateFields = map[string]stateField{
+	"registryInfo.address": {
+		get: func(state *types.ZarfState) string { return state.RegistryInfo.Address },
+		set: func(state *types.ZarfState, value string) error {
+			state.RegistryInfo.Address = value
+			return nil
+		},
+	},
+	"registryInfo.nodePort": {
+		get: func(state *types.ZarfState) string { return strconv.Itoa(state.RegistryInfo.NodePort) },
+		set: func(state *types.ZarfState, value string) error {
+			nodePort, err := strconv.Atoi(value)
+			if err != nil {
+				return fmt.Errorf("invalid node port %q: %w", value, err)
+			}
+			state.RegistryInfo.NodePort = nodePort
+			return nil
+		},
+	},
+	"gitServer.address": {
+		get: func(state *types.ZarfState) string { return state.GitServer.Address },
+		set: func(state *types.ZarfState, value string) error {
+			state.GitServer.Address = value
+			return nil
+		},
+	},
+	"artifactServer.address": {
+		get: func(state *types.ZarfState) string { return state.ArtifactServer.Address },
+		set: func(state *types.ZarfState, value string) error {
+			state.ArtifactServer.Address = value
+			return nil
+		},
+	},
+	"storageClass": {
+		get: func(state *types.ZarfState) string { return state.StorageClass },
+		set: func(state *types.ZarfState, value string) error {
+			state.StorageClass = value
+			return nil
+		},
+	},
+	"pullSecretSelector": {
+		get: func(state *types.ZarfState) string { return state.PullSecretSelector },
+		set: func(state *types.ZarfState, value string) error {
+			state.PullSecretSelector = value
+			return nil
+		},
+	},
+	"agentTLS.ca": {
+		get: func(state *types.ZarfState) string { return string(state.AgentTLS.CA) },
+		set: func(state *types.ZarfState, value string) error {
+			state.AgentTLS.CA = []byte(value)
+			return nil
+		},
+	},
+	"agentTLS.cert": {
+		get: func(state *types.ZarfState) string { return string(state.AgentTLS.Cert) },
+		set: func(state *types.ZarfState, value string) error {
+			state.AgentTLS.Cert = []byte(value)
+			return nil
+		},
+	},
+	"agentTLS.key": {
+		get: func(state *types.ZarfState) string { return string(state.AgentTLS.Key) },
+		set: func(state *types.ZarfState, value string) error {
+			state.AgentTLS.Key = []byte(value)
+			return nil
+		},
+		sensitive: true,
+	},
+}
+
+// ZarfStateFields returns the fields of the Zarf state that can be edited.
+func ZarfStateFields() []string {
+	return slices.Sorted(maps.Keys(stateFields))
+}
+
+// GetZarfStateField returns the value of an editable field of the Zarf state.
+func GetZarfStateField(state *types.ZarfState, key string) (string, error) {
+	field, ok := stateFields[key]
+	if !ok {
+		return "", fmt.Errorf("unknown Zarf state field %q, the fields are %s", key, strings.Join(ZarfStateFields(), ", "))
+	}
+	return field.get(state), nil
+}
+
+// IsSensitiveZarfStateField returns true if the value of an editable field of the Zarf state must be redacted when
+// it is printed.
+func IsSensitiveZarfStateField(key string) bool {
+	return stateFields[key].sensitive
+}
+
+// SetZarfStateField sets an editable field of the Zarf state, the state should be validated once every field is set.
+func SetZarfStateField(state *types.ZarfState, key, value string) error {
+	field, ok := stateFields[key]
+	if !ok {
+		return fmt.Errorf("unknown Zarf state field %q, the fields are %s", key, strings.Join(ZarfStateFields(), ", "))
+	}
+	return field.set(state, value)
+}
+
+// ValidateZarfState returns an error for every value of the Zarf state that Zarf can not work with.
+func ValidateZarfState(state *types.ZarfState) error {
+	var err error
+	if state.Distro == "" {
+		err = errors.Join(err, errors.New("distro is required"))
+	}
+	if state.SchemaVersion > ZarfStateSchemaVersion {
+		err = errors.Join(err, fmt.Errorf("%w, schema version %d is newer than version %d", ErrZarfStateNewer, state.SchemaVersion, ZarfStateSchemaVersion))
+	}
+	if state.StorageClass != "" {
+		if errs := validation.IsDNS1123Subdomain(state.StorageClass); len(errs) > 0 {
+			err = errors.Join(err, fmt.Errorf("invalid storage class %q: %s", state.StorageClass, strings.Join(errs, "; ")))
+		}
+	}
+	if _, selectorErr := ParsePullSecretSelector(state.PullSecretSelector); selectorErr != nil {
+		err = errors.Join(err, selectorErr)
+	}
+
+	if state.RegistryInfo.Address == "" {
+		err = errors.Join(err, errors.New("registry address is required"))
+	} else if u, parseErr := url.Parse("//" + state.RegistryInfo.Address); parseErr != nil || u.Host == "" || strings.Contains(state.RegistryInfo.Address, "://") {
+		err = errors.Join(err, fmt.Errorf("invalid registry address %q, it must be a host with an optional port and path", state.RegistryInfo.Address))
+	}
+	if state.RegistryInfo.NodePort < 0 || state.RegistryInfo.NodePort > 65535 {
+		err = errors.Join(err, fmt.Errorf("invalid registry node port %d", state.RegistryInfo.NodePort))
+	}
+	if state.RegistryInfo.Storage != "" && !slices.Contains(types.RegistryStorageModes, state.RegistryInfo.Storage) {
+		err = errors.Join(err, fmt.Errorf("invalid registry storage mode %q", state.RegistryInfo.Storage))
+	}
+	if state.RegistryInfo.Auth != "" && !slices.Contains(types.RegistryAuthModes, state.RegistryInfo.Auth) {
+		err = errors.Join(err, fmt.Errorf("invalid registry authentication mode %q", state.RegistryInfo.Auth))
+	}
+
+	err = errors.Join(err, validateServerURL("git server", state.GitServer.Address))
+	err = errors.Join(err, validateServerURL("artifact server", state.ArtifactServer.Address))
+	err = errors.Join(err, validateAgentTLS(state.AgentTLS))
+	return err
+}
+
+// validateAgentTLS validates that the agent certificate matches its key and is issued for the agent service by the
+// agent certificate authority. The agent TLS is empty when the agent was never deployed, e.g. in YOLO mode.
+func validateAgentTLS(agentTLS types.GeneratedPKI) error {
+	if len(agentTLS.CA) == 0 && len(agentTLS.Cert) == 0 && len(agentTLS.Key) == 0 {
+		return nil
+	}
+	pair, err := tls.X509KeyPair(agentTLS.Cert, agentTLS.Key)
+	if err != nil {
+		return fmt.Errorf("invalid agent certificate and key: %w", err)
+	}
+	leaf, err := x509.ParseCertificate(pair.Certificate[0])
+	if err != nil {
+		return fmt.Errorf("invalid agent certificate: %w", err)
+	}
+	roots := x509.NewCertPool()
+	if !roots.AppendCertsFromPEM(agentTLS.CA) {
+		return errors.New("invalid agent certificate authority, it must be a PEM encoded certificate")
+	}
+	_, err = leaf.Verify(x509.VerifyOptions{DNSName: config.ZarfAgentHost, Roots: roots})
+	if err != nil {
+		return fmt.Errorf("the agent certificate is not valid for %s: %w", config.ZarfAgentHost, err)
+	}
+	return nil
+}
+
+// validateServerURL validates the URL of a server, an empty URL is valid as the server is not in use.
+func validateServerURL(name, address string) error {
+	if address == "" {
+		return nil
+	}
+	u, err := url.Parse(address)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("invalid %s address %q, it must be an http or https URL", name, address)
+	}
+	return nil
+}
+
+// BackupZarfState copies the Zarf state secret to the backup secret, replacing the previous backup.
+func (c *Cluster) BackupZarfState(ctx context.Context) error {
+	secret, err := c.Clientset.CoreV1().Secrets(ZarfNamespaceName).Get(ctx, ZarfStateSecretNameFor(c.Instance), metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to load the Zarf State from the cluster, has Zarf been initiated?: %w", err)
+	}
+	backup := v1ac.Secret(ZarfStateBackupSecretNameFor(c.Instance), ZarfNamespaceName).
+		WithLabels(map[string]string{
+			ZarfManagedByLabel: "zarf",
+		}).
+		WithType(corev1.SecretTypeOpaque).
+		WithData(secret.Data)
+	_, err = c.Clientset.CoreV1().Secrets(ZarfNamespaceName).Apply(ctx, backup, metav1.ApplyOptions{Force: true, FieldManager: FieldManagerName})
+	if err != nil {
+		return fmt.Errorf("unable to back up the zarf state secret: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,222 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package cluster
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/zarf-dev/zarf/src/config"
+	"github.com/zarf-dev/zarf/src/pkg/pki"
+	"github.com/zarf-dev/zarf/src/types"
+)
+
+// agentTLS is generated once as generating a PKI is slow.
+var agentTLS = func() types.GeneratedPKI {
+	agentTLS, err := pki.GeneratePKI(config.ZarfAgentHost)
+	if err != nil {
+		panic(err)
+	}
+	return agentTLS
+}()
+
+func validZarfState() types.ZarfState {
+	return types.ZarfState{
+		AgentTLS:      agentTLS,
+		SchemaVersion: ZarfStateSchemaVersion,
+		Distro:        DistroIsK3d,
+		GitServer:     types.GitServerInfo{Address: types.ZarfInClusterGitServiceURL},
+		RegistryInfo:  types.RegistryInfo{Address: "127.0.0.1:31999", NodePort: 31999, Storage: types.RegistryStoragePVC, Auth: types.RegistryAuthHtpasswd},
+		ArtifactServer: types.ArtifactServerInfo{
+			Address: types.ZarfInClusterArtifactServiceURL,
+		},
+	}
+}
+
+func TestZarfStateFields(t *testing.T) {
+	t.Parallel()
+
+	state := validZarfState()
+	for _, key := range ZarfStateFields() {
+		value, err := GetZarfStateField(&state, key)
+		require.NoError(t, err)
+		err = SetZarfStateField(&state, key, value)
+		require.NoError(t, err)
+	}
+	require.Equal(t, validZarfState(), state)
+
+	err := SetZarfStateField(&state, "registryInfo.nodePort", "30001")
+	require.NoError(t, err)
+	require.Equal(t, 30001, state.RegistryInfo.NodePort)
+	err = SetZarfStateField(&state, "registryInfo.nodePort", "high")
+	require.ErrorContains(t, err, "invalid node port")
+	err = SetZarfStateField(&state, "agentTLS.key", "key")
+	require.NoError(t, err)
+	require.Equal(t, []byte("key"), state.AgentTLS.Key)
+	require.True(t, IsSensitiveZarfStateField("agentTLS.key"))
+	require.False(t, IsSensitiveZarfStateField("agentTLS.cert"))
+	_, err = GetZarfStateField(&state, "registryInfo.pushPassword")
+	require.ErrorContains(t, err, "unknown Zarf state field")
+}
+
+func TestValidateZarfState(t *testing.T) {
+	t.Parallel()
+
+	otherAgentTLS, err := pki.GeneratePKI(config.ZarfAgentHost)
+	require.NoError(t, err)
+	exampleAgentTLS, err := pki.GeneratePKI("agent.example.com")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name        string
+		edit        func(state *types.ZarfState)
+		expectedErr string
+	}{
+		{
+			name: "valid",
+			edit: func(_ *types.ZarfState) {},
+		},
+		{
+			name: "external registry with a path",
+			edit: func(state *types.ZarfState) {
+				state.RegistryInfo.Address = "registry.example.com:5000/zarf"
+				state.RegistryInfo.NodePort = 0
+			},
+		},
+		{
+			name: "registry address with a scheme",
+			edit: func(state *types.ZarfState) {
+				state.RegistryInfo.Address = "https://registry.example.com"
+			},
+			expectedErr: `invalid registry address "https://registry.example.com"`,
+		},
+		{
+			name: "no registry address",
+			edit: func(state *types.ZarfState) {
+				state.RegistryInfo.Address = ""
+			},
+			expectedErr: "registry address is required",
+		},
+		{
+			name: "node port out of range",
+			edit: func(state *types.ZarfState) {
+				state.RegistryInfo.NodePort = 70000
+			},
+			expectedErr: "invalid registry node port 70000",
+		},
+		{
+			name: "git server without a scheme",
+			edit: func(state *types.ZarfState) {
+				state.GitServer.Address = "git.example.com"
+			},
+			expectedErr: `invalid git server address "git.example.com"`,
+		},
+		{
+			name: "artifact server with another scheme",
+			edit: func(state *types.ZarfState) {
+				state.ArtifactServer.Address = "ftp://artifacts.example.com"
+			},
+			expectedErr: `invalid artifact server address "ftp://artifacts.example.com"`,
+		},
+		{
+			name: "invalid storage class",
+			edit: func(state *types.ZarfState) {
+				state.StorageClass = "Not_Valid"
+			},
+			expectedErr: `invalid storage class "Not_Valid"`,
+		},
+		{
+			name: "invalid pull secret selector",
+			edit: func(state *types.ZarfState) {
+				state.PullSecretSelector = "a in (b"
+			},
+			expectedErr: "invalid pull secret selector",
+		},
+		{
+			name: "agent certificate of another key",
+			edit: func(state *types.ZarfState) {
+				state.AgentTLS.Key = otherAgentTLS.Key
+			},
+			expectedErr: "invalid agent certificate and key",
+		},
+		{
+			name: "agent certificate of another certificate authority",
+			edit: func(state *types.ZarfState) {
+				state.AgentTLS.CA = otherAgentTLS.CA
+			},
+			expectedErr: "the agent certificate is not valid for agent-hook.zarf.svc",
+		},
+		{
+			name: "agent certificate of another host",
+			edit: func(state *types.ZarfState) {
+				state.AgentTLS = exampleAgentTLS
+			},
+			expectedErr: "the agent certificate is not valid for agent-hook.zarf.svc",
+		},
+		{
+			name: "no agent",
+			edit: func(state *types.ZarfState) {
+				state.AgentTLS = types.GeneratedPKI{}
+			},
+		},
+		{
+			name: "no distro",
+			edit: func(state *types.ZarfState) {
+				state.Distro = ""
+			},
+			expectedErr: "distro is required",
+		},
+		{
+			name: "newer schema version",
+			edit: func(state *types.ZarfState) {
+				state.SchemaVersion = ZarfStateSchemaVersion + 1
+			},
+			expectedErr: ErrZarfStateNewer.Error(),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			state := validZarfState()
+			tt.edit(&state)
+			err := ValidateZarfState(&state)
+			if tt.expectedErr != "" {
+				require.ErrorContains(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestBackupZarfState(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	c := &Cluster{Clientset: fake.NewClientset()}
+	err := c.BackupZarfState(ctx)
+	require.ErrorContains(t, err, "has Zarf been initiated?")
+
+	_, err = c.Clientset.CoreV1().Secrets(ZarfNamespaceName).Create(ctx, &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: ZarfStateSecretName, Namespace: ZarfNamespaceName},
+		Data:       map[string][]byte{ZarfStateDataKey: []byte(`{"distro":"k3d"}`)},
+	}, metav1.CreateOptions{})
+	require.NoError(t, err)
+	err = c.BackupZarfState(ctx)
+	require.NoError(t, err)
+
+	state := validZarfState()
+	err = c.SaveZarfState(ctx, &state)
+	require.NoError(t, err)
+	backup, err := c.Clientset.CoreV1().Secrets(ZarfNamespaceName).Get(ctx, ZarfStateBackupSecretName, metav1.GetOptions{})
+	require.NoError(t, err)
+	require.JSONEq(t, `{"distro":"k3d"}`, string(backup.Data[ZarfStateDataKey]))
+	require.Equal(t, "zarf", backup.Labels[ZarfManagedByLabel])
+}
@@ -0,0 +1,142 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package cluster contains Zarf-specific cluster management functions.
+package cluster
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/defenseunicorns/pkg/helpers/v2"
+	"github.com/zarf-dev/zarf/src/pkg/logger"
+	"github.com/zarf-dev/zarf/src/types"
+)
+
+// ZarfStateSchemaVersion is the version of the schema of the Zarf state this version of Zarf reads and writes. It is
+// the version of the last state migration.
+const ZarfStateSchemaVersion = 1
+
+// ErrZarfStateNewer is returned when the Zarf state was written by a newer version of Zarf.
+var ErrZarfStateNewer = errors.New("the Zarf state was written by a newer version of Zarf")
+
+// StateMigration upgrades the Zarf state from the previous schema version to Version.
+type StateMigration struct {
+	// Schema version of the state after the migration
+	Version int
+	// Description of the changes the migration makes to the state
+	Description string
+	// Migrate changes the state in place, it is only run on states at the previous schema version
+	Migrate func(state map[string]any) error
+}
+
+// stateMigrations are the migrations of the Zarf state in the order they are run. States written before the schema
+// version was recorded are at version 0.
+var stateMigrations = []StateMigration{
+	{
+		Version:     1,
+		Description: "record the storage and authentication modes of the internal registry",
+		Migrate:     migrateRegistryModes,
+	},
+}
+
+// MigrateZarfStateData runs the migrations of the Zarf state data that are newer than its schema version and returns
+// the migrated data along with the migrations that were run.
+func MigrateZarfStateData(data []byte) ([]byte, []StateMigration, error) {
+	state := map[string]any{}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	// Keep numbers as they were written rather than converting them to floats
+	dec.UseNumber()
+	if err := dec.Decode(&state); err != nil {
+		return nil, nil, err
+	}
+
+	version := 0
+	if v, ok := state["schemaVersion"]; ok {
+		n, ok := v.(json.Number)
+		if !ok {
+			return nil, nil, fmt.Errorf("invalid schema version of the Zarf state: %v", v)
+		}
+		i, err := n.Int64()
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid schema version of the Zarf state: %w", err)
+		}
+		version = int(i)
+	}
+	if version > ZarfStateSchemaVersion {
+		return nil, nil, fmt.Errorf("%w, schema version %d is newer than version %d, upgrade Zarf to continue", ErrZarfStateNewer, version, ZarfStateSchemaVersion)
+	}
+
+	applied := []StateMigration{}
+	for _, migration := range stateMigrations {
+		if migration.Version <= version {
+			continue
+		}
+		if err := migration.Migrate(state); err != nil {
+			return nil, nil, fmt.Errorf("unable to migrate the Zarf state to schema version %d: %w", migration.Version, err)
+		}
+		state["schemaVersion"] = migration.Version
+		applied = append(applied, migration)
+	}
+	if len(applied) == 0 {
+		return data, applied, nil
+	}
+
+	b, err := json.Marshal(state)
+	if err != nil {
+		return nil, nil, err
+	}
+	return b, applied, nil
+}
+
+// MigrateZarfState runs the migrations of the Zarf state in the cluster that are newer than its schema version and
+// returns the migrations that were run. The migrated state is only saved when dryRun is false.
+func (c *Cluster) MigrateZarfState(ctx context.Context, dryRun bool) ([]StateMigration, error) {
+	secret, err := c.Clientset.CoreV1().Secrets(ZarfNamespaceName).Get(ctx, ZarfStateSecretNameFor(c.Instance), metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to load the Zarf State from the cluster, has Zarf been initiated?: %w", err)
+	}
+	data, applied, err := MigrateZarfStateData(secret.Data[ZarfStateDataKey])
+	if err != nil {
+		return nil, err
+	}
+	if dryRun || len(applied) == 0 {
+		return applied, nil
+	}
+
+	state := &types.ZarfState{}
+	if err := json.Unmarshal(data, state); err != nil {
+		return nil, err
+	}
+	if err := c.SaveZarfState(ctx, state); err != nil {
+		return nil, err
+	}
+	logger.From(ctx).Info("migrated the Zarf state", "schemaVersion", state.SchemaVersion, "migrations", len(applied))
+	return applied, nil
+}
+
+// migrateRegistryModes records the modes of internal registries initialized before the storage and authentication
+// modes were recorded, which use a persistent volume claim and htpasswd.
+func migrateRegistryModes(state map[string]any) error {
+	registryInfo, ok := state["registryInfo"].(map[string]any)
+	if !ok {
+		return nil
+	}
+	address, _ := registryInfo["address"].(string)
+	nodePort, _ := registryInfo["nodePort"].(json.Number)
+	if address == "" || address != fmt.Sprintf("%s:%s", helpers.IPV4Localhost, nodePort) {
+		return nil
+	}
+	if storage, _ := registryInfo["storage"].(string); storage == "" {
+		registryInfo["storage"] = types.RegistryStoragePVC
+	}
+	if auth, _ := registryInfo["auth"].(string); auth == "" {
+		registryInfo["auth"] = types.RegistryAuthHtpasswd
+	}
+	return nil
+}
@@ -0,0 +1,155 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package cluster
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/zarf-dev/zarf/src/types"
+)
+
+func TestStateMigrations(t *testing.T) {
+	t.Parallel()
+
+	// Every schema version has exactly one migration and the last one is the version this version of Zarf writes.
+	for i, migration := range stateMigrations {
+		require.Equal(t, i+1, migration.Version)
+		require.NotEmpty(t, migration.Description)
+	}
+	require.Equal(t, ZarfStateSchemaVersion, stateMigrations[len(stateMigrations)-1].Version)
+}
+
+func TestMigrateZarfStateData(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name            string
+		data            string
+		expected        string
+		expectedApplied []int
+		expectedErr     error
+	}{
+		{
+			name:            "unversioned internal registry",
+			data:            `{"distro":"k3d","registryInfo":{"address":"127.0.0.1:31999","nodePort":31999}}`,
+			expected:        `{"distro":"k3d","registryInfo":{"address":"127.0.0.1:31999","auth":"htpasswd","nodePort":31999,"storage":"pvc"},"schemaVersion":1}`,
+			expectedApplied: []int{1},
+		},
+		{
+			name:            "unversioned internal registry with modes",
+			data:            `{"registryInfo":{"address":"127.0.0.1:31999","nodePort":31999,"storage":"s3","auth":"robot"}}`,
+			expected:        `{"registryInfo":{"address":"127.0.0.1:31999","auth":"robot","nodePort":31999,"storage":"s3"},"schemaVersion":1}`,
+			expectedApplied: []int{1},
+		},
+		{
+			name:            "unversioned external registry",
+			data:            `{"registryInfo":{"address":"registry.example.com","nodePort":0}}`,
+			expected:        `{"registryInfo":{"address":"registry.example.com","nodePort":0},"schemaVersion":1}`,
+			expectedApplied: []int{1},
+		},
+		{
+			name:            "unknown fields are kept",
+			data:            `{"someFutureField":{"enabled":true}}`,
+			expected:        `{"schemaVersion":1,"someFutureField":{"enabled":true}}`,
+			expectedApplied: []int{1},
+		},
+		{
+			name:            "current version",
+			data:            `{"schemaVersion":1,"registryInfo":{"address":"127.0.0.1:31999","nodePort":31999}}`,
+			expected:        `{"schemaVersion":1,"registryInfo":{"address":"127.0.0.1:31999","nodePort":31999}}`,
+			expectedApplied: []int{},
+		},
+		{
+			name:        "newer version",
+			data:        `{"schemaVersion":99}`,
+			expectedErr: ErrZarfStateNewer,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			data, applied, err := MigrateZarfStateData([]byte(tt.data))
+			if tt.expectedErr != nil {
+				require.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			require.JSONEq(t, tt.expected, string(data))
+			versions := []int{}
+			for _, migration := range applied {
+				versions = append(versions, migration.Version)
+			}
+			require.Equal(t, tt.expectedApplied, versions)
+		})
+	}
+}
+
+func TestMigrateZarfState(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	legacy := `{"distro":"k3d","registryInfo":{"address":"127.0.0.1:31999","nodePort":31999,"pushUsername":"zarf-push"}}`
+	c := &Cluster{
+		Clientset: fake.NewClientset(&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: ZarfStateSecretName, Namespace: ZarfNamespaceName},
+			Data:       map[string][]byte{ZarfStateDataKey: []byte(legacy)},
+		}),
+	}
+	stateData := func() []byte {
+		t.Helper()
+		secret, err := c.Clientset.CoreV1().Secrets(ZarfNamespaceName).Get(ctx, ZarfStateSecretName, metav1.GetOptions{})
+		require.NoError(t, err)
+		return secret.Data[ZarfStateDataKey]
+	}
+
+	applied, err := c.MigrateZarfState(ctx, true)
+	require.NoError(t, err)
+	require.Len(t, applied, 1)
+	require.JSONEq(t, legacy, string(stateData()))
+
+	// Loading the state migrates it in memory without saving it.
+	state, err := c.LoadZarfState(ctx)
+	require.NoError(t, err)
+	require.Equal(t, ZarfStateSchemaVersion, state.SchemaVersion)
+	require.Equal(t, types.RegistryStoragePVC, state.RegistryInfo.Storage)
+	require.Equal(t, types.RegistryAuthHtpasswd, state.RegistryInfo.Auth)
+	require.JSONEq(t, legacy, string(stateData()))
+
+	applied, err = c.MigrateZarfState(ctx, false)
+	require.NoError(t, err)
+	require.Len(t, applied, 1)
+	saved := types.ZarfState{}
+	require.NoError(t, json.Unmarshal(stateData(), &saved))
+	require.Equal(t, *state, saved)
+
+	applied, err = c.MigrateZarfState(ctx, false)
+	require.NoError(t, err)
+	require.Empty(t, applied)
+}
+
+func TestSaveZarfStateNewer(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	c := &Cluster{
+		Clientset: fake.NewClientset(&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: ZarfStateSecretName, Namespace: ZarfNamespaceName},
+			Data:       map[string][]byte{ZarfStateDataKey: []byte(`{"schemaVersion":99,"distro":"k3d"}`)},
+		}),
+	}
+	state, err := c.LoadZarfState(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "k3d", state.Distro)
+
+	err = c.SaveZarfState(ctx, state)
+	require.ErrorIs(t, err, ErrZarfStateNewer)
+}
@@ -0,0 +1,288 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package cluster contains Zarf-specific cluster management functions.
+package cluster
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/avast/retry-go/v4"
+	"github.com/defenseunicorns/pkg/helpers/v2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	v1ac "k8s.io/client-go/applyconfigurations/core/v1"
+	"k8s.io/client-go/dynamic"
+
+	"github.com/zarf-dev/zarf/src/pkg/logger"
+	"github.com/zarf-dev/zarf/src/types"
+)
+
+// Names of the secrets holding the certificates of the Zarf managed services.
+const (
+	ZarfRegistryTLSSecretName  = "zarf-registry-tls"
+	ZarfGitServerTLSSecretName = "zarf-git-server-tls"
+)
+
+// caBundleKey is the secret key cert-manager and Flux read the CA bundle from.
+const caBundleKey = "ca.crt"
+
+var certificateResource = schema.GroupVersionResource{Group: "cert-manager.io", Version: "v1", Resource: "certificates"}
+
+// TLSService is a Zarf managed service that can be served with a user provided certificate.
+type TLSService struct {
+	// Name of the kubernetes.io/tls secret holding the certificate
+	SecretName string
+	// Name of the service the certificate is served on
+	ServiceName string
+	// Name of the deployment serving the certificate
+	DeploymentName string
+}
+
+var (
+	// RegistryTLSService is the internal registry deployed by the init package.
+	RegistryTLSService = TLSService{
+		SecretName:     ZarfRegistryTLSSecretName,
+		ServiceName:    ZarfRegistryName,
+		DeploymentName: ZarfRegistryName,
+	}
+	// GitServerTLSService is the internal git server deployed by the init package.
+	GitServerTLSService = TLSService{
+		SecretName:     ZarfGitServerTLSSecretName,
+		ServiceName:    ZarfGitServerName,
+		DeploymentName: "zarf-gitea",
+	}
+)
+
+// DNSName returns the service DNS name workloads reach the service on, which the certificate has to be valid for.
+func (s TLSService) DNSName() string {
+	return fmt.Sprintf("%s.%s.svc.cluster.local", s.ServiceName, ZarfNamespaceName)
+}
+
+// ValidateTLSOptions checks that the options provide either a certificate with its key or a cert-manager issuer.
+func ValidateTLSOptions(opts types.TLSOptions) error {
+	if opts.Issuer != "" && (opts.CertFile != "" || opts.KeyFile != "") {
+		return errors.New("a certificate and a cert-manager issuer can not be used together")
+	}
+	if (opts.CertFile == "") != (opts.KeyFile == "") {
+		return errors.New("a certificate and its key must be provided together")
+	}
+	if opts.CAFile != "" && !opts.Enabled() {
+		return errors.New("a CA bundle requires a certificate or a cert-manager issuer")
+	}
+	if opts.Issuer != "" {
+		if _, _, err := parseIssuer(opts.Issuer); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ApplyServiceTLS stores the certificate described by the options in the TLS secret of the service. Certificates from
+// a cert-manager issuer are requested with a Certificate resource that cert-manager keeps renewed. It returns nil when
+// the options do not provide a certificate. The CA bundle is stored with the certificate, from where the init package
+// distributes it to the container runtimes of the nodes and Zarf to the namespaces it manages.
+func (c *Cluster) ApplyServiceTLS(ctx context.Context, svc TLSService, opts types.TLSOptions) (*types.TLSInfo, error) {
+	if !opts.Enabled() {
+		return nil, nil
+	}
+	err := ValidateTLSOptions(opts)
+	if err != nil {
+		return nil, err
+	}
+	var ca []byte
+	if opts.CAFile != "" {
+		ca, err = os.ReadFile(opts.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read the CA bundle: %w", err)
+		}
+	}
+
+	if opts.Issuer != "" {
+		issuedCA, err := c.requestCertificate(ctx, svc, opts.Issuer)
+		if err != nil {
+			return nil, err
+		}
+		if len(ca) == 0 {
+			ca = issuedCA
+		}
+	} else {
+		cert, err := os.ReadFile(opts.CertFile)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read the certificate: %w", err)
+		}
+		key, err := os.ReadFile(opts.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read the certificate key: %w", err)
+		}
+		loopback, err := verifyCertificate(cert, key, ca, svc.DNSName())
+		if err != nil {
+			return nil, err
+		}
+		if !loopback {
+			logger.From(ctx).Warn("certificate is not valid for the loopback address, node port pulls and Zarf tunnels can not verify it",
+				"service", svc.ServiceName, "address", helpers.IPV4Localhost)
+		}
+		data := map[string][]byte{
+			corev1.TLSCertKey:       cert,
+			corev1.TLSPrivateKeyKey: key,
+		}
+		if len(ca) > 0 {
+			data[caBundleKey] = ca
+		}
+		secret := v1ac.Secret(svc.SecretName, ZarfNamespaceName).
+			WithLabels(map[string]string{
+				ZarfManagedByLabel: "zarf",
+			}).
+			WithType(corev1.SecretTypeTLS).
+			WithData(data)
+		_, err = c.Clientset.CoreV1().Secrets(ZarfNamespaceName).Apply(ctx, secret, metav1.ApplyOptions{Force: true, FieldManager: FieldManagerName})
+		if err != nil {
+			return nil, fmt.Errorf("unable to apply the TLS secret %s: %w", svc.SecretName, err)
+		}
+	}
+	logger.From(ctx).Info("applied TLS certificate", "service", svc.ServiceName, "secret", svc.SecretName)
+
+	return &types.TLSInfo{
+		SecretName: svc.SecretName,
+		Issuer:     opts.Issuer,
+		CA:         string(ca),
+	}, nil
+}
+
+// RotateServiceTLS replaces the certificate of the service and restarts the deployment serving it so the new
+// certificate is picked up.
+func (c *Cluster) RotateServiceTLS(ctx context.Context, svc TLSService, opts types.TLSOptions) (*types.TLSInfo, error) {
+	tlsInfo, err := c.ApplyServiceTLS(ctx, svc, opts)
+	if err != nil {
+		return nil, err
+	}
+	patch := fmt.Sprintf(`{"spec":{"template":{"metadata":{"annotations":{"kubectl.kubernetes.io/restartedAt":%q}}}}}`, time.Now().Format(time.RFC3339))
+	_, err = c.Clientset.AppsV1().Deployments(ZarfNamespaceName).Patch(ctx, svc.DeploymentName, k8stypes.StrategicMergePatchType, []byte(patch), metav1.PatchOptions{FieldManager: FieldManagerName})
+	if err != nil {
+		return nil, fmt.Errorf("unable to restart %s: %w", svc.DeploymentName, err)
+	}
+	return tlsInfo, nil
+}
+
+// requestCertificate applies a cert-manager Certificate for the service and waits for it to be issued. It returns the
+// CA bundle cert-manager stored with the certificate, if any.
+func (c *Cluster) requestCertificate(ctx context.Context, svc TLSService, issuer string) ([]byte, error) {
+	kind, name, err := parseIssuer(issuer)
+	if err != nil {
+		return nil, err
+	}
+	certificate := &unstructured.Unstructured{
+		Object: map[string]any{
+			"apiVersion": "cert-manager.io/v1",
+			"kind":       "Certificate",
+			"metadata": map[string]any{
+				"name":      svc.SecretName,
+				"namespace": ZarfNamespaceName,
+				"labels": map[string]any{
+					ZarfManagedByLabel: "zarf",
+				},
+			},
+			"spec": map[string]any{
+				"secretName":  svc.SecretName,
+				"ipAddresses": []any{helpers.IPV4Localhost},
+				"dnsNames":    []any{svc.DNSName()},
+				"issuerRef": map[string]any{
+					"group": "cert-manager.io",
+					"kind":  kind,
+					"name":  name,
+				},
+			},
+		},
+	}
+	dynamicClient, err := dynamic.NewForConfig(c.RestConfig)
+	if err != nil {
+		return nil, err
+	}
+	_, err = dynamicClient.Resource(certificateResource).Namespace(ZarfNamespaceName).Apply(ctx, svc.SecretName, certificate, metav1.ApplyOptions{Force: true, FieldManager: FieldManagerName})
+	if err != nil {
+		return nil, fmt.Errorf("unable to request a certificate from %s, is cert-manager installed?: %w", issuer, err)
+	}
+
+	waitCtx, cancel := context.WithTimeout(ctx, 2*time.Minute)
+	defer cancel()
+	var secret *corev1.Secret
+	err = retry.Do(func() error {
+		secret, err = c.Clientset.CoreV1().Secrets(ZarfNamespaceName).Get(waitCtx, svc.SecretName, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		if len(secret.Data[corev1.TLSCertKey]) == 0 {
+			return errors.New("certificate has not been issued yet")
+		}
+		return nil
+	}, retry.Context(waitCtx), retry.Attempts(0), retry.DelayType(retry.FixedDelay), retry.Delay(time.Second))
+	if err != nil {
+		return nil, fmt.Errorf("certificate for %s was not issued by %s: %w", svc.ServiceName, issuer, err)
+	}
+	return secret.Data[caBundleKey], nil
+}
+
+// parseIssuer splits a cert-manager issuer reference in the form [Kind/]name. Issuers without a kind are namespaced
+// Issuers in the Zarf namespace.
+func parseIssuer(issuer string) (string, string, error) {
+	kind, name, ok := strings.Cut(issuer, "/")
+	if !ok {
+		kind, name = "Issuer", issuer
+	}
+	if kind != "Issuer" && kind != "ClusterIssuer" {
+		return "", "", fmt.Errorf("invalid issuer kind %q, must be Issuer or ClusterIssuer", kind)
+	}
+	if name == "" {
+		return "", "", fmt.Errorf("issuer %q is missing a name", issuer)
+	}
+	return kind, name, nil
+}
+
+// verifyCertificate checks that the key belongs to the certificate, that the certificate is valid for the DNS name and,
+// when a CA bundle is given, that it was issued by the CA bundle. It returns whether the certificate is also valid for
+// the loopback address.
+func verifyCertificate(certPEM, keyPEM, caPEM []byte, dnsName string) (bool, error) {
+	pair, err := tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		return false, fmt.Errorf("invalid certificate and key: %w", err)
+	}
+	leaf, err := x509.ParseCertificate(pair.Certificate[0])
+	if err != nil {
+		return false, fmt.Errorf("invalid certificate: %w", err)
+	}
+	if err := leaf.VerifyHostname(dnsName); err != nil {
+		return false, fmt.Errorf("certificate is not valid for %s", dnsName)
+	}
+	loopback := leaf.VerifyHostname(helpers.IPV4Localhost) == nil
+	if len(caPEM) == 0 {
+		return loopback, nil
+	}
+	roots := x509.NewCertPool()
+	if !roots.AppendCertsFromPEM(caPEM) {
+		return false, errors.New("CA bundle does not contain any PEM encoded certificates")
+	}
+	intermediates := x509.NewCertPool()
+	for _, der := range pair.Certificate[1:] {
+		cert, err := x509.ParseCertificate(der)
+		if err != nil {
+			return false, fmt.Errorf("invalid certificate chain: %w", err)
+		}
+		intermediates.AddCert(cert)
+	}
+	_, err = leaf.Verify(x509.VerifyOptions{Roots: roots, Intermediates: intermediates, DNSName: dnsName})
+	if err != nil {
+		return false, fmt.Errorf("certificate was not issued by the CA bundle: %w", err)
+	}
+	return loopback, nil
+}
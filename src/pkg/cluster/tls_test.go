@@ -0,0 +1,180 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package cluster
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/zarf-dev/zarf/src/pkg/pki"
+	"github.com/zarf-dev/zarf/src/test/testutil"
+	"github.com/zarf-dev/zarf/src/types"
+)
+
+func TestValidateTLSOptions(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		opts        types.TLSOptions
+		expectedErr string
+	}{
+		{
+			name: "disabled",
+		},
+		{
+			name: "certificate",
+			opts: types.TLSOptions{CertFile: "tls.crt", KeyFile: "tls.key", CAFile: "ca.crt"},
+		},
+		{
+			name: "issuer",
+			opts: types.TLSOptions{Issuer: "ClusterIssuer/internal-ca"},
+		},
+		{
+			name:        "certificate and issuer",
+			opts:        types.TLSOptions{CertFile: "tls.crt", KeyFile: "tls.key", Issuer: "internal-ca"},
+			expectedErr: "a certificate and a cert-manager issuer can not be used together",
+		},
+		{
+			name:        "missing key",
+			opts:        types.TLSOptions{CertFile: "tls.crt"},
+			expectedErr: "a certificate and its key must be provided together",
+		},
+		{
+			name:        "only CA",
+			opts:        types.TLSOptions{CAFile: "ca.crt"},
+			expectedErr: "a CA bundle requires a certificate or a cert-manager issuer",
+		},
+		{
+			name:        "invalid issuer kind",
+			opts:        types.TLSOptions{Issuer: "Vault/internal-ca"},
+			expectedErr: `invalid issuer kind "Vault", must be Issuer or ClusterIssuer`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := ValidateTLSOptions(tt.opts)
+			if tt.expectedErr != "" {
+				require.EqualError(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func writeTLSFiles(t *testing.T, generated types.GeneratedPKI) types.TLSOptions {
+	t.Helper()
+
+	dir := t.TempDir()
+	opts := types.TLSOptions{
+		CertFile: filepath.Join(dir, "tls.crt"),
+		KeyFile:  filepath.Join(dir, "tls.key"),
+		CAFile:   filepath.Join(dir, "ca.crt"),
+	}
+	require.NoError(t, os.WriteFile(opts.CertFile, generated.Cert, 0o600))
+	require.NoError(t, os.WriteFile(opts.KeyFile, generated.Key, 0o600))
+	require.NoError(t, os.WriteFile(opts.CAFile, generated.CA, 0o600))
+	return opts
+}
+
+func TestApplyServiceTLS(t *testing.T) {
+	t.Parallel()
+
+	registryPKI, err := pki.GeneratePKI("zarf-docker-registry.zarf.svc.cluster.local")
+	require.NoError(t, err)
+	gitPKI, err := pki.GeneratePKI("zarf-gitea-http.zarf.svc.cluster.local")
+	require.NoError(t, err)
+
+	t.Run("certificate", func(t *testing.T) {
+		t.Parallel()
+		ctx := testutil.TestContext(t)
+		c := &Cluster{Clientset: fake.NewClientset()}
+
+		tlsInfo, err := c.ApplyServiceTLS(ctx, GitServerTLSService, writeTLSFiles(t, gitPKI))
+		require.NoError(t, err)
+		require.Equal(t, &types.TLSInfo{SecretName: ZarfGitServerTLSSecretName, CA: string(gitPKI.CA)}, tlsInfo)
+		secret, err := c.Clientset.CoreV1().Secrets(ZarfNamespaceName).Get(ctx, ZarfGitServerTLSSecretName, metav1.GetOptions{})
+		require.NoError(t, err)
+		require.Equal(t, corev1.SecretTypeTLS, secret.Type)
+		require.Equal(t, gitPKI.Cert, secret.Data[corev1.TLSCertKey])
+		require.Equal(t, gitPKI.Key, secret.Data[corev1.TLSPrivateKeyKey])
+		require.Equal(t, gitPKI.CA, secret.Data[caBundleKey])
+	})
+
+	t.Run("registry certificate", func(t *testing.T) {
+		t.Parallel()
+		ctx := testutil.TestContext(t)
+		c := &Cluster{Clientset: fake.NewClientset()}
+
+		tlsInfo, err := c.ApplyServiceTLS(ctx, RegistryTLSService, writeTLSFiles(t, registryPKI))
+		require.NoError(t, err)
+		require.Equal(t, &types.TLSInfo{SecretName: ZarfRegistryTLSSecretName, CA: string(registryPKI.CA)}, tlsInfo)
+		secret, err := c.Clientset.CoreV1().Secrets(ZarfNamespaceName).Get(ctx, ZarfRegistryTLSSecretName, metav1.GetOptions{})
+		require.NoError(t, err)
+		require.Equal(t, registryPKI.CA, secret.Data[caBundleKey])
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		t.Parallel()
+		ctx := testutil.TestContext(t)
+		c := &Cluster{Clientset: fake.NewClientset()}
+
+		tlsInfo, err := c.ApplyServiceTLS(ctx, RegistryTLSService, types.TLSOptions{})
+		require.NoError(t, err)
+		require.Nil(t, tlsInfo)
+	})
+
+	t.Run("wrong host", func(t *testing.T) {
+		t.Parallel()
+		ctx := testutil.TestContext(t)
+		c := &Cluster{Clientset: fake.NewClientset()}
+
+		_, err := c.ApplyServiceTLS(ctx, RegistryTLSService, writeTLSFiles(t, gitPKI))
+		require.EqualError(t, err, "certificate is not valid for zarf-docker-registry.zarf.svc.cluster.local")
+	})
+
+	t.Run("wrong CA", func(t *testing.T) {
+		t.Parallel()
+		ctx := testutil.TestContext(t)
+		c := &Cluster{Clientset: fake.NewClientset()}
+
+		opts := writeTLSFiles(t, registryPKI)
+		require.NoError(t, os.WriteFile(opts.CAFile, gitPKI.CA, 0o600))
+		_, err := c.ApplyServiceTLS(ctx, RegistryTLSService, opts)
+		require.ErrorContains(t, err, "certificate was not issued by the CA bundle")
+	})
+}
+
+func TestRotateServiceTLS(t *testing.T) {
+	t.Parallel()
+	ctx := testutil.TestContext(t)
+
+	gitPKI, err := pki.GeneratePKI("zarf-gitea-http.zarf.svc.cluster.local")
+	require.NoError(t, err)
+	c := &Cluster{Clientset: fake.NewClientset()}
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      GitServerTLSService.DeploymentName,
+			Namespace: ZarfNamespaceName,
+		},
+	}
+	_, err = c.Clientset.AppsV1().Deployments(ZarfNamespaceName).Create(ctx, deployment, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	tlsInfo, err := c.RotateServiceTLS(ctx, GitServerTLSService, writeTLSFiles(t, gitPKI))
+	require.NoError(t, err)
+	require.Equal(t, ZarfGitServerTLSSecretName, tlsInfo.SecretName)
+	deployment, err = c.Clientset.AppsV1().Deployments(ZarfNamespaceName).Get(ctx, GitServerTLSService.DeploymentName, metav1.GetOptions{})
+	require.NoError(t, err)
+	require.NotEmpty(t, deployment.Spec.Template.Annotations["kubectl.kubernetes.io/restartedAt"])
+}
@@ -385,6 +385,15 @@ func (tunnel *Tunnel) HTTPEndpoint() string {
 	return fmt.Sprintf("http://%s", tunnel.Endpoint())
 }
 
+// ServiceEndpoint returns the tunnel endpoint as a HTTPS URL string when the service is served with TLS and as a
+// HTTP URL string otherwise.
+func (tunnel *Tunnel) ServiceEndpoint(tlsInfo *types.TLSInfo) string {
+	if tlsInfo != nil {
+		return fmt.Sprintf("https://%s", tunnel.Endpoint())
+	}
+	return tunnel.HTTPEndpoint()
+}
+
 // FullURL returns the tunnel endpoint as a HTTP URL string with the urlSuffix appended.
 func (tunnel *Tunnel) FullURL() string {
 	return fmt.Sprintf("%s%s", tunnel.HTTPEndpoint(), tunnel.urlSuffix)
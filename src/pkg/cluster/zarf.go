@@ -155,8 +155,9 @@ func (c *Cluster) StripZarfLabelsAndSecretsFromNamespaces(ctx context.Context) {
 	l.Debug("done stripping zarf labels and secrets from namespaces", "duration", time.Since(start))
 }
 
-// RecordPackageDeployment saves metadata about a package that has been deployed to the cluster.
-func (c *Cluster) RecordPackageDeployment(ctx context.Context, pkg v1alpha1.ZarfPackage, components []types.DeployedComponent, generation int) (*types.DeployedPackage, error) {
+// RecordPackageDeployment saves metadata about a package that has been deployed to the cluster. The source is only
+// recorded when it is not empty.
+func (c *Cluster) RecordPackageDeployment(ctx context.Context, pkg v1alpha1.ZarfPackage, components []types.DeployedComponent, generation int, source string) (*types.DeployedPackage, error) {
 	packageName := pkg.Metadata.Name
 
 	// TODO: This is done for backwards compatibility and could be removed in the future.
@@ -176,6 +177,7 @@ func (c *Cluster) RecordPackageDeployment(ctx context.Context, pkg v1alpha1.Zarf
 		DeployedComponents: components,
 		ConnectStrings:     connectStrings,
 		Generation:         generation,
+		Source:             source,
 	}
 
 	packageData, err := json.Marshal(deployedPackage)
@@ -260,8 +262,8 @@ func (c *Cluster) UpdateInternalArtifactServerToken(ctx context.Context, oldGitS
 		return "", err
 	}
 	defer tunnel.Close()
-	tunnelURL := tunnel.HTTPEndpoint()
-	giteaClient, err := gitea.NewClient(tunnelURL, oldGitServer.PushUsername, oldGitServer.PushPassword)
+	tunnelURL := tunnel.ServiceEndpoint(oldGitServer.TLS)
+	giteaClient, err := gitea.NewClient(tunnelURL, oldGitServer.PushUsername, oldGitServer.PushPassword, oldGitServer.TLS.CABundle())
 	if err != nil {
 		return "", err
 	}
@@ -290,8 +292,8 @@ func (c *Cluster) UpdateInternalGitServerSecret(ctx context.Context, oldGitServe
 		return err
 	}
 	defer tunnel.Close()
-	tunnelURL := tunnel.HTTPEndpoint()
-	giteaClient, err := gitea.NewClient(tunnelURL, oldGitServer.PushUsername, oldGitServer.PushPassword)
+	tunnelURL := tunnel.ServiceEndpoint(oldGitServer.TLS)
+	giteaClient, err := gitea.NewClient(tunnelURL, oldGitServer.PushUsername, oldGitServer.PushPassword, oldGitServer.TLS.CABundle())
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,165 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package events emits a newline delimited JSON stream of structured progress events for programmatic consumers.
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/zarf-dev/zarf/src/pkg/logger"
+)
+
+// Format declares the encoding of the event stream.
+type Format string
+
+const (
+	// FormatNone disables the event stream.
+	FormatNone Format = ""
+	// FormatJSON emits one JSON object per line.
+	FormatJSON Format = "json"
+)
+
+// ParseFormat takes a string representation of a Format and ensures it is supported.
+func ParseFormat(s string) (Format, error) {
+	f := Format(strings.ToLower(s))
+	switch f {
+	case FormatNone, FormatJSON:
+		return f, nil
+	default:
+		return "", fmt.Errorf("invalid events format: %s", s)
+	}
+}
+
+// Type is the kind of an event.
+type Type string
+
+const (
+	// TypePhase marks the transition of an operation such as create or deploy.
+	TypePhase Type = "phase"
+	// TypeComponent reports the status of a component being assembled or deployed.
+	TypeComponent Type = "component"
+	// TypeImage reports the progress of an image being saved or pushed.
+	TypeImage Type = "image"
+	// TypeVerification reports the result of verifying a package source against a checksums file.
+	TypeVerification Type = "verification"
+)
+
+// Status is the state an event reports.
+type Status string
+
+const (
+	// StatusStarted is emitted when work begins.
+	StatusStarted Status = "started"
+	// StatusProgress is emitted while work is in progress.
+	StatusProgress Status = "progress"
+	// StatusSucceeded is emitted when work completes successfully.
+	StatusSucceeded Status = "succeeded"
+	// StatusFailed is emitted when work fails, the event holds the error.
+	StatusFailed Status = "failed"
+)
+
+// Event is a single entry of the event stream.
+type Event struct {
+	Time      time.Time `json:"time"`
+	Type      Type      `json:"type"`
+	Status    Status    `json:"status"`
+	Phase     string    `json:"phase,omitempty"`
+	Component string    `json:"component,omitempty"`
+	Image     string    `json:"image,omitempty"`
+	Source    string    `json:"source,omitempty"`
+	Checksums string    `json:"checksums,omitempty"`
+	Signed    bool      `json:"signed,omitempty"`
+	Files     []string  `json:"files,omitempty"`
+	Completed int64     `json:"completed,omitempty"`
+	Total     int64     `json:"total,omitempty"`
+	Error     string    `json:"error,omitempty"`
+}
+
+// Emitter writes events to a stream. Emitting with a nil emitter is a no-op.
+type Emitter struct {
+	mu  sync.Mutex
+	enc *json.Encoder
+}
+
+// NewEmitter returns an emitter that writes newline delimited JSON events to w.
+func NewEmitter(w io.Writer) *Emitter {
+	return &Emitter{enc: json.NewEncoder(w)}
+}
+
+// Emit writes the event to the stream, setting its time if unset.
+// Write failures are dropped as the event stream must never fail the operation being reported.
+func (e *Emitter) Emit(ev Event) {
+	if e == nil {
+		return
+	}
+	if ev.Time.IsZero() {
+		ev.Time = time.Now().UTC()
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	_ = e.enc.Encode(ev) //nolint:errcheck
+}
+
+// Phase emits the transition of an operation such as create or deploy.
+func (e *Emitter) Phase(phase string, status Status, err error) {
+	e.Emit(Event{Type: TypePhase, Status: status, Phase: phase, Error: errString(err)})
+}
+
+// Component emits the status of a component.
+func (e *Emitter) Component(component string, status Status, err error) {
+	e.Emit(Event{Type: TypeComponent, Status: status, Component: component, Error: errString(err)})
+}
+
+// Image emits the status and progress in bytes of an image.
+func (e *Emitter) Image(image string, status Status, completed, total int64, err error) {
+	e.Emit(Event{Type: TypeImage, Status: status, Image: image, Completed: completed, Total: total, Error: errString(err)})
+}
+
+// Verification emits the result of verifying the files of a package source against a checksums file.
+func (e *Emitter) Verification(source, checksums string, signed bool, files []string, err error) {
+	e.Emit(Event{Type: TypeVerification, Status: Done(err), Source: source, Checksums: checksums, Signed: signed, Files: files, Error: errString(err)})
+}
+
+// Done returns StatusFailed if err is set and StatusSucceeded otherwise.
+func Done(err error) Status {
+	if err != nil {
+		return StatusFailed
+	}
+	return StatusSucceeded
+}
+
+func errString(err error) string {
+	if err == nil {
+		return ""
+	}
+	return logger.Redact(err.Error())
+}
+
+// ctxKey provides a location to store an emitter in a context.
+type ctxKey struct{}
+
+var defaultCtxKey = ctxKey{}
+
+// WithContext takes a context.Context and an *Emitter, storing it on the key.
+func WithContext(ctx context.Context, e *Emitter) context.Context {
+	return context.WithValue(ctx, defaultCtxKey, e)
+}
+
+// From returns the emitter in the context or nil if the event stream is not enabled.
+func From(ctx context.Context) *Emitter {
+	if ctx == nil {
+		return nil
+	}
+	e, ok := ctx.Value(defaultCtxKey).(*Emitter)
+	if !ok {
+		return nil
+	}
+	return e
+}
@@ -0,0 +1,79 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package events
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/zarf-dev/zarf/src/pkg/logger"
+)
+
+func TestParseFormat(t *testing.T) {
+	t.Parallel()
+
+	f, err := ParseFormat("")
+	require.NoError(t, err)
+	require.Equal(t, FormatNone, f)
+	f, err = ParseFormat("JSON")
+	require.NoError(t, err)
+	require.Equal(t, FormatJSON, f)
+	_, err = ParseFormat("yaml")
+	require.EqualError(t, err, "invalid events format: yaml")
+}
+
+func TestEmitter(t *testing.T) {
+	t.Parallel()
+
+	logger.AddSecret("events-test-secret")
+
+	buf := &bytes.Buffer{}
+	e := NewEmitter(buf)
+	e.Phase("deploy", StatusStarted, nil)
+	e.Component("podinfo", StatusStarted, nil)
+	e.Image("ghcr.io/stefanprodan/podinfo:6.4.0", StatusProgress, 512, 1024, nil)
+	e.Component("podinfo", Done(errors.New("login failed with events-test-secret")), errors.New("login failed with events-test-secret"))
+	e.Verification("zarf-package-podinfo-amd64.tar.zst.part000", "checksums.txt", true, []string{"zarf-package-podinfo-amd64.tar.zst.part000"}, nil)
+	e.Phase("deploy", Done(nil), nil)
+
+	expected := []Event{
+		{Type: TypePhase, Status: StatusStarted, Phase: "deploy"},
+		{Type: TypeComponent, Status: StatusStarted, Component: "podinfo"},
+		{Type: TypeImage, Status: StatusProgress, Image: "ghcr.io/stefanprodan/podinfo:6.4.0", Completed: 512, Total: 1024},
+		{Type: TypeComponent, Status: StatusFailed, Component: "podinfo", Error: "login failed with **sanitized**"},
+		{Type: TypeVerification, Status: StatusSucceeded, Source: "zarf-package-podinfo-amd64.tar.zst.part000", Checksums: "checksums.txt", Signed: true, Files: []string{"zarf-package-podinfo-amd64.tar.zst.part000"}},
+		{Type: TypePhase, Status: StatusSucceeded, Phase: "deploy"},
+	}
+	actual := []Event{}
+	scanner := bufio.NewScanner(buf)
+	for scanner.Scan() {
+		var ev Event
+		err := json.Unmarshal(scanner.Bytes(), &ev)
+		require.NoError(t, err)
+		require.False(t, ev.Time.IsZero())
+		ev.Time = time.Time{}
+		actual = append(actual, ev)
+	}
+	require.NoError(t, scanner.Err())
+	require.Equal(t, expected, actual)
+}
+
+func TestFrom(t *testing.T) {
+	t.Parallel()
+
+	require.Nil(t, From(context.Background()))
+	// A nil emitter must be safe to use when the event stream is disabled.
+	From(context.Background()).Phase("create", StatusStarted, nil)
+
+	e := NewEmitter(&bytes.Buffer{})
+	ctx := WithContext(context.Background(), e)
+	require.Same(t, e, From(ctx))
+}
@@ -0,0 +1,70 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package exitcode defines the exit codes of the Zarf CLI so that automation can branch on the class of a failure.
+package exitcode
+
+import (
+	"context"
+	"errors"
+)
+
+// Code is a process exit code. The values are part of the CLI contract and must not change between releases.
+type Code int
+
+const (
+	// OK is returned when the command succeeds.
+	OK Code = 0
+	// Failure is returned for errors that do not belong to a more specific class.
+	Failure Code = 1
+	// Config is returned for invalid flags, arguments or configuration files.
+	Config Code = 2
+	// Signature is returned when a package signature is missing, unexpected or does not match the provided key.
+	Signature Code = 3
+	// Cluster is returned when Zarf is unable to connect to the Kubernetes cluster.
+	Cluster Code = 4
+	// NonInteractive is returned when input is required but prompts are disabled with --non-interactive.
+	NonInteractive Code = 5
+	// Interrupted is returned when the command is cancelled, e.g. with Ctrl+C.
+	Interrupted Code = 130
+)
+
+// Error associates an exit code with an error.
+type Error struct {
+	Code Code
+	Err  error
+}
+
+// Error returns the message of the wrapped error.
+func (e *Error) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns the wrapped error.
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Wrap associates the exit code with err. It returns nil when err is nil.
+func Wrap(code Code, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &Error{Code: code, Err: err}
+}
+
+// FromError returns the exit code for err. The outermost code in the error chain wins, errors without a code that were
+// caused by a cancelled context are Interrupted and any other error is a Failure.
+func FromError(err error) Code {
+	if err == nil {
+		return OK
+	}
+	var codeErr *Error
+	if errors.As(err, &codeErr) {
+		return codeErr.Code
+	}
+	if errors.Is(err, context.Canceled) {
+		return Interrupted
+	}
+	return Failure
+}
@@ -0,0 +1,69 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package exitcode
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFromError(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		err      error
+		expected Code
+	}{
+		{
+			name:     "nil",
+			expected: OK,
+		},
+		{
+			name:     "unclassified",
+			err:      errors.New("boom"),
+			expected: Failure,
+		},
+		{
+			name:     "wrapped code",
+			err:      fmt.Errorf("unable to deploy: %w", Wrap(Cluster, errors.New("connection refused"))),
+			expected: Cluster,
+		},
+		{
+			name:     "joined code",
+			err:      fmt.Errorf("%w: %w", errors.New("selection canceled"), Wrap(NonInteractive, errors.New("prompt"))),
+			expected: NonInteractive,
+		},
+		{
+			name:     "outermost code",
+			err:      Wrap(Config, Wrap(Signature, errors.New("bad signature"))),
+			expected: Config,
+		},
+		{
+			name:     "cancelled",
+			err:      fmt.Errorf("unable to pull: %w", context.Canceled),
+			expected: Interrupted,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			require.Equal(t, tt.expected, FromError(tt.err))
+		})
+	}
+}
+
+func TestWrap(t *testing.T) {
+	t.Parallel()
+
+	require.NoError(t, Wrap(Config, nil))
+	base := errors.New("package signature did not match the provided key")
+	err := Wrap(Signature, base)
+	require.EqualError(t, err, base.Error())
+	require.ErrorIs(t, err, base)
+}
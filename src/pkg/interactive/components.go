@@ -16,6 +16,13 @@ import (
 
 // SelectOptionalComponent prompts to confirm optional components
 func SelectOptionalComponent(component v1alpha1.ZarfComponent) (bool, error) {
+	if prompter != nil {
+		return prompter.SelectOptionalComponent(component)
+	}
+	if err := CheckPrompt(fmt.Sprintf("selection of the optional %s component, choose it with --components or use --confirm", component.Name)); err != nil {
+		return false, err
+	}
+
 	message.HorizontalRule()
 
 	displayComponent := component
@@ -42,9 +49,20 @@ func SelectOptionalComponent(component v1alpha1.ZarfComponent) (bool, error) {
 	return confirm, nil
 }
 
-// SelectChoiceGroup prompts to select component groups
-func SelectChoiceGroup(componentGroup []v1alpha1.ZarfComponent) (v1alpha1.ZarfComponent, error) {
+// SelectChoiceGroup prompts to select a component from a group, returning false if no component was selected from an
+// atMostOne group
+func SelectChoiceGroup(group v1alpha1.ZarfComponentGroup, componentGroup []v1alpha1.ZarfComponent) (v1alpha1.ZarfComponent, bool, error) {
+	if prompter != nil {
+		return prompter.SelectChoiceGroup(group, componentGroup)
+	}
+	if err := CheckPrompt(fmt.Sprintf("selection of a component from the %s group, choose it with --components", group.Name)); err != nil {
+		return v1alpha1.ZarfComponent{}, false, err
+	}
+
 	message.HorizontalRule()
+	if group.Description != "" {
+		message.Question(group.Description)
+	}
 
 	var chosen int
 	var options []string
@@ -53,13 +71,28 @@ func SelectChoiceGroup(componentGroup []v1alpha1.ZarfComponent) (v1alpha1.ZarfCo
 		text := fmt.Sprintf("Name: %s\n  Description: %s\n", component.Name, component.Description)
 		options = append(options, text)
 	}
+	if group.Selection == v1alpha1.AtMostOneSelection {
+		options = append(options, "None\n")
+	}
 
 	prompt := &survey.Select{
 		Message: "Select a component to deploy:",
 		Options: options,
 	}
+	for i, component := range componentGroup {
+		if component.Default {
+			prompt.Default = options[i]
+		}
+	}
 
 	pterm.Println()
 
-	return componentGroup[chosen], survey.AskOne(prompt, &chosen)
+	err := survey.AskOne(prompt, &chosen)
+	if err != nil {
+		return v1alpha1.ZarfComponent{}, false, err
+	}
+	if chosen >= len(componentGroup) {
+		return v1alpha1.ZarfComponent{}, false, nil
+	}
+	return componentGroup[chosen], true, nil
 }
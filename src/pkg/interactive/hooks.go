@@ -0,0 +1,76 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package interactive contains functions for interacting with the user via STDIN.
+package interactive
+
+import (
+	"context"
+
+	"github.com/zarf-dev/zarf/src/api/v1alpha1"
+	"github.com/zarf-dev/zarf/src/types"
+)
+
+// Prompter answers the prompts of Zarf operations in place of the terminal prompts, so that programs embedding Zarf
+// can ask for input in their own UI.
+type Prompter interface {
+	// PromptVariable returns the value of a package variable or template.
+	PromptVariable(ctx context.Context, variable v1alpha1.InteractiveVariable) (string, error)
+	// SelectOptionalComponent returns whether the optional component is deployed.
+	SelectOptionalComponent(component v1alpha1.ZarfComponent) (bool, error)
+	// SelectChoiceGroup returns the component deployed from the group, or false if none is deployed from an
+	// atMostOne group.
+	SelectChoiceGroup(group v1alpha1.ZarfComponentGroup, components []v1alpha1.ZarfComponent) (v1alpha1.ZarfComponent, bool, error)
+	// PromptSigPassword returns the password of the private key a package is signed with.
+	PromptSigPassword() ([]byte, error)
+}
+
+// ConfirmRequest describes an operation waiting for confirmation.
+type ConfirmRequest struct {
+	// Stage is the operation, one of Create, Deploy or Mirror.
+	Stage string
+	// Package is the package the operation is performed on, with the components selected for it.
+	Package v1alpha1.ZarfPackage
+	// Warnings were flagged while reading the package.
+	Warnings []string
+	// SBOMFiles are the SBOM viewers of the package, staged for review before a deploy.
+	SBOMFiles []string
+	// ReleaseNotes are the release notes embedded in the package, set when they are requested before a deploy.
+	ReleaseNotes string
+	// Diff is the difference from the version of the package that is deployed, set when the package was deployed
+	// before and the cluster could be reached.
+	Diff *types.PackageDiff
+}
+
+// Confirmer confirms Zarf operations before they make changes, in place of the package summary and prompt printed
+// to the terminal.
+type Confirmer interface {
+	Confirm(ctx context.Context, request ConfirmRequest) (bool, error)
+}
+
+var (
+	prompter  Prompter
+	confirmer Confirmer
+)
+
+// SetPrompter answers prompts with the prompter instead of prompting in the terminal. A nil prompter prompts in the
+// terminal again.
+func SetPrompter(p Prompter) {
+	prompter = p
+}
+
+// ActivePrompter returns the prompter set with SetPrompter, or nil when prompts are answered in the terminal.
+func ActivePrompter() Prompter {
+	return prompter
+}
+
+// SetConfirmer confirms operations with the confirmer instead of prompting in the terminal. A nil confirmer prompts
+// in the terminal again.
+func SetConfirmer(c Confirmer) {
+	confirmer = c
+}
+
+// ActiveConfirmer returns the confirmer set with SetConfirmer, or nil when operations are confirmed in the terminal.
+func ActiveConfirmer() Confirmer {
+	return confirmer
+}
@@ -0,0 +1,61 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package interactive
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/zarf-dev/zarf/src/api/v1alpha1"
+	"github.com/zarf-dev/zarf/src/config"
+)
+
+type testPrompter struct{}
+
+func (testPrompter) PromptVariable(_ context.Context, variable v1alpha1.InteractiveVariable) (string, error) {
+	return "value-of-" + variable.Name, nil
+}
+
+func (testPrompter) SelectOptionalComponent(component v1alpha1.ZarfComponent) (bool, error) {
+	return component.Name == "extras", nil
+}
+
+func (testPrompter) SelectChoiceGroup(_ v1alpha1.ZarfComponentGroup, components []v1alpha1.ZarfComponent) (v1alpha1.ZarfComponent, bool, error) {
+	return components[len(components)-1], true, nil
+}
+
+func (testPrompter) PromptSigPassword() ([]byte, error) {
+	return []byte("secret"), nil
+}
+
+func TestSetPrompter(t *testing.T) {
+	// The prompter answers prompts that would fail in the terminal.
+	config.CommonOptions.NonInteractive = true
+	SetPrompter(testPrompter{})
+	t.Cleanup(func() {
+		config.CommonOptions.NonInteractive = false
+		SetPrompter(nil)
+	})
+
+	value, err := PromptVariable(context.Background(), v1alpha1.InteractiveVariable{Variable: v1alpha1.Variable{Name: "DOMAIN"}})
+	require.NoError(t, err)
+	require.Equal(t, "value-of-DOMAIN", value)
+	selected, err := SelectOptionalComponent(v1alpha1.ZarfComponent{Name: "extras"})
+	require.NoError(t, err)
+	require.True(t, selected)
+	group := v1alpha1.ZarfComponentGroup{Name: "letters", Components: []string{"a", "b"}}
+	component, selected, err := SelectChoiceGroup(group, []v1alpha1.ZarfComponent{{Name: "a"}, {Name: "b"}})
+	require.NoError(t, err)
+	require.True(t, selected)
+	require.Equal(t, "b", component.Name)
+	password, err := PromptSigPassword()
+	require.NoError(t, err)
+	require.Equal(t, []byte("secret"), password)
+
+	SetPrompter(nil)
+	_, err = SelectOptionalComponent(v1alpha1.ZarfComponent{Name: "extras"})
+	require.ErrorIs(t, err, ErrNonInteractive)
+}
@@ -6,16 +6,37 @@ package interactive
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/AlecAivazis/survey/v2"
 	"github.com/zarf-dev/zarf/src/api/v1alpha1"
+	"github.com/zarf-dev/zarf/src/config"
+	"github.com/zarf-dev/zarf/src/pkg/exitcode"
 	"github.com/zarf-dev/zarf/src/pkg/logger"
 	"github.com/zarf-dev/zarf/src/pkg/message"
 )
 
+// ErrNonInteractive is returned instead of prompting when prompts are disabled with --non-interactive.
+var ErrNonInteractive = errors.New("input is required but prompts are disabled by --non-interactive")
+
+// CheckPrompt returns an error describing the prompt when prompts are disabled, it must be called before prompting.
+func CheckPrompt(prompt string) error {
+	if !config.CommonOptions.NonInteractive {
+		return nil
+	}
+	return exitcode.Wrap(exitcode.NonInteractive, fmt.Errorf("%w: %s", ErrNonInteractive, prompt))
+}
+
 // PromptSigPassword prompts the user for the password to their private key
 func PromptSigPassword() ([]byte, error) {
+	if prompter != nil {
+		return prompter.PromptSigPassword()
+	}
+	if err := CheckPrompt("private key password, set it with --signing-key-pass"); err != nil {
+		return nil, err
+	}
+
 	var password string
 
 	prompt := &survey.Password{
@@ -28,8 +49,33 @@ func PromptSigPassword() ([]byte, error) {
 	return []byte(password), nil
 }
 
+// PromptRegistryPassword prompts the user for the password of a registry
+func PromptRegistryPassword(registry string) (string, error) {
+	if err := CheckPrompt(fmt.Sprintf("password for %s, set it with --password or --password-stdin", registry)); err != nil {
+		return "", err
+	}
+
+	var password string
+
+	prompt := &survey.Password{
+		Message: fmt.Sprintf("Password for %s: ", registry),
+	}
+	err := survey.AskOne(prompt, &password)
+	if err != nil {
+		return "", err
+	}
+	return password, nil
+}
+
 // PromptVariable prompts the user for a value for a variable
 func PromptVariable(ctx context.Context, variable v1alpha1.InteractiveVariable) (string, error) {
+	if prompter != nil {
+		return prompter.PromptVariable(ctx, variable)
+	}
+	if err := CheckPrompt(fmt.Sprintf("value for variable %q, set it with --set", variable.Name)); err != nil {
+		return "", err
+	}
+
 	if variable.Description != "" {
 		message.Question(variable.Description)
 		logger.From(ctx).Info(variable.Description)
@@ -0,0 +1,38 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package interactive
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/zarf-dev/zarf/src/api/v1alpha1"
+	"github.com/zarf-dev/zarf/src/config"
+	"github.com/zarf-dev/zarf/src/pkg/exitcode"
+)
+
+func TestNonInteractive(t *testing.T) {
+	require.NoError(t, CheckPrompt("anything"))
+
+	config.CommonOptions.NonInteractive = true
+	t.Cleanup(func() {
+		config.CommonOptions.NonInteractive = false
+	})
+
+	_, err := PromptVariable(context.Background(), v1alpha1.InteractiveVariable{Variable: v1alpha1.Variable{Name: "DOMAIN"}})
+	require.ErrorIs(t, err, ErrNonInteractive)
+	require.ErrorContains(t, err, `value for variable "DOMAIN"`)
+	require.Equal(t, exitcode.NonInteractive, exitcode.FromError(err))
+
+	_, err = SelectOptionalComponent(v1alpha1.ZarfComponent{Name: "extras"})
+	require.ErrorIs(t, err, ErrNonInteractive)
+	group := v1alpha1.ZarfComponentGroup{Name: "letters", Components: []string{"a", "b"}}
+	_, _, err = SelectChoiceGroup(group, []v1alpha1.ZarfComponent{{Name: "a"}, {Name: "b"}})
+	require.ErrorIs(t, err, ErrNonInteractive)
+	require.ErrorContains(t, err, "letters group")
+	_, err = PromptSigPassword()
+	require.ErrorIs(t, err, ErrNonInteractive)
+}
@@ -28,6 +28,10 @@ type ComponentPaths struct {
 	Repos          string
 	Manifests      string
 	DataInjections string
+	Artifacts      string
+	HostArtifacts  string
+	HostServices   string
+	Tofu           string
 }
 
 // Components contains paths for components.
@@ -128,6 +132,18 @@ func (c *Components) Unarchive(component v1alpha1.ZarfComponent) error {
 	if len(component.DataInjections) > 0 {
 		cs.DataInjections = filepath.Join(cs.Base, DataInjectionsDir)
 	}
+	if len(component.Artifacts) > 0 {
+		cs.Artifacts = filepath.Join(cs.Base, ArtifactsDir)
+	}
+	if len(component.HostArtifacts) > 0 {
+		cs.HostArtifacts = filepath.Join(cs.Base, HostArtifactsDir)
+	}
+	if len(component.HostServices) > 0 {
+		cs.HostServices = filepath.Join(cs.Base, HostServicesDir)
+	}
+	if len(component.Tofu) > 0 {
+		cs.Tofu = filepath.Join(cs.Base, TofuDir)
+	}
 	if c.Dirs == nil {
 		c.Dirs = make(map[string]*ComponentPaths)
 	}
@@ -223,6 +239,34 @@ func (c *Components) Create(component v1alpha1.ZarfComponent) (*ComponentPaths,
 		}
 	}
 
+	if len(component.Artifacts) > 0 {
+		cp.Artifacts = filepath.Join(base, ArtifactsDir)
+		if err := helpers.CreateDirectory(cp.Artifacts, helpers.ReadWriteExecuteUser); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(component.HostArtifacts) > 0 {
+		cp.HostArtifacts = filepath.Join(base, HostArtifactsDir)
+		if err := helpers.CreateDirectory(cp.HostArtifacts, helpers.ReadWriteExecuteUser); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(component.HostServices) > 0 {
+		cp.HostServices = filepath.Join(base, HostServicesDir)
+		if err := helpers.CreateDirectory(cp.HostServices, helpers.ReadWriteExecuteUser); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(component.Tofu) > 0 {
+		cp.Tofu = filepath.Join(base, TofuDir)
+		if err := helpers.CreateDirectory(cp.Tofu, helpers.ReadWriteExecuteUser); err != nil {
+			return nil, err
+		}
+	}
+
 	if c.Dirs == nil {
 		c.Dirs = make(map[string]*ComponentPaths)
 	}
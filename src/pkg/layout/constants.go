@@ -15,11 +15,19 @@ const (
 	ManifestsDir      = "manifests"
 	DataInjectionsDir = "data"
 	ValuesDir         = "values"
+	ArtifactsDir      = "artifacts"
+	HostArtifactsDir  = "hostartifacts"
+	HostServicesDir   = "hostservices"
+	TofuDir           = "tofu"
+	BuildsDir         = "builds"
 
 	ZarfYAML  = "zarf.yaml"
 	Signature = "zarf.yaml.sig"
 	Checksums = "checksums.txt"
 
+	ReleaseNotes = "release-notes.md"
+	BuildInfo    = "build-info.json"
+
 	ImagesDir     = "images"
 	ComponentsDir = "components"
 
@@ -32,7 +32,9 @@ type PackagePaths struct {
 	ZarfYAML  string
 	Checksums string
 
-	Signature string
+	Signature    string
+	ReleaseNotes string
+	BuildInfo    string
 
 	Components Components
 	SBOMs      SBOMs
@@ -305,6 +307,10 @@ func (pp *PackagePaths) SetFromPaths(paths []string) {
 			pp.ZarfYAML = filepath.Join(pp.Base, path)
 		case path == Signature:
 			pp.Signature = filepath.Join(pp.Base, path)
+		case path == ReleaseNotes:
+			pp.ReleaseNotes = filepath.Join(pp.Base, path)
+		case path == BuildInfo:
+			pp.BuildInfo = filepath.Join(pp.Base, path)
 		case path == Checksums:
 			pp.Checksums = filepath.Join(pp.Base, path)
 		case path == SBOMTar:
@@ -356,6 +362,8 @@ func (pp *PackagePaths) Files() map[string]string {
 	add(pp.ZarfYAML)
 	add(pp.Signature)
 	add(pp.Checksums)
+	add(pp.ReleaseNotes)
+	add(pp.BuildInfo)
 
 	add(pp.Images.OCILayout)
 	add(pp.Images.Index)
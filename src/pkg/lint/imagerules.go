@@ -0,0 +1,126 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package lint contains functions for verifying zarf yaml files are valid
+package lint
+
+import (
+	"fmt"
+	"strings"
+
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+
+	"github.com/zarf-dev/zarf/src/api/v1alpha1"
+	"github.com/zarf-dev/zarf/src/pkg/transform"
+	"github.com/zarf-dev/zarf/src/pkg/utils"
+)
+
+// Rules are lint rules that packages are checked against in addition to the built-in checks.
+type Rules struct {
+	// Images are the rules the images of the package are checked against when the package is created.
+	Images ImageRules `json:"images,omitempty"`
+}
+
+// ImageRules are the OCI annotations and labels that images are required to have.
+type ImageRules struct {
+	// RequiredAnnotations are the annotations every image manifest must have, e.g. org.opencontainers.image.source.
+	RequiredAnnotations []string `json:"requiredAnnotations,omitempty"`
+	// RequiredLabels are the labels every image config must have.
+	RequiredLabels []string `json:"requiredLabels,omitempty"`
+	// Exclude are the prefixes of image references that are not checked, e.g. docker.io/library/.
+	Exclude []string `json:"exclude,omitempty"`
+	// Severity of a missing annotation or label, either error (default) or warning.
+	Severity string `json:"severity,omitempty"`
+}
+
+// ReadRules reads lint rules from a YAML file.
+func ReadRules(path string) (Rules, error) {
+	var rules Rules
+	if err := utils.ReadYaml(path, &rules); err != nil {
+		return Rules{}, fmt.Errorf("unable to read the lint rules %s: %w", path, err)
+	}
+	if _, err := parseSeverity(rules.Images.Severity); err != nil {
+		return Rules{}, fmt.Errorf("invalid image rules in %s: %w", path, err)
+	}
+	return rules, nil
+}
+
+func parseSeverity(s string) (Severity, error) {
+	switch strings.ToLower(s) {
+	case "", "error":
+		return SevErr, nil
+	case "warning":
+		return SevWarn, nil
+	default:
+		return "", fmt.Errorf("invalid severity %q: must be error or warning", s)
+	}
+}
+
+// CheckImageMetadata checks that the pulled images of the package have the annotations and labels required by the
+// rules. Images that are used by more than one component are reported for the first one.
+func CheckImageMetadata(pkg v1alpha1.ZarfPackage, pulled map[transform.Image]v1.Image, rules ImageRules) ([]PackageFinding, error) {
+	if len(rules.RequiredAnnotations) == 0 && len(rules.RequiredLabels) == 0 {
+		return nil, nil
+	}
+	severity, err := parseSeverity(rules.Severity)
+	if err != nil {
+		return nil, err
+	}
+	findings := []PackageFinding{}
+	checked := map[transform.Image]bool{}
+	for i, component := range pkg.Components {
+		for j, src := range component.Images {
+			refInfo, err := transform.ParseImageRef(src)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create ref for image %s: %w", src, err)
+			}
+			img, ok := pulled[refInfo]
+			if !ok || checked[refInfo] || isExcludedImage(refInfo.Reference, rules.Exclude) {
+				continue
+			}
+			checked[refInfo] = true
+
+			manifest, err := img.Manifest()
+			if err != nil {
+				return nil, fmt.Errorf("unable to read the manifest of %s: %w", refInfo.Reference, err)
+			}
+			configFile, err := img.ConfigFile()
+			if err != nil {
+				return nil, fmt.Errorf("unable to read the config of %s: %w", refInfo.Reference, err)
+			}
+			yqPath := fmt.Sprintf(".components.[%d].images.[%d]", i, j)
+			for _, annotation := range rules.RequiredAnnotations {
+				if manifest.Annotations[annotation] != "" {
+					continue
+				}
+				findings = append(findings, PackageFinding{
+					YqPath:      yqPath,
+					Description: fmt.Sprintf("Image is missing the required annotation %s", annotation),
+					Item:        src,
+					Severity:    severity,
+				})
+			}
+			for _, label := range rules.RequiredLabels {
+				if configFile.Config.Labels[label] != "" {
+					continue
+				}
+				findings = append(findings, PackageFinding{
+					YqPath:      yqPath,
+					Description: fmt.Sprintf("Image is missing the required label %s", label),
+					Item:        src,
+					Severity:    severity,
+				})
+			}
+		}
+	}
+	return findings, nil
+}
+
+func isExcludedImage(ref string, exclude []string) bool {
+	for _, prefix := range exclude {
+		if strings.HasPrefix(ref, prefix) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,176 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package lint contains functions for verifying zarf yaml files are valid
+package lint
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zarf-dev/zarf/src/api/v1alpha1"
+	"github.com/zarf-dev/zarf/src/pkg/transform"
+)
+
+func TestReadRules(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		content     string
+		expected    Rules
+		expectedErr string
+	}{
+		{
+			name: "image rules",
+			content: `images:
+  requiredAnnotations:
+    - org.opencontainers.image.source
+  requiredLabels:
+    - org.opencontainers.image.vendor
+  exclude:
+    - docker.io/library/
+  severity: warning
+`,
+			expected: Rules{
+				Images: ImageRules{
+					RequiredAnnotations: []string{"org.opencontainers.image.source"},
+					RequiredLabels:      []string{"org.opencontainers.image.vendor"},
+					Exclude:             []string{"docker.io/library/"},
+					Severity:            "warning",
+				},
+			},
+		},
+		{
+			name:        "invalid severity",
+			content:     "images:\n  severity: fatal\n",
+			expectedErr: "must be error or warning",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			path := filepath.Join(t.TempDir(), "rules.yaml")
+			err := os.WriteFile(path, []byte(tt.content), 0o644)
+			require.NoError(t, err)
+			rules, err := ReadRules(path)
+			if tt.expectedErr != "" {
+				require.ErrorContains(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, rules)
+		})
+	}
+}
+
+func TestCheckImageMetadata(t *testing.T) {
+	t.Parallel()
+
+	newImage := func(t *testing.T, annotations, labels map[string]string) v1.Image {
+		t.Helper()
+		img, err := random.Image(16, 1)
+		require.NoError(t, err)
+		cf, err := img.ConfigFile()
+		require.NoError(t, err)
+		cf.Config.Labels = labels
+		img, err = mutate.ConfigFile(img, cf)
+		require.NoError(t, err)
+		return mutate.Annotations(img, annotations).(v1.Image)
+	}
+	parse := func(t *testing.T, ref string) transform.Image {
+		t.Helper()
+		refInfo, err := transform.ParseImageRef(ref)
+		require.NoError(t, err)
+		return refInfo
+	}
+
+	pkg := v1alpha1.ZarfPackage{
+		Components: []v1alpha1.ZarfComponent{
+			{Name: "a", Images: []string{"ghcr.io/zarf-dev/app:1.0.0", "ghcr.io/zarf-dev/bare:1.0.0"}},
+			{Name: "b", Images: []string{"ghcr.io/zarf-dev/bare:1.0.0", "nginx:1.27"}},
+		},
+	}
+	pulled := map[transform.Image]v1.Image{
+		parse(t, "ghcr.io/zarf-dev/app:1.0.0"): newImage(t,
+			map[string]string{"org.opencontainers.image.source": "https://github.com/zarf-dev/app"},
+			map[string]string{"org.opencontainers.image.vendor": "Zarf"},
+		),
+		parse(t, "ghcr.io/zarf-dev/bare:1.0.0"): newImage(t, nil, nil),
+		parse(t, "nginx:1.27"):                  newImage(t, nil, nil),
+	}
+
+	tests := []struct {
+		name     string
+		rules    ImageRules
+		expected []PackageFinding
+	}{
+		{
+			name:  "no rules",
+			rules: ImageRules{},
+		},
+		{
+			name: "missing annotations and labels",
+			rules: ImageRules{
+				RequiredAnnotations: []string{"org.opencontainers.image.source"},
+				RequiredLabels:      []string{"org.opencontainers.image.vendor"},
+				Exclude:             []string{"docker.io/library/"},
+			},
+			expected: []PackageFinding{
+				{
+					YqPath:      ".components.[0].images.[1]",
+					Description: "Image is missing the required annotation org.opencontainers.image.source",
+					Item:        "ghcr.io/zarf-dev/bare:1.0.0",
+					Severity:    SevErr,
+				},
+				{
+					YqPath:      ".components.[0].images.[1]",
+					Description: "Image is missing the required label org.opencontainers.image.vendor",
+					Item:        "ghcr.io/zarf-dev/bare:1.0.0",
+					Severity:    SevErr,
+				},
+			},
+		},
+		{
+			name: "warning",
+			rules: ImageRules{
+				RequiredLabels: []string{"org.opencontainers.image.vendor"},
+				Severity:       "warning",
+			},
+			expected: []PackageFinding{
+				{
+					YqPath:      ".components.[0].images.[1]",
+					Description: "Image is missing the required label org.opencontainers.image.vendor",
+					Item:        "ghcr.io/zarf-dev/bare:1.0.0",
+					Severity:    SevWarn,
+				},
+				{
+					YqPath:      ".components.[1].images.[1]",
+					Description: "Image is missing the required label org.opencontainers.image.vendor",
+					Item:        "nginx:1.27",
+					Severity:    SevWarn,
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			findings, err := CheckImageMetadata(pkg, pulled, tt.rules)
+			require.NoError(t, err)
+			if tt.expected == nil {
+				require.Empty(t, findings)
+				return
+			}
+			require.Equal(t, tt.expected, findings)
+		})
+	}
+}
@@ -5,6 +5,7 @@
 package lint
 
 import (
+	"errors"
 	"fmt"
 	"io/fs"
 	"path/filepath"
@@ -18,13 +19,20 @@ import (
 // ZarfSchema is exported so main.go can embed the schema file
 var ZarfSchema fs.ReadFileFS
 
+func readZarfSchema() ([]byte, error) {
+	if ZarfSchema == nil {
+		return nil, errors.New("the Zarf schema is not loaded, embed zarf.schema.json and set it as lint.ZarfSchema")
+	}
+	return ZarfSchema.ReadFile("zarf.schema.json")
+}
+
 // ValidatePackageSchemaAtPath checks the Zarf package in the current directory against the Zarf schema
 func ValidatePackageSchemaAtPath(path string, setVariables map[string]string) ([]PackageFinding, error) {
 	var untypedZarfPackage interface{}
 	if err := utils.ReadYaml(filepath.Join(path, layout.ZarfYAML), &untypedZarfPackage); err != nil {
 		return nil, err
 	}
-	jsonSchema, err := ZarfSchema.ReadFile("zarf.schema.json")
+	jsonSchema, err := readZarfSchema()
 	if err != nil {
 		return nil, err
 	}
@@ -41,7 +49,7 @@ func ValidatePackageSchema(setVariables map[string]string) ([]PackageFinding, er
 	if err := utils.ReadYaml(layout.ZarfYAML, &untypedZarfPackage); err != nil {
 		return nil, err
 	}
-	jsonSchema, err := ZarfSchema.ReadFile("zarf.schema.json")
+	jsonSchema, err := readZarfSchema()
 	if err != nil {
 		return nil, err
 	}
@@ -5,12 +5,17 @@
 package lint
 
 import (
+	"cmp"
 	"errors"
 	"fmt"
 	"regexp"
+	"slices"
 	"strings"
 
+	"github.com/Masterminds/semver/v3"
 	"github.com/zarf-dev/zarf/src/api/v1alpha1"
+	"github.com/zarf-dev/zarf/src/pkg/transform"
+	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/util/validation"
 )
 
@@ -21,6 +26,10 @@ var (
 	// Define allowed OS, an empty string means it is allowed on all operating systems
 	// same as enums on ZarfComponentOnlyTarget
 	supportedOS = []string{"linux", "darwin", "windows", ""}
+	// isImagePlatform matches the platforms images are built for, same as the pattern of ZarfImageBuild.Platforms.
+	isImagePlatform = regexp.MustCompile(`^(linux|windows)/[a-z0-9]+(/[a-z0-9]+)?$`).MatchString
+	// isUnitName matches the systemd unit file names of host services, same as the pattern of ZarfHostService.Name.
+	isUnitName = regexp.MustCompile(`^[a-zA-Z0-9:_.@-]+\.(service|socket|timer|path|mount|target)$`).MatchString
 )
 
 // SupportedOS returns the supported operating systems.
@@ -40,32 +49,62 @@ const (
 
 // Package errors found during validation.
 const (
-	PkgValidateErrInitNoYOLO              = "sorry, you can't YOLO an init package"
-	PkgValidateErrConstant                = "invalid package constant: %w"
-	PkgValidateErrYOLONoOCI               = "OCI images not allowed in YOLO"
-	PkgValidateErrYOLONoGit               = "git repos not allowed in YOLO"
-	PkgValidateErrYOLONoArch              = "cluster architecture not allowed in YOLO"
-	PkgValidateErrYOLONoDistro            = "cluster distros not allowed in YOLO"
-	PkgValidateErrComponentNameNotUnique  = "component name %q is not unique"
-	PkgValidateErrComponentReqDefault     = "component %q cannot be both required and default"
-	PkgValidateErrComponentReqGrouped     = "component %q cannot be both required and grouped"
-	PkgValidateErrChartNameNotUnique      = "chart name %q is not unique"
-	PkgValidateErrChart                   = "invalid chart definition: %w"
-	PkgValidateErrManifestNameNotUnique   = "manifest name %q is not unique"
-	PkgValidateErrManifest                = "invalid manifest definition: %w"
-	PkgValidateErrGroupMultipleDefaults   = "group %q has multiple defaults (%q, %q)"
-	PkgValidateErrGroupOneComponent       = "group %q only has one component (%q)"
-	PkgValidateErrAction                  = "invalid action: %w"
-	PkgValidateErrActionCmdWait           = "action %q cannot be both a command and wait action"
-	PkgValidateErrActionClusterNetwork    = "a single wait action must contain only one of cluster or network"
-	PkgValidateErrChartName               = "chart %q exceed the maximum length of %d characters"
-	PkgValidateErrChartNamespaceMissing   = "chart %q must include a namespace"
-	PkgValidateErrChartURLOrPath          = "chart %q must have either a url or localPath"
-	PkgValidateErrChartVersion            = "chart %q must include a chart version"
-	PkgValidateErrManifestFileOrKustomize = "manifest %q must have at least one file or kustomization"
-	PkgValidateErrManifestNameLength      = "manifest %q exceed the maximum length of %d characters"
-	PkgValidateErrVariable                = "invalid package variable: %w"
-	PkgValidateErrNoComponents            = "package does not contain any compatible components"
+	PkgValidateErrInitNoYOLO               = "sorry, you can't YOLO an init package"
+	PkgValidateErrConstant                 = "invalid package constant: %w"
+	PkgValidateErrYOLONoOCI                = "OCI images not allowed in YOLO"
+	PkgValidateErrYOLONoGit                = "git repos not allowed in YOLO"
+	PkgValidateErrYOLONoArch               = "cluster architecture not allowed in YOLO"
+	PkgValidateErrYOLONoDistro             = "cluster distros not allowed in YOLO"
+	PkgValidateErrComponentNameNotUnique   = "component name %q is not unique"
+	PkgValidateErrComponentReqDefault      = "component %q cannot be both required and default"
+	PkgValidateErrComponentReqGrouped      = "component %q cannot be both required and grouped"
+	PkgValidateErrComponentClusterVersion  = "component %q has an invalid cluster version constraint %q: %w"
+	PkgValidateErrComponentRequirement     = "component %q has an invalid %s requirement %q: %w"
+	PkgValidateErrChartNameNotUnique       = "chart name %q is not unique"
+	PkgValidateErrChart                    = "invalid chart definition: %w"
+	PkgValidateErrManifestNameNotUnique    = "manifest name %q is not unique"
+	PkgValidateErrArtifactReference        = "artifact reference %q in component %q is invalid: %w"
+	PkgValidateErrHostArtifactName         = "host artifact name %q in component %q must be a file name"
+	PkgValidateErrHostArtifactPlatform     = "host artifact %q in component %q has more than one source for %s"
+	PkgValidateErrHostServiceName          = "host service name %q in component %q must be a systemd unit file name such as edge-agent.service"
+	PkgValidateErrHostServiceNameNotUnique = "host service %q in component %q is installed by another component"
+	PkgValidateErrTofuNameNotUnique        = "tofu module name %q in component %q is not unique"
+	PkgValidateErrTofuBackendOption        = "tofu module %q in component %q sets %s which is not used by the %s backend"
+	PkgValidateErrImageBuildReference      = "image build %q in component %q has an invalid image reference: %w"
+	PkgValidateErrImageBuildNotUnique      = "image build %q in component %q is not unique"
+	PkgValidateErrImageBuildContext        = "image build %q in component %q must have a context"
+	PkgValidateErrImageBuildPlatform       = "image build %q in component %q has an invalid platform %q, must be os/architecture or os/architecture/variant"
+	PkgValidateErrImageBuildPlatformDup    = "image build %q in component %q lists the platform %q more than once"
+	PkgValidateErrNamespace                = "invalid namespace definition in component %q: %w"
+	PkgValidateErrNamespaceNotUnique       = "namespace %q in component %q is not unique"
+	PkgValidateErrNamespaceName            = "namespace name %q is invalid: %s"
+	PkgValidateErrNamespacePolicy          = "namespace %q has an invalid policy %q, must be one of create, adopt or require"
+	PkgValidateErrNamespaceLabel           = "namespace %q has an invalid label %q: %s"
+	PkgValidateErrNamespaceAnnotation      = "namespace %q has an invalid annotation %q: %s"
+	PkgValidateErrDataInjectionTarget      = "data injection into %q in component %q must target either a selector and container or a persistentVolumeClaim and image"
+	PkgValidateErrManifest                 = "invalid manifest definition: %w"
+	PkgValidateErrGroupMultipleDefaults    = "group %q has multiple defaults (%q, %q)"
+	PkgValidateErrGroupOneComponent        = "group %q only has one component (%q)"
+	PkgValidateErrGroupName                = "group name %q must be all lowercase and contain no special characters except '-' and cannot start with a '-'"
+	PkgValidateErrGroupNameNotUnique       = "group name %q is not unique"
+	PkgValidateErrGroupSelection           = "group %q has an invalid selection %q, must be one of exactlyOne or atMostOne"
+	PkgValidateErrGroupTooFewComponents    = "group %q must have at least two components"
+	PkgValidateErrGroupMissingComponent    = "group %q contains component %q which is not in the package"
+	PkgValidateErrComponentMultipleGroups  = "component %q is in more than one group (%q, %q)"
+	PkgValidateErrComponentBothGroups      = "component %q cannot be in both group %q and the deprecated group %q"
+	PkgValidateErrAction                   = "invalid action: %w"
+	PkgValidateErrActionCmdWait            = "action %q cannot be both a command and wait action"
+	PkgValidateErrActionClusterNetwork     = "a single wait action must contain only one of cluster, network or exec"
+	PkgValidateErrActionWaitBody           = "wait action body %q must be a valid regular expression used with http or https"
+	PkgValidateErrActionWaitExecCmd        = "wait action exec must include a cmd"
+	PkgValidateErrChartName                = "chart %q exceed the maximum length of %d characters"
+	PkgValidateErrChartNamespaceMissing    = "chart %q must include a namespace"
+	PkgValidateErrChartURLOrPath           = "chart %q must have either a url or localPath"
+	PkgValidateErrChartVersion             = "chart %q must include a chart version"
+	PkgValidateErrManifestFileOrKustomize  = "manifest %q must have at least one file or kustomization"
+	PkgValidateErrManifestNameLength       = "manifest %q exceed the maximum length of %d characters"
+	PkgValidateErrVariable                 = "invalid package variable: %w"
+	PkgValidateErrNoComponents             = "package does not contain any compatible components"
 )
 
 // ValidatePackage runs all validation checks on the package.
@@ -83,11 +122,14 @@ func ValidatePackage(pkg v1alpha1.ZarfPackage) error {
 		}
 	}
 	uniqueComponentNames := make(map[string]bool)
+	uniqueTofuModuleNames := make(map[string]bool)
+	uniqueHostServiceNames := make(map[string]bool)
+	uniqueImageBuilds := make(map[string]bool)
 	groupDefault := make(map[string]string)
 	groupedComponents := make(map[string][]string)
 	if pkg.Metadata.YOLO {
 		for _, component := range pkg.Components {
-			if len(component.Images) > 0 {
+			if len(component.Images) > 0 || len(component.Builds) > 0 || len(component.Artifacts) > 0 {
 				err = errors.Join(err, errors.New(PkgValidateErrYOLONoOCI))
 			}
 			if len(component.Repos) > 0 {
@@ -115,6 +157,24 @@ func ValidatePackage(pkg v1alpha1.ZarfPackage) error {
 				err = errors.Join(err, fmt.Errorf(PkgValidateErrComponentReqGrouped, component.Name))
 			}
 		}
+		if component.Only.Cluster.Version != "" {
+			if _, versionErr := semver.NewConstraint(component.Only.Cluster.Version); versionErr != nil {
+				err = errors.Join(err, fmt.Errorf(PkgValidateErrComponentClusterVersion, component.Name, component.Only.Cluster.Version, versionErr))
+			}
+		}
+		requirements := []struct{ name, value string }{
+			{"cpu", component.Requirements.CPU},
+			{"memory", component.Requirements.Memory},
+			{"storage", component.Requirements.Storage},
+		}
+		for _, requirement := range requirements {
+			if requirement.value == "" {
+				continue
+			}
+			if _, quantityErr := resource.ParseQuantity(requirement.value); quantityErr != nil {
+				err = errors.Join(err, fmt.Errorf(PkgValidateErrComponentRequirement, component.Name, requirement.name, requirement.value, quantityErr))
+			}
+		}
 		uniqueChartNames := make(map[string]bool)
 		for _, chart := range component.Charts {
 			// ensure chart name is unique
@@ -137,6 +197,86 @@ func ValidatePackage(pkg v1alpha1.ZarfPackage) error {
 				err = errors.Join(err, fmt.Errorf(PkgValidateErrManifest, manifestErr))
 			}
 		}
+		for _, a := range component.Artifacts {
+			if _, refErr := transform.ParseImageRef(a.Reference); refErr != nil {
+				err = errors.Join(err, fmt.Errorf(PkgValidateErrArtifactReference, a.Reference, component.Name, refErr))
+			}
+		}
+		for _, build := range component.Builds {
+			refInfo, refErr := transform.ParseImageRef(build.Image)
+			if refErr != nil {
+				err = errors.Join(err, fmt.Errorf(PkgValidateErrImageBuildReference, build.Image, component.Name, refErr))
+			} else {
+				// Only one build can produce the image that is included in the package.
+				if uniqueImageBuilds[refInfo.Reference] {
+					err = errors.Join(err, fmt.Errorf(PkgValidateErrImageBuildNotUnique, build.Image, component.Name))
+				}
+				uniqueImageBuilds[refInfo.Reference] = true
+			}
+			if build.Context == "" {
+				err = errors.Join(err, fmt.Errorf(PkgValidateErrImageBuildContext, build.Image, component.Name))
+			}
+			for i, platform := range build.Platforms {
+				if !isImagePlatform(platform) {
+					err = errors.Join(err, fmt.Errorf(PkgValidateErrImageBuildPlatform, build.Image, component.Name, platform))
+				}
+				if slices.Contains(build.Platforms[:i], platform) {
+					err = errors.Join(err, fmt.Errorf(PkgValidateErrImageBuildPlatformDup, build.Image, component.Name, platform))
+				}
+			}
+		}
+		for _, hostArtifact := range component.HostArtifacts {
+			if hostArtifact.Name == "" || hostArtifact.Name == "." || hostArtifact.Name == ".." || strings.ContainsAny(hostArtifact.Name, `/\`) {
+				err = errors.Join(err, fmt.Errorf(PkgValidateErrHostArtifactName, hostArtifact.Name, component.Name))
+			}
+			platforms := map[string]bool{}
+			for _, src := range hostArtifact.Sources {
+				platform := fmt.Sprintf("%s/%s", cmp.Or(src.OS, "any"), cmp.Or(src.Architecture, "any"))
+				if platforms[platform] {
+					err = errors.Join(err, fmt.Errorf(PkgValidateErrHostArtifactPlatform, hostArtifact.Name, component.Name, platform))
+				}
+				platforms[platform] = true
+			}
+		}
+		for _, hostService := range component.HostServices {
+			if !isUnitName(hostService.Name) {
+				err = errors.Join(err, fmt.Errorf(PkgValidateErrHostServiceName, hostService.Name, component.Name))
+			}
+			// Units are installed into a single directory on the host.
+			if uniqueHostServiceNames[hostService.Name] {
+				err = errors.Join(err, fmt.Errorf(PkgValidateErrHostServiceNameNotUnique, hostService.Name, component.Name))
+			}
+			uniqueHostServiceNames[hostService.Name] = true
+		}
+		for _, module := range component.Tofu {
+			// The state of a module is named after the package and the module.
+			if uniqueTofuModuleNames[module.Name] {
+				err = errors.Join(err, fmt.Errorf(PkgValidateErrTofuNameNotUnique, module.Name, component.Name))
+			}
+			uniqueTofuModuleNames[module.Name] = true
+			backend := cmp.Or(module.Backend, v1alpha1.TofuBackendLocal)
+			if backend == v1alpha1.TofuBackendKubernetes && module.StatePath != "" {
+				err = errors.Join(err, fmt.Errorf(PkgValidateErrTofuBackendOption, module.Name, component.Name, "statePath", backend))
+			}
+			if backend == v1alpha1.TofuBackendLocal && module.Namespace != "" {
+				err = errors.Join(err, fmt.Errorf(PkgValidateErrTofuBackendOption, module.Name, component.Name, "namespace", backend))
+			}
+		}
+		uniqueNamespaces := make(map[string]bool)
+		for _, namespace := range component.Namespaces {
+			if uniqueNamespaces[namespace.Name] {
+				err = errors.Join(err, fmt.Errorf(PkgValidateErrNamespaceNotUnique, namespace.Name, component.Name))
+			}
+			uniqueNamespaces[namespace.Name] = true
+			if namespaceErr := validateNamespace(namespace); namespaceErr != nil {
+				err = errors.Join(err, fmt.Errorf(PkgValidateErrNamespace, component.Name, namespaceErr))
+			}
+		}
+		for _, data := range component.DataInjections {
+			if !validDataInjectionTarget(data.Target) {
+				err = errors.Join(err, fmt.Errorf(PkgValidateErrDataInjectionTarget, data.Target.Path, component.Name))
+			}
+		}
 		if actionsErr := validateActions(component.Actions); actionsErr != nil {
 			err = errors.Join(err, fmt.Errorf("%q: %w", component.Name, actionsErr))
 		}
@@ -156,6 +296,64 @@ func ValidatePackage(pkg v1alpha1.ZarfPackage) error {
 			err = errors.Join(err, fmt.Errorf(PkgValidateErrGroupOneComponent, groupKey, componentNames[0]))
 		}
 	}
+	err = errors.Join(err, validateGroups(pkg, groupedComponents))
+	return err
+}
+
+// validateGroups validates the component groups of a package.
+func validateGroups(pkg v1alpha1.ZarfPackage, deprecatedGroups map[string][]string) error {
+	var err error
+	components := map[string]v1alpha1.ZarfComponent{}
+	for _, component := range pkg.Components {
+		components[component.Name] = component
+	}
+	uniqueGroupNames := map[string]bool{}
+	componentGroups := map[string]string{}
+	for _, group := range pkg.Groups {
+		if !IsLowercaseNumberHyphenNoStartHyphen(group.Name) {
+			err = errors.Join(err, fmt.Errorf(PkgValidateErrGroupName, group.Name))
+		}
+		// Group names share the selection namespace of component and deprecated group names.
+		_, isComponent := components[group.Name]
+		_, isDeprecatedGroup := deprecatedGroups[group.Name]
+		if uniqueGroupNames[group.Name] || isComponent || isDeprecatedGroup {
+			err = errors.Join(err, fmt.Errorf(PkgValidateErrGroupNameNotUnique, group.Name))
+		}
+		uniqueGroupNames[group.Name] = true
+		switch group.Selection {
+		case "", v1alpha1.ExactlyOneSelection, v1alpha1.AtMostOneSelection:
+		default:
+			err = errors.Join(err, fmt.Errorf(PkgValidateErrGroupSelection, group.Name, group.Selection))
+		}
+		if len(group.Components) < 2 {
+			err = errors.Join(err, fmt.Errorf(PkgValidateErrGroupTooFewComponents, group.Name))
+		}
+		defaultComponent := ""
+		for _, name := range group.Components {
+			if other, ok := componentGroups[name]; ok {
+				err = errors.Join(err, fmt.Errorf(PkgValidateErrComponentMultipleGroups, name, other, group.Name))
+				continue
+			}
+			componentGroups[name] = group.Name
+			component, ok := components[name]
+			if !ok {
+				err = errors.Join(err, fmt.Errorf(PkgValidateErrGroupMissingComponent, group.Name, name))
+				continue
+			}
+			if component.IsRequired() {
+				err = errors.Join(err, fmt.Errorf(PkgValidateErrComponentReqGrouped, name))
+			}
+			if component.DeprecatedGroup != "" {
+				err = errors.Join(err, fmt.Errorf(PkgValidateErrComponentBothGroups, name, group.Name, component.DeprecatedGroup))
+			}
+			if component.Default {
+				if defaultComponent != "" {
+					err = errors.Join(err, fmt.Errorf(PkgValidateErrGroupMultipleDefaults, group.Name, defaultComponent, name))
+				}
+				defaultComponent = name
+			}
+		}
+	}
 	return err
 }
 
@@ -222,14 +420,26 @@ func validateAction(action v1alpha1.ZarfComponentAction) error {
 			err = errors.Join(err, fmt.Errorf(PkgValidateErrActionCmdWait, action.Cmd))
 		}
 
-		// Validate only cluster or network, not both
-		if action.Wait.Cluster != nil && action.Wait.Network != nil {
+		// Validate exactly one of cluster, network or exec
+		conditions := 0
+		for _, set := range []bool{action.Wait.Cluster != nil, action.Wait.Network != nil, action.Wait.Exec != nil} {
+			if set {
+				conditions++
+			}
+		}
+		if conditions != 1 {
 			err = errors.Join(err, errors.New(PkgValidateErrActionClusterNetwork))
 		}
 
-		// Validate at least one of cluster or network
-		if action.Wait.Cluster == nil && action.Wait.Network == nil {
-			err = errors.Join(err, errors.New(PkgValidateErrActionClusterNetwork))
+		if network := action.Wait.Network; network != nil && network.Body != "" {
+			_, reErr := regexp.Compile(network.Body)
+			if reErr != nil || !strings.HasPrefix(strings.ToLower(network.Protocol), "http") {
+				err = errors.Join(err, fmt.Errorf(PkgValidateErrActionWaitBody, network.Body))
+			}
+		}
+
+		if action.Wait.Exec != nil && action.Wait.Exec.Cmd == "" {
+			err = errors.Join(err, errors.New(PkgValidateErrActionWaitExecCmd))
 		}
 	}
 
@@ -290,6 +500,48 @@ func validateChart(chart v1alpha1.ZarfChart) error {
 	return err
 }
 
+// validateNamespace runs all validation checks on a namespace.
+func validateNamespace(namespace v1alpha1.ZarfNamespace) error {
+	var err error
+
+	if errs := validation.IsDNS1123Label(namespace.Name); len(errs) > 0 {
+		err = errors.Join(err, fmt.Errorf(PkgValidateErrNamespaceName, namespace.Name, strings.Join(errs, "; ")))
+	}
+
+	switch namespace.Policy {
+	case "", v1alpha1.NamespacePolicyCreate, v1alpha1.NamespacePolicyAdopt, v1alpha1.NamespacePolicyRequire:
+	default:
+		err = errors.Join(err, fmt.Errorf(PkgValidateErrNamespacePolicy, namespace.Name, namespace.Policy))
+	}
+
+	for key, value := range namespace.Labels {
+		if errs := append(validation.IsQualifiedName(key), validation.IsValidLabelValue(value)...); len(errs) > 0 {
+			err = errors.Join(err, fmt.Errorf(PkgValidateErrNamespaceLabel, namespace.Name, key, strings.Join(errs, "; ")))
+		}
+	}
+
+	for key := range namespace.Annotations {
+		if errs := validation.IsQualifiedName(key); len(errs) > 0 {
+			err = errors.Join(err, fmt.Errorf(PkgValidateErrNamespaceAnnotation, namespace.Name, key, strings.Join(errs, "; ")))
+		}
+	}
+
+	return err
+}
+
+// validDataInjectionTarget returns whether a data injection targets exactly one of a container or a PersistentVolumeClaim.
+func validDataInjectionTarget(target v1alpha1.ZarfContainerTarget) bool {
+	targetsContainer := target.Selector != "" || target.Container != ""
+	targetsClaim := target.PersistentVolumeClaim != "" || target.Image != ""
+	if targetsContainer == targetsClaim {
+		return false
+	}
+	if targetsContainer {
+		return target.Selector != "" && target.Container != ""
+	}
+	return target.PersistentVolumeClaim != "" && target.Image != ""
+}
+
 // validateManifest runs all validation checks on a manifest.
 func validateManifest(manifest v1alpha1.ZarfManifest) error {
 	var err error
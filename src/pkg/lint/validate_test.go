@@ -5,6 +5,7 @@
 package lint
 
 import (
+	"errors"
 	"fmt"
 	"strings"
 	"testing"
@@ -12,6 +13,8 @@ import (
 	"github.com/defenseunicorns/pkg/helpers/v2"
 	"github.com/stretchr/testify/require"
 	"github.com/zarf-dev/zarf/src/api/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/validation"
 )
 
 func TestZarfPackageValidate(t *testing.T) {
@@ -88,6 +91,18 @@ func TestZarfPackageValidate(t *testing.T) {
 					{
 						Name: "duplicate",
 					},
+					{
+						Name: "invalid-cluster-version",
+						Only: v1alpha1.ZarfComponentOnlyTarget{
+							Cluster: v1alpha1.ZarfComponentOnlyCluster{
+								Version: "not a version",
+							},
+						},
+					},
+					{
+						Name:         "invalid-requirements",
+						Requirements: v1alpha1.ZarfComponentRequirements{CPU: "2", Memory: "lots"},
+					},
 				},
 				Constants: []v1alpha1.Constant{
 					{
@@ -106,6 +121,244 @@ func TestZarfPackageValidate(t *testing.T) {
 				fmt.Sprintf(PkgValidateErrComponentNameNotUnique, "duplicate"),
 				fmt.Sprintf(PkgValidateErrGroupOneComponent, "a-group", "required-in-group"),
 				fmt.Sprintf(PkgValidateErrGroupMultipleDefaults, "multi-default", "multi-default", "multi-default-2"),
+				fmt.Errorf(PkgValidateErrComponentClusterVersion, "invalid-cluster-version", "not a version", errors.New("improper constraint: not a version")).Error(),
+				fmt.Errorf(PkgValidateErrComponentRequirement, "invalid-requirements", "memory", "lots", resource.ErrFormatWrong).Error(),
+			},
+		},
+		{
+			name: "invalid groups",
+			pkg: v1alpha1.ZarfPackage{
+				Kind: v1alpha1.ZarfPackageConfig,
+				Metadata: v1alpha1.ZarfMetadata{
+					Name: "invalid-groups",
+				},
+				Components: []v1alpha1.ZarfComponent{
+					{Name: "nginx", Default: true},
+					{Name: "istio", Default: true},
+					{Name: "traefik", Required: helpers.BoolPtr(true)},
+					{Name: "legacy", DeprecatedGroup: "old"},
+					{Name: "legacy-2", DeprecatedGroup: "old"},
+				},
+				Groups: []v1alpha1.ZarfComponentGroup{
+					{Name: "ingress", Components: []string{"nginx", "istio", "traefik"}},
+					{Name: "Mesh", Components: []string{"istio", "linkerd"}, Selection: "oneOf"},
+					{Name: "old", Components: []string{"legacy"}},
+				},
+			},
+			expectedErrs: []string{
+				fmt.Sprintf(PkgValidateErrGroupMultipleDefaults, "ingress", "nginx", "istio"),
+				fmt.Sprintf(PkgValidateErrComponentReqGrouped, "traefik"),
+				fmt.Sprintf(PkgValidateErrGroupName, "Mesh"),
+				fmt.Sprintf(PkgValidateErrGroupSelection, "Mesh", "oneOf"),
+				fmt.Sprintf(PkgValidateErrComponentMultipleGroups, "istio", "ingress", "Mesh"),
+				fmt.Sprintf(PkgValidateErrGroupMissingComponent, "Mesh", "linkerd"),
+				fmt.Sprintf(PkgValidateErrGroupNameNotUnique, "old"),
+				fmt.Sprintf(PkgValidateErrGroupTooFewComponents, "old"),
+				fmt.Sprintf(PkgValidateErrComponentBothGroups, "legacy", "old", "old"),
+			},
+		},
+		{
+			name: "invalid data injections",
+			pkg: v1alpha1.ZarfPackage{
+				Kind: v1alpha1.ZarfPackageConfig,
+				Metadata: v1alpha1.ZarfMetadata{
+					Name: "invalid-data-injections",
+				},
+				Components: []v1alpha1.ZarfComponent{
+					{
+						Name: "data",
+						DataInjections: []v1alpha1.ZarfDataInjection{
+							{Target: v1alpha1.ZarfContainerTarget{Path: "/pod", Selector: "app=data", Container: "data"}},
+							{Target: v1alpha1.ZarfContainerTarget{Path: "/pvc", PersistentVolumeClaim: "data", Image: "busybox:1.37"}},
+							{Target: v1alpha1.ZarfContainerTarget{Path: "/no-container", Selector: "app=data"}},
+							{Target: v1alpha1.ZarfContainerTarget{Path: "/no-image", PersistentVolumeClaim: "data"}},
+							{Target: v1alpha1.ZarfContainerTarget{Path: "/both", Selector: "app=data", Container: "data", PersistentVolumeClaim: "data", Image: "busybox:1.37"}},
+							{Target: v1alpha1.ZarfContainerTarget{Path: "/none"}},
+						},
+					},
+				},
+			},
+			expectedErrs: []string{
+				fmt.Sprintf(PkgValidateErrDataInjectionTarget, "/no-container", "data"),
+				fmt.Sprintf(PkgValidateErrDataInjectionTarget, "/no-image", "data"),
+				fmt.Sprintf(PkgValidateErrDataInjectionTarget, "/both", "data"),
+				fmt.Sprintf(PkgValidateErrDataInjectionTarget, "/none", "data"),
+			},
+		},
+		{
+			name: "invalid artifacts",
+			pkg: v1alpha1.ZarfPackage{
+				Kind: v1alpha1.ZarfPackageConfig,
+				Metadata: v1alpha1.ZarfMetadata{
+					Name: "invalid-artifacts",
+				},
+				Components: []v1alpha1.ZarfComponent{
+					{
+						Name: "artifacts",
+						Artifacts: []v1alpha1.ZarfArtifact{
+							{Reference: "oci://ghcr.io/zarf-dev/wasm/hello:1.0.0"},
+							{Reference: "ghcr.io/zarf-dev/Invalid:1.0.0"},
+						},
+					},
+				},
+			},
+			expectedErrs: []string{
+				fmt.Errorf(PkgValidateErrArtifactReference, "ghcr.io/zarf-dev/Invalid:1.0.0", "artifacts", errors.New("invalid reference format: repository name (zarf-dev/Invalid) must be lowercase")).Error(),
+			},
+		},
+		{
+			name: "invalid host artifacts",
+			pkg: v1alpha1.ZarfPackage{
+				Kind: v1alpha1.ZarfPackageConfig,
+				Metadata: v1alpha1.ZarfMetadata{
+					Name: "invalid-host-artifacts",
+				},
+				Components: []v1alpha1.ZarfComponent{
+					{
+						Name: "tools",
+						HostArtifacts: []v1alpha1.ZarfHostArtifact{
+							{
+								Name: "k9s",
+								Sources: []v1alpha1.ZarfHostArtifactSource{
+									{OS: "linux", Architecture: "amd64", Source: "k9s-linux-amd64"},
+									{OS: "darwin", Source: "k9s-darwin"},
+									{Source: "k9s"},
+								},
+							},
+							{
+								Name:    "../k9s",
+								Sources: []v1alpha1.ZarfHostArtifactSource{{Source: "k9s"}},
+							},
+							{
+								Name: "kubectl",
+								Sources: []v1alpha1.ZarfHostArtifactSource{
+									{OS: "linux", Source: "kubectl-linux"},
+									{OS: "linux", Source: "kubectl"},
+								},
+							},
+						},
+					},
+				},
+			},
+			expectedErrs: []string{
+				fmt.Sprintf(PkgValidateErrHostArtifactName, "../k9s", "tools"),
+				fmt.Sprintf(PkgValidateErrHostArtifactPlatform, "kubectl", "tools", "linux/any"),
+			},
+		},
+		{
+			name: "invalid host services",
+			pkg: v1alpha1.ZarfPackage{
+				Kind: v1alpha1.ZarfPackageConfig,
+				Metadata: v1alpha1.ZarfMetadata{
+					Name: "invalid-host-services",
+				},
+				Components: []v1alpha1.ZarfComponent{
+					{
+						Name: "agent",
+						HostServices: []v1alpha1.ZarfHostService{
+							{Name: "edge-agent.service", Source: "edge-agent.service"},
+							{Name: "edge-agent.timer", Source: "edge-agent.timer"},
+							{Name: "../edge-agent.service", Source: "edge-agent.service"},
+							{Name: "edge-agent", Source: "edge-agent.service"},
+						},
+					},
+					{
+						Name:         "agent-v2",
+						HostServices: []v1alpha1.ZarfHostService{{Name: "edge-agent.service", Source: "edge-agent.service"}},
+					},
+				},
+			},
+			expectedErrs: []string{
+				fmt.Sprintf(PkgValidateErrHostServiceName, "../edge-agent.service", "agent"),
+				fmt.Sprintf(PkgValidateErrHostServiceName, "edge-agent", "agent"),
+				fmt.Sprintf(PkgValidateErrHostServiceNameNotUnique, "edge-agent.service", "agent-v2"),
+			},
+		},
+		{
+			name: "invalid tofu modules",
+			pkg: v1alpha1.ZarfPackage{
+				Kind: v1alpha1.ZarfPackageConfig,
+				Metadata: v1alpha1.ZarfMetadata{
+					Name: "invalid-tofu-modules",
+				},
+				Components: []v1alpha1.ZarfComponent{
+					{
+						Name: "network",
+						Tofu: []v1alpha1.ZarfTofuModule{
+							{Name: "network", Source: "network", StatePath: "/tmp/network.tfstate"},
+							{Name: "dns", Source: "dns", Namespace: "infra"},
+						},
+					},
+					{
+						Name: "cluster",
+						Tofu: []v1alpha1.ZarfTofuModule{
+							{Name: "network", Source: "network", Backend: v1alpha1.TofuBackendKubernetes, StatePath: "/tmp/network.tfstate"},
+						},
+					},
+				},
+			},
+			expectedErrs: []string{
+				fmt.Sprintf(PkgValidateErrTofuBackendOption, "dns", "network", "namespace", "local"),
+				fmt.Sprintf(PkgValidateErrTofuNameNotUnique, "network", "cluster"),
+				fmt.Sprintf(PkgValidateErrTofuBackendOption, "network", "cluster", "statePath", "kubernetes"),
+			},
+		},
+		{
+			name: "invalid image builds",
+			pkg: v1alpha1.ZarfPackage{
+				Kind: v1alpha1.ZarfPackageConfig,
+				Metadata: v1alpha1.ZarfMetadata{
+					Name: "invalid-image-builds",
+				},
+				Components: []v1alpha1.ZarfComponent{
+					{
+						Name: "api",
+						Builds: []v1alpha1.ZarfImageBuild{
+							{Image: "ghcr.io/example/api:1.0.0", Context: "api"},
+							{Image: "ghcr.io/example/worker:1.0.0"},
+						},
+					},
+					{
+						Name: "api-debug",
+						Builds: []v1alpha1.ZarfImageBuild{
+							{Image: "ghcr.io/example/api:1.0.0", Context: "api", Target: "debug"},
+							{Image: "ghcr.io/example/debug:1.0.0", Context: "debug", Platforms: []string{"linux/amd64", "linux/arm64", "linux/amd64", "darwin/arm64"}},
+						},
+					},
+				},
+			},
+			expectedErrs: []string{
+				fmt.Sprintf(PkgValidateErrImageBuildContext, "ghcr.io/example/worker:1.0.0", "api"),
+				fmt.Sprintf(PkgValidateErrImageBuildNotUnique, "ghcr.io/example/api:1.0.0", "api-debug"),
+				fmt.Sprintf(PkgValidateErrImageBuildPlatformDup, "ghcr.io/example/debug:1.0.0", "api-debug", "linux/amd64"),
+				fmt.Sprintf(PkgValidateErrImageBuildPlatform, "ghcr.io/example/debug:1.0.0", "api-debug", "darwin/arm64"),
+			},
+		},
+		{
+			name: "invalid namespaces",
+			pkg: v1alpha1.ZarfPackage{
+				Kind: v1alpha1.ZarfPackageConfig,
+				Metadata: v1alpha1.ZarfMetadata{
+					Name: "invalid-namespaces",
+				},
+				Components: []v1alpha1.ZarfComponent{
+					{
+						Name: "app",
+						Namespaces: []v1alpha1.ZarfNamespace{
+							{Name: "app", Policy: v1alpha1.NamespacePolicyCreate},
+							{Name: "app", Policy: v1alpha1.NamespacePolicyAdopt},
+							{Name: "Shared_NS", Policy: v1alpha1.NamespacePolicyRequire},
+							{Name: "monitoring", Policy: "delete"},
+							{Name: "logging", Labels: map[string]string{"team/a/b": "logging"}},
+						},
+					},
+				},
+			},
+			expectedErrs: []string{
+				fmt.Sprintf(PkgValidateErrNamespaceNotUnique, "app", "app"),
+				fmt.Errorf(PkgValidateErrNamespace, "app", fmt.Errorf(PkgValidateErrNamespaceName, "Shared_NS", strings.Join(validation.IsDNS1123Label("Shared_NS"), "; "))).Error(),
+				fmt.Errorf(PkgValidateErrNamespace, "app", fmt.Errorf(PkgValidateErrNamespacePolicy, "monitoring", "delete")).Error(),
+				fmt.Errorf(PkgValidateErrNamespace, "app", fmt.Errorf(PkgValidateErrNamespaceLabel, "logging", "team/a/b", strings.Join(validation.IsQualifiedName("team/a/b"), "; "))).Error(),
 			},
 		},
 		{
@@ -118,9 +371,10 @@ func TestZarfPackageValidate(t *testing.T) {
 				},
 				Components: []v1alpha1.ZarfComponent{
 					{
-						Name:   "yolo",
-						Images: []string{"an-image"},
-						Repos:  []string{"a-repo"},
+						Name:      "yolo",
+						Images:    []string{"an-image"},
+						Artifacts: []v1alpha1.ZarfArtifact{{Reference: "an-artifact"}},
+						Repos:     []string{"a-repo"},
 						Only: v1alpha1.ZarfComponentOnlyTarget{
 							Cluster: v1alpha1.ZarfComponentOnlyCluster{
 								Architecture: "not-empty",
@@ -463,6 +717,40 @@ func TestValidateComponentAction(t *testing.T) {
 			},
 			expectedErrs: []string{PkgValidateErrActionClusterNetwork},
 		},
+		{
+			name: "network and exec both set",
+			action: v1alpha1.ZarfComponentAction{
+				Wait: &v1alpha1.ZarfComponentActionWait{Network: &v1alpha1.ZarfComponentActionWaitNetwork{Protocol: "tcp"}, Exec: &v1alpha1.ZarfComponentActionWaitExec{Cmd: "true"}},
+			},
+			expectedErrs: []string{PkgValidateErrActionClusterNetwork},
+		},
+		{
+			name: "valid network wait with body",
+			action: v1alpha1.ZarfComponentAction{
+				Wait: &v1alpha1.ZarfComponentActionWait{Network: &v1alpha1.ZarfComponentActionWaitNetwork{Protocol: "https", Address: "example.com", Body: "^ok$"}},
+			},
+		},
+		{
+			name: "body with tcp",
+			action: v1alpha1.ZarfComponentAction{
+				Wait: &v1alpha1.ZarfComponentActionWait{Network: &v1alpha1.ZarfComponentActionWaitNetwork{Protocol: "tcp", Body: "ok"}},
+			},
+			expectedErrs: []string{fmt.Sprintf(PkgValidateErrActionWaitBody, "ok")},
+		},
+		{
+			name: "invalid body regular expression",
+			action: v1alpha1.ZarfComponentAction{
+				Wait: &v1alpha1.ZarfComponentActionWait{Network: &v1alpha1.ZarfComponentActionWaitNetwork{Protocol: "http", Body: "(ok"}},
+			},
+			expectedErrs: []string{fmt.Sprintf(PkgValidateErrActionWaitBody, "(ok")},
+		},
+		{
+			name: "exec without a cmd",
+			action: v1alpha1.ZarfComponentAction{
+				Wait: &v1alpha1.ZarfComponentActionWait{Exec: &v1alpha1.ZarfComponentActionWaitExec{}},
+			},
+			expectedErrs: []string{PkgValidateErrActionWaitExecCmd},
+		},
 	}
 
 	for _, tt := range tests {
@@ -0,0 +1,55 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package logger
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+)
+
+// fanoutHandler sends each record to all of its handlers.
+type fanoutHandler struct {
+	handlers []slog.Handler
+}
+
+// Enabled returns true if any handler is enabled for the level.
+func (h *fanoutHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	for _, handler := range h.handlers {
+		if handler.Enabled(ctx, level) {
+			return true
+		}
+	}
+	return false
+}
+
+// Handle sends the record to every handler enabled for its level.
+func (h *fanoutHandler) Handle(ctx context.Context, r slog.Record) error {
+	var errs []error
+	for _, handler := range h.handlers {
+		if !handler.Enabled(ctx, r.Level) {
+			continue
+		}
+		errs = append(errs, handler.Handle(ctx, r.Clone()))
+	}
+	return errors.Join(errs...)
+}
+
+// WithAttrs returns a fanoutHandler whose handlers include the attrs.
+func (h *fanoutHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	handlers := make([]slog.Handler, 0, len(h.handlers))
+	for _, handler := range h.handlers {
+		handlers = append(handlers, handler.WithAttrs(attrs))
+	}
+	return &fanoutHandler{handlers: handlers}
+}
+
+// WithGroup returns a fanoutHandler whose handlers use the group.
+func (h *fanoutHandler) WithGroup(name string) slog.Handler {
+	handlers := make([]slog.Handler, 0, len(h.handlers))
+	for _, handler := range h.handlers {
+		handlers = append(handlers, handler.WithGroup(name))
+	}
+	return &fanoutHandler{handlers: handlers}
+}
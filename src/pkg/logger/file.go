@@ -0,0 +1,266 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package logger
+
+import (
+	"cmp"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultFileMaxSizeMB is the size a log file can grow to before it is rotated.
+	DefaultFileMaxSizeMB = 100
+	// DefaultFileMaxBackups is the number of rotated log files that are retained.
+	DefaultFileMaxBackups = 5
+
+	backupTimeFormat = "2006-01-02T15-04-05.000"
+)
+
+// FileConfig configures a log file destination.
+type FileConfig struct {
+	// Path is the path of the log file. No file is written when empty.
+	Path string
+	// MaxSizeMB is the size in megabytes a log file can grow to before it is rotated. Defaults to DefaultFileMaxSizeMB.
+	MaxSizeMB int
+	// MaxBackups is the number of rotated log files to retain. Defaults to DefaultFileMaxBackups.
+	MaxBackups int
+	// Compress gzips rotated log files.
+	Compress bool
+}
+
+// RotatingFile is an io.Writer that writes to a log file and rotates it once it grows past its max size.
+type RotatingFile struct {
+	cfg  FileConfig
+	mu   sync.Mutex
+	file *os.File
+	size int64
+}
+
+var _ io.WriteCloser = (*RotatingFile)(nil)
+
+// NewRotatingFile opens the log file for appending, creating it and its parent directories if needed.
+func NewRotatingFile(cfg FileConfig) (*RotatingFile, error) {
+	if cfg.Path == "" {
+		return nil, errors.New("log file path cannot be empty")
+	}
+	if cfg.MaxSizeMB <= 0 {
+		cfg.MaxSizeMB = DefaultFileMaxSizeMB
+	}
+	if cfg.MaxBackups <= 0 {
+		cfg.MaxBackups = DefaultFileMaxBackups
+	}
+	r := &RotatingFile{cfg: cfg}
+	err := r.open()
+	if err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Write writes p to the log file, rotating it first if the write would exceed the max size.
+func (r *RotatingFile) Write(p []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.size > 0 && r.size+int64(len(p)) > r.maxSize() {
+		err := r.rotate()
+		if err != nil {
+			return 0, err
+		}
+	}
+	n, err := r.file.Write(p)
+	r.size += int64(n)
+	return n, err
+}
+
+// Name returns the path of the log file.
+func (r *RotatingFile) Name() string {
+	return r.cfg.Path
+}
+
+// Close closes the log file.
+func (r *RotatingFile) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.file.Close()
+}
+
+func (r *RotatingFile) maxSize() int64 {
+	return int64(r.cfg.MaxSizeMB) * 1024 * 1024
+}
+
+func (r *RotatingFile) open() error {
+	err := os.MkdirAll(filepath.Dir(r.cfg.Path), 0o700)
+	if err != nil {
+		return err
+	}
+	f, err := os.OpenFile(r.cfg.Path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("unable to open log file: %w", err)
+	}
+	info, err := f.Stat()
+	if err != nil {
+		return errors.Join(err, f.Close())
+	}
+	r.file = f
+	r.size = info.Size()
+	return nil
+}
+
+// rotate moves the current log file to a timestamped backup, opens a new log file, and removes old backups.
+func (r *RotatingFile) rotate() error {
+	err := r.file.Close()
+	if err != nil {
+		return err
+	}
+	backupPath := r.backupPath(time.Now())
+	err = os.Rename(r.cfg.Path, backupPath)
+	if err != nil {
+		return err
+	}
+	err = r.open()
+	if err != nil {
+		return err
+	}
+	if r.cfg.Compress {
+		err = compressFile(backupPath)
+		if err != nil {
+			return err
+		}
+	}
+	return r.prune()
+}
+
+// backupPath returns a path for a backup rotated at t that does not collide with existing backups.
+func (r *RotatingFile) backupPath(t time.Time) string {
+	ext := filepath.Ext(r.cfg.Path)
+	prefix := strings.TrimSuffix(r.cfg.Path, ext)
+	ts := t.UTC().Format(backupTimeFormat)
+	path := fmt.Sprintf("%s-%s%s", prefix, ts, ext)
+	for i := 1; fileExists(path) || fileExists(path+".gz"); i++ {
+		path = fmt.Sprintf("%s-%s.%d%s", prefix, ts, i, ext)
+	}
+	return path
+}
+
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+// backup is a rotated log file.
+type backup struct {
+	name string
+	time time.Time
+	// seq is the collision suffix of backups rotated within the same millisecond, zero for the first one.
+	seq int
+}
+
+// parseBackup parses a file name in the <prefix>-<timestamp>[.<N>]<ext>[.gz] form backupPath creates.
+func parseBackup(name, prefix, ext string) (backup, bool) {
+	rest := strings.TrimSuffix(name, ".gz")
+	if !strings.HasSuffix(rest, ext) || !strings.HasPrefix(rest, prefix+"-") {
+		return backup{}, false
+	}
+	rest = strings.TrimSuffix(strings.TrimPrefix(rest, prefix+"-"), ext)
+	if len(rest) < len(backupTimeFormat) {
+		return backup{}, false
+	}
+	t, err := time.Parse(backupTimeFormat, rest[:len(backupTimeFormat)])
+	if err != nil {
+		return backup{}, false
+	}
+	b := backup{name: name, time: t}
+	rest = rest[len(backupTimeFormat):]
+	if rest == "" {
+		return b, true
+	}
+	seq, ok := strings.CutPrefix(rest, ".")
+	if !ok {
+		return backup{}, false
+	}
+	b.seq, err = strconv.Atoi(seq)
+	if err != nil || b.seq < 1 || strconv.Itoa(b.seq) != seq {
+		return backup{}, false
+	}
+	return b, true
+}
+
+// prune removes the oldest backups so that at most MaxBackups are retained.
+func (r *RotatingFile) prune() error {
+	ext := filepath.Ext(r.cfg.Path)
+	prefix := strings.TrimSuffix(filepath.Base(r.cfg.Path), ext)
+	entries, err := os.ReadDir(filepath.Dir(r.cfg.Path))
+	if err != nil {
+		return err
+	}
+	backups := []backup{}
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		b, ok := parseBackup(entry.Name(), prefix, ext)
+		if !ok {
+			continue
+		}
+		backups = append(backups, b)
+	}
+	// Sort oldest first, by the numeric suffix for backups rotated within the same millisecond.
+	slices.SortFunc(backups, func(a, b backup) int {
+		if c := a.time.Compare(b.time); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.seq, b.seq)
+	})
+	if len(backups) <= r.cfg.MaxBackups {
+		return nil
+	}
+	var errs []error
+	for _, b := range backups[:len(backups)-r.cfg.MaxBackups] {
+		errs = append(errs, os.Remove(filepath.Join(filepath.Dir(r.cfg.Path), b.name)))
+	}
+	return errors.Join(errs...)
+}
+
+// compressFile gzips the file at path and removes the original.
+func compressFile(path string) error {
+	err := gzipFile(path, path+".gz")
+	if err != nil {
+		return err
+	}
+	return os.Remove(path)
+}
+
+func gzipFile(srcPath, dstPath string) (err error) {
+	src, err := os.Open(srcPath)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = errors.Join(err, src.Close())
+	}()
+	dst, err := os.OpenFile(dstPath, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = errors.Join(err, dst.Close())
+	}()
+	gz := gzip.NewWriter(dst)
+	_, err = io.Copy(gz, src)
+	if err != nil {
+		return errors.Join(err, gz.Close())
+	}
+	return gz.Close()
+}
@@ -0,0 +1,183 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package logger
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRotatingFile(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name            string
+		compress        bool
+		expectedBackups int
+	}{
+		{
+			name:            "rotated files are retained",
+			expectedBackups: 2,
+		},
+		{
+			name:            "rotated files are compressed",
+			compress:        true,
+			expectedBackups: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			dir := t.TempDir()
+			path := filepath.Join(dir, "logs", "zarf.log")
+			f, err := NewRotatingFile(FileConfig{
+				Path:       path,
+				MaxSizeMB:  1,
+				MaxBackups: tt.expectedBackups,
+				Compress:   tt.compress,
+			})
+			require.NoError(t, err)
+			t.Cleanup(func() {
+				require.NoError(t, f.Close())
+			})
+
+			line := []byte(strings.Repeat("a", 1023) + "\n")
+			for range 4 * 1024 {
+				_, err := f.Write(line)
+				require.NoError(t, err)
+			}
+
+			info, err := os.Stat(path)
+			require.NoError(t, err)
+			require.LessOrEqual(t, info.Size(), int64(1024*1024))
+
+			entries, err := os.ReadDir(filepath.Dir(path))
+			require.NoError(t, err)
+			backups := []string{}
+			for _, entry := range entries {
+				if entry.Name() != "zarf.log" {
+					backups = append(backups, entry.Name())
+				}
+			}
+			require.Len(t, backups, tt.expectedBackups)
+			for _, backup := range backups {
+				require.True(t, strings.HasPrefix(backup, "zarf-"))
+				if !tt.compress {
+					require.True(t, strings.HasSuffix(backup, ".log"))
+					continue
+				}
+				require.True(t, strings.HasSuffix(backup, ".log.gz"))
+				gf, err := os.Open(filepath.Join(filepath.Dir(path), backup))
+				require.NoError(t, err)
+				gz, err := gzip.NewReader(gf)
+				require.NoError(t, err)
+				b, err := io.ReadAll(gz)
+				require.NoError(t, err)
+				require.Len(t, b, 1024*1024)
+				require.NoError(t, gf.Close())
+			}
+		})
+	}
+}
+
+func TestRotatingFilePrune(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "zarf.log")
+	f, err := NewRotatingFile(FileConfig{Path: path, MaxBackups: 2})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, f.Close())
+	})
+
+	names := []string{
+		"zarf-2024-01-02T03-04-05.000.log",
+		"zarf-2024-01-02T03-04-05.000.2.log",
+		"zarf-2024-01-02T03-04-05.000.10.log.gz",
+		"zarf-2023-12-31T23-59-59.999.log",
+		// Not backups of the log file
+		"zarf-debug.log",
+		"zarf-2024-01-02T03-04-05.000.old.log",
+		"zarf-2024-01-02T03-04-05.000.log.txt",
+		"other-2024-01-02T03-04-05.000.log",
+	}
+	for _, name := range names {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), nil, 0o600))
+	}
+	require.NoError(t, f.prune())
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	remaining := []string{}
+	for _, entry := range entries {
+		remaining = append(remaining, entry.Name())
+	}
+	require.ElementsMatch(t, []string{
+		"zarf.log",
+		"zarf-2024-01-02T03-04-05.000.2.log",
+		"zarf-2024-01-02T03-04-05.000.10.log.gz",
+		"zarf-debug.log",
+		"zarf-2024-01-02T03-04-05.000.old.log",
+		"zarf-2024-01-02T03-04-05.000.log.txt",
+		"other-2024-01-02T03-04-05.000.log",
+	}, remaining)
+}
+
+func TestRotatingFileAppends(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "zarf.log")
+	err := os.WriteFile(path, []byte("existing\n"), 0o600)
+	require.NoError(t, err)
+
+	f, err := NewRotatingFile(FileConfig{Path: path})
+	require.NoError(t, err)
+	_, err = f.Write([]byte("new\n"))
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	b, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, "existing\nnew\n", string(b))
+}
+
+func TestNewWithFile(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "zarf.log")
+	f, err := NewRotatingFile(FileConfig{Path: path})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, f.Close())
+	})
+	cfg := Config{
+		Level:       Info,
+		Format:      FormatConsole,
+		Destination: DestinationNone,
+		File:        f,
+	}
+	l, err := New(cfg)
+	require.NoError(t, err)
+	l.With("component", "podinfo").Info("deploying component")
+	l.Debug("not written")
+
+	b, err := os.ReadFile(path)
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(b)), "\n")
+	require.Len(t, lines, 1)
+	entry := map[string]any{}
+	err = json.Unmarshal([]byte(lines[0]), &entry)
+	require.NoError(t, err)
+	require.Equal(t, "deploying component", entry["msg"])
+	require.Equal(t, "podinfo", entry["component"])
+}
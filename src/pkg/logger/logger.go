@@ -135,6 +135,11 @@ type Config struct {
 	Format
 	Destination
 	Color
+	// Output selects where logs are sent. An empty value corresponds to OutputStderr.
+	Output
+	// File additionally receives JSON logs when set, usually a RotatingFile. It is not written with the legacy format,
+	// which writes its own logs to the file through the message package.
+	File io.Writer
 }
 
 // Color is a type that represents whether or not to use color in the logger.
@@ -147,6 +152,8 @@ func (c Config) LogValue() slog.Value {
 		slog.Any("format", c.Format),
 		slog.Any("destination", destinationString(c.Destination)),
 		slog.Bool("color", bool(c.Color)),
+		slog.Any("output", c.Output),
+		slog.Bool("file", c.File != nil),
 	)
 }
 
@@ -204,6 +211,28 @@ func New(cfg Config) (*slog.Logger, error) {
 		return nil, fmt.Errorf("unsupported log format: %s", cfg.Format)
 	}
 
+	// Host logging systems record the time and level themselves so they replace the formatted handler.
+	if cfg.Output != "" && cfg.Output != OutputStderr {
+		if cfg.Format.ToLower() == FormatLegacy {
+			return nil, fmt.Errorf("log destination %s is not supported with the %s log format", cfg.Output, FormatLegacy)
+		}
+		var err error
+		handler, err = newSystemHandler(cfg.Output, slog.Level(cfg.Level))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// The legacy format writes its own log file through the message package.
+	if cfg.File != nil && cfg.Format.ToLower() != FormatLegacy {
+		handler = &fanoutHandler{
+			handlers: []slog.Handler{handler, slog.NewJSONHandler(cfg.File, &opts)},
+		}
+	}
+
+	// Redact secrets before records reach any destination.
+	handler = &redactHandler{handler: handler}
+
 	return slog.New(handler), nil
 }
 
@@ -0,0 +1,147 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package logger
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// RedactedValue replaces secrets in log output.
+const RedactedValue = "**sanitized**"
+
+// minSecretLength is the length below which secrets are only redacted when they make up a whole value. Replacing short
+// values everywhere would mangle unrelated output that happens to contain them, such as a password of "zarf".
+const minSecretLength = 8
+
+// sensitiveKeys are attribute key fragments whose values are always redacted regardless of their content.
+var sensitiveKeys = []string{"password", "passwd", "token"}
+
+// secrets holds the values registered with AddSecret.
+var secrets = &secretSet{}
+
+type secretSet struct {
+	mu     sync.RWMutex
+	values []string
+	short  []string
+}
+
+// AddSecret registers values that must never appear in log output. Empty values are ignored and values shorter than
+// minSecretLength are only redacted when a message or attribute consists of nothing but the value.
+func AddSecret(values ...string) {
+	secrets.mu.Lock()
+	defer secrets.mu.Unlock()
+	for _, v := range values {
+		if v == "" || slices.Contains(secrets.values, v) || slices.Contains(secrets.short, v) {
+			continue
+		}
+		if len(v) < minSecretLength {
+			secrets.short = append(secrets.short, v)
+			continue
+		}
+		secrets.values = append(secrets.values, v)
+	}
+	// Replace longer secrets first so a secret containing another is fully redacted.
+	slices.SortFunc(secrets.values, func(a, b string) int {
+		return len(b) - len(a)
+	})
+}
+
+// Redact replaces every registered secret in s with RedactedValue. Short secrets are only replaced when they are all
+// of s.
+func Redact(s string) string {
+	secrets.mu.RLock()
+	defer secrets.mu.RUnlock()
+	if slices.Contains(secrets.short, s) {
+		return RedactedValue
+	}
+	for _, v := range secrets.values {
+		s = strings.ReplaceAll(s, v, RedactedValue)
+	}
+	return s
+}
+
+// redactHandler masks registered secrets and sensitive attributes before passing records to its handler.
+type redactHandler struct {
+	handler slog.Handler
+}
+
+// Enabled returns true if the wrapped handler is enabled for the level.
+func (h *redactHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.handler.Enabled(ctx, level)
+}
+
+// Handle redacts the message and attributes of the record and passes it to the wrapped handler.
+func (h *redactHandler) Handle(ctx context.Context, r slog.Record) error {
+	nr := slog.NewRecord(r.Time, r.Level, Redact(r.Message), r.PC)
+	r.Attrs(func(a slog.Attr) bool {
+		nr.AddAttrs(redactAttr(a))
+		return true
+	})
+	return h.handler.Handle(ctx, nr)
+}
+
+// WithAttrs returns a redactHandler whose wrapped handler includes the redacted attrs.
+func (h *redactHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	redacted := make([]slog.Attr, 0, len(attrs))
+	for _, a := range attrs {
+		redacted = append(redacted, redactAttr(a))
+	}
+	return &redactHandler{handler: h.handler.WithAttrs(redacted)}
+}
+
+// WithGroup returns a redactHandler whose wrapped handler uses the group.
+func (h *redactHandler) WithGroup(name string) slog.Handler {
+	return &redactHandler{handler: h.handler.WithGroup(name)}
+}
+
+func redactAttr(a slog.Attr) slog.Attr {
+	if isSensitiveKey(a.Key) {
+		return slog.String(a.Key, RedactedValue)
+	}
+	v := a.Value.Resolve()
+	switch v.Kind() {
+	case slog.KindString:
+		return slog.String(a.Key, Redact(v.String()))
+	case slog.KindGroup:
+		attrs := v.Group()
+		redacted := make([]slog.Attr, 0, len(attrs))
+		for _, ga := range attrs {
+			redacted = append(redacted, redactAttr(ga))
+		}
+		return slog.Attr{Key: a.Key, Value: slog.GroupValue(redacted...)}
+	case slog.KindAny:
+		// Errors and Stringers are rendered as text by the handlers so they are redacted in their text form.
+		switch av := v.Any().(type) {
+		case error:
+			return redactText(a.Key, v, av.Error())
+		case fmt.Stringer:
+			return redactText(a.Key, v, av.String())
+		}
+	}
+	return slog.Attr{Key: a.Key, Value: v}
+}
+
+// redactText returns the attr as a redacted string if its text contains a secret and unchanged otherwise.
+func redactText(key string, v slog.Value, text string) slog.Attr {
+	redacted := Redact(text)
+	if redacted == text {
+		return slog.Attr{Key: key, Value: v}
+	}
+	return slog.String(key, redacted)
+}
+
+func isSensitiveKey(key string) bool {
+	key = strings.ToLower(key)
+	for _, k := range sensitiveKeys {
+		if strings.Contains(key, k) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,39 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+//go:build !windows && !plan9
+
+package logger
+
+import (
+	"fmt"
+	"log/slog"
+	"log/syslog"
+)
+
+// syslogSender sends records to the local syslog daemon.
+type syslogSender struct {
+	w *syslog.Writer
+}
+
+func newSyslogSender() (*syslogSender, error) {
+	w, err := syslog.New(syslog.LOG_INFO|syslog.LOG_USER, syslogIdentifier)
+	if err != nil {
+		return nil, fmt.Errorf("unable to connect to syslog: %w", err)
+	}
+	return &syslogSender{w: w}, nil
+}
+
+func (s *syslogSender) send(level slog.Level, msg string, attrs []slog.Attr) error {
+	line := formatLine(msg, attrs)
+	switch {
+	case level >= slog.LevelError:
+		return s.w.Err(line)
+	case level >= slog.LevelWarn:
+		return s.w.Warning(line)
+	case level >= slog.LevelInfo:
+		return s.w.Info(line)
+	default:
+		return s.w.Debug(line)
+	}
+}
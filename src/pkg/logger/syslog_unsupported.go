@@ -0,0 +1,21 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+//go:build windows || plan9
+
+package logger
+
+import (
+	"errors"
+	"log/slog"
+)
+
+type syslogSender struct{}
+
+func newSyslogSender() (*syslogSender, error) {
+	return nil, errors.New("syslog is not supported on this platform")
+}
+
+func (s *syslogSender) send(_ slog.Level, _ string, _ []slog.Attr) error {
+	return nil
+}
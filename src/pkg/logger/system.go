@@ -0,0 +1,190 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package logger
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+
+	"github.com/coreos/go-systemd/v22/journal"
+)
+
+// Output declares where formatted logs are sent.
+type Output string
+
+var (
+	// OutputStderr writes logs in the configured Format to Config.Destination, which defaults to Stderr.
+	OutputStderr Output = "stderr"
+	// OutputSyslog sends logs to the local syslog daemon.
+	OutputSyslog Output = "syslog"
+	// OutputJournald sends logs to the local systemd journal with attributes as journal fields.
+	OutputJournald Output = "journald"
+)
+
+// ParseOutput takes a string representation of an Output and ensures it is supported.
+func ParseOutput(s string) (Output, error) {
+	o := Output(strings.ToLower(s))
+	switch o {
+	case "":
+		return OutputStderr, nil
+	case OutputStderr, OutputSyslog, OutputJournald:
+		return o, nil
+	default:
+		return "", fmt.Errorf("invalid log destination: %s", s)
+	}
+}
+
+// syslogIdentifier identifies Zarf in syslog and the journal.
+const syslogIdentifier = "zarf"
+
+// systemSender delivers a flattened record to a host logging system.
+type systemSender interface {
+	send(level slog.Level, msg string, attrs []slog.Attr) error
+}
+
+// systemHandler flattens records into a message and key value pairs for a host logging system.
+// The host logging system records the time, level and source, so the handler only sends the message and attributes.
+type systemHandler struct {
+	level  slog.Level
+	sender systemSender
+	attrs  []slog.Attr
+	groups []string
+}
+
+func newSystemHandler(output Output, level slog.Level) (slog.Handler, error) {
+	var sender systemSender
+	switch output {
+	case OutputSyslog:
+		s, err := newSyslogSender()
+		if err != nil {
+			return nil, err
+		}
+		sender = s
+	case OutputJournald:
+		if !journal.Enabled() {
+			return nil, fmt.Errorf("the systemd journal is not available on this host")
+		}
+		sender = journaldSender{}
+	default:
+		return nil, fmt.Errorf("unsupported log destination: %s", output)
+	}
+	return &systemHandler{level: level, sender: sender}, nil
+}
+
+// Enabled returns true if the level is at or above the configured level.
+func (h *systemHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return level >= h.level
+}
+
+// Handle flattens the record attributes and sends the record.
+func (h *systemHandler) Handle(_ context.Context, r slog.Record) error {
+	attrs := make([]slog.Attr, 0, len(h.attrs)+r.NumAttrs())
+	attrs = append(attrs, h.attrs...)
+	r.Attrs(func(a slog.Attr) bool {
+		attrs = flattenAttr(attrs, h.groups, a)
+		return true
+	})
+	return h.sender.send(r.Level, r.Message, attrs)
+}
+
+// WithAttrs returns a systemHandler that includes the flattened attrs in every record.
+func (h *systemHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	nh := *h
+	nh.attrs = append([]slog.Attr{}, h.attrs...)
+	for _, a := range attrs {
+		nh.attrs = flattenAttr(nh.attrs, h.groups, a)
+	}
+	return &nh
+}
+
+// WithGroup returns a systemHandler that prefixes the keys of following attrs with the group name.
+func (h *systemHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	nh := *h
+	nh.groups = append(append([]string{}, h.groups...), name)
+	return &nh
+}
+
+// flattenAttr appends the attr to attrs with its key prefixed by the groups it belongs to, expanding nested groups.
+func flattenAttr(attrs []slog.Attr, groups []string, a slog.Attr) []slog.Attr {
+	v := a.Value.Resolve()
+	if v.Kind() == slog.KindGroup {
+		if a.Key != "" {
+			groups = append(append([]string{}, groups...), a.Key)
+		}
+		for _, ga := range v.Group() {
+			attrs = flattenAttr(attrs, groups, ga)
+		}
+		return attrs
+	}
+	if a.Key == "" {
+		return attrs
+	}
+	key := strings.Join(append(append([]string{}, groups...), a.Key), ".")
+	return append(attrs, slog.Attr{Key: key, Value: v})
+}
+
+// formatLine renders the message followed by the attrs as key=value pairs.
+func formatLine(msg string, attrs []slog.Attr) string {
+	var sb strings.Builder
+	sb.WriteString(msg)
+	for _, a := range attrs {
+		sb.WriteString(" ")
+		sb.WriteString(a.Key)
+		sb.WriteString("=")
+		val := a.Value.String()
+		if strings.ContainsAny(val, " \t\n\"=") {
+			val = strconv.Quote(val)
+		}
+		sb.WriteString(val)
+	}
+	return sb.String()
+}
+
+// journaldSender sends records to the systemd journal.
+type journaldSender struct{}
+
+func (journaldSender) send(level slog.Level, msg string, attrs []slog.Attr) error {
+	vars := map[string]string{
+		"SYSLOG_IDENTIFIER": syslogIdentifier,
+	}
+	for _, a := range attrs {
+		vars[journalFieldName(a.Key)] = a.Value.String()
+	}
+	return journal.Send(formatLine(msg, attrs), journalPriority(level), vars)
+}
+
+// journalFieldName converts an attr key to a valid journal field name, which may only contain uppercase letters,
+// digits and underscores and may not start with an underscore or digit.
+func journalFieldName(key string) string {
+	name := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z':
+			return r - 'a' + 'A'
+		case r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+			return r
+		default:
+			return '_'
+		}
+	}, key)
+	return "ZARF_" + name
+}
+
+func journalPriority(level slog.Level) journal.Priority {
+	switch {
+	case level >= slog.LevelError:
+		return journal.PriErr
+	case level >= slog.LevelWarn:
+		return journal.PriWarning
+	case level >= slog.LevelInfo:
+		return journal.PriInfo
+	default:
+		return journal.PriDebug
+	}
+}
@@ -0,0 +1,88 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package logger
+
+import (
+	"errors"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type recordedLine struct {
+	level slog.Level
+	line  string
+}
+
+type recordingSender struct {
+	lines []recordedLine
+}
+
+func (s *recordingSender) send(level slog.Level, msg string, attrs []slog.Attr) error {
+	s.lines = append(s.lines, recordedLine{level: level, line: formatLine(msg, attrs)})
+	return nil
+}
+
+func TestParseOutput(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		input       string
+		expected    Output
+		expectedErr string
+	}{
+		{input: "", expected: OutputStderr},
+		{input: "stderr", expected: OutputStderr},
+		{input: "Syslog", expected: OutputSyslog},
+		{input: "journald", expected: OutputJournald},
+		{input: "stdout", expectedErr: "invalid log destination: stdout"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			t.Parallel()
+			output, err := ParseOutput(tt.input)
+			if tt.expectedErr != "" {
+				require.EqualError(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, output)
+		})
+	}
+}
+
+func TestSystemHandler(t *testing.T) {
+	t.Parallel()
+
+	sender := &recordingSender{}
+	l := slog.New(&systemHandler{level: slog.LevelInfo, sender: sender})
+
+	l.Debug("not sent")
+	l.Info("deploying component", "name", "podinfo", "retries", 3)
+	l.With("package", "dos-games").WithGroup("image").Warn("slow pull", "ref", "ghcr.io/zarf-dev/doom:1.0", slog.Group("layer", "size", 10))
+	l.Error("deploy failed", "error", errors.New("timed out waiting for pods"))
+
+	expected := []recordedLine{
+		{level: slog.LevelInfo, line: "deploying component name=podinfo retries=3"},
+		{level: slog.LevelWarn, line: "slow pull package=dos-games image.ref=ghcr.io/zarf-dev/doom:1.0 image.layer.size=10"},
+		{level: slog.LevelError, line: `deploy failed error="timed out waiting for pods"`},
+	}
+	require.Equal(t, expected, sender.lines)
+}
+
+func TestJournalFieldName(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, "ZARF_IMAGE_REF", journalFieldName("image.ref"))
+	require.Equal(t, "ZARF_PACKAGEVERSION", journalFieldName("packageVersion"))
+	require.Equal(t, "ZARF_SIZE_MB", journalFieldName("size-mb"))
+}
+
+func TestNewSystemOutputWithLegacyFormat(t *testing.T) {
+	t.Parallel()
+
+	_, err := New(Config{Format: FormatLegacy, Output: OutputSyslog})
+	require.EqualError(t, err, "log destination syslog is not supported with the legacy log format")
+}
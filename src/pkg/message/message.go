@@ -13,6 +13,8 @@ import (
 
 	"github.com/defenseunicorns/pkg/helpers/v2"
 	"github.com/pterm/pterm"
+
+	"github.com/zarf-dev/zarf/src/pkg/logger"
 )
 
 // LogLevel is the level of logging to display.
@@ -79,7 +81,7 @@ func InitializePTerm(w io.Writer) {
 
 // UseLogFile wraps a given file in a PausableWriter
 // and sets it as the log file used by the message package.
-func UseLogFile(f *os.File) (*PausableWriter, error) {
+func UseLogFile(f io.Writer) (*PausableWriter, error) {
 	logFile = NewPausableWriter(f)
 
 	return logFile, nil
@@ -290,6 +292,12 @@ func debugPrinter(offset int, a ...any) {
 	now := time.Now().Format(time.RFC3339)
 	// prepend to a
 	a = append([]any{now, " - "}, a...)
+	// redact secrets registered with the logger
+	for i, v := range a {
+		if str, ok := v.(string); ok {
+			a[i] = logger.Redact(str)
+		}
+	}
 
 	printer.Println(a...)
 
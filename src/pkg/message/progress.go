@@ -16,11 +16,19 @@ const padding = "    "
 // ProgressBar is a struct used to drive a pterm ProgressbarPrinter.
 type ProgressBar struct {
 	progress  *pterm.ProgressbarPrinter
+	reporter  *reporterProgress
 	startText string
 }
 
 // NewProgressBar creates a new ProgressBar instance from a total value and a format.
 func NewProgressBar(total int64, text string) *ProgressBar {
+	if progressReporter != nil {
+		return &ProgressBar{
+			reporter:  newReporterProgress(progressReporter, text, total),
+			startText: text,
+		}
+	}
+
 	var progress *pterm.ProgressbarPrinter
 	var err error
 	if NoProgress {
@@ -48,6 +56,10 @@ func NewProgressBar(total int64, text string) *ProgressBar {
 // Updatef updates the ProgressBar with new text.
 func (p *ProgressBar) Updatef(format string, a ...any) {
 	msg := fmt.Sprintf(format, a...)
+	if p.reporter != nil {
+		p.reporter.setTitle(msg)
+		return
+	}
 	if NoProgress {
 		debugPrinter(2, msg)
 		return
@@ -57,6 +69,10 @@ func (p *ProgressBar) Updatef(format string, a ...any) {
 
 // Failf marks the ProgressBar as failed in the CLI.
 func (p *ProgressBar) Failf(format string, a ...any) {
+	if p.reporter != nil {
+		p.reporter.finish(ProgressFailed, fmt.Sprintf(format, a...))
+		return
+	}
 	err := p.Close()
 	if err != nil {
 		Debug("unable to close failed progressbar", "error", err)
@@ -78,6 +94,10 @@ func (p *ProgressBar) Close() error {
 
 // Update updates the ProgressBar with completed progress and new text.
 func (p *ProgressBar) Update(complete int64, text string) {
+	if p.reporter != nil {
+		p.reporter.set(complete, text)
+		return
+	}
 	if NoProgress {
 		debugPrinter(2, text)
 		return
@@ -89,6 +109,10 @@ func (p *ProgressBar) Update(complete int64, text string) {
 
 // Add updates the ProgressBar with completed progress.
 func (p *ProgressBar) Add(n int) {
+	if p.reporter != nil {
+		p.reporter.add(int64(n))
+		return
+	}
 	if p.progress != nil {
 		if p.progress.Current+n >= p.progress.Total {
 			// @RAZZLE TODO: This is a hack to prevent the progress bar from going over 100% and causing TUI ugliness.
@@ -102,7 +126,7 @@ func (p *ProgressBar) Add(n int) {
 // Write updates the ProgressBar with the number of bytes in a buffer as the completed progress.
 func (p *ProgressBar) Write(data []byte) (int, error) {
 	n := len(data)
-	if p.progress != nil {
+	if p.progress != nil || p.reporter != nil {
 		p.Add(n)
 	}
 	return n, nil
@@ -110,6 +134,10 @@ func (p *ProgressBar) Write(data []byte) (int, error) {
 
 // Successf marks the ProgressBar as successful in the CLI.
 func (p *ProgressBar) Successf(format string, a ...any) {
+	if p.reporter != nil {
+		p.reporter.finish(ProgressSucceeded, fmt.Sprintf(format, a...))
+		return
+	}
 	err := p.Close()
 	if err != nil {
 		Debug("unable to close successful progressbar", "error", err)
@@ -119,6 +147,9 @@ func (p *ProgressBar) Successf(format string, a ...any) {
 
 // GetCurrent returns the current total
 func (p *ProgressBar) GetCurrent() int {
+	if p.reporter != nil {
+		return int(p.reporter.current.Load())
+	}
 	if p.progress != nil {
 		return p.progress.Current
 	}
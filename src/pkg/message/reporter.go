@@ -0,0 +1,118 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package message provides a rich set of functions for displaying messages to the user.
+package message
+
+import (
+	"sync/atomic"
+)
+
+// ProgressState is the state of a progress bar or spinner.
+type ProgressState string
+
+// Progress states.
+const (
+	ProgressRunning   ProgressState = "running"
+	ProgressSucceeded ProgressState = "succeeded"
+	ProgressFailed    ProgressState = "failed"
+	// ProgressStopped is reported when a spinner is stopped without a success or failure, usually because the
+	// operation returned early with an error.
+	ProgressStopped ProgressState = "stopped"
+)
+
+// ProgressUpdate is the progress of a progress bar or spinner.
+type ProgressUpdate struct {
+	// ID identifies the progress bar or spinner across its updates.
+	ID int64
+	// Title describes the work in progress.
+	Title string
+	// Current is the completed work of a progress bar.
+	Current int64
+	// Total is the work of a progress bar, it is zero for spinners.
+	Total int64
+	// State is the state of the progress bar or spinner.
+	State ProgressState
+}
+
+// ProgressReporter receives the progress of Zarf operations in place of the progress bars and spinners drawn to the
+// terminal, so that programs embedding Zarf can show progress in their own UI. Report is called from the goroutines
+// doing the work and must be safe for concurrent use.
+type ProgressReporter interface {
+	Report(update ProgressUpdate)
+}
+
+var (
+	progressReporter ProgressReporter
+	progressID       atomic.Int64
+)
+
+// SetProgressReporter sends the progress of progress bars and spinners created after it is called to the reporter
+// instead of drawing them. A nil reporter draws them again.
+func SetProgressReporter(r ProgressReporter) {
+	progressReporter = r
+}
+
+// ActiveProgressReporter returns the reporter set with SetProgressReporter, or nil when progress is drawn.
+func ActiveProgressReporter() ProgressReporter {
+	return progressReporter
+}
+
+// reporterProgress reports the progress of a single progress bar or spinner.
+type reporterProgress struct {
+	reporter ProgressReporter
+	id       int64
+	title    atomic.Value
+	current  atomic.Int64
+	total    int64
+	done     atomic.Bool
+}
+
+func newReporterProgress(r ProgressReporter, title string, total int64) *reporterProgress {
+	p := &reporterProgress{
+		reporter: r,
+		id:       progressID.Add(1),
+		total:    total,
+	}
+	p.title.Store(title)
+	p.report(ProgressRunning)
+	return p
+}
+
+func (p *reporterProgress) setTitle(title string) {
+	p.title.Store(title)
+	p.report(ProgressRunning)
+}
+
+func (p *reporterProgress) add(n int64) {
+	p.current.Add(n)
+	p.report(ProgressRunning)
+}
+
+func (p *reporterProgress) set(current int64, title string) {
+	p.current.Store(current)
+	p.setTitle(title)
+}
+
+// finish reports the final state once, later calls are ignored.
+func (p *reporterProgress) finish(state ProgressState, title string) {
+	if !p.done.CompareAndSwap(false, true) {
+		return
+	}
+	p.title.Store(title)
+	p.report(state)
+}
+
+func (p *reporterProgress) report(state ProgressState) {
+	if state == ProgressRunning && p.done.Load() {
+		return
+	}
+	title, _ := p.title.Load().(string)
+	p.reporter.Report(ProgressUpdate{
+		ID:      p.id,
+		Title:   title,
+		Current: p.current.Load(),
+		Total:   p.total,
+		State:   state,
+	})
+}
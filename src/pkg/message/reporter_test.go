@@ -0,0 +1,69 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package message
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type testReporter struct {
+	mu      sync.Mutex
+	updates []ProgressUpdate
+}
+
+func (r *testReporter) Report(update ProgressUpdate) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.updates = append(r.updates, update)
+}
+
+func TestProgressReporter(t *testing.T) {
+	r := &testReporter{}
+	SetProgressReporter(r)
+	t.Cleanup(func() {
+		SetProgressReporter(nil)
+	})
+
+	bar := NewProgressBar(10, "Pushing image")
+	bar.Add(4)
+	_, err := bar.Write([]byte("ab"))
+	require.NoError(t, err)
+	require.Equal(t, 6, bar.GetCurrent())
+	bar.Update(8, "Pushing layer")
+	bar.Successf("Pushed %d images", 1)
+	bar.Add(1)
+	barID := r.updates[0].ID
+	require.Equal(t, []ProgressUpdate{
+		{ID: barID, Title: "Pushing image", Current: 0, Total: 10, State: ProgressRunning},
+		{ID: barID, Title: "Pushing image", Current: 4, Total: 10, State: ProgressRunning},
+		{ID: barID, Title: "Pushing image", Current: 6, Total: 10, State: ProgressRunning},
+		{ID: barID, Title: "Pushing layer", Current: 8, Total: 10, State: ProgressRunning},
+		{ID: barID, Title: "Pushed 1 images", Current: 8, Total: 10, State: ProgressSucceeded},
+	}, r.updates)
+
+	r.updates = nil
+	spinner := NewProgressSpinner("Loading %s", "package")
+	spinner.Updatef("Validating package")
+	_, err = spinner.Write([]byte("first line\nlast line\n"))
+	require.NoError(t, err)
+	spinner.Errorf(errors.New("bad checksum"), "Unable to validate package")
+	spinner.Stop()
+	spinnerID := r.updates[0].ID
+	require.NotEqual(t, barID, spinnerID)
+	require.Equal(t, []ProgressUpdate{
+		{ID: spinnerID, Title: "Loading package", State: ProgressRunning},
+		{ID: spinnerID, Title: "Validating package", State: ProgressRunning},
+		{ID: spinnerID, Title: "last line", State: ProgressRunning},
+		{ID: spinnerID, Title: "Unable to validate package", State: ProgressFailed},
+	}, r.updates)
+
+	r.updates = nil
+	spinner = NewProgressSpinner("Loading package")
+	spinner.Stop()
+	require.Equal(t, ProgressStopped, r.updates[len(r.updates)-1].State)
+}
@@ -10,17 +10,23 @@ import (
 	"fmt"
 	"log/slog"
 	"strings"
+	"sync"
 
 	"github.com/pterm/pterm"
 )
 
-var activeSpinner *Spinner
+var (
+	// activeSpinnerMu guards activeSpinner, as spinners are started and stopped by work running concurrently.
+	activeSpinnerMu sync.Mutex
+	activeSpinner   *Spinner
+)
 
 var sequence = []string{`  ⠋ `, `  ⠙ `, `  ⠹ `, `  ⠸ `, `  ⠼ `, `  ⠴ `, `  ⠦ `, `  ⠧ `, `  ⠇ `, `  ⠏ `}
 
 // Spinner is a wrapper around pterm.SpinnerPrinter.
 type Spinner struct {
 	spinner        *pterm.SpinnerPrinter
+	reporter       *reporterProgress
 	startText      string
 	termWidth      int
 	preserveWrites bool
@@ -28,6 +34,8 @@ type Spinner struct {
 
 // NewProgressSpinner creates a new progress spinner.
 func NewProgressSpinner(format string, a ...any) *Spinner {
+	activeSpinnerMu.Lock()
+	defer activeSpinnerMu.Unlock()
 	if activeSpinner != nil {
 		activeSpinner.Updatef(format, a...)
 		debugPrinter(2, "Active spinner already exists")
@@ -37,6 +45,13 @@ func NewProgressSpinner(format string, a ...any) *Spinner {
 	var spinner *pterm.SpinnerPrinter
 	var err error
 	text := pterm.Sprintf(format, a...)
+	if progressReporter != nil {
+		activeSpinner = &Spinner{
+			reporter:  newReporterProgress(progressReporter, text, 0),
+			startText: text,
+		}
+		return activeSpinner
+	}
 	if NoProgress {
 		Info(text)
 	} else {
@@ -72,6 +87,17 @@ func (p *Spinner) DisablePreserveWrites() {
 // Write the given text to the spinner.
 func (p *Spinner) Write(raw []byte) (int, error) {
 	size := len(raw)
+	if p.reporter != nil {
+		// Only the last line of the written text is reported, as the spinner text would be.
+		scanner := bufio.NewScanner(bytes.NewReader(raw))
+		for scanner.Scan() {
+			if line := strings.TrimSpace(scanner.Text()); line != "" {
+				p.reporter.title.Store(line)
+			}
+		}
+		p.reporter.report(ProgressRunning)
+		return size, nil
+	}
 	if NoProgress {
 		if p.preserveWrites {
 			pterm.Printfln("     %s", string(raw))
@@ -100,6 +126,10 @@ func (p *Spinner) Write(raw []byte) (int, error) {
 
 // Updatef updates the spinner text.
 func (p *Spinner) Updatef(format string, a ...any) {
+	if p.reporter != nil {
+		p.reporter.setTitle(pterm.Sprintf(format, a...))
+		return
+	}
 	if NoProgress {
 		debugPrinter(2, fmt.Sprintf(format, a...))
 		return
@@ -112,12 +142,18 @@ func (p *Spinner) Updatef(format string, a ...any) {
 
 // Stop the spinner.
 func (p *Spinner) Stop() {
+	if p.reporter != nil {
+		title, _ := p.reporter.title.Load().(string)
+		p.reporter.finish(ProgressStopped, title)
+	}
 	if p.spinner != nil && p.spinner.IsActive {
 		err := p.spinner.Stop()
 		if err != nil {
 			slog.Debug("unable to stop spinner", "error", err)
 		}
 	}
+	activeSpinnerMu.Lock()
+	defer activeSpinnerMu.Unlock()
 	activeSpinner = nil
 }
 
@@ -129,7 +165,9 @@ func (p *Spinner) Success() {
 // Successf prints a success message with the spinner and stops it.
 func (p *Spinner) Successf(format string, a ...any) {
 	text := pterm.Sprintf(format, a...)
-	if p.spinner != nil {
+	if p.reporter != nil {
+		p.reporter.finish(ProgressSucceeded, text)
+	} else if p.spinner != nil {
 		p.spinner.Success(text)
 	} else {
 		Info(text)
@@ -139,6 +177,11 @@ func (p *Spinner) Successf(format string, a ...any) {
 
 // Errorf prints an error message with the spinner.
 func (p *Spinner) Errorf(err error, format string, a ...any) {
+	if p.reporter != nil {
+		p.reporter.finish(ProgressFailed, fmt.Sprintf(format, a...))
+		debugPrinter(2, err)
+		return
+	}
 	Warnf(format, a...)
 	debugPrinter(2, err)
 }
@@ -0,0 +1,255 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package notify sends webhook notifications for Zarf lifecycle events.
+package notify
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/zarf-dev/zarf/src/api/v1alpha1"
+	"github.com/zarf-dev/zarf/src/config"
+	"github.com/zarf-dev/zarf/src/pkg/logger"
+)
+
+// Event is a lifecycle event that webhooks can subscribe to.
+type Event string
+
+const (
+	// EventDeployStarted is sent when a package deploy starts.
+	EventDeployStarted Event = "deploy.started"
+	// EventDeploySucceeded is sent when a package deploy succeeds.
+	EventDeploySucceeded Event = "deploy.succeeded"
+	// EventDeployFailed is sent when a package deploy fails.
+	EventDeployFailed Event = "deploy.failed"
+	// EventPackagePublished is sent when a package is published to a registry.
+	EventPackagePublished Event = "package.published"
+)
+
+// Events are all of the events webhooks can subscribe to.
+var Events = []Event{EventDeployStarted, EventDeploySucceeded, EventDeployFailed, EventPackagePublished}
+
+// WebhookType is the kind of service a webhook sends to.
+type WebhookType string
+
+const (
+	// WebhookGeneric sends the notification as JSON.
+	WebhookGeneric WebhookType = "generic"
+	// WebhookSlack sends the notification as a Slack message.
+	WebhookSlack WebhookType = "slack"
+)
+
+const (
+	// SignatureHeader is the header holding the HMAC-SHA256 signature of the payload.
+	SignatureHeader = "X-Zarf-Signature"
+	// EventHeader is the header holding the event of the notification.
+	EventHeader = "X-Zarf-Event"
+
+	defaultTimeout = 10 * time.Second
+)
+
+const slackTemplate = `{"text": {{ printf "Zarf %s: %s %s %s" .Event .Package .Version .Error | trimSpace | json }}}`
+
+// Webhook configures an endpoint that is sent notifications.
+type Webhook struct {
+	// URL is the endpoint the notification is posted to.
+	URL string `mapstructure:"url"`
+	// Type is the kind of service the endpoint belongs to, defaults to generic.
+	Type WebhookType `mapstructure:"type"`
+	// Secret signs the payload with HMAC-SHA256 when set.
+	Secret string `mapstructure:"secret"`
+	// Events are the events the webhook is sent, defaults to all events.
+	Events []Event `mapstructure:"events"`
+	// Template is a Go template rendered with the notification to build the payload.
+	Template string `mapstructure:"template"`
+}
+
+// Notification describes a lifecycle event.
+type Notification struct {
+	Event        Event     `json:"event"`
+	Time         time.Time `json:"time"`
+	Package      string    `json:"package,omitempty"`
+	Version      string    `json:"version,omitempty"`
+	Architecture string    `json:"architecture,omitempty"`
+	Reference    string    `json:"reference,omitempty"`
+	Error        string    `json:"error,omitempty"`
+	ZarfVersion  string    `json:"zarfVersion"`
+}
+
+// NewNotification returns a notification of the event for the package.
+func NewNotification(event Event, pkg v1alpha1.ZarfPackage, err error) Notification {
+	n := Notification{
+		Event:        event,
+		Time:         time.Now().UTC(),
+		Package:      pkg.Metadata.Name,
+		Version:      pkg.Metadata.Version,
+		Architecture: pkg.Metadata.Architecture,
+		ZarfVersion:  config.CLIVersion,
+	}
+	if err != nil {
+		n.Error = logger.Redact(err.Error())
+	}
+	return n
+}
+
+type webhook struct {
+	Webhook
+	tmpl *template.Template
+}
+
+// Notifier sends notifications to the configured webhooks. A nil Notifier does not send anything.
+type Notifier struct {
+	webhooks []webhook
+	client   *http.Client
+}
+
+// NewNotifier validates the webhooks and returns a notifier that sends to them.
+func NewNotifier(webhooks []Webhook) (*Notifier, error) {
+	if len(webhooks) == 0 {
+		return nil, nil
+	}
+	n := &Notifier{
+		client: &http.Client{Timeout: defaultTimeout},
+	}
+	for i, wh := range webhooks {
+		u, err := url.Parse(wh.URL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return nil, fmt.Errorf("webhook %d has an invalid URL", i)
+		}
+		if wh.Type == "" {
+			wh.Type = WebhookGeneric
+		}
+		if wh.Type != WebhookGeneric && wh.Type != WebhookSlack {
+			return nil, fmt.Errorf("webhook %d has an invalid type %s, must be one of %s or %s", i, wh.Type, WebhookGeneric, WebhookSlack)
+		}
+		for _, event := range wh.Events {
+			if !slices.Contains(Events, event) {
+				return nil, fmt.Errorf("webhook %d subscribes to an unknown event %s", i, event)
+			}
+		}
+		text := wh.Template
+		if text == "" && wh.Type == WebhookSlack {
+			text = slackTemplate
+		}
+		var tmpl *template.Template
+		if text != "" {
+			tmpl, err = template.New("webhook").Funcs(templateFuncs).Option("missingkey=error").Parse(text)
+			if err != nil {
+				return nil, fmt.Errorf("webhook %d has an invalid template: %w", i, err)
+			}
+		}
+		if wh.Secret != "" {
+			logger.AddSecret(wh.Secret)
+		}
+		n.webhooks = append(n.webhooks, webhook{Webhook: wh, tmpl: tmpl})
+	}
+	return n, nil
+}
+
+var templateFuncs = template.FuncMap{
+	"json": func(v any) (string, error) {
+		b, err := json.Marshal(v)
+		return string(b), err
+	},
+	"trimSpace": strings.TrimSpace,
+}
+
+// Notify sends the notification to every webhook subscribed to its event.
+// Failures are logged as warnings so that a notification never fails the operation it reports on.
+func (n *Notifier) Notify(ctx context.Context, notification Notification) {
+	if n == nil {
+		return
+	}
+	l := logger.From(ctx)
+	for _, wh := range n.webhooks {
+		if len(wh.Events) > 0 && !slices.Contains(wh.Events, notification.Event) {
+			continue
+		}
+		err := n.send(ctx, wh, notification)
+		if err != nil {
+			l.Warn("unable to send webhook notification", "event", notification.Event, "host", hostOf(wh.URL), "error", err)
+		}
+	}
+}
+
+func (n *Notifier) send(ctx context.Context, wh webhook, notification Notification) error {
+	body, err := payload(wh, notification)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, wh.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(EventHeader, string(notification.Event))
+	if wh.Secret != "" {
+		req.Header.Set(SignatureHeader, Sign(wh.Secret, body))
+	}
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	return nil
+}
+
+// payload renders the webhook template, or marshals the notification to JSON when there is no template.
+func payload(wh webhook, notification Notification) ([]byte, error) {
+	if wh.tmpl == nil {
+		return json.Marshal(notification)
+	}
+	var buf bytes.Buffer
+	err := wh.tmpl.Execute(&buf, notification)
+	if err != nil {
+		return nil, fmt.Errorf("unable to render webhook template: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// Sign returns the signature of the payload in the format sent in the signature header.
+func Sign(secret string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// hostOf returns the host of the URL so that tokens in webhook paths are not logged.
+func hostOf(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+	return u.Host
+}
+
+type notifierKey struct{}
+
+// WithContext takes a context and notifier and returns a context including the notifier.
+func WithContext(ctx context.Context, n *Notifier) context.Context {
+	return context.WithValue(ctx, notifierKey{}, n)
+}
+
+// From returns the notifier in the context, or nil if notifications are not configured.
+func From(ctx context.Context) *Notifier {
+	n, ok := ctx.Value(notifierKey{}).(*Notifier)
+	if !ok {
+		return nil
+	}
+	return n
+}
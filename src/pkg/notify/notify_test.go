@@ -0,0 +1,150 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/zarf-dev/zarf/src/api/v1alpha1"
+)
+
+type request struct {
+	event     string
+	signature string
+	body      string
+}
+
+func newTestServer(t *testing.T) (*httptest.Server, func() []request) {
+	t.Helper()
+
+	var mu sync.Mutex
+	requests := []request{}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		mu.Lock()
+		defer mu.Unlock()
+		requests = append(requests, request{
+			event:     r.Header.Get(EventHeader),
+			signature: r.Header.Get(SignatureHeader),
+			body:      string(b),
+		})
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	t.Cleanup(ts.Close)
+	return ts, func() []request {
+		mu.Lock()
+		defer mu.Unlock()
+		return requests
+	}
+}
+
+func TestNewNotifier(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		webhooks    []Webhook
+		expectedErr string
+	}{
+		{
+			name:     "valid",
+			webhooks: []Webhook{{URL: "https://example.com/hook", Type: WebhookSlack, Events: []Event{EventDeployFailed}}},
+		},
+		{
+			name:        "invalid URL",
+			webhooks:    []Webhook{{URL: "example.com/hook"}},
+			expectedErr: "webhook 0 has an invalid URL",
+		},
+		{
+			name:        "invalid type",
+			webhooks:    []Webhook{{URL: "https://example.com/hook", Type: "teams"}},
+			expectedErr: "webhook 0 has an invalid type teams, must be one of generic or slack",
+		},
+		{
+			name:        "unknown event",
+			webhooks:    []Webhook{{URL: "https://example.com/hook", Events: []Event{"deploy.finished"}}},
+			expectedErr: "webhook 0 subscribes to an unknown event deploy.finished",
+		},
+		{
+			name:        "invalid template",
+			webhooks:    []Webhook{{URL: "https://example.com/hook", Template: "{{ .Package "}},
+			expectedErr: "webhook 0 has an invalid template: template: webhook:1: unclosed action",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			_, err := NewNotifier(tt.webhooks)
+			if tt.expectedErr != "" {
+				require.EqualError(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestNotify(t *testing.T) {
+	t.Parallel()
+
+	ts, requests := newTestServer(t)
+	n, err := NewNotifier([]Webhook{
+		{URL: ts.URL + "/generic", Secret: "hmac-secret"},
+		{URL: ts.URL + "/slack", Type: WebhookSlack, Events: []Event{EventDeployFailed}},
+		{URL: ts.URL + "/custom", Template: `{"pkg": {{ json .Package }}, "event": "{{ .Event }}"}`, Events: []Event{EventPackagePublished}},
+	})
+	require.NoError(t, err)
+
+	pkg := v1alpha1.ZarfPackage{Metadata: v1alpha1.ZarfMetadata{Name: "dos-games", Version: "1.1.0"}}
+	ctx := WithContext(context.Background(), n)
+	From(ctx).Notify(ctx, NewNotification(EventDeployStarted, pkg, nil))
+	From(ctx).Notify(ctx, NewNotification(EventDeployFailed, pkg, errors.New("timed out")))
+	published := NewNotification(EventPackagePublished, pkg, nil)
+	published.Reference = "ghcr.io/zarf-dev/packages/dos-games:1.1.0"
+	From(ctx).Notify(ctx, published)
+
+	reqs := requests()
+	require.Len(t, reqs, 5)
+
+	require.Equal(t, "deploy.started", reqs[0].event)
+	require.Equal(t, Sign("hmac-secret", []byte(reqs[0].body)), reqs[0].signature)
+	var started Notification
+	require.NoError(t, json.Unmarshal([]byte(reqs[0].body), &started))
+	require.Equal(t, EventDeployStarted, started.Event)
+	require.Equal(t, "dos-games", started.Package)
+
+	require.Equal(t, "deploy.failed", reqs[2].event)
+	require.Empty(t, reqs[2].signature)
+	require.JSONEq(t, `{"text": "Zarf deploy.failed: dos-games 1.1.0 timed out"}`, reqs[2].body)
+
+	require.Equal(t, "package.published", reqs[4].event)
+	require.JSONEq(t, `{"pkg": "dos-games", "event": "package.published"}`, reqs[4].body)
+}
+
+func TestNilNotifier(t *testing.T) {
+	t.Parallel()
+
+	n, err := NewNotifier(nil)
+	require.NoError(t, err)
+	require.Nil(t, n)
+	require.Nil(t, From(context.Background()))
+	// A nil notifier must be safe to use when no webhooks are configured.
+	From(context.Background()).Notify(context.Background(), NewNotification(EventDeployStarted, v1alpha1.ZarfPackage{}, nil))
+}
+
+func TestSign(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, "sha256=260d3a3ed3918bcd74a4e2c80657e2a5ad6f8a96ff8e86bbbabd276bbb9b39be", Sign("secret", []byte(`{"event":"deploy.started"}`)))
+}
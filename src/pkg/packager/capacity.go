@@ -0,0 +1,121 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package packager contains functions for interacting with, managing and deploying Zarf packages.
+package packager
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"github.com/zarf-dev/zarf/src/api/v1alpha1"
+	"github.com/zarf-dev/zarf/src/pkg/cluster"
+	"github.com/zarf-dev/zarf/src/pkg/logger"
+	"github.com/zarf-dev/zarf/src/pkg/message"
+	"github.com/zarf-dev/zarf/src/types"
+)
+
+// checkCapacity compares the requirements of the components to deploy with the schedulable capacity of the cluster
+// before anything is pushed to it. Components deployed by an earlier deploy of the package are left out, as their
+// workloads already take up capacity.
+func (p *Packager) checkCapacity(ctx context.Context) error {
+	l := logger.From(ctx)
+	mode := cmp.Or(p.cfg.DeployOpts.CapacityCheck, types.CapacityCheckWarn)
+	if mode == types.CapacityCheckSkip {
+		return nil
+	}
+	components := slices.DeleteFunc(slices.Clone(p.cfg.Pkg.Components), func(component v1alpha1.ZarfComponent) bool {
+		return component.Requirements == v1alpha1.ZarfComponentRequirements{}
+	})
+	if len(components) == 0 {
+		return nil
+	}
+	// The cluster does not exist yet when the init package deploys it
+	if p.cfg.Pkg.IsInitConfig() && slices.ContainsFunc(p.cfg.Pkg.Components, func(component v1alpha1.ZarfComponent) bool {
+		return component.Name == "k3s"
+	}) {
+		return nil
+	}
+
+	connectCtx, cancel := context.WithTimeout(ctx, cluster.DefaultTimeout)
+	defer cancel()
+	if err := p.connectToCluster(connectCtx); err != nil {
+		return fmt.Errorf("unable to connect to the Kubernetes cluster: %w", err)
+	}
+	if deployedPackage, _ := p.cluster.GetDeployedPackage(ctx, p.cfg.Pkg.Metadata.Name); deployedPackage != nil {
+		components = slices.DeleteFunc(components, func(component v1alpha1.ZarfComponent) bool {
+			return slices.ContainsFunc(deployedPackage.DeployedComponents, func(deployed types.DeployedComponent) bool {
+				return deployed.Name == component.Name
+			})
+		})
+	}
+	required, err := componentRequirements(components)
+	if err != nil {
+		return err
+	}
+	available, err := p.cluster.GetSchedulableCapacity(ctx)
+	if err != nil {
+		return fmt.Errorf("unable to get the schedulable capacity of the cluster: %w", err)
+	}
+
+	shortages := capacityShortages(required, available)
+	if len(shortages) == 0 {
+		l.Debug("the cluster has the capacity for the requirements of the components", "required", required, "available", available)
+		return nil
+	}
+	msg := fmt.Sprintf("the cluster does not have the schedulable capacity for the requirements of the components: %s", strings.Join(shortages, ", "))
+	if mode == types.CapacityCheckFail {
+		return errors.New(msg)
+	}
+	message.Warn(msg)
+	l.Warn("the cluster does not have the schedulable capacity for the requirements of the components", "shortages", shortages)
+	return nil
+}
+
+// componentRequirements returns the sum of the requirements of the components.
+func componentRequirements(components []v1alpha1.ZarfComponent) (corev1.ResourceList, error) {
+	required := corev1.ResourceList{}
+	for _, component := range components {
+		requirements := map[corev1.ResourceName]string{
+			corev1.ResourceCPU:              component.Requirements.CPU,
+			corev1.ResourceMemory:           component.Requirements.Memory,
+			corev1.ResourceEphemeralStorage: component.Requirements.Storage,
+		}
+		for name, value := range requirements {
+			if value == "" {
+				continue
+			}
+			quantity, err := resource.ParseQuantity(value)
+			if err != nil {
+				return nil, fmt.Errorf("component %s has an invalid %s requirement %q: %w", component.Name, name, value, err)
+			}
+			total := required[name]
+			total.Add(quantity)
+			required[name] = total
+		}
+	}
+	return required, nil
+}
+
+// capacityShortages describes every resource that is required beyond the available capacity.
+func capacityShortages(required, available corev1.ResourceList) []string {
+	shortages := []string{}
+	for _, name := range cluster.CapacityResources {
+		quantity, ok := required[name]
+		if !ok {
+			continue
+		}
+		capacity := available[name]
+		if quantity.Cmp(capacity) > 0 {
+			shortages = append(shortages, fmt.Sprintf("%s requires %s but %s is schedulable", name, quantity.String(), capacity.String()))
+		}
+	}
+	return shortages
+}
@@ -0,0 +1,87 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package packager
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/zarf-dev/zarf/src/api/v1alpha1"
+	"github.com/zarf-dev/zarf/src/pkg/cluster"
+	"github.com/zarf-dev/zarf/src/test/testutil"
+	"github.com/zarf-dev/zarf/src/types"
+)
+
+func TestComponentRequirements(t *testing.T) {
+	t.Parallel()
+
+	required, err := componentRequirements([]v1alpha1.ZarfComponent{
+		{Name: "api", Requirements: v1alpha1.ZarfComponentRequirements{CPU: "500m", Memory: "1Gi"}},
+		{Name: "db", Requirements: v1alpha1.ZarfComponentRequirements{CPU: "2", Storage: "20Gi"}},
+		{Name: "docs"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "2500m", required.Cpu().String())
+	require.Equal(t, "1Gi", required.Memory().String())
+	require.Equal(t, "20Gi", required.StorageEphemeral().String())
+
+	_, err = componentRequirements([]v1alpha1.ZarfComponent{
+		{Name: "api", Requirements: v1alpha1.ZarfComponentRequirements{Memory: "lots"}},
+	})
+	require.ErrorContains(t, err, `component api has an invalid memory requirement "lots"`)
+
+	shortages := capacityShortages(required, corev1.ResourceList{
+		corev1.ResourceCPU:              resource.MustParse("4"),
+		corev1.ResourceMemory:           resource.MustParse("512Mi"),
+		corev1.ResourceEphemeralStorage: resource.MustParse("20Gi"),
+	})
+	require.Equal(t, []string{"memory requires 1Gi but 512Mi is schedulable"}, shortages)
+}
+
+func TestCheckCapacity(t *testing.T) {
+	t.Parallel()
+	ctx := testutil.TestContext(t)
+
+	c := &cluster.Cluster{
+		Clientset: fake.NewClientset(&corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: "node"},
+			Status: corev1.NodeStatus{
+				Allocatable: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("2"),
+					corev1.ResourceMemory: resource.MustParse("4Gi"),
+				},
+				Conditions: []corev1.NodeCondition{{Type: corev1.NodeReady, Status: corev1.ConditionTrue}},
+			},
+		}),
+	}
+	pkg := v1alpha1.ZarfPackage{
+		Metadata: v1alpha1.ZarfMetadata{Name: "test"},
+		Components: []v1alpha1.ZarfComponent{
+			{Name: "api", Requirements: v1alpha1.ZarfComponentRequirements{CPU: "1", Memory: "2Gi"}},
+			{Name: "db", Requirements: v1alpha1.ZarfComponentRequirements{CPU: "2"}},
+		},
+	}
+	newPackager := func(capacityCheck string) *Packager {
+		return &Packager{
+			cluster: c,
+			cfg:     &types.PackagerConfig{Pkg: pkg, DeployOpts: types.ZarfDeployOptions{CapacityCheck: capacityCheck}},
+		}
+	}
+
+	err := newPackager(types.CapacityCheckFail).checkCapacity(ctx)
+	require.EqualError(t, err, "the cluster does not have the schedulable capacity for the requirements of the components: cpu requires 3 but 2 is schedulable")
+	require.NoError(t, newPackager(types.CapacityCheckWarn).checkCapacity(ctx))
+	require.NoError(t, newPackager("").checkCapacity(ctx))
+	require.NoError(t, newPackager(types.CapacityCheckSkip).checkCapacity(ctx))
+
+	// The workloads of components that are already deployed take up capacity already
+	_, err = c.RecordPackageDeployment(ctx, pkg, []types.DeployedComponent{{Name: "db"}}, 1, "")
+	require.NoError(t, err)
+	require.NoError(t, newPackager(types.CapacityCheckFail).checkCapacity(ctx))
+}
@@ -158,11 +158,23 @@ func (p *Packager) connectToCluster(ctx context.Context) error {
 		return nil
 	}
 
-	cluster, err := cluster.NewClusterWithWait(ctx)
+	c, err := cluster.NewClusterWithWait(ctx)
 	if err != nil {
 		return err
 	}
-	p.cluster = cluster
+	if p.cfg.DeployOpts.ServiceAccount != "" {
+		namespace, name, err := cluster.ParseServiceAccount(p.cfg.DeployOpts.ServiceAccount)
+		if err != nil {
+			return err
+		}
+		c, err = c.ImpersonateServiceAccount(namespace, name)
+		if err != nil {
+			return fmt.Errorf("unable to impersonate the service account %s: %w", p.cfg.DeployOpts.ServiceAccount, err)
+		}
+		message.Infof("Deploying as the service account %s", p.cfg.DeployOpts.ServiceAccount)
+		logger.From(ctx).Info("deploying as service account", "user", c.ImpersonatedUser())
+	}
+	p.cluster = c
 
 	return p.attemptClusterChecks(ctx)
 }
@@ -28,9 +28,24 @@ func overrideMetadata(c *v1alpha1.ZarfComponent, override v1alpha1.ZarfComponent
 		c.Only.LocalOS = override.Only.LocalOS
 	}
 
+	overrideRequirements(&c.Requirements, override.Requirements)
+
 	return nil
 }
 
+// overrideRequirements replaces the requirements of the imported component with the ones the importing component sets.
+func overrideRequirements(r *v1alpha1.ZarfComponentRequirements, override v1alpha1.ZarfComponentRequirements) {
+	if override.CPU != "" {
+		r.CPU = override.CPU
+	}
+	if override.Memory != "" {
+		r.Memory = override.Memory
+	}
+	if override.Storage != "" {
+		r.Storage = override.Storage
+	}
+}
+
 func overrideDeprecated(c *v1alpha1.ZarfComponent, override v1alpha1.ZarfComponent) {
 	// Override cosign key path if it was provided.
 	if override.DeprecatedCosignKeyPath != "" {
@@ -81,6 +96,12 @@ func overrideResources(c *v1alpha1.ZarfComponent, override v1alpha1.ZarfComponen
 	c.DataInjections = append(c.DataInjections, override.DataInjections...)
 	c.Files = append(c.Files, override.Files...)
 	c.Images = append(c.Images, override.Images...)
+	c.Artifacts = append(c.Artifacts, override.Artifacts...)
+	c.HostArtifacts = append(c.HostArtifacts, override.HostArtifacts...)
+	c.HostServices = append(c.HostServices, override.HostServices...)
+	c.Tofu = append(c.Tofu, override.Tofu...)
+	c.Builds = append(c.Builds, override.Builds...)
+	c.Namespaces = append(c.Namespaces, override.Namespaces...)
 	c.Repos = append(c.Repos, override.Repos...)
 
 	// Merge charts with the same name to keep them unique
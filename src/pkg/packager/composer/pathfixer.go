@@ -25,6 +25,28 @@ func fixPaths(child *v1alpha1.ZarfComponent, relativeToHead string) {
 		child.Files[fileIdx].Source = composed
 	}
 
+	for hostArtifactIdx, hostArtifact := range child.HostArtifacts {
+		for sourceIdx, src := range hostArtifact.Sources {
+			composed := makePathRelativeTo(src.Source, relativeToHead)
+			child.HostArtifacts[hostArtifactIdx].Sources[sourceIdx].Source = composed
+		}
+	}
+
+	for hostServiceIdx, hostService := range child.HostServices {
+		composed := makePathRelativeTo(hostService.Source, relativeToHead)
+		child.HostServices[hostServiceIdx].Source = composed
+	}
+
+	for moduleIdx, module := range child.Tofu {
+		composed := makePathRelativeTo(module.Source, relativeToHead)
+		child.Tofu[moduleIdx].Source = composed
+	}
+
+	for buildIdx, build := range child.Builds {
+		composed := makePathRelativeTo(build.Context, relativeToHead)
+		child.Builds[buildIdx].Context = composed
+	}
+
 	for chartIdx, chart := range child.Charts {
 		for valuesIdx, valuesFile := range chart.ValuesFiles {
 			composed := makePathRelativeTo(valuesFile, relativeToHead)
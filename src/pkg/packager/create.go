@@ -63,7 +63,11 @@ func (p *Packager) Create(ctx context.Context) error {
 	)
 
 	// TODO(mkcp): Remove interactive on logger release
-	if !p.confirmAction(ctx, config.ZarfCreateStage, warnings, nil) {
+	confirmed, err := p.confirmAction(ctx, config.ZarfCreateStage, warnings, nil, nil)
+	if err != nil {
+		return err
+	}
+	if !confirmed {
 		return fmt.Errorf("package creation canceled")
 	}
 
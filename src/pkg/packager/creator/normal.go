@@ -11,6 +11,7 @@ import (
 	"math/rand"
 	"os"
 	"path/filepath"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -21,11 +22,16 @@ import (
 	"github.com/zarf-dev/zarf/src/api/v1alpha1"
 	"github.com/zarf-dev/zarf/src/config"
 	"github.com/zarf-dev/zarf/src/config/lang"
+	"github.com/zarf-dev/zarf/src/internal/artifact"
 	"github.com/zarf-dev/zarf/src/internal/git"
 	"github.com/zarf-dev/zarf/src/internal/packager/helm"
+	"github.com/zarf-dev/zarf/src/internal/packager/hostartifacts"
+	"github.com/zarf-dev/zarf/src/internal/packager/hostservices"
+	"github.com/zarf-dev/zarf/src/internal/packager/imagebuild"
 	"github.com/zarf-dev/zarf/src/internal/packager/images"
 	"github.com/zarf-dev/zarf/src/internal/packager/kustomize"
 	"github.com/zarf-dev/zarf/src/internal/packager/sbom"
+	"github.com/zarf-dev/zarf/src/internal/packager/tofu"
 	"github.com/zarf-dev/zarf/src/pkg/layout"
 	"github.com/zarf-dev/zarf/src/pkg/logger"
 	"github.com/zarf-dev/zarf/src/pkg/message"
@@ -117,6 +123,10 @@ func (pc *PackageCreator) LoadPackageDefinition(ctx context.Context, src *layout
 		}
 	}
 
+	for i, component := range pkg.Components {
+		pkg.Components[i] = imagebuild.WithImages(component)
+	}
+
 	if err := Validate(pkg, pc.createOpts.BaseDir, pc.createOpts.SetVariables); err != nil {
 		return v1alpha1.ZarfPackage{}, nil, err
 	}
@@ -144,7 +154,7 @@ func (pc *PackageCreator) Assemble(ctx context.Context, dst *layout.PackagePaths
 			}
 		}
 
-		if err := pc.addComponent(ctx, component, dst); err != nil {
+		if err := pc.addComponent(ctx, component, dst, arch); err != nil {
 			onFailure()
 			return fmt.Errorf("unable to add component %q: %w", component.Name, err)
 		}
@@ -191,15 +201,48 @@ func (pc *PackageCreator) Assemble(ctx context.Context, dst *layout.PackagePaths
 		if err != nil {
 			return err
 		}
-		pullCfg := images.PullConfig{
-			DestinationDirectory: dst.Images.Base,
-			ImageList:            imageList,
-			Arch:                 arch,
-			RegistryOverrides:    pc.createOpts.RegistryOverrides,
-			CacheDirectory:       filepath.Join(cachePath, layout.ImagesDir),
+		imageRegistryOverrides, err := images.ComponentRegistryOverrides(components, pc.createOpts.RegistryOverrides)
+		if err != nil {
+			return err
 		}
-
-		pulled, err := images.Pull(ctx, pullCfg)
+		imagePlatforms, err := images.ComponentImagePlatforms(components)
+		if err != nil {
+			return err
+		}
+		var imageTarballs map[string]string
+		var imageIndexTarballs map[string]map[string]string
+		if slices.ContainsFunc(components, func(component v1alpha1.ZarfComponent) bool { return len(component.Builds) > 0 }) {
+			buildDir, err := utils.MakeTempDir(config.CommonOptions.TempDirectory)
+			if err != nil {
+				return err
+			}
+			defer os.RemoveAll(buildDir)
+			imageTarballs, imageIndexTarballs, err = imagebuild.BuildComponents(ctx, components, "", arch, buildDir, imagebuild.Remote{
+				Addr:          pc.createOpts.BuildkitAddr,
+				Pool:          pc.createOpts.BuildkitPool,
+				PoolNamespace: pc.createOpts.BuildkitPoolNamespace,
+				TLSCACert:     pc.createOpts.BuildkitTLSCACert,
+				TLSCert:       pc.createOpts.BuildkitTLSCert,
+				TLSKey:        pc.createOpts.BuildkitTLSKey,
+				TLSServerName: pc.createOpts.BuildkitTLSServerName,
+			})
+			if err != nil {
+				return err
+			}
+		}
+		pullCfg := images.PullConfig{
+			DestinationDirectory:   dst.Images.Base,
+			ImageList:              imageList,
+			Arch:                   arch,
+			RegistryOverrides:      pc.createOpts.RegistryOverrides,
+			ImageRegistryOverrides: imageRegistryOverrides,
+			ImagePlatforms:         imagePlatforms,
+			ImageTarballs:          imageTarballs,
+			ImageIndexTarballs:     imageIndexTarballs,
+			CacheDirectory:         filepath.Join(cachePath, layout.ImagesDir),
+		}
+
+		pulled, _, err := images.Pull(ctx, pullCfg)
 		if err != nil {
 			return err
 		}
@@ -361,7 +404,7 @@ func (pc *PackageCreator) Output(ctx context.Context, dst *layout.PackagePaths,
 
 // TODO(mkcp): Refactor addComponent to better segment component handling logic by its type. There's also elaborate
 // if/elses that can be de-nested.
-func (pc *PackageCreator) addComponent(ctx context.Context, component v1alpha1.ZarfComponent, dst *layout.PackagePaths) error {
+func (pc *PackageCreator) addComponent(ctx context.Context, component v1alpha1.ZarfComponent, dst *layout.PackagePaths, arch string) error {
 	l := logger.From(ctx)
 	// TODO(mkcp): Remove message on logger release
 	message.HeaderInfof("📦 %s COMPONENT", strings.ToUpper(component.Name))
@@ -451,6 +494,29 @@ func (pc *PackageCreator) addComponent(ctx context.Context, component v1alpha1.Z
 		}
 	}
 
+	for hostArtifactIdx, hostArtifact := range component.HostArtifacts {
+		for sourceIdx, src := range hostArtifact.Sources {
+			dst := filepath.Join(componentPaths.HostArtifacts, hostartifacts.SourcePath(hostArtifactIdx, sourceIdx, hostArtifact.Name))
+			if err := hostartifacts.Pull(ctx, "", src, dst, component.DeprecatedCosignKeyPath); err != nil {
+				return err
+			}
+		}
+	}
+
+	for hostServiceIdx, hostService := range component.HostServices {
+		dst := filepath.Join(componentPaths.HostServices, hostservices.SourcePath(hostServiceIdx, hostService.Name))
+		if err := hostservices.Pull(ctx, "", hostService, dst, component.DeprecatedCosignKeyPath); err != nil {
+			return err
+		}
+	}
+
+	for moduleIdx, module := range component.Tofu {
+		dst := filepath.Join(componentPaths.Tofu, strconv.Itoa(moduleIdx))
+		if err := tofu.Vendor(ctx, module.Source, dst, tofu.Platforms(module, arch)); err != nil {
+			return fmt.Errorf("unable to vendor tofu module %s: %w", module.Name, err)
+		}
+	}
+
 	// Run data injections
 	injectionsCount := len(component.DataInjections)
 	if injectionsCount > 0 {
@@ -561,6 +627,16 @@ func (pc *PackageCreator) addComponent(ctx context.Context, component v1alpha1.Z
 		l.Debug("done loading git repos", "component", component.Name, "duration", time.Since(reposStart))
 	}
 
+	for _, a := range component.Artifacts {
+		opts := artifact.RegistryOptions{
+			PlainHTTP:             config.CommonOptions.PlainHTTP,
+			InsecureSkipTLSVerify: config.CommonOptions.InsecureSkipTLSVerify,
+		}
+		if _, err := artifact.Save(ctx, a.Reference, a.ArtifactType, componentPaths.Artifacts, opts); err != nil {
+			return err
+		}
+	}
+
 	if err := actions.Run(ctx, onCreate.Defaults, onCreate.After, nil); err != nil {
 		return fmt.Errorf("unable to run component after action: %w", err)
 	}
@@ -600,5 +676,9 @@ func (pc *PackageCreator) getFilesToSBOM(component v1alpha1.ZarfComponent, dst *
 		appendSBOMFiles(path)
 	}
 
+	if len(component.HostArtifacts) > 0 {
+		appendSBOMFiles(componentPaths.HostArtifacts)
+	}
+
 	return componentSBOM, nil
 }
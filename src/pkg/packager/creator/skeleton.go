@@ -18,6 +18,8 @@ import (
 	"github.com/zarf-dev/zarf/src/config"
 	"github.com/zarf-dev/zarf/src/config/lang"
 	"github.com/zarf-dev/zarf/src/internal/packager/helm"
+	"github.com/zarf-dev/zarf/src/internal/packager/hostartifacts"
+	"github.com/zarf-dev/zarf/src/internal/packager/hostservices"
 	"github.com/zarf-dev/zarf/src/internal/packager/kustomize"
 	"github.com/zarf-dev/zarf/src/pkg/layout"
 	"github.com/zarf-dev/zarf/src/pkg/logger"
@@ -232,6 +234,51 @@ func (sc *SkeletonCreator) addComponent(ctx context.Context, component v1alpha1.
 		}
 	}
 
+	for hostArtifactIdx, hostArtifact := range component.HostArtifacts {
+		for sourceIdx, src := range hostArtifact.Sources {
+			if helpers.IsURL(src.Source) {
+				continue
+			}
+
+			rel := filepath.Join(layout.HostArtifactsDir, hostartifacts.SourcePath(hostArtifactIdx, sourceIdx, hostArtifact.Name))
+			if err := hostartifacts.Pull(ctx, "", src, filepath.Join(componentPaths.Base, rel), ""); err != nil {
+				return nil, err
+			}
+
+			// Local sources are already extracted into the skeleton.
+			updatedComponent.HostArtifacts[hostArtifactIdx].Sources[sourceIdx].Source = rel
+			updatedComponent.HostArtifacts[hostArtifactIdx].Sources[sourceIdx].ExtractPath = ""
+		}
+	}
+
+	for hostServiceIdx, hostService := range component.HostServices {
+		if helpers.IsURL(hostService.Source) {
+			continue
+		}
+
+		rel := filepath.Join(layout.HostServicesDir, hostservices.SourcePath(hostServiceIdx, hostService.Name))
+		if err := hostservices.Pull(ctx, "", hostService, filepath.Join(componentPaths.Base, rel), ""); err != nil {
+			return nil, err
+		}
+		updatedComponent.HostServices[hostServiceIdx].Source = rel
+	}
+
+	for moduleIdx, module := range component.Tofu {
+		rel := filepath.Join(layout.TofuDir, strconv.Itoa(moduleIdx))
+		if err := helpers.CreatePathAndCopy(module.Source, filepath.Join(componentPaths.Base, rel)); err != nil {
+			return nil, fmt.Errorf("unable to copy tofu module %s: %w", module.Name, err)
+		}
+		updatedComponent.Tofu[moduleIdx].Source = rel
+	}
+
+	for buildIdx, build := range component.Builds {
+		rel := filepath.Join(layout.BuildsDir, strconv.Itoa(buildIdx))
+		if err := helpers.CreatePathAndCopy(build.Context, filepath.Join(componentPaths.Base, rel)); err != nil {
+			return nil, fmt.Errorf("unable to copy the build context of image %s: %w", build.Image, err)
+		}
+		updatedComponent.Builds[buildIdx].Context = rel
+	}
+
 	if len(component.DataInjections) > 0 {
 		spinner := message.NewProgressSpinner("Loading data injections")
 		defer spinner.Stop()
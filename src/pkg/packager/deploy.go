@@ -5,13 +5,16 @@
 package packager
 
 import (
+	"cmp"
 	"context"
 	"fmt"
+	"maps"
 	"net/url"
 	"os"
 	"path/filepath"
 	"regexp"
 	"runtime"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -19,25 +22,38 @@ import (
 	"golang.org/x/sync/errgroup"
 
 	"github.com/avast/retry-go/v4"
+	"go.opentelemetry.io/otel/attribute"
 	corev1 "k8s.io/api/core/v1"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/clientcmd"
 
 	"github.com/defenseunicorns/pkg/helpers/v2"
 	"github.com/zarf-dev/zarf/src/api/v1alpha1"
 	"github.com/zarf-dev/zarf/src/config"
+	"github.com/zarf-dev/zarf/src/config/lang"
+	"github.com/zarf-dev/zarf/src/internal/artifact"
+	"github.com/zarf-dev/zarf/src/internal/fips"
 	"github.com/zarf-dev/zarf/src/internal/git"
 	"github.com/zarf-dev/zarf/src/internal/gitea"
 	"github.com/zarf-dev/zarf/src/internal/healthchecks"
+	"github.com/zarf-dev/zarf/src/internal/metrics"
 	"github.com/zarf-dev/zarf/src/internal/packager/helm"
+	"github.com/zarf-dev/zarf/src/internal/packager/hostartifacts"
+	"github.com/zarf-dev/zarf/src/internal/packager/hostservices"
 	"github.com/zarf-dev/zarf/src/internal/packager/images"
 	"github.com/zarf-dev/zarf/src/internal/packager/template"
+	"github.com/zarf-dev/zarf/src/internal/packager/tofu"
+	"github.com/zarf-dev/zarf/src/internal/tracing"
 	"github.com/zarf-dev/zarf/src/pkg/cluster"
+	"github.com/zarf-dev/zarf/src/pkg/events"
 	"github.com/zarf-dev/zarf/src/pkg/layout"
 	"github.com/zarf-dev/zarf/src/pkg/logger"
 	"github.com/zarf-dev/zarf/src/pkg/message"
+	"github.com/zarf-dev/zarf/src/pkg/notify"
 	"github.com/zarf-dev/zarf/src/pkg/packager/actions"
 	"github.com/zarf-dev/zarf/src/pkg/packager/filters"
+	"github.com/zarf-dev/zarf/src/pkg/packager/sources"
 	"github.com/zarf-dev/zarf/src/pkg/transform"
 	"github.com/zarf-dev/zarf/src/types"
 )
@@ -47,9 +63,20 @@ var (
 	localClusterServiceRegex = regexp.MustCompile(`^(?P<name>[^\.]+)\.(?P<namespace>[^\.]+)\.svc\.cluster\.local$`)
 )
 
+// interruptCleanupTimeout bounds the cleanup done once a deployment fails or is interrupted.
+const interruptCleanupTimeout = time.Minute
+
+// cleanupContext returns a context for cleaning up after a deployment that is not cancelled along with ctx, so that
+// the cluster state is still updated when the deployment is interrupted.
+func cleanupContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(context.WithoutCancel(ctx), interruptCleanupTimeout)
+}
+
 func (p *Packager) resetRegistryHPA(ctx context.Context) {
 	l := logger.From(ctx)
 	if p.isConnectedToCluster() && p.hpaModified {
+		ctx, cancel := cleanupContext(ctx)
+		defer cancel()
 		if err := p.cluster.EnableRegHPAScaleDown(ctx); err != nil {
 			message.Debugf("unable to reenable the registry HPA scale down: %s", err.Error())
 			l.Debug("unable to reenable the registry HPA scale down", "error", err.Error())
@@ -58,27 +85,50 @@ func (p *Packager) resetRegistryHPA(ctx context.Context) {
 }
 
 // Deploy attempts to deploy the given PackageConfig.
-func (p *Packager) Deploy(ctx context.Context) error {
+func (p *Packager) Deploy(ctx context.Context) (err error) {
+	ctx, span := tracing.Start(ctx, "deploy")
+	events.From(ctx).Phase("deploy", events.StatusStarted, nil)
 	l := logger.From(ctx)
 	start := time.Now()
+	started := false
+	defer func() {
+		p.recordDeployMetric(ctx, start, err)
+		events.From(ctx).Phase("deploy", events.Done(err), err)
+		// Only deployments that were announced as started are reported as finished. The notification is sent with a
+		// cleanup context so that a failure is still delivered when the deployment was interrupted.
+		if started {
+			deployEvent := notify.EventDeploySucceeded
+			if err != nil {
+				deployEvent = notify.EventDeployFailed
+			}
+			notifyCtx, cancel := cleanupContext(ctx)
+			notify.From(notifyCtx).Notify(notifyCtx, notify.NewNotification(deployEvent, p.cfg.Pkg, err))
+			cancel()
+		}
+		tracing.End(span, err)
+	}()
 	isInteractive := !config.CommonOptions.Confirm
 
 	deployFilter := filters.Combine(
 		filters.ByLocalOS(runtime.GOOS),
+		filters.ByFeatures(p.cfg.DeployOpts.Features),
+		filters.ByCluster(func() (types.ClusterFacts, error) {
+			return p.getClusterFacts(ctx)
+		}),
 		filters.ForDeploy(p.cfg.PkgOpts.OptionalComponents, isInteractive),
 	)
 
 	warnings := []string{}
 	if isInteractive {
 		filter := filters.Empty()
-		pkg, loadWarnings, err := p.source.LoadPackage(ctx, p.layout, filter, true)
+		pkg, loadWarnings, err := p.loadPackage(ctx, filter)
 		if err != nil {
 			return fmt.Errorf("unable to load the package: %w", err)
 		}
 		p.cfg.Pkg = pkg
 		warnings = append(warnings, loadWarnings...)
 	} else {
-		pkg, loadWarnings, err := p.source.LoadPackage(ctx, p.layout, deployFilter, true)
+		pkg, loadWarnings, err := p.loadPackage(ctx, deployFilter)
 		if err != nil {
 			return fmt.Errorf("unable to load the package: %w", err)
 		}
@@ -101,10 +151,28 @@ func (p *Packager) Deploy(ctx context.Context) error {
 	}
 	warnings = append(warnings, sbomWarnings...)
 
+	diff := p.getDeployedPackageDiff(ctx)
+	if diff != nil && diff.Downgrade {
+		warnings = append(warnings, fmt.Sprintf("version %s of the package %s is already deployed, this deploys the older version %s", diff.FromVersion, p.cfg.Pkg.Metadata.Name, diff.ToVersion))
+	}
+
+	if names := hostservices.Names(p.cfg.Pkg.Components); len(names) > 0 {
+		warnings = append(warnings, fmt.Sprintf("this package installs the systemd services %s onto this host", strings.Join(names, ", ")))
+	}
+	if names := hostartifacts.Names(p.cfg.Pkg.Components); len(names) > 0 {
+		warnings = append(warnings, fmt.Sprintf("this package installs %s onto this host", strings.Join(names, ", ")))
+	}
+
 	// Confirm the overall package deployment
-	if !p.confirmAction(ctx, config.ZarfDeployStage, warnings, sbomViewFiles) {
+	confirmed, err := p.confirmAction(ctx, config.ZarfDeployStage, warnings, sbomViewFiles, diff)
+	if err != nil {
+		return err
+	}
+	if !confirmed {
 		return fmt.Errorf("deployment cancelled")
 	}
+	notify.From(ctx).Notify(ctx, notify.NewNotification(notify.EventDeployStarted, p.cfg.Pkg, nil))
+	started = true
 
 	if isInteractive {
 		p.cfg.Pkg.Components, err = deployFilter.Apply(p.cfg.Pkg)
@@ -118,6 +186,19 @@ func (p *Packager) Deploy(ctx context.Context) error {
 		}
 	}
 
+	// Host services change the host itself and have to be allowed explicitly, even when the deploy is confirmed
+	if names := hostservices.Names(p.cfg.Pkg.Components); len(names) > 0 && !p.cfg.DeployOpts.AllowHostServices {
+		return fmt.Errorf("the components to deploy install the systemd services %s onto this host, deploy with --allow-host-services to allow it", strings.Join(names, ", "))
+	}
+	// Host artifacts are installed onto the host as well, outside of the cluster the package is deployed to
+	if err := p.validateHostArtifacts(); err != nil {
+		return err
+	}
+
+	if err := p.checkCapacity(ctx); err != nil {
+		return err
+	}
+
 	p.hpaModified = false
 	// Reset registry HPA scale down whether an error occurs or not
 	defer p.resetRegistryHPA(ctx)
@@ -125,6 +206,14 @@ func (p *Packager) Deploy(ctx context.Context) error {
 	// Get a list of all the components we are deploying and actually deploy them
 	deployedComponents, err := p.deployComponents(ctx)
 	if err != nil {
+		if ctx.Err() != nil && p.isConnectedToCluster() {
+			name := p.cfg.Pkg.Metadata.Name
+			message.Warnf(lang.CmdPackageDeployInterruptedWarn, name, p.cfg.PkgOpts.PackageSource, name)
+			l.Warn("deployment was interrupted and the package is partially deployed, deploy it again to finish the deployment or remove it",
+				"name", name,
+				"source", p.cfg.PkgOpts.PackageSource,
+			)
+		}
 		return err
 	}
 	if len(deployedComponents) == 0 {
@@ -144,13 +233,90 @@ func (p *Packager) Deploy(ctx context.Context) error {
 	return nil
 }
 
+// loadPackage loads the package to deploy. With lazy loading, sources that support it only load the package metadata
+// up front, the contents of each component are extracted when it is deployed and removed once it succeeded.
+func (p *Packager) loadPackage(ctx context.Context, filter filters.ComponentFilterStrategy) (pkg v1alpha1.ZarfPackage, warnings []string, err error) {
+	if lazySource, ok := p.lazySource(); ok {
+		pkg, warnings, err = lazySource.LoadPackageLazy(ctx, p.layout, filter)
+	} else {
+		pkg, warnings, err = p.source.LoadPackage(ctx, p.layout, filter, true)
+	}
+	if err != nil {
+		return pkg, nil, err
+	}
+	if config.CommonOptions.FIPS {
+		err = fips.ValidatePackage(pkg)
+		if err != nil {
+			return pkg, nil, err
+		}
+	}
+	return pkg, warnings, nil
+}
+
+// lazySource returns the source as a lazy package source when lazy loading is enabled and supported by the source.
+// Tarball sources are read once per component when they are loaded lazily, so zarf package deploy only enables lazy
+// loading by default for OCI sources.
+func (p *Packager) lazySource() (sources.LazyPackageSource, bool) {
+	if !p.cfg.DeployOpts.LazyLoad {
+		return nil, false
+	}
+	lazySource, ok := p.source.(sources.LazyPackageSource)
+	return lazySource, ok
+}
+
+// getClusterFacts returns the facts of the cluster that components are conditionally deployed on. The package is not
+// loaded yet when the components are filtered, so the cluster checks are left to the connection made on deploy.
+func (p *Packager) getClusterFacts(ctx context.Context) (types.ClusterFacts, error) {
+	c := p.cluster
+	if c == nil {
+		connectCtx, cancel := context.WithTimeout(ctx, cluster.DefaultTimeout)
+		defer cancel()
+		var err error
+		c, err = cluster.NewClusterWithWait(connectCtx)
+		if err != nil {
+			return types.ClusterFacts{}, fmt.Errorf("unable to connect to the Kubernetes cluster: %w", err)
+		}
+	}
+	facts, err := c.GetClusterFacts(ctx)
+	if err != nil {
+		return types.ClusterFacts{}, err
+	}
+	logger.From(ctx).Debug("filtering components on cluster facts", "distro", facts.Distro, "version", facts.Version, "providers", facts.Providers)
+	return facts, nil
+}
+
+// recordDeployMetric records the deployment metric if metrics are enabled.
+func (p *Packager) recordDeployMetric(ctx context.Context, start time.Time, err error) {
+	recorder := metrics.From(ctx)
+	if recorder == nil {
+		return
+	}
+	size, sizeErr := helpers.GetDirSize(p.layout.Base)
+	if sizeErr != nil {
+		logger.From(ctx).Debug("unable to get the package size", "error", sizeErr)
+	}
+	recorder.Record(ctx, metrics.NewMetric(metrics.OperationDeploy, p.cfg.Pkg, size, start, err))
+}
+
 // deployComponents loops through a list of ZarfComponents and deploys them.
 func (p *Packager) deployComponents(ctx context.Context) ([]types.DeployedComponent, error) {
 	l := logger.From(ctx)
 	deployedComponents := []types.DeployedComponent{}
 
+	lazySource, isLazy := p.lazySource()
+
 	// Process all the components we are deploying
-	for _, component := range p.cfg.Pkg.Components {
+	for componentIdx, component := range p.cfg.Pkg.Components {
+		// Do not start the next component once the deployment is interrupted.
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		if isLazy {
+			if err := lazySource.LoadComponent(ctx, p.layout, component); err != nil {
+				return nil, fmt.Errorf("unable to load component %q: %w", component.Name, err)
+			}
+		}
+
 		packageGeneration := 1
 		// Connect to cluster if a component requires it.
 		if component.RequiresCluster() {
@@ -158,6 +324,9 @@ func (p *Packager) deployComponents(ctx context.Context) ([]types.DeployedCompon
 			if p.cfg.Pkg.IsInitConfig() {
 				timeout = 5 * time.Minute
 			}
+			if componentTimeout, ok := p.componentTimeout(component); ok {
+				timeout = componentTimeout
+			}
 			connectCtx, cancel := context.WithTimeout(ctx, timeout)
 			defer cancel()
 			if err := p.connectToCluster(connectCtx); err != nil {
@@ -188,7 +357,7 @@ func (p *Packager) deployComponents(ctx context.Context) ([]types.DeployedCompon
 		deployedComponents = append(deployedComponents, deployedComponent)
 		idx := len(deployedComponents) - 1
 		if p.isConnectedToCluster() {
-			if _, err := p.cluster.RecordPackageDeployment(ctx, p.cfg.Pkg, deployedComponents, packageGeneration); err != nil {
+			if _, err := p.cluster.RecordPackageDeployment(ctx, p.cfg.Pkg, deployedComponents, packageGeneration, p.recordedSource()); err != nil {
 				message.Debugf("Unable to record package deployment for component %q: this will affect features like `zarf package remove`: %s", component.Name, err.Error())
 				l.Debug("unable to record package deployment", "component", component.Name, "error", err.Error())
 			}
@@ -202,10 +371,13 @@ func (p *Packager) deployComponents(ctx context.Context) ([]types.DeployedCompon
 			charts, deployErr = p.deployComponent(ctx, component, false, false)
 		}
 
-		onDeploy := component.Actions.OnDeploy
+		onDeploy := p.deployActions(component)
 
+		// The failure actions and the component status are run and recorded even when the deployment is interrupted.
 		onFailure := func() {
-			if err := actions.Run(ctx, onDeploy.Defaults, onDeploy.OnFailure, p.variableConfig); err != nil {
+			cleanupCtx, cancel := cleanupContext(ctx)
+			defer cancel()
+			if err := actions.Run(cleanupCtx, onDeploy.Defaults, onDeploy.OnFailure, p.variableConfig); err != nil {
 				message.Debugf("unable to run component failure action: %s", err.Error())
 				l.Debug("unable to run component failure action", "error", err.Error())
 			}
@@ -214,8 +386,13 @@ func (p *Packager) deployComponents(ctx context.Context) ([]types.DeployedCompon
 		if deployErr != nil {
 			onFailure()
 			deployedComponents[idx].Status = types.ComponentStatusFailed
+			if ctx.Err() != nil {
+				deployedComponents[idx].Status = types.ComponentStatusInterrupted
+			}
 			if p.isConnectedToCluster() {
-				if _, err := p.cluster.RecordPackageDeployment(ctx, p.cfg.Pkg, deployedComponents, packageGeneration); err != nil {
+				cleanupCtx, cancel := cleanupContext(ctx)
+				defer cancel()
+				if _, err := p.cluster.RecordPackageDeployment(cleanupCtx, p.cfg.Pkg, deployedComponents, packageGeneration, p.recordedSource()); err != nil {
 					message.Debugf("Unable to record package deployment for component %q: this will affect features like `zarf package remove`: %s", component.Name, err.Error())
 					l.Debug("unable to record package deployment", "component", component.Name, "error", err.Error())
 				}
@@ -227,7 +404,7 @@ func (p *Packager) deployComponents(ctx context.Context) ([]types.DeployedCompon
 		deployedComponents[idx].InstalledCharts = charts
 		deployedComponents[idx].Status = types.ComponentStatusSucceeded
 		if p.isConnectedToCluster() {
-			if _, err := p.cluster.RecordPackageDeployment(ctx, p.cfg.Pkg, deployedComponents, packageGeneration); err != nil {
+			if _, err := p.cluster.RecordPackageDeployment(ctx, p.cfg.Pkg, deployedComponents, packageGeneration, p.recordedSource()); err != nil {
 				message.Debugf("Unable to record package deployment for component %q: this will affect features like `zarf package remove`: %s", component.Name, err.Error())
 				l.Debug("unable to record package deployment", "component", component.Name, "error", err.Error())
 			}
@@ -237,6 +414,14 @@ func (p *Packager) deployComponents(ctx context.Context) ([]types.DeployedCompon
 			onFailure()
 			return nil, fmt.Errorf("unable to run component success action: %w", err)
 		}
+
+		// Free the disk space of the component before the next one is extracted
+		if isLazy {
+			if err := lazySource.UnloadComponent(ctx, p.layout, component, p.cfg.Pkg.Components[componentIdx+1:]); err != nil {
+				message.Debugf("Unable to remove the contents of component %q: %s", component.Name, err.Error())
+				l.Debug("unable to remove the contents of component", "component", component.Name, "error", err.Error())
+			}
+		}
 	}
 
 	return deployedComponents, nil
@@ -249,8 +434,16 @@ func (p *Packager) deployInitComponent(ctx context.Context, component v1alpha1.Z
 	isRegistry := component.Name == "zarf-registry"
 	isInjector := component.Name == "zarf-injector"
 	isAgent := component.Name == "zarf-agent"
+	isGitServer := component.Name == "git-server"
 	isK3s := component.Name == "k3s"
 
+	// Named instances share the cluster, agent and git server of the default instance or bring their own
+	if config.CommonOptions.Instance != "" && (isK3s || isAgent || isGitServer) {
+		message.Notef("Not deploying the component (%s) for the Zarf instance %s", component.Name, config.CommonOptions.Instance)
+		l.Info("skipping init package component for named Zarf instance", "component", component.Name, "instance", config.CommonOptions.Instance)
+		return nil, nil
+	}
+
 	if isK3s {
 		p.cfg.InitOpts.ApplianceMode = true
 	}
@@ -263,6 +456,15 @@ func (p *Packager) deployInitComponent(ctx context.Context, component v1alpha1.Z
 		}
 	}
 
+	if isSeedRegistry || isRegistry || isGitServer {
+		if p.state == nil {
+			if err := p.setupState(ctx); err != nil {
+				return nil, err
+			}
+		}
+		p.setStorageClassVariables()
+	}
+
 	if hasExternalRegistry && (isSeedRegistry || isInjector || isRegistry) {
 		message.Notef("Not deploying the component (%s) since external registry information was provided during `zarf init`", component.Name)
 		l.Info("skipping init package component since external registry information was provided", "component", component.Name)
@@ -274,9 +476,22 @@ func (p *Packager) deployInitComponent(ctx context.Context, component v1alpha1.Z
 		p.hpaModified = true
 	}
 
-	// Before deploying the seed registry, start the injector
-	if isSeedRegistry {
-		err := p.cluster.StartInjection(ctx, p.layout.Base, p.layout.Images.Base, component.Images)
+	// Before deploying the seed registry, start the injector unless the registry image is pulled through a proxy or
+	// from a mirror
+	if isSeedRegistry && p.cfg.InitOpts.SeedFrom != "" {
+		config.ZarfSeedRegistry = strings.TrimSuffix(strings.TrimPrefix(p.cfg.InitOpts.SeedFrom, helpers.OCIURLPrefix), "/")
+		config.ZarfSeedNode = ""
+		l.Info("pulling the seed registry image from the mirror", "mirror", p.cfg.InitOpts.SeedFrom)
+	} else if isSeedRegistry && p.cfg.InitOpts.SeedStrategy == cluster.SeedStrategyPullThrough {
+		config.ZarfSeedRegistry = p.cfg.InitOpts.SeedProxy
+		config.ZarfSeedNode = ""
+	} else if isSeedRegistry {
+		opts := cluster.InjectionOptions{
+			Strategy: p.cfg.InitOpts.SeedStrategy,
+			HostPath: p.cfg.InitOpts.SeedHostPath,
+			HostPort: int32(p.cfg.InitOpts.SeedHostPort),
+		}
+		err := p.cluster.StartInjection(ctx, p.layout.Base, p.layout.Images.Base, component.Images, opts)
 		if err != nil {
 			return nil, err
 		}
@@ -296,11 +511,34 @@ func (p *Packager) deployInitComponent(ctx context.Context, component v1alpha1.Z
 		}
 	}
 
+	// The registry pod that replaced the seed registry starts with an empty emptyDir volume, so push the registry image
+	// again for the registry to be able to reschedule.
+	if isRegistry && p.state.RegistryInfo.Storage == types.RegistryStorageEphemeral {
+		message.Warn("The registry uses ephemeral storage, images are lost whenever the registry pod restarts and are pushed again on the next package deploy")
+		l.Warn("the registry uses ephemeral storage, images are lost whenever the registry pod restarts and are pushed again on the next package deploy")
+		if err := p.pushImagesToRegistry(ctx, component.Images, false); err != nil {
+			return nil, fmt.Errorf("unable to re-seed the registry: %w", err)
+		}
+	}
+
+	// Namespaces that already have a pull secret switch to or from their robot tokens once the registry accepts them
+	if isRegistry && p.state.RegistryInfo.IsInternal() {
+		if err := p.cluster.UpdateZarfManagedImageSecrets(ctx, p.state); err != nil {
+			return nil, fmt.Errorf("unable to update the registry pull secrets: %w", err)
+		}
+	}
+
 	return charts, nil
 }
 
 // Deploy a Zarf Component.
-func (p *Packager) deployComponent(ctx context.Context, component v1alpha1.ZarfComponent, noImgChecksum bool, noImgPush bool) ([]types.InstalledChart, error) {
+func (p *Packager) deployComponent(ctx context.Context, component v1alpha1.ZarfComponent, noImgChecksum bool, noImgPush bool) (_ []types.InstalledChart, err error) {
+	ctx, span := tracing.Start(ctx, "deploy component", attribute.String("component", component.Name))
+	events.From(ctx).Component(component.Name, events.StatusStarted, nil)
+	defer func() {
+		events.From(ctx).Component(component.Name, events.Done(err), err)
+		tracing.End(span, err)
+	}()
 	l := logger.From(ctx)
 	start := time.Now()
 	// Toggles for general deploy operations
@@ -314,8 +552,13 @@ func (p *Packager) deployComponent(ctx context.Context, component v1alpha1.ZarfC
 	hasManifests := len(component.Manifests) > 0
 	hasRepos := len(component.Repos) > 0
 	hasFiles := len(component.Files) > 0
+	hasHostArtifacts := len(component.HostArtifacts) > 0
+	hasHostServices := len(component.HostServices) > 0
+	hasTofu := len(component.Tofu) > 0
+	hasArtifacts := len(component.Artifacts) > 0 && !noImgPush
+	hasNamespaces := len(component.Namespaces) > 0
 
-	onDeploy := component.Actions.OnDeploy
+	onDeploy := p.deployActions(component)
 
 	if component.RequiresCluster() {
 		// Setup the state in the config
@@ -337,7 +580,7 @@ func (p *Packager) deployComponent(ctx context.Context, component v1alpha1.ZarfC
 		}
 	}
 
-	err := p.populateComponentAndStateTemplates(ctx, component.Name)
+	err = p.populateComponentAndStateTemplates(ctx, component.Name)
 	if err != nil {
 		return nil, err
 	}
@@ -346,19 +589,52 @@ func (p *Packager) deployComponent(ctx context.Context, component v1alpha1.ZarfC
 		return nil, fmt.Errorf("unable to run component before action: %w", err)
 	}
 
+	if hasNamespaces {
+		if err := p.cluster.ApplyNamespacePolicies(ctx, p.cfg.Pkg.Metadata.Name, component.Name, component.Namespaces); err != nil {
+			return nil, fmt.Errorf("unable to apply the namespace policies: %w", err)
+		}
+	}
+
 	if hasFiles {
 		if err := p.processComponentFiles(ctx, component, componentPath.Files); err != nil {
 			return nil, fmt.Errorf("unable to process the component files: %w", err)
 		}
 	}
 
+	if hasHostArtifacts {
+		if err := p.installHostArtifacts(ctx, component, componentPath.HostArtifacts); err != nil {
+			return nil, fmt.Errorf("unable to install the host artifacts: %w", err)
+		}
+	}
+
+	if hasHostServices {
+		if err := p.installHostServices(ctx, component, componentPath.HostServices); err != nil {
+			return nil, fmt.Errorf("unable to install the host services: %w", err)
+		}
+	}
+
+	if hasTofu {
+		if err := p.applyTofuModules(ctx, component, componentPath.Tofu); err != nil {
+			return nil, fmt.Errorf("unable to apply the tofu modules: %w", err)
+		}
+	}
+
 	if hasImages {
 		if err := p.pushImagesToRegistry(ctx, component.Images, noImgChecksum); err != nil {
 			return nil, fmt.Errorf("unable to push images to the registry: %w", err)
 		}
 	}
 
+	if hasArtifacts {
+		if err := p.pushArtifactsToRegistry(ctx, component.Artifacts, componentPath.Artifacts, noImgChecksum); err != nil {
+			return nil, fmt.Errorf("unable to push the artifacts to the registry: %w", err)
+		}
+	}
+
 	if hasRepos {
+		if p.state.GitServer.Address == "" {
+			return nil, fmt.Errorf("component %s has repositories but the Zarf instance %s has no git server, set one with 'zarf init --instance %s --git-url'", component.Name, p.state.Instance, p.state.Instance)
+		}
 		if err = p.pushReposToRepository(ctx, componentPath.Repos, component.Repos); err != nil {
 			return nil, fmt.Errorf("unable to push the repos to the repository: %w", err)
 		}
@@ -384,7 +660,8 @@ func (p *Packager) deployComponent(ctx context.Context, component v1alpha1.ZarfC
 	}
 
 	if len(component.HealthChecks) > 0 {
-		healthCheckContext, cancel := context.WithTimeout(ctx, p.cfg.DeployOpts.Timeout)
+		timeout, _ := p.componentTimeout(component)
+		healthCheckContext, cancel := context.WithTimeout(ctx, timeout)
 		defer cancel()
 		spinner := message.NewProgressSpinner("Running health checks")
 		l.Info("running health checks")
@@ -403,6 +680,43 @@ func (p *Packager) deployComponent(ctx context.Context, component v1alpha1.ZarfC
 	return charts, nil
 }
 
+// componentTimeout returns the timeout of the Helm operations, health checks and wait actions of the component and
+// whether it was set explicitly. An explicit deploy timeout takes precedence over the timeout of the component.
+func (p *Packager) componentTimeout(component v1alpha1.ZarfComponent) (time.Duration, bool) {
+	if p.cfg.DeployOpts.TimeoutOverride {
+		return p.cfg.DeployOpts.Timeout, true
+	}
+	if component.TimeoutSeconds > 0 {
+		return time.Duration(component.TimeoutSeconds) * time.Second, true
+	}
+	return p.cfg.DeployOpts.Timeout, false
+}
+
+// deployActions returns the deploy actions of the component. Wait actions without their own timeout use the timeout of
+// the component when one is set and the default wait timeout otherwise.
+func (p *Packager) deployActions(component v1alpha1.ZarfComponent) v1alpha1.ZarfComponentActionSet {
+	onDeploy := component.Actions.OnDeploy
+	timeout, ok := p.componentTimeout(component)
+	if !ok {
+		return onDeploy
+	}
+	seconds := int(timeout.Seconds())
+	withTimeout := func(actionList []v1alpha1.ZarfComponentAction) []v1alpha1.ZarfComponentAction {
+		actionList = slices.Clone(actionList)
+		for i := range actionList {
+			if actionList[i].Wait != nil && actionList[i].MaxTotalSeconds == nil {
+				actionList[i].MaxTotalSeconds = &seconds
+			}
+		}
+		return actionList
+	}
+	onDeploy.Before = withTimeout(onDeploy.Before)
+	onDeploy.After = withTimeout(onDeploy.After)
+	onDeploy.OnSuccess = withTimeout(onDeploy.OnSuccess)
+	onDeploy.OnFailure = withTimeout(onDeploy.OnFailure)
+	return onDeploy
+}
+
 // Move files onto the host of the machine performing the deployment.
 func (p *Packager) processComponentFiles(ctx context.Context, component v1alpha1.ZarfComponent, pkgLocation string) error {
 	l := logger.From(ctx)
@@ -493,6 +807,95 @@ func (p *Packager) processComponentFiles(ctx context.Context, component v1alpha1
 	return nil
 }
 
+// validateHostArtifacts returns an error if the components to deploy have invalid host artifacts, or have host
+// artifacts that are not allowed to be installed.
+func (p *Packager) validateHostArtifacts() error {
+	for _, component := range p.cfg.Pkg.Components {
+		for _, hostArtifact := range component.HostArtifacts {
+			if err := hostartifacts.Validate(hostArtifact); err != nil {
+				return fmt.Errorf("component %s: %w", component.Name, err)
+			}
+		}
+	}
+	if names := hostartifacts.Names(p.cfg.Pkg.Components); len(names) > 0 && !p.cfg.DeployOpts.AllowHostArtifacts {
+		return fmt.Errorf("the components to deploy install %s onto this host, deploy with --allow-host-artifacts to allow it", strings.Join(names, ", "))
+	}
+	return nil
+}
+
+// installHostArtifacts installs the host artifacts of the component and adds their install directories to the PATH
+// so that later actions can use them.
+func (p *Packager) installHostArtifacts(ctx context.Context, component v1alpha1.ZarfComponent, pkgLocation string) error {
+	l := logger.From(ctx)
+	for hostArtifactIdx, hostArtifact := range component.HostArtifacts {
+		installed, err := hostartifacts.Install(hostArtifact, hostArtifactIdx, pkgLocation, p.cfg.Pkg.Metadata.Name)
+		if err != nil {
+			return err
+		}
+		message.Successf("Installed %s", installed)
+		l.Info("installed host artifact", "name", hostArtifact.Name, "path", installed)
+
+		installDir := filepath.Dir(installed)
+		added, err := hostartifacts.AddToPath(installDir)
+		if err != nil {
+			return err
+		}
+		if added {
+			message.Warnf("%s is not in your PATH, add it to use %s outside of Zarf", installDir, hostArtifact.Name)
+			l.Warn("host artifact install directory is not in the PATH, add it to use the host artifact outside of zarf", "name", hostArtifact.Name, "path", installDir)
+		}
+	}
+	return nil
+}
+
+// applyTofuModules templates the variables of the tofu modules of the component and plans and applies the modules.
+func (p *Packager) applyTofuModules(ctx context.Context, component v1alpha1.ZarfComponent, pkgLocation string) error {
+	l := logger.From(ctx)
+	opts := tofu.ApplyOptions{PackageName: p.cfg.Pkg.Metadata.Name}
+	// The kubernetes backend uses the same kubeconfig as Zarf, or the in cluster config when there is none.
+	for _, path := range clientcmd.NewDefaultClientConfigLoadingRules().GetLoadingPrecedence() {
+		if !helpers.InvalidPath(path) {
+			opts.KubeConfigPath = path
+			break
+		}
+	}
+	for moduleIdx, module := range component.Tofu {
+		dir := filepath.Join(pkgLocation, strconv.Itoa(moduleIdx))
+		variablesPath, err := tofu.WriteVariables(module, dir)
+		if err != nil {
+			return err
+		}
+		if err := p.variableConfig.ReplaceTextTemplate(variablesPath); err != nil {
+			return fmt.Errorf("unable to template the variables of tofu module %s: %w", module.Name, err)
+		}
+
+		message.Notef("Applying tofu module %s", module.Name)
+		l.Info("applying tofu module", "name", module.Name, "backend", cmp.Or(module.Backend, v1alpha1.TofuBackendLocal))
+		if err := tofu.Apply(ctx, module, dir, opts); err != nil {
+			return fmt.Errorf("unable to apply tofu module %s: %w", module.Name, err)
+		}
+	}
+	return nil
+}
+
+// installHostServices installs the systemd units of the host services of the component and enables and starts them.
+func (p *Packager) installHostServices(ctx context.Context, component v1alpha1.ZarfComponent, pkgLocation string) error {
+	l := logger.From(ctx)
+	m, err := hostservices.NewManager()
+	if err != nil {
+		return err
+	}
+	for hostServiceIdx, hostService := range component.HostServices {
+		installed, err := m.Install(ctx, hostService, hostServiceIdx, pkgLocation, p.cfg.Pkg.Metadata.Name)
+		if err != nil {
+			return fmt.Errorf("unable to install host service %s: %w", hostService.Name, err)
+		}
+		message.Successf("Installed %s", installed)
+		l.Info("installed host service", "name", hostService.Name, "path", installed, "enable", hostService.Enable, "start", hostService.Start)
+	}
+	return nil
+}
+
 // setupState fetches the current ZarfState from the k8s cluster and sets the packager to use it
 func (p *Packager) setupState(ctx context.Context) error {
 	l := logger.From(ctx)
@@ -535,7 +938,19 @@ func (p *Packager) setupState(ctx context.Context) error {
 
 	p.state = state
 
+	ephemeral := !p.cfg.Pkg.IsInitConfig() && state.RegistryInfo.Storage == types.RegistryStorageEphemeral
+	if ephemeral {
+		message.Warn("The Zarf registry uses ephemeral storage, the images of this package are lost whenever the registry pod restarts. " +
+			"They are pushed again from the source of the package on the next package deploy.")
+		l.Warn("the Zarf registry uses ephemeral storage, the images of this package are lost whenever the registry pod restarts, " +
+			"they are pushed again from the source of the package on the next package deploy")
+	}
+
 	spinner.Success()
+
+	if ephemeral {
+		p.pushMissingImages(ctx)
+	}
 	return nil
 }
 
@@ -550,7 +965,87 @@ func (p *Packager) populateComponentAndStateTemplates(ctx context.Context, compo
 
 func (p *Packager) populatePackageVariableConfig() error {
 	p.variableConfig.SetConstants(p.cfg.Pkg.Constants)
-	return p.variableConfig.PopulateVariables(p.cfg.Pkg.Variables, p.cfg.PkgOpts.SetVariables)
+	setVariables := p.cfg.PkgOpts.SetVariables
+	if p.cfg.Pkg.IsInitConfig() {
+		// The resources set with the init flags take precedence over the same variables set with --set.
+		setVariables = maps.Clone(setVariables)
+		if setVariables == nil {
+			setVariables = map[string]string{}
+		}
+		maps.Copy(setVariables, initResourceVariables(p.cfg.InitOpts))
+		maps.Copy(setVariables, initStorageVariables(p.cfg.InitOpts))
+	}
+	return p.variableConfig.PopulateVariables(p.cfg.Pkg.Variables, setVariables)
+}
+
+// initResourceVariables returns the variables of the init package that set the resources given to zarf init.
+func initResourceVariables(opts types.ZarfInitOptions) map[string]string {
+	variables := map[string]string{}
+	services := []struct {
+		prefix    string
+		resources types.ServiceResources
+	}{
+		{"REGISTRY", opts.RegistryResources},
+		{"GIT_SERVER", opts.GitServerResources},
+		{"AGENT", opts.AgentResources},
+	}
+	for _, svc := range services {
+		values := map[string]string{
+			"CPU_REQ":       svc.resources.CPURequest,
+			"CPU_LIMIT":     svc.resources.CPULimit,
+			"MEM_REQ":       svc.resources.MemoryRequest,
+			"MEM_LIMIT":     svc.resources.MemoryLimit,
+			"PVC_SIZE":      svc.resources.PVCSize,
+			"STORAGE_CLASS": svc.resources.StorageClass,
+		}
+		for suffix, value := range values {
+			if value != "" {
+				variables[fmt.Sprintf("%s_%s", svc.prefix, suffix)] = value
+			}
+		}
+	}
+	return variables
+}
+
+// initStorageVariables returns the variables of the init package that set the registry storage given to zarf init.
+func initStorageVariables(opts types.ZarfInitOptions) map[string]string {
+	switch opts.RegistryInfo.Storage {
+	case types.RegistryStorageEphemeral:
+		// Every replica would have its own emptyDir volume and only some of the images
+		return map[string]string{
+			"REGISTRY_PVC_ENABLED": "false",
+			"REGISTRY_HPA_ENABLE":  "false",
+		}
+	case types.RegistryStorageS3:
+		variables := map[string]string{
+			"REGISTRY_PVC_ENABLED": "false",
+			"REGISTRY_S3_BUCKET":   opts.RegistryS3.Bucket,
+		}
+		values := map[string]string{
+			"REGISTRY_S3_REGION":     opts.RegistryS3.Region,
+			"REGISTRY_S3_ENDPOINT":   opts.RegistryS3.Endpoint,
+			"REGISTRY_S3_ACCESS_KEY": opts.RegistryS3.AccessKey,
+			"REGISTRY_S3_SECRET_KEY": opts.RegistryS3.SecretKey,
+		}
+		for name, value := range values {
+			if value != "" {
+				variables[name] = value
+			}
+		}
+		return variables
+	}
+	return map[string]string{}
+}
+
+// setStorageClassVariables sets the storage class of the registry and git server to the storage class of the cluster
+// when they were not given their own.
+func (p *Packager) setStorageClassVariables() {
+	for _, name := range []string{"REGISTRY_STORAGE_CLASS", "GIT_SERVER_STORAGE_CLASS"} {
+		if variable, ok := p.variableConfig.GetSetVariable(name); ok && variable.Value != "" {
+			continue
+		}
+		p.variableConfig.SetVariable(name, p.state.StorageClass, false, false, v1alpha1.RawVariableType)
+	}
 }
 
 // Push all of the components images to the configured container registry.
@@ -574,10 +1069,83 @@ func (p *Packager) pushImagesToRegistry(ctx context.Context, componentImages []s
 		Arch:            p.cfg.Pkg.Build.Architecture,
 		Retries:         p.cfg.PkgOpts.Retries,
 	}
+	if p.cfg.Pkg.IsInitConfig() {
+		pushCfg.Mirror = p.cfg.InitOpts.SeedFrom
+	}
 
 	return images.Push(ctx, pushCfg)
 }
 
+// Push all of the components artifacts to the configured registry.
+func (p *Packager) pushArtifactsToRegistry(ctx context.Context, artifacts []v1alpha1.ZarfArtifact, artifactsPath string, noImgChecksum bool) error {
+	l := logger.From(ctx)
+	regInfo := p.state.RegistryInfo
+	return retry.Do(func() error {
+		registryURL, tunnel, err := p.cluster.ConnectToZarfRegistryEndpoint(ctx, regInfo)
+		if err != nil {
+			return err
+		}
+		if tunnel != nil {
+			defer tunnel.Close()
+		}
+		opts := artifact.RegistryOptions{
+			// The internal registry is only served over HTTPS when it has a certificate.
+			PlainHTTP:             config.CommonOptions.PlainHTTP || (regInfo.IsInternal() && regInfo.TLS.CABundle() == nil),
+			InsecureSkipTLSVerify: config.CommonOptions.InsecureSkipTLSVerify,
+			Username:              regInfo.PushUsername,
+			Password:              regInfo.PushPassword,
+			CABundle:              regInfo.TLS.CABundle(),
+		}
+		for _, a := range artifacts {
+			refInfo, err := transform.ParseImageRef(a.Reference)
+			if err != nil {
+				return fmt.Errorf("invalid artifact reference %s: %w", a.Reference, err)
+			}
+			// Artifacts are pushed by their tag so they can be referenced like images, and by digest when they have no tag.
+			srcRef := refInfo.Reference
+			if refInfo.Tag != "" {
+				srcRef = fmt.Sprintf("%s:%s", refInfo.Name, refInfo.Tag)
+			}
+			dsts := []string{}
+			if !noImgChecksum {
+				dst, err := transform.ImageTransformHost(registryURL, srcRef, regInfo.ImageRules...)
+				if err != nil {
+					return err
+				}
+				dsts = append(dsts, dst)
+			}
+			dst, err := transform.ImageTransformHostWithoutChecksum(registryURL, srcRef, regInfo.ImageRules...)
+			if err != nil {
+				return err
+			}
+			dsts = append(dsts, dst)
+
+			l.Info("pushing artifact", "name", a.Reference)
+			for _, dst := range dsts {
+				push := func() error {
+					desc, err := artifact.PushLayout(ctx, artifactsPath, a.Reference, dst, opts)
+					if err != nil {
+						return err
+					}
+					if refInfo.Digest != "" && desc.Digest.String() != refInfo.Digest {
+						return fmt.Errorf("pushed artifact %s has the digest %s instead of %s", a.Reference, desc.Digest, refInfo.Digest)
+					}
+					return nil
+				}
+				if tunnel != nil {
+					err = tunnel.Wrap(push)
+				} else {
+					err = push()
+				}
+				if err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}, retry.Context(ctx), retry.Attempts(uint(p.cfg.PkgOpts.Retries)), retry.Delay(500*time.Millisecond))
+}
+
 // Push all of the components git repos to the configured git server.
 func (p *Packager) pushReposToRepository(ctx context.Context, reposPath string, repos []string) error {
 	l := logger.From(ctx)
@@ -610,12 +1178,14 @@ func (p *Packager) pushReposToRepository(ctx context.Context, reposPath string,
 					return err
 				}
 				defer tunnel.Close()
-				giteaClient, err := gitea.NewClient(tunnel.HTTPEndpoint(), p.state.GitServer.PushUsername, p.state.GitServer.PushPassword)
+				tunnelURL := tunnel.ServiceEndpoint(p.state.GitServer.TLS)
+				caBundle := p.state.GitServer.TLS.CABundle()
+				giteaClient, err := gitea.NewClient(tunnelURL, p.state.GitServer.PushUsername, p.state.GitServer.PushPassword, caBundle)
 				if err != nil {
 					return err
 				}
 				return tunnel.Wrap(func() error {
-					err = repository.Push(ctx, tunnel.HTTPEndpoint(), p.state.GitServer.PushUsername, p.state.GitServer.PushPassword)
+					err = repository.Push(ctx, tunnelURL, p.state.GitServer.PushUsername, p.state.GitServer.PushPassword, caBundle)
 					if err != nil {
 						return err
 					}
@@ -632,7 +1202,7 @@ func (p *Packager) pushReposToRepository(ctx context.Context, reposPath string,
 				})
 			}
 
-			err = repository.Push(ctx, p.state.GitServer.Address, p.state.GitServer.PushUsername, p.state.GitServer.PushPassword)
+			err = repository.Push(ctx, p.state.GitServer.Address, p.state.GitServer.PushUsername, p.state.GitServer.PushPassword, p.state.GitServer.TLS.CABundle())
 			if err != nil {
 				return err
 			}
@@ -675,6 +1245,7 @@ func (p *Packager) generateValuesOverrides(chart v1alpha1.ZarfChart, componentNa
 // Install all Helm charts and raw k8s manifests into the k8s cluster.
 func (p *Packager) installChartAndManifests(ctx context.Context, componentPaths *layout.ComponentPaths, component v1alpha1.ZarfComponent) ([]types.InstalledChart, error) {
 	installedCharts := []types.InstalledChart{}
+	timeout, _ := p.componentTimeout(component)
 
 	for _, chart := range component.Charts {
 		// Do not wait for the chart to be ready if data injections are present.
@@ -682,6 +1253,17 @@ func (p *Packager) installChartAndManifests(ctx context.Context, componentPaths
 			chart.NoWait = true
 		}
 
+		// Verify the vendored dependencies so that Helm never has to download them.
+		recorded := []v1alpha1.ZarfChartDependency{}
+		for _, dep := range p.cfg.Pkg.Build.ChartDependencies {
+			if dep.Component == component.Name && dep.Chart == chart.Name {
+				recorded = append(recorded, dep)
+			}
+		}
+		if err := helm.VerifyChartDependencies(helm.StandardName(componentPaths.Charts, chart)+".tgz", recorded); err != nil {
+			return nil, err
+		}
+
 		// zarf magic for the value file
 		for idx := range chart.ValuesFiles {
 			valueFilePath := helm.StandardValuesName(componentPaths.Values, chart, idx)
@@ -707,7 +1289,7 @@ func (p *Packager) installChartAndManifests(ctx context.Context, componentPaths
 				p.state,
 				p.cluster,
 				valuesOverrides,
-				p.cfg.DeployOpts.Timeout,
+				timeout,
 				p.cfg.PkgOpts.Retries),
 		)
 
@@ -751,7 +1333,7 @@ func (p *Packager) installChartAndManifests(ctx context.Context, componentPaths
 				p.state,
 				p.cluster,
 				nil,
-				p.cfg.DeployOpts.Timeout,
+				timeout,
 				p.cfg.PkgOpts.Retries),
 		)
 		if err != nil {
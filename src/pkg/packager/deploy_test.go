@@ -4,11 +4,19 @@
 package packager
 
 import (
+	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/kubernetes/fake"
+
 	"github.com/zarf-dev/zarf/src/api/v1alpha1"
+	"github.com/zarf-dev/zarf/src/internal/packager/template"
+	"github.com/zarf-dev/zarf/src/pkg/cluster"
+	"github.com/zarf-dev/zarf/src/pkg/layout"
 	"github.com/zarf-dev/zarf/src/pkg/packager/sources"
+	"github.com/zarf-dev/zarf/src/test/testutil"
 	"github.com/zarf-dev/zarf/src/types"
 )
 
@@ -272,3 +280,202 @@ func TestServiceInfoFromServiceURL(t *testing.T) {
 		})
 	}
 }
+
+func TestInitResourceVariables(t *testing.T) {
+	t.Parallel()
+
+	opts := types.ZarfInitOptions{
+		RegistryResources: types.ServiceResources{
+			CPURequest:   "500m",
+			MemoryLimit:  "4Gi",
+			PVCSize:      "100Gi",
+			StorageClass: "fast-ssd",
+		},
+		GitServerResources: types.ServiceResources{
+			CPULimit: "2",
+		},
+		AgentResources: types.ServiceResources{
+			MemoryRequest: "64Mi",
+		},
+	}
+	expected := map[string]string{
+		"REGISTRY_CPU_REQ":       "500m",
+		"REGISTRY_MEM_LIMIT":     "4Gi",
+		"REGISTRY_PVC_SIZE":      "100Gi",
+		"REGISTRY_STORAGE_CLASS": "fast-ssd",
+		"GIT_SERVER_CPU_LIMIT":   "2",
+		"AGENT_MEM_REQ":          "64Mi",
+	}
+	require.Equal(t, expected, initResourceVariables(opts))
+	require.Empty(t, initResourceVariables(types.ZarfInitOptions{}))
+}
+
+func TestDeployComponentsInterrupted(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(testutil.TestContext(t))
+	defer cancel()
+	c := &cluster.Cluster{Clientset: fake.NewClientset()}
+	p := &Packager{
+		cluster:        c,
+		layout:         layout.New(t.TempDir()),
+		variableConfig: template.GetZarfVariableConfig(ctx),
+		cfg: &types.PackagerConfig{
+			Pkg: v1alpha1.ZarfPackage{
+				Metadata: v1alpha1.ZarfMetadata{Name: "test"},
+				Components: []v1alpha1.ZarfComponent{
+					{Name: "first"},
+					{
+						Name: "second",
+						Actions: v1alpha1.ZarfComponentActions{
+							OnDeploy: v1alpha1.ZarfComponentActionSet{
+								Before: []v1alpha1.ZarfComponentAction{{Cmd: "sleep 30"}},
+							},
+						},
+					},
+					{Name: "third"},
+				},
+			},
+		},
+	}
+
+	go func() {
+		time.Sleep(500 * time.Millisecond)
+		cancel()
+	}()
+	start := time.Now()
+	_, err := p.deployComponents(ctx)
+	require.ErrorIs(t, err, context.Canceled)
+	require.Less(t, time.Since(start), 10*time.Second)
+
+	deployedPackage, err := c.GetDeployedPackage(testutil.TestContext(t), "test")
+	require.NoError(t, err)
+	require.Len(t, deployedPackage.DeployedComponents, 2)
+	require.Equal(t, types.ComponentStatusSucceeded, deployedPackage.DeployedComponents[0].Status)
+	require.Equal(t, types.ComponentStatusInterrupted, deployedPackage.DeployedComponents[1].Status)
+}
+
+func TestComponentTimeout(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name             string
+		deployOpts       types.ZarfDeployOptions
+		timeoutSeconds   int
+		expectedTimeout  time.Duration
+		expectedExplicit bool
+	}{
+		{
+			name:            "default",
+			deployOpts:      types.ZarfDeployOptions{Timeout: 15 * time.Minute},
+			expectedTimeout: 15 * time.Minute,
+		},
+		{
+			name:             "component timeout",
+			deployOpts:       types.ZarfDeployOptions{Timeout: 15 * time.Minute},
+			timeoutSeconds:   1800,
+			expectedTimeout:  30 * time.Minute,
+			expectedExplicit: true,
+		},
+		{
+			name:             "deploy timeout overrides component timeout",
+			deployOpts:       types.ZarfDeployOptions{Timeout: time.Hour, TimeoutOverride: true},
+			timeoutSeconds:   1800,
+			expectedTimeout:  time.Hour,
+			expectedExplicit: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			p := &Packager{cfg: &types.PackagerConfig{DeployOpts: tt.deployOpts}}
+			timeout, explicit := p.componentTimeout(v1alpha1.ZarfComponent{TimeoutSeconds: tt.timeoutSeconds})
+			require.Equal(t, tt.expectedTimeout, timeout)
+			require.Equal(t, tt.expectedExplicit, explicit)
+		})
+	}
+}
+
+func TestDeployActions(t *testing.T) {
+	t.Parallel()
+
+	waitSeconds := 60
+	component := v1alpha1.ZarfComponent{
+		TimeoutSeconds: 1800,
+		Actions: v1alpha1.ZarfComponentActions{
+			OnDeploy: v1alpha1.ZarfComponentActionSet{
+				Before: []v1alpha1.ZarfComponentAction{
+					{Cmd: "echo before"},
+					{Wait: &v1alpha1.ZarfComponentActionWait{Cluster: &v1alpha1.ZarfComponentActionWaitCluster{Kind: "pod", Name: "app"}}},
+				},
+				After: []v1alpha1.ZarfComponentAction{
+					{Wait: &v1alpha1.ZarfComponentActionWait{Network: &v1alpha1.ZarfComponentActionWaitNetwork{Protocol: "http", Address: "localhost"}}, MaxTotalSeconds: &waitSeconds},
+				},
+			},
+		},
+	}
+
+	p := &Packager{cfg: &types.PackagerConfig{DeployOpts: types.ZarfDeployOptions{Timeout: 15 * time.Minute}}}
+	onDeploy := p.deployActions(component)
+	require.Nil(t, onDeploy.Before[0].MaxTotalSeconds)
+	require.Equal(t, 1800, *onDeploy.Before[1].MaxTotalSeconds)
+	require.Equal(t, 60, *onDeploy.After[0].MaxTotalSeconds)
+	// The actions of the component are left unchanged.
+	require.Nil(t, component.Actions.OnDeploy.Before[1].MaxTotalSeconds)
+
+	// Without a timeout the default wait timeout is used.
+	component.TimeoutSeconds = 0
+	onDeploy = p.deployActions(component)
+	require.Nil(t, onDeploy.Before[1].MaxTotalSeconds)
+}
+
+func TestInitStorageVariables(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		opts     types.ZarfInitOptions
+		expected map[string]string
+	}{
+		{
+			name:     "pvc",
+			opts:     types.ZarfInitOptions{RegistryInfo: types.RegistryInfo{Storage: types.RegistryStoragePVC}},
+			expected: map[string]string{},
+		},
+		{
+			name: "ephemeral",
+			opts: types.ZarfInitOptions{RegistryInfo: types.RegistryInfo{Storage: types.RegistryStorageEphemeral}},
+			expected: map[string]string{
+				"REGISTRY_PVC_ENABLED": "false",
+				"REGISTRY_HPA_ENABLE":  "false",
+			},
+		},
+		{
+			name: "s3",
+			opts: types.ZarfInitOptions{
+				RegistryInfo: types.RegistryInfo{Storage: types.RegistryStorageS3},
+				RegistryS3: types.S3Options{
+					Bucket:    "zarf-registry",
+					Endpoint:  "https://minio.example.com",
+					AccessKey: "access",
+					SecretKey: "secret",
+				},
+			},
+			expected: map[string]string{
+				"REGISTRY_PVC_ENABLED":   "false",
+				"REGISTRY_S3_BUCKET":     "zarf-registry",
+				"REGISTRY_S3_ENDPOINT":   "https://minio.example.com",
+				"REGISTRY_S3_ACCESS_KEY": "access",
+				"REGISTRY_S3_SECRET_KEY": "secret",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			require.Equal(t, tt.expected, initStorageVariables(tt.opts))
+		})
+	}
+}
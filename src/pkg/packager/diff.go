@@ -0,0 +1,149 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package packager contains functions for interacting with, managing and deploying Zarf packages.
+package packager
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/Masterminds/semver/v3"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+
+	"github.com/zarf-dev/zarf/src/api/v1alpha1"
+	"github.com/zarf-dev/zarf/src/pkg/cluster"
+	"github.com/zarf-dev/zarf/src/pkg/logger"
+	"github.com/zarf-dev/zarf/src/types"
+)
+
+// deployedPackageTimeout bounds the lookup of the deployed package, which must not hold up the deploy confirmation.
+const deployedPackageTimeout = 10 * time.Second
+
+// getDeployedPackageDiff returns the difference between the package and the version of it that is deployed to the
+// cluster. It returns nil when the package is not deployed yet or the cluster can not be reached.
+func (p *Packager) getDeployedPackageDiff(ctx context.Context) *types.PackageDiff {
+	l := logger.From(ctx)
+	if p.cfg.Pkg.IsInitConfig() || !slices.ContainsFunc(p.cfg.Pkg.Components, v1alpha1.ZarfComponent.RequiresCluster) {
+		return nil
+	}
+	c := p.cluster
+	if c == nil {
+		var err error
+		c, err = cluster.NewCluster()
+		if err != nil {
+			l.Debug("unable to connect to the cluster to compare the deployed package", "error", err)
+			return nil
+		}
+	}
+	ctx, cancel := context.WithTimeout(ctx, deployedPackageTimeout)
+	defer cancel()
+	deployedPackage, err := c.GetDeployedPackage(ctx, p.cfg.Pkg.Metadata.Name)
+	if err != nil {
+		if !kerrors.IsNotFound(err) {
+			l.Debug("unable to get the deployed package", "name", p.cfg.Pkg.Metadata.Name, "error", err)
+		}
+		return nil
+	}
+	diff := diffPackages(deployedPackage.Data, p.cfg.Pkg)
+	return &diff
+}
+
+// diffPackages returns the changes to the version, components, images and variables from the deployed package to the
+// package being deployed. Image digests are only compared when both packages recorded them on create.
+func diffPackages(deployed, pkg v1alpha1.ZarfPackage) types.PackageDiff {
+	diff := types.PackageDiff{
+		FromVersion: deployed.Metadata.Version,
+		ToVersion:   pkg.Metadata.Version,
+	}
+	fromVersion, fromErr := semver.NewVersion(diff.FromVersion)
+	toVersion, toErr := semver.NewVersion(diff.ToVersion)
+	if fromErr == nil && toErr == nil {
+		diff.Downgrade = toVersion.LessThan(fromVersion)
+	}
+
+	diff.AddedComponents, diff.RemovedComponents = diffNames(componentNames(deployed), componentNames(pkg))
+
+	fromImages := packageImages(deployed)
+	toImages := packageImages(pkg)
+	diff.AddedImages, diff.RemovedImages = diffNames(fromImages, toImages)
+	for _, image := range toImages {
+		fromDigest := deployed.Build.ImageDigests[image]
+		toDigest := pkg.Build.ImageDigests[image]
+		if fromDigest == "" || toDigest == "" || fromDigest == toDigest {
+			continue
+		}
+		diff.ChangedImages = append(diff.ChangedImages, types.ImageDigestChange{
+			Reference:  image,
+			FromDigest: fromDigest,
+			ToDigest:   toDigest,
+		})
+	}
+
+	fromVariables := map[string]v1alpha1.InteractiveVariable{}
+	for _, variable := range deployed.Variables {
+		fromVariables[variable.Name] = variable
+	}
+	toVariables := map[string]v1alpha1.InteractiveVariable{}
+	for _, variable := range pkg.Variables {
+		toVariables[variable.Name] = variable
+	}
+	diff.AddedVariables, diff.RemovedVariables = diffNames(variableNames(deployed), variableNames(pkg))
+	for _, name := range variableNames(pkg) {
+		fromVariable, ok := fromVariables[name]
+		if ok && fromVariable != toVariables[name] {
+			diff.ChangedVariables = append(diff.ChangedVariables, name)
+		}
+	}
+	return diff
+}
+
+// diffNames returns the names that are only in to and the names that are only in from, both sorted.
+func diffNames(from, to []string) ([]string, []string) {
+	added := []string{}
+	for _, name := range to {
+		if !slices.Contains(from, name) {
+			added = append(added, name)
+		}
+	}
+	removed := []string{}
+	for _, name := range from {
+		if !slices.Contains(to, name) {
+			removed = append(removed, name)
+		}
+	}
+	slices.Sort(added)
+	slices.Sort(removed)
+	return added, removed
+}
+
+func componentNames(pkg v1alpha1.ZarfPackage) []string {
+	names := []string{}
+	for _, component := range pkg.Components {
+		names = append(names, component.Name)
+	}
+	return names
+}
+
+func packageImages(pkg v1alpha1.ZarfPackage) []string {
+	images := []string{}
+	for _, component := range pkg.Components {
+		for _, image := range component.Images {
+			if !slices.Contains(images, image) {
+				images = append(images, image)
+			}
+		}
+	}
+	slices.Sort(images)
+	return images
+}
+
+func variableNames(pkg v1alpha1.ZarfPackage) []string {
+	names := []string{}
+	for _, variable := range pkg.Variables {
+		names = append(names, variable.Name)
+	}
+	slices.Sort(names)
+	return names
+}
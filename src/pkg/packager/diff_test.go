@@ -0,0 +1,102 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package packager
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/zarf-dev/zarf/src/api/v1alpha1"
+	"github.com/zarf-dev/zarf/src/types"
+)
+
+func TestDiffPackages(t *testing.T) {
+	t.Parallel()
+
+	newPackage := func(version string, digest string, defaultValue string, components ...v1alpha1.ZarfComponent) v1alpha1.ZarfPackage {
+		pkg := v1alpha1.ZarfPackage{
+			Metadata:   v1alpha1.ZarfMetadata{Name: "podinfo", Version: version},
+			Components: components,
+			Variables: []v1alpha1.InteractiveVariable{
+				{Variable: v1alpha1.Variable{Name: "REPLICAS"}, Default: defaultValue},
+			},
+		}
+		if digest != "" {
+			pkg.Build.ImageDigests = map[string]string{"ghcr.io/stefanprodan/podinfo:6.4.0": digest}
+		}
+		return pkg
+	}
+	podinfo := v1alpha1.ZarfComponent{Name: "podinfo", Images: []string{"ghcr.io/stefanprodan/podinfo:6.4.0"}}
+	redis := v1alpha1.ZarfComponent{Name: "redis", Images: []string{"docker.io/library/redis:7.2"}}
+
+	tests := []struct {
+		name     string
+		deployed v1alpha1.ZarfPackage
+		pkg      v1alpha1.ZarfPackage
+		expected types.PackageDiff
+		empty    bool
+	}{
+		{
+			name:     "no changes",
+			deployed: newPackage("1.0.0", "sha256:a", "1", podinfo),
+			pkg:      newPackage("1.0.0", "sha256:a", "1", podinfo),
+			expected: types.PackageDiff{
+				FromVersion:       "1.0.0",
+				ToVersion:         "1.0.0",
+				AddedComponents:   []string{},
+				RemovedComponents: []string{},
+				AddedImages:       []string{},
+				RemovedImages:     []string{},
+				AddedVariables:    []string{},
+				RemovedVariables:  []string{},
+			},
+			empty: true,
+		},
+		{
+			name:     "upgrade",
+			deployed: newPackage("1.0.0", "sha256:a", "1", podinfo),
+			pkg:      newPackage("1.1.0", "sha256:b", "3", podinfo, redis),
+			expected: types.PackageDiff{
+				FromVersion:       "1.0.0",
+				ToVersion:         "1.1.0",
+				AddedComponents:   []string{"redis"},
+				RemovedComponents: []string{},
+				AddedImages:       []string{"docker.io/library/redis:7.2"},
+				RemovedImages:     []string{},
+				ChangedImages: []types.ImageDigestChange{
+					{Reference: "ghcr.io/stefanprodan/podinfo:6.4.0", FromDigest: "sha256:a", ToDigest: "sha256:b"},
+				},
+				AddedVariables:   []string{},
+				RemovedVariables: []string{},
+				ChangedVariables: []string{"REPLICAS"},
+			},
+		},
+		{
+			name:     "downgrade without digests",
+			deployed: newPackage("1.1.0", "sha256:b", "1", podinfo, redis),
+			pkg:      newPackage("1.0.0", "", "1", podinfo),
+			expected: types.PackageDiff{
+				FromVersion:       "1.1.0",
+				ToVersion:         "1.0.0",
+				Downgrade:         true,
+				AddedComponents:   []string{},
+				RemovedComponents: []string{"redis"},
+				AddedImages:       []string{},
+				RemovedImages:     []string{"docker.io/library/redis:7.2"},
+				AddedVariables:    []string{},
+				RemovedVariables:  []string{},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			diff := diffPackages(tt.deployed, tt.pkg)
+			require.Equal(t, tt.expected, diff)
+			require.Equal(t, tt.empty, diff.IsEmpty())
+		})
+	}
+}
@@ -0,0 +1,91 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package filters contains core implementations of the ComponentFilterStrategy interface.
+package filters
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/zarf-dev/zarf/src/api/v1alpha1"
+	"github.com/zarf-dev/zarf/src/types"
+)
+
+// ByCluster creates a new filter that filters components based on facts about the cluster. The facts are only looked up
+// when a component has cluster conditions so that packages without them can be filtered without a cluster connection.
+func ByCluster(getFacts func() (types.ClusterFacts, error)) ComponentFilterStrategy {
+	return &clusterFilter{getFacts: getFacts}
+}
+
+// clusterFilter filters components based on facts about the cluster.
+type clusterFilter struct {
+	getFacts func() (types.ClusterFacts, error)
+}
+
+// Apply applies the filter.
+func (f *clusterFilter) Apply(pkg v1alpha1.ZarfPackage) ([]v1alpha1.ZarfComponent, error) {
+	var facts *types.ClusterFacts
+	filtered := []v1alpha1.ZarfComponent{}
+	for _, component := range pkg.Components {
+		if !component.Only.Cluster.HasDeployConditions() {
+			filtered = append(filtered, component)
+			continue
+		}
+		if facts == nil {
+			clusterFacts, err := f.getFacts()
+			if err != nil {
+				return nil, fmt.Errorf("unable to get the cluster facts for component %s: %w", component.Name, err)
+			}
+			facts = &clusterFacts
+		}
+		ok, err := matchesCluster(component.Only.Cluster, *facts)
+		if err != nil {
+			return nil, fmt.Errorf("component %s: %w", component.Name, err)
+		}
+		if ok {
+			filtered = append(filtered, component)
+		}
+	}
+	return filtered, nil
+}
+
+// matchesCluster returns if the cluster facts satisfy all of the conditions.
+func matchesCluster(only v1alpha1.ZarfComponentOnlyCluster, facts types.ClusterFacts) (bool, error) {
+	if len(only.Distros) > 0 && !slices.Contains(only.Distros, facts.Distro) {
+		return false, nil
+	}
+	if only.Version != "" {
+		constraint, err := semver.NewConstraint(only.Version)
+		if err != nil {
+			return false, fmt.Errorf("invalid Kubernetes version constraint %s: %w", only.Version, err)
+		}
+		version, err := semver.NewVersion(facts.Version)
+		if err != nil {
+			return false, fmt.Errorf("unable to parse the Kubernetes version %s: %w", facts.Version, err)
+		}
+		// Distributions add pre-release and build suffixes to the version (e.g. v1.30.2-eks-1552ad0) which would
+		// otherwise never satisfy a constraint without a pre-release.
+		version = semver.New(version.Major(), version.Minor(), version.Patch(), "", "")
+		if !constraint.Check(version) {
+			return false, nil
+		}
+	}
+	if len(only.Providers) > 0 && !slices.ContainsFunc(facts.Providers, func(provider string) bool {
+		return slices.Contains(only.Providers, provider)
+	}) {
+		return false, nil
+	}
+	if len(only.NodeLabels) > 0 && !slices.ContainsFunc(facts.NodeLabels, func(labels map[string]string) bool {
+		for k, v := range only.NodeLabels {
+			if labels[k] != v {
+				return false
+			}
+		}
+		return true
+	}) {
+		return false, nil
+	}
+	return true, nil
+}
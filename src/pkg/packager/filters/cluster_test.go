@@ -0,0 +1,93 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package filters contains core implementations of the ComponentFilterStrategy interface.
+package filters
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/zarf-dev/zarf/src/api/v1alpha1"
+	"github.com/zarf-dev/zarf/src/types"
+)
+
+func TestClusterFilter(t *testing.T) {
+	t.Parallel()
+
+	pkg := v1alpha1.ZarfPackage{
+		Components: []v1alpha1.ZarfComponent{
+			{Name: "always", Only: v1alpha1.ZarfComponentOnlyTarget{Cluster: v1alpha1.ZarfComponentOnlyCluster{Architecture: "amd64"}}},
+			{Name: "k3s", Only: v1alpha1.ZarfComponentOnlyTarget{Cluster: v1alpha1.ZarfComponentOnlyCluster{Distros: []string{"k3s", "k3d"}}}},
+			{Name: "eks", Only: v1alpha1.ZarfComponentOnlyTarget{Cluster: v1alpha1.ZarfComponentOnlyCluster{Distros: []string{"eks"}}}},
+			{Name: "new", Only: v1alpha1.ZarfComponentOnlyTarget{Cluster: v1alpha1.ZarfComponentOnlyCluster{Version: ">= 1.30"}}},
+			{Name: "old", Only: v1alpha1.ZarfComponentOnlyTarget{Cluster: v1alpha1.ZarfComponentOnlyCluster{Version: "< 1.30"}}},
+			{Name: "aws", Only: v1alpha1.ZarfComponentOnlyTarget{Cluster: v1alpha1.ZarfComponentOnlyCluster{Providers: []string{"aws"}}}},
+			{Name: "gpu", Only: v1alpha1.ZarfComponentOnlyTarget{Cluster: v1alpha1.ZarfComponentOnlyCluster{NodeLabels: map[string]string{"gpu": "true"}}}},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		facts    types.ClusterFacts
+		expected []string
+	}{
+		{
+			name: "k3s",
+			facts: types.ClusterFacts{
+				Distro:     "k3s",
+				Version:    "v1.29.6+k3s2",
+				Providers:  []string{"k3s"},
+				NodeLabels: []map[string]string{{"node.kubernetes.io/instance-type": "k3s"}},
+			},
+			expected: []string{"always", "k3s", "old"},
+		},
+		{
+			name: "eks",
+			facts: types.ClusterFacts{
+				Distro:     "eks",
+				Version:    "v1.30.2-eks-1552ad0",
+				Providers:  []string{"aws"},
+				NodeLabels: []map[string]string{{"gpu": "false"}, {"gpu": "true", "zone": "a"}},
+			},
+			expected: []string{"always", "eks", "new", "aws", "gpu"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			filter := ByCluster(func() (types.ClusterFacts, error) {
+				return tt.facts, nil
+			})
+			result, err := filter.Apply(pkg)
+			require.NoError(t, err)
+			names := []string{}
+			for _, component := range result {
+				names = append(names, component.Name)
+			}
+			require.Equal(t, tt.expected, names)
+		})
+	}
+}
+
+func TestClusterFilterFacts(t *testing.T) {
+	t.Parallel()
+
+	getFacts := func() (types.ClusterFacts, error) {
+		return types.ClusterFacts{}, errors.New("no cluster")
+	}
+	pkg := v1alpha1.ZarfPackage{
+		Components: []v1alpha1.ZarfComponent{{Name: "always"}},
+	}
+	result, err := ByCluster(getFacts).Apply(pkg)
+	require.NoError(t, err)
+	require.Len(t, result, 1)
+
+	pkg.Components = append(pkg.Components, v1alpha1.ZarfComponent{
+		Name: "k3s",
+		Only: v1alpha1.ZarfComponentOnlyTarget{Cluster: v1alpha1.ZarfComponentOnlyCluster{Distros: []string{"k3s"}}},
+	})
+	_, err = ByCluster(getFacts).Apply(pkg)
+	require.EqualError(t, err, "unable to get the cluster facts for component k3s: no cluster")
+}
@@ -110,6 +110,20 @@ func componentMatrix(_ *testing.T) []v1alpha1.ZarfComponent {
 	return components
 }
 
+// groupPackage returns a package with a required component and a group of ingress components.
+func groupPackage(selection v1alpha1.ZarfComponentGroupSelection, withDefault bool) v1alpha1.ZarfPackage {
+	return v1alpha1.ZarfPackage{
+		Components: []v1alpha1.ZarfComponent{
+			{Name: "podinfo", Required: helpers.BoolPtr(true)},
+			{Name: "nginx", Default: withDefault},
+			{Name: "istio"},
+		},
+		Groups: []v1alpha1.ZarfComponentGroup{
+			{Name: "ingress", Components: []string{"nginx", "istio"}, Selection: selection},
+		},
+	}
+}
+
 func TestDeployFilter_Apply(t *testing.T) {
 	possibilities := componentMatrix(t)
 
@@ -182,6 +196,48 @@ func TestDeployFilter_Apply(t *testing.T) {
 			optionalComponents: strings.Join([]string{"group=foo && default=false", "group=foo && default=true"}, ","),
 			expectedErr:        ErrMultipleSameGroup,
 		},
+		"Test exactly one group without a selection deploys the default": {
+			pkg: groupPackage(v1alpha1.ExactlyOneSelection, true),
+			want: []v1alpha1.ZarfComponent{
+				{Name: "podinfo", Required: helpers.BoolPtr(true)},
+				{Name: "nginx", Default: true},
+			},
+		},
+		"Test exactly one group with a selection": {
+			pkg:                groupPackage(v1alpha1.ExactlyOneSelection, true),
+			optionalComponents: "istio",
+			want: []v1alpha1.ZarfComponent{
+				{Name: "podinfo", Required: helpers.BoolPtr(true)},
+				{Name: "istio"},
+			},
+		},
+		"Test failing when exactly one group has no default and no selection was made": {
+			pkg:         groupPackage(v1alpha1.ExactlyOneSelection, false),
+			expectedErr: ErrNoDefaultOrSelection,
+		},
+		"Test failing when exactly one group has its default excluded": {
+			pkg:                groupPackage(v1alpha1.ExactlyOneSelection, true),
+			optionalComponents: "-nginx",
+			expectedErr:        ErrNoDefaultOrSelection,
+		},
+		"Test failing when multiple are selected from an at most one group": {
+			pkg:                groupPackage(v1alpha1.AtMostOneSelection, false),
+			optionalComponents: "nginx,istio",
+			expectedErr:        ErrMultipleSameGroup,
+		},
+		"Test at most one group without a default or selection": {
+			pkg: groupPackage(v1alpha1.AtMostOneSelection, false),
+			want: []v1alpha1.ZarfComponent{
+				{Name: "podinfo", Required: helpers.BoolPtr(true)},
+			},
+		},
+		"Test at most one group with its default excluded": {
+			pkg:                groupPackage(v1alpha1.AtMostOneSelection, true),
+			optionalComponents: "-nginx",
+			want: []v1alpha1.ZarfComponent{
+				{Name: "podinfo", Required: helpers.BoolPtr(true)},
+			},
+		},
 		"Test failing when no components are found that match the query": {
 			pkg: v1alpha1.ZarfPackage{
 				Build: v1alpha1.ZarfBuildData{
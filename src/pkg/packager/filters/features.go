@@ -0,0 +1,39 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package filters contains core implementations of the ComponentFilterStrategy interface.
+package filters
+
+import (
+	"slices"
+
+	"github.com/zarf-dev/zarf/src/api/v1alpha1"
+)
+
+// ByFeatures creates a new filter that filters components based on the enabled feature flags.
+func ByFeatures(features []string) ComponentFilterStrategy {
+	return &featuresFilter{features}
+}
+
+// featuresFilter filters components based on the enabled feature flags.
+type featuresFilter struct {
+	features []string
+}
+
+// Apply applies the filter.
+func (f *featuresFilter) Apply(pkg v1alpha1.ZarfPackage) ([]v1alpha1.ZarfComponent, error) {
+	filtered := []v1alpha1.ZarfComponent{}
+	for _, component := range pkg.Components {
+		enabled := true
+		for _, feature := range component.Only.Features {
+			if !slices.Contains(f.features, feature) {
+				enabled = false
+				break
+			}
+		}
+		if enabled {
+			filtered = append(filtered, component)
+		}
+	}
+	return filtered, nil
+}
@@ -0,0 +1,57 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package filters contains core implementations of the ComponentFilterStrategy interface.
+package filters
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/zarf-dev/zarf/src/api/v1alpha1"
+)
+
+func TestFeaturesFilter(t *testing.T) {
+	t.Parallel()
+
+	pkg := v1alpha1.ZarfPackage{
+		Components: []v1alpha1.ZarfComponent{
+			{Name: "always"},
+			{Name: "gpu", Only: v1alpha1.ZarfComponentOnlyTarget{Features: []string{"gpu"}}},
+			{Name: "gpu-monitoring", Only: v1alpha1.ZarfComponentOnlyTarget{Features: []string{"gpu", "monitoring"}}},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		features []string
+		expected []string
+	}{
+		{
+			name:     "no features",
+			expected: []string{"always"},
+		},
+		{
+			name:     "one feature",
+			features: []string{"gpu"},
+			expected: []string{"always", "gpu"},
+		},
+		{
+			name:     "all features",
+			features: []string{"monitoring", "gpu"},
+			expected: []string{"always", "gpu", "gpu-monitoring"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			result, err := ByFeatures(tt.features).Apply(pkg)
+			require.NoError(t, err)
+			names := []string{}
+			for _, component := range result {
+				names = append(names, component.Name)
+			}
+			require.Equal(t, tt.expected, names)
+		})
+	}
+}
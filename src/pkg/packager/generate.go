@@ -7,6 +7,7 @@ package packager
 import (
 	"context"
 	"fmt"
+	"io/fs"
 	"os"
 	"path/filepath"
 	"strings"
@@ -14,12 +15,17 @@ import (
 
 	"github.com/defenseunicorns/pkg/helpers/v2"
 	goyaml "github.com/goccy/go-yaml"
+	"helm.sh/helm/v3/pkg/chart/loader"
+	"sigs.k8s.io/kustomize/api/konfig"
+
 	"github.com/zarf-dev/zarf/src/api/v1alpha1"
 	"github.com/zarf-dev/zarf/src/config"
+	"github.com/zarf-dev/zarf/src/pkg/cluster"
 	"github.com/zarf-dev/zarf/src/pkg/layout"
 	"github.com/zarf-dev/zarf/src/pkg/lint"
 	"github.com/zarf-dev/zarf/src/pkg/logger"
 	"github.com/zarf-dev/zarf/src/pkg/message"
+	"github.com/zarf-dev/zarf/src/pkg/utils"
 )
 
 // Generate generates a Zarf package definition.
@@ -58,12 +64,46 @@ func (p *Packager) Generate(ctx context.Context) error {
 			},
 		},
 	}
+	version := p.cfg.GenerateOpts.Version
+	fromCompose := p.cfg.GenerateOpts.Path != "" && isComposeFile(p.cfg.GenerateOpts.Path)
+	switch {
+	case p.cfg.GenerateOpts.Namespace != "":
+		if err := helpers.CreateDirectory(p.cfg.GenerateOpts.Output, helpers.ReadExecuteAllWriteUser); err != nil {
+			return err
+		}
+		c, err := cluster.NewClusterWithWait(ctx)
+		if err != nil {
+			return err
+		}
+		generatedComponent, err = namespaceComponent(ctx, c, p.cfg.GenerateOpts.Name, p.cfg.GenerateOpts.Namespace, p.cfg.GenerateOpts.Output)
+		if err != nil {
+			return err
+		}
+	case fromCompose:
+		if err := helpers.CreateDirectory(p.cfg.GenerateOpts.Output, helpers.ReadExecuteAllWriteUser); err != nil {
+			return err
+		}
+		var err error
+		generatedComponent, err = composeComponent(ctx, p.cfg.GenerateOpts.Name, p.cfg.GenerateOpts.Path, p.cfg.GenerateOpts.Output)
+		if err != nil {
+			return err
+		}
+	case p.cfg.GenerateOpts.Path != "":
+		var err error
+		generatedComponent, err = localComponent(p.cfg.GenerateOpts.Name, p.cfg.GenerateOpts.Path)
+		if err != nil {
+			return err
+		}
+		if version == "" && len(generatedComponent.Charts) == 1 {
+			version = generatedComponent.Charts[0].Version
+		}
+	}
 
 	p.cfg.Pkg = v1alpha1.ZarfPackage{
 		Kind: v1alpha1.ZarfPackageConfig,
 		Metadata: v1alpha1.ZarfMetadata{
 			Name:        p.cfg.GenerateOpts.Name,
-			Version:     p.cfg.GenerateOpts.Version,
+			Version:     version,
 			Description: "auto-generated using `zarf dev generate`",
 		},
 		Components: []v1alpha1.ZarfComponent{
@@ -71,16 +111,19 @@ func (p *Packager) Generate(ctx context.Context) error {
 		},
 	}
 
-	images, err := p.findImages(ctx)
-	if err != nil {
-		// purposefully not returning error here, as we can still generate the package without images
-		message.Warnf("Unable to find images: %s", err.Error())
-		l.Error("failed to find images", "error", err.Error())
-	}
+	// Images of a namespace or compose file are taken from its workloads or services instead of rendering what was generated.
+	if p.cfg.GenerateOpts.Namespace == "" && !fromCompose {
+		images, err := p.findImages(ctx)
+		if err != nil {
+			// purposefully not returning error here, as we can still generate the package without images
+			message.Warnf("Unable to find images: %s", err.Error())
+			l.Error("failed to find images", "error", err.Error())
+		}
 
-	for i := range p.cfg.Pkg.Components {
-		name := p.cfg.Pkg.Components[i].Name
-		p.cfg.Pkg.Components[i].Images = images[name]
+		for i := range p.cfg.Pkg.Components {
+			name := p.cfg.Pkg.Components[i].Name
+			p.cfg.Pkg.Components[i].Images = images[name]
+		}
 	}
 
 	if err := lint.ValidatePackage(p.cfg.Pkg); err != nil {
@@ -91,6 +134,16 @@ func (p *Packager) Generate(ctx context.Context) error {
 		return err
 	}
 
+	// Local paths are found relative to the working directory but are relative to the zarf.yaml in the package.
+	if p.cfg.GenerateOpts.Path != "" && !fromCompose {
+		for i := range p.cfg.Pkg.Components {
+			err := relativeComponentPaths(&p.cfg.Pkg.Components[i], p.cfg.GenerateOpts.Output)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
 	b, err := goyaml.MarshalWithOptions(p.cfg.Pkg, goyaml.IndentSequence(true), goyaml.UseSingleQuote(false))
 	if err != nil {
 		return err
@@ -109,3 +162,176 @@ func (p *Packager) Generate(ctx context.Context) error {
 
 	return os.WriteFile(generatedZarfYAMLPath, []byte(content), helpers.ReadAllWriteUser)
 }
+
+// localComponent introspects a local Helm chart, kustomization or directory of manifests and returns a component that
+// deploys it. Directories that are not a chart or kustomization are searched for charts, kustomizations and manifests.
+func localComponent(name, path string) (v1alpha1.ZarfComponent, error) {
+	component := v1alpha1.ZarfComponent{
+		Name:     name,
+		Required: helpers.BoolPtr(true),
+	}
+	fi, err := os.Stat(path)
+	if err != nil {
+		return v1alpha1.ZarfComponent{}, err
+	}
+	if !fi.IsDir() {
+		switch {
+		case isChartArchive(path):
+			chart, err := localChart(name, path)
+			if err != nil {
+				return v1alpha1.ZarfComponent{}, err
+			}
+			component.Charts = append(component.Charts, chart)
+		case isManifest(path):
+			component.Manifests = append(component.Manifests, v1alpha1.ZarfManifest{Name: name, Namespace: name, Files: []string{path}})
+		default:
+			return v1alpha1.ZarfComponent{}, fmt.Errorf("%s is not a Helm chart, kustomization or manifest", path)
+		}
+		return component, nil
+	}
+
+	files := []string{}
+	err = filepath.WalkDir(path, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if p != path && strings.HasPrefix(d.Name(), ".") {
+				return filepath.SkipDir
+			}
+			if !helpers.InvalidPath(filepath.Join(p, "Chart.yaml")) {
+				chart, err := localChart(name, p)
+				if err != nil {
+					return err
+				}
+				component.Charts = append(component.Charts, chart)
+				return filepath.SkipDir
+			}
+			if hasKustomization(p) {
+				manifestName := name
+				if p != path {
+					manifestName = fmt.Sprintf("%s-%s", name, d.Name())
+				}
+				component.Manifests = append(component.Manifests, v1alpha1.ZarfManifest{Name: manifestName, Namespace: name, Kustomizations: []string{p}})
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if isChartArchive(p) {
+			chart, err := localChart(name, p)
+			if err != nil {
+				return err
+			}
+			component.Charts = append(component.Charts, chart)
+			return nil
+		}
+		if isManifest(p) {
+			files = append(files, p)
+		}
+		return nil
+	})
+	if err != nil {
+		return v1alpha1.ZarfComponent{}, err
+	}
+	if len(files) > 0 {
+		component.Manifests = append(component.Manifests, v1alpha1.ZarfManifest{Name: name, Namespace: name, Files: files})
+	}
+	if len(component.Charts) == 0 && len(component.Manifests) == 0 {
+		return v1alpha1.ZarfComponent{}, fmt.Errorf("no Helm charts, kustomizations or manifests found in %s", path)
+	}
+	return component, nil
+}
+
+// localChart returns the chart for a chart directory or archive using the name and version from its Chart.yaml.
+func localChart(namespace, path string) (v1alpha1.ZarfChart, error) {
+	chart, err := loader.Load(path)
+	if err != nil {
+		return v1alpha1.ZarfChart{}, fmt.Errorf("unable to load the Helm chart %s: %w", path, err)
+	}
+	return v1alpha1.ZarfChart{
+		Name:      chart.Metadata.Name,
+		Version:   chart.Metadata.Version,
+		Namespace: namespace,
+		LocalPath: path,
+	}, nil
+}
+
+// hasKustomization returns whether the directory contains a kustomization file.
+func hasKustomization(dir string) bool {
+	for _, name := range konfig.RecognizedKustomizationFileNames() {
+		if !helpers.InvalidPath(filepath.Join(dir, name)) {
+			return true
+		}
+	}
+	return false
+}
+
+// isChartArchive returns whether the file is a packaged Helm chart.
+func isChartArchive(path string) bool {
+	return strings.HasSuffix(path, ".tgz") || strings.HasSuffix(path, ".tar.gz")
+}
+
+// isManifest returns whether the file is YAML that contains Kubernetes resources.
+func isManifest(path string) bool {
+	if ext := filepath.Ext(path); ext != ".yaml" && ext != ".yml" {
+		return false
+	}
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return false
+	}
+	objs, err := utils.SplitYAML(b)
+	if err != nil || len(objs) == 0 {
+		return false
+	}
+	for _, obj := range objs {
+		if obj.GetAPIVersion() == "" || obj.GetKind() == "" {
+			return false
+		}
+	}
+	return true
+}
+
+// relativeComponentPaths rewrites the local paths of the component to be relative to the directory of the zarf.yaml.
+func relativeComponentPaths(component *v1alpha1.ZarfComponent, dir string) error {
+	rel := func(path string) (string, error) {
+		absDir, err := filepath.Abs(dir)
+		if err != nil {
+			return "", err
+		}
+		absPath, err := filepath.Abs(path)
+		if err != nil {
+			return "", err
+		}
+		relPath, err := filepath.Rel(absDir, absPath)
+		if err != nil {
+			return "", err
+		}
+		return filepath.ToSlash(relPath), nil
+	}
+	var err error
+	for i, chart := range component.Charts {
+		if chart.LocalPath == "" {
+			continue
+		}
+		component.Charts[i].LocalPath, err = rel(chart.LocalPath)
+		if err != nil {
+			return err
+		}
+	}
+	for i, manifest := range component.Manifests {
+		for j, f := range manifest.Files {
+			component.Manifests[i].Files[j], err = rel(f)
+			if err != nil {
+				return err
+			}
+		}
+		for j, k := range manifest.Kustomizations {
+			component.Manifests[i].Kustomizations[j], err = rel(k)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
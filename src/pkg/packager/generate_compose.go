@@ -0,0 +1,455 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package packager
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/defenseunicorns/pkg/helpers/v2"
+	"github.com/google/shlex"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/yaml"
+
+	"github.com/zarf-dev/zarf/src/api/v1alpha1"
+	"github.com/zarf-dev/zarf/src/pkg/logger"
+)
+
+// composeServiceLabel is set on the workloads generated from a compose service and selects their pods.
+const composeServiceLabel = "zarf.dev/compose-service"
+
+// composeVolumeSize is the size requested by the persistent volume claims generated from named volumes.
+const composeVolumeSize = "1Gi"
+
+// composeFile is the subset of the Compose specification that is translated to Kubernetes resources.
+type composeFile struct {
+	Services map[string]composeService `json:"services"`
+}
+
+// composeService is a service of a compose file. Fields that allow more than one syntax are decoded when translated.
+type composeService struct {
+	Image       string `json:"image"`
+	Command     any    `json:"command"`
+	Entrypoint  any    `json:"entrypoint"`
+	Environment any    `json:"environment"`
+	EnvFile     any    `json:"env_file"`
+	Ports       []any  `json:"ports"`
+	Expose      []any  `json:"expose"`
+	Volumes     []any  `json:"volumes"`
+	WorkingDir  string `json:"working_dir"`
+	Deploy      *struct {
+		Replicas *int32 `json:"replicas"`
+	} `json:"deploy"`
+}
+
+// isComposeFile returns whether the file is a compose file with services.
+func isComposeFile(path string) bool {
+	if ext := filepath.Ext(path); ext != ".yaml" && ext != ".yml" {
+		return false
+	}
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return false
+	}
+	content := map[string]any{}
+	if err := yaml.Unmarshal(b, &content); err != nil {
+		return false
+	}
+	if _, ok := content["kind"]; ok {
+		return false
+	}
+	services, ok := content["services"].(map[string]any)
+	return ok && len(services) > 0
+}
+
+// composeComponent translates the services of a compose file into a Deployment per service, a Service for the ports
+// they publish or expose and a persistent volume claim per named volume. The manifests are written to the output
+// directory and referenced relative to it.
+func composeComponent(ctx context.Context, name, path, outputDir string) (v1alpha1.ZarfComponent, error) {
+	l := logger.From(ctx)
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return v1alpha1.ZarfComponent{}, err
+	}
+	compose := composeFile{}
+	if err := yaml.Unmarshal(b, &compose); err != nil {
+		return v1alpha1.ZarfComponent{}, fmt.Errorf("unable to parse the compose file %s: %w", path, err)
+	}
+	objs, images, warnings, err := composeResources(compose)
+	if err != nil {
+		return v1alpha1.ZarfComponent{}, err
+	}
+	for _, warning := range warnings {
+		l.Warn(warning)
+	}
+
+	files := []string{}
+	for _, obj := range objs {
+		for _, field := range capturedFieldsToRemove {
+			unstructured.RemoveNestedField(obj.Object, field...)
+		}
+		b, err := yaml.Marshal(obj.Object)
+		if err != nil {
+			return v1alpha1.ZarfComponent{}, err
+		}
+		manifestPath := filepath.Join("manifests", fmt.Sprintf("%s-%s.yaml", strings.ToLower(obj.GetKind()), obj.GetName()))
+		if err := writeCapturedFile(filepath.Join(outputDir, manifestPath), b); err != nil {
+			return v1alpha1.ZarfComponent{}, err
+		}
+		files = append(files, filepath.ToSlash(manifestPath))
+	}
+	l.Info("translated compose file", "path", path, "services", len(compose.Services), "images", len(images))
+
+	return v1alpha1.ZarfComponent{
+		Name:      name,
+		Required:  helpers.BoolPtr(true),
+		Manifests: []v1alpha1.ZarfManifest{{Name: name, Namespace: name, Files: files}},
+		Images:    images,
+	}, nil
+}
+
+// composeResources returns the resources and images of the compose services and warnings for the parts of the
+// services that can not be translated.
+func composeResources(compose composeFile) ([]*unstructured.Unstructured, []string, []string, error) {
+	objs := []*unstructured.Unstructured{}
+	images := []string{}
+	warnings := []string{}
+	add := func(apiVersion, kind string, obj runtime.Object) error {
+		content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+		if err != nil {
+			return err
+		}
+		u := &unstructured.Unstructured{Object: content}
+		u.SetAPIVersion(apiVersion)
+		u.SetKind(kind)
+		objs = append(objs, u)
+		return nil
+	}
+
+	names := []string{}
+	for serviceName := range compose.Services {
+		names = append(names, serviceName)
+	}
+	slices.Sort(names)
+	volumes := map[string]bool{}
+	for _, composeServiceName := range names {
+		svc := compose.Services[composeServiceName]
+		serviceName := composeName(composeServiceName)
+		if svc.Image == "" {
+			return nil, nil, nil, fmt.Errorf("service %s has no image, images built by compose must be pushed and set as the image of the service", serviceName)
+		}
+		if !slices.Contains(images, svc.Image) {
+			images = append(images, svc.Image)
+		}
+		if svc.EnvFile != nil {
+			warnings = append(warnings, fmt.Sprintf("the env_file of service %s is not translated, add its variables to the environment of the service", serviceName))
+		}
+
+		container := corev1.Container{
+			Name:       serviceName,
+			Image:      svc.Image,
+			WorkingDir: svc.WorkingDir,
+		}
+		var err error
+		container.Command, err = composeCommand(svc.Entrypoint)
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("invalid entrypoint of service %s: %w", serviceName, err)
+		}
+		container.Args, err = composeCommand(svc.Command)
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("invalid command of service %s: %w", serviceName, err)
+		}
+		env, skipped, err := composeEnvironment(svc.Environment)
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("invalid environment of service %s: %w", serviceName, err)
+		}
+		container.Env = env
+		for _, key := range skipped {
+			warnings = append(warnings, fmt.Sprintf("the variable %s of service %s has no value and is taken from the shell by compose, it is not translated", key, serviceName))
+		}
+
+		ports, err := composePorts(svc.Ports, svc.Expose)
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("invalid ports of service %s: %w", serviceName, err)
+		}
+		for _, port := range ports {
+			containerPort := corev1.ContainerPort{ContainerPort: port.TargetPort.IntVal, Protocol: port.Protocol}
+			if !slices.Contains(container.Ports, containerPort) {
+				container.Ports = append(container.Ports, containerPort)
+			}
+		}
+
+		podVolumes := []corev1.Volume{}
+		for i, v := range svc.Volumes {
+			mount, err := parseComposeVolume(v)
+			if err != nil {
+				return nil, nil, nil, fmt.Errorf("invalid volume of service %s: %w", serviceName, err)
+			}
+			volumeName := fmt.Sprintf("%s-%d", serviceName, i)
+			var source corev1.VolumeSource
+			switch mount.Type {
+			case "volume":
+				if mount.Source == "" {
+					source.EmptyDir = &corev1.EmptyDirVolumeSource{}
+					break
+				}
+				volumeName = composeName(mount.Source)
+				source.PersistentVolumeClaim = &corev1.PersistentVolumeClaimVolumeSource{ClaimName: volumeName}
+				volumes[volumeName] = true
+			case "tmpfs":
+				source.EmptyDir = &corev1.EmptyDirVolumeSource{Medium: corev1.StorageMediumMemory}
+			default:
+				warnings = append(warnings, fmt.Sprintf("the %s mount of %s into service %s is not translated, package its content as a manifest or data injection", mount.Type, mount.Source, serviceName))
+				continue
+			}
+			podVolumes = append(podVolumes, corev1.Volume{Name: volumeName, VolumeSource: source})
+			container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{Name: volumeName, MountPath: mount.Target, ReadOnly: mount.ReadOnly})
+		}
+
+		labels := map[string]string{composeServiceLabel: serviceName}
+		deployment := &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: serviceName, Labels: labels},
+			Spec: appsv1.DeploymentSpec{
+				Selector: &metav1.LabelSelector{MatchLabels: labels},
+				Template: corev1.PodTemplateSpec{
+					ObjectMeta: metav1.ObjectMeta{Labels: labels},
+					Spec: corev1.PodSpec{
+						Containers: []corev1.Container{container},
+						Volumes:    podVolumes,
+					},
+				},
+			},
+		}
+		if svc.Deploy != nil {
+			deployment.Spec.Replicas = svc.Deploy.Replicas
+		}
+		if err := add("apps/v1", "Deployment", deployment); err != nil {
+			return nil, nil, nil, err
+		}
+		// Compose services reach each other by service name, which the Service provides in the cluster.
+		if len(ports) > 0 {
+			service := &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{Name: serviceName, Labels: labels},
+				Spec: corev1.ServiceSpec{
+					Selector: labels,
+					Ports:    ports,
+				},
+			}
+			if err := add("v1", "Service", service); err != nil {
+				return nil, nil, nil, err
+			}
+		}
+	}
+
+	volumeNames := []string{}
+	for volumeName := range volumes {
+		volumeNames = append(volumeNames, volumeName)
+	}
+	slices.Sort(volumeNames)
+	for _, volumeName := range volumeNames {
+		pvc := &corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{Name: volumeName},
+			Spec: corev1.PersistentVolumeClaimSpec{
+				AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+				Resources: corev1.VolumeResourceRequirements{
+					Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse(composeVolumeSize)},
+				},
+			},
+		}
+		if err := add("v1", "PersistentVolumeClaim", pvc); err != nil {
+			return nil, nil, nil, err
+		}
+	}
+	return objs, images, warnings, nil
+}
+
+// composeName returns the name of a compose service or volume as a valid Kubernetes name.
+func composeName(name string) string {
+	return strings.ReplaceAll(strings.ToLower(name), "_", "-")
+}
+
+// composeCommand returns the arguments of a command or entrypoint, which is either a list or a string that is split
+// the way a shell would.
+func composeCommand(v any) ([]string, error) {
+	switch c := v.(type) {
+	case nil:
+		return nil, nil
+	case string:
+		return shlex.Split(c)
+	case []any:
+		args := []string{}
+		for _, arg := range c {
+			args = append(args, fmt.Sprint(arg))
+		}
+		return args, nil
+	default:
+		return nil, fmt.Errorf("expected a string or list, got %T", v)
+	}
+}
+
+// composeEnvironment returns the variables of an environment, which is either a map or a list of KEY=VALUE. It also
+// returns the variables without a value, which compose takes from the shell it runs in.
+func composeEnvironment(v any) ([]corev1.EnvVar, []string, error) {
+	env := []corev1.EnvVar{}
+	skipped := []string{}
+	switch e := v.(type) {
+	case nil:
+		return nil, nil, nil
+	case map[string]any:
+		for key, value := range e {
+			if value == nil {
+				skipped = append(skipped, key)
+				continue
+			}
+			env = append(env, corev1.EnvVar{Name: key, Value: fmt.Sprint(value)})
+		}
+	case []any:
+		for _, item := range e {
+			key, value, ok := strings.Cut(fmt.Sprint(item), "=")
+			if !ok {
+				skipped = append(skipped, key)
+				continue
+			}
+			env = append(env, corev1.EnvVar{Name: key, Value: value})
+		}
+	default:
+		return nil, nil, fmt.Errorf("expected a map or list, got %T", v)
+	}
+	slices.SortFunc(env, func(a, b corev1.EnvVar) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	slices.Sort(skipped)
+	return env, skipped, nil
+}
+
+// composePorts returns the service ports of the published and exposed ports. Published ports are served on the port
+// they are published on and exposed ports on the container port.
+func composePorts(published, exposed []any) ([]corev1.ServicePort, error) {
+	ports := []corev1.ServicePort{}
+	add := func(port, target int32, protocol string) {
+		protocol = strings.ToUpper(protocol)
+		if protocol == "" {
+			protocol = string(corev1.ProtocolTCP)
+		}
+		for _, p := range ports {
+			if p.Port == port && string(p.Protocol) == protocol {
+				return
+			}
+		}
+		ports = append(ports, corev1.ServicePort{
+			Name:       fmt.Sprintf("%d-%s", port, strings.ToLower(protocol)),
+			Port:       port,
+			TargetPort: intstr.FromInt32(target),
+			Protocol:   corev1.Protocol(protocol),
+		})
+	}
+	for _, v := range published {
+		switch p := v.(type) {
+		case map[string]any:
+			target, err := parseComposePort(p["target"])
+			if err != nil {
+				return nil, err
+			}
+			port := target
+			if p["published"] != nil {
+				port, err = parseComposePort(p["published"])
+				if err != nil {
+					return nil, err
+				}
+			}
+			protocol, _ := p["protocol"].(string)
+			add(port, target, protocol)
+		default:
+			// [HOST_IP:][HOST_PORT:]CONTAINER_PORT[/PROTOCOL]
+			spec, protocol, _ := strings.Cut(fmt.Sprint(p), "/")
+			parts := strings.Split(spec, ":")
+			target, err := parseComposePort(parts[len(parts)-1])
+			if err != nil {
+				return nil, err
+			}
+			port := target
+			if len(parts) > 1 && parts[len(parts)-2] != "" {
+				port, err = parseComposePort(parts[len(parts)-2])
+				if err != nil {
+					return nil, err
+				}
+			}
+			add(port, target, protocol)
+		}
+	}
+	for _, v := range exposed {
+		spec, protocol, _ := strings.Cut(fmt.Sprint(v), "/")
+		target, err := parseComposePort(spec)
+		if err != nil {
+			return nil, err
+		}
+		add(target, target, protocol)
+	}
+	return ports, nil
+}
+
+// parseComposePort parses a single port, port ranges are not supported.
+func parseComposePort(v any) (int32, error) {
+	s := fmt.Sprint(v)
+	port, err := strconv.ParseInt(s, 10, 32)
+	if err != nil || port < 1 || port > 65535 {
+		return 0, fmt.Errorf("%q is not a port, port ranges are not supported", s)
+	}
+	return int32(port), nil
+}
+
+// composeMount is a volume of a compose service.
+type composeMount struct {
+	// Type is volume, bind or tmpfs.
+	Type     string
+	Source   string
+	Target   string
+	ReadOnly bool
+}
+
+// parseComposeVolume parses the short syntax SOURCE:TARGET[:MODE] or the long syntax of a volume. A short volume
+// is a bind mount when its source is a path and an anonymous volume when it has no source.
+func parseComposeVolume(v any) (composeMount, error) {
+	if m, ok := v.(map[string]any); ok {
+		mount := composeMount{}
+		mount.Type, _ = m["type"].(string)
+		mount.Source, _ = m["source"].(string)
+		mount.Target, _ = m["target"].(string)
+		mount.ReadOnly, _ = m["read_only"].(bool)
+		if mount.Type == "" {
+			mount.Type = "volume"
+		}
+		if mount.Target == "" {
+			return composeMount{}, fmt.Errorf("volume %v has no target", v)
+		}
+		return mount, nil
+	}
+	parts := strings.Split(fmt.Sprint(v), ":")
+	switch len(parts) {
+	case 1:
+		return composeMount{Type: "volume", Target: parts[0]}, nil
+	case 2, 3:
+		mount := composeMount{Type: "volume", Source: parts[0], Target: parts[1]}
+		if len(parts) == 3 {
+			mount.ReadOnly = slices.Contains(strings.Split(parts[2], ","), "ro")
+		}
+		if strings.HasPrefix(mount.Source, "/") || strings.HasPrefix(mount.Source, ".") || strings.HasPrefix(mount.Source, "~") {
+			mount.Type = "bind"
+		}
+		return mount, nil
+	default:
+		return composeMount{}, fmt.Errorf("%q is not a volume", fmt.Sprint(v))
+	}
+}
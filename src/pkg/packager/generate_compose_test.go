@@ -0,0 +1,137 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package packager
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/defenseunicorns/pkg/helpers/v2"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/yaml"
+
+	"github.com/zarf-dev/zarf/src/api/v1alpha1"
+	"github.com/zarf-dev/zarf/src/test/testutil"
+)
+
+func TestComposeComponent(t *testing.T) {
+	t.Parallel()
+	ctx := testutil.TestContext(t)
+
+	composePath := filepath.Join("testdata", "generate", "compose", "compose.yaml")
+	require.True(t, isComposeFile(composePath))
+	require.False(t, isComposeFile(filepath.Join("testdata", "generate", "app", "service.yaml")))
+
+	outputDir := t.TempDir()
+	component, err := composeComponent(ctx, "app", composePath, outputDir)
+	require.NoError(t, err)
+	expected := v1alpha1.ZarfComponent{
+		Name:     "app",
+		Required: helpers.BoolPtr(true),
+		Manifests: []v1alpha1.ZarfManifest{
+			{
+				Name:      "app",
+				Namespace: "app",
+				Files: []string{
+					"manifests/deployment-cache.yaml",
+					"manifests/service-cache.yaml",
+					"manifests/deployment-web.yaml",
+					"manifests/service-web.yaml",
+					"manifests/persistentvolumeclaim-cache-data.yaml",
+				},
+			},
+		},
+		Images: []string{"redis:7.2", "ghcr.io/stefanprodan/podinfo:6.4.0"},
+	}
+	require.Equal(t, expected, component)
+
+	b, err := os.ReadFile(filepath.Join(outputDir, "manifests", "deployment-web.yaml"))
+	require.NoError(t, err)
+	web := appsv1.Deployment{}
+	err = yaml.Unmarshal(b, &web)
+	require.NoError(t, err)
+	container := web.Spec.Template.Spec.Containers[0]
+	require.Equal(t, []string{"./podinfo", "--port", "9898", "--level", "info"}, container.Args)
+	require.Equal(t, []corev1.EnvVar{{Name: "PODINFO_UI_MESSAGE", Value: "hello"}}, container.Env)
+	require.Equal(t, []corev1.ContainerPort{{ContainerPort: 9898, Protocol: corev1.ProtocolTCP}}, container.Ports)
+
+	b, err = os.ReadFile(filepath.Join(outputDir, "manifests", "service-web.yaml"))
+	require.NoError(t, err)
+	svc := corev1.Service{}
+	err = yaml.Unmarshal(b, &svc)
+	require.NoError(t, err)
+	require.Equal(t, []corev1.ServicePort{{Name: "8080-tcp", Port: 8080, TargetPort: intstr.FromInt32(9898), Protocol: corev1.ProtocolTCP}}, svc.Spec.Ports)
+	require.Equal(t, map[string]string{composeServiceLabel: "web"}, svc.Spec.Selector)
+
+	// The bind mount is skipped and the named volume is claimed.
+	b, err = os.ReadFile(filepath.Join(outputDir, "manifests", "deployment-cache.yaml"))
+	require.NoError(t, err)
+	cache := appsv1.Deployment{}
+	err = yaml.Unmarshal(b, &cache)
+	require.NoError(t, err)
+	require.Equal(t, int32(2), *cache.Spec.Replicas)
+	require.Equal(t, []corev1.Volume{{Name: "cache-data", VolumeSource: corev1.VolumeSource{PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "cache-data"}}}}, cache.Spec.Template.Spec.Volumes)
+	require.Equal(t, []corev1.VolumeMount{{Name: "cache-data", MountPath: "/data"}}, cache.Spec.Template.Spec.Containers[0].VolumeMounts)
+
+	// Manifests are not overwritten.
+	_, err = composeComponent(ctx, "app", composePath, outputDir)
+	require.ErrorContains(t, err, "already exists")
+}
+
+func TestComposeResourcesErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		service     composeService
+		expectedErr string
+	}{
+		{
+			name:        "no image",
+			service:     composeService{},
+			expectedErr: "service app has no image",
+		},
+		{
+			name:        "port range",
+			service:     composeService{Image: "nginx", Ports: []any{"8000-8010:8000-8010"}},
+			expectedErr: "port ranges are not supported",
+		},
+		{
+			name:        "invalid command",
+			service:     composeService{Image: "nginx", Command: map[string]any{}},
+			expectedErr: "invalid command of service app",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, _, _, err := composeResources(composeFile{Services: map[string]composeService{"app": tt.service}})
+			require.ErrorContains(t, err, tt.expectedErr)
+		})
+	}
+}
+
+func TestParseComposeVolume(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		volume   any
+		expected composeMount
+	}{
+		{volume: "/data", expected: composeMount{Type: "volume", Target: "/data"}},
+		{volume: "data:/data", expected: composeMount{Type: "volume", Source: "data", Target: "/data"}},
+		{volume: "./conf:/etc/conf:ro", expected: composeMount{Type: "bind", Source: "./conf", Target: "/etc/conf", ReadOnly: true}},
+		{volume: map[string]any{"type": "tmpfs", "target": "/tmp"}, expected: composeMount{Type: "tmpfs", Target: "/tmp"}},
+	}
+	for _, tt := range tests {
+		mount, err := parseComposeVolume(tt.volume)
+		require.NoError(t, err)
+		require.Equal(t, tt.expected, mount)
+	}
+}
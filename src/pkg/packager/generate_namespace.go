@@ -0,0 +1,297 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package packager
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/defenseunicorns/pkg/helpers/v2"
+	"helm.sh/helm/v3/pkg/chartutil"
+	"helm.sh/helm/v3/pkg/release"
+	"helm.sh/helm/v3/pkg/storage"
+	"helm.sh/helm/v3/pkg/storage/driver"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/yaml"
+
+	"github.com/zarf-dev/zarf/src/api/v1alpha1"
+	"github.com/zarf-dev/zarf/src/pkg/cluster"
+	"github.com/zarf-dev/zarf/src/pkg/logger"
+)
+
+// helmReleaseAnnotation is set by Helm on every resource of a release.
+const helmReleaseAnnotation = "meta.helm.sh/release-name"
+
+// capturedFieldsToRemove are the fields set by the cluster that are removed from captured resources.
+var capturedFieldsToRemove = [][]string{
+	{"status"},
+	{"metadata", "namespace"},
+	{"metadata", "uid"},
+	{"metadata", "resourceVersion"},
+	{"metadata", "generation"},
+	{"metadata", "creationTimestamp"},
+	{"metadata", "managedFields"},
+	{"metadata", "selfLink"},
+	{"metadata", "annotations", "kubectl.kubernetes.io/last-applied-configuration"},
+	{"metadata", "annotations", "deployment.kubernetes.io/revision"},
+	{"spec", "clusterIP"},
+	{"spec", "clusterIPs"},
+	{"spec", "template", "metadata", "creationTimestamp"},
+	{"spec", "jobTemplate", "metadata", "creationTimestamp"},
+	{"spec", "jobTemplate", "spec", "template", "metadata", "creationTimestamp"},
+}
+
+// namespaceComponent captures the Helm releases, resources and images of a namespace into a component. The charts,
+// values and manifests are written to the output directory and referenced relative to it.
+func namespaceComponent(ctx context.Context, c *cluster.Cluster, name, namespace, outputDir string) (v1alpha1.ZarfComponent, error) {
+	l := logger.From(ctx)
+	component := v1alpha1.ZarfComponent{
+		Name:     name,
+		Required: helpers.BoolPtr(true),
+	}
+
+	releases, err := storage.Init(driver.NewSecrets(c.Clientset.CoreV1().Secrets(namespace))).ListDeployed()
+	if err != nil {
+		return v1alpha1.ZarfComponent{}, fmt.Errorf("unable to list the Helm releases in %s: %w", namespace, err)
+	}
+	slices.SortFunc(releases, func(a, b *release.Release) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	for _, rls := range releases {
+		if rls.Chart == nil || rls.Chart.Metadata == nil {
+			continue
+		}
+		chartDir := filepath.Join(outputDir, "charts")
+		if err := helpers.CreateDirectory(chartDir, helpers.ReadExecuteAllWriteUser); err != nil {
+			return v1alpha1.ZarfComponent{}, err
+		}
+		archive := fmt.Sprintf("%s-%s.tgz", rls.Chart.Metadata.Name, rls.Chart.Metadata.Version)
+		if !helpers.InvalidPath(filepath.Join(chartDir, archive)) {
+			return v1alpha1.ZarfComponent{}, fmt.Errorf("unable to capture %s, the file already exists", filepath.Join(chartDir, archive))
+		}
+		chartPath, err := chartutil.Save(rls.Chart, chartDir)
+		if err != nil {
+			return v1alpha1.ZarfComponent{}, fmt.Errorf("unable to save the chart of the Helm release %s: %w", rls.Name, err)
+		}
+		chart := v1alpha1.ZarfChart{
+			Name:        rls.Chart.Metadata.Name,
+			Version:     rls.Chart.Metadata.Version,
+			Namespace:   namespace,
+			ReleaseName: rls.Name,
+			LocalPath:   filepath.ToSlash(filepath.Join("charts", filepath.Base(chartPath))),
+		}
+		if len(rls.Config) > 0 {
+			valuesPath := filepath.Join("values", fmt.Sprintf("%s.yaml", rls.Name))
+			b, err := yaml.Marshal(rls.Config)
+			if err != nil {
+				return v1alpha1.ZarfComponent{}, err
+			}
+			if err := writeCapturedFile(filepath.Join(outputDir, valuesPath), b); err != nil {
+				return v1alpha1.ZarfComponent{}, err
+			}
+			chart.ValuesFiles = []string{filepath.ToSlash(valuesPath)}
+		}
+		l.Info("captured helm release", "name", rls.Name, "chart", chart.Name, "version", chart.Version)
+		component.Charts = append(component.Charts, chart)
+	}
+
+	objs, images, err := namespaceResources(ctx, c, namespace)
+	if err != nil {
+		return v1alpha1.ZarfComponent{}, err
+	}
+	files := []string{}
+	for _, obj := range objs {
+		if _, ok := obj.GetAnnotations()[helmReleaseAnnotation]; ok {
+			continue
+		}
+		if len(obj.GetOwnerReferences()) > 0 {
+			continue
+		}
+		for _, field := range capturedFieldsToRemove {
+			unstructured.RemoveNestedField(obj.Object, field...)
+		}
+		if len(obj.GetAnnotations()) == 0 {
+			unstructured.RemoveNestedField(obj.Object, "metadata", "annotations")
+		}
+		b, err := yaml.Marshal(obj.Object)
+		if err != nil {
+			return v1alpha1.ZarfComponent{}, err
+		}
+		manifestPath := filepath.Join("manifests", fmt.Sprintf("%s-%s.yaml", strings.ToLower(obj.GetKind()), obj.GetName()))
+		if err := writeCapturedFile(filepath.Join(outputDir, manifestPath), b); err != nil {
+			return v1alpha1.ZarfComponent{}, err
+		}
+		files = append(files, filepath.ToSlash(manifestPath))
+	}
+	if len(files) > 0 {
+		component.Manifests = append(component.Manifests, v1alpha1.ZarfManifest{Name: name, Namespace: namespace, Files: files})
+	}
+	if len(component.Charts) == 0 && len(component.Manifests) == 0 {
+		return v1alpha1.ZarfComponent{}, fmt.Errorf("no Helm releases or resources found in the namespace %s", namespace)
+	}
+	component.Images = images
+	return component, nil
+}
+
+// namespaceResources returns the workloads, services and configuration of the namespace and the images they use.
+// Images of pods that were patched by the Zarf agent point at the Zarf registry and are only taken from the pod
+// templates of their workloads.
+func namespaceResources(ctx context.Context, c *cluster.Cluster, namespace string) ([]*unstructured.Unstructured, []string, error) {
+	objs := []*unstructured.Unstructured{}
+	images := map[string]bool{}
+	addPodSpec := func(spec corev1.PodSpec) {
+		for _, container := range slices.Concat(spec.InitContainers, spec.Containers) {
+			images[container.Image] = true
+		}
+	}
+	add := func(apiVersion, kind string, obj runtime.Object) error {
+		content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+		if err != nil {
+			return err
+		}
+		u := &unstructured.Unstructured{Object: content}
+		u.SetAPIVersion(apiVersion)
+		u.SetKind(kind)
+		objs = append(objs, u)
+		return nil
+	}
+	opts := metav1.ListOptions{}
+
+	deployments, err := c.Clientset.AppsV1().Deployments(namespace).List(ctx, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, d := range deployments.Items {
+		addPodSpec(d.Spec.Template.Spec)
+		if err := add("apps/v1", "Deployment", &d); err != nil {
+			return nil, nil, err
+		}
+	}
+	statefulSets, err := c.Clientset.AppsV1().StatefulSets(namespace).List(ctx, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, s := range statefulSets.Items {
+		addPodSpec(s.Spec.Template.Spec)
+		if err := add("apps/v1", "StatefulSet", &s); err != nil {
+			return nil, nil, err
+		}
+	}
+	daemonSets, err := c.Clientset.AppsV1().DaemonSets(namespace).List(ctx, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, d := range daemonSets.Items {
+		addPodSpec(d.Spec.Template.Spec)
+		if err := add("apps/v1", "DaemonSet", &d); err != nil {
+			return nil, nil, err
+		}
+	}
+	cronJobs, err := c.Clientset.BatchV1().CronJobs(namespace).List(ctx, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, j := range cronJobs.Items {
+		addPodSpec(j.Spec.JobTemplate.Spec.Template.Spec)
+		if err := add("batch/v1", "CronJob", &j); err != nil {
+			return nil, nil, err
+		}
+	}
+	pods, err := c.Clientset.CoreV1().Pods(namespace).List(ctx, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, p := range pods.Items {
+		if p.Labels["zarf-agent"] == "patched" {
+			continue
+		}
+		addPodSpec(p.Spec)
+		// Pods of workloads are recreated by their workload and are not captured.
+		if len(p.OwnerReferences) > 0 {
+			continue
+		}
+		if err := add("v1", "Pod", &p); err != nil {
+			return nil, nil, err
+		}
+	}
+	services, err := c.Clientset.CoreV1().Services(namespace).List(ctx, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, s := range services.Items {
+		if s.Labels["provider"] == "kubernetes" {
+			continue
+		}
+		if err := add("v1", "Service", &s); err != nil {
+			return nil, nil, err
+		}
+	}
+	configMaps, err := c.Clientset.CoreV1().ConfigMaps(namespace).List(ctx, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, cm := range configMaps.Items {
+		if cm.Name == "kube-root-ca.crt" {
+			continue
+		}
+		if err := add("v1", "ConfigMap", &cm); err != nil {
+			return nil, nil, err
+		}
+	}
+	serviceAccounts, err := c.Clientset.CoreV1().ServiceAccounts(namespace).List(ctx, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, sa := range serviceAccounts.Items {
+		if sa.Name == "default" {
+			continue
+		}
+		if err := add("v1", "ServiceAccount", &sa); err != nil {
+			return nil, nil, err
+		}
+	}
+	pvcs, err := c.Clientset.CoreV1().PersistentVolumeClaims(namespace).List(ctx, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, pvc := range pvcs.Items {
+		if err := add("v1", "PersistentVolumeClaim", &pvc); err != nil {
+			return nil, nil, err
+		}
+	}
+	ingresses, err := c.Clientset.NetworkingV1().Ingresses(namespace).List(ctx, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, i := range ingresses.Items {
+		if err := add("networking.k8s.io/v1", "Ingress", &i); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	sortedImages := []string{}
+	for image := range images {
+		sortedImages = append(sortedImages, image)
+	}
+	slices.Sort(sortedImages)
+	return objs, sortedImages, nil
+}
+
+// writeCapturedFile writes a file captured from the cluster without overwriting existing files.
+func writeCapturedFile(path string, b []byte) error {
+	if !helpers.InvalidPath(path) {
+		return fmt.Errorf("unable to capture %s, the file already exists", path)
+	}
+	if err := helpers.CreateDirectory(filepath.Dir(path), helpers.ReadExecuteAllWriteUser); err != nil {
+		return err
+	}
+	return os.WriteFile(path, b, helpers.ReadAllWriteUser)
+}
@@ -0,0 +1,136 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package packager
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/defenseunicorns/pkg/helpers/v2"
+	"github.com/stretchr/testify/require"
+	"helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/release"
+	"helm.sh/helm/v3/pkg/storage/driver"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/zarf-dev/zarf/src/api/v1alpha1"
+	"github.com/zarf-dev/zarf/src/pkg/cluster"
+	"github.com/zarf-dev/zarf/src/test/testutil"
+)
+
+func TestNamespaceComponent(t *testing.T) {
+	t.Parallel()
+	ctx := testutil.TestContext(t)
+
+	podSpec := func(image string) corev1.PodTemplateSpec {
+		return corev1.PodTemplateSpec{
+			Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "app", Image: image}}},
+		}
+	}
+	cs := fake.NewClientset(
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            "web",
+				Namespace:       "app",
+				ResourceVersion: "42",
+				Annotations:     map[string]string{"deployment.kubernetes.io/revision": "3"},
+			},
+			Spec:   appsv1.DeploymentSpec{Template: podSpec("nginx:1.27")},
+			Status: appsv1.DeploymentStatus{ReadyReplicas: 1},
+		},
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "podinfo",
+				Namespace:   "app",
+				Annotations: map[string]string{helmReleaseAnnotation: "podinfo"},
+			},
+			Spec: appsv1.DeploymentSpec{Template: podSpec("ghcr.io/stefanprodan/podinfo:6.4.0")},
+		},
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            "web-abc",
+				Namespace:       "app",
+				Labels:          map[string]string{"zarf-agent": "patched"},
+				OwnerReferences: []metav1.OwnerReference{{Kind: "ReplicaSet", Name: "web-123"}},
+			},
+			Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "app", Image: "127.0.0.1:31999/library/nginx:1.27-zarf-123"}}},
+		},
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "debug", Namespace: "app"},
+			Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "debug", Image: "busybox:1.36"}}},
+		},
+		&corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "app"},
+			Spec:       corev1.ServiceSpec{ClusterIP: "10.0.0.1", Ports: []corev1.ServicePort{{Port: 80}}},
+		},
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "kube-root-ca.crt", Namespace: "app"}},
+		&corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: "app"}},
+		&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "credentials", Namespace: "app"}},
+		&corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "other"}},
+	)
+	rls := &release.Release{
+		Name:      "podinfo",
+		Namespace: "app",
+		Version:   1,
+		Info:      &release.Info{Status: release.StatusDeployed},
+		Chart: &chart.Chart{
+			Metadata: &chart.Metadata{APIVersion: chart.APIVersionV2, Name: "podinfo", Version: "6.4.0"},
+		},
+		Config: map[string]interface{}{"replicaCount": 2},
+	}
+	err := driver.NewSecrets(cs.CoreV1().Secrets("app")).Create("sh.helm.release.v1.podinfo.v1", rls)
+	require.NoError(t, err)
+
+	outputDir := t.TempDir()
+	component, err := namespaceComponent(ctx, &cluster.Cluster{Clientset: cs}, "my-app", "app", outputDir)
+	require.NoError(t, err)
+	expected := v1alpha1.ZarfComponent{
+		Name:     "my-app",
+		Required: helpers.BoolPtr(true),
+		Charts: []v1alpha1.ZarfChart{
+			{
+				Name:        "podinfo",
+				Version:     "6.4.0",
+				Namespace:   "app",
+				ReleaseName: "podinfo",
+				LocalPath:   "charts/podinfo-6.4.0.tgz",
+				ValuesFiles: []string{"values/podinfo.yaml"},
+			},
+		},
+		Manifests: []v1alpha1.ZarfManifest{
+			{
+				Name:      "my-app",
+				Namespace: "app",
+				Files:     []string{"manifests/deployment-web.yaml", "manifests/pod-debug.yaml", "manifests/service-web.yaml"},
+			},
+		},
+		Images: []string{"busybox:1.36", "ghcr.io/stefanprodan/podinfo:6.4.0", "nginx:1.27"},
+	}
+	require.Equal(t, expected, component)
+
+	require.FileExists(t, filepath.Join(outputDir, "charts", "podinfo-6.4.0.tgz"))
+	b, err := os.ReadFile(filepath.Join(outputDir, "values", "podinfo.yaml"))
+	require.NoError(t, err)
+	require.Equal(t, "replicaCount: 2\n", string(b))
+	b, err = os.ReadFile(filepath.Join(outputDir, "manifests", "deployment-web.yaml"))
+	require.NoError(t, err)
+	require.NotContains(t, string(b), "resourceVersion")
+	require.NotContains(t, string(b), "status")
+	require.NotContains(t, string(b), "namespace")
+	require.NotContains(t, string(b), "annotations")
+	b, err = os.ReadFile(filepath.Join(outputDir, "manifests", "service-web.yaml"))
+	require.NoError(t, err)
+	require.NotContains(t, string(b), "clusterIP")
+
+	// Captured files are never overwritten.
+	_, err = namespaceComponent(ctx, &cluster.Cluster{Clientset: cs}, "my-app", "app", outputDir)
+	require.ErrorContains(t, err, "already exists")
+
+	_, err = namespaceComponent(ctx, &cluster.Cluster{Clientset: fake.NewClientset()}, "my-app", "empty", t.TempDir())
+	require.EqualError(t, err, "no Helm releases or resources found in the namespace empty")
+}
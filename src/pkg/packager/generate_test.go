@@ -0,0 +1,122 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package packager
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/defenseunicorns/pkg/helpers/v2"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zarf-dev/zarf/src/api/v1alpha1"
+)
+
+func TestLocalComponent(t *testing.T) {
+	t.Parallel()
+
+	appDir := filepath.Join("testdata", "generate", "app")
+	tests := []struct {
+		name        string
+		path        string
+		expected    v1alpha1.ZarfComponent
+		expectedErr string
+	}{
+		{
+			name: "directory",
+			path: appDir,
+			expected: v1alpha1.ZarfComponent{
+				Name:     "app",
+				Required: helpers.BoolPtr(true),
+				Charts: []v1alpha1.ZarfChart{
+					{Name: "podinfo", Version: "6.4.0", Namespace: "app", LocalPath: filepath.Join(appDir, "chart")},
+				},
+				Manifests: []v1alpha1.ZarfManifest{
+					{Name: "app-overlay", Namespace: "app", Kustomizations: []string{filepath.Join(appDir, "overlay")}},
+					{Name: "app", Namespace: "app", Files: []string{filepath.Join(appDir, "service.yaml")}},
+				},
+			},
+		},
+		{
+			name: "chart",
+			path: filepath.Join(appDir, "chart"),
+			expected: v1alpha1.ZarfComponent{
+				Name:     "app",
+				Required: helpers.BoolPtr(true),
+				Charts: []v1alpha1.ZarfChart{
+					{Name: "podinfo", Version: "6.4.0", Namespace: "app", LocalPath: filepath.Join(appDir, "chart")},
+				},
+			},
+		},
+		{
+			name: "kustomization",
+			path: filepath.Join(appDir, "overlay"),
+			expected: v1alpha1.ZarfComponent{
+				Name:     "app",
+				Required: helpers.BoolPtr(true),
+				Manifests: []v1alpha1.ZarfManifest{
+					{Name: "app", Namespace: "app", Kustomizations: []string{filepath.Join(appDir, "overlay")}},
+				},
+			},
+		},
+		{
+			name: "manifest",
+			path: filepath.Join(appDir, "service.yaml"),
+			expected: v1alpha1.ZarfComponent{
+				Name:     "app",
+				Required: helpers.BoolPtr(true),
+				Manifests: []v1alpha1.ZarfManifest{
+					{Name: "app", Namespace: "app", Files: []string{filepath.Join(appDir, "service.yaml")}},
+				},
+			},
+		},
+		{
+			name:        "not a manifest",
+			path:        filepath.Join(appDir, "config", "settings.yaml"),
+			expectedErr: "is not a Helm chart, kustomization or manifest",
+		},
+		{
+			name:        "nothing to deploy",
+			path:        filepath.Join(appDir, "config"),
+			expectedErr: "no Helm charts, kustomizations or manifests found in",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			component, err := localComponent("app", tt.path)
+			if tt.expectedErr != "" {
+				require.ErrorContains(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, component)
+		})
+	}
+}
+
+func TestRelativeComponentPaths(t *testing.T) {
+	t.Parallel()
+
+	component := v1alpha1.ZarfComponent{
+		Charts: []v1alpha1.ZarfChart{
+			{Name: "podinfo", LocalPath: filepath.Join("deploy", "chart")},
+			{Name: "remote", URL: "https://stefanprodan.github.io/podinfo"},
+		},
+		Manifests: []v1alpha1.ZarfManifest{
+			{
+				Name:           "app",
+				Files:          []string{filepath.Join("deploy", "service.yaml")},
+				Kustomizations: []string{filepath.Join("deploy", "overlay")},
+			},
+		},
+	}
+	err := relativeComponentPaths(&component, "package")
+	require.NoError(t, err)
+	require.Equal(t, "../deploy/chart", component.Charts[0].LocalPath)
+	require.Empty(t, component.Charts[1].LocalPath)
+	require.Equal(t, []string{"../deploy/service.yaml"}, component.Manifests[0].Files)
+	require.Equal(t, []string{"../deploy/overlay"}, component.Manifests[0].Kustomizations)
+}
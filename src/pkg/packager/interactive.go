@@ -9,28 +9,53 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/AlecAivazis/survey/v2"
 	"github.com/defenseunicorns/pkg/helpers/v2"
 	"github.com/pterm/pterm"
 	"github.com/zarf-dev/zarf/src/config"
+	"github.com/zarf-dev/zarf/src/pkg/interactive"
 	"github.com/zarf-dev/zarf/src/pkg/layout"
 	"github.com/zarf-dev/zarf/src/pkg/logger"
 	"github.com/zarf-dev/zarf/src/pkg/message"
 	"github.com/zarf-dev/zarf/src/pkg/utils"
+	"github.com/zarf-dev/zarf/src/types"
 )
 
-func (p *Packager) confirmAction(ctx context.Context, stage string, warnings []string, sbomViewFiles []string) bool {
+func (p *Packager) confirmAction(ctx context.Context, stage string, warnings []string, sbomViewFiles []string, diff *types.PackageDiff) (bool, error) {
+	releaseNotes, err := p.releaseNotes(stage)
+	if err != nil {
+		return false, err
+	}
+
+	if c := interactive.ActiveConfirmer(); c != nil && !config.CommonOptions.Confirm {
+		return c.Confirm(ctx, interactive.ConfirmRequest{
+			Stage:        stage,
+			Package:      p.cfg.Pkg,
+			Warnings:     warnings,
+			SBOMFiles:    sbomViewFiles,
+			ReleaseNotes: releaseNotes,
+			Diff:         diff,
+		})
+	}
+
 	pterm.Println()
 	message.HeaderInfof("📦 PACKAGE DEFINITION")
 	l := logger.From(ctx)
-	err := utils.ColorPrintYAML(p.cfg.Pkg, p.getPackageYAMLHints(stage), true)
+	err = utils.ColorPrintYAML(p.cfg.Pkg, p.getPackageYAMLHints(stage), true)
 	if err != nil {
 		message.WarnErr(err, "unable to print yaml")
 	}
 
 	// Print any potential breaking changes (if this is a Deploy confirm) between this CLI version and the deployed init package
 	if stage == config.ZarfDeployStage {
+		if diff != nil {
+			message.HorizontalRule()
+			message.Title("Deployed Package Changes", "the changes from the version of this package that is already deployed")
+			printPackageDiff(ctx, *diff)
+		}
+
 		if p.cfg.Pkg.IsSBOMAble() {
 			// Print the location that the user can view the package SBOMs from
 			message.HorizontalRule()
@@ -58,6 +83,12 @@ func (p *Packager) confirmAction(ctx context.Context, stage string, warnings []s
 				message.Warn("This package does NOT contain an SBOM.  If you require an SBOM, please contact the creator of this package to request a version that includes an SBOM.")
 			}
 		}
+
+		if releaseNotes != "" {
+			message.HorizontalRule()
+			message.Title("Release Notes", "the changes in this version of the package")
+			pterm.Println(releaseNotes)
+		}
 	}
 
 	if len(warnings) > 0 {
@@ -75,7 +106,10 @@ func (p *Packager) confirmAction(ctx context.Context, stage string, warnings []s
 	if config.CommonOptions.Confirm {
 		pterm.Println()
 		message.Successf("%s Zarf package confirmed", stage)
-		return config.CommonOptions.Confirm
+		return config.CommonOptions.Confirm, nil
+	}
+	if err := interactive.CheckPrompt(fmt.Sprintf("confirmation to %s the package, confirm it with --confirm", strings.ToLower(stage))); err != nil {
+		return false, err
 	}
 
 	prompt := &survey.Confirm{
@@ -88,10 +122,66 @@ func (p *Packager) confirmAction(ctx context.Context, stage string, warnings []s
 	var confirm bool
 	if err := survey.AskOne(prompt, &confirm); err != nil || !confirm {
 		// User aborted or declined, cancel the action
-		return false
+		return false, nil
+	}
+
+	return true, nil
+}
+
+// printPackageDiff prints the changes from the deployed package, downgrades are also flagged as package warnings.
+func printPackageDiff(ctx context.Context, diff types.PackageDiff) {
+	l := logger.From(ctx)
+	if diff.IsEmpty() {
+		pterm.Println("No changes to the version, components, images or variables of the deployed package.")
+		l.Info("no changes from the deployed package")
+		return
+	}
+	if diff.FromVersion != diff.ToVersion {
+		pterm.Printfln("%s %s -> %s", pterm.Bold.Sprint("Version:"), diff.FromVersion, diff.ToVersion)
+	}
+	printDiffList := func(title string, names []string) {
+		if len(names) == 0 {
+			return
+		}
+		pterm.Printfln("%s %s", pterm.Bold.Sprint(title), strings.Join(names, ", "))
 	}
+	printDiffList("Added components:", diff.AddedComponents)
+	printDiffList("Removed components:", diff.RemovedComponents)
+	printDiffList("Added images:", diff.AddedImages)
+	printDiffList("Removed images:", diff.RemovedImages)
+	for _, image := range diff.ChangedImages {
+		pterm.Printfln("%s %s %s -> %s", pterm.Bold.Sprint("Changed image digest:"), image.Reference, image.FromDigest, image.ToDigest)
+	}
+	printDiffList("Added variables:", diff.AddedVariables)
+	printDiffList("Removed variables:", diff.RemovedVariables)
+	printDiffList("Changed variables:", diff.ChangedVariables)
+	l.Info("changes from the deployed package",
+		"fromVersion", diff.FromVersion,
+		"toVersion", diff.ToVersion,
+		"addedComponents", diff.AddedComponents,
+		"removedComponents", diff.RemovedComponents,
+		"addedImages", diff.AddedImages,
+		"removedImages", diff.RemovedImages,
+		"changedImages", len(diff.ChangedImages),
+		"addedVariables", diff.AddedVariables,
+		"removedVariables", diff.RemovedVariables,
+		"changedVariables", diff.ChangedVariables,
+	)
+}
 
-	return true
+// releaseNotes returns the release notes embedded in the package when they are requested before a deploy.
+func (p *Packager) releaseNotes(stage string) (string, error) {
+	if stage != config.ZarfDeployStage || !p.cfg.DeployOpts.ShowReleaseNotes {
+		return "", nil
+	}
+	if p.layout.ReleaseNotes == "" || helpers.InvalidPath(p.layout.ReleaseNotes) {
+		return "", nil
+	}
+	b, err := os.ReadFile(p.layout.ReleaseNotes)
+	if err != nil {
+		return "", fmt.Errorf("unable to read the release notes: %w", err)
+	}
+	return string(b), nil
 }
 
 func (p *Packager) getPackageYAMLHints(stage string) map[string]string {
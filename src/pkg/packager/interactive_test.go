@@ -0,0 +1,45 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package packager
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/zarf-dev/zarf/src/api/v1alpha1"
+	"github.com/zarf-dev/zarf/src/config"
+	"github.com/zarf-dev/zarf/src/pkg/interactive"
+	"github.com/zarf-dev/zarf/src/types"
+)
+
+type testConfirmer struct {
+	request interactive.ConfirmRequest
+}
+
+func (c *testConfirmer) Confirm(_ context.Context, request interactive.ConfirmRequest) (bool, error) {
+	c.request = request
+	return false, nil
+}
+
+func TestConfirmActionConfirmer(t *testing.T) {
+	c := &testConfirmer{}
+	interactive.SetConfirmer(c)
+	t.Cleanup(func() {
+		interactive.SetConfirmer(nil)
+	})
+
+	pkg := v1alpha1.ZarfPackage{Metadata: v1alpha1.ZarfMetadata{Name: "test"}}
+	p := &Packager{cfg: &types.PackagerConfig{Pkg: pkg}}
+	confirmed, err := p.confirmAction(context.Background(), config.ZarfDeployStage, []string{"warning"}, []string{"sbom.html"}, nil)
+	require.NoError(t, err)
+	require.False(t, confirmed)
+	require.Equal(t, interactive.ConfirmRequest{
+		Stage:     config.ZarfDeployStage,
+		Package:   pkg,
+		Warnings:  []string{"warning"},
+		SBOMFiles: []string{"sbom.html"},
+	}, c.request)
+}
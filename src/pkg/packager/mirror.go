@@ -12,6 +12,7 @@ import (
 
 	"github.com/zarf-dev/zarf/src/api/v1alpha1"
 	"github.com/zarf-dev/zarf/src/config"
+	"github.com/zarf-dev/zarf/src/internal/fips"
 	"github.com/zarf-dev/zarf/src/pkg/message"
 	"github.com/zarf-dev/zarf/src/pkg/packager/filters"
 	"github.com/zarf-dev/zarf/src/types"
@@ -28,6 +29,12 @@ func (p *Packager) Mirror(ctx context.Context) error {
 	if err != nil {
 		return fmt.Errorf("unable to load the package: %w", err)
 	}
+	if config.CommonOptions.FIPS {
+		err = fips.ValidatePackage(pkg)
+		if err != nil {
+			return err
+		}
+	}
 	p.cfg.Pkg = pkg
 
 	sbomViewFiles, sbomWarnings, err := p.layout.SBOMs.StageSBOMViewFiles()
@@ -37,7 +44,11 @@ func (p *Packager) Mirror(ctx context.Context) error {
 	warnings = append(warnings, sbomWarnings...)
 
 	// Confirm the overall package mirror
-	if !p.confirmAction(ctx, config.ZarfMirrorStage, warnings, sbomViewFiles) {
+	confirmed, err := p.confirmAction(ctx, config.ZarfMirrorStage, warnings, sbomViewFiles, nil)
+	if err != nil {
+		return err
+	}
+	if !confirmed {
 		return fmt.Errorf("mirror cancelled")
 	}
 
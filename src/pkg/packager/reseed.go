@@ -0,0 +1,158 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package packager contains functions for interacting with, managing and deploying Zarf packages.
+package packager
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/defenseunicorns/pkg/helpers/v2"
+
+	"github.com/zarf-dev/zarf/src/config"
+	"github.com/zarf-dev/zarf/src/internal/packager/images"
+	"github.com/zarf-dev/zarf/src/pkg/layout"
+	"github.com/zarf-dev/zarf/src/pkg/logger"
+	"github.com/zarf-dev/zarf/src/pkg/message"
+	"github.com/zarf-dev/zarf/src/pkg/packager/filters"
+	"github.com/zarf-dev/zarf/src/pkg/packager/sources"
+	"github.com/zarf-dev/zarf/src/pkg/transform"
+	"github.com/zarf-dev/zarf/src/pkg/utils"
+	"github.com/zarf-dev/zarf/src/types"
+)
+
+// recordedSource returns the source that is recorded with the deployed package. It is only recorded when the registry
+// uses ephemeral storage and for sources that can be read again, which excludes stdin.
+func (p *Packager) recordedSource() string {
+	if p.state == nil || p.state.RegistryInfo.Storage != types.RegistryStorageEphemeral {
+		return ""
+	}
+	source := p.cfg.PkgOpts.PackageSource
+	switch sources.Identify(source) {
+	case "oci", "http", "https", "sget":
+		return source
+	case "tarball", "split":
+		abs, err := filepath.Abs(source)
+		if err != nil {
+			return ""
+		}
+		return abs
+	}
+	return ""
+}
+
+// pushMissingImages pushes the images of the deployed packages that are missing from a registry with ephemeral
+// storage, which loses its images whenever the registry pod restarts. The images are pushed from the source each
+// package was deployed from, packages without a recorded source have to be deployed again.
+func (p *Packager) pushMissingImages(ctx context.Context) {
+	l := logger.From(ctx)
+	deployedPackages, err := p.cluster.GetDeployedZarfPackages(ctx)
+	if err != nil {
+		message.Warnf("Unable to check the registry for missing images: %s", err.Error())
+		l.Warn("unable to check the registry for missing images", "error", err)
+		return
+	}
+	for _, dp := range deployedPackages {
+		// The images of the package that is being deployed are pushed by the deployment.
+		if dp.Name == p.cfg.Pkg.Metadata.Name {
+			continue
+		}
+		err := p.pushMissingPackageImages(ctx, dp)
+		if err != nil {
+			message.Warnf("Unable to push the missing images of the package %s, deploy it again to push them: %s", dp.Name, err.Error())
+			l.Warn("unable to push the missing images of the package, deploy it again to push them", "package", dp.Name, "error", err)
+		}
+	}
+}
+
+func (p *Packager) pushMissingPackageImages(ctx context.Context, dp types.DeployedPackage) error {
+	l := logger.From(ctx)
+	componentImages := deployedImages(dp)
+	imageList := []transform.Image{}
+	for _, imgs := range componentImages {
+		for _, img := range imgs {
+			ref, err := transform.ParseImageRef(img)
+			if err != nil {
+				return fmt.Errorf("failed to create ref for image %s: %w", img, err)
+			}
+			imageList = append(imageList, ref)
+		}
+	}
+	if len(imageList) == 0 {
+		return nil
+	}
+	pushCfg := images.PushConfig{
+		ImageList: helpers.Unique(imageList),
+		RegInfo:   p.state.RegistryInfo,
+		Arch:      dp.Data.Build.Architecture,
+		Retries:   p.cfg.PkgOpts.Retries,
+	}
+	missing, err := images.Missing(ctx, pushCfg)
+	if err != nil {
+		return err
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+	if dp.Source == "" {
+		return fmt.Errorf("%d images are missing from the registry and the package has no recorded source", len(missing))
+	}
+
+	message.Notef("Pushing %d missing images of the package %s from %s", len(missing), dp.Name, dp.Source)
+	l.Info("pushing the missing images of the package", "package", dp.Name, "source", dp.Source, "images", len(missing))
+	components := []string{}
+	for name, imgs := range componentImages {
+		if slices.ContainsFunc(imgs, func(img string) bool {
+			return slices.ContainsFunc(missing, func(ref transform.Image) bool { return ref.Reference == img })
+		}) {
+			components = append(components, name)
+		}
+	}
+	tmpDir, err := utils.MakeTempDir(config.CommonOptions.TempDirectory)
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmpDir)
+	// The signature was validated when the package was deployed, the aggregate checksum of the deployed package
+	// ensures that the images are pushed from the same package.
+	pkgOpts := &types.ZarfPackageOptions{
+		PackageSource:           dp.Source,
+		SkipSignatureValidation: true,
+	}
+	source, err := sources.New(ctx, pkgOpts)
+	if err != nil {
+		return err
+	}
+	paths := layout.New(tmpDir)
+	pkg, _, err := source.LoadPackage(ctx, paths, filters.BySelectState(strings.Join(components, ",")), false)
+	if err != nil {
+		return fmt.Errorf("unable to load the package from %s: %w", dp.Source, err)
+	}
+	if pkg.Metadata.AggregateChecksum == "" || pkg.Metadata.AggregateChecksum != dp.Data.Metadata.AggregateChecksum {
+		return fmt.Errorf("the package at %s is not the deployed package", dp.Source)
+	}
+	pushCfg.SourceDirectory = paths.Images.Base
+	pushCfg.ImageList = missing
+	return images.Push(ctx, pushCfg)
+}
+
+// deployedImages returns the images of the components of a deployed package that were deployed successfully.
+func deployedImages(dp types.DeployedPackage) map[string][]string {
+	componentImages := map[string][]string{}
+	for _, dc := range dp.DeployedComponents {
+		if dc.Status != types.ComponentStatusSucceeded {
+			continue
+		}
+		for _, component := range dp.Data.Components {
+			if component.Name == dc.Name && len(component.Images) > 0 {
+				componentImages[component.Name] = component.Images
+			}
+		}
+	}
+	return componentImages
+}
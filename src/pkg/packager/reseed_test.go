@@ -0,0 +1,83 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package packager
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/zarf-dev/zarf/src/api/v1alpha1"
+	"github.com/zarf-dev/zarf/src/types"
+)
+
+func TestRecordedSource(t *testing.T) {
+	t.Parallel()
+
+	abs, err := filepath.Abs("zarf-package-test-amd64.tar.zst")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		storage  string
+		source   string
+		expected string
+	}{
+		{
+			name:     "oci",
+			storage:  types.RegistryStorageEphemeral,
+			source:   "oci://ghcr.io/zarf-dev/packages/test:1.0.0",
+			expected: "oci://ghcr.io/zarf-dev/packages/test:1.0.0",
+		},
+		{
+			name:     "tarball",
+			storage:  types.RegistryStorageEphemeral,
+			source:   "zarf-package-test-amd64.tar.zst",
+			expected: abs,
+		},
+		{
+			name:    "stdin",
+			storage: types.RegistryStorageEphemeral,
+			source:  "-",
+		},
+		{
+			name:    "pvc",
+			storage: types.RegistryStoragePVC,
+			source:  "oci://ghcr.io/zarf-dev/packages/test:1.0.0",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			p := &Packager{
+				cfg:   &types.PackagerConfig{PkgOpts: types.ZarfPackageOptions{PackageSource: tt.source}},
+				state: &types.ZarfState{RegistryInfo: types.RegistryInfo{Storage: tt.storage}},
+			}
+			require.Equal(t, tt.expected, p.recordedSource())
+		})
+	}
+}
+
+func TestDeployedImages(t *testing.T) {
+	t.Parallel()
+
+	dp := types.DeployedPackage{
+		Data: v1alpha1.ZarfPackage{
+			Components: []v1alpha1.ZarfComponent{
+				{Name: "web", Images: []string{"nginx:1.27"}},
+				{Name: "db", Images: []string{"postgres:17"}},
+				{Name: "config"},
+				{Name: "cache", Images: []string{"redis:7"}},
+			},
+		},
+		DeployedComponents: []types.DeployedComponent{
+			{Name: "web", Status: types.ComponentStatusSucceeded},
+			{Name: "db", Status: types.ComponentStatusFailed},
+			{Name: "config", Status: types.ComponentStatusSucceeded},
+		},
+	}
+	require.Equal(t, map[string][]string{"web": {"nginx:1.27"}}, deployedImages(dp))
+}
@@ -0,0 +1,185 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package sources contains core implementations of the PackageSource interface.
+package sources
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/defenseunicorns/pkg/helpers/v2"
+	"github.com/zarf-dev/zarf/src/config"
+	"github.com/zarf-dev/zarf/src/pkg/events"
+	"github.com/zarf-dev/zarf/src/pkg/exitcode"
+	"github.com/zarf-dev/zarf/src/pkg/logger"
+	"github.com/zarf-dev/zarf/src/pkg/utils"
+	"github.com/zarf-dev/zarf/src/types"
+)
+
+// ChecksumsSignatureSuffix is appended to the location of a checksums file to find its signature.
+const ChecksumsSignatureSuffix = ".sig"
+
+// ErrChecksumsNoKey is returned when a checksums file is provided without a key to verify its signature.
+var ErrChecksumsNoKey = errors.New("checksums file provided but no key was provided - add a key with the --key flag or use the --skip-signature-validation flag and run the command again")
+
+// ChecksumManifest maps file names to their SHA256 checksums.
+type ChecksumManifest map[string]string
+
+// ParseChecksumManifest parses a checksums file in the format written by sha256sum.
+func ParseChecksumManifest(r io.Reader) (ChecksumManifest, error) {
+	manifest := ChecksumManifest{}
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		shasum, name, ok := strings.Cut(line, " ")
+		if !ok {
+			return nil, fmt.Errorf("invalid checksums line %q", line)
+		}
+		// sha256sum marks files read in binary mode with a leading asterisk.
+		name = strings.TrimPrefix(strings.TrimSpace(name), "*")
+		if len(shasum) != 64 || name == "" {
+			return nil, fmt.Errorf("invalid checksums line %q", line)
+		}
+		manifest[filepath.Base(name)] = strings.ToLower(shasum)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(manifest) == 0 {
+		return nil, errors.New("checksums file does not contain any checksums")
+	}
+	return manifest, nil
+}
+
+// Shasum returns the checksum of the file with the given name. An empty name is only resolved when the manifest
+// holds a single checksum, as is the case for packages streamed over stdin.
+func (m ChecksumManifest) Shasum(name string) (string, error) {
+	if name == "" {
+		if len(m) != 1 {
+			return "", fmt.Errorf("checksums file must contain a single checksum to verify a package without a file name, found %d", len(m))
+		}
+		for _, shasum := range m {
+			return shasum, nil
+		}
+	}
+	shasum, ok := m[name]
+	if !ok {
+		return "", fmt.Errorf("checksums file does not contain a checksum for %s", name)
+	}
+	return shasum, nil
+}
+
+// LoadChecksumManifest fetches the checksums file of the options from a local path or URL, verifies its signature with
+// the public key of the options and parses it.
+func LoadChecksumManifest(ctx context.Context, opts *types.ZarfPackageOptions) (ChecksumManifest, error) {
+	if opts.PublicKeyPath == "" && !opts.SkipSignatureValidation {
+		return nil, exitcode.Wrap(exitcode.Signature, ErrChecksumsNoKey)
+	}
+
+	tmp, err := utils.MakeTempDir(config.CommonOptions.TempDirectory)
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(tmp)
+
+	checksumsPath := opts.ChecksumsPath
+	signaturePath := opts.ChecksumsPath + ChecksumsSignatureSuffix
+	if helpers.IsURL(opts.ChecksumsPath) {
+		checksumsPath = filepath.Join(tmp, "checksums")
+		if err := utils.DownloadToFile(ctx, opts.ChecksumsPath, checksumsPath, ""); err != nil {
+			return nil, fmt.Errorf("unable to download checksums file %s: %w", opts.ChecksumsPath, err)
+		}
+		if opts.PublicKeyPath != "" {
+			remoteSignature := signaturePath
+			signaturePath = checksumsPath + ChecksumsSignatureSuffix
+			if err := utils.DownloadToFile(ctx, remoteSignature, signaturePath, ""); err != nil {
+				return nil, fmt.Errorf("unable to download checksums signature %s: %w", remoteSignature, err)
+			}
+		}
+	}
+
+	if opts.PublicKeyPath != "" {
+		logger.From(ctx).Debug("verifying checksums signature", "checksums", opts.ChecksumsPath, "key", opts.PublicKeyPath)
+		if err := utils.CosignVerifyBlob(ctx, checksumsPath, signaturePath, opts.PublicKeyPath); err != nil {
+			return nil, exitcode.Wrap(exitcode.Signature, fmt.Errorf("checksums signature did not match the provided key: %w", err))
+		}
+	}
+
+	f, err := os.Open(checksumsPath)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return ParseChecksumManifest(f)
+}
+
+// resolveChecksumShasum loads the checksums file of the options and sets the shasum of the options to the checksum
+// of the package file with the given name, so that the package is verified against it while it is read.
+func resolveChecksumShasum(ctx context.Context, opts *types.ZarfPackageOptions, name string) (err error) {
+	defer func() {
+		if err != nil {
+			emitVerification(ctx, opts, nil, err)
+		}
+	}()
+
+	manifest, err := LoadChecksumManifest(ctx, opts)
+	if err != nil {
+		return err
+	}
+	shasum, err := manifest.Shasum(name)
+	if err != nil {
+		return err
+	}
+	if opts.Shasum != "" && !strings.EqualFold(opts.Shasum, shasum) {
+		return fmt.Errorf("mismatch in CLI options and checksums file, expected %s, found %s", opts.Shasum, shasum)
+	}
+	opts.Shasum = shasum
+	return nil
+}
+
+// verifyChecksumFiles verifies the files against the checksums file of the options before any of them are read.
+func verifyChecksumFiles(ctx context.Context, opts *types.ZarfPackageOptions, files []string) (err error) {
+	names := []string{}
+	defer func() {
+		emitVerification(ctx, opts, names, err)
+	}()
+
+	manifest, err := LoadChecksumManifest(ctx, opts)
+	if err != nil {
+		return err
+	}
+	for _, file := range files {
+		name := filepath.Base(file)
+		shasum, err := manifest.Shasum(name)
+		if err != nil {
+			return err
+		}
+		if err := helpers.SHAsMatch(file, shasum); err != nil {
+			return fmt.Errorf("package part %s failed checksum verification: %w", name, err)
+		}
+		names = append(names, name)
+	}
+	return nil
+}
+
+// emitVerification reports the result of verifying the package source against its checksums file.
+func emitVerification(ctx context.Context, opts *types.ZarfPackageOptions, files []string, err error) {
+	source := opts.PackageSource
+	if source == StdinSource {
+		source = "stdin"
+	}
+	events.From(ctx).Verification(source, opts.ChecksumsPath, opts.PublicKeyPath != "", files, err)
+	if err == nil {
+		logger.From(ctx).Info("verified package against checksums file", "source", source, "checksums", opts.ChecksumsPath, "signed", opts.PublicKeyPath != "")
+	}
+}
@@ -0,0 +1,213 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package sources
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/defenseunicorns/pkg/helpers/v2"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zarf-dev/zarf/src/pkg/events"
+	"github.com/zarf-dev/zarf/src/pkg/layout"
+	"github.com/zarf-dev/zarf/src/pkg/packager/filters"
+	"github.com/zarf-dev/zarf/src/pkg/utils"
+	"github.com/zarf-dev/zarf/src/types"
+)
+
+const (
+	helloChecksum = "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824"
+	worldChecksum = "486ea46224d1bb4fb680f34f7c9ad96a8f24ec88be73ea8e5a6c65260e9cb8a7"
+)
+
+func TestParseChecksumManifest(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		content     string
+		expected    ChecksumManifest
+		expectedErr string
+	}{
+		{
+			name:    "sha256sum format",
+			content: fmt.Sprintf("# checksums\n%s  hello.txt\n%s *dist/world.txt\n\n", helloChecksum, strings.ToUpper(worldChecksum)),
+			expected: ChecksumManifest{
+				"hello.txt": helloChecksum,
+				"world.txt": worldChecksum,
+			},
+		},
+		{
+			name:        "invalid checksum",
+			content:     "abc  hello.txt\n",
+			expectedErr: "invalid checksums line \"abc  hello.txt\"",
+		},
+		{
+			name:        "missing name",
+			content:     helloChecksum + "\n",
+			expectedErr: fmt.Sprintf("invalid checksums line %q", helloChecksum),
+		},
+		{
+			name:        "empty",
+			content:     "# nothing here\n",
+			expectedErr: "checksums file does not contain any checksums",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			manifest, err := ParseChecksumManifest(strings.NewReader(tt.content))
+			if tt.expectedErr != "" {
+				require.EqualError(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, manifest)
+		})
+	}
+}
+
+func TestChecksumManifestShasum(t *testing.T) {
+	t.Parallel()
+
+	manifest := ChecksumManifest{"hello.txt": helloChecksum, "world.txt": worldChecksum}
+	shasum, err := manifest.Shasum("hello.txt")
+	require.NoError(t, err)
+	require.Equal(t, helloChecksum, shasum)
+	_, err = manifest.Shasum("missing.txt")
+	require.EqualError(t, err, "checksums file does not contain a checksum for missing.txt")
+	_, err = manifest.Shasum("")
+	require.EqualError(t, err, "checksums file must contain a single checksum to verify a package without a file name, found 2")
+
+	shasum, err = ChecksumManifest{"hello.txt": helloChecksum}.Shasum("")
+	require.NoError(t, err)
+	require.Equal(t, helloChecksum, shasum)
+}
+
+func TestLoadChecksumManifest(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	dir := t.TempDir()
+	checksumsPath := filepath.Join(dir, "checksums.txt")
+	err := os.WriteFile(checksumsPath, []byte(fmt.Sprintf("%s  hello.txt\n", helloChecksum)), 0o600)
+	require.NoError(t, err)
+
+	_, err = LoadChecksumManifest(ctx, &types.ZarfPackageOptions{ChecksumsPath: checksumsPath})
+	require.ErrorIs(t, err, ErrChecksumsNoKey)
+
+	manifest, err := LoadChecksumManifest(ctx, &types.ZarfPackageOptions{ChecksumsPath: checksumsPath, SkipSignatureValidation: true})
+	require.NoError(t, err)
+	require.Equal(t, ChecksumManifest{"hello.txt": helloChecksum}, manifest)
+
+	_, err = utils.CosignSignBlob(checksumsPath, checksumsPath+ChecksumsSignatureSuffix, "./testdata/cosign.key", func(_ bool) ([]byte, error) {
+		return []byte("test"), nil
+	})
+	require.NoError(t, err)
+	manifest, err = LoadChecksumManifest(ctx, &types.ZarfPackageOptions{ChecksumsPath: checksumsPath, PublicKeyPath: "./testdata/cosign.pub"})
+	require.NoError(t, err)
+	require.Equal(t, ChecksumManifest{"hello.txt": helloChecksum}, manifest)
+
+	// Modifying the checksums file after it was signed fails the verification.
+	err = os.WriteFile(checksumsPath, []byte(fmt.Sprintf("%s  hello.txt\n", worldChecksum)), 0o600)
+	require.NoError(t, err)
+	_, err = LoadChecksumManifest(ctx, &types.ZarfPackageOptions{ChecksumsPath: checksumsPath, PublicKeyPath: "./testdata/cosign.pub"})
+	require.ErrorContains(t, err, "checksums signature did not match the provided key")
+}
+
+func TestSplitTarballSourceChecksums(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	err := os.WriteFile(filepath.Join(dir, "zarf-package-test-amd64.tar.zst.part000"), []byte(`{"sha256sum":"`+helloChecksum+`","bytes":5,"count":1}`), 0o600)
+	require.NoError(t, err)
+	err = os.WriteFile(filepath.Join(dir, "zarf-package-test-amd64.tar.zst.part001"), []byte("hello"), 0o600)
+	require.NoError(t, err)
+	checksums, err := os.Create(filepath.Join(t.TempDir(), "checksums.txt"))
+	require.NoError(t, err)
+	for _, name := range []string{"zarf-package-test-amd64.tar.zst.part000", "zarf-package-test-amd64.tar.zst.part001"} {
+		shasum, err := helpers.GetSHA256OfFile(filepath.Join(dir, name))
+		require.NoError(t, err)
+		_, err = fmt.Fprintf(checksums, "%s  %s\n", shasum, name)
+		require.NoError(t, err)
+	}
+	require.NoError(t, checksums.Close())
+
+	// Modifying a part fails before the package is reassembled.
+	err = os.WriteFile(filepath.Join(dir, "zarf-package-test-amd64.tar.zst.part001"), []byte("world"), 0o600)
+	require.NoError(t, err)
+	buf := &bytes.Buffer{}
+	ctx := events.WithContext(context.Background(), events.NewEmitter(buf))
+	s := &SplitTarballSource{&types.ZarfPackageOptions{
+		PackageSource:           filepath.Join(dir, "zarf-package-test-amd64.tar.zst.part000"),
+		ChecksumsPath:           checksums.Name(),
+		SkipSignatureValidation: true,
+	}}
+	_, err = s.Collect(ctx, t.TempDir())
+	require.ErrorContains(t, err, "package part zarf-package-test-amd64.tar.zst.part001 failed checksum verification")
+	require.FileExists(t, filepath.Join(dir, "zarf-package-test-amd64.tar.zst.part001"))
+	ev := lastEvent(t, buf)
+	require.Equal(t, events.TypeVerification, ev.Type)
+	require.Equal(t, events.StatusFailed, ev.Status)
+	require.Equal(t, []string{"zarf-package-test-amd64.tar.zst.part000"}, ev.Files)
+
+	err = os.WriteFile(filepath.Join(dir, "zarf-package-test-amd64.tar.zst.part001"), []byte("hello"), 0o600)
+	require.NoError(t, err)
+	reassembled, err := s.Collect(ctx, t.TempDir())
+	require.NoError(t, err)
+	b, err := os.ReadFile(reassembled)
+	require.NoError(t, err)
+	require.Equal(t, "hello", string(b))
+	ev = lastEvent(t, buf)
+	require.Equal(t, events.StatusSucceeded, ev.Status)
+	require.Equal(t, checksums.Name(), ev.Checksums)
+	require.False(t, ev.Signed)
+	require.Equal(t, []string{"zarf-package-test-amd64.tar.zst.part000", "zarf-package-test-amd64.tar.zst.part001"}, ev.Files)
+}
+
+func TestStdinPackageSourceChecksums(t *testing.T) {
+	tarball, err := os.ReadFile(filepath.Join("testdata", "zarf-package-wordpress-amd64-16.0.4.tar.zst"))
+	require.NoError(t, err)
+	checksumsPath := filepath.Join(t.TempDir(), "checksums.txt")
+	err = os.WriteFile(checksumsPath, []byte("835b06fc509e639497fb45f45d432e5c4cbd5d84212db5357b16bc69724b0e26  zarf-package-wordpress-amd64-16.0.4.tar.zst\n"), 0o600)
+	require.NoError(t, err)
+
+	buf := &bytes.Buffer{}
+	ctx := events.WithContext(context.Background(), events.NewEmitter(buf))
+	opts := &types.ZarfPackageOptions{PackageSource: StdinSource, ChecksumsPath: checksumsPath, SkipSignatureValidation: true}
+	ps := &StdinPackageSource{ZarfPackageOptions: opts, Stdin: bytes.NewReader(tarball)}
+	_, _, err = ps.LoadPackage(ctx, layout.New(t.TempDir()), filters.Empty(), false)
+	require.NoError(t, err)
+	ev := lastEvent(t, buf)
+	require.Equal(t, events.TypeVerification, ev.Type)
+	require.Equal(t, events.StatusSucceeded, ev.Status)
+	require.Equal(t, "stdin", ev.Source)
+
+	opts = &types.ZarfPackageOptions{PackageSource: StdinSource, ChecksumsPath: checksumsPath, SkipSignatureValidation: true}
+	ps = &StdinPackageSource{ZarfPackageOptions: opts, Stdin: bytes.NewReader(tarball[:len(tarball)-1])}
+	_, _, err = ps.LoadPackage(ctx, layout.New(t.TempDir()), filters.Empty(), false)
+	require.ErrorContains(t, err, "expected sha256 of stdin to be 835b06fc509e639497fb45f45d432e5c4cbd5d84212db5357b16bc69724b0e26")
+	require.Equal(t, events.StatusFailed, lastEvent(t, buf).Status)
+}
+
+func lastEvent(t *testing.T, buf *bytes.Buffer) events.Event {
+	t.Helper()
+	var ev events.Event
+	scanner := bufio.NewScanner(bytes.NewReader(buf.Bytes()))
+	for scanner.Scan() {
+		ev = events.Event{}
+		err := json.Unmarshal(scanner.Bytes(), &ev)
+		require.NoError(t, err)
+	}
+	require.NoError(t, scanner.Err())
+	return ev
+}
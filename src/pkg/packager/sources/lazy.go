@@ -0,0 +1,583 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package sources contains core implementations of the PackageSource interface.
+package sources
+
+import (
+	"archive/tar"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"maps"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/defenseunicorns/pkg/helpers/v2"
+	"github.com/defenseunicorns/pkg/oci"
+	"github.com/mholt/archiver/v3"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/zarf-dev/zarf/src/api/v1alpha1"
+	"github.com/zarf-dev/zarf/src/config"
+	"github.com/zarf-dev/zarf/src/pkg/layout"
+	"github.com/zarf-dev/zarf/src/pkg/logger"
+	"github.com/zarf-dev/zarf/src/pkg/message"
+	"github.com/zarf-dev/zarf/src/pkg/packager/filters"
+	"github.com/zarf-dev/zarf/src/pkg/transform"
+	"github.com/zarf-dev/zarf/src/pkg/utils"
+	"github.com/zarf-dev/zarf/src/pkg/zoci"
+)
+
+// LazyPackageSource is a package source that extracts the contents of each component as it is deployed rather than
+// when the package is loaded, which lowers the peak disk usage of a deployment and the time to its first component.
+type LazyPackageSource interface {
+	PackageSource
+
+	// LoadPackageLazy loads the metadata, SBOMs and image manifests of a package, leaving component tarballs and
+	// image blobs to LoadComponent.
+	LoadPackageLazy(ctx context.Context, dst *layout.PackagePaths, filter filters.ComponentFilterStrategy) (pkg v1alpha1.ZarfPackage, warnings []string, err error)
+
+	// LoadComponent extracts the component and the image blobs it needs, validating their checksums.
+	LoadComponent(ctx context.Context, dst *layout.PackagePaths, component v1alpha1.ZarfComponent) error
+
+	// UnloadComponent removes the contents of a deployed component and the image blobs none of the remaining components need.
+	UnloadComponent(ctx context.Context, dst *layout.PackagePaths, component v1alpha1.ZarfComponent, remaining []v1alpha1.ZarfComponent) error
+}
+
+var (
+	// verify that TarballSource implements LazyPackageSource
+	_ LazyPackageSource = (*TarballSource)(nil)
+	// verify that SplitTarballSource implements LazyPackageSource
+	_ LazyPackageSource = (*SplitTarballSource)(nil)
+	// verify that OCISource implements LazyPackageSource
+	_ LazyPackageSource = (*OCISource)(nil)
+)
+
+// LoadPackageLazy loads the metadata of a package from a tarball.
+func (s *TarballSource) LoadPackageLazy(ctx context.Context, dst *layout.PackagePaths, filter filters.ComponentFilterStrategy) (pkg v1alpha1.ZarfPackage, warnings []string, err error) {
+	l := logger.From(ctx)
+	spinner := message.NewProgressSpinner("Loading package from %q", s.PackageSource)
+	defer spinner.Stop()
+	start := time.Now()
+	l.Info("loading package", "source", s.PackageSource, "lazy", true)
+
+	if s.Shasum != "" {
+		if err := helpers.SHAsMatch(s.PackageSource, s.Shasum); err != nil {
+			return pkg, nil, err
+		}
+	}
+
+	pathsExtracted := []string{}
+	err = archiver.Walk(s.PackageSource, func(f archiver.File) error {
+		name := archiveName(f)
+		if isLazyPath(name) {
+			return nil
+		}
+		path, err := extractFile(f, dst.Base)
+		if err != nil || path == "" {
+			return err
+		}
+		pathsExtracted = append(pathsExtracted, name)
+		return nil
+	})
+	if err != nil {
+		return pkg, nil, err
+	}
+
+	// The image manifests follow the index in a second pass as the blobs come before the index in the tarball.
+	if slices.Contains(pathsExtracted, filepath.ToSlash(layout.IndexPath)) {
+		index, err := readImageIndex(filepath.Join(dst.Base, layout.IndexPath))
+		if err != nil {
+			return pkg, nil, err
+		}
+		manifests := map[string]bool{}
+		for _, desc := range index.Manifests {
+			manifests[path.Join(filepath.ToSlash(layout.ImagesBlobsDir), desc.Digest.Encoded())] = true
+		}
+		paths, err := extractArchivePaths(s.PackageSource, dst.Base, manifests)
+		if err != nil {
+			return pkg, nil, err
+		}
+		pathsExtracted = append(pathsExtracted, paths...)
+
+		// The manifests of the platforms kept for an image follow the image indexes that list them.
+		platformManifests, err := imagePlatformManifests(dst, index)
+		if err != nil {
+			return pkg, nil, err
+		}
+		for name := range platformManifests {
+			if slices.Contains(pathsExtracted, name) {
+				delete(platformManifests, name)
+			}
+		}
+		paths, err = extractArchivePaths(s.PackageSource, dst.Base, platformManifests)
+		if err != nil {
+			return pkg, nil, err
+		}
+		pathsExtracted = append(pathsExtracted, paths...)
+	}
+
+	pkg, warnings, err = loadExtractedPackage(ctx, dst, pathsExtracted, filter, false, true, s.ZarfPackageOptions)
+	if err != nil {
+		return pkg, nil, err
+	}
+	if dst.SBOMs.Path != "" {
+		if err := dst.SBOMs.Unarchive(); err != nil {
+			return pkg, nil, err
+		}
+	}
+
+	spinner.Success()
+	l.Debug("done loading package", "source", s.PackageSource, "duration", time.Since(start))
+	return pkg, warnings, nil
+}
+
+// LoadComponent extracts a component and its image blobs from a tarball.
+func (s *TarballSource) LoadComponent(ctx context.Context, dst *layout.PackagePaths, component v1alpha1.ZarfComponent) error {
+	start := time.Now()
+
+	checksums, wanted, err := componentPaths(dst, component)
+	if err != nil {
+		return err
+	}
+	paths, err := extractArchivePaths(s.PackageSource, dst.Base, wanted)
+	if err != nil {
+		return err
+	}
+	if err := loadComponentPaths(dst, component, checksums, paths); err != nil {
+		return err
+	}
+	logger.From(ctx).Debug("loaded component", "name", component.Name, "files", len(paths), "duration", time.Since(start))
+	return nil
+}
+
+// UnloadComponent removes a deployed component and the image blobs only it needed.
+func (s *TarballSource) UnloadComponent(ctx context.Context, dst *layout.PackagePaths, component v1alpha1.ZarfComponent, remaining []v1alpha1.ZarfComponent) error {
+	return unloadComponent(ctx, dst, component, remaining)
+}
+
+// LoadPackageLazy reassembles a split tarball and loads its metadata.
+func (s *SplitTarballSource) LoadPackageLazy(ctx context.Context, dst *layout.PackagePaths, filter filters.ComponentFilterStrategy) (pkg v1alpha1.ZarfPackage, warnings []string, err error) {
+	tb, err := s.Collect(ctx, filepath.Dir(s.PackageSource))
+	if err != nil {
+		return pkg, nil, err
+	}
+
+	// Update the package source to the reassembled tarball so that components are loaded from it
+	s.PackageSource = tb
+	// Clear the shasum so it is not used for validation
+	s.Shasum = ""
+
+	ts := &TarballSource{
+		s.ZarfPackageOptions,
+	}
+	return ts.LoadPackageLazy(ctx, dst, filter)
+}
+
+// LoadComponent extracts a component and its image blobs from the reassembled tarball.
+func (s *SplitTarballSource) LoadComponent(ctx context.Context, dst *layout.PackagePaths, component v1alpha1.ZarfComponent) error {
+	ts := &TarballSource{
+		s.ZarfPackageOptions,
+	}
+	return ts.LoadComponent(ctx, dst, component)
+}
+
+// UnloadComponent removes a deployed component and the image blobs only it needed.
+func (s *SplitTarballSource) UnloadComponent(ctx context.Context, dst *layout.PackagePaths, component v1alpha1.ZarfComponent, remaining []v1alpha1.ZarfComponent) error {
+	ts := &TarballSource{
+		s.ZarfPackageOptions,
+	}
+	return ts.UnloadComponent(ctx, dst, component, remaining)
+}
+
+// LoadPackageLazy pulls the metadata, SBOMs and image manifests of a package from an OCI registry, the layers of the
+// components are only pulled once they are selected and deployed.
+func (s *OCISource) LoadPackageLazy(ctx context.Context, dst *layout.PackagePaths, filter filters.ComponentFilterStrategy) (pkg v1alpha1.ZarfPackage, warnings []string, err error) {
+	l := logger.From(ctx)
+	start := time.Now()
+	l.Info("loading package", "source", s.PackageSource, "lazy", true)
+
+	root, err := s.FetchRoot(ctx)
+	if err != nil {
+		return pkg, nil, err
+	}
+	metadata := slices.Concat(zoci.PackageAlwaysPull, []string{layout.SBOMTar, layout.IndexPath, layout.OCILayoutPath})
+	pathsPulled, err := s.pullPaths(ctx, root, dst, metadata)
+	if err != nil {
+		return pkg, nil, err
+	}
+
+	if slices.Contains(pathsPulled, filepath.ToSlash(layout.IndexPath)) {
+		index, err := readImageIndex(filepath.Join(dst.Base, layout.IndexPath))
+		if err != nil {
+			return pkg, nil, err
+		}
+		manifests := []string{}
+		for _, desc := range index.Manifests {
+			manifests = append(manifests, path.Join(filepath.ToSlash(layout.ImagesBlobsDir), desc.Digest.Encoded()))
+		}
+		paths, err := s.pullPaths(ctx, root, dst, manifests)
+		if err != nil {
+			return pkg, nil, err
+		}
+		pathsPulled = append(pathsPulled, paths...)
+
+		// The manifests of the platforms kept for an image are listed by the image indexes pulled above.
+		platformManifests, err := imagePlatformManifests(dst, index)
+		if err != nil {
+			return pkg, nil, err
+		}
+		manifests = []string{}
+		for name := range platformManifests {
+			if !slices.Contains(pathsPulled, name) {
+				manifests = append(manifests, name)
+			}
+		}
+		paths, err = s.pullPaths(ctx, root, dst, manifests)
+		if err != nil {
+			return pkg, nil, err
+		}
+		pathsPulled = append(pathsPulled, paths...)
+	}
+
+	pkg, warnings, err = loadExtractedPackage(ctx, dst, pathsPulled, filter, false, true, s.ZarfPackageOptions)
+	if err != nil {
+		return pkg, nil, err
+	}
+	if dst.SBOMs.Path != "" {
+		if err := dst.SBOMs.Unarchive(); err != nil {
+			return pkg, nil, err
+		}
+	}
+
+	l.Debug("done loading package", "source", s.PackageSource, "duration", time.Since(start))
+	return pkg, warnings, nil
+}
+
+// LoadComponent pulls the layers of a component and its image blobs from an OCI registry.
+func (s *OCISource) LoadComponent(ctx context.Context, dst *layout.PackagePaths, component v1alpha1.ZarfComponent) error {
+	start := time.Now()
+
+	checksums, wanted, err := componentPaths(dst, component)
+	if err != nil {
+		return err
+	}
+	root, err := s.FetchRoot(ctx)
+	if err != nil {
+		return err
+	}
+	paths, err := s.pullPaths(ctx, root, dst, slices.Sorted(maps.Keys(wanted)))
+	if err != nil {
+		return err
+	}
+	if len(paths) != len(wanted) {
+		for name := range wanted {
+			if !slices.Contains(paths, name) {
+				return fmt.Errorf("package does not contain %s", name)
+			}
+		}
+	}
+	if err := loadComponentPaths(dst, component, checksums, paths); err != nil {
+		return err
+	}
+	logger.From(ctx).Debug("loaded component", "name", component.Name, "layers", len(paths), "duration", time.Since(start))
+	return nil
+}
+
+// UnloadComponent removes a deployed component and the image blobs only it needed.
+func (s *OCISource) UnloadComponent(ctx context.Context, dst *layout.PackagePaths, component v1alpha1.ZarfComponent, remaining []v1alpha1.ZarfComponent) error {
+	return unloadComponent(ctx, dst, component, remaining)
+}
+
+// pullPaths pulls the layers of the package at the given slash separated paths into dst, skipping the paths that are
+// not in the package, and returns the paths that were pulled.
+func (s *OCISource) pullPaths(ctx context.Context, root *oci.Manifest, dst *layout.PackagePaths, paths []string) ([]string, error) {
+	pulled := []string{}
+	layers := []ocispec.Descriptor{}
+	for _, name := range paths {
+		desc := root.Locate(name)
+		if oci.IsEmptyDescriptor(desc) {
+			continue
+		}
+		pulled = append(pulled, name)
+		layers = append(layers, desc)
+	}
+	if len(layers) == 0 {
+		return pulled, nil
+	}
+	if err := s.PullLayers(ctx, dst.Base, config.CommonOptions.OCIConcurrency, layers); err != nil {
+		return nil, fmt.Errorf("unable to pull the package layers: %w", err)
+	}
+	return pulled, nil
+}
+
+// componentPaths returns the checksums of the package and the slash separated paths of the component tarball and
+// the image blobs of the component that are not loaded yet.
+func componentPaths(dst *layout.PackagePaths, component v1alpha1.ZarfComponent) (map[string]string, map[string]bool, error) {
+	checksums, err := readChecksums(dst)
+	if err != nil {
+		return nil, nil, err
+	}
+	wanted := map[string]bool{}
+	// Empty components are not archived into the package.
+	tarball := path.Join(layout.ComponentsDir, fmt.Sprintf("%s.tar", component.Name))
+	if _, ok := checksums[tarball]; ok {
+		wanted[tarball] = true
+	}
+	blobs, err := imageBlobs(dst, []v1alpha1.ZarfComponent{component})
+	if err != nil {
+		return nil, nil, err
+	}
+	for blob := range blobs {
+		if !helpers.InvalidPath(filepath.Join(dst.Base, layout.ImagesBlobsDir, blob)) {
+			continue
+		}
+		wanted[path.Join(filepath.ToSlash(layout.ImagesBlobsDir), blob)] = true
+	}
+	return checksums, wanted, nil
+}
+
+// loadComponentPaths validates the checksums of the loaded paths of a component and unarchives the component.
+func loadComponentPaths(dst *layout.PackagePaths, component v1alpha1.ZarfComponent, checksums map[string]string, paths []string) error {
+	if err := validateChecksums(dst, checksums, paths); err != nil {
+		return err
+	}
+	dst.SetFromPaths(paths)
+
+	if err := dst.Components.Unarchive(component); err != nil {
+		if !errors.Is(err, layout.ErrNotLoaded) {
+			return err
+		}
+		if _, err := dst.Components.Create(component); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// unloadComponent removes a deployed component and the image blobs none of the remaining components need.
+func unloadComponent(ctx context.Context, dst *layout.PackagePaths, component v1alpha1.ZarfComponent, remaining []v1alpha1.ZarfComponent) error {
+	if dir, ok := dst.Components.Dirs[component.Name]; ok {
+		if err := os.RemoveAll(dir.Base); err != nil {
+			return err
+		}
+		delete(dst.Components.Dirs, component.Name)
+	}
+
+	blobs, err := imageBlobs(dst, []v1alpha1.ZarfComponent{component})
+	if err != nil {
+		return err
+	}
+	needed, err := imageBlobs(dst, remaining)
+	if err != nil {
+		return err
+	}
+	removed := 0
+	for blob := range blobs {
+		if needed[blob] {
+			continue
+		}
+		blobPath := filepath.Join(dst.Base, layout.ImagesBlobsDir, blob)
+		if err := os.Remove(blobPath); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+		dst.Images.Blobs = slices.DeleteFunc(dst.Images.Blobs, func(b string) bool { return b == blobPath })
+		removed++
+	}
+	logger.From(ctx).Debug("unloaded component", "name", component.Name, "blobsRemoved", removed)
+	return nil
+}
+
+// isLazyPath returns true for the files of a package tarball that are extracted when their component is loaded.
+func isLazyPath(name string) bool {
+	if strings.HasPrefix(name, filepath.ToSlash(layout.ImagesBlobsDir)+"/") {
+		return true
+	}
+	return strings.HasPrefix(name, layout.ComponentsDir+"/") && path.Ext(name) == ".tar"
+}
+
+// archiveName returns the slash separated name of a file in a package tarball.
+func archiveName(f archiver.File) string {
+	header, ok := f.Header.(*tar.Header)
+	if !ok {
+		return ""
+	}
+	return path.Clean(header.Name)
+}
+
+// extractArchivePaths extracts the wanted files of the package tarball into dir in a single pass, stopping once all
+// of them were found.
+func extractArchivePaths(archive, dir string, wanted map[string]bool) ([]string, error) {
+	paths := []string{}
+	if len(wanted) == 0 {
+		return paths, nil
+	}
+	err := archiver.Walk(archive, func(f archiver.File) error {
+		name := archiveName(f)
+		if !wanted[name] {
+			return nil
+		}
+		p, err := extractFile(f, dir)
+		if err != nil || p == "" {
+			return err
+		}
+		paths = append(paths, name)
+		if len(paths) == len(wanted) {
+			return archiver.ErrStopWalk
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(paths) != len(wanted) {
+		for name := range wanted {
+			if !slices.Contains(paths, name) {
+				return nil, fmt.Errorf("package does not contain %s", name)
+			}
+		}
+	}
+	return paths, nil
+}
+
+// readChecksums returns the checksums of the package files keyed by their slash separated path.
+func readChecksums(dst *layout.PackagePaths) (map[string]string, error) {
+	checksums := map[string]string{}
+	err := lineByLine(dst.Checksums, func(line string) error {
+		if line == "" {
+			return nil
+		}
+		sha, rel, ok := strings.Cut(line, " ")
+		if !ok || sha == "" || rel == "" {
+			return fmt.Errorf("invalid checksum line: %s", line)
+		}
+		checksums[rel] = sha
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return checksums, nil
+}
+
+// validateChecksums validates the files at the given paths, relative to the package, against the package checksums.
+func validateChecksums(dst *layout.PackagePaths, checksums map[string]string, paths []string) error {
+	for _, rel := range paths {
+		sha, ok := checksums[filepath.ToSlash(rel)]
+		if !ok {
+			return fmt.Errorf("unable to validate checksums, %s is not in %s", rel, layout.Checksums)
+		}
+		if err := helpers.SHAsMatch(filepath.Join(dst.Base, rel), sha); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// imageBlobs returns the digests of the config and layer blobs of the images of the components.
+func imageBlobs(dst *layout.PackagePaths, components []v1alpha1.ZarfComponent) (map[string]bool, error) {
+	blobs := map[string]bool{}
+	images := []string{}
+	for _, component := range components {
+		images = append(images, component.Images...)
+	}
+	if len(images) == 0 {
+		return blobs, nil
+	}
+	if dst.Images.Index == "" {
+		return nil, errors.New("package does not contain an image index")
+	}
+	index, err := readImageIndex(dst.Images.Index)
+	if err != nil {
+		return nil, err
+	}
+	for _, image := range images {
+		refInfo, err := transform.ParseImageRef(image)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse image ref %q: %w", image, err)
+		}
+		desc := helpers.Find(index.Manifests, func(desc ocispec.Descriptor) bool {
+			return desc.Annotations[ocispec.AnnotationBaseImageName] == refInfo.Reference ||
+				// A backwards compatibility shim for older Zarf versions that would leave docker.io off of image annotations
+				(desc.Annotations[ocispec.AnnotationBaseImageName] == refInfo.Path+refInfo.TagOrDigest && refInfo.Host == "docker.io")
+		})
+		if desc.Digest == "" {
+			return nil, fmt.Errorf("image %s is not in the package", image)
+		}
+		b, err := os.ReadFile(filepath.Join(dst.Base, layout.ImagesBlobsDir, desc.Digest.Encoded()))
+		if err != nil {
+			return nil, err
+		}
+		var manifest ocispec.Manifest
+		if err := json.Unmarshal(b, &manifest); err != nil {
+			return nil, err
+		}
+		blobs[manifest.Config.Digest.Encoded()] = true
+		for _, layer := range manifest.Layers {
+			blobs[layer.Digest.Encoded()] = true
+		}
+
+		indexDesc := helpers.Find(index.Manifests, func(desc ocispec.Descriptor) bool {
+			return desc.Annotations[utils.ImageIndexAnnotation] == refInfo.Reference
+		})
+		if indexDesc.Digest == "" {
+			continue
+		}
+		imageIndex, err := readImageIndex(filepath.Join(dst.Base, layout.ImagesBlobsDir, indexDesc.Digest.Encoded()))
+		if err != nil {
+			return nil, err
+		}
+		for _, platformDesc := range imageIndex.Manifests {
+			b, err := os.ReadFile(filepath.Join(dst.Base, layout.ImagesBlobsDir, platformDesc.Digest.Encoded()))
+			if err != nil {
+				return nil, err
+			}
+			var platformManifest ocispec.Manifest
+			if err := json.Unmarshal(b, &platformManifest); err != nil {
+				return nil, err
+			}
+			blobs[platformManifest.Config.Digest.Encoded()] = true
+			for _, layer := range platformManifest.Layers {
+				blobs[layer.Digest.Encoded()] = true
+			}
+		}
+	}
+	return blobs, nil
+}
+
+// imagePlatformManifests returns the slash separated paths of the manifests of the platforms kept for images, which
+// are listed by the image indexes of the package image index.
+func imagePlatformManifests(dst *layout.PackagePaths, index ocispec.Index) (map[string]bool, error) {
+	manifests := map[string]bool{}
+	for _, desc := range index.Manifests {
+		if desc.Annotations[utils.ImageIndexAnnotation] == "" {
+			continue
+		}
+		imageIndex, err := readImageIndex(filepath.Join(dst.Base, layout.ImagesBlobsDir, desc.Digest.Encoded()))
+		if err != nil {
+			return nil, err
+		}
+		for _, platformDesc := range imageIndex.Manifests {
+			manifests[path.Join(filepath.ToSlash(layout.ImagesBlobsDir), platformDesc.Digest.Encoded())] = true
+		}
+	}
+	return manifests, nil
+}
+
+func readImageIndex(indexPath string) (ocispec.Index, error) {
+	var index ocispec.Index
+	b, err := os.ReadFile(indexPath)
+	if err != nil {
+		return index, err
+	}
+	if err := json.Unmarshal(b, &index); err != nil {
+		return index, fmt.Errorf("unable to read the image index: %w", err)
+	}
+	return index, nil
+}
@@ -0,0 +1,253 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package sources
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/defenseunicorns/pkg/helpers/v2"
+	"github.com/defenseunicorns/pkg/oci"
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zarf-dev/zarf/src/api/v1alpha1"
+	"github.com/zarf-dev/zarf/src/pkg/layout"
+	"github.com/zarf-dev/zarf/src/pkg/packager/filters"
+	"github.com/zarf-dev/zarf/src/pkg/utils"
+	"github.com/zarf-dev/zarf/src/pkg/zoci"
+	"github.com/zarf-dev/zarf/src/test/testutil"
+	"github.com/zarf-dev/zarf/src/types"
+)
+
+// writeBlob writes content to the blobs of the image layout in dir and returns its descriptor.
+func writeBlob(t *testing.T, dir string, mediaType string, content []byte) ocispec.Descriptor {
+	t.Helper()
+	sum := sha256.Sum256(content)
+	desc := ocispec.Descriptor{
+		MediaType: mediaType,
+		Digest:    digest.NewDigestFromEncoded(digest.SHA256, hex.EncodeToString(sum[:])),
+		Size:      int64(len(content)),
+	}
+	err := os.WriteFile(filepath.Join(dir, layout.ImagesBlobsDir, desc.Digest.Encoded()), content, helpers.ReadWriteUser)
+	require.NoError(t, err)
+	return desc
+}
+
+// createLazyTestPackage creates a package with two components whose images share a layer and an empty component.
+func createLazyTestPackage(t *testing.T) (string, map[string]ocispec.Descriptor) {
+	t.Helper()
+
+	dir := t.TempDir()
+	err := os.MkdirAll(filepath.Join(dir, layout.ImagesBlobsDir), helpers.ReadWriteExecuteUser)
+	require.NoError(t, err)
+	blobs := map[string]ocispec.Descriptor{
+		"shared":  writeBlob(t, dir, ocispec.MediaTypeImageLayer, []byte("shared layer")),
+		"a":       writeBlob(t, dir, ocispec.MediaTypeImageLayer, []byte("a layer")),
+		"b":       writeBlob(t, dir, ocispec.MediaTypeImageLayer, []byte("b layer")),
+		"configA": writeBlob(t, dir, ocispec.MediaTypeImageConfig, []byte(`{"architecture":"amd64","os":"linux","config":{"Labels":{"image":"a"}}}`)),
+		"configB": writeBlob(t, dir, ocispec.MediaTypeImageConfig, []byte(`{"architecture":"amd64","os":"linux","config":{"Labels":{"image":"b"}}}`)),
+	}
+	index := ocispec.Index{MediaType: ocispec.MediaTypeImageIndex}
+	index.SchemaVersion = 2
+	for _, image := range []struct{ name, ref, layer string }{{"a", "ghcr.io/zarf-dev/a:1.0.0", "a"}, {"b", "ghcr.io/zarf-dev/b:1.0.0", "b"}} {
+		manifest := ocispec.Manifest{
+			MediaType: ocispec.MediaTypeImageManifest,
+			Config:    blobs["config"+strings.ToUpper(image.name)],
+			Layers:    []ocispec.Descriptor{blobs["shared"], blobs[image.layer]},
+		}
+		manifest.SchemaVersion = 2
+		b, err := json.Marshal(manifest)
+		require.NoError(t, err)
+		desc := writeBlob(t, dir, ocispec.MediaTypeImageManifest, b)
+		desc.Annotations = map[string]string{ocispec.AnnotationBaseImageName: image.ref}
+		blobs["manifest"+strings.ToUpper(image.name)] = desc
+		index.Manifests = append(index.Manifests, desc)
+	}
+	b, err := json.Marshal(index)
+	require.NoError(t, err)
+	err = os.WriteFile(filepath.Join(dir, layout.IndexPath), b, helpers.ReadWriteUser)
+	require.NoError(t, err)
+	err = os.WriteFile(filepath.Join(dir, layout.OCILayoutPath), []byte(`{"imageLayoutVersion":"1.0.0"}`), helpers.ReadWriteUser)
+	require.NoError(t, err)
+
+	paths := []string{filepath.ToSlash(layout.IndexPath), filepath.ToSlash(layout.OCILayoutPath)}
+	for _, desc := range blobs {
+		paths = append(paths, filepath.ToSlash(filepath.Join(layout.ImagesBlobsDir, desc.Digest.Encoded())))
+	}
+	for _, name := range []string{"a", "b"} {
+		componentDir := filepath.Join(t.TempDir(), name)
+		err := os.MkdirAll(filepath.Join(componentDir, layout.FilesDir, "0"), helpers.ReadWriteExecuteUser)
+		require.NoError(t, err)
+		err = os.WriteFile(filepath.Join(componentDir, layout.FilesDir, "0", "hello"), []byte(name), helpers.ReadWriteUser)
+		require.NoError(t, err)
+		err = helpers.CreateDirectory(filepath.Join(dir, layout.ComponentsDir), helpers.ReadWriteExecuteUser)
+		require.NoError(t, err)
+		err = helpers.CreateReproducibleTarballFromDir(componentDir, name, filepath.Join(dir, layout.ComponentsDir, name+".tar"))
+		require.NoError(t, err)
+		paths = append(paths, layout.ComponentsDir+"/"+name+".tar")
+	}
+
+	pp := layout.New(dir)
+	pp.SetFromPaths(paths)
+	pp.Checksums = filepath.Join(dir, layout.Checksums)
+	checksum, err := pp.GenerateChecksums()
+	require.NoError(t, err)
+	pkg := v1alpha1.ZarfPackage{
+		Kind:     v1alpha1.ZarfPackageConfig,
+		Metadata: v1alpha1.ZarfMetadata{Name: "lazy", AggregateChecksum: checksum},
+		Build:    v1alpha1.ZarfBuildData{Architecture: "amd64"},
+		Components: []v1alpha1.ZarfComponent{
+			{Name: "a", Required: helpers.BoolPtr(true), Images: []string{"ghcr.io/zarf-dev/a:1.0.0"}, Files: []v1alpha1.ZarfFile{{Source: "hello", Target: "hello"}}},
+			{Name: "b", Required: helpers.BoolPtr(true), Images: []string{"ghcr.io/zarf-dev/b:1.0.0"}, Files: []v1alpha1.ZarfFile{{Source: "hello", Target: "hello"}}},
+			{Name: "c", Required: helpers.BoolPtr(true)},
+		},
+	}
+	err = utils.WriteYaml(filepath.Join(dir, layout.ZarfYAML), pkg, helpers.ReadWriteUser)
+	require.NoError(t, err)
+
+	tarPath := filepath.Join(t.TempDir(), "zarf-package-lazy-amd64.tar")
+	err = pp.ArchivePackage(context.Background(), tarPath, 0)
+	require.NoError(t, err)
+	return tarPath, blobs
+}
+
+func TestTarballSourceLazy(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	tarPath, blobs := createLazyTestPackage(t)
+	blobPath := func(dst *layout.PackagePaths, name string) string {
+		return filepath.Join(dst.Base, layout.ImagesBlobsDir, blobs[name].Digest.Encoded())
+	}
+
+	ts := &TarballSource{&types.ZarfPackageOptions{PackageSource: tarPath, SkipSignatureValidation: true}}
+	dst := layout.New(t.TempDir())
+	pkg, _, err := ts.LoadPackageLazy(ctx, dst, filters.Empty())
+	require.NoError(t, err)
+	require.Len(t, pkg.Components, 3)
+	require.NoDirExists(t, filepath.Join(dst.Base, layout.ComponentsDir))
+	require.FileExists(t, blobPath(dst, "manifestA"))
+	require.FileExists(t, blobPath(dst, "manifestB"))
+	for _, name := range []string{"shared", "a", "b", "configA", "configB"} {
+		require.NoFileExists(t, blobPath(dst, name))
+	}
+
+	err = ts.LoadComponent(ctx, dst, pkg.Components[0])
+	require.NoError(t, err)
+	require.FileExists(t, filepath.Join(dst.Components.Dirs["a"].Files, "0", "hello"))
+	require.NoFileExists(t, filepath.Join(dst.Base, layout.ComponentsDir, "a.tar"))
+	for _, name := range []string{"shared", "a", "configA"} {
+		require.FileExists(t, blobPath(dst, name))
+	}
+	require.NoFileExists(t, blobPath(dst, "b"))
+
+	err = ts.UnloadComponent(ctx, dst, pkg.Components[0], pkg.Components[1:])
+	require.NoError(t, err)
+	require.NoDirExists(t, filepath.Join(dst.Base, layout.ComponentsDir, "a"))
+	require.NotContains(t, dst.Components.Dirs, "a")
+	require.NoFileExists(t, blobPath(dst, "a"))
+	require.NoFileExists(t, blobPath(dst, "configA"))
+	require.FileExists(t, blobPath(dst, "shared"))
+	require.FileExists(t, blobPath(dst, "manifestA"))
+
+	err = ts.LoadComponent(ctx, dst, pkg.Components[1])
+	require.NoError(t, err)
+	require.FileExists(t, filepath.Join(dst.Components.Dirs["b"].Files, "0", "hello"))
+	for _, name := range []string{"shared", "b", "configB"} {
+		require.FileExists(t, blobPath(dst, name))
+	}
+
+	err = ts.LoadComponent(ctx, dst, pkg.Components[2])
+	require.NoError(t, err)
+	require.DirExists(t, dst.Components.Dirs["c"].Base)
+}
+
+func TestTarballSourceLazyChecksumMismatch(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	tarPath, _ := createLazyTestPackage(t)
+	ts := &TarballSource{&types.ZarfPackageOptions{PackageSource: tarPath, SkipSignatureValidation: true}}
+	dst := layout.New(t.TempDir())
+	pkg, _, err := ts.LoadPackageLazy(ctx, dst, filters.Empty())
+	require.NoError(t, err)
+
+	// The checksums were validated when the package was loaded, changing them afterwards stands in for a modified tarball.
+	b, err := os.ReadFile(dst.Checksums)
+	require.NoError(t, err)
+	lines := strings.Split(string(b), "\n")
+	for i, line := range lines {
+		if strings.HasSuffix(line, " components/a.tar") {
+			lines[i] = strings.Repeat("0", 64) + " components/a.tar"
+		}
+	}
+	err = os.WriteFile(dst.Checksums, []byte(strings.Join(lines, "\n")), helpers.ReadWriteUser)
+	require.NoError(t, err)
+
+	err = ts.LoadComponent(ctx, dst, pkg.Components[0])
+	require.ErrorContains(t, err, "to be "+strings.Repeat("0", 64))
+}
+
+func TestOCISourceLazy(t *testing.T) {
+	t.Parallel()
+	ctx := testutil.TestContext(t)
+
+	tarPath, blobs := createLazyTestPackage(t)
+	blobPath := func(dst *layout.PackagePaths, name string) string {
+		return filepath.Join(dst.Base, layout.ImagesBlobsDir, blobs[name].Digest.Encoded())
+	}
+
+	ts := &TarballSource{&types.ZarfPackageOptions{PackageSource: tarPath, SkipSignatureValidation: true}}
+	loaded := layout.New(t.TempDir())
+	pkg, _, err := ts.LoadPackage(ctx, loaded, filters.Empty(), false)
+	require.NoError(t, err)
+	port, err := helpers.GetAvailablePort()
+	require.NoError(t, err)
+	registryURL := testutil.SetupInMemoryRegistry(ctx, t, port)
+	remote, err := zoci.NewRemote(ctx, fmt.Sprintf("%s/lazy:0.0.1", registryURL), oci.PlatformForArch("amd64"), oci.WithPlainHTTP(true))
+	require.NoError(t, err)
+	err = remote.PublishPackage(ctx, &pkg, loaded, 3)
+	require.NoError(t, err)
+
+	s := &OCISource{&types.ZarfPackageOptions{PackageSource: "oci://" + registryURL + "/lazy:0.0.1", SkipSignatureValidation: true}, remote}
+	dst := layout.New(t.TempDir())
+	pkg, _, err = s.LoadPackageLazy(ctx, dst, filters.Empty())
+	require.NoError(t, err)
+	require.Len(t, pkg.Components, 3)
+	require.NoDirExists(t, filepath.Join(dst.Base, layout.ComponentsDir))
+	require.FileExists(t, blobPath(dst, "manifestA"))
+	require.FileExists(t, blobPath(dst, "manifestB"))
+	for _, name := range []string{"shared", "a", "b", "configA", "configB"} {
+		require.NoFileExists(t, blobPath(dst, name))
+	}
+
+	// Only the layers of the selected components are pulled.
+	err = s.LoadComponent(ctx, dst, pkg.Components[1])
+	require.NoError(t, err)
+	require.FileExists(t, filepath.Join(dst.Components.Dirs["b"].Files, "0", "hello"))
+	require.NoFileExists(t, filepath.Join(dst.Base, layout.ComponentsDir, "a.tar"))
+	for _, name := range []string{"shared", "b", "configB"} {
+		require.FileExists(t, blobPath(dst, name))
+	}
+	require.NoFileExists(t, blobPath(dst, "a"))
+	require.NoFileExists(t, blobPath(dst, "configA"))
+
+	err = s.UnloadComponent(ctx, dst, pkg.Components[1], pkg.Components[2:])
+	require.NoError(t, err)
+	require.NotContains(t, dst.Components.Dirs, "b")
+	require.NoFileExists(t, blobPath(dst, "shared"))
+
+	err = s.LoadComponent(ctx, dst, pkg.Components[2])
+	require.NoError(t, err)
+	require.DirExists(t, dst.Components.Dirs["c"].Base)
+}
@@ -8,6 +8,7 @@ import (
 	"context"
 	"fmt"
 	"net/url"
+	"os"
 	"strings"
 
 	"github.com/defenseunicorns/pkg/helpers/v2"
@@ -42,6 +43,10 @@ type PackageSource interface {
 
 // Identify returns the type of package source based on the provided package source string.
 func Identify(pkgSrc string) string {
+	if pkgSrc == StdinSource {
+		return "stdin"
+	}
+
 	if helpers.IsURL(pkgSrc) {
 		parsed, _ := url.Parse(pkgSrc)
 		return parsed.Scheme
@@ -81,6 +86,8 @@ func New(ctx context.Context, pkgOpts *types.ZarfPackageOptions) (PackageSource,
 		source = &URLSource{pkgOpts}
 	case "split":
 		source = &SplitTarballSource{pkgOpts}
+	case "stdin":
+		source = &StdinPackageSource{ZarfPackageOptions: pkgOpts, Stdin: os.Stdin}
 	default:
 		return nil, fmt.Errorf("could not identify source type for %q", pkgSrc)
 	}
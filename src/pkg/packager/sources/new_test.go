@@ -86,6 +86,12 @@ func TestNewPackageSource(t *testing.T) {
 			expectedIdentify: "split",
 			expectedType:     &SplitTarballSource{},
 		},
+		{
+			name:             "stdin",
+			src:              "-",
+			expectedIdentify: "stdin",
+			expectedType:     &StdinPackageSource{},
+		},
 	}
 	for _, tt := range tests {
 		tt := tt
@@ -152,6 +158,12 @@ func TestPackageSource(t *testing.T) {
 			shasum:      "835b06fc509e639497fb45f45d432e5c4cbd5d84212db5357b16bc69724b0e26",
 			expectedErr: "",
 		},
+		{
+			name:        "http-shasum-mismatch",
+			src:         fmt.Sprintf("%s/zarf-package-wordpress-amd64-16.0.4.tar.zst", ts.URL),
+			shasum:      "abc",
+			expectedErr: fmt.Sprintf("expected sha256 of %s/zarf-package-wordpress-amd64-16.0.4.tar.zst to be abc, found 835b06fc509e639497fb45f45d432e5c4cbd5d84212db5357b16bc69724b0e26", ts.URL),
+		},
 		{
 			name:        "http-insecure",
 			src:         fmt.Sprintf("%s/zarf-package-wordpress-amd64-16.0.4.tar.zst", ts.URL),
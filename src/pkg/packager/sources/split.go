@@ -44,6 +44,13 @@ func (s *SplitTarballSource) Collect(ctx context.Context, dir string) (string, e
 	// Ensure the files are in order so they are appended in the correct order
 	sort.Strings(fileList)
 
+	// Verify every part before any of them is read, so that a modified part fails before the package is reassembled.
+	if s.ChecksumsPath != "" {
+		if err := verifyChecksumFiles(ctx, s.ZarfPackageOptions, fileList); err != nil {
+			return "", err
+		}
+	}
+
 	reassembled := filepath.Join(dir, filepath.Base(strings.Replace(s.PackageSource, ".part000", "", 1)))
 	// Create the new package
 	pkgFile, err := os.Create(reassembled)
@@ -0,0 +1,318 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package sources contains core implementations of the PackageSource interface.
+package sources
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/defenseunicorns/pkg/helpers/v2"
+	"github.com/mholt/archiver/v3"
+	"github.com/zarf-dev/zarf/src/api/v1alpha1"
+	"github.com/zarf-dev/zarf/src/config"
+	"github.com/zarf-dev/zarf/src/pkg/layout"
+	"github.com/zarf-dev/zarf/src/pkg/logger"
+	"github.com/zarf-dev/zarf/src/pkg/message"
+	"github.com/zarf-dev/zarf/src/pkg/packager/filters"
+	"github.com/zarf-dev/zarf/src/pkg/utils"
+	"github.com/zarf-dev/zarf/src/types"
+)
+
+// StdinSource is the package source name that reads a package tarball from stdin.
+const StdinSource = "-"
+
+var (
+	// verify that StdinPackageSource implements PackageSource
+	_ PackageSource = (*StdinPackageSource)(nil)
+
+	zstdMagic = []byte{0x28, 0xb5, 0x2f, 0xfd}
+)
+
+// StdinPackageSource is a package source for package tarballs streamed over stdin.
+type StdinPackageSource struct {
+	*types.ZarfPackageOptions
+	Stdin io.Reader
+}
+
+// LoadPackage loads a package from stdin, extracting it while it is read.
+func (s *StdinPackageSource) LoadPackage(ctx context.Context, dst *layout.PackagePaths, filter filters.ComponentFilterStrategy, unarchiveAll bool) (pkg v1alpha1.ZarfPackage, warnings []string, err error) {
+	spinner := message.NewProgressSpinner("Loading package from stdin")
+	defer spinner.Stop()
+	start := time.Now()
+	l := logger.From(ctx)
+	l.Info("loading package", "source", "stdin")
+
+	if s.ChecksumsPath != "" {
+		if err := resolveChecksumShasum(ctx, s.ZarfPackageOptions, ""); err != nil {
+			return pkg, nil, err
+		}
+	}
+	pkg, warnings, err = streamPackage(ctx, s.Stdin, s.ZarfPackageOptions, dst, filter, unarchiveAll)
+	if err != nil {
+		return pkg, nil, err
+	}
+
+	spinner.Success()
+	l.Debug("done loading package", "source", "stdin", "duration", time.Since(start))
+	return pkg, warnings, nil
+}
+
+// LoadPackageMetadata loads a package's metadata from stdin.
+func (s *StdinPackageSource) LoadPackageMetadata(ctx context.Context, dst *layout.PackagePaths, wantSBOM bool, skipValidation bool) (pkg v1alpha1.ZarfPackage, warnings []string, err error) {
+	tmp, err := utils.MakeTempDir(config.CommonOptions.TempDirectory)
+	if err != nil {
+		return pkg, nil, err
+	}
+	defer os.RemoveAll(tmp)
+
+	dstTarball, err := s.Collect(ctx, tmp)
+	if err != nil {
+		return pkg, nil, err
+	}
+
+	ts := &TarballSource{
+		&types.ZarfPackageOptions{
+			PackageSource:           dstTarball,
+			SkipSignatureValidation: s.SkipSignatureValidation,
+			PublicKeyPath:           s.PublicKeyPath,
+		},
+	}
+	return ts.LoadPackageMetadata(ctx, dst, wantSBOM, skipValidation)
+}
+
+// Collect writes the package tarball read from stdin to the given directory, verifying its shasum when one is set.
+func (s *StdinPackageSource) Collect(ctx context.Context, dir string) (string, error) {
+	if s.ChecksumsPath != "" {
+		if err := resolveChecksumShasum(ctx, s.ZarfPackageOptions, ""); err != nil {
+			return "", err
+		}
+	}
+	dstTarball := filepath.Join(dir, "zarf-package-stdin-unknown")
+	f, err := os.Create(dstTarball)
+	if err != nil {
+		return "", err
+	}
+	hasher := sha256.New()
+	_, err = io.Copy(io.MultiWriter(f, hasher), s.Stdin)
+	err = errors.Join(err, f.Close())
+	if err != nil {
+		return "", err
+	}
+	err = verifyStreamShasum("stdin", s.Shasum, hasher.Sum(nil))
+	if s.ChecksumsPath != "" {
+		emitVerification(ctx, s.ZarfPackageOptions, nil, err)
+	}
+	if err != nil {
+		return "", err
+	}
+	return RenameFromMetadata(dstTarball)
+}
+
+// loadURLPackage loads a package from an http or https URL, verifying it against its shasum before it is extracted.
+func loadURLPackage(ctx context.Context, opts *types.ZarfPackageOptions, dst *layout.PackagePaths, filter filters.ComponentFilterStrategy, unarchiveAll bool) (pkg v1alpha1.ZarfPackage, warnings []string, err error) {
+	l := logger.From(ctx)
+	start := time.Now()
+	l.Info("loading package", "source", opts.PackageSource)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, opts.PackageSource, nil)
+	if err != nil {
+		return pkg, nil, err
+	}
+	resp, err := newHTTPClient().Do(req)
+	if err != nil {
+		return pkg, nil, fmt.Errorf("unable to download the package %s: %w", opts.PackageSource, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return pkg, nil, fmt.Errorf("bad HTTP status: %s", resp.Status)
+	}
+
+	progressBar := message.NewProgressBar(resp.ContentLength, fmt.Sprintf("Loading package from %s", opts.PackageSource))
+	pkg, warnings, err = streamPackage(ctx, io.TeeReader(resp.Body, progressBar), opts, dst, filter, unarchiveAll)
+	if err != nil {
+		progressBar.Failf("Unable to load the package from %s", opts.PackageSource)
+		return pkg, nil, err
+	}
+	progressBar.Successf("Loaded package from %s", opts.PackageSource)
+	l.Debug("done loading package", "source", opts.PackageSource, "duration", time.Since(start))
+	return pkg, warnings, nil
+}
+
+// newHTTPClient returns the client packages are downloaded with. It verifies servers against the system roots, or the
+// CAs set with SSL_CERT_FILE and SSL_CERT_DIR, unless TLS verification is skipped, and gives up on servers that do
+// not start responding. The body itself is not bound by a timeout as packages can take long to download.
+func newHTTPClient() *http.Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if transport.TLSClientConfig == nil {
+		transport.TLSClientConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+	}
+	transport.TLSClientConfig.InsecureSkipVerify = config.CommonOptions.InsecureSkipTLSVerify //nolint:gosec
+	transport.ResponseHeaderTimeout = 30 * time.Second
+	return &http.Client{Transport: transport}
+}
+
+// streamPackage extracts the package tarball read from r into dst while it is read. When the options set a shasum, the
+// tarball is hashed as it is extracted into a staging directory, and the files are only moved into dst once the
+// shasum of the whole stream is verified. The files extracted into dst are removed when the package cannot be loaded.
+func streamPackage(ctx context.Context, r io.Reader, opts *types.ZarfPackageOptions, dst *layout.PackagePaths, filter filters.ComponentFilterStrategy, unarchiveAll bool) (pkg v1alpha1.ZarfPackage, warnings []string, err error) {
+	existing, err := os.ReadDir(dst.Base)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return pkg, nil, err
+	}
+	defer func() {
+		if err != nil {
+			removeExtracted(dst.Base, existing)
+		}
+	}()
+
+	var pathsExtracted []string
+	if opts.Shasum != "" {
+		pathsExtracted, err = extractVerifiedStream(ctx, r, opts, dst.Base)
+	} else {
+		pathsExtracted, err = extractStream(r, dst.Base)
+	}
+	if err != nil {
+		return pkg, nil, err
+	}
+	return loadExtractedPackage(ctx, dst, pathsExtracted, filter, unarchiveAll, false, opts)
+}
+
+// extractVerifiedStream extracts the stream into a staging directory in dir while hashing it, verifies its SHA256
+// against the shasum of the options once the whole stream is read, and then promotes the staged files into dir.
+func extractVerifiedStream(ctx context.Context, r io.Reader, opts *types.ZarfPackageOptions, dir string) ([]string, error) {
+	err := os.MkdirAll(dir, helpers.ReadExecuteAllWriteUser)
+	if err != nil {
+		return nil, err
+	}
+	staging, err := os.MkdirTemp(dir, ".zarf-stream-*")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(staging)
+
+	hasher := sha256.New()
+	tee := io.TeeReader(r, hasher)
+	pathsExtracted, extractErr := extractStream(tee, staging)
+	// The archive can end before the stream does, such as with the padding of the tar format. A stream that can not be
+	// extracted is still read to the end, so a truncated or corrupted stream is reported as a shasum mismatch.
+	_, err = io.Copy(io.Discard, tee)
+	if err != nil {
+		return nil, err
+	}
+	err = verifyStream(ctx, hasher, opts)
+	if err != nil {
+		return nil, err
+	}
+	if extractErr != nil {
+		return nil, extractErr
+	}
+
+	entries, err := os.ReadDir(staging)
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range entries {
+		target := filepath.Join(dir, entry.Name())
+		err = os.RemoveAll(target)
+		if err != nil {
+			return nil, err
+		}
+		err = os.Rename(filepath.Join(staging, entry.Name()), target)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return pathsExtracted, nil
+}
+
+// extractStream extracts the tar or zstd compressed tar archive read from r into dir and returns the paths of the
+// extracted files.
+func extractStream(r io.Reader, dir string) ([]string, error) {
+	br := bufio.NewReader(r)
+	var tr archiver.Reader = archiver.NewTar()
+	magic, err := br.Peek(len(zstdMagic))
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+	if bytes.Equal(magic, zstdMagic) {
+		tr = archiver.NewTarZstd()
+	}
+	if err := tr.Open(br, 0); err != nil {
+		return nil, err
+	}
+	defer tr.Close()
+
+	pathsExtracted := []string{}
+	for {
+		f, err := tr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("unable to read the package tarball: %w", err)
+		}
+		path, err := extractFile(f, dir)
+		if err != nil {
+			return nil, err
+		}
+		if path != "" {
+			pathsExtracted = append(pathsExtracted, path)
+		}
+	}
+	return pathsExtracted, nil
+}
+
+// removeExtracted removes the entries of dir that are not in existing, the entries it had before a package was
+// extracted into it.
+func removeExtracted(dir string, existing []os.DirEntry) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return
+	}
+	for _, entry := range entries {
+		if slices.ContainsFunc(existing, func(e os.DirEntry) bool { return e.Name() == entry.Name() }) {
+			continue
+		}
+		os.RemoveAll(filepath.Join(dir, entry.Name()))
+	}
+}
+
+// verifyStream verifies the SHA256 of the whole stream against the shasum of the options.
+func verifyStream(ctx context.Context, hasher hash.Hash, opts *types.ZarfPackageOptions) error {
+	source := opts.PackageSource
+	if source == StdinSource {
+		source = "stdin"
+	}
+	err := verifyStreamShasum(source, opts.Shasum, hasher.Sum(nil))
+	if opts.ChecksumsPath != "" {
+		emitVerification(ctx, opts, nil, err)
+	}
+	return err
+}
+
+func verifyStreamShasum(source, expected string, sum []byte) error {
+	if expected == "" {
+		return nil
+	}
+	actual := hex.EncodeToString(sum)
+	if !strings.EqualFold(actual, expected) {
+		return fmt.Errorf("expected sha256 of %s to be %s, found %s", source, expected, actual)
+	}
+	return nil
+}
@@ -0,0 +1,116 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package sources
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/zarf-dev/zarf/src/api/v1alpha1"
+	"github.com/zarf-dev/zarf/src/pkg/layout"
+	"github.com/zarf-dev/zarf/src/pkg/packager/filters"
+	"github.com/zarf-dev/zarf/src/types"
+)
+
+func TestStdinPackageSource(t *testing.T) {
+	b, err := os.ReadFile("./testdata/expected-pkg.json")
+	require.NoError(t, err)
+	expectedPkg := v1alpha1.ZarfPackage{}
+	err = json.Unmarshal(b, &expectedPkg)
+	require.NoError(t, err)
+	tarball, err := os.ReadFile(filepath.Join("testdata", "zarf-package-wordpress-amd64-16.0.4.tar.zst"))
+	require.NoError(t, err)
+	shasum := "835b06fc509e639497fb45f45d432e5c4cbd5d84212db5357b16bc69724b0e26"
+
+	tests := []struct {
+		name        string
+		shasum      string
+		expectedErr string
+	}{
+		{
+			name:   "with shasum",
+			shasum: shasum,
+		},
+		{
+			name: "without shasum",
+		},
+		{
+			name:        "shasum mismatch",
+			shasum:      "abc",
+			expectedErr: "expected sha256 of stdin to be abc, found " + shasum,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := &types.ZarfPackageOptions{PackageSource: StdinSource, Shasum: tt.shasum}
+
+			ps := &StdinPackageSource{ZarfPackageOptions: opts, Stdin: bytes.NewReader(tarball)}
+			dir := t.TempDir()
+			pkg, warnings, err := ps.LoadPackage(context.Background(), layout.New(dir), filters.Empty(), false)
+			if tt.expectedErr != "" {
+				require.EqualError(t, err, tt.expectedErr)
+				// Nothing is extracted from a stream that does not match its shasum.
+				entries, err := os.ReadDir(dir)
+				require.NoError(t, err)
+				require.Empty(t, entries)
+				return
+			}
+			require.NoError(t, err)
+			require.Empty(t, warnings)
+			require.Equal(t, expectedPkg, pkg)
+
+			ps = &StdinPackageSource{ZarfPackageOptions: opts, Stdin: bytes.NewReader(tarball)}
+			collectDir := t.TempDir()
+			fp, err := ps.Collect(context.Background(), collectDir)
+			require.NoError(t, err)
+			require.Equal(t, filepath.Join(collectDir, "zarf-package-wordpress-amd64-16.0.4.tar.zst"), fp)
+		})
+	}
+}
+
+func TestStreamPackageOutsidePath(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	content := []byte("hello")
+	err := tw.WriteHeader(&tar.Header{Name: "checksums.txt", Mode: 0o600, Size: int64(len(content))})
+	require.NoError(t, err)
+	_, err = tw.Write(content)
+	require.NoError(t, err)
+	err = tw.WriteHeader(&tar.Header{Name: "../zarf.yaml", Mode: 0o600, Size: int64(len(content))})
+	require.NoError(t, err)
+	_, err = tw.Write(content)
+	require.NoError(t, err)
+	require.NoError(t, tw.Close())
+
+	opts := &types.ZarfPackageOptions{PackageSource: StdinSource}
+	dir := t.TempDir()
+	_, _, err = streamPackage(context.Background(), &buf, opts, layout.New(dir), filters.Empty(), false)
+	require.EqualError(t, err, "package contains a file outside of the package: ../zarf.yaml")
+	// The files extracted before the failure are removed.
+	require.NoFileExists(t, filepath.Join(dir, "checksums.txt"))
+}
+
+func TestLoadURLPackageVerifiesServer(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(srv.Close)
+
+	opts := &types.ZarfPackageOptions{PackageSource: srv.URL + "/zarf-package-test-amd64.tar.zst"}
+	_, _, err := loadURLPackage(context.Background(), opts, layout.New(t.TempDir()), filters.Empty(), false)
+	require.ErrorContains(t, err, "certificate signed by unknown authority")
+}
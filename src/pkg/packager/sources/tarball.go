@@ -52,40 +52,66 @@ func (s *TarballSource) LoadPackage(ctx context.Context, dst *layout.PackagePath
 	pathsExtracted := []string{}
 
 	err = archiver.Walk(s.PackageSource, func(f archiver.File) error {
-		if f.IsDir() {
-			return nil
-		}
-		header, ok := f.Header.(*tar.Header)
-		if !ok {
-			return fmt.Errorf("expected header to be *tar.Header but was %T", f.Header)
+		path, err := extractFile(f, dst.Base)
+		if err != nil || path == "" {
+			return err
 		}
-		path := header.Name
+		pathsExtracted = append(pathsExtracted, path)
+		return nil
+	})
+	if err != nil {
+		return pkg, nil, err
+	}
 
-		dir := filepath.Dir(path)
-		if dir != "." {
-			if err := os.MkdirAll(filepath.Join(dst.Base, dir), helpers.ReadExecuteAllWriteUser); err != nil {
-				return err
-			}
-		}
+	pkg, warnings, err = loadExtractedPackage(ctx, dst, pathsExtracted, filter, unarchiveAll, false, s.ZarfPackageOptions)
+	if err != nil {
+		return pkg, nil, err
+	}
 
-		dstPath := filepath.Join(dst.Base, path)
-		pathsExtracted = append(pathsExtracted, path)
-		dst, err := os.Create(dstPath)
-		if err != nil {
-			return err
-		}
-		defer dst.Close()
+	spinner.Success()
+	l.Debug("done loading package", "source", s.PackageSource, "duration", time.Since(start))
 
-		_, err = io.Copy(dst, f)
-		if err != nil {
-			return err
+	return pkg, warnings, nil
+}
+
+// extractFile writes the file of a package tarball into dir and returns its path relative to dir, or an empty path for directories.
+func extractFile(f archiver.File, dir string) (string, error) {
+	if f.IsDir() {
+		return "", nil
+	}
+	header, ok := f.Header.(*tar.Header)
+	if !ok {
+		return "", fmt.Errorf("expected header to be *tar.Header but was %T", f.Header)
+	}
+	path := header.Name
+	if !filepath.IsLocal(path) {
+		return "", fmt.Errorf("package contains a file outside of the package: %s", path)
+	}
+
+	parent := filepath.Dir(path)
+	if parent != "." {
+		if err := os.MkdirAll(filepath.Join(dir, parent), helpers.ReadExecuteAllWriteUser); err != nil {
+			return "", err
 		}
+	}
 
-		return nil
-	})
+	dst, err := os.Create(filepath.Join(dir, path))
 	if err != nil {
-		return pkg, nil, err
+		return "", err
 	}
+	defer dst.Close()
+
+	_, err = io.Copy(dst, f)
+	if err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// loadExtractedPackage loads a package whose tarball was extracted into dst, validating its checksums and signature.
+// A partial package only validates the checksums of the files that were extracted.
+func loadExtractedPackage(ctx context.Context, dst *layout.PackagePaths, pathsExtracted []string, filter filters.ComponentFilterStrategy, unarchiveAll, isPartial bool, opts *types.ZarfPackageOptions) (pkg v1alpha1.ZarfPackage, warnings []string, err error) {
+	l := logger.From(ctx)
 
 	dst.SetFromPaths(pathsExtracted)
 
@@ -105,17 +131,17 @@ func (s *TarballSource) LoadPackage(ctx context.Context, dst *layout.PackagePath
 	if !dst.IsLegacyLayout() {
 		spinner := message.NewProgressSpinner("Validating full package checksums")
 		defer spinner.Stop()
-		l.Info("validating package checksums", "source", s.PackageSource)
+		l.Info("validating package checksums", "source", opts.PackageSource)
 
-		if err := ValidatePackageIntegrity(dst, pkg.Metadata.AggregateChecksum, false); err != nil {
+		if err := ValidatePackageIntegrity(dst, pkg.Metadata.AggregateChecksum, isPartial); err != nil {
 			return pkg, nil, err
 		}
 
 		spinner.Success()
-		l.Debug("done validating package checksums", "source", s.PackageSource)
+		l.Debug("done validating package checksums", "source", opts.PackageSource)
 
-		if !s.SkipSignatureValidation {
-			if err := ValidatePackageSignature(ctx, dst, s.PublicKeyPath); err != nil {
+		if !opts.SkipSignatureValidation {
+			if err := ValidatePackageSignature(ctx, dst, opts.PublicKeyPath); err != nil {
 				return pkg, nil, err
 			}
 		}
@@ -142,9 +168,6 @@ func (s *TarballSource) LoadPackage(ctx context.Context, dst *layout.PackagePath
 		}
 	}
 
-	spinner.Success()
-	l.Debug("done loading package", "source", s.PackageSource, "duration", time.Since(start))
-
 	return pkg, warnings, nil
 }
 
@@ -32,6 +32,9 @@ type URLSource struct {
 
 // Collect downloads a package from the source URL.
 func (s *URLSource) Collect(ctx context.Context, dir string) (string, error) {
+	if err := s.resolveChecksums(ctx); err != nil {
+		return "", err
+	}
 	if s.Shasum == "" && !strings.HasPrefix(s.PackageSource, helpers.SGETURLPrefix) {
 		return "", fmt.Errorf("remote package provided without a shasum, please provide one with --shasum")
 	}
@@ -44,7 +47,11 @@ func (s *URLSource) Collect(ctx context.Context, dir string) (string, error) {
 
 	dstTarball := filepath.Join(dir, "zarf-package-url-unknown")
 
-	if err := utils.DownloadToFile(ctx, packageURL, dstTarball, s.SGetKeyPath); err != nil {
+	err := utils.DownloadToFile(ctx, packageURL, dstTarball, s.SGetKeyPath)
+	if s.ChecksumsPath != "" {
+		emitVerification(ctx, s.ZarfPackageOptions, nil, err)
+	}
+	if err != nil {
 		return "", err
 	}
 
@@ -52,7 +59,20 @@ func (s *URLSource) Collect(ctx context.Context, dir string) (string, error) {
 }
 
 // LoadPackage loads a package from an http, https or sget URL.
+//
+// Packages served over http or https are downloaded to a temporary file and verified against the shasum before any
+// of their payloads are extracted.
 func (s *URLSource) LoadPackage(ctx context.Context, dst *layout.PackagePaths, filter filters.ComponentFilterStrategy, unarchiveAll bool) (pkg v1alpha1.ZarfPackage, warnings []string, err error) {
+	if !strings.HasPrefix(s.PackageSource, helpers.SGETURLPrefix) {
+		if err := s.resolveChecksums(ctx); err != nil {
+			return pkg, nil, err
+		}
+		if s.Shasum == "" {
+			return pkg, nil, fmt.Errorf("remote package provided without a shasum, please provide one with --shasum")
+		}
+		return loadURLPackage(ctx, s.ZarfPackageOptions, dst, filter, unarchiveAll)
+	}
+
 	tmp, err := utils.MakeTempDir(config.CommonOptions.TempDirectory)
 	if err != nil {
 		return pkg, nil, err
@@ -96,3 +116,15 @@ func (s *URLSource) LoadPackageMetadata(ctx context.Context, dst *layout.Package
 
 	return ts.LoadPackageMetadata(ctx, dst, wantSBOM, skipValidation)
 }
+
+// resolveChecksums sets the shasum of the package from the checksums file, when one is provided.
+func (s *URLSource) resolveChecksums(ctx context.Context) error {
+	if s.ChecksumsPath == "" {
+		return nil
+	}
+	name, err := helpers.ExtractBasePathFromURL(s.PackageSource)
+	if err != nil {
+		return err
+	}
+	return resolveChecksumShasum(ctx, s.ZarfPackageOptions, name)
+}
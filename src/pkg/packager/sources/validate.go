@@ -15,6 +15,7 @@ import (
 	"strings"
 
 	"github.com/defenseunicorns/pkg/helpers/v2"
+	"github.com/zarf-dev/zarf/src/pkg/exitcode"
 	"github.com/zarf-dev/zarf/src/pkg/layout"
 	"github.com/zarf-dev/zarf/src/pkg/logger"
 	"github.com/zarf-dev/zarf/src/pkg/message"
@@ -42,15 +43,15 @@ func ValidatePackageSignature(ctx context.Context, paths *layout.PackagePaths, p
 		return nil
 	} else if sigExist && publicKeyPath == "" {
 		// The package is signed but no key was provided
-		return ErrPkgSigButNoKey
+		return exitcode.Wrap(exitcode.Signature, ErrPkgSigButNoKey)
 	} else if !sigExist && publicKeyPath != "" {
 		// A key was provided but there is no signature
-		return ErrPkgKeyButNoSig
+		return exitcode.Wrap(exitcode.Signature, ErrPkgKeyButNoSig)
 	}
 
 	// Validate the signature with the key we were provided
 	if err := utils.CosignVerifyBlob(ctx, paths.ZarfYAML, paths.Signature, publicKeyPath); err != nil {
-		return fmt.Errorf("package signature did not match the provided key: %w", err)
+		return exitcode.Wrap(exitcode.Signature, fmt.Errorf("package signature did not match the provided key: %w", err))
 	}
 
 	return nil
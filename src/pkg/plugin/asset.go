@@ -0,0 +1,103 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package plugin
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+)
+
+// assetProviderPrefix is the prefix of executables that fetch assets, e.g. zarf-asset-s3 fetches s3:// URLs.
+const assetProviderPrefix = Prefix + "asset-"
+
+// builtinSchemes are fetched by Zarf itself and can not be replaced by a provider.
+var builtinSchemes = []string{"http", "https", "sget", "oci", "file"}
+
+// AssetProvider fetches component assets, such as files, manifests and charts, from URLs with a scheme Zarf does not support.
+type AssetProvider interface {
+	// Fetch writes the asset at the URL to dst.
+	Fetch(ctx context.Context, src *url.URL, dst io.Writer) error
+}
+
+// AssetProviderFunc adapts a function to an AssetProvider.
+type AssetProviderFunc func(ctx context.Context, src *url.URL, dst io.Writer) error
+
+// Fetch calls f.
+func (f AssetProviderFunc) Fetch(ctx context.Context, src *url.URL, dst io.Writer) error {
+	return f(ctx, src, dst)
+}
+
+var (
+	providersMu sync.RWMutex
+	providers   = map[string]AssetProvider{}
+)
+
+// RegisterAssetProvider registers the provider for URLs with the scheme. It is intended to be called from init functions
+// of programs that embed Zarf.
+func RegisterAssetProvider(scheme string, p AssetProvider) error {
+	scheme = strings.ToLower(scheme)
+	if scheme == "" {
+		return fmt.Errorf("asset provider scheme must not be empty")
+	}
+	for _, builtin := range builtinSchemes {
+		if scheme == builtin {
+			return fmt.Errorf("asset provider can not replace the builtin %s scheme", scheme)
+		}
+	}
+	providersMu.Lock()
+	defer providersMu.Unlock()
+	if _, ok := providers[scheme]; ok {
+		return fmt.Errorf("asset provider for the %s scheme is already registered", scheme)
+	}
+	providers[scheme] = p
+	return nil
+}
+
+// AssetProviderFor returns the provider for the scheme. Registered providers take precedence over zarf-asset-<scheme>
+// executables on the PATH.
+func AssetProviderFor(scheme string) (AssetProvider, bool) {
+	scheme = strings.ToLower(scheme)
+	providersMu.RLock()
+	p, ok := providers[scheme]
+	providersMu.RUnlock()
+	if ok {
+		return p, true
+	}
+	for _, builtin := range builtinSchemes {
+		if scheme == builtin {
+			return nil, false
+		}
+	}
+	path, err := exec.LookPath(assetProviderPrefix + scheme)
+	if err != nil {
+		return nil, false
+	}
+	return execAssetProvider{path: path}, true
+}
+
+// execAssetProvider fetches assets by running an executable with the URL as its only argument and reading the asset from
+// its standard output.
+type execAssetProvider struct {
+	path string
+}
+
+func (p execAssetProvider) Fetch(ctx context.Context, src *url.URL, dst io.Writer) error {
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, p.path, src.String())
+	cmd.Stdout = dst
+	cmd.Stderr = &stderr
+	cmd.Env = os.Environ()
+	err := cmd.Run()
+	if err != nil {
+		return fmt.Errorf("asset provider %s failed: %w: %s", p.path, err, strings.TrimSpace(stderr.String()))
+	}
+	return nil
+}
@@ -0,0 +1,118 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package plugin discovers and runs Zarf plugins and asset providers.
+package plugin
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+)
+
+const (
+	// Prefix is the prefix of plugin executable names, e.g. zarf-backstage adds the zarf backstage command.
+	Prefix = "zarf-"
+	// BinEnv is the environment variable plugins can use to call back into the Zarf binary that ran them.
+	BinEnv = "ZARF_BIN"
+)
+
+// Plugin is a plugin executable found on the PATH.
+type Plugin struct {
+	// Name is the command the plugin adds, e.g. backstage for zarf-backstage.
+	Name string
+	// Path is the path to the plugin executable.
+	Path string
+	// AssetProvider is true when the plugin fetches component assets rather than adding a command.
+	AssetProvider bool
+}
+
+// Lookup finds the plugin for the longest sequence of command arguments and returns its path and remaining arguments.
+// For example zarf foo bar --flag runs zarf-foo-bar --flag if it exists, falling back to zarf-foo bar --flag.
+func Lookup(args []string) (string, []string, bool) {
+	names := []string{}
+	for _, arg := range args {
+		if strings.HasPrefix(arg, "-") {
+			break
+		}
+		names = append(names, arg)
+	}
+	for i := len(names); i > 0; i-- {
+		name := Prefix + strings.Join(names[:i], "-")
+		if strings.HasPrefix(name, assetProviderPrefix) {
+			continue
+		}
+		path, err := exec.LookPath(name)
+		if err != nil {
+			continue
+		}
+		return path, args[i:], true
+	}
+	return "", nil, false
+}
+
+// Run runs the plugin with the arguments, connected to the standard streams of Zarf.
+func Run(ctx context.Context, path string, args []string) error {
+	cmd := exec.CommandContext(ctx, path, args...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	cmd.Env = os.Environ()
+	if self, err := os.Executable(); err == nil {
+		cmd.Env = append(cmd.Env, BinEnv+"="+self)
+	}
+	return cmd.Run()
+}
+
+// List returns the plugins found on the PATH. Plugins earlier in the PATH shadow plugins with the same name.
+func List() []Plugin {
+	plugins := []Plugin{}
+	seen := map[string]bool{}
+	for _, dir := range filepath.SplitList(os.Getenv("PATH")) {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		for _, entry := range entries {
+			if !strings.HasPrefix(entry.Name(), Prefix) || !isExecutable(entry) {
+				continue
+			}
+			name := strings.TrimPrefix(entry.Name(), Prefix)
+			if runtime.GOOS == "windows" {
+				name = strings.TrimSuffix(name, filepath.Ext(name))
+			}
+			if name == "" || seen[name] {
+				continue
+			}
+			seen[name] = true
+			p := Plugin{
+				Name: name,
+				Path: filepath.Join(dir, entry.Name()),
+			}
+			if scheme, ok := strings.CutPrefix(entry.Name(), assetProviderPrefix); ok {
+				p.Name = strings.TrimSuffix(scheme, filepath.Ext(scheme))
+				p.AssetProvider = true
+			}
+			plugins = append(plugins, p)
+		}
+	}
+	slices.SortFunc(plugins, func(a, b Plugin) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	return plugins
+}
+
+func isExecutable(entry os.DirEntry) bool {
+	info, err := entry.Info()
+	if err != nil || info.IsDir() {
+		return false
+	}
+	if runtime.GOOS == "windows" {
+		return strings.EqualFold(filepath.Ext(entry.Name()), ".exe")
+	}
+	return info.Mode()&0o111 != 0
+}
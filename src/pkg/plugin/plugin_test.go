@@ -0,0 +1,146 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package plugin
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func writeExecutable(t *testing.T, dir, name, script string) string {
+	t.Helper()
+
+	path := filepath.Join(dir, name)
+	err := os.WriteFile(path, []byte("#!/bin/sh\n"+script+"\n"), 0o755)
+	require.NoError(t, err)
+	return path
+}
+
+func TestLookup(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("plugin scripts are not executable on windows")
+	}
+	dir := t.TempDir()
+	t.Setenv("PATH", dir)
+	foo := writeExecutable(t, dir, "zarf-foo", "exit 0")
+	fooBar := writeExecutable(t, dir, "zarf-foo-bar", "exit 0")
+	writeExecutable(t, dir, "zarf-asset-s3", "exit 0")
+
+	tests := []struct {
+		name         string
+		args         []string
+		expectedPath string
+		expectedArgs []string
+		expectedOk   bool
+	}{
+		{
+			name:         "longest match",
+			args:         []string{"foo", "bar", "baz", "--flag"},
+			expectedPath: fooBar,
+			expectedArgs: []string{"baz", "--flag"},
+			expectedOk:   true,
+		},
+		{
+			name:         "fallback",
+			args:         []string{"foo", "qux"},
+			expectedPath: foo,
+			expectedArgs: []string{"qux"},
+			expectedOk:   true,
+		},
+		{
+			name:         "stops at flags",
+			args:         []string{"foo", "--bar"},
+			expectedPath: foo,
+			expectedArgs: []string{"--bar"},
+			expectedOk:   true,
+		},
+		{
+			name: "asset providers are not commands",
+			args: []string{"asset", "s3"},
+		},
+		{
+			name: "not found",
+			args: []string{"missing"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path, args, ok := Lookup(tt.args)
+			require.Equal(t, tt.expectedOk, ok)
+			require.Equal(t, tt.expectedPath, path)
+			require.Equal(t, tt.expectedArgs, args)
+		})
+	}
+}
+
+func TestList(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("plugin scripts are not executable on windows")
+	}
+	first := t.TempDir()
+	second := t.TempDir()
+	t.Setenv("PATH", first+string(os.PathListSeparator)+second)
+	backstage := writeExecutable(t, first, "zarf-backstage", "exit 0")
+	writeExecutable(t, second, "zarf-backstage", "exit 0")
+	s3 := writeExecutable(t, second, "zarf-asset-s3", "exit 0")
+	err := os.WriteFile(filepath.Join(second, "zarf-not-executable"), nil, 0o644)
+	require.NoError(t, err)
+
+	expected := []Plugin{
+		{Name: "backstage", Path: backstage},
+		{Name: "s3", Path: s3, AssetProvider: true},
+	}
+	require.Equal(t, expected, List())
+}
+
+func TestAssetProvider(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("plugin scripts are not executable on windows")
+	}
+	dir := t.TempDir()
+	t.Setenv("PATH", dir)
+	writeExecutable(t, dir, "zarf-asset-s3", `echo "fetched $1"`)
+	writeExecutable(t, dir, "zarf-asset-gcs", `echo "access denied" >&2; exit 1`)
+
+	err := RegisterAssetProvider("memory", AssetProviderFunc(func(_ context.Context, src *url.URL, dst io.Writer) error {
+		_, err := dst.Write([]byte("registered " + src.Host))
+		return err
+	}))
+	require.NoError(t, err)
+	err = RegisterAssetProvider("MEMORY", AssetProviderFunc(nil))
+	require.EqualError(t, err, "asset provider for the memory scheme is already registered")
+	err = RegisterAssetProvider("https", AssetProviderFunc(nil))
+	require.EqualError(t, err, "asset provider can not replace the builtin https scheme")
+
+	_, ok := AssetProviderFor("https")
+	require.False(t, ok)
+	_, ok = AssetProviderFor("azure")
+	require.False(t, ok)
+
+	fetch := func(rawURL string) (string, error) {
+		u, err := url.Parse(rawURL)
+		require.NoError(t, err)
+		p, ok := AssetProviderFor(u.Scheme)
+		require.True(t, ok)
+		var buf bytes.Buffer
+		err = p.Fetch(context.Background(), u, &buf)
+		return buf.String(), err
+	}
+	out, err := fetch("memory://bucket/file.txt")
+	require.NoError(t, err)
+	require.Equal(t, "registered bucket", out)
+	out, err = fetch("s3://bucket/file.txt")
+	require.NoError(t, err)
+	require.Equal(t, "fetched s3://bucket/file.txt\n", out)
+	_, err = fetch("gcs://bucket/file.txt")
+	require.ErrorContains(t, err, "access denied")
+}
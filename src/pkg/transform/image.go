@@ -5,7 +5,9 @@
 package transform
 
 import (
+	"errors"
 	"fmt"
+	"regexp"
 	"strings"
 
 	"github.com/defenseunicorns/pkg/helpers/v2"
@@ -23,8 +25,75 @@ type Image struct {
 	TagOrDigest string
 }
 
+// ImageRule rewrites the path an image is stored under in the target registry. Rules match the fully qualified name
+// of the image without its tag or digest, e.g. docker.io/library/nginx.
+type ImageRule struct {
+	// Prefix matches names that start with it, the prefix is replaced with Replace
+	Prefix string `json:"prefix,omitempty"`
+	// Regex matches the whole name against a regular expression, Replace can reference its capture groups with ${1}
+	Regex string `json:"regex,omitempty"`
+	// Replace is the path the matched part of the name is replaced with
+	Replace string `json:"replace"`
+}
+
+// ValidateImageRules checks that every rule has either a prefix or a valid regular expression.
+func ValidateImageRules(rules []ImageRule) error {
+	errs := []error{}
+	for i, rule := range rules {
+		if (rule.Prefix == "") == (rule.Regex == "") {
+			errs = append(errs, fmt.Errorf("image rule %d must have either a prefix or a regex", i))
+			continue
+		}
+		if rule.Regex == "" {
+			continue
+		}
+		_, err := compileImageRule(rule.Regex)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("image rule %d has an invalid regex: %w", i, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func compileImageRule(expr string) (*regexp.Regexp, error) {
+	return regexp.Compile(fmt.Sprintf("^(?:%s)$", expr))
+}
+
+// imagePath returns the path of the image in the target registry, which is rewritten by the first rule that matches.
+func imagePath(image Image, rules []ImageRule) (string, error) {
+	for _, rule := range rules {
+		var path string
+		switch {
+		case rule.Prefix != "":
+			if !strings.HasPrefix(image.Name, rule.Prefix) {
+				continue
+			}
+			path = rule.Replace + strings.TrimPrefix(image.Name, rule.Prefix)
+		case rule.Regex != "":
+			re, err := compileImageRule(rule.Regex)
+			if err != nil {
+				return "", err
+			}
+			if !re.MatchString(image.Name) {
+				continue
+			}
+			path = re.ReplaceAllString(image.Name, rule.Replace)
+		default:
+			continue
+		}
+		path = strings.Trim(path, "/")
+		// Paths are validated with a placeholder domain as they do not have one of their own.
+		if _, err := reference.ParseNamed("localhost/" + path); err != nil {
+			return "", fmt.Errorf("image rule rewrote %s to the invalid path %q", image.Name, path)
+		}
+		return path, nil
+	}
+	return image.Path, nil
+}
+
 // ImageTransformHost replaces the base url for an image and adds a crc32 of the original url to the end of the src (note image refs are not full URLs).
-func ImageTransformHost(targetHost, srcReference string) (string, error) {
+// The path of the image is rewritten by the first of the rules that matches it.
+func ImageTransformHost(targetHost, srcReference string, rules ...ImageRule) (string, error) {
 	image, err := ParseImageRef(srcReference)
 	if err != nil {
 		return "", err
@@ -35,19 +104,25 @@ func ImageTransformHost(targetHost, srcReference string) (string, error) {
 		return srcReference, nil
 	}
 
+	path, err := imagePath(image, rules)
+	if err != nil {
+		return "", err
+	}
+
 	// Generate a crc32 hash of the image host + name
 	checksum := helpers.GetCRCHash(image.Name)
 
 	// If this image is specified by digest then don't add a checksum as it will already be a specific SHA
 	if image.Digest != "" {
-		return fmt.Sprintf("%s/%s@%s", targetHost, image.Path, image.Digest), nil
+		return fmt.Sprintf("%s/%s@%s", targetHost, path, image.Digest), nil
 	}
 
-	return fmt.Sprintf("%s/%s:%s-zarf-%d", targetHost, image.Path, image.Tag, checksum), nil
+	return fmt.Sprintf("%s/%s:%s-zarf-%d", targetHost, path, image.Tag, checksum), nil
 }
 
 // ImageTransformHostWithoutChecksum replaces the base url for an image but avoids adding a checksum of the original url (note image refs are not full URLs).
-func ImageTransformHostWithoutChecksum(targetHost, srcReference string) (string, error) {
+// The path of the image is rewritten by the first of the rules that matches it.
+func ImageTransformHostWithoutChecksum(targetHost, srcReference string, rules ...ImageRule) (string, error) {
 	image, err := ParseImageRef(srcReference)
 	if err != nil {
 		return "", err
@@ -58,7 +133,12 @@ func ImageTransformHostWithoutChecksum(targetHost, srcReference string) (string,
 		return srcReference, nil
 	}
 
-	return fmt.Sprintf("%s/%s%s", targetHost, image.Path, image.TagOrDigest), nil
+	path, err := imagePath(image, rules)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%s/%s%s", targetHost, path, image.TagOrDigest), nil
 }
 
 // ParseImageRef parses a source reference into an Image struct
@@ -79,6 +79,98 @@ func TestImageTransformHostWithoutChecksum(t *testing.T) {
 	}
 }
 
+func TestImageTransformHostWithRules(t *testing.T) {
+	t.Parallel()
+
+	rules := []ImageRule{
+		{Prefix: "docker.io/library/", Replace: "dockerhub/library/"},
+		{Regex: `ghcr\.io/([^/]+)/(.+)`, Replace: "github/${1}/${2}"},
+		{Prefix: "registry1.dso.mil/ironbank/", Replace: "/ironbank/"},
+		{Regex: `.*`, Replace: "other/$0"},
+	}
+	tests := []struct {
+		name               string
+		ref                string
+		expected           string
+		expectedNoChecksum string
+	}{
+		{
+			name:               "prefix",
+			ref:                "nginx:1.23.3",
+			expected:           "harbor.example.com/dockerhub/library/nginx:1.23.3-zarf-3793515731",
+			expectedNoChecksum: "harbor.example.com/dockerhub/library/nginx:1.23.3",
+		},
+		{
+			name:               "regex with capture groups",
+			ref:                "ghcr.io/stefanprodan/podinfo:6.3.3",
+			expected:           "harbor.example.com/github/stefanprodan/podinfo:6.3.3-zarf-2985051089",
+			expectedNoChecksum: "harbor.example.com/github/stefanprodan/podinfo:6.3.3",
+		},
+		{
+			name:               "first rule wins and slashes are trimmed",
+			ref:                "registry1.dso.mil/ironbank/opensource/zarf-dev/zarf/zarf-agent:v0.25.0",
+			expected:           "harbor.example.com/ironbank/opensource/zarf-dev/zarf/zarf-agent:v0.25.0-zarf-1211467612",
+			expectedNoChecksum: "harbor.example.com/ironbank/opensource/zarf-dev/zarf/zarf-agent:v0.25.0",
+		},
+		{
+			name:               "digest",
+			ref:                "zarf-dev/zarf-agent@sha256:84605f731c6a18194794c51e70021c671ab064654b751aa57e905bce55be13de",
+			expected:           "harbor.example.com/other/docker.io/zarf-dev/zarf-agent@sha256:84605f731c6a18194794c51e70021c671ab064654b751aa57e905bce55be13de",
+			expectedNoChecksum: "harbor.example.com/other/docker.io/zarf-dev/zarf-agent@sha256:84605f731c6a18194794c51e70021c671ab064654b751aa57e905bce55be13de",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			newRef, err := ImageTransformHost("harbor.example.com", tt.ref, rules...)
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, newRef)
+			newRef, err = ImageTransformHostWithoutChecksum("harbor.example.com", tt.ref, rules...)
+			require.NoError(t, err)
+			require.Equal(t, tt.expectedNoChecksum, newRef)
+		})
+	}
+
+	_, err := ImageTransformHost("harbor.example.com", "nginx", ImageRule{Prefix: "docker.io/", Replace: "Upper/"})
+	require.EqualError(t, err, `image rule rewrote docker.io/library/nginx to the invalid path "Upper/library/nginx"`)
+}
+
+func TestValidateImageRules(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		rules       []ImageRule
+		expectedErr string
+	}{
+		{
+			name:  "valid",
+			rules: []ImageRule{{Prefix: "docker.io/", Replace: "dockerhub/"}, {Regex: `ghcr\.io/(.+)`, Replace: "github/$1"}},
+		},
+		{
+			name:        "prefix and regex",
+			rules:       []ImageRule{{Prefix: "docker.io/", Regex: "docker.io/.*", Replace: "dockerhub/"}},
+			expectedErr: "image rule 0 must have either a prefix or a regex",
+		},
+		{
+			name:        "invalid regex",
+			rules:       []ImageRule{{Prefix: "docker.io/"}, {Regex: "ghcr.io/(", Replace: "github/"}},
+			expectedErr: "image rule 1 has an invalid regex: error parsing regexp: missing closing ): `^(?:ghcr.io/()$`",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := ValidateImageRules(tt.rules)
+			if tt.expectedErr != "" {
+				require.EqualError(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
 func TestParseImageRef(t *testing.T) {
 	var expectedResult = [][]string{
 		{"docker.io/", "library/nginx", "latest", ""},
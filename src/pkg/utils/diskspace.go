@@ -0,0 +1,18 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+//go:build !windows
+
+package utils
+
+import "golang.org/x/sys/unix"
+
+// FreeDiskSpace returns the number of bytes available to the current user on the filesystem of path.
+func FreeDiskSpace(path string) (uint64, error) {
+	var stat unix.Statfs_t
+	if err := unix.Statfs(path, &stat); err != nil {
+		return 0, err
+	}
+	//nolint:unconvert // the field types differ between platforms
+	return uint64(stat.Bavail) * uint64(stat.Bsize), nil
+}
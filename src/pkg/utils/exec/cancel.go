@@ -0,0 +1,20 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+//go:build !windows
+
+package exec
+
+import (
+	"os/exec"
+	"syscall"
+)
+
+// killProcessGroup starts the command in its own process group and kills the whole group once the context of the
+// command is done, so that the processes a shell forks do not outlive it.
+func killProcessGroup(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	cmd.Cancel = func() error {
+		return syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+	}
+}
@@ -0,0 +1,11 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+//go:build windows
+
+package exec
+
+import "os/exec"
+
+// killProcessGroup is a no-op on Windows, only the command itself is killed once its context is done.
+func killProcessGroup(_ *exec.Cmd) {}
@@ -28,6 +28,7 @@ type Config struct {
 	CommandPrinter func(format string, a ...any)
 	Stdout         io.Writer
 	Stderr         io.Writer
+	Stdin          io.Reader
 }
 
 // PrintCfg is a helper function for returning a Config struct with Print set to true.
@@ -63,6 +64,11 @@ func CmdWithContext(ctx context.Context, config Config, command string, args ...
 	cmd := exec.CommandContext(ctx, command, args...)
 	cmd.Dir = config.Dir
 	cmd.Env = append(os.Environ(), config.Env...)
+	cmd.Stdin = config.Stdin
+	// Commands reading from a terminal have to stay in its process group.
+	if config.Stdin == nil {
+		killProcessGroup(cmd)
+	}
 
 	// Capture the command outputs.
 	cmdStdout, err := cmd.StdoutPipe()
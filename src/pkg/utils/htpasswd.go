@@ -6,6 +6,7 @@ package utils
 
 import (
 	"fmt"
+	"slices"
 
 	"golang.org/x/crypto/bcrypt"
 )
@@ -18,3 +19,21 @@ func GetHtpasswdString(username string, password string) (string, error) {
 	}
 	return fmt.Sprintf("%s:%s", username, hash), nil
 }
+
+// GetHtpasswdStrings converts the passwords of the users by username to `htpasswd` lines sorted by username.
+func GetHtpasswdStrings(users map[string]string) ([]string, error) {
+	usernames := []string{}
+	for username := range users {
+		usernames = append(usernames, username)
+	}
+	slices.Sort(usernames)
+	lines := []string{}
+	for _, username := range usernames {
+		line, err := GetHtpasswdString(username, users[username])
+		if err != nil {
+			return nil, err
+		}
+		lines = append(lines, line)
+	}
+	return lines, nil
+}
@@ -5,6 +5,7 @@
 package utils
 
 import (
+	"cmp"
 	"encoding/json"
 	"fmt"
 	"os"
@@ -19,6 +20,10 @@ import (
 	"github.com/zarf-dev/zarf/src/pkg/transform"
 )
 
+// ImageIndexAnnotation annotates the image index of the platforms that are kept for a multi-platform image with the
+// image reference. The image of the platform the package is created for is annotated with its reference on its own.
+const ImageIndexAnnotation = "zarf.dev/image-index"
+
 // LoadOCIImage returns a v1.Image with the image ref specified from a location provided, or an error if the image cannot be found.
 func LoadOCIImage(imgPath string, refInfo transform.Image) (v1.Image, error) {
 	// Use the manifest within the index.json to load the specific image we want
@@ -45,6 +50,26 @@ func LoadOCIImage(imgPath string, refInfo transform.Image) (v1.Image, error) {
 	return nil, fmt.Errorf("unable to find image (%s) at the path (%s)", refInfo.Reference, imgPath)
 }
 
+// LoadOCIImageIndex returns the image index of the platforms kept for the image ref at the location provided, or nil
+// if only a single platform of the image is in the package.
+func LoadOCIImageIndex(imgPath string, refInfo transform.Image) (v1.ImageIndex, error) {
+	layoutPath := layout.Path(imgPath)
+	imgIdx, err := layoutPath.ImageIndex()
+	if err != nil {
+		return nil, err
+	}
+	idxManifest, err := imgIdx.IndexManifest()
+	if err != nil {
+		return nil, err
+	}
+	for _, manifest := range idxManifest.Manifests {
+		if manifest.Annotations[ImageIndexAnnotation] == refInfo.Reference {
+			return imgIdx.ImageIndex(manifest.Digest)
+		}
+	}
+	return nil, nil
+}
+
 // AddImageNameAnnotation adds an annotation to the index.json file so that the deploying code can figure out what the image reference <-> digest shasum will be.
 func AddImageNameAnnotation(ociPath string, referenceToDigest map[string]string) error {
 	indexPath := filepath.Join(ociPath, "index.json")
@@ -105,7 +130,8 @@ func OnlyHasImageLayers(img v1.Image) (bool, error) {
 	return true, nil
 }
 
-// SortImagesIndex sorts the index.json by digest.
+// SortImagesIndex sorts the index.json by digest and then by the image references the manifests are annotated with, so
+// that the order does not depend on the order the images were saved in.
 func SortImagesIndex(ociPath string) error {
 	indexPath := filepath.Join(ociPath, "index.json")
 	b, err := os.ReadFile(indexPath)
@@ -118,7 +144,11 @@ func SortImagesIndex(ociPath string) error {
 		return err
 	}
 	slices.SortFunc(index.Manifests, func(a, b ocispec.Descriptor) int {
-		return strings.Compare(string(a.Digest), string(b.Digest))
+		return cmp.Or(
+			strings.Compare(string(a.Digest), string(b.Digest)),
+			strings.Compare(a.Annotations[ocispec.AnnotationBaseImageName], b.Annotations[ocispec.AnnotationBaseImageName]),
+			strings.Compare(a.Annotations[ImageIndexAnnotation], b.Annotations[ImageIndexAnnotation]),
+		)
 	})
 	b, err = json.Marshal(index)
 	if err != nil {
@@ -20,6 +20,7 @@ import (
 	"github.com/zarf-dev/zarf/src/config/lang"
 	"github.com/zarf-dev/zarf/src/pkg/logger"
 	"github.com/zarf-dev/zarf/src/pkg/message"
+	"github.com/zarf-dev/zarf/src/pkg/plugin"
 )
 
 func parseChecksum(src string) (string, string, error) {
@@ -76,6 +77,11 @@ func DownloadToFile(ctx context.Context, src, dst, cosignKeyPath string) (err er
 		if err != nil {
 			return fmt.Errorf("unable to download file with sget: %s: %w", src, err)
 		}
+	} else if provider, ok := plugin.AssetProviderFor(parsed.Scheme); ok {
+		err = provider.Fetch(ctx, parsed, file)
+		if err != nil {
+			return fmt.Errorf("unable to fetch %s: %w", src, err)
+		}
 	} else {
 		err = httpGetFile(ctx, src, file)
 		if err != nil {
@@ -5,11 +5,14 @@
 package utils
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"io"
 	"net"
 	"net/http"
 	"path"
+	"regexp"
 	"strconv"
 	"strings"
 	"time"
@@ -29,12 +32,30 @@ func isJSONPathWaitType(condition string) bool {
 	return true
 }
 
+// waitDelay blocks for the delay before the next check, it returns an error once the wait times out or the parent
+// context is cancelled.
+func waitDelay(ctx, waitCtx context.Context, delay time.Duration) error {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-waitCtx.Done():
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		return errors.New("wait timed out")
+	case <-timer.C:
+		return nil
+	}
+}
+
 // ExecuteWait executes the wait-for command.
-func ExecuteWait(waitTimeout, waitNamespace, condition, kind, identifier string, timeout time.Duration) error {
+func ExecuteWait(ctx context.Context, waitTimeout, waitNamespace, condition, kind, identifier, body string, timeout time.Duration) error {
 	// Handle network endpoints.
 	switch kind {
 	case "http", "https", "tcp":
-		return waitForNetworkEndpoint(kind, identifier, condition, timeout)
+		return WaitForNetworkEndpoint(ctx, kind, identifier, condition, body, timeout, -1)
+	case "exec":
+		return WaitForCommand(ctx, v1alpha1.Shell{}, exec.Config{}, identifier, timeout, -1)
 	}
 
 	// Type of wait, condition or JSONPath
@@ -62,7 +83,8 @@ func ExecuteWait(waitTimeout, waitNamespace, condition, kind, identifier string,
 	}
 
 	// Set the timeout for the wait-for command.
-	expired := time.After(timeout)
+	waitCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
 
 	// Set the custom message for optional namespace.
 	namespaceMsg := ""
@@ -84,57 +106,68 @@ func ExecuteWait(waitTimeout, waitNamespace, condition, kind, identifier string,
 
 	for {
 		// Delay the check for 1 second
-		time.Sleep(time.Second)
+		if err := waitDelay(ctx, waitCtx, time.Second); err != nil {
+			return err
+		}
 
-		select {
-		case <-expired:
-			return errors.New("wait timed out")
+		spinner.Updatef(existMsg)
+		// Check if the resource exists, the kubectl calls are killed once the wait times out.
+		zarfKubectlGet := fmt.Sprintf("%s tools kubectl get %s %s %s", zarfCommand, namespaceFlag, kind, identifier)
+		stdout, stderr, err := exec.CmdWithContext(waitCtx, exec.Config{}, shell, append(shellArgs, zarfKubectlGet)...)
+		if err != nil {
+			message.Debug(stdout, stderr, err)
+			continue
+		}
 
-		default:
-			spinner.Updatef(existMsg)
-			// Check if the resource exists.
-			zarfKubectlGet := fmt.Sprintf("%s tools kubectl get %s %s %s", zarfCommand, namespaceFlag, kind, identifier)
-			stdout, stderr, err := exec.Cmd(shell, append(shellArgs, zarfKubectlGet)...)
-			if err != nil {
-				message.Debug(stdout, stderr, err)
-				continue
-			}
+		resourceNotFound := strings.Contains(stderr, "No resources found") && identifier == ""
+		if resourceNotFound {
+			message.Debug(stdout, stderr, err)
+			continue
+		}
 
-			resourceNotFound := strings.Contains(stderr, "No resources found") && identifier == ""
-			if resourceNotFound {
-				message.Debug(stdout, stderr, err)
-				continue
-			}
+		// If only checking for existence, exit here.
+		switch condition {
+		case "", "exist", "exists":
+			spinner.Success()
+			return nil
+		}
 
-			// If only checking for existence, exit here.
-			switch condition {
-			case "", "exist", "exists":
-				spinner.Success()
-				return nil
-			}
+		spinner.Updatef(conditionMsg)
+		// Wait for the resource to meet the given condition.
+		zarfKubectlWait := fmt.Sprintf("%s tools kubectl wait %s %s %s --for %s%s --timeout=%s",
+			zarfCommand, namespaceFlag, kind, identifier, waitType, condition, waitTimeout)
 
-			spinner.Updatef(conditionMsg)
-			// Wait for the resource to meet the given condition.
-			zarfKubectlWait := fmt.Sprintf("%s tools kubectl wait %s %s %s --for %s%s --timeout=%s",
-				zarfCommand, namespaceFlag, kind, identifier, waitType, condition, waitTimeout)
+		// If there is an error, log it and try again.
+		if stdout, stderr, err := exec.CmdWithContext(waitCtx, exec.Config{}, shell, append(shellArgs, zarfKubectlWait)...); err != nil {
+			message.Debug(stdout, stderr, err)
+			continue
+		}
 
-			// If there is an error, log it and try again.
-			if stdout, stderr, err := exec.Cmd(shell, append(shellArgs, zarfKubectlWait)...); err != nil {
-				message.Debug(stdout, stderr, err)
-				continue
-			}
+		// And just like that, success!
+		spinner.Successf(conditionMsg)
+		return nil
+	}
+}
 
-			// And just like that, success!
-			spinner.Successf(conditionMsg)
-			return nil
+// WaitForNetworkEndpoint waits for a network endpoint to respond. For http and https endpoints the body of the response
+// must also match the body regular expression if one is given. The endpoint is checked again up to maxRetries times
+// after the first check fails, a negative maxRetries checks it until the timeout.
+func WaitForNetworkEndpoint(ctx context.Context, resource, name, condition, body string, timeout time.Duration, maxRetries int) error {
+	var bodyRegex *regexp.Regexp
+	if body != "" {
+		if resource != "http" && resource != "https" {
+			return fmt.Errorf("a body can only be matched for http and https endpoints, not %s", resource)
+		}
+		var err error
+		bodyRegex, err = regexp.Compile(body)
+		if err != nil {
+			return fmt.Errorf("invalid body regular expression %q: %w", body, err)
 		}
 	}
-}
 
-// waitForNetworkEndpoint waits for a network endpoint to respond.
-func waitForNetworkEndpoint(resource, name, condition string, timeout time.Duration) error {
 	// Set the timeout for the wait-for command.
-	expired := time.After(timeout)
+	waitCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
 
 	// Setup the spinner messages.
 	condition = strings.ToLower(condition)
@@ -146,67 +179,135 @@ func waitForNetworkEndpoint(resource, name, condition string, timeout time.Durat
 
 	delay := 100 * time.Millisecond
 
-	for {
+	for retries := 0; ; retries++ {
+		if maxRetries >= 0 && retries > maxRetries {
+			return fmt.Errorf("wait failed after %d retries", maxRetries)
+		}
+
 		// Delay the check for 100ms the first time and then 1 second after that.
-		time.Sleep(delay)
+		if err := waitDelay(ctx, waitCtx, delay); err != nil {
+			return err
+		}
 		delay = time.Second
 
-		select {
-		case <-expired:
-			return errors.New("wait timed out")
-		default:
-			switch resource {
-			case "http", "https":
-				// Handle HTTP and HTTPS endpoints.
-				url := fmt.Sprintf("%s://%s", resource, name)
-
-				// Default to checking for a 2xx response.
-				if condition == "success" {
-					// Try to get the URL and check the status code.
-					resp, err := http.Get(url)
-
-					// If the status code is not in the 2xx range, try again.
-					if err != nil || resp.StatusCode < 200 || resp.StatusCode > 299 {
-						message.Debug(err)
-						continue
-					}
-
-					// Success, break out of the switch statement.
-					break
-				}
+		switch resource {
+		case "http", "https":
+			// Handle HTTP and HTTPS endpoints.
+			url := fmt.Sprintf("%s://%s", resource, name)
 
-				// Convert the condition to an int and check if it's a valid HTTP status code.
-				code, err := strconv.Atoi(condition)
-				if err != nil {
-					return fmt.Errorf("http status code %s is not an integer: %w", condition, err)
-				}
-				if http.StatusText(code) == "" {
-					return errors.New("http status code %s is unknown")
-				}
+			// Try to get the URL and check the status code and body.
+			code, respBody, err := getResponse(waitCtx, url)
+			if err != nil {
+				message.Debug(err)
+				continue
+			}
+			if bodyRegex != nil && !bodyRegex.Match(respBody) {
+				message.Debug("response body of", url, "does not match", body)
+				continue
+			}
 
-				// Try to get the URL and check the status code.
-				resp, err := http.Get(url)
-				if err != nil || resp.StatusCode != code {
-					message.Debug(err)
-					continue
-				}
-			default:
-				// Fallback to any generic protocol using net.Dial
-				conn, err := net.Dial(resource, name)
-				if err != nil {
-					message.Debug(err)
-					continue
-				}
-				err = conn.Close()
-				if err != nil {
-					message.Debug(err)
+			// Default to checking for a 2xx response.
+			if condition == "success" {
+				// If the status code is not in the 2xx range, try again.
+				if code < 200 || code > 299 {
+					message.Debug("unexpected status code", code, "from", url)
 					continue
 				}
+
+				// Success, break out of the switch statement.
+				break
 			}
 
-			// Yay, we made it!
-			spinner.Success()
-			return nil
+			// Convert the condition to an int and check if it's a valid HTTP status code.
+			expected, err := strconv.Atoi(condition)
+			if err != nil {
+				return fmt.Errorf("http status code %s is not an integer: %w", condition, err)
+			}
+			if http.StatusText(expected) == "" {
+				return fmt.Errorf("http status code %s is unknown", condition)
+			}
+			if code != expected {
+				message.Debug("unexpected status code", code, "from", url)
+				continue
+			}
+		default:
+			// Fallback to any generic protocol using net.Dial
+			var dialer net.Dialer
+			conn, err := dialer.DialContext(waitCtx, resource, name)
+			if err != nil {
+				message.Debug(err)
+				continue
+			}
+			err = conn.Close()
+			if err != nil {
+				message.Debug(err)
+				continue
+			}
 		}
+
+		// Yay, we made it!
+		spinner.Success()
+		return nil
+	}
+}
+
+// maxResponseBodySize limits how much of a response body is read to match it.
+const maxResponseBodySize = 1 << 20
+
+// getResponse returns the status code and the start of the body of a GET request to the url.
+func getResponse(ctx context.Context, url string) (int, []byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return 0, nil, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseBodySize))
+	if err != nil {
+		return 0, nil, err
+	}
+	return resp.StatusCode, body, nil
+}
+
+// WaitForCommand runs the command in the shell every second until it exits with code 0. The command is run again up to
+// maxRetries times after the first run fails, a negative maxRetries runs it until the timeout.
+func WaitForCommand(ctx context.Context, shellPref v1alpha1.Shell, cfg exec.Config, cmd string, timeout time.Duration, maxRetries int) error {
+	if cmd == "" {
+		return errors.New("a command is required")
+	}
+
+	// Set the timeout for the wait-for command.
+	waitCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	spinner := message.NewProgressSpinner("Waiting for command %q to succeed.", cmd)
+	defer spinner.Stop()
+
+	shell, shellArgs := exec.GetOSShell(shellPref)
+	delay := 100 * time.Millisecond
+
+	for retries := 0; ; retries++ {
+		if maxRetries >= 0 && retries > maxRetries {
+			return fmt.Errorf("wait failed after %d retries", maxRetries)
+		}
+
+		// Delay the check for 100ms the first time and then 1 second after that.
+		if err := waitDelay(ctx, waitCtx, delay); err != nil {
+			return err
+		}
+		delay = time.Second
+
+		// The command is killed once the wait times out.
+		stdout, stderr, err := exec.CmdWithContext(waitCtx, cfg, shell, append(shellArgs, cmd)...)
+		if err != nil {
+			message.Debug(stdout, stderr, err)
+			continue
+		}
+
+		spinner.Success()
+		return nil
 	}
 }
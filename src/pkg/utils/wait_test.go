@@ -5,11 +5,20 @@
 package utils
 
 import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
+	"github.com/zarf-dev/zarf/src/api/v1alpha1"
 	"github.com/zarf-dev/zarf/src/pkg/message"
+	"github.com/zarf-dev/zarf/src/pkg/utils/exec"
 )
 
 type TestIsJSONPathWaitTypeSuite struct {
@@ -52,3 +61,59 @@ func TestIsJSONPathWaitType(t *testing.T) {
 	message.SetLogLevel(message.DebugLevel)
 	suite.Run(t, new(TestIsJSONPathWaitTypeSuite))
 }
+
+func TestWaitForNetworkEndpoint(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusAccepted)
+		_, _ = w.Write([]byte("status: ready"))
+	}))
+	t.Cleanup(srv.Close)
+	address := strings.TrimPrefix(srv.URL, "http://")
+
+	err := WaitForNetworkEndpoint(context.Background(), "http", address, "", "", time.Minute, -1)
+	require.NoError(t, err)
+	err = WaitForNetworkEndpoint(context.Background(), "http", address, "202", "", time.Minute, -1)
+	require.NoError(t, err)
+	err = WaitForNetworkEndpoint(context.Background(), "tcp", address, "", "", time.Minute, -1)
+	require.NoError(t, err)
+
+	err = WaitForNetworkEndpoint(context.Background(), "http", address, "200", "", 50*time.Millisecond, -1)
+	require.EqualError(t, err, "wait timed out")
+	err = WaitForNetworkEndpoint(context.Background(), "http", address, "200", "", time.Minute, 0)
+	require.EqualError(t, err, "wait failed after 0 retries")
+
+	err = WaitForNetworkEndpoint(context.Background(), "http", address, "", "^status: ready$", time.Minute, -1)
+	require.NoError(t, err)
+	err = WaitForNetworkEndpoint(context.Background(), "http", address, "", "starting", 50*time.Millisecond, -1)
+	require.EqualError(t, err, "wait timed out")
+	err = WaitForNetworkEndpoint(context.Background(), "http", address, "", "(ready", time.Minute, -1)
+	require.ErrorContains(t, err, `invalid body regular expression "(ready"`)
+	err = WaitForNetworkEndpoint(context.Background(), "tcp", address, "", "ready", time.Minute, -1)
+	require.EqualError(t, err, "a body can only be matched for http and https endpoints, not tcp")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	start := time.Now()
+	err = WaitForNetworkEndpoint(ctx, "http", address, "200", "", time.Minute, -1)
+	require.ErrorIs(t, err, context.Canceled)
+	require.Less(t, time.Since(start), time.Second)
+}
+
+func TestWaitForCommand(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	err := WaitForCommand(context.Background(), v1alpha1.Shell{}, exec.Config{Dir: dir, Env: []string{"WAIT_EXIT=0"}}, "exit $WAIT_EXIT", time.Minute, -1)
+	require.NoError(t, err)
+	err = WaitForCommand(context.Background(), v1alpha1.Shell{}, exec.Config{Dir: dir}, "exit 1", 50*time.Millisecond, -1)
+	require.EqualError(t, err, "wait timed out")
+	err = WaitForCommand(context.Background(), v1alpha1.Shell{}, exec.Config{Dir: dir}, "echo retry >> retries.txt && exit 1", time.Minute, 1)
+	require.EqualError(t, err, "wait failed after 1 retries")
+	b, err := os.ReadFile(filepath.Join(dir, "retries.txt"))
+	require.NoError(t, err)
+	require.Equal(t, "retry\nretry\n", string(b))
+	err = WaitForCommand(context.Background(), v1alpha1.Shell{}, exec.Config{}, "", time.Minute, -1)
+	require.EqualError(t, err, "a command is required")
+}
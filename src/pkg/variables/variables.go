@@ -9,6 +9,7 @@ import (
 	"regexp"
 
 	"github.com/zarf-dev/zarf/src/api/v1alpha1"
+	"github.com/zarf-dev/zarf/src/pkg/logger"
 )
 
 // SetVariableMap represents a map of variable names to their set values
@@ -32,6 +33,9 @@ func (vc *VariableConfig) PopulateVariables(variables []v1alpha1.InteractiveVari
 		// Variable is present, no need to continue checking
 		if present {
 			vc.setVariableMap[variable.Name].Sensitive = variable.Sensitive
+			if variable.Sensitive {
+				logger.AddSecret(vc.setVariableMap[variable.Name].Value)
+			}
 			vc.setVariableMap[variable.Name].AutoIndent = variable.AutoIndent
 			vc.setVariableMap[variable.Name].Type = variable.Type
 			if err := vc.CheckVariablePattern(variable.Name, variable.Pattern); err != nil {
@@ -65,6 +69,9 @@ func (vc *VariableConfig) PopulateVariables(variables []v1alpha1.InteractiveVari
 
 // SetVariable sets a variable in a VariableConfig's SetVariableMap
 func (vc *VariableConfig) SetVariable(name, value string, sensitive bool, autoIndent bool, varType v1alpha1.VariableType) {
+	if sensitive {
+		logger.AddSecret(value)
+	}
 	vc.setVariableMap[name] = &v1alpha1.SetVariable{
 		Variable: v1alpha1.Variable{
 			Name:       name,
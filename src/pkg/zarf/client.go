@@ -0,0 +1,113 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package zarf is the Go SDK for creating, deploying, inspecting and pulling Zarf packages.
+//
+// The exported API of this package follows semantic versioning, fields are only added to the option structs and
+// existing methods keep their signatures between minor releases. Tools embedding Zarf should use this package rather
+// than the packager implementations under src/internal, which change without notice.
+package zarf
+
+import (
+	"io/fs"
+	"sync"
+
+	"github.com/zarf-dev/zarf/src/config"
+	"github.com/zarf-dev/zarf/src/pkg/interactive"
+	"github.com/zarf-dev/zarf/src/pkg/lint"
+	"github.com/zarf-dev/zarf/src/pkg/message"
+)
+
+// ClientOptions are the settings shared by every operation of a Client.
+type ClientOptions struct {
+	// Architecture of the packages to create and load, defaults to the architecture of the host.
+	Architecture string
+	// CachePath is the directory images and git repositories are cached in, defaults to ~/.zarf-cache.
+	CachePath string
+	// TempDirectory is the directory packages are staged in, defaults to the temporary directory of the host.
+	TempDirectory string
+	// OCIConcurrency is the number of layers transferred at once with OCI registries, defaults to 3.
+	OCIConcurrency int
+	// PlainHTTP connects to OCI registries over HTTP instead of HTTPS.
+	PlainHTTP bool
+	// InsecureSkipTLSVerify skips verifying the certificates of OCI registries.
+	InsecureSkipTLSVerify bool
+	// Schema holds the zarf.schema.json of the Zarf release, which package definitions are validated against on
+	// create. It is embedded by the Zarf binary and has to be embedded by programs that create packages.
+	Schema fs.ReadFileFS
+	// Prompter answers the prompts for optional components, variables and key passwords. Without a prompter
+	// operations fail instead of prompting, and the input has to be passed in the options of each method.
+	Prompter interactive.Prompter
+	// Confirmer confirms deploys before they make changes. Without a confirmer deploys are confirmed automatically.
+	// With a confirmer and no prompter, pass the optional components to deploy in DeployOptions.
+	Confirmer interactive.Confirmer
+	// ProgressReporter receives the progress of operations in place of the progress bars and spinners drawn to the
+	// terminal.
+	ProgressReporter message.ProgressReporter
+}
+
+// Client performs Zarf package operations.
+//
+// Zarf keeps its settings in process wide state. The options of a Client are applied to that state for the duration
+// of each operation and restored afterwards, so the operations of all Clients in the process run one at a time.
+// Operations only ask for input through the Prompter and Confirmer of the options, choices that the CLI prompts for
+// are otherwise made through the option structs of each method.
+type Client struct {
+	opts ClientOptions
+}
+
+// NewClient creates a Client with the given options.
+func NewClient(opts ClientOptions) *Client {
+	if opts.CachePath == "" {
+		opts.CachePath = config.ZarfDefaultCachePath
+	}
+	if opts.OCIConcurrency <= 0 {
+		opts.OCIConcurrency = 3
+	}
+	return &Client{opts: opts}
+}
+
+// mu serializes the operations of all Clients as they share the process wide settings of Zarf.
+var mu sync.Mutex
+
+// apply applies the options of the client to the process wide settings of Zarf. The returned function restores the
+// previous settings and has to be called once the operation is done.
+func (c *Client) apply() func() {
+	mu.Lock()
+	prevArch := config.CLIArch
+	prevOpts := config.CommonOptions
+	prevPrompter := interactive.ActivePrompter()
+	prevConfirmer := interactive.ActiveConfirmer()
+	prevReporter := message.ActiveProgressReporter()
+	prevSchema := lint.ZarfSchema
+
+	config.CLIArch = c.opts.Architecture
+	config.CommonOptions.CachePath = c.opts.CachePath
+	config.CommonOptions.TempDirectory = c.opts.TempDirectory
+	config.CommonOptions.OCIConcurrency = c.opts.OCIConcurrency
+	config.CommonOptions.PlainHTTP = c.opts.PlainHTTP
+	config.CommonOptions.InsecureSkipTLSVerify = c.opts.InsecureSkipTLSVerify
+	config.CommonOptions.Confirm = c.opts.Confirmer == nil
+	config.CommonOptions.NonInteractive = c.opts.Prompter == nil
+	interactive.SetPrompter(c.opts.Prompter)
+	interactive.SetConfirmer(c.opts.Confirmer)
+	message.SetProgressReporter(c.opts.ProgressReporter)
+	if c.opts.Schema != nil {
+		lint.ZarfSchema = c.opts.Schema
+	}
+
+	return func() {
+		config.CLIArch = prevArch
+		config.CommonOptions = prevOpts
+		interactive.SetPrompter(prevPrompter)
+		interactive.SetConfirmer(prevConfirmer)
+		message.SetProgressReporter(prevReporter)
+		lint.ZarfSchema = prevSchema
+		mu.Unlock()
+	}
+}
+
+// Architecture returns the architecture the client creates and loads packages for.
+func (c *Client) Architecture() string {
+	return config.GetArch(c.opts.Architecture)
+}
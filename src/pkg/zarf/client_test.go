@@ -0,0 +1,125 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package zarf
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/zarf-dev/zarf/src/api/v1alpha1"
+	"github.com/zarf-dev/zarf/src/config"
+	"github.com/zarf-dev/zarf/src/pkg/interactive"
+	"github.com/zarf-dev/zarf/src/pkg/packager/sources"
+	"github.com/zarf-dev/zarf/src/test/testutil"
+)
+
+// The tests are not run in parallel as operations set process wide settings.
+
+func writeTestPackage(t *testing.T) string {
+	t.Helper()
+	packagePath := t.TempDir()
+	err := os.WriteFile(filepath.Join(packagePath, "hello.txt"), []byte("hello"), 0o600)
+	require.NoError(t, err)
+	definition := `kind: ZarfPackageConfig
+metadata:
+  name: sdk
+  version: "###ZARF_PKG_TMPL_VERSION###"
+components:
+  - name: hello
+    required: true
+    files:
+      - source: hello.txt
+        target: /tmp/hello.txt
+`
+	err = os.WriteFile(filepath.Join(packagePath, "zarf.yaml"), []byte(definition), 0o600)
+	require.NoError(t, err)
+	return packagePath
+}
+
+func TestCreateAndInspectPackage(t *testing.T) {
+	ctx := testutil.TestContext(t)
+
+	packagePath := writeTestPackage(t)
+	c := NewClient(ClientOptions{Architecture: "amd64", CachePath: t.TempDir(), Schema: testutil.LoadSchema(t, "../../../zarf.schema.json")})
+	outputDir := t.TempDir()
+	err := c.CreatePackage(ctx, packagePath, CreateOptions{
+		Output:       outputDir,
+		SetVariables: map[string]string{"version": "1.0.0"},
+		SkipSBOM:     true,
+	})
+	require.NoError(t, err)
+
+	pkg, err := c.InspectPackage(ctx, filepath.Join(outputDir, "zarf-package-sdk-amd64-1.0.0.tar.zst"), InspectOptions{})
+	require.NoError(t, err)
+	require.Equal(t, "sdk", pkg.Metadata.Name)
+	require.Equal(t, "1.0.0", pkg.Metadata.Version)
+	require.Equal(t, "amd64", pkg.Build.Architecture)
+	require.Len(t, pkg.Components, 1)
+	require.Equal(t, "hello", pkg.Components[0].Name)
+
+	_, err = c.InspectPackage(ctx, filepath.Join(outputDir, "zarf-package-sdk-amd64-1.0.0.tar.zst"), InspectOptions{Shasum: "0000"})
+	require.Error(t, err)
+}
+
+type testPrompter struct {
+	interactive.Prompter
+}
+
+func (testPrompter) PromptVariable(_ context.Context, variable v1alpha1.InteractiveVariable) (string, error) {
+	if variable.Name == "VERSION" {
+		return "2.0.0", nil
+	}
+	return "", fmt.Errorf("unexpected prompt for %s", variable.Name)
+}
+
+type testConfirmer struct{}
+
+func (testConfirmer) Confirm(_ context.Context, _ interactive.ConfirmRequest) (bool, error) {
+	return true, nil
+}
+
+func TestCreatePackageHooks(t *testing.T) {
+	ctx := testutil.TestContext(t)
+
+	packagePath := writeTestPackage(t)
+	c := NewClient(ClientOptions{
+		Architecture: "amd64",
+		CachePath:    t.TempDir(),
+		Schema:       testutil.LoadSchema(t, "../../../zarf.schema.json"),
+		Prompter:     testPrompter{},
+		Confirmer:    testConfirmer{},
+	})
+	outputDir := t.TempDir()
+	err := c.CreatePackage(ctx, packagePath, CreateOptions{Output: outputDir, SkipSBOM: true})
+	require.NoError(t, err)
+	require.FileExists(t, filepath.Join(outputDir, "zarf-package-sdk-amd64-2.0.0.tar.zst"))
+}
+
+func TestClientRestoresSettings(t *testing.T) {
+	ctx := testutil.TestContext(t)
+
+	tempDir := t.TempDir()
+	c := NewClient(ClientOptions{Architecture: "arm64", TempDirectory: tempDir, Confirmer: testConfirmer{}})
+	require.Empty(t, config.CLIArch)
+	require.NotEqual(t, tempDir, config.CommonOptions.TempDirectory)
+
+	_, err := c.InspectPackage(ctx, filepath.Join(t.TempDir(), "missing.tar.zst"), InspectOptions{})
+	require.Error(t, err)
+	require.Empty(t, config.CLIArch)
+	require.NotEqual(t, tempDir, config.CommonOptions.TempDirectory)
+	require.Nil(t, interactive.ActiveConfirmer())
+}
+
+func TestDeployPackageStdin(t *testing.T) {
+	ctx := testutil.TestContext(t)
+
+	c := NewClient(ClientOptions{Architecture: "amd64"})
+	err := c.DeployPackage(ctx, sources.StdinSource, DeployOptions{})
+	require.EqualError(t, err, "packages cannot be deployed from stdin with the SDK, pass the package as a file instead")
+}
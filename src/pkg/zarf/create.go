@@ -0,0 +1,109 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package zarf
+
+import (
+	"context"
+	"runtime/debug"
+	"strings"
+
+	"github.com/defenseunicorns/pkg/helpers/v2"
+
+	"github.com/zarf-dev/zarf/src/internal/packager/imagebuild"
+	"github.com/zarf-dev/zarf/src/internal/packager2"
+)
+
+// CreateOptions are the options for CreatePackage.
+type CreateOptions struct {
+	// Output is the directory or oci:// reference the package is written to, defaults to the current directory.
+	Output string
+	// Flavor selects the components with a matching only.flavor.
+	Flavor string
+	// SetVariables are the values of package templates, keys are case insensitive.
+	SetVariables map[string]string
+	// RegistryOverrides maps registries to the registries images are pulled from instead.
+	RegistryOverrides map[string]string
+	// SigningKeyPath is the private key the package is signed with.
+	SigningKeyPath string
+	// SigningKeyPassword is the password of the signing key.
+	SigningKeyPassword string
+	// MaxPackageSizeMB splits the package into parts of at most this size, zero does not split the package.
+	MaxPackageSizeMB int
+	// SkipSBOM skips generating the SBOMs of the package.
+	SkipSBOM bool
+	// SBOMOutputDir is the directory the SBOMs of the package are also written to.
+	SBOMOutputDir string
+	// DifferentialPackagePath is a previously created package whose images and repositories are left out.
+	DifferentialPackagePath string
+	// OverlayPaths are overlay files merged into the package definition in order.
+	OverlayPaths []string
+	// BuildCache reuses the components of earlier creates that did not change.
+	BuildCache bool
+	// RemoteCache is an oci:// reference the build cache is shared through.
+	RemoteCache string
+	// RemoteCacheReadOnly only reads from the remote cache.
+	RemoteCacheReadOnly bool
+	// MaxMemoryMB is the memory budget of the create in megabytes, zero for no budget.
+	MaxMemoryMB int
+	// MaxTempSpaceMB is the temporary space budget of the create in megabytes, zero for no budget.
+	MaxTempSpaceMB int
+	// IncludePlatforms are the platforms of multi-platform images kept in the package next to its architecture.
+	IncludePlatforms []string
+	// BuildkitAddr is the address of a remote buildkitd the images of components are built on.
+	BuildkitAddr string
+	// BuildkitPool is the label selector of buildkitd pods in the cluster the images of components are built on.
+	BuildkitPool string
+	// BuildkitPoolNamespace is the namespace of the buildkitd pods of the pool, defaults to buildkit.
+	BuildkitPoolNamespace string
+	// BuildkitTLSCACert is the CA certificate the remote buildkitd certificates are verified with.
+	BuildkitTLSCACert string
+	// BuildkitTLSCert is the client certificate presented to the remote buildkitd.
+	BuildkitTLSCert string
+	// BuildkitTLSKey is the key of the client certificate.
+	BuildkitTLSKey string
+	// BuildkitTLSServerName is the name the remote buildkitd certificates are verified for.
+	BuildkitTLSServerName string
+}
+
+// CreatePackage creates the package defined in the zarf.yaml of the given directory. The definition can also be fetched
+// from a git repository, such as git::https://example.com/org/repo.git//path?ref=v1.2.0, or from a skeleton package
+// in an OCI registry.
+func (c *Client) CreatePackage(ctx context.Context, packagePath string, opts CreateOptions) error {
+	defer c.apply()()
+
+	opt := packager2.CreateOptions{
+		Flavor:                  opts.Flavor,
+		RegistryOverrides:       opts.RegistryOverrides,
+		SigningKeyPath:          opts.SigningKeyPath,
+		SigningKeyPassword:      opts.SigningKeyPassword,
+		SetVariables:            helpers.TransformMapKeys(opts.SetVariables, strings.ToUpper),
+		MaxPackageSizeMB:        opts.MaxPackageSizeMB,
+		SBOMOut:                 opts.SBOMOutputDir,
+		SkipSBOM:                opts.SkipSBOM,
+		Output:                  opts.Output,
+		DifferentialPackagePath: opts.DifferentialPackagePath,
+		OverlayPaths:            opts.OverlayPaths,
+		BuildCache:              opts.BuildCache,
+		RemoteCache:             opts.RemoteCache,
+		RemoteCacheReadOnly:     opts.RemoteCacheReadOnly,
+		MaxMemoryMB:             opts.MaxMemoryMB,
+		MaxTempSpaceMB:          opts.MaxTempSpaceMB,
+		IncludePlatforms:        opts.IncludePlatforms,
+		Buildkit: imagebuild.Remote{
+			Addr:          opts.BuildkitAddr,
+			Pool:          opts.BuildkitPool,
+			PoolNamespace: opts.BuildkitPoolNamespace,
+			TLSCACert:     opts.BuildkitTLSCACert,
+			TLSCert:       opts.BuildkitTLSCert,
+			TLSKey:        opts.BuildkitTLSKey,
+			TLSServerName: opts.BuildkitTLSServerName,
+		},
+	}
+	if opt.MaxMemoryMB > 0 {
+		// Restore the limit of the embedding program once the create is done.
+		previous := debug.SetMemoryLimit(int64(opt.MaxMemoryMB) * 1000 * 1000)
+		defer debug.SetMemoryLimit(previous)
+	}
+	return packager2.Create(ctx, packagePath, opt)
+}
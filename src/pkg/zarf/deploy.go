@@ -0,0 +1,88 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package zarf
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/defenseunicorns/pkg/helpers/v2"
+
+	"github.com/zarf-dev/zarf/src/config"
+	"github.com/zarf-dev/zarf/src/pkg/packager"
+	"github.com/zarf-dev/zarf/src/pkg/packager/sources"
+	"github.com/zarf-dev/zarf/src/types"
+)
+
+// DeployOptions are the options for DeployPackage.
+type DeployOptions struct {
+	// Components are the optional components to deploy, supporting the globs and exclusions of the --components flag.
+	Components []string
+	// Features enables the components with a matching only.features.
+	Features []string
+	// SetVariables are the values of package variables, keys are case insensitive.
+	SetVariables map[string]string
+	// ValuesOverrides maps component names to chart names to Helm values that override the values of the chart.
+	ValuesOverrides map[string]map[string]map[string]any
+	// Shasum is the SHA256 the package is verified against.
+	Shasum string
+	// ChecksumsPath is a signed checksums file that split, URL and stdin packages are verified against.
+	ChecksumsPath string
+	// PublicKeyPath is the public key the signature of the package is verified with.
+	PublicKeyPath string
+	// SkipSignatureValidation skips verifying the signature of the package.
+	SkipSignatureValidation bool
+	// AdoptExistingResources adopts resources already in the cluster into the Helm releases of the package.
+	AdoptExistingResources bool
+	// Retries is the number of times image pushes and Helm installs are retried, defaults to 3.
+	Retries int
+	// Timeout of Helm operations, health checks and wait actions, overrides the timeouts of the components when set.
+	// Defaults to the timeout of each component or 15 minutes.
+	Timeout time.Duration
+}
+
+// DeployPackage deploys the package from the given source, a package tarball, split package, URL or oci:// reference,
+// to the cluster of the current Kubernetes context.
+func (c *Client) DeployPackage(ctx context.Context, source string, opts DeployOptions) error {
+	defer c.apply()()
+
+	if source == sources.StdinSource {
+		return errors.New("packages cannot be deployed from stdin with the SDK, pass the package as a file instead")
+	}
+	if opts.Retries <= 0 {
+		opts.Retries = config.ZarfDefaultRetries
+	}
+	timeoutOverride := opts.Timeout > 0
+	if !timeoutOverride {
+		opts.Timeout = config.ZarfDefaultTimeout
+	}
+
+	cfg := types.PackagerConfig{
+		PkgOpts: types.ZarfPackageOptions{
+			PackageSource:           source,
+			Shasum:                  opts.Shasum,
+			ChecksumsPath:           opts.ChecksumsPath,
+			OptionalComponents:      strings.Join(opts.Components, ","),
+			SetVariables:            helpers.TransformMapKeys(opts.SetVariables, strings.ToUpper),
+			PublicKeyPath:           opts.PublicKeyPath,
+			SkipSignatureValidation: opts.SkipSignatureValidation,
+			Retries:                 opts.Retries,
+		},
+		DeployOpts: types.ZarfDeployOptions{
+			AdoptExistingResources: opts.AdoptExistingResources,
+			Timeout:                opts.Timeout,
+			TimeoutOverride:        timeoutOverride,
+			ValuesOverridesMap:     opts.ValuesOverrides,
+			Features:               opts.Features,
+		},
+	}
+	pkgClient, err := packager.New(&cfg, packager.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	defer pkgClient.ClearTempPaths()
+	return pkgClient.Deploy(ctx)
+}
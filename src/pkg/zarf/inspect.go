@@ -0,0 +1,60 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package zarf
+
+import (
+	"context"
+
+	"github.com/zarf-dev/zarf/src/api/v1alpha1"
+	"github.com/zarf-dev/zarf/src/internal/packager2"
+	"github.com/zarf-dev/zarf/src/pkg/cluster"
+	"github.com/zarf-dev/zarf/src/pkg/packager/filters"
+)
+
+// InspectOptions are the options for InspectPackage.
+type InspectOptions struct {
+	// FromCluster reads the definition of a package deployed to the cluster of the current Kubernetes context, the
+	// source is then the name of the deployed package.
+	FromCluster bool
+	// Shasum is the SHA256 the package is verified against.
+	Shasum string
+	// PublicKeyPath is the public key the signature of the package is verified with.
+	PublicKeyPath string
+	// SkipSignatureValidation skips verifying the signature of the package.
+	SkipSignatureValidation bool
+}
+
+// InspectPackage returns the definition of the package from the given source, a package tarball, split package, URL
+// or oci:// reference.
+func (c *Client) InspectPackage(ctx context.Context, source string, opts InspectOptions) (v1alpha1.ZarfPackage, error) {
+	defer c.apply()()
+
+	if opts.FromCluster {
+		cs, err := cluster.NewCluster()
+		if err != nil {
+			return v1alpha1.ZarfPackage{}, err
+		}
+		depPkg, err := cs.GetDeployedPackage(ctx, source)
+		if err != nil {
+			return v1alpha1.ZarfPackage{}, err
+		}
+		return depPkg.Data, nil
+	}
+
+	loadOpt := packager2.LoadOptions{
+		Source:                  source,
+		Shasum:                  opts.Shasum,
+		Architecture:            c.Architecture(),
+		PublicKeyPath:           opts.PublicKeyPath,
+		SkipSignatureValidation: opts.SkipSignatureValidation,
+		Filter:                  filters.Empty(),
+	}
+	pkgLayout, err := packager2.LoadPackage(ctx, loadOpt)
+	if err != nil {
+		return v1alpha1.ZarfPackage{}, err
+	}
+	//nolint: errcheck // ignore
+	defer pkgLayout.Cleanup()
+	return pkgLayout.Pkg, nil
+}
@@ -0,0 +1,54 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package zarf
+
+import (
+	"context"
+	"strings"
+
+	"github.com/zarf-dev/zarf/src/internal/packager2"
+	"github.com/zarf-dev/zarf/src/pkg/packager/filters"
+)
+
+// PullOptions are the options for PullPackage.
+type PullOptions struct {
+	// OutputDir is the directory the package is written to, defaults to the current directory.
+	OutputDir string
+	// Components are the components pulled from oci:// references, supporting the globs and exclusions of the
+	// --components flag. All components are pulled when empty. A package pulled with some of its components only
+	// holds those components and their images and is not signed.
+	Components []string
+	// Shasum is the SHA256 the package is verified against.
+	Shasum string
+	// PublicKeyPath is the public key the signature of the package is verified with.
+	PublicKeyPath string
+	// SkipSignatureValidation skips verifying the signature of the package.
+	SkipSignatureValidation bool
+	// Layers selects the layers pulled from oci:// references instead of the whole package, such as metadata and
+	// sboms. The layers are written to a directory named after the package instead of a package tarball.
+	Layers []string
+}
+
+// PullPackage pulls the package from the given http(s):// URL or oci:// reference into a package tarball.
+func (c *Client) PullPackage(ctx context.Context, source string, opts PullOptions) error {
+	defer c.apply()()
+
+	outputDir := opts.OutputDir
+	if outputDir == "" {
+		outputDir = "."
+	}
+	filter := filters.Empty()
+	if len(opts.Components) > 0 {
+		filter = filters.BySelectState(strings.Join(opts.Components, ","))
+	}
+	pullOpt := packager2.PullOptions{
+		Shasum:                  opts.Shasum,
+		Architecture:            c.Architecture(),
+		PublicKeyPath:           opts.PublicKeyPath,
+		SkipSignatureValidation: opts.SkipSignatureValidation,
+		Filter:                  filter,
+		Layers:                  opts.Layers,
+	}
+	return packager2.Pull(ctx, source, outputDir, pullOpt)
+}
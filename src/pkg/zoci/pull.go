@@ -6,6 +6,7 @@ package zoci
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"path/filepath"
@@ -22,7 +23,7 @@ import (
 
 var (
 	// PackageAlwaysPull is a list of paths that will always be pulled from the remote repository.
-	PackageAlwaysPull = []string{layout.ZarfYAML, layout.Checksums, layout.Signature}
+	PackageAlwaysPull = []string{layout.ZarfYAML, layout.Checksums, layout.Signature, layout.ReleaseNotes, layout.BuildInfo}
 )
 
 // PullPackage pulls the package from the remote repository and saves it to the given path.
@@ -33,6 +34,8 @@ var (
 //   - zarf.yaml
 //   - checksums.txt
 //   - zarf.yaml.sig
+//   - release-notes.md
+//   - build-info.json
 func (r *Remote) PullPackage(ctx context.Context, destinationDir string, concurrency int, layersToPull ...ocispec.Descriptor) (_ []ocispec.Descriptor, err error) {
 	isPartialPull := len(layersToPull) > 0
 
@@ -82,6 +85,29 @@ func (r *Remote) PullPackage(ctx context.Context, destinationDir string, concurr
 	return layersToPull, nil
 }
 
+// PullLayers pulls only the given layers of the package and saves them to the given path, leaving any other files in
+// the path in place.
+func (r *Remote) PullLayers(ctx context.Context, destinationDir string, concurrency int, layers []ocispec.Descriptor) (err error) {
+	manifest, err := r.FetchRoot(ctx)
+	if err != nil {
+		return err
+	}
+	layers = append(layers, manifest.Config)
+
+	dst, err := file.New(destinationDir)
+	if err != nil {
+		return err
+	}
+	defer func(dst *file.Store) {
+		err2 := dst.Close()
+		err = errors.Join(err, err2)
+	}(dst)
+
+	copyOpts := r.GetDefaultCopyOpts()
+	copyOpts.Concurrency = concurrency
+	return r.CopyToTarget(ctx, layers, dst, copyOpts)
+}
+
 // LayersFromRequestedComponents returns the descriptors for the given components from the root manifest.
 //
 // It also retrieves the descriptors for all image layers that are required by the components.
@@ -140,27 +166,64 @@ func (r *Remote) LayersFromRequestedComponents(ctx context.Context, requestedCom
 					(layer.Annotations[ocispec.AnnotationBaseImageName] == refInfo.Path+refInfo.TagOrDigest && refInfo.Host == "docker.io")
 			})
 
-			// even though these are technically image manifests, we store them as Zarf blobs
-			manifestDescriptor.MediaType = ZarfLayerMediaTypeBlob
+			manifestLayers, err := r.imageLayers(ctx, root, manifestDescriptor)
+			if err != nil {
+				return nil, err
+			}
+			layers = append(layers, manifestLayers...)
 
-			manifest, err := r.FetchManifest(ctx, manifestDescriptor)
+			// Add the images of the other platforms kept for the image
+			indexDescriptor := helpers.Find(index.Manifests, func(layer ocispec.Descriptor) bool {
+				return layer.Annotations[utils.ImageIndexAnnotation] == refInfo.Reference
+			})
+			if indexDescriptor.Digest == "" {
+				continue
+			}
+			indexDescriptor.MediaType = ZarfLayerMediaTypeBlob
+			b, err := r.FetchLayer(ctx, indexDescriptor)
 			if err != nil {
 				return nil, err
 			}
-			// Add the manifest and the manifest config layers
-			layers = append(layers, root.Locate(filepath.Join(layout.ImagesBlobsDir, manifestDescriptor.Digest.Encoded())))
-			layers = append(layers, root.Locate(filepath.Join(layout.ImagesBlobsDir, manifest.Config.Digest.Encoded())))
-
-			// Add all the layers from the manifest
-			for _, layer := range manifest.Layers {
-				layerPath := filepath.Join(layout.ImagesBlobsDir, layer.Digest.Encoded())
-				layers = append(layers, root.Locate(layerPath))
+			var imageIndex ocispec.Index
+			if err := json.Unmarshal(b, &imageIndex); err != nil {
+				return nil, err
+			}
+			layers = append(layers, root.Locate(filepath.Join(layout.ImagesBlobsDir, indexDescriptor.Digest.Encoded())))
+			for _, platformDescriptor := range imageIndex.Manifests {
+				platformLayers, err := r.imageLayers(ctx, root, platformDescriptor)
+				if err != nil {
+					return nil, err
+				}
+				layers = append(layers, platformLayers...)
 			}
 		}
 	}
 	return layers, nil
 }
 
+// imageLayers returns the layers of the package that hold the manifest, config and layers of an image.
+func (r *Remote) imageLayers(ctx context.Context, root *oci.Manifest, manifestDescriptor ocispec.Descriptor) ([]ocispec.Descriptor, error) {
+	// even though these are technically image manifests, we store them as Zarf blobs
+	manifestDescriptor.MediaType = ZarfLayerMediaTypeBlob
+
+	manifest, err := r.FetchManifest(ctx, manifestDescriptor)
+	if err != nil {
+		return nil, err
+	}
+	// Add the manifest and the manifest config layers
+	layers := []ocispec.Descriptor{
+		root.Locate(filepath.Join(layout.ImagesBlobsDir, manifestDescriptor.Digest.Encoded())),
+		root.Locate(filepath.Join(layout.ImagesBlobsDir, manifest.Config.Digest.Encoded())),
+	}
+
+	// Add all the layers from the manifest
+	for _, layer := range manifest.Layers {
+		layerPath := filepath.Join(layout.ImagesBlobsDir, layer.Digest.Encoded())
+		layers = append(layers, root.Locate(layerPath))
+	}
+	return layers, nil
+}
+
 // PullPackageMetadata pulls the package metadata from the remote repository and saves it to `destinationDir`.
 func (r *Remote) PullPackageMetadata(ctx context.Context, destinationDir string) ([]ocispec.Descriptor, error) {
 	return r.PullPaths(ctx, destinationDir, PackageAlwaysPull)
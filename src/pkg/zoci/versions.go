@@ -0,0 +1,169 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+// Package zoci contains functions for interacting with Zarf packages stored in OCI registries.
+package zoci
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/defenseunicorns/pkg/helpers/v2"
+	"github.com/defenseunicorns/pkg/oci"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2/content"
+)
+
+// tagRegex matches valid OCI distribution tags, anything else in the tag position of a URL is treated as a semver constraint.
+var tagRegex = regexp.MustCompile(`^[\w][\w.-]{0,127}$`)
+
+// PackageVersion is a tag of a published package and the platforms it is available for.
+type PackageVersion struct {
+	Tag       string   `json:"tag"`
+	Digest    string   `json:"digest"`
+	Platforms []string `json:"platforms"`
+}
+
+// ParseVersionConstraint splits an OCI URL into the URL without its tag and the semver constraint held in the tag.
+// A nil constraint is returned when the URL has no tag, references a digest, or the tag is a literal OCI tag.
+func ParseVersionConstraint(url string) (string, *semver.Constraints, error) {
+	if strings.Contains(url, "@") {
+		return url, nil, nil
+	}
+	trimmed := strings.TrimPrefix(url, helpers.OCIURLPrefix)
+	repoIdx := strings.LastIndex(trimmed, "/")
+	tagIdx := strings.Index(trimmed[repoIdx+1:], ":")
+	if repoIdx == -1 || tagIdx == -1 {
+		return url, nil, nil
+	}
+	tagIdx += repoIdx + 1
+	tag := trimmed[tagIdx+1:]
+	if tagRegex.MatchString(tag) {
+		return url, nil, nil
+	}
+	constraint, err := semver.NewConstraint(tag)
+	if err != nil {
+		return "", nil, fmt.Errorf("tag %q is neither a valid OCI tag nor a semver constraint: %w", tag, err)
+	}
+	return helpers.OCIURLPrefix + trimmed[:tagIdx], constraint, nil
+}
+
+// SelectVersion returns the tag with the highest semver version that satisfies the constraint.
+func SelectVersion(tags []string, constraint *semver.Constraints) (string, error) {
+	var selectedTag string
+	var selected *semver.Version
+	for _, tag := range tags {
+		v, err := semver.NewVersion(tag)
+		if err != nil {
+			continue
+		}
+		if !constraint.Check(v) {
+			continue
+		}
+		if selected == nil || v.GreaterThan(selected) {
+			selected = v
+			selectedTag = tag
+		}
+	}
+	if selected == nil {
+		return "", fmt.Errorf("no published version satisfies constraint %q", constraint.String())
+	}
+	return selectedTag, nil
+}
+
+// ResolveVersionConstraint resolves a semver constraint in the tag of an OCI URL to the highest matching published tag.
+// URLs without a constraint are returned unchanged.
+func ResolveVersionConstraint(ctx context.Context, url string, platform ocispec.Platform, mods ...oci.Modifier) (string, error) {
+	repoURL, constraint, err := ParseVersionConstraint(url)
+	if err != nil {
+		return "", err
+	}
+	if constraint == nil {
+		return url, nil
+	}
+	remote, err := NewRemote(ctx, repoURL, platform, mods...)
+	if err != nil {
+		return "", err
+	}
+	tags, err := remote.ListTags(ctx)
+	if err != nil {
+		return "", err
+	}
+	tag, err := SelectVersion(tags, constraint)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", repoURL, err)
+	}
+	return fmt.Sprintf("%s:%s", repoURL, tag), nil
+}
+
+// ListTags returns all tags of the remote repository.
+func (r *Remote) ListTags(ctx context.Context) ([]string, error) {
+	tags := []string{}
+	err := r.Repo().Tags(ctx, "", func(page []string) error {
+		tags = append(tags, page...)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to list tags for %s: %w", r.Repo().Reference, err)
+	}
+	return tags, nil
+}
+
+// ListVersions returns every tag of the remote repository with the digest and platforms it references.
+// Semver tags are sorted in descending order followed by all other tags (i.e. channels such as stable) in lexical order.
+func (r *Remote) ListVersions(ctx context.Context) ([]PackageVersion, error) {
+	tags, err := r.ListTags(ctx)
+	if err != nil {
+		return nil, err
+	}
+	versions := []PackageVersion{}
+	for _, tag := range tags {
+		desc, err := r.Repo().Resolve(ctx, tag)
+		if err != nil {
+			return nil, err
+		}
+		version := PackageVersion{
+			Tag:       tag,
+			Digest:    desc.Digest.String(),
+			Platforms: []string{},
+		}
+		if desc.MediaType == ocispec.MediaTypeImageIndex {
+			b, err := content.FetchAll(ctx, r.Repo(), desc)
+			if err != nil {
+				return nil, err
+			}
+			var index ocispec.Index
+			err = json.Unmarshal(b, &index)
+			if err != nil {
+				return nil, err
+			}
+			for _, manifest := range index.Manifests {
+				if manifest.Platform == nil {
+					continue
+				}
+				version.Platforms = append(version.Platforms, manifest.Platform.Architecture)
+			}
+		}
+		versions = append(versions, version)
+	}
+	slices.SortStableFunc(versions, func(a, b PackageVersion) int {
+		av, aErr := semver.NewVersion(a.Tag)
+		bv, bErr := semver.NewVersion(b.Tag)
+		switch {
+		case aErr == nil && bErr == nil:
+			return bv.Compare(av)
+		case aErr == nil:
+			return -1
+		case bErr == nil:
+			return 1
+		default:
+			return strings.Compare(a.Tag, b.Tag)
+		}
+	})
+	return versions, nil
+}
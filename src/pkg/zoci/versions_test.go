@@ -0,0 +1,183 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021-Present The Zarf Authors
+
+package zoci
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/defenseunicorns/pkg/helpers/v2"
+	"github.com/defenseunicorns/pkg/oci"
+	"github.com/opencontainers/image-spec/specs-go"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/stretchr/testify/require"
+	"oras.land/oras-go/v2"
+
+	"github.com/zarf-dev/zarf/src/test/testutil"
+)
+
+func TestParseVersionConstraint(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name               string
+		url                string
+		expectedURL        string
+		expectedConstraint string
+		expectedErr        string
+	}{
+		{
+			name:        "literal tag",
+			url:         "oci://example.com/component:1.2.0",
+			expectedURL: "oci://example.com/component:1.2.0",
+		},
+		{
+			name:        "no tag with registry port",
+			url:         "oci://localhost:5000/component",
+			expectedURL: "oci://localhost:5000/component",
+		},
+		{
+			name:        "digest",
+			url:         "oci://example.com/component@sha256:3b0e8a9c1f4b6d2e7a5c8f9b0d1e2f3a4b5c6d7e8f9a0b1c2d3e4f5a6b7c8d9e",
+			expectedURL: "oci://example.com/component@sha256:3b0e8a9c1f4b6d2e7a5c8f9b0d1e2f3a4b5c6d7e8f9a0b1c2d3e4f5a6b7c8d9e",
+		},
+		{
+			name:               "caret constraint",
+			url:                "oci://localhost:5000/lib/component:^1.2",
+			expectedURL:        "oci://localhost:5000/lib/component",
+			expectedConstraint: "^1.2",
+		},
+		{
+			name:               "range constraint",
+			url:                "oci://example.com/component:>=1.0.0 <2.0.0",
+			expectedURL:        "oci://example.com/component",
+			expectedConstraint: ">=1.0.0 <2.0.0",
+		},
+		{
+			name:        "invalid constraint",
+			url:         "oci://example.com/component:^foo",
+			expectedErr: "tag \"^foo\" is neither a valid OCI tag nor a semver constraint",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			url, constraint, err := ParseVersionConstraint(tt.url)
+			if tt.expectedErr != "" {
+				require.ErrorContains(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expectedURL, url)
+			if tt.expectedConstraint == "" {
+				require.Nil(t, constraint)
+				return
+			}
+			require.Equal(t, tt.expectedConstraint, constraint.String())
+		})
+	}
+}
+
+func TestSelectVersion(t *testing.T) {
+	t.Parallel()
+
+	tags := []string{"latest", "1.1.0", "1.2.0", "1.2.3", "1.3.0-rc.1", "1.3.0-upstream", "2.0.0", "v1.4.1"}
+
+	tests := []struct {
+		name        string
+		constraint  string
+		expected    string
+		expectedErr string
+	}{
+		{
+			name:       "caret selects highest minor",
+			constraint: "^1.2",
+			expected:   "v1.4.1",
+		},
+		{
+			name:       "tilde selects highest patch",
+			constraint: "~1.2",
+			expected:   "1.2.3",
+		},
+		{
+			name:       "pre-releases and flavors are excluded",
+			constraint: ">=1.2.3 <1.4.0",
+			expected:   "1.2.3",
+		},
+		{
+			name:        "no matching version",
+			constraint:  "^3",
+			expectedErr: "no published version satisfies constraint \"^3\"",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			constraint, err := semver.NewConstraint(tt.constraint)
+			require.NoError(t, err)
+			tag, err := SelectVersion(tags, constraint)
+			if tt.expectedErr != "" {
+				require.EqualError(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, tag)
+		})
+	}
+}
+
+func TestListVersions(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	port, err := helpers.GetAvailablePort()
+	require.NoError(t, err)
+	registryURL := testutil.SetupInMemoryRegistry(ctx, t, port)
+	remote, err := NewRemote(ctx, fmt.Sprintf("%s/test", registryURL), PlatformForSkeleton(), oci.WithPlainHTTP(true))
+	require.NoError(t, err)
+
+	pushIndex := func(tags []string, archs ...string) {
+		t.Helper()
+		index := ocispec.Index{
+			Versioned: specs.Versioned{SchemaVersion: 2},
+			MediaType: ocispec.MediaTypeImageIndex,
+		}
+		for _, arch := range archs {
+			desc, err := oras.PackManifest(ctx, remote.Repo(), oras.PackManifestVersion1_1, ZarfConfigMediaType, oras.PackManifestOptions{
+				ManifestAnnotations: map[string]string{ocispec.AnnotationTitle: arch},
+			})
+			require.NoError(t, err)
+			desc.Platform = &ocispec.Platform{OS: oci.MultiOS, Architecture: arch}
+			index.Manifests = append(index.Manifests, desc)
+		}
+		b, err := json.Marshal(index)
+		require.NoError(t, err)
+		_, err = oras.TagBytesN(ctx, remote.Repo(), ocispec.MediaTypeImageIndex, b, tags, oras.DefaultTagBytesNOptions)
+		require.NoError(t, err)
+	}
+	pushIndex([]string{"1.0.0"}, "amd64")
+	pushIndex([]string{"1.10.0", "stable"}, "amd64", "arm64")
+	pushIndex([]string{"1.2.0"}, "arm64")
+
+	versions, err := remote.ListVersions(ctx)
+	require.NoError(t, err)
+	tags := []string{}
+	for _, v := range versions {
+		tags = append(tags, v.Tag)
+	}
+	require.Equal(t, []string{"1.10.0", "1.2.0", "1.0.0", "stable"}, tags)
+	require.Equal(t, []string{"amd64", "arm64"}, versions[0].Platforms)
+	require.Equal(t, versions[0].Digest, versions[3].Digest)
+
+	resolved, err := ResolveVersionConstraint(ctx, fmt.Sprintf("oci://%s/test:~1.2", registryURL), PlatformForSkeleton(), oci.WithPlainHTTP(true))
+	require.NoError(t, err)
+	require.Equal(t, fmt.Sprintf("oci://%s/test:1.2.0", registryURL), resolved)
+}
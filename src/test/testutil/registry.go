@@ -7,6 +7,7 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"net"
 	"testing"
 	"time"
 
@@ -31,5 +32,16 @@ func SetupInMemoryRegistry(ctx context.Context, t *testing.T, port int) string {
 	require.NoError(t, err)
 	//nolint:errcheck // ignore
 	go ref.ListenAndServe()
-	return fmt.Sprintf("localhost:%d", port)
+	addr := fmt.Sprintf("localhost:%d", port)
+	// Wait for the registry to accept connections to avoid racing the first request.
+	require.Eventually(t, func() bool {
+		conn, err := net.Dial("tcp", addr)
+		if err != nil {
+			return false
+		}
+		//nolint:errcheck // ignore
+		conn.Close()
+		return true
+	}, 5*time.Second, 10*time.Millisecond)
+	return addr
 }
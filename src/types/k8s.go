@@ -10,6 +10,7 @@ import (
 	"github.com/defenseunicorns/pkg/helpers/v2"
 	"github.com/zarf-dev/zarf/src/api/v1alpha1"
 	"github.com/zarf-dev/zarf/src/config/lang"
+	"github.com/zarf-dev/zarf/src/pkg/transform"
 )
 
 // ComponentStatus defines the deployment status of a Zarf component within a package.
@@ -21,6 +22,9 @@ const (
 	ComponentStatusFailed    ComponentStatus = "Failed"
 	ComponentStatusDeploying ComponentStatus = "Deploying"
 	ComponentStatusRemoving  ComponentStatus = "Removing"
+	// ComponentStatusInterrupted is recorded for the component that was deploying when the deployment was interrupted,
+	// leaving the package partially deployed.
+	ComponentStatusInterrupted ComponentStatus = "Interrupted"
 )
 
 // Values during setup of the initial zarf state
@@ -36,8 +40,72 @@ const (
 
 	ZarfInClusterGitServiceURL      = "http://zarf-gitea-http.zarf.svc.cluster.local:3000"
 	ZarfInClusterArtifactServiceURL = ZarfInClusterGitServiceURL + "/api/packages/" + ZarfGitPushUser
+
+	ZarfInClusterGitServiceTLSURL      = "https://zarf-gitea-http.zarf.svc.cluster.local:3000"
+	ZarfInClusterArtifactServiceTLSURL = ZarfInClusterGitServiceTLSURL + "/api/packages/" + ZarfGitPushUser
+)
+
+// Storage modes of the internal registry
+const (
+	// RegistryStoragePVC stores the images of the internal registry on a persistent volume claim.
+	RegistryStoragePVC = "pvc"
+	// RegistryStorageEphemeral stores the images of the internal registry in an emptyDir volume, they are lost
+	// whenever the registry pod restarts.
+	RegistryStorageEphemeral = "ephemeral"
+	// RegistryStorageS3 stores the images of the internal registry in an S3 bucket.
+	RegistryStorageS3 = "s3"
+)
+
+// RegistryStorageModes are the storage modes of the internal registry.
+var RegistryStorageModes = []string{RegistryStoragePVC, RegistryStorageEphemeral, RegistryStorageS3}
+
+// Authentication modes of the internal registry
+const (
+	// RegistryAuthHtpasswd authenticates every consumer of the internal registry with the shared push and pull users.
+	RegistryAuthHtpasswd = "htpasswd"
+	// RegistryAuthRobot additionally gives every robot namespace its own static token. The tokens are users of the
+	// htpasswd file and are not scoped to their namespace, each of them can push and pull every repository.
+	RegistryAuthRobot = "robot"
+	// RegistryAuthOIDC delegates authentication to the token endpoint of an external OIDC provider.
+	RegistryAuthOIDC = "oidc"
 )
 
+// RegistryAuthModes are the authentication modes of the internal registry.
+var RegistryAuthModes = []string{RegistryAuthHtpasswd, RegistryAuthRobot, RegistryAuthOIDC}
+
+// RegistryRobotUserPrefix is the prefix of the usernames of the robot tokens of namespaces.
+const RegistryRobotUserPrefix = "zarf-robot-"
+
+// RegistryTokenAuth contains the token endpoint the internal registry delegates authentication to.
+type RegistryTokenAuth struct {
+	// URL of the token endpoint clients are redirected to
+	Realm string `json:"realm"`
+	// Name of the registry service the tokens are issued for
+	Service string `json:"service"`
+	// Issuer of the tokens
+	Issuer string `json:"issuer"`
+	// PEM encoded certificates of the keys the tokens are signed with
+	RootCertBundle []byte `json:"rootCertBundle"`
+}
+
+// TLSInfo contains information about the certificate a Zarf managed service is served with.
+type TLSInfo struct {
+	// Name of the kubernetes.io/tls secret in the Zarf namespace holding the certificate and key
+	SecretName string `json:"secretName"`
+	// cert-manager issuer of the certificate in the form [Kind/]name, empty if the certificate was provided directly
+	Issuer string `json:"issuer,omitempty"`
+	// PEM encoded CA bundle the certificate is verified against
+	CA string `json:"ca,omitempty"`
+}
+
+// CABundle returns the PEM encoded CA bundle of the certificate, or nil when the service is not served with TLS.
+func (ti *TLSInfo) CABundle() []byte {
+	if ti == nil || ti.CA == "" {
+		return nil
+	}
+	return []byte(ti.CA)
+}
+
 // GeneratedPKI is a struct for storing generated PKI data.
 type GeneratedPKI struct {
 	CA   []byte `json:"ca"`
@@ -47,6 +115,10 @@ type GeneratedPKI struct {
 
 // ZarfState is maintained as a secret in the Zarf namespace to track Zarf init data.
 type ZarfState struct {
+	// Version of the schema of the state, states written before the version was recorded are at version 0
+	SchemaVersion int `json:"schemaVersion"`
+	// Name of the Zarf instance the state belongs to, empty for the default instance
+	Instance string `json:"instance,omitempty"`
 	// Indicates if Zarf was initialized while deploying its own k8s cluster
 	ZarfAppliance bool `json:"zarfAppliance"`
 	// K8s distribution of the cluster Zarf was deployed to
@@ -64,6 +136,21 @@ type ZarfState struct {
 	RegistryInfo RegistryInfo `json:"registryInfo"`
 	// Information about the artifact registry Zarf is configured to use
 	ArtifactServer ArtifactServerInfo `json:"artifactServer"`
+	// Label selector of the namespaces that receive the Zarf managed image pull secret, empty for the namespaces Zarf
+	// deploys to
+	PullSecretSelector string `json:"pullSecretSelector,omitempty"`
+}
+
+// ClusterFacts contains the facts about a cluster that components can be conditionally deployed on.
+type ClusterFacts struct {
+	// K8s distribution of the cluster
+	Distro string
+	// Kubernetes version of the API server
+	Version string
+	// Cloud providers of the nodes as reported by their provider IDs
+	Providers []string
+	// Labels of every node in the cluster
+	NodeLabels []map[string]string
 }
 
 // DeployedPackage contains information about a Zarf Package that has been deployed to a cluster
@@ -75,6 +162,42 @@ type DeployedPackage struct {
 	Generation         int                  `json:"generation"`
 	DeployedComponents []DeployedComponent  `json:"deployedComponents"`
 	ConnectStrings     ConnectStrings       `json:"connectStrings,omitempty"`
+	// Source the package was deployed from, recorded when the registry uses ephemeral storage to push the images of
+	// the package again after the registry restarts
+	Source string `json:"source,omitempty"`
+}
+
+// PackageDiff is the difference between a package and the version of it that is deployed to the cluster.
+type PackageDiff struct {
+	// The version of the deployed package.
+	FromVersion string
+	// The version of the package being deployed.
+	ToVersion string
+	// Whether the package being deployed is older than the deployed package.
+	Downgrade         bool
+	AddedComponents   []string
+	RemovedComponents []string
+	AddedImages       []string
+	RemovedImages     []string
+	ChangedImages     []ImageDigestChange
+	AddedVariables    []string
+	RemovedVariables  []string
+	ChangedVariables  []string
+}
+
+// ImageDigestChange is an image that is pulled from a different digest under the same reference.
+type ImageDigestChange struct {
+	Reference  string
+	FromDigest string
+	ToDigest   string
+}
+
+// IsEmpty returns true if the package does not change the version, components, images or variables of the deployed package.
+func (d PackageDiff) IsEmpty() bool {
+	return d.FromVersion == d.ToVersion &&
+		len(d.AddedComponents) == 0 && len(d.RemovedComponents) == 0 &&
+		len(d.AddedImages) == 0 && len(d.RemovedImages) == 0 && len(d.ChangedImages) == 0 &&
+		len(d.AddedVariables) == 0 && len(d.RemovedVariables) == 0 && len(d.ChangedVariables) == 0
 }
 
 // ConnectString contains information about a connection made with Zarf connect.
@@ -115,11 +238,13 @@ type GitServerInfo struct {
 	PullPassword string `json:"pullPassword"`
 	// URL address of the git server
 	Address string `json:"address"`
+	// TLS certificate the internal git server is served with
+	TLS *TLSInfo `json:"tls,omitempty"`
 }
 
 // IsInternal returns true if the git server URL is equivalent to a git server deployed through the default init package
 func (gs GitServerInfo) IsInternal() bool {
-	return gs.Address == ZarfInClusterGitServiceURL
+	return gs.Address == ZarfInClusterGitServiceURL || gs.Address == ZarfInClusterGitServiceTLSURL
 }
 
 // FillInEmptyValues sets every necessary value that's currently empty to a reasonable default
@@ -170,7 +295,7 @@ type ArtifactServerInfo struct {
 
 // IsInternal returns true if the artifact server URL is equivalent to the artifact server deployed through the default init package
 func (as ArtifactServerInfo) IsInternal() bool {
-	return as.Address == ZarfInClusterArtifactServiceURL
+	return as.Address == ZarfInClusterArtifactServiceURL || as.Address == ZarfInClusterArtifactServiceTLSURL
 }
 
 // FillInEmptyValues sets every necessary value that's currently empty to a reasonable default
@@ -202,6 +327,28 @@ type RegistryInfo struct {
 	NodePort int `json:"nodePort"`
 	// Secret value that the registry was seeded with
 	Secret string `json:"secret"`
+	// TLS certificate the internal registry is served with
+	TLS *TLSInfo `json:"tls,omitempty"`
+	// Rules that rewrite the paths images are pushed to and pulled from in the registry
+	ImageRules []transform.ImageRule `json:"imageRules,omitempty"`
+	// API used to create the projects images are pushed to when they do not exist, harbor or quay
+	ProjectAPI string `json:"projectAPI,omitempty"`
+	// Username of a user or robot account allowed to create projects. If not provided the push-user is used
+	ProjectUsername string `json:"projectUsername,omitempty"`
+	// Password of the project user, or the OAuth token for Quay
+	ProjectPassword string `json:"projectPassword,omitempty"`
+	// Push with short-lived tokens from the token endpoint of the registry that are scoped to a single repository
+	ScopedTokens bool `json:"scopedTokens,omitempty"`
+	// Storage mode of the internal registry, pvc, ephemeral or s3. Empty for external registries and for registries
+	// initialized before the storage mode was recorded, which use a persistent volume claim.
+	Storage string `json:"storage,omitempty"`
+	// Authentication mode of the internal registry, htpasswd, robot or oidc. Empty for external registries and for
+	// registries initialized before the authentication mode was recorded, which use htpasswd.
+	Auth string `json:"auth,omitempty"`
+	// Unscoped static tokens of the robot namespaces by namespace
+	RobotTokens map[string]string `json:"robotTokens,omitempty"`
+	// Token endpoint of the OIDC provider the internal registry delegates authentication to
+	TokenAuth *RegistryTokenAuth `json:"tokenAuth,omitempty"`
 }
 
 // IsInternal returns true if the registry URL is equivalent to the registry deployed through the default init package
@@ -209,6 +356,27 @@ func (ri RegistryInfo) IsInternal() bool {
 	return ri.Address == fmt.Sprintf("%s:%d", helpers.IPV4Localhost, ri.NodePort)
 }
 
+// PullCredentials returns the username and password pods in the namespace pull images with, the robot token of the
+// namespace if it has one and otherwise the pull user.
+func (ri RegistryInfo) PullCredentials(namespace string) (string, string) {
+	if token, ok := ri.RobotTokens[namespace]; ok {
+		return RegistryRobotUserPrefix + namespace, token
+	}
+	return ri.PullUsername, ri.PullPassword
+}
+
+// HtpasswdUsers returns the passwords of the users of the htpasswd file of the internal registry by username.
+func (ri RegistryInfo) HtpasswdUsers() map[string]string {
+	users := map[string]string{
+		ri.PushUsername: ri.PushPassword,
+		ri.PullUsername: ri.PullPassword,
+	}
+	for namespace, token := range ri.RobotTokens {
+		users[RegistryRobotUserPrefix+namespace] = token
+	}
+	return users
+}
+
 // FillInEmptyValues sets every necessary value not already set to a reasonable default
 func (ri *RegistryInfo) FillInEmptyValues() error {
 	var err error
@@ -222,6 +390,14 @@ func (ri *RegistryInfo) FillInEmptyValues() error {
 		ri.Address = fmt.Sprintf("%s:%d", helpers.IPV4Localhost, ri.NodePort)
 	}
 
+	// The storage and authentication modes only apply to the internal registry
+	if !ri.IsInternal() {
+		ri.Storage = ""
+		ri.Auth = ""
+		ri.RobotTokens = nil
+		ri.TokenAuth = nil
+	}
+
 	// Generate a push-user password if not provided by init flag
 	if ri.PushPassword == "" {
 		if ri.PushPassword, err = helpers.RandomString(ZarfGeneratedPasswordLen); err != nil {
@@ -12,6 +12,8 @@ import (
 type ZarfCommonOptions struct {
 	// Verify that Zarf should perform an action
 	Confirm bool
+	// Fail instead of prompting for input
+	NonInteractive bool
 	// Allow insecure connections for remote packages
 	Insecure bool
 	// Disable checking the server TLS certificate for validity
@@ -24,6 +26,10 @@ type ZarfCommonOptions struct {
 	TempDirectory string
 	// Number of concurrent layer operations to perform when interacting with a remote package
 	OCIConcurrency int
+	// Enforce the FIPS crypto policy
+	FIPS bool
+	// Name of the Zarf instance of the cluster to use, empty for the default instance
+	Instance string
 }
 
 // ZarfPackageOptions tracks the user-defined preferences during common package operations.
@@ -44,6 +50,8 @@ type ZarfPackageOptions struct {
 	Retries int
 	// Skip validating the signature of the Zarf package
 	SkipSignatureValidation bool
+	// Location of a signed checksums file that split, URL and stdin package sources are verified against before they are loaded
+	ChecksumsPath string
 }
 
 // ZarfInspectOptions tracks the user-defined preferences during a package inspection.
@@ -54,6 +62,8 @@ type ZarfInspectOptions struct {
 	SBOMOutputDir string
 	// ListImages will list the images in the package
 	ListImages bool
+	// ReleaseNotes prints the release notes embedded in the package
+	ReleaseNotes bool
 }
 
 // ZarfFindImagesOptions tracks the user-defined preferences during a prepare find-images search.
@@ -74,18 +84,61 @@ type ZarfFindImagesOptions struct {
 type ZarfDeployOptions struct {
 	// Whether to adopt any pre-existing K8s resources into the Helm charts managed by Zarf
 	AdoptExistingResources bool
-	// Timeout for performing Helm operations
+	// Timeout for performing Helm operations, health checks and wait actions
 	Timeout time.Duration
+	// Whether Timeout was set explicitly and takes precedence over the timeouts of the components
+	TimeoutOverride bool
 	// [Library Only] A map of component names to chart names containing Helm Chart values to override values on deploy
 	ValuesOverridesMap map[string]map[string]map[string]interface{}
 	// [Dev Deploy Only] Manual override for ###ZARF_REGISTRY###
 	RegistryURL string
+	// Feature flags that enable components with matching 'only.features'
+	Features []string
+	// ServiceAccount in the form namespace/name that is impersonated when deploying to the cluster
+	ServiceAccount string
+	// Labels added to every resource Zarf applies and to the pod templates of workloads
+	Labels map[string]string
+	// Annotations added to every resource Zarf applies and to the pod templates of workloads
+	Annotations map[string]string
+	// Node selectors added to the pod spec of every workload Zarf applies
+	NodeSelector map[string]string
+	// Tolerations in the form key[=value][:effect] added to the pod spec of every workload Zarf applies
+	Tolerations []string
+	// Hostnames mapped to an IP address, in-cluster service, registry or git-server in the pods of every workload Zarf applies
+	HostAliases map[string]string
+	// Whether to print the release notes embedded in the package before the deploy is confirmed
+	ShowReleaseNotes bool
+	// How a cluster without the capacity for the requirements of the components is handled, one of CapacityChecks
+	CapacityCheck string
+	// Whether the systemd services of the components may be installed onto the deploy host
+	AllowHostServices bool
+	// Whether the host artifacts of the components may be installed onto the deploy host
+	AllowHostArtifacts bool
+	// Whether the contents of each component are extracted just before it is deployed instead of up front
+	LazyLoad bool
 }
 
+// Ways a cluster without the capacity for the requirements of the components is handled on deploy
+const (
+	// CapacityCheckWarn warns about the missing capacity and deploys the package.
+	CapacityCheckWarn = "warn"
+	// CapacityCheckFail fails the deploy before anything is pushed to the cluster.
+	CapacityCheckFail = "fail"
+	// CapacityCheckSkip does not check the capacity of the cluster.
+	CapacityCheckSkip = "skip"
+)
+
+// CapacityChecks are the ways a cluster without the capacity for the requirements of the components is handled.
+var CapacityChecks = []string{CapacityCheckWarn, CapacityCheckFail, CapacityCheckSkip}
+
 // ZarfMirrorOptions tracks the user-defined preferences during a package mirror.
 type ZarfMirrorOptions struct {
 	// Whether to skip adding a Zarf checksum to image references
 	NoImgChecksum bool
+	// Directory of an OCI image layout to write the images to instead of pushing them to a registry
+	OCILayoutDir string
+	// Directory to write the repositories to as bare repositories instead of pushing them to a git server
+	GitDir string
 }
 
 // ZarfPublishOptions tracks the user-defined preferences during a package publish.
@@ -114,6 +167,10 @@ type ZarfGenerateOptions struct {
 	Version string
 	// Relative path to the chart in the git repository
 	GitPath string
+	// Local Helm chart, kustomization or directory of manifests to generate the package from
+	Path string
+	// Cluster namespace to capture the Helm releases, resources and images of
+	Namespace string
 	// Location where the finalized zarf.yaml will be placed
 	Output string
 }
@@ -130,6 +187,100 @@ type ZarfInitOptions struct {
 	ArtifactServer ArtifactServerInfo
 	// StorageClass of the k8s cluster Zarf is initializing
 	StorageClass string
+	// How the registry image is bootstrapped into the cluster
+	SeedStrategy string
+	// Registry proxy the registry image is pulled through with the pull-through seed strategy
+	SeedProxy string
+	// Directory on the nodes holding the pre-seeded images for the hostpath seed strategy
+	SeedHostPath string
+	// Host port of the injector for the hostport and hostpath seed strategies
+	SeedHostPort int
+	// Mirror registry the registry images are pulled from instead of the init package
+	SeedFrom string
+	// TLS certificate to serve the internal registry with
+	RegistryTLS TLSOptions
+	// TLS certificate to serve the internal git server with
+	GitServerTLS TLSOptions
+	// Resources of the internal registry
+	RegistryResources ServiceResources
+	// S3 bucket the internal registry stores its images in with the s3 registry storage
+	RegistryS3 S3Options
+	// Namespaces that are given their own unscoped token with the robot registry authentication
+	RegistryRobotNamespaces []string
+	// Label selector of the namespaces that receive the Zarf managed image pull secret
+	PullSecretSelector string
+	// Token endpoint of the OIDC provider the internal registry delegates authentication to with the oidc registry
+	// authentication
+	RegistryTokenAuth TokenAuthOptions
+	// Resources of the internal git server
+	GitServerResources ServiceResources
+	// Resources of the Zarf agent
+	AgentResources ServiceResources
+}
+
+// ServiceResources tracks the user-defined resources of a service Zarf deploys on init. Empty values keep the
+// defaults of the init package.
+type ServiceResources struct {
+	// CPU request of the service
+	CPURequest string
+	// CPU limit of the service
+	CPULimit string
+	// Memory request of the service
+	MemoryRequest string
+	// Memory limit of the service
+	MemoryLimit string
+	// Size of the persistent volume claim of the service
+	PVCSize string
+	// Storage class of the persistent volume claim of the service, defaults to the storage class of the cluster
+	StorageClass string
+}
+
+// S3Options tracks the user-defined S3 bucket the internal registry stores its images in.
+type S3Options struct {
+	// Name of the bucket
+	Bucket string
+	// Region of the bucket
+	Region string
+	// Endpoint of S3 compatible storage, empty for AWS S3
+	Endpoint string
+	// Access key of the bucket, empty to use the credentials of the environment of the registry
+	AccessKey string
+	// Secret key of the bucket
+	SecretKey string
+}
+
+// TokenAuthOptions tracks the user-defined token endpoint the internal registry delegates authentication to.
+type TokenAuthOptions struct {
+	// URL of the token endpoint
+	Realm string
+	// Name of the registry service the tokens are issued for
+	Service string
+	// Issuer of the tokens
+	Issuer string
+	// Path to the PEM encoded certificates of the keys the tokens are signed with
+	CertFile string
+}
+
+// Enabled returns true if a token endpoint was provided.
+func (o TokenAuthOptions) Enabled() bool {
+	return o.Realm != "" || o.Service != "" || o.Issuer != "" || o.CertFile != ""
+}
+
+// TLSOptions tracks the user-defined certificate a Zarf managed service is served with.
+type TLSOptions struct {
+	// Path to the PEM encoded certificate
+	CertFile string
+	// Path to the PEM encoded private key of the certificate
+	KeyFile string
+	// Path to the PEM encoded CA bundle that issued the certificate
+	CAFile string
+	// cert-manager issuer to request the certificate from in the form [Kind/]name
+	Issuer string
+}
+
+// Enabled returns true if a certificate or an issuer was provided.
+func (o TLSOptions) Enabled() bool {
+	return o.CertFile != "" || o.KeyFile != "" || o.Issuer != ""
 }
 
 // ZarfCreateOptions tracks the user-defined options used to create the package.
@@ -158,6 +309,42 @@ type ZarfCreateOptions struct {
 	RegistryOverrides map[string]string
 	// An optional variant that controls which components will be included in a package
 	Flavor string
+	// Paths to overlay files that patch the package definition before validation
+	Overlays []string
+	// Whether to reuse components assembled by previous builds from the Zarf cache
+	BuildCache bool
+	// URL of an S3 bucket or OCI repository that is shared as a cache between machines
+	RemoteCache string
+	// Whether to only read from the remote cache
+	RemoteCacheReadOnly bool
+	// Memory in MB image assembly should stay under
+	MaxMemoryMB int
+	// Temporary space in MB the package may need before create fails
+	MaxTempSpaceMB int
+	// Platforms of multi-platform images to keep in the package next to the package architecture
+	IncludePlatforms []string
+	// Severity at or above which unwaived image vulnerabilities fail package creation
+	FailOnSeverity string
+	// Path to a file of accepted vulnerabilities with expiry dates
+	VulnerabilityWaiversPath string
+	// Whether to allow kustomize remote bases that are not pinned to a tag or commit
+	AllowUnpinned bool
+	// Path to a file of lint rules the package is checked against
+	LintRulesPath string
+	// Address of a remote buildkitd the images of components are built on
+	BuildkitAddr string
+	// Label selector of the buildkitd pods in the cluster the images of components are built on
+	BuildkitPool string
+	// Namespace of the buildkitd pods of the pool
+	BuildkitPoolNamespace string
+	// CA certificate the remote buildkitd certificates are verified with
+	BuildkitTLSCACert string
+	// Client certificate presented to the remote buildkitd
+	BuildkitTLSCert string
+	// Key of the client certificate presented to the remote buildkitd
+	BuildkitTLSKey string
+	// Name the remote buildkitd certificates are verified for
+	BuildkitTLSServerName string
 	// Whether to create a skeleton package
 	IsSkeleton bool
 	// Whether to create a YOLO package